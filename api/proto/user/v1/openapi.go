@@ -0,0 +1,9 @@
+package userv1
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI v2 spec of the REST API that grpc-gateway serves
+// for UserService, generated from user.proto
+//
+//go:embed user.swagger.json
+var OpenAPI []byte
@@ -2,77 +2,63 @@ package main
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/modules/postgres"
-	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/passwordhash"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/storage"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/transport"
 	userhttp "github.com/yourusername/go-scaffolding/internal/user/adapters/http"
 	userPostgres "github.com/yourusername/go-scaffolding/internal/user/adapters/postgres"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
 	"github.com/yourusername/go-scaffolding/internal/user/ports"
 	userservice "github.com/yourusername/go-scaffolding/internal/user/service"
-	gormpostgres "gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
-func setupTestDB(t *testing.T) (*gorm.DB, func()) {
-	ctx := context.Background()
-
-	// Start PostgreSQL container
-	pgContainer, err := postgres.Run(ctx,
-		"postgres:16-alpine",
-		postgres.WithDatabase("testdb"),
-		postgres.WithUsername("testuser"),
-		postgres.WithPassword("testpass"),
-		testcontainers.WithWaitStrategy(
-			wait.ForLog("database system is ready to accept connections").
-				WithOccurrence(2).
-				WithStartupTimeout(60*time.Second)),
-	)
-	require.NoError(t, err, "Failed to start PostgreSQL container")
-
-	// Get connection string
-	connStr, err := pgContainer.ConnectionString(ctx, "sslmode=disable")
-	require.NoError(t, err, "Failed to get connection string")
-
-	// Connect to database
-	db, err := gorm.Open(gormpostgres.Open(connStr), &gorm.Config{})
-	require.NoError(t, err, "Failed to connect to database")
-
-	// Run migrations
-	err = db.AutoMigrate(&userPostgres.UserModel{})
-	require.NoError(t, err, "Failed to run migrations")
-
-	cleanup := func() {
-		sqlDB, _ := db.DB()
-		if sqlDB != nil {
-			sqlDB.Close()
-		}
-		if err := testcontainers.TerminateContainer(pgContainer); err != nil {
-			t.Logf("Failed to terminate container: %v", err)
-		}
-	}
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
 
-	return db, cleanup
+// usersTemplate holds the schema used by the user API tests
+var usersTemplate = pgtest.Template{
+	Name: "users",
+	Migrate: func(db *gorm.DB) error {
+		return db.AutoMigrate(&userPostgres.UserModel{}, &userPostgres.ErasureModel{}, &userPostgres.PreferencesModel{}, &userPostgres.PasswordModel{}, &userPostgres.EventModel{}, &userPostgres.LoginModel{})
+	},
 }
 
 func TestIntegration_UserAPI(t *testing.T) {
-	// Setup test database
-	db, cleanup := setupTestDB(t)
-	defer cleanup()
+	// Each test gets its own database cloned from the migrated template
+	db := pgtest.DB(t, usersTemplate)
 
 	// Create service and handler
-	repo := userPostgres.NewUserRepository(db)
-	usersvc := userservice.NewUserService(repo)
-	router := setupTestRouter(usersvc)
+	repo := userPostgres.NewUserRepository(db, idgen.UUIDv7{})
+	usersvc := userservice.NewUserService(repo, nil, clock.System{}, idgen.UUIDv7{}, userservice.Options{})
+	avatars := userservice.NewAvatarService(repo, storage.NewLocalStorage(config.LocalStorageConfig{Dir: t.TempDir()}), clock.System{}, idgen.UUIDv7{})
+	preferences, err := userservice.NewPreferencesService(repo, clock.System{}, domain.Preferences{
+		Locale:        "en",
+		Timezone:      "UTC",
+		Notifications: domain.NotificationPreferences{Email: true, Digest: domain.DigestWeekly},
+	})
+	require.NoError(t, err)
+	passwords, err := userservice.NewPasswordService(usersvc, repo, passwordhash.NewArgon2id(passwordhash.DefaultParams()), nil, clock.System{}, idgen.UUIDv7{}, domain.PasswordPolicy{MinLength: 12, MaxLength: 128}, userservice.LoginOptions{})
+	require.NoError(t, err)
+	router := setupTestRouter(usersvc, userservice.NewImportService(repo, clock.System{}, idgen.UUIDv7{}, lock.NewMemoryLocker()), avatars, preferences, userservice.NewActivityService(repo), passwords)
 
 	// Test data
 	userEmail := "integration@example.com"
@@ -124,6 +110,21 @@ func TestIntegration_UserAPI(t *testing.T) {
 		assert.Equal(t, userName, resp["name"])
 	})
 
+	t.Run("LookupUserByEmail", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/lookup?email="+url.QueryEscape(strings.ToUpper(userEmail)), nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err)
+
+		assert.Equal(t, userID, resp["id"])
+	})
+
 	t.Run("GetUserByEmail", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/users/email/"+userEmail, nil)
 		w := httptest.NewRecorder()
@@ -131,6 +132,7 @@ func TestIntegration_UserAPI(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Deprecation"))
 
 		var resp map[string]interface{}
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
@@ -162,6 +164,24 @@ func TestIntegration_UserAPI(t *testing.T) {
 		assert.Equal(t, updatedName, resp["name"])
 	})
 
+	t.Run("ListActivity", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+userID+"/activity", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var resp map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		require.NoError(t, err)
+
+		events := resp["events"].([]interface{})
+		require.Len(t, events, 2)
+		assert.Equal(t, "user.name_changed", events[0].(map[string]interface{})["type"])
+		assert.Equal(t, "user.created", events[1].(map[string]interface{})["type"])
+	})
+
 	t.Run("ListUsers", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/users?limit=10&offset=0", nil)
 		w := httptest.NewRecorder()
@@ -261,19 +281,30 @@ func TestIntegration_UserAPI(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		require.NoError(t, err)
 
-		assert.Equal(t, "limit cannot exceed 100", resp["error"])
+		assert.Equal(t, "invalid query parameters", resp["error"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"field": "limit", "message": "must be at most 100"},
+		}, resp["fields"])
 	})
 }
 
 func TestIntegration_DatabasePersistence(t *testing.T) {
-	// Setup test database
-	db, cleanup := setupTestDB(t)
-	defer cleanup()
+	// Each test gets its own database cloned from the migrated template
+	db := pgtest.DB(t, usersTemplate)
 
 	// Create service and handler
-	repo := userPostgres.NewUserRepository(db)
-	usersvc := userservice.NewUserService(repo)
-	router := setupTestRouter(usersvc)
+	repo := userPostgres.NewUserRepository(db, idgen.UUIDv7{})
+	usersvc := userservice.NewUserService(repo, nil, clock.System{}, idgen.UUIDv7{}, userservice.Options{})
+	avatars := userservice.NewAvatarService(repo, storage.NewLocalStorage(config.LocalStorageConfig{Dir: t.TempDir()}), clock.System{}, idgen.UUIDv7{})
+	preferences, err := userservice.NewPreferencesService(repo, clock.System{}, domain.Preferences{
+		Locale:        "en",
+		Timezone:      "UTC",
+		Notifications: domain.NotificationPreferences{Email: true, Digest: domain.DigestWeekly},
+	})
+	require.NoError(t, err)
+	passwords, err := userservice.NewPasswordService(usersvc, repo, passwordhash.NewArgon2id(passwordhash.DefaultParams()), nil, clock.System{}, idgen.UUIDv7{}, domain.PasswordPolicy{MinLength: 12, MaxLength: 128}, userservice.LoginOptions{})
+	require.NoError(t, err)
+	router := setupTestRouter(usersvc, userservice.NewImportService(repo, clock.System{}, idgen.UUIDv7{}, lock.NewMemoryLocker()), avatars, preferences, userservice.NewActivityService(repo), passwords)
 
 	t.Run("DataPersistsAcrossRequests", func(t *testing.T) {
 		// Create user
@@ -359,12 +390,59 @@ func TestIntegration_DatabasePersistence(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "Updated Name", model.Name)
 	})
+
+	t.Run("EmailChangesArePersistedCorrectly", func(t *testing.T) {
+		// Create two users
+		var userIDs []string
+		for _, email := range []string{"change@example.com", "taken@example.com"} {
+			body, _ := json.Marshal(map[string]string{"email": email, "name": "Email User"})
+
+			req := httptest.NewRequest(http.MethodPost, "/users", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+			require.Equal(t, http.StatusCreated, w.Code)
+
+			var createResp map[string]interface{}
+			json.Unmarshal(w.Body.Bytes(), &createResp)
+			userIDs = append(userIDs, createResp["id"].(string))
+		}
+
+		// Changing to another user's email (in any case) conflicts
+		body, _ := json.Marshal(map[string]string{"email": "Taken@Example.com"})
+
+		req := httptest.NewRequest(http.MethodPut, "/users/"+userIDs[0]+"/email", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+
+		// Changing to a free email is applied immediately
+		body, _ = json.Marshal(map[string]string{"email": "changed@example.com"})
+
+		req = httptest.NewRequest(http.MethodPut, "/users/"+userIDs[0]+"/email", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w = httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// Verify change persisted in database
+		var model userPostgres.UserModel
+		err := db.Where("id = ?", userIDs[0]).First(&model).Error
+		require.NoError(t, err)
+		assert.Equal(t, "changed@example.com", model.Email)
+	})
 }
 
-// setupTestRouter creates a Gin router with user routes for testing
-func setupTestRouter(userService ports.UserService) *gin.Engine {
+// setupTestRouter creates a Gin router with user routes for testing.
+// Background jobs are not exercised, so their routes are left without a
+// service.
+func setupTestRouter(userService ports.UserService, importer ports.UserImporter, avatars ports.UserAvatars, preferences ports.UserPreferences, activity ports.UserActivity, passwords ports.UserPasswords) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
-	userhttp.RegisterUserRoutes(router, userService)
+	userhttp.RegisterUserRoutes(transport.Gin(router), userService, importer, nil, avatars, preferences, activity, passwords, userhttp.RouteOptions{LegacyEmailRoute: true})
 	return router
 }
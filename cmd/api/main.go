@@ -3,14 +3,19 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/ipfilter"
 	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	wireproviders "github.com/yourusername/go-scaffolding/internal/wire"
 )
 
 const (
@@ -20,45 +25,132 @@ const (
 	serverWriteTimeout    = 10 * time.Second
 	serverIdleTimeout     = 120 * time.Second
 	serverShutdownTimeout = 15 * time.Second
+	workersStopTimeout    = 5 * time.Second
 )
 
 func main() {
+	seedData := flag.Bool("seed", false, "load the demo data at startup (refused in production)")
+	flag.Parse()
+
 	// Get config path from environment or use default
 	configPath := getConfigPath()
 
+	// The flag enables seeding as SEED_ENABLED does, before the
+	// configuration is loaded
+	if *seedData {
+		os.Setenv("SEED_ENABLED", "true")
+	}
+
 	// Initialize application with all dependencies via Wire
-	engine, cleanup, err := initializeApp(configPath)
+	app, cleanup, err := initializeApp(configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize application: %v\n", err)
 		os.Exit(1)
 	}
 	defer cleanup()
 
+	// Load the demo data before serving when seeding is enabled
+	if app.Seeder != nil {
+		logger := logger.New("info", os.Stdout)
+		report, err := app.Seed(app.Lifecycle.Context())
+		if err != nil {
+			cleanup()
+			fmt.Fprintf(os.Stderr, "Failed to seed demo data: %v\n", err)
+			os.Exit(1)
+		}
+		logger.Info().
+			Int("users_created", report.UsersCreated).
+			Int("organizations_created", report.OrganizationsCreated).
+			Int("members_added", report.MembersAdded).
+			Msg("Seeded demo data")
+	}
+
 	// Get port from environment or use default
 	port := getPort()
 
-	// Create HTTP server with timeouts
+	// Create HTTP server with timeouts. Requests run in the root context,
+	// so those still running when shutdown gives up on them are cancelled.
+	root := app.Lifecycle.Context()
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", port),
-		Handler:      engine,
+		Handler:      app.Engine,
 		ReadTimeout:  serverReadTimeout,
 		WriteTimeout: serverWriteTimeout,
 		IdleTimeout:  serverIdleTimeout,
+		TLSConfig:    app.TLS,
+		BaseContext:  func(net.Listener) context.Context { return root },
 	}
 
+	// Run background jobs, capture user changes and project them into the
+	// read model until shutdown; with leader election the jobs and change
+	// data capture only run on the leader
+	workersDone := make(chan struct{})
+	go func() {
+		defer close(workersDone)
+		app.RunWorkers(root)
+	}()
+
 	// Start server in a goroutine
 	go func() {
 		logger := logger.New("info", os.Stdout)
 		logger.Info().
 			Str("port", port).
 			Str("address", srv.Addr).
+			Bool("tls", srv.TLSConfig != nil).
 			Msg("Starting HTTP server")
 
-		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		// The certificates are in TLSConfig, so no files are passed
+		serve := srv.ListenAndServe
+		if srv.TLSConfig != nil {
+			serve = func() error { return srv.ListenAndServeTLS("", "") }
+		}
+		if err := serve(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatal().Err(err).Msg("HTTP server failed")
 		}
 	}()
 
+	// Warm up while the server listens: liveness passes meanwhile, and
+	// readiness reports healthy once the tasks have finished
+	go app.WarmUp(root)
+
+	// Start the gRPC server in a goroutine when it is enabled
+	if app.GRPC != nil {
+		go func() {
+			logger := logger.New("info", os.Stdout)
+			logger.Info().
+				Str("address", app.GRPC.Addr).
+				Msg("Starting gRPC server")
+
+			if err := app.GRPC.ListenAndServe(); err != nil {
+				logger.Fatal().Err(err).Msg("gRPC server failed")
+			}
+		}()
+	}
+
+	// Re-resolve database credentials and re-read the IP filter rules on
+	// SIGHUP, e.g. after a secret rotated or an allowlist changed
+	if app.Credentials != nil || app.IPFilter != nil {
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			logger := logger.New("info", os.Stdout)
+			for range reload {
+				if app.Credentials != nil {
+					if _, err := app.Credentials.Reload(context.Background()); err != nil {
+						logger.Error().Err(err).Msg("Failed to reload database credentials")
+					}
+				}
+				if app.IPFilter != nil {
+					if err := reloadIPFilter(app.IPFilter, configPath); err != nil {
+						logger.Error().Err(err).Msg("Failed to reload IP filter")
+						continue
+					}
+					logger.Info().Msg("Reloaded IP filter")
+				}
+			}
+		}()
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -71,8 +163,22 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
 	defer cancel()
 
+	if app.GRPC != nil {
+		app.GRPC.Shutdown(ctx)
+	}
+
 	if err := srv.Shutdown(ctx); err != nil {
-		logger.Fatal().Err(err).Msg("Server forced to shutdown")
+		logger.Error().Err(err).Msg("Requests still running after the shutdown timeout are cancelled")
+	}
+
+	// Cancel the requests left running, the background workers and the
+	// jobs requests started, along with their queries. Unfinished jobs are
+	// resumed by another instance.
+	app.Lifecycle.Cancel()
+	select {
+	case <-workersDone:
+	case <-time.After(workersStopTimeout):
+		logger.Error().Msg("Background workers did not stop in time")
 	}
 
 	logger.Info().Msg("Server exited")
@@ -93,3 +199,18 @@ func getPort() string {
 	}
 	return "8080"
 }
+
+// reloadIPFilter applies the IP filter rules of the config file
+func reloadIPFilter(filter *ipfilter.Filter, configPath string) error {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+
+	opts, err := wireproviders.IPFilterOptions(cfg.HTTP.IPFilter)
+	if err != nil {
+		return err
+	}
+	filter.Update(opts)
+	return nil
+}
@@ -4,13 +4,12 @@
 package main
 
 import (
-	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 	wireproviders "github.com/yourusername/go-scaffolding/internal/wire"
 )
 
 // initializeApp initializes the application with all dependencies
-func initializeApp(configPath string) (*gin.Engine, func(), error) {
+func initializeApp(configPath string) (*wireproviders.App, func(), error) {
 	wire.Build(wireproviders.ProviderSet)
 	return nil, nil, nil
 }
@@ -0,0 +1,212 @@
+// Command dump exports the users table as JSON Lines, one user per line,
+// and restores such a dump into the configured database. With --anonymize
+// emails, names and usernames are replaced by realistic fakes, the same
+// for a user in every dump, and avatars are dropped, so a production
+// dataset can be copied to staging:
+//
+//	APP_ENVIRONMENT=production go run ./cmd/dump --anonymize --out users.jsonl
+//	go run ./cmd/dump --restore users.jsonl
+//
+// Production data is only dumped anonymized, and nothing is restored into
+// production.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/yourusername/go-scaffolding/internal/anonymize"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/postgres"
+	"github.com/yourusername/go-scaffolding/internal/wire"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultConfigPath = "config.yaml"
+	batchSize         = 500
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to dump users: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	anonymized := flag.Bool("anonymize", false, "replace emails, names and usernames with fakes and drop avatars")
+	out := flag.String("out", "-", "file the dump is written to; - for stdout")
+	restore := flag.String("restore", "", "dump file to restore into the database instead of dumping; - for stdin")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := wire.ProvideConfig(getConfigPath())
+	if err != nil {
+		return err
+	}
+	if cfg.Storage.InMemory() {
+		return errors.New("the memory storage driver keeps no users to dump or restore")
+	}
+	production := cfg.App.Environment == "production"
+	if *restore != "" && production {
+		return errors.New("restoring is refused in production")
+	}
+	if *restore == "" && production && !*anonymized {
+		return errors.New("production data is only dumped with --anonymize")
+	}
+
+	keys, err := wire.ProvideFieldKeys(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Logs go to stderr, as the dump may go to stdout
+	log := logger.New(cfg.App.LogLevel, os.Stderr)
+	creds, cleanupCreds, err := wire.ProvideDatabaseCredentials(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer cleanupCreds()
+
+	db, cleanupDB, err := wire.ProvidePostgresDB(cfg, creds, nil, log)
+	if err != nil {
+		return err
+	}
+	defer cleanupDB()
+
+	if *restore != "" {
+		restored, err := restoreUsers(ctx, cfg, db, keys, *restore)
+		log.Info().Int("users", restored).Msg("Restored users")
+		return err
+	}
+
+	dumped, err := dumpUsers(ctx, cfg, db, keys, *out, *anonymized)
+	log.Info().Int("users", dumped).Bool("anonymized", *anonymized).Msg("Dumped users")
+	return err
+}
+
+// dumpUsers writes the users of every tenant to the file at path
+func dumpUsers(ctx context.Context, cfg *config.Config, db *gorm.DB, keys *fieldcrypt.Keyring, path string, anonymized bool) (int, error) {
+	w := io.Writer(os.Stdout)
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		w = f
+	}
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+
+	dump := func(ctx context.Context) (int, error) {
+		return postgres.DumpUsers(ctx, db, keys, batchSize, func(record postgres.UserRecord) error {
+			if anonymized {
+				record = anonymizeUser(record)
+			}
+			return encoder.Encode(record)
+		})
+	}
+
+	total := 0
+	var err error
+	if cfg.Tenancy.Enabled {
+		err = tenancy.ForEachTenant(ctx, db, cfg.Tenancy.Isolation, func(ctx context.Context) error {
+			dumped, err := dump(ctx)
+			total += dumped
+			return err
+		})
+	} else {
+		total, err = dump(ctx)
+	}
+	if err != nil {
+		return total, err
+	}
+	return total, buf.Flush()
+}
+
+// anonymizeUser replaces the personal data of record with the fakes of its
+// ID
+func anonymizeUser(record postgres.UserRecord) postgres.UserRecord {
+	person := anonymize.For(record.ID)
+	record.Email = person.Email()
+	record.Name = person.Name()
+	if record.Username != nil {
+		username := person.Username()
+		record.Username = &username
+	}
+	record.AvatarKey = nil
+	return record
+}
+
+// restoreUsers restores the dump at path in batches. With a schema per
+// tenant a batch holds the users of one tenant, restored into its schema.
+func restoreUsers(ctx context.Context, cfg *config.Config, db *gorm.DB, keys *fieldcrypt.Keyring, path string) (int, error) {
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		r = f
+	}
+	decoder := json.NewDecoder(bufio.NewReader(r))
+
+	total := 0
+	var batch []postgres.UserRecord
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		batchCtx := tenancy.AllTenants(ctx)
+		if cfg.Tenancy.Enabled && cfg.Tenancy.Isolation == tenancy.SchemaPerTenant {
+			batchCtx = tenancy.WithTenant(ctx, batch[0].TenantID)
+		}
+		restored, err := postgres.RestoreUsers(batchCtx, db, keys, batch)
+		total += restored
+		batch = batch[:0]
+		return err
+	}
+
+	for line := 1; ; line++ {
+		var record postgres.UserRecord
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return total, fmt.Errorf("user %d of the dump: %w", line, err)
+		}
+
+		if len(batch) == batchSize || (len(batch) > 0 && batch[0].TenantID != record.TenantID) {
+			if err := flush(); err != nil {
+				return total, err
+			}
+		}
+		batch = append(batch, record)
+	}
+	return total, flush()
+}
+
+// getConfigPath returns the config path from environment or default
+func getConfigPath() string {
+	if path := os.Getenv("CONFIG_PATH"); path != "" {
+		return path
+	}
+	return defaultConfigPath
+}
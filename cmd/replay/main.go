@@ -0,0 +1,65 @@
+// Command replay serves the fixtures recorded with http.fixtures.record as
+// a stub server, without a database, for reproducing a bug report or
+// developing a frontend against a fixed API:
+//
+//	go run ./cmd/replay --dir ./data/fixtures --addr :8081
+//
+// Captures saved from GET /admin/debug/captures can be replayed the same
+// way. See fixture.Replayer for how requests are matched.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fixture"
+)
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to replay fixtures: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	dir := flag.String("dir", "./data/fixtures", "directory of the fixtures")
+	addr := flag.String("addr", ":8081", "address the stub server listens on")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	entries, err := fixture.Load(*dir)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		return fmt.Errorf("no fixtures in %s", *dir)
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           fixture.NewReplayer(entries),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Replaying %d fixtures from %s on %s\n", len(entries), *dir, *addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Command rotate-keys re-encrypts the user emails, names and events stored
+// with an older key, or in plaintext, with the primary key of
+// users.encryption.keys. Run it after deploying a new primary key, and
+// before removing the old one from the keyring.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/postgres"
+	"github.com/yourusername/go-scaffolding/internal/wire"
+)
+
+const defaultConfigPath = "config.yaml"
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to rotate encryption keys: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	// Stop on interrupt; a later run picks up the rows not yet rewritten
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := wire.ProvideConfig(getConfigPath())
+	if err != nil {
+		return err
+	}
+	if cfg.Storage.InMemory() {
+		return errors.New("the memory storage driver keeps nothing to rotate")
+	}
+
+	keys, err := wire.ProvideFieldKeys(cfg)
+	if err != nil {
+		return err
+	}
+	if keys == nil {
+		return errors.New("users.encryption is disabled")
+	}
+
+	log := wire.ProvideLogger(cfg)
+	creds, cleanupCreds, err := wire.ProvideDatabaseCredentials(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer cleanupCreds()
+
+	db, cleanupDB, err := wire.ProvidePostgresDB(cfg, creds, nil, log)
+	if err != nil {
+		return err
+	}
+	defer cleanupDB()
+
+	rotate := func(ctx context.Context) (int, error) {
+		return postgres.RotateKeys(ctx, db, keys, cfg.Users.Encryption.RotationBatchSize)
+	}
+
+	total := 0
+	if cfg.Tenancy.Enabled {
+		err = tenancy.ForEachTenant(ctx, db, cfg.Tenancy.Isolation, func(ctx context.Context) error {
+			rotated, err := rotate(ctx)
+			total += rotated
+			return err
+		})
+	} else {
+		total, err = rotate(ctx)
+	}
+
+	log.Info().
+		Str("key_id", keys.PrimaryKeyID()).
+		Int("rows", total).
+		Msg("Re-encrypted user data")
+	return err
+}
+
+// getConfigPath returns the config path from environment or default
+func getConfigPath() string {
+	if path := os.Getenv("CONFIG_PATH"); path != "" {
+		return path
+	}
+	return defaultConfigPath
+}
@@ -0,0 +1,374 @@
+// Command scaffold generates code for the repository. Its init command
+// renames a project created from the scaffold, replacing the module path
+// and the application name:
+//
+//	go run ./cmd/scaffold init --module github.com/acme/myservice
+//
+// Its new-domain command adds a domain module laid out like internal/org,
+// with CRUD over a single entity:
+//
+//	go run ./cmd/scaffold new-domain product --fields=name:string,description:text,price:float,active:bool
+//
+// Field types are string (required, up to 255 characters), text, int,
+// float, bool and time (optional). Run it from the repository root, then
+// follow the steps it prints to wire the module into the application.
+//
+// Templates in .scaffold/templates override the defaults of the same name,
+// for example to generate chi handlers or sqlc repositories. The
+// eject-templates command copies the defaults there for editing:
+//
+//	go run ./cmd/scaffold eject-templates http_handlers.go.tmpl http_routes.go.tmpl
+//
+// Its db diff command compares the schema of the configured database with
+// the one the migrations build, and exits non-zero on drift, as a
+// pre-deploy gate:
+//
+//	CONFIG_PATH=config.production.yaml go run ./cmd/scaffold db diff
+//
+// Its db utc command rewrites the timestamp without time zone columns,
+// written in the local time of the server before times were stored in
+// UTC, as UTC. It lists the columns unless --apply is given, and must run
+// once, with the service stopped, before the version storing UTC starts:
+//
+//	CONFIG_PATH=config.production.yaml go run ./cmd/scaffold db utc --from=Europe/Berlin --apply
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/schemadiff"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/utcshift"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/scaffold"
+	"github.com/yourusername/go-scaffolding/internal/wire"
+)
+
+const usage = `Usage:
+  scaffold init --module=<path> [--name=<name>] [--root=<dir>]
+  scaffold new-domain <name> --fields=<name:type,...> [--plural=<name>] [--root=<dir>]
+  scaffold eject-templates [--root=<dir>] [template...]
+  scaffold db diff [--schema=<name>] [--root=<dir>]
+  scaffold db utc --from=<zone> [--apply] [--schema=<name>] [--root=<dir>]
+
+Commands:
+  init             Replace the module path and application name of the project
+  new-domain       Generate the domain, ports, mocks, service, PostgreSQL and
+                   HTTP adapters, Wire providers, migration and tests of a new
+                   domain
+  eject-templates  Copy the default templates, or the ones named, to
+                   .scaffold/templates to override them
+  db diff          Report the drift of the database schema from the one the
+                   migrations build, exiting non-zero if any; the database
+                   is configured by CONFIG_PATH or config.yaml under root
+  db utc           Rewrite the timestamp without time zone columns from the
+                   local time of zone to UTC, once, with the service
+                   stopped; lists the columns unless --apply is given
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "scaffold: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("a command is required")
+	}
+
+	switch args[0] {
+	case "init":
+		return initProject(args[1:], stdout)
+	case "new-domain":
+		return newDomain(args[1:], stdout)
+	case "eject-templates":
+		return ejectTemplates(args[1:], stdout)
+	case "db":
+		switch {
+		case len(args) >= 2 && args[1] == "diff":
+			return dbDiff(args[2:], stdout)
+		case len(args) >= 2 && args[1] == "utc":
+			return dbUTC(args[2:], stdout)
+		default:
+			fmt.Fprint(os.Stderr, usage)
+			return fmt.Errorf("unknown db command; use db diff or db utc")
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// initProject renames the project and prints the steps left to check it
+func initProject(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	module := fs.String("module", "", "module path of the project, such as github.com/acme/myservice")
+	name := fs.String("name", "", "application name, defaults to the last element of the module path")
+	root := fs.String("root", ".", "root directory of the repository")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *module == "" {
+		fs.Usage()
+		return fmt.Errorf("the module path is required")
+	}
+
+	changed, err := scaffold.Init(*root, *module, *name)
+	if err != nil {
+		return err
+	}
+	if len(changed) == 0 {
+		fmt.Fprintln(stdout, "the project already has this module path and name")
+		return nil
+	}
+
+	for _, path := range changed {
+		fmt.Fprintf(stdout, "updated %s\n", path)
+	}
+	fmt.Fprint(stdout, `
+Next steps:
+  1. Build and test the project: go build ./... && go test ./...
+  2. Regenerate the protobuf and GraphQL code, whose descriptors and
+     internal names still spell the old module path:
+     task proto:generate graphql:generate
+  3. Review the changes, then commit them.
+`)
+	return nil
+}
+
+// newDomain generates a domain module and prints the steps left to wire it
+func newDomain(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("new-domain", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	fields := fs.String("fields", "", "comma-separated name:type fields")
+	plural := fs.String("plural", "", "plural of the name, when not a regular English plural")
+	root := fs.String("root", ".", "root directory of the repository")
+
+	// The name may come before, between or after the flags
+	var name string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if name == "" && fs.NArg() > 0 {
+		name = fs.Arg(0)
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return err
+		}
+	}
+	if name == "" {
+		fs.Usage()
+		return fmt.Errorf("the domain name is required")
+	}
+
+	parsed, err := scaffold.ParseFields(*fields)
+	if err != nil {
+		return err
+	}
+	module, err := scaffold.ModulePath(*root)
+	if err != nil {
+		return err
+	}
+	domain, err := scaffold.NewDomain(name, *plural, module, parsed)
+	if err != nil {
+		return err
+	}
+	migration, err := scaffold.NextMigration(filepath.Join(*root, "migrations"))
+	if err != nil {
+		return err
+	}
+
+	templates, err := scaffold.LoadTemplates(*root)
+	if err != nil {
+		return err
+	}
+
+	files, err := scaffold.Render(templates, domain, migration)
+	if err != nil {
+		return err
+	}
+	if err := scaffold.Write(*root, files); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		fmt.Fprintf(stdout, "created %s\n", f.Path)
+	}
+	fmt.Fprintf(stdout, `
+Next steps:
+  1. Add %[1]sProviders to DomainSet in internal/wire/domains.go.
+  2. Add a %[2]sRoutes %[1]sRoutes parameter to ProvideDomainRoutes and add
+     it to the routes it returns.
+  3. Regenerate the injector: task wire:generate
+  4. Apply the migration: task migrate:up
+  5. Run the tests: go test ./internal/%[3]s/...
+`, domain.Name(), domain.Var(), domain.Package())
+	return nil
+}
+
+// ejectTemplates copies default templates to be overridden
+func ejectTemplates(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("eject-templates", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	root := fs.String("root", ".", "root directory of the repository")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	paths, err := scaffold.EjectTemplates(*root, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		fmt.Fprintf(stdout, "created %s\n", path)
+	}
+	fmt.Fprintf(stdout, "\nEdit them, then run new-domain: templates in %s replace the defaults of the same name.\n", scaffold.TemplatesDir)
+	return nil
+}
+
+// dbDiff prints the drift of the database schema from the migrations, and
+// fails when there is any
+func dbDiff(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("db diff", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	schema := fs.String("schema", "public", "database schema to compare, such as that of a tenant")
+	root := fs.String("root", ".", "root directory of the repository")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	db, cleanup, err := openDatabase(*root)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	expected, err := schemadiff.Expected(ctx, db, filepath.Join(*root, "migrations"))
+	if err != nil {
+		return err
+	}
+	actual, err := schemadiff.Inspect(ctx, db, *schema)
+	if err != nil {
+		return err
+	}
+
+	drift := schemadiff.Diff(expected, actual)
+	if len(drift) == 0 {
+		fmt.Fprintf(stdout, "schema %s matches the migrations\n", *schema)
+		return nil
+	}
+	for _, d := range drift {
+		fmt.Fprintln(stdout, d)
+	}
+	return fmt.Errorf("schema %s drifted from the migrations: %d differences", *schema, len(drift))
+}
+
+// dbUTC lists the timestamp without time zone columns, or with --apply
+// shifts them from the local time of a zone to UTC
+func dbUTC(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("db utc", flag.ContinueOnError)
+	fs.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	from := fs.String("from", "", "IANA time zone the server wrote local times in, such as Europe/Berlin")
+	apply := fs.Bool("apply", false, "shift the columns rather than only list them")
+	schema := fs.String("schema", "public", "database schema to shift, such as that of a tenant")
+	root := fs.String("root", ".", "root directory of the repository")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *from == "" {
+		fs.Usage()
+		return errors.New("--from is required")
+	}
+	loc, err := time.LoadLocation(*from)
+	if err != nil || *from == "Local" {
+		return fmt.Errorf("unknown time zone %q", *from)
+	}
+	if loc == time.UTC {
+		return fmt.Errorf("times written in %s are already in UTC; there is nothing to shift", *from)
+	}
+
+	db, cleanup, err := openDatabase(*root)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	ctx := context.Background()
+	columns, err := utcshift.Columns(ctx, db, *schema)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		fmt.Fprintf(stdout, "schema %s has no timestamp without time zone columns\n", *schema)
+		return nil
+	}
+
+	if !*apply {
+		for _, c := range columns {
+			fmt.Fprintf(stdout, "%s.%s\n", c.Table, c.Name)
+		}
+		fmt.Fprintf(stdout, "%d columns would shift from %s to UTC; rerun with --apply to shift them\n", len(columns), *from)
+		return nil
+	}
+
+	results, err := utcshift.Shift(ctx, db, *schema, *from, columns)
+	if err != nil {
+		return err
+	}
+	for _, r := range results {
+		fmt.Fprintf(stdout, "%s: %d rows shifted\n", r.Table, r.Rows)
+	}
+	fmt.Fprintf(stdout, "%d columns shifted from %s to UTC\n", len(columns), *from)
+	return nil
+}
+
+// openDatabase connects to the database configured by CONFIG_PATH, or by
+// config.yaml under root
+func openDatabase(root string) (*gorm.DB, func(), error) {
+	configPath := os.Getenv("CONFIG_PATH")
+	if configPath == "" {
+		configPath = filepath.Join(root, "config.yaml")
+	}
+	cfg, err := wire.ProvideConfig(configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cfg.Storage.InMemory() {
+		return nil, nil, errors.New("the memory storage driver has no database schema")
+	}
+
+	// Logs go to stderr, leaving the report on stdout
+	log := logger.New(cfg.App.LogLevel, os.Stderr)
+	creds, cleanupCreds, err := wire.ProvideDatabaseCredentials(cfg, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db, cleanupDB, err := wire.ProvidePostgresDB(cfg, creds, nil, log)
+	if err != nil {
+		cleanupCreds()
+		return nil, nil, err
+	}
+	return db, func() {
+		cleanupDB()
+		cleanupCreds()
+	}, nil
+}
@@ -0,0 +1,126 @@
+// Command seed loads demo users and organizations into the database: the
+// built-in demo data, or the YAML fixtures of --fixtures or seed.fixtures.
+// It is idempotent, so it can run again after fixtures are added, and is
+// refused in production. The API keys of the fixtures are configuration:
+// they are accepted by an API server started with --seed.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/yourusername/go-scaffolding/internal/seed"
+	"github.com/yourusername/go-scaffolding/internal/wire"
+)
+
+const defaultConfigPath = "config.yaml"
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to seed demo data: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	fixturesPath := flag.String("fixtures", "", "YAML fixture file (default seed.fixtures, or the built-in demo data)")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := wire.ProvideConfig(getConfigPath())
+	if err != nil {
+		return err
+	}
+	if err := seed.CheckEnvironment(cfg.App.Environment); err != nil {
+		return err
+	}
+	if cfg.Storage.InMemory() {
+		return errors.New("the memory storage driver keeps nothing once seeded; start the API server with --seed instead")
+	}
+	if *fixturesPath == "" {
+		*fixturesPath = cfg.Seed.Fixtures
+	}
+
+	fixtures, err := seed.Load(*fixturesPath)
+	if err != nil {
+		return err
+	}
+
+	log := wire.ProvideLogger(cfg)
+	creds, cleanupCreds, err := wire.ProvideDatabaseCredentials(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer cleanupCreds()
+
+	db, cleanupDB, err := wire.ProvidePostgresDB(cfg, creds, nil, log)
+	if err != nil {
+		return err
+	}
+	defer cleanupDB()
+
+	keys, err := wire.ProvideFieldKeys(cfg)
+	if err != nil {
+		return err
+	}
+	ids, err := wire.ProvideIDGenerator(cfg)
+	if err != nil {
+		return err
+	}
+	// Seeding only creates users, which no cache holds yet
+	repo, err := wire.ProvideUserRepository(cfg, db, keys, nil, wire.ProvideEventBus(cfg), wire.ProvideUserReadModel(cfg, db), ids, log)
+	if err != nil {
+		return err
+	}
+	mailer, err := wire.ProvideMailer(cfg, log)
+	if err != nil {
+		return err
+	}
+	validator, err := wire.ProvideEmailValidator(cfg, log)
+	if err != nil {
+		return err
+	}
+	breaches, err := wire.ProvideBreachedPasswords(cfg, log)
+	if err != nil {
+		return err
+	}
+
+	clock := wire.ProvideClock()
+	users := wire.ProvideUserService(cfg, repo, mailer, clock, ids, validator)
+	passwords, err := wire.ProvideUserPasswords(cfg, users, repo, wire.ProvidePasswordHasher(), breaches, nil, mailer, clock, ids, log)
+	if err != nil {
+		return err
+	}
+	orgs := wire.ProvideOrganizationService(
+		wire.ProvideOrganizationRepository(db),
+		wire.ProvideOrganizationUserDirectory(users),
+		wire.ProvideOrganizationClock(clock),
+		wire.ProvideOrganizationIDGenerator(ids),
+	)
+
+	report, err := seed.NewSeeder(fixtures, users, passwords, orgs).Seed(ctx)
+	log.Info().
+		Int("users_created", report.UsersCreated).
+		Int("users_existing", report.UsersExisting).
+		Int("organizations_created", report.OrganizationsCreated).
+		Int("organizations_existing", report.OrganizationsExisting).
+		Int("members_added", report.MembersAdded).
+		Int("members_existing", report.MembersExisting).
+		Msg("Seeded demo data")
+	return err
+}
+
+// getConfigPath returns the config path from environment or default
+func getConfigPath() string {
+	if path := os.Getenv("CONFIG_PATH"); path != "" {
+		return path
+	}
+	return defaultConfigPath
+}
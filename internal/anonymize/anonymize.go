@@ -0,0 +1,80 @@
+// Package anonymize replaces personal data with realistic fakes for
+// datasets copied out of production. Fakes are derived from a stable key,
+// such as the row ID, so the same row gets the same fake identity in every
+// dump and rows keep telling apart from each other, while nothing of the
+// original values is kept.
+package anonymize
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+)
+
+// EmailDomain is the domain of fake emails. It is reserved for
+// documentation, so mail sent to them by a staging environment goes
+// nowhere.
+const EmailDomain = "example.com"
+
+var firstNames = []string{
+	"ada", "alan", "amara", "ana", "arjun", "beatriz", "carlos", "chen",
+	"chloe", "daniel", "elena", "emeka", "fatima", "felix", "grace", "hana",
+	"hugo", "ines", "ivan", "jamal", "julia", "kai", "kenji", "laila",
+	"leo", "lucia", "maria", "mateo", "mei", "nadia", "noah", "olga",
+	"omar", "priya", "rafael", "sara", "sofia", "tomas", "yara", "zoe",
+}
+
+var lastNames = []string{
+	"adeyemi", "almeida", "andersen", "bauer", "becker", "costa", "cruz",
+	"dubois", "evans", "fischer", "garcia", "haddad", "hansen", "ito",
+	"jensen", "kim", "kowalski", "larsen", "lopez", "martin", "mendes",
+	"moreau", "nakamura", "nguyen", "novak", "okafor", "oliveira", "park",
+	"petrov", "quinn", "rossi", "santos", "schmidt", "silva", "singh",
+	"tanaka", "walker", "weber", "wong", "yilmaz",
+}
+
+// Person is a fake identity
+type Person struct {
+	first, last string
+	digest      [sha256.Size]byte
+}
+
+// For returns the fake identity of key. The same key always gets the same
+// identity.
+func For(key string) Person {
+	digest := sha256.Sum256([]byte("anonymize:" + key))
+	return Person{
+		first:  firstNames[binary.BigEndian.Uint32(digest[0:4])%uint32(len(firstNames))],
+		last:   lastNames[binary.BigEndian.Uint32(digest[4:8])%uint32(len(lastNames))],
+		digest: digest,
+	}
+}
+
+// Name returns the full name, such as "Maria Garcia"
+func (p Person) Name() string {
+	return title(p.first) + " " + title(p.last)
+}
+
+// Email returns an email at EmailDomain, such as
+// maria.garcia.3f9a1c02bd@example.com. Its suffix keeps the emails of
+// different keys apart.
+func (p Person) Email() string {
+	return p.first + "." + p.last + "." + p.suffix(5) + "@" + EmailDomain
+}
+
+// Username returns a username of at most 30 characters, such as
+// maria_garcia_3f9a1c02
+func (p Person) Username() string {
+	return p.first + "_" + p.last + "_" + p.suffix(4)
+}
+
+// suffix returns n bytes of the identity's digest in hex, taken after
+// those choosing the names
+func (p Person) suffix(n int) string {
+	return hex.EncodeToString(p.digest[8 : 8+n])
+}
+
+// title returns the name with its first letter in upper case
+func title(name string) string {
+	return string(name[0]-'a'+'A') + name[1:]
+}
@@ -0,0 +1,45 @@
+package anonymize
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFor_Stable(t *testing.T) {
+	a := For("9b2f6c1e-5d0a-4c3b-8e7f-1a2b3c4d5e6f")
+	b := For("9b2f6c1e-5d0a-4c3b-8e7f-1a2b3c4d5e6f")
+
+	assert.Equal(t, a.Name(), b.Name())
+	assert.Equal(t, a.Email(), b.Email())
+	assert.Equal(t, a.Username(), b.Username())
+}
+
+func TestFor_Distinct(t *testing.T) {
+	emails := map[string]bool{}
+	usernames := map[string]bool{}
+	for i := range 10000 {
+		p := For(strings.Repeat("x", i))
+		emails[p.Email()] = true
+		usernames[p.Username()] = true
+	}
+
+	assert.Len(t, emails, 10000)
+	assert.Len(t, usernames, 10000)
+}
+
+func TestPerson_Formats(t *testing.T) {
+	emailRegex := regexp.MustCompile(`^[a-z]+\.[a-z]+\.[0-9a-f]{10}@example\.com$`)
+	usernameRegex := regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*$`)
+	nameRegex := regexp.MustCompile(`^[A-Z][a-z]+ [A-Z][a-z]+$`)
+
+	for _, key := range []string{"", "a", "user-1", "9b2f6c1e-5d0a-4c3b-8e7f-1a2b3c4d5e6f"} {
+		p := For(key)
+		assert.Regexp(t, emailRegex, p.Email())
+		assert.Regexp(t, usernameRegex, p.Username())
+		assert.LessOrEqual(t, len(p.Username()), 30)
+		assert.Regexp(t, nameRegex, p.Name())
+	}
+}
@@ -0,0 +1,242 @@
+// Package apptest starts the whole application behind a test HTTP server so
+// black-box tests can exercise it through its public API. The application
+// is assembled from the same providers as cmd/api, with the mailer and
+// clock replaced by fakes the test controls.
+package apptest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idempotency"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/quota"
+	orgpostgres "github.com/yourusername/go-scaffolding/internal/org/adapters/postgres"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/jobs"
+	userpostgres "github.com/yourusername/go-scaffolding/internal/user/adapters/postgres"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/readmodel"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/stats"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/wire"
+)
+
+// Backend selects where the application stores its data
+type Backend string
+
+const (
+	// BackendMemory keeps data in memory; organization routes are disabled
+	BackendMemory Backend = "memory"
+
+	// BackendPostgres gives every app a database of its own from pgtest.
+	// The test package must run its tests through pgtest.Run.
+	BackendPostgres Backend = "postgres"
+)
+
+// DefaultNow is the time the fake clock starts at unless Options.Now is set
+var DefaultNow = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// Options configures a test application
+type Options struct {
+	// Backend selects the repositories; BackendMemory when empty
+	Backend Backend
+
+	// Now is the fake clock's starting time; DefaultNow when zero
+	Now time.Time
+
+	// Configure adjusts the configuration before the application is built
+	Configure func(cfg *config.Config)
+}
+
+// App is a running test application
+type App struct {
+	// URL is the base URL of the server, without a trailing slash
+	URL string
+
+	// Client sends requests to the server
+	Client *http.Client
+
+	// Config is the configuration the application was built with
+	Config *config.Config
+
+	// Clock is the time seen by the application
+	Clock *clock.Fake
+
+	// Mailer captures every email the application sends
+	Mailer *Mailer
+
+	// Users is the repository the application stores users in, for tests
+	// setting up data its API cannot create, such as users with given IDs
+	Users ports.UserRepository
+
+	// Passwords logs users in, which the API has no endpoint for
+	Passwords ports.UserPasswords
+}
+
+// schema holds every table the application uses
+var schema = pgtest.Template{
+	Name: "app",
+	Migrate: func(db *gorm.DB) error {
+		return db.AutoMigrate(
+			&userpostgres.UserModel{},
+			&userpostgres.ErasureModel{},
+			&userpostgres.PreferencesModel{},
+			&userpostgres.PasswordModel{},
+			&userpostgres.EventModel{},
+			&userpostgres.LoginModel{},
+			&readmodel.Model{},
+			&jobs.Model{},
+			// The statistics views, as plain tables
+			&stats.DailySignupsModel{},
+			&stats.StatusCountsModel{},
+			&orgpostgres.OrganizationModel{},
+			&orgpostgres.MembershipModel{},
+			&orgpostgres.InvitationModel{},
+			&idempotency.KeyModel{},
+			&quota.CountModel{},
+		)
+	},
+}
+
+// StartTestApp builds the application and serves it until the test ends.
+// Background jobs are not started.
+func StartTestApp(t *testing.T, opts Options) *App {
+	t.Helper()
+
+	if opts.Backend == "" {
+		opts.Backend = BackendMemory
+	}
+	if opts.Now.IsZero() {
+		opts.Now = DefaultNow
+	}
+
+	cfg := loadConfig(t, opts)
+	if opts.Configure != nil {
+		opts.Configure(cfg)
+	}
+
+	var db *gorm.DB
+	if opts.Backend == BackendPostgres {
+		db = pgtest.DB(t, schema)
+	}
+
+	gin.SetMode(gin.TestMode)
+	app := &App{
+		Config: cfg,
+		Clock:  clock.NewFake(opts.Now),
+		Mailer: &Mailer{},
+	}
+
+	engine := newEngine(t, cfg, db, app)
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+
+	app.URL = server.URL
+	app.Client = server.Client()
+	return app
+}
+
+// loadConfig loads the default configuration for the chosen backend, with
+// uploads and objects kept in a temporary directory
+func loadConfig(t *testing.T, opts Options) *config.Config {
+	t.Helper()
+
+	driver := "local"
+	if opts.Backend == BackendMemory {
+		driver = "memory"
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := fmt.Sprintf("app:\n  environment: test\n  log_level: error\nstorage:\n  driver: %s\n  local:\n    dir: %s\n  objects:\n    local:\n      dir: %s\n", driver, filepath.Join(dir, "uploads"), filepath.Join(dir, "objects"))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	cfg, err := config.Load(path)
+	require.NoError(t, err, "Failed to load test configuration")
+	return cfg
+}
+
+// newEngine assembles the router the same way cmd/api does, with the
+// app's fakes in place of the mailer and clock
+func newEngine(t *testing.T, cfg *config.Config, db *gorm.DB, app *App) *gin.Engine {
+	t.Helper()
+
+	ids, err := wire.ProvideIDGenerator(cfg)
+	require.NoError(t, err)
+	fileStorage, err := wire.ProvideFileStorage(cfg)
+	require.NoError(t, err)
+	objectStorage, err := wire.ProvideObjectStorage(cfg)
+	require.NoError(t, err)
+
+	keys, err := wire.ProvideFieldKeys(cfg)
+	require.NoError(t, err)
+	bus := wire.ProvideEventBus(cfg)
+	userCache, cleanupCache, err := wire.ProvideCache(cfg, nil, nil)
+	require.NoError(t, err)
+	t.Cleanup(cleanupCache)
+	userRepo, err := wire.ProvideUserRepository(cfg, db, keys, userCache, bus, wire.ProvideUserReadModel(cfg, db), ids, wire.ProvideLogger(cfg))
+	require.NoError(t, err)
+	app.Users = userRepo
+	validator, err := wire.ProvideEmailValidator(cfg, wire.ProvideLogger(cfg))
+	require.NoError(t, err)
+	userService := wire.ProvideUserService(cfg, userRepo, app.Mailer, app.Clock, ids, validator)
+	locker := wire.ProvideLocker(cfg, db, nil, wire.ProvideLogger(cfg))
+	userImporter := wire.ProvideUserImporter(userRepo, app.Clock, ids, wire.ProvideUserLocker(locker))
+	root, cancelRoot := wire.ProvideLifecycle()
+	t.Cleanup(cancelRoot)
+	userJobs := wire.ProvideUserJobs(cfg, wire.ProvideJobRepository(cfg, db), userRepo, wire.ProvideUserObjectStorage(objectStorage), app.Clock, ids, wire.ProvideUserLocker(locker), root, wire.ProvideLogger(cfg))
+	userAvatars := wire.ProvideUserAvatars(userRepo, fileStorage, app.Clock, ids)
+	userPreferences, err := wire.ProvideUserPreferences(cfg, userRepo, app.Clock)
+	require.NoError(t, err)
+	userActivity := wire.ProvideUserActivity(userRepo)
+	breaches, err := wire.ProvideBreachedPasswords(cfg, wire.ProvideLogger(cfg))
+	require.NoError(t, err)
+	loginRisk, err := wire.ProvideLoginRiskEvaluator(cfg)
+	require.NoError(t, err)
+	userPasswords, err := wire.ProvideUserPasswords(cfg, userService, userRepo, wire.ProvidePasswordHasher(), breaches, loginRisk, app.Mailer, app.Clock, ids, wire.ProvideLogger(cfg))
+	require.NoError(t, err)
+	app.Passwords = userPasswords
+	userStats := wire.ProvideUserStatistics(cfg, db, userRepo, app.Clock)
+
+	orgRepo := wire.ProvideOrganizationRepository(db)
+	directory := wire.ProvideOrganizationUserDirectory(userService)
+	orgService := wire.ProvideOrganizationService(orgRepo, directory, app.Clock, ids)
+	invitations := wire.ProvideInvitationService(cfg, wire.ProvideInvitationRepository(db), orgRepo, directory, app.Mailer, wire.ProvideTransactor(db), app.Clock, ids)
+	domainRoutes := wire.ProvideDomainRoutes(wire.ProvideOrganizationRoutes(cfg, orgService, invitations, app.Clock))
+
+	quotas := wire.ProvideQuotaTracker(cfg, db, app.Clock)
+	capturer := wire.ProvideCapturer(cfg, wire.ProvideLogger(cfg), app.Clock)
+	recorder, err := wire.ProvideFixtureRecorder(cfg, wire.ProvideLogger(cfg), app.Clock)
+	require.NoError(t, err)
+	clientIPs, err := wire.ProvideClientIPResolver(cfg)
+	require.NoError(t, err)
+	ipFilter, err := wire.ProvideIPFilter(cfg, wire.ProvideLogger(cfg))
+	require.NoError(t, err)
+	adminRoutes, err := wire.ProvideAdminRoutes(cfg, wire.ProvideLogger(cfg), userService, userAvatars, userActivity, db, nil, nil, quotas, capturer)
+	require.NoError(t, err)
+
+	gatewayRoutes, err := wire.ProvideGatewayRoutes(cfg, userService)
+	require.NoError(t, err)
+
+	// cmd/api warms up while the server listens; tests serve once warmed up
+	warmer, err := wire.ProvideWarmer(cfg, wire.ProvideLogger(cfg), db, nil, userRepo)
+	require.NoError(t, err)
+	if warmer != nil {
+		require.NoError(t, warmer.Run(root.Context()))
+	}
+
+	engine, err := wire.ProvideGinEngine(cfg, userService, userImporter, userJobs, userAvatars, userPreferences, userActivity, userPasswords, userStats, domainRoutes, fileStorage, objectStorage, wire.ProvideIdempotencyStore(cfg, db), quotas, capturer, recorder, clientIPs, wire.ProvideClientIdentities(cfg), ipFilter, wire.ProvideHealthChecker(db, nil, nil, nil, nil, warmer), adminRoutes, gatewayRoutes, wire.ProvideGraphQLRoutes(cfg, userService, bus), nil, bus, otel.GetTracerProvider(), nil, wire.ProvideLogger(cfg))
+	require.NoError(t, err)
+	return engine
+}
@@ -0,0 +1,1566 @@
+package apptest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fixture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/storage"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// send makes a JSON request against app and decodes the response into out
+func send(t *testing.T, app *App, method, path string, body, out any) int {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(method, app.URL+path, bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.Client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+// testAdminToken authorizes sendAsAdmin on apps configured with it
+const testAdminToken = "admin-secret"
+
+// sendAsAdmin is send with the admin token
+func sendAsAdmin(t *testing.T, app *App, method, path string, body, out any) int {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(method, app.URL+path, bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+testAdminToken)
+
+	resp, err := app.Client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestStartTestApp_ServesHealth(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	resp, err := app.Client.Get(app.URL + "/health/ready")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, app.Config.Storage.InMemory())
+	assert.Equal(t, DefaultNow, app.Clock.Now())
+}
+
+func TestStartTestApp_IsolatesApps(t *testing.T) {
+	first := StartTestApp(t, Options{})
+	second := StartTestApp(t, Options{})
+
+	status := send(t, first, http.MethodPost, "/users", map[string]string{"email": "isolated@example.com", "name": "Isolated"}, nil)
+	require.Equal(t, http.StatusCreated, status)
+
+	var users struct {
+		Users []map[string]any `json:"users"`
+	}
+	status = send(t, second, http.MethodGet, "/users", nil, &users)
+	require.Equal(t, http.StatusOK, status)
+	assert.Empty(t, users.Users)
+}
+
+func TestStartTestApp_EmailChangeUsesFakes(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.RequireEmailVerification = true
+			cfg.Users.EmailChangeTokenTTL = time.Hour
+		},
+	})
+
+	var user map[string]any
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "old@example.com", "name": "Mover"}, &user)
+	require.Equal(t, http.StatusCreated, status)
+	id := user["id"].(string)
+
+	changeEmail := func() string {
+		status := send(t, app, http.MethodPut, "/users/"+id+"/email", map[string]string{"email": "new@example.com"}, nil)
+		require.Equal(t, http.StatusAccepted, status)
+
+		msg, ok := app.Mailer.Last("new@example.com")
+		require.True(t, ok, "confirmation email is captured")
+		lines := strings.Split(strings.TrimSpace(msg.Body), "\n")
+		return strings.TrimSpace(lines[len(lines)-1])
+	}
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		token := changeEmail()
+		app.Clock.Advance(2 * time.Hour)
+
+		status := send(t, app, http.MethodPost, "/users/"+id+"/email/confirm", map[string]string{"token": token}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("fresh token confirms the change", func(t *testing.T) {
+		token := changeEmail()
+
+		var confirmed map[string]any
+		status := send(t, app, http.MethodPost, "/users/"+id+"/email/confirm", map[string]string{"token": token}, &confirmed)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "new@example.com", confirmed["email"])
+	})
+}
+
+func TestStartTestApp_EmailValidation(t *testing.T) {
+	validation := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deliverable := r.URL.Query().Get("email") != "nobody@example.com"
+		_ = json.NewEncoder(w).Encode(map[string]any{"deliverable": deliverable, "reason": "mailbox does not exist"})
+	}))
+	defer validation.Close()
+
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.EmailValidation.Enabled = true
+			cfg.Users.EmailValidation.BaseURL = validation.URL
+		},
+	})
+
+	var body map[string]any
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "nobody@example.com", "name": "Nobody"}, &body)
+	assert.Equal(t, http.StatusUnprocessableEntity, status)
+	assert.Equal(t, "email address cannot receive mail: mailbox does not exist", body["error"])
+
+	status = send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, nil)
+	assert.Equal(t, http.StatusCreated, status)
+}
+
+func TestStartTestApp_StrictEmailValidation(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.EmailValidation.Mode = "strict"
+		},
+	})
+
+	var body map[string]any
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "jöhn@example.com", "name": "John"}, &body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "invalid email format: local part must be a dot-separated list of ASCII atoms", body["error"])
+
+	status = send(t, app, http.MethodPost, "/users", map[string]string{"email": strings.Repeat("a", 65) + "@example.com", "name": "Long"}, &body)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status = send(t, app, http.MethodPost, "/users", map[string]string{"email": "o'brien@example.com", "name": "Jane"}, nil)
+	assert.Equal(t, http.StatusCreated, status)
+}
+
+func TestStartTestApp_Gateway(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.HTTP.Gateway.Enabled = true
+		},
+	})
+
+	var created map[string]any
+	status := send(t, app, http.MethodPost, "/v1/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &created)
+	require.Equal(t, http.StatusOK, status)
+	id, _ := created["id"].(string)
+	require.NotEmpty(t, id)
+	assert.Contains(t, created, "created_at", "fields keep their proto names")
+
+	// Both transports serve the same users
+	var handwritten map[string]any
+	status = send(t, app, http.MethodGet, "/users/"+id, nil, &handwritten)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "jane@example.com", handwritten["email"])
+
+	var updated map[string]any
+	status = send(t, app, http.MethodPut, "/v1/users/"+id, map[string]string{"name": "Jane Doe"}, &updated)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "Jane Doe", updated["name"])
+
+	var failure map[string]any
+	status = send(t, app, http.MethodPost, "/v1/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &failure)
+	assert.Equal(t, http.StatusConflict, status)
+	assert.Equal(t, "email already exists", failure["message"])
+
+	// Requests the spec rejects get problem details before the gateway
+	var invalid map[string]any
+	status = send(t, app, http.MethodPost, "/v1/users", map[string]string{"email": "joe@example.com"}, &invalid)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "VALIDATION_FAILED", invalid["code"])
+	assert.Equal(t, []any{map[string]any{"field": "name", "message": `property "name" is missing`}}, invalid["fields"])
+
+	status = send(t, app, http.MethodDelete, "/v1/users/"+id, nil, nil)
+	assert.Equal(t, http.StatusOK, status)
+	status = send(t, app, http.MethodGet, "/v1/users/"+id, nil, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	var spec map[string]any
+	status = send(t, app, http.MethodGet, "/v1/openapi.json", nil, &spec)
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, spec["paths"], "/v1/users/{id}")
+}
+
+func TestStartTestApp_GatewayDisabledByDefault(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	status := send(t, app, http.MethodGet, "/v1/openapi.json", nil, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestStartTestApp_GraphQL(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.HTTP.GraphQL.Enabled = true
+		},
+	})
+
+	var created struct {
+		Data struct {
+			CreateUser struct{ ID, Status string }
+		}
+	}
+	status := send(t, app, http.MethodPost, "/graphql", map[string]string{
+		"query": `mutation { createUser(input: {email: "jane@example.com", name: "Jane"}) { id status } }`,
+	}, &created)
+	require.Equal(t, http.StatusOK, status)
+	id := created.Data.CreateUser.ID
+	require.NotEmpty(t, id)
+	assert.Equal(t, "ACTIVE", created.Data.CreateUser.Status)
+
+	// The REST routes serve the same users
+	var handwritten map[string]any
+	status = send(t, app, http.MethodGet, "/users/"+id, nil, &handwritten)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "jane@example.com", handwritten["email"])
+
+	var listed struct {
+		Data struct {
+			Users struct {
+				Edges []struct{ Node struct{ Email string } }
+			}
+		}
+	}
+	status = send(t, app, http.MethodPost, "/graphql", map[string]string{
+		"query": `{ users(first: 10) { edges { node { email } } } }`,
+	}, &listed)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, listed.Data.Users.Edges, 1)
+	assert.Equal(t, "jane@example.com", listed.Data.Users.Edges[0].Node.Email)
+
+	resp, err := app.Client.Get(app.URL + "/graphql/playground")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "the playground is served outside production")
+}
+
+func TestStartTestApp_GraphQLDisabledByDefault(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	status := send(t, app, http.MethodPost, "/graphql", map[string]string{"query": "{ __typename }"}, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+// sse is an event read from GET /users/events
+type sse struct {
+	ID, Event, Data string
+}
+
+// streamEvents opens GET /users/events, resuming after lastEventID when it
+// is not empty. The stream is closed by stop or when the test ends.
+func streamEvents(t *testing.T, app *App, lastEventID string) (events <-chan sse, stop func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, app.URL+"/users/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := app.Client.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	out := make(chan sse, 16)
+	go func() {
+		defer close(out)
+		defer resp.Body.Close()
+
+		var event sse
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			field, value, _ := strings.Cut(scanner.Text(), ": ")
+			switch field {
+			case "id":
+				event.ID = value
+			case "event":
+				event.Event = value
+			case "data":
+				event.Data = value
+			case "":
+				if event.Event != "" {
+					out <- event
+				}
+				event = sse{}
+			}
+		}
+	}()
+	return out, cancel
+}
+
+// nextEvent returns the next event of the stream, failing the test when
+// none arrives
+func nextEvent(t *testing.T, events <-chan sse) sse {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "stream closed")
+		return event
+	case <-time.After(2 * time.Second):
+		require.FailNow(t, "no event received")
+		return sse{}
+	}
+}
+
+func TestStartTestApp_EventStream(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.Events.Stream.Enabled = true
+			cfg.Users.Events.Stream.MaxReplay = 2
+		},
+	})
+
+	events, stop := streamEvents(t, app, "")
+
+	var user struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &user)
+	require.Equal(t, http.StatusCreated, status)
+
+	created := nextEvent(t, events)
+	assert.Equal(t, "user.created", created.Event)
+	var data struct {
+		UserID string `json:"user_id"`
+		Type   string
+	}
+	require.NoError(t, json.Unmarshal([]byte(created.Data), &data))
+	assert.Equal(t, user.ID, data.UserID)
+	assert.Equal(t, "user.created", data.Type)
+	stop()
+
+	// Changes made while disconnected are replayed, oldest first
+	app.Clock.Advance(time.Second)
+	status = send(t, app, http.MethodPut, "/users/"+user.ID, map[string]string{"name": "Jane Doe"}, nil)
+	require.Equal(t, http.StatusOK, status)
+	app.Clock.Advance(time.Second)
+	status = send(t, app, http.MethodPost, "/users/"+user.ID+"/suspend", nil, nil)
+	require.Equal(t, http.StatusOK, status)
+
+	events, stop = streamEvents(t, app, created.ID)
+	assert.Equal(t, "user.name_changed", nextEvent(t, events).Event)
+	suspended := nextEvent(t, events)
+	assert.Equal(t, "user.status_changed", suspended.Event)
+	stop()
+
+	t.Run("tells clients too far behind to reset", func(t *testing.T) {
+		app.Clock.Advance(time.Second)
+		status := send(t, app, http.MethodPost, "/users/"+user.ID+"/activate", nil, nil)
+		require.Equal(t, http.StatusOK, status)
+
+		// Three changes follow created, more than max_replay
+		events, _ := streamEvents(t, app, created.ID)
+		reset := nextEvent(t, events)
+		assert.Equal(t, "reset", reset.Event)
+
+		// Resuming after the reset replays nothing and follows new changes
+		events, _ = streamEvents(t, app, reset.ID)
+		app.Clock.Advance(time.Second)
+		status = send(t, app, http.MethodPut, "/users/"+user.ID, map[string]string{"name": "Jane Roe"}, nil)
+		require.Equal(t, http.StatusOK, status)
+		renamed := nextEvent(t, events)
+		assert.Equal(t, "user.name_changed", renamed.Event)
+		assert.Contains(t, renamed.Data, "Jane Roe")
+	})
+
+	t.Run("rejects an invalid Last-Event-ID", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, app.URL+"/users/events", nil)
+		require.NoError(t, err)
+		req.Header.Set("Last-Event-ID", "not-a-cursor")
+
+		resp, err := app.Client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestStartTestApp_EventStreamDisabledByDefault(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	// Without the stream route, "events" is taken for a malformed user ID
+	var body struct{ Code string }
+	status := send(t, app, http.MethodGet, "/users/events", nil, &body)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "INVALID_PATH_PARAMETER", body.Code)
+}
+
+func TestStartTestApp_StatsDisabledByDefault(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	status := send(t, app, http.MethodGet, "/stats/users", nil, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestStartTestApp_Stats(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.Stats.Enabled = true
+			cfg.Users.Stats.MaxDays = 14
+		},
+	})
+
+	for _, email := range []string{"ada@example.com", "grace@example.com"} {
+		status := send(t, app, http.MethodPost, "/users", map[string]string{"email": email, "name": "Test User"}, nil)
+		require.Equal(t, http.StatusCreated, status)
+	}
+
+	resp, body := get(t, app, "/stats/users?days=7", "application/json")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get("Cache-Control"), "private")
+
+	var stats struct {
+		Total         int            `json:"total"`
+		Active        int            `json:"active"`
+		ByStatus      map[string]int `json:"by_status"`
+		SignupsPerDay []struct {
+			Start   string `json:"start"`
+			Signups int    `json:"signups"`
+		} `json:"signups_per_day"`
+	}
+	require.NoError(t, json.Unmarshal(body, &stats))
+	assert.Equal(t, 2, stats.Total)
+	assert.Equal(t, 2, stats.Active)
+	assert.Equal(t, map[string]int{"active": 2, "suspended": 0, "deactivated": 0}, stats.ByStatus)
+	require.Len(t, stats.SignupsPerDay, 7)
+	assert.Equal(t, app.Clock.Now().Format(time.DateOnly), stats.SignupsPerDay[6].Start)
+	assert.Equal(t, 2, stats.SignupsPerDay[6].Signups)
+
+	// Unchanged statistics are revalidated without a body
+	req, err := http.NewRequest(http.MethodGet, app.URL+"/stats/users?days=7", nil)
+	require.NoError(t, err)
+	req.Header.Set("If-None-Match", resp.Header.Get("ETag"))
+	revalidated, err := app.Client.Do(req)
+	require.NoError(t, err)
+	revalidated.Body.Close()
+	assert.Equal(t, http.StatusNotModified, revalidated.StatusCode)
+
+	status := send(t, app, http.MethodGet, "/stats/users?days=15", nil, nil)
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+// get requests path from app with the Accept header and returns the
+// response with its body
+func get(t *testing.T, app *App, path, accept string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, app.URL+path, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", accept)
+
+	resp, err := app.Client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestStartTestApp_SignedObjectURLs(t *testing.T) {
+	var objectsCfg config.LocalObjectStorageConfig
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Storage.Objects.Local.SigningKey = "secret"
+			objectsCfg = cfg.Storage.Objects.Local
+		},
+	})
+
+	// URLs signed by another process sharing the key are accepted
+	objects, err := storage.NewLocalObjectStorage(objectsCfg)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	putURL, err := objects.SignURL(ctx, "exports/users.csv", http.MethodPut, time.Minute)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPut, app.URL+putURL, strings.NewReader("id,email"))
+	require.NoError(t, err)
+	resp, err := app.Client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	getURL, err := objects.SignURL(ctx, "exports/users.csv", http.MethodGet, time.Minute)
+	require.NoError(t, err)
+	resp, body := get(t, app, getURL, "*/*")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "id,email", string(body))
+
+	resp, _ = get(t, app, "/objects/exports/users.csv", "*/*")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "objects are private")
+}
+
+func TestStartTestApp_ContentNegotiation(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.ResponseFormats = []string{"xml", "msgpack"}
+		},
+	})
+
+	var user struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &user)
+	require.Equal(t, http.StatusCreated, status)
+
+	t.Run("xml", func(t *testing.T) {
+		resp, body := get(t, app, "/users/"+user.ID, "application/xml")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/xml; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "Accept", resp.Header.Get("Vary"))
+
+		var decoded struct {
+			XMLName   xml.Name
+			ID        string    `xml:"id"`
+			Email     string    `xml:"email"`
+			CreatedAt time.Time `xml:"created_at"`
+		}
+		require.NoError(t, xml.Unmarshal(body, &decoded))
+		assert.Equal(t, "user", decoded.XMLName.Local)
+		assert.Equal(t, user.ID, decoded.ID)
+		assert.Equal(t, "jane@example.com", decoded.Email)
+		assert.Equal(t, DefaultNow, decoded.CreatedAt.UTC())
+
+		resp, body = get(t, app, "/users/"+user.ID+"/activity", "text/xml")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), `<data><field name="email">jane@example.com</field><field name="name">Jane</field></data>`)
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		resp, body := get(t, app, "/users/"+user.ID, "application/msgpack")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/msgpack", resp.Header.Get("Content-Type"))
+
+		handle := &codec.MsgpackHandle{}
+		handle.RawToString = true
+		var decoded map[string]any
+		require.NoError(t, codec.NewDecoderBytes(body, handle).Decode(&decoded))
+		assert.Equal(t, user.ID, decoded["id"])
+		assert.Equal(t, "jane@example.com", decoded["email"])
+		assert.Equal(t, DefaultNow, decoded["created_at"], "times use the timestamp extension")
+		assert.NotContains(t, decoded, "XMLName")
+	})
+
+	t.Run("errors are negotiated too", func(t *testing.T) {
+		resp, body := get(t, app, "/users/00000000-0000-0000-0000-000000000000", "application/xml")
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "application/xml; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.Contains(t, string(body), "<error><message>")
+	})
+
+	t.Run("json stays the default", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "text/html", "application/xml;q=0.1, application/json", "application/xml;q=0"} {
+			resp, body := get(t, app, "/users/"+user.ID, accept)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"), accept)
+			assert.Contains(t, string(body), `"email":"jane@example.com"`)
+		}
+	})
+}
+
+func TestStartTestApp_ContentNegotiationDisabledByDefault(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	var user struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &user)
+	require.Equal(t, http.StatusCreated, status)
+
+	resp, _ := get(t, app, "/users/"+user.ID, "application/xml")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+	assert.Empty(t, resp.Header.Get("Vary"))
+}
+
+func TestStartTestApp_DomainValidationFields(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	type fieldError struct{ Field, Message string }
+	var body struct {
+		Code   string
+		Fields []fieldError
+	}
+
+	// Both values pass binding, so the domain reports them together
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": `"jane"@example.com`, "name": "   "}, &body)
+	require.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "VALIDATION_FAILED", body.Code)
+	assert.Equal(t, []fieldError{
+		{Field: "email", Message: "invalid email format"},
+		{Field: "name", Message: "name must be non-empty, not exceed 255 characters and contain no control characters"},
+	}, body.Fields)
+
+	var created struct{ ID string }
+	status = send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+
+	status = send(t, app, http.MethodPut, "/users/"+created.ID, map[string]string{"name": "   "}, &body)
+	require.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, []fieldError{
+		{Field: "name", Message: "name must be non-empty, not exceed 255 characters and contain no control characters"},
+	}, body.Fields)
+}
+
+func TestStartTestApp_Passwords(t *testing.T) {
+	app := StartTestApp(t, Options{Configure: func(cfg *config.Config) {
+		cfg.Users.Passwords.RequireDigit = true
+	}})
+
+	type fieldError struct{ Field, Message string }
+	var body struct {
+		Code   string
+		Fields []fieldError
+	}
+
+	// Every broken rule is reported, alongside invalid user fields
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane.smith@example.com", "name": "   ", "password": "smith"}, &body)
+	require.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "VALIDATION_FAILED", body.Code)
+	assert.Equal(t, []fieldError{
+		{Field: "name", Message: "name must be non-empty, not exceed 255 characters and contain no control characters"},
+		{Field: "password", Message: "password is too short: must be at least 12 characters"},
+		{Field: "password", Message: "password must contain a digit"},
+		{Field: "password", Message: "password must not contain the email address or name"},
+	}, body.Fields)
+
+	var created struct{ ID string }
+	status = send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane.smith@example.com", "name": "Jane Smith", "password": "tulip-harbor-42"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+
+	path := "/users/" + created.ID + "/password"
+	status = send(t, app, http.MethodPut, path, map[string]string{"current_password": "wrong", "password": "violet-canyon-77"}, &body)
+	require.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, "INCORRECT_PASSWORD", body.Code)
+
+	status = send(t, app, http.MethodPut, path, map[string]string{"current_password": "tulip-harbor-42", "password": "violet-canyon-77"}, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+
+	status = send(t, app, http.MethodPut, path, map[string]string{"current_password": "tulip-harbor-42", "password": "amber-meadow-19"}, &body)
+	assert.Equal(t, http.StatusForbidden, status, "the old password no longer works")
+
+	// Users created without a password set their first one without a current password
+	status = send(t, app, http.MethodPost, "/users", map[string]string{"email": "john@example.com", "name": "John"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	status = send(t, app, http.MethodPut, "/users/"+created.ID+"/password", map[string]string{"password": "amber-meadow-19"}, nil)
+	assert.Equal(t, http.StatusNoContent, status)
+}
+
+func TestStartTestApp_BindingTags(t *testing.T) {
+	app := StartTestApp(t, Options{Configure: func(cfg *config.Config) {
+		cfg.Users.Passwords.RequireDigit = true
+	}})
+
+	type fieldError struct{ Field, Message string }
+	var body struct {
+		Code   string
+		Fields []fieldError
+	}
+
+	var created struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+
+	status = send(t, app, http.MethodPut, "/users/"+created.ID+"/username", map[string]string{"username": "jane..doe"}, &body)
+	require.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, "VALIDATION_FAILED", body.Code)
+	assert.Equal(t, []fieldError{
+		{Field: "username", Message: "username must be 3-30 lowercase letters, digits or single . _ - separators"},
+	}, body.Fields)
+
+	status = send(t, app, http.MethodPut, "/users/"+created.ID+"/username", map[string]string{"username": " Jane.Doe "}, nil)
+	assert.Equal(t, http.StatusOK, status, "usernames are checked once normalized")
+
+	// The configured policy applies before the current password is checked
+	status = send(t, app, http.MethodPut, "/users/"+created.ID+"/password", map[string]string{"password": "short"}, &body)
+	require.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, []fieldError{
+		{Field: "password", Message: "password is too short: must be at least 12 characters; password must contain a digit"},
+	}, body.Fields)
+}
+
+func TestStartTestApp_ErrorCodes(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	type errorBody struct {
+		Error string
+		Code  string
+	}
+
+	var created struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+
+	cases := []struct {
+		name         string
+		method, path string
+		body         any
+		status       int
+		code         string
+	}{
+		{"domain error", http.MethodGet, "/users/00000000-0000-0000-0000-000000000000", nil, http.StatusNotFound, "USER_NOT_FOUND"},
+		{"conflict", http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, http.StatusConflict, "EMAIL_TAKEN"},
+		{"invalid body", http.MethodPost, "/users", map[string]string{"email": "jane"}, http.StatusBadRequest, "VALIDATION_FAILED"},
+		{"invalid query", http.MethodGet, "/users?limit=0", nil, http.StatusBadRequest, "VALIDATION_FAILED"},
+		{"invalid path", http.MethodGet, "/users/not-a-uuid", nil, http.StatusBadRequest, "INVALID_PATH_PARAMETER"},
+		{"invalid job path", http.MethodGet, "/jobs/1", nil, http.StatusBadRequest, "INVALID_PATH_PARAMETER"},
+		{"unknown route", http.MethodGet, "/nope", nil, http.StatusNotFound, "NOT_FOUND"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body errorBody
+			status := send(t, app, tc.method, tc.path, tc.body, &body)
+			assert.Equal(t, tc.status, status)
+			assert.Equal(t, tc.code, body.Code)
+		})
+	}
+
+	t.Run("bulk items", func(t *testing.T) {
+		var bulk struct {
+			Results []struct{ Status, Error, Code string }
+		}
+		users := []map[string]string{{"email": "jane@example.com", "name": "Jane"}, {"email": "new@example.com", "name": "New"}}
+		status := send(t, app, http.MethodPost, "/users/bulk", map[string]any{"users": users, "mode": "best_effort"}, &bulk)
+		require.Equal(t, http.StatusMultiStatus, status)
+		assert.Equal(t, "EMAIL_TAKEN", bulk.Results[0].Code)
+		assert.Empty(t, bulk.Results[1].Code)
+	})
+}
+
+func TestStartTestApp_SparseFields(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	var user struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "jane@example.com", "name": "Jane"}, &user)
+	require.Equal(t, http.StatusCreated, status)
+
+	var sparse map[string]any
+	status = send(t, app, http.MethodGet, "/users/"+user.ID+"?fields=email,created_at", nil, &sparse)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]any{
+		"id":         user.ID,
+		"email":      "jane@example.com",
+		"created_at": DefaultNow.Format(utc.Layout),
+	}, sparse)
+
+	var list struct{ Users []map[string]any }
+	status = send(t, app, http.MethodGet, "/users?fields=name", nil, &list)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []map[string]any{{"id": user.ID, "name": "Jane"}}, list.Users)
+
+	status = send(t, app, http.MethodGet, "/users?fields=name&include_deleted=true", nil, &list)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []map[string]any{{"id": user.ID, "name": "Jane", "last_login_at": nil, "deleted_at": nil}}, list.Users)
+
+	var problem struct {
+		Error  string
+		Fields []struct{ Field, Message string }
+	}
+	status = send(t, app, http.MethodGet, "/users/"+user.ID+"?fields=email,password", nil, &problem)
+	require.Equal(t, http.StatusBadRequest, status)
+	require.Len(t, problem.Fields, 1)
+	assert.Equal(t, "fields", problem.Fields[0].Field)
+	assert.Contains(t, problem.Fields[0].Message, "password")
+
+	status = send(t, app, http.MethodGet, "/users?fields=secret", nil, &problem)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	var full map[string]any
+	status = send(t, app, http.MethodGet, "/users/"+user.ID, nil, &full)
+	require.Equal(t, http.StatusOK, status)
+	assert.Contains(t, full, "updated_at", "every field without a selection")
+}
+
+// jsonapiDocument is a JSON:API document as served by the user routes
+type jsonapiDocument struct {
+	Data   json.RawMessage
+	Errors []struct {
+		Status, Title, Detail string
+		Source                struct{ Pointer, Parameter string }
+	}
+	Meta  map[string]any
+	Links map[string]string
+}
+
+// jsonapiResource is a JSON:API resource object
+type jsonapiResource struct {
+	Type, ID      string
+	Attributes    map[string]any
+	Relationships map[string]struct{ Links map[string]string }
+	Links         map[string]string
+}
+
+// getJSONAPI requests path from app as JSON:API and decodes the document
+func getJSONAPI(t *testing.T, app *App, path string) (int, jsonapiDocument) {
+	t.Helper()
+
+	resp, body := get(t, app, path, "application/vnd.api+json")
+	assert.Equal(t, "application/vnd.api+json", resp.Header.Get("Content-Type"))
+
+	var doc jsonapiDocument
+	require.NoError(t, json.Unmarshal(body, &doc), string(body))
+	return resp.StatusCode, doc
+}
+
+func TestStartTestApp_JSONAPI(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.ResponseFormats = []string{"jsonapi"}
+		},
+	})
+
+	ids := make([]string, 3)
+	for i, name := range []string{"ada", "grace", "alan"} {
+		var user struct{ ID string }
+		status := send(t, app, http.MethodPost, "/users", map[string]string{"email": name + "@example.com", "name": name}, &user)
+		require.Equal(t, http.StatusCreated, status)
+		ids[i] = user.ID
+	}
+
+	status, doc := getJSONAPI(t, app, "/users/"+ids[0])
+	require.Equal(t, http.StatusOK, status)
+	var user jsonapiResource
+	require.NoError(t, json.Unmarshal(doc.Data, &user))
+	assert.Equal(t, "users", user.Type)
+	assert.Equal(t, ids[0], user.ID)
+	assert.Equal(t, "ada@example.com", user.Attributes["email"])
+	assert.NotContains(t, user.Attributes, "id")
+	assert.Equal(t, "/users/"+ids[0]+"/preferences", user.Relationships["preferences"].Links["related"])
+	assert.Equal(t, "/users/"+ids[0], user.Links["self"])
+
+	t.Run("pagination links", func(t *testing.T) {
+		status, doc := getJSONAPI(t, app, "/users?limit=2")
+		require.Equal(t, http.StatusOK, status)
+		var users []jsonapiResource
+		require.NoError(t, json.Unmarshal(doc.Data, &users))
+		assert.Len(t, users, 2)
+		assert.Equal(t, "/users?limit=2&offset=2", doc.Links["next"])
+		assert.Equal(t, "/users?limit=2&offset=0", doc.Links["first"])
+		assert.NotContains(t, doc.Links, "prev")
+
+		status, doc = getJSONAPI(t, app, doc.Links["next"])
+		require.Equal(t, http.StatusOK, status)
+		require.NoError(t, json.Unmarshal(doc.Data, &users))
+		assert.Len(t, users, 1)
+		assert.Equal(t, "/users?limit=2&offset=0", doc.Links["prev"])
+		assert.NotContains(t, doc.Links, "next")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		status, doc := getJSONAPI(t, app, "/users?limit=0")
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Len(t, doc.Errors, 1)
+		assert.Equal(t, "400", doc.Errors[0].Status)
+		assert.Equal(t, "limit", doc.Errors[0].Source.Parameter)
+
+		status, doc = getJSONAPI(t, app, "/users/00000000-0000-0000-0000-000000000000")
+		require.Equal(t, http.StatusNotFound, status)
+		require.Len(t, doc.Errors, 1)
+		assert.Equal(t, "Not Found", doc.Errors[0].Title)
+		assert.Equal(t, "user not found", doc.Errors[0].Detail)
+	})
+
+	t.Run("plain json stays the default", func(t *testing.T) {
+		resp, body := get(t, app, "/users/"+ids[0], "")
+		assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+		assert.Contains(t, string(body), `"email":"ada@example.com"`)
+	})
+}
+
+func TestStartTestApp_JSONAPIByDefault(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.DefaultResponseFormat = "jsonapi"
+		},
+	})
+
+	var created jsonapiDocument
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+	var user jsonapiResource
+	require.NoError(t, json.Unmarshal(created.Data, &user))
+	assert.Equal(t, "users", user.Type)
+
+	resp, body := get(t, app, "/users/"+user.ID+"/activity", "*/*")
+	assert.Equal(t, "application/vnd.api+json", resp.Header.Get("Content-Type"))
+	assert.Contains(t, string(body), `"type":"events"`)
+
+	resp, body = get(t, app, "/users/"+user.ID, "application/json")
+	assert.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"), "JSON stays available")
+	assert.Contains(t, string(body), `"email":"ada@example.com"`)
+}
+
+func TestStartTestApp_AdminRoutes(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Admin.Token = "admin-secret"
+		},
+	})
+
+	// sendAdmin makes a request against the admin routes with the given token
+	sendAdmin := func(method, path, token string, body, out any) int {
+		t.Helper()
+
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(method, app.URL+path, bytes.NewReader(payload))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := app.Client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+		if out != nil {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+		}
+		return resp.StatusCode
+	}
+
+	var user map[string]any
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "admin@example.com", "name": "Managed"}, &user)
+	require.Equal(t, http.StatusCreated, status)
+	id := user["id"].(string)
+	require.Equal(t, http.StatusNoContent, send(t, app, http.MethodDelete, "/users/"+id, nil, nil))
+
+	t.Run("requires the admin token", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, sendAdmin(http.MethodPost, "/admin/users/"+id+"/restore", "", nil, nil))
+		assert.Equal(t, http.StatusUnauthorized, sendAdmin(http.MethodGet, "/admin/audit", "wrong", nil, nil))
+	})
+
+	t.Run("restore is no longer part of the public API", func(t *testing.T) {
+		status := send(t, app, http.MethodPost, "/users/"+id+"/restore", nil, nil)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("restores a deleted user", func(t *testing.T) {
+		var restored map[string]any
+		status := sendAdmin(http.MethodPost, "/admin/users/"+id+"/restore", "admin-secret", nil, &restored)
+		require.Equal(t, http.StatusOK, status)
+		assert.Nil(t, restored["deleted_at"])
+	})
+
+	t.Run("queries the audit log", func(t *testing.T) {
+		var page struct {
+			Events []struct {
+				UserID string `json:"user_id"`
+				Type   string `json:"type"`
+			} `json:"events"`
+		}
+		status := sendAdmin(http.MethodGet, "/admin/audit?type=user.deleted", "admin-secret", nil, &page)
+		require.Equal(t, http.StatusOK, status)
+		require.Len(t, page.Events, 1)
+		assert.Equal(t, id, page.Events[0].UserID)
+		assert.Equal(t, "user.deleted", page.Events[0].Type)
+	})
+
+	t.Run("erases a user", func(t *testing.T) {
+		var record map[string]any
+		status := sendAdmin(http.MethodDelete, "/admin/users/"+id, "admin-secret", nil, &record)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, id, record["user_id"])
+
+		assert.Equal(t, http.StatusNotFound, send(t, app, http.MethodGet, "/users/"+id, nil, nil))
+	})
+
+	t.Run("changes the log level", func(t *testing.T) {
+		var level map[string]string
+		status := sendAdmin(http.MethodPut, "/admin/log-level", "admin-secret", map[string]string{"level": "warn"}, &level)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "warn", level["level"])
+
+		status = sendAdmin(http.MethodGet, "/admin/log-level", "admin-secret", nil, &level)
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "warn", level["level"])
+
+		status = sendAdmin(http.MethodPut, "/admin/log-level", "admin-secret", map[string]string{"level": "loud"}, nil)
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+}
+
+func TestStartTestApp_AdminRoutesDisabledWithoutToken(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	status := send(t, app, http.MethodGet, "/admin/log-level", nil, nil)
+	assert.Equal(t, http.StatusNotFound, status)
+}
+
+func TestStartTestApp_ChiRouter(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.HTTP.Router = "chi"
+			cfg.Admin.Token = "admin-secret"
+			cfg.Users.ResponseFormats = []string{"jsonapi"}
+		},
+	})
+
+	var user map[string]any
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &user)
+	require.Equal(t, http.StatusCreated, status)
+	id := user["id"].(string)
+
+	var fetched map[string]any
+	require.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "/users/"+id, nil, &fetched))
+	assert.Equal(t, "ada@example.com", fetched["email"])
+
+	var updated map[string]any
+	require.Equal(t, http.StatusOK, send(t, app, http.MethodPut, "/users/"+id, map[string]string{"name": "Ada Lovelace"}, &updated))
+	assert.Equal(t, "Ada Lovelace", updated["name"])
+
+	t.Run("path parameters reach the handlers", func(t *testing.T) {
+		status, doc := getJSONAPI(t, app, "/users/"+id+"/preferences")
+		require.Equal(t, http.StatusOK, status)
+		var prefs jsonapiResource
+		require.NoError(t, json.Unmarshal(doc.Data, &prefs))
+		assert.Equal(t, id, prefs.ID)
+	})
+
+	t.Run("unknown routes get problem details", func(t *testing.T) {
+		resp, _ := get(t, app, "/users/"+id+"/unknown", "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "application/problem+json", resp.Header.Get("Content-Type"))
+	})
+
+	t.Run("admin routes", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, send(t, app, http.MethodDelete, "/users/"+id, nil, nil))
+
+		req, err := http.NewRequest(http.MethodPost, app.URL+"/admin/users/"+id+"/restore", nil)
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer admin-secret")
+		resp, err := app.Client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "/users/"+id, nil, nil))
+	})
+}
+
+func TestStartTestApp_RecordsFixtures(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "fixtures")
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.HTTP.Fixtures.Record = true
+			cfg.HTTP.Fixtures.Dir = dir
+		},
+	})
+
+	var user map[string]any
+	require.Equal(t, http.StatusCreated, send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &user))
+	id := user["id"].(string)
+	require.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "/users/"+id, nil, nil))
+	resp, _ := get(t, app, "/health/ready", "")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	entries, err := fixture.Load(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2, "health checks are not recorded")
+
+	stub := httptest.NewServer(fixture.NewReplayer(entries))
+	defer stub.Close()
+	replayed, err := stub.Client().Get(stub.URL + "/users/" + id)
+	require.NoError(t, err)
+	defer replayed.Body.Close()
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(replayed.Body).Decode(&body))
+	assert.Equal(t, http.StatusOK, replayed.StatusCode)
+	assert.Equal(t, id, body["id"])
+	assert.Equal(t, logger.Redacted, body["email"], "fixtures are redacted")
+}
+
+func TestStartTestApp_MemoryCache(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Cache.Driver = "memory"
+		},
+	})
+
+	var user map[string]any
+	require.Equal(t, http.StatusCreated, send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &user))
+	id := user["id"].(string)
+
+	hits := func() float64 {
+		t.Helper()
+		families, err := prometheus.DefaultGatherer.Gather()
+		require.NoError(t, err)
+		for _, family := range families {
+			if family.GetName() != "cache_operations_total" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels["driver"] == "memory" && labels["operation"] == "get" && labels["result"] == "hit" {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+		return 0
+	}
+
+	before := hits()
+	var fetched map[string]any
+	require.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "/users/"+id, nil, &fetched))
+	require.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "/users/"+id, nil, &fetched))
+	assert.Greater(t, hits(), before, "the second read is served from the cache")
+
+	require.Equal(t, http.StatusOK, send(t, app, http.MethodPut, "/users/"+id, map[string]string{"name": "Ada Lovelace"}, nil))
+	require.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "/users/"+id, nil, &fetched))
+	assert.Equal(t, "Ada Lovelace", fetched["name"], "updates invalidate the cached user")
+
+	require.Equal(t, http.StatusNoContent, send(t, app, http.MethodDelete, "/users/"+id, nil, nil))
+	assert.Equal(t, http.StatusNotFound, send(t, app, http.MethodGet, "/users/"+id, nil, nil))
+}
+
+func TestStartTestApp_CacheControl(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.HTTP.CacheControl.Enabled = true
+			cfg.HTTP.CacheControl.Routes = map[string]string{"/users/:id": "public, max-age=30"}
+		},
+	})
+
+	var user map[string]any
+	require.Equal(t, http.StatusCreated, send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &user))
+
+	get := func(path string, header http.Header) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, app.URL+path, nil)
+		require.NoError(t, err)
+		maps.Copy(req.Header, header)
+		resp, err := app.Client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := get("/users/"+user["id"].(string), nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "public, max-age=30", resp.Header.Get("Cache-Control"))
+	assert.NotEmpty(t, resp.Header.Get("Expires"))
+	assert.Contains(t, resp.Header.Values("Vary"), "Authorization, X-API-Key")
+
+	resp = get("/users/"+user["id"].(string), http.Header{"Authorization": {"Bearer token"}})
+	assert.Equal(t, "private, no-cache", resp.Header.Get("Cache-Control"), "authenticated")
+
+	resp = get("/users/00000000-0000-0000-0000-000000000000", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Cache-Control"), "errors are not cached")
+
+	resp = get("/health/live", nil)
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"), "default")
+
+	req, err := http.NewRequest(http.MethodDelete, app.URL+"/users/"+user["id"].(string), nil)
+	require.NoError(t, err)
+	resp, err = app.Client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"), "mutation")
+}
+
+func TestStartTestApp_Timezone(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.HTTP.Locale.Enabled = true
+		},
+	})
+
+	var user map[string]any
+	require.Equal(t, http.StatusCreated, send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &user))
+	assert.True(t, strings.HasSuffix(user["created_at"].(string), "Z"), "UTC by default")
+
+	get := func(timezone string) (*http.Response, map[string]any) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, app.URL+"/users/"+user["id"].(string), nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Timezone", timezone)
+		resp, err := app.Client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp, body
+	}
+
+	resp, body := get("Asia/Kolkata")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, strings.HasSuffix(body["created_at"].(string), "+05:30"), body["created_at"])
+	assert.Contains(t, resp.Header.Values("Vary"), "X-Timezone")
+
+	created, err := time.Parse(time.RFC3339Nano, user["created_at"].(string))
+	require.NoError(t, err)
+	localized, err := time.Parse(time.RFC3339Nano, body["created_at"].(string))
+	require.NoError(t, err)
+	assert.True(t, created.Equal(localized), "the same instant")
+
+	resp, _ = get("Nowhere/Special")
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestStartTestApp_HeadUsers(t *testing.T) {
+	for _, router := range []string{"gin", "chi"} {
+		t.Run(router, func(t *testing.T) {
+			app := StartTestApp(t, Options{
+				Configure: func(cfg *config.Config) { cfg.HTTP.Router = router },
+			})
+
+			var created struct{ ID string }
+			status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &created)
+			require.Equal(t, http.StatusCreated, status)
+
+			head := func(path string) *http.Response {
+				req, err := http.NewRequest(http.MethodHead, app.URL+path, nil)
+				require.NoError(t, err)
+				resp, err := app.Client.Do(req)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				return resp
+			}
+
+			assert.Equal(t, http.StatusOK, head("/users/"+created.ID).StatusCode)
+			assert.Equal(t, http.StatusOK, head("/users?email=ADA%40example.com").StatusCode)
+			assert.Equal(t, http.StatusNotFound, head("/users?email=grace%40example.com").StatusCode)
+			assert.Equal(t, http.StatusBadRequest, head("/users?email=ada").StatusCode)
+			assert.Equal(t, http.StatusBadRequest, head("/users/not-a-uuid").StatusCode)
+
+			require.Equal(t, http.StatusNoContent, send(t, app, http.MethodDelete, "/users/"+created.ID, nil, nil))
+			assert.Equal(t, http.StatusNotFound, head("/users/"+created.ID).StatusCode, "soft-deleted users do not exist")
+			assert.Equal(t, http.StatusNotFound, head("/users?email=ada%40example.com").StatusCode)
+		})
+	}
+}
+
+func TestStartTestApp_UserAvailability(t *testing.T) {
+	for _, router := range []string{"gin", "chi"} {
+		t.Run(router, func(t *testing.T) {
+			app := StartTestApp(t, Options{
+				Configure: func(cfg *config.Config) { cfg.HTTP.Router = router },
+			})
+
+			var created struct{ ID string }
+			status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &created)
+			require.Equal(t, http.StatusCreated, status)
+			require.Equal(t, http.StatusOK, send(t, app, http.MethodPut, "/users/"+created.ID+"/username", map[string]string{"username": "ada"}, nil))
+
+			type availability struct {
+				Value     string
+				Available bool
+				Status    string
+				Code      string
+			}
+			var body struct{ Email, Username *availability }
+			check := func(query string) *http.Response {
+				body.Email, body.Username = nil, nil
+				resp, err := app.Client.Get(app.URL + "/users/availability?" + query)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+				return resp
+			}
+
+			resp := check("email=ADA%40example.com&username=grace")
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "private, max-age=10", resp.Header.Get("Cache-Control"))
+			assert.Equal(t, &availability{Value: "ada@example.com", Status: "taken"}, body.Email)
+			assert.Equal(t, &availability{Value: "grace", Available: true, Status: "available"}, body.Username)
+
+			resp = check("email=ada&username=admin")
+			assert.Equal(t, http.StatusOK, resp.StatusCode, "invalid identifiers are not errors")
+			assert.Equal(t, &availability{Value: "ada", Status: "invalid", Code: "VALIDATION_FAILED"}, body.Email)
+			assert.Equal(t, &availability{Value: "admin", Status: "invalid", Code: "USERNAME_RESERVED"}, body.Username)
+
+			require.Equal(t, http.StatusNoContent, send(t, app, http.MethodDelete, "/users/"+created.ID, nil, nil))
+			check("username=ada")
+			assert.Nil(t, body.Email, "only the identifiers asked about are answered")
+			assert.Equal(t, "taken", body.Username.Status, "soft-deleted users keep their identifiers until purged")
+
+			var problem struct{ Code string }
+			assert.Equal(t, http.StatusBadRequest, send(t, app, http.MethodGet, "/users/availability", nil, &problem))
+			assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+		})
+	}
+}
+
+func TestStartTestApp_UserAvailabilityRateLimit(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Users.Availability.Requests = 1
+			cfg.Users.Availability.Interval = time.Minute
+			cfg.Users.Availability.Burst = 2
+		},
+	})
+
+	for range 2 {
+		require.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "/users/availability?username=ada", nil, nil))
+	}
+
+	resp, err := app.Client.Get(app.URL + "/users/availability?username=ada")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var problem struct{ Code string }
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&problem))
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "RATE_LIMITED", problem.Code)
+	assert.Equal(t, "60", resp.Header.Get("Retry-After"))
+}
+
+func TestStartTestApp_LoginHistory(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Admin.Token = testAdminToken
+		},
+	})
+	ctx := context.Background()
+
+	var created struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+
+	client := domain.LoginClient{IP: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+	require.NoError(t, app.Users.RecordLogin(ctx, domain.NewLoginEvent(uuid.NewString(), created.ID, client, domain.ErrInvalidCredentials, app.Clock.Now())))
+	app.Clock.Advance(time.Minute)
+	require.NoError(t, app.Users.RecordLogin(ctx, domain.NewLoginEvent(uuid.NewString(), created.ID, client, nil, app.Clock.Now())))
+
+	type login struct {
+		Succeeded  bool
+		Failure    string
+		IP         string
+		UserAgent  string `json:"user_agent"`
+		OccurredAt string `json:"occurred_at"`
+	}
+	var page struct {
+		Logins     []login
+		NextCursor string `json:"next_cursor"`
+	}
+	status = sendAsAdmin(t, app, http.MethodGet, "/admin/users/"+created.ID+"/logins?limit=1", nil, &page)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, []login{{Succeeded: true, IP: "203.0.113.7", UserAgent: "Mozilla/5.0", OccurredAt: "2025-01-01T12:01:00.000Z"}}, page.Logins)
+	require.NotEmpty(t, page.NextCursor)
+
+	cursor := page.NextCursor
+	page.NextCursor = ""
+	status = sendAsAdmin(t, app, http.MethodGet, "/admin/users/"+created.ID+"/logins?cursor="+cursor, nil, &page)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, page.Logins, 1, "older logins follow")
+	assert.Equal(t, "invalid_password", page.Logins[0].Failure)
+	assert.Empty(t, page.NextCursor)
+
+	// Admin responses carry the time of the last successful login
+	var list struct {
+		Users []struct {
+			LastLoginAt string `json:"last_login_at"`
+		}
+	}
+	status = send(t, app, http.MethodGet, "/users?include_deleted=true", nil, &list)
+	require.Equal(t, http.StatusOK, status)
+	require.Len(t, list.Users, 1)
+	assert.Equal(t, "2025-01-01T12:01:00.000Z", list.Users[0].LastLoginAt)
+
+	var problem struct{ Code string }
+	assert.Equal(t, http.StatusNotFound, sendAsAdmin(t, app, http.MethodGet, "/admin/users/"+uuid.NewString()+"/logins", nil, &problem))
+	assert.Equal(t, "USER_NOT_FOUND", problem.Code)
+	assert.Equal(t, http.StatusBadRequest, sendAsAdmin(t, app, http.MethodGet, "/admin/users/"+created.ID+"/logins?cursor=nope", nil, &problem))
+	assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+
+	// The history is for operators, not the public user routes
+	assert.Equal(t, http.StatusNotFound, send(t, app, http.MethodGet, "/users/"+created.ID+"/logins", nil, nil))
+	assert.Equal(t, http.StatusUnauthorized, send(t, app, http.MethodGet, "/admin/users/"+created.ID+"/logins", nil, nil))
+}
+
+func TestStartTestApp_Login(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Admin.Token = testAdminToken
+		},
+	})
+
+	var created struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada", "password": "tulip-harbor-42"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+
+	var login struct {
+		User struct {
+			ID    string
+			Email string
+		}
+		StepUp bool `json:"step_up"`
+	}
+	status = send(t, app, http.MethodPost, "/users/login", map[string]string{"email": "Ada@Example.com", "password": "tulip-harbor-42"}, &login)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, created.ID, login.User.ID)
+	assert.Equal(t, "ada@example.com", login.User.Email)
+	assert.False(t, login.StepUp)
+	app.Clock.Advance(time.Minute)
+
+	// Wrong passwords and unknown emails are answered alike
+	var problem struct{ Code string }
+	for _, credentials := range []map[string]string{
+		{"email": "ada@example.com", "password": "wrong-password-1"},
+		{"email": "nobody@example.com", "password": "tulip-harbor-42"},
+	} {
+		assert.Equal(t, http.StatusUnauthorized, send(t, app, http.MethodPost, "/users/login", credentials, &problem))
+		assert.Equal(t, "INVALID_CREDENTIALS", problem.Code)
+	}
+	assert.Equal(t, http.StatusBadRequest, send(t, app, http.MethodPost, "/users/login", map[string]string{"email": "ada@example.com"}, &problem))
+	assert.Equal(t, "VALIDATION_FAILED", problem.Code)
+
+	// Attempts are recorded with the client's address and user agent
+	var page struct {
+		Logins []struct {
+			Succeeded bool
+			IP        string
+			UserAgent string `json:"user_agent"`
+		}
+	}
+	require.Equal(t, http.StatusOK, sendAsAdmin(t, app, http.MethodGet, "/admin/users/"+created.ID+"/logins", nil, &page))
+	require.Len(t, page.Logins, 2)
+	assert.False(t, page.Logins[0].Succeeded)
+	assert.True(t, page.Logins[1].Succeeded)
+	assert.Equal(t, "127.0.0.1", page.Logins[1].IP)
+	assert.Equal(t, "Go-http-client/1.1", page.Logins[1].UserAgent)
+}
+
+func TestStartTestApp_LoginRisk(t *testing.T) {
+	app := StartTestApp(t, Options{
+		Configure: func(cfg *config.Config) {
+			cfg.Admin.Token = testAdminToken
+			cfg.HTTP.TrustedProxies = []string{"127.0.0.1/32"}
+			cfg.Users.LoginRisk.CountryHeader = "CF-IPCountry"
+		},
+	})
+
+	var created struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "ada@example.com", "name": "Ada", "password": "tulip-harbor-42"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+
+	// login logs in through the CDN, which names the client's country
+	login := func(ip, userAgent, country string) bool {
+		t.Helper()
+
+		body := strings.NewReader(`{"email":"ada@example.com","password":"tulip-harbor-42"}`)
+		req, err := http.NewRequest(http.MethodPost, app.URL+"/users/login", body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set("X-Forwarded-For", ip)
+		req.Header.Set("CF-IPCountry", country)
+
+		resp, err := app.Client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var out struct {
+			StepUp bool `json:"step_up"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return out.StepUp
+	}
+
+	assert.False(t, login("203.0.113.7", "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Firefox/128.0", "FR"), "first logins have nothing to compare with")
+
+	app.Clock.Advance(time.Hour)
+	assert.True(t, login("198.51.100.4", "curl/8.5.0", "BR"), "a new country asks for a second factor")
+
+	msg, ok := app.Mailer.Last("ada@example.com")
+	require.True(t, ok, "the user is told about the new device")
+	assert.Equal(t, "New sign-in to your account", msg.Subject)
+	assert.Contains(t, msg.Body, "IP address: 198.51.100.4")
+	assert.Contains(t, msg.Body, "Country: BR")
+
+	var page struct {
+		Logins []struct{ IP, Country string }
+	}
+	require.Equal(t, http.StatusOK, sendAsAdmin(t, app, http.MethodGet, "/admin/users/"+created.ID+"/logins", nil, &page))
+	require.Len(t, page.Logins, 2)
+	assert.Equal(t, "198.51.100.4", page.Logins[0].IP)
+	assert.Equal(t, "BR", page.Logins[0].Country)
+}
+
+func TestStartTestApp_DeleteUserRefusesErasure(t *testing.T) {
+	app := StartTestApp(t, Options{})
+
+	var created struct{ ID string }
+	status := send(t, app, http.MethodPost, "/users", map[string]string{"email": "erase@example.com", "name": "Erase Me"}, &created)
+	require.Equal(t, http.StatusCreated, status)
+
+	type fieldError struct{ Field, Message string }
+	var body struct {
+		Error  string
+		Code   string
+		Fields []fieldError
+	}
+	for _, mode := range []string{"erase", "purge"} {
+		status = send(t, app, http.MethodDelete, "/users/"+created.ID+"?mode="+mode, nil, &body)
+		require.Equal(t, http.StatusBadRequest, status, mode)
+		assert.Equal(t, "VALIDATION_FAILED", body.Code)
+		assert.Contains(t, body.Error, "DELETE /admin/users/{id}", "the client is pointed to the admin route")
+		require.Len(t, body.Fields, 1)
+		assert.Equal(t, "mode", body.Fields[0].Field)
+	}
+	assert.Equal(t, http.StatusOK, send(t, app, http.MethodGet, "/users/"+created.ID, nil, nil), "the user is left untouched")
+
+	assert.Equal(t, http.StatusNoContent, send(t, app, http.MethodDelete, "/users/"+created.ID+"?mode=soft", nil, nil))
+	assert.Equal(t, http.StatusNotFound, send(t, app, http.MethodGet, "/users/"+created.ID, nil, nil))
+}
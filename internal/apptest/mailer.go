@@ -0,0 +1,49 @@
+package apptest
+
+import (
+	"context"
+	"sync"
+)
+
+// Message is an email sent by the application
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer records emails instead of delivering them
+type Mailer struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// Send records the email
+func (m *Mailer) Send(ctx context.Context, to, subject, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.messages = append(m.messages, Message{To: to, Subject: subject, Body: body})
+	return nil
+}
+
+// Messages returns every email sent so far, oldest first
+func (m *Mailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.messages...)
+}
+
+// Last returns the most recent email sent to the given address
+func (m *Mailer) Last(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].To == to {
+			return m.messages[i], true
+		}
+	}
+	return Message{}, false
+}
@@ -0,0 +1,275 @@
+// Package pact verifies consumer-driven contracts in the Pact JSON format
+// against a running provider. It replays the HTTP interactions of pact
+// files, versions 2 and 3 of the specification, after setting up the
+// provider states they are given in, and checks each response against the
+// expected one and its matching rules.
+//
+// Generators and message pacts are not supported: requests are sent with
+// the example values recorded by the consumer.
+package pact
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Pact is the contract between a consumer and a provider
+type Pact struct {
+	// Consumer and Provider name the two sides of the contract
+	Consumer string
+	Provider string
+
+	// Interactions are the requests the consumer sends and the responses
+	// it expects, in the order of the file
+	Interactions []Interaction
+}
+
+// Interaction is a request the consumer sends and the response it expects
+type Interaction struct {
+	Description string
+
+	// States are the provider states the request is sent in
+	States []State
+
+	Request  Request
+	Response Response
+}
+
+// State is a provider state, such as "a user exists", and its parameters
+type State struct {
+	Name   string
+	Params map[string]any
+}
+
+// Request is the request a consumer sends
+type Request struct {
+	Method  string
+	Path    string
+	Query   url.Values
+	Headers http.Header
+
+	// Body is the JSON of the body, or a JSON string holding it for other
+	// content types; nil without body
+	Body json.RawMessage
+}
+
+// Response is the response a consumer expects
+type Response struct {
+	Status  int
+	Headers http.Header
+
+	// Body is the JSON of the body, or a JSON string holding it for other
+	// content types; nil when the consumer does not look at it
+	Body json.RawMessage
+
+	// Rules relax how the headers and body are compared
+	Rules Rules
+}
+
+// Load reads a pact file
+func Load(path string) (*Pact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pact: %w", err)
+	}
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// LoadDir reads the pact files of a directory, the *.json files, sorted by
+// name
+func LoadDir(dir string) ([]*Pact, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pacts: %w", err)
+	}
+	sort.Strings(paths)
+
+	pacts := make([]*Pact, 0, len(paths))
+	for _, path := range paths {
+		p, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		pacts = append(pacts, p)
+	}
+	return pacts, nil
+}
+
+// rawPact is a pact file as written by version 2 and 3 consumers
+type rawPact struct {
+	Consumer     struct{ Name string } `json:"consumer"`
+	Provider     struct{ Name string } `json:"provider"`
+	Interactions []rawInteraction      `json:"interactions"`
+}
+
+// rawInteraction is an interaction of a pact file
+type rawInteraction struct {
+	Description    string      `json:"description"`
+	ProviderState  string      `json:"providerState"`
+	ProviderStates []rawState  `json:"providerStates"`
+	Request        rawRequest  `json:"request"`
+	Response       rawResponse `json:"response"`
+	Type           string      `json:"type"`
+}
+
+// rawState is a version 3 provider state
+type rawState struct {
+	Name   string         `json:"name"`
+	Params map[string]any `json:"params"`
+}
+
+// rawRequest is the request of an interaction
+type rawRequest struct {
+	Method  string          `json:"method"`
+	Path    string          `json:"path"`
+	Query   json.RawMessage `json:"query"`
+	Headers json.RawMessage `json:"headers"`
+	Body    json.RawMessage `json:"body"`
+}
+
+// rawResponse is the response of an interaction
+type rawResponse struct {
+	Status        int             `json:"status"`
+	Headers       json.RawMessage `json:"headers"`
+	Body          json.RawMessage `json:"body"`
+	MatchingRules json.RawMessage `json:"matchingRules"`
+}
+
+// Parse decodes a pact file
+func Parse(data []byte) (*Pact, error) {
+	var raw rawPact
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse pact: %w", err)
+	}
+
+	p := &Pact{Consumer: raw.Consumer.Name, Provider: raw.Provider.Name}
+	for _, ri := range raw.Interactions {
+		if ri.Type != "" && ri.Type != "Synchronous/HTTP" {
+			return nil, fmt.Errorf("interaction %q: unsupported type %q", ri.Description, ri.Type)
+		}
+
+		interaction, err := parseInteraction(ri)
+		if err != nil {
+			return nil, fmt.Errorf("interaction %q: %w", ri.Description, err)
+		}
+		p.Interactions = append(p.Interactions, interaction)
+	}
+	return p, nil
+}
+
+// parseInteraction converts an interaction of a pact file
+func parseInteraction(ri rawInteraction) (Interaction, error) {
+	interaction := Interaction{Description: ri.Description}
+
+	if ri.ProviderState != "" {
+		interaction.States = append(interaction.States, State{Name: ri.ProviderState})
+	}
+	for _, state := range ri.ProviderStates {
+		interaction.States = append(interaction.States, State(state))
+	}
+
+	query, err := parseQuery(ri.Request.Query)
+	if err != nil {
+		return Interaction{}, err
+	}
+	requestHeaders, err := parseHeaders(ri.Request.Headers)
+	if err != nil {
+		return Interaction{}, err
+	}
+	interaction.Request = Request{
+		Method:  strings.ToUpper(ri.Request.Method),
+		Path:    ri.Request.Path,
+		Query:   query,
+		Headers: requestHeaders,
+		Body:    present(ri.Request.Body),
+	}
+
+	responseHeaders, err := parseHeaders(ri.Response.Headers)
+	if err != nil {
+		return Interaction{}, err
+	}
+	rules, err := parseRules(ri.Response.MatchingRules)
+	if err != nil {
+		return Interaction{}, err
+	}
+	status := ri.Response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	interaction.Response = Response{
+		Status:  status,
+		Headers: responseHeaders,
+		Body:    present(ri.Response.Body),
+		Rules:   rules,
+	}
+	return interaction, nil
+}
+
+// present returns nil for a missing body
+func present(body json.RawMessage) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+	return body
+}
+
+// parseQuery reads a version 2 query string or a version 3 map of values
+func parseQuery(data json.RawMessage) (url.Values, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var query string
+	if err := json.Unmarshal(data, &query); err == nil {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid query: %w", err)
+		}
+		return values, nil
+	}
+
+	var values url.Values
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	return values, nil
+}
+
+// parseHeaders reads headers whose values are strings or lists of them
+func parseHeaders(data json.RawMessage) (http.Header, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return nil, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid headers: %w", err)
+	}
+
+	headers := make(http.Header, len(raw))
+	for name, value := range raw {
+		var single string
+		if err := json.Unmarshal(value, &single); err == nil {
+			headers.Add(name, single)
+			continue
+		}
+		var list []string
+		if err := json.Unmarshal(value, &list); err != nil {
+			return nil, fmt.Errorf("invalid header %s: %w", name, err)
+		}
+		for _, v := range list {
+			headers.Add(name, v)
+		}
+	}
+	return headers, nil
+}
@@ -0,0 +1,110 @@
+package pact
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_V2(t *testing.T) {
+	p, err := Parse([]byte(`{
+	  "consumer": {"name": "web"},
+	  "provider": {"name": "api"},
+	  "interactions": [{
+	    "description": "a request for a user",
+	    "providerState": "a user exists",
+	    "request": {"method": "get", "path": "/users/1", "query": "fields=id&fields=name", "headers": {"Accept": "application/json"}},
+	    "response": {
+	      "status": 200,
+	      "headers": {"Content-Type": "application/json"},
+	      "body": {"id": "1"},
+	      "matchingRules": {"$.body.id": {"match": "type"}, "$.headers.Content-Type": {"regex": "application/json.*"}}
+	    }
+	  }],
+	  "metadata": {"pactSpecification": {"version": "2.0.0"}}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "web", p.Consumer)
+	assert.Equal(t, "api", p.Provider)
+	require.Len(t, p.Interactions, 1)
+
+	interaction := p.Interactions[0]
+	assert.Equal(t, []State{{Name: "a user exists"}}, interaction.States)
+	assert.Equal(t, http.MethodGet, interaction.Request.Method)
+	assert.Equal(t, url.Values{"fields": {"id", "name"}}, interaction.Request.Query)
+	assert.Equal(t, "application/json", interaction.Request.Headers.Get("Accept"))
+	assert.Nil(t, interaction.Request.Body)
+
+	assert.Equal(t, http.StatusOK, interaction.Response.Status)
+	assert.JSONEq(t, `{"id": "1"}`, string(interaction.Response.Body))
+	assert.Equal(t, map[string]RuleSet{"$.id": {Matchers: []Matcher{{Match: "type"}}}}, interaction.Response.Rules.Body)
+	assert.Equal(t, map[string]RuleSet{"Content-Type": {Matchers: []Matcher{{Match: "regex", Regex: "application/json.*"}}}}, interaction.Response.Rules.Header)
+}
+
+func TestParse_V3(t *testing.T) {
+	p, err := Parse([]byte(`{
+	  "consumer": {"name": "web"},
+	  "provider": {"name": "api"},
+	  "interactions": [{
+	    "description": "a request to list users",
+	    "providerStates": [{"name": "a user exists", "params": {"id": "1"}}],
+	    "request": {"method": "GET", "path": "/users", "query": {"limit": ["10"]}},
+	    "response": {
+	      "status": 200,
+	      "body": {"users": [{"id": "1"}]},
+	      "matchingRules": {
+	        "body": {"$.users": {"matchers": [{"match": "type", "min": 1}], "combine": "AND"}},
+	        "status": {"$": {"matchers": [{"match": "statusCode", "status": "success"}]}}
+	      }
+	    }
+	  }]
+	}`))
+	require.NoError(t, err)
+	require.Len(t, p.Interactions, 1)
+
+	interaction := p.Interactions[0]
+	assert.Equal(t, []State{{Name: "a user exists", Params: map[string]any{"id": "1"}}}, interaction.States)
+	assert.Equal(t, url.Values{"limit": {"10"}}, interaction.Request.Query)
+
+	one := 1
+	assert.Equal(t, map[string]RuleSet{"$.users": {Matchers: []Matcher{{Match: "type", Min: &one}}}}, interaction.Response.Rules.Body)
+	assert.Empty(t, interaction.Response.Rules.Header)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"json":    `{`,
+		"message": `{"interactions": [{"description": "an event", "type": "Asynchronous/Messages"}]}`,
+		"path":    `{"interactions": [{"description": "a request", "response": {"matchingRules": {"body": {"users": {"matchers": [{"match": "type"}]}}}}}]}`,
+		"regex":   `{"interactions": [{"description": "a request", "response": {"matchingRules": {"$.body.id": {"regex": "("}}}}]}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.json"), []byte(`{"consumer": {"name": "b"}}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"consumer": {"name": "a"}}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a pact"), 0o600))
+
+	pacts, err := LoadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, pacts, 2)
+	assert.Equal(t, "a", pacts[0].Consumer)
+	assert.Equal(t, "b", pacts[1].Consumer)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.json"), []byte(`{`), 0o600))
+	_, err = LoadDir(dir)
+	assert.ErrorContains(t, err, "c.json")
+}
@@ -0,0 +1,437 @@
+package pact
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Rules are the matching rules of a response: for body values, by JSON
+// path such as "$.users[*].id", and for headers, by name
+type Rules struct {
+	Body   map[string]RuleSet
+	Header map[string]RuleSet
+}
+
+// RuleSet is the matchers of a path. All of them must pass, or one of them
+// with Or.
+type RuleSet struct {
+	Matchers []Matcher
+	Or       bool
+}
+
+// Matcher relaxes the comparison of a value to the example of the pact
+type Matcher struct {
+	// Match is the kind of matcher: type, regex, equality, include,
+	// integer, decimal, number, boolean or null
+	Match string `json:"match"`
+
+	// Regex is the pattern of regex matchers
+	Regex string `json:"regex"`
+
+	// Value is the substring of include matchers
+	Value string `json:"value"`
+
+	// Min and Max bound the length of arrays matched by type
+	Min *int `json:"min"`
+	Max *int `json:"max"`
+}
+
+// parseRules reads version 2 rules, keyed by paths such as "$.body.id" and
+// "$.headers.Location", or version 3 rules grouped by category
+func parseRules(data json.RawMessage) (Rules, error) {
+	rules := Rules{Body: map[string]RuleSet{}, Header: map[string]RuleSet{}}
+	if len(data) == 0 || string(data) == "null" {
+		return rules, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Rules{}, fmt.Errorf("invalid matching rules: %w", err)
+	}
+
+	for key, value := range raw {
+		if strings.HasPrefix(key, "$") {
+			var matcher Matcher
+			if err := json.Unmarshal(value, &matcher); err != nil {
+				return Rules{}, fmt.Errorf("invalid matching rule %s: %w", key, err)
+			}
+			set := RuleSet{Matchers: []Matcher{normalize(matcher)}}
+
+			switch {
+			case key == "$.body" || strings.HasPrefix(key, "$.body.") || strings.HasPrefix(key, "$.body["):
+				rules.Body["$"+strings.TrimPrefix(key, "$.body")] = set
+			case strings.HasPrefix(key, "$.headers."):
+				rules.Header[strings.TrimPrefix(key, "$.headers.")] = set
+			}
+			continue
+		}
+
+		var category map[string]struct {
+			Matchers []Matcher `json:"matchers"`
+			Combine  string    `json:"combine"`
+		}
+		if err := json.Unmarshal(value, &category); err != nil {
+			return Rules{}, fmt.Errorf("invalid matching rules for %s: %w", key, err)
+		}
+
+		target := map[string]map[string]RuleSet{"body": rules.Body, "header": rules.Header}[key]
+		if target == nil {
+			// Rules of the request and of the status are not verified
+			continue
+		}
+		for path, rule := range category {
+			set := RuleSet{Or: strings.EqualFold(rule.Combine, "OR")}
+			for _, matcher := range rule.Matchers {
+				set.Matchers = append(set.Matchers, normalize(matcher))
+			}
+			target[path] = set
+		}
+	}
+
+	for path := range rules.Body {
+		if _, err := parsePath(path); err != nil {
+			return Rules{}, err
+		}
+	}
+	for _, group := range []map[string]RuleSet{rules.Body, rules.Header} {
+		for path, set := range group {
+			for _, matcher := range set.Matchers {
+				if _, err := regexp.Compile(matcher.Regex); err != nil {
+					return Rules{}, fmt.Errorf("invalid regex of %s: %w", path, err)
+				}
+			}
+		}
+	}
+	return rules, nil
+}
+
+// normalize fills in the kind of version 2 matchers, which only name it by
+// their fields
+func normalize(m Matcher) Matcher {
+	if m.Match == "" {
+		if m.Regex != "" {
+			m.Match = "regex"
+		} else {
+			m.Match = "type"
+		}
+	}
+	return m
+}
+
+// parsePath splits a JSON path such as "$.users[*].id" or "$['a b']" into
+// its tokens, with indexes in brackets such as "[0]"
+func parsePath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("invalid matching rule path %q", path)
+	}
+
+	tokens := []string{"$"}
+	rest := path[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid matching rule path %q", path)
+			}
+			tokens = append(tokens, rest[2:end])
+			rest = rest[end+2:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid matching rule path %q", path)
+			}
+			tokens = append(tokens, rest[:end+1])
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid matching rule path %q", path)
+			}
+			tokens = append(tokens, rest[:end])
+			rest = rest[end:]
+		default:
+			return nil, fmt.Errorf("invalid matching rule path %q", path)
+		}
+	}
+	return tokens, nil
+}
+
+// weight scores how closely a rule path matches the path of a value: 0
+// when it does not apply, and higher for longer paths with fewer
+// wildcards. Rules apply to the values below their path as well.
+func weight(rule, path []string) int {
+	if len(rule) > len(path) {
+		return 0
+	}
+
+	score := 1
+	for i, token := range rule {
+		switch {
+		case token == path[i]:
+			score *= 2
+		case token == "*" || token == "[*]":
+			score *= 1
+		default:
+			return 0
+		}
+	}
+	return score
+}
+
+// Mismatch is a difference between a response and the expected one
+type Mismatch struct {
+	// Path locates the difference, such as "status", "header Location" or
+	// "body $.users[0].id"
+	Path    string
+	Message string
+}
+
+// String implements fmt.Stringer
+func (m Mismatch) String() string {
+	return m.Path + ": " + m.Message
+}
+
+// bodyMatcher compares bodies under the body rules of a response
+type bodyMatcher struct {
+	rules []bodyRule
+}
+
+// bodyRule is a rule set and its parsed path
+type bodyRule struct {
+	path []string
+	set  RuleSet
+}
+
+// newBodyMatcher parses the paths of the body rules
+func newBodyMatcher(rules map[string]RuleSet) bodyMatcher {
+	var m bodyMatcher
+	for path, set := range rules {
+		tokens, err := parsePath(path)
+		if err != nil {
+			// Rejected by parseRules
+			continue
+		}
+		m.rules = append(m.rules, bodyRule{path: tokens, set: set})
+	}
+	return m
+}
+
+// rule returns the rule set applying to a path, the one matching it most
+// closely
+func (m bodyMatcher) rule(path []string) (RuleSet, bool) {
+	var best RuleSet
+	bestWeight, bestLen := 0, 0
+	for _, r := range m.rules {
+		w := weight(r.path, path)
+		if w > bestWeight || (w == bestWeight && w > 0 && len(r.path) > bestLen) {
+			best, bestWeight, bestLen = r.set, w, len(r.path)
+		}
+	}
+	return best, bestWeight > 0
+}
+
+// compare lists the differences of actual from the expected JSON value
+func (m bodyMatcher) compare(path []string, expected, actual any) []Mismatch {
+	set, ruled := m.rule(path)
+	if ruled {
+		if msg := set.check(expected, actual); msg != "" {
+			return []Mismatch{{Path: bodyPath(path), Message: msg}}
+		}
+	}
+
+	switch exp := expected.(type) {
+	case map[string]any:
+		act, ok := actual.(map[string]any)
+		if !ok {
+			return []Mismatch{{Path: bodyPath(path), Message: fmt.Sprintf("expected an object, got %s", describe(actual))}}
+		}
+		var mismatches []Mismatch
+		for key, value := range exp {
+			child := append(append([]string(nil), path...), key)
+			got, ok := act[key]
+			if !ok {
+				mismatches = append(mismatches, Mismatch{Path: bodyPath(child), Message: "is missing"})
+				continue
+			}
+			mismatches = append(mismatches, m.compare(child, value, got)...)
+		}
+		return mismatches
+	case []any:
+		act, ok := actual.([]any)
+		if !ok {
+			return []Mismatch{{Path: bodyPath(path), Message: fmt.Sprintf("expected an array, got %s", describe(actual))}}
+		}
+		if !ruled && len(act) != len(exp) {
+			return []Mismatch{{Path: bodyPath(path), Message: fmt.Sprintf("expected %d items, got %d", len(exp), len(act))}}
+		}
+		if len(exp) == 0 {
+			return nil
+		}
+		var mismatches []Mismatch
+		for i, value := range act {
+			// Items past the examples match the last one, as under a type
+			// matcher each item matches the template
+			example := exp[min(i, len(exp)-1)]
+			child := append(append([]string(nil), path...), "["+strconv.Itoa(i)+"]")
+			mismatches = append(mismatches, m.compare(child, example, value)...)
+		}
+		return mismatches
+	default:
+		if ruled {
+			return nil
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			return []Mismatch{{Path: bodyPath(path), Message: fmt.Sprintf("expected %s, got %s", encode(expected), encode(actual))}}
+		}
+		return nil
+	}
+}
+
+// bodyPath formats a path for a mismatch
+func bodyPath(path []string) string {
+	var b strings.Builder
+	b.WriteString("body ")
+	for i, token := range path {
+		if i > 0 && !strings.HasPrefix(token, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(token)
+	}
+	return b.String()
+}
+
+// check applies the matchers of the set to a value, returning why it does
+// not match, if it does not
+func (s RuleSet) check(expected, actual any) string {
+	var failures []string
+	for _, matcher := range s.Matchers {
+		msg := matcher.check(expected, actual)
+		if msg == "" && s.Or {
+			return ""
+		}
+		if msg != "" {
+			failures = append(failures, msg)
+		}
+	}
+	return strings.Join(failures, "; ")
+}
+
+// check applies the matcher to a value, returning why it does not match, if
+// it does not
+func (m Matcher) check(expected, actual any) string {
+	switch m.Match {
+	case "type":
+		if kind(expected) != kind(actual) {
+			return fmt.Sprintf("expected %s, got %s", kind(expected), describe(actual))
+		}
+		if items, ok := actual.([]any); ok {
+			if m.Min != nil && len(items) < *m.Min {
+				return fmt.Sprintf("expected at least %d items, got %d", *m.Min, len(items))
+			}
+			if m.Max != nil && len(items) > *m.Max {
+				return fmt.Sprintf("expected at most %d items, got %d", *m.Max, len(items))
+			}
+		}
+	case "regex":
+		value, ok := scalar(actual)
+		if !ok {
+			return fmt.Sprintf("expected a value matching %s, got %s", m.Regex, describe(actual))
+		}
+		// Patterns must match the whole value
+		if !regexp.MustCompile("^(?:" + m.Regex + ")$").MatchString(value) {
+			return fmt.Sprintf("expected a value matching %s, got %q", m.Regex, value)
+		}
+	case "equality":
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Sprintf("expected %s, got %s", encode(expected), encode(actual))
+		}
+	case "include":
+		value, ok := scalar(actual)
+		if !ok || !strings.Contains(value, m.Value) {
+			return fmt.Sprintf("expected a value including %q, got %s", m.Value, encode(actual))
+		}
+	case "integer":
+		n, ok := actual.(float64)
+		if !ok || n != math.Trunc(n) {
+			return fmt.Sprintf("expected an integer, got %s", encode(actual))
+		}
+	case "decimal", "number":
+		if _, ok := actual.(float64); !ok {
+			return fmt.Sprintf("expected a number, got %s", encode(actual))
+		}
+	case "boolean":
+		if _, ok := actual.(bool); !ok {
+			return fmt.Sprintf("expected a boolean, got %s", encode(actual))
+		}
+	case "null":
+		if actual != nil {
+			return fmt.Sprintf("expected null, got %s", encode(actual))
+		}
+	default:
+		return fmt.Sprintf("unsupported matcher %q", m.Match)
+	}
+	return ""
+}
+
+// kind names the JSON type of a value
+func kind(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "a boolean"
+	case float64:
+		return "a number"
+	case string:
+		return "a string"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// describe names the type of a value with the value, for scalars
+func describe(value any) string {
+	switch value.(type) {
+	case []any, map[string]any:
+		return kind(value)
+	default:
+		return kind(value) + " " + encode(value)
+	}
+}
+
+// scalar returns the text of a string, number or boolean
+func scalar(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		return "", false
+	}
+}
+
+// encode formats a value as JSON
+func encode(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,149 @@
+package pact
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compareJSON compares JSON values under body rules, returning the paths
+// and messages of the mismatches
+func compareJSON(t *testing.T, rules map[string]RuleSet, expected, actual string) []string {
+	t.Helper()
+
+	var want, got any
+	require.NoError(t, json.Unmarshal([]byte(expected), &want))
+	require.NoError(t, json.Unmarshal([]byte(actual), &got))
+
+	var mismatches []string
+	for _, m := range newBodyMatcher(rules).compare([]string{"$"}, want, got) {
+		mismatches = append(mismatches, m.String())
+	}
+	return mismatches
+}
+
+// rule returns a rule set of one matcher
+func rule(m Matcher) RuleSet {
+	return RuleSet{Matchers: []Matcher{m}}
+}
+
+func TestParsePath(t *testing.T) {
+	tokens, err := parsePath("$.users[*].address['postal code']")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"$", "users", "[*]", "address", "postal code"}, tokens)
+
+	for _, path := range []string{"users", "$.", "$[0", "$..id"} {
+		_, err := parsePath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestWeight(t *testing.T) {
+	path := []string{"$", "users", "[0]", "id"}
+
+	assert.Zero(t, weight([]string{"$", "orgs"}, path))
+	assert.Zero(t, weight([]string{"$", "users", "[0]", "id", "x"}, path))
+	assert.Greater(t, weight([]string{"$", "users", "[*]", "id"}, path), weight([]string{"$", "users"}, path))
+	assert.Greater(t, weight([]string{"$", "users", "[0]", "id"}, path), weight([]string{"$", "users", "[*]", "id"}, path))
+}
+
+func TestCompare_WithoutRules(t *testing.T) {
+	assert.Empty(t, compareJSON(t, nil, `{"id": "1", "tags": ["a"]}`, `{"id": "1", "tags": ["a"], "extra": true}`),
+		"responses may have fields the consumer does not use")
+
+	assert.Equal(t, []string{
+		`body $.id: expected "1", got "2"`,
+	}, compareJSON(t, nil, `{"id": "1"}`, `{"id": "2"}`))
+	assert.Equal(t, []string{
+		`body $.name: is missing`,
+	}, compareJSON(t, nil, `{"name": "Ada"}`, `{}`))
+	assert.Equal(t, []string{
+		`body $.tags: expected 1 items, got 2`,
+	}, compareJSON(t, nil, `{"tags": ["a"]}`, `{"tags": ["a", "b"]}`))
+	assert.Equal(t, []string{
+		`body $.user: expected an object, got a string "1"`,
+	}, compareJSON(t, nil, `{"user": {"id": "1"}}`, `{"user": "1"}`))
+}
+
+func TestCompare_Matchers(t *testing.T) {
+	one := 1
+	tests := []struct {
+		name             string
+		rules            map[string]RuleSet
+		expected, actual string
+		mismatches       []string
+	}{
+		{
+			name:     "type",
+			rules:    map[string]RuleSet{"$.id": rule(Matcher{Match: "type"})},
+			expected: `{"id": "1"}`, actual: `{"id": "2"}`,
+		},
+		{
+			name:     "type mismatch",
+			rules:    map[string]RuleSet{"$.id": rule(Matcher{Match: "type"})},
+			expected: `{"id": "1"}`, actual: `{"id": 2}`,
+			mismatches: []string{`body $.id: expected a string, got a number 2`},
+		},
+		{
+			name:     "type of array items",
+			rules:    map[string]RuleSet{"$.users": rule(Matcher{Match: "type", Min: &one})},
+			expected: `{"users": [{"id": "1"}]}`, actual: `{"users": [{"id": "2"}, {"id": "3", "name": "Ada"}]}`,
+		},
+		{
+			name:     "type of array items mismatch",
+			rules:    map[string]RuleSet{"$.users": rule(Matcher{Match: "type", Min: &one})},
+			expected: `{"users": [{"id": "1"}]}`, actual: `{"users": [{"id": "2"}, {"name": "Ada"}]}`,
+			mismatches: []string{`body $.users[1].id: is missing`},
+		},
+		{
+			name:     "minimum items",
+			rules:    map[string]RuleSet{"$.users": rule(Matcher{Match: "type", Min: &one})},
+			expected: `{"users": [{"id": "1"}]}`, actual: `{"users": []}`,
+			mismatches: []string{`body $.users: expected at least 1 items, got 0`},
+		},
+		{
+			name: "closest rule",
+			rules: map[string]RuleSet{
+				"$.users":        rule(Matcher{Match: "type"}),
+				"$.users[*].id":  rule(Matcher{Match: "regex", Regex: `\d+`}),
+				"$.users[0].tag": rule(Matcher{Match: "equality"}),
+			},
+			expected: `{"users": [{"id": "1", "tag": "a"}]}`, actual: `{"users": [{"id": "x", "tag": "b"}]}`,
+			mismatches: []string{
+				`body $.users[0].id: expected a value matching \d+, got "x"`,
+				`body $.users[0].tag: expected "a", got "b"`,
+			},
+		},
+		{
+			name:     "regex matches the whole value",
+			rules:    map[string]RuleSet{"$.status": rule(Matcher{Match: "regex", Regex: "active|suspended"})},
+			expected: `{"status": "active"}`, actual: `{"status": "inactive"}`,
+			mismatches: []string{`body $.status: expected a value matching active|suspended, got "inactive"`},
+		},
+		{
+			name:     "include",
+			rules:    map[string]RuleSet{"$.email": rule(Matcher{Match: "include", Value: "@"})},
+			expected: `{"email": "a@example.com"}`, actual: `{"email": "b@example.org"}`,
+		},
+		{
+			name:     "integer",
+			rules:    map[string]RuleSet{"$.count": rule(Matcher{Match: "integer"})},
+			expected: `{"count": 1}`, actual: `{"count": 1.5}`,
+			mismatches: []string{`body $.count: expected an integer, got 1.5`},
+		},
+		{
+			name: "or",
+			rules: map[string]RuleSet{"$.id": {Or: true, Matchers: []Matcher{
+				{Match: "integer"}, {Match: "regex", Regex: `\d+`},
+			}}},
+			expected: `{"id": 1}`, actual: `{"id": "2"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.mismatches, compareJSON(t, tt.rules, tt.expected, tt.actual))
+		})
+	}
+}
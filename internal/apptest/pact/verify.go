@@ -0,0 +1,221 @@
+package pact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// StateFunc sets up a provider state from its parameters, such as by
+// storing the user a request reads
+type StateFunc func(params map[string]any) error
+
+// Provider is a running provider an interaction is verified against
+type Provider struct {
+	// URL is the base URL of the provider, without a trailing slash
+	URL string
+
+	// Client sends the requests; http.DefaultClient when nil
+	Client *http.Client
+
+	// States sets up the provider states, by name. Interactions given in a
+	// state missing from it fail.
+	States map[string]StateFunc
+}
+
+// Verify replays every interaction of the pacts as a subtest named after
+// its consumer and description. Each interaction runs against a provider
+// of its own from start, so the states of one cannot leak into the next.
+func Verify(t *testing.T, pacts []*Pact, start func(t *testing.T) *Provider) {
+	t.Helper()
+
+	for _, p := range pacts {
+		for _, interaction := range p.Interactions {
+			t.Run(p.Consumer+"/"+interaction.Description, func(t *testing.T) {
+				provider := start(t)
+				for _, mismatch := range VerifyInteraction(provider, interaction) {
+					t.Error(mismatch)
+				}
+			})
+		}
+	}
+}
+
+// VerifyInteraction sets up the states of the interaction, sends its
+// request to the provider and compares the response to the expected one
+func VerifyInteraction(provider *Provider, interaction Interaction) []Mismatch {
+	for _, state := range interaction.States {
+		setUp, ok := provider.States[state.Name]
+		if !ok {
+			return []Mismatch{{Path: "provider state", Message: fmt.Sprintf("no provider state %q", state.Name)}}
+		}
+		if err := setUp(state.Params); err != nil {
+			return []Mismatch{{Path: "provider state", Message: fmt.Sprintf("failed to set up %q: %v", state.Name, err)}}
+		}
+	}
+
+	req, err := newRequest(provider.URL, interaction.Request)
+	if err != nil {
+		return []Mismatch{{Path: "request", Message: err.Error()}}
+	}
+
+	client := provider.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return []Mismatch{{Path: "request", Message: err.Error()}}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return []Mismatch{{Path: "response", Message: fmt.Sprintf("failed to read body: %v", err)}}
+	}
+	return compareResponse(interaction.Response, resp, body)
+}
+
+// newRequest builds the request of an interaction
+func newRequest(baseURL string, r Request) (*http.Request, error) {
+	var body io.Reader
+	if r.Body != nil {
+		payload := []byte(r.Body)
+		// Bodies of other content types are stored as JSON strings
+		var text string
+		if !isJSON(r.Headers.Get("Content-Type")) && json.Unmarshal(r.Body, &text) == nil {
+			payload = []byte(text)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	target := baseURL + r.Path
+	if len(r.Query) > 0 {
+		target += "?" + r.Query.Encode()
+	}
+	req, err := http.NewRequest(r.Method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+	for name, values := range r.Headers {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+	return req, nil
+}
+
+// compareResponse lists the differences of a response from the expected
+// one, sorted by path
+func compareResponse(expected Response, resp *http.Response, body []byte) []Mismatch {
+	var mismatches []Mismatch
+
+	if resp.StatusCode != expected.Status {
+		mismatches = append(mismatches, Mismatch{Path: "status", Message: fmt.Sprintf("expected %d, got %d", expected.Status, resp.StatusCode)})
+	}
+
+	for name, values := range expected.Headers {
+		if msg := compareHeader(name, values, resp.Header, expected.Rules.Header); msg != "" {
+			mismatches = append(mismatches, Mismatch{Path: "header " + http.CanonicalHeaderKey(name), Message: msg})
+		}
+	}
+
+	if expected.Body != nil {
+		mismatches = append(mismatches, compareBody(expected, resp.Header.Get("Content-Type"), body)...)
+	}
+
+	sort.SliceStable(mismatches, func(i, j int) bool {
+		return mismatches[i].Path < mismatches[j].Path
+	})
+	return mismatches
+}
+
+// compareHeader compares a header with the expected values
+func compareHeader(name string, expected []string, actual http.Header, rules map[string]RuleSet) string {
+	values := actual.Values(name)
+	if len(values) == 0 {
+		return "is missing"
+	}
+	got := strings.Join(values, ", ")
+
+	for ruleName, set := range rules {
+		if strings.EqualFold(ruleName, name) {
+			return set.check(strings.Join(expected, ", "), got)
+		}
+	}
+
+	if strings.EqualFold(name, "Content-Type") {
+		return compareMediaType(expected[0], got)
+	}
+	want := splitValues(expected)
+	if !reflect.DeepEqual(want, splitValues(values)) {
+		return fmt.Sprintf("expected %q, got %q", strings.Join(expected, ", "), got)
+	}
+	return ""
+}
+
+// splitValues splits header values on commas, trimming each
+func splitValues(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for part := range strings.SplitSeq(value, ",") {
+			split = append(split, strings.TrimSpace(part))
+		}
+	}
+	return split
+}
+
+// compareMediaType compares media types, ignoring the parameters the
+// expected one does not set
+func compareMediaType(expected, actual string) string {
+	wantType, wantParams, err := mime.ParseMediaType(expected)
+	if err != nil {
+		return fmt.Sprintf("invalid expected media type %q", expected)
+	}
+	gotType, gotParams, err := mime.ParseMediaType(actual)
+	if err != nil || gotType != wantType {
+		return fmt.Sprintf("expected %q, got %q", expected, actual)
+	}
+	for key, value := range wantParams {
+		if !strings.EqualFold(gotParams[key], value) {
+			return fmt.Sprintf("expected %q, got %q", expected, actual)
+		}
+	}
+	return ""
+}
+
+// compareBody compares a body with the expected one. Bodies of JSON
+// content types are compared as JSON, under the body rules; others as text.
+func compareBody(expected Response, contentType string, body []byte) []Mismatch {
+	var want any
+	if err := json.Unmarshal(expected.Body, &want); err != nil {
+		return []Mismatch{{Path: "body", Message: fmt.Sprintf("invalid expected body: %v", err)}}
+	}
+	matcher := newBodyMatcher(expected.Rules.Body)
+
+	text, isText := want.(string)
+	if isText && !isJSON(contentType) && !isJSON(expected.Headers.Get("Content-Type")) {
+		return matcher.compare([]string{"$"}, text, string(body))
+	}
+
+	var got any
+	if err := json.Unmarshal(body, &got); err != nil {
+		return []Mismatch{{Path: "body", Message: fmt.Sprintf("expected JSON, got %q", body)}}
+	}
+	return matcher.compare([]string{"$"}, want, got)
+}
+
+// isJSON reports whether a content type is JSON, such as application/json
+// or application/problem+json
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
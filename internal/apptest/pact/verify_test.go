@@ -0,0 +1,112 @@
+package pact
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// userPact expects the user stored by the "a user exists" state
+const userPact = `{
+  "consumer": {"name": "web"},
+  "interactions": [{
+    "description": "a request for a user",
+    "providerStates": [{"name": "a user exists", "params": {"name": "Ada"}}],
+    "request": {"method": "POST", "path": "/users/1", "headers": {"Content-Type": "text/plain"}, "body": "hello"},
+    "response": {
+      "status": 200,
+      "headers": {"Content-Type": "application/json", "Cache-Control": "no-store"},
+      "body": {"id": "1", "name": "Ada"},
+      "matchingRules": {"body": {"$.id": {"matchers": [{"match": "type"}]}}}
+    }
+  }]
+}`
+
+// newProvider serves the user stored by the "a user exists" state, echoing
+// the request body in a header
+func newProvider(t *testing.T, contentType string) *Provider {
+	t.Helper()
+
+	var name string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		if r.Method != http.MethodPost || r.URL.Path != "/users/1" || string(body) != "hello" || name == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = io.WriteString(w, `{"id": "42", "name": "`+name+`", "email": "ada@example.com"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	return &Provider{
+		URL: server.URL,
+		States: map[string]StateFunc{
+			"a user exists": func(params map[string]any) error {
+				name, _ = params["name"].(string)
+				return nil
+			},
+		},
+	}
+}
+
+// interaction parses the interaction of userPact
+func interaction(t *testing.T) Interaction {
+	t.Helper()
+
+	p, err := Parse([]byte(userPact))
+	require.NoError(t, err)
+	require.Len(t, p.Interactions, 1)
+	return p.Interactions[0]
+}
+
+func TestVerifyInteraction(t *testing.T) {
+	mismatches := VerifyInteraction(newProvider(t, "application/json; charset=utf-8"), interaction(t))
+	assert.Empty(t, mismatches)
+}
+
+func TestVerifyInteraction_Mismatches(t *testing.T) {
+	provider := newProvider(t, "text/html")
+
+	mismatches := VerifyInteraction(provider, interaction(t))
+	assert.Equal(t, []Mismatch{
+		{Path: "header Content-Type", Message: `expected "application/json", got "text/html"`},
+	}, mismatches)
+
+	setUp := provider.States["a user exists"]
+	provider.States["a user exists"] = func(map[string]any) error {
+		return setUp(map[string]any{"name": "Grace"})
+	}
+	mismatches = VerifyInteraction(provider, interaction(t))
+	assert.Equal(t, []Mismatch{
+		{Path: "body $.name", Message: `expected "Ada", got "Grace"`},
+		{Path: "header Content-Type", Message: `expected "application/json", got "text/html"`},
+	}, mismatches)
+
+	provider.States["a user exists"] = func(map[string]any) error {
+		// Stores no user
+		return setUp(map[string]any{})
+	}
+	mismatches = VerifyInteraction(provider, interaction(t))
+	assert.Equal(t, []Mismatch{
+		{Path: "body", Message: `expected JSON, got "404 page not found\n"`},
+		{Path: "header Cache-Control", Message: "is missing"},
+		{Path: "header Content-Type", Message: `expected "application/json", got "text/plain; charset=utf-8"`},
+		{Path: "status", Message: "expected 200, got 404"},
+	}, mismatches)
+
+	provider.States["a user exists"] = func(map[string]any) error { return errors.New("boom") }
+	mismatches = VerifyInteraction(provider, interaction(t))
+	assert.Equal(t, []Mismatch{{Path: "provider state", Message: `failed to set up "a user exists": boom`}}, mismatches)
+
+	delete(provider.States, "a user exists")
+	mismatches = VerifyInteraction(provider, interaction(t))
+	assert.Equal(t, []Mismatch{{Path: "provider state", Message: `no provider state "a user exists"`}}, mismatches)
+}
@@ -0,0 +1,28 @@
+package apptest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/apptest/pact"
+)
+
+// TestPactProvider verifies the pacts of testdata/pacts, or of the
+// directory in PACT_DIR, such as pacts fetched from a broker, against the
+// application with in-memory repositories
+func TestPactProvider(t *testing.T) {
+	dir := os.Getenv("PACT_DIR")
+	if dir == "" {
+		dir = filepath.Join("testdata", "pacts")
+	}
+	pacts, err := pact.LoadDir(dir)
+	require.NoError(t, err)
+	require.NotEmpty(t, pacts, "no pacts in %s", dir)
+
+	pact.Verify(t, pacts, func(t *testing.T) *pact.Provider {
+		return StartTestApp(t, Options{}).Provider()
+	})
+}
@@ -0,0 +1,58 @@
+package apptest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/yourusername/go-scaffolding/internal/apptest/pact"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// Provider returns the app as a Pact provider, with the provider states
+// consumers may give their interactions in:
+//
+//   - "a user exists", with the optional params id, email, name and
+//     username of the user
+//   - "no users exist"
+func (a *App) Provider() *pact.Provider {
+	return &pact.Provider{
+		URL:    a.URL,
+		Client: a.Client,
+		States: map[string]pact.StateFunc{
+			"a user exists": a.userExists,
+			"no users exist": func(map[string]any) error {
+				// Every interaction gets an app of its own
+				return nil
+			},
+		},
+	}
+}
+
+// userExists stores a user with the given params
+func (a *App) userExists(params map[string]any) error {
+	param := func(name, fallback string) string {
+		if value, ok := params[name]; ok {
+			return fmt.Sprint(value)
+		}
+		return fallback
+	}
+
+	user, err := domain.NewUser(
+		param("id", "0190c6d2-7b1e-7a3c-9d4e-5f6a7b8c9d0e"),
+		param("email", "jane@example.com"),
+		param("name", "Jane"),
+		a.Clock.Now(),
+	)
+	if err != nil {
+		return err
+	}
+	if username, ok := params["username"]; ok {
+		if err := user.ChangeUsername(fmt.Sprint(username), a.Clock.Now()); err != nil {
+			return err
+		}
+	}
+	user.AssignEventIDs(uuid.NewString)
+	return a.Users.Create(context.Background(), user)
+}
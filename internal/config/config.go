@@ -1,20 +1,38 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
+	"golang.org/x/text/language"
 )
 
 // Config holds all application configuration
 type Config struct {
 	App           AppConfig
+	HTTP          HTTPConfig
+	GRPC          GRPCConfig
+	TLS           TLSConfig
+	Admin         AdminConfig
+	Startup       StartupConfig
+	Tenancy       TenancyConfig
 	Postgres      PostgresConfig
 	MongoDB       MongoDBConfig
 	Redis         RedisConfig
+	Locks         LocksConfig
+	Cache         CacheConfig
+	Leader        LeaderConfig
 	Observability ObservabilityConfig
+	Users         UsersConfig
+	Organizations OrganizationsConfig
+	Mailer        MailerConfig
+	Storage       StorageConfig
+	Seed          SeedConfig
 }
 
 // AppConfig holds application-level configuration
@@ -26,6 +44,253 @@ type AppConfig struct {
 	LogLevel    string `mapstructure:"log_level"`
 }
 
+// HTTPConfig holds HTTP server behaviour shared by all routes
+type HTTPConfig struct {
+	Router         string             `mapstructure:"router"`          // router dispatching the user routes on the Gin server: gin or chi
+	TrustedProxies []string           `mapstructure:"trusted_proxies"` // networks whose X-Forwarded-For is believed; empty trusts none
+	JSON           JSONConfig         `mapstructure:"json"`
+	Idempotency    IdempotencyConfig  `mapstructure:"idempotency"`
+	Timeout        TimeoutConfig      `mapstructure:"timeout"`
+	CacheControl   CacheControlConfig `mapstructure:"cache_control"`
+	Locale         LocaleConfig       `mapstructure:"locale"`
+	LoadShed       LoadShedConfig     `mapstructure:"load_shedding"`
+	Quota          QuotaConfig        `mapstructure:"quota"`
+	IPFilter       IPFilterConfig     `mapstructure:"ip_filter"`
+	Gateway        GatewayConfig      `mapstructure:"gateway"`
+	GraphQL        GraphQLConfig      `mapstructure:"graphql"`
+	Fixtures       FixturesConfig     `mapstructure:"fixtures"`
+}
+
+// httpRouters are the routers of HTTPConfig
+var httpRouters = []string{"gin", "chi"}
+
+// validate rejects unknown routers, fixture recording without a directory
+// and invalid languages
+func (c HTTPConfig) validate() error {
+	if !slices.Contains(httpRouters, c.Router) {
+		return fmt.Errorf("unknown http router: %q", c.Router)
+	}
+	if c.Fixtures.Record && c.Fixtures.Dir == "" {
+		return errors.New("http.fixtures.dir is required to record fixtures")
+	}
+	if c.Fixtures.Record && c.Fixtures.MaxBodyBytes <= 0 {
+		return fmt.Errorf("http.fixtures.max_body_bytes must be positive: %d", c.Fixtures.MaxBodyBytes)
+	}
+	return c.Locale.validate()
+}
+
+// FixturesConfig holds the recording of requests and their responses to
+// Dir, one file each, redacted with the strict policy, for cmd/replay to
+// serve as a stub server. Bodies are cut to MaxBodyBytes.
+type FixturesConfig struct {
+	Record       bool   `mapstructure:"record"`
+	Dir          string `mapstructure:"dir"`
+	MaxBodyBytes int    `mapstructure:"max_body_bytes"`
+}
+
+// GatewayConfig holds the REST API generated from the protos by
+// grpc-gateway, served under /v1 next to the handwritten routes
+type GatewayConfig struct {
+	Enabled          bool `mapstructure:"enabled"`
+	ValidateRequests bool `mapstructure:"validate_requests"` // reject requests the served OpenAPI spec does not allow
+}
+
+// GraphQLConfig holds the GraphQL API of the users, served at /graphql.
+// The playground and introspection are only served outside production.
+type GraphQLConfig struct {
+	Enabled       bool                `mapstructure:"enabled"`
+	Subscriptions SubscriptionsConfig `mapstructure:"subscriptions"`
+}
+
+// SubscriptionsConfig holds the GraphQL subscriptions pushing user changes
+// over WebSocket. Connections authenticate with a bearer token in their
+// connection_init payload.
+type SubscriptionsConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	JWTSecret      string        `mapstructure:"jwt_secret"`      // HMAC key bearer tokens are signed with
+	MaxConnections int           `mapstructure:"max_connections"` // 0 disables the limit
+	KeepAlive      time.Duration `mapstructure:"keep_alive"`      // ping interval of idle connections
+}
+
+// GRPCConfig holds the gRPC server configuration. The server listens on
+// app.grpc_port.
+type GRPCConfig struct {
+	Enabled           bool           `mapstructure:"enabled"`
+	Auth              GRPCAuthConfig `mapstructure:"auth"`
+	StreamSendTimeout time.Duration  `mapstructure:"stream_send_timeout"` // longest wait for a client to accept one streamed message
+}
+
+// GRPCAuthConfig holds the credentials gRPC calls are accepted with
+type GRPCAuthConfig struct {
+	Enabled       bool              `mapstructure:"enabled"`
+	APIKeys       map[string]string `mapstructure:"api_keys"`       // client name to API key
+	JWTSecret     string            `mapstructure:"jwt_secret"`     // HMAC key bearer tokens are signed with
+	ExemptMethods []string          `mapstructure:"exempt_methods"` // full methods or "/service/" prefixes
+}
+
+// TLSConfig serves the HTTP and gRPC listeners over TLS and, with a
+// client CA, authenticates clients by their certificates
+type TLSConfig struct {
+	Enabled      bool              `mapstructure:"enabled"`
+	CertFile     string            `mapstructure:"cert_file"`
+	KeyFile      string            `mapstructure:"key_file"`
+	ClientCAFile string            `mapstructure:"client_ca_file"` // empty does not ask for client certificates
+	ClientAuth   string            `mapstructure:"client_auth"`    // require or optional
+	Principals   map[string]string `mapstructure:"principals"`     // certificate identity to principal; empty maps each identity to itself
+}
+
+// AdminConfig holds access rules for the operational routes under /admin
+type AdminConfig struct {
+	Token           string   `mapstructure:"token"`            // bearer token; empty disables the admin routes
+	AllowedNetworks []string `mapstructure:"allowed_networks"` // CIDRs or addresses; empty allows any client
+}
+
+// JSONConfig holds JSON request body decoding rules
+type JSONConfig struct {
+	Strict       bool  `mapstructure:"strict"`
+	MaxBodyBytes int64 `mapstructure:"max_body_bytes"`
+}
+
+// IdempotencyConfig holds Idempotency-Key handling for POST requests
+type IdempotencyConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`
+	TTL             time.Duration `mapstructure:"ttl"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval"`
+	MaxBodyBytes    int64         `mapstructure:"max_body_bytes"`
+}
+
+// TimeoutConfig holds the deadline applied to request handling
+type TimeoutConfig struct {
+	Default time.Duration            `mapstructure:"default"`
+	Routes  map[string]time.Duration `mapstructure:"routes"` // keyed by path prefix
+}
+
+// CacheControlConfig holds the Cache-Control header values of responses.
+// Routes and Default apply to GET and HEAD requests, Mutations to the other
+// methods, and Authenticated replaces them all for requests with
+// credentials; an empty value sets no header.
+type CacheControlConfig struct {
+	Enabled       bool              `mapstructure:"enabled"`
+	Default       string            `mapstructure:"default"`
+	Routes        map[string]string `mapstructure:"routes"` // keyed by path prefix
+	Mutations     string            `mapstructure:"mutations"`
+	Authenticated string            `mapstructure:"authenticated"`
+}
+
+// LocaleConfig holds the resolution of the language and time zone of
+// requests from Accept-Language and X-Timezone. Languages are BCP 47 tags,
+// the first answering clients without an acceptable preference.
+type LocaleConfig struct {
+	Enabled   bool     `mapstructure:"enabled"`
+	Languages []string `mapstructure:"languages"`
+}
+
+func (c LocaleConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.Languages) == 0 {
+		return errors.New("http.locale.languages must not be empty")
+	}
+	for _, tag := range c.Languages {
+		if _, err := language.Parse(tag); err != nil {
+			return fmt.Errorf("invalid http.locale.languages tag %q: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+// LoadShedConfig holds the concurrency limits protecting the database
+// under traffic spikes
+type LoadShedConfig struct {
+	Enabled    bool                              `mapstructure:"enabled"`
+	Limit      ConcurrencyLimitConfig            `mapstructure:",squash"`
+	RetryAfter time.Duration                     `mapstructure:"retry_after"`
+	Routes     map[string]ConcurrencyLimitConfig `mapstructure:"routes"` // keyed by path prefix
+}
+
+// ConcurrencyLimitConfig bounds the requests a route group handles at once
+type ConcurrencyLimitConfig struct {
+	MaxConcurrent int           `mapstructure:"max_concurrent"`
+	MaxQueue      int           `mapstructure:"max_queue"`
+	MaxWait       time.Duration `mapstructure:"max_wait"`
+}
+
+// QuotaConfig holds the daily and monthly request quotas of API clients,
+// identified by the X-API-Key header, and of tenants. A limit of 0 leaves
+// the period unlimited.
+type QuotaConfig struct {
+	Enabled         bool                         `mapstructure:"enabled"`
+	Daily           int64                        `mapstructure:"daily"`
+	Monthly         int64                        `mapstructure:"monthly"`
+	APIKeys         map[string]string            `mapstructure:"api_keys"`  // client name to API key
+	Overrides       map[string]QuotaLimitsConfig `mapstructure:"overrides"` // keyed by subject, such as api_key:partner or tenant:acme
+	ExemptPaths     []string                     `mapstructure:"exempt_paths"`
+	CleanupInterval time.Duration                `mapstructure:"cleanup_interval"`
+}
+
+// QuotaLimitsConfig holds the requests allowed per UTC day and month
+type QuotaLimitsConfig struct {
+	Daily   int64 `mapstructure:"daily"`
+	Monthly int64 `mapstructure:"monthly"`
+}
+
+// IPFilterConfig restricts route groups to client networks. It is read
+// again on SIGHUP, so the lists can change without a restart.
+type IPFilterConfig struct {
+	Enabled bool                           `mapstructure:"enabled"`
+	Routes  map[string]IPFilterRulesConfig `mapstructure:"routes"` // keyed by path prefix
+}
+
+// IPFilterRulesConfig holds the CIDRs or addresses a route group allows and
+// denies. Deny wins; an empty allow list allows every address not denied.
+type IPFilterRulesConfig struct {
+	Allow []string `mapstructure:"allow"`
+	Deny  []string `mapstructure:"deny"`
+}
+
+// StartupConfig bounds how long the application waits for its
+// dependencies to become reachable before it fails to start
+type StartupConfig struct {
+	WaitTimeout    time.Duration `mapstructure:"wait_timeout"` // zero tries once
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff"`
+	Warmup         WarmupConfig  `mapstructure:"warmup"`
+}
+
+// WarmupConfig holds the warm-up run once the application is wired, such as
+// opening database connections, before readiness reports healthy
+type WarmupConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	TaskTimeout time.Duration `mapstructure:"task_timeout"` // bounds each task registered without a timeout of its own
+}
+
+func (c *WarmupConfig) validate() error {
+	if c.Enabled && c.TaskTimeout <= 0 {
+		return fmt.Errorf("startup.warmup.task_timeout must be positive: %s", c.TaskTimeout)
+	}
+	return nil
+}
+
+// TenancyConfig holds multi-tenancy configuration
+type TenancyConfig struct {
+	Enabled   bool             `mapstructure:"enabled"`
+	Resolver  string           `mapstructure:"resolver"` // header, subdomain or jwt
+	Header    string           `mapstructure:"header"`
+	Domain    string           `mapstructure:"domain"` // tenants are served at <tenant>.<domain>
+	JWT       TenancyJWTConfig `mapstructure:"jwt"`
+	Isolation string           `mapstructure:"isolation"` // shared_schema or schema_per_tenant
+
+	// ExemptPaths are path prefixes served without a tenant
+	ExemptPaths []string `mapstructure:"exempt_paths"`
+}
+
+// TenancyJWTConfig holds the verification of bearer tokens naming the tenant
+type TenancyJWTConfig struct {
+	Claim  string `mapstructure:"claim"`
+	Secret string `mapstructure:"secret"` // HMAC key the tokens are signed with
+}
+
 // PostgresConfig holds PostgreSQL configuration
 type PostgresConfig struct {
 	Host            string        `mapstructure:"host"`
@@ -38,6 +303,73 @@ type PostgresConfig struct {
 	MaxOpenConns    int           `mapstructure:"max_open_conns"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
 	LogLevel        string        `mapstructure:"log_level"`
+
+	// ConnMaxIdleTime closes connections idle for longer, so a pool shrinks
+	// back after a burst instead of holding max_idle_conns connections open
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+
+	// PasswordFile, when set, holds the password instead of Password, e.g.
+	// written by a Vault agent. It is read again every PasswordReloadInterval
+	// so rotated credentials apply without a restart.
+	PasswordFile           string        `mapstructure:"password_file"`
+	PasswordReloadInterval time.Duration `mapstructure:"password_reload_interval"`
+
+	// Auth selects how connections authenticate: "password" (default),
+	// "rds_iam" for AWS RDS IAM tokens, or "cloudsql" for the Cloud SQL Go
+	// connector, with Host set to the instance connection name
+	Auth     string                 `mapstructure:"auth"`
+	RDS      PostgresRDSConfig      `mapstructure:"rds"`
+	CloudSQL PostgresCloudSQLConfig `mapstructure:"cloudsql"`
+
+	// PrepareStmt caches prepared statements per connection; disable it
+	// behind a pooler in transaction mode such as PgBouncer
+	PrepareStmt bool `mapstructure:"prepare_stmt"`
+
+	// DeadlineTimeouts sets the statement_timeout of each statement whose
+	// context has a deadline, such as that of its request, to the time left;
+	// see database.UseDeadlineTimeouts
+	DeadlineTimeouts bool `mapstructure:"deadline_timeouts"`
+
+	// Replicas receive reads of requests that allow them; see database.ReadFromReplica
+	Replicas             []PostgresReplicaConfig `mapstructure:"replicas"`
+	ReplicaCheckInterval time.Duration           `mapstructure:"replica_check_interval"`
+}
+
+// validatePool rejects negative pool settings, which database/sql would
+// silently treat as no limit or no idle connections
+func (c PostgresConfig) validatePool() error {
+	switch {
+	case c.MaxIdleConns < 0:
+		return fmt.Errorf("postgres.max_idle_conns must not be negative: %d", c.MaxIdleConns)
+	case c.MaxOpenConns < 0:
+		return fmt.Errorf("postgres.max_open_conns must not be negative: %d", c.MaxOpenConns)
+	case c.ConnMaxLifetime < 0:
+		return fmt.Errorf("postgres.conn_max_lifetime must not be negative: %s", c.ConnMaxLifetime)
+	case c.ConnMaxIdleTime < 0:
+		return fmt.Errorf("postgres.conn_max_idle_time must not be negative: %s", c.ConnMaxIdleTime)
+	}
+	return nil
+}
+
+// PostgresRDSConfig holds AWS RDS IAM authentication settings
+type PostgresRDSConfig struct {
+	Region string `mapstructure:"region"` // defaults to the region of the AWS SDK, e.g. AWS_REGION
+}
+
+// PostgresCloudSQLConfig holds Cloud SQL connector settings
+type PostgresCloudSQLConfig struct {
+	IAMAuthN bool   `mapstructure:"iam_authn"` // log in as the IAM principal in User, without a password
+	IPType   string `mapstructure:"ip_type"`   // public, private or psc
+}
+
+// PostgresReplicaConfig holds a PostgreSQL read replica. Unset fields are
+// taken from the primary.
+type PostgresReplicaConfig struct {
+	Name     string `mapstructure:"name"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	User     string `mapstructure:"user"`
+	Password string `mapstructure:"password"`
 }
 
 // MongoDBConfig holds MongoDB configuration
@@ -54,10 +386,745 @@ type RedisConfig struct {
 	DB       int    `mapstructure:"db"`
 }
 
+// SeedConfig holds the demo data loaded by cmd/seed, and by the API server
+// when Enabled. Seeding is refused in production.
+type SeedConfig struct {
+	Enabled  bool   `mapstructure:"enabled"`  // seed when the API server starts, as its --seed flag does
+	Fixtures string `mapstructure:"fixtures"` // YAML fixture file; empty loads the built-in demo data
+}
+
+// LocksConfig holds the locks that keep scheduled jobs and imports on one
+// instance at a time. Driver is memory, postgres or redis; memory locks
+// are not shared, so they only suit a single instance.
+type LocksConfig struct {
+	Driver string `mapstructure:"driver"`
+	Prefix string `mapstructure:"prefix"` // prefix of the Redis keys
+}
+
+// lockDrivers are the drivers of LocksConfig
+var lockDrivers = []string{"memory", "postgres", "redis"}
+
+// validate checks the driver against the storage and Redis it needs
+func (c LocksConfig) validate(storage StorageConfig, redis RedisConfig) error {
+	if !slices.Contains(lockDrivers, c.Driver) {
+		return fmt.Errorf("unknown locks driver: %q", c.Driver)
+	}
+	if c.Driver == "postgres" && storage.InMemory() {
+		return errors.New("locks.driver postgres requires PostgreSQL, not the memory storage driver")
+	}
+	if c.Driver == "redis" && !redis.Enabled() {
+		return errors.New("locks.driver redis requires redis.host")
+	}
+	return nil
+}
+
+// CacheConfig holds the cache of the users read by ID, email and username.
+// Driver is none, memory or redis; the memory cache is not shared, so a
+// change on one instance is not seen by the others until TTL passes unless
+// Invalidation fans the changes out to them.
+type CacheConfig struct {
+	Driver       string                  `mapstructure:"driver"`
+	TTL          time.Duration           `mapstructure:"ttl"`
+	Prefix       string                  `mapstructure:"prefix"` // prefix of the Redis keys
+	Memory       MemoryCacheConfig       `mapstructure:"memory"`
+	Invalidation CacheInvalidationConfig `mapstructure:"invalidation"`
+}
+
+// MemoryCacheConfig holds the in-process cache, which evicts the values
+// used least often once MaxBytes are held
+type MemoryCacheConfig struct {
+	MaxBytes int64 `mapstructure:"max_bytes"`
+}
+
+// CacheInvalidationConfig holds the fan-out of the memory cache
+// invalidations between replicas. Driver is none, redis, publishing on a
+// Redis channel, or postgres, notifying a PostgreSQL channel.
+type CacheInvalidationConfig struct {
+	Driver  string `mapstructure:"driver"`
+	Channel string `mapstructure:"channel"`
+}
+
+// cacheDrivers are the drivers of CacheConfig
+var cacheDrivers = []string{"none", "memory", "redis"}
+
+// cacheInvalidationDrivers are the drivers of CacheInvalidationConfig
+var cacheInvalidationDrivers = []string{"none", "redis", "postgres"}
+
+// Enabled reports whether users are cached
+func (c CacheConfig) Enabled() bool {
+	return c.Driver != "none"
+}
+
+// Enabled reports whether invalidations are fanned out
+func (c CacheInvalidationConfig) Enabled() bool {
+	return c.Driver != "none"
+}
+
+// validate checks the drivers against the storage and Redis they need
+func (c CacheConfig) validate(storage StorageConfig, redis RedisConfig, users UsersConfig) error {
+	if !slices.Contains(cacheDrivers, c.Driver) {
+		return fmt.Errorf("unknown cache driver: %q", c.Driver)
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	if c.TTL <= 0 {
+		return fmt.Errorf("cache.ttl must be positive: %s", c.TTL)
+	}
+	if c.Driver == "memory" && c.Memory.MaxBytes <= 0 {
+		return fmt.Errorf("cache.memory.max_bytes must be positive: %d", c.Memory.MaxBytes)
+	}
+	if c.Driver == "redis" && !redis.Enabled() {
+		return errors.New("cache.driver redis requires redis.host")
+	}
+	if c.Driver == "redis" && users.Encryption.Enabled {
+		// Cached users hold their emails and names in plaintext
+		return errors.New("cache.driver redis cannot be used with users.encryption")
+	}
+	return c.Invalidation.validate(c, storage, redis)
+}
+
+// validate checks the driver against the cache it invalidates and the
+// storage and Redis it needs
+func (c CacheInvalidationConfig) validate(cache CacheConfig, storage StorageConfig, redis RedisConfig) error {
+	if !slices.Contains(cacheInvalidationDrivers, c.Driver) {
+		return fmt.Errorf("unknown cache invalidation driver: %q", c.Driver)
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	if cache.Driver != "memory" {
+		return errors.New("cache.invalidation requires cache.driver memory")
+	}
+	if c.Channel == "" {
+		return errors.New("cache.invalidation.channel is required")
+	}
+	if c.Driver == "redis" && !redis.Enabled() {
+		return errors.New("cache.invalidation.driver redis requires redis.host")
+	}
+	if c.Driver == "postgres" && storage.InMemory() {
+		return errors.New("cache.invalidation.driver postgres requires PostgreSQL, not the memory storage driver")
+	}
+	return nil
+}
+
+// LeaderConfig holds the election of the replica running the scheduler and
+// change data capture. Driver is kubernetes, holding a Lease object in
+// Namespace, or postgres, holding a row of the leader_leases table. An
+// empty Namespace uses the namespace of the pod, an empty Name uses
+// app.name and an empty Identity the host name with a random suffix.
+type LeaderConfig struct {
+	Enabled       bool          `mapstructure:"enabled"`
+	Driver        string        `mapstructure:"driver"`
+	Name          string        `mapstructure:"name"`
+	Identity      string        `mapstructure:"identity"`
+	Namespace     string        `mapstructure:"namespace"`
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+	RenewDeadline time.Duration `mapstructure:"renew_deadline"`
+	RetryPeriod   time.Duration `mapstructure:"retry_period"`
+}
+
+// leaderDrivers are the drivers of LeaderConfig
+var leaderDrivers = []string{"kubernetes", "postgres"}
+
+// validate checks the driver against the storage and the timings against
+// each other: the leader must stop before its lease expires, and retry
+// renewing it before the deadline
+func (c LeaderConfig) validate(storage StorageConfig) error {
+	if !c.Enabled {
+		return nil
+	}
+	if !slices.Contains(leaderDrivers, c.Driver) {
+		return fmt.Errorf("unknown leader driver: %q", c.Driver)
+	}
+	if c.Driver == "postgres" && storage.InMemory() {
+		return errors.New("leader.driver postgres requires PostgreSQL, not the memory storage driver")
+	}
+	if c.RetryPeriod <= 0 {
+		return errors.New("leader.retry_period must be positive")
+	}
+	if c.RenewDeadline <= c.RetryPeriod {
+		return errors.New("leader.renew_deadline must be longer than leader.retry_period")
+	}
+	if c.LeaseDuration <= c.RenewDeadline {
+		return errors.New("leader.lease_duration must be longer than leader.renew_deadline")
+	}
+	return nil
+}
+
 // ObservabilityConfig holds observability configuration
 type ObservabilityConfig struct {
-	LogLevel       string `mapstructure:"log_level"`
-	JaegerEndpoint string `mapstructure:"jaeger_endpoint"`
+	LogLevel       string          `mapstructure:"log_level"`
+	JaegerEndpoint string          `mapstructure:"jaeger_endpoint"` // Deprecated: use Tracing with the jaeger exporter
+	Tracing        TracingConfig   `mapstructure:"tracing"`
+	Redaction      RedactionConfig `mapstructure:"redaction"`
+	Capture        CaptureConfig   `mapstructure:"capture"`
+	Alerts         AlertsConfig    `mapstructure:"alerts"`
+}
+
+// TracingConfig holds the export of traces. Exporter is none, otlp_grpc,
+// otlp_http, jaeger or zipkin; an empty Endpoint uses the default of the
+// exporter, and an empty ServiceName uses app.name.
+type TracingConfig struct {
+	Exporter    string            `mapstructure:"exporter"`
+	Endpoint    string            `mapstructure:"endpoint"`
+	Headers     map[string]string `mapstructure:"headers"`
+	ServiceName string            `mapstructure:"service_name"`
+	Sampling    SamplingConfig    `mapstructure:"sampling"`
+}
+
+// SamplingConfig holds the share of new traces recorded, overridden per
+// route group keyed by path prefix. Spans continuing a trace follow the
+// decision of their parent.
+type SamplingConfig struct {
+	Ratio  float64            `mapstructure:"ratio"`
+	Routes map[string]float64 `mapstructure:"routes"`
+}
+
+// traceExporters are the valid trace exporters
+var traceExporters = []string{"none", "otlp_grpc", "otlp_http", "jaeger", "zipkin"}
+
+// validate rejects unknown exporters and ratios outside 0 to 1
+func (c TracingConfig) validate() error {
+	if !slices.Contains(traceExporters, c.Exporter) {
+		return fmt.Errorf("unknown trace exporter: %q", c.Exporter)
+	}
+	if r := c.Sampling.Ratio; r < 0 || r > 1 {
+		return fmt.Errorf("trace sampling ratio must be between 0 and 1: %v", r)
+	}
+	for prefix, r := range c.Sampling.Routes {
+		if r < 0 || r > 1 {
+			return fmt.Errorf("trace sampling ratio of %s must be between 0 and 1: %v", prefix, r)
+		}
+	}
+	return nil
+}
+
+// AlertsConfig holds the alerts on requests and database queries that keep
+// exceeding their latency threshold. An alert fires when a route or
+// statement is slow Occurrences times within Window, and then not again for
+// it until Cooldown passes. Hook is log, webhook or pagerduty. A zero
+// threshold disables its alerts; SlowRequestRoutes overrides SlowRequest
+// per route group keyed by path prefix.
+type AlertsConfig struct {
+	Enabled             bool                     `mapstructure:"enabled"`
+	Hook                string                   `mapstructure:"hook"`
+	WebhookURL          string                   `mapstructure:"webhook_url"`
+	PagerDutyRoutingKey string                   `mapstructure:"pagerduty_routing_key"`
+	Window              time.Duration            `mapstructure:"window"`
+	Occurrences         int                      `mapstructure:"occurrences"`
+	Cooldown            time.Duration            `mapstructure:"cooldown"`
+	SlowRequest         time.Duration            `mapstructure:"slow_request"`
+	SlowRequestRoutes   map[string]time.Duration `mapstructure:"slow_request_routes"`
+	SlowQuery           time.Duration            `mapstructure:"slow_query"`
+}
+
+// alertHooks are the valid alert hooks
+var alertHooks = []string{"log", "webhook", "pagerduty"}
+
+// validate rejects unknown hooks and hooks missing their destination
+func (c AlertsConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if !slices.Contains(alertHooks, c.Hook) {
+		return fmt.Errorf("unknown alert hook: %q", c.Hook)
+	}
+	if c.Hook == "webhook" && c.WebhookURL == "" {
+		return errors.New("observability.alerts.webhook_url is required by the webhook hook")
+	}
+	if c.Hook == "pagerduty" && c.PagerDutyRoutingKey == "" {
+		return errors.New("observability.alerts.pagerduty_routing_key is required by the pagerduty hook")
+	}
+	if c.Occurrences < 1 {
+		return fmt.Errorf("observability.alerts.occurrences must be at least 1: %d", c.Occurrences)
+	}
+	return nil
+}
+
+// CaptureConfig holds the capture of request and response bodies for
+// troubleshooting. When enabled, capturing is still off until turned on
+// through the admin routes for some routes or request IDs, for at most
+// MaxDuration. Bodies are cut to MaxBodyBytes and always redacted with the
+// strict policy.
+type CaptureConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	MaxBodyBytes int           `mapstructure:"max_body_bytes"`
+	BufferSize   int           `mapstructure:"buffer_size"`
+	Log          bool          `mapstructure:"log"`
+	MaxDuration  time.Duration `mapstructure:"max_duration"`
+}
+
+// RedactionConfig holds the removal of personal data and secrets from logs.
+// Fields are matched as substrings of field and query parameter names,
+// ignoring case. Policies maps app.environment to off, fields or strict;
+// Policy, when set, applies to every environment instead.
+type RedactionConfig struct {
+	Fields   []string          `mapstructure:"fields"`
+	Policies map[string]string `mapstructure:"policies"`
+	Policy   string            `mapstructure:"policy"`
+}
+
+// redactionPolicies are the valid redaction policies
+var redactionPolicies = []string{"off", "fields", "strict"}
+
+// PolicyFor returns the redaction policy of the environment, strict for
+// environments without one
+func (c RedactionConfig) PolicyFor(environment string) string {
+	if c.Policy != "" {
+		return c.Policy
+	}
+	if policy, ok := c.Policies[environment]; ok {
+		return policy
+	}
+	return "strict"
+}
+
+// validate rejects unknown policies, so a typo does not turn redaction off
+func (c RedactionConfig) validate() error {
+	policies := []string{c.Policy}
+	for _, policy := range c.Policies {
+		policies = append(policies, policy)
+	}
+	for _, policy := range policies {
+		if policy != "" && !slices.Contains(redactionPolicies, policy) {
+			return fmt.Errorf("unknown redaction policy: %q", policy)
+		}
+	}
+	return nil
+}
+
+// UsersConfig holds user module configuration
+type UsersConfig struct {
+	RequireEmailVerification bool                  `mapstructure:"require_email_verification"`
+	EmailChangeTokenTTL      time.Duration         `mapstructure:"email_change_token_ttl"`
+	AdminToken               string                `mapstructure:"admin_token"` // Deprecated: use Admin.Token
+	LegacyEmailRoute         bool                  `mapstructure:"legacy_email_route"`
+	ResponseFormats          []string              `mapstructure:"response_formats"`        // json, jsonapi, xml or msgpack, negotiated by Accept
+	DefaultResponseFormat    string                `mapstructure:"default_response_format"` // format of clients without a preference
+	IDGenerator              string                `mapstructure:"id_generator"`
+	Repository               string                `mapstructure:"repository"`
+	Retention                RetentionConfig       `mapstructure:"retention"`
+	Preferences              PreferencesConfig     `mapstructure:"preferences"`
+	EmailValidation          EmailValidationConfig `mapstructure:"email_validation"`
+	Passwords                PasswordsConfig       `mapstructure:"passwords"`
+	Encryption               EncryptionConfig      `mapstructure:"encryption"`
+	Events                   EventsConfig          `mapstructure:"events"`
+	ReadModel                ReadModelConfig       `mapstructure:"read_model"`
+	Stats                    StatsConfig           `mapstructure:"stats"`
+	Jobs                     JobsConfig            `mapstructure:"jobs"`
+	Availability             AvailabilityConfig    `mapstructure:"availability"`
+	LoginRisk                LoginRiskConfig       `mapstructure:"login_risk"`
+}
+
+// AvailabilityConfig holds GET /users/availability, which tells signup
+// forms whether an email address or username is free. Each client IP may
+// check Requests times per Interval, in bursts of up to Burst, and may
+// cache answers for MaxAge.
+type AvailabilityConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Requests int           `mapstructure:"requests"`
+	Interval time.Duration `mapstructure:"interval"`
+	Burst    int           `mapstructure:"burst"`
+	MaxAge   time.Duration `mapstructure:"max_age"` // 0 disables caching
+}
+
+// validate rejects availability settings it cannot limit with
+func (c AvailabilityConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	switch {
+	case c.Requests < 1:
+		return fmt.Errorf("invalid users.availability.requests: %d", c.Requests)
+	case c.Interval <= 0:
+		return fmt.Errorf("invalid users.availability.interval: %s", c.Interval)
+	case c.Burst < 1:
+		return fmt.Errorf("invalid users.availability.burst: %d", c.Burst)
+	case c.MaxAge < 0:
+		return fmt.Errorf("invalid users.availability.max_age: %s", c.MaxAge)
+	}
+	return nil
+}
+
+// LoginRiskConfig holds the rules logins whose password matched are
+// evaluated with, against the user's History latest logins. The action of
+// a rule is none, notify to email the user, or step_up to ask for a
+// second factor. CountryHeader names the header the CDN or proxy in front
+// of the service sets to the client's country, such as CF-IPCountry; it is
+// only read from trusted proxies, and logins have no country without it.
+type LoginRiskConfig struct {
+	Enabled       bool                `mapstructure:"enabled"`
+	History       int                 `mapstructure:"history"`
+	CountryHeader string              `mapstructure:"country_header"`
+	NewCountry    string              `mapstructure:"new_country"`
+	NewDevice     string              `mapstructure:"new_device"`
+	Velocity      LoginVelocityConfig `mapstructure:"velocity"`
+}
+
+// LoginVelocityConfig holds the rule matching logins preceded by
+// MaxFailures failed attempts within Window
+type LoginVelocityConfig struct {
+	Window      time.Duration `mapstructure:"window"`
+	MaxFailures int           `mapstructure:"max_failures"` // 0 disables the rule
+	Action      string        `mapstructure:"action"`
+}
+
+// validate rejects login risk settings the rules cannot apply
+func (c LoginRiskConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	for key, action := range map[string]string{"new_country": c.NewCountry, "new_device": c.NewDevice, "velocity.action": c.Velocity.Action} {
+		switch action {
+		case "none", "notify", "step_up":
+		default:
+			return fmt.Errorf("invalid users.login_risk.%s: %q", key, action)
+		}
+	}
+
+	switch {
+	case c.History < 1:
+		return fmt.Errorf("invalid users.login_risk.history: %d", c.History)
+	case c.Velocity.Window <= 0:
+		return fmt.Errorf("invalid users.login_risk.velocity.window: %s", c.Velocity.Window)
+	case c.Velocity.MaxFailures < 0:
+		return fmt.Errorf("invalid users.login_risk.velocity.max_failures: %d", c.Velocity.MaxFailures)
+	}
+	return nil
+}
+
+// JobsConfig holds the background import and export jobs. Every
+// CheckInterval, jobs not saved for StaleAfter, whose instance stopped, are
+// resumed, and jobs finished longer than Retention ago are deleted with
+// their files.
+type JobsConfig struct {
+	Retention     time.Duration `mapstructure:"retention"`
+	StaleAfter    time.Duration `mapstructure:"stale_after"`
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+}
+
+// validate rejects job settings that are not positive
+func (c JobsConfig) validate() error {
+	switch {
+	case c.Retention <= 0:
+		return fmt.Errorf("invalid users.jobs.retention: %s", c.Retention)
+	case c.StaleAfter <= 0:
+		return fmt.Errorf("invalid users.jobs.stale_after: %s", c.StaleAfter)
+	case c.CheckInterval <= 0:
+		return fmt.Errorf("invalid users.jobs.check_interval: %s", c.CheckInterval)
+	}
+	return nil
+}
+
+// StatsConfig holds GET /stats/users, which serves user statistics from
+// materialized views refreshed every RefreshInterval
+type StatsConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"` // also how long responses may be cached
+	MaxDays         int           `mapstructure:"max_days"`         // days of signups a request may ask for
+}
+
+// ReadModelConfig holds the user_read_models table that user listings and
+// searches read instead of the users table. It is projected from user
+// events and rebuilt every RebuildInterval to catch up on changes whose
+// events the projection missed.
+type ReadModelConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`
+	RebuildInterval time.Duration `mapstructure:"rebuild_interval"`
+	BatchSize       int           `mapstructure:"batch_size"` // users saved per statement by a rebuild
+}
+
+// EventsConfig holds the in-process delivery of committed user changes to
+// GraphQL subscriptions and the event stream
+type EventsConfig struct {
+	Buffer int               `mapstructure:"buffer"` // events queued per subscriber before it is dropped
+	Stream EventStreamConfig `mapstructure:"stream"`
+	CDC    EventCDCConfig    `mapstructure:"cdc"`
+}
+
+// EventStreamConfig holds GET /users/events, which streams user changes as
+// server-sent events
+type EventStreamConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	Heartbeat      time.Duration `mapstructure:"heartbeat"`       // comment sent on idle streams so proxies keep them open
+	MaxReplay      int           `mapstructure:"max_replay"`      // events replayed after Last-Event-ID before the client is told to reset
+	MaxConnections int           `mapstructure:"max_connections"` // 0 disables the limit
+}
+
+// EventCDCConfig holds change data capture of the users and user_events
+// tables through Postgres logical replication. When enabled, user events
+// reach subscribers from the replication stream instead of being published
+// after each commit, including changes made outside the API. Plugin is
+// pgoutput or wal2json; Publication is only used by pgoutput. A lag of the
+// slot above MaxLagBytes fails the cdc health check; 0 disables the check.
+type EventCDCConfig struct {
+	Enabled        bool          `mapstructure:"enabled"`
+	Plugin         string        `mapstructure:"plugin"`
+	Slot           string        `mapstructure:"slot"`
+	Publication    string        `mapstructure:"publication"`
+	CreateSlot     bool          `mapstructure:"create_slot"`    // create the slot and publication when missing
+	TemporarySlot  bool          `mapstructure:"temporary_slot"` // drop the slot when the connection closes
+	StatusInterval time.Duration `mapstructure:"status_interval"`
+	MaxLagBytes    int64         `mapstructure:"max_lag_bytes"`
+}
+
+// cdcPlugins are the logical decoding plugins of EventCDCConfig
+var cdcPlugins = []string{"pgoutput", "wal2json"}
+
+// replicationName matches the slot and publication names that need no
+// quoting
+var replicationName = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// validate checks the plugin and the slot and publication names
+func (c EventCDCConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if !slices.Contains(cdcPlugins, c.Plugin) {
+		return fmt.Errorf("unknown cdc plugin: %q", c.Plugin)
+	}
+	if !replicationName.MatchString(c.Slot) {
+		return fmt.Errorf("invalid cdc slot name: %q", c.Slot)
+	}
+	if c.Plugin == "pgoutput" && !replicationName.MatchString(c.Publication) {
+		return fmt.Errorf("invalid cdc publication name: %q", c.Publication)
+	}
+	return nil
+}
+
+// validateReadModel rejects the read model with storage and settings it
+// cannot serve
+func (c UsersConfig) validateReadModel(storage StorageConfig, tenancy TenancyConfig) error {
+	if !c.ReadModel.Enabled {
+		return nil
+	}
+	switch {
+	case storage.InMemory():
+		return errors.New("users.read_model requires PostgreSQL, not the memory storage driver")
+	case tenancy.Enabled && tenancy.Isolation == "schema_per_tenant":
+		// Each tenant schema would need a projection of its own
+		return errors.New("users.read_model does not support schema_per_tenant isolation")
+	case c.Encryption.Enabled:
+		// Searching needs the emails and names in plaintext
+		return errors.New("users.read_model cannot be used with users.encryption")
+	case c.ReadModel.RebuildInterval <= 0:
+		return fmt.Errorf("invalid users.read_model.rebuild_interval: %s", c.ReadModel.RebuildInterval)
+	}
+	return nil
+}
+
+// validateStats rejects the statistics with settings they cannot serve
+func (c UsersConfig) validateStats(tenancy TenancyConfig) error {
+	if !c.Stats.Enabled {
+		return nil
+	}
+	switch {
+	case tenancy.Enabled && tenancy.Isolation == "schema_per_tenant":
+		// The views of each tenant schema would need refreshing on their own
+		return errors.New("users.stats does not support schema_per_tenant isolation")
+	case c.Stats.RefreshInterval <= 0:
+		return fmt.Errorf("invalid users.stats.refresh_interval: %s", c.Stats.RefreshInterval)
+	case c.Stats.MaxDays < 1:
+		return fmt.Errorf("invalid users.stats.max_days: %d", c.Stats.MaxDays)
+	}
+	return nil
+}
+
+// RetentionConfig holds the purge policy for soft-deleted users
+type RetentionConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Days      int           `mapstructure:"days"`
+	Interval  time.Duration `mapstructure:"interval"`
+	BatchSize int           `mapstructure:"batch_size"`
+	DryRun    bool          `mapstructure:"dry_run"`
+}
+
+// Period returns how long soft-deleted users are kept
+func (c RetentionConfig) Period() time.Duration {
+	return time.Duration(c.Days) * 24 * time.Hour
+}
+
+// EmailValidationConfig holds how new and changed email addresses are
+// checked beyond the domain's syntax rules. Enabled turns on the remote
+// service; Mode and MX work without it.
+type EmailValidationConfig struct {
+	Enabled  bool               `mapstructure:"enabled"`
+	BaseURL  string             `mapstructure:"base_url"`
+	APIKey   string             `mapstructure:"api_key"`
+	Timeout  time.Duration      `mapstructure:"timeout"`
+	FailOpen bool               `mapstructure:"fail_open"`
+	Mode     string             `mapstructure:"mode"`
+	MX       MXValidationConfig `mapstructure:"mx"`
+}
+
+// MXValidationConfig holds the DNS check that the domain of an address
+// accepts mail
+type MXValidationConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// PasswordsConfig holds the policy new passwords must follow. Lengths are
+// counted in characters.
+type PasswordsConfig struct {
+	MinLength          int               `mapstructure:"min_length"`
+	MaxLength          int               `mapstructure:"max_length"`
+	RequireUpper       bool              `mapstructure:"require_upper"`
+	RequireLower       bool              `mapstructure:"require_lower"`
+	RequireDigit       bool              `mapstructure:"require_digit"`
+	RequireSymbol      bool              `mapstructure:"require_symbol"`
+	RejectPersonalInfo bool              `mapstructure:"reject_personal_info"` // reject passwords containing the email address or name
+	BreachCheck        BreachCheckConfig `mapstructure:"breach_check"`
+}
+
+// BreachCheckConfig holds the check of new passwords against the Have I
+// Been Pwned Pwned Passwords range API
+type BreachCheckConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	BaseURL  string        `mapstructure:"base_url"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	FailOpen bool          `mapstructure:"fail_open"`
+}
+
+// EncryptionConfig holds the encryption of user emails and names at rest.
+// Keys lists "<id>:<base64 key>" entries, the first of which encrypts new
+// values; the others only decrypt. KeysFile and IndexKeyFile, when set,
+// hold the keys instead, e.g. written by a secrets provider.
+type EncryptionConfig struct {
+	Enabled           bool   `mapstructure:"enabled"`
+	Keys              string `mapstructure:"keys"`
+	KeysFile          string `mapstructure:"keys_file"`
+	IndexKey          string `mapstructure:"index_key"` // key of the blind index emails are looked up by; never rotated
+	IndexKeyFile      string `mapstructure:"index_key_file"`
+	RotationBatchSize int    `mapstructure:"rotation_batch_size"` // rows re-encrypted per batch by cmd/rotate-keys
+}
+
+// OrganizationsConfig holds organization module configuration
+type OrganizationsConfig struct {
+	Invitations InvitationsConfig `mapstructure:"invitations"`
+}
+
+// InvitationsConfig holds organization invitation configuration
+type InvitationsConfig struct {
+	TTL       time.Duration `mapstructure:"ttl"`
+	AcceptURL string        `mapstructure:"accept_url"`
+}
+
+// PreferencesConfig holds the preferences of users who never saved any
+type PreferencesConfig struct {
+	Locale        string                        `mapstructure:"locale"`
+	Timezone      string                        `mapstructure:"timezone"`
+	Notifications NotificationPreferencesConfig `mapstructure:"notifications"`
+}
+
+// NotificationPreferencesConfig holds default notification settings
+type NotificationPreferencesConfig struct {
+	Email  bool   `mapstructure:"email"`
+	Digest string `mapstructure:"digest"`
+}
+
+// MailerConfig holds outgoing email configuration
+type MailerConfig struct {
+	Driver   string `mapstructure:"driver"`
+	From     string `mapstructure:"from"`
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// StorageConfig holds file storage configuration. Avatars are stored with
+// Driver; Objects holds the private objects, such as exports, imports and
+// attachments.
+type StorageConfig struct {
+	Driver  string              `mapstructure:"driver"`
+	Local   LocalStorageConfig  `mapstructure:"local"`
+	S3      S3StorageConfig     `mapstructure:"s3"`
+	Objects ObjectStorageConfig `mapstructure:"objects"`
+}
+
+// InMemory reports whether the memory driver is selected. It keeps users
+// in process memory instead of PostgreSQL so the API runs without a
+// database; uploaded files are stored like with the local driver.
+func (c StorageConfig) InMemory() bool {
+	return c.Driver == "memory"
+}
+
+// LocalStorageConfig holds local-disk storage configuration
+type LocalStorageConfig struct {
+	Dir     string `mapstructure:"dir"`
+	BaseURL string `mapstructure:"base_url"`
+}
+
+// S3StorageConfig holds S3 storage configuration
+type S3StorageConfig struct {
+	Bucket          string        `mapstructure:"bucket"`
+	Region          string        `mapstructure:"region"`
+	Endpoint        string        `mapstructure:"endpoint"`
+	AccessKeyID     string        `mapstructure:"access_key_id"`
+	SecretAccessKey string        `mapstructure:"secret_access_key"`
+	UsePathStyle    bool          `mapstructure:"use_path_style"`
+	PresignTTL      time.Duration `mapstructure:"presign_ttl"`
+}
+
+// ObjectStorageConfig holds the storage of private objects, read back by
+// the application or through signed URLs. Driver is local, s3 or minio;
+// minio uses the S3 settings with Endpoint pointing to MinIO. Lifecycle
+// deletes the objects under a prefix once they are older than ExpireAfter,
+// checked every CleanupInterval.
+type ObjectStorageConfig struct {
+	Driver          string                   `mapstructure:"driver"`
+	Local           LocalObjectStorageConfig `mapstructure:"local"`
+	S3              S3StorageConfig          `mapstructure:"s3"`
+	Lifecycle       []LifecycleRuleConfig    `mapstructure:"lifecycle"`
+	CleanupInterval time.Duration            `mapstructure:"cleanup_interval"`
+}
+
+// LocalObjectStorageConfig holds local-disk object storage. The API serves
+// the signed URLs under BaseURL, checking them with SigningKey; without
+// one, a random key is generated at startup.
+type LocalObjectStorageConfig struct {
+	Dir        string `mapstructure:"dir"`
+	BaseURL    string `mapstructure:"base_url"`
+	SigningKey string `mapstructure:"signing_key"`
+}
+
+// LifecycleRuleConfig expires the objects under a key prefix
+type LifecycleRuleConfig struct {
+	Prefix      string        `mapstructure:"prefix"`
+	ExpireAfter time.Duration `mapstructure:"expire_after"`
+}
+
+// objectStorageDrivers are the drivers of ObjectStorageConfig
+var objectStorageDrivers = []string{"local", "s3", "minio"}
+
+// validate checks the driver against its settings and the lifecycle rules
+func (c ObjectStorageConfig) validate() error {
+	if !slices.Contains(objectStorageDrivers, c.Driver) {
+		return fmt.Errorf("unknown storage.objects driver: %q", c.Driver)
+	}
+	if c.Driver != "local" && c.S3.Bucket == "" {
+		return fmt.Errorf("storage.objects.driver %s requires storage.objects.s3.bucket", c.Driver)
+	}
+	if c.Driver == "minio" && c.S3.Endpoint == "" {
+		return errors.New("storage.objects.driver minio requires storage.objects.s3.endpoint")
+	}
+	for _, rule := range c.Lifecycle {
+		// An empty prefix would expire every object
+		if rule.Prefix == "" {
+			return errors.New("storage.objects.lifecycle rules require a prefix")
+		}
+		if rule.ExpireAfter <= 0 {
+			return fmt.Errorf("storage.objects.lifecycle rule %s: expire_after must be positive", rule.Prefix)
+		}
+	}
+	if len(c.Lifecycle) > 0 && c.CleanupInterval <= 0 {
+		return errors.New("storage.objects.cleanup_interval must be positive")
+	}
+	return nil
 }
 
 // Load reads configuration from file and environment variables
@@ -70,13 +1137,220 @@ func Load(configPath string) (*Config, error) {
 	v.SetDefault("app.http_port", 8080)
 	v.SetDefault("app.grpc_port", 9090)
 	v.SetDefault("app.log_level", "info")
+	v.SetDefault("http.router", "gin")
+	v.SetDefault("http.trusted_proxies", []string{})
+	v.SetDefault("http.json.strict", false)
+	v.SetDefault("http.json.max_body_bytes", 1<<20)
+	v.SetDefault("http.idempotency.enabled", true)
+	v.SetDefault("http.idempotency.ttl", "24h")
+	v.SetDefault("http.idempotency.cleanup_interval", "1h")
+	v.SetDefault("http.idempotency.max_body_bytes", 10<<20)
+	v.SetDefault("http.timeout.default", "5s")
+	v.SetDefault("http.cache_control.enabled", false)
+	v.SetDefault("http.cache_control.default", "no-cache")
+	v.SetDefault("http.cache_control.routes", map[string]string{})
+	v.SetDefault("http.cache_control.mutations", "no-store")
+	v.SetDefault("http.cache_control.authenticated", "private, no-cache")
+	v.SetDefault("http.locale.enabled", false)
+	v.SetDefault("http.locale.languages", []string{"en"})
+	v.SetDefault("http.load_shedding.enabled", true)
+	v.SetDefault("http.load_shedding.max_concurrent", 100)
+	v.SetDefault("http.load_shedding.max_queue", 100)
+	v.SetDefault("http.load_shedding.max_wait", "1s")
+	v.SetDefault("http.load_shedding.retry_after", "1s")
+	v.SetDefault("http.quota.enabled", false)
+	v.SetDefault("http.quota.daily", 10000)
+	v.SetDefault("http.quota.monthly", 250000)
+	v.SetDefault("http.quota.api_keys", map[string]string{})
+	v.SetDefault("http.quota.exempt_paths", []string{"/health/", "/debug/", "/metrics", "/admin/"})
+	v.SetDefault("http.quota.cleanup_interval", "1h")
+	v.SetDefault("http.ip_filter.enabled", false)
+	v.SetDefault("http.gateway.enabled", false)
+	v.SetDefault("http.gateway.validate_requests", true)
+	v.SetDefault("http.fixtures.record", false)
+	v.SetDefault("http.fixtures.dir", "./data/fixtures")
+	v.SetDefault("http.fixtures.max_body_bytes", 1<<20)
+	v.SetDefault("http.graphql.enabled", false)
+	v.SetDefault("http.graphql.subscriptions.enabled", false)
+	v.SetDefault("http.graphql.subscriptions.max_connections", 1000)
+	v.SetDefault("http.graphql.subscriptions.keep_alive", "25s")
+	v.SetDefault("startup.wait_timeout", "30s")
+	v.SetDefault("startup.initial_backoff", "500ms")
+	v.SetDefault("startup.max_backoff", "5s")
+	v.SetDefault("startup.warmup.enabled", true)
+	v.SetDefault("startup.warmup.task_timeout", "10s")
+	v.SetDefault("tenancy.enabled", false)
+	v.SetDefault("tenancy.resolver", "header")
+	v.SetDefault("tenancy.header", "X-Tenant-ID")
+	v.SetDefault("tenancy.domain", "")
+	v.SetDefault("tenancy.jwt.claim", "tenant_id")
+	v.SetDefault("tenancy.jwt.secret", "")
+	v.SetDefault("tenancy.isolation", "shared_schema")
+	v.SetDefault("tenancy.exempt_paths", []string{"/health/", "/debug/", "/files/", "/metrics"})
 	v.SetDefault("postgres.sslmode", "disable")
 	v.SetDefault("postgres.max_idle_conns", 10)
 	v.SetDefault("postgres.max_open_conns", 100)
 	v.SetDefault("postgres.conn_max_lifetime", "1h")
+	v.SetDefault("postgres.conn_max_idle_time", "10m")
 	v.SetDefault("postgres.log_level", "warn")
+	v.SetDefault("postgres.password_reload_interval", "1m")
+	v.SetDefault("postgres.auth", "password")
+	v.SetDefault("postgres.rds.region", "")
+	v.SetDefault("postgres.cloudsql.iam_authn", false)
+	v.SetDefault("postgres.cloudsql.ip_type", "public")
+	v.SetDefault("postgres.prepare_stmt", true)
+	v.SetDefault("postgres.deadline_timeouts", false)
+	v.SetDefault("postgres.replica_check_interval", "10s")
+	v.SetDefault("seed.enabled", false)
+	v.SetDefault("seed.fixtures", "")
+	v.SetDefault("redis.host", "")
+	v.SetDefault("redis.port", 6379)
 	v.SetDefault("redis.db", 0)
+	v.SetDefault("locks.driver", "memory")
+	v.SetDefault("locks.prefix", "locks")
+	v.SetDefault("cache.driver", "none")
+	v.SetDefault("cache.ttl", "5m")
+	v.SetDefault("cache.prefix", "cache")
+	v.SetDefault("cache.memory.max_bytes", 64<<20)
+	v.SetDefault("cache.invalidation.driver", "none")
+	v.SetDefault("cache.invalidation.channel", "cache_invalidations")
+	v.SetDefault("leader.enabled", false)
+	v.SetDefault("leader.driver", "kubernetes")
+	v.SetDefault("leader.name", "")
+	v.SetDefault("leader.identity", "")
+	v.SetDefault("leader.namespace", "")
+	v.SetDefault("leader.lease_duration", "15s")
+	v.SetDefault("leader.renew_deadline", "10s")
+	v.SetDefault("leader.retry_period", "2s")
 	v.SetDefault("observability.log_level", "info")
+	v.SetDefault("observability.tracing.exporter", "none")
+	v.SetDefault("observability.tracing.endpoint", "")
+	v.SetDefault("observability.tracing.service_name", "")
+	v.SetDefault("observability.tracing.sampling.ratio", 1.0)
+	v.SetDefault("observability.redaction.fields", []string{"email", "password", "token", "secret", "authorization", "cookie", "api_key", "dsn"})
+	v.SetDefault("observability.redaction.policies.development", "fields")
+	v.SetDefault("observability.redaction.policies.test", "fields")
+	v.SetDefault("observability.redaction.policy", "")
+	v.SetDefault("observability.capture.enabled", false)
+	v.SetDefault("observability.capture.max_body_bytes", 4096)
+	v.SetDefault("observability.capture.buffer_size", 100)
+	v.SetDefault("observability.capture.log", true)
+	v.SetDefault("observability.capture.max_duration", "1h")
+	v.SetDefault("observability.alerts.enabled", false)
+	v.SetDefault("observability.alerts.hook", "log")
+	v.SetDefault("observability.alerts.webhook_url", "")
+	v.SetDefault("observability.alerts.pagerduty_routing_key", "")
+	v.SetDefault("observability.alerts.window", "5m")
+	v.SetDefault("observability.alerts.occurrences", 5)
+	v.SetDefault("observability.alerts.cooldown", "30m")
+	v.SetDefault("observability.alerts.slow_request", "2s")
+	v.SetDefault("observability.alerts.slow_query", "500ms")
+	v.SetDefault("grpc.enabled", false)
+	v.SetDefault("grpc.auth.enabled", true)
+	v.SetDefault("grpc.auth.api_keys", map[string]string{})
+	v.SetDefault("grpc.auth.jwt_secret", "")
+	v.SetDefault("grpc.auth.exempt_methods", []string{"/grpc.health.v1.Health/"})
+	v.SetDefault("grpc.stream_send_timeout", "30s")
+	v.SetDefault("tls.enabled", false)
+	v.SetDefault("tls.cert_file", "")
+	v.SetDefault("tls.key_file", "")
+	v.SetDefault("tls.client_ca_file", "")
+	v.SetDefault("tls.client_auth", "require")
+	v.SetDefault("tls.principals", map[string]string{})
+	v.SetDefault("admin.token", "")
+	v.SetDefault("admin.allowed_networks", []string{})
+	v.SetDefault("users.require_email_verification", false)
+	v.SetDefault("users.email_change_token_ttl", "24h")
+	v.SetDefault("users.legacy_email_route", true)
+	v.SetDefault("users.response_formats", []string{"json"})
+	v.SetDefault("users.default_response_format", "json")
+	v.SetDefault("users.id_generator", "uuidv7")
+	v.SetDefault("users.repository", "gorm")
+	v.SetDefault("users.retention.enabled", false)
+	v.SetDefault("users.retention.days", 30)
+	v.SetDefault("users.retention.interval", "1h")
+	v.SetDefault("users.retention.batch_size", 500)
+	v.SetDefault("users.retention.dry_run", false)
+	v.SetDefault("users.preferences.locale", "en")
+	v.SetDefault("users.preferences.timezone", "UTC")
+	v.SetDefault("users.preferences.notifications.email", true)
+	v.SetDefault("users.preferences.notifications.digest", "weekly")
+	v.SetDefault("users.email_validation.enabled", false)
+	v.SetDefault("users.email_validation.base_url", "")
+	v.SetDefault("users.email_validation.api_key", "")
+	v.SetDefault("users.email_validation.timeout", "3s")
+	v.SetDefault("users.email_validation.fail_open", true)
+	v.SetDefault("users.email_validation.mode", "default")
+	v.SetDefault("users.email_validation.mx.enabled", false)
+	v.SetDefault("users.email_validation.mx.timeout", "2s")
+	v.SetDefault("users.passwords.min_length", 12)
+	v.SetDefault("users.passwords.max_length", 128)
+	v.SetDefault("users.passwords.require_upper", false)
+	v.SetDefault("users.passwords.require_lower", false)
+	v.SetDefault("users.passwords.require_digit", false)
+	v.SetDefault("users.passwords.require_symbol", false)
+	v.SetDefault("users.passwords.reject_personal_info", true)
+	v.SetDefault("users.passwords.breach_check.enabled", false)
+	v.SetDefault("users.passwords.breach_check.base_url", "https://api.pwnedpasswords.com")
+	v.SetDefault("users.passwords.breach_check.timeout", "3s")
+	v.SetDefault("users.passwords.breach_check.fail_open", true)
+	v.SetDefault("users.encryption.enabled", false)
+	v.SetDefault("users.encryption.keys", "")
+	v.SetDefault("users.encryption.keys_file", "")
+	v.SetDefault("users.encryption.index_key", "")
+	v.SetDefault("users.encryption.index_key_file", "")
+	v.SetDefault("users.encryption.rotation_batch_size", 500)
+	v.SetDefault("users.events.buffer", 64)
+	v.SetDefault("users.events.stream.enabled", false)
+	v.SetDefault("users.events.stream.heartbeat", "15s")
+	v.SetDefault("users.events.stream.max_replay", 1000)
+	v.SetDefault("users.events.stream.max_connections", 1000)
+	v.SetDefault("users.events.cdc.enabled", false)
+	v.SetDefault("users.events.cdc.plugin", "pgoutput")
+	v.SetDefault("users.events.cdc.slot", "go_scaffolding_users")
+	v.SetDefault("users.events.cdc.publication", "go_scaffolding_users")
+	v.SetDefault("users.events.cdc.create_slot", true)
+	v.SetDefault("users.events.cdc.temporary_slot", false)
+	v.SetDefault("users.events.cdc.status_interval", "10s")
+	v.SetDefault("users.events.cdc.max_lag_bytes", 256<<20)
+	v.SetDefault("users.read_model.enabled", false)
+	v.SetDefault("users.read_model.rebuild_interval", "1h")
+	v.SetDefault("users.read_model.batch_size", 500)
+	v.SetDefault("users.stats.enabled", false)
+	v.SetDefault("users.stats.refresh_interval", "15m")
+	v.SetDefault("users.stats.max_days", 90)
+	v.SetDefault("users.jobs.retention", "24h")
+	v.SetDefault("users.jobs.stale_after", "1m")
+	v.SetDefault("users.jobs.check_interval", "1m")
+	v.SetDefault("users.availability.enabled", true)
+	v.SetDefault("users.availability.requests", 60)
+	v.SetDefault("users.availability.interval", "1m")
+	v.SetDefault("users.availability.burst", 10)
+	v.SetDefault("users.availability.max_age", "10s")
+	v.SetDefault("users.login_risk.enabled", true)
+	v.SetDefault("users.login_risk.history", 20)
+	v.SetDefault("users.login_risk.country_header", "")
+	v.SetDefault("users.login_risk.new_country", "step_up")
+	v.SetDefault("users.login_risk.new_device", "notify")
+	v.SetDefault("users.login_risk.velocity.window", "15m")
+	v.SetDefault("users.login_risk.velocity.max_failures", 5)
+	v.SetDefault("users.login_risk.velocity.action", "step_up")
+	v.SetDefault("organizations.invitations.ttl", "168h")
+	v.SetDefault("organizations.invitations.accept_url", "http://localhost:3000/invitations/accept")
+	v.SetDefault("mailer.driver", "log")
+	v.SetDefault("mailer.port", 587)
+	v.SetDefault("storage.driver", "local")
+	v.SetDefault("storage.local.dir", "./data/uploads")
+	v.SetDefault("storage.local.base_url", "/files")
+	v.SetDefault("storage.s3.region", "us-east-1")
+	v.SetDefault("storage.s3.presign_ttl", "15m")
+	v.SetDefault("storage.objects.driver", "local")
+	v.SetDefault("storage.objects.local.dir", "./data/objects")
+	v.SetDefault("storage.objects.local.base_url", "/objects")
+	v.SetDefault("storage.objects.local.signing_key", "")
+	v.SetDefault("storage.objects.s3.region", "us-east-1")
+	v.SetDefault("storage.objects.s3.presign_ttl", "15m")
+	v.SetDefault("storage.objects.cleanup_interval", "1h")
 
 	// Read from config file
 	v.SetConfigFile(configPath)
@@ -95,6 +1369,70 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	if err := cfg.HTTP.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Postgres.validatePool(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Observability.Redaction.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Observability.Tracing.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Observability.Alerts.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Users.Events.CDC.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Users.Events.CDC.Enabled && cfg.Storage.InMemory() {
+		return nil, errors.New("users.events.cdc requires PostgreSQL, not the memory storage driver")
+	}
+	if cfg.Users.Events.CDC.Enabled && cfg.Tenancy.Enabled && cfg.Tenancy.Isolation == "schema_per_tenant" {
+		// The tables of each tenant schema would need a publication of their own
+		return nil, errors.New("users.events.cdc does not support schema_per_tenant isolation")
+	}
+	if err := cfg.Users.validateReadModel(cfg.Storage, cfg.Tenancy); err != nil {
+		return nil, err
+	}
+	if err := cfg.Users.validateStats(cfg.Tenancy); err != nil {
+		return nil, err
+	}
+	if err := cfg.Users.Jobs.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Users.Availability.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Users.LoginRisk.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Locks.validate(cfg.Storage, cfg.Redis); err != nil {
+		return nil, err
+	}
+	if err := cfg.Cache.validate(cfg.Storage, cfg.Redis, cfg.Users); err != nil {
+		return nil, err
+	}
+	if err := cfg.Leader.validate(cfg.Storage); err != nil {
+		return nil, err
+	}
+	if err := cfg.Storage.Objects.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Startup.Warmup.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.Seed.Enabled && cfg.App.Environment == "production" {
+		return nil, errors.New("seed.enabled is refused in production")
+	}
+
+	// users.admin_token predates the admin section
+	if cfg.Admin.Token == "" {
+		cfg.Admin.Token = cfg.Users.AdminToken
+	}
+
 	return &cfg, nil
 }
 
@@ -102,11 +1440,57 @@ func Load(configPath string) (*Config, error) {
 func (c *PostgresConfig) ConnectionString() string {
 	return fmt.Sprintf(
 		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		c.Host, c.Port, c.User, c.Password, c.Database, c.SSLMode,
+		dsnValue(c.Host), c.Port, dsnValue(c.User), dsnValue(c.Password), dsnValue(c.Database), dsnValue(c.SSLMode),
 	)
 }
 
+// dsnValue quotes a connection string value when it is empty or contains
+// spaces, quotes or backslashes; an empty password would otherwise take
+// the next keyword as its value
+func dsnValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " '\\\t\n") {
+		return value
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return "'" + escaped + "'"
+}
+
+// Replica returns the connection settings of a read replica, taking unset
+// fields from the primary
+func (c *PostgresConfig) Replica(replica PostgresReplicaConfig) PostgresConfig {
+	cfg := *c
+	cfg.Replicas = nil
+
+	if replica.Host != "" {
+		cfg.Host = replica.Host
+	}
+	if replica.Port != 0 {
+		cfg.Port = replica.Port
+	}
+	if replica.User != "" {
+		cfg.User = replica.User
+	}
+	if replica.Password != "" {
+		cfg.Password = replica.Password
+		cfg.PasswordFile = ""
+	}
+
+	return cfg
+}
+
+// Address returns SMTP server address
+func (c *MailerConfig) Address() string {
+	return fmt.Sprintf("%s:%d", c.Host, c.Port)
+}
+
 // Address returns Redis address
 func (c *RedisConfig) Address() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
+
+// Enabled reports whether Redis is configured. Components only use Redis
+// when it is, and nothing connects to it otherwise.
+func (c *RedisConfig) Enabled() bool {
+	return c.Host != ""
+}
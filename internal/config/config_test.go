@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -96,7 +97,19 @@ func TestPostgresConfig_ConnectionString(t *testing.T) {
 				Database: "mydb",
 				SSLMode:  "disable",
 			},
-			expected: "host=127.0.0.1 port=5432 user=postgres password= dbname=mydb sslmode=disable",
+			expected: "host=127.0.0.1 port=5432 user=postgres password='' dbname=mydb sslmode=disable",
+		},
+		{
+			name: "connection string with special characters in password",
+			config: PostgresConfig{
+				Host:     "localhost",
+				Port:     5432,
+				User:     "postgres",
+				Password: `it's a \secret`,
+				Database: "mydb",
+				SSLMode:  "disable",
+			},
+			expected: `host=localhost port=5432 user=postgres password='it\'s a \\secret' dbname=mydb sslmode=disable`,
 		},
 	}
 
@@ -108,6 +121,38 @@ func TestPostgresConfig_ConnectionString(t *testing.T) {
 	}
 }
 
+func TestPostgresConfig_Replica(t *testing.T) {
+	primary := PostgresConfig{
+		Host:     "primary.example.com",
+		Port:     5432,
+		User:     "app",
+		Password: "secret",
+		Database: "app",
+		SSLMode:  "require",
+		Replicas: []PostgresReplicaConfig{{Host: "replica.example.com"}},
+	}
+
+	replica := primary.Replica(PostgresReplicaConfig{Host: "replica.example.com", Port: 5433, User: "reader"})
+
+	assert.Equal(t, "host=replica.example.com port=5433 user=reader password=secret dbname=app sslmode=require", replica.ConnectionString())
+	assert.Empty(t, replica.Replicas)
+	assert.Equal(t, "primary.example.com", primary.Host, "the primary is unchanged")
+}
+
+func TestPostgresConfig_ReplicaWithOwnPassword(t *testing.T) {
+	primary := PostgresConfig{Host: "primary.example.com", PasswordFile: "/vault/secrets/db-password"}
+
+	replica := primary.Replica(PostgresReplicaConfig{Password: "reader-secret"})
+
+	assert.Equal(t, "reader-secret", replica.Password)
+	assert.Empty(t, replica.PasswordFile, "the replica's own password replaces the primary's file")
+}
+
+func TestRedisConfig_Enabled(t *testing.T) {
+	assert.False(t, (&RedisConfig{Port: 6379}).Enabled())
+	assert.True(t, (&RedisConfig{Host: "localhost", Port: 6379}).Enabled())
+}
+
 func TestRedisConfig_Address(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -147,3 +192,917 @@ func TestRedisConfig_Address(t *testing.T) {
 		})
 	}
 }
+
+func TestLoad_UsersAndMailerDefaults(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("app:\n  name: test-app\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.False(t, cfg.HTTP.JSON.Strict)
+	assert.Equal(t, int64(1<<20), cfg.HTTP.JSON.MaxBodyBytes)
+	assert.True(t, cfg.HTTP.Idempotency.Enabled)
+	assert.Equal(t, 24*time.Hour, cfg.HTTP.Idempotency.TTL)
+	assert.Equal(t, time.Hour, cfg.HTTP.Idempotency.CleanupInterval)
+	assert.Equal(t, int64(10<<20), cfg.HTTP.Idempotency.MaxBodyBytes)
+	assert.Equal(t, 5*time.Second, cfg.HTTP.Timeout.Default)
+	assert.Empty(t, cfg.HTTP.Timeout.Routes)
+	assert.True(t, cfg.HTTP.LoadShed.Enabled)
+	assert.Equal(t, ConcurrencyLimitConfig{MaxConcurrent: 100, MaxQueue: 100, MaxWait: time.Second}, cfg.HTTP.LoadShed.Limit)
+	assert.Equal(t, time.Second, cfg.HTTP.LoadShed.RetryAfter)
+	assert.Empty(t, cfg.HTTP.LoadShed.Routes)
+	assert.False(t, cfg.HTTP.Quota.Enabled)
+	assert.Equal(t, int64(10000), cfg.HTTP.Quota.Daily)
+	assert.Equal(t, int64(250000), cfg.HTTP.Quota.Monthly)
+	assert.Empty(t, cfg.HTTP.Quota.APIKeys)
+	assert.Equal(t, []string{"/health/", "/debug/", "/metrics", "/admin/"}, cfg.HTTP.Quota.ExemptPaths)
+	assert.Equal(t, time.Hour, cfg.HTTP.Quota.CleanupInterval)
+	assert.False(t, cfg.HTTP.IPFilter.Enabled)
+	assert.Equal(t, "gin", cfg.HTTP.Router)
+	assert.Empty(t, cfg.HTTP.TrustedProxies)
+	assert.Empty(t, cfg.HTTP.IPFilter.Routes)
+	assert.Equal(t, CaptureConfig{MaxBodyBytes: 4096, BufferSize: 100, Log: true, MaxDuration: time.Hour}, cfg.Observability.Capture)
+	assert.Equal(t, "none", cfg.Observability.Tracing.Exporter)
+	assert.Empty(t, cfg.Observability.Tracing.Endpoint)
+	assert.Equal(t, 1.0, cfg.Observability.Tracing.Sampling.Ratio)
+	assert.Empty(t, cfg.Observability.Tracing.Sampling.Routes)
+	assert.Equal(t, AlertsConfig{Hook: "log", Window: 5 * time.Minute, Occurrences: 5, Cooldown: 30 * time.Minute, SlowRequest: 2 * time.Second, SlowQuery: 500 * time.Millisecond}, cfg.Observability.Alerts)
+	assert.Equal(t, TLSConfig{ClientAuth: "require", Principals: map[string]string{}}, cfg.TLS)
+	assert.False(t, cfg.HTTP.Gateway.Enabled)
+	assert.True(t, cfg.HTTP.Gateway.ValidateRequests)
+	assert.Equal(t, FixturesConfig{Dir: "./data/fixtures", MaxBodyBytes: 1 << 20}, cfg.HTTP.Fixtures)
+	assert.False(t, cfg.HTTP.GraphQL.Enabled)
+	assert.Equal(t, SubscriptionsConfig{MaxConnections: 1000, KeepAlive: 25 * time.Second}, cfg.HTTP.GraphQL.Subscriptions)
+	assert.Equal(t, 30*time.Second, cfg.Startup.WaitTimeout)
+	assert.Equal(t, 500*time.Millisecond, cfg.Startup.InitialBackoff)
+	assert.Equal(t, 5*time.Second, cfg.Startup.MaxBackoff)
+	assert.True(t, cfg.Startup.Warmup.Enabled)
+	assert.Equal(t, 10*time.Second, cfg.Startup.Warmup.TaskTimeout)
+	assert.Empty(t, cfg.Postgres.PasswordFile)
+	assert.Equal(t, time.Minute, cfg.Postgres.PasswordReloadInterval)
+	assert.Equal(t, "password", cfg.Postgres.Auth)
+	assert.Equal(t, 10*time.Minute, cfg.Postgres.ConnMaxIdleTime)
+	assert.False(t, cfg.Tenancy.Enabled)
+	assert.Equal(t, "header", cfg.Tenancy.Resolver)
+	assert.Equal(t, "X-Tenant-ID", cfg.Tenancy.Header)
+	assert.Equal(t, "tenant_id", cfg.Tenancy.JWT.Claim)
+	assert.Equal(t, "shared_schema", cfg.Tenancy.Isolation)
+	assert.Equal(t, []string{"/health/", "/debug/", "/files/", "/metrics"}, cfg.Tenancy.ExemptPaths)
+	assert.Empty(t, cfg.Postgres.RDS.Region)
+	assert.False(t, cfg.Postgres.CloudSQL.IAMAuthN)
+	assert.Equal(t, "public", cfg.Postgres.CloudSQL.IPType)
+	assert.True(t, cfg.Postgres.PrepareStmt)
+	assert.False(t, cfg.Postgres.DeadlineTimeouts)
+	assert.Empty(t, cfg.Postgres.Replicas)
+	assert.Equal(t, 10*time.Second, cfg.Postgres.ReplicaCheckInterval)
+	assert.False(t, cfg.Users.RequireEmailVerification)
+	assert.Equal(t, 24*time.Hour, cfg.Users.EmailChangeTokenTTL)
+	assert.Empty(t, cfg.Users.AdminToken)
+	assert.Empty(t, cfg.Admin.Token)
+	assert.False(t, cfg.GRPC.Enabled)
+	assert.True(t, cfg.GRPC.Auth.Enabled)
+	assert.Empty(t, cfg.GRPC.Auth.APIKeys)
+	assert.Equal(t, []string{"/grpc.health.v1.Health/"}, cfg.GRPC.Auth.ExemptMethods)
+	assert.Equal(t, 30*time.Second, cfg.GRPC.StreamSendTimeout)
+	assert.Empty(t, cfg.Admin.AllowedNetworks)
+	assert.True(t, cfg.Users.LegacyEmailRoute)
+	assert.Equal(t, []string{"json"}, cfg.Users.ResponseFormats)
+	assert.Equal(t, "json", cfg.Users.DefaultResponseFormat)
+	assert.Equal(t, "uuidv7", cfg.Users.IDGenerator)
+	assert.Equal(t, "gorm", cfg.Users.Repository)
+	assert.False(t, cfg.Users.Retention.Enabled)
+	assert.Equal(t, 30*24*time.Hour, cfg.Users.Retention.Period())
+	assert.Equal(t, time.Hour, cfg.Users.Retention.Interval)
+	assert.Equal(t, 500, cfg.Users.Retention.BatchSize)
+	assert.Equal(t, "en", cfg.Users.Preferences.Locale)
+	assert.Equal(t, "UTC", cfg.Users.Preferences.Timezone)
+	assert.True(t, cfg.Users.Preferences.Notifications.Email)
+	assert.Equal(t, "weekly", cfg.Users.Preferences.Notifications.Digest)
+	assert.False(t, cfg.Users.EmailValidation.Enabled)
+	assert.Equal(t, 3*time.Second, cfg.Users.EmailValidation.Timeout)
+	assert.True(t, cfg.Users.EmailValidation.FailOpen)
+	assert.Equal(t, "default", cfg.Users.EmailValidation.Mode)
+	assert.False(t, cfg.Users.EmailValidation.MX.Enabled)
+	assert.Equal(t, 2*time.Second, cfg.Users.EmailValidation.MX.Timeout)
+	assert.Equal(t, PasswordsConfig{
+		MinLength:          12,
+		MaxLength:          128,
+		RejectPersonalInfo: true,
+		BreachCheck: BreachCheckConfig{
+			BaseURL:  "https://api.pwnedpasswords.com",
+			Timeout:  3 * time.Second,
+			FailOpen: true,
+		},
+	}, cfg.Users.Passwords)
+	assert.Equal(t, EncryptionConfig{RotationBatchSize: 500}, cfg.Users.Encryption)
+	assert.Equal(t, 64, cfg.Users.Events.Buffer)
+	assert.Equal(t, EventStreamConfig{Heartbeat: 15 * time.Second, MaxReplay: 1000, MaxConnections: 1000}, cfg.Users.Events.Stream)
+	assert.Equal(t, EventCDCConfig{
+		Plugin:         "pgoutput",
+		Slot:           "go_scaffolding_users",
+		Publication:    "go_scaffolding_users",
+		CreateSlot:     true,
+		StatusInterval: 10 * time.Second,
+		MaxLagBytes:    256 << 20,
+	}, cfg.Users.Events.CDC)
+	assert.Equal(t, ReadModelConfig{RebuildInterval: time.Hour, BatchSize: 500}, cfg.Users.ReadModel)
+	assert.Equal(t, StatsConfig{RefreshInterval: 15 * time.Minute, MaxDays: 90}, cfg.Users.Stats)
+	assert.Equal(t, JobsConfig{Retention: 24 * time.Hour, StaleAfter: time.Minute, CheckInterval: time.Minute}, cfg.Users.Jobs)
+	assert.Equal(t, AvailabilityConfig{Enabled: true, Requests: 60, Interval: time.Minute, Burst: 10, MaxAge: 10 * time.Second}, cfg.Users.Availability)
+	assert.Equal(t, LoginRiskConfig{
+		Enabled:    true,
+		History:    20,
+		NewCountry: "step_up",
+		NewDevice:  "notify",
+		Velocity:   LoginVelocityConfig{Window: 15 * time.Minute, MaxFailures: 5, Action: "step_up"},
+	}, cfg.Users.LoginRisk)
+	assert.Equal(t, 7*24*time.Hour, cfg.Organizations.Invitations.TTL)
+	assert.Equal(t, "http://localhost:3000/invitations/accept", cfg.Organizations.Invitations.AcceptURL)
+	assert.Equal(t, "local", cfg.Storage.Driver)
+	assert.Equal(t, "/files", cfg.Storage.Local.BaseURL)
+	assert.Equal(t, 15*time.Minute, cfg.Storage.S3.PresignTTL)
+	assert.Equal(t, "log", cfg.Mailer.Driver)
+	assert.Equal(t, 587, cfg.Mailer.Port)
+}
+
+func TestLoad_TimeoutRoutes(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("http:\n  timeout:\n    default: 3s\n    routes:\n      /health: 1s\n      /users/export: 0s\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, cfg.HTTP.Timeout.Default)
+	assert.Equal(t, map[string]time.Duration{
+		"/health":       time.Second,
+		"/users/export": 0,
+	}, cfg.HTTP.Timeout.Routes)
+}
+
+func TestLoad_AdminTokenFallsBackToUsersAdminToken(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("users:\n  admin_token: legacy\nadmin:\n  allowed_networks: [10.0.0.0/8]\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, "legacy", cfg.Admin.Token)
+	assert.Equal(t, []string{"10.0.0.0/8"}, cfg.Admin.AllowedNetworks)
+}
+
+func TestLoad_LoadSheddingRoutes(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("http:\n  load_shedding:\n    max_concurrent: 50\n    routes:\n      /users/import:\n        max_concurrent: 2\n        max_wait: 500ms\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, 50, cfg.HTTP.LoadShed.Limit.MaxConcurrent)
+	assert.Equal(t, 100, cfg.HTTP.LoadShed.Limit.MaxQueue)
+	assert.Equal(t, map[string]ConcurrencyLimitConfig{
+		"/users/import": {MaxConcurrent: 2, MaxWait: 500 * time.Millisecond},
+	}, cfg.HTTP.LoadShed.Routes)
+}
+
+func TestLoad_CacheControlRoutes(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("http:\n  cache_control:\n    enabled: true\n    routes:\n      /users/:id: public, max-age=30\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, CacheControlConfig{
+		Enabled:       true,
+		Default:       "no-cache",
+		Routes:        map[string]string{"/users/:id": "public, max-age=30"},
+		Mutations:     "no-store",
+		Authenticated: "private, no-cache",
+	}, cfg.HTTP.CacheControl)
+}
+
+func TestLoad_Locale(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, LocaleConfig{Languages: []string{"en"}}, cfg.HTTP.Locale)
+}
+
+func TestLoad_InvalidLocale(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "no languages", yaml: "http:\n  locale:\n    enabled: true\n    languages: []", wantErr: "http.locale.languages must not be empty"},
+		{name: "invalid tag", yaml: "http:\n  locale:\n    enabled: true\n    languages: [en, english-please]", wantErr: `invalid http.locale.languages tag "english-please": language: tag is not well-formed`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_QuotaOverrides(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("http:\n  quota:\n    api_keys:\n      partner: secret\n    overrides:\n      tenant:acme:\n        daily: 50000\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"partner": "secret"}, cfg.HTTP.Quota.APIKeys)
+	assert.Equal(t, map[string]QuotaLimitsConfig{"tenant:acme": {Daily: 50000}}, cfg.HTTP.Quota.Overrides)
+	assert.Equal(t, int64(10000), cfg.HTTP.Quota.Daily)
+}
+
+func TestLoad_IPFilterRoutes(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("http:\n  trusted_proxies: [10.0.0.1]\n  ip_filter:\n    routes:\n      /admin:\n        allow: [10.0.0.0/8]\n        deny: [10.9.0.0/16]\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1"}, cfg.HTTP.TrustedProxies)
+	assert.Equal(t, map[string]IPFilterRulesConfig{
+		"/admin": {Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.9.0.0/16"}},
+	}, cfg.HTTP.IPFilter.Routes)
+}
+
+func TestStorageConfig_InMemory(t *testing.T) {
+	assert.True(t, StorageConfig{Driver: "memory"}.InMemory())
+	assert.False(t, StorageConfig{Driver: "local"}.InMemory())
+	assert.False(t, StorageConfig{}.InMemory())
+}
+
+func TestLoad_Redaction(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("observability:\n  redaction:\n    policies:\n      staging: fields\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+
+	redaction := cfg.Observability.Redaction
+	assert.Equal(t, []string{"email", "password", "token", "secret", "authorization", "cookie", "api_key", "dsn"}, redaction.Fields)
+	assert.Equal(t, "fields", redaction.PolicyFor("development"))
+	assert.Equal(t, "fields", redaction.PolicyFor("staging"))
+	assert.Equal(t, "strict", redaction.PolicyFor("production"))
+
+	redaction.Policy = "off"
+	assert.Equal(t, "off", redaction.PolicyFor("production"))
+}
+
+func TestLoad_Capture(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("observability:\n  capture:\n    enabled: true\n    max_body_bytes: 1024\n    log: false\n    max_duration: 15m\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, CaptureConfig{Enabled: true, MaxBodyBytes: 1024, BufferSize: 100, MaxDuration: 15 * time.Minute}, cfg.Observability.Capture)
+}
+
+func TestLoad_Tracing(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("observability:\n  tracing:\n    exporter: otlp_grpc\n    endpoint: https://otel.example.com:4317\n    headers:\n      x-api-key: secret\n    sampling:\n      ratio: 0.25\n      routes:\n        /health: 0\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, TracingConfig{
+		Exporter: "otlp_grpc",
+		Endpoint: "https://otel.example.com:4317",
+		Headers:  map[string]string{"x-api-key": "secret"},
+		Sampling: SamplingConfig{Ratio: 0.25, Routes: map[string]float64{"/health": 0}},
+	}, cfg.Observability.Tracing)
+}
+
+func TestLoad_InvalidTracing(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "unknown exporter", yaml: "exporter: datadog", wantErr: `unknown trace exporter: "datadog"`},
+		{name: "ratio above 1", yaml: "sampling:\n      ratio: 2", wantErr: "trace sampling ratio must be between 0 and 1: 2"},
+		{name: "negative route ratio", yaml: "sampling:\n      routes:\n        /users: -0.5", wantErr: "trace sampling ratio of /users must be between 0 and 1: -0.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString("observability:\n  tracing:\n    " + tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_Alerts(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("observability:\n  alerts:\n    enabled: true\n    hook: pagerduty\n    pagerduty_routing_key: key\n    occurrences: 3\n    slow_request_routes:\n      /users/import: 30s\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	alerts := cfg.Observability.Alerts
+	assert.Equal(t, "pagerduty", alerts.Hook)
+	assert.Equal(t, "key", alerts.PagerDutyRoutingKey)
+	assert.Equal(t, 3, alerts.Occurrences)
+	assert.Equal(t, map[string]time.Duration{"/users/import": 30 * time.Second}, alerts.SlowRequestRoutes)
+}
+
+func TestLoad_InvalidHTTPRouter(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("http:\n  router: echo\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	_, err = Load(tmpFile.Name())
+	assert.EqualError(t, err, `unknown http router: "echo"`)
+}
+
+func TestLoad_InvalidFixtures(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no dir", "http:\n  fixtures:\n    record: true\n    dir: \"\"\n", "http.fixtures.dir is required to record fixtures"},
+		{"no body", "http:\n  fixtures:\n    record: true\n    max_body_bytes: 0\n", "http.fixtures.max_body_bytes must be positive: 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.content)
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidWarmup(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"zero timeout", "startup:\n  warmup:\n    task_timeout: 0s\n", "startup.warmup.task_timeout must be positive: 0s"},
+		{"negative timeout", "startup:\n  warmup:\n    task_timeout: -1s\n", "startup.warmup.task_timeout must be positive: -1s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.content)
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidPostgresPool(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "negative max idle", yaml: "max_idle_conns: -1", wantErr: "postgres.max_idle_conns must not be negative: -1"},
+		{name: "negative max open", yaml: "max_open_conns: -1", wantErr: "postgres.max_open_conns must not be negative: -1"},
+		{name: "negative lifetime", yaml: "conn_max_lifetime: -1m", wantErr: "postgres.conn_max_lifetime must not be negative: -1m0s"},
+		{name: "negative idle time", yaml: "conn_max_idle_time: -1m", wantErr: "postgres.conn_max_idle_time must not be negative: -1m0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString("postgres:\n  " + tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidAlerts(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "unknown hook", yaml: "hook: slack", wantErr: `unknown alert hook: "slack"`},
+		{name: "webhook without URL", yaml: "hook: webhook", wantErr: "observability.alerts.webhook_url is required by the webhook hook"},
+		{name: "pagerduty without key", yaml: "hook: pagerduty", wantErr: "observability.alerts.pagerduty_routing_key is required by the pagerduty hook"},
+		{name: "no occurrences", yaml: "occurrences: 0", wantErr: "observability.alerts.occurrences must be at least 1: 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString("observability:\n  alerts:\n    enabled: true\n    " + tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidCDC(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "unknown plugin", yaml: "users:\n  events:\n    cdc:\n      enabled: true\n      plugin: decoderbufs", wantErr: `unknown cdc plugin: "decoderbufs"`},
+		{name: "invalid slot", yaml: "users:\n  events:\n    cdc:\n      enabled: true\n      slot: Users-Slot", wantErr: `invalid cdc slot name: "Users-Slot"`},
+		{name: "memory storage", yaml: "storage:\n  driver: memory\nusers:\n  events:\n    cdc:\n      enabled: true", wantErr: "users.events.cdc requires PostgreSQL, not the memory storage driver"},
+		{name: "schema per tenant", yaml: "tenancy:\n  enabled: true\n  isolation: schema_per_tenant\nusers:\n  events:\n    cdc:\n      enabled: true", wantErr: "users.events.cdc does not support schema_per_tenant isolation"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidReadModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "memory storage", yaml: "storage:\n  driver: memory\nusers:\n  read_model:\n    enabled: true", wantErr: "users.read_model requires PostgreSQL, not the memory storage driver"},
+		{name: "schema per tenant", yaml: "tenancy:\n  enabled: true\n  isolation: schema_per_tenant\nusers:\n  read_model:\n    enabled: true", wantErr: "users.read_model does not support schema_per_tenant isolation"},
+		{name: "encryption", yaml: "users:\n  encryption:\n    enabled: true\n  read_model:\n    enabled: true", wantErr: "users.read_model cannot be used with users.encryption"},
+		{name: "rebuild interval", yaml: "users:\n  read_model:\n    enabled: true\n    rebuild_interval: 0s", wantErr: "invalid users.read_model.rebuild_interval: 0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidStats(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "schema per tenant", yaml: "tenancy:\n  enabled: true\n  isolation: schema_per_tenant\nusers:\n  stats:\n    enabled: true", wantErr: "users.stats does not support schema_per_tenant isolation"},
+		{name: "refresh interval", yaml: "users:\n  stats:\n    enabled: true\n    refresh_interval: 0s", wantErr: "invalid users.stats.refresh_interval: 0s"},
+		{name: "max days", yaml: "users:\n  stats:\n    enabled: true\n    max_days: 0", wantErr: "invalid users.stats.max_days: 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidAvailability(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "requests", yaml: "users:\n  availability:\n    requests: 0", wantErr: "invalid users.availability.requests: 0"},
+		{name: "interval", yaml: "users:\n  availability:\n    interval: 0s", wantErr: "invalid users.availability.interval: 0s"},
+		{name: "burst", yaml: "users:\n  availability:\n    burst: 0", wantErr: "invalid users.availability.burst: 0"},
+		{name: "max age", yaml: "users:\n  availability:\n    max_age: -1s", wantErr: "invalid users.availability.max_age: -1s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidLoginRisk(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "action", yaml: "users:\n  login_risk:\n    new_device: block", wantErr: `invalid users.login_risk.new_device: "block"`},
+		{name: "history", yaml: "users:\n  login_risk:\n    history: 0", wantErr: "invalid users.login_risk.history: 0"},
+		{name: "window", yaml: "users:\n  login_risk:\n    velocity:\n      window: 0s", wantErr: "invalid users.login_risk.velocity.window: 0s"},
+		{name: "max failures", yaml: "users:\n  login_risk:\n    velocity:\n      max_failures: -1", wantErr: "invalid users.login_risk.velocity.max_failures: -1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_InvalidJobs(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "retention", yaml: "users:\n  jobs:\n    retention: 0s", wantErr: "invalid users.jobs.retention: 0s"},
+		{name: "stale after", yaml: "users:\n  jobs:\n    stale_after: -1m", wantErr: "invalid users.jobs.stale_after: -1m0s"},
+		{name: "check interval", yaml: "users:\n  jobs:\n    check_interval: 0s", wantErr: "invalid users.jobs.check_interval: 0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_Locks(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, LocksConfig{Driver: "memory", Prefix: "locks"}, cfg.Locks)
+}
+
+func TestLoad_Cache(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, CacheConfig{
+		Driver:       "none",
+		TTL:          5 * time.Minute,
+		Prefix:       "cache",
+		Memory:       MemoryCacheConfig{MaxBytes: 64 << 20},
+		Invalidation: CacheInvalidationConfig{Driver: "none", Channel: "cache_invalidations"},
+	}, cfg.Cache)
+	assert.False(t, cfg.Cache.Enabled())
+	assert.False(t, cfg.Cache.Invalidation.Enabled())
+}
+
+func TestLoad_InvalidCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "unknown driver", yaml: "cache:\n  driver: memcached", wantErr: `unknown cache driver: "memcached"`},
+		{name: "zero ttl", yaml: "cache:\n  driver: memory\n  ttl: 0s", wantErr: "cache.ttl must be positive: 0s"},
+		{name: "zero max bytes", yaml: "cache:\n  driver: memory\n  memory:\n    max_bytes: 0", wantErr: "cache.memory.max_bytes must be positive: 0"},
+		{name: "redis without host", yaml: "cache:\n  driver: redis", wantErr: "cache.driver redis requires redis.host"},
+		{name: "redis with encryption", yaml: "redis:\n  host: localhost\ncache:\n  driver: redis\nusers:\n  encryption:\n    enabled: true", wantErr: "cache.driver redis cannot be used with users.encryption"},
+		{name: "unknown invalidation driver", yaml: "cache:\n  driver: memory\n  invalidation:\n    driver: kafka", wantErr: `unknown cache invalidation driver: "kafka"`},
+		{name: "invalidation without memory cache", yaml: "redis:\n  host: localhost\ncache:\n  driver: redis\n  invalidation:\n    driver: redis", wantErr: "cache.invalidation requires cache.driver memory"},
+		{name: "invalidation without channel", yaml: "cache:\n  driver: memory\n  invalidation:\n    driver: postgres\n    channel: \"\"", wantErr: "cache.invalidation.channel is required"},
+		{name: "redis invalidation without host", yaml: "cache:\n  driver: memory\n  invalidation:\n    driver: redis", wantErr: "cache.invalidation.driver redis requires redis.host"},
+		{name: "postgres invalidation with memory storage", yaml: "storage:\n  driver: memory\ncache:\n  driver: memory\n  invalidation:\n    driver: postgres", wantErr: "cache.invalidation.driver postgres requires PostgreSQL, not the memory storage driver"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_Redis(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.False(t, cfg.Redis.Enabled(), "Redis is optional")
+	assert.Equal(t, 6379, cfg.Redis.Port)
+
+	t.Setenv("REDIS_HOST", "cache.internal")
+	cfg, err = Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.True(t, cfg.Redis.Enabled())
+	assert.Equal(t, "cache.internal:6379", cfg.Redis.Address())
+}
+
+func TestLoad_Seed(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, SeedConfig{}, cfg.Seed)
+}
+
+func TestLoad_InvalidSeed(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("app:\n  environment: production\nseed:\n  enabled: true\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	_, err = Load(tmpFile.Name())
+	assert.EqualError(t, err, "seed.enabled is refused in production")
+}
+
+func TestLoad_InvalidLocks(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "unknown driver", yaml: "locks:\n  driver: etcd", wantErr: `unknown locks driver: "etcd"`},
+		{name: "postgres without database", yaml: "storage:\n  driver: memory\nlocks:\n  driver: postgres", wantErr: "locks.driver postgres requires PostgreSQL, not the memory storage driver"},
+		{name: "redis without host", yaml: "locks:\n  driver: redis", wantErr: "locks.driver redis requires redis.host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_Leader(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, LeaderConfig{
+		Driver:        "kubernetes",
+		LeaseDuration: 15 * time.Second,
+		RenewDeadline: 10 * time.Second,
+		RetryPeriod:   2 * time.Second,
+	}, cfg.Leader)
+}
+
+func TestLoad_InvalidLeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "unknown driver", yaml: "leader:\n  enabled: true\n  driver: etcd", wantErr: `unknown leader driver: "etcd"`},
+		{name: "postgres without database", yaml: "storage:\n  driver: memory\nleader:\n  enabled: true\n  driver: postgres", wantErr: "leader.driver postgres requires PostgreSQL, not the memory storage driver"},
+		{name: "no retry period", yaml: "leader:\n  enabled: true\n  retry_period: 0s", wantErr: "leader.retry_period must be positive"},
+		{name: "deadline within retry period", yaml: "leader:\n  enabled: true\n  renew_deadline: 2s", wantErr: "leader.renew_deadline must be longer than leader.retry_period"},
+		{name: "lease within deadline", yaml: "leader:\n  enabled: true\n  lease_duration: 10s", wantErr: "leader.lease_duration must be longer than leader.renew_deadline"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_ObjectStorage(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("storage:\n  objects:\n    lifecycle:\n      - prefix: exports/\n        expire_after: 168h\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	cfg, err := Load(tmpFile.Name())
+	require.NoError(t, err)
+	assert.Equal(t, ObjectStorageConfig{
+		Driver: "local",
+		Local:  LocalObjectStorageConfig{Dir: "./data/objects", BaseURL: "/objects"},
+		S3:     S3StorageConfig{Region: "us-east-1", PresignTTL: 15 * time.Minute},
+		Lifecycle: []LifecycleRuleConfig{
+			{Prefix: "exports/", ExpireAfter: 168 * time.Hour},
+		},
+		CleanupInterval: time.Hour,
+	}, cfg.Storage.Objects)
+}
+
+func TestLoad_InvalidObjectStorage(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{name: "unknown driver", yaml: "storage:\n  objects:\n    driver: floppy", wantErr: `unknown storage.objects driver: "floppy"`},
+		{name: "s3 without bucket", yaml: "storage:\n  objects:\n    driver: s3", wantErr: "storage.objects.driver s3 requires storage.objects.s3.bucket"},
+		{name: "minio without endpoint", yaml: "storage:\n  objects:\n    driver: minio\n    s3:\n      bucket: exports", wantErr: "storage.objects.driver minio requires storage.objects.s3.endpoint"},
+		{name: "rule without prefix", yaml: "storage:\n  objects:\n    lifecycle:\n      - expire_after: 24h", wantErr: "storage.objects.lifecycle rules require a prefix"},
+		{name: "rule without expiry", yaml: "storage:\n  objects:\n    lifecycle:\n      - prefix: exports/", wantErr: "storage.objects.lifecycle rule exports/: expire_after must be positive"},
+		{name: "no cleanup interval", yaml: "storage:\n  objects:\n    cleanup_interval: 0s\n    lifecycle:\n      - prefix: exports/\n        expire_after: 24h", wantErr: "storage.objects.cleanup_interval must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile, err := os.CreateTemp("", "config-*.yaml")
+			require.NoError(t, err)
+			defer os.Remove(tmpFile.Name())
+
+			_, err = tmpFile.WriteString(tt.yaml + "\n")
+			require.NoError(t, err)
+			tmpFile.Close()
+
+			_, err = Load(tmpFile.Name())
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_UnknownRedactionPolicy(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "config-*.yaml")
+	require.NoError(t, err)
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString("observability:\n  redaction:\n    policies:\n      production: none\n")
+	require.NoError(t, err)
+	tmpFile.Close()
+
+	_, err = Load(tmpFile.Name())
+	assert.EqualError(t, err, `unknown redaction policy: "none"`)
+}
@@ -0,0 +1,165 @@
+package admin
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// setupRouter returns a router with an admin group guarded by opts, and
+// the buffer the middleware logs to
+func setupRouter(t *testing.T, opts Options) (*gin.Engine, *bytes.Buffer) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var logs bytes.Buffer
+	router := gin.New()
+	router.Use(request.Middleware(request.Options{MaxBodyBytes: 1 << 10}))
+	group := router.Group(Prefix, Middleware(opts, logger.New("info", &logs)))
+	group.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	RegisterLogLevelRoutes(group)
+	return router, &logs
+}
+
+func serve(router *gin.Engine, method, path, remoteAddr, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_RequiresToken(t *testing.T) {
+	router, logs := setupRouter(t, Options{Token: "secret"})
+
+	w := serve(router, http.MethodGet, "/admin/ping", "192.0.2.1:1234", "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer realm="admin"`, w.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, logs.String(), "Admin request denied")
+
+	w = serve(router, http.MethodGet, "/admin/ping", "192.0.2.1:1234", "wrong", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve(router, http.MethodGet, "/admin/ping", "192.0.2.1:1234", "secret", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Contains(t, logs.String(), `"route":"/admin/ping"`)
+}
+
+func TestMiddleware_RejectsEverythingWithoutToken(t *testing.T) {
+	router, _ := setupRouter(t, Options{})
+
+	w := serve(router, http.MethodGet, "/admin/ping", "192.0.2.1:1234", "", "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestMiddleware_AllowedNetworks(t *testing.T) {
+	networks, err := ParseNetworks([]string{"10.0.0.0/8", "192.0.2.7"})
+	require.NoError(t, err)
+	router, _ := setupRouter(t, Options{Token: "secret", AllowedNetworks: networks})
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		wantStatus int
+	}{
+		{name: "inside a network", remoteAddr: "10.1.2.3:1234", wantStatus: http.StatusNoContent},
+		{name: "single address", remoteAddr: "192.0.2.7:1234", wantStatus: http.StatusNoContent},
+		{name: "outside the networks", remoteAddr: "192.0.2.8:1234", wantStatus: http.StatusForbidden},
+		{name: "forwarded header is ignored", remoteAddr: "192.0.2.8:1234", forwarded: "10.1.2.3", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/admin/ping", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("Authorization", "Bearer secret")
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMiddleware_AllowedNetworksBehindProxy(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	networks, err := ParseNetworks([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	proxies, err := clientip.ParseNetworks([]string{"192.0.2.1"})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(clientip.NewResolver(proxies).Middleware())
+	group := router.Group(Prefix, Middleware(Options{Token: "secret", AllowedNetworks: networks}, logger.New("info", io.Discard)))
+	group.GET("/ping", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for forwarded, want := range map[string]int{"10.1.2.3": http.StatusNoContent, "198.51.100.1": http.StatusForbidden} {
+		req := httptest.NewRequest(http.MethodGet, "/admin/ping", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("X-Forwarded-For", forwarded)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, want, w.Code, forwarded)
+	}
+}
+
+func TestParseNetworks(t *testing.T) {
+	networks, err := ParseNetworks([]string{"10.1.0.0/16", "::1", "10.1.2.3/8"})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.1.0.0/16"),
+		netip.MustParsePrefix("::1/128"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+	}, networks)
+
+	_, err = ParseNetworks([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+	_, err = ParseNetworks([]string{"localhost"})
+	assert.Error(t, err)
+}
+
+func TestLogLevelRoutes(t *testing.T) {
+	previous := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(previous) })
+
+	router, _ := setupRouter(t, Options{Token: "secret"})
+
+	w := serve(router, http.MethodPut, "/admin/log-level", "192.0.2.1:1234", "secret", `{"level":"debug"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+
+	w = serve(router, http.MethodGet, "/admin/log-level", "192.0.2.1:1234", "secret", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"level":"debug"}`, w.Body.String())
+
+	w = serve(router, http.MethodPut, "/admin/log-level", "192.0.2.1:1234", "secret", `{"level":"loud"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+}
@@ -0,0 +1,53 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// LogLevelRequest is the body of PUT /admin/log-level
+type LogLevelRequest struct {
+	Level string `json:"level" binding:"required,oneof=trace debug info warn error"`
+}
+
+// LogLevelResponse reports the current log level
+type LogLevelResponse struct {
+	Level string `json:"level"`
+}
+
+// RegisterLogLevelRoutes registers the routes reading and changing the log
+// level of the running process. The change is not persisted and lasts until
+// the next restart.
+func RegisterLogLevelRoutes(group *gin.RouterGroup) {
+	group.GET("/log-level", func(c *gin.Context) {
+		c.JSON(http.StatusOK, LogLevelResponse{Level: zerolog.GlobalLevel().String()})
+	})
+
+	group.PUT("/log-level", func(c *gin.Context) {
+		var req LogLevelRequest
+		if err := request.BindJSON(c, &req); err != nil {
+			var bindErr *request.Error
+			if errors.As(err, &bindErr) {
+				c.JSON(bindErr.Status, gin.H{"error": bindErr.Message, "code": problem.CodeOf(bindErr), "fields": bindErr.Fields})
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": problem.CodeValidationFailed})
+			return
+		}
+
+		level, err := zerolog.ParseLevel(req.Level)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": problem.CodeValidationFailed})
+			return
+		}
+
+		zerolog.SetGlobalLevel(level)
+		c.JSON(http.StatusOK, LogLevelResponse{Level: level.String()})
+	})
+}
@@ -0,0 +1,27 @@
+// Package cache keeps short-lived values, such as users read on hot paths,
+// in process memory or in Redis, selected by cache.driver.
+//
+// The memory cache evicts by size and is not shared: each instance caches
+// and invalidates on its own, so it only suits a single replica. Redis is
+// shared by every instance connected to it, so an invalidation on one is
+// seen by all of them.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// Cache stores values under keys until they expire
+type Cache interface {
+	// Get returns the value of key, with found set to false when it is
+	// missing or expired
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+
+	// Set stores value under key for ttl. The memory cache may drop the
+	// value when it is full of values used more often.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes keys. Missing keys are ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// forEachCache runs test against every Cache implementation. expire ends
+// the TTL of the values.
+func forEachCache(t *testing.T, test func(t *testing.T, c Cache, expire func(ttl time.Duration))) {
+	t.Run("memory", func(t *testing.T) {
+		c, err := NewMemoryCache(1 << 20)
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		test(t, c, func(ttl time.Duration) { time.Sleep(ttl) })
+	})
+	t.Run("redis", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+		test(t, NewRedisCache(client, "cache"), server.FastForward)
+	})
+}
+
+func TestCache_SetGetDelete(t *testing.T) {
+	forEachCache(t, func(t *testing.T, c Cache, _ func(time.Duration)) {
+		ctx := context.Background()
+
+		_, found, err := c.Get(ctx, "users:id:42")
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		require.NoError(t, c.Set(ctx, "users:id:42", []byte(`{"name":"Ada"}`), time.Minute))
+		require.NoError(t, c.Set(ctx, "users:id:7", []byte(`{"name":"Grace"}`), time.Minute))
+		value, found, err := c.Get(ctx, "users:id:42")
+		require.NoError(t, err)
+		require.True(t, found)
+		assert.Equal(t, `{"name":"Ada"}`, string(value))
+
+		require.NoError(t, c.Delete(ctx, "users:id:42", "users:id:missing"))
+		_, found, err = c.Get(ctx, "users:id:42")
+		require.NoError(t, err)
+		assert.False(t, found, "deleted")
+		_, found, err = c.Get(ctx, "users:id:7")
+		require.NoError(t, err)
+		assert.True(t, found, "other keys are kept")
+	})
+}
+
+func TestCache_Expires(t *testing.T) {
+	forEachCache(t, func(t *testing.T, c Cache, expire func(time.Duration)) {
+		ctx := context.Background()
+		require.NoError(t, c.Set(ctx, "users:id:42", []byte("Ada"), 20*time.Millisecond))
+
+		expire(30 * time.Millisecond)
+		_, found, err := c.Get(ctx, "users:id:42")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestMemoryCache_EvictsBySize(t *testing.T) {
+	c, err := NewMemoryCache(4 << 10)
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	ctx := context.Background()
+
+	value := []byte(strings.Repeat("x", 1<<10))
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		require.NoError(t, c.Set(ctx, key, value, time.Minute))
+	}
+
+	held := 0
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		if _, found, _ := c.Get(ctx, key); found {
+			held++
+		}
+	}
+	assert.LessOrEqual(t, held, 4, "values beyond max_bytes are evicted or not admitted")
+
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(c))
+	assert.LessOrEqual(t, c.cache.MaxCost()-c.cache.RemainingCost(), int64(4<<10))
+	count, err := testutil.GatherAndCount(reg, "cache_memory_evictions_total", "cache_memory_rejections_total", "cache_memory_used_bytes", "cache_memory_max_bytes")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+}
+
+// failingCache fails every call
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingCache) Delete(ctx context.Context, keys ...string) error {
+	return errors.New("connection refused")
+}
+
+func TestInstrument(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	memory, err := NewMemoryCache(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(memory.Close)
+
+	c, err := Instrument(memory, "memory", reg)
+	require.NoError(t, err)
+	_, _, _ = c.Get(ctx, "users:id:42")
+	require.NoError(t, c.Set(ctx, "users:id:42", []byte("Ada"), time.Minute))
+	_, _, _ = c.Get(ctx, "users:id:42")
+	require.NoError(t, c.Delete(ctx, "users:id:42"))
+
+	failing, err := Instrument(failingCache{}, "redis", reg)
+	require.NoError(t, err, "registering twice reuses the counter")
+	_, _, err = failing.Get(ctx, "users:id:42")
+	assert.Error(t, err)
+
+	operations := c.(*instrumented).operations
+	assert.Equal(t, 1.0, testutil.ToFloat64(operations.WithLabelValues("memory", "get", "miss")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(operations.WithLabelValues("memory", "get", "hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(operations.WithLabelValues("memory", "set", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(operations.WithLabelValues("memory", "delete", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(operations.WithLabelValues("redis", "get", "error")))
+}
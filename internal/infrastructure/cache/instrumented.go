@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// instrumented counts the outcomes of the calls to a Cache
+type instrumented struct {
+	cache      Cache
+	operations *prometheus.CounterVec
+	driver     string
+}
+
+// Instrument returns c counting its hits, misses and errors in the
+// cache_operations_total counter of reg, labeled with driver. Registering
+// twice with the same registry reuses the counter registered first.
+func Instrument(c Cache, driver string, reg prometheus.Registerer) (Cache, error) {
+	operations, err := register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_operations_total",
+		Help: "Cache operations by driver, operation and result: hit, miss, ok or error.",
+	}, []string{"driver", "operation", "result"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &instrumented{cache: c, operations: operations, driver: driver}, nil
+}
+
+// Get returns the value and records a hit, a miss or an error
+func (c *instrumented) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, found, err := c.cache.Get(ctx, key)
+	switch {
+	case err != nil:
+		c.record("get", "error")
+	case found:
+		c.record("get", "hit")
+	default:
+		c.record("get", "miss")
+	}
+	return value, found, err
+}
+
+// Set stores the value and records the outcome
+func (c *instrumented) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	err := c.cache.Set(ctx, key, value, ttl)
+	c.record("set", outcome(err))
+	return err
+}
+
+// Delete removes the keys and records the outcome
+func (c *instrumented) Delete(ctx context.Context, keys ...string) error {
+	err := c.cache.Delete(ctx, keys...)
+	c.record("delete", outcome(err))
+	return err
+}
+
+func (c *instrumented) record(operation, result string) {
+	c.operations.WithLabelValues(c.driver, operation, result).Inc()
+}
+
+// outcome is the result label of a write
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
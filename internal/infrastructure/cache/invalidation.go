@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+const (
+	// initialBackoff and maxBackoff bound the pauses before subscribing
+	// again to a failed bus
+	initialBackoff = 500 * time.Millisecond
+	maxBackoff     = 30 * time.Second
+)
+
+// Bus carries invalidations between the instances of the application
+type Bus interface {
+	// Publish sends payload to every instance subscribed, including this one
+	Publish(ctx context.Context, payload []byte) error
+
+	// Subscribe calls handle with each payload published until ctx is done
+	// or the subscription fails. subscribed is called once payloads are
+	// received.
+	Subscribe(ctx context.Context, subscribed func(), handle func(payload []byte)) error
+}
+
+// invalidation is the message published for deleted keys
+type invalidation struct {
+	Origin      string    `json:"origin"` // instance that deleted the keys
+	Keys        []string  `json:"keys"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Invalidator fans the deletes of a memory cache out to the memory caches
+// of the other instances, so a change made on one instance is not served
+// stale by the others for longer than the invalidation takes to arrive.
+//
+// An invalidation published while an instance is not subscribed, such as
+// while its connection to the bus is down, is lost. Each instance therefore
+// clears its cache whenever it subscribes; values still expire after their
+// TTL if the bus stays down.
+type Invalidator struct {
+	bus    Bus
+	origin string
+	log    *logger.Logger
+	local  *MemoryCache
+
+	events *prometheus.CounterVec
+	lag    prometheus.Histogram
+}
+
+// NewInvalidator creates an invalidator publishing on bus, counting its
+// invalidations and their lag in reg. Registering twice with the same
+// registry reuses the metrics registered first.
+func NewInvalidator(bus Bus, reg prometheus.Registerer, log *logger.Logger) (*Invalidator, error) {
+	events, err := register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_invalidations_total",
+		Help: "Cache invalidations by event: published, publish_failed, applied or invalid.",
+	}, []string{"event"}))
+	if err != nil {
+		return nil, err
+	}
+
+	lag, err := register(reg, prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "cache_invalidation_lag_seconds",
+		Help:    "Time from publishing an invalidation to applying it on another instance.",
+		Buckets: prometheus.DefBuckets,
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Invalidator{
+		bus:    bus,
+		origin: uuid.NewString(),
+		log:    log,
+		events: events,
+		lag:    lag,
+	}, nil
+}
+
+// Wrap returns local publishing its deletes, and makes Run apply the
+// deletes of the other instances to it
+func (i *Invalidator) Wrap(local *MemoryCache) Cache {
+	i.local = local
+	return &invalidating{MemoryCache: local, invalidator: i}
+}
+
+// Run applies the invalidations of the other instances to the wrapped
+// cache until ctx is done, subscribing again with backoff when the bus
+// fails
+func (i *Invalidator) Run(ctx context.Context) {
+	if i.local == nil {
+		i.log.Error().Msg("Cache invalidator runs without a cache to invalidate")
+		return
+	}
+
+	backoff := initialBackoff
+	for {
+		err := i.bus.Subscribe(ctx, func() {
+			// Invalidations published until now may have been missed
+			i.local.Clear()
+			backoff = initialBackoff
+			i.log.Info().Msg("Subscribed to cache invalidations")
+		}, func(payload []byte) {
+			i.apply(ctx, payload)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		i.log.Warn().Err(err).Dur("retry_in", backoff).Msg("Cache invalidation subscription failed")
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// apply deletes the keys of an invalidation published by another instance
+func (i *Invalidator) apply(ctx context.Context, payload []byte) {
+	var msg invalidation
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		i.events.WithLabelValues("invalid").Inc()
+		i.log.Warn().Err(err).Msg("Ignoring invalid cache invalidation")
+		return
+	}
+	// Deleted locally before it was published
+	if msg.Origin == i.origin {
+		return
+	}
+
+	_ = i.local.Delete(ctx, msg.Keys...)
+	i.events.WithLabelValues("applied").Inc()
+	i.lag.Observe(max(time.Since(msg.PublishedAt), 0).Seconds())
+}
+
+// publish sends an invalidation of keys to the other instances
+func (i *Invalidator) publish(ctx context.Context, keys []string) error {
+	payload, err := json.Marshal(invalidation{Origin: i.origin, Keys: keys, PublishedAt: time.Now()})
+	if err != nil {
+		return err
+	}
+	if err := i.bus.Publish(ctx, payload); err != nil {
+		i.events.WithLabelValues("publish_failed").Inc()
+		return err
+	}
+	i.events.WithLabelValues("published").Inc()
+	return nil
+}
+
+// invalidating is a memory cache publishing its deletes
+type invalidating struct {
+	*MemoryCache
+	invalidator *Invalidator
+}
+
+// Delete removes the keys here, then from the other instances. The keys
+// are removed here even when publishing fails.
+func (c *invalidating) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return errors.Join(c.MemoryCache.Delete(ctx, keys...), c.invalidator.publish(ctx, keys))
+}
+
+// register registers c with reg, or returns the collector already
+// registered in its place
+func register[C prometheus.Collector](reg prometheus.Registerer, c C) (C, error) {
+	err := reg.Register(c)
+	var existing prometheus.AlreadyRegisteredError
+	if errors.As(err, &existing) {
+		if same, ok := existing.ExistingCollector.(C); ok {
+			return same, nil
+		}
+	}
+	return c, err
+}
@@ -0,0 +1,224 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// memoryBus is a Bus delivering payloads synchronously to its subscribers
+type memoryBus struct {
+	mu          sync.Mutex
+	subscribers map[int]func([]byte)
+	next        int
+	publishErr  error
+}
+
+func newMemoryBus() *memoryBus {
+	return &memoryBus{subscribers: map[int]func([]byte){}}
+}
+
+func (b *memoryBus) Publish(ctx context.Context, payload []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.publishErr != nil {
+		return b.publishErr
+	}
+	for _, handle := range b.subscribers {
+		handle(payload)
+	}
+	return nil
+}
+
+func (b *memoryBus) Subscribe(ctx context.Context, subscribed func(), handle func([]byte)) error {
+	b.mu.Lock()
+	id := b.next
+	b.next++
+	b.subscribers[id] = handle
+	b.mu.Unlock()
+	subscribed()
+
+	<-ctx.Done()
+	b.mu.Lock()
+	delete(b.subscribers, id)
+	b.mu.Unlock()
+	return ctx.Err()
+}
+
+func (b *memoryBus) count() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// replica is an instance with a memory cache invalidated over a bus
+type replica struct {
+	cache       Cache
+	invalidator *Invalidator
+	registry    *prometheus.Registry
+}
+
+func newReplica(t *testing.T, bus Bus) replica {
+	t.Helper()
+	memory, err := NewMemoryCache(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(memory.Close)
+
+	registry := prometheus.NewRegistry()
+	invalidator, err := NewInvalidator(bus, registry, logger.New("info", io.Discard))
+	require.NoError(t, err)
+	return replica{cache: invalidator.Wrap(memory), invalidator: invalidator, registry: registry}
+}
+
+// run runs the invalidators of replicas until the test ends, returning
+// once they are subscribed
+func run(t *testing.T, bus *memoryBus, replicas ...replica) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	for _, r := range replicas {
+		wg.Go(func() { r.invalidator.Run(ctx) })
+	}
+	require.Eventually(t, func() bool { return bus.count() == len(replicas) }, time.Second, time.Millisecond)
+}
+
+// lagSamples returns the number of lags observed in reg
+func lagSamples(t *testing.T, reg *prometheus.Registry) uint64 {
+	t.Helper()
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == "cache_invalidation_lag_seconds" {
+			return family.GetMetric()[0].GetHistogram().GetSampleCount()
+		}
+	}
+	return 0
+}
+
+func TestInvalidator_FansOutDeletes(t *testing.T) {
+	bus := newMemoryBus()
+	a, b := newReplica(t, bus), newReplica(t, bus)
+	run(t, bus, a, b)
+	ctx := context.Background()
+
+	for _, r := range []replica{a, b} {
+		require.NoError(t, r.cache.Set(ctx, "users:id:42", []byte("Ada"), time.Minute))
+		require.NoError(t, r.cache.Set(ctx, "users:id:7", []byte("Grace"), time.Minute))
+	}
+
+	require.NoError(t, a.cache.Delete(ctx, "users:id:42"))
+	for name, r := range map[string]replica{"a": a, "b": b} {
+		_, found, err := r.cache.Get(ctx, "users:id:42")
+		require.NoError(t, err)
+		assert.False(t, found, "deleted from %s", name)
+		_, found, err = r.cache.Get(ctx, "users:id:7")
+		require.NoError(t, err)
+		assert.True(t, found, "other keys are kept on %s", name)
+	}
+
+	events := func(r replica, event string) float64 {
+		return testutil.ToFloat64(r.invalidator.events.WithLabelValues(event))
+	}
+	assert.Equal(t, 1.0, events(a, "published"))
+	assert.Equal(t, 0.0, events(a, "applied"), "own invalidations are skipped")
+	assert.Equal(t, 1.0, events(b, "applied"))
+	assert.Equal(t, uint64(0), lagSamples(t, a.registry))
+	assert.Equal(t, uint64(1), lagSamples(t, b.registry))
+}
+
+func TestInvalidator_ClearsOnSubscribe(t *testing.T) {
+	bus := newMemoryBus()
+	r := newReplica(t, bus)
+	ctx := context.Background()
+	require.NoError(t, r.cache.Set(ctx, "users:id:42", []byte("Ada"), time.Minute))
+
+	run(t, bus, r)
+	_, found, err := r.cache.Get(ctx, "users:id:42")
+	require.NoError(t, err)
+	assert.False(t, found, "invalidations may have been missed before subscribing")
+}
+
+func TestInvalidator_IgnoresInvalidPayloads(t *testing.T) {
+	bus := newMemoryBus()
+	r := newReplica(t, bus)
+	run(t, bus, r)
+
+	require.NoError(t, bus.Publish(context.Background(), []byte("not json")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.invalidator.events.WithLabelValues("invalid")))
+}
+
+func TestInvalidator_PublishFails(t *testing.T) {
+	bus := newMemoryBus()
+	r := newReplica(t, bus)
+	run(t, bus, r)
+	ctx := context.Background()
+	require.NoError(t, r.cache.Set(ctx, "users:id:42", []byte("Ada"), time.Minute))
+
+	bus.publishErr = errors.New("bus down")
+	assert.EqualError(t, r.cache.Delete(ctx, "users:id:42"), "bus down")
+	_, found, err := r.cache.Get(ctx, "users:id:42")
+	require.NoError(t, err)
+	assert.False(t, found, "deleted here anyway")
+	assert.Equal(t, 1.0, testutil.ToFloat64(r.invalidator.events.WithLabelValues("publish_failed")))
+}
+
+func TestNewInvalidator_ReusesMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first, err := NewInvalidator(newMemoryBus(), registry, logger.New("info", io.Discard))
+	require.NoError(t, err)
+	second, err := NewInvalidator(newMemoryBus(), registry, logger.New("info", io.Discard))
+	require.NoError(t, err)
+
+	assert.Same(t, first.events, second.events)
+	assert.NotEqual(t, first.origin, second.origin)
+}
+
+func TestRedisBus(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	bus := NewRedisBus(client, "cache_invalidations")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	subscribed := make(chan struct{})
+	received := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- bus.Subscribe(ctx, func() { close(subscribed) }, func(payload []byte) {
+			received <- string(payload)
+		})
+	}()
+
+	<-subscribed
+	require.NoError(t, bus.Publish(context.Background(), []byte(`{"keys":["users:id:42"]}`)))
+	select {
+	case payload := <-received:
+		assert.Equal(t, `{"keys":["users:id:42"]}`, payload)
+	case <-time.After(time.Second):
+		t.Fatal("payload not received")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("subscription not stopped")
+	}
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// averageValueBytes estimates the size of a value, to size the admission
+// counters of the memory cache
+const averageValueBytes = 512
+
+// MemoryCache implements Cache in process memory with ristretto. Values
+// cost their size in bytes; once maxBytes are used, the values used least
+// often are evicted.
+type MemoryCache struct {
+	cache *ristretto.Cache[string, []byte]
+
+	evicted  *prometheus.Desc
+	rejected *prometheus.Desc
+	used     *prometheus.Desc
+	capacity *prometheus.Desc
+}
+
+// NewMemoryCache creates a memory cache holding up to maxBytes of values
+func NewMemoryCache(maxBytes int64) (*MemoryCache, error) {
+	c, err := ristretto.NewCache(&ristretto.Config[string, []byte]{
+		// Ten counters per value the cache can hold, as ristretto advises
+		NumCounters: max(10*maxBytes/averageValueBytes, 1000),
+		MaxCost:     maxBytes,
+		BufferItems: 64,
+		Metrics:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create memory cache: %w", err)
+	}
+
+	return &MemoryCache{
+		cache:    c,
+		evicted:  prometheus.NewDesc("cache_memory_evictions_total", "Values evicted from the memory cache to make room.", nil, nil),
+		rejected: prometheus.NewDesc("cache_memory_rejections_total", "Values not admitted into the memory cache as less used than those they would evict.", nil, nil),
+		used:     prometheus.NewDesc("cache_memory_used_bytes", "Bytes of values held by the memory cache.", nil, nil),
+		capacity: prometheus.NewDesc("cache_memory_max_bytes", "Bytes of values the memory cache holds before evicting.", nil, nil),
+	}, nil
+}
+
+// Get returns the value of key unless it expired or was evicted
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, found := c.cache.Get(key)
+	return value, found, nil
+}
+
+// Set stores value for ttl. It waits until the value is stored, so a Get
+// that follows finds it unless it was not admitted.
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.cache.SetWithTTL(key, value, int64(len(value)), ttl)
+	c.cache.Wait()
+	return nil
+}
+
+// Delete removes the keys
+func (c *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	for _, key := range keys {
+		c.cache.Del(key)
+	}
+	return nil
+}
+
+// Clear drops every value
+func (c *MemoryCache) Clear() {
+	c.cache.Clear()
+}
+
+// Close stops the goroutines of the cache and drops its values
+func (c *MemoryCache) Close() {
+	c.cache.Close()
+}
+
+// Describe implements prometheus.Collector
+func (c *MemoryCache) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.evicted
+	ch <- c.rejected
+	ch <- c.used
+	ch <- c.capacity
+}
+
+// Collect implements prometheus.Collector with the eviction stats and
+// size of the cache
+func (c *MemoryCache) Collect(ch chan<- prometheus.Metric) {
+	metrics := c.cache.Metrics
+	maxCost := c.cache.MaxCost()
+	ch <- prometheus.MustNewConstMetric(c.evicted, prometheus.CounterValue, float64(metrics.KeysEvicted()))
+	ch <- prometheus.MustNewConstMetric(c.rejected, prometheus.CounterValue, float64(metrics.SetsRejected()))
+	ch <- prometheus.MustNewConstMetric(c.used, prometheus.GaugeValue, float64(maxCost-c.cache.RemainingCost()))
+	ch <- prometheus.MustNewConstMetric(c.capacity, prometheus.GaugeValue, float64(maxCost))
+}
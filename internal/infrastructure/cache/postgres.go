@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// PostgresBus implements Bus with LISTEN and NOTIFY on a PostgreSQL
+// channel. Payloads are limited to 8000 bytes.
+type PostgresBus struct {
+	db      *gorm.DB
+	connect func(ctx context.Context) (*pgx.Conn, error)
+	channel string
+}
+
+// NewPostgresBus creates a bus notifying channel through db. Subscriptions
+// listen on a connection of their own, opened with connect, as pooled
+// connections are not kept between queries.
+func NewPostgresBus(db *gorm.DB, connect func(ctx context.Context) (*pgx.Conn, error), channel string) *PostgresBus {
+	return &PostgresBus{
+		db:      db,
+		connect: connect,
+		channel: channel,
+	}
+}
+
+// Publish notifies the channel. Outside a transaction the notification is
+// sent at once.
+func (b *PostgresBus) Publish(ctx context.Context, payload []byte) error {
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", b.channel, string(payload)).Error
+}
+
+// Subscribe listens on the channel
+func (b *PostgresBus) Subscribe(ctx context.Context, subscribed func(), handle func(payload []byte)) error {
+	conn, err := b.connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to listen: %w", err)
+	}
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", b.channel, err)
+	}
+	subscribed()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		handle([]byte(notification.Payload))
+	}
+}
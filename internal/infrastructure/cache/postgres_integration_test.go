@@ -0,0 +1,64 @@
+//go:build integration
+
+package cache
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// emptyTemplate holds no tables, as notifications need none
+var emptyTemplate = pgtest.Template{
+	Name:    "cache",
+	Migrate: func(db *gorm.DB) error { return nil },
+}
+
+func TestPostgresBus(t *testing.T) {
+	db := pgtest.DB(t, emptyTemplate)
+	dsn := db.Dialector.(*postgres.Dialector).DSN
+	connect := func(ctx context.Context) (*pgx.Conn, error) {
+		return pgx.Connect(ctx, dsn)
+	}
+	bus := NewPostgresBus(db, connect, "cache invalidations")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	subscribed := make(chan struct{})
+	received := make(chan string, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- bus.Subscribe(ctx, func() { close(subscribed) }, func(payload []byte) {
+			received <- string(payload)
+		})
+	}()
+
+	<-subscribed
+	require.NoError(t, bus.Publish(context.Background(), []byte(`{"keys":["users:id:42"]}`)))
+	select {
+	case payload := <-received:
+		assert.Equal(t, `{"keys":["users:id:42"]}`, payload)
+	case <-time.After(5 * time.Second):
+		t.Fatal("payload not received")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not stopped")
+	}
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisCache implements Cache with Redis keys expiring with their values,
+// so every instance connected to the same Redis shares them
+type RedisCache struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisCache creates a Redis cache holding the value of key in the key
+// prefix:key
+func NewRedisCache(client redis.UniversalClient, prefix string) *RedisCache {
+	return &RedisCache{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Get returns the value of key unless it expired
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+":"+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value for ttl
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefix+":"+key, value, ttl).Err()
+}
+
+// Delete removes the keys
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = c.prefix + ":" + key
+	}
+	return c.client.Del(ctx, prefixed...).Err()
+}
+
+// RedisBus implements Bus with a Redis pub/sub channel
+type RedisBus struct {
+	client  redis.UniversalClient
+	channel string
+}
+
+// NewRedisBus creates a bus publishing on channel
+func NewRedisBus(client redis.UniversalClient, channel string) *RedisBus {
+	return &RedisBus{
+		client:  client,
+		channel: channel,
+	}
+}
+
+// Publish publishes payload on the channel
+func (b *RedisBus) Publish(ctx context.Context, payload []byte) error {
+	return b.client.Publish(ctx, b.channel, payload).Err()
+}
+
+// Subscribe receives the payloads published on the channel
+func (b *RedisBus) Subscribe(ctx context.Context, subscribed func(), handle func(payload []byte)) error {
+	pubsub := b.client.Subscribe(ctx, b.channel)
+	defer pubsub.Close()
+
+	// The first reply confirms the subscription
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+	subscribed()
+
+	// Receiving blocks without watching ctx
+	stop := context.AfterFunc(ctx, func() { _ = pubsub.Close() })
+	defer stop()
+
+	for {
+		msg, err := pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		handle([]byte(msg.Payload))
+	}
+}
@@ -0,0 +1,190 @@
+// Package cachecontrol sets the Cache-Control and Expires headers of
+// responses from declared policies: one per route group for reads, one for
+// mutations, and one replacing the others for authenticated requests, whose
+// responses shared caches must not hand to other clients.
+package cachecontrol
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/quota"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// Policy is a Cache-Control header value, such as "public, max-age=60" or
+// "no-store". The zero Policy sets no headers.
+type Policy struct {
+	value  string
+	maxAge time.Duration
+	fresh  bool // has a max-age
+	stale  bool // no-store or no-cache, so never served without revalidating
+}
+
+// ParsePolicy parses a Cache-Control header value. An empty value parses to
+// the zero Policy.
+func ParsePolicy(value string) (Policy, error) {
+	p := Policy{value: strings.TrimSpace(value)}
+	if p.value == "" {
+		return Policy{}, nil
+	}
+
+	for directive := range strings.SplitSeq(p.value, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(directive), "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return Policy{}, fmt.Errorf("empty directive in %q", value)
+		}
+
+		switch name {
+		case "max-age", "s-maxage", "stale-while-revalidate", "stale-if-error":
+			seconds, err := strconv.Atoi(strings.TrimSpace(arg))
+			if !hasArg || err != nil || seconds < 0 {
+				return Policy{}, fmt.Errorf("%s must be a number of seconds in %q", name, value)
+			}
+			if name == "max-age" {
+				p.maxAge = time.Duration(seconds) * time.Second
+				p.fresh = true
+			}
+		case "no-store", "no-cache":
+			p.stale = true
+		}
+	}
+	return p, nil
+}
+
+// String returns the header value
+func (p Policy) String() string {
+	return p.value
+}
+
+// IsZero reports whether the policy sets no headers
+func (p Policy) IsZero() bool {
+	return p.value == ""
+}
+
+// apply sets the headers of p, with Expires for the HTTP/1.0 caches that
+// ignore Cache-Control
+func (p Policy) apply(header http.Header, now time.Time) {
+	header.Set("Cache-Control", p.value)
+	switch {
+	case p.stale:
+		header.Set("Expires", time.Unix(0, 0).UTC().Format(http.TimeFormat))
+	case p.fresh:
+		header.Set("Expires", now.Add(p.maxAge).UTC().Format(http.TimeFormat))
+	}
+}
+
+// Options configures the middleware
+type Options struct {
+	// Default applies to GET and HEAD requests to routes without a policy
+	// of their own
+	Default Policy
+
+	// Routes holds the policies of GET and HEAD requests to route groups,
+	// keyed by path prefix such as "/users/:id". The longest matching
+	// prefix wins.
+	Routes map[string]Policy
+
+	// Mutations applies to the other methods, such as POST and DELETE
+	Mutations Policy
+
+	// Authenticated replaces the other policies for requests with an
+	// Authorization or X-API-Key header, such as "private, no-cache". The
+	// zero Policy leaves the other policies in place.
+	Authenticated Policy
+}
+
+// For returns the policy of a request to a route path
+func (o Options) For(r *http.Request, path string) Policy {
+	if !o.Authenticated.IsZero() && IsAuthenticated(r) {
+		return o.Authenticated
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return o.Mutations
+	}
+	if prefix, ok := request.MatchPrefix(o.Routes, path); ok {
+		return o.Routes[prefix]
+	}
+	return o.Default
+}
+
+// IsAuthenticated reports whether the request carries credentials
+func IsAuthenticated(r *http.Request) bool {
+	return r.Header.Get("Authorization") != "" || r.Header.Get(quota.APIKeyHeader) != ""
+}
+
+// Middleware sets the caching headers of the policy matching each request
+// on its successful responses. Handlers setting Cache-Control themselves,
+// such as for files, keep theirs; error responses and event streams get
+// no headers. With an Authenticated policy responses vary by credentials,
+// so shared caches key them apart.
+func Middleware(opts Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := opts.For(c.Request, request.RoutePath(c))
+		if policy.IsZero() || request.IsStreaming(c.Request) {
+			c.Next()
+			return
+		}
+
+		writer := &headerWriter{ResponseWriter: c.Writer, policy: policy, vary: !opts.Authenticated.IsZero()}
+		c.Writer = writer
+
+		c.Next()
+
+		// Responses without a body are written by Gin after the handlers
+		writer.apply()
+	}
+}
+
+// headerWriter sets the caching headers just before the response is
+// written, once its status is known
+type headerWriter struct {
+	gin.ResponseWriter
+	policy  Policy
+	vary    bool
+	applied bool
+}
+
+// apply sets the headers unless the response is written, failed, or has
+// a Cache-Control header already
+func (w *headerWriter) apply() {
+	if w.applied || w.ResponseWriter.Written() {
+		return
+	}
+	w.applied = true
+
+	header := w.Header()
+	if w.vary {
+		header.Add("Vary", "Authorization, "+quota.APIKeyHeader)
+	}
+	if w.Status() >= http.StatusBadRequest || header.Get("Cache-Control") != "" {
+		return
+	}
+	w.policy.apply(header, time.Now())
+}
+
+func (w *headerWriter) WriteHeaderNow() {
+	w.apply()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *headerWriter) Write(data []byte) (int, error) {
+	w.apply()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *headerWriter) WriteString(s string) (int, error) {
+	w.apply()
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *headerWriter) Flush() {
+	w.apply()
+	w.ResponseWriter.Flush()
+}
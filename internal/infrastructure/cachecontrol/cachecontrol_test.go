@@ -0,0 +1,151 @@
+package cachecontrol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func policy(t *testing.T, value string) Policy {
+	t.Helper()
+	p, err := ParsePolicy(value)
+	require.NoError(t, err)
+	return p
+}
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy(" public, max-age=60, s-maxage=30 ")
+	require.NoError(t, err)
+	assert.Equal(t, "public, max-age=60, s-maxage=30", p.String())
+	assert.Equal(t, time.Minute, p.maxAge)
+
+	p, err = ParsePolicy("")
+	require.NoError(t, err)
+	assert.True(t, p.IsZero())
+
+	for _, value := range []string{"max-age", "max-age=soon", "max-age=-1", "public,,no-cache", "s-maxage="} {
+		_, err := ParsePolicy(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// setupRouter returns a router serving GET /users/:id, GET /users, POST
+// /users and GET /avatars, which sets its own Cache-Control, behind the
+// middleware
+func setupRouter(opts Options) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Middleware(opts))
+	router.GET("/users/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+	router.GET("/users", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.POST("/users", func(c *gin.Context) { c.JSON(http.StatusCreated, gin.H{}) })
+	router.GET("/avatars", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=86400")
+		c.String(http.StatusOK, "image")
+	})
+	return router
+}
+
+func serve(router *gin.Engine, method, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	router := setupRouter(Options{
+		Default:   policy(t, "no-cache"),
+		Routes:    map[string]Policy{"/users/:id": policy(t, "public, max-age=30")},
+		Mutations: policy(t, "no-store"),
+	})
+
+	tests := []struct {
+		name         string
+		method, path string
+		wantControl  string
+		wantExpires  bool
+	}{
+		{name: "route policy", method: http.MethodGet, path: "/users/42", wantControl: "public, max-age=30", wantExpires: true},
+		{name: "default policy without body", method: http.MethodGet, path: "/users", wantControl: "no-cache", wantExpires: true},
+		{name: "mutation", method: http.MethodPost, path: "/users", wantControl: "no-store", wantExpires: true},
+		{name: "error response", method: http.MethodGet, path: "/users/missing"},
+		{name: "handler policy", method: http.MethodGet, path: "/avatars", wantControl: "public, max-age=86400"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(router, tt.method, tt.path, nil)
+			assert.Equal(t, tt.wantControl, w.Header().Get("Cache-Control"))
+			assert.Equal(t, tt.wantExpires, w.Header().Get("Expires") != "")
+			assert.Empty(t, w.Header().Values("Vary"), "responses do not vary without an authenticated policy")
+		})
+	}
+}
+
+func TestMiddleware_Expires(t *testing.T) {
+	router := setupRouter(Options{
+		Routes:    map[string]Policy{"/users/:id": policy(t, "public, max-age=30")},
+		Mutations: policy(t, "no-store"),
+	})
+
+	before := time.Now().Truncate(time.Second)
+	expires, err := http.ParseTime(serve(router, http.MethodGet, "/users/42", nil).Header().Get("Expires"))
+	require.NoError(t, err)
+	assert.WithinRange(t, expires, before.Add(30*time.Second), time.Now().Add(30*time.Second))
+
+	expires, err = http.ParseTime(serve(router, http.MethodPost, "/users", nil).Header().Get("Expires"))
+	require.NoError(t, err)
+	assert.True(t, expires.Before(before), "already expired")
+}
+
+func TestMiddleware_Authenticated(t *testing.T) {
+	router := setupRouter(Options{
+		Routes:        map[string]Policy{"/users/:id": policy(t, "public, max-age=30")},
+		Mutations:     policy(t, "no-store"),
+		Authenticated: policy(t, "private, no-cache"),
+	})
+
+	anonymous := serve(router, http.MethodGet, "/users/42", nil)
+	assert.Equal(t, "public, max-age=30", anonymous.Header().Get("Cache-Control"))
+	assert.Equal(t, "Authorization, X-API-Key", anonymous.Header().Get("Vary"), "shared caches keep the responses apart")
+
+	for name, header := range map[string]http.Header{
+		"bearer token": {"Authorization": {"Bearer secret"}},
+		"api key":      {"X-Api-Key": {"partner-key"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, method := range []string{http.MethodGet, http.MethodPost} {
+				path := "/users"
+				if method == http.MethodGet {
+					path = "/users/42"
+				}
+				w := serve(router, method, path, header)
+				assert.Equal(t, "private, no-cache", w.Header().Get("Cache-Control"), method)
+			}
+		})
+	}
+}
+
+func TestMiddleware_NoPolicy(t *testing.T) {
+	router := setupRouter(Options{})
+
+	w := serve(router, http.MethodGet, "/users/42", nil)
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+	assert.Empty(t, w.Header().Get("Expires"))
+}
@@ -0,0 +1,91 @@
+package capture
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// Paths of the admin routes, on the admin group
+const (
+	SettingsPath = "/debug/capture"
+	EntriesPath  = "/debug/captures"
+)
+
+// EnableRequest is the body of PUT /admin/debug/capture
+type EnableRequest struct {
+	Routes     []string `json:"routes"`
+	RequestIDs []string `json:"request_ids"`
+
+	// Duration is how long capturing stays on, such as "15m"; the
+	// maximum duration when empty or longer
+	Duration string `json:"duration"`
+}
+
+// SettingsResponse reports whether capturing is on and for what
+type SettingsResponse struct {
+	Enabled bool `json:"enabled"`
+	Settings
+}
+
+// EntriesResponse lists the buffered captures, newest first
+type EntriesResponse struct {
+	Captures []Entry `json:"captures"`
+}
+
+// RegisterAdminRoutes registers the routes turning capturing on and off and
+// reading the buffered captures on the admin group
+func RegisterAdminRoutes(group gin.IRoutes, c *Capturer) {
+	group.GET(SettingsPath, func(ctx *gin.Context) {
+		settings, ok := c.Settings()
+		ctx.JSON(http.StatusOK, SettingsResponse{Enabled: ok, Settings: settings})
+	})
+
+	group.PUT(SettingsPath, func(ctx *gin.Context) {
+		var req EnableRequest
+		if err := request.BindJSON(ctx, &req); err != nil {
+			var bindErr *request.Error
+			if errors.As(err, &bindErr) {
+				ctx.JSON(bindErr.Status, gin.H{"error": bindErr.Message, "code": problem.CodeOf(bindErr), "fields": bindErr.Fields})
+				return
+			}
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "code": problem.CodeValidationFailed})
+			return
+		}
+		if len(req.Routes) == 0 && len(req.RequestIDs) == 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "routes or request_ids is required", "code": problem.CodeValidationFailed})
+			return
+		}
+
+		settings := Settings{Routes: req.Routes, RequestIDs: req.RequestIDs}
+		if req.Duration != "" {
+			duration, err := time.ParseDuration(req.Duration)
+			if err != nil || duration <= 0 {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "duration must be a positive duration such as 15m", "code": problem.CodeValidationFailed})
+				return
+			}
+			settings.ExpiresAt = c.clock.Now().Add(duration)
+		}
+
+		ctx.JSON(http.StatusOK, SettingsResponse{Enabled: true, Settings: c.Enable(settings)})
+	})
+
+	group.DELETE(SettingsPath, func(ctx *gin.Context) {
+		c.Disable()
+		ctx.Status(http.StatusNoContent)
+	})
+
+	group.GET(EntriesPath, func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, EntriesResponse{Captures: c.Entries()})
+	})
+
+	group.DELETE(EntriesPath, func(ctx *gin.Context) {
+		c.Clear()
+		ctx.Status(http.StatusNoContent)
+	})
+}
@@ -0,0 +1,338 @@
+// Package capture records the request and response bodies of selected
+// requests for troubleshooting. Capturing is off until an operator turns
+// it on for some routes or request IDs, and turns itself off again after a
+// while. Bodies are cut to a size limit and redacted before they are kept
+// in a ring buffer, read through the admin routes, or logged.
+package capture
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/httpclient"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// AllRoutes selects every route in Settings.Routes
+const AllRoutes = "*"
+
+// sensitiveHeaders are redacted whatever the redaction fields
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Api-Key"}
+
+// Clock tells the time captures are stamped and expire with
+type Clock interface {
+	Now() time.Time
+}
+
+// Options configures the capturer
+type Options struct {
+	// MaxBodyBytes is how much of each body is kept
+	MaxBodyBytes int
+
+	// BufferSize is how many captures the admin routes can read; older
+	// ones are dropped
+	BufferSize int
+
+	// Log writes each capture to the log as well
+	Log bool
+
+	// MaxDuration bounds how long capturing stays on
+	MaxDuration time.Duration
+
+	// ExemptPaths are path prefixes never captured, such as the admin
+	// routes reading the captures
+	ExemptPaths []string
+}
+
+// Settings selects the requests captured until ExpiresAt
+type Settings struct {
+	// Routes are route patterns, such as "/users/:id", or AllRoutes
+	Routes []string `json:"routes"`
+
+	// RequestIDs are values of the X-Request-ID header
+	RequestIDs []string `json:"request_ids"`
+
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Entry is one captured request with its response
+type Entry struct {
+	ID         string    `json:"id"`
+	Time       time.Time `json:"time"`
+	RequestID  string    `json:"request_id,omitempty"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Route      string    `json:"route"`
+	ClientIP   string    `json:"client_ip"`
+	Status     int       `json:"status"`
+	DurationMS int64     `json:"duration_ms"`
+	Request    Message   `json:"request"`
+	Response   Message   `json:"response"`
+}
+
+// Message holds the redacted headers and body of a request or response
+type Message struct {
+	Headers   map[string]string `json:"headers"`
+	Body      string            `json:"body"`
+	Truncated bool              `json:"truncated"`
+}
+
+// Capturer records the requests selected by its settings
+type Capturer struct {
+	opts     Options
+	redactor *logger.Redactor
+	log      *logger.Logger
+	clock    Clock
+
+	mu       sync.Mutex
+	settings *Settings
+	entries  []Entry // ring buffer of BufferSize entries
+	next     int
+}
+
+// New creates a capturer redacting bodies and headers with redactor.
+// Capturing is off until Enable is called.
+func New(opts Options, redactor *logger.Redactor, log *logger.Logger, clock Clock) *Capturer {
+	return &Capturer{
+		opts:     opts,
+		redactor: redactor,
+		log:      log,
+		clock:    clock,
+	}
+}
+
+// Enable captures the requests selected by settings until they expire,
+// at most MaxDuration from now, and returns the settings in effect
+func (c *Capturer) Enable(settings Settings) Settings {
+	limit := c.clock.Now().Add(c.opts.MaxDuration)
+	if settings.ExpiresAt.IsZero() || settings.ExpiresAt.After(limit) {
+		settings.ExpiresAt = limit
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settings = &settings
+	return settings
+}
+
+// Disable stops capturing
+func (c *Capturer) Disable() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settings = nil
+}
+
+// Settings returns the settings in effect, or false when capturing is off
+func (c *Capturer) Settings() (Settings, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.activeSettings()
+}
+
+// activeSettings returns the unexpired settings; c.mu must be held
+func (c *Capturer) activeSettings() (Settings, bool) {
+	if c.settings == nil || !c.clock.Now().Before(c.settings.ExpiresAt) {
+		return Settings{}, false
+	}
+	return *c.settings, true
+}
+
+// Entries returns the buffered captures, newest first
+func (c *Capturer) Entries() []Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries := make([]Entry, 0, len(c.entries))
+	for i := range len(c.entries) {
+		entries = append(entries, c.entries[(c.next-1-i+len(c.entries))%len(c.entries)])
+	}
+	return entries
+}
+
+// Clear drops the buffered captures
+func (c *Capturer) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries, c.next = nil, 0
+}
+
+// Middleware captures the requests selected by the settings. Only the first
+// MaxBodyBytes of request bodies are read ahead of the handler, so large
+// uploads are not buffered.
+func (c *Capturer) Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !c.selects(ctx) {
+			ctx.Next()
+			return
+		}
+
+		c.record(ctx.Request.Context(), c.Tap(ctx))
+	}
+}
+
+// Tap runs the rest of the handler chain and returns the redacted entry of
+// the request and its response, whatever the settings. It lets other
+// middleware, such as fixture recording, keep entries elsewhere.
+func (c *Capturer) Tap(ctx *gin.Context) Entry {
+	start := c.clock.Now()
+	requestBody, requestTruncated := c.peekBody(ctx.Request)
+	writer := &recordingWriter{ResponseWriter: ctx.Writer, limit: c.opts.MaxBodyBytes}
+	ctx.Writer = writer
+
+	ctx.Next()
+
+	return Entry{
+		ID:         uuid.New().String(),
+		Time:       start,
+		RequestID:  ctx.GetHeader(httpclient.RequestIDHeader),
+		Method:     ctx.Request.Method,
+		Path:       c.redactor.String(ctx.Request.URL.RequestURI()),
+		Route:      request.RoutePath(ctx),
+		ClientIP:   clientip.FromRequest(ctx.Request).String(),
+		Status:     writer.Status(),
+		DurationMS: c.clock.Now().Sub(start).Milliseconds(),
+		Request:    c.message(ctx.Request.Header, requestBody, requestTruncated),
+		Response:   c.message(writer.Header(), writer.body.Bytes(), writer.truncated),
+	}
+}
+
+// selects reports whether the request is captured
+func (c *Capturer) selects(ctx *gin.Context) bool {
+	settings, ok := c.Settings()
+	if !ok || request.IsStreaming(ctx.Request) {
+		return false
+	}
+	for _, prefix := range c.opts.ExemptPaths {
+		if strings.HasPrefix(ctx.Request.URL.Path, prefix) {
+			return false
+		}
+	}
+
+	if id := ctx.GetHeader(httpclient.RequestIDHeader); id != "" && slices.Contains(settings.RequestIDs, id) {
+		return true
+	}
+	return slices.Contains(settings.Routes, AllRoutes) || slices.Contains(settings.Routes, ctx.FullPath())
+}
+
+// peekBody reads the first MaxBodyBytes of the request body and puts them
+// back, so the handler reads the whole body
+func (c *Capturer) peekBody(r *http.Request) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false
+	}
+
+	peeked, _ := io.ReadAll(io.LimitReader(r.Body, int64(c.opts.MaxBodyBytes)+1))
+	r.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(peeked), r.Body), Closer: r.Body}
+
+	if len(peeked) > c.opts.MaxBodyBytes {
+		return peeked[:c.opts.MaxBodyBytes], true
+	}
+	return peeked, false
+}
+
+// message redacts the headers and body of a request or response. Bodies
+// that are not text are left out.
+func (c *Capturer) message(header http.Header, body []byte, truncated bool) Message {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		if slices.Contains(sensitiveHeaders, http.CanonicalHeaderKey(name)) || c.redactor.Sensitive(name) {
+			value = logger.Redacted
+		}
+		headers[name] = c.redactor.String(value)
+	}
+
+	msg := Message{Headers: headers, Truncated: truncated}
+	if len(body) == 0 {
+		return msg
+	}
+	if contentType := header.Get("Content-Type"); !textual(contentType) {
+		msg.Body = "[" + contentType + " body not captured]"
+		return msg
+	}
+	msg.Body = strings.ToValidUTF8(string(c.redactor.Redact(body)), "")
+	return msg
+}
+
+// record keeps the entry in the buffer and logs it
+func (c *Capturer) record(ctx context.Context, entry Entry) {
+	if c.opts.Log {
+		c.log.Info().
+			Ctx(ctx).
+			Str("capture_id", entry.ID).
+			Interface("capture", entry).
+			Msg("Captured request")
+	}
+	if c.opts.BufferSize <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) < c.opts.BufferSize {
+		c.entries = append(c.entries, entry)
+	} else {
+		c.entries[c.next] = entry
+	}
+	c.next = (c.next + 1) % c.opts.BufferSize
+}
+
+// textual reports whether bodies of the content type are text worth
+// capturing, such as JSON, XML, forms and text/*
+func textual(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return contentType == ""
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "json") ||
+		strings.HasSuffix(mediaType, "xml") ||
+		mediaType == "application/x-www-form-urlencoded"
+}
+
+// readCloser reads from Reader and closes Closer
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// recordingWriter copies up to limit bytes of the response body while
+// writing it to the client
+type recordingWriter struct {
+	gin.ResponseWriter
+	body      bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.keep(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// keep copies what fits under the limit of b
+func (w *recordingWriter) keep(b []byte) {
+	room := w.limit - w.body.Len()
+	if len(b) > room {
+		b = b[:max(room, 0)]
+		w.truncated = true
+	}
+	w.body.Write(b)
+}
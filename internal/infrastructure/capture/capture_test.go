@@ -0,0 +1,201 @@
+package capture
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newCapturer(clk Clock, bufferSize int) *Capturer {
+	return New(Options{
+		MaxBodyBytes: 64,
+		BufferSize:   bufferSize,
+		MaxDuration:  time.Hour,
+		ExemptPaths:  []string{"/admin"},
+	}, logger.NewRedactor(logger.PolicyStrict, []string{"password", "token"}), logger.New("info", io.Discard), clk)
+}
+
+// setupRouter serves echo routes behind the capturer, and its admin routes
+func setupRouter(c *Capturer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(c.Middleware())
+	RegisterAdminRoutes(router.Group("/admin"), c)
+	echo := func(ctx *gin.Context) {
+		body, _ := io.ReadAll(ctx.Request.Body)
+		ctx.Data(http.StatusCreated, ctx.ContentType(), body)
+	}
+	router.POST("/users", echo)
+	router.POST("/users/:id", echo)
+	return router
+}
+
+func serve(router *gin.Engine, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_CapturesSelectedRoutes(t *testing.T) {
+	c := newCapturer(clock.NewFake(testNow), 10)
+	router := setupRouter(c)
+	c.Enable(Settings{Routes: []string{"/users/:id"}})
+
+	body := `{"email":"ada@example.com","password":"hunter2"}`
+	w := serve(router, http.MethodPost, "/users/42?token=abc", body, map[string]string{"Authorization": "Bearer abc"})
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, body, w.Body.String(), "the handler reads the whole body")
+
+	serve(router, http.MethodPost, "/users", body, nil)
+
+	entries := c.Entries()
+	require.Len(t, entries, 1, "only the selected route is captured")
+	entry := entries[0]
+	assert.Equal(t, "/users/:id", entry.Route)
+	assert.Equal(t, "/users/42?token=[REDACTED]", entry.Path)
+	assert.Equal(t, http.StatusCreated, entry.Status)
+	assert.Equal(t, `{"email":"[REDACTED]","password":"[REDACTED]"}`, entry.Request.Body)
+	assert.Equal(t, `{"email":"[REDACTED]","password":"[REDACTED]"}`, entry.Response.Body)
+	assert.Equal(t, logger.Redacted, entry.Request.Headers["Authorization"])
+	assert.False(t, entry.Request.Truncated)
+}
+
+func TestMiddleware_CapturesRequestIDs(t *testing.T) {
+	c := newCapturer(clock.NewFake(testNow), 10)
+	router := setupRouter(c)
+	c.Enable(Settings{RequestIDs: []string{"req-1"}})
+
+	serve(router, http.MethodPost, "/users", `{}`, map[string]string{"X-Request-ID": "req-2"})
+	serve(router, http.MethodPost, "/users", `{}`, map[string]string{"X-Request-ID": "req-1"})
+
+	entries := c.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "req-1", entries[0].RequestID)
+}
+
+func TestMiddleware_TruncatesBodies(t *testing.T) {
+	c := newCapturer(clock.NewFake(testNow), 10)
+	router := setupRouter(c)
+	c.Enable(Settings{Routes: []string{AllRoutes}})
+
+	body := `{"name":"` + strings.Repeat("a", 100) + `"}`
+	w := serve(router, http.MethodPost, "/users", body, nil)
+	assert.Equal(t, body, w.Body.String(), "the client gets the whole response")
+
+	entry := c.Entries()[0]
+	assert.True(t, entry.Request.Truncated)
+	assert.True(t, entry.Response.Truncated)
+	assert.Len(t, entry.Request.Body, 64)
+	assert.Len(t, entry.Response.Body, 64)
+}
+
+func TestMiddleware_SkipsBinaryBodies(t *testing.T) {
+	c := newCapturer(clock.NewFake(testNow), 10)
+	router := setupRouter(c)
+	c.Enable(Settings{Routes: []string{AllRoutes}})
+
+	serve(router, http.MethodPost, "/users", "\x89PNG", map[string]string{"Content-Type": "image/png"})
+
+	assert.Equal(t, "[image/png body not captured]", c.Entries()[0].Request.Body)
+}
+
+func TestCapturer_Expires(t *testing.T) {
+	clk := clock.NewFake(testNow)
+	c := newCapturer(clk, 10)
+	router := setupRouter(c)
+
+	settings := c.Enable(Settings{Routes: []string{AllRoutes}, ExpiresAt: testNow.Add(24 * time.Hour)})
+	assert.Equal(t, testNow.Add(time.Hour), settings.ExpiresAt, "capped at the maximum duration")
+
+	clk.Advance(time.Hour)
+	_, ok := c.Settings()
+	assert.False(t, ok)
+
+	serve(router, http.MethodPost, "/users", `{}`, nil)
+	assert.Empty(t, c.Entries())
+}
+
+func TestCapturer_RingBuffer(t *testing.T) {
+	c := newCapturer(clock.NewFake(testNow), 2)
+	router := setupRouter(c)
+	c.Enable(Settings{Routes: []string{AllRoutes}})
+
+	for _, id := range []string{"1", "2", "3"} {
+		serve(router, http.MethodPost, "/users/"+id, `{}`, map[string]string{"X-Request-ID": id})
+	}
+
+	entries := c.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "3", entries[0].RequestID, "newest first")
+	assert.Equal(t, "2", entries[1].RequestID)
+}
+
+func TestRegisterAdminRoutes(t *testing.T) {
+	c := newCapturer(clock.NewFake(testNow), 10)
+	router := setupRouter(c)
+
+	w := serve(router, http.MethodPut, "/admin/debug/capture", `{}`, nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serve(router, http.MethodPut, "/admin/debug/capture", `{"routes":["/users"],"duration":"15m"}`, nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var settings SettingsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &settings))
+	assert.True(t, settings.Enabled)
+	assert.Equal(t, testNow.Add(15*time.Minute), settings.ExpiresAt.UTC())
+
+	serve(router, http.MethodPost, "/users", `{"name":"ada"}`, nil)
+
+	w = serve(router, http.MethodGet, "/admin/debug/captures", "", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var entries EntriesResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	require.Len(t, entries.Captures, 1, "admin requests are not captured")
+	assert.Equal(t, `{"name":"ada"}`, entries.Captures[0].Request.Body)
+
+	w = serve(router, http.MethodDelete, "/admin/debug/capture", "", nil)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = serve(router, http.MethodGet, "/admin/debug/capture", "", nil)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &settings))
+	assert.False(t, settings.Enabled)
+}
+
+func TestTap_IgnoresSettings(t *testing.T) {
+	c := newCapturer(clock.NewFake(testNow), 10)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var entry Entry
+	router.Use(func(ctx *gin.Context) {
+		entry = c.Tap(ctx)
+	})
+	router.POST("/users/:id", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"password": "hunter2"})
+	})
+
+	serve(router, http.MethodPost, "/users/42", `{"name":"Ada"}`, nil)
+	assert.Equal(t, "/users/:id", entry.Route)
+	assert.Equal(t, http.StatusOK, entry.Status)
+	assert.Equal(t, `{"name":"Ada"}`, entry.Request.Body)
+	assert.Equal(t, `{"password":"[REDACTED]"}`, entry.Response.Body)
+	assert.Empty(t, c.Entries(), "tapped entries are not buffered")
+}
@@ -0,0 +1,120 @@
+// Package cdc streams committed row changes out of Postgres through
+// logical replication, for change data capture.
+//
+// A Listener holds a replication slot, which makes the server keep the WAL
+// until the listener confirms it, so no change is missed across restarts.
+// Changes are decoded with the pgoutput plugin built into Postgres, or
+// with wal2json, and handed to a Handler one committed transaction at a
+// time. A transaction is only confirmed once its handler returns, so it is
+// delivered at least once: after a failure or a restart it is streamed
+// again.
+//
+// The slot holds back the WAL as long as nothing confirms it, and fills the
+// disk of the database when the listener stays away. Drop the slots no
+// listener uses anymore:
+//
+//	SELECT pg_drop_replication_slot('go_scaffolding_users');
+package cdc
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Logical decoding plugins
+const (
+	PluginPgoutput = "pgoutput"
+	PluginWal2JSON = "wal2json"
+)
+
+// Op is the kind of a row change
+type Op string
+
+// Row change kinds
+const (
+	OpInsert Op = "insert"
+	OpUpdate Op = "update"
+	OpDelete Op = "delete"
+)
+
+// Row holds the values of the columns of a row in their text
+// representation, such as "t" for a true boolean. NULL is nil.
+type Row map[string]*string
+
+// Value returns the value of column, and false when the column is NULL or
+// absent
+func (r Row) Value(column string) (string, bool) {
+	v := r[column]
+	if v == nil {
+		return "", false
+	}
+	return *v, true
+}
+
+// timestampLayouts are the text representations of timestamps with and
+// without time zone
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// ParseTimestamp parses the text representation of a timestamp column;
+// timestamps without time zone are taken as UTC
+func ParseTimestamp(value string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp: %q", value)
+}
+
+// Change is a change to a row
+type Change struct {
+	Op     Op
+	Schema string
+	Table  string
+
+	// Old is the row before an update or delete. It holds the columns of
+	// the replica identity of the table: the primary key by default, all
+	// columns with REPLICA IDENTITY FULL. It is nil for inserts, and for
+	// updates that did not change the replica identity.
+	Old Row
+
+	// New is the row after an insert or update. Unchanged values stored
+	// out of line, such as large texts, are absent rather than repeated.
+	New Row
+}
+
+// Transaction is a committed transaction
+type Transaction struct {
+	// LSN is the position of the end of the commit in the WAL. Confirming
+	// it means the transaction is not streamed again.
+	LSN LSN
+
+	CommitTime time.Time
+
+	// Changes are the changes to the streamed tables, in the order they
+	// were made
+	Changes []Change
+}
+
+// Handler processes a committed transaction. The transaction is streamed
+// again when it returns an error.
+type Handler func(ctx context.Context, tx Transaction) error
+
+// decoder decodes the WAL messages of a logical decoding plugin
+type decoder interface {
+	// options returns the plugin options of START_REPLICATION
+	options() string
+
+	// decode decodes a WAL message starting at walStart, and returns the
+	// transaction it completes, if any
+	decode(walStart LSN, data []byte) (*Transaction, error)
+
+	// inTransaction reports whether a transaction began and did not yet
+	// commit
+	inTransaction() bool
+}
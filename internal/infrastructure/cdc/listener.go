@@ -0,0 +1,406 @@
+package cdc
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// metrics publishes the counters and lag of the listener under /debug/vars
+var metrics = expvar.NewMap("cdc")
+
+// Defaults and bounds of the listener
+const (
+	defaultStatusInterval = 10 * time.Second
+	minReconnectBackoff   = time.Second
+	maxReconnectBackoff   = 30 * time.Second
+)
+
+// sqlstateObjectInUse is returned when another connection streams the slot
+const sqlstateObjectInUse = "55006"
+
+// Options configures a listener
+type Options struct {
+	// Plugin is PluginPgoutput or PluginWal2JSON
+	Plugin string
+
+	// Slot is the replication slot to stream from
+	Slot string
+
+	// Publication lists the tables pgoutput streams
+	Publication string
+
+	// Tables are the schema-qualified tables to stream, such as
+	// public.users. wal2json streams them; pgoutput streams the tables of
+	// the publication, and CreateSlot adds them to it.
+	Tables []string
+
+	// CreateSlot creates the slot and publication when missing, instead of
+	// failing to stream
+	CreateSlot bool
+
+	// TemporarySlot creates a slot dropped when the connection closes. The
+	// server keeps no WAL while the listener is away, so changes made in
+	// between are missed.
+	TemporarySlot bool
+
+	// StatusInterval is how often the processed position is confirmed to
+	// the server; ten seconds when zero
+	StatusInterval time.Duration
+
+	// MaxLagBytes fails the health check when the streamed WAL trails the
+	// server by more; 0 disables it
+	MaxLagBytes uint64
+}
+
+// Connect opens a logical replication connection
+type Connect func(ctx context.Context) (*pgconn.PgConn, error)
+
+// Listener streams the committed changes of a replication slot to a
+// handler. Only one connection streams a slot at a time: listeners of
+// other instances stand by until the slot is free.
+type Listener struct {
+	opts    Options
+	connect Connect
+	log     *logger.Logger
+
+	mu        sync.Mutex
+	streaming bool
+	standby   bool
+	lastErr   error
+	confirmed LSN // processed position confirmed to the server
+	serverEnd LSN // end of the WAL last reported by the server
+}
+
+// NewListener creates a listener streaming through connections opened
+// with connect. The transactions streamed and rows changed are counted
+// under /debug/vars as "cdc.transactions" and "cdc.changes", failed
+// connections and handlers as "cdc.errors", and the lag behind the server
+// is published as "cdc.lag_bytes".
+func NewListener(opts Options, connect Connect, log *logger.Logger) (*Listener, error) {
+	if opts.Plugin != PluginPgoutput && opts.Plugin != PluginWal2JSON {
+		return nil, fmt.Errorf("unknown logical decoding plugin: %q", opts.Plugin)
+	}
+	if opts.Slot == "" {
+		return nil, errors.New("replication slot is required")
+	}
+	if opts.Plugin == PluginPgoutput && opts.Publication == "" {
+		return nil, errors.New("publication is required by pgoutput")
+	}
+	if opts.StatusInterval <= 0 {
+		opts.StatusInterval = defaultStatusInterval
+	}
+
+	l := &Listener{
+		opts:    opts,
+		connect: connect,
+		log:     log,
+		lastErr: errors.New("not started"),
+	}
+	metrics.Set("lag_bytes", expvar.Func(func() any { return l.lag() }))
+	return l, nil
+}
+
+// Run streams changes to handler until ctx is done, reconnecting with
+// backoff when the connection or handler fails
+func (l *Listener) Run(ctx context.Context, handler Handler) {
+	backoff := minReconnectBackoff
+	for {
+		err := l.stream(ctx, handler)
+		if ctx.Err() != nil {
+			l.setStopped(ctx.Err(), false)
+			return
+		}
+
+		var pgErr *pgconn.PgError
+		standby := errors.As(err, &pgErr) && pgErr.Code == sqlstateObjectInUse
+		l.setStopped(err, standby)
+		if standby {
+			l.log.Debug().Str("slot", l.opts.Slot).Msg("Replication slot streamed by another instance; standing by")
+		} else {
+			metrics.Add("errors", 1)
+			l.log.Error().Err(err).Str("slot", l.opts.Slot).Dur("retry_in", backoff).Msg("Change data capture stopped")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		if standby {
+			backoff = maxReconnectBackoff
+		} else {
+			backoff = min(backoff*2, maxReconnectBackoff)
+		}
+	}
+}
+
+// stream streams changes over a new connection until it fails
+func (l *Listener) stream(ctx context.Context, handler Handler) error {
+	conn, err := l.connect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect for replication: %w", err)
+	}
+	defer conn.Close(context.WithoutCancel(ctx))
+
+	if err := l.prepare(ctx, conn); err != nil {
+		return err
+	}
+
+	var dec decoder
+	if l.opts.Plugin == PluginPgoutput {
+		dec = newPgoutputDecoder(l.opts.Publication)
+	} else {
+		dec = newWal2JSONDecoder(l.opts.Tables)
+	}
+	if err := startReplication(ctx, conn, l.opts.Slot, dec.options()); err != nil {
+		return fmt.Errorf("failed to start replication from slot %s: %w", l.opts.Slot, err)
+	}
+	l.setStreaming()
+	l.log.Info().Str("slot", l.opts.Slot).Str("plugin", l.opts.Plugin).Msg("Change data capture streaming")
+
+	nextStatus := time.Now().Add(l.opts.StatusInterval)
+	for {
+		if !time.Now().Before(nextStatus) {
+			if err := sendStandbyStatus(conn, l.position()); err != nil {
+				return fmt.Errorf("failed to confirm position: %w", err)
+			}
+			nextStatus = time.Now().Add(l.opts.StatusInterval)
+		}
+
+		receiveCtx, cancel := context.WithDeadline(ctx, nextStatus)
+		msg, err := conn.ReceiveMessage(receiveCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil && pgconn.Timeout(err) {
+				continue
+			}
+			return err
+		}
+
+		switch msg := msg.(type) {
+		case *pgproto3.CopyData:
+			reply, err := l.receive(ctx, dec, msg.Data, handler)
+			if err != nil {
+				return err
+			}
+			if reply {
+				nextStatus = time.Now()
+			}
+		case *pgproto3.ErrorResponse:
+			return pgconn.ErrorResponseToPgError(msg)
+		case *pgproto3.CopyDone:
+			return errStopped
+		}
+	}
+}
+
+// receive processes a replication message and reports whether the server
+// asked for a status update
+func (l *Listener) receive(ctx context.Context, dec decoder, data []byte, handler Handler) (bool, error) {
+	if len(data) == 0 {
+		return false, nil
+	}
+
+	switch data[0] {
+	case xlogDataByte:
+		xld, err := parseXLogData(data[1:])
+		if err != nil {
+			return false, err
+		}
+		l.observe(xld.ServerEnd)
+
+		tx, err := dec.decode(xld.WALStart, xld.Data)
+		if err != nil {
+			return false, fmt.Errorf("failed to decode WAL at %s: %w", xld.WALStart, err)
+		}
+		if tx == nil {
+			return false, nil
+		}
+		if len(tx.Changes) > 0 {
+			if err := handler(ctx, *tx); err != nil {
+				return false, fmt.Errorf("failed to handle transaction at %s: %w", tx.LSN, err)
+			}
+			metrics.Add("transactions", 1)
+			metrics.Add("changes", int64(len(tx.Changes)))
+		}
+		l.advance(tx.LSN)
+
+	case keepaliveByte:
+		ka, err := parseKeepalive(data[1:])
+		if err != nil {
+			return false, err
+		}
+		l.observe(ka.ServerEnd)
+		if !dec.inTransaction() {
+			// Everything up to the end of the WAL was streamed, and
+			// handled; what was not streamed touched no streamed table
+			l.advance(ka.ServerEnd)
+		}
+		return ka.ReplyRequested, nil
+	}
+	return false, nil
+}
+
+// prepare creates the publication and slot when missing, if asked to
+func (l *Listener) prepare(ctx context.Context, conn *pgconn.PgConn) error {
+	if !l.opts.CreateSlot && !l.opts.TemporarySlot {
+		return nil
+	}
+
+	if l.opts.Plugin == PluginPgoutput && len(l.opts.Tables) > 0 {
+		if err := l.preparePublication(ctx, conn); err != nil {
+			return fmt.Errorf("failed to prepare publication %s: %w", l.opts.Publication, err)
+		}
+	}
+
+	if !l.opts.TemporarySlot {
+		rows, err := query(ctx, conn, "SELECT plugin FROM pg_replication_slots WHERE slot_name = "+quoteLiteral(l.opts.Slot))
+		if err != nil {
+			return fmt.Errorf("failed to look up replication slot %s: %w", l.opts.Slot, err)
+		}
+		if len(rows) > 0 {
+			if plugin := string(rows[0][0]); plugin != l.opts.Plugin {
+				return fmt.Errorf("replication slot %s decodes with %s, not %s", l.opts.Slot, plugin, l.opts.Plugin)
+			}
+			return nil
+		}
+	}
+
+	sql := "CREATE_REPLICATION_SLOT " + quoteIdentifier(l.opts.Slot)
+	if l.opts.TemporarySlot {
+		sql += " TEMPORARY"
+	}
+	sql += " LOGICAL " + l.opts.Plugin + " NOEXPORT_SNAPSHOT"
+	if _, err := query(ctx, conn, sql); err != nil {
+		return fmt.Errorf("failed to create replication slot %s: %w", l.opts.Slot, err)
+	}
+	l.log.Info().Str("slot", l.opts.Slot).Bool("temporary", l.opts.TemporarySlot).Msg("Replication slot created")
+	return nil
+}
+
+// preparePublication creates the publication, or adds the tables it lacks
+func (l *Listener) preparePublication(ctx context.Context, conn *pgconn.PgConn) error {
+	publication := quoteLiteral(l.opts.Publication)
+	rows, err := query(ctx, conn, "SELECT 1 FROM pg_publication WHERE pubname = "+publication)
+	if err != nil {
+		return err
+	}
+
+	var tables []string
+	for _, table := range l.opts.Tables {
+		tables = append(tables, quoteTable(table))
+	}
+	if len(rows) == 0 {
+		_, err := query(ctx, conn, "CREATE PUBLICATION "+quoteIdentifier(l.opts.Publication)+" FOR TABLE "+strings.Join(tables, ", "))
+		return err
+	}
+
+	rows, err = query(ctx, conn, "SELECT schemaname || '.' || tablename FROM pg_publication_tables WHERE pubname = "+publication)
+	if err != nil {
+		return err
+	}
+	published := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		published[string(row[0])] = true
+	}
+	for i, table := range l.opts.Tables {
+		if published[table] {
+			continue
+		}
+		if _, err := query(ctx, conn, "ALTER PUBLICATION "+quoteIdentifier(l.opts.Publication)+" ADD TABLE "+tables[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// query runs sql and returns the rows of its last result
+func query(ctx context.Context, conn *pgconn.PgConn, sql string) ([][][]byte, error) {
+	results, err := conn.Exec(ctx, sql).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+	return results[len(results)-1].Rows, nil
+}
+
+// Check reports whether changes are being streamed without lagging behind
+// more than MaxLagBytes. A listener standing by for another instance is
+// healthy.
+func (l *Listener) Check(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.standby {
+		return nil
+	}
+	if !l.streaming {
+		return fmt.Errorf("not streaming: %w", l.lastErr)
+	}
+	if lag := l.lagLocked(); l.opts.MaxLagBytes > 0 && lag > l.opts.MaxLagBytes {
+		return fmt.Errorf("%d bytes of WAL behind, more than %d", lag, l.opts.MaxLagBytes)
+	}
+	return nil
+}
+
+// setStreaming records that the slot is being streamed
+func (l *Listener) setStreaming() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.streaming, l.standby, l.lastErr = true, false, nil
+}
+
+// setStopped records why streaming stopped
+func (l *Listener) setStopped(err error, standby bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.streaming, l.standby, l.lastErr = false, standby, err
+}
+
+// observe records the end of the WAL on the server
+func (l *Listener) observe(end LSN) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.serverEnd = max(l.serverEnd, end)
+}
+
+// advance records that the WAL up to position was processed
+func (l *Listener) advance(position LSN) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.confirmed = max(l.confirmed, position)
+}
+
+// position returns the processed position
+func (l *Listener) position() LSN {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.confirmed
+}
+
+// lag returns how many bytes of WAL the processed position trails the
+// server
+func (l *Listener) lag() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lagLocked()
+}
+
+func (l *Listener) lagLocked() uint64 {
+	if l.serverEnd <= l.confirmed {
+		return 0
+	}
+	return uint64(l.serverEnd - l.confirmed)
+}
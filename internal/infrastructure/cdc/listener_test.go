@@ -0,0 +1,163 @@
+package cdc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+func newTestListener(t *testing.T, maxLag uint64) *Listener {
+	t.Helper()
+	l, err := NewListener(Options{Plugin: PluginPgoutput, Slot: "users", Publication: "users", MaxLagBytes: maxLag}, nil, logger.New("info", io.Discard))
+	require.NoError(t, err)
+	return l
+}
+
+// xlog builds an XLogData message carrying data
+func xlog(walStart, serverEnd LSN, data []byte) []byte {
+	buf := []byte{xlogDataByte}
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walStart))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(serverEnd))
+	buf = binary.BigEndian.AppendUint64(buf, micros(commitTime))
+	return append(buf, data...)
+}
+
+// keepaliveMessage builds a primary keepalive message
+func keepaliveMessage(serverEnd LSN, reply bool) []byte {
+	buf := []byte{keepaliveByte}
+	buf = binary.BigEndian.AppendUint64(buf, uint64(serverEnd))
+	buf = binary.BigEndian.AppendUint64(buf, micros(commitTime))
+	if reply {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+func TestNewListener_Validates(t *testing.T) {
+	log := logger.New("info", io.Discard)
+
+	_, err := NewListener(Options{Plugin: "decoderbufs", Slot: "users"}, nil, log)
+	assert.ErrorContains(t, err, "unknown logical decoding plugin")
+	_, err = NewListener(Options{Plugin: PluginPgoutput, Slot: "users"}, nil, log)
+	assert.ErrorContains(t, err, "publication is required")
+	_, err = NewListener(Options{Plugin: PluginWal2JSON}, nil, log)
+	assert.ErrorContains(t, err, "slot is required")
+}
+
+func TestListener_Receive(t *testing.T) {
+	l := newTestListener(t, 0)
+	dec := newPgoutputDecoder("users")
+	ctx := context.Background()
+
+	var handled []Transaction
+	handler := func(ctx context.Context, tx Transaction) error {
+		handled = append(handled, tx)
+		return nil
+	}
+	receive := func(data []byte) bool {
+		reply, err := l.receive(ctx, dec, data, handler)
+		require.NoError(t, err)
+		return reply
+	}
+
+	receive(xlog(0x100, 0x200, newMessage('B').u64(0x180).u64(micros(commitTime)).u32(7)))
+	receive(xlog(0x100, 0x200, usersRelation()))
+	receive(xlog(0x120, 0x200, newMessage('I').u32(16384).u8('N').tuple(ptr("42"), ptr("Ada"), nil)))
+
+	// A keepalive inside a transaction does not confirm it
+	assert.True(t, receive(keepaliveMessage(0x200, true)))
+	assert.Equal(t, LSN(0), l.position())
+
+	receive(xlog(0x180, 0x200, newMessage('C').u8(0).u64(0x170).u64(0x180).u64(micros(commitTime))))
+	require.Len(t, handled, 1)
+	assert.Len(t, handled[0].Changes, 1)
+	assert.Equal(t, LSN(0x180), l.position())
+	assert.Equal(t, uint64(0x80), l.lag())
+
+	// Between transactions the server has sent everything streamed
+	assert.False(t, receive(keepaliveMessage(0x300, false)))
+	assert.Equal(t, LSN(0x300), l.position())
+	assert.Equal(t, uint64(0), l.lag())
+
+	// Transactions without changes to the streamed tables are confirmed
+	// without the handler
+	receive(xlog(0x400, 0x480, newMessage('B').u64(0x480).u64(micros(commitTime)).u32(8)))
+	receive(xlog(0x480, 0x480, newMessage('C').u8(0).u64(0x470).u64(0x480).u64(micros(commitTime))))
+	assert.Len(t, handled, 1)
+	assert.Equal(t, LSN(0x480), l.position())
+}
+
+func TestListener_ReceiveHandlerFailure(t *testing.T) {
+	l := newTestListener(t, 0)
+	dec := newPgoutputDecoder("users")
+	ctx := context.Background()
+	failing := func(ctx context.Context, tx Transaction) error { return errors.New("bus unavailable") }
+
+	for _, data := range [][]byte{
+		xlog(0x100, 0x200, newMessage('B').u64(0x180).u64(micros(commitTime)).u32(7)),
+		xlog(0x100, 0x200, usersRelation()),
+		xlog(0x120, 0x200, newMessage('I').u32(16384).u8('N').tuple(ptr("42"), ptr("Ada"), nil)),
+	} {
+		_, err := l.receive(ctx, dec, data, failing)
+		require.NoError(t, err)
+	}
+
+	_, err := l.receive(ctx, dec, xlog(0x180, 0x200, newMessage('C').u8(0).u64(0x170).u64(0x180).u64(micros(commitTime))), failing)
+	assert.ErrorContains(t, err, "bus unavailable")
+	assert.Equal(t, LSN(0), l.position(), "a failed transaction is streamed again")
+}
+
+func TestListener_Check(t *testing.T) {
+	l := newTestListener(t, 100)
+	ctx := context.Background()
+
+	assert.ErrorContains(t, l.Check(ctx), "not started")
+
+	l.setStreaming()
+	assert.NoError(t, l.Check(ctx))
+
+	l.observe(0x1000)
+	l.advance(0xF00)
+	assert.ErrorContains(t, l.Check(ctx), "256 bytes of WAL behind")
+	l.advance(0x1000)
+	assert.NoError(t, l.Check(ctx))
+
+	l.setStopped(errors.New("connection reset"), false)
+	assert.ErrorContains(t, l.Check(ctx), "connection reset")
+
+	l.setStopped(errors.New("slot is active"), true)
+	assert.NoError(t, l.Check(ctx), "another instance streams the slot")
+}
+
+func TestLSN(t *testing.T) {
+	lsn, err := ParseLSN("16/B374D848")
+	require.NoError(t, err)
+	assert.Equal(t, LSN(0x16B374D848), lsn)
+	assert.Equal(t, "16/B374D848", lsn.String())
+
+	for _, invalid := range []string{"", "16", "G/0", "0/100000000"} {
+		_, err := ParseLSN(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestStandbyStatus(t *testing.T) {
+	now := postgresEpoch.Add(time.Second)
+	buf := standbyStatus(0x180, now)
+
+	require.Len(t, buf, 34)
+	assert.Equal(t, byte(standbyStatusByte), buf[0])
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, uint64(0x180), binary.BigEndian.Uint64(buf[1+8*i:]))
+	}
+	assert.Equal(t, uint64(1_000_000), binary.BigEndian.Uint64(buf[25:]))
+	assert.Equal(t, byte(0), buf[33])
+}
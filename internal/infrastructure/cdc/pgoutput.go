@@ -0,0 +1,238 @@
+package cdc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// relation is a table described by a pgoutput Relation message
+type relation struct {
+	schema  string
+	table   string
+	columns []string
+}
+
+// pgoutputDecoder decodes version 1 of the pgoutput protocol
+type pgoutputDecoder struct {
+	publication string
+
+	// relations describes the tables by OID; the server sends a table
+	// before its first change on each connection, and again when it changes
+	relations map[uint32]relation
+
+	// tx is the transaction being decoded, nil between transactions
+	tx *Transaction
+}
+
+// newPgoutputDecoder creates a decoder of the changes of publication
+func newPgoutputDecoder(publication string) *pgoutputDecoder {
+	return &pgoutputDecoder{
+		publication: publication,
+		relations:   make(map[uint32]relation),
+	}
+}
+
+// options returns the protocol version and publication to stream
+func (d *pgoutputDecoder) options() string {
+	return fmt.Sprintf("(proto_version '1', publication_names %s)", quoteLiteral(d.publication))
+}
+
+// inTransaction reports whether a Begin was not yet followed by a Commit
+func (d *pgoutputDecoder) inTransaction() bool {
+	return d.tx != nil
+}
+
+// errTruncated reports a message shorter than its content
+var errTruncated = errors.New("truncated pgoutput message")
+
+// reader reads the fields of a pgoutput message
+type reader struct {
+	buf []byte
+	err error
+}
+
+func (r *reader) take(n int) []byte {
+	if r.err != nil || len(r.buf) < n {
+		r.err = errTruncated
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *reader) byte() byte {
+	if b := r.take(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (r *reader) uint16() uint16 {
+	if b := r.take(2); b != nil {
+		return binary.BigEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.take(4); b != nil {
+		return binary.BigEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) uint64() uint64 {
+	if b := r.take(8); b != nil {
+		return binary.BigEndian.Uint64(b)
+	}
+	return 0
+}
+
+// string reads a null-terminated string
+func (r *reader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	for i, c := range r.buf {
+		if c == 0 {
+			s := string(r.buf[:i])
+			r.buf = r.buf[i+1:]
+			return s
+		}
+	}
+	r.err = errTruncated
+	return ""
+}
+
+// decode decodes a pgoutput message
+func (d *pgoutputDecoder) decode(walStart LSN, data []byte) (*Transaction, error) {
+	if len(data) == 0 {
+		return nil, errTruncated
+	}
+	r := &reader{buf: data[1:]}
+
+	switch data[0] {
+	case 'B': // Begin: final LSN, commit time, xid
+		r.uint64()
+		commitTime := pgTime(r.uint64())
+		r.uint32()
+		d.tx = &Transaction{CommitTime: commitTime}
+
+	case 'C': // Commit: flags, commit LSN, end LSN, commit time
+		r.byte()
+		r.uint64()
+		end := LSN(r.uint64())
+		r.uint64()
+		if r.err != nil {
+			return nil, r.err
+		}
+		tx := d.tx
+		d.tx = nil
+		if tx == nil {
+			return nil, errors.New("pgoutput commit without begin")
+		}
+		tx.LSN = end
+		return tx, nil
+
+	case 'R': // Relation: OID, namespace, name, replica identity, columns
+		oid := r.uint32()
+		rel := relation{schema: r.string(), table: r.string()}
+		r.byte()
+		columns := int(r.uint16())
+		for i := 0; i < columns && r.err == nil; i++ {
+			r.byte() // flags
+			rel.columns = append(rel.columns, r.string())
+			r.uint32() // type OID
+			r.uint32() // type modifier
+		}
+		d.relations[oid] = rel
+
+	case 'I': // Insert: OID, 'N', new tuple
+		rel, err := d.relation(r.uint32())
+		if err != nil {
+			return nil, err
+		}
+		if tag := r.byte(); tag != 'N' && r.err == nil {
+			return nil, fmt.Errorf("unexpected pgoutput tuple %q in insert", tag)
+		}
+		d.add(Change{Op: OpInsert, Schema: rel.schema, Table: rel.table, New: r.tuple(rel)})
+
+	case 'U': // Update: OID, optional 'K' or 'O' old tuple, 'N' new tuple
+		rel, err := d.relation(r.uint32())
+		if err != nil {
+			return nil, err
+		}
+		change := Change{Op: OpUpdate, Schema: rel.schema, Table: rel.table}
+		tag := r.byte()
+		if tag == 'K' || tag == 'O' {
+			change.Old = r.tuple(rel)
+			tag = r.byte()
+		}
+		if tag != 'N' && r.err == nil {
+			return nil, fmt.Errorf("unexpected pgoutput tuple %q in update", tag)
+		}
+		change.New = r.tuple(rel)
+		d.add(change)
+
+	case 'D': // Delete: OID, 'K' or 'O' old tuple
+		rel, err := d.relation(r.uint32())
+		if err != nil {
+			return nil, err
+		}
+		r.byte()
+		d.add(Change{Op: OpDelete, Schema: rel.schema, Table: rel.table, Old: r.tuple(rel)})
+
+	default:
+		// Origin, Type, Truncate and logical messages carry no row changes
+	}
+
+	return nil, r.err
+}
+
+// relation returns the table of oid
+func (d *pgoutputDecoder) relation(oid uint32) (relation, error) {
+	rel, ok := d.relations[oid]
+	if !ok {
+		return relation{}, fmt.Errorf("pgoutput change to unknown relation %d", oid)
+	}
+	return rel, nil
+}
+
+// add adds a change to the current transaction
+func (d *pgoutputDecoder) add(change Change) {
+	if d.tx != nil {
+		d.tx.Changes = append(d.tx.Changes, change)
+	}
+}
+
+// tuple reads the column values of a row of rel
+func (r *reader) tuple(rel relation) Row {
+	columns := int(r.uint16())
+	row := make(Row, columns)
+	for i := 0; i < columns && r.err == nil; i++ {
+		var name string
+		if i < len(rel.columns) {
+			name = rel.columns[i]
+		}
+
+		switch kind := r.byte(); kind {
+		case 'n':
+			row[name] = nil
+		case 'u':
+			// Unchanged value stored out of line; not sent
+		case 't', 'b':
+			value := string(r.take(int(r.uint32())))
+			row[name] = &value
+		default:
+			if r.err == nil {
+				r.err = fmt.Errorf("unexpected pgoutput column kind %q", kind)
+			}
+		}
+	}
+	return row
+}
+
+// Ensure pgoutputDecoder implements decoder
+var _ decoder = (*pgoutputDecoder)(nil)
@@ -0,0 +1,133 @@
+package cdc
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// message builds a pgoutput message
+type message []byte
+
+func newMessage(kind byte) message { return message{kind} }
+
+func (m message) u8(v byte) message    { return append(m, v) }
+func (m message) u16(v uint16) message { return binary.BigEndian.AppendUint16(m, v) }
+func (m message) u32(v uint32) message { return binary.BigEndian.AppendUint32(m, v) }
+func (m message) u64(v uint64) message { return binary.BigEndian.AppendUint64(m, v) }
+func (m message) str(s string) message { return append(append(m, s...), 0) }
+
+// tuple appends a tuple of text values; nil is NULL and "\x00" an
+// unchanged value stored out of line
+func (m message) tuple(values ...*string) message {
+	m = m.u16(uint16(len(values)))
+	for _, v := range values {
+		switch {
+		case v == nil:
+			m = m.u8('n')
+		case *v == "\x00":
+			m = m.u8('u')
+		default:
+			m = m.u8('t').u32(uint32(len(*v)))
+			m = append(m, *v...)
+		}
+	}
+	return m
+}
+
+func ptr(s string) *string { return &s }
+
+var commitTime = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func usersRelation() message {
+	m := newMessage('R').u32(16384).str("public").str("users").u8('f').u16(3)
+	for _, column := range []string{"id", "name", "deleted_at"} {
+		m = m.u8(0).str(column).u32(25).u32(0xFFFFFFFF)
+	}
+	return m
+}
+
+func micros(t time.Time) uint64 {
+	return uint64(t.Sub(postgresEpoch).Microseconds())
+}
+
+func TestPgoutputDecoder(t *testing.T) {
+	d := newPgoutputDecoder("users")
+	assert.Equal(t, "(proto_version '1', publication_names 'users')", d.options())
+
+	messages := []message{
+		newMessage('B').u64(0x100).u64(micros(commitTime)).u32(7),
+		usersRelation(),
+		newMessage('I').u32(16384).u8('N').tuple(ptr("42"), ptr("Ada"), nil),
+		newMessage('U').u32(16384).u8('O').tuple(ptr("42"), ptr("Ada"), nil).u8('N').tuple(ptr("42"), ptr("\x00"), ptr("2026-03-01 12:00:00")),
+		newMessage('D').u32(16384).u8('K').tuple(ptr("42"), nil, nil),
+	}
+	for _, m := range messages {
+		tx, err := d.decode(0, m)
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+		assert.True(t, d.inTransaction())
+	}
+
+	tx, err := d.decode(0, newMessage('C').u8(0).u64(0x100).u64(0x180).u64(micros(commitTime)))
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+	assert.False(t, d.inTransaction())
+
+	assert.Equal(t, LSN(0x180), tx.LSN)
+	assert.Equal(t, commitTime, tx.CommitTime)
+	require.Len(t, tx.Changes, 3)
+
+	insert := tx.Changes[0]
+	assert.Equal(t, OpInsert, insert.Op)
+	assert.Equal(t, "public", insert.Schema)
+	assert.Equal(t, "users", insert.Table)
+	assert.Equal(t, Row{"id": ptr("42"), "name": ptr("Ada"), "deleted_at": nil}, insert.New)
+	assert.Nil(t, insert.Old)
+
+	update := tx.Changes[1]
+	assert.Equal(t, OpUpdate, update.Op)
+	assert.Equal(t, Row{"id": ptr("42"), "name": ptr("Ada"), "deleted_at": nil}, update.Old)
+	assert.Equal(t, Row{"id": ptr("42"), "deleted_at": ptr("2026-03-01 12:00:00")}, update.New, "the unchanged value is absent")
+
+	remove := tx.Changes[2]
+	assert.Equal(t, OpDelete, remove.Op)
+	value, ok := remove.Old.Value("id")
+	assert.True(t, ok)
+	assert.Equal(t, "42", value)
+	assert.Nil(t, remove.New)
+}
+
+func TestPgoutputDecoder_Errors(t *testing.T) {
+	tests := map[string]message{
+		"unknown relation": newMessage('I').u32(1).u8('N').tuple(ptr("42")),
+		"truncated":        newMessage('B').u64(0x100),
+		"commit first":     newMessage('C').u8(0).u64(0x100).u64(0x180).u64(0),
+	}
+	for name, m := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newPgoutputDecoder("users").decode(0, m)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	for value, want := range map[string]time.Time{
+		"2026-03-01 12:00:00":           time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		"2026-03-01 12:00:00.123456":    time.Date(2026, 3, 1, 12, 0, 0, 123456000, time.UTC),
+		"2026-03-01 14:00:00.5+02":      time.Date(2026, 3, 1, 12, 0, 0, 500000000, time.UTC),
+		"2026-03-01 06:30:00-05:30":     time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		"2026-03-01 12:00:00.000001+00": time.Date(2026, 3, 1, 12, 0, 0, 1000, time.UTC),
+	} {
+		got, err := ParseTimestamp(value)
+		require.NoError(t, err, value)
+		assert.True(t, want.Equal(got), "%s: got %s", value, got)
+	}
+
+	_, err := ParseTimestamp("yesterday")
+	assert.Error(t, err)
+}
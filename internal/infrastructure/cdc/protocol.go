@@ -0,0 +1,162 @@
+package cdc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgproto3"
+)
+
+// LSN is a position in the write-ahead log
+type LSN uint64
+
+// String formats the LSN like Postgres, such as "16/B374D848"
+func (l LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(l>>32), uint32(l))
+}
+
+// ParseLSN parses an LSN formatted like Postgres
+func ParseLSN(s string) (LSN, error) {
+	hi, lo, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, fmt.Errorf("invalid LSN: %q", s)
+	}
+	upper, err := strconv.ParseUint(hi, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LSN: %q", s)
+	}
+	lower, err := strconv.ParseUint(lo, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid LSN: %q", s)
+	}
+	return LSN(upper<<32 | lower), nil
+}
+
+// Messages of the streaming replication protocol, sent in CopyData
+const (
+	xlogDataByte      = 'w'
+	keepaliveByte     = 'k'
+	standbyStatusByte = 'r'
+)
+
+// postgresEpoch is the origin of the timestamps of the replication protocol
+var postgresEpoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// pgTime converts a replication protocol timestamp, in microseconds since
+// postgresEpoch
+func pgTime(micros uint64) time.Time {
+	return postgresEpoch.Add(time.Duration(int64(micros)) * time.Microsecond).UTC()
+}
+
+// xlogData is a WAL message streamed by the server
+type xlogData struct {
+	WALStart   LSN
+	ServerEnd  LSN
+	ServerTime time.Time
+	Data       []byte
+}
+
+// parseXLogData parses the body of an XLogData message
+func parseXLogData(buf []byte) (xlogData, error) {
+	if len(buf) < 24 {
+		return xlogData{}, fmt.Errorf("XLogData too short: %d bytes", len(buf))
+	}
+	return xlogData{
+		WALStart:   LSN(binary.BigEndian.Uint64(buf)),
+		ServerEnd:  LSN(binary.BigEndian.Uint64(buf[8:])),
+		ServerTime: pgTime(binary.BigEndian.Uint64(buf[16:])),
+		Data:       buf[24:],
+	}, nil
+}
+
+// keepalive is a primary keepalive message
+type keepalive struct {
+	ServerEnd      LSN
+	ServerTime     time.Time
+	ReplyRequested bool
+}
+
+// parseKeepalive parses the body of a primary keepalive message
+func parseKeepalive(buf []byte) (keepalive, error) {
+	if len(buf) < 17 {
+		return keepalive{}, fmt.Errorf("keepalive too short: %d bytes", len(buf))
+	}
+	return keepalive{
+		ServerEnd:      LSN(binary.BigEndian.Uint64(buf)),
+		ServerTime:     pgTime(binary.BigEndian.Uint64(buf[8:])),
+		ReplyRequested: buf[16] == 1,
+	}, nil
+}
+
+// standbyStatus encodes a standby status update confirming that the WAL
+// up to position was processed, so the server may discard it
+func standbyStatus(position LSN, now time.Time) []byte {
+	buf := make([]byte, 0, 34)
+	buf = append(buf, standbyStatusByte)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(position)) // written
+	buf = binary.BigEndian.AppendUint64(buf, uint64(position)) // flushed
+	buf = binary.BigEndian.AppendUint64(buf, uint64(position)) // applied
+	buf = binary.BigEndian.AppendUint64(buf, uint64(now.Sub(postgresEpoch).Microseconds()))
+	return append(buf, 0) // no reply requested
+}
+
+// sendStandbyStatus confirms the WAL up to position to the server
+func sendStandbyStatus(conn *pgconn.PgConn, position LSN) error {
+	conn.Frontend().Send(&pgproto3.CopyData{Data: standbyStatus(position, time.Now())})
+	return conn.Frontend().Flush()
+}
+
+// startReplication switches conn to streaming the changes of slot, from
+// the position the slot confirmed last
+func startReplication(ctx context.Context, conn *pgconn.PgConn, slot, options string) error {
+	sql := fmt.Sprintf("START_REPLICATION SLOT %s LOGICAL 0/0 %s", quoteIdentifier(slot), options)
+	conn.Frontend().Send(&pgproto3.Query{String: sql})
+	if err := conn.Frontend().Flush(); err != nil {
+		return err
+	}
+
+	for {
+		msg, err := conn.ReceiveMessage(ctx)
+		if err != nil {
+			return err
+		}
+		switch msg := msg.(type) {
+		case *pgproto3.CopyBothResponse:
+			return nil
+		case *pgproto3.ErrorResponse:
+			return pgconn.ErrorResponseToPgError(msg)
+		case *pgproto3.NoticeResponse:
+		default:
+			return fmt.Errorf("unexpected %T starting replication", msg)
+		}
+	}
+}
+
+// errStopped is returned when the server ends the replication stream
+var errStopped = errors.New("server stopped replication")
+
+// quoteIdentifier quotes an identifier for SQL
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteLiteral quotes a string for SQL
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// quoteTable quotes a table name qualified with its schema, such as
+// public.users
+func quoteTable(table string) string {
+	schema, name, ok := strings.Cut(table, ".")
+	if !ok {
+		return quoteIdentifier(table)
+	}
+	return quoteIdentifier(schema) + "." + quoteIdentifier(name)
+}
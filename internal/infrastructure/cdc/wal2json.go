@@ -0,0 +1,144 @@
+package cdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// wal2jsonDecoder decodes version 2 of the wal2json format, which streams
+// one JSON object per change
+type wal2jsonDecoder struct {
+	tables []string
+
+	// tx is the transaction being decoded, nil between transactions
+	tx *Transaction
+}
+
+// newWal2JSONDecoder creates a decoder of the changes to tables
+func newWal2JSONDecoder(tables []string) *wal2jsonDecoder {
+	return &wal2jsonDecoder{tables: tables}
+}
+
+// options returns the format, with commit times, and the tables to stream
+func (d *wal2jsonDecoder) options() string {
+	return fmt.Sprintf(`("format-version" '2', "include-timestamp" '1', "add-tables" %s)`,
+		quoteLiteral(strings.Join(d.tables, ",")))
+}
+
+// inTransaction reports whether a "B" was not yet followed by a "C"
+func (d *wal2jsonDecoder) inTransaction() bool {
+	return d.tx != nil
+}
+
+// wal2jsonMessage is a message of format version 2
+type wal2jsonMessage struct {
+	Action    string           `json:"action"`
+	Timestamp string           `json:"timestamp"`
+	Schema    string           `json:"schema"`
+	Table     string           `json:"table"`
+	Columns   []wal2jsonColumn `json:"columns"`
+	Identity  []wal2jsonColumn `json:"identity"`
+}
+
+// wal2jsonColumn is a column value of a wal2json message
+type wal2jsonColumn struct {
+	Name  string          `json:"name"`
+	Value json.RawMessage `json:"value"`
+}
+
+// decode decodes a wal2json message. The WAL start of a commit message is
+// the end of the commit.
+func (d *wal2jsonDecoder) decode(walStart LSN, data []byte) (*Transaction, error) {
+	var msg wal2jsonMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, fmt.Errorf("invalid wal2json message: %w", err)
+	}
+
+	switch msg.Action {
+	case "B":
+		d.tx = &Transaction{}
+		if msg.Timestamp != "" {
+			t, err := ParseTimestamp(msg.Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			d.tx.CommitTime = t
+		}
+
+	case "C":
+		tx := d.tx
+		d.tx = nil
+		if tx == nil {
+			return nil, errors.New("wal2json commit without begin")
+		}
+		tx.LSN = walStart
+		if msg.Timestamp != "" {
+			t, err := ParseTimestamp(msg.Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			tx.CommitTime = t
+		}
+		return tx, nil
+
+	case "I", "U", "D":
+		if d.tx == nil {
+			return nil, nil
+		}
+		change := Change{
+			Op:     map[string]Op{"I": OpInsert, "U": OpUpdate, "D": OpDelete}[msg.Action],
+			Schema: msg.Schema,
+			Table:  msg.Table,
+		}
+		var err error
+		if msg.Action != "D" {
+			if change.New, err = wal2jsonRow(msg.Columns); err != nil {
+				return nil, err
+			}
+		}
+		if msg.Identity != nil {
+			if change.Old, err = wal2jsonRow(msg.Identity); err != nil {
+				return nil, err
+			}
+		}
+		d.tx.Changes = append(d.tx.Changes, change)
+
+	default:
+		// Truncates and logical messages carry no row changes
+	}
+	return nil, nil
+}
+
+// wal2jsonRow converts wal2json column values to their text
+// representation in Postgres, like pgoutput sends them
+func wal2jsonRow(columns []wal2jsonColumn) (Row, error) {
+	row := make(Row, len(columns))
+	for _, column := range columns {
+		raw := bytes.TrimSpace(column.Value)
+		var value string
+		switch {
+		case len(raw) == 0 || string(raw) == "null":
+			row[column.Name] = nil
+			continue
+		case raw[0] == '"':
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return nil, fmt.Errorf("invalid wal2json value of %s: %w", column.Name, err)
+			}
+		case string(raw) == "true":
+			value = "t"
+		case string(raw) == "false":
+			value = "f"
+		default:
+			// Numbers are written as Postgres prints them
+			value = string(raw)
+		}
+		row[column.Name] = &value
+	}
+	return row, nil
+}
+
+// Ensure wal2jsonDecoder implements decoder
+var _ decoder = (*wal2jsonDecoder)(nil)
@@ -0,0 +1,59 @@
+package cdc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWal2JSONDecoder(t *testing.T) {
+	d := newWal2JSONDecoder([]string{"public.users", "public.user_events"})
+	assert.Equal(t, `("format-version" '2', "include-timestamp" '1', "add-tables" 'public.users,public.user_events')`, d.options())
+
+	messages := []string{
+		`{"action":"B","timestamp":"2026-03-01 12:00:00.5+00"}`,
+		`{"action":"I","schema":"public","table":"users","columns":[{"name":"id","type":"uuid","value":"42"},{"name":"name","type":"text","value":"Ada"},{"name":"deleted_at","type":"timestamp","value":null}]}`,
+		`{"action":"U","schema":"public","table":"users","columns":[{"name":"id","type":"uuid","value":"42"},{"name":"verified","type":"boolean","value":true},{"name":"logins","type":"integer","value":3}],"identity":[{"name":"id","type":"uuid","value":"42"},{"name":"verified","type":"boolean","value":false}]}`,
+		`{"action":"D","schema":"public","table":"users","identity":[{"name":"id","type":"uuid","value":"42"}]}`,
+	}
+	for _, m := range messages {
+		tx, err := d.decode(0x100, []byte(m))
+		require.NoError(t, err)
+		assert.Nil(t, tx)
+	}
+	assert.True(t, d.inTransaction())
+
+	tx, err := d.decode(0x180, []byte(`{"action":"C","timestamp":"2026-03-01 12:00:00.5+00"}`))
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+	assert.False(t, d.inTransaction())
+
+	assert.Equal(t, LSN(0x180), tx.LSN, "a commit starts where the transaction ends")
+	assert.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 500000000, time.UTC), tx.CommitTime)
+	require.Len(t, tx.Changes, 3)
+
+	assert.Equal(t, Change{Op: OpInsert, Schema: "public", Table: "users", New: Row{"id": ptr("42"), "name": ptr("Ada"), "deleted_at": nil}}, tx.Changes[0])
+	assert.Equal(t, Change{
+		Op:     OpUpdate,
+		Schema: "public",
+		Table:  "users",
+		Old:    Row{"id": ptr("42"), "verified": ptr("f")},
+		New:    Row{"id": ptr("42"), "verified": ptr("t"), "logins": ptr("3")},
+	}, tx.Changes[1], "values are converted like pgoutput sends them")
+	assert.Equal(t, Change{Op: OpDelete, Schema: "public", Table: "users", Old: Row{"id": ptr("42")}}, tx.Changes[2])
+}
+
+func TestWal2JSONDecoder_Errors(t *testing.T) {
+	for name, m := range map[string]string{
+		"invalid json": `{"action":`,
+		"commit first": `{"action":"C"}`,
+		"bad time":     `{"action":"B","timestamp":"yesterday"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newWal2JSONDecoder(nil).decode(0, []byte(m))
+			assert.Error(t, err)
+		})
+	}
+}
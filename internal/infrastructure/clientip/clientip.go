@@ -0,0 +1,165 @@
+// Package clientip resolves the address of the client behind load
+// balancers and reverse proxies. X-Forwarded-For is only believed when the
+// connection comes from a trusted proxy, and then only up to the first
+// address a trusted proxy did not add, so clients cannot choose the address
+// they are logged, filtered or counted under.
+package clientip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ForwardedForHeader is the only header client addresses are read from
+const ForwardedForHeader = "X-Forwarded-For"
+
+// Resolver finds the client address of requests
+type Resolver struct {
+	trustedProxies []netip.Prefix
+}
+
+// NewResolver creates a resolver believing X-Forwarded-For from connections
+// made by trustedProxies. Without any, the address of the connection is
+// the client's.
+func NewResolver(trustedProxies []netip.Prefix) *Resolver {
+	return &Resolver{
+		trustedProxies: trustedProxies,
+	}
+}
+
+// Resolve returns the address of the client of r. It is the address of
+// the connection unless that is a trusted proxy; then X-Forwarded-For is
+// walked from the right, past the trusted proxies, to the first address a
+// trusted proxy did not add. Addresses further left could have been forged
+// by the client. When a hop does not parse, the connection address is
+// returned, as the client cannot be told apart from the proxy.
+func (r *Resolver) Resolve(req *http.Request) netip.Addr {
+	remote := remoteAddr(req)
+	if !Contains(r.trustedProxies, remote) {
+		return remote
+	}
+
+	hops := forwardedFor(req.Header)
+	addr := remote
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(hops[i])
+		if err != nil {
+			return remote
+		}
+		addr = hop.Unmap()
+		if !Contains(r.trustedProxies, addr) {
+			return addr
+		}
+	}
+
+	// Every hop is a trusted proxy, so the request started inside
+	return addr
+}
+
+// TrustedHeader returns the header name of req when the connection comes
+// from a trusted proxy, which set it or passed it on, and "" otherwise, as
+// clients reaching the service directly could have forged it
+func (r *Resolver) TrustedHeader(req *http.Request, name string) string {
+	if !Contains(r.trustedProxies, remoteAddr(req)) {
+		return ""
+	}
+	return req.Header.Get(name)
+}
+
+// Middleware resolves the client address of each request and stores it in
+// the request context, for FromRequest and FromContext
+func (r *Resolver) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithAddr(c.Request.Context(), r.Resolve(c.Request)))
+		c.Next()
+	}
+}
+
+type contextKey struct{}
+
+// WithAddr returns a copy of ctx carrying the client address
+func WithAddr(ctx context.Context, addr netip.Addr) context.Context {
+	return context.WithValue(ctx, contextKey{}, addr)
+}
+
+// FromContext returns the client address stored by Middleware
+func FromContext(ctx context.Context) (netip.Addr, bool) {
+	addr, ok := ctx.Value(contextKey{}).(netip.Addr)
+	return addr, ok
+}
+
+// FromRequest returns the client address stored by Middleware or, for
+// requests it did not see, the address of the connection. The result is
+// invalid when neither parses.
+func FromRequest(r *http.Request) netip.Addr {
+	if addr, ok := FromContext(r.Context()); ok {
+		return addr
+	}
+	return remoteAddr(r)
+}
+
+// remoteAddr returns the address of the connection of r
+func remoteAddr(r *http.Request) netip.Addr {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr.Unmap()
+}
+
+// forwardedFor returns the addresses of the X-Forwarded-For headers, in
+// order
+func forwardedFor(header http.Header) []string {
+	var hops []string
+	for _, value := range header.Values(ForwardedForHeader) {
+		for hop := range strings.SplitSeq(value, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	return hops
+}
+
+// Contains reports whether addr is in one of the networks
+func Contains(networks []netip.Prefix, addr netip.Addr) bool {
+	for _, network := range networks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseNetworks parses CIDR prefixes or single addresses, such as
+// "10.0.0.0/8" or "127.0.0.1"
+func ParseNetworks(values []string) ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid network %q: %w", value, err)
+			}
+			networks = append(networks, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", value, err)
+		}
+		networks = append(networks, prefix.Masked())
+	}
+	return networks, nil
+}
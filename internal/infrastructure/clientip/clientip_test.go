@@ -0,0 +1,106 @@
+package clientip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	trusted, err := ParseNetworks([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	resolver := NewResolver(trusted)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		want       string
+	}{
+		{name: "connection address", remoteAddr: "198.51.100.1:1234", want: "198.51.100.1"},
+		{name: "IPv6 connection address", remoteAddr: "[2001:db8::1]:1234", want: "2001:db8::1"},
+		{name: "IPv4-mapped address", remoteAddr: "[::ffff:198.51.100.1]:1234", want: "198.51.100.1"},
+		{name: "header of an untrusted client is ignored", remoteAddr: "198.51.100.1:1234", forwarded: []string{"203.0.113.1"}, want: "198.51.100.1"},
+		{name: "trusted proxy without header", remoteAddr: "10.0.0.1:1234", want: "10.0.0.1"},
+		{name: "rightmost untrusted hop", remoteAddr: "10.0.0.1:1234", forwarded: []string{"203.0.113.1, 198.51.100.1, 10.0.0.2"}, want: "198.51.100.1"},
+		{name: "repeated headers", remoteAddr: "10.0.0.1:1234", forwarded: []string{"203.0.113.1", "198.51.100.1"}, want: "198.51.100.1"},
+		{name: "every hop trusted", remoteAddr: "10.0.0.1:1234", forwarded: []string{"10.0.0.3, 10.0.0.2"}, want: "10.0.0.3"},
+		{name: "malformed hop", remoteAddr: "10.0.0.1:1234", forwarded: []string{"not-an-ip"}, want: "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, value := range tt.forwarded {
+				req.Header.Add(ForwardedForHeader, value)
+			}
+
+			assert.Equal(t, tt.want, resolver.Resolve(req).String())
+		})
+	}
+}
+
+func TestResolver_TrustedHeader(t *testing.T) {
+	trusted, err := ParseNetworks([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	resolver := NewResolver(trusted)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("CF-IPCountry", "FR")
+
+	req.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "FR", resolver.TrustedHeader(req, "CF-IPCountry"))
+
+	req.RemoteAddr = "198.51.100.1:1234"
+	assert.Empty(t, resolver.TrustedHeader(req, "CF-IPCountry"), "clients could forge it")
+}
+
+func TestResolver_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	trusted, err := ParseNetworks([]string{"10.0.0.1"})
+	require.NoError(t, err)
+
+	var got netip.Addr
+	router := gin.New()
+	router.Use(NewResolver(trusted).Middleware())
+	router.GET("/", func(c *gin.Context) { got = FromRequest(c.Request) })
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set(ForwardedForHeader, "198.51.100.1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, "198.51.100.1", got.String())
+}
+
+func TestFromRequest_WithoutMiddleware(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	req.Header.Set(ForwardedForHeader, "198.51.100.1")
+	assert.Equal(t, "10.0.0.1", FromRequest(req).String())
+
+	req.RemoteAddr = "pipe"
+	assert.False(t, FromRequest(req).IsValid())
+}
+
+func TestParseNetworks(t *testing.T) {
+	parsed, err := ParseNetworks([]string{"10.1.0.0/16", "::1", "10.1.2.3/8"})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.1.0.0/16"),
+		netip.MustParsePrefix("::1/128"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+	}, parsed)
+
+	_, err = ParseNetworks([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+	_, err = ParseNetworks([]string{"localhost"})
+	assert.Error(t, err)
+}
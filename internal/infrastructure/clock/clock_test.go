@@ -0,0 +1,32 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystem_Now(t *testing.T) {
+	before := time.Now()
+	now := System{}.Now()
+
+	assert.False(t, now.Before(before))
+	assert.WithinDuration(t, time.Now(), now, time.Second)
+	assert.Equal(t, time.UTC, now.Location())
+}
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := NewFake(start)
+
+	assert.Equal(t, start, clock.Now())
+	assert.Equal(t, start, clock.Now(), "time stands still")
+
+	assert.Equal(t, start.Add(time.Hour), clock.Advance(time.Hour))
+	assert.Equal(t, start.Add(time.Hour), clock.Now())
+
+	later := start.Add(48 * time.Hour)
+	clock.Set(later)
+	assert.Equal(t, later, clock.Now())
+}
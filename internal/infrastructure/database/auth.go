@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	"cloud.google.com/go/cloudsqlconn"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/rds/auth"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// Authentication methods selected by postgres.auth
+const (
+	AuthPassword = "password"
+	AuthRDSIAM   = "rds_iam"
+	AuthCloudSQL = "cloudsql"
+)
+
+// TokenSource issues a short-lived password for connecting to host:port as
+// user, such as an IAM authentication token
+type TokenSource interface {
+	Token(ctx context.Context, host string, port uint16, user string) (string, error)
+}
+
+// Authenticate returns the credentials selected by cfg.Auth. Close them
+// after the pools opened with them.
+func Authenticate(ctx context.Context, cfg config.PostgresConfig, log *logger.Logger) (*Credentials, error) {
+	switch cfg.Auth {
+	case AuthPassword, "":
+		return NewCredentials(ctx, PasswordSourceFor(cfg), log)
+	case AuthRDSIAM:
+		tokens, err := NewRDSTokens(ctx, cfg.RDS)
+		if err != nil {
+			return nil, err
+		}
+		return NewTokenCredentials(ctx, tokens, cfg, log)
+	case AuthCloudSQL:
+		return NewCloudSQLCredentials(ctx, cfg, log)
+	default:
+		return nil, fmt.Errorf("unknown postgres auth: %q", cfg.Auth)
+	}
+}
+
+// RDSTokens issues AWS RDS IAM authentication tokens. Tokens are signed
+// locally with the AWS credentials and are valid for 15 minutes.
+type RDSTokens struct {
+	region      string
+	credentials aws.CredentialsProvider
+}
+
+// NewRDSTokens creates an RDS token source. Credentials come from the
+// default AWS chain (environment, shared config, instance or pod role).
+func NewRDSTokens(ctx context.Context, cfg config.PostgresRDSConfig) (*RDSTokens, error) {
+	var opts []func(*awsconfig.LoadOptions) error
+	if cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(cfg.Region))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	if awsCfg.Region == "" {
+		return nil, errors.New("rds_iam auth requires postgres.rds.region or AWS_REGION")
+	}
+
+	return &RDSTokens{
+		region:      awsCfg.Region,
+		credentials: awsCfg.Credentials,
+	}, nil
+}
+
+// Token returns an authentication token for the RDS instance at host:port
+func (t *RDSTokens) Token(ctx context.Context, host string, port uint16, user string) (string, error) {
+	endpoint := net.JoinHostPort(host, strconv.Itoa(int(port)))
+	return auth.BuildAuthToken(ctx, endpoint, t.region, user, t.credentials)
+}
+
+// NewCloudSQLCredentials returns credentials that connect through the Cloud
+// SQL Go connector, which refreshes the instance certificates and, with IAM
+// authentication, the login token in the background. The host of each
+// pool is the instance connection name, project:region:instance.
+func NewCloudSQLCredentials(ctx context.Context, cfg config.PostgresConfig, log *logger.Logger) (*Credentials, error) {
+	ipType, err := cloudSQLIPType(cfg.CloudSQL.IPType)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []cloudsqlconn.Option{cloudsqlconn.WithDefaultDialOptions(ipType)}
+	source := PasswordSourceFor(cfg)
+	if cfg.CloudSQL.IAMAuthN {
+		opts = append(opts, cloudsqlconn.WithIAMAuthN())
+		source = StaticPassword("")
+	}
+
+	dialer, err := cloudsqlconn.NewDialer(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cloud SQL dialer: %w", err)
+	}
+
+	creds, err := NewCredentials(ctx, source, log)
+	if err != nil {
+		_ = dialer.Close()
+		return nil, err
+	}
+	creds.dial = func(ctx context.Context, instance string) (net.Conn, error) {
+		return dialer.Dial(ctx, instance)
+	}
+	creds.close = dialer.Close
+
+	return creds, nil
+}
+
+// cloudSQLIPType returns the dial option for postgres.cloudsql.ip_type
+func cloudSQLIPType(ipType string) (cloudsqlconn.DialOption, error) {
+	switch ipType {
+	case "public", "":
+		return cloudsqlconn.WithPublicIP(), nil
+	case "private":
+		return cloudsqlconn.WithPrivateIP(), nil
+	case "psc":
+		return cloudsqlconn.WithPSC(), nil
+	default:
+		return nil, fmt.Errorf("unknown postgres.cloudsql.ip_type: %q", ipType)
+	}
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// countingTokens issues numbered tokens for any host
+type countingTokens struct {
+	issued int
+	err    error
+}
+
+func (t *countingTokens) Token(ctx context.Context, host string, port uint16, user string) (string, error) {
+	if t.err != nil {
+		return "", t.err
+	}
+	t.issued++
+	return host + "-" + strconv.Itoa(t.issued), nil
+}
+
+func TestAuthenticate_UnknownAuth(t *testing.T) {
+	_, err := Authenticate(context.Background(), config.PostgresConfig{Auth: "kerberos"}, logger.New("info", io.Discard))
+	assert.EqualError(t, err, `unknown postgres auth: "kerberos"`)
+}
+
+func TestAuthenticate_Password(t *testing.T) {
+	creds, err := Authenticate(context.Background(), config.PostgresConfig{Password: "postgres"}, logger.New("info", io.Discard))
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", creds.Password())
+	assert.NoError(t, creds.Close())
+}
+
+func TestRDSTokens_Token(t *testing.T) {
+	tokens := &RDSTokens{
+		region:      "eu-west-1",
+		credentials: credentials.NewStaticCredentialsProvider("AKIDEXAMPLE", "secret", ""),
+	}
+
+	token, err := tokens.Token(context.Background(), "db.eu-west-1.rds.amazonaws.com", 5432, "app")
+	require.NoError(t, err)
+	assert.Contains(t, token, "db.eu-west-1.rds.amazonaws.com:5432?Action=connect")
+	assert.Contains(t, token, "DBUser=app")
+	assert.Contains(t, token, "X-Amz-Credential=AKIDEXAMPLE%2F")
+	assert.Contains(t, token, "%2Feu-west-1%2Frds-db%2Faws4_request")
+}
+
+func TestNewRDSTokens_RequiresRegion(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+
+	_, err := NewRDSTokens(context.Background(), config.PostgresRDSConfig{})
+	assert.ErrorContains(t, err, "requires postgres.rds.region")
+
+	tokens, err := NewRDSTokens(context.Background(), config.PostgresRDSConfig{Region: "us-east-2"})
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-2", tokens.region)
+}
+
+func TestTokenCredentials_IssueATokenPerConnection(t *testing.T) {
+	tokens := &countingTokens{}
+	creds, err := NewTokenCredentials(context.Background(), tokens, config.PostgresConfig{Host: "primary", Port: 5432}, logger.New("info", io.Discard))
+	require.NoError(t, err)
+
+	first := &pgx.ConnConfig{}
+	first.Host = "primary"
+	require.NoError(t, creds.beforeConnect(context.Background(), first))
+	second := &pgx.ConnConfig{}
+	second.Host = "replica"
+	require.NoError(t, creds.beforeConnect(context.Background(), second))
+
+	assert.Equal(t, "primary-2", first.Password)
+	assert.Equal(t, "replica-3", second.Password)
+
+	// Connections outlive their token
+	assert.False(t, creds.stale(first.Password))
+
+	rotated, err := creds.Reload(context.Background())
+	require.NoError(t, err)
+	assert.False(t, rotated)
+}
+
+func TestNewTokenCredentials_FailsWithoutTokens(t *testing.T) {
+	unavailable := errors.New("no credentials")
+	_, err := NewTokenCredentials(context.Background(), &countingTokens{err: unavailable}, config.PostgresConfig{}, logger.New("info", io.Discard))
+	assert.ErrorIs(t, err, unavailable)
+}
+
+func TestCredentials_OpenDBDialsTheInstance(t *testing.T) {
+	creds, err := NewCredentials(context.Background(), StaticPassword("postgres"), logger.New("info", io.Discard))
+	require.NoError(t, err)
+
+	refused := errors.New("dial refused")
+	var dialed []string
+	creds.dial = func(ctx context.Context, instance string) (net.Conn, error) {
+		dialed = append(dialed, instance)
+		return nil, refused
+	}
+
+	db, err := creds.OpenDB(config.PostgresConfig{
+		Host:     "project:region:instance",
+		Port:     5432,
+		User:     "app",
+		Database: "app",
+		SSLMode:  "require",
+	})
+	require.NoError(t, err)
+	defer db.Close()
+
+	assert.ErrorIs(t, db.PingContext(context.Background()), refused)
+	require.NotEmpty(t, dialed)
+	assert.Equal(t, "project:region:instance", dialed[0])
+}
+
+func TestCredentials_WithPasswordKeepsTheDialer(t *testing.T) {
+	creds, err := NewCredentials(context.Background(), StaticPassword("primary"), logger.New("info", io.Discard))
+	require.NoError(t, err)
+	creds.dial = func(ctx context.Context, instance string) (net.Conn, error) {
+		return nil, errors.New("unreachable")
+	}
+
+	replica := creds.withPassword("replica")
+	assert.Equal(t, "replica", replica.Password())
+	assert.NotNil(t, replica.dial)
+	assert.NoError(t, replica.Close())
+}
+
+func TestCloudSQLIPType(t *testing.T) {
+	for _, ipType := range []string{"", "public", "private", "psc"} {
+		_, err := cloudSQLIPType(ipType)
+		assert.NoError(t, err, ipType)
+	}
+
+	_, err := cloudSQLIPType("ipv6")
+	assert.EqualError(t, err, `unknown postgres.cloudsql.ip_type: "ipv6"`)
+}
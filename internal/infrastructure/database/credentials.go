@@ -0,0 +1,292 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// PasswordSource resolves the current database password. Sources backed
+// by a secrets provider return a new password after it rotates.
+type PasswordSource interface {
+	Password(ctx context.Context) (string, error)
+}
+
+// StaticPassword is a password that never changes
+type StaticPassword string
+
+// Password returns p
+func (p StaticPassword) Password(ctx context.Context) (string, error) {
+	return string(p), nil
+}
+
+// FilePassword reads the password from the file at the path, such as one
+// written by a Vault agent or mounted from a Kubernetes secret
+type FilePassword string
+
+// Password returns the contents of the file without surrounding whitespace.
+// An empty file is an error, so a file caught mid-write is not used.
+func (p FilePassword) Password(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(string(p))
+	if err != nil {
+		return "", err
+	}
+
+	password := strings.TrimSpace(string(data))
+	if password == "" {
+		return "", fmt.Errorf("password file %s is empty", string(p))
+	}
+	return password, nil
+}
+
+// PasswordSourceFor returns the source of the password in cfg: the password
+// file when one is set, otherwise the configured password
+func PasswordSourceFor(cfg config.PostgresConfig) PasswordSource {
+	if cfg.PasswordFile != "" {
+		return FilePassword(cfg.PasswordFile)
+	}
+	return StaticPassword(cfg.Password)
+}
+
+// Credentials holds the password new database connections authenticate
+// with, and replaces it when its source rotates
+type Credentials struct {
+	source PasswordSource
+	log    *logger.Logger
+
+	mu       sync.RWMutex
+	password string
+
+	// tokens, when set, issues a password for each new connection instead
+	tokens TokenSource
+
+	// dial, when set, connects to the instance named by the host instead of
+	// resolving it; close releases what it holds
+	dial  func(ctx context.Context, instance string) (net.Conn, error)
+	close func() error
+}
+
+// NewCredentials resolves the current password from source
+func NewCredentials(ctx context.Context, source PasswordSource, log *logger.Logger) (*Credentials, error) {
+	password, err := source.Password(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve database password: %w", err)
+	}
+
+	return &Credentials{
+		source:   source,
+		log:      log,
+		password: password,
+	}, nil
+}
+
+// NewTokenCredentials returns credentials that authenticate each new
+// connection with a fresh token from tokens. A token is only checked when a
+// connection is opened, so open connections are kept after it expires. A
+// first token for cfg is issued to fail fast when tokens are unavailable.
+func NewTokenCredentials(ctx context.Context, tokens TokenSource, cfg config.PostgresConfig, log *logger.Logger) (*Credentials, error) {
+	if _, err := tokens.Token(ctx, cfg.Host, uint16(cfg.Port), cfg.User); err != nil {
+		return nil, fmt.Errorf("failed to issue database token: %w", err)
+	}
+
+	return &Credentials{
+		tokens: tokens,
+		log:    log,
+	}, nil
+}
+
+// withPassword returns credentials that connect like c but with password,
+// for a replica that has its own
+func (c *Credentials) withPassword(password string) *Credentials {
+	return &Credentials{
+		source:   StaticPassword(password),
+		log:      c.log,
+		password: password,
+		tokens:   c.tokens,
+		dial:     c.dial,
+	}
+}
+
+// Close releases the resources of the credentials, such as the Cloud SQL
+// dialer. Pools opened with them must be closed first.
+func (c *Credentials) Close() error {
+	if c.close == nil {
+		return nil
+	}
+	return c.close()
+}
+
+// Password returns the current password
+func (c *Credentials) Password() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.password
+}
+
+// Reload resolves the password again and reports whether it changed. On
+// error the current password is kept. Token credentials have nothing to
+// reload, as each connection gets a fresh token.
+func (c *Credentials) Reload(ctx context.Context) (bool, error) {
+	if c.tokens != nil {
+		return false, nil
+	}
+
+	password, err := c.source.Password(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve database password: %w", err)
+	}
+
+	c.mu.Lock()
+	changed := password != c.password
+	c.password = password
+	c.mu.Unlock()
+
+	if changed {
+		c.log.Info().Msg("Database password rotated; connections using the old one are being replaced")
+	}
+	return changed, nil
+}
+
+// Watch reloads the password every interval until ctx is done
+func (c *Credentials) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := c.Reload(ctx); err != nil {
+				c.log.Warn().Err(err).Msg("Keeping the current database password")
+			}
+		}
+	}
+}
+
+// OpenDB opens a connection pool to the database in cfg. New connections
+// authenticate with the current password, and pooled connections opened
+// with an older one are closed when next taken from the pool, so a
+// rotation reaches every connection without a restart. The
+// statement_timeout set by UseDeadlineTimeouts is reset then too. Every
+// connection runs in UTC and reads and writes times in UTC.
+func (c *Credentials) OpenDB(cfg config.PostgresConfig) (*sql.DB, error) {
+	connConfig, err := pgx.ParseConfig(cfg.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection settings: %w", err)
+	}
+
+	c.useDialer(&connConfig.Config)
+	useUTC(&connConfig.Config)
+
+	return stdlib.OpenDB(*connConfig,
+		stdlib.OptionBeforeConnect(c.beforeConnect),
+		stdlib.OptionAfterConnect(registerUTCTypes),
+		stdlib.OptionResetSession(func(ctx context.Context, conn *pgx.Conn) error {
+			if c.stale(conn.Config().Password) {
+				return driver.ErrBadConn
+			}
+			return resetStatementTimeout(ctx, conn)
+		}),
+	), nil
+}
+
+// ConnectReplication opens a logical replication connection to the
+// database in cfg, authenticated like the connections of OpenDB. Such a
+// connection streams changes from a replication slot and runs plain SQL
+// queries, but no extended protocol statements.
+func (c *Credentials) ConnectReplication(ctx context.Context, cfg config.PostgresConfig) (*pgconn.PgConn, error) {
+	connConfig, err := pgconn.ParseConfig(cfg.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection settings: %w", err)
+	}
+	connConfig.RuntimeParams["replication"] = "database"
+	c.useDialer(connConfig)
+	useUTC(connConfig)
+
+	if err := c.authenticate(ctx, connConfig); err != nil {
+		return nil, err
+	}
+	return pgconn.ConnectConfig(ctx, connConfig)
+}
+
+// Connect opens a single connection to the database in cfg, outside the
+// pool, authenticated like the connections of OpenDB. It suits sessions
+// that must outlive a query, such as one waiting with LISTEN for
+// notifications.
+func (c *Credentials) Connect(ctx context.Context, cfg config.PostgresConfig) (*pgx.Conn, error) {
+	connConfig, err := pgx.ParseConfig(cfg.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection settings: %w", err)
+	}
+	c.useDialer(&connConfig.Config)
+	useUTC(&connConfig.Config)
+
+	if err := c.authenticate(ctx, &connConfig.Config); err != nil {
+		return nil, err
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, connConfig)
+	if err != nil {
+		return nil, err
+	}
+	useUTCTypes(conn.TypeMap())
+	return conn, nil
+}
+
+// useDialer makes connections of connConfig go through the connector of
+// the credentials, if any
+func (c *Credentials) useDialer(connConfig *pgconn.Config) {
+	if c.dial == nil {
+		return
+	}
+
+	// The connector encrypts the connection itself, and the host names
+	// the instance rather than an address to resolve
+	instance := connConfig.Host
+	connConfig.Host = "localhost"
+	connConfig.TLSConfig = nil
+	connConfig.Fallbacks = nil
+	connConfig.DialFunc = func(ctx context.Context, _, _ string) (net.Conn, error) {
+		return c.dial(ctx, instance)
+	}
+}
+
+// beforeConnect makes a new connection use the current password, or a
+// fresh token
+func (c *Credentials) beforeConnect(ctx context.Context, connConfig *pgx.ConnConfig) error {
+	return c.authenticate(ctx, &connConfig.Config)
+}
+
+// authenticate sets the current password, or a fresh token, on connConfig
+func (c *Credentials) authenticate(ctx context.Context, connConfig *pgconn.Config) error {
+	if c.tokens == nil {
+		connConfig.Password = c.Password()
+		return nil
+	}
+
+	token, err := c.tokens.Token(ctx, connConfig.Host, connConfig.Port, connConfig.User)
+	if err != nil {
+		return fmt.Errorf("failed to issue database token: %w", err)
+	}
+	connConfig.Password = token
+	return nil
+}
+
+// stale reports whether a connection opened with password must be replaced
+func (c *Credentials) stale(password string) bool {
+	return c.tokens == nil && password != c.Password()
+}
@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+func writePassword(t *testing.T, path, password string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(password), 0o600))
+}
+
+func TestFilePassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+
+	writePassword(t, path, "s3cret\n")
+	password, err := FilePassword(path).Password(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", password)
+
+	writePassword(t, path, "  \n")
+	_, err = FilePassword(path).Password(context.Background())
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = FilePassword(filepath.Join(t.TempDir(), "missing")).Password(context.Background())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestPasswordSourceFor(t *testing.T) {
+	assert.Equal(t, StaticPassword("postgres"), PasswordSourceFor(config.PostgresConfig{Password: "postgres"}))
+	assert.Equal(t, FilePassword("/run/secrets/db"), PasswordSourceFor(config.PostgresConfig{
+		Password:     "postgres",
+		PasswordFile: "/run/secrets/db",
+	}))
+}
+
+func TestCredentials_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	writePassword(t, path, "first")
+
+	creds, err := NewCredentials(context.Background(), FilePassword(path), logger.New("info", io.Discard))
+	require.NoError(t, err)
+	assert.Equal(t, "first", creds.Password())
+
+	rotated, err := creds.Reload(context.Background())
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	writePassword(t, path, "second")
+	rotated, err = creds.Reload(context.Background())
+	require.NoError(t, err)
+	assert.True(t, rotated)
+	assert.Equal(t, "second", creds.Password())
+
+	// A failed reload keeps the current password
+	require.NoError(t, os.Remove(path))
+	_, err = creds.Reload(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, "second", creds.Password())
+}
+
+func TestNewCredentials_FailsWithoutPassword(t *testing.T) {
+	_, err := NewCredentials(context.Background(), FilePassword(filepath.Join(t.TempDir(), "missing")), logger.New("info", io.Discard))
+	assert.ErrorContains(t, err, "failed to resolve database password")
+}
+
+func TestCredentials_ReplacesConnectionsAfterRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	writePassword(t, path, "first")
+
+	creds, err := NewCredentials(context.Background(), FilePassword(path), logger.New("info", io.Discard))
+	require.NoError(t, err)
+
+	connConfig := &pgx.ConnConfig{}
+	require.NoError(t, creds.beforeConnect(context.Background(), connConfig))
+	assert.Equal(t, "first", connConfig.Password)
+	assert.False(t, creds.stale(connConfig.Password))
+
+	writePassword(t, path, "second")
+	_, err = creds.Reload(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, creds.stale(connConfig.Password))
+	require.NoError(t, creds.beforeConnect(context.Background(), connConfig))
+	assert.Equal(t, "second", connConfig.Password)
+}
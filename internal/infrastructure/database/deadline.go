@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+)
+
+const (
+	// pinnedKey stores the connection a statement was pinned to on its
+	// GORM instance, with the connection pool it replaced
+	pinnedKey = "database:deadline_pinned"
+
+	// timeoutSetKey marks a pgx connection whose statement_timeout was
+	// changed, in its custom data, so it is reset before reuse
+	timeoutSetKey = "database:statement_timeout_set"
+)
+
+// pinned is a connection a statement runs on, and the connection pool it
+// would have run on
+type pinned struct {
+	conn     *sql.Conn
+	original gorm.ConnPool
+}
+
+// UseDeadlineTimeouts sets the statement_timeout of each statement of db
+// whose context has a deadline to the time left until it, so PostgreSQL
+// stops working on a query whose request already timed out even when the
+// cancel request of the driver does not reach it.
+//
+// In a transaction the timeout is set with SET LOCAL. Other statements run
+// on a connection of their own, bypassing the prepared statement cache,
+// whose timeout is reset before it is reused; the connections must come
+// from OpenDB. This costs a round trip per statement with a deadline.
+func UseDeadlineTimeouts(db *gorm.DB) error {
+	return db.Use(&deadlinePlugin{})
+}
+
+// deadlinePlugin registers the GORM callbacks setting statement timeouts
+type deadlinePlugin struct{}
+
+// Name implements gorm.Plugin
+func (p *deadlinePlugin) Name() string {
+	return "database:deadline_timeouts"
+}
+
+// Initialize implements gorm.Plugin. The callbacks run after dbresolver
+// picked the node of the statement.
+func (p *deadlinePlugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	registrations := []error{
+		callbacks.Create().Before("gorm:create").Register("database:before_create", p.set),
+		callbacks.Create().After("gorm:create").Register("database:after_create", p.release),
+		callbacks.Query().Before("gorm:query").Register("database:before_query", p.set),
+		callbacks.Query().After("gorm:query").Register("database:after_query", p.release),
+		callbacks.Update().Before("gorm:update").Register("database:before_update", p.set),
+		callbacks.Update().After("gorm:update").Register("database:after_update", p.release),
+		callbacks.Delete().Before("gorm:delete").Register("database:before_delete", p.set),
+		callbacks.Delete().After("gorm:delete").Register("database:after_delete", p.release),
+		callbacks.Row().Before("gorm:row").Register("database:before_row", p.set),
+		callbacks.Row().After("gorm:row").Register("database:after_row", p.releaseRows),
+		callbacks.Raw().Before("gorm:raw").Register("database:before_raw", p.set),
+		callbacks.Raw().After("gorm:raw").Register("database:after_raw", p.release),
+	}
+	return errors.Join(registrations...)
+}
+
+// set sets the statement_timeout of the statement from the deadline of its
+// context
+func (p *deadlinePlugin) set(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Context == nil || db.DryRun {
+		return
+	}
+	deadline, ok := stmt.Context.Deadline()
+	if !ok {
+		return
+	}
+	timeout, ok := statementTimeout(time.Until(deadline))
+	if !ok {
+		// The driver fails the statement with the error of its context
+		return
+	}
+
+	if _, inTx := stmt.ConnPool.(gorm.TxCommitter); inTx {
+		_, err := stmt.ConnPool.ExecContext(stmt.Context, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeout))
+		if err != nil {
+			_ = db.AddError(fmt.Errorf("failed to set statement timeout: %w", err))
+		}
+		return
+	}
+
+	pool, ok := sqlDBOf(stmt.ConnPool)
+	if !ok {
+		return
+	}
+	conn, err := pool.Conn(stmt.Context)
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	if err := setStatementTimeout(stmt.Context, conn, timeout); err != nil {
+		_ = conn.Close()
+		_ = db.AddError(fmt.Errorf("failed to set statement timeout: %w", err))
+		return
+	}
+
+	db.InstanceSet(pinnedKey, &pinned{conn: conn, original: stmt.ConnPool})
+	stmt.ConnPool = conn
+}
+
+// release returns the connection the statement was pinned to to the pool
+func (p *deadlinePlugin) release(db *gorm.DB) {
+	if pin := unpin(db); pin != nil {
+		_ = pin.conn.Close()
+	}
+}
+
+// releaseRows returns the connection the statement was pinned to once the
+// rows it returned are closed, which Close waits for
+func (p *deadlinePlugin) releaseRows(db *gorm.DB) {
+	if pin := unpin(db); pin != nil {
+		go pin.conn.Close()
+	}
+}
+
+// unpin restores the connection pool of the statement, returning the
+// connection it was pinned to, if any
+func unpin(db *gorm.DB) *pinned {
+	value, ok := db.InstanceGet(pinnedKey)
+	if !ok {
+		return nil
+	}
+	pin, ok := value.(*pinned)
+	if !ok || pin == nil {
+		return nil
+	}
+	db.InstanceSet(pinnedKey, (*pinned)(nil))
+	db.Statement.ConnPool = pin.original
+	return pin
+}
+
+// statementTimeout returns the statement_timeout in milliseconds for the
+// time left, rounded up so it is never 0, which disables the timeout. It
+// reports false when no time is left.
+func statementTimeout(left time.Duration) (int64, bool) {
+	if left <= 0 {
+		return 0, false
+	}
+	return int64((left + time.Millisecond - 1) / time.Millisecond), true
+}
+
+// sqlDBOf returns the database/sql pool behind a GORM connection pool
+func sqlDBOf(pool gorm.ConnPool) (*sql.DB, bool) {
+	switch p := pool.(type) {
+	case *sql.DB:
+		return p, true
+	case gorm.GetDBConnector:
+		db, err := p.GetDBConn()
+		return db, err == nil
+	}
+	return nil, false
+}
+
+// setStatementTimeout sets the statement_timeout of conn and marks it to
+// be reset before reuse
+func setStatementTimeout(ctx context.Context, conn *sql.Conn, millis int64) error {
+	return conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("statement timeouts need the pgx driver, got %T", driverConn)
+		}
+		if _, err := c.Conn().Exec(ctx, fmt.Sprintf("SET statement_timeout = %d", millis)); err != nil {
+			return err
+		}
+		c.Conn().PgConn().CustomData()[timeoutSetKey] = true
+		return nil
+	})
+}
+
+// resetStatementTimeout restores the statement_timeout of a connection
+// returned to the pool after a statement with a deadline changed it. A
+// connection that cannot be reset is discarded.
+func resetStatementTimeout(ctx context.Context, conn *pgx.Conn) error {
+	data := conn.PgConn().CustomData()
+	if _, ok := data[timeoutSetKey]; !ok {
+		return nil
+	}
+	if _, err := conn.Exec(ctx, "RESET statement_timeout"); err != nil {
+		return driver.ErrBadConn
+	}
+	delete(data, timeoutSetKey)
+	return nil
+}
@@ -0,0 +1,95 @@
+//go:build integration
+
+package database
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+var emptyTemplate = pgtest.Template{
+	Name:    "empty",
+	Migrate: func(*gorm.DB) error { return nil },
+}
+
+// setupDeadlineDB returns a database with deadline timeouts, on a pool of
+// one connection reset like those of OpenDB
+func setupDeadlineDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	dialector, ok := pgtest.DB(t, emptyTemplate).Dialector.(*postgres.Dialector)
+	require.True(t, ok)
+	connConfig, err := pgx.ParseConfig(dialector.DSN)
+	require.NoError(t, err)
+
+	sqlDB := stdlib.OpenDB(*connConfig, stdlib.OptionResetSession(resetStatementTimeout))
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		Logger:      logger.Default.LogMode(logger.Silent),
+		PrepareStmt: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, UseDeadlineTimeouts(db))
+	return db
+}
+
+// statementTimeoutOf returns the statement_timeout a statement of ctx runs with
+func statementTimeoutOf(t *testing.T, ctx context.Context, db *gorm.DB) string {
+	t.Helper()
+
+	var timeout string
+	require.NoError(t, db.WithContext(ctx).Raw("SHOW statement_timeout").Scan(&timeout).Error)
+	return timeout
+}
+
+func TestUseDeadlineTimeouts_Postgres(t *testing.T) {
+	db := setupDeadlineDB(t)
+	background := context.Background()
+
+	ctx, cancel := context.WithTimeout(background, time.Minute)
+	defer cancel()
+
+	t.Run("sets the time left", func(t *testing.T) {
+		assert.NotEqual(t, "0", statementTimeoutOf(t, ctx, db))
+	})
+
+	t.Run("resets the connection before reuse", func(t *testing.T) {
+		assert.Equal(t, "0", statementTimeoutOf(t, background, db))
+	})
+
+	t.Run("sets it locally in a transaction", func(t *testing.T) {
+		err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			assert.NotEqual(t, "0", statementTimeoutOf(t, ctx, tx))
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "0", statementTimeoutOf(t, background, db))
+	})
+
+	t.Run("cancels statements running past the deadline", func(t *testing.T) {
+		short, cancel := context.WithTimeout(background, 200*time.Millisecond)
+		defer cancel()
+
+		err := db.WithContext(short).Exec("SELECT pg_sleep(5)").Error
+		assert.Error(t, err)
+		assert.Equal(t, "0", statementTimeoutOf(t, background, db))
+	})
+}
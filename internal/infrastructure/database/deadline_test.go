@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementTimeout(t *testing.T) {
+	tests := []struct {
+		left   time.Duration
+		want   int64
+		wantOK bool
+	}{
+		{left: 5 * time.Second, want: 5000, wantOK: true},
+		{left: 1500*time.Millisecond + time.Microsecond, want: 1501, wantOK: true},
+		{left: time.Nanosecond, want: 1, wantOK: true},
+		{left: 0},
+		{left: -time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.left.String(), func(t *testing.T) {
+			got, ok := statementTimeout(tt.left)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUseDeadlineTimeouts(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, UseDeadlineTimeouts(db))
+	require.NoError(t, db.Create(&testRecord{Name: "one"}).Error)
+
+	t.Run("leaves statements without a deadline alone", func(t *testing.T) {
+		var count int64
+		require.NoError(t, db.Model(&testRecord{}).Count(&count).Error)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("needs the pgx driver and releases the connection", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+
+		var records []testRecord
+		err := db.WithContext(ctx).Find(&records).Error
+		assert.ErrorContains(t, err, "statement timeouts need the pgx driver")
+
+		// The pool holds a single connection, which was returned
+		require.NoError(t, db.Find(&records).Error)
+		assert.Len(t, records, 1)
+	})
+}
@@ -0,0 +1,269 @@
+// Package pgtest runs PostgreSQL for integration tests. One server is shared
+// by every test in a test binary, and each test gets its own database cloned
+// from a migrated template, so tests are isolated without paying for a new
+// container or a full migration each time.
+//
+// Packages using it wrap their tests with Run:
+//
+//	func TestMain(m *testing.M) {
+//		os.Exit(pgtest.Run(m))
+//	}
+package pgtest
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	gormpostgres "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// EnvDSN points the harness at an existing server instead of starting a
+	// container, e.g. a CI service container. The user must be allowed to
+	// create databases.
+	EnvDSN = "PGTEST_DSN"
+
+	// EnvReuse keeps a single named container running across test binaries
+	// and runs instead of starting one per package
+	EnvReuse = "PGTEST_REUSE"
+
+	image          = "postgres:16-alpine"
+	reuseName      = "go-scaffolding-pgtest"
+	startupTimeout = 60 * time.Second
+)
+
+// Template describes the schema every database cloned from it starts with
+type Template struct {
+	// Name identifies the template; it must be a lowercase SQL identifier
+	Name string
+
+	// Migrate creates the schema. It runs once per test binary.
+	Migrate func(db *gorm.DB) error
+}
+
+var (
+	mu       sync.Mutex
+	shared   *server
+	startErr error
+)
+
+// server is the PostgreSQL instance shared by the tests of one binary
+type server struct {
+	container testcontainers.Container // nil when the server is not owned by this binary
+	dsn       *url.URL
+	admin     *gorm.DB
+	templates map[string]string
+	clones    int
+}
+
+// Run runs the tests and then removes the databases and the container
+// they used. Use it from TestMain; containers are always terminated here
+// rather than left to Ryuk, so runs with Ryuk disabled do not leak them.
+func Run(m *testing.M) int {
+	code := m.Run()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if shared != nil {
+		shared.close()
+		shared = nil
+	}
+	return code
+}
+
+// DB returns a connection to a new database cloned from tmpl. The database
+// is dropped when the test finishes.
+func DB(t *testing.T, tmpl Template) *gorm.DB {
+	t.Helper()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	s, err := start()
+	require.NoError(t, err, "Failed to start PostgreSQL")
+
+	template, err := s.template(tmpl)
+	require.NoError(t, err, "Failed to create template database %q", tmpl.Name)
+
+	s.clones++
+	name := fmt.Sprintf("test_%d_%d", os.Getpid(), s.clones)
+	err = s.admin.Exec(fmt.Sprintf("CREATE DATABASE %s TEMPLATE %s", quoteIdent(name), quoteIdent(template))).Error
+	require.NoError(t, err, "Failed to create test database")
+
+	db, err := open(dsnFor(s.dsn, name))
+	require.NoError(t, err, "Failed to connect to test database")
+
+	t.Cleanup(func() {
+		closeDB(db)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err := s.admin.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", quoteIdent(name))).Error; err != nil {
+			t.Logf("Failed to drop test database %s: %v", name, err)
+		}
+	})
+
+	return db
+}
+
+// start connects to the shared server, starting it on first use.
+// Callers must hold mu.
+func start() (*server, error) {
+	if shared != nil || startErr != nil {
+		return shared, startErr
+	}
+
+	shared, startErr = newServer(context.Background())
+	return shared, startErr
+}
+
+// newServer connects to the server named by EnvDSN or starts a container
+func newServer(ctx context.Context) (*server, error) {
+	s := &server{templates: make(map[string]string)}
+
+	dsn := os.Getenv(EnvDSN)
+	if dsn == "" {
+		container, err := runContainer(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if os.Getenv(EnvReuse) == "" {
+			s.container = container
+		}
+
+		dsn, err = container.ConnectionString(ctx, "sslmode=disable")
+		if err != nil {
+			s.close()
+			return nil, err
+		}
+	}
+
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		s.close()
+		return nil, fmt.Errorf("invalid %s: %w", EnvDSN, err)
+	}
+	s.dsn = parsed
+
+	s.admin, err = open(dsn)
+	if err != nil {
+		s.close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// runContainer starts a PostgreSQL container, or attaches to the running
+// shared one when EnvReuse is set
+func runContainer(ctx context.Context) (*tcpostgres.PostgresContainer, error) {
+	opts := []testcontainers.ContainerCustomizer{
+		tcpostgres.WithDatabase("testdb"),
+		tcpostgres.WithUsername("testuser"),
+		tcpostgres.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(startupTimeout)),
+	}
+	if os.Getenv(EnvReuse) != "" {
+		opts = append(opts, testcontainers.WithReuseByName(reuseName))
+	}
+
+	return tcpostgres.Run(ctx, image, opts...)
+}
+
+// template returns the database holding tmpl's schema, creating and
+// migrating it on first use. Callers must hold mu.
+func (s *server) template(tmpl Template) (string, error) {
+	if name, ok := s.templates[tmpl.Name]; ok {
+		return name, nil
+	}
+
+	// Templates are private to this binary, so packages sharing a reused
+	// container never clone a schema another package is still migrating
+	name := fmt.Sprintf("%s_template_%d", tmpl.Name, os.Getpid())
+	if err := s.admin.Exec(fmt.Sprintf("CREATE DATABASE %s", quoteIdent(name))).Error; err != nil {
+		return "", err
+	}
+
+	if err := migrate(dsnFor(s.dsn, name), tmpl.Migrate); err != nil {
+		s.admin.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", quoteIdent(name)))
+		return "", err
+	}
+
+	s.templates[tmpl.Name] = name
+	return name, nil
+}
+
+// migrate applies fn to the database at dsn. The connection is closed
+// before returning because cloning fails while anyone is connected to
+// the template.
+func migrate(dsn string, fn func(db *gorm.DB) error) error {
+	db, err := open(dsn)
+	if err != nil {
+		return err
+	}
+	defer closeDB(db)
+
+	if fn == nil {
+		return nil
+	}
+	return fn(db)
+}
+
+// close drops the templates and terminates the container owned by this binary
+func (s *server) close() {
+	if s.admin != nil {
+		for _, name := range s.templates {
+			s.admin.Exec(fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", quoteIdent(name)))
+		}
+		closeDB(s.admin)
+	}
+
+	if s.container != nil {
+		if err := testcontainers.TerminateContainer(s.container); err != nil {
+			fmt.Fprintf(os.Stderr, "pgtest: failed to terminate container: %v\n", err)
+		}
+	}
+}
+
+// open connects to dsn without logging every statement
+func open(dsn string) (*gorm.DB, error) {
+	return gorm.Open(gormpostgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+}
+
+// closeDB closes the connection pool behind db
+func closeDB(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
+// dsnFor returns dsn with its database replaced by name
+func dsnFor(dsn *url.URL, name string) string {
+	u := *dsn
+	u.Path = "/" + name
+	u.RawPath = ""
+	return u.String()
+}
+
+// quoteIdent quotes a SQL identifier
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,37 @@
+//go:build integration
+
+package pgtest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(Run(m))
+}
+
+var notesTemplate = Template{
+	Name: "notes",
+	Migrate: func(db *gorm.DB) error {
+		return db.Exec("CREATE TABLE notes (id SERIAL PRIMARY KEY, body TEXT NOT NULL)").Error
+	},
+}
+
+func TestDB_IsolatesTests(t *testing.T) {
+	first := DB(t, notesTemplate)
+	second := DB(t, notesTemplate)
+
+	require.NoError(t, first.Exec("INSERT INTO notes (body) VALUES ('hello')").Error)
+
+	var count int64
+	require.NoError(t, first.Table("notes").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	require.NoError(t, second.Table("notes").Count(&count).Error)
+	assert.Zero(t, count, "clones start from the migrated template")
+}
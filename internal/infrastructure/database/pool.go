@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+// PoolsPath is the admin route of the connection pool view
+const PoolsPath = "/debug/db"
+
+// PrimaryPool names the pool of the primary
+const PrimaryPool = "primary"
+
+// Pool is the connection pool of the primary or of a read replica
+type Pool struct {
+	Name string
+	DB   *sql.DB
+}
+
+// Pools returns the connection pools of db and of its replicas, which may
+// be nil
+func Pools(db *gorm.DB, replicas *Replicas) ([]Pool, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	pools := []Pool{{Name: PrimaryPool, DB: sqlDB}}
+	return append(pools, replicas.Pools()...), nil
+}
+
+// Pools returns the connection pools of the replicas. It returns nil when
+// r is nil, i.e. when no replicas are configured.
+func (r *Replicas) Pools() []Pool {
+	if r == nil {
+		return nil
+	}
+
+	pools := make([]Pool, 0, len(r.nodes))
+	for _, node := range r.nodes {
+		pools = append(pools, Pool{Name: node.Name, DB: node.db})
+	}
+	return pools
+}
+
+// Warm opens up to n connections of the pool and releases them as idle, so
+// the first requests do not pay for connecting. n is capped by the open
+// connections the pool allows; connections beyond max_idle_conns are closed
+// again on release.
+func (p Pool) Warm(ctx context.Context, n int) error {
+	if limit := p.DB.Stats().MaxOpenConnections; limit > 0 {
+		n = min(n, limit)
+	}
+
+	conns := make([]*sql.Conn, 0, n)
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+	for range n {
+		conn, err := p.DB.Conn(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to open connection of pool %s: %w", p.Name, err)
+		}
+		conns = append(conns, conn)
+		if err := conn.PingContext(ctx); err != nil {
+			return fmt.Errorf("failed to ping connection of pool %s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// RegisterPoolMetrics exports the stats of pools as the go_sql_* gauges and
+// counters of Prometheus, labeled with db_name. The returned func
+// unregisters them, for when the pools are closed.
+func RegisterPoolMetrics(reg prometheus.Registerer, pools []Pool) (func(), error) {
+	registered := make([]prometheus.Collector, 0, len(pools))
+	unregister := func() {
+		for _, c := range registered {
+			reg.Unregister(c)
+		}
+	}
+
+	for _, pool := range pools {
+		c := collectors.NewDBStatsCollector(pool.DB, pool.Name)
+		if err := reg.Register(c); err != nil {
+			unregister()
+			return nil, fmt.Errorf("failed to register metrics of pool %s: %w", pool.Name, err)
+		}
+		registered = append(registered, c)
+	}
+	return unregister, nil
+}
+
+// PoolSettings are the configured limits of a pool
+type PoolSettings struct {
+	MaxOpenConns    int    `json:"max_open_conns"`
+	MaxIdleConns    int    `json:"max_idle_conns"`
+	ConnMaxLifetime string `json:"conn_max_lifetime"`
+	ConnMaxIdleTime string `json:"conn_max_idle_time"`
+}
+
+// PoolStats are the stats of a pool since it was opened, with advice on
+// tuning its settings
+type PoolStats struct {
+	Name     string       `json:"name"`
+	Settings PoolSettings `json:"settings"`
+
+	Open  int `json:"open"`
+	InUse int `json:"in_use"`
+	Idle  int `json:"idle"`
+
+	// WaitCount requests waited WaitDuration in total for a connection
+	WaitCount    int64  `json:"wait_count"`
+	WaitDuration string `json:"wait_duration"`
+
+	// Connections closed by max_idle_conns, conn_max_idle_time and
+	// conn_max_lifetime
+	MaxIdleClosed     int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64 `json:"max_lifetime_closed"`
+
+	Advice []string `json:"advice"`
+}
+
+// PoolsResponse is the body of the connection pool view
+type PoolsResponse struct {
+	Pools []PoolStats `json:"pools"`
+}
+
+// InspectPools returns the stats of pools, all configured by cfg
+func InspectPools(pools []Pool, cfg config.PostgresConfig) []PoolStats {
+	stats := make([]PoolStats, 0, len(pools))
+	for _, pool := range pools {
+		s := pool.DB.Stats()
+		stats = append(stats, PoolStats{
+			Name: pool.Name,
+			Settings: PoolSettings{
+				MaxOpenConns:    cfg.MaxOpenConns,
+				MaxIdleConns:    cfg.MaxIdleConns,
+				ConnMaxLifetime: cfg.ConnMaxLifetime.String(),
+				ConnMaxIdleTime: cfg.ConnMaxIdleTime.String(),
+			},
+			Open:              s.OpenConnections,
+			InUse:             s.InUse,
+			Idle:              s.Idle,
+			WaitCount:         s.WaitCount,
+			WaitDuration:      s.WaitDuration.String(),
+			MaxIdleClosed:     s.MaxIdleClosed,
+			MaxIdleTimeClosed: s.MaxIdleTimeClosed,
+			MaxLifetimeClosed: s.MaxLifetimeClosed,
+			Advice:            advise(s, cfg),
+		})
+	}
+	return stats
+}
+
+// advise returns the changes to cfg that stats suggest. The stats are
+// counted since the pool was opened, so advice on waits and closes
+// reflects the whole life of the process.
+func advise(stats sql.DBStats, cfg config.PostgresConfig) []string {
+	advice := []string{}
+	if cfg.MaxOpenConns > 0 && cfg.MaxIdleConns > cfg.MaxOpenConns {
+		advice = append(advice, fmt.Sprintf(
+			"max_idle_conns (%d) exceeds max_open_conns (%d), which caps it",
+			cfg.MaxIdleConns, cfg.MaxOpenConns))
+	}
+	if stats.WaitCount > 0 {
+		advice = append(advice, fmt.Sprintf(
+			"%d requests waited %s in total for a connection: raise max_open_conns (%d) if the database has connections to spare, or look for slow queries holding connections",
+			stats.WaitCount, stats.WaitDuration, cfg.MaxOpenConns))
+	}
+	if stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections {
+		advice = append(advice, fmt.Sprintf(
+			"all %d connections are in use: requests wait for one to be released",
+			stats.MaxOpenConnections))
+	}
+	if stats.MaxIdleClosed > 0 {
+		advice = append(advice, fmt.Sprintf(
+			"%d connections were closed because max_idle_conns (%d) were already idle: raise max_idle_conns towards the usual number in use if bursts keep reopening them",
+			stats.MaxIdleClosed, cfg.MaxIdleConns))
+	}
+	return advice
+}
+
+// RegisterAdminRoutes registers the connection pool view on the admin
+// group, answering with the stats of pools and advice on tuning cfg
+func RegisterAdminRoutes(group gin.IRoutes, pools []Pool, cfg config.PostgresConfig) {
+	group.GET(PoolsPath, func(c *gin.Context) {
+		c.JSON(http.StatusOK, PoolsResponse{Pools: InspectPools(pools, cfg)})
+	})
+}
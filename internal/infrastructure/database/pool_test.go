@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+func TestPools(t *testing.T) {
+	db, replica := setupReplica(t)
+	pools, err := Pools(db, &Replicas{nodes: []*Replica{replica}})
+	require.NoError(t, err)
+	require.Len(t, pools, 2)
+	assert.Equal(t, PrimaryPool, pools[0].Name)
+	assert.Equal(t, "r1", pools[1].Name)
+	assert.Same(t, replica.db, pools[1].DB)
+
+	t.Run("without replicas", func(t *testing.T) {
+		pools, err := Pools(db, nil)
+		require.NoError(t, err)
+		require.Len(t, pools, 1)
+		assert.Equal(t, PrimaryPool, pools[0].Name)
+	})
+}
+
+func TestRegisterPoolMetrics(t *testing.T) {
+	pools, err := Pools(setupTestDB(t), nil)
+	require.NoError(t, err)
+	reg := prometheus.NewRegistry()
+
+	unregister, err := RegisterPoolMetrics(reg, pools)
+	require.NoError(t, err)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	names := map[string]bool{}
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	for _, name := range []string{"go_sql_open_connections", "go_sql_in_use_connections", "go_sql_idle_connections", "go_sql_wait_count_total", "go_sql_wait_duration_seconds_total"} {
+		assert.True(t, names[name], name)
+	}
+
+	t.Run("fails on a pool registered twice", func(t *testing.T) {
+		_, err := RegisterPoolMetrics(reg, pools)
+		assert.Error(t, err)
+	})
+
+	t.Run("unregisters", func(t *testing.T) {
+		unregister()
+		_, err := RegisterPoolMetrics(reg, pools)
+		assert.NoError(t, err)
+	})
+}
+
+func TestPool_Warm(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	sqlDB.SetMaxIdleConns(3)
+
+	pool := Pool{Name: PrimaryPool, DB: sqlDB}
+	require.NoError(t, pool.Warm(context.Background(), 3))
+	assert.Equal(t, 3, sqlDB.Stats().Idle, "warmed connections stay idle in the pool")
+
+	t.Run("capped by max_open_conns", func(t *testing.T) {
+		sqlDB.SetMaxOpenConns(2)
+		require.NoError(t, pool.Warm(context.Background(), 3))
+		assert.Equal(t, 2, sqlDB.Stats().OpenConnections)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorContains(t, pool.Warm(ctx, 1), "failed to open connection of pool primary")
+	})
+}
+
+func TestAdvise(t *testing.T) {
+	cfg := config.PostgresConfig{MaxIdleConns: 10, MaxOpenConns: 100}
+
+	tests := []struct {
+		name  string
+		stats sql.DBStats
+		cfg   config.PostgresConfig
+		want  []string
+	}{
+		{name: "healthy", stats: sql.DBStats{MaxOpenConnections: 100, OpenConnections: 5, InUse: 2, Idle: 3}, cfg: cfg, want: []string{}},
+		{
+			name:  "waits",
+			stats: sql.DBStats{MaxOpenConnections: 100, WaitCount: 3, WaitDuration: 1500 * time.Millisecond},
+			cfg:   cfg,
+			want:  []string{"3 requests waited 1.5s in total for a connection: raise max_open_conns (100) if the database has connections to spare, or look for slow queries holding connections"},
+		},
+		{
+			name:  "saturated",
+			stats: sql.DBStats{MaxOpenConnections: 100, OpenConnections: 100, InUse: 100},
+			cfg:   cfg,
+			want:  []string{"all 100 connections are in use: requests wait for one to be released"},
+		},
+		{
+			name:  "idle churn",
+			stats: sql.DBStats{MaxOpenConnections: 100, MaxIdleClosed: 42},
+			cfg:   cfg,
+			want:  []string{"42 connections were closed because max_idle_conns (10) were already idle: raise max_idle_conns towards the usual number in use if bursts keep reopening them"},
+		},
+		{
+			name:  "idle above open",
+			stats: sql.DBStats{MaxOpenConnections: 5},
+			cfg:   config.PostgresConfig{MaxIdleConns: 10, MaxOpenConns: 5},
+			want:  []string{"max_idle_conns (10) exceeds max_open_conns (5), which caps it"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, advise(tt.stats, tt.cfg))
+		})
+	}
+}
+
+func TestRegisterAdminRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	pools, err := Pools(setupTestDB(t), nil)
+	require.NoError(t, err)
+	cfg := config.PostgresConfig{MaxIdleConns: 10, MaxOpenConns: 100, ConnMaxLifetime: time.Hour, ConnMaxIdleTime: 10 * time.Minute}
+
+	router := gin.New()
+	RegisterAdminRoutes(router, pools, cfg)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, PoolsPath, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var body PoolsResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Pools, 1)
+	pool := body.Pools[0]
+	assert.Equal(t, PrimaryPool, pool.Name)
+	assert.Equal(t, PoolSettings{MaxOpenConns: 100, MaxIdleConns: 10, ConnMaxLifetime: "1h0m0s", ConnMaxIdleTime: "10m0s"}, pool.Settings)
+	assert.Equal(t, 1, pool.Open)
+	assert.Equal(t, 1, pool.Idle)
+	assert.Equal(t, "0s", pool.WaitDuration)
+}
@@ -7,14 +7,19 @@ import (
 
 	"github.com/yourusername/go-scaffolding/internal/config"
 	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/startup"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 )
 
-// NewPostgresDB creates a new PostgreSQL database connection using GORM
-func NewPostgresDB(cfg *config.Config, log *logger.Logger) (*gorm.DB, error) {
-	dsn := cfg.Postgres.ConnectionString()
+// NewPostgresDB creates a new PostgreSQL database connection using GORM.
+// Connections authenticate with the current password of creds.
+func NewPostgresDB(cfg *config.Config, creds *Credentials, log *logger.Logger) (*gorm.DB, error) {
+	pool, err := creds.OpenDB(cfg.Postgres)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
 
 	// Configure GORM logger to use our zerolog logger
 	gormLogger := gormlogger.New(
@@ -24,14 +29,24 @@ func NewPostgresDB(cfg *config.Config, log *logger.Logger) (*gorm.DB, error) {
 			LogLevel:                  mapLogLevel(cfg.Postgres.LogLevel),
 			IgnoreRecordNotFoundError: true,
 			Colorful:                  false,
+			// Log statements with placeholders so their values, such as
+			// emails and tokens, stay out of the logs
+			ParameterizedQueries: cfg.Observability.Redaction.PolicyFor(cfg.App.Environment) != logger.PolicyOff,
 		},
 	)
 
 	// Open database connection
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger,
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{
+		Logger:      gormLogger,
+		PrepareStmt: cfg.Postgres.PrepareStmt,
+		// The primary is pinged below; replicas registered later inherit this
+		// config and must not fail startup when they are down
+		DisableAutomaticPing: true,
+		// Times GORM fills in itself, such as CreatedAt, are stored in UTC
+		NowFunc: func() time.Time { return time.Now().UTC() },
 	})
 	if err != nil {
+		_ = pool.Close()
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
@@ -45,12 +60,16 @@ func NewPostgresDB(cfg *config.Config, log *logger.Logger) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(cfg.Postgres.MaxIdleConns)
 	sqlDB.SetMaxOpenConns(cfg.Postgres.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(cfg.Postgres.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.Postgres.ConnMaxIdleTime)
 
-	// Ping database to verify connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := sqlDB.PingContext(ctx); err != nil {
+	// Wait for the database, which may still be starting
+	err = startup.Wait(context.Background(), "postgres", startup.Options{
+		Timeout:        cfg.Startup.WaitTimeout,
+		InitialBackoff: cfg.Startup.InitialBackoff,
+		MaxBackoff:     cfg.Startup.MaxBackoff,
+	}, log, sqlDB.PingContext)
+	if err != nil {
+		_ = sqlDB.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
@@ -0,0 +1,257 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" database/sql driver
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+const (
+	// replicasResolver names the dbresolver configuration holding the
+	// replicas. Statements only use it when Conn asks for it, so everything
+	// else keeps reading from the primary.
+	replicasResolver = "replicas"
+
+	// replicaCheckTimeout bounds each replica ping
+	replicaCheckTimeout = 2 * time.Second
+)
+
+// replicaKey is the context key under which the read routing is stored
+type replicaKey struct{}
+
+// ReadFromReplica marks ctx so reads through Conn may be served by a read
+// replica. Replicas lag behind the primary, so only use it for reads that
+// do not feed a write.
+func ReadFromReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaKey{}, true)
+}
+
+// ReadFromPrimary marks ctx so reads through Conn are served by the
+// primary, overriding ReadFromReplica, e.g. to read back a write
+func ReadFromPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaKey{}, false)
+}
+
+// readsFromReplica reports whether ctx allows reads from a replica
+func readsFromReplica(ctx context.Context) bool {
+	allowed, _ := ctx.Value(replicaKey{}).(bool)
+	return allowed
+}
+
+// ReplicaReads is middleware letting GET and HEAD requests read from
+// replicas. Other requests read from the primary, so a read followed by a
+// write never works from stale data.
+func ReplicaReads() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			c.Request = c.Request.WithContext(ReadFromReplica(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
+
+// Replica is a read replica and its health as of the last check
+type Replica struct {
+	Name string
+
+	db  *sql.DB
+	mu  sync.RWMutex
+	err error
+}
+
+// Err returns the error of the last check, or nil if the replica was reachable
+func (r *Replica) Err() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.err
+}
+
+// Check pings the replica and records the result
+func (r *Replica) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+	defer cancel()
+
+	err := r.db.PingContext(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.err = err
+	return err
+}
+
+// Replicas routes reads to the read replicas that passed their last check,
+// and to the primary while none did. It is the dbresolver policy of the
+// replicas registered on the primary connection.
+type Replicas struct {
+	primary gorm.ConnPool
+	nodes   []*Replica
+	byPool  map[gorm.ConnPool]*Replica
+	log     *logger.Logger
+}
+
+// UseReplicas connects to the replicas in cfg and registers them on db.
+// Replicas that are down do not fail startup; reads skip them until a
+// check succeeds.
+func UseReplicas(db *gorm.DB, cfg config.PostgresConfig, creds *Credentials, log *logger.Logger) (*Replicas, error) {
+	nodes := make([]*Replica, 0, len(cfg.Replicas))
+	for _, replica := range cfg.Replicas {
+		settings := cfg.Replica(replica)
+
+		// Replicas without a password of their own share the rotating
+		// credentials of the primary
+		replicaCreds := creds
+		if settings.Password != cfg.Password || settings.PasswordFile != cfg.PasswordFile {
+			replicaCreds = creds.withPassword(settings.Password)
+		}
+
+		// Opening does not connect, so an unreachable replica is not an error here
+		sqlDB, err := replicaCreds.OpenDB(settings)
+		if err != nil {
+			closeReplicas(nodes)
+			return nil, fmt.Errorf("failed to open replica %s: %w", settings.Host, err)
+		}
+		sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+		sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+		sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
+		name := replica.Name
+		if name == "" {
+			name = fmt.Sprintf("%s:%d", settings.Host, settings.Port)
+		}
+		nodes = append(nodes, &Replica{Name: name, db: sqlDB})
+	}
+
+	replicas, err := useReplicas(db, nodes, func(conn *sql.DB) gorm.Dialector {
+		return postgres.New(postgres.Config{Conn: conn})
+	})
+	if err != nil {
+		closeReplicas(nodes)
+		return nil, err
+	}
+	replicas.log = log
+
+	replicas.Check(context.Background())
+	return replicas, nil
+}
+
+// useReplicas registers nodes on db, opening each with dialector
+func useReplicas(db *gorm.DB, nodes []*Replica, dialector func(*sql.DB) gorm.Dialector) (*Replicas, error) {
+	primary, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	r := &Replicas{
+		primary: primary,
+		nodes:   nodes,
+		byPool:  make(map[gorm.ConnPool]*Replica, len(nodes)),
+	}
+
+	// The primary is listed last so the policy can fall back to it: with a
+	// single replica, dbresolver would not consult the policy at all
+	dialectors := make([]gorm.Dialector, 0, len(nodes)+1)
+	for _, node := range nodes {
+		r.byPool[node.db] = node
+		dialectors = append(dialectors, dialector(node.db))
+	}
+	dialectors = append(dialectors, dialector(primary))
+
+	err = db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: dialectors,
+		Policy:   r,
+	}, replicasResolver))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register replicas: %w", err)
+	}
+
+	return r, nil
+}
+
+// Resolve picks a random replica among those that passed their last
+// check, or the primary when none did
+func (r *Replicas) Resolve(pools []gorm.ConnPool) gorm.ConnPool {
+	healthy := make([]gorm.ConnPool, 0, len(pools))
+	for _, pool := range pools {
+		if node, ok := r.byPool[pool]; ok && node.Err() == nil {
+			healthy = append(healthy, pool)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return r.primary
+	}
+	return healthy[rand.IntN(len(healthy))]
+}
+
+// Nodes returns the replicas. It returns nil when r is nil, i.e. when no
+// replicas are configured.
+func (r *Replicas) Nodes() []*Replica {
+	if r == nil {
+		return nil
+	}
+	return r.nodes
+}
+
+// Check checks every replica, logging those that went down or came back
+func (r *Replicas) Check(ctx context.Context) {
+	for _, node := range r.nodes {
+		before := node.Err()
+		err := node.Check(ctx)
+		if r.log == nil {
+			continue
+		}
+
+		switch {
+		case err != nil && before == nil:
+			r.log.Warn().Err(err).Str("replica", node.Name).Msg("Read replica is down; its reads go to other nodes")
+		case err == nil && before != nil:
+			r.log.Info().Str("replica", node.Name).Msg("Read replica is back")
+		}
+	}
+}
+
+// Monitor checks the replicas every interval until ctx is done
+func (r *Replicas) Monitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.Check(ctx)
+		}
+	}
+}
+
+// Close closes the replica connections
+func (r *Replicas) Close() error {
+	return closeReplicas(r.nodes)
+}
+
+// closeReplicas closes the connections of nodes
+func closeReplicas(nodes []*Replica) error {
+	var errs []error
+	for _, node := range nodes {
+		if err := node.db.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("replica %s: %w", node.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
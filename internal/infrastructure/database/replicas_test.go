@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupReplica returns a primary with a replica registered on it. Both are
+// separate databases holding one record each, so reads show which node
+// served them.
+func setupReplica(t *testing.T) (*gorm.DB, *Replica) {
+	t.Helper()
+
+	db := setupTestDB(t)
+	require.NoError(t, db.Create(&testRecord{Name: "primary"}).Error)
+
+	replicaDB := setupTestDB(t)
+	require.NoError(t, replicaDB.Create(&testRecord{Name: "replica"}).Error)
+	replicaSQL, err := replicaDB.DB()
+	require.NoError(t, err)
+
+	replica := &Replica{Name: "r1", db: replicaSQL}
+	_, err = useReplicas(db, []*Replica{replica}, func(conn *sql.DB) gorm.Dialector {
+		return sqlite.Dialector{Conn: conn}
+	})
+	require.NoError(t, err)
+
+	return db, replica
+}
+
+// readName returns the name of the first record read through Conn
+func readName(t *testing.T, ctx context.Context, db *gorm.DB) string {
+	t.Helper()
+
+	var record testRecord
+	require.NoError(t, Conn(ctx, db).First(&record).Error)
+	return record.Name
+}
+
+func TestConn_ReadsFromPrimaryByDefault(t *testing.T) {
+	db, _ := setupReplica(t)
+
+	assert.Equal(t, "primary", readName(t, context.Background(), db))
+}
+
+func TestConn_ReadsFromReplica(t *testing.T) {
+	db, _ := setupReplica(t)
+
+	assert.Equal(t, "replica", readName(t, ReadFromReplica(context.Background()), db))
+}
+
+func TestConn_ReadFromPrimaryOverridesReplica(t *testing.T) {
+	db, _ := setupReplica(t)
+	ctx := ReadFromPrimary(ReadFromReplica(context.Background()))
+
+	assert.Equal(t, "primary", readName(t, ctx, db))
+}
+
+func TestConn_WritesGoToPrimary(t *testing.T) {
+	db, _ := setupReplica(t)
+	ctx := ReadFromReplica(context.Background())
+
+	require.NoError(t, Conn(ctx, db).Create(&testRecord{Name: "written"}).Error)
+
+	assert.Equal(t, int64(2), countRecords(t, db))
+}
+
+func TestConn_TransactionsStayOnPrimary(t *testing.T) {
+	db, _ := setupReplica(t)
+	transactor := NewTransactor(db)
+
+	err := transactor.WithinTransaction(ReadFromReplica(context.Background()), func(ctx context.Context) error {
+		assert.Equal(t, "primary", readName(t, ctx, db))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestConn_FallsBackToPrimaryWhenReplicaIsDown(t *testing.T) {
+	db, replica := setupReplica(t)
+	replica.err = errors.New("connection refused")
+
+	assert.Equal(t, "primary", readName(t, ReadFromReplica(context.Background()), db))
+}
+
+func TestReplicas_CheckRecordsHealth(t *testing.T) {
+	_, replica := setupReplica(t)
+
+	require.NoError(t, replica.Check(context.Background()))
+	assert.NoError(t, replica.Err())
+
+	require.NoError(t, replica.db.Close())
+	assert.Error(t, replica.Check(context.Background()))
+	assert.Error(t, replica.Err())
+}
+
+func TestReplicas_NodesOfNil(t *testing.T) {
+	var replicas *Replicas
+	assert.Nil(t, replicas.Nodes())
+}
+
+func TestReplicaReads(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{http.MethodGet, true},
+		{http.MethodHead, true},
+		{http.MethodPost, false},
+		{http.MethodPatch, false},
+		{http.MethodDelete, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			var got bool
+			router := gin.New()
+			router.Use(ReplicaReads())
+			router.Handle(tt.method, "/", func(c *gin.Context) {
+				got = readsFromReplica(c.Request.Context())
+			})
+
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, "/", nil))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// Package schemadiff detects drift between the schema of a live database
+// and the schema its migrations build: tables, views and columns missing
+// or unexpected, column types and nullability that differ, and indexes
+// missing, unexpected or defined differently. Constraints other than those
+// backed by indexes, defaults, functions and triggers are not compared.
+package schemadiff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ignoredTables are maintained by the migration tool, not the migrations
+var ignoredTables = map[string]bool{"schema_migrations": true}
+
+// shadowSchema is the schema the migrations are applied to by Expected
+const shadowSchema = "schemadiff_expected"
+
+// Schema is the structure of the tables and views of a database schema
+type Schema struct {
+	Tables map[string]*Table
+}
+
+// Table is a table, view or materialized view
+type Table struct {
+	Name    string
+	Columns map[string]Column
+
+	// Indexes are keyed by name
+	Indexes map[string]Index
+}
+
+// Column is a column of a table
+type Column struct {
+	Name     string
+	Type     string // as formatted by PostgreSQL, such as character varying(255)
+	Nullable bool
+}
+
+// Index is an index of a table
+type Index struct {
+	Name string
+
+	// Definition is the CREATE INDEX statement, without the schema of the
+	// table
+	Definition string
+}
+
+// Kinds of drift
+const (
+	MissingTable     = "missing table"
+	UnexpectedTable  = "unexpected table"
+	MissingColumn    = "missing column"
+	UnexpectedColumn = "unexpected column"
+	TypeMismatch     = "type mismatch"
+	NullMismatch     = "nullability mismatch"
+	MissingIndex     = "missing index"
+	UnexpectedIndex  = "unexpected index"
+	IndexMismatch    = "index mismatch"
+)
+
+// Drift is a difference between the expected and the actual schema
+type Drift struct {
+	Kind   string
+	Table  string
+	Object string // the column or index, empty for tables
+
+	// Expected and Actual describe the object when it differs
+	Expected string
+	Actual   string
+}
+
+// String describes the drift on one line
+func (d Drift) String() string {
+	name := d.Table
+	if d.Object != "" {
+		name += "." + d.Object
+	}
+	if d.Expected == "" && d.Actual == "" {
+		return fmt.Sprintf("%s: %s", name, d.Kind)
+	}
+	return fmt.Sprintf("%s: %s: expected %s, got %s", name, d.Kind, d.Expected, d.Actual)
+}
+
+// Diff returns the drift of actual from expected, ordered by table
+func Diff(expected, actual *Schema) []Drift {
+	var drift []Drift
+	for _, name := range sortedKeys(expected.Tables, actual.Tables) {
+		want, have := expected.Tables[name], actual.Tables[name]
+		switch {
+		case have == nil:
+			drift = append(drift, Drift{Kind: MissingTable, Table: name})
+		case want == nil:
+			drift = append(drift, Drift{Kind: UnexpectedTable, Table: name})
+		default:
+			drift = append(drift, diffColumns(want, have)...)
+			drift = append(drift, diffIndexes(want, have)...)
+		}
+	}
+	return drift
+}
+
+// diffColumns returns the drift of the columns of a table
+func diffColumns(want, have *Table) []Drift {
+	var drift []Drift
+	for _, name := range sortedKeys(want.Columns, have.Columns) {
+		wantCol, wanted := want.Columns[name]
+		haveCol, had := have.Columns[name]
+		switch {
+		case !had:
+			drift = append(drift, Drift{Kind: MissingColumn, Table: want.Name, Object: name})
+		case !wanted:
+			drift = append(drift, Drift{Kind: UnexpectedColumn, Table: want.Name, Object: name})
+		default:
+			if wantCol.Type != haveCol.Type {
+				drift = append(drift, Drift{Kind: TypeMismatch, Table: want.Name, Object: name, Expected: wantCol.Type, Actual: haveCol.Type})
+			}
+			if wantCol.Nullable != haveCol.Nullable {
+				drift = append(drift, Drift{Kind: NullMismatch, Table: want.Name, Object: name, Expected: nullability(wantCol), Actual: nullability(haveCol)})
+			}
+		}
+	}
+	return drift
+}
+
+// diffIndexes returns the drift of the indexes of a table
+func diffIndexes(want, have *Table) []Drift {
+	var drift []Drift
+	for _, name := range sortedKeys(want.Indexes, have.Indexes) {
+		wantIdx, wanted := want.Indexes[name]
+		haveIdx, had := have.Indexes[name]
+		switch {
+		case !had:
+			drift = append(drift, Drift{Kind: MissingIndex, Table: want.Name, Object: name})
+		case !wanted:
+			drift = append(drift, Drift{Kind: UnexpectedIndex, Table: want.Name, Object: name})
+		case wantIdx.Definition != haveIdx.Definition:
+			drift = append(drift, Drift{Kind: IndexMismatch, Table: want.Name, Object: name, Expected: wantIdx.Definition, Actual: haveIdx.Definition})
+		}
+	}
+	return drift
+}
+
+// Inspect returns the tables, views and materialized views of the schema
+// named schemaName, such as public
+func Inspect(ctx context.Context, db *gorm.DB, schemaName string) (*Schema, error) {
+	var columns []struct {
+		TableName  string
+		ColumnName string
+		DataType   string
+		Nullable   bool
+	}
+	err := db.WithContext(ctx).Raw(`
+		SELECT c.relname AS table_name, a.attname AS column_name,
+			format_type(a.atttypid, a.atttypmod) AS data_type, NOT a.attnotnull AS nullable
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = ? AND c.relkind IN ('r', 'p', 'v', 'm') AND a.attnum > 0 AND NOT a.attisdropped`,
+		schemaName).Scan(&columns).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the columns of schema %s: %w", schemaName, err)
+	}
+
+	var indexes []struct {
+		TableName string
+		IndexName string
+		IndexDef  string
+	}
+	err = db.WithContext(ctx).Raw(`
+		SELECT tablename AS table_name, indexname AS index_name, indexdef AS index_def
+		FROM pg_indexes WHERE schemaname = ?`,
+		schemaName).Scan(&indexes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the indexes of schema %s: %w", schemaName, err)
+	}
+
+	schema := &Schema{Tables: map[string]*Table{}}
+	table := func(name string) *Table {
+		t, ok := schema.Tables[name]
+		if !ok {
+			t = &Table{Name: name, Columns: map[string]Column{}, Indexes: map[string]Index{}}
+			schema.Tables[name] = t
+		}
+		return t
+	}
+	for _, c := range columns {
+		if ignoredTables[c.TableName] {
+			continue
+		}
+		table(c.TableName).Columns[c.ColumnName] = Column{Name: c.ColumnName, Type: c.DataType, Nullable: c.Nullable}
+	}
+	for _, i := range indexes {
+		if ignoredTables[i.TableName] {
+			continue
+		}
+		table(i.TableName).Indexes[i.IndexName] = Index{Name: i.IndexName, Definition: unqualify(i.IndexDef, schemaName)}
+	}
+	return schema, nil
+}
+
+// Expected returns the schema built by the *.up.sql migrations of dir,
+// applied in order to an empty schema. They are applied in a transaction
+// that is rolled back, so the database is left unchanged; its user needs
+// the CREATE privilege on it.
+func Expected(ctx context.Context, db *gorm.DB, dir string) (*Schema, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.up.sql"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no migrations in %s", dir)
+	}
+	sort.Strings(files)
+
+	var expected *Schema
+	errRollback := errors.New("rollback")
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Objects the migrations create unqualified go to the shadow schema,
+		// while extensions installed in public stay visible
+		statements := []string{
+			"CREATE SCHEMA " + shadowSchema,
+			"SET LOCAL search_path TO " + shadowSchema + ", public",
+		}
+		for _, statement := range statements {
+			if err := tx.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to create the schema to migrate: %w", err)
+			}
+		}
+
+		for _, file := range files {
+			migration, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if err := tx.Exec(string(migration)).Error; err != nil {
+				return fmt.Errorf("migration %s: %w", filepath.Base(file), err)
+			}
+		}
+
+		expected, err = Inspect(ctx, tx, shadowSchema)
+		if err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		return nil, err
+	}
+	return expected, nil
+}
+
+// unqualify removes the schema from the table of an index definition, as
+// in CREATE INDEX idx ON public.users USING btree (email)
+func unqualify(definition, schemaName string) string {
+	for _, prefix := range []string{" ON ONLY ", " ON "} {
+		definition = strings.Replace(definition, prefix+schemaName+".", prefix, 1)
+		definition = strings.Replace(definition, prefix+`"`+schemaName+`".`, prefix, 1)
+	}
+	return definition
+}
+
+// nullability describes whether a column accepts NULL
+func nullability(c Column) string {
+	if c.Nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
+
+// sortedKeys returns the keys of a and b, sorted and without duplicates
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := slices.Collect(maps.Keys(a))
+	keys = slices.AppendSeq(keys, maps.Keys(b))
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
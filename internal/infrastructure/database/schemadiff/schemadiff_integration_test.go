@@ -0,0 +1,78 @@
+//go:build integration
+
+package schemadiff
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+)
+
+var migrationsDir = filepath.Join("..", "..", "..", "..", "migrations")
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// migrationsTemplate holds the schema built by the migrations, as deployed
+var migrationsTemplate = pgtest.Template{
+	Name: "migrations",
+	Migrate: func(db *gorm.DB) error {
+		files, err := filepath.Glob(filepath.Join(migrationsDir, "*.up.sql"))
+		if err != nil {
+			return err
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			migration, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if err := db.Exec(string(migration)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+func TestExpected_MatchesMigratedDatabase(t *testing.T) {
+	db := pgtest.DB(t, migrationsTemplate)
+	ctx := context.Background()
+
+	expected, err := Expected(ctx, db, migrationsDir)
+	require.NoError(t, err)
+	assert.Contains(t, expected.Tables, "users")
+	assert.Contains(t, expected.Tables, "user_signups_daily", "materialized views are inspected")
+
+	actual, err := Inspect(ctx, db, "public")
+	require.NoError(t, err)
+	assert.Empty(t, Diff(expected, actual))
+
+	t.Run("leaves the database unchanged", func(t *testing.T) {
+		var schemas int64
+		require.NoError(t, db.Raw("SELECT COUNT(*) FROM pg_namespace WHERE nspname = ?", shadowSchema).Scan(&schemas).Error)
+		assert.Zero(t, schemas)
+	})
+
+	t.Run("reports drift", func(t *testing.T) {
+		require.NoError(t, db.Exec("DROP INDEX idx_users_created_at").Error)
+		require.NoError(t, db.Exec("ALTER TABLE users ALTER COLUMN avatar_key TYPE TEXT").Error)
+
+		actual, err := Inspect(ctx, db, "public")
+		require.NoError(t, err)
+
+		drift := Diff(expected, actual)
+		assert.Contains(t, drift, Drift{Kind: MissingIndex, Table: "users", Object: "idx_users_created_at"})
+		assert.Contains(t, drift, Drift{Kind: TypeMismatch, Table: "users", Object: "avatar_key", Expected: "character varying(255)", Actual: "text"})
+	})
+}
@@ -0,0 +1,71 @@
+package schemadiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSchema() *Schema {
+	return &Schema{Tables: map[string]*Table{
+		"users": {
+			Name: "users",
+			Columns: map[string]Column{
+				"id":    {Name: "id", Type: "uuid"},
+				"email": {Name: "email", Type: "text"},
+				"name":  {Name: "name", Type: "character varying(255)"},
+			},
+			Indexes: map[string]Index{
+				"users_pkey":            {Name: "users_pkey", Definition: "CREATE UNIQUE INDEX users_pkey ON users USING btree (id)"},
+				"idx_users_email_lower": {Name: "idx_users_email_lower", Definition: "CREATE UNIQUE INDEX idx_users_email_lower ON users USING btree (lower(email))"},
+			},
+		},
+		"organizations": {
+			Name:    "organizations",
+			Columns: map[string]Column{"id": {Name: "id", Type: "uuid"}},
+			Indexes: map[string]Index{},
+		},
+	}}
+}
+
+func TestDiff_NoDrift(t *testing.T) {
+	assert.Empty(t, Diff(testSchema(), testSchema()))
+}
+
+func TestDiff(t *testing.T) {
+	actual := testSchema()
+	users := actual.Tables["users"]
+	delete(actual.Tables, "organizations")
+	actual.Tables["legacy"] = &Table{Name: "legacy", Columns: map[string]Column{}, Indexes: map[string]Index{}}
+	delete(users.Columns, "name")
+	users.Columns["nickname"] = Column{Name: "nickname", Type: "text", Nullable: true}
+	users.Columns["email"] = Column{Name: "email", Type: "character varying(254)", Nullable: true}
+	delete(users.Indexes, "idx_users_email_lower")
+	users.Indexes["users_pkey"] = Index{Name: "users_pkey", Definition: "CREATE INDEX users_pkey ON users USING btree (id)"}
+	users.Indexes["idx_users_nickname"] = Index{Name: "idx_users_nickname", Definition: "CREATE INDEX idx_users_nickname ON users USING btree (nickname)"}
+
+	var report []string
+	for _, d := range Diff(testSchema(), actual) {
+		report = append(report, d.String())
+	}
+	assert.Equal(t, []string{
+		"legacy: unexpected table",
+		"organizations: missing table",
+		"users.email: type mismatch: expected text, got character varying(254)",
+		"users.email: nullability mismatch: expected NOT NULL, got NULL",
+		"users.name: missing column",
+		"users.nickname: unexpected column",
+		"users.idx_users_email_lower: missing index",
+		"users.idx_users_nickname: unexpected index",
+		"users.users_pkey: index mismatch: expected CREATE UNIQUE INDEX users_pkey ON users USING btree (id), got CREATE INDEX users_pkey ON users USING btree (id)",
+	}, report)
+}
+
+func TestUnqualify(t *testing.T) {
+	assert.Equal(t,
+		"CREATE INDEX idx_users_email ON users USING btree (email)",
+		unqualify("CREATE INDEX idx_users_email ON public.users USING btree (email)", "public"))
+	assert.Equal(t,
+		"CREATE INDEX idx_users_email ON ONLY users USING btree (email)",
+		unqualify(`CREATE INDEX idx_users_email ON ONLY "tenant-a".users USING btree (email)`, "tenant-a"))
+}
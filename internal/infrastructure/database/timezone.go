@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// useUTC runs the sessions of connConfig in UTC, so CURRENT_TIMESTAMP
+// and conversions between timestamp and timestamptz do not depend on the
+// time zone of the database server
+func useUTC(connConfig *pgconn.Config) {
+	connConfig.RuntimeParams["timezone"] = "UTC"
+}
+
+// registerUTCTypes makes conn write times to timestamp columns in UTC and
+// scan timestamptz values in UTC. Left alone, pgx writes the wall clock
+// of a time in whatever zone it carries, and scans timestamptz in the
+// local time zone.
+func registerUTCTypes(_ context.Context, conn *pgx.Conn) error {
+	useUTCTypes(conn.TypeMap())
+	return nil
+}
+
+// useUTCTypes registers the codecs of registerUTCTypes in types
+func useUTCTypes(types *pgtype.Map) {
+	types.RegisterType(&pgtype.Type{
+		Name:  "timestamp",
+		OID:   pgtype.TimestampOID,
+		Codec: utcTimestampCodec{TimestampCodec: &pgtype.TimestampCodec{ScanLocation: time.UTC}},
+	})
+	types.RegisterType(&pgtype.Type{
+		Name:  "timestamptz",
+		OID:   pgtype.TimestamptzOID,
+		Codec: &pgtype.TimestamptzCodec{ScanLocation: time.UTC},
+	})
+}
+
+// utcTimestampCodec is the timestamp codec of pgx, converting times to
+// UTC before it drops their zone
+type utcTimestampCodec struct {
+	*pgtype.TimestampCodec
+}
+
+func (c utcTimestampCodec) PlanEncode(m *pgtype.Map, oid uint32, format int16, value any) pgtype.EncodePlan {
+	next := c.TimestampCodec.PlanEncode(m, oid, format, value)
+	if next == nil {
+		return nil
+	}
+	return utcEncodePlan{next: next}
+}
+
+type utcEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p utcEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	ts, err := value.(pgtype.TimestampValuer).TimestampValue()
+	if err != nil {
+		return nil, err
+	}
+	if ts.Valid && ts.InfinityModifier == pgtype.Finite {
+		ts.Time = ts.Time.UTC()
+	}
+	return p.next.Encode(ts, buf)
+}
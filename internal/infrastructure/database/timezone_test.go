@@ -0,0 +1,48 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseUTCTypes_Timestamp(t *testing.T) {
+	types := pgtype.NewMap()
+	useUTCTypes(types)
+	tokyo := time.FixedZone("JST", 9*60*60)
+
+	for _, value := range []any{
+		time.Date(2025, 1, 1, 21, 0, 0, 0, tokyo),
+		pgtype.Timestamp{Time: time.Date(2025, 1, 1, 21, 0, 0, 0, tokyo), Valid: true},
+	} {
+		text, err := types.Encode(pgtype.TimestampOID, pgtype.TextFormatCode, value, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "2025-01-01 12:00:00", string(text), "the wall clock in UTC, not in Tokyo")
+
+		binary, err := types.Encode(pgtype.TimestampOID, pgtype.BinaryFormatCode, value, nil)
+		require.NoError(t, err)
+		var scanned time.Time
+		require.NoError(t, types.Scan(pgtype.TimestampOID, pgtype.BinaryFormatCode, binary, &scanned))
+		assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), scanned)
+	}
+
+	text, err := types.Encode(pgtype.TimestampOID, pgtype.TextFormatCode, pgtype.Timestamp{InfinityModifier: pgtype.Infinity, Valid: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "infinity", string(text))
+
+	text, err = types.Encode(pgtype.TimestampOID, pgtype.TextFormatCode, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, text)
+}
+
+func TestUseUTCTypes_Timestamptz(t *testing.T) {
+	types := pgtype.NewMap()
+	useUTCTypes(types)
+
+	var scanned time.Time
+	require.NoError(t, types.Scan(pgtype.TimestamptzOID, pgtype.TextFormatCode, []byte("2025-01-01 21:00:00+09"), &scanned))
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), scanned)
+}
@@ -0,0 +1,105 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// txKey is the context key under which the active transaction is stored
+type txKey struct{}
+
+// afterCommitKey is the context key under which the functions to run once
+// the active transaction commits are stored
+type afterCommitKey struct{}
+
+// Transactor runs functions inside a database transaction that repositories
+// pick up from the context, so work spanning several repositories, or
+// features, commits or rolls back as a whole
+type Transactor struct {
+	db *gorm.DB
+}
+
+// NewTransactor creates a new Transactor
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn in a transaction carried by the context passed to
+// fn. The transaction is rolled back if fn returns an error or panics.
+// Calls nested in an active transaction join it.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	var afterCommit []func()
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(ctx, txKey{}, tx)
+		return fn(context.WithValue(ctx, afterCommitKey{}, &afterCommit))
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range afterCommit {
+		hook()
+	}
+	return nil
+}
+
+// InTransaction reports whether ctx carries a transaction started by
+// WithinTransaction
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
+}
+
+// AfterCommit runs fn once the transaction stored in ctx by
+// WithinTransaction commits, or at once when there is none. fn is dropped
+// when the transaction rolls back. Use it for side effects, such as
+// notifications, that must not announce changes that were never stored.
+func AfterCommit(ctx context.Context, fn func()) {
+	if hooks, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		*hooks = append(*hooks, fn)
+		return
+	}
+	fn()
+}
+
+// Conn returns the transaction stored in ctx by WithinTransaction, or db when
+// there is none. Repositories use it for every query so they take part in
+// transactions started by services. Outside a transaction, reads go to a
+// replica when ctx allows it; see ReadFromReplica.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	if readsFromReplica(ctx) {
+		return db.WithContext(ctx).Clauses(dbresolver.Use(replicasResolver))
+	}
+	return db.WithContext(ctx)
+}
+
+// SQLTx returns the *sql.Tx behind the transaction stored in ctx by
+// WithinTransaction. Repositories that run SQL through database/sql instead
+// of GORM use it to take part in the same transactions.
+func SQLTx(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	if !ok {
+		return nil, false
+	}
+
+	pool := tx.Statement.ConnPool
+	// With PrepareStmt, GORM wraps the transaction to cache its statements
+	if prepared, ok := pool.(*gorm.PreparedStmtTX); ok {
+		pool = prepared.Tx
+	}
+
+	sqlTx, ok := pool.(*sql.Tx)
+	return sqlTx, ok
+}
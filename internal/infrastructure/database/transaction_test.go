@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type testRecord struct {
+	ID   uint
+	Name string
+}
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	return setupTestDBWithConfig(t, &gorm.Config{})
+}
+
+func setupTestDBWithConfig(t *testing.T, config *gorm.Config) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), config)
+	require.NoError(t, err)
+
+	// Each connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(t, db.AutoMigrate(&testRecord{}))
+	return db
+}
+
+func countRecords(t *testing.T, db *gorm.DB) int64 {
+	t.Helper()
+
+	var count int64
+	require.NoError(t, db.Model(&testRecord{}).Count(&count).Error)
+	return count
+}
+
+func TestTransactor_Commit(t *testing.T) {
+	db := setupTestDB(t)
+	transactor := NewTransactor(db)
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		return Conn(ctx, db).Create(&testRecord{Name: "a"}).Error
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), countRecords(t, db))
+}
+
+func TestTransactor_Rollback(t *testing.T) {
+	db := setupTestDB(t)
+	transactor := NewTransactor(db)
+	failure := errors.New("second step failed")
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		if err := Conn(ctx, db).Create(&testRecord{Name: "a"}).Error; err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	assert.Equal(t, int64(0), countRecords(t, db))
+}
+
+func TestTransactor_NestedCallsJoin(t *testing.T) {
+	db := setupTestDB(t)
+	transactor := NewTransactor(db)
+	failure := errors.New("outer step failed")
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		err := transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			return Conn(ctx, db).Create(&testRecord{Name: "inner"}).Error
+		})
+		if err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	// The inner work belonged to the outer transaction and was rolled back with it
+	assert.Equal(t, int64(0), countRecords(t, db))
+}
+
+func TestInTransaction(t *testing.T) {
+	transactor := NewTransactor(setupTestDB(t))
+
+	assert.False(t, InTransaction(context.Background()))
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		assert.True(t, InTransaction(ctx))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestAfterCommit(t *testing.T) {
+	db := setupTestDB(t)
+	transactor := NewTransactor(db)
+
+	t.Run("runs once the transaction commits", func(t *testing.T) {
+		var ran []string
+		err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func() { ran = append(ran, "outer") })
+			return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+				AfterCommit(ctx, func() { ran = append(ran, "inner") })
+				assert.Empty(t, ran, "nothing runs before the commit")
+				return nil
+			})
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"outer", "inner"}, ran)
+	})
+
+	t.Run("is dropped when the transaction rolls back", func(t *testing.T) {
+		ran := false
+		err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+			AfterCommit(ctx, func() { ran = true })
+			return errors.New("failed")
+		})
+		require.Error(t, err)
+		assert.False(t, ran)
+	})
+
+	t.Run("runs at once without a transaction", func(t *testing.T) {
+		ran := false
+		AfterCommit(context.Background(), func() { ran = true })
+		assert.True(t, ran)
+	})
+}
+
+func TestConn_WithoutTransaction(t *testing.T) {
+	db := setupTestDB(t)
+
+	require.NoError(t, Conn(context.Background(), db).Create(&testRecord{Name: "a"}).Error)
+	assert.Equal(t, int64(1), countRecords(t, db))
+}
+
+func TestSQLTx_JoinsTransaction(t *testing.T) {
+	db := setupTestDB(t)
+	transactor := NewTransactor(db)
+	failure := errors.New("second step failed")
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		tx, ok := SQLTx(ctx)
+		require.True(t, ok)
+
+		if _, err := tx.ExecContext(ctx, "INSERT INTO test_records (name) VALUES (?)", "a"); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	// The statement ran in the GORM transaction and was rolled back with it
+	assert.Equal(t, int64(0), countRecords(t, db))
+}
+
+func TestSQLTx_JoinsPreparedStatementTransaction(t *testing.T) {
+	db := setupTestDBWithConfig(t, &gorm.Config{PrepareStmt: true})
+	transactor := NewTransactor(db)
+	failure := errors.New("second step failed")
+
+	err := transactor.WithinTransaction(context.Background(), func(ctx context.Context) error {
+		tx, ok := SQLTx(ctx)
+		require.True(t, ok)
+
+		if _, err := tx.ExecContext(ctx, "INSERT INTO test_records (name) VALUES (?)", "a"); err != nil {
+			return err
+		}
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	assert.Equal(t, int64(0), countRecords(t, db))
+}
+
+func TestSQLTx_WithoutTransaction(t *testing.T) {
+	_, ok := SQLTx(context.Background())
+	assert.False(t, ok)
+}
@@ -0,0 +1,95 @@
+// Package utcshift rewrites the timestamp without time zone columns of a
+// database from the wall clock of a time zone to the wall clock of UTC.
+// Such columns hold no zone, and were written in whatever zone the server
+// ran in before the service stored every time in UTC; shifting them once
+// makes the old rows agree with the new ones.
+package utcshift
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+// Column is a timestamp without time zone column of a table
+type Column struct {
+	Table string
+	Name  string
+}
+
+// Columns returns the timestamp without time zone columns of the tables
+// of schema, by table and then column name. Partitions are left to their
+// partitioned table, and views hold no data of their own.
+func Columns(ctx context.Context, db *gorm.DB, schema string) ([]Column, error) {
+	var columns []Column
+	err := db.WithContext(ctx).Raw(`
+		SELECT c.relname AS "table", a.attname AS name
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = ? AND c.relkind IN ('r', 'p') AND NOT c.relispartition
+			AND a.attnum > 0 AND NOT a.attisdropped
+			AND a.atttypid = 'timestamp without time zone'::regtype
+		ORDER BY c.relname, a.attname`,
+		schema).Scan(&columns).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the timestamp columns of schema %s: %w", schema, err)
+	}
+	return columns, nil
+}
+
+// Result is the number of rows shifted in a table
+type Result struct {
+	Table string
+	Rows  int64
+}
+
+// Shift rewrites the columns, written as wall clocks in the time zone
+// from, as wall clocks in UTC, all in one transaction. The user triggers
+// of the tables are disabled meanwhile, so that the rewrite does not touch
+// columns such as updated_at. It must run once, before the service writes
+// UTC: rows it shifts twice are wrong by the offset of from.
+func Shift(ctx context.Context, db *gorm.DB, schema, from string, columns []Column) ([]Result, error) {
+	byTable := map[string][]string{}
+	for _, c := range columns {
+		byTable[c.Table] = append(byTable[c.Table], c.Name)
+	}
+
+	var results []Result
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range slices.Sorted(maps.Keys(byTable)) {
+			name := pgx.Identifier{schema, table}.Sanitize()
+
+			sets := make([]string, 0, len(byTable[table]))
+			args := make([]any, 0, len(byTable[table]))
+			for _, column := range byTable[table] {
+				quoted := pgx.Identifier{column}.Sanitize()
+				sets = append(sets, fmt.Sprintf("%s = (%s AT TIME ZONE ?) AT TIME ZONE 'UTC'", quoted, quoted))
+				args = append(args, from)
+			}
+
+			if err := tx.Exec("ALTER TABLE " + name + " DISABLE TRIGGER USER").Error; err != nil {
+				return fmt.Errorf("failed to disable the triggers of %s: %w", table, err)
+			}
+			update := tx.Exec("UPDATE "+name+" SET "+strings.Join(sets, ", "), args...)
+			if update.Error != nil {
+				return fmt.Errorf("failed to shift %s: %w", table, update.Error)
+			}
+			if err := tx.Exec("ALTER TABLE " + name + " ENABLE TRIGGER USER").Error; err != nil {
+				return fmt.Errorf("failed to enable the triggers of %s: %w", table, err)
+			}
+
+			results = append(results, Result{Table: table, Rows: update.RowsAffected})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
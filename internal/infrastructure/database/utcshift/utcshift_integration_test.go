@@ -0,0 +1,112 @@
+//go:build integration
+
+package utcshift
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// localTemplate has rows written in the local time of Tokyo, with a
+// trigger bumping updated_at like that of the users table
+var localTemplate = pgtest.Template{
+	Name: "utcshift",
+	Migrate: func(db *gorm.DB) error {
+		return db.Exec(`
+			CREATE TABLE accounts (
+				id INT PRIMARY KEY,
+				created_at TIMESTAMP NOT NULL,
+				updated_at TIMESTAMP NOT NULL,
+				deleted_at TIMESTAMP,
+				expires_at TIMESTAMPTZ NOT NULL
+			);
+			CREATE FUNCTION touch() RETURNS TRIGGER AS $$
+			BEGIN
+				NEW.updated_at = CURRENT_TIMESTAMP;
+				RETURN NEW;
+			END;
+			$$ LANGUAGE plpgsql;
+			CREATE TRIGGER accounts_touch BEFORE UPDATE ON accounts
+				FOR EACH ROW EXECUTE FUNCTION touch();
+			INSERT INTO accounts VALUES
+				(1, '2025-01-01 21:00:00', '2025-01-02 09:30:00', NULL, '2025-01-01 12:00:00+00');
+
+			CREATE TABLE visits (at TIMESTAMP NOT NULL) PARTITION BY RANGE (at);
+			CREATE TABLE visits_2025 PARTITION OF visits FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');
+			INSERT INTO visits VALUES ('2025-06-01 09:00:00');
+
+			CREATE VIEW recent_accounts AS SELECT * FROM accounts;`).Error
+	},
+}
+
+func TestColumns(t *testing.T) {
+	db := pgtest.DB(t, localTemplate)
+
+	columns, err := Columns(context.Background(), db, "public")
+	require.NoError(t, err)
+	assert.Equal(t, []Column{
+		{Table: "accounts", Name: "created_at"},
+		{Table: "accounts", Name: "deleted_at"},
+		{Table: "accounts", Name: "updated_at"},
+		{Table: "visits", Name: "at"},
+	}, columns, "timestamptz columns, partitions and views are left out")
+}
+
+func TestShift(t *testing.T) {
+	db := pgtest.DB(t, localTemplate)
+	ctx := context.Background()
+
+	columns, err := Columns(ctx, db, "public")
+	require.NoError(t, err)
+	results, err := Shift(ctx, db, "public", "Asia/Tokyo", columns)
+	require.NoError(t, err)
+	assert.Equal(t, []Result{{Table: "accounts", Rows: 1}, {Table: "visits", Rows: 1}}, results)
+
+	var account struct {
+		CreatedAt string
+		UpdatedAt string
+		DeletedAt *string
+		ExpiresAt time.Time
+	}
+	require.NoError(t, db.Raw(`
+		SELECT created_at::text AS created_at, updated_at::text AS updated_at,
+			deleted_at::text AS deleted_at, expires_at
+		FROM accounts WHERE id = 1`).Scan(&account).Error)
+	assert.Equal(t, "2025-01-01 12:00:00", account.CreatedAt)
+	assert.Equal(t, "2025-01-02 00:30:00", account.UpdatedAt, "the trigger does not run")
+	assert.Nil(t, account.DeletedAt)
+	assert.True(t, account.ExpiresAt.Equal(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)), "timestamptz is left alone")
+
+	var visit string
+	require.NoError(t, db.Raw("SELECT at::text FROM visits").Scan(&visit).Error)
+	assert.Equal(t, "2025-06-01 00:00:00", visit, "partitions are shifted once")
+
+	var enabled bool
+	require.NoError(t, db.Raw(`
+		SELECT tgenabled <> 'D' FROM pg_trigger WHERE tgname = 'accounts_touch'`).Scan(&enabled).Error)
+	assert.True(t, enabled, "the triggers are enabled again")
+}
+
+func TestShift_UnknownZone(t *testing.T) {
+	db := pgtest.DB(t, localTemplate)
+	ctx := context.Background()
+
+	_, err := Shift(ctx, db, "public", "Mars/Olympus_Mons", []Column{{Table: "accounts", Name: "created_at"}})
+	require.Error(t, err)
+
+	var createdAt string
+	require.NoError(t, db.Raw("SELECT created_at::text FROM accounts WHERE id = 1").Scan(&createdAt).Error)
+	assert.Equal(t, "2025-01-01 21:00:00", createdAt, "nothing is shifted")
+}
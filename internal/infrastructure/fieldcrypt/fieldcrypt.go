@@ -0,0 +1,204 @@
+// Package fieldcrypt encrypts individual database columns holding personal
+// data with AES-256-GCM, and derives blind indexes so encrypted values can
+// still be looked up by equality.
+//
+// Encrypted values are stored as text:
+//
+//	enc:v1:<key id>:<base64 of nonce and ciphertext>
+//
+// The key ID names the key a value was encrypted with, so a keyring holding
+// the new key and the old ones reads every value while they are
+// re-encrypted. The column name is authenticated with each value, so a
+// value copied into another column fails to decrypt. Values without the
+// prefix are read as plaintext, which lets encryption be turned on for a
+// table that already holds data.
+package fieldcrypt
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// prefix marks encrypted values and the version of their format
+const prefix = "enc:v1:"
+
+// keySize is the size of AES-256 and blind index keys, in bytes
+const keySize = 32
+
+var (
+	// ErrUnknownKey indicates a value was encrypted with a key the keyring lacks
+	ErrUnknownKey = errors.New("value encrypted with an unknown key")
+
+	// ErrMalformedValue indicates a value has the prefix but cannot be decrypted
+	ErrMalformedValue = errors.New("malformed encrypted value")
+)
+
+// Keyring holds the keys of encrypted columns: the encryption keys by ID,
+// the first of which encrypts new values, and the key of blind indexes
+type Keyring struct {
+	primary string
+	aeads   map[string]cipher.AEAD
+	index   []byte
+}
+
+// NewKeyring parses keys and indexKey. keys lists "<id>:<base64 key>"
+// entries separated by commas or whitespace; the first one is the primary
+// key. Every key, including indexKey, is 32 random bytes in base64, such as
+// the output of `openssl rand -base64 32`.
+func NewKeyring(keys, indexKey string) (*Keyring, error) {
+	k := &Keyring{aeads: make(map[string]cipher.AEAD)}
+
+	entries := strings.FieldsFunc(keys, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	if len(entries) == 0 {
+		return nil, errors.New("no encryption keys configured")
+	}
+
+	for _, entry := range entries {
+		id, encoded, ok := strings.Cut(entry, ":")
+		if !ok || id == "" || strings.ContainsAny(id, ":") {
+			return nil, fmt.Errorf("encryption key %q is not <id>:<base64 key>", entry)
+		}
+		if _, dup := k.aeads[id]; dup {
+			return nil, fmt.Errorf("duplicate encryption key ID %q", id)
+		}
+
+		key, err := decodeKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %w", id, err)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		k.aeads[id] = aead
+		if k.primary == "" {
+			k.primary = id
+		}
+	}
+
+	index, err := decodeKey(indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("blind index key: %w", err)
+	}
+	k.index = index
+
+	return k, nil
+}
+
+// decodeKey decodes a base64 key of keySize bytes
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, errors.New("key is not valid base64")
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", keySize, len(key))
+	}
+	return key, nil
+}
+
+// PrimaryKeyID returns the ID of the key new values are encrypted with
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primary
+}
+
+// Encrypt encrypts plaintext for the column with the primary key
+func (k *Keyring) Encrypt(column, plaintext string) (string, error) {
+	aead := k.aeads[k.primary]
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(column))
+
+	return prefix + k.primary + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of a value read from the column. Values
+// that are not encrypted are returned unchanged.
+func (k *Keyring) Decrypt(column, value string) (string, error) {
+	id, sealed, ok := parse(value)
+	if !ok {
+		return value, nil
+	}
+
+	aead, found := k.aeads[id]
+	if !found {
+		return "", fmt.Errorf("%w %q in column %s", ErrUnknownKey, id, column)
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(sealed)
+	if err != nil || len(data) < aead.NonceSize() {
+		return "", fmt.Errorf("%w in column %s", ErrMalformedValue, column)
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("%w in column %s", ErrMalformedValue, column)
+	}
+	return string(plaintext), nil
+}
+
+// IsCurrent reports whether value is encrypted with the primary key, so
+// re-encrypting it would change nothing
+func (k *Keyring) IsCurrent(value string) bool {
+	id, _, ok := parse(value)
+	return ok && id == k.primary
+}
+
+// IsEncrypted reports whether value is an encrypted value
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// parse splits an encrypted value into its key ID and sealed data
+func parse(value string) (id, sealed string, ok bool) {
+	rest, found := strings.CutPrefix(value, prefix)
+	if !found {
+		return "", "", false
+	}
+	return strings.Cut(rest, ":")
+}
+
+// BlindIndex returns the HMAC-SHA256 of value in hex. Equal values have
+// equal indexes, so a unique index and equality lookups work on the index
+// column. Callers normalize value first, e.g. by lowercasing an email.
+func (k *Keyring) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, k.index)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type keyringKey struct{}
+
+// WithKeyring returns a context whose GORM statements encrypt and decrypt
+// the columns tagged with the encrypted serializer using k. A nil k leaves
+// values unchanged.
+func WithKeyring(ctx context.Context, k *Keyring) context.Context {
+	if k == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, keyringKey{}, k)
+}
+
+// FromContext returns the keyring of ctx, or nil
+func FromContext(ctx context.Context) *Keyring {
+	k, _ := ctx.Value(keyringKey{}).(*Keyring)
+	return k
+}
@@ -0,0 +1,166 @@
+package fieldcrypt
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// testKey returns a base64 key of keySize bytes filled with b
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), keySize)))
+}
+
+func newTestKeyring(t *testing.T, keys string) *Keyring {
+	t.Helper()
+
+	k, err := NewKeyring(keys, testKey('i'))
+	require.NoError(t, err)
+	return k
+}
+
+func TestNewKeyring(t *testing.T) {
+	k := newTestKeyring(t, "2024:"+testKey('a')+", 2023:"+testKey('b'))
+	assert.Equal(t, "2024", k.PrimaryKeyID())
+
+	for _, tc := range []struct{ name, keys, index string }{
+		{"no keys", "", testKey('i')},
+		{"missing ID", testKey('a'), testKey('i')},
+		{"short key", "k1:" + base64.StdEncoding.EncodeToString([]byte("short")), testKey('i')},
+		{"invalid base64", "k1:not-base64!", testKey('i')},
+		{"duplicate ID", "k1:" + testKey('a') + " k1:" + testKey('b'), testKey('i')},
+		{"no index key", "k1:" + testKey('a'), ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewKeyring(tc.keys, tc.index)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	k := newTestKeyring(t, "k1:"+testKey('a'))
+
+	encrypted, err := k.Encrypt("email", "jane@example.com")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:k1:"), encrypted)
+	assert.NotContains(t, encrypted, "jane")
+
+	other, err := k.Encrypt("email", "jane@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, other, "every value has its own nonce")
+
+	plaintext, err := k.Decrypt("email", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", plaintext)
+
+	_, err = k.Decrypt("name", encrypted)
+	assert.ErrorIs(t, err, ErrMalformedValue, "values are bound to their column")
+
+	plaintext, err = k.Decrypt("email", "legacy@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "legacy@example.com", plaintext, "unencrypted values are read as is")
+
+	_, err = k.Decrypt("email", "enc:v1:k1:AAAA")
+	assert.ErrorIs(t, err, ErrMalformedValue)
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old := newTestKeyring(t, "k1:"+testKey('a'))
+	encrypted, err := old.Encrypt("email", "jane@example.com")
+	require.NoError(t, err)
+
+	rotated := newTestKeyring(t, "k2:"+testKey('b')+" k1:"+testKey('a'))
+	assert.False(t, rotated.IsCurrent(encrypted))
+	plaintext, err := rotated.Decrypt("email", encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", plaintext)
+
+	reencrypted, err := rotated.Encrypt("email", plaintext)
+	require.NoError(t, err)
+	assert.True(t, rotated.IsCurrent(reencrypted))
+
+	retired := newTestKeyring(t, "k2:"+testKey('b'))
+	_, err = retired.Decrypt("email", encrypted)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyring_BlindIndex(t *testing.T) {
+	k := newTestKeyring(t, "k1:"+testKey('a'))
+	rotated := newTestKeyring(t, "k2:"+testKey('b'))
+
+	assert.Equal(t, k.BlindIndex("jane@example.com"), k.BlindIndex("jane@example.com"))
+	assert.NotEqual(t, k.BlindIndex("jane@example.com"), k.BlindIndex("john@example.com"))
+	assert.Equal(t, k.BlindIndex("jane@example.com"), rotated.BlindIndex("jane@example.com"), "the index key is independent of the encryption keys")
+	assert.Len(t, k.BlindIndex("jane@example.com"), 64)
+}
+
+type record struct {
+	ID      uint              `gorm:"primaryKey"`
+	Email   string            `gorm:"serializer:encrypted"`
+	Pending *string           `gorm:"serializer:encrypted"`
+	Data    map[string]string `gorm:"serializer:encrypted"`
+}
+
+func TestSerializer(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&record{}))
+
+	k := newTestKeyring(t, "k1:"+testKey('a'))
+	ctx := WithKeyring(context.Background(), k)
+	pending := "new@example.com"
+
+	in := record{Email: "jane@example.com", Pending: &pending, Data: map[string]string{"name": "Jane"}}
+	require.NoError(t, db.WithContext(ctx).Create(&in).Error)
+
+	var raw struct {
+		Email   string
+		Pending *string
+		Data    string
+	}
+	require.NoError(t, db.Table("records").Where("id = ?", in.ID).Take(&raw).Error)
+	assert.True(t, IsEncrypted(raw.Email), raw.Email)
+	assert.True(t, IsEncrypted(*raw.Pending), *raw.Pending)
+	assert.True(t, strings.HasPrefix(raw.Data, `"enc:v1:`), raw.Data)
+
+	var out record
+	require.NoError(t, db.WithContext(ctx).First(&out, in.ID).Error)
+	assert.Equal(t, in, out)
+
+	t.Run("nil values stay NULL", func(t *testing.T) {
+		empty := record{Email: "john@example.com"}
+		require.NoError(t, db.WithContext(ctx).Create(&empty).Error)
+
+		var out record
+		require.NoError(t, db.WithContext(ctx).First(&out, empty.ID).Error)
+		assert.Nil(t, out.Pending)
+		assert.Nil(t, out.Data)
+	})
+
+	t.Run("without a keyring values are stored as is", func(t *testing.T) {
+		plain := record{Email: "plain@example.com", Data: map[string]string{"name": "Plain"}}
+		require.NoError(t, db.Create(&plain).Error)
+
+		var raw struct{ Email, Data string }
+		require.NoError(t, db.Table("records").Where("id = ?", plain.ID).Take(&raw).Error)
+		assert.Equal(t, "plain@example.com", raw.Email)
+		assert.JSONEq(t, `{"name":"Plain"}`, raw.Data)
+
+		var out record
+		require.NoError(t, db.WithContext(ctx).First(&out, plain.ID).Error)
+		assert.Equal(t, plain, out, "plaintext rows are read with a keyring too")
+	})
+
+	t.Run("reading encrypted values needs the keyring", func(t *testing.T) {
+		var out record
+		err := db.First(&out, in.ID).Error
+		assert.ErrorContains(t, err, "no keyring")
+	})
+}
@@ -0,0 +1,139 @@
+package fieldcrypt
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+)
+
+// SerializerName is the name models refer to in their tags:
+//
+//	Email string `gorm:"serializer:encrypted"`
+const SerializerName = "encrypted"
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// Serializer is a GORM serializer that encrypts and decrypts with the
+// keyring of the statement's context, set with WithKeyring, and stores
+// values unchanged when there is none. Reading an encrypted value without
+// a keyring fails instead of returning ciphertext.
+//
+// string and *string fields are stored as text. Fields of other types are
+// stored as JSON like with the json serializer, and once encrypted as a
+// JSON string holding the ciphertext, so they fit JSON columns either way.
+type Serializer struct{}
+
+// Scan implements schema.SerializerInterface
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	target := field.ReflectValueOf(ctx, dst)
+	if dbValue == nil {
+		target.Set(reflect.Zero(field.FieldType))
+		return nil
+	}
+
+	var value string
+	switch v := dbValue.(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("cannot decrypt %T in column %s", dbValue, field.DBName)
+	}
+
+	if !isText(field) {
+		var encrypted string
+		if json.Unmarshal([]byte(value), &encrypted) == nil && IsEncrypted(encrypted) {
+			plaintext, err := decrypt(ctx, field, encrypted)
+			if err != nil {
+				return err
+			}
+			value = plaintext
+		}
+
+		decoded := reflect.New(field.FieldType)
+		if err := json.Unmarshal([]byte(value), decoded.Interface()); err != nil {
+			return fmt.Errorf("failed to decode column %s: %w", field.DBName, err)
+		}
+		target.Set(decoded.Elem())
+		return nil
+	}
+
+	if IsEncrypted(value) {
+		plaintext, err := decrypt(ctx, field, value)
+		if err != nil {
+			return err
+		}
+		value = plaintext
+	}
+
+	if field.FieldType.Kind() == reflect.Ptr {
+		target.Set(reflect.ValueOf(&value))
+	} else {
+		target.SetString(value)
+	}
+	return nil
+}
+
+// Value implements schema.SerializerValuerInterface
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	keys := FromContext(ctx)
+
+	if !isText(field) {
+		encoded, err := json.Marshal(fieldValue)
+		if err != nil || string(encoded) == "null" {
+			return nil, err
+		}
+		if keys == nil {
+			return string(encoded), nil
+		}
+
+		ciphertext, err := keys.Encrypt(field.DBName, string(encoded))
+		if err != nil {
+			return nil, err
+		}
+		encoded, err = json.Marshal(ciphertext)
+		return string(encoded), err
+	}
+
+	var value string
+	switch v := fieldValue.(type) {
+	case string:
+		value = v
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		value = *v
+	default:
+		return nil, fmt.Errorf("cannot encrypt %T in column %s", fieldValue, field.DBName)
+	}
+
+	if keys == nil {
+		return value, nil
+	}
+	return keys.Encrypt(field.DBName, value)
+}
+
+// isText reports whether field is a string or *string, stored as text
+func isText(field *schema.Field) bool {
+	t := field.FieldType
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.String
+}
+
+// decrypt decrypts a value of field with the keyring of ctx
+func decrypt(ctx context.Context, field *schema.Field, value string) (string, error) {
+	keys := FromContext(ctx)
+	if keys == nil {
+		return "", fmt.Errorf("column %s is encrypted but no keyring is configured", field.DBName)
+	}
+	return keys.Decrypt(field.DBName, value)
+}
@@ -0,0 +1,135 @@
+// Package fixture records requests and their responses to disk and replays
+// them as a stub server. Each fixture is a file holding a capture.Entry,
+// redacted like captures are, so captures read from the admin routes can
+// be replayed as well. Recording reproduces a bug report against a stub;
+// replaying gives frontend development a deterministic API without a
+// database.
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/capture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// unsafeName matches the characters of a path left out of file names
+var unsafeName = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// maxNameLength bounds the part of file names taken from the path
+const maxNameLength = 60
+
+// Recorder writes a fixture for each request
+type Recorder struct {
+	dir         string
+	tapper      *capture.Capturer
+	exemptPaths []string
+	log         *logger.Logger
+}
+
+// NewRecorder creates a recorder writing to dir, which is created if
+// missing. tapper redacts the entries and cuts their bodies; requests for
+// paths under exemptPaths, such as the admin routes, are not recorded.
+func NewRecorder(dir string, tapper *capture.Capturer, exemptPaths []string, log *logger.Logger) (*Recorder, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+	return &Recorder{dir: dir, tapper: tapper, exemptPaths: exemptPaths, log: log}, nil
+}
+
+// Middleware records every request but streams and exempt paths. Fixtures
+// that cannot be written are logged; the request is not failed.
+func (r *Recorder) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if request.IsStreaming(c.Request) || r.exempt(c.Request.URL.Path) {
+			c.Next()
+			return
+		}
+
+		entry := r.tapper.Tap(c)
+		if err := Save(r.dir, entry); err != nil {
+			r.log.Warn().Ctx(c.Request.Context()).Err(err).Msg("Failed to record fixture")
+		}
+	}
+}
+
+// exempt reports whether requests for path are not recorded
+func (r *Recorder) exempt(path string) bool {
+	for _, prefix := range r.exemptPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Save writes an entry to dir, in a file named after its time, method and
+// path, so a directory listing reads in recording order
+func Save(dir string, entry capture.Entry) error {
+	path, _, _ := strings.Cut(entry.Path, "?")
+	name := strings.Trim(unsafeName.ReplaceAllString(path, "_"), "_")
+	if len(name) > maxNameLength {
+		name = name[:maxNameLength]
+	}
+	id := entry.ID
+	if len(id) > 8 {
+		id = id[:8]
+	}
+	file := fmt.Sprintf("%s-%s-%s-%s.json", entry.Time.UTC().Format("20060102T150405.000000"), entry.Method, name, id)
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, file), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// Load reads the fixtures of dir, sorted by time. Files hold an entry, or
+// a list of them as GET /admin/debug/captures returns.
+func Load(dir string) ([]capture.Entry, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fixtures: %w", err)
+	}
+
+	var entries []capture.Entry
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read fixture: %w", err)
+		}
+
+		var file struct {
+			capture.Entry
+			Captures []capture.Entry `json:"captures"`
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("%s: invalid fixture: %w", path, err)
+		}
+		switch {
+		case file.Captures != nil:
+			entries = append(entries, file.Captures...)
+		case file.Method != "":
+			entries = append(entries, file.Entry)
+		default:
+			return nil, fmt.Errorf("%s: not a fixture or a list of captures", path)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
@@ -0,0 +1,101 @@
+package fixture
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/capture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// newRecorder records to a temporary directory behind a router with user
+// and admin routes
+func newRecorder(t *testing.T) (*gin.Engine, string) {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "fixtures")
+	log := logger.New("info", io.Discard)
+	tapper := capture.New(capture.Options{MaxBodyBytes: 1024}, logger.NewRedactor(logger.PolicyStrict, []string{"password"}), log, clock.NewFake(testNow))
+	recorder, err := NewRecorder(dir, tapper, []string{"/admin"}, log)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(recorder.Middleware())
+	router.POST("/users", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"id": "42", "name": "Ada"})
+	})
+	router.GET("/admin/stats", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"users": 1})
+	})
+	return router, dir
+}
+
+func TestRecorder_WritesFixtures(t *testing.T) {
+	router, dir := newRecorder(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/users?source=web", strings.NewReader(`{"name":"Ada","password":"hunter2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/admin/stats", nil))
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Len(t, paths, 1, "exempt paths are not recorded")
+	assert.True(t, strings.HasPrefix(filepath.Base(paths[0]), "20260301T120000.000000-POST-users-"), paths[0])
+
+	entries, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "/users?source=web", entry.Path)
+	assert.Equal(t, "/users", entry.Route)
+	assert.Equal(t, http.StatusCreated, entry.Status)
+	assert.Equal(t, `{"name":"Ada","password":"[REDACTED]"}`, entry.Request.Body)
+	assert.JSONEq(t, `{"id":"42","name":"Ada"}`, entry.Response.Body)
+}
+
+func TestLoad_Captures(t *testing.T) {
+	dir := t.TempDir()
+
+	later := capture.Entry{ID: "b", Time: testNow.Add(time.Minute), Method: http.MethodGet, Path: "/users/42", Status: http.StatusOK}
+	earlier := capture.Entry{ID: "a", Time: testNow, Method: http.MethodPost, Path: "/users", Status: http.StatusCreated}
+	require.NoError(t, Save(dir, later))
+
+	// As read from GET /admin/debug/captures, newest first
+	data, err := json.Marshal(capture.EntriesResponse{Captures: []capture.Entry{earlier}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "captures.json"), data, 0o600))
+
+	entries, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "a", entries[0].ID, "entries are sorted by time")
+	assert.Equal(t, "b", entries[1].ID)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.json"), []byte(`{"title":"bug"}`), 0o600))
+
+	_, err := Load(dir)
+	assert.ErrorContains(t, err, "not a fixture")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.json"), []byte(`{`), 0o600))
+	_, err = Load(dir)
+	assert.ErrorContains(t, err, "invalid fixture")
+}
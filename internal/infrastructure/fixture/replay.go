@@ -0,0 +1,145 @@
+package fixture
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/capture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+)
+
+// IDHeader names the fixture a replayed response comes from
+const IDHeader = "X-Fixture-ID"
+
+// skippedHeaders are recorded headers not replayed, as they describe the
+// recorded connection rather than the response
+var skippedHeaders = []string{"Content-Length", "Connection", "Date", "Transfer-Encoding"}
+
+// Replayer serves recorded responses. A request is answered with a fixture
+// of the same method, preferring one of the same path and query, then of
+// the same path, then of the same route, such as "/users/:id"; fixtures
+// whose request body is the same win ties. A request matching several
+// equally well is answered with each of them in recording order, then the
+// last one again, so a recorded sequence such as a create then a read
+// replays as it happened. Requests matching none get 404 problem details.
+type Replayer struct {
+	entries []capture.Entry
+
+	mu     sync.Mutex
+	served map[string]int // times each request was answered, by key
+}
+
+// NewReplayer creates a replayer of entries in recording order, as Load
+// returns them
+func NewReplayer(entries []capture.Entry) *Replayer {
+	return &Replayer{entries: entries, served: map[string]int{}}
+}
+
+// ServeHTTP implements http.Handler
+func (p *Replayer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	matches := p.match(r, string(body))
+	if len(matches) == 0 {
+		details := problem.New(http.StatusNotFound, "no fixture matches "+r.Method+" "+r.URL.RequestURI())
+		details.Instance = r.URL.Path
+		_ = problem.Write(w, details)
+		return
+	}
+
+	key := r.Method + " " + r.URL.RequestURI() + "\n" + string(body)
+	p.mu.Lock()
+	n := p.served[key]
+	p.served[key] = n + 1
+	p.mu.Unlock()
+	entry := matches[min(n, len(matches)-1)]
+
+	for name, value := range entry.Response.Headers {
+		if !skipped(name) {
+			w.Header().Set(name, value)
+		}
+	}
+	w.Header().Set(IDHeader, entry.ID)
+	w.WriteHeader(entry.Status)
+	_, _ = io.WriteString(w, entry.Response.Body)
+}
+
+// match returns the fixtures matching the request best, in recording order
+func (p *Replayer) match(r *http.Request, body string) []capture.Entry {
+	var best []capture.Entry
+	bestScore := 0
+	for _, entry := range p.entries {
+		score := p.score(entry, r, body)
+		switch {
+		case score == 0 || score < bestScore:
+		case score > bestScore:
+			best, bestScore = []capture.Entry{entry}, score
+		default:
+			best = append(best, entry)
+		}
+	}
+	return best
+}
+
+// score rates how well a fixture matches the request: 0 when it does not
+func (p *Replayer) score(entry capture.Entry, r *http.Request, body string) int {
+	if entry.Method != r.Method {
+		return 0
+	}
+	recorded, err := url.ParseRequestURI(entry.Path)
+	if err != nil {
+		return 0
+	}
+
+	score := 0
+	switch {
+	case recorded.Path == r.URL.Path && recorded.Query().Encode() == r.URL.Query().Encode():
+		score = 6
+	case recorded.Path == r.URL.Path:
+		score = 4
+	case routeMatches(entry.Route, r.URL.Path):
+		score = 2
+	default:
+		return 0
+	}
+	if entry.Request.Body == body {
+		score++
+	}
+	return score
+}
+
+// routeMatches reports whether path matches a gin route pattern, whose
+// :name segments match any segment and *name the rest of the path
+func routeMatches(route, path string) bool {
+	if route == "" {
+		return false
+	}
+
+	routeSegments := strings.Split(strings.Trim(route, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range routeSegments {
+		if strings.HasPrefix(segment, "*") {
+			return true
+		}
+		if i >= len(pathSegments) {
+			return false
+		}
+		if !strings.HasPrefix(segment, ":") && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return len(routeSegments) == len(pathSegments)
+}
+
+// skipped reports whether a recorded header is not replayed
+func skipped(name string) bool {
+	for _, header := range skippedHeaders {
+		if strings.EqualFold(header, name) {
+			return true
+		}
+	}
+	return false
+}
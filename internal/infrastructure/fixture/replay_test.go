@@ -0,0 +1,106 @@
+package fixture
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/capture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+)
+
+// entry is a fixture answering method and path with a body naming it
+func entry(id, method, path, route, requestBody string) capture.Entry {
+	return capture.Entry{
+		ID:     id,
+		Time:   testNow,
+		Method: method,
+		Path:   path,
+		Route:  route,
+		Status: http.StatusOK,
+		Request: capture.Message{
+			Body: requestBody,
+		},
+		Response: capture.Message{
+			Headers: map[string]string{"Content-Type": "application/json", "Content-Length": "99", "Date": "Sun, 01 Mar 2026 12:00:00 GMT"},
+			Body:    `{"fixture":"` + id + `"}`,
+		},
+	}
+}
+
+// replay sends a request to the replayer and returns the fixture answering
+// it
+func replay(t *testing.T, r *Replayer, method, target, body string) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code, target)
+	assert.Equal(t, w.Header().Get(IDHeader), strings.TrimSuffix(strings.TrimPrefix(w.Body.String(), `{"fixture":"`), `"}`))
+	return w.Header().Get(IDHeader)
+}
+
+func TestReplayer_PrefersClosestMatch(t *testing.T) {
+	r := NewReplayer([]capture.Entry{
+		entry("route", http.MethodGet, "/users/1", "/users/:id", ""),
+		entry("path", http.MethodGet, "/users/42", "/users/:id", ""),
+		entry("query", http.MethodGet, "/users/42?fields=name", "/users/:id", ""),
+		entry("post", http.MethodPost, "/users/42", "/users/:id", ""),
+		entry("ada", http.MethodPost, "/users", "/users", `{"name":"Ada"}`),
+		entry("grace", http.MethodPost, "/users", "/users", `{"name":"Grace"}`),
+	})
+
+	assert.Equal(t, "query", replay(t, r, http.MethodGet, "/users/42?fields=name", ""))
+	assert.Equal(t, "path", replay(t, r, http.MethodGet, "/users/42?fields=email", ""))
+	assert.Equal(t, "route", replay(t, r, http.MethodGet, "/users/7", ""))
+	assert.Equal(t, "post", replay(t, r, http.MethodPost, "/users/42", ""))
+	assert.Equal(t, "grace", replay(t, r, http.MethodPost, "/users", `{"name":"Grace"}`))
+}
+
+func TestReplayer_ReplaysSequences(t *testing.T) {
+	first := entry("first", http.MethodGet, "/users/42", "/users/:id", "")
+	second := entry("second", http.MethodGet, "/users/42", "/users/:id", "")
+	second.Time = testNow.Add(time.Second)
+	r := NewReplayer([]capture.Entry{first, second})
+
+	assert.Equal(t, "first", replay(t, r, http.MethodGet, "/users/42", ""))
+	assert.Equal(t, "second", replay(t, r, http.MethodGet, "/users/42", ""))
+	assert.Equal(t, "second", replay(t, r, http.MethodGet, "/users/42", ""), "the last response repeats")
+}
+
+func TestReplayer_Headers(t *testing.T) {
+	r := NewReplayer([]capture.Entry{entry("user", http.MethodGet, "/users/42", "/users/:id", "")})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Date"), "headers of the recorded connection are dropped")
+	assert.Empty(t, w.Header().Get("Content-Length"))
+}
+
+func TestReplayer_NoMatch(t *testing.T) {
+	r := NewReplayer([]capture.Entry{entry("user", http.MethodGet, "/users/42", "/users/:id", "")})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/users/42", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+	var details problem.Details
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&details))
+	assert.Equal(t, "no fixture matches DELETE /users/42", details.Detail)
+}
+
+func TestRouteMatches(t *testing.T) {
+	assert.True(t, routeMatches("/users/:id", "/users/42"))
+	assert.True(t, routeMatches("/files/*path", "/files/a/b"))
+	assert.False(t, routeMatches("/users/:id", "/users/42/avatar"))
+	assert.False(t, routeMatches("/users/:id/avatar", "/users/42"))
+	assert.False(t, routeMatches("", "/users"))
+}
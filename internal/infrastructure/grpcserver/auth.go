@@ -0,0 +1,195 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/mtls"
+)
+
+const (
+	// APIKeyMetadata is the metadata key API keys are sent in
+	APIKeyMetadata = "x-api-key"
+
+	// AuthorizationMetadata is the metadata key bearer tokens are sent in
+	AuthorizationMetadata = "authorization"
+)
+
+// Authentication methods reported in Principal.Method
+const (
+	MethodAPIKey     = "api_key"
+	MethodJWT        = "jwt"
+	MethodClientCert = "client_cert"
+)
+
+// AuthOptions configures call authentication. A call is accepted with a
+// verified client certificate, with a known API key in the x-api-key
+// metadata or with a bearer token signed with JWTSecret in the
+// authorization metadata.
+type AuthOptions struct {
+	// ClientCertificates maps the identities of verified client
+	// certificates to principals; nil ignores client certificates. A
+	// certificate whose identity is not mapped fails the call.
+	ClientCertificates *mtls.Identities
+
+	// APIKeys maps the name of each client to its key. The name becomes
+	// the subject of the principal.
+	APIKeys map[string]string
+
+	// JWTSecret is the HMAC key bearer tokens are signed with; empty
+	// rejects bearer tokens
+	JWTSecret string
+
+	// ExemptMethods are full method names, or service prefixes ending in
+	// "/", served without credentials
+	ExemptMethods []string
+}
+
+// Principal identifies the caller of an authenticated call
+type Principal struct {
+	// Subject is the name of the API key, the sub claim of the token or
+	// the principal of the client certificate
+	Subject string
+
+	// Method is MethodAPIKey, MethodJWT or MethodClientCert
+	Method string
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the caller of an authenticated call
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// errUnauthenticated is returned for calls without valid credentials; it
+// does not say which check failed
+var errUnauthenticated = status.Error(codes.Unauthenticated, "missing or invalid credentials")
+
+// authenticator checks the credentials of calls
+type authenticator struct {
+	opts AuthOptions
+}
+
+func newAuthenticator(opts AuthOptions) *authenticator {
+	return &authenticator{opts: opts}
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if a.exempt(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	principal, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(withPrincipal(ctx, principal), req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if a.exempt(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	principal, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &wrappedStream{ServerStream: ss, ctx: withPrincipal(ss.Context(), principal)})
+}
+
+// withPrincipal stores the caller in ctx and in the access log line
+func withPrincipal(ctx context.Context, principal Principal) context.Context {
+	if call, ok := ctx.Value(loggedCallKey{}).(*loggedCall); ok {
+		call.principal = principal.Subject
+	}
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// exempt reports whether method is served without credentials
+func (a *authenticator) exempt(method string) bool {
+	for _, exempt := range a.opts.ExemptMethods {
+		if method == exempt || (strings.HasSuffix(exempt, "/") && strings.HasPrefix(method, exempt)) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate returns the caller named by the client certificate of the
+// connection or by the credentials in the incoming metadata
+func (a *authenticator) authenticate(ctx context.Context) (Principal, error) {
+	if a.opts.ClientCertificates != nil {
+		principal, err := a.clientCertificate(ctx)
+		if !errors.Is(err, mtls.ErrNoCertificate) {
+			return principal, err
+		}
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if key := first(md, APIKeyMetadata); key != "" {
+		for name, known := range a.opts.APIKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(known)) == 1 {
+				return Principal{Subject: name, Method: MethodAPIKey}, nil
+			}
+		}
+		return Principal{}, errUnauthenticated
+	}
+
+	token, ok := strings.CutPrefix(first(md, AuthorizationMetadata), "Bearer ")
+	if !ok || token == "" || a.opts.JWTSecret == "" {
+		return Principal{}, errUnauthenticated
+	}
+
+	claims := jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return []byte(a.opts.JWTSecret), nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	if err != nil || claims.Subject == "" {
+		return Principal{}, errUnauthenticated
+	}
+	return Principal{Subject: claims.Subject, Method: MethodJWT}, nil
+}
+
+// clientCertificate returns the caller named by the verified client
+// certificate of the connection, or mtls.ErrNoCertificate without one
+func (a *authenticator) clientCertificate(ctx context.Context) (Principal, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Principal{}, mtls.ErrNoCertificate
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok {
+		return Principal{}, mtls.ErrNoCertificate
+	}
+
+	principal, err := a.opts.ClientCertificates.Resolve(&info.State)
+	if errors.Is(err, mtls.ErrUnknownIdentity) {
+		return Principal{}, errUnauthenticated
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{Subject: principal.Subject, Method: MethodClientCert}, nil
+}
+
+// first returns the first value of key in md
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,253 @@
+package grpcserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/mtls"
+)
+
+const testSecret = "test-secret"
+
+// testService is a hand-written service so the tests need no generated
+// code. Whoami reports the caller's subject in the "subject" header.
+var testService = grpc.ServiceDesc{
+	ServiceName: "test.Test",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "Whoami", Handler: unaryHandler("/test.Test/Whoami", func(ctx context.Context) error {
+			principal, _ := PrincipalFromContext(ctx)
+			return grpc.SetHeader(ctx, metadata.Pairs("subject", principal.Subject))
+		})},
+		{MethodName: "Panic", Handler: unaryHandler("/test.Test/Panic", func(ctx context.Context) error {
+			panic("boom")
+		})},
+	},
+	Streams: []grpc.StreamDesc{
+		{StreamName: "Watch", ServerStreams: true, Handler: func(srv any, stream grpc.ServerStream) error {
+			return stream.SendMsg(&emptypb.Empty{})
+		}},
+	},
+}
+
+func unaryHandler(method string, fn func(ctx context.Context) error) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		in := new(emptypb.Empty)
+		if err := dec(in); err != nil {
+			return nil, err
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return &emptypb.Empty{}, fn(ctx)
+		}
+		return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: method}, handler)
+	}
+}
+
+type testServer struct {
+	conn    *grpc.ClientConn
+	logs    *bytes.Buffer
+	metrics *Metrics
+}
+
+func startServer(t *testing.T) *testServer {
+	t.Helper()
+
+	logs := &bytes.Buffer{}
+	metrics, err := NewMetrics(prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	server := New(Options{
+		Logger:  logger.New("info", logs),
+		Metrics: metrics,
+		Auth: &AuthOptions{
+			APIKeys:       map[string]string{"billing": "billing-key"},
+			JWTSecret:     testSecret,
+			ExemptMethods: []string{"/test.Test/Panic"},
+		},
+	})
+	server.RegisterService(&testService, struct{}{})
+
+	return &testServer{conn: dial(t, server), logs: logs, metrics: metrics}
+}
+
+// dial serves server over an in-memory listener until the test ends and
+// returns a client connection to it
+func dial(t *testing.T, server *Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func (s *testServer) whoami(ctx context.Context) (string, error) {
+	var header metadata.MD
+	err := s.conn.Invoke(ctx, "/test.Test/Whoami", &emptypb.Empty{}, &emptypb.Empty{}, grpc.Header(&header))
+	if subject := header.Get("subject"); len(subject) > 0 {
+		return subject[0], err
+	}
+	return "", err
+}
+
+func signToken(t *testing.T, secret string, claims jwt.RegisteredClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return token
+}
+
+func TestServer_Auth(t *testing.T) {
+	s := startServer(t)
+	valid := signToken(t, testSecret, jwt.RegisteredClaims{Subject: "user-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))})
+
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		subject string
+		code    codes.Code
+	}{
+		{name: "no credentials", md: metadata.MD{}, code: codes.Unauthenticated},
+		{name: "known API key", md: metadata.Pairs(APIKeyMetadata, "billing-key"), subject: "billing", code: codes.OK},
+		{name: "unknown API key", md: metadata.Pairs(APIKeyMetadata, "other-key"), code: codes.Unauthenticated},
+		{name: "valid bearer token", md: metadata.Pairs(AuthorizationMetadata, "Bearer "+valid), subject: "user-1", code: codes.OK},
+		{
+			name: "token signed with another secret",
+			md:   metadata.Pairs(AuthorizationMetadata, "Bearer "+signToken(t, "other", jwt.RegisteredClaims{Subject: "user-1"})),
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "expired token",
+			md:   metadata.Pairs(AuthorizationMetadata, "Bearer "+signToken(t, testSecret, jwt.RegisteredClaims{Subject: "user-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour))})),
+			code: codes.Unauthenticated,
+		},
+		{name: "token without subject", md: metadata.Pairs(AuthorizationMetadata, "Bearer "+signToken(t, testSecret, jwt.RegisteredClaims{})), code: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject, err := s.whoami(metadata.NewOutgoingContext(context.Background(), tt.md))
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.Equal(t, tt.subject, subject)
+		})
+	}
+}
+
+func TestServer_AuthStream(t *testing.T) {
+	s := startServer(t)
+	desc := &grpc.StreamDesc{ServerStreams: true}
+
+	stream, err := s.conn.NewStream(context.Background(), desc, "/test.Test/Watch")
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+	err = stream.RecvMsg(&emptypb.Empty{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), APIKeyMetadata, "billing-key")
+	stream, err = s.conn.NewStream(ctx, desc, "/test.Test/Watch")
+	require.NoError(t, err)
+	require.NoError(t, stream.CloseSend())
+	assert.NoError(t, stream.RecvMsg(&emptypb.Empty{}))
+}
+
+func TestAuthenticator_ClientCertificate(t *testing.T) {
+	auth := newAuthenticator(AuthOptions{
+		ClientCertificates: mtls.NewIdentities(map[string]string{"orders.internal": "orders"}),
+		APIKeys:            map[string]string{"billing": "billing-key"},
+	})
+
+	withCert := func(ctx context.Context, dnsName string) context.Context {
+		cert := &x509.Certificate{DNSNames: []string{dnsName}}
+		state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+		return peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+	}
+
+	principal, err := auth.authenticate(withCert(context.Background(), "orders.internal"))
+	require.NoError(t, err)
+	assert.Equal(t, Principal{Subject: "orders", Method: MethodClientCert}, principal)
+
+	_, err = auth.authenticate(withCert(context.Background(), "unknown.internal"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Without a certificate the metadata credentials are checked
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(APIKeyMetadata, "billing-key"))
+	principal, err = auth.authenticate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, MethodAPIKey, principal.Method)
+}
+
+func TestServer_RecoversFromPanics(t *testing.T) {
+	s := startServer(t)
+
+	err := s.conn.Invoke(context.Background(), "/test.Test/Panic", &emptypb.Empty{}, &emptypb.Empty{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+	assert.Contains(t, s.logs.String(), "Recovered from panic in gRPC handler")
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), APIKeyMetadata, "billing-key")
+	_, err = s.whoami(ctx)
+	assert.NoError(t, err, "the server keeps serving after a panic")
+}
+
+func TestServer_LogsAndMetrics(t *testing.T) {
+	s := startServer(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		APIKeyMetadata, "billing-key",
+		"traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	)
+	_, err := s.whoami(ctx)
+	require.NoError(t, err)
+	_, err = s.whoami(context.Background())
+	require.Error(t, err)
+
+	logs := s.logs.String()
+	assert.Contains(t, logs, `"grpc_method":"/test.Test/Whoami"`)
+	assert.Contains(t, logs, `"principal":"billing"`)
+	assert.Contains(t, logs, `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`)
+	assert.Contains(t, logs, `"grpc_code":"Unauthenticated"`)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(s.metrics.handled.WithLabelValues("unary", "test.Test", "Whoami", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(s.metrics.handled.WithLabelValues("unary", "test.Test", "Whoami", "Unauthenticated")))
+}
+
+func TestNewMetrics_RegistersOnce(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	first, err := NewMetrics(reg)
+	require.NoError(t, err)
+	second, err := NewMetrics(reg)
+	require.NoError(t, err)
+
+	assert.Same(t, first.handled, second.handled)
+}
@@ -0,0 +1,96 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/health"
+)
+
+// Service names the health service answers for. The empty name is the
+// overall health of the server, which is its readiness.
+const (
+	HealthLiveness  = "liveness"
+	HealthReadiness = "readiness"
+)
+
+// DefaultHealthWatchInterval is how often Watch runs the checks when
+// Options leaves HealthWatchInterval unset
+const DefaultHealthWatchInterval = 5 * time.Second
+
+// healthServer implements grpc.health.v1.Health with the same checks as
+// GET /health/live and GET /health/ready. A degraded server still serves.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	checker  *health.Checker
+	interval time.Duration
+}
+
+// Check reports the current status of a service
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	serving, ok := h.status(ctx, req.GetService())
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+	}
+	return &healthpb.HealthCheckResponse{Status: serving}, nil
+}
+
+// List reports the status of every service
+func (h *healthServer) List(ctx context.Context, _ *healthpb.HealthListRequest) (*healthpb.HealthListResponse, error) {
+	statuses := make(map[string]*healthpb.HealthCheckResponse)
+	for _, service := range []string{"", HealthLiveness, HealthReadiness} {
+		serving, _ := h.status(ctx, service)
+		statuses[service] = &healthpb.HealthCheckResponse{Status: serving}
+	}
+	return &healthpb.HealthListResponse{Statuses: statuses}, nil
+}
+
+// Watch sends the status of a service, then every change of it, until the
+// caller goes away. Unknown services are reported as SERVICE_UNKNOWN.
+func (h *healthServer) Watch(req *healthpb.HealthCheckRequest, stream grpc.ServerStreamingServer[healthpb.HealthCheckResponse]) error {
+	ctx := stream.Context()
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_ServingStatus(-1)
+	for {
+		serving, _ := h.status(ctx, req.GetService())
+		if serving != last {
+			if err := stream.Send(&healthpb.HealthCheckResponse{Status: serving}); err != nil {
+				return err
+			}
+			last = serving
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// status runs the checks behind service. It returns false for unknown
+// services.
+func (h *healthServer) status(ctx context.Context, service string) (healthpb.HealthCheckResponse_ServingStatus, bool) {
+	var result health.HealthResult
+	switch service {
+	case HealthLiveness:
+		result = h.checker.Liveness()
+	case "", HealthReadiness:
+		result = h.checker.Readiness(ctx)
+	default:
+		return healthpb.HealthCheckResponse_SERVICE_UNKNOWN, false
+	}
+
+	if result.Status == health.StatusUnhealthy {
+		return healthpb.HealthCheckResponse_NOT_SERVING, true
+	}
+	return healthpb.HealthCheckResponse_SERVING, true
+}
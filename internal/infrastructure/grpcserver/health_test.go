@@ -0,0 +1,129 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/health"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// startHealthServer serves the health and reflection services with auth
+// required for everything else. The database check fails while down is set.
+func startHealthServer(t *testing.T, down *atomic.Bool) healthpb.HealthClient {
+	t.Helper()
+
+	checker := health.NewChecker()
+	checker.AddCheck("database", func(ctx context.Context) error {
+		if down.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	server := New(Options{
+		Logger:              logger.New("info", io.Discard),
+		Auth:                &AuthOptions{APIKeys: map[string]string{"billing": "billing-key"}, ExemptMethods: []string{"/grpc.health.v1.Health/"}},
+		Health:              checker,
+		HealthWatchInterval: 10 * time.Millisecond,
+		Reflection:          true,
+	})
+	return healthpb.NewHealthClient(dial(t, server))
+}
+
+func TestHealth_Check(t *testing.T) {
+	var down atomic.Bool
+	client := startHealthServer(t, &down)
+	ctx := context.Background()
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(HealthReadiness))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(HealthLiveness))
+
+	down.Store(true)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(HealthReadiness))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(HealthLiveness), "liveness does not depend on the database")
+
+	_, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "billing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHealth_List(t *testing.T) {
+	var down atomic.Bool
+	down.Store(true)
+	client := startHealthServer(t, &down)
+
+	resp, err := client.List(context.Background(), &healthpb.HealthListRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatuses()[""].GetStatus())
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatuses()[HealthLiveness].GetStatus())
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatuses()[HealthReadiness].GetStatus())
+}
+
+func TestHealth_Watch(t *testing.T) {
+	var down atomic.Bool
+	client := startHealthServer(t, &down)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: HealthReadiness})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	down.Store(true)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus(), "changes are sent as they happen")
+
+	unknown, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "billing"})
+	require.NoError(t, err)
+	resp, err = unknown.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVICE_UNKNOWN, resp.GetStatus())
+}
+
+func TestReflection_ListsServicesWithoutCredentials(t *testing.T) {
+	server := New(Options{
+		Logger:     logger.New("info", io.Discard),
+		Auth:       &AuthOptions{APIKeys: map[string]string{"billing": "billing-key"}},
+		Health:     health.NewChecker(),
+		Reflection: true,
+	})
+
+	stream, err := reflectionpb.NewServerReflectionClient(dial(t, server)).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, "grpc.health.v1.Health")
+}
@@ -0,0 +1,98 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tracecontext"
+)
+
+// loggedCall collects what interceptors further down the chain learn
+// about a call, such as its principal, for the access log line
+type loggedCall struct {
+	principal string
+}
+
+type loggedCallKey struct{}
+
+// UnaryLogging writes an access log line for every unary call, starting a
+// trace for calls that arrive without one
+func UnaryLogging(log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		call := &loggedCall{}
+		ctx = tracecontext.Ensure(ctx)
+		resp, err := handler(context.WithValue(ctx, loggedCallKey{}, call), req)
+		logCall(ctx, log, call, info.FullMethod, "unary", start, err)
+		return resp, err
+	}
+}
+
+// StreamLogging writes an access log line for every streaming call once
+// the stream ends, starting a trace for calls that arrive without one
+func StreamLogging(log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		call := &loggedCall{}
+		ctx := tracecontext.Ensure(ss.Context())
+		err := handler(srv, &wrappedStream{ServerStream: ss, ctx: context.WithValue(ctx, loggedCallKey{}, call)})
+		logCall(ctx, log, call, info.FullMethod, streamType(info), start, err)
+		return err
+	}
+}
+
+// logCall logs a finished call at a level matching its status: server
+// faults are errors, everything else is info
+func logCall(ctx context.Context, log *logger.Logger, call *loggedCall, method, callType string, start time.Time, err error) {
+	code := status.Code(err)
+
+	var event *zerolog.Event
+	if isServerFault(code) {
+		event = log.Error().Err(err)
+	} else {
+		event = log.Info()
+	}
+
+	event = event.
+		Ctx(ctx).
+		Str("grpc_method", method).
+		Str("grpc_type", callType).
+		Str("grpc_code", code.String()).
+		Dur("duration", time.Since(start))
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		event = event.Str("peer", p.Addr.String())
+	}
+	if call.principal != "" {
+		event = event.Str("principal", call.principal)
+	}
+	event.Msg("gRPC call")
+}
+
+// isServerFault reports whether code means the server, not the caller,
+// is at fault
+func isServerFault(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unimplemented, codes.Unavailable:
+		return true
+	}
+	return false
+}
+
+// streamType names the kind of a streaming call
+func streamType(info *grpc.StreamServerInfo) string {
+	switch {
+	case info.IsClientStream && info.IsServerStream:
+		return "bidi_stream"
+	case info.IsClientStream:
+		return "client_stream"
+	default:
+		return "server_stream"
+	}
+}
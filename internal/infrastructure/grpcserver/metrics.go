@@ -0,0 +1,90 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics records calls in Prometheus. The metric names and labels follow
+// go-grpc-prometheus so existing dashboards work unchanged.
+type Metrics struct {
+	handled  *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewMetrics registers the call metrics with reg. Registering twice with
+// the same registry reuses the metrics registered first.
+func NewMetrics(reg prometheus.Registerer) (*Metrics, error) {
+	handled, err := register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_handled_total",
+		Help: "Total number of RPCs completed on the server, regardless of success or failure.",
+	}, []string{"grpc_type", "grpc_service", "grpc_method", "grpc_code"}))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := register(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Histogram of response latency (seconds) of gRPC that had been application-level handled by the server.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_type", "grpc_service", "grpc_method"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Metrics{handled: handled, duration: duration}, nil
+}
+
+// UnaryInterceptor records unary calls
+func (m *Metrics) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, "unary", start, err)
+		return resp, err
+	}
+}
+
+// StreamInterceptor records streaming calls once the stream ends
+func (m *Metrics) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		m.observe(info.FullMethod, streamType(info), start, err)
+		return err
+	}
+}
+
+func (m *Metrics) observe(fullMethod, callType string, start time.Time, err error) {
+	service, method := splitMethod(fullMethod)
+	m.handled.WithLabelValues(callType, service, method, status.Code(err).String()).Inc()
+	m.duration.WithLabelValues(callType, service, method).Observe(time.Since(start).Seconds())
+}
+
+// splitMethod splits "/package.Service/Method" into its service and method
+func splitMethod(fullMethod string) (string, string) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return "unknown", "unknown"
+	}
+	return service, method
+}
+
+// register registers c with reg, or returns the collector already
+// registered in its place
+func register[C prometheus.Collector](reg prometheus.Registerer, c C) (C, error) {
+	err := reg.Register(c)
+	var existing prometheus.AlreadyRegisteredError
+	if errors.As(err, &existing) {
+		if same, ok := existing.ExistingCollector.(C); ok {
+			return same, nil
+		}
+	}
+	return c, err
+}
@@ -0,0 +1,50 @@
+package grpcserver
+
+import (
+	"context"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// UnaryRecovery turns a panic in a unary handler into an Internal status
+// instead of crashing the process
+func UnaryRecovery(log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, log, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery turns a panic in a stream handler into an Internal status
+// instead of crashing the process
+func StreamRecovery(log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), log, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered logs a panic with its stack and returns the status sent to the
+// caller, which does not reveal the panic
+func recovered(ctx context.Context, log *logger.Logger, method string, r any) error {
+	log.Error().
+		Ctx(ctx).
+		Interface("panic", r).
+		Str("grpc_method", method).
+		Bytes("stack", debug.Stack()).
+		Msg("Recovered from panic in gRPC handler")
+	return status.Error(codes.Internal, "internal server error")
+}
@@ -12,6 +12,9 @@ type Status string
 const (
 	StatusHealthy   Status = "healthy"
 	StatusUnhealthy Status = "unhealthy"
+
+	// StatusDegraded means only non-critical checks failed; the app still serves traffic
+	StatusDegraded Status = "degraded"
 )
 
 // CheckFunc is a function that performs a health check
@@ -31,14 +34,16 @@ type HealthResult struct {
 
 // Checker manages health checks
 type Checker struct {
-	checks map[string]CheckFunc
-	mu     sync.RWMutex
+	checks      map[string]CheckFunc
+	nonCritical map[string]bool
+	mu          sync.RWMutex
 }
 
 // NewChecker creates a new health checker
 func NewChecker() *Checker {
 	return &Checker{
-		checks: make(map[string]CheckFunc),
+		checks:      make(map[string]CheckFunc),
+		nonCritical: make(map[string]bool),
 	}
 }
 
@@ -49,6 +54,15 @@ func (c *Checker) AddCheck(name string, check CheckFunc) {
 	c.checks[name] = check
 }
 
+// AddNonCriticalCheck registers a health check whose failure degrades the
+// overall status instead of making it unhealthy
+func (c *Checker) AddNonCriticalCheck(name string, check CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks[name] = check
+	c.nonCritical[name] = true
+}
+
 // Check runs all health checks and returns the result
 func (c *Checker) Check(ctx context.Context) HealthResult {
 	c.mu.RLock()
@@ -70,7 +84,12 @@ func (c *Checker) Check(ctx context.Context) HealthResult {
 		if err := check(ctx); err != nil {
 			checkResult.Status = StatusUnhealthy
 			checkResult.Error = err.Error()
-			result.Status = StatusUnhealthy
+
+			if !c.nonCritical[name] {
+				result.Status = StatusUnhealthy
+			} else if result.Status == StatusHealthy {
+				result.Status = StatusDegraded
+			}
 		}
 
 		result.Checks[name] = checkResult
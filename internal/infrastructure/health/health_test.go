@@ -110,3 +110,25 @@ func TestChecker_CheckTimeout(t *testing.T) {
 	assert.Equal(t, StatusUnhealthy, result.Checks["slow"].Status)
 	assert.Contains(t, result.Checks["slow"].Error, "context deadline exceeded")
 }
+
+func TestChecker_NonCriticalCheck(t *testing.T) {
+	checker := NewChecker()
+	checker.AddCheck("pass", func(ctx context.Context) error {
+		return nil
+	})
+	checker.AddNonCriticalCheck("optional", func(ctx context.Context) error {
+		return errors.New("replica unreachable")
+	})
+
+	result := checker.Check(context.Background())
+	assert.Equal(t, StatusDegraded, result.Status)
+	assert.Equal(t, StatusUnhealthy, result.Checks["optional"].Status)
+
+	// A failing critical check still makes the app unhealthy
+	checker.AddCheck("fail", func(ctx context.Context) error {
+		return errors.New("failed")
+	})
+
+	result = checker.Check(context.Background())
+	assert.Equal(t, StatusUnhealthy, result.Status)
+}
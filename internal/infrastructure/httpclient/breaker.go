@@ -0,0 +1,167 @@
+package httpclient
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultFailureThreshold and defaultOpenTimeout are used when
+	// BreakerOptions leaves them unset
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned without sending the request while the circuit
+// of the target host is open
+var ErrCircuitOpen = errors.New("httpclient: circuit open")
+
+// BreakerOptions configures the circuit breaker kept for each host. After
+// FailureThreshold consecutive failures the circuit opens and requests to
+// the host fail fast with ErrCircuitOpen. Once OpenTimeout has passed a
+// single trial request is let through; its success closes the circuit and
+// its failure opens it again.
+type BreakerOptions struct {
+	// Disabled turns the circuit breaker off
+	Disabled bool
+
+	// FailureThreshold is how many consecutive network errors or 5xx
+	// responses open the circuit. defaultFailureThreshold when zero.
+	FailureThreshold int
+
+	// OpenTimeout is how long an open circuit rejects requests.
+	// defaultOpenTimeout when zero.
+	OpenTimeout time.Duration
+}
+
+// breakers holds the circuit breaker of each host a client calls
+type breakers struct {
+	opts BreakerOptions
+
+	mu     sync.Mutex
+	byHost map[string]*breaker
+}
+
+func newBreakers(opts BreakerOptions) *breakers {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultFailureThreshold
+	}
+	if opts.OpenTimeout <= 0 {
+		opts.OpenTimeout = defaultOpenTimeout
+	}
+	return &breakers{opts: opts, byHost: make(map[string]*breaker)}
+}
+
+// forHost returns the circuit breaker of host, or nil when breaking is
+// disabled
+func (b *breakers) forHost(host string) *breaker {
+	if b.opts.Disabled {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	br, ok := b.byHost[host]
+	if !ok {
+		br = &breaker{threshold: b.opts.FailureThreshold, openTimeout: b.opts.OpenTimeout}
+		b.byHost[host] = br
+	}
+	return br
+}
+
+// openCount returns how many hosts currently have an open circuit
+func (b *breakers) openCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	count := 0
+	for _, br := range b.byHost {
+		if br.isOpen() {
+			count++
+		}
+	}
+	return count
+}
+
+// breaker tracks the health of a single host. A nil breaker lets every
+// request through.
+type breaker struct {
+	threshold   int
+	openTimeout time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// allow reports whether a request may be sent. When the open timeout has
+// passed it lets a single trial through and rejects the rest until the
+// trial's result is recorded.
+func (b *breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return nil
+	}
+	if b.trial || time.Since(b.openedAt) < b.openTimeout {
+		return ErrCircuitOpen
+	}
+	b.trial = true
+	return nil
+}
+
+// record updates the breaker with the result of a sent request
+func (b *breaker) record(failed bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	trial := b.trial
+	b.trial = false
+	if !failed {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+
+	b.failures++
+	if trial || b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// release ends a trial without a result, so the next request becomes the
+// trial instead
+func (b *breaker) release() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// isOpen reports whether requests are currently being rejected
+func (b *breaker) isOpen() bool {
+	if b == nil {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return !b.openedAt.IsZero() && (b.trial || time.Since(b.openedAt) < b.openTimeout)
+}
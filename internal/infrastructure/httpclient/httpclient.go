@@ -0,0 +1,182 @@
+// Package httpclient builds the *http.Client used for outbound calls to
+// other services. Every client bounds its calls with a timeout, retries
+// transient failures of requests that are safe to repeat, stops calling a
+// host that keeps failing, propagates the trace context and request ID,
+// and publishes counters under /debug/vars, so integrations built on the
+// scaffold do not each reinvent this.
+package httpclient
+
+import (
+	"context"
+	"expvar"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tracecontext"
+)
+
+// metrics publishes per client counters under /debug/vars
+var metrics = expvar.NewMap("http_client")
+
+// DefaultTimeout bounds a call, retries included, when Options leaves
+// Timeout unset
+const DefaultTimeout = 10 * time.Second
+
+// RequestIDHeader carries the request ID to the called service
+const RequestIDHeader = "X-Request-ID"
+
+// Options configures a client
+type Options struct {
+	// Name identifies the integration in metrics and span names, such as
+	// "payments"
+	Name string
+
+	// Timeout bounds a whole call, retries and backoff included.
+	// DefaultTimeout when zero.
+	Timeout time.Duration
+
+	// Retry decides which failed attempts are sent again
+	Retry RetryPolicy
+
+	// Breaker stops calls to a host after repeated failures
+	Breaker BreakerOptions
+
+	// Transport sends single attempts; http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// New returns a client for the integration described by opts. Clients are
+// safe for concurrent use and should be created once and reused.
+func New(opts Options) *http.Client {
+	if opts.Name == "" {
+		opts.Name = "default"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	base := opts.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	breakers := newBreakers(opts.Breaker)
+	metrics.Set(opts.Name+".open_circuits", expvar.Func(func() any { return breakers.openCount() }))
+
+	traced := otelhttp.NewTransport(base,
+		otelhttp.WithPropagators(tracecontext.Propagator),
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return opts.Name + " " + r.Method
+		}),
+	)
+
+	return &http.Client{
+		Timeout: opts.Timeout,
+		Transport: &transport{
+			name:     opts.Name,
+			next:     traced,
+			retry:    opts.Retry.withDefaults(),
+			breakers: breakers,
+		},
+	}
+}
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context whose outbound requests carry id in the
+// X-Request-ID header
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored by WithRequestID
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// transport applies the circuit breaker and retry policy around the traced
+// transport, so every attempt gets a span of its own
+type transport struct {
+	name     string
+	next     http.RoundTripper
+	retry    RetryPolicy
+	breakers *breakers
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if id, ok := RequestIDFromContext(ctx); ok && req.Header.Get(RequestIDHeader) == "" {
+		// A RoundTripper must not modify the caller's request
+		req = req.Clone(ctx)
+		req.Header.Set(RequestIDHeader, id)
+	}
+
+	breaker := t.breakers.forHost(req.URL.Host)
+	replayable := canRetry(req)
+	backoff := t.retry.InitialBackoff
+	attemptReq := req
+
+	for attempt := 1; ; attempt++ {
+		if err := breaker.allow(); err != nil {
+			metrics.Add(t.name+".rejected", 1)
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		metrics.Add(t.name+".requests", 1)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			// Cancellation by the caller says nothing about the host
+			breaker.release()
+		case isFailure(resp, err):
+			breaker.record(true)
+			metrics.Add(t.name+".failures", 1)
+		default:
+			breaker.record(false)
+		}
+
+		if attempt >= t.retry.MaxAttempts || !replayable || !shouldRetry(ctx, resp, err) || breaker.isOpen() {
+			return resp, err
+		}
+		pause, ok := t.retry.pause(resp, backoff)
+		if !ok || !fitsDeadline(ctx, pause) {
+			return resp, err
+		}
+
+		next, rewindErr := rewind(req)
+		if rewindErr != nil {
+			return resp, err
+		}
+		if resp != nil {
+			drain(resp)
+		}
+
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		metrics.Add(t.name+".retries", 1)
+		attemptReq = next
+		backoff = min(backoff*2, t.retry.MaxBackoff)
+	}
+}
+
+// isFailure reports whether an attempt counts against the host's circuit.
+// 4xx responses are the caller's fault and say nothing about the host.
+func isFailure(resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// fitsDeadline reports whether a retry after pause could still finish
+// before the context's deadline
+func fitsDeadline(ctx context.Context, pause time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) > pause
+}
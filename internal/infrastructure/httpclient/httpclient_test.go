@@ -0,0 +1,262 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var testRetry = RetryPolicy{
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     4 * time.Millisecond,
+}
+
+// failingServer answers the first failures requests with status and the
+// rest with 200, and counts the requests it receives
+func failingServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &count
+}
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	server, count := failingServer(t, 2, http.StatusServiceUnavailable)
+	client := New(Options{Name: "retries", Retry: testRetry})
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), count.Load())
+	assert.Equal(t, "2", metrics.Get("retries.retries").String())
+	assert.Equal(t, "3", metrics.Get("retries.requests").String())
+}
+
+func TestClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	server, count := failingServer(t, 10, http.StatusBadGateway)
+	client := New(Options{Name: "gives-up", Retry: testRetry})
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(defaultMaxAttempts), count.Load())
+}
+
+func TestClient_DoesNotRetryOtherStatuses(t *testing.T) {
+	server, count := failingServer(t, 1, http.StatusInternalServerError)
+	client := New(Options{Name: "no-retry", Retry: testRetry})
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(1), count.Load())
+}
+
+func TestClient_RetriesPostOnlyWithIdempotencyKey(t *testing.T) {
+	t.Run("without a key", func(t *testing.T) {
+		server, count := failingServer(t, 1, http.StatusServiceUnavailable)
+		client := New(Options{Name: "post", Retry: testRetry})
+
+		resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, int32(1), count.Load())
+	})
+
+	t.Run("with a key the body is sent again", func(t *testing.T) {
+		server, count := failingServer(t, 1, http.StatusServiceUnavailable)
+		client := New(Options{Name: "post-key", Retry: testRetry})
+
+		req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+		require.NoError(t, err)
+		req.Header.Set("Idempotency-Key", "key-1")
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "payload", string(body))
+		assert.Equal(t, int32(2), count.Load())
+	})
+}
+
+func TestClient_RetryAfter(t *testing.T) {
+	t.Run("honored within MaxBackoff", func(t *testing.T) {
+		var count atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if count.Add(1) == 1 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}))
+		defer server.Close()
+
+		resp, err := New(Options{Name: "retry-after", Retry: testRetry}).Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, int32(2), count.Load())
+	})
+
+	t.Run("longer than MaxBackoff ends the retries", func(t *testing.T) {
+		var count atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			count.Add(1)
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		resp, err := New(Options{Name: "retry-after-long", Retry: testRetry}).Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "120", resp.Header.Get("Retry-After"))
+		assert.Equal(t, int32(1), count.Load())
+	})
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var count atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := New(Options{
+		Name:    "breaker",
+		Retry:   RetryPolicy{MaxAttempts: 1},
+		Breaker: BreakerOptions{FailureThreshold: 2, OpenTimeout: 20 * time.Millisecond},
+	})
+
+	for range 2 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, "1", metrics.Get("breaker.open_circuits").String())
+
+	_, err := client.Get(server.URL)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, int32(2), count.Load(), "an open circuit does not send requests")
+	assert.Equal(t, "1", metrics.Get("breaker.rejected").String())
+
+	healthy.Store(true)
+	time.Sleep(30 * time.Millisecond)
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err, "the trial request is let through after the open timeout")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "0", metrics.Get("breaker.open_circuits").String())
+}
+
+func TestClient_CircuitBreakerDisabled(t *testing.T) {
+	server, count := failingServer(t, 10, http.StatusInternalServerError)
+	client := New(Options{
+		Name:    "breaker-disabled",
+		Retry:   RetryPolicy{MaxAttempts: 1},
+		Breaker: BreakerOptions{Disabled: true, FailureThreshold: 1},
+	})
+
+	for range 3 {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, int32(3), count.Load())
+}
+
+func TestClient_PropagatesTraceContextAndRequestID(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+	}))
+	defer server.Close()
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+	ctx = WithRequestID(ctx, "req-123")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := New(Options{Name: "tracing"}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	got := <-headers
+	assert.Contains(t, got.Get("Traceparent"), "4bf92f3577b34da6a3ce929d0e0e4736")
+	assert.Equal(t, "req-123", got.Get(RequestIDHeader))
+	assert.Empty(t, req.Header.Get(RequestIDHeader), "the caller's request is not modified")
+}
+
+func TestClient_TimeoutCoversRetries(t *testing.T) {
+	server, _ := failingServer(t, 100, http.StatusServiceUnavailable)
+	client := New(Options{
+		Name:    "timeout",
+		Timeout: 50 * time.Millisecond,
+		Retry:   RetryPolicy{MaxAttempts: 100, InitialBackoff: 20 * time.Millisecond, MaxBackoff: 20 * time.Millisecond},
+	})
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+	}
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryAfter(t *testing.T) {
+	d, ok := retryAfter("3")
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, d)
+
+	d, ok = retryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Zero(t, d)
+
+	_, ok = retryAfter("soon")
+	assert.False(t, ok)
+}
@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultMaxAttempts, defaultInitialBackoff and defaultMaxBackoff are
+	// used when RetryPolicy leaves them unset
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 2 * time.Second
+
+	// idempotencyKeyHeader marks a non-idempotent request the called
+	// service deduplicates, which makes it safe to send again
+	idempotencyKeyHeader = "Idempotency-Key"
+
+	// maxDrain bounds how much of a discarded response body is read so the
+	// connection can be reused
+	maxDrain = 64 << 10
+)
+
+// RetryPolicy decides which failed attempts are sent again. Only requests
+// with an idempotent method or an Idempotency-Key header are retried, and
+// only after a network error or a 429, 502, 503 or 504 response.
+type RetryPolicy struct {
+	// MaxAttempts is how many times a request is sent at most.
+	// defaultMaxAttempts when zero; 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the pause after the first failed attempt. It
+	// doubles after each further failure, up to MaxBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the pause between attempts. A Retry-After longer
+	// than MaxBackoff ends the retries.
+	MaxBackoff time.Duration
+}
+
+// withDefaults fills in the unset fields of p
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultInitialBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = max(defaultMaxBackoff, p.InitialBackoff)
+	}
+	return p
+}
+
+// pause returns how long to wait before the next attempt: the response's
+// Retry-After when it has one, the jittered backoff otherwise. It returns
+// false when the server asks for a longer wait than MaxBackoff.
+func (p RetryPolicy) pause(resp *http.Response, backoff time.Duration) (time.Duration, bool) {
+	if resp != nil {
+		if after, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return after, after <= p.MaxBackoff
+		}
+	}
+	return jitter(backoff), true
+}
+
+// canRetry reports whether req may be sent more than once
+func canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get(idempotencyKeyHeader) != ""
+}
+
+// shouldRetry reports whether an attempt failed in a way another attempt
+// may not
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP
+// date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// rewind returns a copy of req with a fresh body for another attempt
+func rewind(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.GetBody == nil {
+		return next, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	next.Body = body
+	return next, nil
+}
+
+// drain discards a response that will not be returned
+func drain(resp *http.Response) {
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrain)
+	_ = resp.Body.Close()
+}
+
+// jitter spreads a pause between half and all of d, so clients that failed
+// together do not retry in lockstep
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
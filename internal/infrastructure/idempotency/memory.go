@@ -0,0 +1,83 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MemoryStore implements Store in process memory. Records are lost on
+// restart and not shared between instances, so it is meant for running
+// without a database.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]Record
+}
+
+// NewMemoryStore creates a new in-memory idempotency store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]Record),
+	}
+}
+
+// Reserve stores rec as in progress, or returns the unexpired record that
+// already holds the key
+func (s *MemoryStore) Reserve(ctx context.Context, rec *Record) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.records[rec.Key]; ok && existing.ExpiresAt.After(time.Now()) {
+		existing.Header = existing.Header.Clone()
+		return &existing, false, nil
+	}
+
+	s.records[rec.Key] = Record{
+		Key:         rec.Key,
+		RequestHash: rec.RequestHash,
+		ExpiresAt:   rec.ExpiresAt,
+	}
+	return nil, true, nil
+}
+
+// Complete stores the response of a reserved key
+func (s *MemoryStore) Complete(ctx context.Context, key string, statusCode int, header http.Header, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.records[key]
+	if !ok {
+		return nil
+	}
+
+	rec.StatusCode = statusCode
+	rec.Header = header.Clone()
+	rec.Body = append([]byte(nil), body...)
+	s.records[key] = rec
+	return nil
+}
+
+// Release removes a reserved key so the request can be retried
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// DeleteExpired removes records that expired before the cutoff
+func (s *MemoryStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key, rec := range s.records {
+		if !rec.ExpiresAt.After(before) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,212 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+)
+
+const (
+	// HeaderKey is the request header carrying the client's idempotency key
+	HeaderKey = "Idempotency-Key"
+
+	// HeaderReplayed marks responses replayed from a previous request
+	HeaderReplayed = "Idempotent-Replayed"
+
+	// MaxKeyLength is the longest idempotency key accepted
+	MaxKeyLength = 255
+
+	// DefaultMaxBodyBytes is the body size limit used when none is
+	// configured, large enough for user imports
+	DefaultMaxBodyBytes = 10 << 20
+
+	// CodeKeyReused is the error code of a key reused for a different request
+	CodeKeyReused = "IDEMPOTENCY_KEY_REUSED"
+
+	// CodeInProgress is the error code of a retry arriving while the first
+	// request with its key is still being processed
+	CodeInProgress = "IDEMPOTENCY_KEY_IN_USE"
+)
+
+// Options configures the idempotency middleware
+type Options struct {
+	// TTL is how long a key and its stored response are kept
+	TTL time.Duration
+
+	// MaxBodyBytes limits the size of the bodies read to fingerprint
+	// requests; zero uses DefaultMaxBodyBytes
+	MaxBodyBytes int64
+}
+
+// Middleware makes POST requests carrying an Idempotency-Key header safe to
+// retry. The first request with a key is processed and its response stored;
+// retries with the same key and payload get the stored response replayed,
+// and reusing the key for a different payload is rejected with 409.
+// Responses with a 5xx status are not stored so the request can be retried,
+// and neither are handlers that panic.
+func Middleware(store Store, opts Options) gin.HandlerFunc {
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderKey)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > MaxKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "Idempotency-Key cannot exceed 255 characters",
+				"code":  problem.CodeValidationFailed,
+			})
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, opts.MaxBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+					"error": fmt.Sprintf("request body cannot exceed %d bytes", opts.MaxBodyBytes),
+					"code":  problem.CodeRequestTooLarge,
+				})
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "failed to read request body",
+				"code":  problem.CodeValidationFailed,
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Keys are chosen by clients, so tenants may pick the same one
+		ctx := c.Request.Context()
+		if tenant, ok := tenancy.FromContext(ctx); ok {
+			key = tenant + ":" + key
+		}
+
+		requestHash := hashRequest(c.Request, body)
+		existing, reserved, err := store.Reserve(ctx, &Record{
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   time.Now().Add(opts.TTL),
+		})
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+				"error": "internal server error",
+				"code":  problem.CodeInternal,
+			})
+			return
+		}
+
+		if !reserved {
+			replay(c, existing, requestHash)
+			return
+		}
+
+		// Without a response to store, such as when the handler panics, the
+		// key is released so the retry is processed instead of being told
+		// the request is still in progress until the key expires
+		completed := false
+		defer func() {
+			if !completed {
+				_ = store.Release(context.WithoutCancel(ctx), key)
+			}
+		}()
+
+		before := c.Writer.Header().Clone()
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		// Store the outcome even if the client has gone away, so its retry
+		// is answered from the record
+		completed = true
+		_ = store.Complete(context.WithoutCancel(ctx), key, writer.Status(), responseHeader(before, writer.Header()), writer.body.Bytes())
+	}
+}
+
+// responseHeader returns the headers set while handling the request, so
+// the ones of earlier middleware, such as request IDs, are not replayed
+func responseHeader(before, after http.Header) http.Header {
+	header := http.Header{}
+	for name, values := range after {
+		if name == "Content-Length" || slices.Equal(before[name], values) {
+			continue
+		}
+		header[name] = slices.Clone(values)
+	}
+	return header
+}
+
+// replay answers a retried request from the stored record
+func replay(c *gin.Context, rec *Record, requestHash string) {
+	if rec.RequestHash != requestHash {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"error": "Idempotency-Key was already used for a different request",
+			"code":  CodeKeyReused,
+		})
+		return
+	}
+
+	if !rec.Completed() {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{
+			"error": "a request with this Idempotency-Key is still being processed",
+			"code":  CodeInProgress,
+		})
+		return
+	}
+
+	for name, values := range rec.Header {
+		c.Writer.Header()[name] = slices.Clone(values)
+	}
+	c.Header(HeaderReplayed, "true")
+	c.Data(rec.StatusCode, rec.Header.Get("Content-Type"), rec.Body)
+	c.Abort()
+}
+
+// hashRequest fingerprints the method, URL and body of a request
+func hashRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.RequestURI())
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// recordingWriter copies the response body while writing it to the client
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,252 @@
+package idempotency
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+)
+
+// setupRouter returns a router whose POST /items handler counts its calls
+// and answers with the given status
+func setupRouter(t *testing.T, status int) (*gin.Engine, *int) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	router := gin.New()
+	router.Use(Middleware(NewGormStore(setupTestDB(t)), Options{TTL: time.Hour}))
+	router.POST("/items", func(c *gin.Context) {
+		calls++
+		c.JSON(status, gin.H{"call": calls})
+	})
+	router.GET("/items", func(c *gin.Context) {
+		calls++
+		c.Status(http.StatusOK)
+	})
+	return router, &calls
+}
+
+func post(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_ReplaysStoredResponse(t *testing.T) {
+	router, calls := setupRouter(t, http.StatusCreated)
+
+	first := post(router, "abc", `{"name":"x"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(HeaderReplayed))
+
+	retry := post(router, "abc", `{"name":"x"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, "true", retry.Header().Get(HeaderReplayed))
+	assert.Contains(t, retry.Header().Get("Content-Type"), "application/json")
+	assert.Equal(t, 1, *calls)
+}
+
+func TestMiddleware_ReplaysHandlerHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Header("X-Request-ID", c.GetHeader("X-Request-ID"))
+	})
+	router.Use(Middleware(NewGormStore(setupTestDB(t)), Options{TTL: time.Hour}))
+	router.POST("/items", func(c *gin.Context) {
+		c.Header("Location", "/items/1")
+		c.JSON(http.StatusCreated, gin.H{"id": "1"})
+	})
+
+	postWithID := func(requestID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{}`))
+		req.Header.Set(HeaderKey, "abc")
+		req.Header.Set("X-Request-ID", requestID)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusCreated, postWithID("first").Code)
+	retry := postWithID("second")
+
+	assert.Equal(t, "true", retry.Header().Get(HeaderReplayed))
+	assert.Equal(t, "/items/1", retry.Header().Get("Location"))
+	// Headers of earlier middleware belong to the retry
+	assert.Equal(t, "second", retry.Header().Get("X-Request-ID"))
+}
+
+func TestMiddleware_KeyReuseWithDifferentPayload(t *testing.T) {
+	router, calls := setupRouter(t, http.StatusCreated)
+
+	post(router, "abc", `{"name":"x"}`)
+	w := post(router, "abc", `{"name":"y"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "different request")
+	assert.Equal(t, 1, *calls)
+}
+
+func TestMiddleware_ServerErrorsAreNotStored(t *testing.T) {
+	router, calls := setupRouter(t, http.StatusServiceUnavailable)
+
+	post(router, "abc", `{}`)
+	w := post(router, "abc", `{}`)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Empty(t, w.Header().Get(HeaderReplayed))
+	assert.Equal(t, 2, *calls)
+}
+
+func TestMiddleware_PanicsReleaseKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.Use(Middleware(NewGormStore(setupTestDB(t)), Options{TTL: time.Hour}))
+	router.POST("/items", func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, post(router, "abc", `{}`).Code)
+	w := post(router, "abc", `{}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(HeaderReplayed))
+	assert.Equal(t, 2, calls)
+}
+
+func TestMiddleware_ClientErrorsAreStored(t *testing.T) {
+	router, calls := setupRouter(t, http.StatusBadRequest)
+
+	post(router, "abc", `{}`)
+	w := post(router, "abc", `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "true", w.Header().Get(HeaderReplayed))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestMiddleware_PassesThrough(t *testing.T) {
+	router, calls := setupRouter(t, http.StatusCreated)
+
+	// Without a key every request is processed
+	post(router, "", `{}`)
+	post(router, "", `{}`)
+	assert.Equal(t, 2, *calls)
+
+	// Only POST requests are affected
+	for range 2 {
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.Header.Set(HeaderKey, "abc")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	assert.Equal(t, 4, *calls)
+}
+
+func TestMiddleware_RejectsLongKey(t *testing.T) {
+	router, calls := setupRouter(t, http.StatusCreated)
+
+	w := post(router, strings.Repeat("k", MaxKeyLength+1), `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, 0, *calls)
+}
+
+func TestMiddleware_RejectsLargeBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	router := gin.New()
+	router.Use(Middleware(NewGormStore(setupTestDB(t)), Options{TTL: time.Hour, MaxBodyBytes: 16}))
+	router.POST("/items", func(c *gin.Context) {
+		calls++
+		c.Status(http.StatusCreated)
+	})
+
+	w := post(router, "abc", `{"name":"a long enough name"}`)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "REQUEST_TOO_LARGE")
+	assert.Equal(t, 0, calls)
+
+	// Requests without a key are left to the handler
+	assert.Equal(t, http.StatusCreated, post(router, "", `{"name":"a long enough name"}`).Code)
+}
+
+func TestMiddleware_InProgressKey(t *testing.T) {
+	store := NewGormStore(setupTestDB(t))
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Middleware(store, Options{TTL: time.Hour}))
+
+	var retry *httptest.ResponseRecorder
+	router.POST("/items", func(c *gin.Context) {
+		// A retry arriving while the first request is still running
+		if retry == nil {
+			retry = httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{}`))
+			req.Header.Set(HeaderKey, "abc")
+			router.ServeHTTP(retry, req)
+		}
+		c.Status(http.StatusCreated)
+	})
+
+	w := post(router, "abc", `{}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, http.StatusConflict, retry.Code)
+	assert.Contains(t, retry.Body.String(), "still being processed")
+}
+
+func TestMiddleware_KeysArePerTenant(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	calls := 0
+	router := gin.New()
+	router.Use(tenancy.Middleware(tenancy.HeaderResolver{Header: "X-Tenant-ID"}, tenancy.Options{}))
+	router.Use(Middleware(NewGormStore(setupTestDB(t)), Options{TTL: time.Hour}))
+	router.POST("/items", func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	postAs := func(tenant string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"x"}`))
+		req.Header.Set("X-Tenant-ID", tenant)
+		req.Header.Set(HeaderKey, "abc")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusCreated, postAs("acme").Code)
+	other := postAs("globex")
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.Empty(t, other.Header().Get(HeaderReplayed))
+	assert.Equal(t, "true", postAs("acme").Header().Get(HeaderReplayed))
+	assert.Equal(t, 2, calls)
+}
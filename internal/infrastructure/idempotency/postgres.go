@@ -0,0 +1,117 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// KeyModel represents the database model for idempotency records
+type KeyModel struct {
+	Key         string      `gorm:"type:varchar(320);primaryKey"`
+	RequestHash string      `gorm:"type:varchar(64);not null"`
+	StatusCode  int         `gorm:"not null;default:0"`
+	Headers     http.Header `gorm:"type:jsonb;serializer:json"`
+	Body        []byte      `gorm:""`
+	ExpiresAt   time.Time   `gorm:"index;not null"`
+	CreatedAt   time.Time   `gorm:"not null"`
+}
+
+// TableName specifies the table name for KeyModel
+func (KeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+// GormStore implements Store using GORM
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore creates a new database-backed idempotency store
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{
+		db: db,
+	}
+}
+
+// Reserve stores rec as in progress, or returns the unexpired record that
+// already holds the key
+func (s *GormStore) Reserve(ctx context.Context, rec *Record) (*Record, bool, error) {
+	var existing *Record
+	reserved := false
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// An expired record no longer protects its key
+		if err := tx.Where("key = ? AND expires_at <= ?", rec.Key, time.Now()).Delete(&KeyModel{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&KeyModel{
+			Key:         rec.Key,
+			RequestHash: rec.RequestHash,
+			ExpiresAt:   rec.ExpiresAt,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			reserved = true
+			return nil
+		}
+
+		var model KeyModel
+		if err := tx.Where("key = ?", rec.Key).First(&model).Error; err != nil {
+			return err
+		}
+		existing = toRecord(&model)
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	return existing, reserved, nil
+}
+
+// Complete stores the response of a reserved key
+func (s *GormStore) Complete(ctx context.Context, key string, statusCode int, header http.Header, body []byte) error {
+	return s.db.WithContext(ctx).
+		Model(&KeyModel{}).
+		Where("key = ?", key).
+		Select("status_code", "headers", "body").
+		Updates(&KeyModel{
+			StatusCode: statusCode,
+			Headers:    header,
+			Body:       body,
+		}).Error
+}
+
+// Release removes a reserved key so the request can be retried
+func (s *GormStore) Release(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Where("key = ?", key).Delete(&KeyModel{}).Error
+}
+
+// DeleteExpired removes records that expired before the cutoff
+func (s *GormStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", before).Delete(&KeyModel{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// toRecord converts a KeyModel to a Record
+func toRecord(model *KeyModel) *Record {
+	return &Record{
+		Key:         model.Key,
+		RequestHash: model.RequestHash,
+		StatusCode:  model.StatusCode,
+		Header:      model.Headers,
+		Body:        model.Body,
+		ExpiresAt:   model.ExpiresAt,
+	}
+}
@@ -0,0 +1,40 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Record is a request stored under an idempotency key and, once the
+// request has completed, the response to replay on retries
+type Record struct {
+	Key         string
+	RequestHash string
+	StatusCode  int // zero while the request is still being processed
+	Header      http.Header
+	Body        []byte
+	ExpiresAt   time.Time
+}
+
+// Completed reports whether the response has been stored
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Store persists idempotency records
+type Store interface {
+	// Reserve stores rec as in progress. If an unexpired record already
+	// holds the key, it is returned with reserved set to false.
+	Reserve(ctx context.Context, rec *Record) (existing *Record, reserved bool, err error)
+
+	// Complete stores the response of a reserved key
+	Complete(ctx context.Context, key string, statusCode int, header http.Header, body []byte) error
+
+	// Release removes a reserved key so the request can be retried
+	Release(ctx context.Context, key string) error
+
+	// DeleteExpired removes records that expired before the cutoff and
+	// returns how many were removed
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
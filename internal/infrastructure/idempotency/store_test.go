@@ -0,0 +1,118 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Each connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(t, db.AutoMigrate(&KeyModel{}))
+	return db
+}
+
+// forEachStore runs test against every Store implementation
+func forEachStore(t *testing.T, test func(t *testing.T, store Store)) {
+	t.Run("gorm", func(t *testing.T) { test(t, NewGormStore(setupTestDB(t))) })
+	t.Run("memory", func(t *testing.T) { test(t, NewMemoryStore()) })
+}
+
+func TestStore_ReserveAndComplete(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ctx := context.Background()
+		rec := &Record{Key: "key-1", RequestHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+
+		existing, reserved, err := store.Reserve(ctx, rec)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Nil(t, existing)
+
+		// A second reservation sees the in-progress record
+		existing, reserved, err = store.Reserve(ctx, rec)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.False(t, existing.Completed())
+
+		require.NoError(t, store.Complete(ctx, "key-1", 201, http.Header{
+			"Content-Type": {"application/json"},
+			"Location":     {"/users/1"},
+		}, []byte(`{"id":"1"}`)))
+
+		existing, reserved, err = store.Reserve(ctx, rec)
+		require.NoError(t, err)
+		assert.False(t, reserved)
+		assert.True(t, existing.Completed())
+		assert.Equal(t, 201, existing.StatusCode)
+		assert.Equal(t, "application/json", existing.Header.Get("Content-Type"))
+		assert.Equal(t, "/users/1", existing.Header.Get("Location"))
+		assert.Equal(t, []byte(`{"id":"1"}`), existing.Body)
+		assert.Equal(t, "hash", existing.RequestHash)
+	})
+}
+
+func TestStore_Release(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ctx := context.Background()
+		rec := &Record{Key: "key-1", RequestHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+
+		_, reserved, err := store.Reserve(ctx, rec)
+		require.NoError(t, err)
+		require.True(t, reserved)
+
+		require.NoError(t, store.Release(ctx, "key-1"))
+
+		_, reserved, err = store.Reserve(ctx, rec)
+		require.NoError(t, err)
+		assert.True(t, reserved)
+	})
+}
+
+func TestStore_ExpiredKeyCanBeReused(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ctx := context.Background()
+
+		_, _, err := store.Reserve(ctx, &Record{Key: "key-1", RequestHash: "old", ExpiresAt: time.Now().Add(-time.Minute)})
+		require.NoError(t, err)
+
+		existing, reserved, err := store.Reserve(ctx, &Record{Key: "key-1", RequestHash: "new", ExpiresAt: time.Now().Add(time.Hour)})
+		require.NoError(t, err)
+		assert.True(t, reserved)
+		assert.Nil(t, existing)
+	})
+}
+
+func TestStore_DeleteExpired(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ctx := context.Background()
+		now := time.Now()
+
+		_, _, err := store.Reserve(ctx, &Record{Key: "expired", RequestHash: "h", ExpiresAt: now.Add(-time.Minute)})
+		require.NoError(t, err)
+		_, _, err = store.Reserve(ctx, &Record{Key: "live", RequestHash: "h", ExpiresAt: now.Add(time.Hour)})
+		require.NoError(t, err)
+
+		deleted, err := store.DeleteExpired(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		_, reserved, err := store.Reserve(ctx, &Record{Key: "live", RequestHash: "h", ExpiresAt: now.Add(time.Hour)})
+		require.NoError(t, err)
+		assert.False(t, reserved)
+	})
+}
@@ -0,0 +1,74 @@
+package idgen
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+const (
+	KindUUIDv7 = "uuidv7"
+	KindUUIDv4 = "uuidv4"
+)
+
+// Generator creates identifiers for new entities
+type Generator interface {
+	NewID() string
+}
+
+// New creates the generator selected by the configured kind
+func New(kind string) (Generator, error) {
+	switch kind {
+	case KindUUIDv7, "":
+		return UUIDv7{}, nil
+	case KindUUIDv4:
+		return UUIDv4{}, nil
+	default:
+		return nil, fmt.Errorf("unknown id generator: %q", kind)
+	}
+}
+
+// UUIDv7 generates time-ordered UUIDs, so rows inserted together land
+// close together in primary key indexes
+type UUIDv7 struct{}
+
+// NewID returns a new version 7 UUID
+func (UUIDv7) NewID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
+
+// UUIDv4 generates random UUIDs
+type UUIDv4 struct{}
+
+// NewID returns a new version 4 UUID
+func (UUIDv4) NewID() string {
+	return uuid.NewString()
+}
+
+// Sequential is a generator for tests. It returns valid UUIDs numbered
+// from 1, so expected IDs can be written out in assertions.
+type Sequential struct {
+	mu   sync.Mutex
+	next int
+}
+
+// NewSequential creates a sequential generator starting at 1
+func NewSequential() *Sequential {
+	return &Sequential{next: 1}
+}
+
+// NewID returns the next UUID in the sequence
+func (s *Sequential) NewID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := SequentialID(s.next)
+	s.next++
+	return id
+}
+
+// SequentialID returns the nth ID handed out by a sequential generator
+func SequentialID(n int) string {
+	return fmt.Sprintf("00000000-0000-0000-0000-%012d", n)
+}
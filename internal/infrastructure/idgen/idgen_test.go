@@ -0,0 +1,62 @@
+package idgen
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		expected Generator
+		wantErr  bool
+	}{
+		{name: "default", kind: "", expected: UUIDv7{}},
+		{name: "uuidv7", kind: KindUUIDv7, expected: UUIDv7{}},
+		{name: "uuidv4", kind: KindUUIDv4, expected: UUIDv4{}},
+		{name: "unknown", kind: "snowflake", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, err := New(tt.kind)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, gen)
+		})
+	}
+}
+
+func TestUUIDv7(t *testing.T) {
+	first, err := uuid.Parse(UUIDv7{}.NewID())
+	require.NoError(t, err)
+	second, err := uuid.Parse(UUIDv7{}.NewID())
+	require.NoError(t, err)
+
+	assert.Equal(t, uuid.Version(7), first.Version())
+	assert.NotEqual(t, first, second)
+	assert.Less(t, first.String(), second.String(), "IDs sort in creation order")
+}
+
+func TestUUIDv4(t *testing.T) {
+	id, err := uuid.Parse(UUIDv4{}.NewID())
+	require.NoError(t, err)
+	assert.Equal(t, uuid.Version(4), id.Version())
+}
+
+func TestSequential(t *testing.T) {
+	gen := NewSequential()
+
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", gen.NewID())
+	assert.Equal(t, SequentialID(2), gen.NewID())
+
+	_, err := uuid.Parse(SequentialID(3))
+	assert.NoError(t, err, "sequential IDs fit UUID columns")
+}
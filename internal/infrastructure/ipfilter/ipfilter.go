@@ -0,0 +1,94 @@
+// Package ipfilter restricts route groups, such as /admin or /metrics, to
+// client networks. Each group has an allowlist and a denylist of CIDR
+// prefixes, checked against the client address resolved by clientip. The
+// rules can be replaced while the server runs.
+package ipfilter
+
+import (
+	"expvar"
+	"net/http"
+	"net/netip"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// metrics publishes filter counters under /debug/vars
+var metrics = expvar.NewMap("ip_filter")
+
+// Rules restricts the client addresses of a route group. Deny wins over
+// Allow; an empty Allow allows every address that is not denied.
+type Rules struct {
+	Allow []netip.Prefix
+	Deny  []netip.Prefix
+}
+
+// Options configures the filter
+type Options struct {
+	// Routes holds the rules of each route group, keyed by path prefix.
+	// Other routes are not filtered.
+	Routes map[string]Rules
+}
+
+// Filter enforces allow and deny lists on route groups
+type Filter struct {
+	opts atomic.Pointer[Options]
+	log  *logger.Logger
+}
+
+// New creates a filter enforcing opts
+func New(opts Options, log *logger.Logger) *Filter {
+	f := &Filter{log: log}
+	f.Update(opts)
+	return f
+}
+
+// Update replaces the rules of the filter. Requests already let through
+// are not affected.
+func (f *Filter) Update(opts Options) {
+	f.opts.Store(&opts)
+}
+
+// Middleware rejects requests to filtered route groups from addresses the
+// rules do not allow with 403. It must be served behind
+// clientip.Middleware to see the addresses of clients behind proxies.
+func (f *Filter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		opts := f.opts.Load()
+		prefix, ok := request.MatchPrefix(opts.Routes, c.Request.URL.Path)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		addr := clientip.FromRequest(c.Request)
+		if !addr.IsValid() || !opts.Routes[prefix].Allows(addr) {
+			metrics.Add("denied", 1)
+			f.log.Warn().
+				Ctx(c.Request.Context()).
+				Str("method", c.Request.Method).
+				Str("path", c.Request.URL.Path).
+				Str("remote_ip", c.RemoteIP()).
+				Str("client_ip", addr.String()).
+				Msg("Request denied by IP filter")
+
+			problem.Abort(c, problem.New(http.StatusForbidden, "access is not allowed from this address"))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Allows reports whether the rules let addr through
+func (r Rules) Allows(addr netip.Addr) bool {
+	if clientip.Contains(r.Deny, addr) {
+		return false
+	}
+	return len(r.Allow) == 0 || clientip.Contains(r.Allow, addr)
+}
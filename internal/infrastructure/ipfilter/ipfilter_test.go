@@ -0,0 +1,100 @@
+package ipfilter
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+func networks(t *testing.T, values ...string) []netip.Prefix {
+	t.Helper()
+	parsed, err := clientip.ParseNetworks(values)
+	require.NoError(t, err)
+	return parsed
+}
+
+// setupRouter returns a router whose /admin, /metrics and /users routes are
+// served behind filter, trusting X-Forwarded-For from trustedProxies
+func setupRouter(filter *Filter, trustedProxies []netip.Prefix) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(clientip.NewResolver(trustedProxies).Middleware())
+	router.Use(filter.Middleware())
+	for _, path := range []string{"/admin/ping", "/metrics", "/users"} {
+		router.GET(path, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	}
+	return router
+}
+
+func serve(router *gin.Engine, path, remoteAddr, forwarded string) int {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = remoteAddr
+	if forwarded != "" {
+		req.Header.Set("X-Forwarded-For", forwarded)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestMiddleware(t *testing.T) {
+	filter := New(Options{
+		Routes: map[string]Rules{
+			"/admin":   {Allow: networks(t, "192.168.0.0/16"), Deny: networks(t, "192.168.9.0/24")},
+			"/metrics": {Allow: networks(t, "127.0.0.1", "::1")},
+			"/users":   {Deny: networks(t, "203.0.113.0/24")},
+		},
+	}, logger.New("info", io.Discard))
+	router := setupRouter(filter, networks(t, "10.0.0.1"))
+
+	tests := []struct {
+		name       string
+		path       string
+		remoteAddr string
+		forwarded  string
+		wantStatus int
+	}{
+		{name: "allowed network", path: "/admin/ping", remoteAddr: "192.168.1.5:1234", wantStatus: http.StatusNoContent},
+		{name: "outside the allowlist", path: "/admin/ping", remoteAddr: "198.51.100.1:1234", wantStatus: http.StatusForbidden},
+		{name: "deny wins over allow", path: "/admin/ping", remoteAddr: "192.168.9.5:1234", wantStatus: http.StatusForbidden},
+		{name: "single address", path: "/metrics", remoteAddr: "127.0.0.1:1234", wantStatus: http.StatusNoContent},
+		{name: "IPv6 address", path: "/metrics", remoteAddr: "[::1]:1234", wantStatus: http.StatusNoContent},
+		{name: "denylist only", path: "/users", remoteAddr: "203.0.113.7:1234", wantStatus: http.StatusForbidden},
+		{name: "outside the denylist", path: "/users", remoteAddr: "198.51.100.1:1234", wantStatus: http.StatusNoContent},
+		{name: "forwarded by a trusted proxy", path: "/admin/ping", remoteAddr: "10.0.0.1:1234", forwarded: "192.168.1.5", wantStatus: http.StatusNoContent},
+		{name: "forwarded header of an untrusted client is ignored", path: "/admin/ping", remoteAddr: "198.51.100.1:1234", forwarded: "192.168.1.5", wantStatus: http.StatusForbidden},
+		{name: "forged hops left of the proxy are ignored", path: "/admin/ping", remoteAddr: "10.0.0.1:1234", forwarded: "192.168.1.5, 198.51.100.1", wantStatus: http.StatusForbidden},
+		{name: "malformed forwarded address counts as the proxy", path: "/admin/ping", remoteAddr: "10.0.0.1:1234", forwarded: "not-an-ip", wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantStatus, serve(router, tt.path, tt.remoteAddr, tt.forwarded))
+		})
+	}
+}
+
+func TestFilter_Update(t *testing.T) {
+	filter := New(Options{
+		Routes: map[string]Rules{"/admin": {Allow: networks(t, "192.168.0.0/16")}},
+	}, logger.New("info", io.Discard))
+	router := setupRouter(filter, nil)
+
+	assert.Equal(t, http.StatusForbidden, serve(router, "/admin/ping", "198.51.100.1:1234", ""))
+
+	filter.Update(Options{
+		Routes: map[string]Rules{"/admin": {Allow: networks(t, "198.51.100.0/24")}},
+	})
+	assert.Equal(t, http.StatusNoContent, serve(router, "/admin/ping", "198.51.100.1:1234", ""))
+	assert.Equal(t, http.StatusForbidden, serve(router, "/admin/ping", "192.168.1.5:1234", ""))
+}
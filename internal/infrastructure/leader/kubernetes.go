@@ -0,0 +1,286 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Files and variables describing the service account of a pod
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	serviceHostEnv    = "KUBERNETES_SERVICE_HOST"
+	servicePortEnv    = "KUBERNETES_SERVICE_PORT"
+)
+
+// The Lease resource of the API server
+const (
+	leaseAPIVersion    = "coordination.k8s.io/v1"
+	leaseKind          = "Lease"
+	leaseResourcesPath = "/apis/coordination.k8s.io/v1/namespaces/%s/leases"
+
+	// microTimeFormat formats the acquire and renew times of a Lease
+	microTimeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// maxErrorBodyBytes bounds the response body quoted in errors
+const maxErrorBodyBytes = 1024
+
+// KubernetesOptions configures a KubernetesLease
+type KubernetesOptions struct {
+	// Host is the URL of the API server
+	Host string
+
+	// Namespace holds the Lease object
+	Namespace string
+
+	// TokenFile holds the bearer token authenticating requests. It is read
+	// on every request, as the kubelet rotates it; no token is sent when
+	// empty.
+	TokenFile string
+
+	// Client sends the requests; http.DefaultClient when nil
+	Client *http.Client
+}
+
+// InCluster returns the options reaching the API server with the service
+// account of the pod the application runs in. An empty namespace uses the
+// namespace of the pod.
+func InCluster(namespace string) (KubernetesOptions, error) {
+	host, port := os.Getenv(serviceHostEnv), os.Getenv(servicePortEnv)
+	if host == "" || port == "" {
+		return KubernetesOptions{}, errors.New("not running in a Kubernetes cluster: KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return KubernetesOptions{}, fmt.Errorf("failed to read the cluster CA: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca) {
+		return KubernetesOptions{}, errors.New("no certificate found in the cluster CA")
+	}
+
+	if namespace == "" {
+		data, err := os.ReadFile(serviceAccountDir + "/namespace")
+		if err != nil {
+			return KubernetesOptions{}, fmt.Errorf("failed to read the pod namespace: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+
+	return KubernetesOptions{
+		Host:      "https://" + net.JoinHostPort(host, port),
+		Namespace: namespace,
+		TokenFile: serviceAccountDir + "/token",
+		Client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12},
+			},
+		},
+	}, nil
+}
+
+// KubernetesLease implements Lease with coordination.k8s.io/v1 Lease
+// objects, the leases Kubernetes' own controllers elect their leaders with.
+// The service account needs the get, create and update verbs on leases in
+// the namespace.
+type KubernetesLease struct {
+	opts KubernetesOptions
+}
+
+// NewKubernetesLease creates a new lease stored in the API server
+func NewKubernetesLease(opts KubernetesOptions) *KubernetesLease {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	return &KubernetesLease{opts: opts}
+}
+
+// leaseObject is a Lease object. The metadata is kept as received, so an
+// update sends back its resourceVersion and fails when the Lease changed
+// since it was read.
+type leaseObject struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Metadata   json.RawMessage `json:"metadata"`
+	Spec       leaseSpec       `json:"spec"`
+}
+
+// leaseSpec is the spec of a Lease object
+type leaseSpec struct {
+	HolderIdentity       string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          string `json:"acquireTime,omitempty"`
+	RenewTime            string `json:"renewTime,omitempty"`
+	LeaseTransitions     int    `json:"leaseTransitions,omitempty"`
+}
+
+// expired reports whether the holder let the lease expire by now
+func (s leaseSpec) expired(now time.Time) bool {
+	if s.HolderIdentity == "" {
+		return true
+	}
+	renewed, err := time.Parse(time.RFC3339Nano, s.RenewTime)
+	if err != nil {
+		return true
+	}
+	return now.After(renewed.Add(time.Duration(s.LeaseDurationSeconds) * time.Second))
+}
+
+// TryAcquire reads the Lease object and, unless another holder keeps it,
+// updates it. An update racing another one fails with a conflict, which
+// means the other instance won.
+func (l *KubernetesLease) TryAcquire(ctx context.Context, name, identity string, duration time.Duration) (bool, error) {
+	now := time.Now()
+	seconds := max(int(duration.Round(time.Second)/time.Second), 1)
+
+	lease, found, err := l.get(ctx, name)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		metadata, err := json.Marshal(map[string]string{"name": name, "namespace": l.opts.Namespace})
+		if err != nil {
+			return false, err
+		}
+		return l.write(ctx, http.MethodPost, l.resources(), &leaseObject{
+			APIVersion: leaseAPIVersion,
+			Kind:       leaseKind,
+			Metadata:   metadata,
+			Spec: leaseSpec{
+				HolderIdentity:       identity,
+				LeaseDurationSeconds: seconds,
+				AcquireTime:          now.UTC().Format(microTimeFormat),
+				RenewTime:            now.UTC().Format(microTimeFormat),
+			},
+		})
+	}
+
+	spec := &lease.Spec
+	if spec.HolderIdentity != identity {
+		if !spec.expired(now) {
+			return false, nil
+		}
+		if spec.HolderIdentity != "" {
+			spec.LeaseTransitions++
+		}
+		spec.HolderIdentity = identity
+		spec.AcquireTime = now.UTC().Format(microTimeFormat)
+	}
+	spec.LeaseDurationSeconds = seconds
+	spec.RenewTime = now.UTC().Format(microTimeFormat)
+
+	return l.write(ctx, http.MethodPut, l.resource(name), lease)
+}
+
+// Release clears the holder of the Lease object if identity holds it
+func (l *KubernetesLease) Release(ctx context.Context, name, identity string) error {
+	lease, found, err := l.get(ctx, name)
+	if err != nil || !found || lease.Spec.HolderIdentity != identity {
+		return err
+	}
+
+	lease.Spec.HolderIdentity = ""
+	lease.Spec.AcquireTime = ""
+	lease.Spec.RenewTime = ""
+	// Another instance updated the Lease first when this fails
+	_, err = l.write(ctx, http.MethodPut, l.resource(name), lease)
+	return err
+}
+
+// get reads the Lease object named name
+func (l *KubernetesLease) get(ctx context.Context, name string) (*leaseObject, bool, error) {
+	resp, err := l.do(ctx, http.MethodGet, l.resource(name), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, statusError(resp)
+	}
+
+	var lease leaseObject
+	if err := json.NewDecoder(resp.Body).Decode(&lease); err != nil {
+		return nil, false, fmt.Errorf("failed to decode lease %s: %w", name, err)
+	}
+	return &lease, true, nil
+}
+
+// write creates or updates the Lease object, reporting false when another
+// instance changed it first
+func (l *KubernetesLease) write(ctx context.Context, method, path string, lease *leaseObject) (bool, error) {
+	body, err := json.Marshal(lease)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := l.do(ctx, method, path, body)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return true, nil
+	case http.StatusConflict:
+		return false, nil
+	default:
+		return false, statusError(resp)
+	}
+}
+
+// do sends a request to the API server
+func (l *KubernetesLease) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, l.opts.Host+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if l.opts.TokenFile != "" {
+		token, err := os.ReadFile(l.opts.TokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read service account token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	return l.opts.Client.Do(req)
+}
+
+// resources returns the path of the Lease objects of the namespace
+func (l *KubernetesLease) resources() string {
+	return fmt.Sprintf(leaseResourcesPath, url.PathEscape(l.opts.Namespace))
+}
+
+// resource returns the path of the Lease object named name
+func (l *KubernetesLease) resource(name string) string {
+	return l.resources() + "/" + url.PathEscape(name)
+}
+
+// statusError describes an unexpected response of the API server
+func statusError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	return fmt.Errorf("kubernetes API server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,280 @@
+// Package leader elects one instance of the application as the leader, so
+// background components that must not run twice, such as the scheduler and
+// change data capture, run on exactly one replica.
+//
+// The leader holds a lease, a record naming it that expires unless it is
+// renewed. An instance that cannot renew its lease within the renew
+// deadline stops leading before the lease expires, so another instance only
+// takes over once the former leader has stopped.
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"expvar"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// metrics publishes per-lease election counters under /debug/vars
+var metrics = expvar.NewMap("leader")
+
+// Defaults of the election timings
+const (
+	DefaultLeaseDuration = 15 * time.Second
+	DefaultRenewDeadline = 10 * time.Second
+	DefaultRetryPeriod   = 2 * time.Second
+)
+
+// releaseTimeout bounds giving up the lease on shutdown
+const releaseTimeout = 5 * time.Second
+
+// Lease stores who holds the leadership of an election
+type Lease interface {
+	// TryAcquire takes the lease named name for identity for duration when
+	// nobody holds it or its holder let it expire, renews it when identity
+	// holds it, and reports whether identity holds it
+	TryAcquire(ctx context.Context, name, identity string, duration time.Duration) (bool, error)
+
+	// Release gives up the lease named name if identity holds it, so
+	// another instance takes over without waiting for it to expire
+	Release(ctx context.Context, name, identity string) error
+}
+
+// Options configures an Elector
+type Options struct {
+	// Name names the lease; instances electing a leader share it
+	Name string
+
+	// Identity tells this instance apart from the others; DefaultIdentity
+	// when empty
+	Identity string
+
+	// LeaseDuration is how long other instances wait for the leader to
+	// renew the lease before taking it over; DefaultLeaseDuration when zero
+	LeaseDuration time.Duration
+
+	// RenewDeadline is how long the leader keeps leading without renewing
+	// the lease; DefaultRenewDeadline when zero. It must be shorter than
+	// LeaseDuration.
+	RenewDeadline time.Duration
+
+	// RetryPeriod is how often the lease is taken or renewed;
+	// DefaultRetryPeriod when zero
+	RetryPeriod time.Duration
+
+	// OnStartedLeading runs when this instance becomes the leader. ctx is
+	// cancelled when it stops leading, and leadership is only handed over
+	// once OnStartedLeading returned.
+	OnStartedLeading func(ctx context.Context)
+
+	// OnStoppedLeading runs when this instance stops leading, after
+	// OnStartedLeading returned
+	OnStoppedLeading func()
+}
+
+// Elector takes part in the election of a leader among the instances
+// sharing a lease
+type Elector struct {
+	lease Lease
+	opts  Options
+	log   *logger.Logger
+
+	leading atomic.Bool
+	cancel  context.CancelFunc
+	done    chan struct{}
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// New creates a new Elector taking part in the election held on lease
+func New(lease Lease, opts Options, log *logger.Logger) (*Elector, error) {
+	if opts.Identity == "" {
+		identity, err := DefaultIdentity()
+		if err != nil {
+			return nil, err
+		}
+		opts.Identity = identity
+	}
+	if opts.LeaseDuration <= 0 {
+		opts.LeaseDuration = DefaultLeaseDuration
+	}
+	if opts.RenewDeadline <= 0 {
+		opts.RenewDeadline = DefaultRenewDeadline
+	}
+	if opts.RetryPeriod <= 0 {
+		opts.RetryPeriod = DefaultRetryPeriod
+	}
+	if opts.RenewDeadline >= opts.LeaseDuration {
+		return nil, fmt.Errorf("renew deadline %s must be shorter than the lease duration %s", opts.RenewDeadline, opts.LeaseDuration)
+	}
+
+	return &Elector{
+		lease: lease,
+		opts:  opts,
+		log:   log,
+	}, nil
+}
+
+// DefaultIdentity returns the host name, which is the pod name on
+// Kubernetes, followed by a random suffix telling restarts apart
+func DefaultIdentity() (string, error) {
+	host, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get host name: %w", err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return host + "_" + hex.EncodeToString(suffix), nil
+}
+
+// Identity returns the identity this instance holds the lease with
+func (e *Elector) Identity() string {
+	return e.opts.Identity
+}
+
+// IsLeader reports whether this instance leads
+func (e *Elector) IsLeader() bool {
+	return e.leading.Load()
+}
+
+// Check fails while the lease cannot be taken or renewed, as then no
+// instance may be leading
+func (e *Elector) Check(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.lastErr != nil {
+		return fmt.Errorf("leader election failed: %w", e.lastErr)
+	}
+	return nil
+}
+
+// Run takes part in the election until ctx is done, and then stops leading
+// and gives up the lease
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.opts.RetryPeriod)
+	defer ticker.Stop()
+
+	var renewed time.Time
+	for {
+		held, err := e.tryAcquire(ctx)
+		switch {
+		case err == nil && held:
+			renewed = time.Now()
+			if !e.IsLeader() {
+				e.startLeading(ctx)
+			}
+		case e.IsLeader() && err == nil:
+			// Another instance took over the lease
+			e.stopLeading()
+		case e.IsLeader() && time.Since(renewed) >= e.opts.RenewDeadline:
+			// Stop before the lease expires and another instance takes over
+			e.stopLeading()
+		}
+
+		select {
+		case <-ctx.Done():
+			e.resign(ctx, !renewed.IsZero())
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryAcquire takes or renews the lease, recording the outcome
+func (e *Elector) tryAcquire(ctx context.Context) (bool, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, e.opts.RetryPeriod)
+	defer cancel()
+
+	held, err := e.lease.TryAcquire(attemptCtx, e.opts.Name, e.opts.Identity, e.opts.LeaseDuration)
+	if err != nil && ctx.Err() != nil {
+		// Interrupted by shutdown, which gives up the lease anyway
+		return false, nil
+	}
+
+	e.mu.Lock()
+	e.lastErr = err
+	e.mu.Unlock()
+
+	if err != nil {
+		metrics.Add(e.opts.Name+".errors", 1)
+		e.log.Error().
+			Err(err).
+			Str("lease", e.opts.Name).
+			Msg("Failed to take part in leader election")
+	}
+	return held, err
+}
+
+// startLeading runs OnStartedLeading until this instance stops leading
+func (e *Elector) startLeading(ctx context.Context) {
+	ctx, e.cancel = context.WithCancel(ctx)
+	e.done = make(chan struct{})
+	e.leading.Store(true)
+
+	leading := new(expvar.Int)
+	leading.Set(1)
+	metrics.Set(e.opts.Name+".leading", leading)
+	metrics.Add(e.opts.Name+".elected", 1)
+	e.log.Info().
+		Str("lease", e.opts.Name).
+		Str("identity", e.opts.Identity).
+		Msg("Started leading")
+
+	go func() {
+		defer close(e.done)
+		if e.opts.OnStartedLeading != nil {
+			e.opts.OnStartedLeading(ctx)
+		}
+	}()
+}
+
+// stopLeading cancels OnStartedLeading, waits for it to return and runs
+// OnStoppedLeading
+func (e *Elector) stopLeading() {
+	e.cancel()
+	<-e.done
+	e.leading.Store(false)
+
+	metrics.Set(e.opts.Name+".leading", new(expvar.Int))
+	metrics.Add(e.opts.Name+".deposed", 1)
+	e.log.Info().
+		Str("lease", e.opts.Name).
+		Str("identity", e.opts.Identity).
+		Msg("Stopped leading")
+
+	if e.opts.OnStoppedLeading != nil {
+		e.opts.OnStoppedLeading()
+	}
+}
+
+// resign stops leading on shutdown and gives up the lease if this instance
+// held it
+func (e *Elector) resign(ctx context.Context, held bool) {
+	if e.IsLeader() {
+		e.stopLeading()
+	}
+	if !held {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+	defer cancel()
+	if err := e.lease.Release(ctx, e.opts.Name, e.opts.Identity); err != nil {
+		e.log.Error().
+			Err(err).
+			Str("lease", e.opts.Name).
+			Msg("Failed to release leader lease")
+	}
+}
@@ -0,0 +1,182 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// fakeLease is a lease whose holder and failures the tests set
+type fakeLease struct {
+	mu       sync.Mutex
+	holder   string
+	err      error
+	released []string
+}
+
+func (l *fakeLease) TryAcquire(_ context.Context, _, identity string, _ time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err != nil {
+		return false, l.err
+	}
+	if l.holder == "" {
+		l.holder = identity
+	}
+	return l.holder == identity, nil
+}
+
+func (l *fakeLease) Release(_ context.Context, _, identity string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.released = append(l.released, identity)
+	if l.holder == identity {
+		l.holder = ""
+	}
+	return nil
+}
+
+// set changes the holder and the failure of the lease
+func (l *fakeLease) set(holder string, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.holder, l.err = holder, err
+}
+
+// fastOptions returns election timings short enough for tests
+func fastOptions() Options {
+	return Options{
+		Name:          "test",
+		Identity:      "pod-a",
+		LeaseDuration: 150 * time.Millisecond,
+		RenewDeadline: 100 * time.Millisecond,
+		RetryPeriod:   10 * time.Millisecond,
+	}
+}
+
+// runElector runs an elector until the test ends, returning the channels
+// signalled when it starts and stops leading
+func runElector(t *testing.T, lease Lease, opts Options) (*Elector, chan context.Context, chan struct{}) {
+	started := make(chan context.Context, 10)
+	stopped := make(chan struct{}, 10)
+	opts.OnStartedLeading = func(ctx context.Context) {
+		started <- ctx
+		<-ctx.Done()
+	}
+	opts.OnStoppedLeading = func() { stopped <- struct{}{} }
+
+	elector, err := New(lease, opts, logger.New("error", &bytes.Buffer{}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return elector, started, stopped
+}
+
+// receive waits for a value from ch
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+		var zero T
+		return zero
+	}
+}
+
+func TestElector_LeadsWhileHoldingTheLease(t *testing.T) {
+	lease := &fakeLease{}
+	elector, started, stopped := runElector(t, lease, fastOptions())
+
+	leadCtx := receive(t, started)
+	assert.True(t, elector.IsLeader())
+	assert.NoError(t, elector.Check(context.Background()))
+
+	// Another instance took over the lease
+	lease.set("pod-b", nil)
+	receive(t, stopped)
+	assert.Error(t, leadCtx.Err(), "leading was cancelled")
+	assert.False(t, elector.IsLeader())
+
+	// The lease is free again
+	lease.set("", nil)
+	receive(t, started)
+	assert.True(t, elector.IsLeader())
+}
+
+func TestElector_StopsLeadingPastTheRenewDeadline(t *testing.T) {
+	lease := &fakeLease{}
+	elector, started, stopped := runElector(t, lease, fastOptions())
+	receive(t, started)
+
+	lease.set("pod-a", errors.New("connection refused"))
+	assert.Eventually(t, func() bool {
+		return elector.Check(context.Background()) != nil
+	}, time.Second, 5*time.Millisecond)
+	assert.True(t, elector.IsLeader(), "a failed renewal is retried until the deadline")
+
+	receive(t, stopped)
+	assert.False(t, elector.IsLeader())
+	assert.EqualError(t, elector.Check(context.Background()), "leader election failed: connection refused")
+}
+
+func TestElector_ReleasesTheLeaseOnShutdown(t *testing.T) {
+	lease := &fakeLease{}
+	elector, err := New(lease, fastOptions(), logger.New("error", &bytes.Buffer{}))
+	require.NoError(t, err)
+
+	stopped := make(chan struct{})
+	elector.opts.OnStartedLeading = func(ctx context.Context) { <-ctx.Done() }
+	elector.opts.OnStoppedLeading = func() { close(stopped) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx)
+	}()
+	require.Eventually(t, elector.IsLeader, time.Second, 5*time.Millisecond)
+
+	cancel()
+	receive(t, done)
+	receive(t, stopped)
+	assert.Equal(t, []string{"pod-a"}, lease.released)
+	assert.Empty(t, lease.holder)
+}
+
+func TestNew_RenewDeadlineMustBeShorterThanTheLease(t *testing.T) {
+	opts := fastOptions()
+	opts.RenewDeadline = opts.LeaseDuration
+
+	_, err := New(&fakeLease{}, opts, logger.New("error", &bytes.Buffer{}))
+	assert.EqualError(t, err, "renew deadline 150ms must be shorter than the lease duration 150ms")
+}
+
+func TestDefaultIdentity(t *testing.T) {
+	first, err := DefaultIdentity()
+	require.NoError(t, err)
+	second, err := DefaultIdentity()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second, "restarts on the same host are told apart")
+}
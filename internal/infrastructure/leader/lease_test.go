@@ -0,0 +1,207 @@
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// fakeAPIServer serves the Lease objects of a namespace like the
+// Kubernetes API server, rejecting updates with a stale resourceVersion
+type fakeAPIServer struct {
+	mu      sync.Mutex
+	leases  map[string]leaseObject
+	version int
+	token   string
+}
+
+// metadata is the part of the metadata of a Lease the fake reads
+type metadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+func (s *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	prefix := "/apis/coordination.k8s.io/v1/namespaces/jobs/leases"
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+
+	var body leaseObject
+	var meta metadata
+	if r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.Unmarshal(body.Metadata, &meta); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		lease, ok := s.leases[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(lease)
+	case http.MethodPost:
+		if _, ok := s.leases[meta.Name]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.save(meta, body)
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodPut:
+		var current metadata
+		_ = json.Unmarshal(s.leases[name].Metadata, &current)
+		if current.ResourceVersion != meta.ResourceVersion {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		s.save(meta, body)
+	}
+}
+
+// save stores the lease with the next resourceVersion
+func (s *fakeAPIServer) save(meta metadata, lease leaseObject) {
+	s.version++
+	meta.ResourceVersion = strconv.Itoa(s.version)
+	lease.Metadata, _ = json.Marshal(meta)
+	s.leases[meta.Name] = lease
+}
+
+// expire moves the renewal of every lease back past its duration
+func (s *fakeAPIServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, lease := range s.leases {
+		lease.Spec.RenewTime = time.Now().Add(-time.Hour).UTC().Format(microTimeFormat)
+		s.leases[name] = lease
+	}
+}
+
+// forEachLease runs test against every Lease implementation. expire makes
+// the holders of the leases miss their renewal.
+func forEachLease(t *testing.T, test func(t *testing.T, lease Lease, expire func())) {
+	t.Run("postgres", func(t *testing.T) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&LeaseModel{}))
+
+		test(t, NewPostgresLease(db), func() {
+			require.NoError(t, db.Model(&LeaseModel{}).Where("1 = 1").Update("expires_at", time.Now().Add(-time.Hour)).Error)
+		})
+	})
+	t.Run("kubernetes", func(t *testing.T) {
+		server := &fakeAPIServer{leases: make(map[string]leaseObject), token: "secret"}
+		httpServer := httptest.NewServer(server)
+		t.Cleanup(httpServer.Close)
+
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(tokenFile, []byte("secret\n"), 0o600))
+
+		test(t, NewKubernetesLease(KubernetesOptions{
+			Host:      httpServer.URL,
+			Namespace: "jobs",
+			TokenFile: tokenFile,
+		}), server.expire)
+	})
+}
+
+func TestLease_TryAcquire(t *testing.T) {
+	forEachLease(t, func(t *testing.T, lease Lease, _ func()) {
+		ctx := context.Background()
+
+		held, err := lease.TryAcquire(ctx, "scheduler", "pod-a", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, held, "nobody held the lease")
+
+		held, err = lease.TryAcquire(ctx, "scheduler", "pod-b", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, held, "pod-a holds the lease")
+
+		held, err = lease.TryAcquire(ctx, "scheduler", "pod-a", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, held, "the holder renews the lease")
+
+		held, err = lease.TryAcquire(ctx, "cdc", "pod-b", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, held, "other names are separate leases")
+	})
+}
+
+func TestLease_ExpiredLeaseIsTakenOver(t *testing.T) {
+	forEachLease(t, func(t *testing.T, lease Lease, expire func()) {
+		ctx := context.Background()
+
+		held, err := lease.TryAcquire(ctx, "scheduler", "pod-a", time.Minute)
+		require.NoError(t, err)
+		require.True(t, held)
+
+		expire()
+		held, err = lease.TryAcquire(ctx, "scheduler", "pod-b", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, held)
+
+		held, err = lease.TryAcquire(ctx, "scheduler", "pod-a", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, held, "the former holder lost the lease")
+	})
+}
+
+func TestLease_Release(t *testing.T) {
+	forEachLease(t, func(t *testing.T, lease Lease, _ func()) {
+		ctx := context.Background()
+
+		held, err := lease.TryAcquire(ctx, "scheduler", "pod-a", time.Minute)
+		require.NoError(t, err)
+		require.True(t, held)
+
+		// Only the holder gives the lease up
+		require.NoError(t, lease.Release(ctx, "scheduler", "pod-b"))
+		held, err = lease.TryAcquire(ctx, "scheduler", "pod-b", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, held)
+
+		require.NoError(t, lease.Release(ctx, "scheduler", "pod-a"))
+		held, err = lease.TryAcquire(ctx, "scheduler", "pod-b", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, held, "the lease was given up")
+	})
+}
+
+func TestKubernetesLease_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `leases.coordination.k8s.io "scheduler" is forbidden`, http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	lease := NewKubernetesLease(KubernetesOptions{Host: server.URL, Namespace: "jobs"})
+	_, err := lease.TryAcquire(context.Background(), "scheduler", "pod-a", time.Minute)
+	assert.EqualError(t, err, `kubernetes API server returned 403 Forbidden: leases.coordination.k8s.io "scheduler" is forbidden`)
+}
@@ -0,0 +1,75 @@
+package leader
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LeaseModel represents the database model of a lease
+type LeaseModel struct {
+	Name        string    `gorm:"type:varchar(255);primaryKey"`
+	Holder      string    `gorm:"type:varchar(255);not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	Transitions int       `gorm:"not null;default:0"`
+}
+
+// TableName specifies the table name for LeaseModel
+func (LeaseModel) TableName() string {
+	return "leader_leases"
+}
+
+// PostgresLease implements Lease with a row per lease in the leader_leases
+// table, for deployments outside Kubernetes. Expiry is decided with the
+// clocks of the instances, so they must not drift apart by more than
+// the lease duration less the renew deadline.
+type PostgresLease struct {
+	db *gorm.DB
+}
+
+// NewPostgresLease creates a new lease stored in db
+func NewPostgresLease(db *gorm.DB) *PostgresLease {
+	return &PostgresLease{db: db}
+}
+
+// TryAcquire renews the lease if identity holds it or takes it over if it
+// expired, and creates it when missing. Each is a single statement, so two
+// instances cannot both take the lease.
+func (l *PostgresLease) TryAcquire(ctx context.Context, name, identity string, duration time.Duration) (bool, error) {
+	now := time.Now()
+	db := l.db.WithContext(ctx)
+
+	result := db.Model(&LeaseModel{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, identity, now).
+		Updates(map[string]any{
+			"holder":      identity,
+			"expires_at":  now.Add(duration),
+			"transitions": gorm.Expr("CASE WHEN holder = ? THEN transitions ELSE transitions + 1 END", identity),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	result = db.Clauses(clause.OnConflict{DoNothing: true}).Create(&LeaseModel{
+		Name:      name,
+		Holder:    identity,
+		ExpiresAt: now.Add(duration),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Release expires the lease if identity holds it
+func (l *PostgresLease) Release(ctx context.Context, name, identity string) error {
+	return l.db.WithContext(ctx).
+		Model(&LeaseModel{}).
+		Where("name = ? AND holder = ?", name, identity).
+		Update("expires_at", time.Now()).Error
+}
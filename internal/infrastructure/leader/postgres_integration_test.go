@@ -0,0 +1,52 @@
+//go:build integration
+
+package leader
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// leasesTemplate holds the lease table
+var leasesTemplate = pgtest.Template{
+	Name: "leader_leases",
+	Migrate: func(db *gorm.DB) error {
+		return db.AutoMigrate(&LeaseModel{})
+	},
+}
+
+func TestPostgresLease(t *testing.T) {
+	db := pgtest.DB(t, leasesTemplate)
+	lease := NewPostgresLease(db)
+	ctx := context.Background()
+
+	held, err := lease.TryAcquire(ctx, "scheduler", "pod-a", 50*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, held)
+
+	held, err = lease.TryAcquire(ctx, "scheduler", "pod-b", time.Minute)
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	time.Sleep(100 * time.Millisecond)
+	held, err = lease.TryAcquire(ctx, "scheduler", "pod-b", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, held, "the lease expired")
+
+	var model LeaseModel
+	require.NoError(t, db.First(&model, "name = ?", "scheduler").Error)
+	assert.Equal(t, "pod-b", model.Holder)
+	assert.Equal(t, 1, model.Transitions)
+}
@@ -0,0 +1,44 @@
+// Package lifecycle holds the root context of the application. It is
+// cancelled on shutdown once requests have drained, so requests still
+// running past the shutdown window, background workers and the jobs
+// requests started see the shutdown: their queries are cancelled rather
+// than cut off when the process exits.
+package lifecycle
+
+import "context"
+
+// Root is the root context of the application
+type Root struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New returns a root context, live until Cancel is called
+func New() *Root {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Root{ctx: ctx, cancel: cancel}
+}
+
+// Context returns the root context, done once the application shuts down
+func (r *Root) Context() context.Context {
+	return r.ctx
+}
+
+// Cancel cancels the root context and the contexts derived or detached
+// from it. Calling it again does nothing.
+func (r *Root) Cancel() {
+	r.cancel()
+}
+
+// Detach returns a context carrying the values of ctx, such as its tenant
+// and trace, that is cancelled with the root context instead of with ctx.
+// Work a request starts that outlives it runs in such a context. The
+// returned func releases it once the work is done.
+func (r *Root) Detach(ctx context.Context) (context.Context, context.CancelFunc) {
+	detached, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(r.ctx, cancel)
+	return detached, func() {
+		stop()
+		cancel()
+	}
+}
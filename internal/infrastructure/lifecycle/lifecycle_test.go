@@ -0,0 +1,38 @@
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type key struct{}
+
+func TestRoot_Detach(t *testing.T) {
+	root := New()
+	ctx, cancelRequest := context.WithCancel(context.WithValue(context.Background(), key{}, "tenant-a"))
+
+	detached, release := root.Detach(ctx)
+	defer release()
+	assert.Equal(t, "tenant-a", detached.Value(key{}), "values are kept")
+
+	cancelRequest()
+	assert.NoError(t, detached.Err(), "outlives the request")
+
+	root.Cancel()
+	<-detached.Done()
+	assert.ErrorIs(t, detached.Err(), context.Canceled)
+	assert.ErrorIs(t, root.Context().Err(), context.Canceled)
+}
+
+func TestRoot_DetachAfterCancel(t *testing.T) {
+	root := New()
+	root.Cancel()
+	root.Cancel()
+
+	detached, release := root.Detach(context.Background())
+	defer release()
+	<-detached.Done()
+	assert.ErrorIs(t, detached.Err(), context.Canceled)
+}
@@ -0,0 +1,189 @@
+package loadshed
+
+import (
+	"context"
+	"expvar"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// metrics publishes per route group counters under /debug/vars
+var metrics = expvar.NewMap("load_shedding")
+
+// DefaultRetryAfter is the Retry-After sent when none is configured
+const DefaultRetryAfter = time.Second
+
+// defaultGroup names the limiter shared by routes without an override
+const defaultGroup = "default"
+
+// Limit bounds the requests a route group handles at once
+type Limit struct {
+	// MaxConcurrent is how many requests are handled at once. Zero disables
+	// the limit.
+	MaxConcurrent int
+
+	// MaxQueue is how many requests may wait for a free slot
+	MaxQueue int
+
+	// MaxWait is how long a queued request waits before it is shed. Zero
+	// disables queueing.
+	MaxWait time.Duration
+}
+
+// Options configures the load shedding middleware
+type Options struct {
+	// Default limits routes without an override; they share one limiter
+	Default Limit
+
+	// Routes gives route groups, keyed by path prefix such as "/users",
+	// their own limiter. The longest matching prefix wins.
+	Routes map[string]Limit
+
+	// RetryAfter is the delay suggested to shed clients; zero uses
+	// DefaultRetryAfter
+	RetryAfter time.Duration
+}
+
+// Middleware sheds requests beyond a route group's capacity with 503 and a
+// Retry-After header. Requests wait in a bounded queue for a slot, and are
+// shed when the queue is full or their wait budget runs out, so traffic
+// spikes do not pile up on the database. WebSocket connections and event
+// streams would hold a slot for as long as they stay open; they are not
+// counted, and the handlers serving them limit the connections instead.
+func Middleware(opts Options) gin.HandlerFunc {
+	retryAfter := opts.RetryAfter
+	if retryAfter <= 0 {
+		retryAfter = DefaultRetryAfter
+	}
+	retryAfterSeconds := strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))
+
+	limiters := make(map[string]*limiter, len(opts.Routes)+1)
+	limiters[defaultGroup] = newLimiter(defaultGroup, opts.Default)
+	for prefix, limit := range opts.Routes {
+		limiters[prefix] = newLimiter(prefix, limit)
+	}
+
+	return func(c *gin.Context) {
+		if request.IsStreaming(c.Request) {
+			c.Next()
+			return
+		}
+
+		group := defaultGroup
+		if prefix, ok := request.MatchPrefix(opts.Routes, request.RoutePath(c)); ok {
+			group = prefix
+		}
+
+		l := limiters[group]
+		if l == nil {
+			c.Next()
+			return
+		}
+
+		if !l.acquire(c.Request.Context()) {
+			metrics.Add(group+".shed", 1)
+			c.Header("Retry-After", retryAfterSeconds)
+			problem.Abort(c, problem.New(http.StatusServiceUnavailable, "the server is at capacity, retry later"))
+			return
+		}
+		defer l.release()
+
+		c.Next()
+	}
+}
+
+// limiter is a semaphore with a bounded queue of waiting requests
+type limiter struct {
+	name    string
+	slots   chan struct{}
+	maxWait time.Duration
+
+	mu       sync.Mutex
+	waiting  int
+	maxQueue int
+}
+
+// newLimiter returns a limiter for limit, or nil when it is unlimited. Its
+// queue length is published as the "<name>.waiting" metric.
+func newLimiter(name string, limit Limit) *limiter {
+	if limit.MaxConcurrent <= 0 {
+		return nil
+	}
+
+	l := &limiter{
+		name:     name,
+		slots:    make(chan struct{}, limit.MaxConcurrent),
+		maxWait:  limit.MaxWait,
+		maxQueue: limit.MaxQueue,
+	}
+	metrics.Set(name+".waiting", expvar.Func(func() any { return l.queueLength() }))
+	return l
+}
+
+// acquire takes a slot, waiting in the queue if none is free, and reports
+// whether it got one
+func (l *limiter) acquire(ctx context.Context) bool {
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if !l.enqueue() {
+		return false
+	}
+	defer l.dequeue()
+
+	timer := time.NewTimer(l.maxWait)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees the slot taken by acquire
+func (l *limiter) release() {
+	<-l.slots
+}
+
+// enqueue reserves a place in the queue, failing when it is full
+func (l *limiter) enqueue() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.waiting >= l.maxQueue || l.maxWait <= 0 {
+		return false
+	}
+	l.waiting++
+	metrics.Add(l.name+".queued", 1)
+	return true
+}
+
+// dequeue gives back the place taken by enqueue
+func (l *limiter) dequeue() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.waiting--
+}
+
+// queueLength returns how many requests are waiting for a slot
+func (l *limiter) queueLength() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.waiting
+}
@@ -0,0 +1,165 @@
+package loadshed
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+)
+
+// setupRouter returns a router whose /slow handlers hold their slot until
+// release is closed, and signal on started once they run
+func setupRouter(opts Options, started chan<- struct{}, release <-chan struct{}) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	slow := func(c *gin.Context) {
+		started <- struct{}{}
+		<-release
+		c.Status(http.StatusOK)
+	}
+
+	router := gin.New()
+	router.Use(Middleware(opts))
+	router.GET("/slow", slow)
+	router.GET("/users/slow", slow)
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+// serveAsync serves a request in the background and delivers its recorder
+func serveAsync(router *gin.Engine, path string) <-chan *httptest.ResponseRecorder {
+	done := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		done <- w
+	}()
+	return done
+}
+
+func serve(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	return <-serveAsync(router, path)
+}
+
+func TestMiddleware_ShedsWhenQueueIsFull(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	router := setupRouter(Options{
+		Default:    Limit{MaxConcurrent: 1},
+		RetryAfter: 1500 * time.Millisecond,
+		Routes:     map[string]Limit{"/health": {}},
+	}, started, release)
+
+	first := serveAsync(router, "/slow")
+	<-started
+
+	w := serve(router, "/slow")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+	var details problem.Details
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+	assert.Equal(t, http.StatusServiceUnavailable, details.Status)
+
+	// Unlimited groups are not affected
+	assert.Equal(t, http.StatusOK, serve(router, "/health").Code)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-first).Code)
+
+	// The slot is free again
+	go func() { <-started }()
+	assert.Equal(t, http.StatusOK, serve(router, "/slow").Code)
+}
+
+func TestMiddleware_SkipsWebSockets(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	router := setupRouter(Options{Default: Limit{MaxConcurrent: 1}}, started, release)
+
+	first := serveAsync(router, "/slow")
+	<-started
+
+	// A WebSocket connection neither waits for nor takes the busy slot
+	ws := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		r := httptest.NewRequest(http.MethodGet, "/slow", nil)
+		r.Header.Set("Connection", "Upgrade")
+		r.Header.Set("Upgrade", "websocket")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		ws <- w
+	}()
+	<-started
+
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-ws).Code)
+	assert.Equal(t, http.StatusOK, (<-first).Code)
+}
+
+func TestMiddleware_QueuedRequestGetsFreedSlot(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	router := setupRouter(Options{
+		Default: Limit{MaxConcurrent: 1, MaxQueue: 1, MaxWait: 5 * time.Second},
+	}, started, release)
+
+	first := serveAsync(router, "/slow")
+	<-started
+
+	queued := serveAsync(router, "/slow")
+	require.Eventually(t, func() bool {
+		return metrics.Get("default.waiting").String() == "1"
+	}, time.Second, 5*time.Millisecond)
+
+	close(release)
+	<-started
+	assert.Equal(t, http.StatusOK, (<-first).Code)
+	assert.Equal(t, http.StatusOK, (<-queued).Code)
+}
+
+func TestMiddleware_QueuedRequestShedAfterMaxWait(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	router := setupRouter(Options{
+		Routes: map[string]Limit{
+			"/users": {MaxConcurrent: 1, MaxQueue: 1, MaxWait: 20 * time.Millisecond},
+		},
+	}, started, release)
+
+	first := serveAsync(router, "/users/slow")
+	<-started
+
+	w := serve(router, "/users/slow")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	// Routes outside the group use the unlimited default
+	go func() { <-started }()
+	assert.Equal(t, http.StatusOK, serve(router, "/health").Code)
+
+	close(release)
+	assert.Equal(t, http.StatusOK, (<-first).Code)
+}
+
+func TestLimiter_AcquireHonorsContext(t *testing.T) {
+	l := newLimiter("test", Limit{MaxConcurrent: 1, MaxQueue: 1, MaxWait: time.Minute})
+
+	require.True(t, l.acquire(context.Background()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, l.acquire(ctx))
+	assert.Equal(t, 0, l.queueLength())
+
+	l.release()
+	assert.True(t, l.acquire(context.Background()))
+}
+
+func TestNewLimiter_Unlimited(t *testing.T) {
+	assert.Nil(t, newLimiter("test", Limit{}))
+}
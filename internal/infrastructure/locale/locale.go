@@ -0,0 +1,188 @@
+// Package locale resolves the language and time zone a client wants its
+// responses in, from the Accept-Language and X-Timezone headers. Times are
+// always stored in UTC, and responses default to it; response mappers
+// convert them to the time zone of the request with Localize.
+package locale
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+	_ "time/tzdata" // embed the timezone database so minimal images resolve zones
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+)
+
+// TimezoneHeader names the IANA time zone, such as Europe/Berlin, the
+// client wants the times of its responses in
+const TimezoneHeader = "X-Timezone"
+
+// maxTimezoneLength bounds the X-Timezone header, longer than any zone name
+const maxTimezoneLength = 64
+
+// Locale is the language and time zone of a request
+type Locale struct {
+	Language language.Tag
+	Location *time.Location
+}
+
+// Default is the locale of requests without preferences: English and UTC
+var Default = Locale{Language: language.English, Location: time.UTC}
+
+type contextKey struct{}
+
+// WithLocale returns a copy of ctx carrying l
+func WithLocale(ctx context.Context, l Locale) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the locale of the request, and whether the
+// middleware resolved one. Requests it did not see get Default.
+func FromContext(ctx context.Context) (Locale, bool) {
+	l, ok := ctx.Value(contextKey{}).(Locale)
+	if !ok {
+		return Default, false
+	}
+	return l, true
+}
+
+// Options configures the middleware
+type Options struct {
+	// Languages are the languages offered, the first answering clients
+	// without an acceptable preference. Empty offers Default's.
+	Languages []language.Tag
+}
+
+// Middleware stores the locale of each request in its context: the offered
+// language Accept-Language prefers, and the time zone named by X-Timezone,
+// UTC without it. An unknown time zone is answered with 400.
+func Middleware(opts Options) gin.HandlerFunc {
+	languages := opts.Languages
+	if len(languages) == 0 {
+		languages = []language.Tag{Default.Language}
+	}
+	matcher := language.NewMatcher(languages)
+
+	return func(c *gin.Context) {
+		l := Locale{Language: languages[0], Location: time.UTC}
+
+		if accept := c.GetHeader("Accept-Language"); accept != "" {
+			// Unparsable preferences are ignored, as with Accept
+			if tags, _, err := language.ParseAcceptLanguage(accept); err == nil && len(tags) > 0 {
+				_, index, confidence := matcher.Match(tags...)
+				if confidence != language.No {
+					l.Language = languages[index]
+				}
+			}
+		}
+
+		if name := strings.TrimSpace(c.GetHeader(TimezoneHeader)); name != "" {
+			location, ok := LoadLocation(name)
+			if !ok {
+				problem.Abort(c, problem.New(http.StatusBadRequest, "unknown time zone in "+TimezoneHeader+": "+name))
+				return
+			}
+			l.Location = location
+		}
+
+		c.Request = c.Request.WithContext(WithLocale(c.Request.Context(), l))
+		c.Next()
+	}
+}
+
+// LoadLocation returns the IANA time zone named name. "Local" is refused,
+// as it depends on the server.
+func LoadLocation(name string) (*time.Location, bool) {
+	if name == "" || name == "Local" || len(name) > maxTimezoneLength {
+		return nil, false
+	}
+	location, err := time.LoadLocation(name)
+	return location, err == nil
+}
+
+// Time returns t in the time zone of the locale
+func (l Locale) Time(t time.Time) time.Time {
+	return t.In(l.Location)
+}
+
+// IsUTC reports whether the locale keeps times in UTC
+func (l Locale) IsUTC() bool {
+	return l.Location == nil || l.Location == time.UTC
+}
+
+// Localize returns a copy of v, such as a response DTO, with the times of
+// its exported fields, slices, maps and pointers in the time zone of the
+// locale. v is left unchanged, so it may share times with domain objects.
+func (l Locale) Localize(v any) any {
+	if v == nil || l.IsUTC() {
+		return v
+	}
+	return l.localize(reflect.ValueOf(v)).Interface()
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// localize returns a copy of v with its times converted
+func (l Locale) localize(v reflect.Value) reflect.Value {
+	if v.Type() == timeType {
+		return reflect.ValueOf(l.Time(v.Interface().(time.Time)))
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type().Elem())
+		out.Elem().Set(l.localize(v.Elem()))
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(l.localize(v.Elem()))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := range v.NumField() {
+			// Unexported fields are not encoded, and cannot be set
+			if field := out.Field(i); field.CanSet() {
+				field.Set(l.localize(v.Field(i)))
+			}
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			out.Index(i).Set(l.localize(v.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(l.localize(v.Index(i)))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			out.SetMapIndex(iter.Key(), l.localize(iter.Value()))
+		}
+		return out
+	default:
+		return v
+	}
+}
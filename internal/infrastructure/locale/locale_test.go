@@ -0,0 +1,163 @@
+package locale
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
+)
+
+// serve returns the locale the middleware resolves for a request with the
+// headers, and the response status
+func serve(t *testing.T, opts Options, header http.Header) (Locale, int) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var resolved Locale
+	router := gin.New()
+	router.Use(Middleware(opts))
+	router.GET("/users", func(c *gin.Context) {
+		l, ok := FromContext(c.Request.Context())
+		require.True(t, ok)
+		resolved = l
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return resolved, w.Code
+}
+
+func TestMiddleware_Language(t *testing.T) {
+	opts := Options{Languages: []language.Tag{language.English, language.German, language.French}}
+
+	tests := []struct {
+		name   string
+		accept string
+		want   language.Tag
+	}{
+		{name: "no preference", want: language.English},
+		{name: "exact", accept: "fr", want: language.French},
+		{name: "by quality", accept: "es;q=0.9, de;q=0.8, en;q=0.1", want: language.German},
+		{name: "region", accept: "de-AT", want: language.German},
+		{name: "not offered", accept: "ja", want: language.English},
+		{name: "unparsable", accept: ";;;", want: language.English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, status := serve(t, opts, http.Header{"Accept-Language": {tt.accept}})
+			require.Equal(t, http.StatusNoContent, status)
+			assert.Equal(t, tt.want, l.Language)
+		})
+	}
+}
+
+func TestMiddleware_Timezone(t *testing.T) {
+	l, status := serve(t, Options{}, nil)
+	require.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, Default, l, "UTC by default")
+
+	l, status = serve(t, Options{}, http.Header{TimezoneHeader: {"Asia/Tokyo"}})
+	require.Equal(t, http.StatusNoContent, status)
+	assert.Equal(t, "Asia/Tokyo", l.Location.String())
+
+	for _, name := range []string{"Mars/Olympus_Mons", "Local", "../../etc/passwd"} {
+		_, status = serve(t, Options{}, http.Header{TimezoneHeader: {name}})
+		assert.Equal(t, http.StatusBadRequest, status, name)
+	}
+}
+
+func TestFromContext_WithoutMiddleware(t *testing.T) {
+	l, ok := FromContext(t.Context())
+	assert.False(t, ok)
+	assert.Equal(t, Default, l)
+}
+
+type event struct {
+	Type       string    `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type response struct {
+	CreatedAt *time.Time       `json:"created_at"`
+	DeletedAt *time.Time       `json:"deleted_at"`
+	Events    []event          `json:"events"`
+	ByType    map[string]event `json:"by_type"`
+	Data      any              `json:"data"`
+	seen      time.Time
+}
+
+func TestLocalize(t *testing.T) {
+	tokyo, ok := LoadLocation("Asia/Tokyo")
+	require.True(t, ok)
+	l := Locale{Language: language.English, Location: tokyo}
+
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	original := response{
+		CreatedAt: &at,
+		Events:    []event{{Type: "user.created", OccurredAt: at}},
+		ByType:    map[string]event{"created": {Type: "user.created", OccurredAt: at}},
+		Data:      event{OccurredAt: at},
+		seen:      at,
+	}
+
+	localized, ok := l.Localize(original).(response)
+	require.True(t, ok, "the type is kept")
+
+	body, err := json.Marshal(localized)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"created_at": "2024-03-01T21:00:00+09:00",
+		"deleted_at": null,
+		"events": [{"type": "user.created", "occurred_at": "2024-03-01T21:00:00+09:00"}],
+		"by_type": {"created": {"type": "user.created", "occurred_at": "2024-03-01T21:00:00+09:00"}},
+		"data": {"type": "", "occurred_at": "2024-03-01T21:00:00+09:00"}
+	}`, string(body))
+	assert.True(t, localized.CreatedAt.Equal(at), "the same instant")
+
+	assert.Equal(t, time.UTC, at.Location(), "shared times are left unchanged")
+	assert.Equal(t, time.UTC, original.Events[0].OccurredAt.Location())
+	assert.Equal(t, time.UTC, original.ByType["created"].OccurredAt.Location())
+	assert.Equal(t, time.UTC, localized.seen.Location(), "unexported fields are not encoded")
+}
+
+func TestLocalize_UTCTime(t *testing.T) {
+	tokyo, ok := LoadLocation("Asia/Tokyo")
+	require.True(t, ok)
+	l := Locale{Language: language.English, Location: tokyo}
+
+	at := utc.New(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC))
+	localized, ok := l.Localize(struct {
+		CreatedAt *utc.Time `json:"created_at"`
+	}{CreatedAt: &at}).(struct {
+		CreatedAt *utc.Time `json:"created_at"`
+	})
+	require.True(t, ok)
+
+	body, err := json.Marshal(localized)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"created_at": "2024-03-01T21:00:00.000+09:00"}`, string(body))
+	assert.Equal(t, time.UTC, at.Location(), "shared times are left unchanged")
+}
+
+func TestLocalize_UTC(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	original := response{CreatedAt: &at}
+
+	localized := Default.Localize(original).(response)
+	assert.Same(t, original.CreatedAt, localized.CreatedAt, "nothing to convert")
+	assert.Nil(t, Default.Localize(nil))
+}
@@ -0,0 +1,59 @@
+package lock
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// metrics publishes per-key lock counters under /debug/vars
+var metrics = expvar.NewMap("locks")
+
+// instrumented counts the acquisitions, conflicts, errors and releases of
+// the locks of a Locker and logs the conflicts
+type instrumented struct {
+	locker  Locker
+	backend string
+	logger  *logger.Logger
+}
+
+// Instrument returns locker counting the outcomes of its calls, per key,
+// in the locks map of /debug/vars. A lock found held by another holder is
+// logged with backend, which names the locker.
+func Instrument(locker Locker, backend string, log *logger.Logger) Locker {
+	return &instrumented{
+		locker:  locker,
+		backend: backend,
+		logger:  log,
+	}
+}
+
+// Acquire takes the lock and records the outcome
+func (l *instrumented) Acquire(ctx context.Context, key string, ttl time.Duration) (int64, bool, error) {
+	token, acquired, err := l.locker.Acquire(ctx, key, ttl)
+	switch {
+	case err != nil:
+		metrics.Add(key+".errors", 1)
+	case !acquired:
+		metrics.Add(key+".conflicts", 1)
+		l.logger.Info().
+			Str("lock", key).
+			Str("backend", l.backend).
+			Msg("Lock held by another holder")
+	default:
+		metrics.Add(key+".acquired", 1)
+	}
+	return token, acquired, err
+}
+
+// Release gives up the lock and records the outcome
+func (l *instrumented) Release(ctx context.Context, key string, token int64) error {
+	if err := l.locker.Release(ctx, key, token); err != nil {
+		metrics.Add(key+".errors", 1)
+		return err
+	}
+	metrics.Add(key+".released", 1)
+	return nil
+}
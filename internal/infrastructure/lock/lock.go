@@ -0,0 +1,27 @@
+// Package lock provides locks held across every instance of the
+// application, so work such as scheduled jobs and imports runs on one
+// instance at a time.
+//
+// A lock expires after its TTL, so a crashed holder cannot keep it. A
+// holder that outlives its TTL may then run alongside the next one; the
+// fencing token returned with each lock lets the resources they write to
+// tell them apart, by rejecting writes carrying a token older than one
+// already seen.
+package lock
+
+import (
+	"context"
+	"time"
+)
+
+// Locker takes and gives up named locks
+type Locker interface {
+	// Acquire takes the lock named key for ttl, unless another holder has
+	// it, and returns its fencing token with acquired set to true. Tokens
+	// increase with every acquisition of a key.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (token int64, acquired bool, err error)
+
+	// Release gives up the lock named key if it is still held with the
+	// token. Releasing a lock that expired does nothing.
+	Release(ctx context.Context, key string, token int64) error
+}
@@ -0,0 +1,131 @@
+package lock
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// forEachLocker runs test against every Locker implementation that needs
+// no database. expire ends the TTL of the locks.
+func forEachLocker(t *testing.T, test func(t *testing.T, locker Locker, expire func(ttl time.Duration))) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, NewMemoryLocker(), func(ttl time.Duration) { time.Sleep(ttl) })
+	})
+	t.Run("redis", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { client.Close() })
+		test(t, NewRedisLocker(client, "locks"), server.FastForward)
+	})
+}
+
+func TestRedisLocker_HashTaggedKeys(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	locker := NewRedisLocker(client, "locks")
+
+	token, acquired, err := locker.Acquire(context.Background(), "import", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// Both keys carry the {locks:import} hash tag, so Redis Cluster keeps
+	// them in the slot of the script
+	assert.True(t, server.Exists("{locks:import}"))
+	fence, err := server.Get("{locks:import}:fence")
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(token, 10), fence)
+}
+
+func TestLocker_AcquireAndRelease(t *testing.T) {
+	forEachLocker(t, func(t *testing.T, locker Locker, _ func(time.Duration)) {
+		ctx := context.Background()
+
+		token, acquired, err := locker.Acquire(ctx, "import", time.Minute)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		_, acquired, err = locker.Acquire(ctx, "import", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired, "the lock is held")
+
+		_, acquired, err = locker.Acquire(ctx, "retention", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, acquired, "other keys are separate locks")
+
+		require.NoError(t, locker.Release(ctx, "import", token))
+		next, acquired, err := locker.Acquire(ctx, "import", time.Minute)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		assert.Greater(t, next, token, "tokens increase")
+	})
+}
+
+func TestLocker_ExpiredLockIsTakenOver(t *testing.T) {
+	forEachLocker(t, func(t *testing.T, locker Locker, expire func(time.Duration)) {
+		ctx := context.Background()
+
+		stale, acquired, err := locker.Acquire(ctx, "import", 20*time.Millisecond)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		expire(30 * time.Millisecond)
+		token, acquired, err := locker.Acquire(ctx, "import", time.Minute)
+		require.NoError(t, err)
+		require.True(t, acquired)
+		assert.Greater(t, token, stale)
+
+		// The expired holder cannot release the new holder's lock
+		require.NoError(t, locker.Release(ctx, "import", stale))
+		_, acquired, err = locker.Acquire(ctx, "import", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+	})
+}
+
+// failingLocker fails every call
+type failingLocker struct{}
+
+func (failingLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (int64, bool, error) {
+	return 0, false, errors.New("connection refused")
+}
+
+func (failingLocker) Release(ctx context.Context, key string, token int64) error {
+	return errors.New("connection refused")
+}
+
+func TestInstrument(t *testing.T) {
+	var logs bytes.Buffer
+	locker := Instrument(NewMemoryLocker(), "memory", logger.New("info", &logs))
+	ctx := context.Background()
+
+	token, _, err := locker.Acquire(ctx, "instrumented", time.Minute)
+	require.NoError(t, err)
+	_, acquired, err := locker.Acquire(ctx, "instrumented", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+	require.NoError(t, locker.Release(ctx, "instrumented", token))
+
+	assert.Equal(t, "1", metrics.Get("instrumented.acquired").String())
+	assert.Equal(t, "1", metrics.Get("instrumented.conflicts").String())
+	assert.Equal(t, "1", metrics.Get("instrumented.released").String())
+	assert.Contains(t, logs.String(), `"lock":"instrumented"`)
+	assert.Contains(t, logs.String(), "Lock held by another holder")
+
+	failing := Instrument(failingLocker{}, "redis", logger.New("info", &logs))
+	_, _, err = failing.Acquire(ctx, "failing", time.Minute)
+	assert.Error(t, err)
+	assert.Error(t, failing.Release(ctx, "failing", 1))
+	assert.Equal(t, "2", metrics.Get("failing.errors").String())
+}
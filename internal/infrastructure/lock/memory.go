@@ -0,0 +1,57 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryLock is a lock held in memory
+type memoryLock struct {
+	token     int64
+	expiresAt time.Time
+}
+
+// MemoryLocker implements Locker in process memory. Its locks are not
+// shared between instances, so it is meant for tests and running a single
+// instance.
+type MemoryLocker struct {
+	mu     sync.Mutex
+	locks  map[string]memoryLock
+	tokens map[string]int64
+}
+
+// NewMemoryLocker creates a new in-memory locker
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{
+		locks:  make(map[string]memoryLock),
+		tokens: make(map[string]int64),
+	}
+}
+
+// Acquire takes the lock unless an unexpired one holds the key
+func (l *MemoryLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (int64, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if held, ok := l.locks[key]; ok && held.expiresAt.After(now) {
+		return 0, false, nil
+	}
+
+	l.tokens[key]++
+	token := l.tokens[key]
+	l.locks[key] = memoryLock{token: token, expiresAt: now.Add(ttl)}
+	return token, true, nil
+}
+
+// Release gives up the lock if it is still held with the token
+func (l *MemoryLocker) Release(ctx context.Context, key string, token int64) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if held, ok := l.locks[key]; ok && held.token == token {
+		delete(l.locks, key)
+	}
+	return nil
+}
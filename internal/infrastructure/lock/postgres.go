@@ -0,0 +1,143 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// releaseTimeout bounds giving up an advisory lock when its TTL ends,
+// which no caller waits for
+const releaseTimeout = 5 * time.Second
+
+// FenceModel represents the database model of the fencing token of a lock
+type FenceModel struct {
+	Key   string `gorm:"type:varchar(255);primaryKey"`
+	Token int64  `gorm:"not null"`
+}
+
+// TableName specifies the table name for FenceModel
+func (FenceModel) TableName() string {
+	return "lock_fences"
+}
+
+// postgresLock is an advisory lock held by this process. Its conn is nil
+// while the lock is being acquired.
+type postgresLock struct {
+	token int64
+	conn  *sql.Conn
+	timer *time.Timer
+}
+
+// PostgresLocker implements Locker with PostgreSQL session advisory locks,
+// so every instance connected to the same database shares the locks. Each
+// held lock keeps a connection of the pool, and PostgreSQL gives it up when
+// the connection closes, so the locks of a crashed process are released at
+// once instead of when their TTL ends.
+type PostgresLocker struct {
+	db *gorm.DB
+
+	mu   sync.Mutex
+	held map[string]*postgresLock
+}
+
+// NewPostgresLocker creates a new locker taking advisory locks on db. The
+// fencing tokens are counted in the lock_fences table.
+func NewPostgresLocker(db *gorm.DB) *PostgresLocker {
+	return &PostgresLocker{
+		db:   db,
+		held: make(map[string]*postgresLock),
+	}
+}
+
+// Acquire takes the advisory lock of the key on a connection of its own,
+// which is closed when the lock is released or its TTL ends
+func (l *PostgresLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (int64, bool, error) {
+	// Advisory locks are reentrant within a session, but not across the
+	// connections of this process. The key is claimed before the database
+	// round trips, which run without the mutex so that acquiring other keys
+	// does not wait for them.
+	l.mu.Lock()
+	if _, ok := l.held[key]; ok {
+		l.mu.Unlock()
+		return 0, false, nil
+	}
+	held := &postgresLock{}
+	l.held[key] = held
+	l.mu.Unlock()
+
+	token, conn, err := l.lock(ctx, key)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if conn == nil {
+		delete(l.held, key)
+		return 0, false, err
+	}
+
+	held.token = token
+	held.conn = conn
+	held.timer = time.AfterFunc(ttl, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+		defer cancel()
+		_ = l.Release(ctx, key, token)
+	})
+	return token, true, nil
+}
+
+// lock takes the advisory lock of the key on a new connection and issues
+// its fencing token. The connection is nil if the lock is not taken.
+func (l *PostgresLocker) lock(ctx context.Context, key string) (int64, *sql.Conn, error) {
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return 0, nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var acquired bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock(hashtextextended($1, 0))", key).Scan(&acquired)
+	if err != nil || !acquired {
+		conn.Close()
+		return 0, nil, err
+	}
+
+	var token int64
+	err = conn.QueryRowContext(ctx, `
+		INSERT INTO lock_fences (key, token) VALUES ($1, 1)
+		ON CONFLICT (key) DO UPDATE SET token = lock_fences.token + 1
+		RETURNING token`, key).Scan(&token)
+	if err != nil {
+		// Closing the session gives up the advisory lock
+		conn.Close()
+		return 0, nil, fmt.Errorf("failed to issue fencing token: %w", err)
+	}
+
+	return token, conn, nil
+}
+
+// Release gives up the advisory lock if this process holds it with the
+// token. Locks still being acquired, or whose acquire failed, are left alone.
+func (l *PostgresLocker) Release(ctx context.Context, key string, token int64) error {
+	l.mu.Lock()
+	held, ok := l.held[key]
+	if !ok || held.conn == nil || held.token != token {
+		l.mu.Unlock()
+		return nil
+	}
+	delete(l.held, key)
+	l.mu.Unlock()
+
+	held.timer.Stop()
+	defer held.conn.Close()
+
+	_, err := held.conn.ExecContext(ctx, "SELECT pg_advisory_unlock(hashtextextended($1, 0))", key)
+	return err
+}
@@ -0,0 +1,77 @@
+//go:build integration
+
+package lock
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// locksTemplate holds the fencing token table
+var locksTemplate = pgtest.Template{
+	Name: "locks",
+	Migrate: func(db *gorm.DB) error {
+		return db.AutoMigrate(&FenceModel{})
+	},
+}
+
+func TestPostgresLocker(t *testing.T) {
+	db := pgtest.DB(t, locksTemplate)
+	ctx := context.Background()
+
+	// Two lockers stand for two instances sharing the database
+	first, second := NewPostgresLocker(db), NewPostgresLocker(db)
+
+	token, acquired, err := first.Acquire(ctx, "import", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	for _, locker := range []*PostgresLocker{first, second} {
+		_, acquired, err = locker.Acquire(ctx, "import", time.Minute)
+		require.NoError(t, err)
+		assert.False(t, acquired, "the lock is held")
+	}
+
+	require.NoError(t, first.Release(ctx, "import", token))
+	next, acquired, err := second.Acquire(ctx, "import", 50*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, acquired)
+	assert.Greater(t, next, token, "tokens increase")
+
+	// The lock is given up when its TTL ends
+	assert.Eventually(t, func() bool {
+		_, acquired, err := first.Acquire(ctx, "import", time.Minute)
+		return err == nil && acquired
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestPostgresLocker_FailedAcquireFreesKey(t *testing.T) {
+	db := pgtest.DB(t, locksTemplate)
+	locker := NewPostgresLocker(db)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, acquired, err := locker.Acquire(canceled, "export", time.Minute)
+	require.Error(t, err)
+	require.False(t, acquired)
+
+	// Releasing a lock that was never taken is a no-op
+	require.NoError(t, locker.Release(context.Background(), "export", 0))
+
+	token, acquired, err := locker.Acquire(context.Background(), "export", time.Minute)
+	require.NoError(t, err)
+	assert.True(t, acquired, "the failed acquire does not keep the key")
+	require.NoError(t, locker.Release(context.Background(), "export", token))
+}
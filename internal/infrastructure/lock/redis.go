@@ -0,0 +1,69 @@
+package lock
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// acquireScript sets the lock key to the next fencing token unless it is
+// already set. The token counter never expires, so tokens keep increasing
+// after the lock does.
+var acquireScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return 0
+end
+local token = redis.call("INCR", KEYS[2])
+redis.call("SET", KEYS[1], token, "PX", ARGV[1])
+return token
+`)
+
+// releaseScript deletes the lock key if it still holds the token
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// RedisLocker implements Locker with Redis keys expiring with their lock,
+// so every instance connected to the same Redis shares the locks
+type RedisLocker struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisLocker creates a new Redis-backed locker. The lock named key is
+// held in the key {prefix:key}, and its token counter in
+// {prefix:key}:fence. The braces make the pair a Redis Cluster hash tag,
+// so both keys land in the slot the acquire script runs in.
+func NewRedisLocker(client redis.UniversalClient, prefix string) *RedisLocker {
+	return &RedisLocker{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// keys returns the lock key and the token counter key of a lock
+func (l *RedisLocker) keys(key string) []string {
+	tag := "{" + l.prefix + ":" + key + "}"
+	return []string{tag, tag + ":fence"}
+}
+
+// Acquire takes the lock unless the key is set
+func (l *RedisLocker) Acquire(ctx context.Context, key string, ttl time.Duration) (int64, bool, error) {
+	// A zero expiry would keep the lock forever
+	ms := max(ttl.Milliseconds(), 1)
+
+	token, err := acquireScript.Run(ctx, l.client, l.keys(key), ms).Int64()
+	if err != nil {
+		return 0, false, err
+	}
+	return token, token != 0, nil
+}
+
+// Release deletes the key if it still holds the token
+func (l *RedisLocker) Release(ctx context.Context, key string, token int64) error {
+	return releaseScript.Run(ctx, l.client, l.keys(key)[:1], token).Err()
+}
@@ -15,6 +15,12 @@ type Logger struct {
 
 // New creates a new logger with the specified level
 func New(level string, writer io.Writer) *Logger {
+	return NewRedacted(level, writer, nil)
+}
+
+// NewRedacted creates a new logger with the specified level that removes
+// the values redactor finds sensitive from every event
+func NewRedacted(level string, writer io.Writer, redactor *Redactor) *Logger {
 	// Parse log level
 	logLevel, err := zerolog.ParseLevel(level)
 	if err != nil {
@@ -38,11 +44,14 @@ func New(level string, writer io.Writer) *Logger {
 		}
 	}
 
-	logger := zerolog.New(output).
+	// Redact the JSON events before the console writer formats them, and
+	// add the trace of events logged with a request context
+	logger := zerolog.New(redactor.Writer(output)).
 		With().
 		Timestamp().
 		Caller().
-		Logger()
+		Logger().
+		Hook(traceHook{})
 
 	return &Logger{Logger: &logger}
 }
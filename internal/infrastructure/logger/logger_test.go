@@ -2,11 +2,13 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestNew(t *testing.T) {
@@ -74,3 +76,27 @@ func TestWith(t *testing.T) {
 	assert.Equal(t, "test-service", logEntry["service"])
 	assert.Equal(t, "1.0.0", logEntry["version"])
 }
+
+func TestTraceHook(t *testing.T) {
+	var buf bytes.Buffer
+	log := New("info", &buf)
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	log.Info().Ctx(ctx).Msg("traced")
+	log.Info().Msg("untraced")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var traced, untraced map[string]interface{}
+	require.NoError(t, json.Unmarshal(lines[0], &traced))
+	require.NoError(t, json.Unmarshal(lines[1], &untraced))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traced["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", traced["span_id"])
+	assert.NotContains(t, untraced, "trace_id")
+}
@@ -0,0 +1,213 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Redaction policies, selected per environment
+const (
+	// PolicyOff logs everything as is
+	PolicyOff = "off"
+
+	// PolicyFields replaces the values of sensitive fields, and of
+	// sensitive key=value pairs in text such as query strings
+	PolicyFields = "fields"
+
+	// PolicyStrict also replaces email addresses, passwords in connection
+	// URLs and bearer tokens wherever they appear
+	PolicyStrict = "strict"
+)
+
+// Redacted replaces the values removed from log events
+const Redacted = "[REDACTED]"
+
+var (
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+(?:@|%40)[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	urlPassword   = regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*://[^:/\s@]+:)[^@\s/]+@`)
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`)
+)
+
+// Redactor removes sensitive values from log events before they are
+// written. A nil Redactor redacts nothing.
+type Redactor struct {
+	fields    []string
+	pairs     *regexp.Regexp
+	jsonPairs *regexp.Regexp
+	strict    bool
+}
+
+// NewRedactor returns a redactor applying policy to fields. A field is
+// sensitive when its name contains one of fields, ignoring case, so
+// "token" covers "token_hash" and "admin_token". It returns nil for
+// PolicyOff; unknown policies are treated as PolicyStrict.
+func NewRedactor(policy string, fields []string) *Redactor {
+	if policy == PolicyOff {
+		return nil
+	}
+
+	r := &Redactor{strict: policy != PolicyFields}
+	quoted := make([]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		r.fields = append(r.fields, field)
+		quoted = append(quoted, regexp.QuoteMeta(field))
+	}
+	if len(quoted) > 0 {
+		names := `[\w.-]*(?:` + strings.Join(quoted, "|") + `)[\w.-]*`
+		r.pairs = regexp.MustCompile(`(?i)(` + names + `=)[^&\s"',;]+`)
+		r.jsonPairs = regexp.MustCompile(`(?i)("` + names + `"\s*:\s*")(?:[^"\\]|\\.)*"?`)
+	}
+	return r
+}
+
+// Sensitive reports whether the values of the field are redacted
+func (r *Redactor) Sensitive(field string) bool {
+	if r == nil {
+		return false
+	}
+
+	field = strings.ToLower(field)
+	for _, f := range r.fields {
+		if strings.Contains(field, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// String redacts sensitive key=value pairs and quoted "key": "value"
+// pairs, as in cut off JSON, in s and, with PolicyStrict, email addresses,
+// URL passwords and bearer tokens
+func (r *Redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	if r.pairs != nil {
+		s = r.pairs.ReplaceAllString(s, "${1}"+Redacted)
+		s = r.jsonPairs.ReplaceAllString(s, "${1}"+Redacted+`"`)
+	}
+	if r.strict {
+		s = urlPassword.ReplaceAllString(s, "${1}"+Redacted+"@")
+		s = bearerPattern.ReplaceAllString(s, "${1}"+Redacted)
+		s = emailPattern.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// Redact returns a log line without its sensitive values. JSON objects,
+// as written by zerolog, and arrays keep their fields in order with the
+// values of sensitive fields replaced and their strings redacted; other
+// lines are redacted as text.
+func (r *Redactor) Redact(line []byte) []byte {
+	if r == nil {
+		return line
+	}
+
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		var buf bytes.Buffer
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		if err := r.redactValue(dec, &buf, false); err == nil && !dec.More() {
+			if line[len(line)-1] == '\n' {
+				buf.WriteByte('\n')
+			}
+			return buf.Bytes()
+		}
+	}
+	return []byte(r.String(string(line)))
+}
+
+// redactValue copies the next JSON value of dec to buf, replacing it when
+// sensitive
+func (r *Redactor) redactValue(dec *json.Decoder, buf *bytes.Buffer, sensitive bool) error {
+	if sensitive {
+		var skipped json.RawMessage
+		if err := dec.Decode(&skipped); err != nil {
+			return err
+		}
+		buf.WriteString(`"` + Redacted + `"`)
+		return nil
+	}
+
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		buf.WriteRune(rune(t))
+		for i := 0; dec.More(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			field := ""
+			if t == '{' {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				field, _ = key.(string)
+				writeJSON(buf, field)
+				buf.WriteByte(':')
+			}
+			if err := r.redactValue(dec, buf, r.Sensitive(field)); err != nil {
+				return err
+			}
+		}
+		end, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		buf.WriteRune(rune(end.(json.Delim)))
+	case string:
+		writeJSON(buf, r.String(t))
+	default:
+		writeJSON(buf, t)
+	}
+	return nil
+}
+
+// writeJSON writes v as JSON without escaping HTML characters, as zerolog does
+func writeJSON(buf *bytes.Buffer, v interface{}) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+	// Encode ends values with a newline
+	buf.Truncate(buf.Len() - 1)
+}
+
+// Writer returns a writer that redacts each line before writing it to w.
+// Each write must hold whole lines, as zerolog and the Gin logger do.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+	return &redactingWriter{redactor: r, out: w}
+}
+
+type redactingWriter struct {
+	redactor *Redactor
+	out      io.Writer
+}
+
+// Write redacts p and reports it written in full, as the redacted line
+// may differ in length
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if _, err := w.out.Write(w.redactor.Redact(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
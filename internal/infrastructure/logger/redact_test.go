@@ -0,0 +1,117 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFields = []string{"email", "password", "token", "dsn"}
+
+func TestNewRedactor_Off(t *testing.T) {
+	r := NewRedactor(PolicyOff, testFields)
+	assert.Nil(t, r)
+
+	line := []byte(`{"email":"jane@example.com"}` + "\n")
+	assert.Equal(t, line, r.Redact(line))
+
+	var buf bytes.Buffer
+	assert.Same(t, &buf, r.Writer(&buf))
+}
+
+func TestRedactor_Sensitive(t *testing.T) {
+	r := NewRedactor(PolicyFields, testFields)
+	assert.True(t, r.Sensitive("email"))
+	assert.True(t, r.Sensitive("pending_email"))
+	assert.True(t, r.Sensitive("Admin_Token"))
+	assert.False(t, r.Sensitive("name"))
+}
+
+func TestRedactor_Fields(t *testing.T) {
+	r := NewRedactor(PolicyFields, testFields)
+
+	t.Run("replaces sensitive fields and keeps the order", func(t *testing.T) {
+		line := `{"level":"info","email":"jane@example.com","count":3,"user":{"id":"1","password":"secret"},"tokens":["a","b"],"message":"saved jane@example.com"}` + "\n"
+		assert.Equal(t,
+			`{"level":"info","email":"[REDACTED]","count":3,"user":{"id":"1","password":"[REDACTED]"},"tokens":"[REDACTED]","message":"saved jane@example.com"}`+"\n",
+			string(r.Redact([]byte(line))))
+	})
+
+	t.Run("replaces sensitive pairs in text", func(t *testing.T) {
+		line := `[GIN] 200 | GET "/users?email=jane%40example.com&limit=10&token=abc"` + "\n"
+		assert.Equal(t, `[GIN] 200 | GET "/users?email=[REDACTED]&limit=10&token=[REDACTED]"`+"\n", string(r.Redact([]byte(line))))
+	})
+
+	t.Run("replaces sensitive pairs in strings", func(t *testing.T) {
+		line := `{"error":"failed to connect: host=db password=hunter2 sslmode=disable"}`
+		assert.Equal(t, `{"error":"failed to connect: host=db password=[REDACTED] sslmode=disable"}`, string(r.Redact([]byte(line))))
+	})
+}
+
+func TestRedactor_Strict(t *testing.T) {
+	r := NewRedactor(PolicyStrict, testFields)
+
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"email", "sent to jane.doe+x@example.co.uk", "sent to [REDACTED]"},
+		{"escaped email", "GET /users/by-email/jane%40example.com", "GET /users/by-email/[REDACTED]"},
+		{"url password", "dial postgres://app:hunter2@db:5432/app failed", "dial postgres://app:[REDACTED]@db:5432/app failed"},
+		{"bearer token", "Authorization: Bearer eyJhbGciOi.abc-def", "Authorization: Bearer [REDACTED]"},
+		{"nothing sensitive", "user 42 saved", "user 42 saved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, r.String(tt.in))
+		})
+	}
+
+	assert.Equal(t, `{"message":"sent to [REDACTED]"}`, string(r.Redact([]byte(`{"message":"sent to jane@example.com"}`))))
+}
+
+func TestRedactor_InvalidJSON(t *testing.T) {
+	r := NewRedactor(PolicyFields, testFields)
+
+	// Redacted as text rather than dropped
+	assert.Equal(t, `{"email=[REDACTED]`, string(r.Redact([]byte(`{"email=jane@example.com`))))
+
+	// Cut off JSON keeps its quoted pairs redacted
+	assert.Equal(t,
+		`{"name":"Jane","password": "[REDACTED]","pending_email":"[REDACTED]"`,
+		string(r.Redact([]byte(`{"name":"Jane","password": "hun\"ter2","pending_email":"jane@exa`))))
+}
+
+func TestRedactor_Arrays(t *testing.T) {
+	r := NewRedactor(PolicyFields, testFields)
+
+	line := `[{"email":"jane@example.com","name":"Jane"},{"email":"john@example.com"}]`
+	assert.Equal(t, `[{"email":"[REDACTED]","name":"Jane"},{"email":"[REDACTED]"}]`, string(r.Redact([]byte(line))))
+}
+
+func TestNewRedacted(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewRedacted("info", &buf, NewRedactor(PolicyStrict, testFields))
+
+	log.Info().
+		Str("email", "jane@example.com").
+		Err(errors.New("no user jane@example.com")).
+		Msg("lookup failed")
+
+	assert.NotContains(t, buf.String(), "jane@example.com")
+	assert.Contains(t, buf.String(), `"email":"[REDACTED]"`)
+	assert.Contains(t, buf.String(), `"message":"lookup failed"`)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, errors.New("closed") }
+
+func TestRedactor_WriterErrors(t *testing.T) {
+	w := NewRedactor(PolicyFields, testFields).Writer(failingWriter{})
+	_, err := w.Write([]byte("line\n"))
+	assert.Error(t, err)
+}
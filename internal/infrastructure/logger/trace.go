@@ -0,0 +1,21 @@
+package logger
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHook adds the trace and span IDs of the context of an event, set
+// with Ctx, to the event, so log lines can be found from a trace
+type traceHook struct{}
+
+// Run implements zerolog.Hook
+func (traceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	ctx := e.GetCtx()
+	if ctx == nil {
+		return
+	}
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		e.Str("trace_id", span.TraceID().String()).Str("span_id", span.SpanID().String())
+	}
+}
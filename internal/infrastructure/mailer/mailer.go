@@ -0,0 +1,100 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+const (
+	DriverLog  = "log"
+	DriverSMTP = "smtp"
+)
+
+// Mailer sends plain-text emails
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// New creates the mailer selected by the configured driver
+func New(cfg config.MailerConfig, log *logger.Logger) (Mailer, error) {
+	switch cfg.Driver {
+	case DriverLog, "":
+		return NewLogMailer(log), nil
+	case DriverSMTP:
+		return NewSMTPMailer(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown mailer driver: %q", cfg.Driver)
+	}
+}
+
+// LogMailer writes emails to the log instead of delivering them.
+// It is intended for local development only.
+type LogMailer struct {
+	logger *logger.Logger
+}
+
+// NewLogMailer creates a new log mailer
+func NewLogMailer(log *logger.Logger) *LogMailer {
+	return &LogMailer{
+		logger: log,
+	}
+}
+
+// Send logs the email
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.logger.Info().
+		Ctx(ctx).
+		Str("to", to).
+		Str("subject", subject).
+		Str("body", body).
+		Msg("Email sent")
+	return nil
+}
+
+// SMTPMailer delivers emails through an SMTP server
+type SMTPMailer struct {
+	cfg config.MailerConfig
+}
+
+// NewSMTPMailer creates a new SMTP mailer
+func NewSMTPMailer(cfg config.MailerConfig) *SMTPMailer {
+	return &SMTPMailer{
+		cfg: cfg,
+	}
+}
+
+// Send delivers the email via SMTP
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	if err := smtp.SendMail(m.cfg.Address(), auth, m.cfg.From, []string{to}, buildMessage(m.cfg.From, to, subject, body)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+// buildMessage formats an RFC 5322 plain-text message
+func buildMessage(from, to, subject, body string) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + from + "\r\n")
+	b.WriteString("To: " + to + "\r\n")
+	b.WriteString("Subject: " + subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(body)
+	return []byte(b.String())
+}
@@ -0,0 +1,68 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+func TestNew(t *testing.T) {
+	log := logger.New("info", &bytes.Buffer{})
+
+	tests := []struct {
+		name     string
+		driver   string
+		expected interface{}
+		wantErr  bool
+	}{
+		{name: "default driver", driver: "", expected: &LogMailer{}},
+		{name: "log driver", driver: DriverLog, expected: &LogMailer{}},
+		{name: "smtp driver", driver: DriverSMTP, expected: &SMTPMailer{}},
+		{name: "unknown driver", driver: "carrier-pigeon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(config.MailerConfig{Driver: tt.driver}, log)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, m)
+		})
+	}
+}
+
+func TestLogMailer_Send(t *testing.T) {
+	var buf bytes.Buffer
+	m := NewLogMailer(logger.New("info", &buf))
+
+	err := m.Send(context.Background(), "user@example.com", "Hello", "Body text")
+	require.NoError(t, err)
+
+	var logEntry map[string]interface{}
+	err = json.Unmarshal(buf.Bytes(), &logEntry)
+	require.NoError(t, err)
+
+	assert.Equal(t, "user@example.com", logEntry["to"])
+	assert.Equal(t, "Hello", logEntry["subject"])
+	assert.Equal(t, "Body text", logEntry["body"])
+}
+
+func TestBuildMessage(t *testing.T) {
+	msg := string(buildMessage("from@example.com", "to@example.com", "Subject", "Body"))
+
+	assert.Contains(t, msg, "From: from@example.com\r\n")
+	assert.Contains(t, msg, "To: to@example.com\r\n")
+	assert.Contains(t, msg, "Subject: Subject\r\n")
+	assert.True(t, strings.HasSuffix(msg, "\r\n\r\nBody"))
+}
@@ -0,0 +1,227 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tracecontext"
+)
+
+// metrics publishes per consumer counters and lag under /debug/vars
+var metrics = expvar.NewMap("messaging_consumers")
+
+// Defaults of the consumer options left unset
+const (
+	defaultMaxAttempts    = 3
+	defaultInitialBackoff = 100 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+	defaultLease          = time.Minute
+	defaultRetention      = 7 * 24 * time.Hour
+)
+
+// ErrInProgress is returned for a delivery of a message another delivery
+// is processing; the broker should deliver it again later
+var ErrInProgress = errors.New("message is being processed by another delivery")
+
+// RetryPolicy decides how often a failing handler is run
+type RetryPolicy struct {
+	// MaxAttempts is how many times the handler runs at most.
+	// defaultMaxAttempts when zero; 1 disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the pause after the first failed attempt. It
+	// doubles after each further failure, up to MaxBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the pause between attempts
+	MaxBackoff time.Duration
+}
+
+// withDefaults fills in the unset fields of p
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = defaultMaxAttempts
+	}
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultInitialBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = max(defaultMaxBackoff, p.InitialBackoff)
+	}
+	return p
+}
+
+// DeadLetter is a message its handler kept failing on
+type DeadLetter struct {
+	Message  Message
+	Consumer string
+
+	// Error is the last error of the handler
+	Error string
+
+	Attempts int
+	FailedAt time.Time
+}
+
+// DeadLetterQueue keeps the messages handlers gave up on, for inspection
+// and replay
+type DeadLetterQueue interface {
+	Send(ctx context.Context, letter DeadLetter) error
+}
+
+// Clock tells the time leases, records and the consumer lag are computed
+// with
+type Clock interface {
+	Now() time.Time
+}
+
+// Options configures a consumer
+type Options struct {
+	// Name identifies the consumer in the store and the metrics. Consumers
+	// of the same messages with different names each process them.
+	Name string
+
+	Retry RetryPolicy
+
+	// Lease is how long a delivery holds a message before another delivery
+	// may take it over. It must outlast all attempts of the handler, as
+	// the message is otherwise processed twice; a minute when zero.
+	Lease time.Duration
+
+	// Retention is how long processed messages are remembered, and should
+	// outlast the redelivery window of the broker; a week when zero
+	Retention time.Duration
+
+	// DeadLetters receives the messages whose handler still fails after
+	// the last attempt. Without it the claim is released and the error
+	// returned, so the broker delivers the message again.
+	DeadLetters DeadLetterQueue
+}
+
+// Consumer processes the deliveries of a subscription once each, retrying
+// failed handlers and dead lettering the messages they give up on
+type Consumer struct {
+	opts    Options
+	store   Store
+	handler Handler
+	log     *logger.Logger
+	clock   Clock
+
+	// lag is the delay between publishing and receiving of the last
+	// message, in milliseconds
+	lag atomic.Int64
+}
+
+// NewConsumer creates a consumer running handler once per message. Its
+// counters are published under /debug/vars as "<name>.received",
+// "<name>.processed", "<name>.duplicates", "<name>.retries",
+// "<name>.failed" and "<name>.dead_lettered", and its lag as
+// "<name>.lag_ms".
+func NewConsumer(opts Options, store Store, handler Handler, log *logger.Logger, clock Clock) *Consumer {
+	opts.Retry = opts.Retry.withDefaults()
+	if opts.Lease <= 0 {
+		opts.Lease = defaultLease
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = defaultRetention
+	}
+
+	c := &Consumer{
+		opts:    opts,
+		store:   store,
+		handler: handler,
+		log:     log,
+		clock:   clock,
+	}
+	metrics.Set(opts.Name+".lag_ms", expvar.Func(func() any { return c.lag.Load() }))
+	return c
+}
+
+// Handle processes a delivery and returns nil when the broker should
+// acknowledge it: the message was processed, now or before, or dead
+// lettered. Otherwise the broker should deliver it again.
+func (c *Consumer) Handle(ctx context.Context, msg Message) error {
+	name := c.opts.Name
+	now := c.clock.Now()
+	metrics.Add(name+".received", 1)
+	if !msg.PublishedAt.IsZero() {
+		c.lag.Store(max(now.Sub(msg.PublishedAt), 0).Milliseconds())
+	}
+
+	claim, err := c.store.Claim(ctx, name, msg.ID, now.Add(c.opts.Lease))
+	if err != nil {
+		return err
+	}
+	switch claim {
+	case AlreadyProcessed:
+		metrics.Add(name+".duplicates", 1)
+		return nil
+	case InProgress:
+		return ErrInProgress
+	}
+
+	// Continue the trace of the publisher
+	ctx = tracecontext.Extract(ctx, msg.Headers)
+
+	attempts, err := c.run(ctx, msg)
+	if err == nil {
+		metrics.Add(name+".processed", 1)
+		return c.store.Complete(ctx, name, msg.ID, c.clock.Now().Add(c.opts.Retention))
+	}
+	metrics.Add(name+".failed", 1)
+
+	if c.opts.DeadLetters == nil || ctx.Err() != nil {
+		return errors.Join(err, c.store.Release(context.WithoutCancel(ctx), name, msg.ID))
+	}
+
+	letter := DeadLetter{Message: msg, Consumer: name, Error: err.Error(), Attempts: attempts, FailedAt: c.clock.Now()}
+	if dlqErr := c.opts.DeadLetters.Send(ctx, letter); dlqErr != nil {
+		return errors.Join(err, dlqErr, c.store.Release(ctx, name, msg.ID))
+	}
+	metrics.Add(name+".dead_lettered", 1)
+	c.log.Warn().
+		Ctx(ctx).
+		Err(err).
+		Str("consumer", name).
+		Str("message_id", msg.ID).
+		Str("topic", msg.Topic).
+		Int("attempts", attempts).
+		Msg("Message dead lettered")
+
+	// A redelivery must not run the handler again
+	return c.store.Complete(ctx, name, msg.ID, c.clock.Now().Add(c.opts.Retention))
+}
+
+// run runs the handler until it succeeds, fails permanently, runs out of
+// attempts or ctx is done, and returns the attempts made and the last error
+func (c *Consumer) run(ctx context.Context, msg Message) (int, error) {
+	backoff := c.opts.Retry.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := c.handler(ctx, msg)
+		if err == nil || IsPermanent(err) || attempt >= c.opts.Retry.MaxAttempts {
+			return attempt, err
+		}
+
+		metrics.Add(c.opts.Name+".retries", 1)
+		timer := time.NewTimer(jitter(backoff))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		backoff = min(backoff*2, c.opts.Retry.MaxBackoff)
+	}
+}
+
+// jitter spreads d over its upper half, so consumers failing together do
+// not retry in lockstep
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
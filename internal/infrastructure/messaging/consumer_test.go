@@ -0,0 +1,178 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// fakeDeadLetters records the dead letters it gets
+type fakeDeadLetters struct {
+	letters []DeadLetter
+	err     error
+}
+
+func (q *fakeDeadLetters) Send(_ context.Context, letter DeadLetter) error {
+	if q.err != nil {
+		return q.err
+	}
+	q.letters = append(q.letters, letter)
+	return nil
+}
+
+// countingHandler fails the first failures calls with err
+func countingHandler(failures int32, err error) (Handler, *atomic.Int32) {
+	var calls atomic.Int32
+	return func(ctx context.Context, msg Message) error {
+		if calls.Add(1) <= failures {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func newConsumer(t *testing.T, handler Handler, deadLetters DeadLetterQueue) (*Consumer, Store) {
+	store := NewMemoryStore()
+	c := NewConsumer(Options{
+		Name:        t.Name(),
+		Retry:       RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		DeadLetters: deadLetters,
+	}, store, handler, logger.New("info", io.Discard), clock.System{})
+	return c, store
+}
+
+func counter(t *testing.T, name string) int64 {
+	v, ok := metrics.Get(t.Name() + "." + name).(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return v.Value()
+}
+
+func TestConsumer_ProcessesOnce(t *testing.T) {
+	handler, calls := countingHandler(0, nil)
+	c, _ := newConsumer(t, handler, nil)
+	msg := Message{ID: "msg-1"}
+
+	require.NoError(t, c.Handle(context.Background(), msg))
+	require.NoError(t, c.Handle(context.Background(), msg), "a redelivery is acknowledged")
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, int64(2), counter(t, "received"))
+	assert.Equal(t, int64(1), counter(t, "processed"))
+	assert.Equal(t, int64(1), counter(t, "duplicates"))
+}
+
+func TestConsumer_RetriesFailures(t *testing.T) {
+	handler, calls := countingHandler(2, errors.New("database unavailable"))
+	c, _ := newConsumer(t, handler, nil)
+
+	require.NoError(t, c.Handle(context.Background(), Message{ID: "msg-1"}))
+
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Equal(t, int64(2), counter(t, "retries"))
+	assert.Equal(t, int64(1), counter(t, "processed"))
+}
+
+func TestConsumer_DeadLetters(t *testing.T) {
+	handler, calls := countingHandler(10, errors.New("database unavailable"))
+	deadLetters := &fakeDeadLetters{}
+	c, _ := newConsumer(t, handler, deadLetters)
+	msg := Message{ID: "msg-1", Topic: "user.created"}
+
+	require.NoError(t, c.Handle(context.Background(), msg), "a dead lettered message is acknowledged")
+	require.NoError(t, c.Handle(context.Background(), msg))
+
+	assert.Equal(t, int32(3), calls.Load(), "a redelivery does not run the handler again")
+	require.Len(t, deadLetters.letters, 1)
+	letter := deadLetters.letters[0]
+	assert.Equal(t, msg, letter.Message)
+	assert.Equal(t, t.Name(), letter.Consumer)
+	assert.Equal(t, "database unavailable", letter.Error)
+	assert.Equal(t, 3, letter.Attempts)
+	assert.Equal(t, int64(1), counter(t, "dead_lettered"))
+}
+
+func TestConsumer_PermanentErrorsAreNotRetried(t *testing.T) {
+	handler, calls := countingHandler(10, Permanent(errors.New("malformed payload")))
+	deadLetters := &fakeDeadLetters{}
+	c, _ := newConsumer(t, handler, deadLetters)
+
+	require.NoError(t, c.Handle(context.Background(), Message{ID: "msg-1"}))
+
+	assert.Equal(t, int32(1), calls.Load())
+	require.Len(t, deadLetters.letters, 1)
+	assert.Equal(t, 1, deadLetters.letters[0].Attempts)
+}
+
+func TestConsumer_FailureWithoutDeadLetters(t *testing.T) {
+	handler, calls := countingHandler(3, errors.New("database unavailable"))
+	c, _ := newConsumer(t, handler, nil)
+	msg := Message{ID: "msg-1"}
+
+	assert.EqualError(t, c.Handle(context.Background(), msg), "database unavailable")
+	require.NoError(t, c.Handle(context.Background(), msg), "the released message is processed on redelivery")
+
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestConsumer_DeadLetterQueueFailure(t *testing.T) {
+	handler, calls := countingHandler(3, errors.New("database unavailable"))
+	c, _ := newConsumer(t, handler, &fakeDeadLetters{err: errors.New("queue unavailable")})
+	msg := Message{ID: "msg-1"}
+
+	err := c.Handle(context.Background(), msg)
+	assert.ErrorContains(t, err, "queue unavailable")
+
+	require.NoError(t, c.Handle(context.Background(), msg))
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestConsumer_InProgress(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	c, _ := newConsumer(t, func(ctx context.Context, msg Message) error {
+		close(started)
+		<-release
+		return nil
+	}, nil)
+	msg := Message{ID: "msg-1"}
+
+	done := make(chan error)
+	go func() { done <- c.Handle(context.Background(), msg) }()
+	<-started
+
+	assert.ErrorIs(t, c.Handle(context.Background(), msg), ErrInProgress)
+	close(release)
+	assert.NoError(t, <-done)
+}
+
+func TestConsumer_ContinuesTraceAndMeasuresLag(t *testing.T) {
+	var traceID string
+	c, _ := newConsumer(t, func(ctx context.Context, msg Message) error {
+		traceID = trace.SpanContextFromContext(ctx).TraceID().String()
+		return nil
+	}, nil)
+
+	require.NoError(t, c.Handle(context.Background(), Message{
+		ID:          "msg-1",
+		Headers:     map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+		PublishedAt: time.Now().Add(-2 * time.Second),
+	}))
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceID)
+	lag := metrics.Get(t.Name() + ".lag_ms").(expvar.Func)().(int64)
+	assert.GreaterOrEqual(t, lag, int64(2000))
+	assert.Less(t, lag, int64(10000))
+}
@@ -0,0 +1,82 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryRecord is a claim or a processed message held in memory
+type memoryRecord struct {
+	processed bool
+	expiresAt time.Time
+}
+
+// MemoryStore implements Store in process memory. Records are lost on
+// restart and not shared between instances, so it is meant for tests and
+// running without a database.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]memoryRecord
+}
+
+// NewMemoryStore creates a new in-memory processed message store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records: make(map[string]memoryRecord),
+	}
+}
+
+// Claim marks the message as being processed, unless an unexpired record
+// holds it
+func (s *MemoryStore) Claim(ctx context.Context, consumer, messageID string, leaseUntil time.Time) (ClaimResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := consumer + "/" + messageID
+	if existing, ok := s.records[key]; ok && existing.expiresAt.After(time.Now()) {
+		if existing.processed {
+			return AlreadyProcessed, nil
+		}
+		return InProgress, nil
+	}
+
+	s.records[key] = memoryRecord{expiresAt: leaseUntil}
+	return Claimed, nil
+}
+
+// Complete marks a claimed message processed
+func (s *MemoryStore) Complete(ctx context.Context, consumer, messageID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[consumer+"/"+messageID] = memoryRecord{processed: true, expiresAt: expiresAt}
+	return nil
+}
+
+// Release drops a claim
+func (s *MemoryStore) Release(ctx context.Context, consumer, messageID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := consumer + "/" + messageID
+	if !s.records[key].processed {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+// DeleteExpired removes records that expired before the cutoff
+func (s *MemoryStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key, rec := range s.records {
+		if !rec.expiresAt.After(before) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
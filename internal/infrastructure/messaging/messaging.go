@@ -0,0 +1,65 @@
+// Package messaging helps consumers of a message broker process each
+// message once, although brokers deliver them at least once.
+//
+// A Consumer wraps the handler of a subscription. Each delivery is claimed
+// in a Store of processed messages, in Postgres or Redis, so a redelivery
+// of a processed message is acknowledged without running the handler, and
+// two deliveries of the same message are not handled at once. Failed
+// handlers are retried with backoff; messages that still fail go to a
+// dead letter queue instead of blocking the subscription. Counters and the
+// consumer lag are published under /debug/vars.
+//
+// The package does not talk to a broker: the adapter of a subscription
+// converts each delivery to a Message, calls Consumer.Handle, and
+// acknowledges the delivery when it returns nil.
+package messaging
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Message is a delivery of a broker
+type Message struct {
+	// ID identifies the message across redeliveries, such as the ID of the
+	// event it carries; deliveries with the same ID are processed once
+	ID string
+
+	Topic   string
+	Payload []byte
+
+	// Headers carry metadata such as the W3C trace context
+	Headers map[string]string
+
+	// PublishedAt is when the message was published; the consumer lag is
+	// measured from it
+	PublishedAt time.Time
+}
+
+// Handler processes a message. Handlers are retried on error, so they must
+// tolerate running again after a partial failure.
+type Handler func(ctx context.Context, msg Message) error
+
+// permanentError marks handler errors retrying cannot fix
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error of a handler that retrying cannot fix, such as
+// a malformed payload, so the message goes to the dead letter queue at once
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var permanent permanentError
+	return errors.As(err, &permanent)
+}
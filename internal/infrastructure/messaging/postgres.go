@@ -0,0 +1,166 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProcessedMessageModel represents the database model for claimed and
+// processed messages
+type ProcessedMessageModel struct {
+	Consumer  string    `gorm:"type:varchar(255);primaryKey"`
+	MessageID string    `gorm:"type:varchar(255);primaryKey"`
+	Processed bool      `gorm:"not null;default:false"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+}
+
+// TableName specifies the table name for ProcessedMessageModel
+func (ProcessedMessageModel) TableName() string {
+	return "processed_messages"
+}
+
+// GormStore implements Store using GORM, so every instance of a consumer
+// shares the same records
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore creates a new database-backed processed message store
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{
+		db: db,
+	}
+}
+
+// Claim marks the message as being processed, unless an unexpired record
+// holds it
+func (s *GormStore) Claim(ctx context.Context, consumer, messageID string, leaseUntil time.Time) (ClaimResult, error) {
+	result := Claimed
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// An expired claim or record no longer holds the message
+		err := tx.Where("consumer = ? AND message_id = ? AND expires_at <= ?", consumer, messageID, time.Now()).
+			Delete(&ProcessedMessageModel{}).Error
+		if err != nil {
+			return err
+		}
+
+		created := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ProcessedMessageModel{
+			Consumer:  consumer,
+			MessageID: messageID,
+			ExpiresAt: leaseUntil,
+		})
+		if created.Error != nil {
+			return created.Error
+		}
+		if created.RowsAffected == 1 {
+			return nil
+		}
+
+		var model ProcessedMessageModel
+		if err := tx.Where("consumer = ? AND message_id = ?", consumer, messageID).First(&model).Error; err != nil {
+			return err
+		}
+		result = InProgress
+		if model.Processed {
+			result = AlreadyProcessed
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result, nil
+}
+
+// Complete marks a claimed message processed
+func (s *GormStore) Complete(ctx context.Context, consumer, messageID string, expiresAt time.Time) error {
+	return s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "consumer"}, {Name: "message_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"processed", "expires_at"}),
+		}).
+		Create(&ProcessedMessageModel{
+			Consumer:  consumer,
+			MessageID: messageID,
+			Processed: true,
+			ExpiresAt: expiresAt,
+		}).Error
+}
+
+// Release drops a claim
+func (s *GormStore) Release(ctx context.Context, consumer, messageID string) error {
+	return s.db.WithContext(ctx).
+		Where("consumer = ? AND message_id = ? AND NOT processed", consumer, messageID).
+		Delete(&ProcessedMessageModel{}).Error
+}
+
+// DeleteExpired removes records that expired before the cutoff
+func (s *GormStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", before).Delete(&ProcessedMessageModel{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+// DeadLetterModel represents the database model for dead letters
+type DeadLetterModel struct {
+	ID          uint64    `gorm:"primaryKey"`
+	Consumer    string    `gorm:"type:varchar(255);not null;index"`
+	MessageID   string    `gorm:"type:varchar(255);not null"`
+	Topic       string    `gorm:"type:varchar(255);not null;default:''"`
+	Payload     []byte    `gorm:""`
+	Headers     string    `gorm:"type:text;not null;default:'{}'"`
+	Error       string    `gorm:"type:text;not null"`
+	Attempts    int       `gorm:"not null"`
+	PublishedAt time.Time `gorm:""`
+	FailedAt    time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for DeadLetterModel
+func (DeadLetterModel) TableName() string {
+	return "dead_letters"
+}
+
+// GormDeadLetters implements DeadLetterQueue with a table, from which
+// failed messages can be inspected and replayed
+type GormDeadLetters struct {
+	db *gorm.DB
+}
+
+// NewGormDeadLetters creates a new database-backed dead letter queue
+func NewGormDeadLetters(db *gorm.DB) *GormDeadLetters {
+	return &GormDeadLetters{
+		db: db,
+	}
+}
+
+// Send stores the dead letter
+func (q *GormDeadLetters) Send(ctx context.Context, letter DeadLetter) error {
+	headers, err := json.Marshal(letter.Message.Headers)
+	if err != nil {
+		return err
+	}
+	if letter.Message.Headers == nil {
+		headers = []byte("{}")
+	}
+
+	return q.db.WithContext(ctx).Create(&DeadLetterModel{
+		Consumer:    letter.Consumer,
+		MessageID:   letter.Message.ID,
+		Topic:       letter.Message.Topic,
+		Payload:     letter.Message.Payload,
+		Headers:     string(headers),
+		Error:       letter.Error,
+		Attempts:    letter.Attempts,
+		PublishedAt: letter.Message.PublishedAt,
+		FailedAt:    letter.FailedAt,
+	}).Error
+}
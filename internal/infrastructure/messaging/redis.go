@@ -0,0 +1,94 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Values of the Redis keys of claims and processed messages
+const (
+	redisInProgress = "in_progress"
+	redisProcessed  = "processed"
+)
+
+// RedisStore implements Store with Redis keys expiring with their claim or
+// record, so every instance of a consumer shares them and nothing needs
+// deleting
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore creates a new Redis-backed processed message store. Keys
+// are named prefix, consumer and message ID, such as
+// "messaging:user-projector:42".
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// key returns the key of a message of consumer
+func (s *RedisStore) key(consumer, messageID string) string {
+	return s.prefix + ":" + consumer + ":" + messageID
+}
+
+// Claim marks the message as being processed, unless an unexpired key
+// holds it
+func (s *RedisStore) Claim(ctx context.Context, consumer, messageID string, leaseUntil time.Time) (ClaimResult, error) {
+	key := s.key(consumer, messageID)
+
+	claimed, err := s.client.SetNX(ctx, key, redisInProgress, ttl(leaseUntil)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if claimed {
+		return Claimed, nil
+	}
+
+	value, err := s.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		// The key expired in between; the redelivery will claim it
+		return InProgress, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if value == redisProcessed {
+		return AlreadyProcessed, nil
+	}
+	return InProgress, nil
+}
+
+// Complete marks a claimed message processed
+func (s *RedisStore) Complete(ctx context.Context, consumer, messageID string, expiresAt time.Time) error {
+	return s.client.Set(ctx, s.key(consumer, messageID), redisProcessed, ttl(expiresAt)).Err()
+}
+
+// ttl returns the expiry of a key expiring at t. A zero expiry would keep
+// the key forever, so a past t expires the key at once.
+func ttl(t time.Time) time.Duration {
+	return max(time.Until(t), time.Millisecond)
+}
+
+// releaseScript deletes a claim, but not a processed record
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Release drops a claim
+func (s *RedisStore) Release(ctx context.Context, consumer, messageID string) error {
+	return releaseScript.Run(ctx, s.client, []string{s.key(consumer, messageID)}, redisInProgress).Err()
+}
+
+// DeleteExpired does nothing: Redis expires the keys itself
+func (s *RedisStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
@@ -0,0 +1,42 @@
+package messaging
+
+import (
+	"context"
+	"time"
+)
+
+// ClaimResult tells what a claim found
+type ClaimResult int
+
+const (
+	// Claimed means the delivery holds the message and must process it
+	Claimed ClaimResult = iota
+
+	// AlreadyProcessed means the message was processed by an earlier
+	// delivery
+	AlreadyProcessed
+
+	// InProgress means another delivery holds an unexpired claim
+	InProgress
+)
+
+// Store records the messages each consumer has processed. Records are
+// keyed by consumer and message ID, so consumers of the same messages keep
+// track separately.
+type Store interface {
+	// Claim marks the message as being processed by consumer until
+	// leaseUntil. An expired claim, left by a crashed consumer, is taken
+	// over.
+	Claim(ctx context.Context, consumer, messageID string, leaseUntil time.Time) (ClaimResult, error)
+
+	// Complete marks a claimed message processed, and remembers it until
+	// expiresAt
+	Complete(ctx context.Context, consumer, messageID string, expiresAt time.Time) error
+
+	// Release drops a claim so a redelivery processes the message again
+	Release(ctx context.Context, consumer, messageID string) error
+
+	// DeleteExpired removes the records that expired before the cutoff and
+	// returns how many were removed
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
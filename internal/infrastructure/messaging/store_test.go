@@ -0,0 +1,168 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Each connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(t, db.AutoMigrate(&ProcessedMessageModel{}, &DeadLetterModel{}))
+	return db
+}
+
+func setupRedis(t *testing.T) (redis.UniversalClient, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return client, server
+}
+
+func newRedisStore(t *testing.T) Store {
+	client, _ := setupRedis(t)
+	return NewRedisStore(client, "messaging")
+}
+
+// forEachStore runs test against every Store implementation
+func forEachStore(t *testing.T, test func(t *testing.T, store Store)) {
+	t.Run("gorm", func(t *testing.T) { test(t, NewGormStore(setupTestDB(t))) })
+	t.Run("redis", func(t *testing.T) { test(t, newRedisStore(t)) })
+	t.Run("memory", func(t *testing.T) { test(t, NewMemoryStore()) })
+}
+
+func TestStore_ClaimAndComplete(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ctx := context.Background()
+		lease := time.Now().Add(time.Minute)
+
+		result, err := store.Claim(ctx, "projector", "msg-1", lease)
+		require.NoError(t, err)
+		assert.Equal(t, Claimed, result)
+
+		// A concurrent delivery waits for the claim
+		result, err = store.Claim(ctx, "projector", "msg-1", lease)
+		require.NoError(t, err)
+		assert.Equal(t, InProgress, result)
+
+		// Another consumer keeps track separately
+		result, err = store.Claim(ctx, "mailer", "msg-1", lease)
+		require.NoError(t, err)
+		assert.Equal(t, Claimed, result)
+
+		require.NoError(t, store.Complete(ctx, "projector", "msg-1", time.Now().Add(time.Hour)))
+
+		result, err = store.Claim(ctx, "projector", "msg-1", lease)
+		require.NoError(t, err)
+		assert.Equal(t, AlreadyProcessed, result)
+
+		// Releasing a processed message has no effect
+		require.NoError(t, store.Release(ctx, "projector", "msg-1"))
+		result, err = store.Claim(ctx, "projector", "msg-1", lease)
+		require.NoError(t, err)
+		assert.Equal(t, AlreadyProcessed, result)
+	})
+}
+
+func TestStore_Release(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ctx := context.Background()
+		lease := time.Now().Add(time.Minute)
+
+		_, err := store.Claim(ctx, "projector", "msg-1", lease)
+		require.NoError(t, err)
+		require.NoError(t, store.Release(ctx, "projector", "msg-1"))
+
+		result, err := store.Claim(ctx, "projector", "msg-1", lease)
+		require.NoError(t, err)
+		assert.Equal(t, Claimed, result, "a released message is processed again")
+	})
+}
+
+func TestStore_ExpiredClaimIsTakenOver(t *testing.T) {
+	client, server := setupRedis(t)
+	stores := map[string]struct {
+		store Store
+		// elapse lets d pass for the store
+		elapse func(d time.Duration)
+	}{
+		"gorm":   {NewGormStore(setupTestDB(t)), time.Sleep},
+		"redis":  {NewRedisStore(client, "messaging"), server.FastForward},
+		"memory": {NewMemoryStore(), time.Sleep},
+	}
+
+	for name, tt := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := tt.store.Claim(ctx, "projector", "msg-1", time.Now().Add(50*time.Millisecond))
+			require.NoError(t, err)
+			tt.elapse(100 * time.Millisecond)
+
+			result, err := tt.store.Claim(ctx, "projector", "msg-1", time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, Claimed, result, "the claim of a crashed consumer expires")
+		})
+	}
+}
+
+func TestStore_DeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range map[string]Store{"gorm": NewGormStore(setupTestDB(t)), "memory": NewMemoryStore()} {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			require.NoError(t, store.Complete(ctx, "projector", "old", now.Add(-time.Minute)))
+			require.NoError(t, store.Complete(ctx, "projector", "new", now.Add(time.Hour)))
+
+			deleted, err := store.DeleteExpired(ctx, now)
+			require.NoError(t, err)
+			assert.Equal(t, 1, deleted)
+
+			result, err := store.Claim(ctx, "projector", "new", now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, AlreadyProcessed, result)
+		})
+	}
+}
+
+func TestGormDeadLetters(t *testing.T) {
+	db := setupTestDB(t)
+	published := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := NewGormDeadLetters(db).Send(context.Background(), DeadLetter{
+		Message:  Message{ID: "msg-1", Topic: "user.created", Payload: []byte(`{"id":"42"}`), Headers: map[string]string{"traceparent": "00-abc"}, PublishedAt: published},
+		Consumer: "projector",
+		Error:    "boom",
+		Attempts: 3,
+		FailedAt: published.Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	var model DeadLetterModel
+	require.NoError(t, db.First(&model).Error)
+	assert.Equal(t, "projector", model.Consumer)
+	assert.Equal(t, "msg-1", model.MessageID)
+	assert.Equal(t, "user.created", model.Topic)
+	assert.Equal(t, []byte(`{"id":"42"}`), model.Payload)
+	assert.JSONEq(t, `{"traceparent":"00-abc"}`, model.Headers)
+	assert.Equal(t, "boom", model.Error)
+	assert.Equal(t, 3, model.Attempts)
+}
@@ -0,0 +1,182 @@
+// Package mtls serves the HTTP and gRPC listeners over TLS and,
+// optionally, authenticates clients by their certificates. A verified
+// client certificate names the client through its identity, the first URI
+// SAN (such as a SPIFFE ID), DNS SAN or common name, which is mapped to a
+// principal stored in the request context.
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+)
+
+// Client authentication modes
+const (
+	// ClientAuthRequire rejects connections without a client certificate
+	// signed by the client CA
+	ClientAuthRequire = "require"
+
+	// ClientAuthOptional verifies client certificates when they are sent
+	// but accepts connections without one, such as health probes
+	ClientAuthOptional = "optional"
+)
+
+var (
+	// ErrNoCertificate is returned for connections without a verified
+	// client certificate
+	ErrNoCertificate = errors.New("no client certificate")
+
+	// ErrUnknownIdentity is returned for client certificates whose
+	// identity is not mapped to a principal
+	ErrUnknownIdentity = errors.New("client certificate identity is not allowed")
+)
+
+// Options configures the TLS listeners
+type Options struct {
+	// CertFile and KeyFile hold the PEM certificate chain and key the
+	// server presents
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile holds the PEM certificates client certificates are
+	// verified against; empty does not ask for client certificates
+	ClientCAFile string
+
+	// ClientAuth is ClientAuthRequire or ClientAuthOptional.
+	// ClientAuthRequire when empty.
+	ClientAuth string
+}
+
+// ServerConfig returns the TLS configuration of the listeners
+func ServerConfig(opts Options) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if opts.ClientCAFile == "" {
+		return config, nil
+	}
+
+	switch opts.ClientAuth {
+	case ClientAuthRequire, "":
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	case ClientAuthOptional:
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	default:
+		return nil, fmt.Errorf("unknown client auth mode: %q", opts.ClientAuth)
+	}
+
+	pem, err := os.ReadFile(opts.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA: %w", err)
+	}
+	config.ClientCAs = x509.NewCertPool()
+	if !config.ClientCAs.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", opts.ClientCAFile)
+	}
+
+	return config, nil
+}
+
+// Principal identifies a client by its certificate
+type Principal struct {
+	// Subject is the principal the identity is mapped to
+	Subject string
+
+	// Identity is the URI SAN, DNS SAN or common name of the certificate
+	Identity string
+}
+
+// Identities maps the identities of verified client certificates to
+// principals
+type Identities struct {
+	principals map[string]string
+}
+
+// NewIdentities creates a mapping from certificate identities to principal
+// names. An empty mapping makes every identity its own principal; otherwise
+// identities missing from it are rejected.
+func NewIdentities(principals map[string]string) *Identities {
+	return &Identities{
+		principals: principals,
+	}
+}
+
+// Resolve returns the principal of the verified client certificate of the
+// connection. It returns ErrNoCertificate without one and
+// ErrUnknownIdentity when its identity is not mapped.
+func (i *Identities) Resolve(state *tls.ConnectionState) (Principal, error) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return Principal{}, ErrNoCertificate
+	}
+
+	identity := Identity(state.VerifiedChains[0][0])
+	if len(i.principals) == 0 {
+		return Principal{Subject: identity, Identity: identity}, nil
+	}
+
+	subject, ok := i.principals[identity]
+	if !ok || identity == "" {
+		return Principal{}, ErrUnknownIdentity
+	}
+	return Principal{Subject: subject, Identity: identity}, nil
+}
+
+// Identity returns the identity of a client certificate: its first URI
+// SAN, otherwise its first DNS SAN, otherwise its common name
+func Identity(cert *x509.Certificate) string {
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+	return cert.Subject.CommonName
+}
+
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the client principal
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal of the client certificate of
+// the request
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// Middleware stores the principal of the client certificate of each
+// request in its context. Requests whose certificate identity is not
+// mapped get 403; requests without a certificate, only accepted in
+// ClientAuthOptional mode, go through without a principal.
+func Middleware(identities *Identities) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, err := identities.Resolve(c.Request.TLS)
+		switch {
+		case errors.Is(err, ErrNoCertificate):
+			c.Next()
+		case err != nil:
+			problem.Abort(c, problem.New(http.StatusForbidden, "client certificate is not allowed"))
+		default:
+			c.Request = c.Request.WithContext(WithPrincipal(c.Request.Context(), principal))
+			c.Next()
+		}
+	}
+}
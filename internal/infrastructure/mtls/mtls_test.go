@@ -0,0 +1,232 @@
+package mtls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPKI is a CA with a server certificate for 127.0.0.1 and client
+// certificates, written to a temporary directory
+type testPKI struct {
+	t      *testing.T
+	dir    string
+	ca     *x509.Certificate
+	caKey  *ecdsa.PrivateKey
+	caPool *x509.CertPool
+}
+
+func newTestPKI(t *testing.T) *testPKI {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pki := &testPKI{t: t, dir: t.TempDir(), ca: ca, caKey: key, caPool: x509.NewCertPool()}
+	pki.caPool.AddCert(ca)
+	pki.write("ca.pem", "CERTIFICATE", der)
+	return pki
+}
+
+func (p *testPKI) write(name, blockType string, der []byte) string {
+	path := filepath.Join(p.dir, name)
+	require.NoError(p.t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600))
+	return path
+}
+
+// issue signs a certificate shaped by template and returns it with its key
+func (p *testPKI) issue(template *x509.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(p.t, err)
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	der, err := x509.CreateCertificate(rand.Reader, template, p.ca, &key.PublicKey, p.caKey)
+	require.NoError(p.t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// serverOptions writes a server certificate and returns options serving it
+func (p *testPKI) serverOptions(clientAuth string) Options {
+	cert := p.issue(&x509.Certificate{
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	require.NoError(p.t, err)
+
+	return Options{
+		CertFile:     p.write("server.pem", "CERTIFICATE", cert.Certificate[0]),
+		KeyFile:      p.write("server-key.pem", "PRIVATE KEY", keyDER),
+		ClientCAFile: filepath.Join(p.dir, "ca.pem"),
+		ClientAuth:   clientAuth,
+	}
+}
+
+// client returns an HTTP client trusting the CA and presenting certs
+func (p *testPKI) client(certs ...tls.Certificate) *http.Client {
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      p.caPool,
+		Certificates: certs,
+	}}}
+}
+
+func clientCert(p *testPKI, uri string) tls.Certificate {
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "orders"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if uri != "" {
+		u, err := url.Parse(uri)
+		require.NoError(p.t, err)
+		template.URIs = []*url.URL{u}
+	}
+	return p.issue(template)
+}
+
+// startServer serves the principal of each request over TLS configured by
+// opts
+func startServer(t *testing.T, opts Options, identities *Identities) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	config, err := ServerConfig(opts)
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(Middleware(identities))
+	router.GET("/whoami", func(c *gin.Context) {
+		principal, _ := PrincipalFromContext(c.Request.Context())
+		c.String(http.StatusOK, principal.Subject)
+	})
+
+	server := httptest.NewUnstartedServer(router)
+	server.TLS = config
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, client *http.Client, url string) (int, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestMiddleware_RequireClientCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	server := startServer(t, pki.serverOptions(ClientAuthRequire), NewIdentities(nil))
+
+	t.Run("maps the certificate identity to the principal", func(t *testing.T) {
+		status, body := get(t, pki.client(clientCert(pki, "spiffe://example.org/ns/prod/sa/orders")), server.URL+"/whoami")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "spiffe://example.org/ns/prod/sa/orders", body)
+	})
+
+	t.Run("falls back to the common name", func(t *testing.T) {
+		_, body := get(t, pki.client(clientCert(pki, "")), server.URL+"/whoami")
+		assert.Equal(t, "orders", body)
+	})
+
+	t.Run("rejects connections without a certificate", func(t *testing.T) {
+		_, err := pki.client().Get(server.URL + "/whoami")
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects certificates of another CA", func(t *testing.T) {
+		other := newTestPKI(t)
+		client := pki.client(clientCert(other, "spiffe://example.org/ns/prod/sa/orders"))
+		_, err := client.Get(server.URL + "/whoami")
+		assert.Error(t, err)
+	})
+}
+
+func TestMiddleware_OptionalClientCertificate(t *testing.T) {
+	pki := newTestPKI(t)
+	identities := NewIdentities(map[string]string{"spiffe://example.org/ns/prod/sa/orders": "orders-service"})
+	server := startServer(t, pki.serverOptions(ClientAuthOptional), identities)
+
+	status, body := get(t, pki.client(), server.URL+"/whoami")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, body, "no principal without a certificate")
+
+	status, body = get(t, pki.client(clientCert(pki, "spiffe://example.org/ns/prod/sa/orders")), server.URL+"/whoami")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "orders-service", body)
+
+	status, _ = get(t, pki.client(clientCert(pki, "spiffe://example.org/ns/prod/sa/billing")), server.URL+"/whoami")
+	assert.Equal(t, http.StatusForbidden, status)
+}
+
+func TestServerConfig(t *testing.T) {
+	pki := newTestPKI(t)
+
+	opts := pki.serverOptions("")
+	config, err := ServerConfig(opts)
+	require.NoError(t, err)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, config.ClientAuth)
+
+	opts.ClientCAFile = ""
+	config, err = ServerConfig(opts)
+	require.NoError(t, err)
+	assert.Equal(t, tls.NoClientCert, config.ClientAuth)
+
+	opts = pki.serverOptions("sometimes")
+	_, err = ServerConfig(opts)
+	assert.EqualError(t, err, `unknown client auth mode: "sometimes"`)
+
+	opts = pki.serverOptions(ClientAuthRequire)
+	opts.ClientCAFile = opts.KeyFile
+	_, err = ServerConfig(opts)
+	assert.Error(t, err)
+
+	opts.CertFile = filepath.Join(pki.dir, "missing.pem")
+	_, err = ServerConfig(opts)
+	assert.Error(t, err)
+}
+
+func TestIdentity(t *testing.T) {
+	uri, err := url.Parse("spiffe://example.org/sa/orders")
+	require.NoError(t, err)
+
+	assert.Equal(t, "spiffe://example.org/sa/orders", Identity(&x509.Certificate{URIs: []*url.URL{uri}, DNSNames: []string{"orders.internal"}}))
+	assert.Equal(t, "orders.internal", Identity(&x509.Certificate{DNSNames: []string{"orders.internal"}, Subject: pkix.Name{CommonName: "orders"}}))
+	assert.Equal(t, "orders", Identity(&x509.Certificate{Subject: pkix.Name{CommonName: "orders"}}))
+}
@@ -0,0 +1,186 @@
+// Package openapi validates requests against the OpenAPI document the API
+// publishes, so the routes it describes cannot accept what the contract
+// rejects, whatever their handlers bind.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi2conv"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// errInvalidRequest is the message of requests the document rejects
+const errInvalidRequest = "request does not match the API specification"
+
+// Validator checks requests against the operations of an OpenAPI document
+type Validator struct {
+	router       routers.Router
+	maxBodyBytes int64
+}
+
+// NewValidator parses an OpenAPI 3 document, or a Swagger 2.0 one such as
+// protoc-gen-openapiv2 generates, which is converted first. Bodies larger
+// than maxBodyBytes are rejected; zero uses request.DefaultMaxBodyBytes.
+func NewValidator(spec []byte, maxBodyBytes int64) (*Validator, error) {
+	doc, err := load(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to route the OpenAPI document: %w", err)
+	}
+
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = request.DefaultMaxBodyBytes
+	}
+	return &Validator{router: router, maxBodyBytes: maxBodyBytes}, nil
+}
+
+// load parses and validates the document, converting Swagger 2.0
+func load(spec []byte) (*openapi3.T, error) {
+	var version struct {
+		Swagger string `json:"swagger"`
+	}
+	if err := json.Unmarshal(spec, &version); err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI document: %w", err)
+	}
+
+	loader := openapi3.NewLoader()
+	var doc *openapi3.T
+	if version.Swagger != "" {
+		var doc2 openapi2.T
+		if err := json.Unmarshal(spec, &doc2); err != nil {
+			return nil, fmt.Errorf("failed to parse the Swagger document: %w", err)
+		}
+		converted, err := openapi2conv.ToV3(&doc2)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert the Swagger document: %w", err)
+		}
+		doc = converted
+	} else {
+		parsed, err := loader.LoadFromData(spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the OpenAPI document: %w", err)
+		}
+		doc = parsed
+	}
+
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	return doc, nil
+}
+
+// Validate checks the parameters and body of r against its operation in
+// the document. Requests for paths or methods the document does not
+// describe are left to the router. Errors are *request.Error values
+// listing the offending parameters and body fields; the body stays
+// readable for the handler.
+func (v *Validator) Validate(w http.ResponseWriter, r *http.Request) error {
+	route, params, err := v.router.FindRoute(r)
+	if err != nil {
+		return nil
+	}
+
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = http.MaxBytesReader(w, r.Body, v.maxBodyBytes)
+	}
+
+	err = openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: params,
+		Route:      route,
+		Options: &openapi3filter.Options{
+			MultiError:         true,
+			AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+		},
+	})
+	if err == nil {
+		return nil
+	}
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return &request.Error{
+			Status:  http.StatusRequestEntityTooLarge,
+			Message: fmt.Sprintf("request body cannot exceed %d bytes", v.maxBodyBytes),
+		}
+	}
+
+	return &request.Error{
+		Status:  http.StatusBadRequest,
+		Message: errInvalidRequest,
+		Fields:  fieldErrors(err, ""),
+	}
+}
+
+// Middleware answers requests the validator rejects with problem details
+// listing the offending fields in their fields member
+func Middleware(v *Validator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := v.Validate(c.Writer, c.Request)
+		if err == nil {
+			c.Next()
+			return
+		}
+
+		var invalid *request.Error
+		if !errors.As(err, &invalid) {
+			problem.Abort(c, problem.New(http.StatusBadRequest, err.Error()))
+			return
+		}
+		details := problem.New(invalid.Status, invalid.Message)
+		details.Code = problem.CodeOf(invalid)
+		details.Fields = invalid.Fields
+		problem.Abort(c, details)
+	}
+}
+
+// fieldErrors lists the fields of a validation error. Parameters are named
+// as in the request and body fields by their path, such as "address.city".
+func fieldErrors(err error, field string) []request.FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		var fields []request.FieldError
+		for _, inner := range e {
+			fields = append(fields, fieldErrors(inner, field)...)
+		}
+		return fields
+	case *openapi3filter.RequestError:
+		if e.Parameter != nil {
+			field = e.Parameter.Name
+		}
+		switch e.Err.(type) {
+		case openapi3.MultiError, *openapi3.SchemaError:
+			return fieldErrors(e.Err, field)
+		}
+		message := e.Reason
+		if message == "" && e.Err != nil {
+			message = e.Err.Error()
+		}
+		return []request.FieldError{{Field: field, Message: message}}
+	case *openapi3.SchemaError:
+		path := e.JSONPointer()
+		if field != "" {
+			path = append([]string{field}, path...)
+		}
+		return []request.FieldError{{Field: strings.Join(path, "."), Message: e.Reason}}
+	default:
+		return []request.FieldError{{Field: field, Message: err.Error()}}
+	}
+}
@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	userv1 "github.com/yourusername/go-scaffolding/api/proto/user/v1"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// listSpec is an OpenAPI 3 document with a query parameter
+const listSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "test", "version": "1"},
+  "paths": {
+    "/items": {
+      "get": {
+        "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "maximum": 100}}],
+        "responses": {"200": {"description": "items"}}
+      }
+    }
+  }
+}`
+
+// newRouter serves routes echoing request bodies behind the middleware
+func newRouter(t *testing.T, spec string, maxBodyBytes int64) *gin.Engine {
+	t.Helper()
+
+	validator, err := NewValidator([]byte(spec), maxBodyBytes)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware(validator))
+	echo := func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		require.NoError(t, err)
+		c.String(http.StatusOK, string(body))
+	}
+	router.POST("/v1/users", echo)
+	router.PUT("/v1/users/:id", echo)
+	router.GET("/items", echo)
+	router.GET("/undocumented", echo)
+	return router
+}
+
+// serve sends a request with a JSON body, if any
+func serve(router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// decodeProblem decodes problem details
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) problem.Details {
+	t.Helper()
+
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	var details problem.Details
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&details))
+	return details
+}
+
+func TestMiddleware_AcceptsValidRequests(t *testing.T) {
+	router := newRouter(t, string(userv1.OpenAPI), 0)
+
+	body := `{"email":"ada@example.com","name":"Ada"}`
+	w := serve(router, http.MethodPost, "/v1/users", body)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, body, w.Body.String(), "the handler still reads the body")
+
+	w = serve(router, http.MethodGet, "/undocumented", "")
+	assert.Equal(t, http.StatusOK, w.Code, "routes outside the document are not validated")
+}
+
+func TestMiddleware_RejectsInvalidBodies(t *testing.T) {
+	router := newRouter(t, string(userv1.OpenAPI), 0)
+
+	w := serve(router, http.MethodPost, "/v1/users", `{"email":42}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	details := decodeProblem(t, w)
+	assert.Equal(t, problem.CodeValidationFailed, details.Code)
+	assert.Equal(t, errInvalidRequest, details.Detail)
+	assert.Equal(t, "/v1/users", details.Instance)
+	assert.ElementsMatch(t, []request.FieldError{
+		{Field: "email", Message: "value must be a string"},
+		{Field: "name", Message: `property "name" is missing`},
+	}, details.Fields)
+
+	w = serve(router, http.MethodPut, "/v1/users/42", `{}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	details = decodeProblem(t, w)
+	assert.Equal(t, []request.FieldError{{Field: "name", Message: `property "name" is missing`}}, details.Fields)
+
+	w = serve(router, http.MethodPost, "/v1/users", `{"email":`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	details = decodeProblem(t, w)
+	require.Len(t, details.Fields, 1)
+	assert.Empty(t, details.Fields[0].Field)
+}
+
+func TestMiddleware_RejectsInvalidParameters(t *testing.T) {
+	router := newRouter(t, listSpec, 0)
+
+	w := serve(router, http.MethodGet, "/items?limit=10", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(router, http.MethodGet, "/items?limit=many", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	details := decodeProblem(t, w)
+	require.Len(t, details.Fields, 1)
+	assert.Equal(t, "limit", details.Fields[0].Field)
+
+	w = serve(router, http.MethodGet, "/items?limit=500", "")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	details = decodeProblem(t, w)
+	require.Len(t, details.Fields, 1)
+	assert.Equal(t, "limit", details.Fields[0].Field)
+}
+
+func TestMiddleware_LimitsBodies(t *testing.T) {
+	router := newRouter(t, string(userv1.OpenAPI), 16)
+
+	w := serve(router, http.MethodPost, "/v1/users", `{"email":"ada@example.com","name":"Ada"}`)
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	details := decodeProblem(t, w)
+	assert.Equal(t, problem.CodeRequestTooLarge, details.Code)
+	assert.Equal(t, "request body cannot exceed 16 bytes", details.Detail)
+}
+
+func TestNewValidator_InvalidDocument(t *testing.T) {
+	_, err := NewValidator([]byte(`{"openapi": "3.0.3", "paths": {}}`), 0)
+	assert.ErrorContains(t, err, "invalid OpenAPI document")
+
+	_, err = NewValidator([]byte(`not json`), 0)
+	assert.ErrorContains(t, err, "failed to parse the OpenAPI document")
+}
@@ -0,0 +1,123 @@
+// Package passwordhash hashes passwords with Argon2id and encodes the
+// hashes in the PHC string format:
+//
+//	$argon2id$v=19$m=19456,t=2,p=1$<salt>$<key>
+//
+// The parameters are stored with each hash, so raising them later keeps
+// existing hashes verifiable.
+package passwordhash
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// ErrMalformedHash indicates a stored hash is not an Argon2id PHC string
+var ErrMalformedHash = errors.New("malformed argon2id hash")
+
+// Params are the Argon2id cost parameters
+type Params struct {
+	// Memory is the memory used, in KiB
+	Memory uint32
+
+	// Iterations is the number of passes over the memory
+	Iterations uint32
+
+	// Parallelism is the number of threads used
+	Parallelism uint8
+
+	// SaltLength and KeyLength are in bytes
+	SaltLength uint32
+	KeyLength  uint32
+}
+
+// DefaultParams returns the minimum parameters OWASP recommends for
+// Argon2id: 19 MiB of memory, two iterations and one thread
+func DefaultParams() Params {
+	return Params{
+		Memory:      19 * 1024,
+		Iterations:  2,
+		Parallelism: 1,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// Argon2id hashes passwords with Argon2id
+type Argon2id struct {
+	params Params
+}
+
+// NewArgon2id creates a hasher that hashes new passwords with params
+func NewArgon2id(params Params) *Argon2id {
+	return &Argon2id{params: params}
+}
+
+// Hash returns a PHC string holding a hash of password with a random salt
+func (h *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	p := h.params
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, p.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify reports whether password matches hash, using the parameters
+// stored in hash
+func (h *Argon2id) Verify(hash, password string) (bool, error) {
+	p, salt, key, err := decode(hash)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, uint32(len(key)))
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// decode splits a PHC string into its parameters, salt and key
+func decode(hash string) (Params, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+	if version != argon2.Version {
+		return Params{}, nil, nil, fmt.Errorf("%w: unsupported version %d", ErrMalformedHash, version)
+	}
+
+	var p Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+	if p.Memory == 0 || p.Iterations == 0 || p.Parallelism == 0 {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil || len(salt) == 0 {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Params{}, nil, nil, ErrMalformedHash
+	}
+
+	return p, salt, key, nil
+}
@@ -0,0 +1,61 @@
+package passwordhash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testParams keep the tests fast
+var testParams = Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+
+func TestArgon2id_HashAndVerify(t *testing.T) {
+	h := NewArgon2id(testParams)
+
+	hash, err := h.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"), hash)
+
+	ok, err := h.Verify(hash, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify(hash, "correct horse battery stapler")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	other, err := h.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "every hash has its own salt")
+}
+
+func TestArgon2id_VerifyUsesStoredParams(t *testing.T) {
+	hash, err := NewArgon2id(testParams).Hash("secret password")
+	require.NoError(t, err)
+
+	stronger := testParams
+	stronger.Iterations = 3
+	ok, err := NewArgon2id(stronger).Verify(hash, "secret password")
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestArgon2id_MalformedHash(t *testing.T) {
+	h := NewArgon2id(testParams)
+
+	for _, hash := range []string{
+		"",
+		"plaintext",
+		"$2a$10$abcdefghijklmnopqrstuv",
+		"$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=18$m=64,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=0,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA$",
+	} {
+		_, err := h.Verify(hash, "password")
+		assert.ErrorIs(t, err, ErrMalformedHash, hash)
+	}
+}
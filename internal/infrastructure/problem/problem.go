@@ -0,0 +1,149 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// ContentType is the media type of problem details (RFC 9457)
+const ContentType = "application/problem+json"
+
+// Codes of errors raised by the HTTP layer rather than by a domain. Error
+// responses carry them in the same code field as domain error codes.
+const (
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeUnauthenticated  = "UNAUTHENTICATED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	CodeConflict         = "CONFLICT"
+	CodeRequestTooLarge  = "REQUEST_TOO_LARGE"
+	CodeQuotaExceeded    = "QUOTA_EXCEEDED"
+	CodeRateLimited      = "RATE_LIMITED"
+	CodeUnavailable      = "UNAVAILABLE"
+	CodeTimeout          = "TIMEOUT"
+	CodeInternal         = "INTERNAL"
+
+	CodeInvalidPathParameter = request.CodeInvalidPathParameter
+)
+
+// CodeFor returns the code of an HTTP layer error answered with status
+func CodeFor(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeValidationFailed
+	case http.StatusUnauthorized:
+		return CodeUnauthenticated
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return CodeRequestTooLarge
+	case http.StatusTooManyRequests:
+		return CodeQuotaExceeded
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	default:
+		return CodeInternal
+	}
+}
+
+// CodeOf returns the code of a binding error: its own, or that of its status
+func CodeOf(err *request.Error) string {
+	if err.Code != "" {
+		return err.Code
+	}
+	return CodeFor(err.Status)
+}
+
+// Details describes an HTTP error as defined by RFC 9457. Code and Fields
+// are extension members holding the machine-readable error code and, for
+// invalid requests, the offending fields.
+type Details struct {
+	Type     string               `json:"type"`
+	Title    string               `json:"title"`
+	Status   int                  `json:"status"`
+	Code     string               `json:"code"`
+	Detail   string               `json:"detail,omitempty"`
+	Instance string               `json:"instance,omitempty"`
+	Fields   []request.FieldError `json:"fields,omitempty"`
+}
+
+// New creates problem details for a status. The type is "about:blank", so
+// the title is the status text, and the code is the status's.
+func New(status int, detail string) Details {
+	return Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Code:   CodeFor(status),
+		Detail: detail,
+	}
+}
+
+// Abort writes the problem details for the request and stops the handler chain
+func Abort(c *gin.Context, details Details) {
+	if details.Instance == "" {
+		details.Instance = c.Request.URL.Path
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(details.Status, details)
+}
+
+// Write writes the problem details to w. It is meant for writers wrapping
+// the response, which cannot go through the gin context.
+func Write(w http.ResponseWriter, details Details) error {
+	body, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(details.Status)
+	_, err = w.Write(body)
+	return err
+}
+
+// Register makes the engine answer unknown routes with 404 problem
+// details, known routes called with an unsupported method with 405 and an
+// Allow header, and OPTIONS requests with the allowed methods.
+func Register(engine *gin.Engine) {
+	engine.HandleMethodNotAllowed = true
+	engine.NoRoute(NoRoute)
+	engine.NoMethod(NoMethod)
+}
+
+// NoRoute answers requests that match no route
+func NoRoute(c *gin.Context) {
+	Abort(c, New(http.StatusNotFound, "no route matches "+c.Request.Method+" "+c.Request.URL.Path))
+}
+
+// NoMethod answers requests whose route does not support the method. Gin
+// has already set the Allow header to the supported methods.
+func NoMethod(c *gin.Context) {
+	allowed := c.Writer.Header().Get("Allow")
+	if !strings.Contains(allowed, http.MethodOptions) {
+		allowed += ", " + http.MethodOptions
+		c.Header("Allow", allowed)
+	}
+
+	if c.Request.Method == http.MethodOptions {
+		c.AbortWithStatus(http.StatusNoContent)
+		return
+	}
+
+	Abort(c, New(http.StatusMethodNotAllowed, c.Request.Method+" is not allowed; use one of "+allowed))
+}
@@ -0,0 +1,94 @@
+package problem
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	Register(router)
+	router.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.PUT("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func serve(router *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) Details {
+	t.Helper()
+
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+
+	var details Details
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+	return details
+}
+
+func TestNoRoute(t *testing.T) {
+	w := serve(setupRouter(), http.MethodGet, "/nope")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, Details{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Code:     CodeNotFound,
+		Detail:   "no route matches GET /nope",
+		Instance: "/nope",
+	}, decode(t, w))
+}
+
+func TestNoMethod(t *testing.T) {
+	w := serve(setupRouter(), http.MethodDelete, "/users/1")
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, PUT, OPTIONS", w.Header().Get("Allow"))
+
+	details := decode(t, w)
+	assert.Equal(t, http.StatusMethodNotAllowed, details.Status)
+	assert.Equal(t, CodeMethodNotAllowed, details.Code)
+	assert.Equal(t, "Method Not Allowed", details.Title)
+	assert.Equal(t, "/users/1", details.Instance)
+}
+
+func TestOptions(t *testing.T) {
+	router := setupRouter()
+
+	w := serve(router, http.MethodOptions, "/users/1")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "GET, PUT, OPTIONS", w.Header().Get("Allow"))
+	assert.Empty(t, w.Body.String())
+
+	// Unknown routes are not found whatever the method
+	w = serve(router, http.MethodOptions, "/nope")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestAbort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/fail", func(c *gin.Context) {
+		Abort(c, New(http.StatusServiceUnavailable, "try again later"))
+		c.Status(http.StatusOK) // ignored after Abort
+	})
+
+	w := serve(router, http.MethodGet, "/fail")
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	details := decode(t, w)
+	assert.Equal(t, "try again later", details.Detail)
+	assert.Equal(t, "Service Unavailable", details.Title)
+}
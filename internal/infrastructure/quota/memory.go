@@ -0,0 +1,71 @@
+package quota
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore implements Store in process memory. Counts are lost on
+// restart and not shared between instances, so it is meant for running
+// without a database.
+type MemoryStore struct {
+	mu     sync.Mutex
+	counts map[memoryKey]memoryCount
+}
+
+type memoryKey struct {
+	subject string
+	window  string
+}
+
+type memoryCount struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// NewMemoryStore creates a new in-memory quota store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counts: make(map[memoryKey]memoryCount),
+	}
+}
+
+// Counts returns the requests counted for subject in each window
+func (s *MemoryStore) Counts(ctx context.Context, subject string, windows []Window) ([]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counts := make([]int64, len(windows))
+	for i, w := range windows {
+		counts[i] = s.counts[memoryKey{subject, w.Key}].count
+	}
+	return counts, nil
+}
+
+// Increment counts one request of subject in each window
+func (s *MemoryStore) Increment(ctx context.Context, subject string, windows []Window) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, w := range windows {
+		key := memoryKey{subject, w.Key}
+		s.counts[key] = memoryCount{count: s.counts[key].count + 1, expiresAt: w.ExpiresAt}
+	}
+	return nil
+}
+
+// DeleteExpired removes the counts of windows that expired before the cutoff
+func (s *MemoryStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := 0
+	for key, c := range s.counts {
+		if !c.expiresAt.After(before) {
+			delete(s.counts, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
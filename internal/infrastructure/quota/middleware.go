@@ -0,0 +1,187 @@
+package quota
+
+import (
+	"crypto/subtle"
+	"expvar"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+)
+
+// metrics publishes quota counters under /debug/vars
+var metrics = expvar.NewMap("quota")
+
+const (
+	// APIKeyHeader is the header API clients send their key in
+	APIKeyHeader = "X-API-Key"
+
+	// UsagePath reports the quotas of the caller; requests to it are not
+	// counted
+	UsagePath = "/usage"
+)
+
+// subjectKey is the gin context key of the subject of a request
+const subjectKey = "quota.subject"
+
+// Options configures the quota middleware
+type Options struct {
+	// APIKeys maps the name of each API client to its key
+	APIKeys map[string]string
+
+	// ExemptPaths are path prefixes served without counting
+	ExemptPaths []string
+}
+
+// UsageResponse is the body of the usage routes
+type UsageResponse struct {
+	Subject string  `json:"subject"`
+	Quotas  []Usage `json:"quotas"`
+}
+
+// Middleware counts each request against the quotas of the API client
+// named by the X-API-Key header or, without one, of the tenant of the
+// request. Requests from neither are not counted. Counted responses carry
+// the quota closest to running out in X-Quota-Limit, X-Quota-Remaining and
+// X-Quota-Reset, in seconds; once it runs out, requests are answered with
+// 429 and a Retry-After header until it resets. Requests are let through
+// when the store fails, so quota accounting never takes the API down.
+func Middleware(t *Tracker, opts Options, log *logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if exempt(path, opts.ExemptPaths) {
+			c.Next()
+			return
+		}
+
+		subject, ok := subjectOf(c, opts.APIKeys)
+		if !ok {
+			problem.Abort(c, problem.New(http.StatusUnauthorized, "invalid API key"))
+			return
+		}
+		if subject == "" {
+			c.Next()
+			return
+		}
+		c.Set(subjectKey, subject)
+
+		if path == UsagePath {
+			c.Next()
+			return
+		}
+
+		usage, exceeded, err := t.Take(c.Request.Context(), subject)
+		if err != nil {
+			log.Warn().Ctx(c.Request.Context()).Err(err).Str("subject", subject).Msg("Failed to count request against quota")
+			c.Next()
+			return
+		}
+
+		now := t.clock.Now()
+		if exceeded != nil {
+			metrics.Add("exceeded", 1)
+			setHeaders(c, *exceeded, now)
+			c.Header("Retry-After", c.Writer.Header().Get("X-Quota-Reset"))
+			problem.Abort(c, problem.New(http.StatusTooManyRequests, fmt.Sprintf(
+				"%s quota of %d requests exceeded; it resets at %s",
+				exceeded.Period, exceeded.Limit, exceeded.ResetsAt.Format(time.RFC3339),
+			)))
+			return
+		}
+
+		if len(usage) > 0 {
+			metrics.Add("counted", 1)
+			setHeaders(c, tightest(usage), now)
+		}
+		c.Next()
+	}
+}
+
+// RegisterRoutes registers GET /usage, which reports the quotas of the
+// caller. It must be served behind Middleware.
+func RegisterRoutes(router gin.IRoutes, t *Tracker) {
+	router.GET(UsagePath, func(c *gin.Context) {
+		subject := c.GetString(subjectKey)
+		if subject == "" {
+			problem.Abort(c, problem.New(http.StatusUnauthorized, "an API key or tenant is required"))
+			return
+		}
+		writeUsage(c, t, subject)
+	})
+}
+
+// RegisterAdminRoutes registers GET /usage/:subject on the admin group,
+// which reports the quotas of any subject, such as "tenant:acme"
+func RegisterAdminRoutes(group gin.IRoutes, t *Tracker) {
+	group.GET(UsagePath+"/:subject", func(c *gin.Context) {
+		writeUsage(c, t, c.Param("subject"))
+	})
+}
+
+// writeUsage answers with the quotas of subject
+func writeUsage(c *gin.Context, t *Tracker, subject string) {
+	usage, err := t.Usage(c.Request.Context(), subject)
+	if err != nil {
+		problem.Abort(c, problem.New(http.StatusInternalServerError, "failed to read quota usage"))
+		return
+	}
+	if usage == nil {
+		usage = []Usage{}
+	}
+	c.JSON(http.StatusOK, UsageResponse{Subject: subject, Quotas: usage})
+}
+
+// subjectOf returns the subject of the request, or "" when it has none. It
+// reports false for an unknown API key.
+func subjectOf(c *gin.Context, apiKeys map[string]string) (string, bool) {
+	if key := c.GetHeader(APIKeyHeader); key != "" {
+		for name, known := range apiKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(known)) == 1 {
+				return "api_key:" + name, true
+			}
+		}
+		return "", false
+	}
+
+	if tenant, ok := tenancy.FromContext(c.Request.Context()); ok {
+		return "tenant:" + tenant, true
+	}
+	return "", true
+}
+
+// tightest returns the quota with the fewest requests remaining
+func tightest(usage []Usage) Usage {
+	tight := usage[0]
+	for _, u := range usage[1:] {
+		if u.Remaining < tight.Remaining {
+			tight = u
+		}
+	}
+	return tight
+}
+
+// setHeaders describes the quota u on the response
+func setHeaders(c *gin.Context, u Usage, now time.Time) {
+	reset := int64(math.Ceil(u.ResetsAt.Sub(now).Seconds()))
+	c.Header("X-Quota-Limit", strconv.FormatInt(u.Limit, 10))
+	c.Header("X-Quota-Remaining", strconv.FormatInt(u.Remaining, 10))
+	c.Header("X-Quota-Reset", strconv.FormatInt(max(reset, 0), 10))
+}
+
+// exempt reports whether path starts with one of the prefixes
+func exempt(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
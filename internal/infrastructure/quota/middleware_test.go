@@ -0,0 +1,151 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+)
+
+var testOptions = Options{
+	APIKeys:     map[string]string{"partner": "secret-key"},
+	ExemptPaths: []string{"/health"},
+}
+
+// setupRouter returns a router counting requests with tracker. The
+// X-Tenant header stands in for the tenancy middleware.
+func setupRouter(tracker *Tracker) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if tenant := c.GetHeader("X-Tenant"); tenant != "" {
+			c.Request = c.Request.WithContext(tenancy.WithTenant(c.Request.Context(), tenant))
+		}
+	})
+	router.Use(Middleware(tracker, testOptions, logger.New("info", io.Discard)))
+	RegisterRoutes(router, tracker)
+	RegisterAdminRoutes(router.Group("/admin"), tracker)
+	router.GET("/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func serve(router *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	tracker := NewTracker(NewMemoryStore(), clock.NewFake(testNow), Limits{Daily: 2, Monthly: 100}, nil)
+	router := setupRouter(tracker)
+	partner := map[string]string{APIKeyHeader: "secret-key"}
+
+	t.Run("counts requests and describes the tightest quota", func(t *testing.T) {
+		w := serve(router, "/users", partner)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-Quota-Limit"))
+		assert.Equal(t, "1", w.Header().Get("X-Quota-Remaining"))
+		assert.Equal(t, "30600", w.Header().Get("X-Quota-Reset"), "seconds until midnight UTC")
+	})
+
+	t.Run("rejects requests once a quota is used up", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, serve(router, "/users", partner).Code)
+
+		w := serve(router, "/users", partner)
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "30600", w.Header().Get("Retry-After"))
+		assert.Equal(t, "0", w.Header().Get("X-Quota-Remaining"))
+
+		var body problem.Details
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, problem.CodeQuotaExceeded, body.Code)
+		assert.Equal(t, "daily quota of 2 requests exceeded; it resets at 2026-10-19T00:00:00Z", body.Detail)
+	})
+
+	t.Run("reports the usage of the caller without counting", func(t *testing.T) {
+		for range 2 {
+			w := serve(router, UsagePath, partner)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var body UsageResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "api_key:partner", body.Subject)
+			require.Len(t, body.Quotas, 2)
+			assert.Equal(t, int64(2), body.Quotas[0].Used)
+			assert.Equal(t, int64(98), body.Quotas[1].Remaining)
+		}
+	})
+
+	t.Run("counts tenants separately", func(t *testing.T) {
+		w := serve(router, "/users", map[string]string{"X-Tenant": "acme"})
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "1", w.Header().Get("X-Quota-Remaining"))
+
+		w = serve(router, "/admin"+UsagePath+"/tenant:acme", nil)
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"used":1`)
+	})
+
+	t.Run("rejects unknown API keys", func(t *testing.T) {
+		w := serve(router, "/users", map[string]string{APIKeyHeader: "wrong"})
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("does not count anonymous or exempt requests", func(t *testing.T) {
+		w := serve(router, "/users", nil)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("X-Quota-Limit"))
+
+		w = serve(router, "/health", partner)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serve(router, UsagePath, nil)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+// failingStore fails every operation
+type failingStore struct{}
+
+func (failingStore) Counts(ctx context.Context, subject string, windows []Window) ([]int64, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (failingStore) Increment(ctx context.Context, subject string, windows []Window) error {
+	return errors.New("unavailable")
+}
+
+func (failingStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	return 0, errors.New("unavailable")
+}
+
+func TestMiddleware_StoreFailure(t *testing.T) {
+	tracker := NewTracker(failingStore{}, clock.NewFake(testNow), Limits{Daily: 1}, nil)
+	router := setupRouter(tracker)
+
+	// Requests go through uncounted
+	w := serve(router, "/users", map[string]string{"X-Tenant": "acme"})
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve(router, UsagePath, map[string]string{"X-Tenant": "acme"})
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
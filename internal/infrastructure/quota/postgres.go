@@ -0,0 +1,87 @@
+package quota
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CountModel represents the database model for request counts
+type CountModel struct {
+	Subject   string    `gorm:"type:varchar(255);primaryKey"`
+	WindowKey string    `gorm:"type:varchar(16);primaryKey"`
+	Count     int64     `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"index;not null"`
+}
+
+// TableName specifies the table name for CountModel
+func (CountModel) TableName() string {
+	return "request_quotas"
+}
+
+// GormStore implements Store using GORM, so every instance counts against
+// the same quotas
+type GormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore creates a new database-backed quota store
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{
+		db: db,
+	}
+}
+
+// Counts returns the requests counted for subject in each window
+func (s *GormStore) Counts(ctx context.Context, subject string, windows []Window) ([]int64, error) {
+	keys := make([]string, len(windows))
+	for i, w := range windows {
+		keys[i] = w.Key
+	}
+
+	var models []CountModel
+	err := s.db.WithContext(ctx).
+		Where("subject = ? AND window_key IN ?", subject, keys).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]int64, len(models))
+	for _, m := range models {
+		byKey[m.WindowKey] = m.Count
+	}
+	counts := make([]int64, len(windows))
+	for i, w := range windows {
+		counts[i] = byKey[w.Key]
+	}
+	return counts, nil
+}
+
+// Increment counts one request of subject in each window with a single
+// upsert, so concurrent requests are all counted
+func (s *GormStore) Increment(ctx context.Context, subject string, windows []Window) error {
+	models := make([]CountModel, len(windows))
+	for i, w := range windows {
+		models[i] = CountModel{Subject: subject, WindowKey: w.Key, Count: 1, ExpiresAt: w.ExpiresAt}
+	}
+
+	return s.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subject"}, {Name: "window_key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"count": gorm.Expr("request_quotas.count + 1")}),
+		}).
+		Create(&models).Error
+}
+
+// DeleteExpired removes the counts of windows that expired before the cutoff
+func (s *GormStore) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	result := s.db.WithContext(ctx).Where("expires_at <= ?", before).Delete(&CountModel{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
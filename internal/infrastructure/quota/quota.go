@@ -0,0 +1,153 @@
+// Package quota counts the requests of each API client or tenant per day
+// and per month, and rejects them once a quota is used up. Unlike load
+// shedding, which bounds the requests handled at once, quotas bound the
+// requests a client makes over a billing period.
+package quota
+
+import (
+	"context"
+	"time"
+)
+
+// Period is the span a quota applies to. Periods follow the UTC calendar.
+type Period string
+
+// Periods quotas are tracked over
+const (
+	Daily   Period = "daily"
+	Monthly Period = "monthly"
+)
+
+// window returns the key of the period's window containing t and the start
+// of the next window
+func (p Period) window(t time.Time) (string, time.Time) {
+	t = t.UTC()
+	if p == Monthly {
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return "m:" + start.Format("2006-01"), start.AddDate(0, 1, 0)
+	}
+
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return "d:" + start.Format("2006-01-02"), start.AddDate(0, 0, 1)
+}
+
+// Limits holds the requests allowed per period. Zero leaves a period
+// unlimited.
+type Limits struct {
+	Daily   int64
+	Monthly int64
+}
+
+// Usage is the use of one quota of a subject
+type Usage struct {
+	Period    Period    `json:"period"`
+	Limit     int64     `json:"limit"`
+	Used      int64     `json:"used"`
+	Remaining int64     `json:"remaining"`
+	ResetsAt  time.Time `json:"resets_at"`
+}
+
+// Exceeded reports whether no requests remain
+func (u Usage) Exceeded() bool {
+	return u.Used >= u.Limit
+}
+
+// Clock tells the time windows are chosen by
+type Clock interface {
+	Now() time.Time
+}
+
+// Tracker counts requests against the quotas of each subject. A subject is
+// an API client, "api_key:<name>", or a tenant, "tenant:<id>".
+type Tracker struct {
+	store     Store
+	clock     Clock
+	limits    Limits
+	overrides map[string]Limits
+}
+
+// NewTracker creates a tracker applying limits to every subject but those
+// in overrides, which replace both limits of their subject
+func NewTracker(store Store, clock Clock, limits Limits, overrides map[string]Limits) *Tracker {
+	return &Tracker{
+		store:     store,
+		clock:     clock,
+		limits:    limits,
+		overrides: overrides,
+	}
+}
+
+// Usage returns the use of each quota of subject. Subjects without limits
+// have none.
+func (t *Tracker) Usage(ctx context.Context, subject string) ([]Usage, error) {
+	usage, _, err := t.usage(ctx, subject)
+	return usage, err
+}
+
+// Take counts a request of subject against its quotas. When one is used
+// up, the request is not counted and that quota is returned as exceeded.
+// Concurrent requests may overshoot a quota by the few taken between the
+// check and the count.
+func (t *Tracker) Take(ctx context.Context, subject string) (usage []Usage, exceeded *Usage, err error) {
+	usage, windows, err := t.usage(ctx, subject)
+	if err != nil || len(usage) == 0 {
+		return usage, nil, err
+	}
+
+	for i := range usage {
+		if usage[i].Exceeded() {
+			return usage, &usage[i], nil
+		}
+	}
+
+	if err := t.store.Increment(ctx, subject, windows); err != nil {
+		return nil, nil, err
+	}
+	for i := range usage {
+		usage[i].Used++
+		usage[i].Remaining--
+	}
+	return usage, nil, nil
+}
+
+// DeleteExpired removes the counts of windows that have ended and returns
+// how many were removed
+func (t *Tracker) DeleteExpired(ctx context.Context) (int, error) {
+	return t.store.DeleteExpired(ctx, t.clock.Now())
+}
+
+// usage reads the counts of the current windows of subject's quotas
+func (t *Tracker) usage(ctx context.Context, subject string) ([]Usage, []Window, error) {
+	limits, ok := t.overrides[subject]
+	if !ok {
+		limits = t.limits
+	}
+
+	now := t.clock.Now()
+	var usage []Usage
+	var windows []Window
+	for _, q := range []struct {
+		period Period
+		limit  int64
+	}{{Daily, limits.Daily}, {Monthly, limits.Monthly}} {
+		if q.limit <= 0 {
+			continue
+		}
+		key, resetsAt := q.period.window(now)
+		usage = append(usage, Usage{Period: q.period, Limit: q.limit, ResetsAt: resetsAt})
+		windows = append(windows, Window{Key: key, ExpiresAt: resetsAt})
+	}
+	if len(windows) == 0 {
+		return nil, nil, nil
+	}
+
+	counts, err := t.store.Counts(ctx, subject, windows)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range usage {
+		usage[i].Used = counts[i]
+		usage[i].Remaining = max(usage[i].Limit-counts[i], 0)
+	}
+	return usage, windows, nil
+}
@@ -0,0 +1,91 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+)
+
+var testNow = time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC)
+
+func TestPeriod_Window(t *testing.T) {
+	key, resetsAt := Daily.window(testNow)
+	assert.Equal(t, "d:2026-10-18", key)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), resetsAt)
+
+	key, resetsAt = Monthly.window(time.Date(2026, 12, 31, 23, 0, 0, 0, time.UTC))
+	assert.Equal(t, "m:2026-12", key)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), resetsAt)
+
+	// Windows follow UTC whatever the zone of the clock
+	key, _ = Daily.window(time.Date(2026, 10, 18, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*3600)))
+	assert.Equal(t, "d:2026-10-19", key)
+}
+
+func TestTracker_Take(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewFake(testNow)
+	tracker := NewTracker(NewMemoryStore(), clk, Limits{Daily: 2, Monthly: 3}, nil)
+
+	usage, exceeded, err := tracker.Take(ctx, "tenant:acme")
+	require.NoError(t, err)
+	assert.Nil(t, exceeded)
+	assert.Equal(t, []Usage{
+		{Period: Daily, Limit: 2, Used: 1, Remaining: 1, ResetsAt: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{Period: Monthly, Limit: 3, Used: 1, Remaining: 2, ResetsAt: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+	}, usage)
+
+	_, exceeded, err = tracker.Take(ctx, "tenant:acme")
+	require.NoError(t, err)
+	assert.Nil(t, exceeded)
+
+	// The daily quota is used up; rejected requests are not counted
+	for range 2 {
+		_, exceeded, err = tracker.Take(ctx, "tenant:acme")
+		require.NoError(t, err)
+		require.NotNil(t, exceeded)
+		assert.Equal(t, Daily, exceeded.Period)
+	}
+
+	// The next day only the monthly quota is left
+	clk.Advance(24 * time.Hour)
+	usage, exceeded, err = tracker.Take(ctx, "tenant:acme")
+	require.NoError(t, err)
+	assert.Nil(t, exceeded)
+	assert.Equal(t, int64(3), usage[1].Used)
+
+	_, exceeded, err = tracker.Take(ctx, "tenant:acme")
+	require.NoError(t, err)
+	require.NotNil(t, exceeded)
+	assert.Equal(t, Monthly, exceeded.Period)
+
+	// Other subjects have their own counts
+	_, exceeded, err = tracker.Take(ctx, "tenant:globex")
+	require.NoError(t, err)
+	assert.Nil(t, exceeded)
+}
+
+func TestTracker_Overrides(t *testing.T) {
+	ctx := context.Background()
+	tracker := NewTracker(NewMemoryStore(), clock.NewFake(testNow), Limits{Daily: 1}, map[string]Limits{
+		"api_key:partner":  {Monthly: 100},
+		"tenant:unlimited": {},
+	})
+
+	usage, err := tracker.Usage(ctx, "api_key:partner")
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	assert.Equal(t, Monthly, usage[0].Period)
+
+	for range 3 {
+		usage, exceeded, err := tracker.Take(ctx, "tenant:unlimited")
+		require.NoError(t, err)
+		assert.Nil(t, exceeded)
+		assert.Empty(t, usage)
+	}
+}
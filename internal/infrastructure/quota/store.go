@@ -0,0 +1,26 @@
+package quota
+
+import (
+	"context"
+	"time"
+)
+
+// Window is the span of one period whose requests are counted together
+type Window struct {
+	Key       string // such as "d:2026-10-18" or "m:2026-10"
+	ExpiresAt time.Time
+}
+
+// Store persists request counts per subject and window
+type Store interface {
+	// Counts returns the requests counted for subject in each window, in
+	// order, zero for windows without any
+	Counts(ctx context.Context, subject string, windows []Window) ([]int64, error)
+
+	// Increment counts one request of subject in each window
+	Increment(ctx context.Context, subject string, windows []Window) error
+
+	// DeleteExpired removes the counts of windows that expired before the
+	// cutoff and returns how many were removed
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
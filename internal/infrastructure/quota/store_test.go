@@ -0,0 +1,78 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Each connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(t, db.AutoMigrate(&CountModel{}))
+	return db
+}
+
+// forEachStore runs test against every Store implementation
+func forEachStore(t *testing.T, test func(t *testing.T, store Store)) {
+	t.Run("gorm", func(t *testing.T) { test(t, NewGormStore(setupTestDB(t))) })
+	t.Run("memory", func(t *testing.T) { test(t, NewMemoryStore()) })
+}
+
+func TestStore_IncrementAndCounts(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ctx := context.Background()
+		expires := time.Now().Add(time.Hour)
+		day := Window{Key: "d:2026-10-18", ExpiresAt: expires}
+		month := Window{Key: "m:2026-10", ExpiresAt: expires}
+
+		counts, err := store.Counts(ctx, "tenant:acme", []Window{day, month})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{0, 0}, counts)
+
+		require.NoError(t, store.Increment(ctx, "tenant:acme", []Window{day, month}))
+		require.NoError(t, store.Increment(ctx, "tenant:acme", []Window{day, month}))
+		require.NoError(t, store.Increment(ctx, "tenant:acme", []Window{month}))
+		require.NoError(t, store.Increment(ctx, "tenant:globex", []Window{day}))
+
+		counts, err = store.Counts(ctx, "tenant:acme", []Window{day, month})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2, 3}, counts)
+
+		counts, err = store.Counts(ctx, "tenant:globex", []Window{month, day})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{0, 1}, counts)
+	})
+}
+
+func TestStore_DeleteExpired(t *testing.T) {
+	forEachStore(t, func(t *testing.T, store Store) {
+		ctx := context.Background()
+		now := time.Now()
+		ended := Window{Key: "d:2026-10-17", ExpiresAt: now.Add(-time.Minute)}
+		current := Window{Key: "d:2026-10-18", ExpiresAt: now.Add(time.Hour)}
+
+		require.NoError(t, store.Increment(ctx, "tenant:acme", []Window{ended, current}))
+
+		deleted, err := store.DeleteExpired(ctx, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, deleted)
+
+		counts, err := store.Counts(ctx, "tenant:acme", []Window{ended, current})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{0, 1}, counts)
+	})
+}
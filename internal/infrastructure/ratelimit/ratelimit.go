@@ -0,0 +1,86 @@
+// Package ratelimit limits how often each client may call an endpoint,
+// with a token bucket per key, such as the client's IP address. Buckets
+// live in process memory, so each replica limits on its own: behind n
+// replicas, a client may make up to n times the limit.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter holds a token bucket per key. Buckets that have been idle long
+// enough to be full again are dropped, so memory is bounded by the keys
+// seen recently.
+type Limiter struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	idle    time.Duration // time for an empty bucket to fill up
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// bucket is the token bucket of a key and when it was last used
+type bucket struct {
+	limiter *rate.Limiter
+	seen    time.Time
+}
+
+// New returns a limiter allowing each key requests per interval, in bursts
+// of up to burst requests. Both counts must be positive, as must interval.
+func New(requests int, interval time.Duration, burst int) *Limiter {
+	perRequest := interval / time.Duration(requests)
+	return &Limiter{
+		limit:   rate.Every(perRequest),
+		burst:   burst,
+		idle:    perRequest * time.Duration(burst),
+		buckets: map[string]*bucket{},
+	}
+}
+
+// Allow takes a token from the bucket of key. When the bucket is empty, it
+// returns false and how long until a token is back.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	return l.allow(key, time.Now())
+}
+
+// allow is Allow at the time now
+func (l *Limiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = b
+	}
+	b.seen = now
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		// Refused requests do not use up tokens
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// sweep drops the buckets idle for long enough to be full, at most once
+// per that time. Callers must hold the lock.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < l.idle {
+		return
+	}
+	l.swept = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.seen) >= l.idle {
+			delete(l.buckets, key)
+		}
+	}
+}
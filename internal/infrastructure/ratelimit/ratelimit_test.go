@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	l := New(60, time.Minute, 2)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	ok, _ := l.allow("a", now)
+	assert.True(t, ok)
+	ok, _ = l.allow("a", now)
+	assert.True(t, ok, "the burst is allowed at once")
+
+	ok, retryAfter := l.allow("a", now)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, retryAfter)
+
+	ok, _ = l.allow("b", now)
+	assert.True(t, ok, "each key has its own bucket")
+
+	ok, _ = l.allow("a", now.Add(500*time.Millisecond))
+	assert.False(t, ok, "refused requests use no tokens, so one is back after a second")
+	ok, _ = l.allow("a", now.Add(time.Second))
+	assert.True(t, ok)
+}
+
+func TestLimiter_Sweep(t *testing.T) {
+	l := New(60, time.Minute, 2)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	l.allow("a", now)
+	l.allow("b", now.Add(time.Second))
+	assert.Len(t, l.buckets, 2)
+
+	// "a" is full again after two seconds, "b" is not yet
+	l.allow("c", now.Add(2*time.Second))
+	assert.Len(t, l.buckets, 2)
+	assert.NotContains(t, l.buckets, "a")
+	assert.Contains(t, l.buckets, "b")
+}
@@ -0,0 +1,136 @@
+// Package recovery turns panics in HTTP handlers into problem+json 500
+// responses. Each panic is logged through the application logger with its
+// stack and request, counted under /debug/vars and handed to an optional
+// error reporter, instead of going to stderr as with gin.Recovery.
+package recovery
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/httpclient"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// metrics publishes panic counters under /debug/vars
+var metrics = expvar.NewMap("http_recovery")
+
+// Report describes a recovered panic
+type Report struct {
+	// Value is what the handler panicked with
+	Value any
+
+	// Stack is the stack of the panicking goroutine
+	Stack []byte
+
+	Method    string
+	Path      string
+	Route     string
+	RequestID string
+	ClientIP  string
+}
+
+// Reporter forwards recovered panics to an error tracker, such as Sentry
+type Reporter interface {
+	ReportPanic(ctx context.Context, report Report)
+}
+
+// Options configures the recovery middleware
+type Options struct {
+	// Reporter is notified of each panic; nil only logs them
+	Reporter Reporter
+}
+
+// Middleware recovers from panics in the handlers after it. The client
+// gets a problem+json 500 that does not reveal the panic, unless the
+// response was already started or the connection is gone. The panics
+// net/http uses to abort handlers, http.ErrAbortHandler, are passed on.
+func Middleware(log *logger.Logger, opts Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if r == http.ErrAbortHandler {
+				panic(r)
+			}
+
+			report := Report{
+				Value:     r,
+				Stack:     debug.Stack(),
+				Method:    c.Request.Method,
+				Path:      c.Request.URL.Path,
+				Route:     request.RoutePath(c),
+				RequestID: requestID(c),
+				ClientIP:  clientip.FromRequest(c.Request).String(),
+			}
+
+			if brokenConnection(r) {
+				metrics.Add("broken_connections", 1)
+				log.Warn().
+					Ctx(c.Request.Context()).
+					Interface("error", r).
+					Str("method", report.Method).
+					Str("path", report.Path).
+					Str("request_id", report.RequestID).
+					Msg("Client connection broken while writing the response")
+				c.Abort()
+				return
+			}
+
+			metrics.Add("panics", 1)
+			log.Error().
+				Ctx(c.Request.Context()).
+				Interface("panic", r).
+				Bytes("stack", report.Stack).
+				Str("method", report.Method).
+				Str("path", report.Path).
+				Str("route", report.Route).
+				Str("request_id", report.RequestID).
+				Str("client_ip", report.ClientIP).
+				Msg("Recovered from panic in HTTP handler")
+
+			if opts.Reporter != nil {
+				opts.Reporter.ReportPanic(c.Request.Context(), report)
+			}
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			problem.Abort(c, problem.New(http.StatusInternalServerError, "internal server error"))
+		}()
+
+		c.Next()
+	}
+}
+
+// requestID returns the request ID sent by the client or stored in the
+// request context
+func requestID(c *gin.Context) string {
+	if id := c.GetHeader(httpclient.RequestIDHeader); id != "" {
+		return id
+	}
+	id, _ := httpclient.RequestIDFromContext(c.Request.Context())
+	return id
+}
+
+// brokenConnection reports whether the panic is a failed write to a client
+// that went away, which is no bug of the handler
+func brokenConnection(r any) bool {
+	err, ok := r.(error)
+	if !ok {
+		return false
+	}
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
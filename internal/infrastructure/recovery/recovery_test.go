@@ -0,0 +1,124 @@
+package recovery
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+)
+
+type fakeReporter struct {
+	reports []Report
+}
+
+func (r *fakeReporter) ReportPanic(_ context.Context, report Report) {
+	r.reports = append(r.reports, report)
+}
+
+func setupRouter(log *logger.Logger, reporter Reporter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Middleware(log, Options{Reporter: reporter}))
+	router.GET("/users/:id", func(c *gin.Context) {
+		panic("nil map")
+	})
+	router.GET("/started", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic("after writing")
+	})
+	router.GET("/broken", func(c *gin.Context) {
+		panic(fmt.Errorf("write tcp: %w", syscall.EPIPE))
+	})
+	return router
+}
+
+func panics() int64 {
+	v, ok := metrics.Get("panics").(*expvar.Int)
+	if !ok {
+		return 0
+	}
+	return v.Value()
+}
+
+func TestMiddleware(t *testing.T) {
+	var logs bytes.Buffer
+	reporter := &fakeReporter{}
+	router := setupRouter(logger.New("info", &logs), reporter)
+	before := panics()
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	assert.NotContains(t, w.Body.String(), "nil map", "the panic is not revealed")
+	assert.Equal(t, before+1, panics())
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "error", entry["level"])
+	assert.Equal(t, "nil map", entry["panic"])
+	assert.Equal(t, "/users/:id", entry["route"])
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.Contains(t, entry["stack"], "recovery_test.go")
+
+	require.Len(t, reporter.reports, 1)
+	report := reporter.reports[0]
+	assert.Equal(t, "nil map", report.Value)
+	assert.Equal(t, "/users/42", report.Path)
+	assert.Equal(t, "req-1", report.RequestID)
+	assert.Equal(t, "192.0.2.1", report.ClientIP)
+}
+
+func TestMiddleware_ResponseStarted(t *testing.T) {
+	router := setupRouter(logger.New("info", io.Discard), nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/started", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial", w.Body.String(), "nothing is appended to a started response")
+}
+
+func TestMiddleware_BrokenConnection(t *testing.T) {
+	var logs bytes.Buffer
+	reporter := &fakeReporter{}
+	router := setupRouter(logger.New("info", &logs), reporter)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/broken", nil))
+
+	assert.Contains(t, logs.String(), `"level":"warn"`)
+	assert.Empty(t, reporter.reports, "a client going away is not reported")
+}
+
+func TestMiddleware_AbortHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware(logger.New("info", io.Discard), Options{}))
+	router.GET("/abort", func(c *gin.Context) {
+		panic(http.ErrAbortHandler)
+	})
+
+	defer func() {
+		r := recover()
+		assert.True(t, errors.Is(r.(error), http.ErrAbortHandler), "net/http aborts are passed on")
+	}()
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+}
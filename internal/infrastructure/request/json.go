@@ -0,0 +1,265 @@
+package request
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// errInvalidBody is the message of errors listing offending fields
+const errInvalidBody = "invalid request body"
+
+var registerTagNameOnce sync.Once
+
+// BindJSON decodes the JSON request body into obj and validates it with its
+// `binding` tags. The body size limit and strict mode come from Middleware.
+// Errors are *Error values describing the offending fields.
+func BindJSON(c *gin.Context, obj any) error {
+	return DecodeJSON(c.Writer, c.Request, obj)
+}
+
+// DecodeJSON is BindJSON for net/http handlers
+func DecodeJSON(w http.ResponseWriter, r *http.Request, obj any) error {
+	opts := optionsFrom(r.Context())
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, opts.MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return &Error{
+				Status:  http.StatusRequestEntityTooLarge,
+				Message: fmt.Sprintf("request body cannot exceed %d bytes", opts.MaxBodyBytes),
+			}
+		}
+		return badRequest("failed to read request body")
+	}
+
+	if len(body) == 0 {
+		return badRequest("request body must not be empty")
+	}
+
+	if opts.Strict {
+		var raw any
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return decodeError(err)
+		}
+		if unknown := unknownFields(raw, reflect.TypeOf(obj), ""); len(unknown) > 0 {
+			return badRequest(errInvalidBody, unknown...)
+		}
+	}
+
+	if err := json.Unmarshal(body, obj); err != nil {
+		return decodeError(err)
+	}
+
+	return validate(obj)
+}
+
+// decodeError converts a JSON decoding error to an Error
+func decodeError(err error) *Error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return badRequest(errInvalidBody, FieldError{
+			Field:   typeErr.Field,
+			Message: "must be " + describeType(typeErr.Type),
+		})
+	}
+
+	return badRequest("request body must be valid JSON")
+}
+
+// describeType names a Go type the way a JSON client would think of it
+func describeType(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// unknownFields lists the keys of value, a generically decoded JSON
+// document, that the Go type t does not declare. Keys are matched
+// case-insensitively, as encoding/json does.
+func unknownFields(value any, t reflect.Type, path string) []FieldError {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var fields []FieldError
+	switch v := value.(type) {
+	case map[string]any:
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+
+		known := jsonFields(t)
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fieldPath := joinPath(path, key)
+			fieldType, ok := known[strings.ToLower(key)]
+			if !ok {
+				fields = append(fields, FieldError{Field: fieldPath, Message: "is not a known field"})
+				continue
+			}
+			fields = append(fields, unknownFields(v[key], fieldType, fieldPath)...)
+		}
+	case []any:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return nil
+		}
+		for i, item := range v {
+			fields = append(fields, unknownFields(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+
+	return fields
+}
+
+// jsonFields maps the lowercased JSON names of a struct's fields to their types
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		// Untagged embedded structs contribute their fields directly
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for embedded, ft := range jsonFields(f.Type) {
+				fields[embedded] = ft
+			}
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = f.Type
+	}
+	return fields
+}
+
+// joinPath appends a key to a dotted field path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// validate runs the `binding` tag validation of obj and reports failures
+// by JSON field path
+func validate(obj any) error {
+	registerTagNameOnce.Do(registerJSONTagNames)
+
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return badRequest(err.Error())
+	}
+
+	fields := make([]FieldError, len(validationErrs))
+	for i, fe := range validationErrs {
+		// Drop the struct name that starts the namespace
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		fields[i] = FieldError{Field: field, Message: validationMessage(fe)}
+	}
+	return badRequest(errInvalidBody, fields...)
+}
+
+// registerJSONTagNames makes validation errors name fields by their JSON
+// names, or their query or path parameter names for parameter structs
+func registerJSONTagNames() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	engine.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			name = f.Tag.Get("form")
+		}
+		if name == "" || name == "-" {
+			name = f.Tag.Get("path")
+		}
+		if name == "" || name == "-" {
+			return f.Name
+		}
+		return name
+	})
+}
+
+// validationMessage describes a failed validation rule, with the message
+// of its Validation for custom tags
+func validationMessage(fe validator.FieldError) string {
+	if v, ok := lookupValidation(fe.Tag()); ok {
+		return v.Message(fe)
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "uuid":
+		return "must be a UUID"
+	case "uuid4":
+		return "must be a version 4 UUID"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min", "max":
+		bound := "at least"
+		if fe.Tag() == "max" {
+			bound = "at most"
+		}
+		switch fe.Kind() {
+		case reflect.String:
+			return fmt.Sprintf("must be %s %s characters long", bound, fe.Param())
+		case reflect.Slice, reflect.Array, reflect.Map:
+			return fmt.Sprintf("must contain %s %s items", bound, fe.Param())
+		default:
+			return fmt.Sprintf("must be %s %s", bound, fe.Param())
+		}
+	default:
+		return fmt.Sprintf("failed the %s rule", fe.Tag())
+	}
+}
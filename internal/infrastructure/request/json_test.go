@@ -0,0 +1,166 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+type testAddress struct {
+	City string `json:"city" binding:"required"`
+}
+
+type testRequest struct {
+	Email   string        `json:"email" binding:"required,email"`
+	Name    string        `json:"name" binding:"required,max=5"`
+	Tags    []string      `json:"tags" binding:"omitempty,min=2"`
+	Mode    string        `json:"mode" binding:"omitempty,oneof=fast slow"`
+	Active  *bool         `json:"active"`
+	Address *testAddress  `json:"address"`
+	Items   []testAddress `json:"items"`
+}
+
+// bind runs BindJSON for body on a router using opts, or no middleware when opts is nil
+func bind(t *testing.T, opts *Options, body string) (*testRequest, error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var req testRequest
+	var bindErr error
+
+	router := gin.New()
+	if opts != nil {
+		router.Use(Middleware(*opts))
+	}
+	router.POST("/", func(c *gin.Context) {
+		bindErr = BindJSON(c, &req)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	return &req, bindErr
+}
+
+func requireBindError(t *testing.T, err error) *Error {
+	t.Helper()
+
+	var bindErr *Error
+	require.True(t, errors.As(err, &bindErr), "expected *Error, got %v", err)
+	return bindErr
+}
+
+func TestBindJSON_Valid(t *testing.T) {
+	req, err := bind(t, &Options{Strict: true}, `{"email":"a@example.com","name":"Ann","address":{"city":"Oslo"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "a@example.com", req.Email)
+	assert.Equal(t, "Oslo", req.Address.City)
+}
+
+func TestBindJSON_UnknownFields(t *testing.T) {
+	body := `{"emial":"a@example.com","email":"a@example.com","name":"Ann","address":{"city":"Oslo","zip":"1"},"items":[{"city":"x","extra":1}]}`
+
+	t.Run("lenient mode ignores them", func(t *testing.T) {
+		_, err := bind(t, nil, body)
+		assert.NoError(t, err)
+	})
+
+	t.Run("strict mode lists every one", func(t *testing.T) {
+		_, err := bind(t, &Options{Strict: true}, body)
+		bindErr := requireBindError(t, err)
+
+		assert.Equal(t, http.StatusBadRequest, bindErr.Status)
+		assert.Equal(t, []FieldError{
+			{Field: "address.zip", Message: "is not a known field"},
+			{Field: "emial", Message: "is not a known field"},
+			{Field: "items[0].extra", Message: "is not a known field"},
+		}, bindErr.Fields)
+	})
+
+	t.Run("keys match case-insensitively", func(t *testing.T) {
+		_, err := bind(t, &Options{Strict: true}, `{"Email":"a@example.com","NAME":"Ann"}`)
+		assert.NoError(t, err)
+	})
+}
+
+func TestBindJSON_ValidationErrors(t *testing.T) {
+	_, err := bind(t, nil, `{"email":"nope","name":"Too long","tags":["a"],"mode":"other","address":{}}`)
+	bindErr := requireBindError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, bindErr.Status)
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "email", Message: "must be a valid email address"},
+		{Field: "name", Message: "must be at most 5 characters long"},
+		{Field: "tags", Message: "must contain at least 2 items"},
+		{Field: "mode", Message: "must be one of: fast, slow"},
+		{Field: "address.city", Message: "is required"},
+	}, bindErr.Fields)
+}
+
+func TestBindJSON_TypeError(t *testing.T) {
+	_, err := bind(t, nil, `{"email":"a@example.com","name":"Ann","active":"yes"}`)
+	bindErr := requireBindError(t, err)
+
+	assert.Equal(t, []FieldError{{Field: "active", Message: "must be a boolean"}}, bindErr.Fields)
+}
+
+func TestBindJSON_MalformedBody(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *Options
+		body    string
+		message string
+	}{
+		{name: "empty", body: "", message: "request body must not be empty"},
+		{name: "syntax error", body: `{"email":`, message: "request body must be valid JSON"},
+		{name: "trailing data", body: `{"email":"a@example.com","name":"Ann"} {}`, message: "request body must be valid JSON"},
+		{name: "syntax error in strict mode", opts: &Options{Strict: true}, body: `{"email"}`, message: "request body must be valid JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bind(t, tt.opts, tt.body)
+			bindErr := requireBindError(t, err)
+			assert.Equal(t, http.StatusBadRequest, bindErr.Status)
+			assert.Equal(t, tt.message, bindErr.Message)
+		})
+	}
+}
+
+func TestBindJSON_BodyTooLarge(t *testing.T) {
+	body := `{"email":"a@example.com","name":"` + strings.Repeat("a", 100) + `"}`
+
+	_, err := bind(t, &Options{MaxBodyBytes: 64}, body)
+	bindErr := requireBindError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, bindErr.Status)
+
+	// The default limit applies without the middleware
+	_, err = bind(t, nil, `{"email":"a@example.com","name":"`+strings.Repeat("a", DefaultMaxBodyBytes)+`"}`)
+	bindErr = requireBindError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, bindErr.Status)
+}
+
+func TestError_Error(t *testing.T) {
+	err := badRequest(errInvalidBody, FieldError{Field: "email", Message: "is required"}, FieldError{Field: "name", Message: "is required"})
+	assert.Equal(t, "invalid request body: email is required; name is required", err.Error())
+	assert.Equal(t, "request body must not be empty", badRequest("request body must not be empty").Error())
+}
+
+func TestFieldErrors(t *testing.T) {
+	assert.Nil(t, FieldErrors(errors.New("boom")))
+
+	var v validation.Validator
+	v.Check("email", errors.New("invalid email format"))
+	v.Check("name", errors.New("name must not be empty"))
+
+	assert.Equal(t, []FieldError{
+		{Field: "email", Message: "invalid email format"},
+		{Field: "name", Message: "name must not be empty"},
+	}, FieldErrors(v.Err()))
+}
@@ -0,0 +1,116 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// CodeInvalidPathParameter is the code of errors about malformed path
+// parameters, such as an ID that is not a UUID
+const CodeInvalidPathParameter = "INVALID_PATH_PARAMETER"
+
+// errInvalidPath is the message of errors listing offending path parameters
+const errInvalidPath = "invalid path parameters"
+
+// IDPath holds the id path parameter of routes addressing a resource by
+// its UUID
+type IDPath struct {
+	ID string `path:"id" binding:"uuid"`
+}
+
+// BindPath decodes the path parameters into the `path`-tagged fields of
+// obj and validates them with their `binding` tags, so malformed IDs are
+// rejected before they reach a service. It supports the field types of
+// BindQuery. Errors are *Error values with the code
+// CodeInvalidPathParameter, listing every offending parameter.
+func BindPath(c *gin.Context, obj any) error {
+	return decodePath(c.Param, obj)
+}
+
+// DecodePath is BindPath for net/http handlers, reading the wildcards of
+// the route pattern
+func DecodePath(r *http.Request, obj any) error {
+	return decodePath(r.PathValue, obj)
+}
+
+// PathID returns the id path parameter of r, checked to be a UUID
+func PathID(r *http.Request) (string, error) {
+	var path IDPath
+	if err := DecodePath(r, &path); err != nil {
+		return "", err
+	}
+	return path.ID, nil
+}
+
+// decodePath binds the path parameters get returns into obj
+func decodePath(get func(name string) string, obj any) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		panic("request: BindPath requires a pointer to a struct")
+	}
+
+	if fields := decodePathValues(get, v.Elem()); len(fields) > 0 {
+		return invalidPath(errInvalidPath, fields...)
+	}
+
+	registerTagNameOnce.Do(registerJSONTagNames)
+
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return invalidPath(err.Error())
+	}
+
+	fields := make([]FieldError, len(validationErrs))
+	for i, fe := range validationErrs {
+		fields[i] = FieldError{Field: fe.Field(), Message: validationMessage(fe)}
+	}
+	return invalidPath(errInvalidPath, fields...)
+}
+
+// decodePathValues sets the fields of v from the path parameters and
+// reports the parameters that could not be parsed
+func decodePathValues(get func(name string) string, v reflect.Value) []FieldError {
+	var fields []FieldError
+
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, decodePathValues(get, v.Field(i))...)
+			continue
+		}
+
+		name := f.Tag.Get("path")
+		if name == "" || name == "-" || !f.IsExported() {
+			continue
+		}
+
+		raw := get(name)
+		if raw == "" {
+			continue
+		}
+
+		if message := setQueryValue(v.Field(i), raw); message != "" {
+			fields = append(fields, FieldError{Field: name, Message: message})
+		}
+	}
+
+	return fields
+}
+
+// invalidPath creates the 400 Error of malformed path parameters
+func invalidPath(message string, fields ...FieldError) *Error {
+	err := badRequest(message, fields...)
+	err.Code = CodeInvalidPathParameter
+	return err
+}
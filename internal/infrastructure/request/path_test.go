@@ -0,0 +1,76 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPath struct {
+	IDPath
+	Version int `path:"version" binding:"min=1"`
+}
+
+// bindPath runs BindPath for a request to path on a route with id and
+// version parameters
+func bindPath(t *testing.T, path string) (testPath, error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var params testPath
+	var bindErr error
+
+	router := gin.New()
+	router.GET("/items/:id/versions/:version", func(c *gin.Context) {
+		bindErr = BindPath(c, &params)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	return params, bindErr
+}
+
+func TestBindPath(t *testing.T) {
+	params, err := bindPath(t, "/items/01a14deb-f148-777e-9409-200113bcb19c/versions/3")
+	require.NoError(t, err)
+	assert.Equal(t, "01a14deb-f148-777e-9409-200113bcb19c", params.ID)
+	assert.Equal(t, 3, params.Version)
+}
+
+func TestBindPath_Invalid(t *testing.T) {
+	_, err := bindPath(t, "/items/42/versions/0")
+	bindErr := requireBindError(t, err)
+	assert.Equal(t, http.StatusBadRequest, bindErr.Status)
+	assert.Equal(t, CodeInvalidPathParameter, bindErr.Code)
+	assert.Equal(t, "invalid path parameters", bindErr.Message)
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "id", Message: "must be a UUID"},
+		{Field: "version", Message: "must be at least 1"},
+	}, bindErr.Fields)
+
+	_, err = bindPath(t, "/items/01a14deb-f148-777e-9409-200113bcb19c/versions/latest")
+	bindErr = requireBindError(t, err)
+	assert.Equal(t, []FieldError{{Field: "version", Message: "must be an integer"}}, bindErr.Fields)
+}
+
+func TestPathID(t *testing.T) {
+	mux := http.NewServeMux()
+	var id string
+	var err error
+	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err = PathID(r)
+	})
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/00000000-0000-0000-0000-000000000001", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", id, "UUIDs of any version are accepted")
+
+	for _, raw := range []string{"abc", "01A14DEB-F148-777E-9409-200113BCB19C", "01a14deb-f148-777e-9409-200113bcb19c-1"} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/"+raw, nil))
+		bindErr := requireBindError(t, err)
+		assert.Equal(t, []FieldError{{Field: "id", Message: "must be a UUID"}}, bindErr.Fields, raw)
+	}
+}
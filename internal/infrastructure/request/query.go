@@ -0,0 +1,160 @@
+package request
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+const (
+	// DefaultLimit is the page size used when no limit is given
+	DefaultLimit = 10
+
+	// MaxLimit is the largest page size a client may request
+	MaxLimit = 100
+)
+
+// errInvalidQuery is the message of errors listing offending query parameters
+const errInvalidQuery = "invalid query parameters"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Pagination holds the limit and offset query parameters of list endpoints.
+// Embed it in a query struct to bind it along with other parameters.
+type Pagination struct {
+	Limit  int `form:"limit" binding:"min=1,max=100"`
+	Offset int `form:"offset" binding:"min=0"`
+}
+
+// NewPagination returns the pagination used when the client sets none
+func NewPagination() Pagination {
+	return Pagination{Limit: DefaultLimit}
+}
+
+// BindQuery decodes the query string into the `form`-tagged fields of obj
+// and validates them with their `binding` tags. Parameters that are absent
+// or empty keep the value already in obj, so callers set defaults first.
+// Supported field types are strings, booleans, integers, time.Time in
+// RFC 3339 format and pointers to those. Errors are *Error values listing
+// every offending parameter.
+func BindQuery(c *gin.Context, obj any) error {
+	return DecodeQuery(c.Request, obj)
+}
+
+// DecodeQuery is BindQuery for net/http handlers
+func DecodeQuery(r *http.Request, obj any) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		panic("request: BindQuery requires a pointer to a struct")
+	}
+
+	if fields := decodeQuery(r.URL.Query(), v.Elem()); len(fields) > 0 {
+		return badRequest(errInvalidQuery, fields...)
+	}
+
+	registerTagNameOnce.Do(registerJSONTagNames)
+
+	err := binding.Validator.ValidateStruct(obj)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return badRequest(err.Error())
+	}
+
+	// Query structs are flat, so the parameter name is the field name
+	fields := make([]FieldError, len(validationErrs))
+	for i, fe := range validationErrs {
+		fields[i] = FieldError{Field: fe.Field(), Message: validationMessage(fe)}
+	}
+	return badRequest(errInvalidQuery, fields...)
+}
+
+// decodeQuery sets the fields of v from the query values and reports the
+// parameters that could not be parsed
+func decodeQuery(values url.Values, v reflect.Value) []FieldError {
+	var fields []FieldError
+
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fields = append(fields, decodeQuery(values, v.Field(i))...)
+			continue
+		}
+
+		name := f.Tag.Get("form")
+		if name == "" || name == "-" || !f.IsExported() {
+			continue
+		}
+
+		raw := values.Get(name)
+		if raw == "" {
+			continue
+		}
+
+		if message := setQueryValue(v.Field(i), raw); message != "" {
+			fields = append(fields, FieldError{Field: name, Message: message})
+		}
+	}
+
+	return fields
+}
+
+// setQueryValue parses raw into field and returns a message describing the
+// expected format when it cannot
+func setQueryValue(field reflect.Value, raw string) string {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if message := setQueryValue(value.Elem(), raw); message != "" {
+			return message
+		}
+		field.Set(value)
+		return ""
+	}
+
+	if field.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return "must be an RFC 3339 timestamp"
+		}
+		field.Set(reflect.ValueOf(t))
+		return ""
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "must be a boolean"
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return "must be an integer"
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return "must be a non-negative integer"
+		}
+		field.SetUint(n)
+	default:
+		panic("request: unsupported query field type " + field.Type().String())
+	}
+
+	return ""
+}
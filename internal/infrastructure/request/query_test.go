@@ -0,0 +1,93 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testQuery struct {
+	Pagination
+	Status  string     `form:"status" binding:"omitempty,oneof=active suspended"`
+	Since   *time.Time `form:"since"`
+	Deleted bool       `form:"deleted"`
+	Count   *uint      `form:"count"`
+}
+
+// bindQuery runs BindQuery for the query string, starting from defaults
+func bindQuery(t *testing.T, rawQuery string) (testQuery, error) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	query := testQuery{Pagination: NewPagination()}
+	var bindErr error
+
+	router := gin.New()
+	router.GET("/", func(c *gin.Context) {
+		bindErr = BindQuery(c, &query)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?"+rawQuery, nil))
+	return query, bindErr
+}
+
+func TestBindQuery_Defaults(t *testing.T) {
+	query, err := bindQuery(t, "limit=&unknown=1")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLimit, query.Limit)
+	assert.Equal(t, 0, query.Offset)
+	assert.Nil(t, query.Since)
+	assert.Nil(t, query.Count)
+}
+
+func TestBindQuery_Values(t *testing.T) {
+	query, err := bindQuery(t, "limit=25&offset=50&status=active&since=2024-01-02T03:04:05Z&deleted=true&count=3")
+	require.NoError(t, err)
+	assert.Equal(t, 25, query.Limit)
+	assert.Equal(t, 50, query.Offset)
+	assert.Equal(t, "active", query.Status)
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), *query.Since)
+	assert.True(t, query.Deleted)
+	assert.Equal(t, uint(3), *query.Count)
+}
+
+func TestBindQuery_ParseErrors(t *testing.T) {
+	_, err := bindQuery(t, "limit=abc&offset=1.5&since=yesterday&deleted=maybe&count=-1")
+	bindErr := requireBindError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, bindErr.Status)
+	assert.Equal(t, "invalid query parameters", bindErr.Message)
+	assert.Equal(t, []FieldError{
+		{Field: "limit", Message: "must be an integer"},
+		{Field: "offset", Message: "must be an integer"},
+		{Field: "since", Message: "must be an RFC 3339 timestamp"},
+		{Field: "deleted", Message: "must be a boolean"},
+		{Field: "count", Message: "must be a non-negative integer"},
+	}, bindErr.Fields)
+}
+
+func TestBindQuery_RangeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawQuery string
+		want     []FieldError
+	}{
+		{name: "limit too large", rawQuery: "limit=101", want: []FieldError{{Field: "limit", Message: "must be at most 100"}}},
+		{name: "zero limit", rawQuery: "limit=0", want: []FieldError{{Field: "limit", Message: "must be at least 1"}}},
+		{name: "negative offset", rawQuery: "offset=-1", want: []FieldError{{Field: "offset", Message: "must be at least 0"}}},
+		{name: "unknown status", rawQuery: "status=gone", want: []FieldError{{Field: "status", Message: "must be one of: active, suspended"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bindQuery(t, tt.rawQuery)
+			bindErr := requireBindError(t, err)
+			assert.Equal(t, tt.want, bindErr.Fields)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// DefaultMaxBodyBytes is the body size limit used when none is configured
+const DefaultMaxBodyBytes = 1 << 20
+
+// optionsKey is the request context key holding the Options set by
+// Middleware
+type optionsKey struct{}
+
+// Options configures how request bodies are decoded
+type Options struct {
+	// Strict rejects JSON bodies containing fields the target does not declare
+	Strict bool
+
+	// MaxBodyBytes limits the size of JSON bodies; zero uses DefaultMaxBodyBytes
+	MaxBodyBytes int64
+}
+
+// Middleware makes opts apply to every BindJSON and DecodeJSON call of the
+// request. Handlers on routers without it get lenient decoding and the
+// default limit.
+func Middleware(opts Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), optionsKey{}, opts))
+		c.Next()
+	}
+}
+
+// optionsFrom returns the options set by Middleware, or the defaults
+func optionsFrom(ctx context.Context) Options {
+	opts, _ := ctx.Value(optionsKey{}).(Options)
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	return opts
+}
+
+// FieldError describes a problem with a single request field
+type FieldError struct {
+	Field   string `json:"field" xml:"field,attr"`
+	Message string `json:"message" xml:",chardata"`
+}
+
+// FieldErrors lists the fields of the *validation.Error in err's tree, so
+// domain validation failures are reported like binding failures. It
+// returns nil when err holds none.
+func FieldErrors(err error) []FieldError {
+	violations := validation.Violations(err)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	fields := make([]FieldError, len(violations))
+	for i, v := range violations {
+		fields[i] = FieldError{Field: v.Field, Message: v.Err.Error()}
+	}
+	return fields
+}
+
+// Error is returned when a request cannot be bound. Status is the HTTP
+// status to answer with and Fields lists the offending fields, if known.
+// Code is the error code to answer with when it is more specific than the
+// code of Status.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+	Fields  []FieldError
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = fmt.Sprintf("%s %s", f.Field, f.Message)
+	}
+	return e.Message + ": " + strings.Join(parts, "; ")
+}
+
+// badRequest creates a 400 Error
+func badRequest(message string, fields ...FieldError) *Error {
+	return &Error{
+		Status:  http.StatusBadRequest,
+		Message: message,
+		Fields:  fields,
+	}
+}
@@ -0,0 +1,77 @@
+package request
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoutePath returns the route pattern that matched the request, such as
+// "/users/:id", or the raw path when no route matched
+func RoutePath(c *gin.Context) string {
+	if path := c.FullPath(); path != "" {
+		return path
+	}
+	return c.Request.URL.Path
+}
+
+// IsStreaming reports whether the request opens a WebSocket connection or
+// an event stream. Such connections outlive any request, so middleware
+// bounding the work of a request, such as timeouts and load shedding,
+// leave them alone.
+func IsStreaming(r *http.Request) bool {
+	return IsWebSocket(r) || IsEventStream(r)
+}
+
+// IsWebSocket reports whether the request opens a WebSocket connection
+func IsWebSocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		headerContainsToken(r.Header, "Connection", "upgrade")
+}
+
+// IsEventStream reports whether the request asks for server-sent events,
+// as browsers' EventSource does
+func IsEventStream(r *http.Request) bool {
+	for _, value := range r.Header.Values("Accept") {
+		for part := range strings.SplitSeq(value, ",") {
+			mediaType, _, _ := strings.Cut(part, ";")
+			if strings.EqualFold(strings.TrimSpace(mediaType), "text/event-stream") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headerContainsToken reports whether the comma-separated values of the
+// header include token, ignoring case
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for part := range strings.SplitSeq(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchPrefix returns the longest key of routes that is path or a parent of
+// it, so "/users" matches "/users/:id" but not "/usersx". Middleware use it
+// to look up per route group settings.
+func MatchPrefix[V any](routes map[string]V, path string) (string, bool) {
+	matched, found := "", false
+	for prefix := range routes {
+		if (!found || len(prefix) > len(matched)) && hasPathPrefix(path, prefix) {
+			matched, found = prefix, true
+		}
+	}
+	return matched, found
+}
+
+// hasPathPrefix reports whether path is prefix or lies below it
+func hasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
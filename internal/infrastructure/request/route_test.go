@@ -0,0 +1,92 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPrefix(t *testing.T) {
+	routes := map[string]int{
+		"/users":        1,
+		"/users/export": 2,
+		"/health/":      3,
+	}
+
+	tests := []struct {
+		path  string
+		want  string
+		found bool
+	}{
+		{"/users", "/users", true},
+		{"/users/:id", "/users", true},
+		{"/users/export", "/users/export", true},
+		{"/usersx", "", false},
+		{"/health/live", "/health/", true},
+		{"/organizations", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			prefix, found := MatchPrefix(routes, tt.path)
+			assert.Equal(t, tt.want, prefix)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+func TestIsWebSocket(t *testing.T) {
+	tests := []struct {
+		name       string
+		upgrade    string
+		connection string
+		want       bool
+	}{
+		{name: "websocket upgrade", upgrade: "websocket", connection: "Upgrade", want: true},
+		{name: "token list and case", upgrade: "WebSocket", connection: "keep-alive, upgrade", want: true},
+		{name: "plain request", want: false},
+		{name: "other protocol", upgrade: "h2c", connection: "Upgrade", want: false},
+		{name: "upgrade not requested", upgrade: "websocket", connection: "keep-alive", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+			if tt.upgrade != "" {
+				r.Header.Set("Upgrade", tt.upgrade)
+			}
+			if tt.connection != "" {
+				r.Header.Set("Connection", tt.connection)
+			}
+
+			assert.Equal(t, tt.want, IsWebSocket(r))
+		})
+	}
+}
+
+func TestIsEventStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   bool
+	}{
+		{name: "event source", accept: "text/event-stream", want: true},
+		{name: "media type list and parameters", accept: "application/json, Text/Event-Stream;q=0.9", want: true},
+		{name: "plain request", want: false},
+		{name: "other media type", accept: "application/json", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/users/events", nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			assert.Equal(t, tt.want, IsEventStream(r))
+			assert.Equal(t, tt.want, IsStreaming(r))
+		})
+	}
+}
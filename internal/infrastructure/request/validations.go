@@ -0,0 +1,76 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Validation is a custom binding tag. Func checks a field tagged with
+// Tag, and Message describes a field it rejected in the field errors of
+// BindJSON and BindQuery.
+type Validation struct {
+	Tag     string
+	Func    validator.Func
+	Message func(fe validator.FieldError) string
+}
+
+// tagPattern matches the tags RegisterValidations accepts. The validator
+// panics on the separators of its tag syntax.
+var tagPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var (
+	validationsMu sync.RWMutex
+	validations   = map[string]Validation{}
+)
+
+// RegisterValidations makes the tags of vs usable in the binding tags of
+// every request struct, whichever adapter binds it. Registering a tag
+// again replaces its check and message, so the configuration of the last
+// application built applies.
+func RegisterValidations(vs ...Validation) error {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return errors.New("binding validator is not go-playground/validator")
+	}
+
+	validationsMu.Lock()
+	defer validationsMu.Unlock()
+
+	for _, v := range vs {
+		if !tagPattern.MatchString(v.Tag) {
+			return fmt.Errorf("invalid binding tag %q: use lowercase letters, digits and underscores", v.Tag)
+		}
+		if v.Func == nil || v.Message == nil {
+			return fmt.Errorf("binding tag %q needs a check and a message", v.Tag)
+		}
+
+		// The validator only ever sees a function looking the tag up, so
+		// replacing a registration does not touch its own maps
+		if _, registered := validations[v.Tag]; !registered {
+			tag := v.Tag
+			err := engine.RegisterValidation(tag, func(fl validator.FieldLevel) bool {
+				v, _ := lookupValidation(tag)
+				return v.Func(fl)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to register binding tag %q: %w", tag, err)
+			}
+		}
+		validations[v.Tag] = v
+	}
+	return nil
+}
+
+// lookupValidation returns the registered validation of tag
+func lookupValidation(tag string) (Validation, bool) {
+	validationsMu.RLock()
+	defer validationsMu.RUnlock()
+
+	v, ok := validations[tag]
+	return v, ok
+}
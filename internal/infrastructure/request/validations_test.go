@@ -0,0 +1,71 @@
+package request
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prefixed returns a validation accepting strings starting with prefix
+func prefixed(tag, prefix string) Validation {
+	return Validation{
+		Tag: tag,
+		Func: func(fl validator.FieldLevel) bool {
+			return strings.HasPrefix(fl.Field().String(), prefix)
+		},
+		Message: func(validator.FieldError) string { return "must start with " + prefix },
+	}
+}
+
+func TestRegisterValidations(t *testing.T) {
+	require.NoError(t, RegisterValidations(prefixed("test_sku", "SKU-")))
+
+	type product struct {
+		SKU  string `json:"sku" binding:"required,test_sku"`
+		Code string `json:"code" binding:"omitempty,uuid4"`
+	}
+
+	decode := func(body string) error {
+		var p product
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		return DecodeJSON(httptest.NewRecorder(), req, &p)
+	}
+
+	require.NoError(t, decode(`{"sku":"SKU-1"}`))
+
+	bindErr := requireBindError(t, decode(`{"sku":"1","code":"01a14deb-f148-777e-9409-200113bcb19c"}`))
+	assert.ElementsMatch(t, []FieldError{
+		{Field: "sku", Message: "must start with SKU-"},
+		{Field: "code", Message: "must be a version 4 UUID"},
+	}, bindErr.Fields)
+
+	// Registering the tag again replaces its check and message
+	require.NoError(t, RegisterValidations(prefixed("test_sku", "SKU:")))
+	require.NoError(t, decode(`{"sku":"SKU:1"}`))
+	bindErr = requireBindError(t, decode(`{"sku":"SKU-1"}`))
+	assert.Equal(t, []FieldError{{Field: "sku", Message: "must start with SKU:"}}, bindErr.Fields)
+}
+
+func TestRegisterValidations_Query(t *testing.T) {
+	require.NoError(t, RegisterValidations(prefixed("test_cursor", "c_")))
+
+	var query struct {
+		Cursor string `form:"cursor" binding:"omitempty,test_cursor"`
+	}
+	req := httptest.NewRequest(http.MethodGet, "/?cursor=abc", nil)
+	bindErr := requireBindError(t, DecodeQuery(req, &query))
+	assert.Equal(t, []FieldError{{Field: "cursor", Message: "must start with c_"}}, bindErr.Fields)
+}
+
+func TestRegisterValidations_Invalid(t *testing.T) {
+	assert.EqualError(t, RegisterValidations(Validation{Tag: "test_incomplete"}), `binding tag "test_incomplete" needs a check and a message`)
+
+	for _, tag := range []string{"", "test,comma", "test|pipe", "Test"} {
+		assert.Error(t, RegisterValidations(prefixed(tag, "x")), tag)
+	}
+}
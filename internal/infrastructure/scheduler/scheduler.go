@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// metrics publishes per-job counters under /debug/vars
+var metrics = expvar.NewMap("scheduler")
+
+// lockPrefix prefixes the lock names of jobs
+const lockPrefix = "scheduler:"
+
+// tokenKey is the context key of the fencing token of a locked run
+type tokenKey struct{}
+
+// FencingToken returns the fencing token of the lock taken for the run of
+// the job ctx was passed to, when the scheduler uses a locker
+func FencingToken(ctx context.Context) (int64, bool) {
+	token, ok := ctx.Value(tokenKey{}).(int64)
+	return token, ok
+}
+
+// JobFunc is a unit of background work run by the scheduler
+type JobFunc func(ctx context.Context) error
+
+// job is a registered periodic job
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Scheduler runs registered jobs periodically in the background
+type Scheduler struct {
+	logger *logger.Logger
+	locker lock.Locker
+	jobs   []job
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New creates a new scheduler
+func New(log *logger.Logger) *Scheduler {
+	return &Scheduler{
+		logger: log,
+	}
+}
+
+// UseLocker makes every run take the lock named after its job first, so
+// with several instances sharing locker each run happens on one of them.
+// The lock is kept for most of the interval rather than released, so
+// instances ticking a moment later skip the run instead of repeating it.
+// It must be called before Start.
+func (s *Scheduler) UseLocker(locker lock.Locker) {
+	s.locker = locker
+}
+
+// Every registers a job to run at the given interval.
+// Jobs must be registered before Start is called.
+func (s *Scheduler) Every(name string, interval time.Duration, run JobFunc) {
+	s.jobs = append(s.jobs, job{
+		name:     name,
+		interval: interval,
+		run:      run,
+	})
+}
+
+// Start runs every registered job once and then on its interval until Stop
+// is called. A job never overlaps with itself.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+}
+
+// Stop cancels running jobs and waits for them to return
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+// loop runs a job until the context is cancelled
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runOnce(ctx, j)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runOnce runs a job, recording its outcome in the log and metrics
+func (s *Scheduler) runOnce(ctx context.Context, j job) {
+	if s.locker != nil {
+		// The margin lets this instance take the lock again on its next tick
+		token, acquired, err := s.locker.Acquire(ctx, lockPrefix+j.name, j.interval-j.interval/10)
+		if err != nil {
+			metrics.Add(j.name+".failures", 1)
+			s.logger.Error().
+				Err(err).
+				Str("job", j.name).
+				Msg("Failed to lock scheduled job")
+			return
+		}
+		if !acquired {
+			metrics.Add(j.name+".skipped", 1)
+			return
+		}
+		ctx = context.WithValue(ctx, tokenKey{}, token)
+	}
+
+	start := time.Now()
+	err := s.safeRun(ctx, j)
+	duration := time.Since(start)
+
+	metrics.Add(j.name+".runs", 1)
+	durationMs := new(expvar.Int)
+	durationMs.Set(duration.Milliseconds())
+	metrics.Set(j.name+".last_duration_ms", durationMs)
+
+	if err != nil {
+		metrics.Add(j.name+".failures", 1)
+		s.logger.Error().
+			Err(err).
+			Str("job", j.name).
+			Dur("duration", duration).
+			Msg("Scheduled job failed")
+		return
+	}
+
+	s.logger.Debug().
+		Str("job", j.name).
+		Dur("duration", duration).
+		Msg("Scheduled job completed")
+}
+
+// safeRun turns a panicking job into an error so one job cannot take the
+// process down
+func (s *Scheduler) safeRun(ctx context.Context, j job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return j.run(ctx)
+}
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+func TestScheduler_RunsJobPeriodically(t *testing.T) {
+	s := New(logger.New("info", io.Discard))
+
+	var runs atomic.Int32
+	s.Every("periodic", 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool {
+		return runs.Load() >= 3
+	}, time.Second, 5*time.Millisecond)
+	s.Stop()
+
+	stopped := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, runs.Load(), "job must not run after Stop")
+	assert.Nil(t, metrics.Get("periodic.failures"))
+}
+
+func TestScheduler_RecordsFailures(t *testing.T) {
+	s := New(logger.New("info", io.Discard))
+
+	var runs atomic.Int32
+	s.Every("failing", time.Hour, func(ctx context.Context) error {
+		runs.Add(1)
+		if runs.Load() == 1 {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	s.Every("panicking", time.Hour, func(ctx context.Context) error {
+		panic("boom")
+	})
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool {
+		return metrics.Get("failing.failures") != nil && metrics.Get("panicking.failures") != nil
+	}, time.Second, 5*time.Millisecond)
+	s.Stop()
+
+	assert.Equal(t, "1", metrics.Get("failing.failures").String())
+	assert.Equal(t, "1", metrics.Get("panicking.runs").String())
+}
+
+func TestScheduler_SkipsRunsLockedElsewhere(t *testing.T) {
+	locker := lock.NewMemoryLocker()
+	_, acquired, err := locker.Acquire(context.Background(), "scheduler:locked", time.Hour)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	s := New(logger.New("info", io.Discard))
+	s.UseLocker(locker)
+
+	var runs atomic.Int32
+	s.Every("locked", time.Hour, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	})
+
+	s.Start(context.Background())
+	require.Eventually(t, func() bool {
+		return metrics.Get("locked.skipped") != nil
+	}, time.Second, 5*time.Millisecond)
+	s.Stop()
+
+	assert.Zero(t, runs.Load())
+	assert.Nil(t, metrics.Get("locked.runs"))
+}
+
+func TestScheduler_PassesFencingToken(t *testing.T) {
+	s := New(logger.New("info", io.Discard))
+	s.UseLocker(lock.NewMemoryLocker())
+
+	tokens := make(chan int64, 1)
+	s.Every("fenced", time.Hour, func(ctx context.Context) error {
+		// Zero when the token is missing
+		token, _ := FencingToken(ctx)
+		tokens <- token
+		return nil
+	})
+
+	s.Start(context.Background())
+	defer s.Stop()
+
+	select {
+	case token := <-tokens:
+		assert.Equal(t, int64(1), token)
+	case <-time.After(time.Second):
+		t.Fatal("job did not run")
+	}
+}
+
+func TestScheduler_StopWithoutStart(t *testing.T) {
+	s := New(logger.New("info", io.Discard))
+	assert.NotPanics(t, s.Stop)
+}
@@ -0,0 +1,74 @@
+package slowalert
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// startKey stores the start of a statement on its GORM instance
+const startKey = "slowalert:start"
+
+// RegisterQueries observes the latency of every statement of db, keyed by
+// its SQL with placeholders, so query values never reach an alert. Zero
+// threshold registers nothing.
+func (m *Monitor) RegisterQueries(db *gorm.DB, threshold time.Duration) error {
+	if threshold <= 0 {
+		return nil
+	}
+	return db.Use(&plugin{monitor: m, threshold: threshold})
+}
+
+// plugin registers the GORM callbacks timing statements
+type plugin struct {
+	monitor   *Monitor
+	threshold time.Duration
+}
+
+// Name implements gorm.Plugin
+func (p *plugin) Name() string {
+	return "slowalert"
+}
+
+// Initialize implements gorm.Plugin
+func (p *plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	registrations := []error{
+		callbacks.Create().Before("gorm:create").Register("slowalert:before_create", p.start),
+		callbacks.Create().After("gorm:create").Register("slowalert:after_create", p.observe),
+		callbacks.Query().Before("gorm:query").Register("slowalert:before_query", p.start),
+		callbacks.Query().After("gorm:query").Register("slowalert:after_query", p.observe),
+		callbacks.Update().Before("gorm:update").Register("slowalert:before_update", p.start),
+		callbacks.Update().After("gorm:update").Register("slowalert:after_update", p.observe),
+		callbacks.Delete().Before("gorm:delete").Register("slowalert:before_delete", p.start),
+		callbacks.Delete().After("gorm:delete").Register("slowalert:after_delete", p.observe),
+		callbacks.Row().Before("gorm:row").Register("slowalert:before_row", p.start),
+		callbacks.Row().After("gorm:row").Register("slowalert:after_row", p.observe),
+		callbacks.Raw().Before("gorm:raw").Register("slowalert:before_raw", p.start),
+		callbacks.Raw().After("gorm:raw").Register("slowalert:after_raw", p.observe),
+	}
+	return errors.Join(registrations...)
+}
+
+// start stamps the statement with the time it starts
+func (p *plugin) start(db *gorm.DB) {
+	db.InstanceSet(startKey, p.monitor.clock.Now())
+}
+
+// observe hands the latency of the statement to the monitor
+func (p *plugin) observe(db *gorm.DB) {
+	value, ok := db.InstanceGet(startKey)
+	if !ok {
+		return
+	}
+	start, ok := value.(time.Time)
+	if !ok || db.Statement.SQL.Len() == 0 {
+		return
+	}
+
+	statement := strings.Join(strings.Fields(db.Statement.SQL.String()), " ")
+	p.monitor.Observe(db.Statement.Context, KindQuery, statement, p.threshold, p.monitor.clock.Now().Sub(start))
+}
@@ -0,0 +1,77 @@
+package slowalert
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// steppingClock moves forward by step each time it is read, so every
+// statement seems to take step
+type steppingClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(c.step)
+	return c.now
+}
+
+type noteModel struct {
+	ID   uint `gorm:"primaryKey"`
+	Body string
+}
+
+func (noteModel) TableName() string {
+	return "notes"
+}
+
+func TestRegisterQueries(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	require.NoError(t, db.AutoMigrate(&noteModel{}))
+
+	hook := newFakeHook()
+	clk := &steppingClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), step: time.Second}
+	m := New(Options{Window: time.Hour, Occurrences: 3, Cooldown: time.Hour}, hook, logger.New("info", io.Discard), clk)
+	require.NoError(t, m.RegisterQueries(db, 500*time.Millisecond))
+
+	ctx := context.Background()
+	for _, email := range []string{"ada@example.com", "alan@example.com", "grace@example.com"} {
+		var notes []noteModel
+		require.NoError(t, db.WithContext(ctx).Where("body = ?", email).Find(&notes).Error)
+	}
+
+	alerts := fired(m, hook)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, KindQuery, alerts[0].Kind)
+	assert.Equal(t, "SELECT * FROM `notes` WHERE body = ?", alerts[0].Key, "statements are keyed without their values")
+	assert.Equal(t, time.Second, alerts[0].Slowest)
+}
+
+func TestRegisterQueries_ZeroThreshold(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	m := New(Options{}, newFakeHook(), logger.New("info", io.Discard), &steppingClock{})
+	require.NoError(t, m.RegisterQueries(db, 0))
+
+	assert.NotContains(t, db.Config.Plugins, "slowalert")
+}
@@ -0,0 +1,176 @@
+package slowalert
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// PagerDutyURL is the endpoint of the PagerDuty Events API v2
+const PagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+
+// logHook logs alerts
+type logHook struct {
+	log *logger.Logger
+}
+
+// NewLogHook returns a hook logging alerts as warnings
+func NewLogHook(log *logger.Logger) Hook {
+	return logHook{log: log}
+}
+
+// Fire implements Hook
+func (h logHook) Fire(ctx context.Context, alert Alert) error {
+	h.log.Warn().
+		Ctx(ctx).
+		Str("kind", alert.Kind).
+		Str("key", alert.Key).
+		Int("count", alert.Count).
+		Dur("window", alert.Window).
+		Dur("threshold", alert.Threshold).
+		Dur("slowest", alert.Slowest).
+		Msg("Latency threshold exceeded repeatedly")
+	return nil
+}
+
+// webhookPayload is the JSON body posted to webhooks
+type webhookPayload struct {
+	Kind      string    `json:"kind"`
+	Key       string    `json:"key"`
+	Summary   string    `json:"summary"`
+	Count     int       `json:"count"`
+	Window    string    `json:"window"`
+	Threshold string    `json:"threshold"`
+	Slowest   string    `json:"slowest"`
+	FiredAt   time.Time `json:"fired_at"`
+}
+
+// webhookHook posts alerts as JSON
+type webhookHook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookHook returns a hook posting each alert as JSON to url with
+// client
+func NewWebhookHook(url string, client *http.Client) Hook {
+	return webhookHook{url: url, client: client}
+}
+
+// Fire implements Hook
+func (h webhookHook) Fire(ctx context.Context, alert Alert) error {
+	return post(ctx, h.client, h.url, webhookPayload{
+		Kind:      alert.Kind,
+		Key:       alert.Key,
+		Summary:   alert.Summary(),
+		Count:     alert.Count,
+		Window:    alert.Window.String(),
+		Threshold: alert.Threshold.String(),
+		Slowest:   alert.Slowest.String(),
+		FiredAt:   alert.FiredAt,
+	})
+}
+
+// PagerDutyOptions configures the PagerDuty hook
+type PagerDutyOptions struct {
+	// RoutingKey is the integration key of the PagerDuty service
+	RoutingKey string
+
+	// Source names the service in the incident, such as app.name
+	Source string
+
+	// URL overrides PagerDutyURL
+	URL string
+}
+
+// pagerDutyEvent is an event of the PagerDuty Events API v2
+type pagerDutyEvent struct {
+	RoutingKey  string           `json:"routing_key"`
+	EventAction string           `json:"event_action"`
+	DedupKey    string           `json:"dedup_key"`
+	Payload     pagerDutyPayload `json:"payload"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string         `json:"summary"`
+	Source        string         `json:"source"`
+	Severity      string         `json:"severity"`
+	Timestamp     time.Time      `json:"timestamp"`
+	CustomDetails webhookPayload `json:"custom_details"`
+}
+
+// pagerDutyHook triggers PagerDuty incidents
+type pagerDutyHook struct {
+	opts   PagerDutyOptions
+	client *http.Client
+}
+
+// NewPagerDutyHook returns a hook triggering a PagerDuty incident for each
+// alert with client. Alerts of the same route or statement share a dedup
+// key, so PagerDuty adds them to the open incident.
+func NewPagerDutyHook(opts PagerDutyOptions, client *http.Client) Hook {
+	if opts.URL == "" {
+		opts.URL = PagerDutyURL
+	}
+	return pagerDutyHook{opts: opts, client: client}
+}
+
+// Fire implements Hook
+func (h pagerDutyHook) Fire(ctx context.Context, alert Alert) error {
+	digest := sha256.Sum256([]byte(alert.Kind + " " + alert.Key))
+
+	return post(ctx, h.client, h.opts.URL, pagerDutyEvent{
+		RoutingKey:  h.opts.RoutingKey,
+		EventAction: "trigger",
+		DedupKey:    alert.Kind + ":" + hex.EncodeToString(digest[:16]),
+		Payload: pagerDutyPayload{
+			Summary:   alert.Summary(),
+			Source:    h.opts.Source,
+			Severity:  "warning",
+			Timestamp: alert.FiredAt,
+			CustomDetails: webhookPayload{
+				Kind:      alert.Kind,
+				Key:       alert.Key,
+				Count:     alert.Count,
+				Window:    alert.Window.String(),
+				Threshold: alert.Threshold.String(),
+				Slowest:   alert.Slowest.String(),
+				FiredAt:   alert.FiredAt,
+			},
+		},
+	})
+}
+
+// post sends body as JSON to url and fails unless the answer is a 2xx
+func post(ctx context.Context, client *http.Client, url string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert rejected with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package slowalert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+var testAlert = Alert{
+	Kind:      KindQuery,
+	Key:       `SELECT * FROM "users" WHERE email = $1`,
+	Count:     5,
+	Window:    5 * time.Minute,
+	Threshold: 500 * time.Millisecond,
+	Slowest:   1500 * time.Millisecond,
+	FiredAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+// receiver records the JSON body of the last request and answers status
+func receiver(t *testing.T, status int) (*httptest.Server, *map[string]any) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &body
+}
+
+func TestLogHook(t *testing.T) {
+	var logs bytes.Buffer
+
+	require.NoError(t, NewLogHook(logger.New("info", &logs)).Fire(context.Background(), testAlert))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry))
+	assert.Equal(t, "warn", entry["level"])
+	assert.Equal(t, KindQuery, entry["kind"])
+	assert.Equal(t, testAlert.Key, entry["key"])
+	assert.Equal(t, float64(5), entry["count"])
+}
+
+func TestWebhookHook(t *testing.T) {
+	server, body := receiver(t, http.StatusNoContent)
+
+	require.NoError(t, NewWebhookHook(server.URL, server.Client()).Fire(context.Background(), testAlert))
+
+	assert.Equal(t, map[string]any{
+		"kind":      "slow_query",
+		"key":       testAlert.Key,
+		"summary":   testAlert.Summary(),
+		"count":     float64(5),
+		"window":    "5m0s",
+		"threshold": "500ms",
+		"slowest":   "1.5s",
+		"fired_at":  "2026-01-01T00:00:00Z",
+	}, *body)
+}
+
+func TestWebhookHook_Rejected(t *testing.T) {
+	server, _ := receiver(t, http.StatusInternalServerError)
+
+	err := NewWebhookHook(server.URL, server.Client()).Fire(context.Background(), testAlert)
+
+	assert.EqualError(t, err, "alert rejected with status 500")
+}
+
+func TestPagerDutyHook(t *testing.T) {
+	server, body := receiver(t, http.StatusAccepted)
+	hook := NewPagerDutyHook(PagerDutyOptions{RoutingKey: "routing-key", Source: "go-scaffolding", URL: server.URL}, server.Client())
+
+	require.NoError(t, hook.Fire(context.Background(), testAlert))
+	event := *body
+
+	assert.Equal(t, "routing-key", event["routing_key"])
+	assert.Equal(t, "trigger", event["event_action"])
+	assert.Regexp(t, `^slow_query:[0-9a-f]{32}$`, event["dedup_key"])
+	payload := event["payload"].(map[string]any)
+	assert.Equal(t, testAlert.Summary(), payload["summary"])
+	assert.Equal(t, "go-scaffolding", payload["source"])
+	assert.Equal(t, "warning", payload["severity"])
+	assert.Equal(t, testAlert.Key, payload["custom_details"].(map[string]any)["key"])
+
+	// Alerts of the same statement join the open incident
+	dedupKey := event["dedup_key"]
+	require.NoError(t, hook.Fire(context.Background(), testAlert))
+	assert.Equal(t, dedupKey, (*body)["dedup_key"])
+}
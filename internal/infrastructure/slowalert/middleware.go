@@ -0,0 +1,46 @@
+package slowalert
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// Thresholds is the latency requests are slow beyond
+type Thresholds struct {
+	// Default applies to routes without an override; zero ignores them
+	Default time.Duration
+
+	// Routes overrides Default for route groups keyed by path prefix, such
+	// as "/users/import"; the longest matching prefix wins and zero ignores
+	// the group
+	Routes map[string]time.Duration
+}
+
+// Middleware observes the latency of each request, keyed by its method and
+// route. Requests matching no route are ignored, so scans of unknown paths
+// are not tracked, and so are WebSocket connections and event streams,
+// which stay open by design.
+func (m *Monitor) Middleware(thresholds Thresholds) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if request.IsStreaming(c.Request) {
+			c.Next()
+			return
+		}
+
+		start := m.clock.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			return
+		}
+		threshold := thresholds.Default
+		if prefix, ok := request.MatchPrefix(thresholds.Routes, route); ok {
+			threshold = thresholds.Routes[prefix]
+		}
+		m.Observe(c.Request.Context(), KindRequest, c.Request.Method+" "+route, threshold, m.clock.Now().Sub(start))
+	}
+}
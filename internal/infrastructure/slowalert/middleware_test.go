@@ -0,0 +1,48 @@
+package slowalert
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hook := newFakeHook()
+	m, fake := setupMonitor(hook)
+
+	router := gin.New()
+	router.Use(m.Middleware(Thresholds{
+		Default: time.Second,
+		Routes:  map[string]time.Duration{"/users/import": time.Minute, "/health": 0},
+	}))
+	slow := func(c *gin.Context) {
+		fake.Advance(2 * time.Second)
+		c.Status(http.StatusOK)
+	}
+	router.GET("/users/:id", slow)
+	router.POST("/users/import", slow)
+	router.GET("/health", slow)
+	router.NoRoute(slow)
+
+	serve := func(method, path string) {
+		for range 3 {
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, path, nil))
+		}
+	}
+	serve(http.MethodGet, "/users/42")
+	serve(http.MethodPost, "/users/import")
+	serve(http.MethodGet, "/health")
+	serve(http.MethodGet, "/wp-login.php")
+
+	alerts := fired(m, hook)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, KindRequest, alerts[0].Kind)
+	assert.Equal(t, "GET /users/:id", alerts[0].Key)
+	assert.Equal(t, 2*time.Second, alerts[0].Slowest)
+}
@@ -0,0 +1,234 @@
+// Package slowalert raises an alert when requests or database queries keep
+// exceeding their latency threshold. A single slow call is noise; an alert
+// fires when the same route or statement is slow a number of times within
+// a window, and then stays quiet for that route or statement until a
+// cooldown passes, so a degraded dependency does not cause an alert storm.
+//
+// Alerts go to a pluggable Hook: the log, a webhook or PagerDuty.
+package slowalert
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// metrics publishes slow call and alert counters under /debug/vars
+var metrics = expvar.NewMap("slow_alerts")
+
+// Kinds of slow calls
+const (
+	// KindRequest is an HTTP request, keyed by method and route
+	KindRequest = "slow_request"
+
+	// KindQuery is a database statement, keyed by its SQL with placeholders
+	KindQuery = "slow_query"
+)
+
+// maxSeries bounds how many routes and statements are tracked at once;
+// statements beyond it are not tracked until older ones go quiet
+const maxSeries = 1000
+
+// Alert describes a route or statement that was slow repeatedly
+type Alert struct {
+	// Kind is KindRequest or KindQuery
+	Kind string
+
+	// Key is the route, such as "GET /users/:id", or the SQL statement
+	Key string
+
+	// Count is how many slow calls there were within Window
+	Count  int
+	Window time.Duration
+
+	// Threshold is the latency the calls exceeded, and Slowest the
+	// longest of them
+	Threshold time.Duration
+	Slowest   time.Duration
+
+	FiredAt time.Time
+}
+
+// Summary describes the alert in one line
+func (a Alert) Summary() string {
+	return fmt.Sprintf("%s: %d calls over %s within %s, slowest %s: %s",
+		a.Kind, a.Count, a.Threshold, a.Window, a.Slowest, truncate(a.Key, 200))
+}
+
+// Hook delivers alerts
+type Hook interface {
+	Fire(ctx context.Context, alert Alert) error
+}
+
+// Clock tells the time slow calls are counted with
+type Clock interface {
+	Now() time.Time
+}
+
+// Options configures when alerts fire
+type Options struct {
+	// Window is how far back slow calls are counted
+	Window time.Duration
+
+	// Occurrences is how many slow calls of a route or statement within
+	// Window fire an alert
+	Occurrences int
+
+	// Cooldown is how long a route or statement stays quiet after an
+	// alert, however slow it keeps being
+	Cooldown time.Duration
+}
+
+// Monitor counts slow calls and fires the alerts
+type Monitor struct {
+	opts  Options
+	hook  Hook
+	log   *logger.Logger
+	clock Clock
+
+	mu     sync.Mutex
+	series map[string]*series
+
+	pending sync.WaitGroup
+}
+
+// series holds the recent slow calls of a route or statement
+type series struct {
+	calls    []time.Time
+	slowest  time.Duration
+	alerted  time.Time
+	lastCall time.Time
+}
+
+// New creates a monitor firing alerts with hook
+func New(opts Options, hook Hook, log *logger.Logger, clock Clock) *Monitor {
+	if opts.Occurrences < 1 {
+		opts.Occurrences = 1
+	}
+
+	return &Monitor{
+		opts:   opts,
+		hook:   hook,
+		log:    log,
+		clock:  clock,
+		series: make(map[string]*series),
+	}
+}
+
+// Observe records a call of kind to key that took d, and fires an alert
+// when it is the slow call that makes the route or statement exceed
+// Occurrences within Window. Calls within threshold, and all calls when
+// threshold is zero, are ignored. Hooks run in the background.
+func (m *Monitor) Observe(ctx context.Context, kind, key string, threshold, d time.Duration) {
+	if threshold <= 0 || d <= threshold {
+		return
+	}
+	metrics.Add(kind, 1)
+
+	alert, ok := m.record(kind, key, threshold, d)
+	if !ok {
+		return
+	}
+
+	metrics.Add("alerts", 1)
+	m.pending.Add(1)
+	go func() {
+		defer m.pending.Done()
+		if err := m.hook.Fire(context.WithoutCancel(ctx), alert); err != nil {
+			metrics.Add("hook_failures", 1)
+			m.log.Error().
+				Ctx(ctx).
+				Err(err).
+				Str("kind", alert.Kind).
+				Str("key", alert.Key).
+				Msg("Failed to deliver slow call alert")
+		}
+	}()
+}
+
+// record adds a slow call to its series and returns the alert to fire, if
+// any
+func (m *Monitor) record(kind, key string, threshold, d time.Duration) (Alert, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	id := kind + " " + key
+
+	s := m.series[id]
+	if s == nil {
+		if len(m.series) >= maxSeries {
+			m.sweep(now)
+		}
+		if len(m.series) >= maxSeries {
+			metrics.Add("untracked", 1)
+			return Alert{}, false
+		}
+		s = &series{}
+		m.series[id] = s
+	}
+
+	// Forget the calls that left the window
+	since := now.Add(-m.opts.Window)
+	kept := s.calls[:0]
+	for _, at := range s.calls {
+		if at.After(since) {
+			kept = append(kept, at)
+		}
+	}
+	if len(kept) == 0 {
+		s.slowest = 0
+	}
+	s.calls = append(kept, now)
+	s.slowest = max(s.slowest, d)
+	s.lastCall = now
+
+	if len(s.calls) < m.opts.Occurrences {
+		return Alert{}, false
+	}
+	if !s.alerted.IsZero() && now.Sub(s.alerted) < m.opts.Cooldown {
+		metrics.Add("suppressed", 1)
+		return Alert{}, false
+	}
+
+	alert := Alert{
+		Kind:      kind,
+		Key:       key,
+		Count:     len(s.calls),
+		Window:    m.opts.Window,
+		Threshold: threshold,
+		Slowest:   s.slowest,
+		FiredAt:   now,
+	}
+	s.alerted = now
+	s.calls = s.calls[:0]
+	s.slowest = 0
+	return alert, true
+}
+
+// sweep forgets the series with no slow call in the window and no alert in
+// the cooldown
+func (m *Monitor) sweep(now time.Time) {
+	for id, s := range m.series {
+		if now.Sub(s.lastCall) >= m.opts.Window && now.Sub(s.alerted) >= m.opts.Cooldown {
+			delete(m.series, id)
+		}
+	}
+}
+
+// Close waits for the alerts being delivered
+func (m *Monitor) Close() {
+	m.pending.Wait()
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,136 @@
+package slowalert
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// fakeHook hands the alerts it gets to the test
+type fakeHook struct {
+	alerts chan Alert
+	err    error
+}
+
+func newFakeHook() *fakeHook {
+	return &fakeHook{alerts: make(chan Alert, 10)}
+}
+
+func (h *fakeHook) Fire(_ context.Context, alert Alert) error {
+	h.alerts <- alert
+	return h.err
+}
+
+// fired returns the alerts delivered once the monitor is idle
+func fired(m *Monitor, h *fakeHook) []Alert {
+	m.Close()
+	var alerts []Alert
+	for {
+		select {
+		case alert := <-h.alerts:
+			alerts = append(alerts, alert)
+		default:
+			return alerts
+		}
+	}
+}
+
+func setupMonitor(hook Hook) (*Monitor, *clock.Fake) {
+	fake := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	m := New(Options{Window: time.Minute, Occurrences: 3, Cooldown: 10 * time.Minute}, hook, logger.New("info", io.Discard), fake)
+	return m, fake
+}
+
+func TestMonitor_FiresAfterOccurrencesWithinWindow(t *testing.T) {
+	hook := newFakeHook()
+	m, fake := setupMonitor(hook)
+	ctx := context.Background()
+
+	m.Observe(ctx, KindRequest, "GET /users/:id", time.Second, 2*time.Second)
+	fake.Advance(10 * time.Second)
+	m.Observe(ctx, KindRequest, "GET /users/:id", time.Second, 500*time.Millisecond) // fast
+	m.Observe(ctx, KindRequest, "GET /users", time.Second, 2*time.Second)            // another route
+	m.Observe(ctx, KindRequest, "GET /users/:id", time.Second, 3*time.Second)
+	require.Empty(t, fired(m, hook))
+
+	fake.Advance(10 * time.Second)
+	m.Observe(ctx, KindRequest, "GET /users/:id", time.Second, 2*time.Second)
+
+	alerts := fired(m, hook)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, Alert{
+		Kind:      KindRequest,
+		Key:       "GET /users/:id",
+		Count:     3,
+		Window:    time.Minute,
+		Threshold: time.Second,
+		Slowest:   3 * time.Second,
+		FiredAt:   fake.Now(),
+	}, alerts[0])
+	assert.Equal(t, "slow_request: 3 calls over 1s within 1m0s, slowest 3s: GET /users/:id", alerts[0].Summary())
+}
+
+func TestMonitor_ForgetsCallsOutsideWindow(t *testing.T) {
+	hook := newFakeHook()
+	m, fake := setupMonitor(hook)
+
+	for range 5 {
+		m.Observe(context.Background(), KindQuery, "SELECT 1", time.Second, 2*time.Second)
+		fake.Advance(40 * time.Second)
+	}
+
+	assert.Empty(t, fired(m, hook), "never three slow calls within a minute")
+}
+
+func TestMonitor_Deduplicates(t *testing.T) {
+	hook := newFakeHook()
+	m, fake := setupMonitor(hook)
+	slow := func(n int) {
+		for range n {
+			m.Observe(context.Background(), KindQuery, "SELECT 1", time.Second, 2*time.Second)
+		}
+	}
+
+	slow(3)
+	require.Len(t, fired(m, hook), 1)
+
+	slow(10)
+	fake.Advance(5 * time.Minute)
+	slow(10)
+	assert.Empty(t, fired(m, hook), "quiet during the cooldown")
+
+	fake.Advance(5 * time.Minute)
+	slow(3)
+	assert.Len(t, fired(m, hook), 1, "alerts again after the cooldown")
+}
+
+func TestMonitor_ZeroThresholdDisables(t *testing.T) {
+	hook := newFakeHook()
+	m, _ := setupMonitor(hook)
+
+	for range 5 {
+		m.Observe(context.Background(), KindRequest, "GET /users", 0, time.Hour)
+	}
+
+	assert.Empty(t, fired(m, hook))
+}
+
+func TestMonitor_HookFailureIsLogged(t *testing.T) {
+	hook := newFakeHook()
+	hook.err = errors.New("connection refused")
+	m, _ := setupMonitor(hook)
+
+	for range 3 {
+		m.Observe(context.Background(), KindRequest, "GET /users", time.Second, 2*time.Second)
+	}
+
+	assert.Len(t, fired(m, hook), 1)
+}
@@ -0,0 +1,94 @@
+// Package startup waits for the dependencies of the application, such as
+// the database, to become reachable. In Kubernetes the application often
+// starts before the database is ready; retrying for a bounded window lets
+// it come up without crash-looping, and still fails if the dependency
+// never appears. Once the application is wired, Warmer runs the tasks
+// preparing it for traffic before readiness reports healthy.
+package startup
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+const (
+	// attemptTimeout bounds a single connection attempt
+	attemptTimeout = 5 * time.Second
+
+	// defaultInitialBackoff and defaultMaxBackoff are used when Options
+	// leaves them unset
+	defaultInitialBackoff = 500 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+)
+
+// Options bounds the retries of Wait
+type Options struct {
+	// Timeout is how long Wait keeps retrying after the first attempt.
+	// Zero makes a single attempt.
+	Timeout time.Duration
+
+	// InitialBackoff is the pause after the first failed attempt. It
+	// doubles after each further failure, up to MaxBackoff.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the pause between attempts
+	MaxBackoff time.Duration
+}
+
+// Wait calls connect until it succeeds, pausing between attempts with
+// exponential backoff and jitter. It returns the last error once
+// opts.Timeout has passed or ctx is done. name identifies the dependency
+// in logs and errors.
+func Wait(ctx context.Context, name string, opts Options, log *logger.Logger, connect func(ctx context.Context) error) error {
+	if opts.InitialBackoff <= 0 {
+		opts.InitialBackoff = defaultInitialBackoff
+	}
+	if opts.MaxBackoff < opts.InitialBackoff {
+		opts.MaxBackoff = max(defaultMaxBackoff, opts.InitialBackoff)
+	}
+
+	deadline := time.Now().Add(opts.Timeout)
+	backoff := opts.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, attemptTimeout)
+		err := connect(attemptCtx)
+		cancel()
+
+		if err == nil {
+			if attempt > 1 {
+				log.Info().Str("dependency", name).Int("attempts", attempt).Msg("Dependency is reachable")
+			}
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("%s not reachable after %d attempts: %w", name, attempt, err)
+		}
+
+		pause := min(jitter(backoff), remaining)
+		log.Warn().Err(err).Str("dependency", name).Int("attempt", attempt).Dur("retry_in", pause).Msg("Waiting for dependency")
+
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%s not reachable after %d attempts: %w", name, attempt, err)
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, opts.MaxBackoff)
+	}
+}
+
+// jitter spreads a pause between half and all of d, so instances started
+// together do not retry in lockstep
+func jitter(d time.Duration) time.Duration {
+	half := d / 2
+	return half + rand.N(d-half+1)
+}
@@ -0,0 +1,88 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+var testOptions = Options{
+	Timeout:        time.Second,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     4 * time.Millisecond,
+}
+
+func TestWait_RetriesUntilReachable(t *testing.T) {
+	refused := errors.New("connection refused")
+	attempts := 0
+
+	err := Wait(context.Background(), "postgres", testOptions, logger.New("info", io.Discard), func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return refused
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWait_GivesUpAfterTimeout(t *testing.T) {
+	refused := errors.New("connection refused")
+	opts := testOptions
+	opts.Timeout = 20 * time.Millisecond
+
+	start := time.Now()
+	err := Wait(context.Background(), "postgres", opts, logger.New("info", io.Discard), func(ctx context.Context) error {
+		return refused
+	})
+
+	assert.ErrorIs(t, err, refused)
+	assert.Contains(t, err.Error(), "postgres not reachable after")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestWait_ZeroTimeoutTriesOnce(t *testing.T) {
+	refused := errors.New("connection refused")
+	attempts := 0
+
+	err := Wait(context.Background(), "postgres", Options{}, logger.New("info", io.Discard), func(ctx context.Context) error {
+		attempts++
+		return refused
+	})
+
+	assert.ErrorIs(t, err, refused)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWait_StopsWhenContextIsDone(t *testing.T) {
+	refused := errors.New("connection refused")
+	ctx, cancel := context.WithCancel(context.Background())
+	opts := testOptions
+	opts.Timeout = time.Minute
+	opts.InitialBackoff = time.Minute
+	opts.MaxBackoff = time.Minute
+
+	err := Wait(ctx, "postgres", opts, logger.New("info", io.Discard), func(ctx context.Context) error {
+		cancel()
+		return refused
+	})
+
+	assert.ErrorIs(t, err, refused)
+}
+
+func TestJitter(t *testing.T) {
+	for range 100 {
+		d := jitter(10 * time.Millisecond)
+		assert.GreaterOrEqual(t, d, 5*time.Millisecond)
+		assert.LessOrEqual(t, d, 10*time.Millisecond)
+	}
+}
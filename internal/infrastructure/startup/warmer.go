@@ -0,0 +1,125 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// ErrWarmingUp is returned by Warmer.Ready until the warm-up has finished
+var ErrWarmingUp = errors.New("warming up")
+
+// WarmupTask prepares a component for traffic, such as preloading a cache
+// or preparing statements
+type WarmupTask func(ctx context.Context) error
+
+// Warmer runs the tasks registered by components once the application is
+// wired. Until they have finished, Ready fails, so a readiness probe keeps
+// traffic away from an instance still filling its caches. Warm-up only
+// makes the first requests faster: a task failing or timing out is logged
+// and does not keep the instance unready.
+type Warmer struct {
+	timeout time.Duration
+	log     *logger.Logger
+
+	mu      sync.Mutex
+	tasks   []warmupTask
+	started bool
+	done    chan struct{}
+}
+
+type warmupTask struct {
+	name    string
+	timeout time.Duration
+	run     WarmupTask
+}
+
+// NewWarmer creates a warm-up bounding each task by timeout unless it was
+// registered with one of its own
+func NewWarmer(timeout time.Duration, log *logger.Logger) *Warmer {
+	return &Warmer{timeout: timeout, log: log, done: make(chan struct{})}
+}
+
+// Register adds a task run by Run. name identifies it in logs; a zero
+// timeout uses the timeout of the warm-up. Tasks registered once Run has
+// started are not run.
+func (w *Warmer) Register(name string, timeout time.Duration, task WarmupTask) {
+	if timeout <= 0 {
+		timeout = w.timeout
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.started {
+		w.log.Warn().Str("task", name).Msg("Warm-up task registered after warm-up started, skipping")
+		return
+	}
+	w.tasks = append(w.tasks, warmupTask{name: name, timeout: timeout, run: task})
+}
+
+// Run runs the registered tasks concurrently, each within its timeout, and
+// returns once all of them have finished. It returns the errors of the
+// tasks that failed, which are logged as well. Only the first call runs
+// the tasks.
+func (w *Warmer) Run(ctx context.Context) error {
+	w.mu.Lock()
+	if w.started {
+		w.mu.Unlock()
+		<-w.done
+		return nil
+	}
+	w.started = true
+	tasks := w.tasks
+	w.mu.Unlock()
+	defer close(w.done)
+
+	start := time.Now()
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Go(func() {
+			errs[i] = w.run(ctx, task)
+		})
+	}
+	wg.Wait()
+
+	err := errors.Join(errs...)
+	failed := 0
+	for _, taskErr := range errs {
+		if taskErr != nil {
+			failed++
+		}
+	}
+	w.log.Info().Int("tasks", len(tasks)).Int("failed", failed).Dur("duration", time.Since(start)).Msg("Warm-up finished")
+	return err
+}
+
+// run runs a task within its timeout and logs how it went
+func (w *Warmer) run(ctx context.Context, task warmupTask) error {
+	taskCtx, cancel := context.WithTimeout(ctx, task.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := task.run(taskCtx)
+	duration := time.Since(start)
+	if err != nil {
+		w.log.Warn().Err(err).Str("task", task.name).Dur("duration", duration).Msg("Warm-up task failed")
+		return fmt.Errorf("warm-up task %s: %w", task.name, err)
+	}
+	w.log.Info().Str("task", task.name).Dur("duration", duration).Msg("Warm-up task finished")
+	return nil
+}
+
+// Ready reports whether the warm-up has finished, as a health check
+func (w *Warmer) Ready(ctx context.Context) error {
+	select {
+	case <-w.done:
+		return nil
+	default:
+		return ErrWarmingUp
+	}
+}
@@ -0,0 +1,92 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+func TestWarmer_ReadyOnceTasksFinish(t *testing.T) {
+	w := NewWarmer(time.Second, logger.New("info", io.Discard))
+	release := make(chan struct{})
+	var ran atomic.Int32
+	w.Register("users", 0, func(ctx context.Context) error {
+		<-release
+		ran.Add(1)
+		return nil
+	})
+	w.Register("statements", 0, func(ctx context.Context) error {
+		ran.Add(1)
+		return nil
+	})
+
+	assert.ErrorIs(t, w.Ready(context.Background()), ErrWarmingUp)
+
+	done := make(chan error)
+	go func() { done <- w.Run(context.Background()) }()
+	assert.ErrorIs(t, w.Ready(context.Background()), ErrWarmingUp, "not ready while a task runs")
+
+	close(release)
+	require.NoError(t, <-done)
+	assert.Equal(t, int32(2), ran.Load())
+	assert.NoError(t, w.Ready(context.Background()))
+}
+
+func TestWarmer_FailuresDoNotKeepUnready(t *testing.T) {
+	w := NewWarmer(10*time.Millisecond, logger.New("info", io.Discard))
+	broken := errors.New("cache unreachable")
+	w.Register("cache", 0, func(ctx context.Context) error {
+		return broken
+	})
+	w.Register("slow", 0, func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	err := w.Run(context.Background())
+
+	assert.ErrorIs(t, err, broken)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "tasks are bounded by the default timeout")
+	assert.Contains(t, err.Error(), "warm-up task cache")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.NoError(t, w.Ready(context.Background()))
+}
+
+func TestWarmer_TaskTimeout(t *testing.T) {
+	w := NewWarmer(time.Hour, logger.New("info", io.Discard))
+	var deadline time.Time
+	w.Register("templates", time.Minute, func(ctx context.Context) error {
+		deadline, _ = ctx.Deadline()
+		return nil
+	})
+
+	require.NoError(t, w.Run(context.Background()))
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+func TestWarmer_NoTasks(t *testing.T) {
+	w := NewWarmer(time.Second, logger.New("info", io.Discard))
+
+	require.NoError(t, w.Run(context.Background()))
+	assert.NoError(t, w.Ready(context.Background()))
+}
+
+func TestWarmer_RegisterAfterRun(t *testing.T) {
+	w := NewWarmer(time.Second, logger.New("info", io.Discard))
+	require.NoError(t, w.Run(context.Background()))
+
+	w.Register("late", 0, func(ctx context.Context) error {
+		t.Error("tasks registered after Run are not run")
+		return nil
+	})
+	require.NoError(t, w.Run(context.Background()), "later calls do not run tasks")
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+func TestLocalStorage_PutAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStorage(config.LocalStorageConfig{Dir: dir, BaseURL: "/files/"})
+	ctx := context.Background()
+
+	err := s.Put(ctx, "avatars/123/a.png", strings.NewReader("image"), "image/png")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "avatars", "123", "a.png"))
+	require.NoError(t, err)
+	assert.Equal(t, "image", string(data))
+
+	err = s.Delete(ctx, "avatars/123/a.png")
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "avatars", "123", "a.png"))
+	assert.True(t, os.IsNotExist(err))
+
+	// Deleting a missing file is not an error
+	assert.NoError(t, s.Delete(ctx, "avatars/123/a.png"))
+}
+
+func TestLocalStorage_URL(t *testing.T) {
+	s := NewLocalStorage(config.LocalStorageConfig{Dir: t.TempDir(), BaseURL: "/files/"})
+
+	url, err := s.URL(context.Background(), "avatars/123/a b.png")
+	require.NoError(t, err)
+	assert.Equal(t, "/files/avatars/123/a%20b.png", url)
+}
+
+func TestLocalStorage_RejectsKeysOutsideDir(t *testing.T) {
+	s := NewLocalStorage(config.LocalStorageConfig{Dir: t.TempDir(), BaseURL: "/files"})
+	ctx := context.Background()
+
+	for _, key := range []string{"../escape.png", "/etc/passwd", "avatars/../../escape.png", ""} {
+		assert.Error(t, s.Put(ctx, key, strings.NewReader("x"), "image/png"), key)
+		assert.Error(t, s.Delete(ctx, key), key)
+		_, err := s.URL(ctx, key)
+		assert.Error(t, err, key)
+	}
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+// ErrNotFound is returned when reading an object that does not exist
+var ErrNotFound = errors.New("object not found")
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// ObjectStorage stores private objects, such as exports, imports and
+// attachments, which the application reads back or hands out through
+// signed URLs
+type ObjectStorage interface {
+	// Put stores the object under key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+
+	// Get opens the object, failing with ErrNotFound when it is missing.
+	// The caller closes the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// SignURL returns a URL letting a client without credentials send a
+	// GET or PUT request for the object until ttl elapsed. A zero ttl uses
+	// the default of the storage.
+	SignURL(ctx context.Context, key, method string, ttl time.Duration) (string, error)
+
+	// Delete removes the object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+
+	// List calls fn with every object whose key starts with prefix
+	List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error
+}
+
+// NewObjectStorage creates the object storage selected by the configured
+// driver
+func NewObjectStorage(ctx context.Context, cfg config.ObjectStorageConfig) (ObjectStorage, error) {
+	switch cfg.Driver {
+	case DriverLocal, "":
+		return NewLocalObjectStorage(cfg.Local)
+	case DriverS3:
+		return NewS3Storage(ctx, cfg.S3)
+	case DriverMinIO:
+		if cfg.S3.Endpoint == "" {
+			return nil, errors.New("minio storage requires an endpoint")
+		}
+		// MinIO serves buckets under the path, not as subdomains
+		s3Cfg := cfg.S3
+		s3Cfg.UsePathStyle = true
+		return NewS3Storage(ctx, s3Cfg)
+	default:
+		return nil, fmt.Errorf("unknown object storage driver: %q", cfg.Driver)
+	}
+}
+
+// LifecycleRule expires the objects under a key prefix
+type LifecycleRule struct {
+	// Prefix selects the objects, such as exports/
+	Prefix string
+
+	// ExpireAfter is how long after their last write the objects are
+	// deleted
+	ExpireAfter time.Duration
+}
+
+// DeleteExpired deletes the objects that outlived the rule matching their
+// key as of now, and returns how many it deleted. An object matching
+// several rules expires with the first.
+func DeleteExpired(ctx context.Context, objects ObjectStorage, rules []LifecycleRule, now time.Time) (int, error) {
+	deleted := 0
+	for _, rule := range rules {
+		// An empty prefix would expire every object, avatars included
+		if rule.Prefix == "" {
+			return deleted, errors.New("lifecycle rules require a prefix")
+		}
+
+		var expired []string
+		err := objects.List(ctx, rule.Prefix, func(object ObjectInfo) error {
+			if firstRule(rules, object.Key) == rule && now.Sub(object.ModTime) >= rule.ExpireAfter {
+				expired = append(expired, object.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list %s: %w", rule.Prefix, err)
+		}
+
+		for _, key := range expired {
+			if err := objects.Delete(ctx, key); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// firstRule returns the first rule whose prefix the key starts with
+func firstRule(rules []LifecycleRule, key string) LifecycleRule {
+	for _, rule := range rules {
+		if strings.HasPrefix(key, rule.Prefix) {
+			return rule
+		}
+	}
+	return LifecycleRule{}
+}
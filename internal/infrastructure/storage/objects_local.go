@@ -0,0 +1,217 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+// LocalObjectStorage keeps objects on the local disk. Unlike avatars they
+// are not served publicly: signed URLs point to Handler, which the API
+// serves under the configured base URL. It is intended for single-instance
+// setups and development.
+type LocalObjectStorage struct {
+	files      *LocalStorage
+	signingKey []byte
+}
+
+// NewLocalObjectStorage creates a new local-disk object storage. Without a
+// signing key a random one is used, so signed URLs stop working when the
+// process restarts.
+func NewLocalObjectStorage(cfg config.LocalObjectStorageConfig) (*LocalObjectStorage, error) {
+	key := []byte(cfg.SigningKey)
+	if len(key) == 0 {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate signing key: %w", err)
+		}
+	}
+
+	return &LocalObjectStorage{
+		files:      NewLocalStorage(config.LocalStorageConfig{Dir: cfg.Dir, BaseURL: cfg.BaseURL}),
+		signingKey: key,
+	}, nil
+}
+
+// BaseURL returns the URL prefix signed URLs are served under
+func (s *LocalObjectStorage) BaseURL() string {
+	return s.files.BaseURL()
+}
+
+// Put writes the object atomically. The content type is not kept; objects
+// are served with the type their extension or content suggests.
+func (s *LocalObjectStorage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	return s.files.Put(ctx, key, r, contentType)
+}
+
+// Get opens the object
+func (s *LocalObjectStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.files.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open object: %w", err)
+	}
+	return f, nil
+}
+
+// Delete removes the object; deleting a missing object is not an error
+func (s *LocalObjectStorage) Delete(ctx context.Context, key string) error {
+	return s.files.Delete(ctx, key)
+}
+
+// List walks the directory for the objects under prefix
+func (s *LocalObjectStorage) List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error {
+	root := s.files.Dir()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			// Skip the directories outside the prefix
+			if key != "." && !strings.HasPrefix(key+"/", prefix) && !strings.HasPrefix(prefix, key+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Skip other keys and uploads still being written
+		if !strings.HasPrefix(key, prefix) || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		// Nothing was stored yet
+		return nil
+	}
+	return err
+}
+
+// SignURL returns the path Handler serves the object under, with the
+// method and expiry signed
+func (s *LocalObjectStorage) SignURL(ctx context.Context, key, method string, ttl time.Duration) (string, error) {
+	if _, err := s.files.path(key); err != nil {
+		return "", err
+	}
+	if method != http.MethodGet && method != http.MethodPut {
+		return "", fmt.Errorf("unsupported signed URL method: %q", method)
+	}
+	if ttl <= 0 {
+		ttl = defaultPresignTTL
+	}
+
+	return s.signedURL(key, method, time.Now().Add(ttl)), nil
+}
+
+// signedURL returns the URL of the object signed until expires
+func (s *LocalObjectStorage) signedURL(key, method string, expires time.Time) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	query := url.Values{}
+	query.Set("method", method)
+	query.Set("expires", strconv.FormatInt(expires.Unix(), 10))
+	query.Set("signature", s.signature(key, method, expires.Unix()))
+	return s.files.BaseURL() + "/" + strings.Join(segments, "/") + "?" + query.Encode()
+}
+
+// signature authenticates the method and expiry of a signed URL
+func (s *LocalObjectStorage) signature(key, method string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	fmt.Fprintf(mac, "%s\n%s\n%d", method, key, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Handler serves the signed URLs: GET and HEAD read the object, PUT stores
+// the request body as the object. Requests whose signature is missing,
+// wrong or expired are answered with 403 Forbidden.
+func (s *LocalObjectStorage) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, s.files.BaseURL()+"/")
+		query := r.URL.Query()
+
+		method := r.Method
+		if method == http.MethodHead {
+			method = http.MethodGet
+		}
+		expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+		signature := s.signature(key, method, expires)
+		if err != nil || query.Get("method") != method ||
+			!hmac.Equal([]byte(query.Get("signature")), []byte(signature)) ||
+			time.Now().Unix() > expires {
+			http.Error(w, "invalid or expired signature", http.StatusForbidden)
+			return
+		}
+
+		switch method {
+		case http.MethodGet:
+			s.serve(w, r, key)
+		case http.MethodPut:
+			if err := s.Put(r.Context(), key, r.Body, r.Header.Get("Content-Type")); err != nil {
+				http.Error(w, "failed to store object", http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+}
+
+// serve writes the object, honouring range and conditional requests
+func (s *LocalObjectStorage) serve(w http.ResponseWriter, r *http.Request, key string) {
+	object, err := s.Get(r.Context(), key)
+	if errors.Is(err, ErrNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		http.Error(w, "failed to read object", http.StatusInternalServerError)
+		return
+	}
+	defer object.Close()
+
+	f := object.(*os.File)
+	info, err := f.Stat()
+	if err != nil {
+		http.Error(w, "failed to read object", http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
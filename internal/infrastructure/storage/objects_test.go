@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+// newLocalObjects returns a local object storage in a temporary directory
+func newLocalObjects(t *testing.T) (*LocalObjectStorage, string) {
+	dir := t.TempDir()
+	s, err := NewLocalObjectStorage(config.LocalObjectStorageConfig{Dir: dir, BaseURL: "/objects", SigningKey: "secret"})
+	require.NoError(t, err)
+	return s, dir
+}
+
+func TestNewObjectStorage(t *testing.T) {
+	bucket := config.S3StorageConfig{Bucket: "exports", Region: "us-east-1"}
+	tests := []struct {
+		name     string
+		cfg      config.ObjectStorageConfig
+		expected interface{}
+		wantErr  string
+	}{
+		{name: "default driver", cfg: config.ObjectStorageConfig{}, expected: &LocalObjectStorage{}},
+		{name: "s3 driver", cfg: config.ObjectStorageConfig{Driver: DriverS3, S3: bucket}, expected: &S3Storage{}},
+		{name: "minio driver", cfg: config.ObjectStorageConfig{Driver: DriverMinIO, S3: config.S3StorageConfig{Bucket: "exports", Endpoint: "http://localhost:9000"}}, expected: &S3Storage{}},
+		{name: "minio driver without endpoint", cfg: config.ObjectStorageConfig{Driver: DriverMinIO, S3: bucket}, wantErr: "minio storage requires an endpoint"},
+		{name: "unknown driver", cfg: config.ObjectStorageConfig{Driver: "floppy"}, wantErr: `unknown object storage driver: "floppy"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewObjectStorage(context.Background(), tt.cfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, s)
+		})
+	}
+}
+
+func TestLocalObjectStorage_PutGetListDelete(t *testing.T) {
+	s, _ := newLocalObjects(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "exports/2025/users.csv", strings.NewReader("id,email"), "text/csv"))
+	require.NoError(t, s.Put(ctx, "imports/users.csv", strings.NewReader("email"), "text/csv"))
+
+	object, err := s.Get(ctx, "exports/2025/users.csv")
+	require.NoError(t, err)
+	data, err := io.ReadAll(object)
+	require.NoError(t, err)
+	require.NoError(t, object.Close())
+	assert.Equal(t, "id,email", string(data))
+
+	var keys []string
+	require.NoError(t, s.List(ctx, "exports/", func(info ObjectInfo) error {
+		keys = append(keys, info.Key)
+		assert.EqualValues(t, 8, info.Size)
+		return nil
+	}))
+	assert.Equal(t, []string{"exports/2025/users.csv"}, keys)
+
+	require.NoError(t, s.Delete(ctx, "exports/2025/users.csv"))
+	_, err = s.Get(ctx, "exports/2025/users.csv")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalObjectStorage_ListBeforeAnyPut(t *testing.T) {
+	s, err := NewLocalObjectStorage(config.LocalObjectStorageConfig{Dir: filepath.Join(t.TempDir(), "missing")})
+	require.NoError(t, err)
+
+	assert.NoError(t, s.List(context.Background(), "exports/", func(ObjectInfo) error {
+		t.Fatal("no objects were stored")
+		return nil
+	}))
+}
+
+func TestLocalObjectStorage_SignedURLs(t *testing.T) {
+	s, _ := newLocalObjects(t)
+	server := httptest.NewServer(s.Handler())
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+
+	// Upload through a signed PUT URL
+	putURL, err := s.SignURL(ctx, "attachments/a b.txt", http.MethodPut, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(putURL, "/objects/attachments/a%20b.txt?"), putURL)
+
+	req, err := http.NewRequest(http.MethodPut, server.URL+putURL, strings.NewReader("hello"))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// A PUT signature does not allow reading
+	resp, err = http.Get(server.URL + putURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	getURL, err := s.SignURL(ctx, "attachments/a b.txt", http.MethodGet, 0)
+	require.NoError(t, err)
+	resp, err = http.Get(server.URL + getURL)
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "hello", string(body))
+
+	// The signature covers the key
+	resp, err = http.Get(server.URL + strings.Replace(getURL, "a%20b.txt", "other.txt", 1))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	expired := s.signedURL("attachments/a b.txt", http.MethodGet, time.Now().Add(-time.Second))
+	resp, err = http.Get(server.URL + expired)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	_, err = s.SignURL(ctx, "attachments/a b.txt", http.MethodDelete, time.Minute)
+	assert.EqualError(t, err, `unsupported signed URL method: "DELETE"`)
+	_, err = s.SignURL(ctx, "../escape.txt", http.MethodGet, time.Minute)
+	assert.Error(t, err)
+}
+
+func TestDeleteExpired(t *testing.T) {
+	s, dir := newLocalObjects(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	age := func(key string, d time.Duration) {
+		require.NoError(t, s.Put(ctx, key, strings.NewReader("x"), "text/plain"))
+		modTime := now.Add(-d)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, filepath.FromSlash(key)), modTime, modTime))
+	}
+	age("exports/old.csv", 8*24*time.Hour)
+	age("exports/new.csv", time.Hour)
+	age("exports/kept/old.csv", 8*24*time.Hour)
+	age("imports/old.csv", 8*24*time.Hour)
+	age("avatars/1/a.png", 365*24*time.Hour)
+
+	deleted, err := DeleteExpired(ctx, s, []LifecycleRule{
+		// The first matching rule applies
+		{Prefix: "exports/kept/", ExpireAfter: 30 * 24 * time.Hour},
+		{Prefix: "exports/", ExpireAfter: 7 * 24 * time.Hour},
+		{Prefix: "imports/", ExpireAfter: 24 * time.Hour},
+	}, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	var left []string
+	require.NoError(t, s.List(ctx, "", func(info ObjectInfo) error {
+		left = append(left, info.Key)
+		return nil
+	}))
+	assert.ElementsMatch(t, []string{"exports/new.csv", "exports/kept/old.csv", "avatars/1/a.png"}, left)
+
+	_, err = DeleteExpired(ctx, s, []LifecycleRule{{ExpireAfter: time.Hour}}, now)
+	assert.EqualError(t, err, "lifecycle rules require a prefix")
+}
+
+func TestS3Storage_Objects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Query().Get("list-type") == "2":
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Name>exports</Name><Prefix>exports/</Prefix><KeyCount>1</KeyCount><IsTruncated>false</IsTruncated>
+  <Contents><Key>exports/users.csv</Key><LastModified>2025-01-15T09:30:00.000Z</LastModified><Size>42</Size></Contents>
+</ListBucketResult>`)
+		case r.URL.Path == "/exports/exports/missing.csv":
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+		default:
+			_, _ = io.WriteString(w, "id,email")
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	s, err := NewS3Storage(context.Background(), config.S3StorageConfig{
+		Bucket:          "exports",
+		Region:          "us-east-1",
+		Endpoint:        server.URL,
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	object, err := s.Get(ctx, "exports/users.csv")
+	require.NoError(t, err)
+	data, err := io.ReadAll(object)
+	require.NoError(t, err)
+	object.Close()
+	assert.Equal(t, "id,email", string(data))
+
+	_, err = s.Get(ctx, "exports/missing.csv")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var listed []ObjectInfo
+	require.NoError(t, s.List(ctx, "exports/", func(info ObjectInfo) error {
+		listed = append(listed, info)
+		return nil
+	}))
+	assert.Equal(t, []ObjectInfo{
+		{Key: "exports/users.csv", Size: 42, ModTime: time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)},
+	}, listed)
+}
+
+func TestS3Storage_SignURL(t *testing.T) {
+	s, err := NewS3Storage(context.Background(), config.S3StorageConfig{
+		Bucket:          "exports",
+		Region:          "us-east-1",
+		Endpoint:        "http://localhost:9000",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		UsePathStyle:    true,
+	})
+	require.NoError(t, err)
+
+	signed, err := s.SignURL(context.Background(), "exports/users.csv", http.MethodPut, time.Hour)
+	require.NoError(t, err)
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/exports/exports/users.csv", parsed.Path)
+	assert.Equal(t, "3600", parsed.Query().Get("X-Amz-Expires"))
+
+	// The configured TTL applies without one
+	signed, err = s.SignURL(context.Background(), "exports/users.csv", http.MethodGet, 0)
+	require.NoError(t, err)
+	parsed, err = url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "900", parsed.Query().Get("X-Amz-Expires"))
+
+	_, err = s.SignURL(context.Background(), "exports/users.csv", http.MethodDelete, time.Hour)
+	assert.EqualError(t, err, `unsupported signed URL method: "DELETE"`)
+}
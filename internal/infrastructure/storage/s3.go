@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+// defaultPresignTTL is used when no presign TTL is configured
+const defaultPresignTTL = 15 * time.Minute
+
+// S3Storage keeps files in an S3 bucket and serves them through presigned
+// URLs, so buckets can stay private
+type S3Storage struct {
+	client     *s3.Client
+	presigner  *s3.PresignClient
+	bucket     string
+	presignTTL time.Duration
+}
+
+// NewS3Storage creates a new S3 storage. Credentials fall back to the
+// default AWS chain (environment, shared config, instance role) when no
+// static keys are configured.
+func NewS3Storage(ctx context.Context, cfg config.S3StorageConfig) (*S3Storage, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("s3 storage requires a bucket")
+	}
+
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(cfg.Region),
+	}
+	if cfg.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.UsePathStyle
+	})
+
+	presignTTL := cfg.PresignTTL
+	if presignTTL <= 0 {
+		presignTTL = defaultPresignTTL
+	}
+
+	return &S3Storage{
+		client:     client,
+		presigner:  s3.NewPresignClient(client),
+		bucket:     cfg.Bucket,
+		presignTTL: presignTTL,
+	}, nil
+}
+
+// Put uploads the file, replacing any object with the same key.
+// Readers should be seekable so the request can be signed and retried.
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        r,
+		ContentType: aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the object; deleting a missing object is not an error
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+
+	return nil
+}
+
+// URL returns a presigned GET URL valid for the configured TTL
+func (s *S3Storage) URL(ctx context.Context, key string) (string, error) {
+	req, err := s.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(s.presignTTL))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object URL: %w", err)
+	}
+
+	return req.URL, nil
+}
+
+// Get downloads the object
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+
+	return out.Body, nil
+}
+
+// SignURL returns a presigned GET or PUT URL valid for ttl, or for the
+// configured TTL when ttl is zero
+func (s *S3Storage) SignURL(ctx context.Context, key, method string, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		ttl = s.presignTTL
+	}
+
+	var (
+		req *v4.PresignedHTTPRequest
+		err error
+	)
+	switch method {
+	case http.MethodGet:
+		req, err = s.presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(key),
+		}, s3.WithPresignExpires(ttl))
+	case http.MethodPut:
+		req, err = s.presigner.PresignPutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(key),
+		}, s3.WithPresignExpires(ttl))
+	default:
+		return "", fmt.Errorf("unsupported signed URL method: %q", method)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object URL: %w", err)
+	}
+
+	return req.URL, nil
+}
+
+// List pages through the objects under prefix
+func (s *S3Storage) List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error {
+	pages := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for pages.HasMorePages() {
+		page, err := pages.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, object := range page.Contents {
+			err := fn(ObjectInfo{
+				Key:     aws.ToString(object.Key),
+				Size:    aws.ToInt64(object.Size),
+				ModTime: aws.ToTime(object.LastModified),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+func TestS3Storage_URL(t *testing.T) {
+	s, err := NewS3Storage(context.Background(), config.S3StorageConfig{
+		Bucket:          "avatars",
+		Region:          "us-east-1",
+		Endpoint:        "http://localhost:9000",
+		AccessKeyID:     "access",
+		SecretAccessKey: "secret",
+		UsePathStyle:    true,
+		PresignTTL:      5 * time.Minute,
+	})
+	require.NoError(t, err)
+
+	// Presigning is done locally, so no server is needed
+	signed, err := s.URL(context.Background(), "avatars/123/a.png")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:9000", parsed.Host)
+	assert.Equal(t, "/avatars/avatars/123/a.png", parsed.Path)
+	assert.Equal(t, "300", parsed.Query().Get("X-Amz-Expires"))
+	assert.NotEmpty(t, parsed.Query().Get("X-Amz-Signature"))
+}
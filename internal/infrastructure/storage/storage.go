@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+const (
+	DriverLocal  = "local"
+	DriverS3     = "s3"
+	DriverMemory = "memory" // no-database mode; files are kept on local disk
+	DriverMinIO  = "minio"  // object storage only; MinIO's S3 API
+)
+
+// Storage stores files by key and hands out URLs to read them
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Delete(ctx context.Context, key string) error
+	URL(ctx context.Context, key string) (string, error)
+}
+
+// New creates the storage selected by the configured driver
+func New(ctx context.Context, cfg config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case DriverLocal, DriverMemory, "":
+		return NewLocalStorage(cfg.Local), nil
+	case DriverS3:
+		return NewS3Storage(ctx, cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %q", cfg.Driver)
+	}
+}
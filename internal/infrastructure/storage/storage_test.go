@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.StorageConfig
+		expected interface{}
+		wantErr  bool
+	}{
+		{name: "default driver", cfg: config.StorageConfig{}, expected: &LocalStorage{}},
+		{name: "local driver", cfg: config.StorageConfig{Driver: DriverLocal}, expected: &LocalStorage{}},
+		{name: "memory driver keeps files locally", cfg: config.StorageConfig{Driver: DriverMemory}, expected: &LocalStorage{}},
+		{name: "s3 driver", cfg: config.StorageConfig{Driver: DriverS3, S3: config.S3StorageConfig{Bucket: "avatars", Region: "us-east-1"}}, expected: &S3Storage{}},
+		{name: "s3 driver without bucket", cfg: config.StorageConfig{Driver: DriverS3}, wantErr: true},
+		{name: "unknown driver", cfg: config.StorageConfig{Driver: "floppy"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(context.Background(), tt.cfg)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, s)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Isolation strategies selected by tenancy.isolation
+const (
+	// SharedSchema keeps all tenants in the same tables, told apart by
+	// their tenant_id column
+	SharedSchema = "shared_schema"
+
+	// SchemaPerTenant keeps each tenant in its own schema, named
+	// SchemaPrefix followed by the tenant ID
+	SchemaPerTenant = "schema_per_tenant"
+)
+
+// tenantField marks a GORM model as tenant data
+const tenantField = "TenantID"
+
+// scopedClause marks a statement already scoped, so a statement executed
+// twice, such as a Count followed by a Find, is not scoped twice
+const scopedClause = "tenancy:scoped"
+
+// Register scopes every query of db on models with a TenantID field to the
+// tenant in the query's context, and fails those without one with
+// ErrMissingTenant. Raw SQL is not scoped.
+func Register(db *gorm.DB, isolation string) error {
+	switch isolation {
+	case SharedSchema, SchemaPerTenant:
+	default:
+		return fmt.Errorf("unknown tenancy isolation: %q", isolation)
+	}
+
+	return db.Use(&plugin{isolation: isolation})
+}
+
+// SchemaName returns the schema of the tenant with schema per tenant
+// isolation
+func SchemaName(tenant string) string {
+	return SchemaPrefix + tenant
+}
+
+// ForEachTenant runs fn for work that spans tenants, such as background
+// jobs. With a shared schema fn runs once, with an AllTenants context; with
+// a schema per tenant it runs once per tenant schema, with that tenant's
+// context. The errors of all tenants are joined.
+func ForEachTenant(ctx context.Context, db *gorm.DB, isolation string, fn func(ctx context.Context) error) error {
+	if isolation != SchemaPerTenant {
+		return fn(AllTenants(ctx))
+	}
+
+	var schemas []string
+	err := db.WithContext(ctx).
+		Raw(`SELECT schema_name FROM information_schema.schemata WHERE schema_name LIKE ? ORDER BY schema_name`, SchemaPrefix+"%").
+		Scan(&schemas).Error
+	if err != nil {
+		return fmt.Errorf("failed to list tenant schemas: %w", err)
+	}
+
+	var errs []error
+	for _, name := range schemas {
+		tenant := strings.TrimPrefix(name, SchemaPrefix)
+		if Validate(tenant) != nil {
+			continue
+		}
+		if err := fn(WithTenant(ctx, tenant)); err != nil {
+			errs = append(errs, fmt.Errorf("tenant %s: %w", tenant, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// plugin registers the GORM callbacks that scope statements to a tenant
+type plugin struct {
+	isolation string
+}
+
+// Name implements gorm.Plugin
+func (p *plugin) Name() string {
+	return "tenancy"
+}
+
+// Initialize implements gorm.Plugin
+func (p *plugin) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+
+	if err := callbacks.Create().Before("gorm:create").Register("tenancy:create", p.create); err != nil {
+		return err
+	}
+	if err := callbacks.Query().Before("gorm:query").Register("tenancy:query", p.scope); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("tenancy:update", p.update); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("tenancy:delete", p.scope); err != nil {
+		return err
+	}
+	return callbacks.Row().Before("gorm:row").Register("tenancy:row", p.scope)
+}
+
+// tenant returns the tenant a statement on tenant data runs for, and the
+// tenant field of its model. It returns no field for statements on other
+// models and for those that span tenants, and fails the statement when its
+// context has no tenant.
+func (p *plugin) tenant(db *gorm.DB) (string, *schema.Field) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil {
+		return "", nil
+	}
+	field := stmt.Schema.LookUpField(tenantField)
+	if field == nil {
+		return "", nil
+	}
+
+	if tenant, ok := FromContext(stmt.Context); ok {
+		return tenant, field
+	}
+	if SpansTenants(stmt.Context) && p.isolation == SharedSchema {
+		return "", nil
+	}
+
+	_ = db.AddError(fmt.Errorf("%w for %s", ErrMissingTenant, stmt.Table))
+	return "", nil
+}
+
+// scope restricts a statement to the rows of its tenant
+func (p *plugin) scope(db *gorm.DB) {
+	if tenant, field := p.tenant(db); field != nil {
+		p.restrict(db, tenant, field)
+	}
+}
+
+// update scopes an update and keeps the tenant of the rows unchanged
+func (p *plugin) update(db *gorm.DB) {
+	tenant, field := p.tenant(db)
+	if field == nil {
+		return
+	}
+
+	p.restrict(db, tenant, field)
+	db.Statement.Omits = append(db.Statement.Omits, field.DBName)
+}
+
+// restrict limits a statement to the tenant's rows
+func (p *plugin) restrict(db *gorm.DB, tenant string, field *schema.Field) {
+	stmt := db.Statement
+	if _, ok := stmt.Clauses[scopedClause]; ok {
+		return
+	}
+	stmt.Clauses[scopedClause] = clause.Clause{}
+
+	if p.isolation == SchemaPerTenant {
+		p.useSchema(db, tenant)
+		return
+	}
+
+	stmt.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenant},
+	}})
+}
+
+// create stamps new rows with the tenant, refusing rows of another tenant
+func (p *plugin) create(db *gorm.DB) {
+	tenant, field := p.tenant(db)
+	if field == nil {
+		return
+	}
+
+	stmt := db.Statement
+	switch value := stmt.ReflectValue; value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			p.stamp(db, field, value.Index(i), tenant)
+		}
+	case reflect.Struct:
+		p.stamp(db, field, value, tenant)
+	}
+
+	if p.isolation == SchemaPerTenant {
+		p.useSchema(db, tenant)
+	}
+}
+
+// stamp sets the tenant field of a new row
+func (p *plugin) stamp(db *gorm.DB, field *schema.Field, row reflect.Value, tenant string) {
+	ctx := db.Statement.Context
+	current, zero := field.ValueOf(ctx, row)
+	if zero {
+		_ = db.AddError(field.Set(ctx, row, tenant))
+		return
+	}
+	if current != tenant {
+		_ = db.AddError(ErrCrossTenant)
+	}
+}
+
+// useSchema points a statement at the tables in the schema of the tenant
+func (p *plugin) useSchema(db *gorm.DB, tenant string) {
+	stmt := db.Statement
+	schemaName := SchemaName(tenant)
+	if strings.HasPrefix(stmt.Table, schemaName+".") {
+		return
+	}
+	if stmt.TableExpr != nil || strings.Contains(stmt.Table, ".") {
+		_ = db.AddError(fmt.Errorf("tenancy: cannot scope table %q to a tenant schema", stmt.Table))
+		return
+	}
+	stmt.Table = schemaName + "." + stmt.Table
+}
@@ -0,0 +1,176 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// noteModel is tenant data
+type noteModel struct {
+	ID       uint `gorm:"primaryKey"`
+	TenantID string
+	Body     string
+}
+
+func (noteModel) TableName() string {
+	return "notes"
+}
+
+// settingModel is shared by all tenants
+type settingModel struct {
+	ID    uint `gorm:"primaryKey"`
+	Value string
+}
+
+func (settingModel) TableName() string {
+	return "settings"
+}
+
+func setupTestDB(t *testing.T, isolation string) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Each connection to :memory: is a separate database
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(t, db.AutoMigrate(&noteModel{}, &settingModel{}))
+	require.NoError(t, Register(db, isolation))
+	return db
+}
+
+func TestRegister_RejectsUnknownIsolation(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	assert.Error(t, Register(db, "database_per_tenant"))
+}
+
+func TestSharedSchema_ScopesQueriesToTenant(t *testing.T) {
+	db := setupTestDB(t, SharedSchema)
+	acme := WithTenant(context.Background(), "acme")
+	globex := WithTenant(context.Background(), "globex")
+
+	note := noteModel{Body: "acme note"}
+	require.NoError(t, db.WithContext(acme).Create(&note).Error)
+	assert.Equal(t, "acme", note.TenantID)
+	require.NoError(t, db.WithContext(globex).Create(&[]noteModel{{Body: "globex note"}}).Error)
+
+	var notes []noteModel
+	require.NoError(t, db.WithContext(acme).Find(&notes).Error)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "acme note", notes[0].Body)
+
+	var count int64
+	require.NoError(t, db.WithContext(globex).Model(&noteModel{}).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// Another tenant's row is neither found, updated nor deleted
+	err := db.WithContext(globex).First(&noteModel{}, note.ID).Error
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	result := db.WithContext(globex).Model(&noteModel{}).Where("id = ?", note.ID).Update("body", "changed")
+	require.NoError(t, result.Error)
+	assert.Zero(t, result.RowsAffected)
+
+	result = db.WithContext(globex).Delete(&noteModel{}, note.ID)
+	require.NoError(t, result.Error)
+	assert.Zero(t, result.RowsAffected)
+
+	var stored noteModel
+	require.NoError(t, db.WithContext(acme).First(&stored, note.ID).Error)
+	assert.Equal(t, "acme note", stored.Body)
+}
+
+func TestSharedSchema_UpdateKeepsTenant(t *testing.T) {
+	db := setupTestDB(t, SharedSchema)
+	acme := WithTenant(context.Background(), "acme")
+
+	note := noteModel{Body: "note"}
+	require.NoError(t, db.WithContext(acme).Create(&note).Error)
+
+	note.TenantID = "globex"
+	note.Body = "saved"
+	require.NoError(t, db.WithContext(acme).Save(&note).Error)
+
+	var stored noteModel
+	require.NoError(t, db.WithContext(acme).First(&stored, note.ID).Error)
+	assert.Equal(t, "acme", stored.TenantID)
+	assert.Equal(t, "saved", stored.Body)
+}
+
+func TestSharedSchema_RefusesQueriesWithoutTenant(t *testing.T) {
+	db := setupTestDB(t, SharedSchema)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, db.WithContext(ctx).Create(&noteModel{Body: "note"}).Error, ErrMissingTenant)
+	assert.ErrorIs(t, db.WithContext(ctx).Find(&[]noteModel{}).Error, ErrMissingTenant)
+	assert.ErrorIs(t, db.WithContext(ctx).Model(&noteModel{}).Where("id = 1").Update("body", "x").Error, ErrMissingTenant)
+	assert.ErrorIs(t, db.WithContext(ctx).Delete(&noteModel{}, 1).Error, ErrMissingTenant)
+
+	// Models without a tenant field are not scoped
+	assert.NoError(t, db.WithContext(ctx).Create(&settingModel{Value: "x"}).Error)
+	assert.NoError(t, db.WithContext(ctx).Find(&[]settingModel{}).Error)
+}
+
+func TestSharedSchema_RefusesRowsOfAnotherTenant(t *testing.T) {
+	db := setupTestDB(t, SharedSchema)
+
+	err := db.WithContext(WithTenant(context.Background(), "acme")).
+		Create(&noteModel{TenantID: "globex", Body: "note"}).Error
+	assert.ErrorIs(t, err, ErrCrossTenant)
+}
+
+func TestSharedSchema_AllTenants(t *testing.T) {
+	db := setupTestDB(t, SharedSchema)
+	ctx := context.Background()
+	require.NoError(t, db.WithContext(WithTenant(ctx, "acme")).Create(&noteModel{Body: "a"}).Error)
+	require.NoError(t, db.WithContext(WithTenant(ctx, "globex")).Create(&noteModel{Body: "b"}).Error)
+
+	var count int64
+	require.NoError(t, db.WithContext(AllTenants(ctx)).Model(&noteModel{}).Count(&count).Error)
+	assert.Equal(t, int64(2), count)
+
+	calls := 0
+	err := ForEachTenant(ctx, db, SharedSchema, func(ctx context.Context) error {
+		calls++
+		return db.WithContext(ctx).Model(&noteModel{}).Count(&count).Error
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestSchemaPerTenant_UsesTenantSchema(t *testing.T) {
+	db := setupTestDB(t, SchemaPerTenant)
+	ctx := context.Background()
+
+	// SQLite stands in for PostgreSQL schemas with attached databases
+	require.NoError(t, db.Exec(`ATTACH DATABASE ':memory:' AS tenant_acme`).Error)
+	require.NoError(t, db.Exec(`CREATE TABLE tenant_acme.notes (id INTEGER PRIMARY KEY, tenant_id TEXT, body TEXT)`).Error)
+
+	acme := WithTenant(ctx, "acme")
+	require.NoError(t, db.WithContext(acme).Create(&noteModel{Body: "acme note"}).Error)
+
+	var notes []noteModel
+	require.NoError(t, db.WithContext(acme).Find(&notes).Error)
+	require.Len(t, notes, 1)
+	assert.Equal(t, "acme note", notes[0].Body)
+
+	// The shared table is untouched
+	var count int64
+	require.NoError(t, db.Raw(`SELECT COUNT(*) FROM main.notes`).Scan(&count).Error)
+	assert.Zero(t, count)
+
+	// Spanning tenants is refused: each schema must be visited in turn
+	err := db.WithContext(AllTenants(ctx)).Find(&notes).Error
+	assert.ErrorIs(t, err, ErrMissingTenant)
+}
@@ -0,0 +1,73 @@
+package tenancy
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+)
+
+// Options configures the tenancy middleware
+type Options struct {
+	// ExemptPaths are path prefixes served without a tenant, such as health
+	// checks and metrics
+	ExemptPaths []string
+}
+
+// Middleware resolves the tenant of each request into its context. Requests
+// without a valid tenant are rejected, except on exempt paths.
+func Middleware(resolver Resolver, opts Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if exempt(c.Request.URL.Path, opts.ExemptPaths) {
+			c.Next()
+			return
+		}
+
+		tenant, err := resolver.Resolve(c.Request)
+		if errors.Is(err, ErrInvalidToken) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "invalid bearer token",
+				"code":  problem.CodeUnauthenticated,
+			})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "invalid tenant",
+				"code":  problem.CodeValidationFailed,
+			})
+			return
+		}
+
+		if tenant == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "tenant required",
+				"code":  problem.CodeValidationFailed,
+			})
+			return
+		}
+		if err := Validate(tenant); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "invalid tenant",
+				"code":  problem.CodeValidationFailed,
+			})
+			return
+		}
+
+		c.Request = c.Request.WithContext(WithTenant(c.Request.Context(), tenant))
+		c.Next()
+	}
+}
+
+// exempt reports whether path starts with one of the prefixes
+func exempt(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package tenancy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupRouter returns a router that answers with the tenant of the request
+func setupRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	handler := func(c *gin.Context) {
+		tenant, _ := FromContext(c.Request.Context())
+		c.String(http.StatusOK, tenant)
+	}
+
+	router := gin.New()
+	router.Use(Middleware(HeaderResolver{Header: "X-Tenant-ID"}, Options{ExemptPaths: []string{"/health/"}}))
+	router.GET("/items", handler)
+	router.GET("/health/live", handler)
+	return router
+}
+
+func TestMiddleware(t *testing.T) {
+	router := setupRouter()
+
+	tests := []struct {
+		name       string
+		path       string
+		tenant     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "tenant in context", path: "/items", tenant: "acme", wantStatus: http.StatusOK, wantBody: "acme"},
+		{name: "missing tenant", path: "/items", wantStatus: http.StatusBadRequest, wantBody: "tenant required"},
+		{name: "invalid tenant", path: "/items", tenant: "../acme", wantStatus: http.StatusBadRequest, wantBody: "invalid tenant"},
+		{name: "exempt path", path: "/health/live", wantStatus: http.StatusOK, wantBody: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant-ID", tt.tenant)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestMiddleware_RejectsInvalidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Middleware(JWTResolver{Claim: "tenant_id", Secret: []byte("secret")}, Options{}))
+	router.GET("/items", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,117 @@
+package tenancy
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+// Resolver methods selected by tenancy.resolver
+const (
+	ResolverHeader    = "header"
+	ResolverSubdomain = "subdomain"
+	ResolverJWT       = "jwt"
+)
+
+// ErrInvalidToken is returned by JWTResolver for bearer tokens that fail
+// verification
+var ErrInvalidToken = errors.New("invalid bearer token")
+
+// Resolver finds the tenant a request is made for. It returns an empty ID
+// when the request names no tenant.
+type Resolver interface {
+	Resolve(r *http.Request) (string, error)
+}
+
+// NewResolver returns the resolver selected by cfg.Resolver
+func NewResolver(cfg config.TenancyConfig) (Resolver, error) {
+	switch cfg.Resolver {
+	case ResolverHeader, "":
+		if cfg.Header == "" {
+			return nil, errors.New("tenancy header resolver requires tenancy.header")
+		}
+		return HeaderResolver{Header: cfg.Header}, nil
+	case ResolverSubdomain:
+		if cfg.Domain == "" {
+			return nil, errors.New("tenancy subdomain resolver requires tenancy.domain")
+		}
+		return SubdomainResolver{Domain: strings.ToLower(cfg.Domain)}, nil
+	case ResolverJWT:
+		if cfg.JWT.Secret == "" || cfg.JWT.Claim == "" {
+			return nil, errors.New("tenancy jwt resolver requires tenancy.jwt.secret and tenancy.jwt.claim")
+		}
+		return JWTResolver{Claim: cfg.JWT.Claim, Secret: []byte(cfg.JWT.Secret)}, nil
+	default:
+		return nil, fmt.Errorf("unknown tenancy resolver: %q", cfg.Resolver)
+	}
+}
+
+// HeaderResolver takes the tenant from a request header. Use it behind a
+// gateway that authenticates the caller and sets the header.
+type HeaderResolver struct {
+	Header string
+}
+
+// Resolve returns the value of the header
+func (h HeaderResolver) Resolve(r *http.Request) (string, error) {
+	return r.Header.Get(h.Header), nil
+}
+
+// SubdomainResolver takes the tenant from the host, as in
+// acme.example.com for the tenant acme under the domain example.com
+type SubdomainResolver struct {
+	Domain string
+}
+
+// Resolve returns the label of the host below the domain
+func (s SubdomainResolver) Resolve(r *http.Request) (string, error) {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(host)
+
+	tenant, ok := strings.CutSuffix(host, "."+s.Domain)
+	if !ok {
+		return "", nil
+	}
+	if strings.Contains(tenant, ".") {
+		return "", ErrInvalidTenant
+	}
+	return tenant, nil
+}
+
+// JWTResolver takes the tenant from a claim of the HMAC signed bearer token
+// of the request
+type JWTResolver struct {
+	Claim  string
+	Secret []byte
+}
+
+// Resolve verifies the bearer token and returns its tenant claim
+func (j JWTResolver) Resolve(r *http.Request) (string, error) {
+	raw, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return "", nil
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(*jwt.Token) (any, error) {
+		return j.Secret, nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	tenant, ok := claims[j.Claim].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: claim %q is not a string", ErrInvalidToken, j.Claim)
+	}
+	return tenant, nil
+}
@@ -0,0 +1,129 @@
+package tenancy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+)
+
+func TestNewResolver(t *testing.T) {
+	resolver, err := NewResolver(config.TenancyConfig{Resolver: ResolverHeader, Header: "X-Tenant-ID"})
+	require.NoError(t, err)
+	assert.Equal(t, HeaderResolver{Header: "X-Tenant-ID"}, resolver)
+
+	resolver, err = NewResolver(config.TenancyConfig{Resolver: ResolverSubdomain, Domain: "Example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, SubdomainResolver{Domain: "example.com"}, resolver)
+
+	_, err = NewResolver(config.TenancyConfig{Resolver: ResolverSubdomain})
+	assert.Error(t, err)
+
+	_, err = NewResolver(config.TenancyConfig{Resolver: ResolverJWT, JWT: config.TenancyJWTConfig{Claim: "tenant_id"}})
+	assert.Error(t, err)
+
+	_, err = NewResolver(config.TenancyConfig{Resolver: "cookie"})
+	assert.Error(t, err)
+}
+
+func TestHeaderResolver(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Tenant-ID", "acme")
+
+	tenant, err := HeaderResolver{Header: "X-Tenant-ID"}.Resolve(req)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", tenant)
+}
+
+func TestSubdomainResolver(t *testing.T) {
+	resolver := SubdomainResolver{Domain: "example.com"}
+
+	tests := []struct {
+		host    string
+		want    string
+		wantErr error
+	}{
+		{host: "acme.example.com", want: "acme"},
+		{host: "ACME.example.com:8080", want: "acme"},
+		{host: "example.com", want: ""},
+		{host: "acme.other.com", want: ""},
+		{host: "a.b.example.com", wantErr: ErrInvalidTenant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Host = tt.host
+
+			tenant, err := resolver.Resolve(req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tenant)
+		})
+	}
+}
+
+// signToken returns a bearer token for claims signed with secret
+func signToken(t *testing.T, method jwt.SigningMethod, claims jwt.MapClaims, secret []byte) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(secret)
+	require.NoError(t, err)
+	return token
+}
+
+func TestJWTResolver(t *testing.T) {
+	secret := []byte("test-secret")
+	resolver := JWTResolver{Claim: "tenant_id", Secret: secret}
+
+	resolve := func(authorization string) (string, error) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		return resolver.Resolve(req)
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		tenant, err := resolve("Bearer " + signToken(t, jwt.SigningMethodHS256, jwt.MapClaims{"tenant_id": "acme"}, secret))
+		require.NoError(t, err)
+		assert.Equal(t, "acme", tenant)
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		tenant, err := resolve("")
+		require.NoError(t, err)
+		assert.Empty(t, tenant)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, err := resolve("Bearer " + signToken(t, jwt.SigningMethodHS256, jwt.MapClaims{"tenant_id": "acme"}, []byte("other")))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		_, err := resolve("Bearer " + signToken(t, jwt.SigningMethodHS256, jwt.MapClaims{"tenant_id": "acme", "exp": 1}, secret))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("claim not a string", func(t *testing.T) {
+		_, err := resolve("Bearer " + signToken(t, jwt.SigningMethodHS256, jwt.MapClaims{"tenant_id": 7}, secret))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"tenant_id": "acme"}).
+			SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = resolve("Bearer " + token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
@@ -0,0 +1,73 @@
+// Package tenancy isolates the data of tenants sharing one deployment. A
+// middleware resolves the tenant of each request into its context, and a
+// GORM plugin scopes the queries on tenant models to that tenant, either by
+// a tenant_id column or by a schema per tenant. Queries on tenant models
+// without a tenant fail rather than read or write across tenants.
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// SchemaPrefix starts the schema name of each tenant with schema per tenant
+// isolation
+const SchemaPrefix = "tenant_"
+
+var (
+	// ErrMissingTenant is returned for queries on tenant data whose context
+	// carries no tenant
+	ErrMissingTenant = errors.New("tenant context required")
+
+	// ErrInvalidTenant is returned for tenant IDs that are not lowercase
+	// letters, digits, '-' or '_', or are longer than 56 characters
+	ErrInvalidTenant = errors.New("invalid tenant")
+
+	// ErrCrossTenant is returned when a row of one tenant is written in the
+	// context of another
+	ErrCrossTenant = errors.New("row belongs to another tenant")
+)
+
+// tenantRegex keeps SchemaPrefix plus an ID within PostgreSQL's 63 byte
+// identifier limit
+var tenantRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,55}$`)
+
+type contextKey int
+
+const (
+	tenantKey contextKey = iota
+	allTenantsKey
+)
+
+// Validate returns ErrInvalidTenant unless id is a valid tenant ID
+func Validate(id string) error {
+	if !tenantRegex.MatchString(id) {
+		return ErrInvalidTenant
+	}
+	return nil
+}
+
+// WithTenant returns a context for work on behalf of the tenant
+func WithTenant(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey, id)
+}
+
+// FromContext returns the tenant of ctx
+func FromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(tenantKey).(string)
+	return id, ok && id != ""
+}
+
+// AllTenants returns a context for work that deliberately spans tenants,
+// such as purging deleted users. Prefer ForEachTenant, which also works
+// with a schema per tenant.
+func AllTenants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allTenantsKey, true)
+}
+
+// SpansTenants reports whether ctx was returned by AllTenants
+func SpansTenants(ctx context.Context) bool {
+	all, _ := ctx.Value(allTenantsKey).(bool)
+	return all
+}
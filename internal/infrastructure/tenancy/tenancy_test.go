@@ -0,0 +1,37 @@
+package tenancy
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []string{"acme", "acme-corp", "tenant_1", "7", strings.Repeat("a", 56)}
+	for _, id := range valid {
+		assert.NoError(t, Validate(id), id)
+	}
+
+	invalid := []string{"", "Acme", "-acme", "acme.corp", "acme corp", "a'b", strings.Repeat("a", 57)}
+	for _, id := range invalid {
+		assert.ErrorIs(t, Validate(id), ErrInvalidTenant, id)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+
+	_, ok := FromContext(ctx)
+	assert.False(t, ok)
+	_, ok = FromContext(WithTenant(ctx, ""))
+	assert.False(t, ok)
+
+	tenant, ok := FromContext(WithTenant(ctx, "acme"))
+	assert.True(t, ok)
+	assert.Equal(t, "acme", tenant)
+
+	assert.False(t, SpansTenants(ctx))
+	assert.True(t, SpansTenants(AllTenants(ctx)))
+}
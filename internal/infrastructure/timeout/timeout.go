@@ -0,0 +1,105 @@
+package timeout
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// Options configures the timeout middleware
+type Options struct {
+	// Default is the deadline applied to every request. Zero disables it.
+	Default time.Duration
+
+	// Routes overrides the deadline for route groups, keyed by path prefix
+	// such as "/users/import". The longest matching prefix wins, and a zero
+	// duration disables the deadline for the group.
+	Routes map[string]time.Duration
+}
+
+// For returns the deadline that applies to a route path
+func (o Options) For(path string) time.Duration {
+	if prefix, ok := request.MatchPrefix(o.Routes, path); ok {
+		return o.Routes[prefix]
+	}
+	return o.Default
+}
+
+// Middleware bounds the work done for each request with a context deadline.
+// Handlers and the repositories they call see the deadline through the
+// request context, so database queries are cancelled once it passes. A
+// response written after the deadline is replaced by a 504 problem.
+// WebSocket connections and event streams get no deadline.
+func Middleware(opts Options) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d := opts.For(request.RoutePath(c))
+		if d <= 0 || request.IsStreaming(c.Request) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+
+		writer := &timeoutWriter{ResponseWriter: c.Writer, ctx: ctx, path: c.Request.URL.Path}
+		c.Writer = writer
+
+		c.Next()
+
+		// Handlers that gave up without writing anything still get a response
+		writer.expired()
+	}
+}
+
+// timeoutWriter swaps the handler's response for a 504 problem when the
+// deadline has passed before the response is written
+type timeoutWriter struct {
+	gin.ResponseWriter
+	ctx      context.Context
+	path     string
+	timedOut bool
+}
+
+// expired writes the 504 problem on the first write after the deadline and
+// reports whether the handler's output must be discarded
+func (w *timeoutWriter) expired() bool {
+	if w.timedOut {
+		return true
+	}
+	if w.ResponseWriter.Written() || !errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+
+	w.timedOut = true
+	details := problem.New(http.StatusGatewayTimeout, "the request took too long to process")
+	details.Instance = w.path
+	_ = problem.Write(w.ResponseWriter, details)
+	return true
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	if w.expired() {
+		return
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	if w.expired() {
+		return len(data), nil
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	if w.expired() {
+		return len(s), nil
+	}
+	return w.ResponseWriter.WriteString(s)
+}
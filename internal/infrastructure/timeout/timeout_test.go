@@ -0,0 +1,144 @@
+package timeout
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+)
+
+// waitForDeadline blocks until the request deadline passes, like a handler
+// waiting on a cancelled database query, then reports the error
+func waitForDeadline(c *gin.Context) {
+	<-c.Request.Context().Done()
+	c.JSON(http.StatusInternalServerError, gin.H{"error": c.Request.Context().Err().Error()})
+}
+
+func setupRouter(opts Options) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Middleware(opts))
+	router.GET("/users/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+	router.GET("/slow", waitForDeadline)
+	router.GET("/silent", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+	})
+	router.GET("/deadline", func(c *gin.Context) {
+		_, ok := c.Request.Context().Deadline()
+		c.JSON(http.StatusOK, gin.H{"deadline": ok})
+	})
+	return router
+}
+
+func serve(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func TestOptions_For(t *testing.T) {
+	opts := Options{
+		Default: 5 * time.Second,
+		Routes: map[string]time.Duration{
+			"/users":        10 * time.Second,
+			"/users/export": 0,
+			"/health/":      time.Second,
+		},
+	}
+
+	tests := []struct {
+		path string
+		want time.Duration
+	}{
+		{"/organizations", 5 * time.Second},
+		{"/users", 10 * time.Second},
+		{"/users/:id", 10 * time.Second},
+		{"/usersx", 5 * time.Second},
+		{"/users/export", 0},
+		{"/health/live", time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, opts.For(tt.path))
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	router := setupRouter(Options{
+		Default: 20 * time.Millisecond,
+		Routes:  map[string]time.Duration{"/health": time.Second},
+	})
+
+	t.Run("fast request is untouched", func(t *testing.T) {
+		w := serve(router, "/users/1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":"1"}`, w.Body.String())
+	})
+
+	t.Run("handler error after deadline becomes 504", func(t *testing.T) {
+		w := serve(router, "/slow")
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+		var details problem.Details
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &details))
+		assert.Equal(t, http.StatusGatewayTimeout, details.Status)
+		assert.Equal(t, "Gateway Timeout", details.Title)
+		assert.Equal(t, "/slow", details.Instance)
+	})
+
+	t.Run("handler writing nothing gets 504", func(t *testing.T) {
+		w := serve(router, "/silent")
+
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+	})
+
+	t.Run("request context carries the deadline", func(t *testing.T) {
+		w := serve(router, "/deadline")
+
+		assert.JSONEq(t, `{"deadline":true}`, w.Body.String())
+	})
+}
+
+func TestMiddleware_Disabled(t *testing.T) {
+	router := setupRouter(Options{
+		Default: 20 * time.Millisecond,
+		Routes:  map[string]time.Duration{"/deadline": 0},
+	})
+
+	w := serve(router, "/deadline")
+
+	assert.JSONEq(t, `{"deadline":false}`, w.Body.String())
+}
+
+func TestMiddleware_SkipsStreams(t *testing.T) {
+	router := setupRouter(Options{Default: 20 * time.Millisecond})
+
+	r := httptest.NewRequest(http.MethodGet, "/deadline", nil)
+	r.Header.Set("Connection", "Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.JSONEq(t, `{"deadline":false}`, w.Body.String())
+
+	r = httptest.NewRequest(http.MethodGet, "/deadline", nil)
+	r.Header.Set("Accept", "text/event-stream")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	assert.JSONEq(t, `{"deadline":false}`, w.Body.String(), "event streams get no deadline either")
+}
@@ -0,0 +1,108 @@
+// Package tracecontext carries the W3C trace context, the traceparent and
+// tracestate headers, through the service: it is extracted from incoming
+// HTTP requests and gRPC calls, injected into outbound HTTP and gRPC calls
+// and stamped on published events, and the logger adds its trace and span
+// IDs to log lines, so traces stitch across services built from the
+// scaffold.
+//
+// Without a configured exporter spans record nothing and keep the incoming
+// trace context; requests arriving without one get a new, unsampled trace
+// ID, so the logs of the calls they make can still be tied together.
+package tracecontext
+
+import (
+	"context"
+	"crypto/rand"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// instrumentationName names the tracer of the HTTP middleware
+const instrumentationName = "github.com/yourusername/go-scaffolding/internal/infrastructure/tracecontext"
+
+// Propagator reads and writes the W3C traceparent, tracestate and baggage
+// headers. It is used explicitly so propagation does not depend on the
+// global propagator having been configured.
+var Propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Middleware continues the trace of each request from its traceparent and
+// tracestate headers in a server span named after its route, and stores it
+// in the request context for the handlers, outbound calls and log lines of
+// the request. Spans are started with provider.
+func Middleware(provider trace.TracerProvider) gin.HandlerFunc {
+	tracer := provider.Tracer(instrumentationName)
+	return func(c *gin.Context) {
+		ctx := Propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		route := request.RoutePath(c)
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRoute(route), semconv.HTTPRequestMethodKey.String(c.Request.Method)),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(Ensure(ctx))
+		c.Next()
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(c.Writer.Status()))
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(c.Writer.Status()))
+		}
+	}
+}
+
+// Ensure returns ctx when it carries a trace, and otherwise a copy carrying
+// a new, unsampled one. It is called after starting a span, so a
+// configured tracer provider makes its own sampling decision.
+func Ensure(ctx context.Context) context.Context {
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	_, _ = rand.Read(traceID[:])
+	_, _ = rand.Read(spanID[:])
+	return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+}
+
+// Inject returns the trace context of ctx as headers, such as
+// "traceparent", or nil when ctx carries none. Published events carry
+// them so subscribers continue the trace.
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	Propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns a copy of ctx continuing the trace context headers
+// returned by Inject
+func Extract(ctx context.Context, headers map[string]string) context.Context {
+	return Propagator.Extract(ctx, propagation.MapCarrier(headers))
+}
+
+// DialOption makes a gRPC client connection trace its calls and send the
+// trace context of each call to the server
+func DialOption() grpc.DialOption {
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler(otelgrpc.WithPropagators(Propagator)))
+}
+
+// ServerOption makes a gRPC server continue the trace context sent by
+// clients
+func ServerOption() grpc.ServerOption {
+	return grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithPropagators(Propagator)))
+}
@@ -0,0 +1,129 @@
+package tracecontext
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+// setupRouter serves a route answering with the trace ID of its context
+// and the traceparent it sends downstream
+func setupRouter(downstream string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	client := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport, otelhttp.WithPropagators(Propagator))}
+
+	router := gin.New()
+	router.Use(Middleware(otel.GetTracerProvider()))
+	router.GET("/users/:id", func(c *gin.Context) {
+		req, _ := http.NewRequestWithContext(c.Request.Context(), http.MethodGet, downstream, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			c.Status(http.StatusBadGateway)
+			return
+		}
+		resp.Body.Close()
+
+		c.JSON(http.StatusOK, gin.H{
+			"trace_id":    trace.SpanContextFromContext(c.Request.Context()).TraceID().String(),
+			"traceparent": resp.Header.Get("X-Received-Traceparent"),
+		})
+	})
+	return router
+}
+
+// downstreamServer echoes the traceparent it receives
+func downstreamServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Received-Traceparent", r.Header.Get("traceparent"))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func serve(router *gin.Engine, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	router := setupRouter(downstreamServer(t).URL)
+
+	w := serve(router, map[string]string{"traceparent": traceparent, "tracestate": "vendor=value"})
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"trace_id":"`+traceID+`","traceparent":"`+traceparent+`"}`, w.Body.String())
+}
+
+func TestMiddleware_StartsTraceWithoutOne(t *testing.T) {
+	router := setupRouter(downstreamServer(t).URL)
+
+	first := serve(router, nil).Body.String()
+	second := serve(router, nil).Body.String()
+
+	assert.Regexp(t, `"trace_id":"[0-9a-f]{32}"`, first)
+	assert.Regexp(t, `"traceparent":"00-[0-9a-f]{32}-[0-9a-f]{16}-00"`, first, "the new trace is sent downstream")
+	assert.NotEqual(t, first, second)
+}
+
+func TestInjectExtract(t *testing.T) {
+	assert.Nil(t, Inject(context.Background()))
+
+	ctx := Propagator.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceparent})
+	headers := Inject(ctx)
+	assert.Equal(t, traceparent, headers["traceparent"])
+	assert.Equal(t, traceID, trace.SpanContextFromContext(Extract(context.Background(), headers)).TraceID().String())
+}
+
+func TestDialOption(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	received := make(chan trace.SpanContext, 1)
+	server := grpc.NewServer(ServerOption(), grpc.UnaryInterceptor(
+		func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			received <- trace.SpanContextFromContext(ctx)
+			return handler(ctx, req)
+		},
+	))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		DialOption(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	ctx := Propagator.Extract(context.Background(), propagation.MapCarrier{"traceparent": traceparent})
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	assert.Equal(t, traceID, (<-received).TraceID().String())
+}
@@ -0,0 +1,167 @@
+// Package tracing exports the spans started by the HTTP, gRPC and outbound
+// client instrumentation to a tracing backend. The exporter, OTLP over gRPC
+// or HTTP, Jaeger or Zipkin, and the sampling of new traces are chosen by
+// configuration; without an exporter nothing is recorded.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/zipkin"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+)
+
+// Exporters
+const (
+	// ExporterNone records no spans
+	ExporterNone = "none"
+
+	// ExporterOTLPGRPC sends spans with OTLP over gRPC, by default to
+	// http://localhost:4317
+	ExporterOTLPGRPC = "otlp_grpc"
+
+	// ExporterOTLPHTTP sends spans with OTLP over HTTP, by default to
+	// http://localhost:4318/v1/traces
+	ExporterOTLPHTTP = "otlp_http"
+
+	// ExporterJaeger sends spans to the OTLP over HTTP receiver of a
+	// Jaeger collector, which has replaced its own protocol
+	ExporterJaeger = "jaeger"
+
+	// ExporterZipkin sends spans to the Zipkin v2 API, by default at
+	// http://localhost:9411/api/v2/spans
+	ExporterZipkin = "zipkin"
+)
+
+// defaultEndpoints are the endpoints of exporters configured without one
+var defaultEndpoints = map[string]string{
+	ExporterOTLPGRPC: "http://localhost:4317",
+	ExporterOTLPHTTP: "http://localhost:4318/v1/traces",
+	ExporterJaeger:   "http://localhost:4318/v1/traces",
+	ExporterZipkin:   "http://localhost:9411/api/v2/spans",
+}
+
+// Options configures the export of spans
+type Options struct {
+	// Exporter is one of the Exporter constants; empty is ExporterNone
+	Exporter string
+
+	// Endpoint is the URL spans are sent to. An http:// URL sends them
+	// without TLS. Empty uses the default of the exporter.
+	Endpoint string
+
+	// Headers are sent with every export, such as API keys of hosted
+	// backends
+	Headers map[string]string
+
+	// ServiceName and Environment describe the process in every span
+	ServiceName string
+	Environment string
+
+	Sampling Sampling
+}
+
+// Sampling decides which new traces are recorded. Spans continuing a trace
+// follow the decision of their parent, so a trace is recorded whole or not
+// at all.
+type Sampling struct {
+	// Ratio is the share of new traces recorded, from 0 to 1
+	Ratio float64
+
+	// Routes overrides Ratio for the routes under a path prefix, such as
+	// "/health" or "/users"; the longest matching prefix wins
+	Routes map[string]float64
+}
+
+// NewProvider creates the tracer provider exporting spans as opts says. It
+// returns nil for ExporterNone. The provider must be shut down to flush the
+// spans still buffered.
+func NewProvider(ctx context.Context, opts Options) (*sdktrace.TracerProvider, error) {
+	if opts.Exporter == "" || opts.Exporter == ExporterNone {
+		return nil, nil
+	}
+
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", opts.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(opts.ServiceName),
+		semconv.DeploymentEnvironmentNameKey.String(opts.Environment),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe the service: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(NewSampler(opts.Sampling)),
+	), nil
+}
+
+// newExporter creates the exporter named by opts
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	endpoint := opts.Endpoint
+	if endpoint == "" {
+		endpoint = defaultEndpoints[opts.Exporter]
+	}
+
+	switch opts.Exporter {
+	case ExporterOTLPGRPC:
+		return otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint), otlptracegrpc.WithHeaders(opts.Headers))
+	case ExporterOTLPHTTP, ExporterJaeger:
+		return otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint), otlptracehttp.WithHeaders(opts.Headers))
+	case ExporterZipkin:
+		return zipkin.New(endpoint, zipkin.WithHeaders(opts.Headers))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter: %q", opts.Exporter)
+	}
+}
+
+// NewSampler returns the parent-based sampler of s: new traces are
+// recorded at the ratio of the route of their first span, and spans
+// continuing a trace follow their parent
+func NewSampler(s Sampling) sdktrace.Sampler {
+	routes := make(map[string]sdktrace.Sampler, len(s.Routes))
+	for prefix, ratio := range s.Routes {
+		routes[prefix] = sdktrace.TraceIDRatioBased(ratio)
+	}
+
+	return sdktrace.ParentBased(routeSampler{
+		fallback: sdktrace.TraceIDRatioBased(s.Ratio),
+		routes:   routes,
+	})
+}
+
+// routeSampler samples root spans at the ratio of their http.route
+type routeSampler struct {
+	fallback sdktrace.Sampler
+	routes   map[string]sdktrace.Sampler
+}
+
+// ShouldSample implements sdktrace.Sampler
+func (s routeSampler) ShouldSample(params sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	for _, attr := range params.Attributes {
+		if attr.Key != semconv.HTTPRouteKey {
+			continue
+		}
+		if prefix, ok := request.MatchPrefix(s.routes, attr.Value.AsString()); ok {
+			return s.routes[prefix].ShouldSample(params)
+		}
+	}
+	return s.fallback.ShouldSample(params)
+}
+
+// Description implements sdktrace.Sampler
+func (s routeSampler) Description() string {
+	return "RouteSampler{" + s.fallback.Description() + "}"
+}
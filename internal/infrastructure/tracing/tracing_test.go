@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record starts and ends a root span for each route and returns the routes
+// of the spans recorded
+func record(t *testing.T, s Sampling, routes ...string) []string {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter), sdktrace.WithSampler(NewSampler(s)))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	tracer := provider.Tracer("test")
+	for _, route := range routes {
+		_, span := tracer.Start(context.Background(), "GET "+route, trace.WithAttributes(semconv.HTTPRoute(route)))
+		span.End()
+	}
+
+	var recorded []string
+	for _, span := range exporter.GetSpans() {
+		recorded = append(recorded, span.Name)
+	}
+	return recorded
+}
+
+func TestNewSampler(t *testing.T) {
+	s := Sampling{Ratio: 1, Routes: map[string]float64{"/health": 0, "/users/export": 1}}
+
+	recorded := record(t, s, "/health", "/health/ready", "/users/:id", "/metrics")
+
+	assert.Equal(t, []string{"GET /users/:id", "GET /metrics"}, recorded)
+}
+
+func TestNewSampler_LongestPrefixWins(t *testing.T) {
+	s := Sampling{Ratio: 1, Routes: map[string]float64{"/users": 0, "/users/export": 1}}
+
+	recorded := record(t, s, "/users/:id", "/users/export")
+
+	assert.Equal(t, []string{"GET /users/export"}, recorded)
+}
+
+func TestNewSampler_FollowsParent(t *testing.T) {
+	sampler := NewSampler(Sampling{Ratio: 0})
+	traceID := trace.TraceID{1}
+
+	for _, sampled := range []bool{true, false} {
+		var flags trace.TraceFlags
+		if sampled {
+			flags = trace.FlagsSampled
+		}
+		parent := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     trace.SpanID{1},
+			TraceFlags: flags,
+		}))
+
+		result := sampler.ShouldSample(sdktrace.SamplingParameters{ParentContext: parent, TraceID: traceID, Name: "GET /users"})
+
+		assert.Equal(t, sampled, result.Decision == sdktrace.RecordAndSample, "sampled parent: %v", sampled)
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(context.Background(), Options{Exporter: ExporterNone})
+	require.NoError(t, err)
+	assert.Nil(t, provider, "nothing is exported without an exporter")
+
+	for _, exporter := range []string{ExporterOTLPGRPC, ExporterOTLPHTTP, ExporterJaeger, ExporterZipkin} {
+		provider, err := NewProvider(context.Background(), Options{Exporter: exporter, ServiceName: "go-scaffolding"})
+		require.NoError(t, err, exporter)
+		require.NotNil(t, provider, exporter)
+		// Nothing was recorded, so nothing is sent to the missing collector
+		assert.NoError(t, provider.Shutdown(context.Background()), exporter)
+	}
+
+	_, err = NewProvider(context.Background(), Options{Exporter: "datadog"})
+	assert.EqualError(t, err, `failed to create datadog trace exporter: unknown trace exporter: "datadog"`)
+}
@@ -0,0 +1,124 @@
+// Package transport decouples HTTP adapters from the router serving them.
+// Adapters register plain net/http handlers on a Router, reading path
+// parameters with (*http.Request).PathValue, and the application picks the
+// router: gin, which serves every other route, or chi. The server stays a
+// gin engine either way: chi routers are mounted on it, behind its
+// middleware.
+package transport
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+)
+
+// Routers that can serve the adapters
+const (
+	RouterGin = "gin"
+	RouterChi = "chi"
+)
+
+// Router registers the handlers of an adapter. Patterns are paths whose
+// {name} segments match any one segment, such as "/users/{id}"; routes on
+// the same path prefer static segments over wildcards.
+type Router interface {
+	Handle(method, pattern string, handler http.HandlerFunc)
+}
+
+// wildcard matches a {name} segment of a pattern
+var wildcard = regexp.MustCompile(`\{(\w+)\}`)
+
+// GinPattern converts a pattern to gin's syntax, so "/users/{id}" becomes
+// "/users/:id"
+func GinPattern(pattern string) string {
+	return wildcard.ReplaceAllString(pattern, ":$1")
+}
+
+// Gin returns a Router registering the handlers on gin routes
+func Gin(routes gin.IRoutes) Router {
+	return ginRouter{routes: routes}
+}
+
+// ginRouter registers handlers on gin routes
+type ginRouter struct {
+	routes gin.IRoutes
+}
+
+// Handle implements Router
+func (g ginRouter) Handle(method, pattern string, handler http.HandlerFunc) {
+	g.routes.Handle(method, GinPattern(pattern), func(c *gin.Context) {
+		for _, param := range c.Params {
+			c.Request.SetPathValue(param.Key, param.Value)
+		}
+		handler(c.Writer, c.Request)
+	})
+}
+
+// Chi returns a Router registering the handlers on a chi router
+func Chi(mux chi.Router) Router {
+	return chiRouter{mux: mux}
+}
+
+// chiRouter registers handlers on a chi router
+type chiRouter struct {
+	mux chi.Router
+}
+
+// Handle implements Router
+func (m chiRouter) Handle(method, pattern string, handler http.HandlerFunc) {
+	m.mux.MethodFunc(method, pattern, func(w http.ResponseWriter, r *http.Request) {
+		params := chi.RouteContext(r.Context()).URLParams
+		for i, key := range params.Keys {
+			r.SetPathValue(key, params.Values[i])
+		}
+		handler(w, r)
+	})
+}
+
+// Mount serves the routes of mux under group. Each route is registered on
+// gin with the same method and pattern and handed to mux, so the
+// middleware of the group runs and sees the route pattern as it does for
+// gin's own routes. The base path of the group is stripped before mux
+// routes the request. Like gin on conflicting routes, it panics on routes
+// gin cannot serve, such as catch-all ones.
+func Mount(group *gin.RouterGroup, mux chi.Router) {
+	var handler http.Handler = mux
+	if base := strings.TrimSuffix(group.BasePath(), "/"); base != "" {
+		handler = http.StripPrefix(base, mux)
+	}
+	serve := gin.WrapH(handler)
+
+	_ = chi.Walk(mux, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.Contains(route, "*") {
+			panic(fmt.Sprintf("transport: cannot mount catch-all route %s %s", method, route))
+		}
+		group.Handle(method, GinPattern(route), serve)
+		return nil
+	})
+}
+
+// Mounter returns a Router whose routes are served under group, and a func
+// to call once they are all registered, which mounts them
+type Mounter func(group *gin.RouterGroup) (Router, func())
+
+// NewMounter returns the Mounter of the named router, one of RouterGin and
+// RouterChi
+func NewMounter(router string) (Mounter, error) {
+	switch router {
+	case RouterGin:
+		return func(group *gin.RouterGroup) (Router, func()) {
+			return Gin(group), func() {}
+		}, nil
+	case RouterChi:
+		return func(group *gin.RouterGroup) (Router, func()) {
+			mux := chi.NewRouter()
+			return Chi(mux), func() { Mount(group, mux) }
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown router: %q", router)
+	}
+}
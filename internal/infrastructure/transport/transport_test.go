@@ -0,0 +1,95 @@
+package transport
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registerRoutes registers routes answering with their name and path values
+func registerRoutes(router Router) {
+	echo := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, name+" "+r.PathValue("id")+r.PathValue("username"))
+		}
+	}
+	router.Handle(http.MethodGet, "/users/{id}", echo("get"))
+	router.Handle(http.MethodPut, "/users/{id}", echo("put"))
+	router.Handle(http.MethodGet, "/users/{id}/avatar", echo("avatar"))
+	router.Handle(http.MethodGet, "/users/username/{username}", echo("username"))
+	router.Handle(http.MethodGet, "/users/lookup", echo("lookup"))
+}
+
+// serve sends a request to the engine and returns the status and body
+func serve(t *testing.T, engine http.Handler, method, path string) (int, string) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return w.Code, string(body)
+}
+
+func TestGinPattern(t *testing.T) {
+	assert.Equal(t, "/users/:id/avatar", GinPattern("/users/{id}/avatar"))
+	assert.Equal(t, "/users/lookup", GinPattern("/users/lookup"))
+}
+
+func TestRouters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, name := range []string{RouterGin, RouterChi} {
+		t.Run(name, func(t *testing.T) {
+			mount, err := NewMounter(name)
+			require.NoError(t, err)
+
+			engine := gin.New()
+			var matched string
+			group := engine.Group("/api", func(c *gin.Context) {
+				// Middleware of the group sees the route pattern
+				matched = c.FullPath()
+			})
+			router, mountRoutes := mount(group)
+			registerRoutes(router)
+			mountRoutes()
+
+			tests := []struct {
+				method, path, body, route string
+			}{
+				{http.MethodGet, "/api/users/42", "get 42", "/api/users/:id"},
+				{http.MethodPut, "/api/users/42", "put 42", "/api/users/:id"},
+				{http.MethodGet, "/api/users/42/avatar", "avatar 42", "/api/users/:id/avatar"},
+				{http.MethodGet, "/api/users/username/avatar", "username avatar", "/api/users/username/:username"},
+				{http.MethodGet, "/api/users/lookup", "lookup ", "/api/users/lookup"},
+			}
+			for _, tt := range tests {
+				status, body := serve(t, engine, tt.method, tt.path)
+				assert.Equal(t, http.StatusOK, status, tt.path)
+				assert.Equal(t, tt.body, body, tt.path)
+				assert.Equal(t, tt.route, matched, tt.path)
+			}
+
+			status, _ := serve(t, engine, http.MethodDelete, "/api/users/42")
+			assert.Equal(t, http.StatusNotFound, status)
+		})
+	}
+}
+
+func TestNewMounter_Unknown(t *testing.T) {
+	_, err := NewMounter("echo")
+	assert.EqualError(t, err, `unknown router: "echo"`)
+}
+
+func TestMount_CatchAll(t *testing.T) {
+	mux := chi.NewRouter()
+	mux.Get("/files/*", func(http.ResponseWriter, *http.Request) {})
+
+	assert.Panics(t, func() { Mount(&gin.New().RouterGroup, mux) })
+}
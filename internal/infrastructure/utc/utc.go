@@ -0,0 +1,104 @@
+// Package utc keeps the times the service stores and serves in UTC, so
+// they do not depend on the time zone of the server that wrote them.
+package utc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Layout is RFC 3339 with exactly three fractional digits, the format
+// of every time in a response
+const Layout = "2006-01-02T15:04:05.000Z07:00"
+
+// Now returns the current time in UTC
+func Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Time is a time.Time that serializes with Layout. New puts it in UTC,
+// and it stays there unless moved on purpose, as the locale middleware
+// does for clients that ask for their own time zone. The embedded
+// time.Time keeps its methods available; only its encodings change.
+type Time struct {
+	time.Time
+}
+
+// New returns t in UTC
+func New(t time.Time) Time {
+	return Time{Time: t.UTC()}
+}
+
+// NewPtr returns t in UTC, or nil if t is nil
+func NewPtr(t *time.Time) *Time {
+	if t == nil {
+		return nil
+	}
+	u := New(*t)
+	return &u
+}
+
+// String returns t formatted with Layout
+func (t Time) String() string {
+	return t.Format(Layout)
+}
+
+// MarshalText formats t with Layout
+func (t Time) MarshalText() ([]byte, error) {
+	if y := t.Year(); y < 0 || y > 9999 {
+		return nil, fmt.Errorf("year %d outside of range [0,9999]", y)
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText parses an RFC 3339 time with any number of fractional
+// digits and converts it to UTC
+func (t *Time) UnmarshalText(data []byte) error {
+	parsed, err := time.Parse(time.RFC3339Nano, string(data))
+	if err != nil {
+		return err
+	}
+	*t = New(parsed)
+	return nil
+}
+
+// MarshalJSON formats t as a JSON string with Layout
+func (t Time) MarshalJSON() ([]byte, error) {
+	text, err := t.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON parses a JSON string holding an RFC 3339 time. A JSON
+// null leaves t unchanged, as it does for time.Time.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("time must be an RFC 3339 string: %w", err)
+	}
+	return t.UnmarshalText([]byte(text))
+}
+
+// CodecEncodeSelf encodes t as a time.Time, so formats with a native
+// time type, such as the MessagePack timestamp extension, keep using it
+// rather than the text of MarshalText
+func (t Time) CodecEncodeSelf(e *codec.Encoder) {
+	e.MustEncode(t.Time)
+}
+
+// CodecDecodeSelf decodes a time encoded by CodecEncodeSelf
+func (t *Time) CodecDecodeSelf(d *codec.Decoder) {
+	var decoded time.Time
+	d.MustDecode(&decoded)
+	*t = New(decoded)
+}
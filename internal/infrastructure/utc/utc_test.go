@@ -0,0 +1,134 @@
+package utc
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+var tokyo = time.FixedZone("JST", 9*60*60)
+
+func TestNow(t *testing.T) {
+	now := Now()
+
+	assert.Equal(t, time.UTC, now.Location())
+	assert.WithinDuration(t, time.Now(), now, time.Second)
+}
+
+func TestNewPtr(t *testing.T) {
+	assert.Nil(t, NewPtr(nil))
+
+	local := time.Date(2025, 1, 1, 21, 0, 0, 0, tokyo)
+	u := NewPtr(&local)
+	require.NotNil(t, u)
+	assert.Equal(t, time.UTC, u.Location())
+	assert.True(t, local.Equal(u.Time))
+}
+
+func TestTime_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{"whole seconds get milliseconds", time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), `"2025-01-01T12:00:00.000Z"`},
+		{"other zones keep their offset", time.Date(2025, 1, 1, 21, 0, 0, 0, tokyo), `"2025-01-01T21:00:00.000+09:00"`},
+		{"sub-millisecond digits are truncated", time.Date(2025, 1, 1, 12, 0, 0, 123456789, time.UTC), `"2025-01-01T12:00:00.123Z"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(Time{Time: tt.time})
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+		})
+	}
+}
+
+func TestNew_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(New(time.Date(2025, 1, 1, 21, 0, 0, 0, tokyo)))
+	require.NoError(t, err)
+	assert.JSONEq(t, `"2025-01-01T12:00:00.000Z"`, string(data))
+}
+
+func TestTime_MarshalJSON_OutOfRange(t *testing.T) {
+	_, err := json.Marshal(Time{Time: time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC)})
+	assert.Error(t, err)
+}
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    time.Time
+		wantErr bool
+	}{
+		{"milliseconds", `"2025-01-01T12:00:00.123Z"`, time.Date(2025, 1, 1, 12, 0, 0, 123000000, time.UTC), false},
+		{"no fraction", `"2025-01-01T12:00:00Z"`, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), false},
+		{"offset converts to UTC", `"2025-01-01T21:00:00+09:00"`, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), false},
+		{"not RFC 3339", `"01/01/2025"`, time.Time{}, true},
+		{"not a string", `1735732800`, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Time
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Time)
+			assert.Equal(t, time.UTC, got.Location())
+		})
+	}
+}
+
+func TestTime_UnmarshalJSON_Null(t *testing.T) {
+	var body struct {
+		At *Time `json:"at"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"at": null}`), &body))
+	assert.Nil(t, body.At)
+}
+
+func TestTime_XML(t *testing.T) {
+	type event struct {
+		At Time `xml:"at"`
+	}
+
+	data, err := xml.Marshal(event{At: New(time.Date(2025, 1, 1, 21, 0, 0, 0, tokyo))})
+	require.NoError(t, err)
+	assert.Equal(t, "<event><at>2025-01-01T12:00:00.000Z</at></event>", string(data))
+
+	var decoded event
+	require.NoError(t, xml.Unmarshal(data, &decoded))
+	assert.Equal(t, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), decoded.At.Time)
+}
+
+func TestTime_Msgpack(t *testing.T) {
+	handle := &codec.MsgpackHandle{}
+	handle.WriteExt = true
+	at := time.Date(2025, 1, 1, 21, 0, 0, 0, tokyo)
+
+	var data []byte
+	require.NoError(t, codec.NewEncoderBytes(&data, handle).Encode(map[string]any{"at": New(at)}))
+
+	// Decoders that know nothing of Time read the timestamp extension
+	var decoded map[string]any
+	require.NoError(t, codec.NewDecoderBytes(data, handle).Decode(&decoded))
+	assert.Equal(t, at.UTC(), decoded["at"])
+
+	var typed struct {
+		At Time `codec:"at"`
+	}
+	require.NoError(t, codec.NewDecoderBytes(data, handle).Decode(&typed))
+	assert.True(t, at.Equal(typed.At.Time))
+	assert.Equal(t, time.UTC, typed.At.Location())
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+)
+
+// CreateOrganizationRequest represents the request to create an organization
+type CreateOrganizationRequest struct {
+	Name    string `json:"name" binding:"required"`
+	Slug    string `json:"slug" binding:"required"`
+	OwnerID string `json:"owner_id" binding:"required"`
+}
+
+// UpdateOrganizationRequest represents the request to update an organization
+// Note: Slugs are stable identifiers and cannot be changed
+type UpdateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// AddMemberRequest represents the request to add a member to an organization
+type AddMemberRequest struct {
+	UserID string `json:"user_id" binding:"required"`
+	Role   string `json:"role"`
+}
+
+// ChangeMemberRoleRequest represents the request to change a member's role
+type ChangeMemberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+// CreateInvitationRequest represents the request to invite an email to an organization
+type CreateInvitationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role"`
+}
+
+// AcceptInvitationRequest represents the request to accept an invitation.
+// Name is only used when the invitee has no account yet.
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+	Name  string `json:"name"`
+}
+
+// OrganizationResponse represents the organization response
+type OrganizationResponse struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Slug      string   `json:"slug"`
+	CreatedAt utc.Time `json:"created_at"`
+	UpdatedAt utc.Time `json:"updated_at"`
+}
+
+// ListOrganizationsResponse represents the response for listing organizations
+type ListOrganizationsResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+	Limit         int                    `json:"limit"`
+	Offset        int                    `json:"offset"`
+}
+
+// MemberResponse represents an organization member response
+type MemberResponse struct {
+	OrganizationID string   `json:"organization_id"`
+	UserID         string   `json:"user_id"`
+	Role           string   `json:"role"`
+	CreatedAt      utc.Time `json:"created_at"`
+	UpdatedAt      utc.Time `json:"updated_at"`
+}
+
+// ListMembersResponse represents the response for listing organization members
+type ListMembersResponse struct {
+	Members []MemberResponse `json:"members"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
+
+// InvitationResponse represents an invitation response. The token is only
+// ever sent to the invitee.
+type InvitationResponse struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role"`
+	Status         string    `json:"status"`
+	ExpiresAt      utc.Time  `json:"expires_at"`
+	AcceptedAt     *utc.Time `json:"accepted_at,omitempty"`
+	RevokedAt      *utc.Time `json:"revoked_at,omitempty"`
+	CreatedAt      utc.Time  `json:"created_at"`
+	UpdatedAt      utc.Time  `json:"updated_at"`
+}
+
+// ListInvitationsResponse represents the response for listing invitations
+type ListInvitationsResponse struct {
+	Invitations []InvitationResponse `json:"invitations"`
+	Limit       int                  `json:"limit"`
+	Offset      int                  `json:"offset"`
+}
+
+// ListOrganizationsQuery represents the query parameters of GET /organizations
+type ListOrganizationsQuery struct {
+	request.Pagination
+	MemberID string `form:"member_id"`
+}
+
+// MemberPath represents the path parameters of member routes
+type MemberPath struct {
+	ID     string `path:"id" binding:"uuid"`
+	UserID string `path:"user_id" binding:"uuid"`
+}
+
+// InvitationPath represents the path parameters of invitation routes
+type InvitationPath struct {
+	ID           string `path:"id" binding:"uuid"`
+	InvitationID string `path:"invitation_id" binding:"uuid"`
+}
+
+// ErrorResponse represents an error response. Code is the stable,
+// machine-readable error code clients branch on.
+type ErrorResponse struct {
+	Error  string               `json:"error"`
+	Code   string               `json:"code"`
+	Fields []request.FieldError `json:"fields,omitempty"`
+}
+
+// ToOrganizationResponse converts a domain organization to an organization response
+func ToOrganizationResponse(org *domain.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		Slug:      org.Slug,
+		CreatedAt: utc.New(org.CreatedAt),
+		UpdatedAt: utc.New(org.UpdatedAt),
+	}
+}
+
+// ToOrganizationsResponse converts a slice of domain organizations to responses
+func ToOrganizationsResponse(orgs []*domain.Organization) []OrganizationResponse {
+	responses := make([]OrganizationResponse, 0, len(orgs))
+	for _, org := range orgs {
+		responses = append(responses, ToOrganizationResponse(org))
+	}
+	return responses
+}
+
+// ToMemberResponse converts a domain membership to a member response
+func ToMemberResponse(member *domain.Membership) MemberResponse {
+	return MemberResponse{
+		OrganizationID: member.OrganizationID,
+		UserID:         member.UserID,
+		Role:           string(member.Role),
+		CreatedAt:      utc.New(member.CreatedAt),
+		UpdatedAt:      utc.New(member.UpdatedAt),
+	}
+}
+
+// ToMembersResponse converts a slice of domain memberships to member responses
+func ToMembersResponse(members []*domain.Membership) []MemberResponse {
+	responses := make([]MemberResponse, 0, len(members))
+	for _, member := range members {
+		responses = append(responses, ToMemberResponse(member))
+	}
+	return responses
+}
+
+// ToInvitationResponse converts a domain invitation to an invitation response,
+// reporting its status at now
+func ToInvitationResponse(invitation *domain.Invitation, now time.Time) InvitationResponse {
+	return InvitationResponse{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           string(invitation.Role),
+		Status:         string(invitation.Status(now)),
+		ExpiresAt:      utc.New(invitation.ExpiresAt),
+		AcceptedAt:     utc.NewPtr(invitation.AcceptedAt),
+		RevokedAt:      utc.NewPtr(invitation.RevokedAt),
+		CreatedAt:      utc.New(invitation.CreatedAt),
+		UpdatedAt:      utc.New(invitation.UpdatedAt),
+	}
+}
+
+// ToInvitationsResponse converts a slice of domain invitations to invitation responses
+func ToInvitationsResponse(invitations []*domain.Invitation, now time.Time) []InvitationResponse {
+	responses := make([]InvitationResponse, 0, len(invitations))
+	for _, invitation := range invitations {
+		responses = append(responses, ToInvitationResponse(invitation, now))
+	}
+	return responses
+}
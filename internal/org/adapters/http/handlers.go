@@ -0,0 +1,465 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports"
+)
+
+// OrganizationHandler handles HTTP requests for organization operations
+type OrganizationHandler struct {
+	orgService  ports.OrganizationService
+	invitations ports.InvitationService
+	clock       ports.Clock
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler. The clock must be
+// the one the invitation service uses, so reported statuses match its own.
+func NewOrganizationHandler(orgService ports.OrganizationService, invitations ports.InvitationService, clock ports.Clock) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService:  orgService,
+		invitations: invitations,
+		clock:       clock,
+	}
+}
+
+// CreateOrganization handles POST /organizations
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req CreateOrganizationRequest
+
+	if err := request.BindJSON(c, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	org, err := h.orgService.CreateOrganization(c.Request.Context(), req.Name, req.Slug, req.OwnerID)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToOrganizationResponse(org))
+}
+
+// GetOrganization handles GET /organizations/:id
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	var path request.IDPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id := path.ID
+
+	org, err := h.orgService.GetOrganization(c.Request.Context(), id)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToOrganizationResponse(org))
+}
+
+// UpdateOrganization handles PUT /organizations/:id
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	var path request.IDPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id := path.ID
+
+	var req UpdateOrganizationRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	org, err := h.orgService.UpdateOrganization(c.Request.Context(), id, req.Name)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToOrganizationResponse(org))
+}
+
+// DeleteOrganization handles DELETE /organizations/:id
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	var path request.IDPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id := path.ID
+
+	if err := h.orgService.DeleteOrganization(c.Request.Context(), id); err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListOrganizations handles GET /organizations
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	query := ListOrganizationsQuery{Pagination: request.NewPagination()}
+	if err := request.BindQuery(c, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	filter := domain.OrganizationFilter{
+		MemberID: query.MemberID,
+	}
+	limit, offset := query.Limit, query.Offset
+
+	orgs, err := h.orgService.ListOrganizations(c.Request.Context(), filter, limit, offset)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListOrganizationsResponse{
+		Organizations: ToOrganizationsResponse(orgs),
+		Limit:         limit,
+		Offset:        offset,
+	})
+}
+
+// ListMembers handles GET /organizations/:id/members
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	var path request.IDPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id := path.ID
+
+	page, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+	limit, offset := page.Limit, page.Offset
+
+	members, err := h.orgService.ListMembers(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListMembersResponse{
+		Members: ToMembersResponse(members),
+		Limit:   limit,
+		Offset:  offset,
+	})
+}
+
+// AddMember handles POST /organizations/:id/members
+func (h *OrganizationHandler) AddMember(c *gin.Context) {
+	var path request.IDPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id := path.ID
+
+	var req AddMemberRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	role := domain.RoleMember
+	if req.Role != "" {
+		parsed, err := domain.ParseRole(req.Role)
+		if err != nil {
+			statusCode, response := domainErrorResponse(err)
+			c.JSON(statusCode, response)
+			return
+		}
+		role = parsed
+	}
+
+	member, err := h.orgService.AddMember(c.Request.Context(), id, req.UserID, role)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToMemberResponse(member))
+}
+
+// ChangeMemberRole handles PUT /organizations/:id/members/:user_id
+func (h *OrganizationHandler) ChangeMemberRole(c *gin.Context) {
+	var path MemberPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id, userID := path.ID, path.UserID
+
+	var req ChangeMemberRoleRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	role, err := domain.ParseRole(req.Role)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	member, err := h.orgService.ChangeMemberRole(c.Request.Context(), id, userID, role)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToMemberResponse(member))
+}
+
+// RemoveMember handles DELETE /organizations/:id/members/:user_id
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	var path MemberPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id, userID := path.ID, path.UserID
+
+	if err := h.orgService.RemoveMember(c.Request.Context(), id, userID); err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateInvitation handles POST /organizations/:id/invitations
+func (h *OrganizationHandler) CreateInvitation(c *gin.Context) {
+	var path request.IDPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id := path.ID
+
+	var req CreateInvitationRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	role := domain.RoleMember
+	if req.Role != "" {
+		parsed, err := domain.ParseRole(req.Role)
+		if err != nil {
+			statusCode, response := domainErrorResponse(err)
+			c.JSON(statusCode, response)
+			return
+		}
+		role = parsed
+	}
+
+	invitation, err := h.invitations.Invite(c.Request.Context(), id, req.Email, role)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ToInvitationResponse(invitation, h.clock.Now()))
+}
+
+// ListInvitations handles GET /organizations/:id/invitations
+func (h *OrganizationHandler) ListInvitations(c *gin.Context) {
+	var path request.IDPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id := path.ID
+
+	page, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+	limit, offset := page.Limit, page.Offset
+
+	invitations, err := h.invitations.ListInvitations(c.Request.Context(), id, limit, offset)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, ListInvitationsResponse{
+		Invitations: ToInvitationsResponse(invitations, h.clock.Now()),
+		Limit:       limit,
+		Offset:      offset,
+	})
+}
+
+// ResendInvitation handles POST /organizations/:id/invitations/:invitation_id/resend
+func (h *OrganizationHandler) ResendInvitation(c *gin.Context) {
+	var path InvitationPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id, invitationID := path.ID, path.InvitationID
+
+	invitation, err := h.invitations.ResendInvitation(c.Request.Context(), id, invitationID)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToInvitationResponse(invitation, h.clock.Now()))
+}
+
+// RevokeInvitation handles DELETE /organizations/:id/invitations/:invitation_id
+func (h *OrganizationHandler) RevokeInvitation(c *gin.Context) {
+	var path InvitationPath
+	if !bindPath(c, &path) {
+		return
+	}
+	id, invitationID := path.ID, path.InvitationID
+
+	if err := h.invitations.RevokeInvitation(c.Request.Context(), id, invitationID); err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AcceptInvitation handles POST /invitations/accept
+func (h *OrganizationHandler) AcceptInvitation(c *gin.Context) {
+	var req AcceptInvitationRequest
+	if err := request.BindJSON(c, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	member, err := h.invitations.AcceptInvitation(c.Request.Context(), req.Token, req.Name)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		c.JSON(statusCode, response)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToMemberResponse(member))
+}
+
+// bindPagination binds the limit and offset query parameters. It writes a
+// 400 response and returns false when they are malformed or out of range.
+func bindPagination(c *gin.Context) (request.Pagination, bool) {
+	page := request.NewPagination()
+	if err := request.BindQuery(c, &page); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return page, false
+	}
+
+	return page, true
+}
+
+// bindPath binds the path parameters into obj. It writes a 400 response
+// and returns false when they are malformed, such as IDs that are not
+// UUIDs.
+func bindPath(c *gin.Context, obj any) bool {
+	if err := request.BindPath(c, obj); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		c.JSON(statusCode, response)
+		return false
+	}
+
+	return true
+}
+
+// bindErrorResponse converts a request binding error to a status code and
+// an error response listing the offending fields
+func bindErrorResponse(err error) (int, ErrorResponse) {
+	var bindErr *request.Error
+	if errors.As(err, &bindErr) {
+		return bindErr.Status, ErrorResponse{
+			Error:  bindErr.Message,
+			Code:   problem.CodeOf(bindErr),
+			Fields: bindErr.Fields,
+		}
+	}
+
+	return http.StatusBadRequest, ErrorResponse{
+		Error: err.Error(),
+		Code:  problem.CodeValidationFailed,
+	}
+}
+
+// domainErrorResponse converts a domain error to a status code and an error
+// response carrying its code and, for validation errors, the invalid fields
+func domainErrorResponse(err error) (int, ErrorResponse) {
+	statusCode, message := mapDomainErrorToHTTP(err)
+	return statusCode, ErrorResponse{
+		Error:  message,
+		Code:   string(domain.CodeOf(err)),
+		Fields: request.FieldErrors(err),
+	}
+}
+
+// mapDomainErrorToHTTP maps domain errors to HTTP status codes and messages
+func mapDomainErrorToHTTP(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrInvalidOrganizationName):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidSlug):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrDuplicateSlug):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrMemberNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrAlreadyMember):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrInvalidRole):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrLastOwner):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrUserNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrInvalidEmail):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidUserName):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrEmailUnavailable):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrInvitationNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrInvitationExists):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrInvalidInvitationToken):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvitationExpired):
+		return http.StatusGone, err.Error()
+	case errors.Is(err, domain.ErrInvitationNotPending):
+		return http.StatusConflict, err.Error()
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yourusername/go-scaffolding/internal/org/ports"
+)
+
+// RegisterOrganizationRoutes registers all organization routes
+func RegisterOrganizationRoutes(router *gin.Engine, orgService ports.OrganizationService, invitations ports.InvitationService, clock ports.Clock) {
+	handler := NewOrganizationHandler(orgService, invitations, clock)
+
+	// Organization routes
+	orgs := router.Group("/organizations")
+	{
+		orgs.POST("", handler.CreateOrganization)
+		orgs.GET("", handler.ListOrganizations)
+		orgs.GET("/:id", handler.GetOrganization)
+		orgs.PUT("/:id", handler.UpdateOrganization)
+		orgs.DELETE("/:id", handler.DeleteOrganization)
+		orgs.GET("/:id/members", handler.ListMembers)
+		orgs.POST("/:id/members", handler.AddMember)
+		orgs.PUT("/:id/members/:user_id", handler.ChangeMemberRole)
+		orgs.DELETE("/:id/members/:user_id", handler.RemoveMember)
+		orgs.GET("/:id/invitations", handler.ListInvitations)
+		orgs.POST("/:id/invitations", handler.CreateInvitation)
+		orgs.POST("/:id/invitations/:invitation_id/resend", handler.ResendInvitation)
+		orgs.DELETE("/:id/invitations/:invitation_id", handler.RevokeInvitation)
+	}
+
+	// Invitees accept with the mailed token, outside any organization path
+	router.POST("/invitations/accept", handler.AcceptInvitation)
+}
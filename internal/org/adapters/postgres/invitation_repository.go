@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports"
+)
+
+// invitationRepository implements ports.InvitationRepository using GORM
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository creates a new PostgreSQL invitation repository
+func NewInvitationRepository(db *gorm.DB) ports.InvitationRepository {
+	return &invitationRepository{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, if any, or the repository's database
+func (r *invitationRepository) conn(ctx context.Context) *gorm.DB {
+	return database.Conn(ctx, r.db)
+}
+
+// Create creates a new invitation
+func (r *invitationRepository) Create(ctx context.Context, invitation *domain.Invitation) error {
+	return r.conn(ctx).Create(ToInvitationModel(invitation)).Error
+}
+
+// GetByID retrieves an invitation by ID
+func (r *invitationRepository) GetByID(ctx context.Context, id string) (*domain.Invitation, error) {
+	return r.first(r.conn(ctx).Where("id = ?", id))
+}
+
+// GetByTokenHash retrieves an invitation by the hash of its token
+func (r *invitationRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error) {
+	return r.first(r.conn(ctx).Where("token_hash = ?", tokenHash))
+}
+
+// FindPending retrieves the invitation for the email that is neither
+// accepted, revoked nor expired at now
+func (r *invitationRepository) FindPending(ctx context.Context, orgID, email string, now time.Time) (*domain.Invitation, error) {
+	return r.first(r.conn(ctx).
+		Where("organization_id = ? AND email = ?", orgID, email).
+		Where("accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", now))
+}
+
+// Update updates an existing invitation
+func (r *invitationRepository) Update(ctx context.Context, invitation *domain.Invitation) error {
+	// Select all columns so timestamps are written even when nil
+	result := r.conn(ctx).Model(&InvitationModel{ID: invitation.ID}).
+		Select("*").
+		Omit("id", "organization_id", "email", "created_at").
+		Updates(ToInvitationModel(invitation))
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrInvitationNotFound
+	}
+
+	return nil
+}
+
+// List retrieves the invitations of an organization with pagination, newest first
+func (r *invitationRepository) List(ctx context.Context, orgID string, limit, offset int) ([]*domain.Invitation, error) {
+	var models []*InvitationModel
+
+	result := r.conn(ctx).
+		Where("organization_id = ?", orgID).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ToDomainInvitations(models), nil
+}
+
+// first retrieves the first invitation matching query
+func (r *invitationRepository) first(query *gorm.DB) (*domain.Invitation, error) {
+	var model InvitationModel
+
+	result := query.First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, result.Error
+	}
+
+	return ToDomainInvitation(&model), nil
+}
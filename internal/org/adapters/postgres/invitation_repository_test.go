@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+)
+
+func TestInvitationRepository(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewInvitationRepository(db)
+	ctx := context.Background()
+	orgID := uuid.New().String()
+
+	invitation, token, err := domain.NewInvitation(uuid.New().String(), orgID, "invitee@example.com", domain.RoleMember, time.Hour, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(ctx, invitation))
+
+	t.Run("get by id and token hash", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, invitation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "invitee@example.com", found.Email)
+
+		found, err = repo.GetByTokenHash(ctx, domain.HashInvitationToken(token))
+		require.NoError(t, err)
+		assert.Equal(t, invitation.ID, found.ID)
+
+		_, err = repo.GetByTokenHash(ctx, domain.HashInvitationToken("bogus"))
+		assert.ErrorIs(t, err, domain.ErrInvitationNotFound)
+	})
+
+	t.Run("find pending", func(t *testing.T) {
+		found, err := repo.FindPending(ctx, orgID, "invitee@example.com", time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, invitation.ID, found.ID)
+
+		// Expired invitations are not pending
+		_, err = repo.FindPending(ctx, orgID, "invitee@example.com", time.Now().Add(2*time.Hour))
+		assert.ErrorIs(t, err, domain.ErrInvitationNotFound)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		require.NoError(t, invitation.Revoke(time.Now()))
+		require.NoError(t, repo.Update(ctx, invitation))
+
+		found, err := repo.GetByID(ctx, invitation.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.InvitationRevoked, found.Status(time.Now()))
+
+		_, err = repo.FindPending(ctx, orgID, "invitee@example.com", time.Now())
+		assert.ErrorIs(t, err, domain.ErrInvitationNotFound)
+
+		missing := &domain.Invitation{ID: uuid.New().String()}
+		assert.ErrorIs(t, repo.Update(ctx, missing), domain.ErrInvitationNotFound)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		other, _, err := domain.NewInvitation(uuid.New().String(), orgID, "other@example.com", domain.RoleAdmin, time.Hour, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, other))
+
+		invitations, err := repo.List(ctx, orgID, 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, invitations, 2)
+
+		invitations, err = repo.List(ctx, uuid.New().String(), 10, 0)
+		require.NoError(t, err)
+		assert.Empty(t, invitations)
+	})
+}
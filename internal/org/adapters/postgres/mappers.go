@@ -0,0 +1,145 @@
+package postgres
+
+import "github.com/yourusername/go-scaffolding/internal/org/domain"
+
+// ToOrganizationModel converts a domain.Organization to an OrganizationModel
+func ToOrganizationModel(org *domain.Organization) *OrganizationModel {
+	if org == nil {
+		return nil
+	}
+
+	return &OrganizationModel{
+		ID:        org.ID,
+		Name:      org.Name,
+		Slug:      org.Slug,
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
+
+// ToDomainOrganization converts an OrganizationModel to a domain.Organization
+func ToDomainOrganization(model *OrganizationModel) *domain.Organization {
+	if model == nil {
+		return nil
+	}
+
+	return &domain.Organization{
+		ID:        model.ID,
+		Name:      model.Name,
+		Slug:      model.Slug,
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+// ToDomainOrganizations converts a slice of OrganizationModel to a slice of domain.Organization
+func ToDomainOrganizations(models []*OrganizationModel) []*domain.Organization {
+	if models == nil {
+		return nil
+	}
+
+	orgs := make([]*domain.Organization, len(models))
+	for i, model := range models {
+		orgs[i] = ToDomainOrganization(model)
+	}
+
+	return orgs
+}
+
+// ToMembershipModel converts a domain.Membership to a MembershipModel
+func ToMembershipModel(member *domain.Membership) *MembershipModel {
+	if member == nil {
+		return nil
+	}
+
+	return &MembershipModel{
+		OrganizationID: member.OrganizationID,
+		UserID:         member.UserID,
+		Role:           string(member.Role),
+		CreatedAt:      member.CreatedAt,
+		UpdatedAt:      member.UpdatedAt,
+	}
+}
+
+// ToDomainMembership converts a MembershipModel to a domain.Membership
+func ToDomainMembership(model *MembershipModel) *domain.Membership {
+	if model == nil {
+		return nil
+	}
+
+	return &domain.Membership{
+		OrganizationID: model.OrganizationID,
+		UserID:         model.UserID,
+		Role:           domain.Role(model.Role),
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+// ToDomainMemberships converts a slice of MembershipModel to a slice of domain.Membership
+func ToDomainMemberships(models []*MembershipModel) []*domain.Membership {
+	if models == nil {
+		return nil
+	}
+
+	members := make([]*domain.Membership, len(models))
+	for i, model := range models {
+		members[i] = ToDomainMembership(model)
+	}
+
+	return members
+}
+
+// ToInvitationModel converts a domain.Invitation to an InvitationModel
+func ToInvitationModel(invitation *domain.Invitation) *InvitationModel {
+	if invitation == nil {
+		return nil
+	}
+
+	return &InvitationModel{
+		ID:             invitation.ID,
+		OrganizationID: invitation.OrganizationID,
+		Email:          invitation.Email,
+		Role:           string(invitation.Role),
+		TokenHash:      invitation.TokenHash,
+		ExpiresAt:      invitation.ExpiresAt,
+		AcceptedAt:     invitation.AcceptedAt,
+		RevokedAt:      invitation.RevokedAt,
+		CreatedAt:      invitation.CreatedAt,
+		UpdatedAt:      invitation.UpdatedAt,
+	}
+}
+
+// ToDomainInvitation converts an InvitationModel to a domain.Invitation
+func ToDomainInvitation(model *InvitationModel) *domain.Invitation {
+	if model == nil {
+		return nil
+	}
+
+	return &domain.Invitation{
+		ID:             model.ID,
+		OrganizationID: model.OrganizationID,
+		Email:          model.Email,
+		Role:           domain.Role(model.Role),
+		TokenHash:      model.TokenHash,
+		ExpiresAt:      model.ExpiresAt,
+		AcceptedAt:     model.AcceptedAt,
+		RevokedAt:      model.RevokedAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+// ToDomainInvitations converts a slice of InvitationModel to a slice of domain.Invitation
+func ToDomainInvitations(models []*InvitationModel) []*domain.Invitation {
+	if models == nil {
+		return nil
+	}
+
+	invitations := make([]*domain.Invitation, len(models))
+	for i, model := range models {
+		invitations[i] = ToDomainInvitation(model)
+	}
+
+	return invitations
+}
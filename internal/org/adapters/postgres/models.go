@@ -0,0 +1,53 @@
+package postgres
+
+import "time"
+
+// OrganizationModel represents the database model for organizations
+type OrganizationModel struct {
+	ID        string    `gorm:"type:uuid;primaryKey"`
+	TenantID  string    `gorm:"type:varchar(56);not null;default:'';uniqueIndex:idx_organizations_slug,priority:1"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	Slug      string    `gorm:"type:varchar(50);uniqueIndex:idx_organizations_slug,priority:2;not null"`
+	CreatedAt time.Time `gorm:"index;not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for OrganizationModel
+func (OrganizationModel) TableName() string {
+	return "organizations"
+}
+
+// MembershipModel represents the database model for organization memberships
+type MembershipModel struct {
+	OrganizationID string    `gorm:"type:uuid;primaryKey"`
+	UserID         string    `gorm:"type:uuid;primaryKey;index"`
+	TenantID       string    `gorm:"type:varchar(56);not null;default:''"`
+	Role           string    `gorm:"type:varchar(20);not null"`
+	CreatedAt      time.Time `gorm:"not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for MembershipModel
+func (MembershipModel) TableName() string {
+	return "organization_members"
+}
+
+// InvitationModel represents the database model for organization invitations
+type InvitationModel struct {
+	ID             string    `gorm:"type:uuid;primaryKey"`
+	TenantID       string    `gorm:"type:varchar(56);not null;default:''"`
+	OrganizationID string    `gorm:"type:uuid;index:idx_organization_invitations_org_email;not null"`
+	Email          string    `gorm:"type:varchar(254);index:idx_organization_invitations_org_email;not null"`
+	Role           string    `gorm:"type:varchar(20);not null"`
+	TokenHash      string    `gorm:"type:varchar(64);uniqueIndex:idx_organization_invitations_token_hash;not null"`
+	ExpiresAt      time.Time `gorm:"not null"`
+	AcceptedAt     *time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time `gorm:"not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for InvitationModel
+func (InvitationModel) TableName() string {
+	return "organization_invitations"
+}
@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports"
+)
+
+// organizationRepository implements ports.OrganizationRepository using GORM
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new PostgreSQL organization repository
+func NewOrganizationRepository(db *gorm.DB) ports.OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+	}
+}
+
+// conn returns the transaction carried by ctx, if any, or the repository's database
+func (r *organizationRepository) conn(ctx context.Context) *gorm.DB {
+	return database.Conn(ctx, r.db)
+}
+
+// Create creates a new organization and its first owner in a single transaction
+func (r *organizationRepository) Create(ctx context.Context, org *domain.Organization, owner *domain.Membership) error {
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(ToOrganizationModel(org)).Error; err != nil {
+			return err
+		}
+		return tx.Create(ToMembershipModel(owner)).Error
+	})
+	if err != nil {
+		if isUniqueViolation(err, "slug") {
+			return domain.ErrDuplicateSlug
+		}
+		return err
+	}
+
+	return nil
+}
+
+// GetByID retrieves an organization by ID
+func (r *organizationRepository) GetByID(ctx context.Context, id string) (*domain.Organization, error) {
+	var model OrganizationModel
+
+	result := r.conn(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, result.Error
+	}
+
+	return ToDomainOrganization(&model), nil
+}
+
+// Update updates an existing organization
+func (r *organizationRepository) Update(ctx context.Context, org *domain.Organization) error {
+	result := r.conn(ctx).Model(&OrganizationModel{ID: org.ID}).
+		Omit("id", "created_at").
+		Updates(ToOrganizationModel(org))
+
+	if result.Error != nil {
+		if isUniqueViolation(result.Error, "slug") {
+			return domain.ErrDuplicateSlug
+		}
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrOrganizationNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes an organization and all of its memberships in a single transaction
+func (r *organizationRepository) Delete(ctx context.Context, id string) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("organization_id = ?", id).Delete(&MembershipModel{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", id).Delete(&OrganizationModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domain.ErrOrganizationNotFound
+		}
+
+		return nil
+	})
+}
+
+// List retrieves organizations matching the filter with pagination
+func (r *organizationRepository) List(ctx context.Context, filter domain.OrganizationFilter, limit, offset int) ([]*domain.Organization, error) {
+	var models []*OrganizationModel
+
+	query := r.conn(ctx)
+	if filter.MemberID != "" {
+		query = query.Where("id IN (?)", r.conn(ctx).Model(&MembershipModel{}).
+			Select("organization_id").
+			Where("user_id = ?", filter.MemberID))
+	}
+
+	result := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ToDomainOrganizations(models), nil
+}
+
+// AddMember adds a user to an organization
+func (r *organizationRepository) AddMember(ctx context.Context, member *domain.Membership) error {
+	result := r.conn(ctx).Create(ToMembershipModel(member))
+	if result.Error != nil {
+		if isUniqueViolation(result.Error, "") {
+			return domain.ErrAlreadyMember
+		}
+		return result.Error
+	}
+
+	return nil
+}
+
+// GetMember retrieves a user's membership of an organization
+func (r *organizationRepository) GetMember(ctx context.Context, orgID, userID string) (*domain.Membership, error) {
+	var model MembershipModel
+
+	result := r.conn(ctx).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrMemberNotFound
+		}
+		return nil, result.Error
+	}
+
+	return ToDomainMembership(&model), nil
+}
+
+// UpdateMember updates an existing membership
+func (r *organizationRepository) UpdateMember(ctx context.Context, member *domain.Membership) error {
+	result := r.conn(ctx).Model(&MembershipModel{}).
+		Where("organization_id = ? AND user_id = ?", member.OrganizationID, member.UserID).
+		Updates(map[string]interface{}{
+			"role":       string(member.Role),
+			"updated_at": member.UpdatedAt,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// RemoveMember removes a user from an organization
+func (r *organizationRepository) RemoveMember(ctx context.Context, orgID, userID string) error {
+	result := r.conn(ctx).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Delete(&MembershipModel{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// ListMembers retrieves the members of an organization with pagination, oldest first
+func (r *organizationRepository) ListMembers(ctx context.Context, orgID string, limit, offset int) ([]*domain.Membership, error) {
+	var models []*MembershipModel
+
+	result := r.conn(ctx).
+		Where("organization_id = ?", orgID).
+		Order("created_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ToDomainMemberships(models), nil
+}
+
+// CountOwners counts the owners of an organization
+func (r *organizationRepository) CountOwners(ctx context.Context, orgID string) (int, error) {
+	var count int64
+
+	result := r.conn(ctx).Model(&MembershipModel{}).
+		Where("organization_id = ? AND role = ?", orgID, string(domain.RoleOwner)).
+		Count(&count)
+
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(count), nil
+}
+
+// isUniqueViolation checks if the error is a unique constraint violation,
+// optionally on a constraint or column whose name contains column
+func isUniqueViolation(err error, column string) bool {
+	if err == nil {
+		return false
+	}
+
+	// GORM wraps the error, so we check the error message
+	errMsg := err.Error()
+
+	// PostgreSQL and SQLite unique constraint violations
+	if strings.Contains(errMsg, "duplicate key value violates unique constraint") ||
+		strings.Contains(errMsg, "UNIQUE constraint failed") {
+		return strings.Contains(errMsg, column)
+	}
+
+	return false
+}
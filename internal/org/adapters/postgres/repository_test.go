@@ -0,0 +1,178 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	// Use SQLite in-memory database for testing
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Auto-migrate the schema
+	err = db.AutoMigrate(&OrganizationModel{}, &MembershipModel{}, &InvitationModel{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createOrganization(t *testing.T, repo ports.OrganizationRepository, slug, ownerID string) *domain.Organization {
+	t.Helper()
+
+	org, err := domain.NewOrganization(uuid.New().String(), "Org "+slug, slug, time.Now())
+	require.NoError(t, err)
+	owner, err := domain.NewMembership(org.ID, ownerID, domain.RoleOwner, time.Now())
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Create(context.Background(), org, owner))
+	return org
+}
+
+func TestOrganizationRepository_Create(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewOrganizationRepository(db)
+	ctx := context.Background()
+	ownerID := uuid.New().String()
+
+	org := createOrganization(t, repo, "acme", ownerID)
+
+	found, err := repo.GetByID(ctx, org.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", found.Slug)
+
+	owner, err := repo.GetMember(ctx, org.ID, ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleOwner, owner.Role)
+
+	t.Run("duplicate slug", func(t *testing.T) {
+		dup, err := domain.NewOrganization(uuid.New().String(), "Other", "acme", time.Now())
+		require.NoError(t, err)
+		dupOwner, err := domain.NewMembership(dup.ID, ownerID, domain.RoleOwner, time.Now())
+		require.NoError(t, err)
+
+		err = repo.Create(ctx, dup, dupOwner)
+		assert.ErrorIs(t, err, domain.ErrDuplicateSlug)
+
+		// The transaction rolled back, so the owner was not added either
+		_, err = repo.GetMember(ctx, dup.ID, ownerID)
+		assert.ErrorIs(t, err, domain.ErrMemberNotFound)
+	})
+}
+
+func TestOrganizationRepository_GetByID_NotFound(t *testing.T) {
+	repo := NewOrganizationRepository(setupTestDB(t))
+
+	_, err := repo.GetByID(context.Background(), uuid.New().String())
+	assert.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+}
+
+func TestOrganizationRepository_Update(t *testing.T) {
+	repo := NewOrganizationRepository(setupTestDB(t))
+	ctx := context.Background()
+
+	org := createOrganization(t, repo, "acme", uuid.New().String())
+	require.NoError(t, org.UpdateName("Acme Labs", time.Now()))
+	require.NoError(t, repo.Update(ctx, org))
+
+	found, err := repo.GetByID(ctx, org.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Labs", found.Name)
+
+	missing := &domain.Organization{ID: uuid.New().String(), Name: "Missing", Slug: "missing"}
+	assert.ErrorIs(t, repo.Update(ctx, missing), domain.ErrOrganizationNotFound)
+}
+
+func TestOrganizationRepository_Delete(t *testing.T) {
+	repo := NewOrganizationRepository(setupTestDB(t))
+	ctx := context.Background()
+	ownerID := uuid.New().String()
+
+	org := createOrganization(t, repo, "acme", ownerID)
+	require.NoError(t, repo.Delete(ctx, org.ID))
+
+	_, err := repo.GetByID(ctx, org.ID)
+	assert.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+	_, err = repo.GetMember(ctx, org.ID, ownerID)
+	assert.ErrorIs(t, err, domain.ErrMemberNotFound)
+
+	assert.ErrorIs(t, repo.Delete(ctx, org.ID), domain.ErrOrganizationNotFound)
+}
+
+func TestOrganizationRepository_List(t *testing.T) {
+	repo := NewOrganizationRepository(setupTestDB(t))
+	ctx := context.Background()
+	alice := uuid.New().String()
+	bob := uuid.New().String()
+
+	acme := createOrganization(t, repo, "acme", alice)
+	createOrganization(t, repo, "globex", bob)
+
+	orgs, err := repo.List(ctx, domain.OrganizationFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, orgs, 2)
+
+	orgs, err = repo.List(ctx, domain.OrganizationFilter{MemberID: alice}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, orgs, 1)
+	assert.Equal(t, acme.ID, orgs[0].ID)
+}
+
+func TestOrganizationRepository_Members(t *testing.T) {
+	repo := NewOrganizationRepository(setupTestDB(t))
+	ctx := context.Background()
+	ownerID := uuid.New().String()
+	userID := uuid.New().String()
+
+	org := createOrganization(t, repo, "acme", ownerID)
+
+	member, err := domain.NewMembership(org.ID, userID, domain.RoleMember, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, repo.AddMember(ctx, member))
+
+	t.Run("already a member", func(t *testing.T) {
+		err := repo.AddMember(ctx, member)
+		assert.ErrorIs(t, err, domain.ErrAlreadyMember)
+	})
+
+	t.Run("list members", func(t *testing.T) {
+		members, err := repo.ListMembers(ctx, org.ID, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, members, 2)
+		assert.Equal(t, ownerID, members[0].UserID)
+		assert.Equal(t, userID, members[1].UserID)
+	})
+
+	t.Run("update member and count owners", func(t *testing.T) {
+		require.NoError(t, member.ChangeRole(domain.RoleOwner, time.Now()))
+		require.NoError(t, repo.UpdateMember(ctx, member))
+
+		found, err := repo.GetMember(ctx, org.ID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleOwner, found.Role)
+
+		owners, err := repo.CountOwners(ctx, org.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, owners)
+	})
+
+	t.Run("remove member", func(t *testing.T) {
+		require.NoError(t, repo.RemoveMember(ctx, org.ID, userID))
+
+		_, err := repo.GetMember(ctx, org.ID, userID)
+		assert.ErrorIs(t, err, domain.ErrMemberNotFound)
+		assert.ErrorIs(t, repo.RemoveMember(ctx, org.ID, userID), domain.ErrMemberNotFound)
+	})
+}
@@ -0,0 +1,66 @@
+package users
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports"
+	userdomain "github.com/yourusername/go-scaffolding/internal/user/domain"
+	userports "github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// directory implements ports.UserDirectory on top of the user feature's service
+type directory struct {
+	userService userports.UserService
+}
+
+// NewUserDirectory creates a user directory backed by the user service
+func NewUserDirectory(userService userports.UserService) ports.UserDirectory {
+	return &directory{
+		userService: userService,
+	}
+}
+
+// Exists reports whether a user with the ID exists. Soft-deleted users do not.
+func (d *directory) Exists(ctx context.Context, userID string) (bool, error) {
+	_, err := d.userService.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, userdomain.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// FindOrCreateByEmail returns the ID of the user with the email, creating the
+// user with the given name if there is none. User feature errors are
+// translated to organization errors.
+func (d *directory) FindOrCreateByEmail(ctx context.Context, email, name string) (string, error) {
+	user, err := d.userService.GetUserByEmail(ctx, email)
+	if err == nil {
+		return user.ID, nil
+	}
+	if !errors.Is(err, userdomain.ErrUserNotFound) {
+		return "", err
+	}
+
+	user, err = d.userService.CreateUser(ctx, email, name)
+	if err != nil {
+		switch {
+		case errors.Is(err, userdomain.ErrInvalidEmail):
+			return "", domain.ErrInvalidEmail
+		case errors.Is(err, userdomain.ErrInvalidName):
+			return "", domain.ErrInvalidUserName
+		case errors.Is(err, userdomain.ErrDuplicateEmail):
+			// Only a soft-deleted user can hold an email that was not found
+			return "", domain.ErrEmailUnavailable
+		default:
+			return "", err
+		}
+	}
+
+	return user.ID, nil
+}
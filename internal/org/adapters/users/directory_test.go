@@ -0,0 +1,76 @@
+package users
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	userdomain "github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+func TestDirectory_Exists(t *testing.T) {
+	mockUsers := new(mocks.MockUserService)
+	dir := NewUserDirectory(mockUsers)
+	ctx := context.Background()
+	lookupErr := errors.New("database unavailable")
+
+	mockUsers.On("GetUser", ctx, "user-1").Return(&userdomain.User{ID: "user-1"}, nil)
+	mockUsers.On("GetUser", ctx, "missing").Return(nil, userdomain.ErrUserNotFound)
+	mockUsers.On("GetUser", ctx, "broken").Return(nil, lookupErr)
+
+	exists, err := dir.Exists(ctx, "user-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = dir.Exists(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = dir.Exists(ctx, "broken")
+	assert.ErrorIs(t, err, lookupErr)
+}
+
+func TestDirectory_FindOrCreateByEmail(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("existing user", func(t *testing.T) {
+		mockUsers := new(mocks.MockUserService)
+		dir := NewUserDirectory(mockUsers)
+
+		mockUsers.On("GetUserByEmail", ctx, "invitee@example.com").Return(&userdomain.User{ID: "user-1"}, nil)
+
+		id, err := dir.FindOrCreateByEmail(ctx, "invitee@example.com", "")
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", id)
+		mockUsers.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("new user", func(t *testing.T) {
+		mockUsers := new(mocks.MockUserService)
+		dir := NewUserDirectory(mockUsers)
+
+		mockUsers.On("GetUserByEmail", ctx, "invitee@example.com").Return(nil, userdomain.ErrUserNotFound)
+		mockUsers.On("CreateUser", ctx, "invitee@example.com", "Invitee").Return(&userdomain.User{ID: "user-2"}, nil)
+
+		id, err := dir.FindOrCreateByEmail(ctx, "invitee@example.com", "Invitee")
+		require.NoError(t, err)
+		assert.Equal(t, "user-2", id)
+	})
+
+	t.Run("translates user errors", func(t *testing.T) {
+		mockUsers := new(mocks.MockUserService)
+		dir := NewUserDirectory(mockUsers)
+
+		mockUsers.On("GetUserByEmail", ctx, "invitee@example.com").Return(nil, userdomain.ErrUserNotFound)
+		mockUsers.On("CreateUser", ctx, "invitee@example.com", "").Return(nil, userdomain.ErrInvalidName)
+
+		_, err := dir.FindOrCreateByEmail(ctx, "invitee@example.com", "")
+		assert.ErrorIs(t, err, domain.ErrInvalidUserName)
+	})
+}
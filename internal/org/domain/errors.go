@@ -0,0 +1,135 @@
+package domain
+
+import "errors"
+
+// Code is a stable, machine-readable error code. Messages may be reworded,
+// so clients branch on codes instead.
+type Code string
+
+// Error codes
+const (
+	// CodeInternal is the code of errors that are not domain errors
+	CodeInternal Code = "INTERNAL"
+
+	// CodeValidationFailed is the code of invalid input
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+
+	CodeOrganizationNotFound   Code = "ORGANIZATION_NOT_FOUND"
+	CodeSlugTaken              Code = "SLUG_TAKEN"
+	CodeMemberNotFound         Code = "MEMBER_NOT_FOUND"
+	CodeAlreadyMember          Code = "ALREADY_MEMBER"
+	CodeLastOwner              Code = "LAST_OWNER"
+	CodeUserNotFound           Code = "USER_NOT_FOUND"
+	CodeEmailUnavailable       Code = "EMAIL_UNAVAILABLE"
+	CodeInvitationNotFound     Code = "INVITATION_NOT_FOUND"
+	CodeInvitationExists       Code = "INVITATION_EXISTS"
+	CodeInvalidInvitationToken Code = "INVALID_INVITATION_TOKEN"
+	CodeInvitationExpired      Code = "INVITATION_EXPIRED"
+	CodeInvitationNotPending   Code = "INVITATION_NOT_PENDING"
+)
+
+// Error is a domain error carrying a code. The errors below are its
+// sentinels; Wrap attaches the underlying cause to one of them.
+type Error struct {
+	// Code identifies the error for clients
+	Code Code
+
+	// Message describes the error
+	Message string
+
+	// Err is the underlying cause, if any
+	Err error
+}
+
+// NewError creates a domain error
+func NewError(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the same domain error, whatever the causes
+// of either, so errors.Is matches a wrapped error to its sentinel
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == e.Message
+}
+
+// Wrap returns a copy of the error caused by err
+func (e *Error) Wrap(err error) *Error {
+	return &Error{Code: e.Code, Message: e.Message, Err: err}
+}
+
+// CodeOf returns the code of the first domain error in err's tree, or
+// CodeInternal when there is none
+func CodeOf(err error) Code {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return CodeInternal
+}
+
+var (
+	// ErrOrganizationNotFound indicates organization was not found
+	ErrOrganizationNotFound = NewError(CodeOrganizationNotFound, "organization not found")
+
+	// ErrInvalidOrganizationName indicates name is invalid
+	ErrInvalidOrganizationName = NewError(CodeValidationFailed, "name must be non-empty and not exceed 255 characters")
+
+	// ErrInvalidSlug indicates slug is invalid
+	ErrInvalidSlug = NewError(CodeValidationFailed, "slug must be 2-50 lowercase letters, digits or single - separators")
+
+	// ErrDuplicateSlug indicates slug already exists
+	ErrDuplicateSlug = NewError(CodeSlugTaken, "slug already exists")
+
+	// ErrMemberNotFound indicates the user is not a member of the organization
+	ErrMemberNotFound = NewError(CodeMemberNotFound, "member not found")
+
+	// ErrAlreadyMember indicates the user is already a member of the organization
+	ErrAlreadyMember = NewError(CodeAlreadyMember, "user is already a member")
+
+	// ErrInvalidRole indicates the role is not a known membership role
+	ErrInvalidRole = NewError(CodeValidationFailed, "role must be one of owner, admin, member")
+
+	// ErrLastOwner indicates the change would leave the organization without an owner
+	ErrLastOwner = NewError(CodeLastOwner, "organization must keep at least one owner")
+
+	// ErrUserNotFound indicates the user to add does not exist
+	ErrUserNotFound = NewError(CodeUserNotFound, "user not found")
+
+	// ErrInvalidEmail indicates the invited email is invalid
+	ErrInvalidEmail = NewError(CodeValidationFailed, "invalid email format")
+
+	// ErrInvalidUserName indicates the name for an account created on acceptance is invalid
+	ErrInvalidUserName = NewError(CodeValidationFailed, "name is required to create an account and must not exceed 255 characters")
+
+	// ErrEmailUnavailable indicates the invited email belongs to a deleted account
+	ErrEmailUnavailable = NewError(CodeEmailUnavailable, "email belongs to a deleted account")
+
+	// ErrInvitationNotFound indicates invitation was not found
+	ErrInvitationNotFound = NewError(CodeInvitationNotFound, "invitation not found")
+
+	// ErrInvitationExists indicates a pending invitation already exists for the email
+	ErrInvitationExists = NewError(CodeInvitationExists, "a pending invitation already exists for this email")
+
+	// ErrInvalidInvitationToken indicates the invitation token does not match any invitation
+	ErrInvalidInvitationToken = NewError(CodeInvalidInvitationToken, "invalid invitation token")
+
+	// ErrInvitationExpired indicates the invitation was not accepted in time
+	ErrInvitationExpired = NewError(CodeInvitationExpired, "invitation has expired")
+
+	// ErrInvitationNotPending indicates the invitation was already accepted or revoked
+	ErrInvitationNotPending = NewError(CodeInvitationNotPending, "invitation was already accepted or revoked")
+)
@@ -0,0 +1,8 @@
+package domain
+
+// OrganizationFilter narrows which organizations are returned by listing
+// operations. Zero values mean "no restriction".
+type OrganizationFilter struct {
+	// MemberID matches organizations the user is a member of
+	MemberID string
+}
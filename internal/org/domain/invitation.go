@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+const maxEmailLength = 254
+
+// InvitationStatus is the state of an invitation, derived from its timestamps
+type InvitationStatus string
+
+const (
+	// InvitationPending invitations can still be accepted
+	InvitationPending InvitationStatus = "pending"
+
+	// InvitationAccepted invitations were used to join the organization
+	InvitationAccepted InvitationStatus = "accepted"
+
+	// InvitationRevoked invitations were withdrawn by the organization
+	InvitationRevoked InvitationStatus = "revoked"
+
+	// InvitationExpired invitations were not accepted in time; resending renews them
+	InvitationExpired InvitationStatus = "expired"
+)
+
+// Invitation invites an email address to join an organization with a role.
+// Only a hash of the token sent to the invitee is kept.
+type Invitation struct {
+	ID             string
+	OrganizationID string
+	Email          string
+	Role           Role
+	TokenHash      string
+	ExpiresAt      time.Time
+	AcceptedAt     *time.Time
+	RevokedAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewInvitation creates a new invitation with the given ID, valid for ttl
+// from now, and returns the token that must be delivered to the invitee.
+// Invalid fields are reported together in a *validation.Error.
+func NewInvitation(id, organizationID, email string, role Role, ttl time.Duration, now time.Time) (*Invitation, string, error) {
+	var v validation.Validator
+
+	email = NormalizeEmail(email)
+	if !isValidEmail(email) {
+		v.Check("email", ErrInvalidEmail)
+	}
+	if !role.IsValid() {
+		v.Check("role", ErrInvalidRole)
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, "", err
+	}
+
+	token := rand.Text()
+
+	return &Invitation{
+		ID:             id,
+		OrganizationID: organizationID,
+		Email:          email,
+		Role:           role,
+		TokenHash:      HashInvitationToken(token),
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, token, nil
+}
+
+// Status returns the invitation's state at now
+func (i *Invitation) Status(now time.Time) InvitationStatus {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case now.After(i.ExpiresAt):
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
+
+// Renew replaces the token and restarts the expiry, invalidating the link
+// sent previously. Accepted and revoked invitations cannot be renewed.
+func (i *Invitation) Renew(ttl time.Duration, now time.Time) (string, error) {
+	if i.AcceptedAt != nil || i.RevokedAt != nil {
+		return "", ErrInvitationNotPending
+	}
+
+	token := rand.Text()
+
+	i.TokenHash = HashInvitationToken(token)
+	i.ExpiresAt = now.Add(ttl)
+	i.UpdatedAt = now
+	return token, nil
+}
+
+// Revoke withdraws the invitation
+func (i *Invitation) Revoke(now time.Time) error {
+	if i.AcceptedAt != nil || i.RevokedAt != nil {
+		return ErrInvitationNotPending
+	}
+
+	i.RevokedAt = &now
+	i.UpdatedAt = now
+	return nil
+}
+
+// Accept marks the invitation as used
+func (i *Invitation) Accept(now time.Time) error {
+	switch i.Status(now) {
+	case InvitationPending:
+	case InvitationExpired:
+		return ErrInvitationExpired
+	default:
+		return ErrInvitationNotPending
+	}
+
+	i.AcceptedAt = &now
+	i.UpdatedAt = now
+	return nil
+}
+
+// HashInvitationToken returns the hex-encoded SHA-256 digest of a token,
+// which is how tokens are stored and looked up
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeEmail returns the canonical, lowercased form of an email address
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// isValidEmail reports whether email is a bare address
+func isValidEmail(email string) bool {
+	if email == "" || len(email) > maxEmailLength {
+		return false
+	}
+
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNow is the time passed to domain methods in tests
+var testNow = time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC)
+
+// testID is the ID given to entities created in domain tests
+const testID = "550e8400-e29b-41d4-a716-446655440000"
+
+func TestNewInvitation(t *testing.T) {
+	invitation, token, err := NewInvitation(testID, "org-1", "  Invitee@Example.com ", RoleAdmin, time.Hour, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, testID, invitation.ID)
+	assert.Equal(t, "invitee@example.com", invitation.Email)
+	assert.Equal(t, RoleAdmin, invitation.Role)
+	assert.Equal(t, InvitationPending, invitation.Status(testNow))
+	assert.Equal(t, testNow.Add(time.Hour), invitation.ExpiresAt)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, HashInvitationToken(token), invitation.TokenHash)
+	assert.NotContains(t, invitation.TokenHash, token)
+
+	_, _, err = NewInvitation(testID, "org-1", "not-an-email", RoleMember, time.Hour, testNow)
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+
+	_, _, err = NewInvitation(testID, "org-1", "Invitee <invitee@example.com>", RoleMember, time.Hour, testNow)
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+
+	_, _, err = NewInvitation(testID, "org-1", "invitee@example.com", Role("guest"), time.Hour, testNow)
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestInvitation_Status(t *testing.T) {
+	now := testNow
+
+	tests := []struct {
+		name       string
+		invitation Invitation
+		want       InvitationStatus
+	}{
+		{name: "pending", invitation: Invitation{ExpiresAt: now.Add(time.Hour)}, want: InvitationPending},
+		{name: "expired", invitation: Invitation{ExpiresAt: now.Add(-time.Hour)}, want: InvitationExpired},
+		{name: "accepted", invitation: Invitation{ExpiresAt: now.Add(-time.Hour), AcceptedAt: &now}, want: InvitationAccepted},
+		{name: "revoked", invitation: Invitation{ExpiresAt: now.Add(time.Hour), RevokedAt: &now}, want: InvitationRevoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.invitation.Status(testNow))
+		})
+	}
+}
+
+func TestInvitation_Renew(t *testing.T) {
+	invitation, token, err := NewInvitation(testID, "org-1", "invitee@example.com", RoleMember, time.Hour, testNow)
+	require.NoError(t, err)
+
+	later := testNow.Add(2 * time.Hour)
+	assert.Equal(t, InvitationExpired, invitation.Status(later))
+
+	renewed, err := invitation.Renew(time.Hour, later)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, renewed)
+	assert.Equal(t, HashInvitationToken(renewed), invitation.TokenHash)
+	assert.Equal(t, later.Add(time.Hour), invitation.ExpiresAt)
+	assert.Equal(t, InvitationPending, invitation.Status(later))
+
+	require.NoError(t, invitation.Revoke(later))
+	_, err = invitation.Renew(time.Hour, later)
+	assert.ErrorIs(t, err, ErrInvitationNotPending)
+}
+
+func TestInvitation_Revoke(t *testing.T) {
+	invitation, _, err := NewInvitation(testID, "org-1", "invitee@example.com", RoleMember, time.Hour, testNow)
+	require.NoError(t, err)
+
+	require.NoError(t, invitation.Revoke(testNow))
+	assert.Equal(t, InvitationRevoked, invitation.Status(testNow))
+	assert.ErrorIs(t, invitation.Revoke(testNow), ErrInvitationNotPending)
+}
+
+func TestInvitation_Accept(t *testing.T) {
+	t.Run("pending", func(t *testing.T) {
+		invitation, _, err := NewInvitation(testID, "org-1", "invitee@example.com", RoleMember, time.Hour, testNow)
+		require.NoError(t, err)
+
+		require.NoError(t, invitation.Accept(testNow))
+		assert.Equal(t, InvitationAccepted, invitation.Status(testNow))
+		assert.ErrorIs(t, invitation.Accept(testNow), ErrInvitationNotPending)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		invitation, _, err := NewInvitation(testID, "org-1", "invitee@example.com", RoleMember, time.Hour, testNow)
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, invitation.Accept(testNow.Add(time.Hour+time.Second)), ErrInvitationExpired)
+		assert.Nil(t, invitation.AcceptedAt)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		invitation, _, err := NewInvitation(testID, "org-1", "invitee@example.com", RoleMember, time.Hour, testNow)
+		require.NoError(t, err)
+		require.NoError(t, invitation.Revoke(testNow))
+
+		assert.ErrorIs(t, invitation.Accept(testNow), ErrInvitationNotPending)
+	})
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// Role is a member's permission level within an organization
+type Role string
+
+const (
+	// RoleOwner members manage the organization and its owners
+	RoleOwner Role = "owner"
+
+	// RoleAdmin members manage the organization's members
+	RoleAdmin Role = "admin"
+
+	// RoleMember members belong to the organization
+	RoleMember Role = "member"
+)
+
+// ParseRole converts a string to a Role, rejecting unknown values
+func ParseRole(s string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(s)))
+	if !role.IsValid() {
+		return "", ErrInvalidRole
+	}
+	return role, nil
+}
+
+// IsValid reports whether the role is a known membership role
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleOwner, RoleAdmin, RoleMember:
+		return true
+	default:
+		return false
+	}
+}
+
+// Membership links a user to an organization with a role
+type Membership struct {
+	OrganizationID string
+	UserID         string
+	Role           Role
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// NewMembership creates a new membership with validation
+func NewMembership(organizationID, userID string, role Role, now time.Time) (*Membership, error) {
+	if !role.IsValid() {
+		return nil, validation.Field("role", ErrInvalidRole)
+	}
+
+	return &Membership{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// ChangeRole updates the member's role
+func (m *Membership) ChangeRole(role Role, now time.Time) error {
+	if !role.IsValid() {
+		return validation.Field("role", ErrInvalidRole)
+	}
+
+	m.Role = role
+	m.UpdatedAt = now
+	return nil
+}
+
+// IsOwner reports whether the member owns the organization
+func (m *Membership) IsOwner() bool {
+	return m.Role == RoleOwner
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole(" Admin ")
+	require.NoError(t, err)
+	assert.Equal(t, RoleAdmin, role)
+
+	_, err = ParseRole("guest")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestNewMembership(t *testing.T) {
+	member, err := NewMembership("org-1", "user-1", RoleOwner, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, "org-1", member.OrganizationID)
+	assert.Equal(t, "user-1", member.UserID)
+	assert.True(t, member.IsOwner())
+
+	_, err = NewMembership("org-1", "user-1", Role("guest"), testNow)
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestMembership_ChangeRole(t *testing.T) {
+	member, err := NewMembership("org-1", "user-1", RoleOwner, testNow)
+	require.NoError(t, err)
+
+	require.NoError(t, member.ChangeRole(RoleMember, testNow))
+	assert.Equal(t, RoleMember, member.Role)
+	assert.False(t, member.IsOwner())
+
+	assert.ErrorIs(t, member.ChangeRole(Role("guest"), testNow), ErrInvalidRole)
+	assert.Equal(t, RoleMember, member.Role)
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// Slugs are URL-safe handles: lowercase alphanumerics separated by single hyphens
+var slugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+const (
+	maxNameLength = 255
+	minSlugLength = 2
+	maxSlugLength = 50
+)
+
+// Organization represents a group of users
+type Organization struct {
+	ID        string
+	Name      string
+	Slug      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewOrganization creates a new organization with the given ID and
+// validation. Invalid fields are reported together in a *validation.Error.
+func NewOrganization(id, name, slug string, now time.Time) (*Organization, error) {
+	var v validation.Validator
+
+	name = strings.TrimSpace(name)
+	v.Check("name", isValidName(name))
+
+	slug = NormalizeSlug(slug)
+	if !isValidSlug(slug) {
+		v.Check("slug", ErrInvalidSlug)
+	}
+
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	return &Organization{
+		ID:        id,
+		Name:      name,
+		Slug:      slug,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// UpdateName updates the organization's name
+func (o *Organization) UpdateName(name string, now time.Time) error {
+	name = strings.TrimSpace(name)
+	if err := isValidName(name); err != nil {
+		return validation.Field("name", err)
+	}
+
+	o.Name = name
+	o.UpdatedAt = now
+	return nil
+}
+
+// NormalizeSlug returns the canonical form of a slug.
+// Slugs are compared case-insensitively, so they are stored lowercased.
+func NormalizeSlug(slug string) string {
+	return strings.ToLower(strings.TrimSpace(slug))
+}
+
+// isValidName validates organization name
+func isValidName(name string) error {
+	if name == "" || len(name) > maxNameLength {
+		return ErrInvalidOrganizationName
+	}
+	return nil
+}
+
+// isValidSlug validates slug length and format
+func isValidSlug(slug string) bool {
+	if len(slug) < minSlugLength || len(slug) > maxSlugLength {
+		return false
+	}
+	return slugRegex.MatchString(slug)
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+func TestNewOrganization(t *testing.T) {
+	tests := []struct {
+		name     string
+		orgName  string
+		slug     string
+		wantSlug string
+		wantErr  error
+	}{
+		{name: "valid organization", orgName: "Acme Inc", slug: "acme", wantSlug: "acme"},
+		{name: "slug is normalized", orgName: "Acme Inc", slug: "  Acme-Labs ", wantSlug: "acme-labs"},
+		{name: "empty name", orgName: "   ", slug: "acme", wantErr: ErrInvalidOrganizationName},
+		{name: "name too long", orgName: strings.Repeat("a", 256), slug: "acme", wantErr: ErrInvalidOrganizationName},
+		{name: "slug too short", orgName: "Acme", slug: "a", wantErr: ErrInvalidSlug},
+		{name: "slug too long", orgName: "Acme", slug: strings.Repeat("a", 51), wantErr: ErrInvalidSlug},
+		{name: "slug with spaces", orgName: "Acme", slug: "acme labs", wantErr: ErrInvalidSlug},
+		{name: "slug with double hyphen", orgName: "Acme", slug: "acme--labs", wantErr: ErrInvalidSlug},
+		{name: "slug with leading hyphen", orgName: "Acme", slug: "-acme", wantErr: ErrInvalidSlug},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			org, err := NewOrganization(testID, tt.orgName, tt.slug, testNow)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, org)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testID, org.ID)
+			assert.Equal(t, strings.TrimSpace(tt.orgName), org.Name)
+			assert.Equal(t, tt.wantSlug, org.Slug)
+			assert.False(t, org.CreatedAt.IsZero())
+		})
+	}
+}
+
+func TestNewOrganization_ReportsEveryInvalidField(t *testing.T) {
+	_, err := NewOrganization(testID, "", "a", testNow)
+	assert.Equal(t, []validation.Violation{
+		{Field: "name", Err: ErrInvalidOrganizationName},
+		{Field: "slug", Err: ErrInvalidSlug},
+	}, validation.Violations(err))
+}
+
+func TestOrganization_UpdateName(t *testing.T) {
+	org, err := NewOrganization(testID, "Acme", "acme", testNow)
+	require.NoError(t, err)
+	updatedAt := org.UpdatedAt
+
+	require.NoError(t, org.UpdateName("  Acme Labs  ", testNow))
+	assert.Equal(t, "Acme Labs", org.Name)
+	assert.False(t, org.UpdatedAt.Before(updatedAt))
+
+	assert.ErrorIs(t, org.UpdateName("", testNow), ErrInvalidOrganizationName)
+	assert.Equal(t, "Acme Labs", org.Name)
+}
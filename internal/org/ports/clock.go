@@ -0,0 +1,9 @@
+package ports
+
+import "time"
+
+// Clock tells services the current time, which decides when invitations expire
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
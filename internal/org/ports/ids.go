@@ -0,0 +1,7 @@
+package ports
+
+// IDGenerator creates identifiers for new organizations and invitations
+type IDGenerator interface {
+	// NewID returns a new unique identifier
+	NewID() string
+}
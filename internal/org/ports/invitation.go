@@ -0,0 +1,53 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+)
+
+//go:generate mockery --name=InvitationRepository --output=mocks --outpkg=mocks
+
+// InvitationRepository defines the interface for invitation data access
+type InvitationRepository interface {
+	// Create creates a new invitation
+	Create(ctx context.Context, invitation *domain.Invitation) error
+
+	// GetByID retrieves an invitation by ID
+	GetByID(ctx context.Context, id string) (*domain.Invitation, error)
+
+	// GetByTokenHash retrieves an invitation by the hash of its token
+	GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invitation, error)
+
+	// FindPending retrieves the invitation for the email that is neither
+	// accepted, revoked nor expired at now, if any
+	FindPending(ctx context.Context, orgID, email string, now time.Time) (*domain.Invitation, error)
+
+	// Update updates an existing invitation
+	Update(ctx context.Context, invitation *domain.Invitation) error
+
+	// List retrieves the invitations of an organization with pagination
+	List(ctx context.Context, orgID string, limit, offset int) ([]*domain.Invitation, error)
+}
+
+//go:generate mockery --name=InvitationService --output=mocks --outpkg=mocks
+
+// InvitationService defines the interface for inviting users to organizations
+type InvitationService interface {
+	// Invite invites an email address to join an organization and mails the invitation link
+	Invite(ctx context.Context, orgID, email string, role domain.Role) (*domain.Invitation, error)
+
+	// ListInvitations retrieves the invitations of an organization with pagination
+	ListInvitations(ctx context.Context, orgID string, limit, offset int) ([]*domain.Invitation, error)
+
+	// ResendInvitation mails a new link, invalidating the previous one and restarting the expiry
+	ResendInvitation(ctx context.Context, orgID, id string) (*domain.Invitation, error)
+
+	// RevokeInvitation withdraws a pending invitation
+	RevokeInvitation(ctx context.Context, orgID, id string) error
+
+	// AcceptInvitation adds the invitee to the organization, creating their
+	// account with the given name if the email has none
+	AcceptInvitation(ctx context.Context, token, name string) (*domain.Membership, error)
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+//go:generate mockery --name=Mailer --output=mocks --outpkg=mocks
+
+// Mailer defines the interface for sending emails to invitees
+type Mailer interface {
+	// Send sends a plain-text email
+	Send(ctx context.Context, to, subject, body string) error
+}
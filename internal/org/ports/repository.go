@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+)
+
+//go:generate mockery --name=OrganizationRepository --output=mocks --outpkg=mocks
+
+// OrganizationRepository defines the interface for organization data access
+type OrganizationRepository interface {
+	// Create creates a new organization together with its first owner
+	Create(ctx context.Context, org *domain.Organization, owner *domain.Membership) error
+
+	// GetByID retrieves an organization by ID
+	GetByID(ctx context.Context, id string) (*domain.Organization, error)
+
+	// Update updates an existing organization
+	Update(ctx context.Context, org *domain.Organization) error
+
+	// Delete deletes an organization and all of its memberships
+	Delete(ctx context.Context, id string) error
+
+	// List retrieves organizations matching the filter with pagination
+	List(ctx context.Context, filter domain.OrganizationFilter, limit, offset int) ([]*domain.Organization, error)
+
+	// AddMember adds a user to an organization
+	AddMember(ctx context.Context, member *domain.Membership) error
+
+	// GetMember retrieves a user's membership of an organization
+	GetMember(ctx context.Context, orgID, userID string) (*domain.Membership, error)
+
+	// UpdateMember updates an existing membership
+	UpdateMember(ctx context.Context, member *domain.Membership) error
+
+	// RemoveMember removes a user from an organization
+	RemoveMember(ctx context.Context, orgID, userID string) error
+
+	// ListMembers retrieves the members of an organization with pagination
+	ListMembers(ctx context.Context, orgID string, limit, offset int) ([]*domain.Membership, error)
+
+	// CountOwners counts the owners of an organization
+	CountOwners(ctx context.Context, orgID string) (int, error)
+}
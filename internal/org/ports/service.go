@@ -0,0 +1,39 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+)
+
+//go:generate mockery --name=OrganizationService --output=mocks --outpkg=mocks
+
+// OrganizationService defines the interface for organization business logic
+type OrganizationService interface {
+	// CreateOrganization creates a new organization owned by the given user
+	CreateOrganization(ctx context.Context, name, slug, ownerID string) (*domain.Organization, error)
+
+	// GetOrganization retrieves an organization by ID
+	GetOrganization(ctx context.Context, id string) (*domain.Organization, error)
+
+	// UpdateOrganization updates an organization's name
+	UpdateOrganization(ctx context.Context, id, name string) (*domain.Organization, error)
+
+	// DeleteOrganization deletes an organization and all of its memberships
+	DeleteOrganization(ctx context.Context, id string) error
+
+	// ListOrganizations retrieves organizations matching the filter with pagination
+	ListOrganizations(ctx context.Context, filter domain.OrganizationFilter, limit, offset int) ([]*domain.Organization, error)
+
+	// AddMember adds an existing user to an organization
+	AddMember(ctx context.Context, orgID, userID string, role domain.Role) (*domain.Membership, error)
+
+	// ChangeMemberRole changes a member's role, keeping at least one owner
+	ChangeMemberRole(ctx context.Context, orgID, userID string, role domain.Role) (*domain.Membership, error)
+
+	// RemoveMember removes a member, keeping at least one owner
+	RemoveMember(ctx context.Context, orgID, userID string) error
+
+	// ListMembers retrieves the members of an organization with pagination
+	ListMembers(ctx context.Context, orgID string, limit, offset int) ([]*domain.Membership, error)
+}
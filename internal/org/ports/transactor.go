@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+//go:generate mockery --name=Transactor --output=mocks --outpkg=mocks
+
+// Transactor defines the interface for running work atomically across repositories
+type Transactor interface {
+	// WithinTransaction runs fn in a transaction carried by the context passed to fn
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "context"
+
+//go:generate mockery --name=UserDirectory --output=mocks --outpkg=mocks
+
+// UserDirectory defines the interface for looking up users owned by the user
+// feature, so organizations do not depend on its internals
+type UserDirectory interface {
+	// Exists reports whether a user with the ID exists
+	Exists(ctx context.Context, userID string) (bool, error)
+
+	// FindOrCreateByEmail returns the ID of the user with the email, creating
+	// the user with the given name if there is none
+	FindOrCreateByEmail(ctx context.Context, email, name string) (string, error)
+}
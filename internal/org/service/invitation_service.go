@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports"
+)
+
+// InvitationOptions configures the invitation service
+type InvitationOptions struct {
+	// TTL is how long an invitation link stays valid
+	TTL time.Duration
+
+	// AcceptURL is the page invitees open to accept; the token is appended
+	// as the token query parameter
+	AcceptURL string
+}
+
+// InvitationService implements the InvitationService port
+type InvitationService struct {
+	invitations ports.InvitationRepository
+	orgs        ports.OrganizationRepository
+	users       ports.UserDirectory
+	mailer      ports.Mailer
+	tx          ports.Transactor
+	clock       ports.Clock
+	ids         ports.IDGenerator
+	opts        InvitationOptions
+}
+
+// NewInvitationService creates a new invitation service
+func NewInvitationService(
+	invitations ports.InvitationRepository,
+	orgs ports.OrganizationRepository,
+	users ports.UserDirectory,
+	mailer ports.Mailer,
+	tx ports.Transactor,
+	clock ports.Clock,
+	ids ports.IDGenerator,
+	opts InvitationOptions,
+) ports.InvitationService {
+	return &InvitationService{
+		invitations: invitations,
+		orgs:        orgs,
+		users:       users,
+		mailer:      mailer,
+		tx:          tx,
+		clock:       clock,
+		ids:         ids,
+		opts:        opts,
+	}
+}
+
+// Invite invites an email address to join an organization and mails the invitation link
+func (s *InvitationService) Invite(ctx context.Context, orgID, email string, role domain.Role) (*domain.Invitation, error) {
+	org, err := s.orgs.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, token, err := domain.NewInvitation(s.ids.NewID(), org.ID, email, role, s.opts.TTL, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Resending is the way to get a fresh link for a pending invitation
+	pending, err := s.invitations.FindPending(ctx, org.ID, invitation.Email, s.clock.Now())
+	if err != nil && !errors.Is(err, domain.ErrInvitationNotFound) {
+		return nil, err
+	}
+	if pending != nil {
+		return nil, domain.ErrInvitationExists
+	}
+
+	if err := s.invitations.Create(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	if err := s.sendInvitation(ctx, org, invitation, token); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// ListInvitations retrieves the invitations of an organization with pagination
+func (s *InvitationService) ListInvitations(ctx context.Context, orgID string, limit, offset int) ([]*domain.Invitation, error) {
+	if _, err := s.orgs.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.invitations.List(ctx, orgID, limit, offset)
+}
+
+// ResendInvitation mails a new link, invalidating the previous one and restarting the expiry
+func (s *InvitationService) ResendInvitation(ctx context.Context, orgID, id string) (*domain.Invitation, error) {
+	org, err := s.orgs.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := s.getInvitation(ctx, org.ID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := invitation.Renew(s.opts.TTL, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.invitations.Update(ctx, invitation); err != nil {
+		return nil, err
+	}
+
+	if err := s.sendInvitation(ctx, org, invitation, token); err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// RevokeInvitation withdraws a pending invitation
+func (s *InvitationService) RevokeInvitation(ctx context.Context, orgID, id string) error {
+	invitation, err := s.getInvitation(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := invitation.Revoke(s.clock.Now()); err != nil {
+		return err
+	}
+
+	return s.invitations.Update(ctx, invitation)
+}
+
+// AcceptInvitation adds the invitee to the organization, creating their
+// account with the given name if the email has none. The account, the
+// membership and the accepted invitation are saved in one transaction.
+func (s *InvitationService) AcceptInvitation(ctx context.Context, token, name string) (*domain.Membership, error) {
+	invitation, err := s.invitations.GetByTokenHash(ctx, domain.HashInvitationToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvitationNotFound) {
+			return nil, domain.ErrInvalidInvitationToken
+		}
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if err := invitation.Accept(now); err != nil {
+		return nil, err
+	}
+
+	var member *domain.Membership
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		userID, err := s.users.FindOrCreateByEmail(ctx, invitation.Email, name)
+		if err != nil {
+			return err
+		}
+
+		member, err = domain.NewMembership(invitation.OrganizationID, userID, invitation.Role, now)
+		if err != nil {
+			return err
+		}
+
+		if err := s.orgs.AddMember(ctx, member); err != nil {
+			return err
+		}
+
+		return s.invitations.Update(ctx, invitation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// getInvitation retrieves an invitation, hiding invitations of other organizations
+func (s *InvitationService) getInvitation(ctx context.Context, orgID, id string) (*domain.Invitation, error) {
+	invitation, err := s.invitations.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if invitation.OrganizationID != orgID {
+		return nil, domain.ErrInvitationNotFound
+	}
+
+	return invitation, nil
+}
+
+// sendInvitation mails the invitation link to the invitee
+func (s *InvitationService) sendInvitation(ctx context.Context, org *domain.Organization, invitation *domain.Invitation, token string) error {
+	link := s.opts.AcceptURL + "?token=" + url.QueryEscape(token)
+	subject := fmt.Sprintf("You are invited to join %s", org.Name)
+	body := fmt.Sprintf(
+		"You have been invited to join %s as %s.\n\nAccept the invitation before %s:\n\n%s\n",
+		org.Name, invitation.Role, invitation.ExpiresAt.UTC().Format(time.RFC1123), link,
+	)
+
+	if err := s.mailer.Send(ctx, invitation.Email, subject, body); err != nil {
+		return fmt.Errorf("failed to send invitation: %w", err)
+	}
+
+	return nil
+}
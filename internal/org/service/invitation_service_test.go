@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports/mocks"
+)
+
+// testNow is the time reported by the fake clock in service tests
+var testNow = time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC)
+
+type invitationMocks struct {
+	invitations *mocks.MockInvitationRepository
+	orgs        *mocks.MockOrganizationRepository
+	users       *mocks.MockUserDirectory
+	mailer      *mocks.MockMailer
+	tx          *mocks.MockTransactor
+	clock       *clock.Fake
+}
+
+func newTestInvitationService() (*invitationMocks, *InvitationService) {
+	m := &invitationMocks{
+		invitations: new(mocks.MockInvitationRepository),
+		orgs:        new(mocks.MockOrganizationRepository),
+		users:       new(mocks.MockUserDirectory),
+		mailer:      new(mocks.MockMailer),
+		tx:          new(mocks.MockTransactor),
+		clock:       clock.NewFake(testNow),
+	}
+
+	// Run transactional work directly; rollback is covered by the database tests
+	m.tx.EXPECT().WithinTransaction(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		}).Maybe()
+
+	service := NewInvitationService(m.invitations, m.orgs, m.users, m.mailer, m.tx, m.clock, idgen.NewSequential(), InvitationOptions{
+		TTL:       time.Hour,
+		AcceptURL: "https://app.example.com/invitations/accept",
+	}).(*InvitationService)
+
+	return m, service
+}
+
+func TestInvitationService_Invite(t *testing.T) {
+	m, service := newTestInvitationService()
+	ctx := context.Background()
+	org := &domain.Organization{ID: "org-1", Name: "Acme"}
+
+	var body string
+	m.orgs.On("GetByID", ctx, "org-1").Return(org, nil)
+	m.invitations.On("FindPending", ctx, "org-1", "invitee@example.com", testNow).Return(nil, domain.ErrInvitationNotFound)
+	m.invitations.On("Create", ctx, mock.AnythingOfType("*domain.Invitation")).Return(nil)
+	m.mailer.On("Send", ctx, "invitee@example.com", "You are invited to join Acme", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { body = args.String(3) }).
+		Return(nil)
+
+	invitation, err := service.Invite(ctx, "org-1", "Invitee@Example.com", domain.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, idgen.SequentialID(1), invitation.ID)
+	assert.Equal(t, "invitee@example.com", invitation.Email)
+	assert.Equal(t, domain.RoleAdmin, invitation.Role)
+
+	// The mailed link carries the token matching the stored hash
+	_, token, found := strings.Cut(body, "https://app.example.com/invitations/accept?token=")
+	require.True(t, found)
+	assert.Equal(t, invitation.TokenHash, domain.HashInvitationToken(strings.TrimSpace(token)))
+
+	m.orgs.AssertExpectations(t)
+	m.invitations.AssertExpectations(t)
+	m.mailer.AssertExpectations(t)
+}
+
+func TestInvitationService_Invite_PendingExists(t *testing.T) {
+	m, service := newTestInvitationService()
+	ctx := context.Background()
+
+	m.orgs.On("GetByID", ctx, "org-1").Return(&domain.Organization{ID: "org-1"}, nil)
+	m.invitations.On("FindPending", ctx, "org-1", "invitee@example.com", testNow).Return(&domain.Invitation{ID: "inv-1"}, nil)
+
+	_, err := service.Invite(ctx, "org-1", "invitee@example.com", domain.RoleMember)
+	assert.ErrorIs(t, err, domain.ErrInvitationExists)
+
+	m.invitations.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	m.mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInvitationService_ResendInvitation(t *testing.T) {
+	m, service := newTestInvitationService()
+	ctx := context.Background()
+
+	invitation, _, err := domain.NewInvitation("inv-1", "org-1", "invitee@example.com", domain.RoleMember, time.Hour, testNow)
+	require.NoError(t, err)
+	previousHash := invitation.TokenHash
+	now := m.clock.Advance(2 * time.Hour)
+
+	m.orgs.On("GetByID", ctx, "org-1").Return(&domain.Organization{ID: "org-1", Name: "Acme"}, nil)
+	m.invitations.On("GetByID", ctx, invitation.ID).Return(invitation, nil)
+	m.invitations.On("Update", ctx, invitation).Return(nil)
+	m.mailer.On("Send", ctx, "invitee@example.com", mock.Anything, mock.Anything).Return(nil)
+
+	resent, err := service.ResendInvitation(ctx, "org-1", invitation.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, previousHash, resent.TokenHash)
+	assert.Equal(t, now.Add(time.Hour), resent.ExpiresAt)
+	assert.Equal(t, domain.InvitationPending, resent.Status(now))
+
+	m.invitations.AssertExpectations(t)
+	m.mailer.AssertExpectations(t)
+}
+
+func TestInvitationService_RevokeInvitation_OtherOrganization(t *testing.T) {
+	m, service := newTestInvitationService()
+	ctx := context.Background()
+
+	m.invitations.On("GetByID", ctx, "inv-1").Return(&domain.Invitation{ID: "inv-1", OrganizationID: "org-2"}, nil)
+
+	err := service.RevokeInvitation(ctx, "org-1", "inv-1")
+	assert.ErrorIs(t, err, domain.ErrInvitationNotFound)
+
+	m.invitations.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestInvitationService_AcceptInvitation(t *testing.T) {
+	m, service := newTestInvitationService()
+	ctx := context.Background()
+
+	invitation, token, err := domain.NewInvitation("inv-1", "org-1", "invitee@example.com", domain.RoleAdmin, time.Hour, testNow)
+	require.NoError(t, err)
+
+	m.invitations.On("GetByTokenHash", ctx, invitation.TokenHash).Return(invitation, nil)
+	m.users.On("FindOrCreateByEmail", ctx, "invitee@example.com", "Invitee").Return("user-2", nil)
+	m.orgs.On("AddMember", ctx, mock.MatchedBy(func(member *domain.Membership) bool {
+		return member.OrganizationID == "org-1" && member.UserID == "user-2" && member.Role == domain.RoleAdmin
+	})).Return(nil)
+	m.invitations.On("Update", ctx, mock.MatchedBy(func(i *domain.Invitation) bool {
+		return i.Status(testNow) == domain.InvitationAccepted
+	})).Return(nil)
+
+	member, err := service.AcceptInvitation(ctx, token, "Invitee")
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", member.UserID)
+
+	m.invitations.AssertExpectations(t)
+	m.users.AssertExpectations(t)
+	m.orgs.AssertExpectations(t)
+	m.tx.AssertExpectations(t)
+}
+
+func TestInvitationService_AcceptInvitation_InvalidToken(t *testing.T) {
+	m, service := newTestInvitationService()
+	ctx := context.Background()
+
+	m.invitations.On("GetByTokenHash", ctx, domain.HashInvitationToken("bogus")).Return(nil, domain.ErrInvitationNotFound)
+
+	_, err := service.AcceptInvitation(ctx, "bogus", "Invitee")
+	assert.ErrorIs(t, err, domain.ErrInvalidInvitationToken)
+
+	m.tx.AssertNotCalled(t, "WithinTransaction", mock.Anything, mock.Anything)
+}
+
+func TestInvitationService_AcceptInvitation_Expired(t *testing.T) {
+	m, service := newTestInvitationService()
+	ctx := context.Background()
+
+	invitation, token, err := domain.NewInvitation("inv-1", "org-1", "invitee@example.com", domain.RoleMember, time.Hour, testNow)
+	require.NoError(t, err)
+	m.clock.Advance(time.Hour + time.Second)
+
+	m.invitations.On("GetByTokenHash", ctx, invitation.TokenHash).Return(invitation, nil)
+
+	_, err = service.AcceptInvitation(ctx, token, "Invitee")
+	assert.ErrorIs(t, err, domain.ErrInvitationExpired)
+
+	m.users.AssertNotCalled(t, "FindOrCreateByEmail", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInvitationService_AcceptInvitation_AlreadyMember(t *testing.T) {
+	m, service := newTestInvitationService()
+	ctx := context.Background()
+
+	invitation, token, err := domain.NewInvitation("inv-1", "org-1", "invitee@example.com", domain.RoleMember, time.Hour, testNow)
+	require.NoError(t, err)
+
+	m.invitations.On("GetByTokenHash", ctx, invitation.TokenHash).Return(invitation, nil)
+	m.users.On("FindOrCreateByEmail", ctx, "invitee@example.com", "").Return("user-2", nil)
+	m.orgs.On("AddMember", ctx, mock.AnythingOfType("*domain.Membership")).Return(domain.ErrAlreadyMember)
+
+	_, err = service.AcceptInvitation(ctx, token, "")
+	assert.ErrorIs(t, err, domain.ErrAlreadyMember)
+
+	m.invitations.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
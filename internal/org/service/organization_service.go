@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports"
+)
+
+// OrganizationService implements the OrganizationService port
+type OrganizationService struct {
+	repo  ports.OrganizationRepository
+	users ports.UserDirectory
+	clock ports.Clock
+	ids   ports.IDGenerator
+}
+
+// NewOrganizationService creates a new organization service
+func NewOrganizationService(repo ports.OrganizationRepository, users ports.UserDirectory, clock ports.Clock, ids ports.IDGenerator) ports.OrganizationService {
+	return &OrganizationService{
+		repo:  repo,
+		users: users,
+		clock: clock,
+		ids:   ids,
+	}
+}
+
+// CreateOrganization creates a new organization owned by the given user
+func (s *OrganizationService) CreateOrganization(ctx context.Context, name, slug, ownerID string) (*domain.Organization, error) {
+	org, err := domain.NewOrganization(s.ids.NewID(), name, slug, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureUserExists(ctx, ownerID); err != nil {
+		return nil, err
+	}
+
+	owner, err := domain.NewMembership(org.ID, ownerID, domain.RoleOwner, org.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, org, owner); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// GetOrganization retrieves an organization by ID
+func (s *OrganizationService) GetOrganization(ctx context.Context, id string) (*domain.Organization, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// UpdateOrganization updates an organization's name
+func (s *OrganizationService) UpdateOrganization(ctx context.Context, id, name string) (*domain.Organization, error) {
+	org, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := org.UpdateName(name, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(ctx, org); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// DeleteOrganization deletes an organization and all of its memberships
+func (s *OrganizationService) DeleteOrganization(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// ListOrganizations retrieves organizations matching the filter with pagination
+func (s *OrganizationService) ListOrganizations(ctx context.Context, filter domain.OrganizationFilter, limit, offset int) ([]*domain.Organization, error) {
+	return s.repo.List(ctx, filter, limit, offset)
+}
+
+// AddMember adds an existing user to an organization
+func (s *OrganizationService) AddMember(ctx context.Context, orgID, userID string, role domain.Role) (*domain.Membership, error) {
+	if _, err := s.repo.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	member, err := domain.NewMembership(orgID, userID, role, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureUserExists(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// ChangeMemberRole changes a member's role. The last owner cannot be demoted.
+func (s *OrganizationService) ChangeMemberRole(ctx context.Context, orgID, userID string, role domain.Role) (*domain.Membership, error) {
+	member, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.Role == role {
+		return member, nil
+	}
+
+	if member.IsOwner() {
+		if err := s.ensureNotLastOwner(ctx, orgID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := member.ChangeRole(role, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// RemoveMember removes a member. The last owner cannot be removed; delete
+// the organization instead.
+func (s *OrganizationService) RemoveMember(ctx context.Context, orgID, userID string) error {
+	member, err := s.repo.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	if member.IsOwner() {
+		if err := s.ensureNotLastOwner(ctx, orgID); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.RemoveMember(ctx, orgID, userID)
+}
+
+// ListMembers retrieves the members of an organization with pagination
+func (s *OrganizationService) ListMembers(ctx context.Context, orgID string, limit, offset int) ([]*domain.Membership, error) {
+	if _, err := s.repo.GetByID(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListMembers(ctx, orgID, limit, offset)
+}
+
+// ensureUserExists checks the user feature for the user
+func (s *OrganizationService) ensureUserExists(ctx context.Context, userID string) error {
+	exists, err := s.users.Exists(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// ensureNotLastOwner rejects changes that would leave the organization without an owner
+func (s *OrganizationService) ensureNotLastOwner(ctx context.Context, orgID string) error {
+	owners, err := s.repo.CountOwners(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return domain.ErrLastOwner
+	}
+	return nil
+}
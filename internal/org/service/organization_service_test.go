@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/org/domain"
+	"github.com/yourusername/go-scaffolding/internal/org/ports/mocks"
+)
+
+func TestOrganizationService_CreateOrganization(t *testing.T) {
+	mockRepo := new(mocks.MockOrganizationRepository)
+	mockUsers := new(mocks.MockUserDirectory)
+	service := NewOrganizationService(mockRepo, mockUsers, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+
+	mockUsers.On("Exists", ctx, "user-1").Return(true, nil)
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Organization"), mock.MatchedBy(func(m *domain.Membership) bool {
+		return m.UserID == "user-1" && m.Role == domain.RoleOwner
+	})).Return(nil)
+
+	org, err := service.CreateOrganization(ctx, "Acme", "Acme", "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, idgen.SequentialID(1), org.ID)
+	assert.Equal(t, "Acme", org.Name)
+	assert.Equal(t, "acme", org.Slug)
+
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestOrganizationService_CreateOrganization_OwnerNotFound(t *testing.T) {
+	mockRepo := new(mocks.MockOrganizationRepository)
+	mockUsers := new(mocks.MockUserDirectory)
+	service := NewOrganizationService(mockRepo, mockUsers, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+
+	mockUsers.On("Exists", ctx, "missing").Return(false, nil)
+
+	_, err := service.CreateOrganization(ctx, "Acme", "acme", "missing")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrganizationService_CreateOrganization_InvalidSlug(t *testing.T) {
+	mockRepo := new(mocks.MockOrganizationRepository)
+	mockUsers := new(mocks.MockUserDirectory)
+	service := NewOrganizationService(mockRepo, mockUsers, clock.NewFake(testNow), idgen.NewSequential())
+
+	_, err := service.CreateOrganization(context.Background(), "Acme", "acme labs", "user-1")
+	assert.ErrorIs(t, err, domain.ErrInvalidSlug)
+
+	mockUsers.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrganizationService_UpdateOrganization(t *testing.T) {
+	mockRepo := new(mocks.MockOrganizationRepository)
+	service := NewOrganizationService(mockRepo, new(mocks.MockUserDirectory), clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	org := &domain.Organization{ID: "org-1", Name: "Acme", Slug: "acme"}
+
+	mockRepo.On("GetByID", ctx, "org-1").Return(org, nil)
+	mockRepo.On("Update", ctx, org).Return(nil)
+
+	updated, err := service.UpdateOrganization(ctx, "org-1", "Acme Labs")
+	require.NoError(t, err)
+	assert.Equal(t, "Acme Labs", updated.Name)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrganizationService_AddMember(t *testing.T) {
+	mockRepo := new(mocks.MockOrganizationRepository)
+	mockUsers := new(mocks.MockUserDirectory)
+	service := NewOrganizationService(mockRepo, mockUsers, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, "org-1").Return(&domain.Organization{ID: "org-1"}, nil)
+	mockUsers.On("Exists", ctx, "user-2").Return(true, nil)
+	mockRepo.On("AddMember", ctx, mock.AnythingOfType("*domain.Membership")).Return(nil)
+
+	member, err := service.AddMember(ctx, "org-1", "user-2", domain.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, "org-1", member.OrganizationID)
+	assert.Equal(t, "user-2", member.UserID)
+	assert.Equal(t, domain.RoleAdmin, member.Role)
+
+	mockRepo.AssertExpectations(t)
+	mockUsers.AssertExpectations(t)
+}
+
+func TestOrganizationService_AddMember_OrganizationNotFound(t *testing.T) {
+	mockRepo := new(mocks.MockOrganizationRepository)
+	mockUsers := new(mocks.MockUserDirectory)
+	service := NewOrganizationService(mockRepo, mockUsers, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, "missing").Return(nil, domain.ErrOrganizationNotFound)
+
+	_, err := service.AddMember(ctx, "missing", "user-2", domain.RoleMember)
+	assert.ErrorIs(t, err, domain.ErrOrganizationNotFound)
+
+	mockUsers.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+}
+
+func TestOrganizationService_ChangeMemberRole(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("promotes member", func(t *testing.T) {
+		mockRepo := new(mocks.MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, new(mocks.MockUserDirectory), clock.NewFake(testNow), idgen.NewSequential())
+
+		member := &domain.Membership{OrganizationID: "org-1", UserID: "user-2", Role: domain.RoleMember}
+		mockRepo.On("GetMember", ctx, "org-1", "user-2").Return(member, nil)
+		mockRepo.On("UpdateMember", ctx, member).Return(nil)
+
+		updated, err := service.ChangeMemberRole(ctx, "org-1", "user-2", domain.RoleOwner)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleOwner, updated.Role)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CountOwners", mock.Anything, mock.Anything)
+	})
+
+	t.Run("demotes owner when another owner remains", func(t *testing.T) {
+		mockRepo := new(mocks.MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, new(mocks.MockUserDirectory), clock.NewFake(testNow), idgen.NewSequential())
+
+		member := &domain.Membership{OrganizationID: "org-1", UserID: "user-1", Role: domain.RoleOwner}
+		mockRepo.On("GetMember", ctx, "org-1", "user-1").Return(member, nil)
+		mockRepo.On("CountOwners", ctx, "org-1").Return(2, nil)
+		mockRepo.On("UpdateMember", ctx, member).Return(nil)
+
+		updated, err := service.ChangeMemberRole(ctx, "org-1", "user-1", domain.RoleAdmin)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RoleAdmin, updated.Role)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects demoting the last owner", func(t *testing.T) {
+		mockRepo := new(mocks.MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, new(mocks.MockUserDirectory), clock.NewFake(testNow), idgen.NewSequential())
+
+		member := &domain.Membership{OrganizationID: "org-1", UserID: "user-1", Role: domain.RoleOwner}
+		mockRepo.On("GetMember", ctx, "org-1", "user-1").Return(member, nil)
+		mockRepo.On("CountOwners", ctx, "org-1").Return(1, nil)
+
+		_, err := service.ChangeMemberRole(ctx, "org-1", "user-1", domain.RoleMember)
+		assert.ErrorIs(t, err, domain.ErrLastOwner)
+		assert.Equal(t, domain.RoleOwner, member.Role)
+
+		mockRepo.AssertNotCalled(t, "UpdateMember", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrganizationService_RemoveMember(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("removes member", func(t *testing.T) {
+		mockRepo := new(mocks.MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, new(mocks.MockUserDirectory), clock.NewFake(testNow), idgen.NewSequential())
+
+		member := &domain.Membership{OrganizationID: "org-1", UserID: "user-2", Role: domain.RoleMember}
+		mockRepo.On("GetMember", ctx, "org-1", "user-2").Return(member, nil)
+		mockRepo.On("RemoveMember", ctx, "org-1", "user-2").Return(nil)
+
+		require.NoError(t, service.RemoveMember(ctx, "org-1", "user-2"))
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects removing the last owner", func(t *testing.T) {
+		mockRepo := new(mocks.MockOrganizationRepository)
+		service := NewOrganizationService(mockRepo, new(mocks.MockUserDirectory), clock.NewFake(testNow), idgen.NewSequential())
+
+		member := &domain.Membership{OrganizationID: "org-1", UserID: "user-1", Role: domain.RoleOwner}
+		mockRepo.On("GetMember", ctx, "org-1", "user-1").Return(member, nil)
+		mockRepo.On("CountOwners", ctx, "org-1").Return(1, nil)
+
+		err := service.RemoveMember(ctx, "org-1", "user-1")
+		assert.ErrorIs(t, err, domain.ErrLastOwner)
+
+		mockRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
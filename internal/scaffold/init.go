@@ -0,0 +1,134 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/mod/module"
+)
+
+// appNameRegex matches an application name, used in config defaults,
+// binary and container names
+var appNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// generatedRegex matches the comment marking a generated Go file
+var generatedRegex = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// skippedDirs are the directories Init leaves alone
+var skippedDirs = map[string]bool{".git": true, "bin": true, "vendor": true, "node_modules": true}
+
+// Init renames the project in root to the module path modulePath and the
+// application name name, which defaults to the last element of the module
+// path. It rewrites the old module path, such as in go.mod, imports, the
+// proto go_package and documentation links, and the old application name,
+// such as in config defaults, container names and the CDC slot, in every
+// text file. Generated Go files only have their imports rewritten: their
+// embedded descriptors change once regenerated. Init returns the changed
+// files.
+func Init(root, modulePath, name string) ([]string, error) {
+	if err := module.CheckPath(modulePath); err != nil {
+		return nil, fmt.Errorf("invalid module path: %w", err)
+	}
+	if name == "" {
+		name = appName(modulePath)
+	}
+	if !appNameRegex.MatchString(name) {
+		return nil, fmt.Errorf("invalid name %q: use lowercase letters, digits and dashes, starting with a letter", name)
+	}
+
+	oldModule, err := ModulePath(root)
+	if err != nil {
+		return nil, err
+	}
+	oldName := appName(oldModule)
+	if oldModule == modulePath && oldName == name {
+		return nil, nil
+	}
+
+	replacer := strings.NewReplacer(
+		oldModule, modulePath,
+		oldName, name,
+		snakeName(oldName), snakeName(name),
+	)
+	importReplacer := strings.NewReplacer(
+		`"`+oldModule+`"`, `"`+modulePath+`"`,
+		`"`+oldModule+`/`, `"`+modulePath+`/`,
+	)
+
+	var changed []string
+	err = filepath.WalkDir(root, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if p != root && skippedDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if bytes.IndexByte(data, 0) >= 0 || !utf8.Valid(data) {
+			return nil
+		}
+
+		content := string(data)
+		var renamed string
+		if strings.HasSuffix(p, ".go") && generatedRegex.MatchString(content) {
+			renamed = importReplacer.Replace(content)
+		} else {
+			renamed = replacer.Replace(content)
+		}
+		if renamed == content {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, []byte(renamed), info.Mode().Perm()); err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		changed = append(changed, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changed, nil
+}
+
+// appName returns the application name of a module: the last element of
+// its path, without a major version suffix
+func appName(modulePath string) string {
+	prefix, _, ok := module.SplitPathVersion(modulePath)
+	if !ok {
+		prefix = modulePath
+	}
+	return strings.ToLower(path.Base(prefix))
+}
+
+// snakeName returns an application name with underscores instead of
+// dashes, as in identifiers of PostgreSQL replication slots
+func snakeName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
@@ -0,0 +1,94 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTree writes files, keyed by slash-separated path, under root
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func readFile(t *testing.T, root, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestInit(t *testing.T) {
+	root := t.TempDir()
+	rawDesc := "\"BAZ?example.com/old-service/api/proto/v1;v1b\\x06proto3\""
+	writeTree(t, root, map[string]string{
+		"go.mod":            "module example.com/old-service\n\ngo 1.25\n",
+		"main.go":           "package main\n\nimport _ \"example.com/old-service/internal/config\"\n",
+		"config.yaml":       "app:\n  name: old-service\ncdc:\n  slot: old_service_users\n",
+		"api/v1/api.pb.go":  "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage v1\n\nimport _ \"example.com/old-service/pkg\"\n\nconst rawDesc = " + rawDesc + "\n",
+		"unrelated.md":      "Nothing to rename\n",
+		".git/config":       "url = https://example.com/old-service\n",
+		"bin/old-service.t": "old-service\n",
+	})
+
+	changed, err := Init(root, "github.com/acme/my-service/v2", "")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"go.mod", "main.go", "config.yaml", "api/v1/api.pb.go"}, changed)
+
+	assert.Equal(t, "module github.com/acme/my-service/v2\n\ngo 1.25\n", readFile(t, root, "go.mod"))
+	assert.Equal(t, "package main\n\nimport _ \"github.com/acme/my-service/v2/internal/config\"\n", readFile(t, root, "main.go"))
+	assert.Equal(t, "app:\n  name: my-service\ncdc:\n  slot: my_service_users\n", readFile(t, root, "config.yaml"))
+
+	// Only the imports of generated files change, leaving the length-prefixed
+	// descriptor intact
+	generated := readFile(t, root, "api/v1/api.pb.go")
+	assert.Contains(t, generated, "import _ \"github.com/acme/my-service/v2/pkg\"\n")
+	assert.Contains(t, generated, rawDesc)
+
+	assert.Equal(t, "url = https://example.com/old-service\n", readFile(t, root, ".git/config"))
+	assert.Equal(t, "old-service\n", readFile(t, root, "bin/old-service.t"))
+
+	// Running it again changes nothing
+	changed, err = Init(root, "github.com/acme/my-service/v2", "")
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestInit_Name(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		"go.mod":      "module example.com/old-service\n",
+		"config.yaml": "app:\n  name: old-service\n",
+	})
+
+	_, err := Init(root, "github.com/acme/api", "billing")
+	require.NoError(t, err)
+	assert.Equal(t, "module github.com/acme/api\n", readFile(t, root, "go.mod"))
+	assert.Equal(t, "app:\n  name: billing\n", readFile(t, root, "config.yaml"))
+}
+
+func TestInit_Invalid(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{"go.mod": "module example.com/old-service\n"})
+
+	_, err := Init(root, "not a module", "")
+	assert.ErrorContains(t, err, "invalid module path")
+
+	_, err = Init(root, "github.com/acme/MyService", "")
+	require.NoError(t, err, "the name is lowercased")
+	assert.Equal(t, "module github.com/acme/MyService\n", readFile(t, root, "go.mod"))
+
+	_, err = Init(root, "github.com/acme/myservice", "My Service")
+	assert.EqualError(t, err, `invalid name "My Service": use lowercase letters, digits and dashes, starting with a letter`)
+
+	_, err = Init(t.TempDir(), "github.com/acme/myservice", "")
+	assert.Error(t, err, "no go.mod")
+}
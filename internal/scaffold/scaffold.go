@@ -0,0 +1,523 @@
+// Package scaffold generates a new domain module laid out like
+// internal/org: the domain entity and its errors, the repository and
+// service ports with their mocks, the service, the PostgreSQL and HTTP
+// adapters, the Wire providers, the migration, and tests for each layer.
+// The files are rendered from the templates in templates/, or their
+// overrides in .scaffold/templates, and formatted with gofmt. Init renames
+// a project created from the scaffold.
+// cmd/scaffold is its command line.
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"golang.org/x/mod/modfile"
+)
+
+// wordRegex matches a word of a domain or field name
+var wordRegex = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// initialisms are the words spelled in capitals in Go names
+var initialisms = map[string]string{
+	"api":  "API",
+	"http": "HTTP",
+	"id":   "ID",
+	"ip":   "IP",
+	"json": "JSON",
+	"url":  "URL",
+	"uuid": "UUID",
+}
+
+// reservedNames are the identifiers of the generated code that a domain
+// name would shadow
+var reservedNames = map[string]bool{
+	"assert": true, "c": true, "clock": true, "config": true, "context": true,
+	"database": true, "db": true, "domain": true, "errors": true, "existing": true,
+	"fields": true, "found": true, "gin": true, "gorm": true, "handler": true,
+	"http": true, "later": true, "missing": true, "mock": true, "mocks": true,
+	"model": true, "models": true, "newer": true, "now": true, "older": true,
+	"ports": true, "problem": true, "repo": true, "request": true, "require": true,
+	"responses": true, "router": true, "service": true, "strings": true,
+	"time": true, "uuid": true, "validation": true, "wire": true,
+}
+
+// reservedFields are the columns every generated table already has
+var reservedFields = map[string]bool{
+	"id": true, "tenant_id": true, "created_at": true, "updated_at": true,
+}
+
+// FieldType is the type of a field, as written in a field spec
+type FieldType string
+
+// Field types
+const (
+	// FieldString is a required string of at most 255 characters
+	FieldString FieldType = "string"
+
+	// FieldText is an optional string of any length
+	FieldText FieldType = "text"
+
+	// FieldInt is a 64-bit integer
+	FieldInt FieldType = "int"
+
+	// FieldFloat is a 64-bit floating point number
+	FieldFloat FieldType = "float"
+
+	// FieldBool is a boolean
+	FieldBool FieldType = "bool"
+
+	// FieldTime is an optional time
+	FieldTime FieldType = "time"
+)
+
+// fieldTypes describes how each field type is declared
+var fieldTypes = map[FieldType]struct {
+	goType  string
+	gormTag string
+	sqlType string
+}{
+	FieldString: {"string", "type:varchar(255);not null", "VARCHAR(255) NOT NULL"},
+	FieldText:   {"string", "type:text;not null;default:''", "TEXT NOT NULL DEFAULT ''"},
+	FieldInt:    {"int64", "not null;default:0", "BIGINT NOT NULL DEFAULT 0"},
+	FieldFloat:  {"float64", "not null;default:0", "DOUBLE PRECISION NOT NULL DEFAULT 0"},
+	FieldBool:   {"bool", "not null;default:false", "BOOLEAN NOT NULL DEFAULT FALSE"},
+	FieldTime:   {"*time.Time", "", "TIMESTAMPTZ"},
+}
+
+// Field is a field of the generated entity, besides its ID and timestamps
+type Field struct {
+	words []string
+
+	// Type is the type of the field
+	Type FieldType
+}
+
+// ParseFields parses a comma-separated list of name:type fields, such as
+// "title:string,price:float". Names are snake_case.
+func ParseFields(spec string) ([]Field, error) {
+	var fields []Field
+	seen := make(map[string]bool)
+
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, typ, ok := strings.Cut(item, ":")
+		if !ok {
+			return nil, fmt.Errorf("field %q: expected name:type", item)
+		}
+		if _, known := fieldTypes[FieldType(typ)]; !known {
+			return nil, fmt.Errorf("field %q: unknown type %q; use string, text, int, float, bool or time", name, typ)
+		}
+
+		words, err := splitWords(name)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		column := strings.Join(words, "_")
+		if reservedFields[column] {
+			return nil, fmt.Errorf("field %q: every entity already has it", name)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("field %q: declared twice", name)
+		}
+		seen[column] = true
+
+		fields = append(fields, Field{words: words, Type: FieldType(typ)})
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field is required")
+	}
+	return fields, nil
+}
+
+// GoName is the name of the struct field
+func (f Field) GoName() string { return goName(f.words) }
+
+// GoType is the Go type of the field
+func (f Field) GoType() string { return fieldTypes[f.Type].goType }
+
+// JSONName is the name of the field in requests and responses
+func (f Field) JSONName() string { return strings.Join(f.words, "_") }
+
+// Column is the name of the column
+func (f Field) Column() string { return strings.Join(f.words, "_") }
+
+// Label is the name of the field in messages
+func (f Field) Label() string { return strings.Join(f.words, " ") }
+
+// GormTag is the gorm struct tag of the field
+func (f Field) GormTag() string { return fieldTypes[f.Type].gormTag }
+
+// SQLType is the column definition of the field
+func (f Field) SQLType() string { return fieldTypes[f.Type].sqlType }
+
+// IsString reports whether the field is a required string
+func (f Field) IsString() bool { return f.Type == FieldString }
+
+// IsTime reports whether the field is a time
+func (f Field) IsTime() bool { return f.Type == FieldTime }
+
+// Example is a valid value of the field for tests, or empty when the zero
+// value is used
+func (f Field) Example() string {
+	switch f.Type {
+	case FieldString, FieldText:
+		return strconv.Quote("Example " + f.Label())
+	case FieldInt:
+		return "42"
+	case FieldFloat:
+		return "9.5"
+	case FieldBool:
+		return "true"
+	default:
+		return ""
+	}
+}
+
+// Changed is a valid value of the field for tests that differs from
+// Example, or empty for times. Booleans change to false so that updates
+// are shown to save zero values.
+func (f Field) Changed() string {
+	switch f.Type {
+	case FieldString, FieldText:
+		return strconv.Quote("Changed " + f.Label())
+	case FieldInt:
+		return "7"
+	case FieldFloat:
+		return "1.5"
+	case FieldBool:
+		return "false"
+	default:
+		return ""
+	}
+}
+
+// Domain describes the module to generate
+type Domain struct {
+	words  []string
+	plural []string
+
+	// Module is the Go module path of the repository
+	Module string
+
+	// Fields are the fields of the entity
+	Fields []Field
+}
+
+// NewDomain describes the domain named name, in snake_case, kebab-case or
+// CamelCase. The plural of its last word is guessed unless plural is set.
+func NewDomain(name, plural, module string, fields []Field) (*Domain, error) {
+	words, err := splitWords(name)
+	if err != nil {
+		return nil, fmt.Errorf("domain %q: %w", name, err)
+	}
+
+	var pluralWords []string
+	if plural != "" {
+		if pluralWords, err = splitWords(plural); err != nil {
+			return nil, fmt.Errorf("plural %q: %w", plural, err)
+		}
+	} else {
+		pluralWords = append(append([]string{}, words[:len(words)-1]...), pluralize(words[len(words)-1]))
+	}
+
+	d := &Domain{words: words, plural: pluralWords, Module: module, Fields: fields}
+	if len(d.Package()) < 2 {
+		return nil, fmt.Errorf("domain %q: use a name of two letters or more", name)
+	}
+	for _, ident := range []string{d.Package(), d.Var(), d.VarPlural()} {
+		if reservedNames[ident] || token.IsKeyword(ident) || types.Universe.Lookup(ident) != nil {
+			return nil, fmt.Errorf("domain %q: %q is taken by the generated code", name, ident)
+		}
+	}
+	if d.Var() == d.VarPlural() {
+		return nil, fmt.Errorf("domain %q: its plural must differ, set one", name)
+	}
+	return d, nil
+}
+
+// Name is the Go name of the entity, such as BlogPost
+func (d *Domain) Name() string { return goName(d.words) }
+
+// Plural is the Go name of several entities, such as BlogPosts
+func (d *Domain) Plural() string { return goName(d.plural) }
+
+// Var is the variable name of an entity, such as blogPost
+func (d *Domain) Var() string { return varName(d.words) }
+
+// VarPlural is the variable name of several entities, such as blogPosts
+func (d *Domain) VarPlural() string { return varName(d.plural) }
+
+// Receiver is the receiver name of the entity's methods
+func (d *Domain) Receiver() string { return d.Var()[:1] }
+
+// Package is the name of the module's directory under internal/
+func (d *Domain) Package() string { return strings.Join(d.words, "") }
+
+// Table is the name of the table, such as blog_posts
+func (d *Domain) Table() string { return strings.Join(d.plural, "_") }
+
+// Route is the path of the routes, such as /blog-posts
+func (d *Domain) Route() string { return "/" + strings.Join(d.plural, "-") }
+
+// Label is the name of an entity in messages, such as blog post
+func (d *Domain) Label() string { return strings.Join(d.words, " ") }
+
+// LabelPlural is the name of several entities in messages
+func (d *Domain) LabelPlural() string { return strings.Join(d.plural, " ") }
+
+// LabelPluralTitle is LabelPlural starting with a capital
+func (d *Domain) LabelPluralTitle() string { return capitalize(d.LabelPlural()) }
+
+// Code is the prefix of the domain's error codes, such as BLOG_POST
+func (d *Domain) Code() string { return strings.ToUpper(strings.Join(d.words, "_")) }
+
+// HasStrings reports whether the entity has required string fields
+func (d *Domain) HasStrings() bool { return d.StringCount() > 0 }
+
+// HasTimes reports whether the entity has time fields
+func (d *Domain) HasTimes() bool {
+	for _, f := range d.Fields {
+		if f.IsTime() {
+			return true
+		}
+	}
+	return false
+}
+
+// StringCount counts the required string fields
+func (d *Domain) StringCount() int {
+	n := 0
+	for _, f := range d.Fields {
+		if f.IsString() {
+			n++
+		}
+	}
+	return n
+}
+
+// FirstString returns the first required string field, or nil
+func (d *Domain) FirstString() *Field {
+	for i := range d.Fields {
+		if d.Fields[i].IsString() {
+			return &d.Fields[i]
+		}
+	}
+	return nil
+}
+
+// File is a generated file
+type File struct {
+	// Path is relative to the repository root, with forward slashes
+	Path string
+
+	// Content is the content of the file
+	Content []byte
+}
+
+// Render renders the files of the domain with templates, as returned by
+// LoadTemplates. The migration takes the given sequence number. A file
+// whose template renders only blanks is left out, so an override can drop
+// a file its other templates no longer need.
+func Render(templates *template.Template, d *Domain, migration int) ([]File, error) {
+	file := strings.Join(d.words, "_")
+	dir := "internal/" + d.Package()
+	prefix := fmt.Sprintf("migrations/%06d_create_%s", migration, d.Table())
+
+	paths := []struct{ template, path string }{
+		{"domain.go.tmpl", dir + "/domain/" + file + ".go"},
+		{"domain_test.go.tmpl", dir + "/domain/" + file + "_test.go"},
+		{"errors.go.tmpl", dir + "/domain/errors.go"},
+		{"ports_clock.go.tmpl", dir + "/ports/clock.go"},
+		{"ports_repository.go.tmpl", dir + "/ports/repository.go"},
+		{"ports_service.go.tmpl", dir + "/ports/service.go"},
+		{"mock_repository.go.tmpl", dir + "/ports/mocks/" + d.Name() + "Repository.go"},
+		{"mock_service.go.tmpl", dir + "/ports/mocks/" + d.Name() + "Service.go"},
+		{"service.go.tmpl", dir + "/service/" + file + "_service.go"},
+		{"service_test.go.tmpl", dir + "/service/" + file + "_service_test.go"},
+		{"postgres_models.go.tmpl", dir + "/adapters/postgres/models.go"},
+		{"postgres_mappers.go.tmpl", dir + "/adapters/postgres/mappers.go"},
+		{"postgres_repository.go.tmpl", dir + "/adapters/postgres/repository.go"},
+		{"postgres_repository_test.go.tmpl", dir + "/adapters/postgres/repository_test.go"},
+		{"http_dto.go.tmpl", dir + "/adapters/http/dto.go"},
+		{"http_handlers.go.tmpl", dir + "/adapters/http/handlers.go"},
+		{"http_routes.go.tmpl", dir + "/adapters/http/routes.go"},
+		{"wire.go.tmpl", "internal/wire/" + file + ".go"},
+		{"migration.up.sql.tmpl", prefix + ".up.sql"},
+		{"migration.down.sql.tmpl", prefix + ".down.sql"},
+	}
+
+	files := make([]File, 0, len(paths))
+	for _, p := range paths {
+		var buf bytes.Buffer
+		if err := templates.ExecuteTemplate(&buf, p.template, d); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", p.path, err)
+		}
+
+		content := buf.Bytes()
+		if len(bytes.TrimSpace(content)) == 0 {
+			continue
+		}
+		if strings.HasSuffix(p.path, ".go") {
+			formatted, err := format.Source(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to format %s: %w", p.path, err)
+			}
+			content = formatted
+		}
+
+		files = append(files, File{Path: p.path, Content: content})
+	}
+	return files, nil
+}
+
+// Write writes the files under root. Nothing is written when any of them
+// exists already.
+func Write(root string, files []File) error {
+	for _, f := range files {
+		path := filepath.Join(root, filepath.FromSlash(f.Path))
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists", f.Path)
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	for _, f := range files {
+		path := filepath.Join(root, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, f.Content, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ModulePath returns the module path declared by the go.mod file in root
+func ModulePath(root string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+
+	module := modfile.ModulePath(data)
+	if module == "" {
+		return "", fmt.Errorf("no module path in %s", filepath.Join(root, "go.mod"))
+	}
+	return module, nil
+}
+
+// NextMigration returns the sequence number following the last migration
+// in dir
+func NextMigration(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	last := 0
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(prefix); err == nil && n > last {
+			last = n
+		}
+	}
+	return last + 1, nil
+}
+
+// splitWords splits a snake_case, kebab-case or CamelCase name into
+// lowercase words
+func splitWords(name string) ([]string, error) {
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+
+	runes := []rune(strings.TrimSpace(name))
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			flush()
+			word = append(word, r)
+		default:
+			word = append(word, r)
+		}
+	}
+	flush()
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("name is empty")
+	}
+	for _, w := range words {
+		if !wordRegex.MatchString(w) {
+			return nil, fmt.Errorf("use letters and digits, starting with a letter")
+		}
+	}
+	return words, nil
+}
+
+// goName joins words into an exported Go name
+func goName(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		if initialism, ok := initialisms[w]; ok {
+			b.WriteString(initialism)
+		} else {
+			b.WriteString(capitalize(w))
+		}
+	}
+	return b.String()
+}
+
+// varName joins words into an unexported Go name
+func varName(words []string) string {
+	return words[0] + goName(words[1:])
+}
+
+// capitalize returns s starting with a capital
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// pluralize returns the plural of an English noun by the regular rules
+func pluralize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	default:
+		return word + "s"
+	}
+}
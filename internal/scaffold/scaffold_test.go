@@ -0,0 +1,237 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testModule = "github.com/yourusername/go-scaffolding"
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields("title:string, body:text,authorID:string,published_at:time")
+	require.NoError(t, err)
+	require.Len(t, fields, 4)
+
+	assert.Equal(t, "Title", fields[0].GoName())
+	assert.Equal(t, "string", fields[0].GoType())
+	assert.True(t, fields[0].IsString())
+	assert.False(t, fields[1].IsString(), "text fields are optional")
+	assert.Equal(t, "AuthorID", fields[2].GoName())
+	assert.Equal(t, "author_id", fields[2].JSONName())
+	assert.Equal(t, "PublishedAt", fields[3].GoName())
+	assert.Equal(t, "*time.Time", fields[3].GoType())
+	assert.Equal(t, "published at", fields[3].Label())
+}
+
+func TestParseFields_Invalid(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{spec: "", wantErr: "at least one field is required"},
+		{spec: "title", wantErr: `field "title": expected name:type`},
+		{spec: "title:varchar", wantErr: `field "title": unknown type "varchar"; use string, text, int, float, bool or time`},
+		{spec: "2fa:bool", wantErr: `field "2fa": use letters and digits, starting with a letter`},
+		{spec: "created_at:time", wantErr: `field "created_at": every entity already has it`},
+		{spec: "title:string,Title:text", wantErr: `field "Title": declared twice`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseFields(tt.spec)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestNewDomain(t *testing.T) {
+	fields, err := ParseFields("title:string")
+	require.NoError(t, err)
+
+	for _, name := range []string{"blog_post", "blog-post", "BlogPost"} {
+		d, err := NewDomain(name, "", testModule, fields)
+		require.NoError(t, err)
+		assert.Equal(t, "BlogPost", d.Name())
+		assert.Equal(t, "BlogPosts", d.Plural())
+		assert.Equal(t, "blogPost", d.Var())
+		assert.Equal(t, "blogPosts", d.VarPlural())
+		assert.Equal(t, "blogpost", d.Package())
+		assert.Equal(t, "blog_posts", d.Table())
+		assert.Equal(t, "/blog-posts", d.Route())
+		assert.Equal(t, "blog post", d.Label())
+		assert.Equal(t, "BLOG_POST", d.Code())
+	}
+
+	plurals := map[string]string{"category": "categories", "day": "days", "box": "boxes", "address": "addresses", "API": "apis"}
+	for name, want := range plurals {
+		d, err := NewDomain(name, "", testModule, fields)
+		require.NoError(t, err)
+		assert.Equal(t, want, d.Table())
+	}
+
+	d, err := NewDomain("person", "people", testModule, fields)
+	require.NoError(t, err)
+	assert.Equal(t, "People", d.Plural())
+	assert.Equal(t, "/people", d.Route())
+}
+
+func TestNewDomain_Invalid(t *testing.T) {
+	fields, err := ParseFields("title:string")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name, plural string
+		wantErr      string
+	}{
+		{name: "", wantErr: `domain "": name is empty`},
+		{name: "x", wantErr: `domain "x": use a name of two letters or more`},
+		{name: "blog post", wantErr: `domain "blog post": use letters and digits, starting with a letter`},
+		{name: "service", wantErr: `domain "service": "service" is taken by the generated code`},
+		{name: "type", wantErr: `domain "type": "type" is taken by the generated code`},
+		{name: "sheep", plural: "sheep", wantErr: `domain "sheep": its plural must differ, set one`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDomain(tt.name, tt.plural, testModule, fields)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	files := renderTestDomain(t)
+
+	paths := make(map[string][]byte, len(files))
+	for _, f := range files {
+		paths[f.Path] = f.Content
+	}
+	assert.Contains(t, paths, "internal/blogpost/domain/blog_post.go")
+	assert.Contains(t, paths, "internal/blogpost/ports/mocks/BlogPostRepository.go")
+	assert.Contains(t, paths, "internal/wire/blog_post.go")
+	assert.Contains(t, paths, "migrations/000024_create_blog_posts.down.sql")
+
+	up := string(paths["migrations/000024_create_blog_posts.up.sql"])
+	assert.Contains(t, up, "CREATE TABLE IF NOT EXISTS blog_posts (")
+	assert.Contains(t, up, "    title VARCHAR(255) NOT NULL,\n")
+	assert.Contains(t, up, "    published_at TIMESTAMPTZ,\n")
+}
+
+func TestRender_WithoutTimes(t *testing.T) {
+	fields, err := ParseFields("name:string")
+	require.NoError(t, err)
+	d, err := NewDomain("tag", "", testModule, fields)
+	require.NoError(t, err)
+	templates, err := LoadTemplates(t.TempDir())
+	require.NoError(t, err)
+	files, err := Render(templates, d, 24)
+	require.NoError(t, err)
+
+	for _, f := range files {
+		if f.Path == "internal/tag/adapters/http/dto.go" {
+			assert.NotContains(t, string(f.Content), `"time"`, "no unused import")
+			assert.Contains(t, string(f.Content), "CreatedAt utc.Time")
+			return
+		}
+	}
+	t.Fatal("no DTO rendered")
+}
+
+// TestRender_Builds compiles the generated packages as if they were in the
+// repository, without writing them there
+func TestRender_Builds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated code with the go command")
+	}
+
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	overlay := map[string]string{}
+	packages := map[string]bool{}
+	for i, f := range renderTestDomain(t) {
+		if path.Ext(f.Path) != ".go" || strings.HasSuffix(f.Path, "_test.go") {
+			continue
+		}
+		tmp := filepath.Join(dir, filepath.Base(f.Path)+"."+string(rune('a'+i)))
+		require.NoError(t, os.WriteFile(tmp, f.Content, 0o600))
+		overlay[filepath.Join(root, filepath.FromSlash(f.Path))] = tmp
+		packages["./"+path.Dir(f.Path)] = true
+	}
+
+	data, err := json.Marshal(map[string]any{"Replace": overlay})
+	require.NoError(t, err)
+	overlayPath := filepath.Join(dir, "overlay.json")
+	require.NoError(t, os.WriteFile(overlayPath, data, 0o600))
+
+	args := []string{"build", "-overlay", overlayPath}
+	for pkg := range packages {
+		args = append(args, pkg)
+	}
+	cmd := exec.Command("go", args...)
+	cmd.Dir = root
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestWrite(t *testing.T) {
+	root := t.TempDir()
+	files := []File{
+		{Path: "internal/blogpost/domain/blog_post.go", Content: []byte("package domain\n")},
+		{Path: "migrations/000024_create_blog_posts.up.sql", Content: []byte("SELECT 1;\n")},
+	}
+
+	require.NoError(t, Write(root, files))
+	content, err := os.ReadFile(filepath.Join(root, "internal", "blogpost", "domain", "blog_post.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package domain\n", string(content))
+
+	// Nothing is overwritten
+	files[0].Content = []byte("package changed\n")
+	assert.EqualError(t, Write(root, files), "internal/blogpost/domain/blog_post.go already exists")
+	content, err = os.ReadFile(filepath.Join(root, "internal", "blogpost", "domain", "blog_post.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package domain\n", string(content))
+}
+
+func TestNextMigration(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"000001_create_users.up.sql", "000009_add_index.down.sql", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	next, err := NextMigration(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 10, next)
+}
+
+func TestModulePath(t *testing.T) {
+	module, err := ModulePath(filepath.Join("..", ".."))
+	require.NoError(t, err)
+	assert.Equal(t, testModule, module)
+}
+
+// renderTestDomain renders a domain with a field of every type
+func renderTestDomain(t *testing.T) []File {
+	t.Helper()
+
+	fields, err := ParseFields("title:string,body:text,views:int,rating:float,published:bool,published_at:time,author_id:string")
+	require.NoError(t, err)
+	d, err := NewDomain("blog_post", "", testModule, fields)
+	require.NoError(t, err)
+
+	templates, err := LoadTemplates(t.TempDir())
+	require.NoError(t, err)
+	files, err := Render(templates, d, 24)
+	require.NoError(t, err)
+	return files
+}
@@ -0,0 +1,109 @@
+package scaffold
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// TemplatesDir is the directory, relative to the repository root, holding
+// the templates that override the defaults
+const TemplatesDir = ".scaffold/templates"
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// TemplateNames returns the names of the default templates, such as
+// http_handlers.go.tmpl
+func TemplateNames() []string {
+	entries, err := fs.ReadDir(templateFS, "templates")
+	if err != nil {
+		panic(err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+// LoadTemplates returns the default templates, overridden by the *.tmpl
+// files of TemplatesDir under root. An override replaces the default of the
+// same name, such as http_handlers.go.tmpl to generate chi handlers instead
+// of Gin ones. Other files may define templates the overrides include.
+// Templates are executed with the *Domain.
+func LoadTemplates(root string) (*template.Template, error) {
+	sources := map[string]string{}
+	for _, name := range TemplateNames() {
+		content, err := templateFS.ReadFile(path.Join("templates", name))
+		if err != nil {
+			return nil, err
+		}
+		sources[name] = string(content)
+	}
+
+	dir := filepath.Join(root, filepath.FromSlash(TemplatesDir))
+	overrides, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range overrides {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		sources[filepath.Base(file)] = string(content)
+	}
+
+	// Each template is parsed once, so that an override replaces its default
+	// even when blank
+	templates := template.New("")
+	for _, name := range slices.Sorted(maps.Keys(sources)) {
+		if _, err := templates.New(name).Parse(sources[name]); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+	}
+	return templates, nil
+}
+
+// EjectTemplates copies the default templates named, or all of them when
+// names is empty, to TemplatesDir under root for editing. Nothing is
+// written when any of them exists already. EjectTemplates returns the paths
+// of the written files.
+func EjectTemplates(root string, names []string) ([]string, error) {
+	defaults := TemplateNames()
+	if len(names) == 0 {
+		names = defaults
+	}
+
+	files := make([]File, 0, len(names))
+	for _, name := range names {
+		if !slices.Contains(defaults, name) {
+			return nil, fmt.Errorf("unknown template %q; templates are %s", name, strings.Join(defaults, ", "))
+		}
+
+		content, err := templateFS.ReadFile(path.Join("templates", name))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path.Join(TemplatesDir, name), Content: content})
+	}
+
+	if err := Write(root, files); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.Path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,69 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEjectTemplates(t *testing.T) {
+	root := t.TempDir()
+
+	paths, err := EjectTemplates(root, nil)
+	require.NoError(t, err)
+	assert.Len(t, paths, len(TemplateNames()))
+	assert.Contains(t, paths, ".scaffold/templates/http_handlers.go.tmpl")
+
+	ejected, err := os.ReadFile(filepath.Join(root, ".scaffold", "templates", "domain.go.tmpl"))
+	require.NoError(t, err)
+	embedded, err := templateFS.ReadFile("templates/domain.go.tmpl")
+	require.NoError(t, err)
+	assert.Equal(t, embedded, ejected)
+
+	// Ejected templates are not overwritten
+	_, err = EjectTemplates(root, []string{"domain.go.tmpl"})
+	assert.EqualError(t, err, ".scaffold/templates/domain.go.tmpl already exists")
+}
+
+func TestEjectTemplates_Unknown(t *testing.T) {
+	_, err := EjectTemplates(t.TempDir(), []string{"handlers.tmpl"})
+	assert.ErrorContains(t, err, `unknown template "handlers.tmpl"; templates are domain.go.tmpl, `)
+}
+
+func TestLoadTemplates_Overrides(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{
+		".scaffold/templates/http_routes.go.tmpl":      "package http\n\n// {{template \"route_comment\" .}}\nconst Route = \"{{.Route}}\"\n",
+		".scaffold/templates/partials.tmpl":            "{{define \"route_comment\"}}Route is where {{.LabelPlural}} are served{{end}}",
+		".scaffold/templates/postgres_mappers.go.tmpl": "{{/* sqlc generates the rows */}}",
+	})
+
+	templates, err := LoadTemplates(root)
+	require.NoError(t, err)
+	fields, err := ParseFields("title:string")
+	require.NoError(t, err)
+	d, err := NewDomain("blog_post", "", testModule, fields)
+	require.NoError(t, err)
+
+	files, err := Render(templates, d, 24)
+	require.NoError(t, err)
+	paths := make(map[string]string, len(files))
+	for _, f := range files {
+		paths[f.Path] = string(f.Content)
+	}
+
+	assert.Equal(t, "package http\n\n// Route is where blog posts are served\nconst Route = \"/blog-posts\"\n", paths["internal/blogpost/adapters/http/routes.go"])
+	assert.NotContains(t, paths, "internal/blogpost/adapters/postgres/mappers.go", "a blank override drops the file")
+	assert.Contains(t, paths["internal/blogpost/adapters/http/handlers.go"], "gin.Context", "other templates keep their default")
+}
+
+func TestLoadTemplates_Invalid(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, map[string]string{".scaffold/templates/http_routes.go.tmpl": "{{.Route"})
+
+	_, err := LoadTemplates(root)
+	assert.ErrorContains(t, err, "failed to parse http_routes.go.tmpl")
+}
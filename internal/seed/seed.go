@@ -0,0 +1,288 @@
+// Package seed loads demo users, organizations and API keys for
+// development. Fixtures come from the built-in Demo data or a YAML file, and
+// a Seeder applies them through the user and organization services. Seeding
+// is idempotent: what exists already is left as is, so it can run at every
+// start. It is refused in production.
+package seed
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	orgdomain "github.com/yourusername/go-scaffolding/internal/org/domain"
+	orgports "github.com/yourusername/go-scaffolding/internal/org/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// ErrProduction is returned when seeding in the production environment
+var ErrProduction = errors.New("seeding is refused in production")
+
+// Fixtures is the demo data to seed
+type Fixtures struct {
+	// Tenant the data is seeded for; required with multi-tenancy
+	Tenant string `yaml:"tenant"`
+
+	Users         []User         `yaml:"users"`
+	Organizations []Organization `yaml:"organizations"`
+
+	// APIKeys maps the name of each API client to its key. They are
+	// configuration, not data: the API server started with --seed accepts
+	// them besides the configured ones.
+	APIKeys map[string]string `yaml:"api_keys"`
+}
+
+// User is a user to seed. Without a password the user cannot sign in.
+type User struct {
+	Email    string `yaml:"email"`
+	Name     string `yaml:"name"`
+	Password string `yaml:"password"`
+}
+
+// Organization is an organization to seed, owned by the user with the
+// email Owner. Users are seeded first, so members may be seeded users.
+type Organization struct {
+	Name    string   `yaml:"name"`
+	Slug    string   `yaml:"slug"`
+	Owner   string   `yaml:"owner"`
+	Members []Member `yaml:"members"`
+}
+
+// Member is a member of a seeded organization
+type Member struct {
+	Email string `yaml:"email"`
+	Role  string `yaml:"role"`
+}
+
+// Demo returns the built-in demo data
+func Demo() Fixtures {
+	return Fixtures{
+		Users: []User{
+			{Email: "alice@example.com", Name: "Alice Demo", Password: "amber-lighthouse-orbit"},
+			{Email: "bob@example.com", Name: "Bob Demo", Password: "tangerine-sky-lantern"},
+			{Email: "carol@example.com", Name: "Carol Demo"},
+		},
+		Organizations: []Organization{
+			{
+				Name:  "Acme Corporation",
+				Slug:  "acme",
+				Owner: "alice@example.com",
+				Members: []Member{
+					{Email: "bob@example.com", Role: "admin"},
+					{Email: "carol@example.com", Role: "member"},
+				},
+			},
+			{
+				Name:  "Globex",
+				Slug:  "globex",
+				Owner: "bob@example.com",
+			},
+		},
+		APIKeys: map[string]string{
+			"demo": "demo-api-key",
+		},
+	}
+}
+
+// Load returns the fixtures of the YAML file at path, or Demo when path is
+// empty
+func Load(path string) (Fixtures, error) {
+	if path == "" {
+		return Demo(), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixtures{}, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	var fixtures Fixtures
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&fixtures); err != nil {
+		return Fixtures{}, fmt.Errorf("failed to parse fixtures %s: %w", path, err)
+	}
+	return fixtures, nil
+}
+
+// CheckEnvironment returns ErrProduction in the production environment
+func CheckEnvironment(environment string) error {
+	if environment == "production" {
+		return ErrProduction
+	}
+	return nil
+}
+
+// Report counts what a seeding created and found existing
+type Report struct {
+	UsersCreated          int
+	UsersExisting         int
+	OrganizationsCreated  int
+	OrganizationsExisting int
+	MembersAdded          int
+	MembersExisting       int
+}
+
+// Seeder applies fixtures through the user and organization services
+type Seeder struct {
+	fixtures  Fixtures
+	users     ports.UserService
+	passwords ports.UserPasswords
+	orgs      orgports.OrganizationService
+}
+
+// NewSeeder creates a new seeder of fixtures. Without an organization
+// service, as with the memory storage driver, only users are seeded.
+func NewSeeder(fixtures Fixtures, users ports.UserService, passwords ports.UserPasswords, orgs orgports.OrganizationService) *Seeder {
+	return &Seeder{
+		fixtures:  fixtures,
+		users:     users,
+		passwords: passwords,
+		orgs:      orgs,
+	}
+}
+
+// Fixtures returns the fixtures the seeder applies
+func (s *Seeder) Fixtures() Fixtures {
+	return s.fixtures
+}
+
+// Seed creates the users, then the organizations and their members, that
+// do not exist yet. Existing users and memberships are not changed.
+func (s *Seeder) Seed(ctx context.Context) (Report, error) {
+	var report Report
+	if s.fixtures.Tenant != "" {
+		ctx = tenancy.WithTenant(ctx, s.fixtures.Tenant)
+	}
+
+	ids := make(map[string]string, len(s.fixtures.Users))
+	for _, fixture := range s.fixtures.Users {
+		user, created, err := s.seedUser(ctx, fixture)
+		if err != nil {
+			return report, fmt.Errorf("failed to seed user %s: %w", fixture.Email, err)
+		}
+		ids[fixture.Email] = user.ID
+		if created {
+			report.UsersCreated++
+		} else {
+			report.UsersExisting++
+		}
+	}
+
+	if s.orgs == nil {
+		return report, nil
+	}
+	for _, fixture := range s.fixtures.Organizations {
+		if err := s.seedOrganization(ctx, fixture, ids, &report); err != nil {
+			return report, fmt.Errorf("failed to seed organization %s: %w", fixture.Slug, err)
+		}
+	}
+	return report, nil
+}
+
+// seedUser returns the user with the fixture's email, creating it if needed
+func (s *Seeder) seedUser(ctx context.Context, fixture User) (*domain.User, bool, error) {
+	user, err := s.users.GetUserByEmail(ctx, fixture.Email)
+	if err == nil {
+		return user, false, nil
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, false, err
+	}
+
+	if fixture.Password != "" {
+		user, err = s.passwords.Register(ctx, fixture.Email, fixture.Name, fixture.Password)
+	} else {
+		user, err = s.users.CreateUser(ctx, fixture.Email, fixture.Name)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return user, true, nil
+}
+
+// seedOrganization creates the organization if needed, then adds the
+// members it is missing
+func (s *Seeder) seedOrganization(ctx context.Context, fixture Organization, ids map[string]string, report *Report) error {
+	ownerID, err := s.userID(ctx, fixture.Owner, ids)
+	if err != nil {
+		return fmt.Errorf("owner %s: %w", fixture.Owner, err)
+	}
+
+	org, err := s.orgs.CreateOrganization(ctx, fixture.Name, fixture.Slug, ownerID)
+	switch {
+	case err == nil:
+		report.OrganizationsCreated++
+	case errors.Is(err, orgdomain.ErrDuplicateSlug):
+		org, err = s.ownedOrganization(ctx, fixture.Slug, ownerID)
+		if err != nil {
+			return err
+		}
+		report.OrganizationsExisting++
+	default:
+		return err
+	}
+
+	for _, member := range fixture.Members {
+		role, err := orgdomain.ParseRole(member.Role)
+		if err != nil {
+			return fmt.Errorf("member %s: %w", member.Email, err)
+		}
+		userID, err := s.userID(ctx, member.Email, ids)
+		if err != nil {
+			return fmt.Errorf("member %s: %w", member.Email, err)
+		}
+
+		_, err = s.orgs.AddMember(ctx, org.ID, userID, role)
+		switch {
+		case err == nil:
+			report.MembersAdded++
+		case errors.Is(err, orgdomain.ErrAlreadyMember):
+			report.MembersExisting++
+		default:
+			return fmt.Errorf("member %s: %w", member.Email, err)
+		}
+	}
+	return nil
+}
+
+// userID returns the ID of the user with the email, seeded or not
+func (s *Seeder) userID(ctx context.Context, email string, ids map[string]string) (string, error) {
+	if id, ok := ids[email]; ok {
+		return id, nil
+	}
+
+	user, err := s.users.GetUserByEmail(ctx, email)
+	if err != nil {
+		return "", err
+	}
+	ids[email] = user.ID
+	return user.ID, nil
+}
+
+// ownedOrganization returns the organization with the slug among those of
+// the owner. A slug taken by an organization the owner is not a member of
+// is reported as taken.
+func (s *Seeder) ownedOrganization(ctx context.Context, slug, ownerID string) (*orgdomain.Organization, error) {
+	const pageSize = 100
+	for offset := 0; ; offset += pageSize {
+		orgs, err := s.orgs.ListOrganizations(ctx, orgdomain.OrganizationFilter{MemberID: ownerID}, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			if org.Slug == orgdomain.NormalizeSlug(slug) {
+				return org, nil
+			}
+		}
+		if len(orgs) < pageSize {
+			return nil, orgdomain.ErrDuplicateSlug
+		}
+	}
+}
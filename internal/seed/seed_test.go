@@ -0,0 +1,142 @@
+package seed
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	orgdomain "github.com/yourusername/go-scaffolding/internal/org/domain"
+	orgmocks "github.com/yourusername/go-scaffolding/internal/org/ports/mocks"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+func testFixtures() Fixtures {
+	return Fixtures{
+		Users: []User{
+			{Email: "alice@example.com", Name: "Alice", Password: "correct-horse-battery"},
+			{Email: "carol@example.com", Name: "Carol"},
+		},
+		Organizations: []Organization{
+			{
+				Name:    "Acme",
+				Slug:    "acme",
+				Owner:   "alice@example.com",
+				Members: []Member{{Email: "carol@example.com", Role: "member"}},
+			},
+		},
+	}
+}
+
+func TestSeeder_Seed(t *testing.T) {
+	users := new(mocks.MockUserService)
+	passwords := new(mocks.MockUserPasswords)
+	orgs := new(orgmocks.MockOrganizationService)
+	seeder := NewSeeder(testFixtures(), users, passwords, orgs)
+
+	ctx := context.Background()
+
+	users.On("GetUserByEmail", ctx, "alice@example.com").Return(nil, domain.ErrUserNotFound)
+	users.On("GetUserByEmail", ctx, "carol@example.com").Return(nil, domain.ErrUserNotFound)
+	passwords.On("Register", ctx, "alice@example.com", "Alice", "correct-horse-battery").Return(&domain.User{ID: "alice"}, nil)
+	users.On("CreateUser", ctx, "carol@example.com", "Carol").Return(&domain.User{ID: "carol"}, nil)
+	orgs.On("CreateOrganization", ctx, "Acme", "acme", "alice").Return(&orgdomain.Organization{ID: "org-1", Slug: "acme"}, nil)
+	orgs.On("AddMember", ctx, "org-1", "carol", orgdomain.RoleMember).Return(&orgdomain.Membership{}, nil)
+
+	report, err := seeder.Seed(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Report{UsersCreated: 2, OrganizationsCreated: 1, MembersAdded: 1}, report)
+
+	users.AssertExpectations(t)
+	passwords.AssertExpectations(t)
+	orgs.AssertExpectations(t)
+}
+
+func TestSeeder_Seed_Existing(t *testing.T) {
+	users := new(mocks.MockUserService)
+	passwords := new(mocks.MockUserPasswords)
+	orgs := new(orgmocks.MockOrganizationService)
+	seeder := NewSeeder(testFixtures(), users, passwords, orgs)
+
+	ctx := context.Background()
+
+	users.On("GetUserByEmail", ctx, "alice@example.com").Return(&domain.User{ID: "alice"}, nil)
+	users.On("GetUserByEmail", ctx, "carol@example.com").Return(&domain.User{ID: "carol"}, nil)
+	orgs.On("CreateOrganization", ctx, "Acme", "acme", "alice").Return(nil, orgdomain.ErrDuplicateSlug)
+	orgs.On("ListOrganizations", ctx, orgdomain.OrganizationFilter{MemberID: "alice"}, 100, 0).
+		Return([]*orgdomain.Organization{{ID: "org-2", Slug: "globex"}, {ID: "org-1", Slug: "acme"}}, nil)
+	orgs.On("AddMember", ctx, "org-1", "carol", orgdomain.RoleMember).Return(nil, orgdomain.ErrAlreadyMember)
+
+	report, err := seeder.Seed(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, Report{UsersExisting: 2, OrganizationsExisting: 1, MembersExisting: 1}, report)
+
+	passwords.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	users.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+	orgs.AssertExpectations(t)
+}
+
+func TestSeeder_Seed_SlugTakenByOthers(t *testing.T) {
+	users := new(mocks.MockUserService)
+	passwords := new(mocks.MockUserPasswords)
+	orgs := new(orgmocks.MockOrganizationService)
+	seeder := NewSeeder(testFixtures(), users, passwords, orgs)
+
+	ctx := context.Background()
+
+	users.On("GetUserByEmail", ctx, mock.Anything).Return(&domain.User{ID: "alice"}, nil)
+	orgs.On("CreateOrganization", ctx, "Acme", "acme", "alice").Return(nil, orgdomain.ErrDuplicateSlug)
+	orgs.On("ListOrganizations", ctx, mock.Anything, 100, 0).Return([]*orgdomain.Organization{}, nil)
+
+	_, err := seeder.Seed(ctx)
+	assert.ErrorIs(t, err, orgdomain.ErrDuplicateSlug)
+
+	orgs.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`tenant: demo
+users:
+  - email: alice@example.com
+    name: Alice
+    password: correct-horse-battery
+organizations:
+  - name: Acme
+    slug: acme
+    owner: alice@example.com
+api_keys:
+  ci: ci-key
+`), 0o600))
+
+	fixtures, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "demo", fixtures.Tenant)
+	require.Len(t, fixtures.Users, 1)
+	assert.Equal(t, "correct-horse-battery", fixtures.Users[0].Password)
+	require.Len(t, fixtures.Organizations, 1)
+	assert.Equal(t, "alice@example.com", fixtures.Organizations[0].Owner)
+	assert.Equal(t, map[string]string{"ci": "ci-key"}, fixtures.APIKeys)
+
+	fixtures, err = Load("")
+	require.NoError(t, err)
+	assert.Equal(t, Demo(), fixtures)
+}
+
+func TestLoad_UnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("users:\n  - email: alice@example.com\n    role: admin\n"), 0o600))
+
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "field role not found")
+}
+
+func TestCheckEnvironment(t *testing.T) {
+	assert.NoError(t, CheckEnvironment("development"))
+	assert.ErrorIs(t, CheckEnvironment("production"), ErrProduction)
+}
@@ -0,0 +1,209 @@
+// Package cached reads the users of a repository by ID, email and username
+// through a cache.Cache, whichever its driver.
+//
+// Users are cached under their ID; their email and username are cached as
+// keys to the ID. A change to a user only invalidates its ID, which every
+// lookup goes through: a stale email or username key is detected as the
+// user it leads to no longer matches, and is looked up again.
+package cached
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/cache"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// repository reads users through a cache and invalidates them on changes
+type repository struct {
+	ports.UserRepository
+	cache cache.Cache
+	ttl   time.Duration
+	log   *logger.Logger
+}
+
+// NewRepository returns repo reading users through c, where they are kept
+// for ttl. A user is invalidated once a change to it commits. Reads inside
+// a transaction, which must see its changes, and reads spanning tenants
+// skip the cache. Cache failures are logged and the user is read from repo.
+func NewRepository(repo ports.UserRepository, c cache.Cache, ttl time.Duration, log *logger.Logger) ports.UserRepository {
+	return &repository{UserRepository: repo, cache: c, ttl: ttl, log: log}
+}
+
+// GetByID returns the cached user, or reads and caches it
+func (r *repository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	if !cacheable(ctx) {
+		return r.UserRepository.GetByID(ctx, id)
+	}
+
+	if user, ok := r.load(ctx, id); ok {
+		return user, nil
+	}
+	user, err := r.UserRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.store(ctx, user)
+	return user, nil
+}
+
+// GetByEmail returns the cached user of the email, or reads and caches it.
+// Emails are compared case-insensitively, as the repositories do.
+func (r *repository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	matches := func(user *domain.User) bool { return strings.EqualFold(user.Email, email) }
+	return r.getBy(ctx, "email", strings.ToLower(email), matches, func() (*domain.User, error) {
+		return r.UserRepository.GetByEmail(ctx, email)
+	})
+}
+
+// GetByUsername returns the cached user of the username, or reads and
+// caches it
+func (r *repository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	matches := func(user *domain.User) bool { return user.Username == username }
+	return r.getBy(ctx, "username", username, matches, func() (*domain.User, error) {
+		return r.UserRepository.GetByUsername(ctx, username)
+	})
+}
+
+// getBy returns the user the cached key of field leads to, if it still
+// matches, or reads it with get and caches it with its key
+func (r *repository) getBy(ctx context.Context, field, value string, matches func(*domain.User) bool, get func() (*domain.User, error)) (*domain.User, error) {
+	if !cacheable(ctx) {
+		return get()
+	}
+
+	key := r.key(ctx, field, value)
+	if id, ok := r.get(ctx, key); ok {
+		if user, ok := r.load(ctx, string(id)); ok && matches(user) {
+			return user, nil
+		}
+	}
+
+	user, err := get()
+	if err != nil {
+		return nil, err
+	}
+	r.store(ctx, user)
+	r.set(ctx, key, []byte(user.ID))
+	return user, nil
+}
+
+// Update updates the user and invalidates it
+func (r *repository) Update(ctx context.Context, user *domain.User) error {
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	r.invalidate(ctx, user.ID)
+	return nil
+}
+
+// Delete deletes the user and invalidates it
+func (r *repository) Delete(ctx context.Context, id string) error {
+	if err := r.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+// Erase erases the user and invalidates it
+func (r *repository) Erase(ctx context.Context, record *domain.ErasureRecord) error {
+	if err := r.UserRepository.Erase(ctx, record); err != nil {
+		return err
+	}
+	r.invalidate(ctx, record.UserID)
+	return nil
+}
+
+// Restore restores the user and invalidates it
+func (r *repository) Restore(ctx context.Context, id string) error {
+	if err := r.UserRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id)
+	return nil
+}
+
+// RecordLogin records the login and, when it succeeded and so set the
+// last login time, invalidates the user
+func (r *repository) RecordLogin(ctx context.Context, login domain.LoginEvent) error {
+	if err := r.UserRepository.RecordLogin(ctx, login); err != nil {
+		return err
+	}
+	if login.Succeeded {
+		r.invalidate(ctx, login.UserID)
+	}
+	return nil
+}
+
+// invalidate drops the cached user once the transaction in ctx, if any,
+// commits, so a rolled back change keeps it
+func (r *repository) invalidate(ctx context.Context, id string) {
+	key := r.key(ctx, "id", id)
+	// The request may be over by the time the transaction commits
+	ctx = context.WithoutCancel(ctx)
+	database.AfterCommit(ctx, func() {
+		if err := r.cache.Delete(ctx, key); err != nil {
+			r.log.Warn().Ctx(ctx).Err(err).Str("key", key).Msg("Failed to invalidate cached user")
+		}
+	})
+}
+
+// load returns the cached user of id
+func (r *repository) load(ctx context.Context, id string) (*domain.User, bool) {
+	data, ok := r.get(ctx, r.key(ctx, "id", id))
+	if !ok {
+		return nil, false
+	}
+
+	var user domain.User
+	if err := json.Unmarshal(data, &user); err != nil {
+		r.log.Warn().Ctx(ctx).Err(err).Str("user_id", id).Msg("Failed to decode cached user")
+		return nil, false
+	}
+	return &user, true
+}
+
+// store caches the user under its ID
+func (r *repository) store(ctx context.Context, user *domain.User) {
+	data, err := json.Marshal(user)
+	if err != nil {
+		r.log.Warn().Ctx(ctx).Err(err).Str("user_id", user.ID).Msg("Failed to encode user to cache")
+		return
+	}
+	r.set(ctx, r.key(ctx, "id", user.ID), data)
+}
+
+func (r *repository) get(ctx context.Context, key string) ([]byte, bool) {
+	value, found, err := r.cache.Get(ctx, key)
+	if err != nil {
+		r.log.Warn().Ctx(ctx).Err(err).Str("key", key).Msg("Failed to read cached user")
+		return nil, false
+	}
+	return value, found
+}
+
+func (r *repository) set(ctx context.Context, key string, value []byte) {
+	if err := r.cache.Set(ctx, key, value, r.ttl); err != nil {
+		r.log.Warn().Ctx(ctx).Err(err).Str("key", key).Msg("Failed to cache user")
+	}
+}
+
+// key returns the cache key of a user field, scoped to the tenant of ctx
+// so tenants never see each other's users
+func (r *repository) key(ctx context.Context, field, value string) string {
+	tenant, _ := tenancy.FromContext(ctx)
+	return "users:" + tenant + ":" + field + ":" + value
+}
+
+// cacheable reports whether reads in ctx may be served from the cache
+func cacheable(ctx context.Context) bool {
+	return !database.InTransaction(ctx) && !tenancy.SpansTenants(ctx)
+}
@@ -0,0 +1,183 @@
+package cached
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/cache"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/memory"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/repotest"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// countingRepository counts the lookups reaching the wrapped repository
+type countingRepository struct {
+	ports.UserRepository
+	reads int
+}
+
+func (r *countingRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	r.reads++
+	return r.UserRepository.GetByID(ctx, id)
+}
+
+func (r *countingRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	r.reads++
+	return r.UserRepository.GetByEmail(ctx, email)
+}
+
+func (r *countingRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	r.reads++
+	return r.UserRepository.GetByUsername(ctx, username)
+}
+
+// newRepository returns a cached memory repository and the counter of the
+// reads reaching the memory repository
+func newRepository(t *testing.T) (ports.UserRepository, *countingRepository) {
+	t.Helper()
+
+	c, err := cache.NewMemoryCache(1 << 20)
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+
+	inner := &countingRepository{UserRepository: memory.NewUserRepository(idgen.NewSequential())}
+	return NewRepository(inner, c, time.Minute, logger.New("info", io.Discard)), inner
+}
+
+// createUser stores a user named ada
+func createUser(t *testing.T, repo ports.UserRepository) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser(uuid.NewString(), "ada@example.com", "Ada", testNow)
+	require.NoError(t, err)
+	require.NoError(t, user.ChangeUsername("ada", testNow))
+	require.NoError(t, repo.Create(context.Background(), user))
+	return user
+}
+
+func TestRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) ports.UserRepository {
+		repo, _ := newRepository(t)
+		return repo
+	})
+}
+
+func TestRepository_CachesLookups(t *testing.T) {
+	ctx := context.Background()
+	repo, inner := newRepository(t)
+	user := createUser(t, repo)
+
+	for range 3 {
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Ada", found.Name)
+
+		found, err = repo.GetByEmail(ctx, "Ada@Example.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+
+		found, err = repo.GetByUsername(ctx, "ada")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	}
+	assert.Equal(t, 3, inner.reads, "each lookup reads the repository once")
+
+	_, err := repo.GetByID(ctx, uuid.NewString())
+	assert.ErrorIs(t, err, domain.ErrUserNotFound, "unknown users are not cached")
+}
+
+func TestRepository_InvalidatesChanges(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := newRepository(t)
+	user := createUser(t, repo)
+
+	_, err := repo.GetByEmail(ctx, "ada@example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, user.ChangeEmail("lovelace@example.com", testNow))
+	require.NoError(t, user.UpdateName("Ada Lovelace", testNow))
+	require.NoError(t, repo.Update(ctx, user))
+
+	found, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", found.Name)
+
+	_, err = repo.GetByEmail(ctx, "ada@example.com")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound, "the key of the old email leads to a user no longer matching")
+
+	require.NoError(t, repo.Delete(ctx, user.ID))
+	_, err = repo.GetByUsername(ctx, "ada")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	require.NoError(t, repo.Restore(ctx, user.ID))
+	_, err = repo.GetByUsername(ctx, "ada")
+	assert.NoError(t, err)
+}
+
+func TestRepository_InvalidatesOnCommit(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	transactor := database.NewTransactor(db)
+
+	ctx := context.Background()
+	repo, inner := newRepository(t)
+	user := createUser(t, repo)
+	_, err = repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+
+	err = transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		require.NoError(t, user.UpdateName("Ada Lovelace", testNow))
+		require.NoError(t, repo.Update(ctx, user))
+
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Ada Lovelace", found.Name, "reads in a transaction skip the cache")
+		return errors.New("rolled back")
+	})
+	require.Error(t, err)
+
+	// The memory repository does not roll back, but the cached user is kept
+	reads := inner.reads
+	found, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada", found.Name)
+	assert.Equal(t, reads, inner.reads)
+
+	require.NoError(t, transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		return repo.Update(ctx, user)
+	}))
+	found, err = repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", found.Name, "invalidated once committed")
+}
+
+func TestRepository_ScopesTenants(t *testing.T) {
+	repo, inner := newRepository(t)
+	user := createUser(t, repo)
+
+	_, err := repo.GetByID(tenancy.WithTenant(context.Background(), "acme"), user.ID)
+	require.NoError(t, err)
+	_, err = repo.GetByID(tenancy.WithTenant(context.Background(), "globex"), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, inner.reads, "tenants have keys of their own")
+
+	_, err = repo.GetByID(tenancy.AllTenants(context.Background()), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, inner.reads, "reads spanning tenants skip the cache")
+}
@@ -0,0 +1,292 @@
+// Package cdc republishes the changes to users captured from the Postgres
+// replication stream as domain events.
+//
+// The repositories record an event in user_events in the transaction of
+// each change they make, so those events are published as recorded, with
+// the IDs the activity feed and Last-Event-ID know them by. Changes to
+// users made outside the repositories, such as by SQL scripts or other
+// services, have no recorded event; their events are derived from the old
+// and new rows, which needs REPLICA IDENTITY FULL on the users table.
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	pgcdc "github.com/yourusername/go-scaffolding/internal/infrastructure/cdc"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// Tables are the tables the publisher needs streamed
+var Tables = []string{"public.users", "public.user_events"}
+
+// Bus receives the captured events
+type Bus interface {
+	Publish(ctx context.Context, events []domain.Event)
+}
+
+// Publisher publishes the events of captured transactions to a bus
+type Publisher struct {
+	bus  Bus
+	keys *fieldcrypt.Keyring
+}
+
+// NewPublisher creates a publisher to bus. Encrypted emails, names and
+// event data are decrypted with keys, which is nil when field encryption
+// is disabled.
+func NewPublisher(bus Bus, keys *fieldcrypt.Keyring) *Publisher {
+	return &Publisher{bus: bus, keys: keys}
+}
+
+// batch holds consecutive events of a tenant
+type batch struct {
+	tenant string
+	events []domain.Event
+}
+
+// Handle publishes the events of a committed transaction, each to the
+// subscribers of its tenant
+func (p *Publisher) Handle(ctx context.Context, tx pgcdc.Transaction) error {
+	recorded := make(map[string]bool)
+	for _, change := range tx.Changes {
+		if change.Table == "user_events" && change.Op == pgcdc.OpInsert {
+			userID, _ := change.New.Value("user_id")
+			recorded[userID] = true
+		}
+	}
+
+	var batches []batch
+	add := func(tenant string, events ...domain.Event) {
+		if len(events) == 0 {
+			return
+		}
+		if n := len(batches); n > 0 && batches[n-1].tenant == tenant {
+			batches[n-1].events = append(batches[n-1].events, events...)
+			return
+		}
+		batches = append(batches, batch{tenant: tenant, events: events})
+	}
+
+	for i, change := range tx.Changes {
+		switch change.Table {
+		case "user_events":
+			if change.Op != pgcdc.OpInsert {
+				continue
+			}
+			event, err := p.recordedEvent(change.New)
+			if err != nil {
+				return err
+			}
+			tenant, _ := change.New.Value("tenant_id")
+			add(tenant, event)
+
+		case "users":
+			row := change.New
+			if change.Op == pgcdc.OpDelete {
+				row = change.Old
+			}
+			userID, _ := row.Value("id")
+			if recorded[userID] {
+				// The repository recorded the events of the change
+				continue
+			}
+			events, err := p.derivedEvents(change, tx, i)
+			if err != nil {
+				return fmt.Errorf("user %s: %w", userID, err)
+			}
+			tenant, _ := row.Value("tenant_id")
+			if tenant == "" && change.Old != nil {
+				tenant, _ = change.Old.Value("tenant_id")
+			}
+			add(tenant, events...)
+		}
+	}
+
+	for _, b := range batches {
+		p.bus.Publish(tenancy.WithTenant(ctx, b.tenant), b.events)
+	}
+	return nil
+}
+
+// recordedEvent converts an inserted user_events row to its event
+func (p *Publisher) recordedEvent(row pgcdc.Row) (domain.Event, error) {
+	id, _ := row.Value("id")
+	userID, _ := row.Value("user_id")
+	eventType, _ := row.Value("type")
+	event := domain.Event{ID: id, UserID: userID, Type: domain.EventType(eventType)}
+
+	occurredAt, _ := row.Value("occurred_at")
+	t, err := pgcdc.ParseTimestamp(occurredAt)
+	if err != nil {
+		return domain.Event{}, fmt.Errorf("event %s: %w", id, err)
+	}
+	event.OccurredAt = t
+
+	if data, ok := row.Value("data"); ok && data != "null" {
+		// Encrypted data is a JSON string holding the ciphertext of the
+		// JSON object
+		var encrypted string
+		if json.Unmarshal([]byte(data), &encrypted) == nil && fieldcrypt.IsEncrypted(encrypted) {
+			if data, err = p.decrypt("data", encrypted); err != nil {
+				return domain.Event{}, fmt.Errorf("event %s: %w", id, err)
+			}
+		}
+		if err := json.Unmarshal([]byte(data), &event.Data); err != nil {
+			return domain.Event{}, fmt.Errorf("event %s: invalid data: %w", id, err)
+		}
+	}
+	return event, nil
+}
+
+// userRow holds the columns of a users row the events are derived from
+type userRow struct {
+	email, name, status, username, avatarKey, pendingEmail, deletedAt *string
+}
+
+// readUserRow reads row, decrypting its encrypted columns
+func (p *Publisher) readUserRow(row pgcdc.Row) (userRow, error) {
+	var u userRow
+	for column, field := range map[string]**string{
+		"email":         &u.email,
+		"name":          &u.name,
+		"pending_email": &u.pendingEmail,
+	} {
+		value, ok := row.Value(column)
+		if !ok {
+			continue
+		}
+		plaintext, err := p.decrypt(column, value)
+		if err != nil {
+			return userRow{}, err
+		}
+		*field = &plaintext
+	}
+	u.status = row["status"]
+	u.username = row["username"]
+	u.avatarKey = row["avatar_key"]
+	u.deletedAt = row["deleted_at"]
+	return u, nil
+}
+
+// decrypt decrypts a value of the column, and returns unencrypted values
+// unchanged
+func (p *Publisher) decrypt(column, value string) (string, error) {
+	if !fieldcrypt.IsEncrypted(value) {
+		return value, nil
+	}
+	if p.keys == nil {
+		return "", fmt.Errorf("column %s is encrypted but no keyring is configured", column)
+	}
+	return p.keys.Decrypt(column, value)
+}
+
+// derivedEvents derives the events of the index-th change of tx, made to
+// a users row without recording events
+func (p *Publisher) derivedEvents(change pgcdc.Change, tx pgcdc.Transaction, index int) ([]domain.Event, error) {
+	var (
+		userID, _ = change.New.Value("id")
+		events    []domain.Event
+	)
+	record := func(eventType domain.EventType, data map[string]string) {
+		events = append(events, domain.Event{
+			// Stable across redeliveries of the transaction, so consumers
+			// can tell them apart
+			ID:         uuid.NewSHA1(uuid.NameSpaceOID, fmt.Appendf(nil, "%s/%d/%s", tx.LSN, index, eventType)).String(),
+			UserID:     userID,
+			Type:       eventType,
+			Data:       data,
+			OccurredAt: tx.CommitTime.Truncate(time.Microsecond),
+		})
+	}
+
+	switch change.Op {
+	case pgcdc.OpInsert:
+		row, err := p.readUserRow(change.New)
+		if err != nil {
+			return nil, err
+		}
+		record(domain.EventUserCreated, map[string]string{"email": value(row.email), "name": value(row.name)})
+
+	case pgcdc.OpDelete:
+		userID, _ = change.Old.Value("id")
+		if change.Old["deleted_at"] != nil {
+			// Already announced when soft deleted
+			return nil, nil
+		}
+		record(domain.EventUserDeleted, nil)
+
+	case pgcdc.OpUpdate:
+		if change.Old == nil || len(change.Old) < 2 {
+			return nil, errors.New("the old row of users is missing; set REPLICA IDENTITY FULL on the table")
+		}
+		before, err := p.readUserRow(change.Old)
+		if err != nil {
+			return nil, err
+		}
+		// Unchanged values stored out of line are only in the old row
+		merged := make(pgcdc.Row, len(change.Old))
+		for column, v := range change.Old {
+			merged[column] = v
+		}
+		for column, v := range change.New {
+			merged[column] = v
+		}
+		after, err := p.readUserRow(merged)
+		if err != nil {
+			return nil, err
+		}
+
+		if changed(before.name, after.name) {
+			record(domain.EventNameChanged, map[string]string{"name": value(after.name)})
+		}
+		if changed(before.username, after.username) {
+			record(domain.EventUsernameChanged, map[string]string{"username": value(after.username)})
+		}
+		if changed(before.email, after.email) {
+			record(domain.EventEmailChanged, map[string]string{"email": value(after.email)})
+		}
+		if after.pendingEmail != nil && changed(before.pendingEmail, after.pendingEmail) {
+			record(domain.EventEmailChangeRequested, map[string]string{"email": *after.pendingEmail})
+		}
+		if changed(before.status, after.status) {
+			record(domain.EventStatusChanged, map[string]string{"from": value(before.status), "to": value(after.status)})
+		}
+		if changed(before.avatarKey, after.avatarKey) {
+			if after.avatarKey != nil {
+				record(domain.EventAvatarUpdated, nil)
+			} else {
+				record(domain.EventAvatarRemoved, nil)
+			}
+		}
+		switch {
+		case before.deletedAt == nil && after.deletedAt != nil:
+			record(domain.EventUserDeleted, nil)
+		case before.deletedAt != nil && after.deletedAt == nil:
+			record(domain.EventUserRestored, nil)
+		}
+	}
+	return events, nil
+}
+
+// changed reports whether a nullable value changed
+func changed(before, after *string) bool {
+	if before == nil || after == nil {
+		return before != after
+	}
+	return *before != *after
+}
+
+// value returns the value of a nullable column, empty when NULL
+func value(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,204 @@
+package cdc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pgcdc "github.com/yourusername/go-scaffolding/internal/infrastructure/cdc"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// published is a call to Publish
+type published struct {
+	tenant string
+	events []domain.Event
+}
+
+// fakeBus records what is published
+type fakeBus struct {
+	published []published
+}
+
+func (b *fakeBus) Publish(ctx context.Context, events []domain.Event) {
+	tenant, _ := tenancy.FromContext(ctx)
+	b.published = append(b.published, published{tenant: tenant, events: events})
+}
+
+func testKeyring(t *testing.T) *fieldcrypt.Keyring {
+	t.Helper()
+
+	key := sha256.Sum256([]byte("k1"))
+	index := sha256.Sum256([]byte("index"))
+	keys, err := fieldcrypt.NewKeyring("k1:"+base64.StdEncoding.EncodeToString(key[:]), base64.StdEncoding.EncodeToString(index[:]))
+	require.NoError(t, err)
+	return keys
+}
+
+func encrypt(t *testing.T, keys *fieldcrypt.Keyring, column, plaintext string) *string {
+	t.Helper()
+
+	ciphertext, err := keys.Encrypt(column, plaintext)
+	require.NoError(t, err)
+	return &ciphertext
+}
+
+func ptr(s string) *string { return &s }
+
+var commitTime = time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.UTC)
+
+// usersRow returns a users row with the given values over defaults
+func usersRow(values pgcdc.Row) pgcdc.Row {
+	row := pgcdc.Row{
+		"id":            ptr("42"),
+		"tenant_id":     ptr("acme"),
+		"email":         ptr("ada@example.com"),
+		"name":          ptr("Ada"),
+		"status":        ptr("active"),
+		"username":      nil,
+		"avatar_key":    nil,
+		"pending_email": nil,
+		"deleted_at":    nil,
+	}
+	for column, v := range values {
+		row[column] = v
+	}
+	return row
+}
+
+func TestPublisher_PublishesRecordedEvents(t *testing.T) {
+	keys := testKeyring(t)
+	bus := &fakeBus{}
+	data, err := json.Marshal(*encrypt(t, keys, "data", `{"name":"Ada Lovelace"}`))
+	require.NoError(t, err)
+
+	err = NewPublisher(bus, keys).Handle(context.Background(), pgcdc.Transaction{
+		LSN:        0x180,
+		CommitTime: commitTime,
+		Changes: []pgcdc.Change{
+			{
+				Op:    pgcdc.OpUpdate,
+				Table: "users",
+				Old:   usersRow(nil),
+				New:   usersRow(pgcdc.Row{"name": encrypt(t, keys, "name", "Ada Lovelace")}),
+			},
+			{
+				Op:    pgcdc.OpInsert,
+				Table: "user_events",
+				New: pgcdc.Row{
+					"id":          ptr("0b9f6c4e-5f4e-4c47-9a55-1f1b9a0f5c11"),
+					"tenant_id":   ptr("acme"),
+					"user_id":     ptr("42"),
+					"type":        ptr("user.name_changed"),
+					"data":        ptr(string(data)),
+					"occurred_at": ptr("2026-03-01 11:59:59.5"),
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, bus.published, 1)
+	assert.Equal(t, "acme", bus.published[0].tenant)
+	assert.Equal(t, []domain.Event{{
+		ID:         "0b9f6c4e-5f4e-4c47-9a55-1f1b9a0f5c11",
+		UserID:     "42",
+		Type:       domain.EventNameChanged,
+		Data:       map[string]string{"name": "Ada Lovelace"},
+		OccurredAt: time.Date(2026, 3, 1, 11, 59, 59, 500000000, time.UTC),
+	}}, bus.published[0].events, "the recorded event is published instead of one derived from the row")
+}
+
+func TestPublisher_DerivesEventsOfOutsideChanges(t *testing.T) {
+	keys := testKeyring(t)
+	tx := pgcdc.Transaction{
+		LSN:        0x180,
+		CommitTime: commitTime,
+		Changes: []pgcdc.Change{
+			{
+				Op:    pgcdc.OpUpdate,
+				Table: "users",
+				Old:   usersRow(pgcdc.Row{"name": encrypt(t, keys, "name", "Ada")}),
+				New: usersRow(pgcdc.Row{
+					// Rewritten with a new nonce but unchanged
+					"name":          encrypt(t, keys, "name", "Ada"),
+					"status":        ptr("suspended"),
+					"avatar_key":    ptr("avatars/42.png"),
+					"pending_email": encrypt(t, keys, "pending_email", "ada@new.example.com"),
+					"deleted_at":    ptr("2026-03-01 12:00:00"),
+				}),
+			},
+			{Op: pgcdc.OpInsert, Table: "users", New: usersRow(pgcdc.Row{"id": ptr("43"), "tenant_id": ptr("globex")})},
+		},
+	}
+
+	bus := &fakeBus{}
+	require.NoError(t, NewPublisher(bus, keys).Handle(context.Background(), tx))
+
+	require.Len(t, bus.published, 2)
+	assert.Equal(t, "acme", bus.published[0].tenant)
+	var types []domain.EventType
+	for _, event := range bus.published[0].events {
+		types = append(types, event.Type)
+		assert.Equal(t, "42", event.UserID)
+		assert.Equal(t, commitTime.Truncate(time.Microsecond), event.OccurredAt)
+	}
+	assert.Equal(t, []domain.EventType{
+		domain.EventEmailChangeRequested,
+		domain.EventStatusChanged,
+		domain.EventAvatarUpdated,
+		domain.EventUserDeleted,
+	}, types)
+	assert.Equal(t, map[string]string{"email": "ada@new.example.com"}, bus.published[0].events[0].Data)
+	assert.Equal(t, map[string]string{"from": "active", "to": "suspended"}, bus.published[0].events[1].Data)
+
+	assert.Equal(t, "globex", bus.published[1].tenant)
+	assert.Equal(t, domain.EventUserCreated, bus.published[1].events[0].Type)
+	assert.Equal(t, map[string]string{"email": "ada@example.com", "name": "Ada"}, bus.published[1].events[0].Data)
+
+	// A redelivery of the transaction publishes the same event IDs
+	again := &fakeBus{}
+	require.NoError(t, NewPublisher(again, keys).Handle(context.Background(), tx))
+	assert.Equal(t, bus.published, again.published)
+}
+
+func TestPublisher_Deletes(t *testing.T) {
+	bus := &fakeBus{}
+	err := NewPublisher(bus, nil).Handle(context.Background(), pgcdc.Transaction{
+		Changes: []pgcdc.Change{
+			{Op: pgcdc.OpDelete, Table: "users", Old: usersRow(pgcdc.Row{"deleted_at": ptr("2026-03-01 12:00:00")})},
+			{Op: pgcdc.OpDelete, Table: "users", Old: usersRow(pgcdc.Row{"id": ptr("43")})},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, bus.published, 1)
+	require.Len(t, bus.published[0].events, 1, "a soft deleted user was announced before")
+	assert.Equal(t, "43", bus.published[0].events[0].UserID)
+	assert.Equal(t, domain.EventUserDeleted, bus.published[0].events[0].Type)
+}
+
+func TestPublisher_Errors(t *testing.T) {
+	keys := testKeyring(t)
+	tests := map[string]pgcdc.Change{
+		"without replica identity full": {Op: pgcdc.OpUpdate, Table: "users", Old: pgcdc.Row{"id": ptr("42")}, New: usersRow(nil)},
+		"encrypted without keyring":     {Op: pgcdc.OpInsert, Table: "users", New: usersRow(pgcdc.Row{"name": encrypt(t, keys, "name", "Ada")})},
+		"invalid event time":            {Op: pgcdc.OpInsert, Table: "user_events", New: pgcdc.Row{"id": ptr("1"), "occurred_at": ptr("yesterday")}},
+	}
+	for name, change := range tests {
+		t.Run(name, func(t *testing.T) {
+			bus := &fakeBus{}
+			err := NewPublisher(bus, nil).Handle(context.Background(), pgcdc.Transaction{Changes: []pgcdc.Change{change}})
+			assert.Error(t, err)
+			assert.Empty(t, bus.published)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package emailvalidation
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// chain runs validators in order and stops at the first error
+type chain []ports.EmailValidator
+
+// Chain combines validators into one that runs them in order, so cheap
+// checks can reject an address before slower ones are asked. Nil
+// validators are skipped, and Chain returns nil when none is left.
+func Chain(validators ...ports.EmailValidator) ports.EmailValidator {
+	var c chain
+	for _, v := range validators {
+		if v != nil {
+			c = append(c, v)
+		}
+	}
+
+	switch len(c) {
+	case 0:
+		return nil
+	case 1:
+		return c[0]
+	default:
+		return c
+	}
+}
+
+// Validate returns the first error a validator reports
+func (c chain) Validate(ctx context.Context, email string) error {
+	for _, v := range c {
+		if err := v.Validate(ctx, email); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package emailvalidation
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/httpclient"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const testAPIKey = "test-key"
+
+// stub is a canned response for requests matching its method, path and
+// query, in the style of a WireMock mapping
+type stub struct {
+	Method string
+	Path   string
+	Query  map[string]string
+
+	Status int
+	Body   string
+}
+
+// stubServer plays the validation API. Requests that match no stub, or
+// that lack the headers every request must carry, fail the test.
+type stubServer struct {
+	t     *testing.T
+	URL   string
+	stubs []stub
+
+	mu       sync.Mutex
+	requests int
+}
+
+func newStubServer(t *testing.T, stubs ...stub) *stubServer {
+	t.Helper()
+
+	s := &stubServer{t: t, stubs: stubs}
+	server := httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(server.Close)
+	s.URL = server.URL
+	return s
+}
+
+func (s *stubServer) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	s.mu.Unlock()
+
+	assert.Equal(s.t, "Bearer "+testAPIKey, r.Header.Get("Authorization"))
+	assert.Equal(s.t, "application/json", r.Header.Get("Accept"))
+
+	for _, st := range s.stubs {
+		if st.matches(r) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(st.Status)
+			_, _ = io.WriteString(w, st.Body)
+			return
+		}
+	}
+
+	s.t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+	w.WriteHeader(http.StatusNotFound)
+}
+
+func (s *stubServer) requestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (st stub) matches(r *http.Request) bool {
+	if r.Method != st.Method || r.URL.Path != st.Path {
+		return false
+	}
+	query := r.URL.Query()
+	if len(query) != len(st.Query) {
+		return false
+	}
+	for key, value := range st.Query {
+		if query.Get(key) != value {
+			return false
+		}
+	}
+	return true
+}
+
+func validateStub(email string, status int, body string) stub {
+	return stub{
+		Method: http.MethodGet,
+		Path:   "/v1/validate",
+		Query:  map[string]string{"email": email},
+		Status: status,
+		Body:   body,
+	}
+}
+
+func newTestValidator(t *testing.T, baseURL string, failOpen bool) ports.EmailValidator {
+	t.Helper()
+
+	client := httpclient.New(httpclient.Options{
+		Name:  "email-validation-test",
+		Retry: httpclient.RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond},
+	})
+	v, err := New(Options{BaseURL: baseURL, APIKey: testAPIKey, FailOpen: failOpen}, client, logger.New("info", io.Discard))
+	require.NoError(t, err)
+	return v
+}
+
+func TestValidator_Contract(t *testing.T) {
+	server := newStubServer(t,
+		validateStub("jane@example.com", http.StatusOK, `{"email":"jane@example.com","deliverable":true}`),
+		validateStub("jane+news@example.com", http.StatusOK, `{"email":"jane+news@example.com","deliverable":true,"reason":""}`),
+		validateStub("nobody@example.com", http.StatusOK, `{"email":"nobody@example.com","deliverable":false,"reason":"mailbox does not exist"}`),
+		validateStub("silent@example.com", http.StatusOK, `{"email":"silent@example.com","deliverable":false}`),
+	)
+	v := newTestValidator(t, server.URL, false)
+	ctx := context.Background()
+
+	t.Run("deliverable address", func(t *testing.T) {
+		assert.NoError(t, v.Validate(ctx, "jane@example.com"))
+	})
+
+	t.Run("address is query encoded", func(t *testing.T) {
+		assert.NoError(t, v.Validate(ctx, "jane+news@example.com"))
+	})
+
+	t.Run("undeliverable address with reason", func(t *testing.T) {
+		err := v.Validate(ctx, "nobody@example.com")
+		assert.ErrorIs(t, err, domain.ErrUndeliverableEmail)
+		assert.EqualError(t, err, "email address cannot receive mail: mailbox does not exist")
+	})
+
+	t.Run("undeliverable address without reason", func(t *testing.T) {
+		err := v.Validate(ctx, "silent@example.com")
+		assert.Equal(t, domain.ErrUndeliverableEmail, err)
+	})
+}
+
+func TestValidator_BaseURLWithPath(t *testing.T) {
+	server := newStubServer(t, stub{
+		Method: http.MethodGet,
+		Path:   "/api/v1/validate",
+		Query:  map[string]string{"email": "jane@example.com"},
+		Status: http.StatusOK,
+		Body:   `{"deliverable":true}`,
+	})
+
+	v := newTestValidator(t, server.URL+"/api/", false)
+	assert.NoError(t, v.Validate(context.Background(), "jane@example.com"))
+}
+
+func TestValidator_Unavailable(t *testing.T) {
+	tests := []struct {
+		name     string
+		stub     stub
+		attempts int
+	}{
+		{
+			name:     "server error is retried",
+			stub:     validateStub("jane@example.com", http.StatusServiceUnavailable, `{"error":"maintenance"}`),
+			attempts: 3,
+		},
+		{
+			name:     "rejected API key",
+			stub:     validateStub("jane@example.com", http.StatusUnauthorized, `{"error":"invalid api key"}`),
+			attempts: 1,
+		},
+		{
+			name:     "malformed body",
+			stub:     validateStub("jane@example.com", http.StatusOK, `not json`),
+			attempts: 1,
+		},
+		{
+			name:     "body without verdict",
+			stub:     validateStub("jane@example.com", http.StatusOK, `{"email":"jane@example.com"}`),
+			attempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Run("fail closed", func(t *testing.T) {
+				server := newStubServer(t, tt.stub)
+				err := newTestValidator(t, server.URL, false).Validate(context.Background(), "jane@example.com")
+				assert.ErrorIs(t, err, ErrUnavailable)
+				assert.NotErrorIs(t, err, domain.ErrUndeliverableEmail)
+				assert.Equal(t, tt.attempts, server.requestCount())
+			})
+
+			t.Run("fail open", func(t *testing.T) {
+				server := newStubServer(t, tt.stub)
+				err := newTestValidator(t, server.URL, true).Validate(context.Background(), "jane@example.com")
+				assert.NoError(t, err)
+			})
+		})
+	}
+}
+
+func TestValidator_UnreachableService(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	err := newTestValidator(t, url, false).Validate(context.Background(), "jane@example.com")
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	assert.NoError(t, newTestValidator(t, url, true).Validate(context.Background(), "jane@example.com"))
+}
+
+func TestNew_InvalidBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"", "emailcheck.example.com", "://bad"} {
+		_, err := New(Options{BaseURL: baseURL}, http.DefaultClient, logger.New("info", io.Discard))
+		assert.Error(t, err, baseURL)
+	}
+}
@@ -0,0 +1,112 @@
+package emailvalidation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// Resolver looks up the DNS records the MX validator needs. *net.Resolver
+// implements it.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// MXOptions configures the MX validator
+type MXOptions struct {
+	// Timeout bounds each lookup; zero leaves it to the caller's context
+	Timeout time.Duration
+
+	// FailOpen accepts addresses when DNS cannot give an answer, so a
+	// resolver outage does not stop sign-ups
+	FailOpen bool
+}
+
+// mx implements ports.EmailValidator with DNS lookups
+type mx struct {
+	resolver Resolver
+	timeout  time.Duration
+	failOpen bool
+	logger   *logger.Logger
+}
+
+// NewMX creates a validator that returns domain.ErrUndeliverableEmail when
+// the domain of an address does not accept mail. A nil resolver uses
+// net.DefaultResolver.
+func NewMX(opts MXOptions, resolver Resolver, log *logger.Logger) ports.EmailValidator {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &mx{
+		resolver: resolver,
+		timeout:  opts.Timeout,
+		failOpen: opts.FailOpen,
+		logger:   log,
+	}
+}
+
+// Validate looks up the mail exchangers of the address's domain. Following
+// RFC 5321, a domain without MX records receives mail at its own address,
+// and a single "." exchanger (RFC 7505) means it receives none.
+func (v *mx) Validate(ctx context.Context, email string) error {
+	host := email[strings.LastIndexByte(email, '@')+1:]
+
+	lookupCtx := ctx
+	if v.timeout > 0 {
+		var cancel context.CancelFunc
+		lookupCtx, cancel = context.WithTimeout(ctx, v.timeout)
+		defer cancel()
+	}
+
+	err := v.check(lookupCtx, host)
+	if err == nil || errors.Is(err, domain.ErrUndeliverableEmail) {
+		return err
+	}
+
+	err = fmt.Errorf("%w: %w", ErrUnavailable, err)
+	// A lookup that timed out fails open, a request that was canceled does not
+	if v.failOpen && ctx.Err() == nil {
+		v.logger.Warn().Err(err).Str("domain", host).Msg("MX lookup failed, accepting address")
+		return nil
+	}
+	return err
+}
+
+// check returns domain.ErrUndeliverableEmail when host does not accept
+// mail, and other errors when DNS cannot tell
+func (v *mx) check(ctx context.Context, host string) error {
+	records, err := v.resolver.LookupMX(ctx, host)
+	if err == nil && len(records) > 0 {
+		if len(records) == 1 && (records[0].Host == "." || records[0].Host == "") {
+			return domain.ErrUndeliverableEmail.Wrap(errors.New("domain does not accept mail"))
+		}
+		return nil
+	}
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	// No MX records: fall back to the domain's own address
+	if _, err := v.resolver.LookupHost(ctx, host); err != nil {
+		if isNotFound(err) {
+			return domain.ErrUndeliverableEmail.Wrap(errors.New("domain has no mail server"))
+		}
+		return err
+	}
+	return nil
+}
+
+// isNotFound reports whether err says the record does not exist, as
+// opposed to DNS being unable to answer
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,160 @@
+package emailvalidation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+func TestStrict(t *testing.T) {
+	tests := []struct {
+		email string
+		valid bool
+	}{
+		{"jane@example.com", true},
+		{"jane.doe+tag@example.com", true},
+		{"o'brien@example.com", true},
+		{"{x}|y~z@example.com", true},
+		{strings.Repeat("a", 64) + "@example.com", true},
+		{strings.Repeat("a", 65) + "@example.com", false},
+		{`"jane doe"@example.com`, false},
+		{"jöhn@example.com", false},
+	}
+
+	v := NewStrict()
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			err := v.Validate(context.Background(), tt.email)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, domain.ErrInvalidEmail)
+			}
+		})
+	}
+}
+
+// fakeResolver answers lookups from maps; names missing from both are
+// not found
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	err   error
+	delay time.Duration
+}
+
+func (r *fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if r.delay > 0 {
+		select {
+		case <-time.After(r.delay):
+		case <-ctx.Done():
+			return nil, &net.DNSError{Err: ctx.Err().Error(), Name: name, IsTimeout: true}
+		}
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func newMX(resolver Resolver, opts MXOptions) ports.EmailValidator {
+	return NewMX(opts, resolver, logger.New("info", io.Discard))
+}
+
+func TestMX(t *testing.T) {
+	resolver := &fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com": {{Host: "mx1.example.com.", Pref: 10}},
+			"nomail.com":  {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{
+			"implicit.com": {"192.0.2.1"},
+		},
+	}
+	v := newMX(resolver, MXOptions{})
+
+	assert.NoError(t, v.Validate(context.Background(), "jane@example.com"))
+	assert.NoError(t, v.Validate(context.Background(), "jane@implicit.com"), "the domain's own address receives mail")
+	assert.ErrorIs(t, v.Validate(context.Background(), "jane@nomail.com"), domain.ErrUndeliverableEmail)
+	assert.ErrorIs(t, v.Validate(context.Background(), "jane@missing.com"), domain.ErrUndeliverableEmail)
+}
+
+func TestMX_Unavailable(t *testing.T) {
+	failing := &fakeResolver{err: &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}}
+	slow := &fakeResolver{delay: time.Second}
+
+	t.Run("fail closed", func(t *testing.T) {
+		err := newMX(failing, MXOptions{}).Validate(context.Background(), "jane@example.com")
+		assert.ErrorIs(t, err, ErrUnavailable)
+		assert.False(t, errors.Is(err, domain.ErrUndeliverableEmail))
+	})
+
+	t.Run("fail open", func(t *testing.T) {
+		assert.NoError(t, newMX(failing, MXOptions{FailOpen: true}).Validate(context.Background(), "jane@example.com"))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		v := newMX(slow, MXOptions{Timeout: 10 * time.Millisecond})
+		assert.ErrorIs(t, v.Validate(context.Background(), "jane@example.com"), ErrUnavailable)
+
+		v = newMX(slow, MXOptions{Timeout: 10 * time.Millisecond, FailOpen: true})
+		assert.NoError(t, v.Validate(context.Background(), "jane@example.com"))
+	})
+
+	t.Run("canceled request does not fail open", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		v := newMX(slow, MXOptions{FailOpen: true})
+		assert.Error(t, v.Validate(ctx, "jane@example.com"))
+	})
+}
+
+// validatorFunc adapts a function to ports.EmailValidator
+type validatorFunc func(ctx context.Context, email string) error
+
+func (f validatorFunc) Validate(ctx context.Context, email string) error {
+	return f(ctx, email)
+}
+
+func TestChain(t *testing.T) {
+	assert.Nil(t, Chain())
+	assert.Nil(t, Chain(nil, nil))
+
+	single := NewStrict()
+	assert.Equal(t, single, Chain(nil, single))
+
+	var calls []string
+	record := func(name string, err error) ports.EmailValidator {
+		return validatorFunc(func(context.Context, string) error {
+			calls = append(calls, name)
+			return err
+		})
+	}
+
+	v := Chain(record("first", nil), nil, record("second", domain.ErrUndeliverableEmail), record("third", nil))
+	require.NotNil(t, v)
+	assert.ErrorIs(t, v.Validate(context.Background(), "jane@example.com"), domain.ErrUndeliverableEmail)
+	assert.Equal(t, []string{"first", "second"}, calls)
+}
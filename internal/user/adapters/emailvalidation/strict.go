@@ -0,0 +1,61 @@
+package emailvalidation
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// maxLocalPartLength is the longest local part RFC 5321 allows
+const maxLocalPartLength = 64
+
+// atextSpecials are the characters other than letters and digits RFC 5322
+// allows in an atom
+const atextSpecials = "!#$%&'*+-/=?^_`{|}~"
+
+// strict implements ports.EmailValidator with the syntax rules of RFC 5321
+// and RFC 5322 that are left out of the domain's default check
+type strict struct{}
+
+// NewStrict creates a validator that returns domain.ErrInvalidEmail for
+// addresses the domain accepts but many mail servers do not: non-ASCII
+// local parts, and local parts longer than 64 octets.
+func NewStrict() ports.EmailValidator {
+	return strict{}
+}
+
+// Validate checks the local part of email, which the domain has already
+// accepted as an address
+func (strict) Validate(_ context.Context, email string) error {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return domain.ErrInvalidEmail
+	}
+
+	local := email[:at]
+	if len(local) > maxLocalPartLength {
+		return domain.ErrInvalidEmail.Wrap(errors.New("local part exceeds 64 characters"))
+	}
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" || !isAtom(atom) {
+			return domain.ErrInvalidEmail.Wrap(errors.New("local part must be a dot-separated list of ASCII atoms"))
+		}
+	}
+	return nil
+}
+
+// isAtom reports whether s consists of RFC 5322 atext only
+func isAtom(s string) bool {
+	for _, c := range []byte(s) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte(atextSpecials, c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
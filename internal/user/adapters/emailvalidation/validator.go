@@ -0,0 +1,153 @@
+// Package emailvalidation implements the ports.EmailValidator strategies
+// new and changed addresses can be checked with on top of the syntax rules
+// of the user domain:
+//
+//   - Strict enforces the limits of RFC 5321 and RFC 5322 that most mail
+//     servers apply: ASCII local parts of at most 64 octets.
+//   - MX looks up the mail exchangers of the address's domain.
+//   - New asks a remote email validation API.
+//
+// Chain runs several of them in order.
+//
+// The remote validator is the example of a driven adapter for a
+// third-party service: the user service only knows the port, and this
+// package turns it into HTTP calls made with the client from
+// internal/infrastructure/httpclient, which adds timeouts, retries,
+// circuit breaking and trace propagation.
+//
+// The remote validator expects the following contract, which
+// contract_test.go pins down:
+//
+//	GET {base_url}/v1/validate?email={address}
+//	Authorization: Bearer {api_key}
+//	Accept: application/json
+//
+//	200 OK
+//	{"email": "jane@example.com", "deliverable": false, "reason": "mailbox does not exist"}
+//
+// Any other status, or a body without "deliverable", is an error. Replace
+// the request and response types to integrate a real provider.
+package emailvalidation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// validatePath is the endpoint addresses are checked at, relative to the
+// base URL
+const validatePath = "/v1/validate"
+
+// maxResponseBytes bounds how much of a response body is read
+const maxResponseBytes = 64 << 10
+
+// ErrUnavailable indicates the validation service could not give an answer
+var ErrUnavailable = errors.New("email validation service unavailable")
+
+// Options configures the validator
+type Options struct {
+	// BaseURL is the root of the validation API, such as
+	// https://emailcheck.example.com
+	BaseURL string
+
+	// APIKey is sent as a bearer token
+	APIKey string
+
+	// FailOpen accepts addresses when the service cannot give an answer,
+	// so an outage of the provider does not stop sign-ups
+	FailOpen bool
+}
+
+// validateResponse is the body of a successful check
+type validateResponse struct {
+	Email       string `json:"email"`
+	Deliverable *bool  `json:"deliverable"`
+	Reason      string `json:"reason"`
+}
+
+// validator implements ports.EmailValidator against the remote API
+type validator struct {
+	endpoint string
+	apiKey   string
+	failOpen bool
+	client   *http.Client
+	logger   *logger.Logger
+}
+
+// New creates a remote validator that sends its requests with client
+func New(opts Options, client *http.Client, log *logger.Logger) (ports.EmailValidator, error) {
+	base, err := url.Parse(opts.BaseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid email validation base URL: %q", opts.BaseURL)
+	}
+
+	return &validator{
+		endpoint: strings.TrimSuffix(base.String(), "/") + validatePath,
+		apiKey:   opts.APIKey,
+		failOpen: opts.FailOpen,
+		client:   client,
+		logger:   log,
+	}, nil
+}
+
+// Validate returns domain.ErrUndeliverableEmail when the service reports
+// that email cannot receive mail
+func (v *validator) Validate(ctx context.Context, email string) error {
+	result, err := v.check(ctx, email)
+	if err != nil {
+		if v.failOpen && ctx.Err() == nil {
+			v.logger.Warn().Err(err).Msg("Email validation unavailable, accepting address")
+			return nil
+		}
+		return err
+	}
+
+	if *result.Deliverable {
+		return nil
+	}
+	if result.Reason != "" {
+		return domain.ErrUndeliverableEmail.Wrap(errors.New(result.Reason))
+	}
+	return domain.ErrUndeliverableEmail
+}
+
+// check asks the service about email
+func (v *validator) check(ctx context.Context, email string) (*validateResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.endpoint+"?"+url.Values{"email": {email}}.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if v.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+v.apiKey)
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: unexpected status %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	var result validateResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&result); err != nil {
+		return nil, fmt.Errorf("%w: decode response: %w", ErrUnavailable, err)
+	}
+	if result.Deliverable == nil {
+		return nil, fmt.Errorf("%w: response has no deliverable field", ErrUnavailable)
+	}
+	return &result, nil
+}
@@ -0,0 +1,153 @@
+// Package eventbus delivers user events to subscribers in the same process
+// as soon as they are stored. NewRepository wraps the user repository to
+// feed the bus, so every change made through it is announced, whichever
+// service made it.
+//
+// Delivery is best effort and in memory: subscribers only see the events
+// stored by the process they are connected to, and miss events while they
+// are not connected. The activity feed is the durable record.
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tracecontext"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// DefaultBuffer is the number of events queued per subscriber when none is
+// configured
+const DefaultBuffer = 64
+
+// Options configures the event bus
+type Options struct {
+	// Buffer is how many events may wait for a subscriber to receive them.
+	// A subscriber falling further behind is dropped, and its channel is
+	// closed, so a slow client cannot hold up the others. Zero uses
+	// DefaultBuffer.
+	Buffer int
+}
+
+// Bus fans out stored user events to subscribers. It implements the
+// UserEvents port.
+type Bus struct {
+	buffer int
+
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+// subscriber receives the events of one tenant, or of all of them, that
+// match its filter
+type subscriber struct {
+	tenant     string
+	allTenants bool
+	filter     domain.EventFilter
+	events     chan domain.Event
+}
+
+// New creates an event bus
+func New(opts Options) *Bus {
+	buffer := opts.Buffer
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+
+	return &Bus{
+		buffer:      buffer,
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+// Subscribe delivers the events matching the filter that are published in
+// the tenant of ctx, until ctx is done. A context without a tenant returned
+// by tenancy.AllTenants receives the events of every tenant, for workers
+// such as the read model projection.
+func (b *Bus) Subscribe(ctx context.Context, filter domain.EventFilter) (<-chan domain.Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tenant, ok := tenancy.FromContext(ctx)
+	sub := &subscriber{
+		tenant:     tenant,
+		allTenants: !ok && tenancy.SpansTenants(ctx),
+		filter:     filter,
+		events:     make(chan domain.Event, b.buffer),
+	}
+
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.drop(sub)
+	})
+
+	return sub.events, nil
+}
+
+// Publish delivers events stored in the tenant of ctx to its subscribers,
+// carrying the trace context of ctx. It never blocks on a subscriber.
+func (b *Bus) Publish(ctx context.Context, events []domain.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	tenant, _ := tenancy.FromContext(ctx)
+	if headers := tracecontext.Inject(ctx); headers != nil {
+		traced := make([]domain.Event, len(events))
+		for i, event := range events {
+			event.TraceContext = headers
+			traced[i] = event
+		}
+		events = traced
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		if sub.tenant != tenant && !sub.allTenants {
+			continue
+		}
+
+		for _, event := range events {
+			if !sub.filter.Matches(event) {
+				continue
+			}
+
+			select {
+			case sub.events <- event:
+				continue
+			default:
+			}
+
+			// The subscriber fell behind; it has to catch up from the
+			// activity feed
+			b.drop(sub)
+			break
+		}
+	}
+}
+
+// Subscribers returns the number of active subscribers
+func (b *Bus) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// drop removes the subscriber and closes its channel, once. The caller
+// must hold b.mu.
+func (b *Bus) drop(sub *subscriber) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+	delete(b.subscribers, sub)
+	close(sub.events)
+}
@@ -0,0 +1,163 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tracecontext"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+var now = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// receive returns the next event of the subscription, failing the test
+// when none arrives
+func receive(t *testing.T, events <-chan domain.Event) domain.Event {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "subscription closed")
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+		return domain.Event{}
+	}
+}
+
+// assertNoEvent fails the test when the subscription has an event waiting
+func assertNoEvent(t *testing.T, events <-chan domain.Event) {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		assert.Failf(t, "unexpected event", "%+v", event)
+	default:
+	}
+}
+
+func TestBus_DeliversMatchingEvents(t *testing.T) {
+	bus := New(Options{})
+	ctx := t.Context()
+
+	all, err := bus.Subscribe(ctx, domain.EventFilter{})
+	require.NoError(t, err)
+	ada, err := bus.Subscribe(ctx, domain.EventFilter{UserID: "ada"})
+	require.NoError(t, err)
+
+	created := domain.NewEvent(idgen.SequentialID(1), "ada", domain.EventUserCreated, nil, now)
+	renamed := domain.NewEvent(idgen.SequentialID(2), "bob", domain.EventNameChanged, map[string]string{"name": "Bob"}, now)
+	bus.Publish(ctx, []domain.Event{created, renamed})
+
+	assert.Equal(t, created, receive(t, all))
+	assert.Equal(t, renamed, receive(t, all))
+	assert.Equal(t, created, receive(t, ada))
+	assertNoEvent(t, ada)
+}
+
+func TestBus_IsolatesTenants(t *testing.T) {
+	bus := New(Options{})
+	acme := tenancy.WithTenant(t.Context(), "acme")
+	globex := tenancy.WithTenant(t.Context(), "globex")
+
+	events, err := bus.Subscribe(acme, domain.EventFilter{})
+	require.NoError(t, err)
+
+	bus.Publish(globex, []domain.Event{domain.NewEvent(idgen.SequentialID(3), "1", domain.EventUserCreated, nil, now)})
+	bus.Publish(t.Context(), []domain.Event{domain.NewEvent(idgen.SequentialID(4), "2", domain.EventUserCreated, nil, now)})
+	assertNoEvent(t, events)
+
+	bus.Publish(acme, []domain.Event{domain.NewEvent(idgen.SequentialID(5), "3", domain.EventUserCreated, nil, now)})
+	assert.Equal(t, "3", receive(t, events).UserID)
+}
+
+func TestBus_DeliversAllTenantsToSpanningSubscribers(t *testing.T) {
+	bus := New(Options{})
+	all, err := bus.Subscribe(tenancy.AllTenants(t.Context()), domain.EventFilter{})
+	require.NoError(t, err)
+
+	bus.Publish(tenancy.WithTenant(t.Context(), "acme"), []domain.Event{domain.NewEvent(idgen.SequentialID(6), "1", domain.EventUserCreated, nil, now)})
+	bus.Publish(tenancy.WithTenant(t.Context(), "globex"), []domain.Event{domain.NewEvent(idgen.SequentialID(7), "2", domain.EventUserCreated, nil, now)})
+	assert.Equal(t, "1", receive(t, all).UserID)
+	assert.Equal(t, "2", receive(t, all).UserID)
+
+	// A tenant in the context narrows the subscription to it
+	acme, err := bus.Subscribe(tenancy.AllTenants(tenancy.WithTenant(t.Context(), "acme")), domain.EventFilter{})
+	require.NoError(t, err)
+	bus.Publish(tenancy.WithTenant(t.Context(), "globex"), []domain.Event{domain.NewEvent(idgen.SequentialID(8), "3", domain.EventUserCreated, nil, now)})
+	assert.Equal(t, "3", receive(t, all).UserID)
+	assertNoEvent(t, acme)
+}
+
+func TestBus_DropsSlowSubscribers(t *testing.T) {
+	bus := New(Options{Buffer: 2})
+
+	slow, err := bus.Subscribe(t.Context(), domain.EventFilter{})
+	require.NoError(t, err)
+	fast, err := bus.Subscribe(t.Context(), domain.EventFilter{})
+	require.NoError(t, err)
+
+	for i := range 3 {
+		event := domain.NewEvent(idgen.SequentialID(9), "1", domain.EventNameChanged, nil, now.Add(time.Duration(i)))
+		bus.Publish(t.Context(), []domain.Event{event})
+		receive(t, fast)
+	}
+
+	// The buffered events are still delivered before the channel closes
+	receive(t, slow)
+	receive(t, slow)
+	_, ok := <-slow
+	assert.False(t, ok, "the slow subscriber is dropped")
+	assert.Equal(t, 1, bus.Subscribers())
+}
+
+func TestBus_UnsubscribesWhenContextIsDone(t *testing.T) {
+	bus := New(Options{})
+	ctx, cancel := context.WithCancel(t.Context())
+
+	events, err := bus.Subscribe(ctx, domain.EventFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 1, bus.Subscribers())
+
+	cancel()
+	require.Eventually(t, func() bool { return bus.Subscribers() == 0 }, time.Second, time.Millisecond)
+	_, ok := <-events
+	assert.False(t, ok)
+
+	// Publishing after the subscriber left does not panic on its closed channel
+	bus.Publish(ctx, []domain.Event{domain.NewEvent(idgen.SequentialID(10), "1", domain.EventUserCreated, nil, now)})
+
+	_, err = bus.Subscribe(ctx, domain.EventFilter{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestBus_CarriesTraceContext(t *testing.T) {
+	bus := New(Options{})
+	events, err := bus.Subscribe(t.Context(), domain.EventFilter{})
+	require.NoError(t, err)
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	require.NoError(t, err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	require.NoError(t, err)
+	ctx := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	created := domain.NewEvent(idgen.SequentialID(11), "ada", domain.EventUserCreated, nil, now)
+	bus.Publish(ctx, []domain.Event{created})
+
+	event := receive(t, events)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", event.TraceContext["traceparent"])
+	assert.Equal(t, traceID, trace.SpanContextFromContext(tracecontext.Extract(context.Background(), event.TraceContext)).TraceID())
+	assert.Nil(t, created.TraceContext, "the published events are not modified")
+}
@@ -0,0 +1,102 @@
+package eventbus
+
+import (
+	"context"
+	"slices"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// repository publishes the events stored by the wrapped repository
+type repository struct {
+	ports.UserRepository
+	bus *Bus
+	ids ports.IDGenerator
+}
+
+// NewRepository returns repo publishing to bus the events it stores. Inside
+// a transaction started by database.Transactor they are published once it
+// commits, so rolled back changes are never announced. Events that cannot
+// be read back are announced with an ID from ids.
+func NewRepository(repo ports.UserRepository, bus *Bus, ids ports.IDGenerator) ports.UserRepository {
+	return &repository{UserRepository: repo, bus: bus, ids: ids}
+}
+
+// Create creates the user and publishes its events
+func (r *repository) Create(ctx context.Context, user *domain.User) error {
+	// The events are cleared once stored
+	events := slices.Clone(user.Events())
+	if err := r.UserRepository.Create(ctx, user); err != nil {
+		return err
+	}
+	r.publish(ctx, events)
+	return nil
+}
+
+// CreateBatch creates the users and publishes their events
+func (r *repository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	var events []domain.Event
+	for _, user := range users {
+		events = append(events, user.Events()...)
+	}
+	if err := r.UserRepository.CreateBatch(ctx, users); err != nil {
+		return err
+	}
+	r.publish(ctx, events)
+	return nil
+}
+
+// Update updates the user and publishes its events
+func (r *repository) Update(ctx context.Context, user *domain.User) error {
+	events := slices.Clone(user.Events())
+	if err := r.UserRepository.Update(ctx, user); err != nil {
+		return err
+	}
+	r.publish(ctx, events)
+	return nil
+}
+
+// Delete deletes the user and publishes the deletion
+func (r *repository) Delete(ctx context.Context, id string) error {
+	if err := r.UserRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.publishStored(ctx, id, domain.EventUserDeleted)
+	return nil
+}
+
+// Restore restores the user and publishes the restore
+func (r *repository) Restore(ctx context.Context, id string) error {
+	if err := r.UserRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	r.publishStored(ctx, id, domain.EventUserRestored)
+	return nil
+}
+
+// publishStored publishes the latest event of the type that the wrapped
+// repository recorded itself, so subscribers see the same event as the
+// activity feed
+func (r *repository) publishStored(ctx context.Context, userID string, eventType domain.EventType) {
+	events, err := r.UserRepository.ListEvents(ctx, domain.EventFilter{UserID: userID, Type: eventType}, nil, 1)
+	if err != nil || len(events) == 0 {
+		// The change is stored; announce it even though its event could
+		// not be read back
+		events = []domain.Event{domain.NewEvent(r.ids.NewID(), userID, eventType, nil, utc.Now())}
+	}
+	r.publish(ctx, events)
+}
+
+// publish hands the events to the bus once the transaction in ctx, if
+// any, commits
+func (r *repository) publish(ctx context.Context, events []domain.Event) {
+	if len(events) == 0 {
+		return
+	}
+	database.AfterCommit(ctx, func() {
+		r.bus.Publish(ctx, events)
+	})
+}
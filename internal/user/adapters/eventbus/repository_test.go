@@ -0,0 +1,95 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/memory"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+func newUser(t *testing.T, id, email string) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser(id, email, "Test User", now)
+	require.NoError(t, err)
+	return user
+}
+
+func TestRepository_PublishesStoredEvents(t *testing.T) {
+	bus := New(Options{})
+	ids := idgen.NewSequential()
+	inner := memory.NewUserRepository(ids)
+	repo := NewRepository(inner, bus, ids)
+	ctx := t.Context()
+
+	events, err := bus.Subscribe(ctx, domain.EventFilter{})
+	require.NoError(t, err)
+
+	user := newUser(t, "1", "ada@example.com")
+	require.NoError(t, repo.Create(ctx, user))
+	assert.Equal(t, domain.EventUserCreated, receive(t, events).Type)
+
+	require.NoError(t, user.UpdateName("Ada", now))
+	require.NoError(t, repo.Update(ctx, user))
+	renamed := receive(t, events)
+	assert.Equal(t, domain.EventNameChanged, renamed.Type)
+	assert.Equal(t, map[string]string{"name": "Ada"}, renamed.Data)
+
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.User{newUser(t, "2", "bob@example.com"), newUser(t, "3", "eve@example.com")}))
+	assert.Equal(t, "2", receive(t, events).UserID)
+	assert.Equal(t, "3", receive(t, events).UserID)
+
+	require.NoError(t, repo.Delete(ctx, "1"))
+	deleted := receive(t, events)
+	stored, err := inner.ListEvents(ctx, domain.EventFilter{UserID: "1", Type: domain.EventUserDeleted}, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, stored[0], deleted, "subscribers see the event of the activity feed")
+
+	require.NoError(t, repo.Restore(ctx, "1"))
+	assert.Equal(t, domain.EventUserRestored, receive(t, events).Type)
+
+	t.Run("failed writes publish nothing", func(t *testing.T) {
+		err := repo.Create(ctx, newUser(t, "4", "ada@example.com"))
+		require.ErrorIs(t, err, domain.ErrDuplicateEmail)
+		assert.ErrorIs(t, repo.Delete(ctx, "missing"), domain.ErrUserNotFound)
+		assertNoEvent(t, events)
+	})
+}
+
+func TestRepository_PublishesAfterCommit(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	transactor := database.NewTransactor(db)
+
+	bus := New(Options{})
+	ids := idgen.NewSequential()
+	repo := NewRepository(memory.NewUserRepository(ids), bus, ids)
+
+	events, err := bus.Subscribe(t.Context(), domain.EventFilter{})
+	require.NoError(t, err)
+
+	err = transactor.WithinTransaction(t.Context(), func(ctx context.Context) error {
+		require.NoError(t, repo.Create(ctx, newUser(t, "1", "ada@example.com")))
+		assertNoEvent(t, events)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", receive(t, events).UserID)
+
+	err = transactor.WithinTransaction(t.Context(), func(ctx context.Context) error {
+		require.NoError(t, repo.Create(ctx, newUser(t, "2", "bob@example.com")))
+		return errors.New("rolled back")
+	})
+	require.Error(t, err)
+	assertNoEvent(t, events)
+}
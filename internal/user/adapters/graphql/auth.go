@@ -0,0 +1,56 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// subjectKey is the context key of the subject of the token a WebSocket
+// connection was opened with
+type subjectKey struct{}
+
+// errUnauthenticated is returned for subscriptions without valid
+// credentials; it does not say which check failed
+var errUnauthenticated = errors.New("missing or invalid credentials")
+
+// authenticate returns the init function of WebSocket connections. It
+// accepts connections whose connection_init payload carries an
+// authorization bearer token signed with secret, and rejects the others
+// before any subscription starts.
+func authenticate(secret string) transport.WebsocketInitFunc {
+	return func(ctx context.Context, payload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		subject, err := verifyToken(secret, payload.Authorization())
+		if err != nil {
+			return ctx, nil, err
+		}
+		return context.WithValue(ctx, subjectKey{}, subject), nil, nil
+	}
+}
+
+// authenticated reports whether ctx belongs to a connection opened with a
+// valid token
+func authenticated(ctx context.Context) bool {
+	subject, _ := ctx.Value(subjectKey{}).(string)
+	return subject != ""
+}
+
+// verifyToken returns the subject of a bearer token signed with secret
+func verifyToken(secret, authorization string) (string, error) {
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || token == "" || secret == "" {
+		return "", errUnauthenticated
+	}
+
+	claims := jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (any, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	if err != nil || claims.Subject == "" {
+		return "", errUnauthenticated
+	}
+	return claims.Subject, nil
+}
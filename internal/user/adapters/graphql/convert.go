@@ -0,0 +1,150 @@
+package graphql
+
+import (
+	"encoding/base64"
+	"errors"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// cursorPrefix marks the offset encoded in a users cursor
+const cursorPrefix = "offset:"
+
+// toUser converts a domain user to its GraphQL type
+func toUser(user *domain.User) *User {
+	result := &User{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Status:    toUserStatus(user.Status),
+		CreatedAt: user.CreatedAt.UTC(),
+		UpdatedAt: user.UpdatedAt.UTC(),
+	}
+
+	if user.Username != "" {
+		result.Username = &user.Username
+	}
+
+	if user.AvatarKey != "" {
+		avatarURL := "/users/" + user.ID + "/avatar"
+		result.AvatarURL = &avatarURL
+	}
+
+	if user.PendingEmailChange != nil {
+		result.PendingEmail = &user.PendingEmailChange.Email
+	}
+
+	return result
+}
+
+// lookupUser returns the user found by a lookup, or nil without an error
+// when there is none, so a missing user resolves to null
+func lookupUser(user *domain.User, err error) (*User, error) {
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// toUserEvent converts a domain event to its GraphQL type, with its data
+// sorted by field name
+func toUserEvent(event domain.Event) *UserEvent {
+	result := &UserEvent{
+		ID:         event.ID,
+		UserID:     event.UserID,
+		Kind:       toChangeKind(event.Type),
+		Type:       string(event.Type),
+		Data:       make([]*EventField, 0, len(event.Data)),
+		OccurredAt: event.OccurredAt.UTC(),
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(event.Data)) {
+		result.Data = append(result.Data, &EventField{Name: name, Value: event.Data[name]})
+	}
+
+	return result
+}
+
+// toChangeKind returns the kind of change an event type is
+func toChangeKind(eventType domain.EventType) UserChangeKind {
+	switch eventType {
+	case domain.EventUserCreated:
+		return UserChangeKindCreated
+	case domain.EventUserDeleted:
+		return UserChangeKindDeleted
+	default:
+		return UserChangeKindUpdated
+	}
+}
+
+// toUserStatus converts a domain status to the GraphQL enum
+func toUserStatus(status domain.Status) UserStatus {
+	return UserStatus(strings.ToUpper(string(status)))
+}
+
+// toDomainStatus converts a GraphQL status enum to a domain status
+func toDomainStatus(status UserStatus) (domain.Status, error) {
+	return domain.ParseStatus(strings.ToLower(string(status)))
+}
+
+// toUserFilter converts a GraphQL filter to a domain user filter
+func toUserFilter(filter *UserFilter) (domain.UserFilter, error) {
+	if filter == nil {
+		return domain.UserFilter{}, nil
+	}
+
+	result := domain.UserFilter{
+		CreatedAfter:  filter.CreatedAfter,
+		CreatedBefore: filter.CreatedBefore,
+	}
+
+	if filter.Email != nil {
+		result.EmailContains = *filter.Email
+	}
+
+	if filter.Name != nil {
+		result.NameContains = *filter.Name
+	}
+
+	if filter.Status != nil {
+		status, err := toDomainStatus(*filter.Status)
+		if err != nil {
+			return domain.UserFilter{}, err
+		}
+		result.Status = status
+	}
+
+	return result, nil
+}
+
+// encodeCursor returns the opaque cursor of the user at offset
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset of the user after cursor
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, domain.ErrInvalidCursor
+	}
+
+	value, ok := strings.CutPrefix(string(raw), cursorPrefix)
+	if !ok {
+		return 0, domain.ErrInvalidCursor
+	}
+
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, domain.ErrInvalidCursor
+	}
+
+	return offset + 1, nil
+}
@@ -0,0 +1,91 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// Error codes set in the code extension of errors
+const (
+	CodeBadUserInput       = "BAD_USER_INPUT"
+	CodeNotFound           = "NOT_FOUND"
+	CodeConflict           = "CONFLICT"
+	CodeFailedPrecondition = "FAILED_PRECONDITION"
+	CodeForbidden          = "FORBIDDEN"
+	CodeUnauthenticated    = "UNAUTHENTICATED"
+	CodeInternal           = "INTERNAL_SERVER_ERROR"
+)
+
+// errInvalidFirst is returned when a page size is out of range
+var errInvalidFirst = errors.New("first must be between 1 and 100")
+
+// mapResolverErrors is a field middleware that maps the errors returned
+// by resolvers to GraphQL errors with a code extension, a reason extension
+// holding the code of domain errors, and a fields extension listing the
+// invalid fields of validation errors. Errors raised by gqlgen
+// itself, such as invalid arguments, never reach it and are returned as
+// they are.
+func mapResolverErrors(ctx context.Context, next graphql.Resolver) (any, error) {
+	res, err := next(ctx)
+	if err == nil {
+		return res, nil
+	}
+
+	code, message := mapDomainError(err)
+	extensions := map[string]any{"code": code}
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) && code != CodeInternal {
+		extensions["reason"] = string(domainErr.Code)
+	}
+	if violations := validation.Violations(err); len(violations) > 0 && code == CodeBadUserInput {
+		fields := make([]map[string]string, len(violations))
+		for i, v := range violations {
+			fields[i] = map[string]string{"field": v.Field, "message": v.Err.Error()}
+		}
+		extensions["fields"] = fields
+	}
+
+	return res, &gqlerror.Error{
+		Err:        err,
+		Message:    message,
+		Path:       graphql.GetPath(ctx),
+		Extensions: extensions,
+	}
+}
+
+// mapDomainError maps domain errors to an error code and message
+func mapDomainError(err error) (string, string) {
+	switch {
+	case errors.Is(err, errUnauthenticated),
+		errors.Is(err, domain.ErrInvalidCredentials):
+		return CodeUnauthenticated, err.Error()
+	case errors.Is(err, domain.ErrUserNotFound):
+		return CodeNotFound, err.Error()
+	case errors.Is(err, domain.ErrDuplicateEmail),
+		errors.Is(err, domain.ErrDuplicateUsername):
+		return CodeConflict, err.Error()
+	case errors.Is(err, domain.ErrUserNotDeleted),
+		errors.Is(err, domain.ErrInvalidStatusTransition):
+		return CodeFailedPrecondition, err.Error()
+	case errors.Is(err, domain.ErrUserSuspended),
+		errors.Is(err, domain.ErrUserDeactivated):
+		return CodeForbidden, err.Error()
+	case errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrUndeliverableEmail),
+		errors.Is(err, domain.ErrInvalidUsername),
+		errors.Is(err, domain.ErrReservedUsername),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, errInvalidFirst):
+		return CodeBadUserInput, err.Error()
+	default:
+		return CodeInternal, "internal server error"
+	}
+}
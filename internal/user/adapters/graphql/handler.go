@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gorilla/websocket"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const (
+	// Path is where the GraphQL endpoint is served
+	Path = "/graphql"
+
+	// PlaygroundPath is where the GraphQL playground is served
+	PlaygroundPath = "/graphql/playground"
+
+	// queryCacheSize is how many parsed queries are kept
+	queryCacheSize = 1000
+
+	// DefaultKeepAlive is how often idle WebSocket connections are pinged
+	// when no interval is configured
+	DefaultKeepAlive = 25 * time.Second
+)
+
+// Options configures the GraphQL handler
+type Options struct {
+	// Introspection lets clients query the schema, as the playground and
+	// code generators do
+	Introspection bool
+
+	// Subscriptions serves subscriptions over WebSocket; nil disables them
+	Subscriptions *SubscriptionOptions
+}
+
+// SubscriptionOptions configures subscriptions over WebSocket
+type SubscriptionOptions struct {
+	// Events are the user changes pushed to subscribers
+	Events ports.UserEvents
+
+	// JWTSecret is the HMAC key of the bearer tokens connections are
+	// opened with; empty rejects every connection
+	JWTSecret string
+
+	// MaxConnections bounds the WebSocket connections open at once. Zero
+	// disables the limit.
+	MaxConnections int
+
+	// KeepAlive is how often idle connections are pinged; zero uses
+	// DefaultKeepAlive
+	KeepAlive time.Duration
+}
+
+// NewHandler returns the GraphQL endpoint of the user service. It accepts
+// queries by GET and mutations by POST, and subscriptions over WebSocket
+// when they are enabled.
+func NewHandler(userService ports.UserService, opts Options) http.Handler {
+	resolver := &Resolver{userService: userService}
+	if opts.Subscriptions != nil {
+		resolver.events = opts.Subscriptions.Events
+	}
+
+	srv := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	if opts.Subscriptions != nil {
+		keepAlive := opts.Subscriptions.KeepAlive
+		if keepAlive <= 0 {
+			keepAlive = DefaultKeepAlive
+		}
+
+		srv.AddTransport(transport.Websocket{
+			Upgrader: websocket.Upgrader{
+				// Connections authenticate with a token in their
+				// connection_init payload rather than with cookies, so
+				// pages of other origins gain nothing from a browser's
+				// session
+				CheckOrigin: func(*http.Request) bool { return true },
+			},
+			InitFunc:              authenticate(opts.Subscriptions.JWTSecret),
+			KeepAlivePingInterval: keepAlive,
+		})
+	}
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](queryCacheSize))
+	srv.AroundFields(mapResolverErrors)
+
+	if opts.Introspection {
+		srv.Use(extension.Introspection{})
+	}
+
+	if opts.Subscriptions != nil && opts.Subscriptions.MaxConnections > 0 {
+		return limitConnections(srv, opts.Subscriptions.MaxConnections)
+	}
+	return srv
+}
+
+// limitConnections rejects WebSocket connections beyond max open at once
+// with 503. Other requests are left to the load shedding middleware.
+func limitConnections(next http.Handler, max int) http.Handler {
+	slots := make(chan struct{}, max)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !request.IsWebSocket(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+			next.ServeHTTP(w, r)
+		default:
+			details := problem.New(http.StatusServiceUnavailable, "too many subscription connections, retry later")
+			details.Instance = r.URL.Path
+			_ = problem.Write(w, details)
+		}
+	})
+}
+
+// NewPlaygroundHandler returns the GraphQL playground, an in-browser IDE
+// that sends queries to the endpoint at Path
+func NewPlaygroundHandler() http.Handler {
+	return playground.Handler("Users GraphQL", Path)
+}
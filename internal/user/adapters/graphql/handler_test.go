@@ -0,0 +1,291 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/client"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/eventbus"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// response is the body of a GraphQL response
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// code returns the code extension of the first error
+func (r response) code() string {
+	if len(r.Errors) == 0 {
+		return ""
+	}
+	code, _ := r.Errors[0].Extensions["code"].(string)
+	return code
+}
+
+// execute posts a GraphQL operation to the handler and decodes the response
+func execute(t *testing.T, handler http.Handler, query string, variables map[string]any) response {
+	t.Helper()
+
+	payload, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, Path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	var resp response
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp
+}
+
+func TestResolver_Mutations(t *testing.T) {
+	userService := mocks.NewMockUserService(t)
+	handler := NewHandler(userService, Options{})
+
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: "1", Email: "ada@example.com", Name: "Ada", Status: domain.StatusActive, CreatedAt: created, UpdatedAt: created}
+	suspended := &domain.User{ID: "1", Email: "ada@example.com", Name: "Ada", Status: domain.StatusSuspended, AvatarKey: "avatars/1"}
+	userService.EXPECT().CreateUser(mock.Anything, "ada@example.com", "Ada").Return(user, nil).Once()
+	userService.EXPECT().CreateUser(mock.Anything, "ada@example.com", "Ada").Return(nil, domain.ErrDuplicateEmail)
+	userService.EXPECT().UpdateUser(mock.Anything, "1", "").Return(nil, validation.Field("name", domain.ErrInvalidName))
+	userService.EXPECT().ChangeUserStatus(mock.Anything, "1", domain.StatusSuspended).Return(suspended, nil)
+	userService.EXPECT().ChangeUserStatus(mock.Anything, "1", domain.StatusActive).Return(nil, domain.ErrInvalidStatusTransition)
+	userService.EXPECT().DeleteUser(mock.Anything, "1").Return(errors.New("connection reset"))
+
+	createUser := `mutation($input: CreateUserInput!) { createUser(input: $input) { id email status username createdAt } }`
+	input := map[string]any{"input": map[string]any{"email": "ada@example.com", "name": "Ada"}}
+
+	resp := execute(t, handler, createUser, input)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"id":"1","email":"ada@example.com","status":"ACTIVE","username":null,"createdAt":"2024-03-01T12:00:00Z"}`, string(resp.Data["createUser"]))
+
+	resp = execute(t, handler, createUser, input)
+	assert.Equal(t, CodeConflict, resp.code())
+	assert.Equal(t, "email already exists", resp.Errors[0].Message)
+	assert.Equal(t, "EMAIL_TAKEN", resp.Errors[0].Extensions["reason"])
+
+	resp = execute(t, handler, `mutation { updateUser(id: "1", input: {name: ""}) { id } }`, nil)
+	assert.Equal(t, CodeBadUserInput, resp.code())
+	assert.Equal(t, []any{map[string]any{"field": "name", "message": domain.ErrInvalidName.Error()}}, resp.Errors[0].Extensions["fields"])
+
+	resp = execute(t, handler, `mutation { changeUserStatus(id: "1", status: SUSPENDED) { status avatarUrl } }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"status":"SUSPENDED","avatarUrl":"/users/1/avatar"}`, string(resp.Data["changeUserStatus"]))
+
+	resp = execute(t, handler, `mutation { changeUserStatus(id: "1", status: ACTIVE) { status } }`, nil)
+	assert.Equal(t, CodeFailedPrecondition, resp.code())
+
+	resp = execute(t, handler, `mutation { deleteUser(id: "1") }`, nil)
+	assert.Equal(t, CodeInternal, resp.code())
+	assert.Equal(t, "internal server error", resp.Errors[0].Message, "unexpected errors are not leaked")
+	assert.Equal(t, []any{"deleteUser"}, resp.Errors[0].Path)
+	assert.NotContains(t, resp.Errors[0].Extensions, "reason")
+}
+
+func TestResolver_Lookups(t *testing.T) {
+	userService := mocks.NewMockUserService(t)
+	handler := NewHandler(userService, Options{})
+
+	user := &domain.User{
+		ID:                 "1",
+		Email:              "ada@example.com",
+		Name:               "Ada",
+		Username:           "ada",
+		Status:             domain.StatusActive,
+		PendingEmailChange: &domain.EmailChange{Email: "ada@new.example.com"},
+	}
+	userService.EXPECT().GetUser(mock.Anything, "2").Return(nil, domain.ErrUserNotFound)
+	userService.EXPECT().GetUserByEmail(mock.Anything, "ada@example.com").Return(user, nil)
+	userService.EXPECT().GetUserByUsername(mock.Anything, "bob").Return(nil, errors.New("connection reset"))
+
+	resp := execute(t, handler, `{ user(id: "2") { id } }`, nil)
+	assert.Empty(t, resp.Errors, "a missing user is null, not an error")
+	assert.JSONEq(t, `null`, string(resp.Data["user"]))
+
+	resp = execute(t, handler, `{ userByEmail(email: "ada@example.com") { id username pendingEmail } }`, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"id":"1","username":"ada","pendingEmail":"ada@new.example.com"}`, string(resp.Data["userByEmail"]))
+
+	resp = execute(t, handler, `{ userByUsername(username: "bob") { id } }`, nil)
+	assert.Equal(t, CodeInternal, resp.code())
+}
+
+func TestResolver_Users(t *testing.T) {
+	userService := mocks.NewMockUserService(t)
+	handler := NewHandler(userService, Options{})
+
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := domain.UserFilter{NameContains: "a", Status: domain.StatusActive, CreatedAfter: &after}
+	users := []*domain.User{
+		{ID: "1", Email: "ada@example.com", Name: "Ada", Status: domain.StatusActive},
+		{ID: "2", Email: "grace@example.com", Name: "Grace", Status: domain.StatusActive},
+		{ID: "3", Email: "alan@example.com", Name: "Alan", Status: domain.StatusActive},
+	}
+	userService.EXPECT().ListUsers(mock.Anything, filter, 3, 0).Return(users, nil)
+	userService.EXPECT().ListUsers(mock.Anything, filter, 3, 2).Return(users[2:], nil)
+
+	query := `query($after: String) {
+		users(filter: {name: "a", status: ACTIVE, createdAfter: "2024-01-01T00:00:00Z"}, first: 2, after: $after) {
+			edges { cursor node { id } }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	type page struct {
+		Edges []struct {
+			Cursor string
+			Node   struct{ ID string }
+		}
+		PageInfo struct {
+			HasNextPage bool
+			EndCursor   *string
+		}
+	}
+
+	resp := execute(t, handler, query, nil)
+	require.Empty(t, resp.Errors)
+	var first page
+	require.NoError(t, json.Unmarshal(resp.Data["users"], &first))
+	require.Len(t, first.Edges, 2)
+	assert.Equal(t, "2", first.Edges[1].Node.ID)
+	assert.True(t, first.PageInfo.HasNextPage)
+	require.NotNil(t, first.PageInfo.EndCursor)
+	assert.Equal(t, first.Edges[1].Cursor, *first.PageInfo.EndCursor)
+
+	resp = execute(t, handler, query, map[string]any{"after": *first.PageInfo.EndCursor})
+	require.Empty(t, resp.Errors)
+	var second page
+	require.NoError(t, json.Unmarshal(resp.Data["users"], &second))
+	require.Len(t, second.Edges, 1)
+	assert.Equal(t, "3", second.Edges[0].Node.ID)
+	assert.False(t, second.PageInfo.HasNextPage)
+
+	t.Run("rejects invalid arguments", func(t *testing.T) {
+		resp := execute(t, handler, `{ users(after: "not-a-cursor") { pageInfo { hasNextPage } } }`, nil)
+		assert.Equal(t, CodeBadUserInput, resp.code())
+
+		resp = execute(t, handler, `{ users(first: 101) { pageInfo { hasNextPage } } }`, nil)
+		assert.Equal(t, CodeBadUserInput, resp.code())
+		assert.Equal(t, "first must be between 1 and 100", resp.Errors[0].Message)
+
+		resp = execute(t, handler, `{ users(filter: {createdAfter: "yesterday"}) { pageInfo { hasNextPage } } }`, nil)
+		require.NotEmpty(t, resp.Errors)
+		assert.NotEqual(t, "internal server error", resp.Errors[0].Message, "argument errors are not masked")
+	})
+}
+
+func TestHandler_Introspection(t *testing.T) {
+	query := `{ __schema { queryType { name } } }`
+
+	resp := execute(t, NewHandler(mocks.NewMockUserService(t), Options{}), query, nil)
+	assert.NotEmpty(t, resp.Errors, "introspection is disabled by default")
+
+	resp = execute(t, NewHandler(mocks.NewMockUserService(t), Options{Introspection: true}), query, nil)
+	require.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"queryType":{"name":"Query"}}`, string(resp.Data["__schema"]))
+}
+
+// bearer returns the connection_init payload of a token for subject
+// signed with secret
+func bearer(t *testing.T, secret, subject string) map[string]any {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: subject}).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return map[string]any{"Authorization": "Bearer " + token}
+}
+
+func TestHandler_Subscriptions(t *testing.T) {
+	bus := eventbus.New(eventbus.Options{})
+	c := client.New(NewHandler(mocks.NewMockUserService(t), Options{
+		Subscriptions: &SubscriptionOptions{Events: bus, JWTSecret: "secret"},
+	}))
+
+	query := `subscription { userEvents(userId: "1", kinds: [CREATED, DELETED]) { userId kind type data { name value } } }`
+	type message struct {
+		UserEvents struct {
+			UserID string
+			Kind   string
+			Type   string
+			Data   []struct{ Name, Value string }
+		}
+	}
+
+	t.Run("rejects connections without a valid token", func(t *testing.T) {
+		sub := c.WebsocketWithPayload(query, bearer(t, "other", "ada"))
+		defer sub.Close()
+
+		var msg message
+		assert.Error(t, sub.Next(&msg))
+	})
+
+	t.Run("pushes the matching changes", func(t *testing.T) {
+		sub := c.WebsocketWithPayload(query, bearer(t, "secret", "ada"))
+		defer sub.Close()
+		require.Eventually(t, func() bool { return bus.Subscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+		now := time.Now()
+		bus.Publish(t.Context(), []domain.Event{
+			domain.NewEvent(idgen.SequentialID(1), "2", domain.EventUserCreated, nil, now),
+			domain.NewEvent(idgen.SequentialID(2), "1", domain.EventNameChanged, map[string]string{"name": "Ada"}, now),
+			domain.NewEvent(idgen.SequentialID(3), "1", domain.EventUserCreated, map[string]string{"name": "Ada", "email": "ada@example.com"}, now),
+		})
+
+		var msg message
+		require.NoError(t, sub.Next(&msg))
+		assert.Equal(t, "1", msg.UserEvents.UserID)
+		assert.Equal(t, "CREATED", msg.UserEvents.Kind)
+		assert.Equal(t, "user.created", msg.UserEvents.Type)
+		assert.Equal(t, []struct{ Name, Value string }{{"email", "ada@example.com"}, {"name", "Ada"}}, msg.UserEvents.Data)
+
+		bus.Publish(t.Context(), []domain.Event{domain.NewEvent(idgen.SequentialID(4), "1", domain.EventUserDeleted, nil, now)})
+		require.NoError(t, sub.Next(&msg))
+		assert.Equal(t, "DELETED", msg.UserEvents.Kind)
+	})
+
+	t.Run("requires a WebSocket connection", func(t *testing.T) {
+		handler := NewHandler(mocks.NewMockUserService(t), Options{
+			Subscriptions: &SubscriptionOptions{Events: bus, JWTSecret: "secret"},
+		})
+
+		resp := execute(t, handler, `subscription { userEvents { id } }`, nil)
+		assert.Equal(t, CodeUnauthenticated, resp.code())
+	})
+}
+
+func TestHandler_LimitsSubscriptionConnections(t *testing.T) {
+	bus := eventbus.New(eventbus.Options{})
+	c := client.New(NewHandler(mocks.NewMockUserService(t), Options{
+		Subscriptions: &SubscriptionOptions{Events: bus, JWTSecret: "secret", MaxConnections: 1},
+	}))
+	query := `subscription { userEvents { id } }`
+
+	first := c.WebsocketWithPayload(query, bearer(t, "secret", "ada"))
+	defer first.Close()
+	require.Eventually(t, func() bool { return bus.Subscribers() == 1 }, time.Second, 10*time.Millisecond)
+
+	second := c.WebsocketWithPayload(query, bearer(t, "secret", "bob"))
+	defer second.Close()
+	var msg map[string]any
+	assert.Error(t, second.Next(&msg), "connections beyond the limit are refused")
+}
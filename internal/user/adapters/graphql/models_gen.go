@@ -0,0 +1,208 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graphql
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type CreateUserInput struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// A changed field and its new value
+type EventField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type Mutation struct {
+}
+
+type PageInfo struct {
+	HasNextPage bool `json:"hasNextPage"`
+	// Cursor of the last edge; pass it as after to get the next page
+	EndCursor *string `json:"endCursor,omitempty"`
+}
+
+type Query struct {
+}
+
+type Subscription struct {
+}
+
+type UpdateUserInput struct {
+	Name string `json:"name"`
+}
+
+type User struct {
+	ID    string `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	// Unique handle; null when the user has not chosen one
+	Username *string    `json:"username,omitempty"`
+	Status   UserStatus `json:"status"`
+	// URL of the avatar image; null when the user has none
+	AvatarURL *string `json:"avatarUrl,omitempty"`
+	// Email waiting for confirmation, when a change is pending
+	PendingEmail *string   `json:"pendingEmail,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// A page of users in the Relay connection format
+type UserConnection struct {
+	Edges    []*UserEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type UserEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *User  `json:"node"`
+}
+
+// A change to a user, as stored in the user's activity feed
+type UserEvent struct {
+	ID     string         `json:"id"`
+	UserID string         `json:"userId"`
+	Kind   UserChangeKind `json:"kind"`
+	// The detailed event type, such as user.name_changed
+	Type string `json:"type"`
+	// New values of the changed fields
+	Data       []*EventField `json:"data"`
+	OccurredAt time.Time     `json:"occurredAt"`
+}
+
+// Narrows the users listed; unset fields match any user
+type UserFilter struct {
+	// Email contains the value, ignoring case
+	Email *string `json:"email,omitempty"`
+	// Name contains the value, ignoring case
+	Name   *string     `json:"name,omitempty"`
+	Status *UserStatus `json:"status,omitempty"`
+	// Created at or after the time
+	CreatedAfter *time.Time `json:"createdAfter,omitempty"`
+	// Created before the time
+	CreatedBefore *time.Time `json:"createdBefore,omitempty"`
+}
+
+// What kind of change a user event is
+type UserChangeKind string
+
+const (
+	UserChangeKindCreated UserChangeKind = "CREATED"
+	UserChangeKindUpdated UserChangeKind = "UPDATED"
+	UserChangeKindDeleted UserChangeKind = "DELETED"
+)
+
+var AllUserChangeKind = []UserChangeKind{
+	UserChangeKindCreated,
+	UserChangeKindUpdated,
+	UserChangeKindDeleted,
+}
+
+func (e UserChangeKind) IsValid() bool {
+	switch e {
+	case UserChangeKindCreated, UserChangeKindUpdated, UserChangeKindDeleted:
+		return true
+	}
+	return false
+}
+
+func (e UserChangeKind) String() string {
+	return string(e)
+}
+
+func (e *UserChangeKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserChangeKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserChangeKind", str)
+	}
+	return nil
+}
+
+func (e UserChangeKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *UserChangeKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e UserChangeKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+// Lifecycle state of a user
+type UserStatus string
+
+const (
+	UserStatusActive      UserStatus = "ACTIVE"
+	UserStatusSuspended   UserStatus = "SUSPENDED"
+	UserStatusDeactivated UserStatus = "DEACTIVATED"
+)
+
+var AllUserStatus = []UserStatus{
+	UserStatusActive,
+	UserStatusSuspended,
+	UserStatusDeactivated,
+}
+
+func (e UserStatus) IsValid() bool {
+	switch e {
+	case UserStatusActive, UserStatusSuspended, UserStatusDeactivated:
+		return true
+	}
+	return false
+}
+
+func (e UserStatus) String() string {
+	return string(e)
+}
+
+func (e *UserStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserStatus", str)
+	}
+	return nil
+}
+
+func (e UserStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *UserStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e UserStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,26 @@
+//go:generate go run github.com/99designs/gqlgen@v0.17.78 generate --config gqlgen.yml
+
+// Package graphql serves the user service over GraphQL. The schema is in
+// schema.graphqls; generated.go and models_gen.go are generated from it
+// by gqlgen, which also keeps the resolvers in schema.resolvers.go in
+// step with the schema.
+package graphql
+
+import (
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const (
+	// defaultPageSize is the number of users returned when first is not set
+	defaultPageSize = 20
+
+	// maxPageSize is the largest number of users returned in a page
+	maxPageSize = 100
+)
+
+// Resolver resolves the user schema with the user service, and
+// subscriptions with the user events
+type Resolver struct {
+	userService ports.UserService
+	events      ports.UserEvents
+}
@@ -0,0 +1,183 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"slices"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// CreateUser is the resolver for the createUser field.
+func (r *mutationResolver) CreateUser(ctx context.Context, input CreateUserInput) (*User, error) {
+	user, err := r.userService.CreateUser(ctx, input.Email, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// UpdateUser is the resolver for the updateUser field.
+func (r *mutationResolver) UpdateUser(ctx context.Context, id string, input UpdateUserInput) (*User, error) {
+	user, err := r.userService.UpdateUser(ctx, id, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// ChangeEmail is the resolver for the changeEmail field.
+func (r *mutationResolver) ChangeEmail(ctx context.Context, id string, email string) (*User, error) {
+	user, err := r.userService.ChangeEmail(ctx, id, email)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// ChangeUsername is the resolver for the changeUsername field.
+func (r *mutationResolver) ChangeUsername(ctx context.Context, id string, username string) (*User, error) {
+	user, err := r.userService.ChangeUsername(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// ChangeUserStatus is the resolver for the changeUserStatus field.
+func (r *mutationResolver) ChangeUserStatus(ctx context.Context, id string, status UserStatus) (*User, error) {
+	next, err := toDomainStatus(status)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := r.userService.ChangeUserStatus(ctx, id, next)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+// DeleteUser is the resolver for the deleteUser field.
+func (r *mutationResolver) DeleteUser(ctx context.Context, id string) (bool, error) {
+	if err := r.userService.DeleteUser(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id string) (*User, error) {
+	return lookupUser(r.userService.GetUser(ctx, id))
+}
+
+// UserByEmail is the resolver for the userByEmail field.
+func (r *queryResolver) UserByEmail(ctx context.Context, email string) (*User, error) {
+	return lookupUser(r.userService.GetUserByEmail(ctx, email))
+}
+
+// UserByUsername is the resolver for the userByUsername field.
+func (r *queryResolver) UserByUsername(ctx context.Context, username string) (*User, error) {
+	return lookupUser(r.userService.GetUserByUsername(ctx, username))
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, filter *UserFilter, first *int, after *string) (*UserConnection, error) {
+	limit := defaultPageSize
+	if first != nil {
+		limit = *first
+	}
+	if limit < 1 || limit > maxPageSize {
+		return nil, errInvalidFirst
+	}
+
+	offset := 0
+	if after != nil {
+		var err error
+		if offset, err = decodeCursor(*after); err != nil {
+			return nil, err
+		}
+	}
+
+	userFilter, err := toUserFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ask for one more user than the page holds to learn whether another
+	// page follows
+	users, err := r.userService.ListUsers(ctx, userFilter, limit+1, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	connection := &UserConnection{Edges: []*UserEdge{}, PageInfo: &PageInfo{}}
+	if len(users) > limit {
+		users = users[:limit]
+		connection.PageInfo.HasNextPage = true
+	}
+
+	for i, user := range users {
+		connection.Edges = append(connection.Edges, &UserEdge{
+			Cursor: encodeCursor(offset + i),
+			Node:   toUser(user),
+		})
+	}
+
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+
+	return connection, nil
+}
+
+// UserEvents is the resolver for the userEvents field.
+func (r *subscriptionResolver) UserEvents(ctx context.Context, userID *string, kinds []UserChangeKind) (<-chan *UserEvent, error) {
+	if r.events == nil || !authenticated(ctx) {
+		return nil, errUnauthenticated
+	}
+
+	filter := domain.EventFilter{}
+	if userID != nil {
+		filter.UserID = *userID
+	}
+
+	source, err := r.events.Subscribe(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Closing the channel completes the subscription, as when the client
+	// fell behind and the source was closed
+	events := make(chan *UserEvent)
+	go func() {
+		defer close(events)
+		for event := range source {
+			if len(kinds) > 0 && !slices.Contains(kinds, toChangeKind(event.Type)) {
+				continue
+			}
+			select {
+			case events <- toUserEvent(event):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, nil
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// errorDomain is the domain of the ErrorInfo details of the service's errors
+const errorDomain = "user.v1"
+
+// mapDomainErrorToGRPC maps domain errors to gRPC status errors whose
+// ErrorInfo detail carries the domain error code as its reason, and whose
+// BadRequest detail lists the invalid fields of validation errors. Errors that
+// already carry a status, such as a stalled client, are returned as they
+// are.
+func mapDomainErrorToGRPC(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrNoAvatar),
+		errors.Is(err, domain.ErrJobNotFound):
+		return statusWithReason(codes.NotFound, err.Error(), domain.CodeOf(err))
+	case errors.Is(err, domain.ErrDuplicateEmail),
+		errors.Is(err, domain.ErrDuplicateUsername):
+		return statusWithReason(codes.AlreadyExists, err.Error(), domain.CodeOf(err))
+	case errors.Is(err, domain.ErrUserNotDeleted),
+		errors.Is(err, domain.ErrInvalidStatusTransition),
+		errors.Is(err, domain.ErrBulkAborted):
+		return statusWithReason(codes.FailedPrecondition, err.Error(), domain.CodeOf(err))
+	case errors.Is(err, domain.ErrUserSuspended),
+		errors.Is(err, domain.ErrUserDeactivated):
+		return statusWithReason(codes.PermissionDenied, err.Error(), domain.CodeOf(err))
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return statusWithReason(codes.Unauthenticated, err.Error(), domain.CodeOf(err))
+	case errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidName),
+		errors.Is(err, domain.ErrUndeliverableEmail),
+		errors.Is(err, domain.ErrInvalidUsername),
+		errors.Is(err, domain.ErrReservedUsername),
+		errors.Is(err, domain.ErrInvalidImage),
+		errors.Is(err, domain.ErrInvalidLocale),
+		errors.Is(err, domain.ErrInvalidTimezone),
+		errors.Is(err, domain.ErrInvalidDigestFrequency),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, domain.ErrNoPendingEmailChange),
+		errors.Is(err, domain.ErrInvalidEmailChangeToken),
+		errors.Is(err, domain.ErrInvalidImportFile),
+		errors.Is(err, domain.ErrInvalidExportFormat):
+		return statusWithReason(codes.InvalidArgument, err.Error(), domain.CodeOf(err), badRequest(err)...)
+	default:
+		return statusWithReason(codes.Internal, "internal server error", domain.CodeInternal)
+	}
+}
+
+// statusWithReason returns a status error with an ErrorInfo detail, so
+// clients branch on the reason rather than on the message, followed by any
+// other details
+func statusWithReason(code codes.Code, message string, reason domain.Code, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+	info := &errdetails.ErrorInfo{
+		Reason: string(reason),
+		Domain: errorDomain,
+	}
+	detailed, err := st.WithDetails(append([]protoadapt.MessageV1{info}, details...)...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// badRequest returns a BadRequest detail listing the violations of the
+// validation error in err's tree, or nothing when it holds none
+func badRequest(err error) []protoadapt.MessageV1 {
+	violations := validation.Violations(err)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	detail := &errdetails.BadRequest{}
+	for _, v := range violations {
+		detail.FieldViolations = append(detail.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Err.Error(),
+			Reason:      string(domain.CodeOf(v.Err)),
+		})
+	}
+	return []protoadapt.MessageV1{detail}
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	userv1 "github.com/yourusername/go-scaffolding/api/proto/user/v1"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// OpenAPIPath is where the gateway serves the OpenAPI spec of its routes
+const OpenAPIPath = "/v1/openapi.json"
+
+// NewGateway returns the REST API generated from the google.api.http
+// options of user.proto. Requests are served in process by the user gRPC
+// service, without a network hop or the gRPC interceptors, so the gateway
+// works whether or not the gRPC server is enabled; mount it on the Gin
+// engine to get the HTTP middleware instead. Fields keep their proto
+// names, such as created_at, like the handwritten routes. Streaming RPCs
+// are not served.
+func NewGateway(ctx context.Context, userService ports.UserService, opts Options) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions:   protojson.MarshalOptions{UseProtoNames: true},
+			UnmarshalOptions: protojson.UnmarshalOptions{},
+		}),
+	)
+
+	if err := userv1.RegisterUserServiceHandlerServer(ctx, mux, NewUserServer(userService, opts)); err != nil {
+		return nil, err
+	}
+
+	err := mux.HandlePath(http.MethodGet, OpenAPIPath, func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(userv1.OpenAPI)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mux, nil
+}
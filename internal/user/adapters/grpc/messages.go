@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	userv1 "github.com/yourusername/go-scaffolding/api/proto/user/v1"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// toUserFilter converts a ListUsers request to a domain user filter
+func toUserFilter(req *userv1.ListUsersRequest) (domain.UserFilter, error) {
+	filter := domain.UserFilter{
+		EmailContains:  req.GetEmailContains(),
+		NameContains:   req.GetNameContains(),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	}
+
+	if req.GetStatus() != "" {
+		status, err := domain.ParseStatus(req.GetStatus())
+		if err != nil {
+			return domain.UserFilter{}, err
+		}
+		filter.Status = status
+	}
+
+	if req.CreatedAfter != nil {
+		if err := req.CreatedAfter.CheckValid(); err != nil {
+			return domain.UserFilter{}, errors.New("created_after is not a valid timestamp")
+		}
+		after := req.CreatedAfter.AsTime()
+		filter.CreatedAfter = &after
+	}
+
+	if req.CreatedBefore != nil {
+		if err := req.CreatedBefore.CheckValid(); err != nil {
+			return domain.UserFilter{}, errors.New("created_before is not a valid timestamp")
+		}
+		before := req.CreatedBefore.AsTime()
+		filter.CreatedBefore = &before
+	}
+
+	return filter, nil
+}
+
+// toUserMessage converts a domain user to a user message
+func toUserMessage(user *domain.User) *userv1.User {
+	msg := &userv1.User{
+		Id:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Username:  user.Username,
+		Status:    string(user.Status),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+
+	if user.DeletedAt != nil {
+		msg.DeletedAt = timestamppb.New(*user.DeletedAt)
+	}
+
+	return msg
+}
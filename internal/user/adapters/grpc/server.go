@@ -0,0 +1,175 @@
+// Package grpc serves the user feature over gRPC with the generated
+// user.v1.UserService from api/proto/user/v1, and over REST through
+// grpc-gateway from the same definition
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	userv1 "github.com/yourusername/go-scaffolding/api/proto/user/v1"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// DefaultSendTimeout is how long a stream waits for the client to accept a
+// message when Options leaves SendTimeout unset
+const DefaultSendTimeout = 30 * time.Second
+
+// errClientStalled ends a stream whose client stopped receiving
+var errClientStalled = status.Error(codes.DeadlineExceeded, "client did not receive the next message in time")
+
+// Options configures the user gRPC service
+type Options struct {
+	// SendTimeout bounds how long a streaming call waits for the client to
+	// accept one message before it gives up on the client
+	SendTimeout time.Duration
+}
+
+// UserServer implements user.v1.UserService
+type UserServer struct {
+	userv1.UnimplementedUserServiceServer
+
+	userService ports.UserService
+	sendTimeout time.Duration
+}
+
+// NewUserServer creates a new user gRPC service
+func NewUserServer(userService ports.UserService, opts Options) *UserServer {
+	sendTimeout := opts.SendTimeout
+	if sendTimeout <= 0 {
+		sendTimeout = DefaultSendTimeout
+	}
+
+	return &UserServer{
+		userService: userService,
+		sendTimeout: sendTimeout,
+	}
+}
+
+// RegisterUserService registers the user gRPC service on server
+func RegisterUserService(server grpc.ServiceRegistrar, userService ports.UserService, opts Options) {
+	userv1.RegisterUserServiceServer(server, NewUserServer(userService, opts))
+}
+
+// CreateUser creates a new user
+func (s *UserServer) CreateUser(ctx context.Context, req *userv1.CreateUserRequest) (*userv1.User, error) {
+	user, err := s.userService.CreateUser(ctx, req.GetEmail(), req.GetName())
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+	return toUserMessage(user), nil
+}
+
+// GetUser retrieves a user by ID
+func (s *UserServer) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.User, error) {
+	user, err := s.userService.GetUser(ctx, req.GetId())
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+	return toUserMessage(user), nil
+}
+
+// UpdateUser updates a user's name
+func (s *UserServer) UpdateUser(ctx context.Context, req *userv1.UpdateUserRequest) (*userv1.User, error) {
+	user, err := s.userService.UpdateUser(ctx, req.GetId(), req.GetName())
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+	return toUserMessage(user), nil
+}
+
+// DeleteUser soft-deletes a user
+func (s *UserServer) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*emptypb.Empty, error) {
+	if err := s.userService.DeleteUser(ctx, req.GetId()); err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListUsers streams every user matching the filter. Users are read from the
+// repository iterator as they are sent: when the client's flow-control
+// window is full, sending blocks and so does the iteration, and a client
+// that accepts no message for SendTimeout gets DeadlineExceeded.
+func (s *UserServer) ListUsers(req *userv1.ListUsersRequest, stream grpc.ServerStreamingServer[userv1.User]) error {
+	filter, err := toUserFilter(req)
+	if err != nil {
+		return statusWithReason(codes.InvalidArgument, err.Error(), domain.CodeValidationFailed)
+	}
+
+	ctx := stream.Context()
+	out := newSender(stream, s.sendTimeout)
+	defer out.close()
+
+	err = s.userService.ExportUsers(ctx, filter, func(user *domain.User) error {
+		return out.send(ctx, toUserMessage(user))
+	})
+	if err != nil {
+		return mapDomainErrorToGRPC(err)
+	}
+	return nil
+}
+
+// sender sends the messages of a stream from its own goroutine. Send only
+// returns once the client has room for the message or the stream ends, so
+// waiting for it elsewhere is what allows giving up on a stalled client:
+// returning from the handler ends the stream and unblocks Send.
+type sender[T any] struct {
+	stream  grpc.ServerStreamingServer[T]
+	timeout time.Duration
+	timer   *time.Timer
+	msgs    chan *T
+	errs    chan error
+}
+
+func newSender[T any](stream grpc.ServerStreamingServer[T], timeout time.Duration) *sender[T] {
+	s := &sender[T]{
+		stream:  stream,
+		timeout: timeout,
+		timer:   time.NewTimer(timeout),
+		msgs:    make(chan *T),
+		// Buffered so a Send that fails after the handler gave up on it
+		// does not block the goroutine forever
+		errs: make(chan error, 1),
+	}
+	s.timer.Stop()
+	go s.run()
+	return s
+}
+
+func (s *sender[T]) run() {
+	for msg := range s.msgs {
+		s.errs <- s.stream.Send(msg)
+	}
+}
+
+// send sends msg, waiting at most the timeout for the client to accept it
+func (s *sender[T]) send(ctx context.Context, msg *T) error {
+	select {
+	case s.msgs <- msg:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	s.timer.Reset(s.timeout)
+	defer s.timer.Stop()
+
+	select {
+	case err := <-s.errs:
+		return err
+	case <-s.timer.C:
+		return errClientStalled
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// close stops the sending goroutine once its last Send returns
+func (s *sender[T]) close() {
+	close(s.msgs)
+}
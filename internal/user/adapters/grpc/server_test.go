@@ -0,0 +1,287 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	userv1 "github.com/yourusername/go-scaffolding/api/proto/user/v1"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// dial serves the user service over an in-memory listener until the test
+// ends and returns a client for it
+func dial(t *testing.T, userService *mocks.MockUserService, opts Options) userv1.UserServiceClient {
+	t.Helper()
+
+	server := grpc.NewServer()
+	RegisterUserService(server, userService, opts)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return userv1.NewUserServiceClient(conn)
+}
+
+// receiveAll reads a stream to its end and returns the users and the error
+// it ended with, nil for a complete stream
+func receiveAll(stream grpc.ServerStreamingClient[userv1.User]) ([]*userv1.User, error) {
+	var users []*userv1.User
+	for {
+		user, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return users, nil
+		}
+		if err != nil {
+			return users, err
+		}
+		users = append(users, user)
+	}
+}
+
+// exportUsers makes ExportUsers pass the users to its callback
+func exportUsers(users ...*domain.User) func(context.Context, domain.UserFilter, func(*domain.User) error) error {
+	return func(_ context.Context, _ domain.UserFilter, fn func(*domain.User) error) error {
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func TestUserServer_CRUD(t *testing.T) {
+	userService := mocks.NewMockUserService(t)
+	client := dial(t, userService, Options{})
+	ctx := context.Background()
+
+	user := &domain.User{ID: "1", Email: "ada@example.com", Name: "Ada", Status: domain.StatusActive}
+	userService.EXPECT().CreateUser(mock.Anything, "ada@example.com", "Ada").Return(user, nil).Once()
+	userService.EXPECT().CreateUser(mock.Anything, "ada@example.com", "Ada").Return(nil, domain.ErrDuplicateEmail)
+	userService.EXPECT().GetUser(mock.Anything, "2").Return(nil, domain.ErrUserNotFound)
+	userService.EXPECT().UpdateUser(mock.Anything, "1", "").Return(nil, validation.Field("name", domain.ErrInvalidName))
+	userService.EXPECT().DeleteUser(mock.Anything, "1").Return(errors.New("connection reset"))
+
+	created, err := client.CreateUser(ctx, &userv1.CreateUserRequest{Email: "ada@example.com", Name: "Ada"})
+	require.NoError(t, err)
+	assert.Equal(t, "1", created.GetId())
+	assert.Equal(t, "active", created.GetStatus())
+
+	_, err = client.CreateUser(ctx, &userv1.CreateUserRequest{Email: "ada@example.com", Name: "Ada"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	assert.Equal(t, "EMAIL_TAKEN", reason(t, err))
+
+	_, err = client.GetUser(ctx, &userv1.GetUserRequest{Id: "2"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "USER_NOT_FOUND", reason(t, err))
+
+	_, err = client.UpdateUser(ctx, &userv1.UpdateUserRequest{Id: "1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, "VALIDATION_FAILED", reason(t, err))
+	violations := fieldViolations(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "name", violations[0].GetField())
+	assert.Equal(t, domain.ErrInvalidName.Error(), violations[0].GetDescription())
+	assert.Equal(t, "VALIDATION_FAILED", violations[0].GetReason())
+
+	_, err = client.DeleteUser(ctx, &userv1.DeleteUserRequest{Id: "1"})
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message(), "unexpected errors are not leaked")
+	assert.Equal(t, "INTERNAL", reason(t, err))
+}
+
+// reason returns the reason of the ErrorInfo detail of a status error
+func reason(t *testing.T, err error) string {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, "user.v1", info.GetDomain())
+			return info.GetReason()
+		}
+	}
+	t.Fatalf("no ErrorInfo in %v", err)
+	return ""
+}
+
+func fieldViolations(t *testing.T, err error) []*errdetails.BadRequest_FieldViolation {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			return badRequest.GetFieldViolations()
+		}
+	}
+	t.Fatalf("no BadRequest in %v", err)
+	return nil
+}
+
+func TestUserServer_ListUsers(t *testing.T) {
+	userService := mocks.NewMockUserService(t)
+	client := dial(t, userService, Options{})
+
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	deleted := created.Add(time.Hour)
+
+	userService.EXPECT().ExportUsers(mock.Anything, domain.UserFilter{
+		EmailContains:  "example.com",
+		Status:         domain.StatusActive,
+		CreatedAfter:   &after,
+		IncludeDeleted: true,
+	}, mock.Anything).RunAndReturn(exportUsers(
+		&domain.User{ID: "1", Email: "ada@example.com", Name: "Ada", Username: "ada", Status: domain.StatusActive, CreatedAt: created, UpdatedAt: created},
+		&domain.User{ID: "2", Email: "bob@example.com", Name: "Bob", Status: domain.StatusActive, CreatedAt: created, UpdatedAt: created, DeletedAt: &deleted},
+	))
+
+	stream, err := client.ListUsers(context.Background(), &userv1.ListUsersRequest{
+		EmailContains:  "example.com",
+		Status:         "active",
+		CreatedAfter:   timestamppb.New(after),
+		IncludeDeleted: true,
+	})
+	require.NoError(t, err)
+
+	users, err := receiveAll(stream)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	assert.Equal(t, "1", users[0].GetId())
+	assert.Equal(t, "ada@example.com", users[0].GetEmail())
+	assert.Equal(t, "ada", users[0].GetUsername())
+	assert.Equal(t, "active", users[0].GetStatus())
+	assert.Equal(t, created, users[0].GetCreatedAt().AsTime())
+	assert.Nil(t, users[0].GetDeletedAt())
+	assert.Equal(t, deleted, users[1].GetDeletedAt().AsTime())
+}
+
+func TestUserServer_ListUsers_InvalidFilter(t *testing.T) {
+	client := dial(t, mocks.NewMockUserService(t), Options{})
+
+	tests := []struct {
+		name string
+		req  *userv1.ListUsersRequest
+	}{
+		{name: "unknown status", req: &userv1.ListUsersRequest{Status: "banned"}},
+		{name: "invalid timestamp", req: &userv1.ListUsersRequest{CreatedBefore: &timestamppb.Timestamp{Nanos: -1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.ListUsers(context.Background(), tt.req)
+			require.NoError(t, err)
+			_, err = receiveAll(stream)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func TestUserServer_ListUsers_FailsMidStream(t *testing.T) {
+	userService := mocks.NewMockUserService(t)
+	client := dial(t, userService, Options{})
+
+	userService.EXPECT().ExportUsers(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+			if err := exportUsers(&domain.User{ID: "1"})(ctx, filter, fn); err != nil {
+				return err
+			}
+			return errors.New("connection reset")
+		})
+
+	stream, err := client.ListUsers(context.Background(), &userv1.ListUsersRequest{})
+	require.NoError(t, err)
+
+	users, err := receiveAll(stream)
+	assert.Len(t, users, 1, "users sent before the failure are received")
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "internal server error", status.Convert(err).Message())
+}
+
+func TestUserServer_ListUsers_FlowControl(t *testing.T) {
+	userService := mocks.NewMockUserService(t)
+	client := dial(t, userService, Options{})
+
+	const total = 20000
+	var produced atomic.Int64
+	userService.EXPECT().ExportUsers(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.UserFilter, fn func(*domain.User) error) error {
+			for range total {
+				produced.Add(1)
+				if err := fn(&domain.User{ID: "1", Email: "ada@example.com", Name: "Ada Lovelace"}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	stream, err := client.ListUsers(context.Background(), &userv1.ListUsersRequest{})
+	require.NoError(t, err)
+
+	// Without a receiving client the iteration stops once the flow-control
+	// windows are full
+	time.Sleep(200 * time.Millisecond)
+	paused := produced.Load()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, paused, produced.Load(), "iteration is paused while the client does not receive")
+	assert.Less(t, paused, int64(total))
+
+	users, err := receiveAll(stream)
+	require.NoError(t, err)
+	assert.Len(t, users, total)
+}
+
+// stalledStream is a stream whose client never accepts a message: Send
+// blocks until the stream ends
+type stalledStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *stalledStream) Context() context.Context { return s.ctx }
+
+func (s *stalledStream) Send(*userv1.User) error {
+	<-s.ctx.Done()
+	return s.ctx.Err()
+}
+
+func TestUserServer_ListUsers_StalledClient(t *testing.T) {
+	userService := mocks.NewMockUserService(t)
+	server := NewUserServer(userService, Options{SendTimeout: 20 * time.Millisecond})
+
+	userService.EXPECT().ExportUsers(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(exportUsers(&domain.User{ID: "1"}, &domain.User{ID: "2"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	err := server.ListUsers(&userv1.ListUsersRequest{}, &stalledStream{ctx: ctx})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), time.Second, "the stream gives up after the send timeout")
+}
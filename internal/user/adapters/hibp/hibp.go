@@ -0,0 +1,130 @@
+// Package hibp implements ports.BreachedPasswords with the Pwned Passwords
+// range API of Have I Been Pwned.
+//
+// The API uses k-anonymity: only the first five hex characters of the
+// password's SHA-1 digest are sent, and the service answers with the
+// suffixes of every breached digest sharing that prefix, so neither the
+// password nor its full digest leaves the process:
+//
+//	GET {base_url}/range/{first 5 characters of the SHA-1 digest}
+//	Add-Padding: true
+//
+//	200 OK
+//	0018A45C4D1DEF81644B54AB7F969B88D65:10
+//	00D4F6E8FA6EECAD2A3AA415EEC418D38EC:0
+//	...
+//
+// The padding header makes every response a similar size, and the padded
+// entries carry a count of zero.
+package hibp
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" //nolint:gosec // SHA-1 is what the range API is keyed on
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// prefixLength is how many hex characters of the digest are sent
+const prefixLength = 5
+
+// maxResponseBytes bounds how much of a response body is read; padded
+// responses hold around a thousand lines
+const maxResponseBytes = 1 << 20
+
+// ErrUnavailable indicates the breach service could not give an answer
+var ErrUnavailable = errors.New("breached password service unavailable")
+
+// Options configures the checker
+type Options struct {
+	// BaseURL is the root of the range API, such as
+	// https://api.pwnedpasswords.com
+	BaseURL string
+
+	// FailOpen treats passwords as not breached when the service cannot
+	// give an answer, so an outage does not stop sign-ups
+	FailOpen bool
+}
+
+// checker implements ports.BreachedPasswords against the range API
+type checker struct {
+	endpoint string
+	failOpen bool
+	client   *http.Client
+	logger   *logger.Logger
+}
+
+// New creates a checker that sends its requests with client
+func New(opts Options, client *http.Client, log *logger.Logger) (ports.BreachedPasswords, error) {
+	base, err := url.Parse(opts.BaseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid breached password base URL: %q", opts.BaseURL)
+	}
+
+	return &checker{
+		endpoint: strings.TrimSuffix(base.String(), "/") + "/range/",
+		failOpen: opts.FailOpen,
+		client:   client,
+		logger:   log,
+	}, nil
+}
+
+// IsBreached reports whether password appears in a known data breach
+func (c *checker) IsBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // see import
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	breached, err := c.check(ctx, digest[:prefixLength], digest[prefixLength:])
+	if err != nil {
+		if c.failOpen && ctx.Err() == nil {
+			c.logger.Warn().Err(err).Msg("Breached password check unavailable, accepting password")
+			return false, nil
+		}
+		return false, err
+	}
+	return breached, nil
+}
+
+// check looks for suffix among the breached digests starting with prefix
+func (c *checker) check(ctx context.Context, prefix, suffix string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.endpoint+prefix, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Add-Padding", "true")
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("%w: unexpected status %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	scanner := bufio.NewScanner(io.LimitReader(resp.Body, maxResponseBytes))
+	for scanner.Scan() {
+		candidate, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(candidate, suffix) {
+			return count != "0", nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("%w: read response: %w", ErrUnavailable, err)
+	}
+	return false, nil
+}
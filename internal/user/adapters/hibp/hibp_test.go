@@ -0,0 +1,120 @@
+package hibp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+)
+
+// The SHA-1 digest of "password" is
+// 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+const (
+	passwordPrefix = "5BAA6"
+	passwordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+)
+
+// rangeServer answers range requests for passwordPrefix with body and
+// records the prefixes it was asked about
+func rangeServer(t *testing.T, status int, body string) (*httptest.Server, *[]string) {
+	t.Helper()
+
+	var prefixes []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		prefixes = append(prefixes, r.URL.Path)
+		w.WriteHeader(status)
+		_, _ = fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server, &prefixes
+}
+
+func newChecker(t *testing.T, baseURL string, failOpen bool) *checker {
+	t.Helper()
+
+	c, err := New(Options{BaseURL: baseURL, FailOpen: failOpen}, http.DefaultClient, logger.New("info", io.Discard))
+	require.NoError(t, err)
+	return c.(*checker)
+}
+
+func TestChecker_SendsOnlyThePrefix(t *testing.T) {
+	server, prefixes := rangeServer(t, http.StatusOK, "")
+	c := newChecker(t, server.URL, false)
+
+	_, err := c.IsBreached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/range/" + passwordPrefix}, *prefixes)
+}
+
+func TestChecker_IsBreached(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{
+			name: "listed suffix",
+			body: "0018A45C4D1DEF81644B54AB7F969B88D65:10\r\n" + passwordSuffix + ":9545824\r\n",
+			want: true,
+		},
+		{
+			name: "lowercase suffix",
+			body: "1e4c9b93f3f0682250b6cf8331b7ee68fd8:3\n",
+			want: true,
+		},
+		{
+			name: "padding entry",
+			body: passwordSuffix + ":0\r\n",
+			want: false,
+		},
+		{
+			name: "not listed",
+			body: "0018A45C4D1DEF81644B54AB7F969B88D65:10\r\n",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := rangeServer(t, http.StatusOK, tt.body)
+			c := newChecker(t, server.URL, false)
+
+			breached, err := c.IsBreached(context.Background(), "password")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, breached)
+		})
+	}
+}
+
+func TestChecker_Unavailable(t *testing.T) {
+	server, _ := rangeServer(t, http.StatusServiceUnavailable, "")
+
+	_, err := newChecker(t, server.URL, false).IsBreached(context.Background(), "password")
+	assert.ErrorIs(t, err, ErrUnavailable)
+
+	breached, err := newChecker(t, server.URL, true).IsBreached(context.Background(), "password")
+	require.NoError(t, err)
+	assert.False(t, breached)
+}
+
+func TestChecker_FailOpenRespectsCancellation(t *testing.T) {
+	server, _ := rangeServer(t, http.StatusOK, "")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := newChecker(t, server.URL, true).IsBreached(ctx, "password")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNew_RejectsInvalidBaseURL(t *testing.T) {
+	_, err := New(Options{BaseURL: "api.pwnedpasswords.com"}, http.DefaultClient, logger.New("info", io.Discard))
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// AdminHandler handles the operational user endpoints under /admin. It
+// performs no authorization of its own: the admin route group does.
+type AdminHandler struct {
+	userService ports.UserService
+	avatars     ports.UserAvatars
+	activity    ports.UserActivity
+}
+
+// NewAdminHandler creates a new AdminHandler
+func NewAdminHandler(userService ports.UserService, avatars ports.UserAvatars, activity ports.UserActivity) *AdminHandler {
+	return &AdminHandler{
+		userService: userService,
+		avatars:     avatars,
+		activity:    activity,
+	}
+}
+
+// RestoreUser handles POST /admin/users/{id}/restore
+func (h *AdminHandler) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	id, err := request.PathID(r)
+	if err != nil {
+		statusCode, response := bindErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	user, err := h.userService.RestoreUser(r.Context(), id)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ToAdminUserResponse(user))
+}
+
+// EraseUser handles DELETE /admin/users/{id}, permanently deleting the user
+func (h *AdminHandler) EraseUser(w http.ResponseWriter, r *http.Request) {
+	id, err := request.PathID(r)
+	if err != nil {
+		statusCode, response := bindErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	// Stored files are outside the database transaction, so remove them first
+	if err := h.avatars.DeleteAvatar(r.Context(), id); err != nil {
+		statusCode, response := domainErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	record, err := h.userService.EraseUser(r.Context(), id)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ToErasureResponse(record))
+}
+
+// ListLogins handles GET /admin/users/{id}/logins
+func (h *AdminHandler) ListLogins(w http.ResponseWriter, r *http.Request) {
+	id, err := request.PathID(r)
+	if err != nil {
+		statusCode, response := bindErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	query := LoginsQuery{Limit: DefaultLoginsLimit}
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	page, err := h.activity.ListLogins(r.Context(), id, query.Cursor, query.Limit)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ToLoginsResponse(page))
+}
+
+// ListAuditLog handles GET /admin/audit
+func (h *AdminHandler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := AuditLogQuery{Limit: DefaultActivityLimit}
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	page, err := h.activity.ListAuditLog(r.Context(), query.ToEventFilter(), query.Cursor, query.Limit)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ToAuditLogResponse(page))
+}
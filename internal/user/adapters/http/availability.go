@@ -0,0 +1,91 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/ratelimit"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// AvailabilityOptions configures GET /users/availability
+type AvailabilityOptions struct {
+	// Users checks the identifiers
+	Users ports.UserService
+
+	// Limiter limits the checks of each client IP; nil disables the limit
+	Limiter *ratelimit.Limiter
+
+	// MaxAge is how long clients may cache answers; zero disables caching
+	MaxAge time.Duration
+}
+
+// AvailabilityHandler tells signup forms whether an email address or a
+// username is free, as users type them. Malformed and reserved identifiers
+// are answered with 200 and an invalid status rather than an error, so a
+// form can show the reason next to the field. Checks are rate limited per
+// client IP, which also slows down the enumeration of accounts.
+type AvailabilityHandler struct {
+	users   ports.UserService
+	limiter *ratelimit.Limiter
+	maxAge  time.Duration
+}
+
+// NewAvailabilityHandler creates a new AvailabilityHandler
+func NewAvailabilityHandler(opts AvailabilityOptions) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		users:   opts.Users,
+		limiter: opts.Limiter,
+		maxAge:  opts.MaxAge,
+	}
+}
+
+// CheckAvailability handles GET /users/availability
+func (h *AvailabilityHandler) CheckAvailability(w http.ResponseWriter, r *http.Request) {
+	if h.limiter != nil {
+		if ok, retryAfter := h.limiter.Allow(clientip.FromRequest(r).String()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			writeJSON(w, http.StatusTooManyRequests, ErrorResponse{
+				Error: "too many availability checks, retry later",
+				Code:  problem.CodeRateLimited,
+			})
+			return
+		}
+	}
+
+	var query AvailabilityQuery
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+	if query.Email == "" && query.Username == "" {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: "email or username is required",
+			Code:  problem.CodeFor(http.StatusBadRequest),
+		})
+		return
+	}
+
+	result, err := h.users.CheckAvailability(r.Context(), domain.AvailabilityCheck{
+		Email:    query.Email,
+		Username: query.Username,
+	})
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	if h.maxAge > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(h.maxAge.Seconds())))
+	}
+	writeJSON(w, http.StatusOK, ToAvailabilityResponse(result))
+}
@@ -1,8 +1,15 @@
 package http
 
 import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
 )
 
@@ -10,52 +17,764 @@ import (
 type CreateUserRequest struct {
 	Email string `json:"email" binding:"required,email"`
 	Name  string `json:"name" binding:"required"`
+
+	// Password is optional; when given it must follow the password policy
+	Password string `json:"password"`
+}
+
+// BulkCreateUsersRequest represents the request to create many users at once.
+// Items are validated individually so each one gets its own result.
+type BulkCreateUsersRequest struct {
+	Users []BulkUserItem `json:"users" binding:"required,min=1"`
+	Mode  string         `json:"mode" binding:"omitempty,oneof=atomic best_effort"`
+}
+
+// BulkUserItem represents one user of a bulk creation request
+type BulkUserItem struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
 }
 
 // UpdateUserRequest represents the request to update a user
-// Note: Email updates are not allowed to maintain data integrity and prevent
-// conflicts with existing email constraints in the database
+// Note: Email changes go through ChangeEmailRequest so uniqueness checks and
+// verification are applied consistently
 type UpdateUserRequest struct {
 	Name string `json:"name" binding:"required"`
 }
 
+// ChangeEmailRequest represents the request to change a user's email
+type ChangeEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ChangeUsernameRequest represents the request to set a user's username
+type ChangeUsernameRequest struct {
+	Username string `json:"username" binding:"required,username"`
+}
+
+// ChangePasswordRequest represents the request to change a user's password.
+// CurrentPassword may be empty for users who have none yet. Password is
+// checked against the password policy before the current password is.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password" binding:"required,password_policy"`
+}
+
+// LoginRequest represents the request to log in with an email and password
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// ConfirmEmailChangeRequest represents the request to confirm a pending email change
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UpdatePreferencesRequest represents the request to replace a user's
+// preferences. Omitted fields are reset to their defaults.
+type UpdatePreferencesRequest struct {
+	Locale        *string                               `json:"locale"`
+	Timezone      *string                               `json:"timezone"`
+	Notifications *UpdateNotificationPreferencesRequest `json:"notifications"`
+}
+
+// UpdateNotificationPreferencesRequest represents the notification settings of a preferences update
+type UpdateNotificationPreferencesRequest struct {
+	Email  *bool   `json:"email"`
+	Digest *string `json:"digest"`
+}
+
 // UserResponse represents the user response
 type UserResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	XMLName      xml.Name  `json:"-" xml:"user"`
+	ID           string    `json:"id" xml:"id"`
+	Email        string    `json:"email,omitempty" xml:"email,omitempty"`
+	Name         string    `json:"name,omitempty" xml:"name,omitempty"`
+	Username     string    `json:"username,omitempty" xml:"username,omitempty"`
+	AvatarURL    string    `json:"avatar_url,omitempty" xml:"avatar_url,omitempty"`
+	Status       string    `json:"status,omitempty" xml:"status,omitempty"`
+	PendingEmail string    `json:"pending_email,omitempty" xml:"pending_email,omitempty"`
+	CreatedAt    *utc.Time `json:"created_at,omitempty" xml:"created_at,omitempty"`
+	UpdatedAt    *utc.Time `json:"updated_at,omitempty" xml:"updated_at,omitempty"`
 }
 
 // ListUsersResponse represents the response for listing users
 type ListUsersResponse struct {
-	Users  []UserResponse `json:"users"`
-	Limit  int            `json:"limit"`
-	Offset int            `json:"offset"`
+	XMLName xml.Name       `json:"-" xml:"users"`
+	Users   []UserResponse `json:"users" xml:"user"`
+	Limit   int            `json:"limit" xml:"limit"`
+	Offset  int            `json:"offset" xml:"offset"`
+}
+
+// AdminUserResponse represents a user including its soft-delete state
+type AdminUserResponse struct {
+	UserResponse
+	LastLoginAt *utc.Time `json:"last_login_at" xml:"last_login_at,omitempty"`
+	DeletedAt   *utc.Time `json:"deleted_at" xml:"deleted_at,omitempty"`
+}
+
+// AdminListUsersResponse represents the response for listing users including deleted ones
+type AdminListUsersResponse struct {
+	XMLName xml.Name            `json:"-" xml:"users"`
+	Users   []AdminUserResponse `json:"users" xml:"user"`
+	Limit   int                 `json:"limit" xml:"limit"`
+	Offset  int                 `json:"offset" xml:"offset"`
+}
+
+// ErasureResponse represents the compliance record of an erased user
+type ErasureResponse struct {
+	ID       string   `json:"id"`
+	UserID   string   `json:"user_id"`
+	ErasedAt utc.Time `json:"erased_at"`
+}
+
+// BulkCreateItemResponse represents the outcome of one bulk creation item
+type BulkCreateItemResponse struct {
+	Index  int           `json:"index" xml:"index"`
+	Status string        `json:"status" xml:"status"`
+	User   *UserResponse `json:"user,omitempty" xml:"user,omitempty"`
+	Error  string        `json:"error,omitempty" xml:"error,omitempty"`
+	Code   string        `json:"code,omitempty" xml:"code,omitempty"`
+}
+
+// BulkCreateUsersResponse represents the response for a bulk creation
+type BulkCreateUsersResponse struct {
+	XMLName xml.Name                 `json:"-" xml:"bulk_create"`
+	Created int                      `json:"created" xml:"created"`
+	Failed  int                      `json:"failed" xml:"failed"`
+	Results []BulkCreateItemResponse `json:"results" xml:"results>result"`
+}
+
+// ImportLineResponse represents a skipped or failed line of an import
+type ImportLineResponse struct {
+	Line   int    `json:"line" xml:"line"`
+	Email  string `json:"email,omitempty" xml:"email,omitempty"`
+	Status string `json:"status" xml:"status"`
+	Error  string `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// ImportReportResponse represents the report of a finished import
+type ImportReportResponse struct {
+	XMLName xml.Name             `json:"-" xml:"import_report"`
+	Created int                  `json:"created" xml:"created"`
+	Skipped int                  `json:"skipped" xml:"skipped"`
+	Failed  int                  `json:"failed" xml:"failed"`
+	Lines   []ImportLineResponse `json:"lines" xml:"lines>line"`
+}
+
+// JobProgressResponse represents the rows a background job processed.
+// Total is omitted while it is not known.
+type JobProgressResponse struct {
+	Processed int `json:"processed" xml:"processed"`
+	Total     int `json:"total,omitempty" xml:"total,omitempty"`
+}
+
+// JobResponse represents a background import or export job
+type JobResponse struct {
+	XMLName     xml.Name              `json:"-" xml:"job"`
+	ID          string                `json:"id" xml:"id"`
+	Kind        string                `json:"kind" xml:"kind"`
+	Status      string                `json:"status" xml:"status"`
+	Progress    JobProgressResponse   `json:"progress" xml:"progress"`
+	Format      string                `json:"format,omitempty" xml:"format,omitempty"`
+	Report      *ImportReportResponse `json:"report,omitempty" xml:"report,omitempty"`
+	DownloadURL string                `json:"download_url,omitempty" xml:"download_url,omitempty"`
+	Error       string                `json:"error,omitempty" xml:"error,omitempty"`
+	CreatedAt   utc.Time              `json:"created_at" xml:"created_at"`
+	UpdatedAt   utc.Time              `json:"updated_at" xml:"updated_at"`
+	CompletedAt *utc.Time             `json:"completed_at,omitempty" xml:"completed_at,omitempty"`
+}
+
+// PreferencesResponse represents a user's preferences. UpdatedAt is null
+// while the user has the defaults.
+type PreferencesResponse struct {
+	XMLName       xml.Name                        `json:"-" xml:"preferences"`
+	Locale        string                          `json:"locale" xml:"locale"`
+	Timezone      string                          `json:"timezone" xml:"timezone"`
+	Notifications NotificationPreferencesResponse `json:"notifications" xml:"notifications"`
+	UpdatedAt     *utc.Time                       `json:"updated_at" xml:"updated_at"`
+}
+
+// NotificationPreferencesResponse represents a user's notification settings
+type NotificationPreferencesResponse struct {
+	Email  bool   `json:"email" xml:"email"`
+	Digest string `json:"digest" xml:"digest"`
+}
+
+// UserFilterQuery represents the query parameters filtering users
+type UserFilterQuery struct {
+	Email          string     `form:"email"`
+	Name           string     `form:"name"`
+	Status         string     `form:"status" binding:"omitempty,oneof=active suspended deactivated"`
+	CreatedAfter   *time.Time `form:"created_after"`
+	CreatedBefore  *time.Time `form:"created_before"`
+	IncludeDeleted bool       `form:"include_deleted"`
+}
+
+// ToUserFilter converts the filter query parameters to a domain user filter
+func (q UserFilterQuery) ToUserFilter() domain.UserFilter {
+	return domain.UserFilter{
+		EmailContains:  q.Email,
+		NameContains:   q.Name,
+		Status:         domain.Status(q.Status),
+		CreatedAfter:   inUTC(q.CreatedAfter),
+		CreatedBefore:  inUTC(q.CreatedBefore),
+		IncludeDeleted: q.IncludeDeleted,
+	}
+}
+
+// inUTC returns t in UTC, or nil if t is nil
+func inUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
 }
 
-// ErrorResponse represents an error response
+// UserFieldsQuery represents the fields query parameter, a comma-separated
+// list of the user fields to return
+type UserFieldsQuery struct {
+	Fields string `form:"fields"`
+}
+
+// ListUsersQuery represents the query parameters of GET /users
+type ListUsersQuery struct {
+	request.Pagination
+	UserFilterQuery
+	UserFieldsQuery
+}
+
+// ExportUsersQuery represents the query parameters of GET and POST
+// /users/export
+type ExportUsersQuery struct {
+	Format string `form:"format" binding:"oneof=csv ndjson"`
+	UserFilterQuery
+}
+
+// LookupUserQuery represents the query parameters of GET /users/lookup
+type LookupUserQuery struct {
+	Email string `form:"email" binding:"required,email"`
+}
+
+// ImportUsersQuery represents the query parameters of POST /users/import
+type ImportUsersQuery struct {
+	Async bool `form:"async"`
+}
+
+// ActivityQuery represents the query parameters of GET /users/:id/activity
+type ActivityQuery struct {
+	Limit  int    `form:"limit" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// LoginsQuery represents the query parameters of GET /admin/users/:id/logins
+type LoginsQuery struct {
+	Limit  int    `form:"limit" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// AuditLogQuery represents the query parameters of GET /admin/audit
+type AuditLogQuery struct {
+	UserID string `form:"user_id" binding:"omitempty,uuid"`
+	Type   string `form:"type"`
+	Limit  int    `form:"limit" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+}
+
+// ToEventFilter converts the query to a domain event filter
+func (q AuditLogQuery) ToEventFilter() domain.EventFilter {
+	return domain.EventFilter{
+		UserID: q.UserID,
+		Type:   domain.EventType(q.Type),
+	}
+}
+
+// EventStreamQuery represents the query parameters of GET /users/events
+type EventStreamQuery struct {
+	UserID string `form:"user_id" binding:"omitempty,uuid"`
+	Type   string `form:"type"`
+}
+
+// ToEventFilter converts the query to a domain event filter
+func (q EventStreamQuery) ToEventFilter() domain.EventFilter {
+	return domain.EventFilter{
+		UserID: q.UserID,
+		Type:   domain.EventType(q.Type),
+	}
+}
+
+// AvailabilityQuery represents the query parameters of GET /users/availability
+type AvailabilityQuery struct {
+	Email    string `form:"email"`
+	Username string `form:"username"`
+}
+
+// AvailabilityResponse represents the response of GET /users/availability;
+// only the identifiers asked about are present
+type AvailabilityResponse struct {
+	Email    *IdentifierAvailability `json:"email,omitempty"`
+	Username *IdentifierAvailability `json:"username,omitempty"`
+}
+
+// IdentifierAvailability represents the availability of one identifier
+type IdentifierAvailability struct {
+	Value     string `json:"value"` // normalized as it would be stored
+	Available bool   `json:"available"`
+	Status    string `json:"status"`            // available, taken or invalid
+	Code      string `json:"code,omitempty"`    // why an invalid identifier is invalid
+	Message   string `json:"message,omitempty"` // the same, for people
+}
+
+// ToAvailabilityResponse converts the availability of a check to a response
+func ToAvailabilityResponse(result *domain.AvailabilityResult) AvailabilityResponse {
+	return AvailabilityResponse{
+		Email:    toIdentifierAvailability(result.Email),
+		Username: toIdentifierAvailability(result.Username),
+	}
+}
+
+// toIdentifierAvailability converts the availability of one identifier,
+// nil when it was not asked about
+func toIdentifierAvailability(availability *domain.Availability) *IdentifierAvailability {
+	if availability == nil {
+		return nil
+	}
+
+	response := &IdentifierAvailability{
+		Value:     availability.Value,
+		Available: availability.Status == domain.Available,
+		Status:    string(availability.Status),
+	}
+	if availability.Err != nil {
+		response.Code = string(domain.CodeOf(availability.Err))
+		response.Message = availability.Err.Error()
+	}
+	return response
+}
+
+// UserStatsQuery represents the query parameters of GET /stats/users
+type UserStatsQuery struct {
+	Days int `form:"days" binding:"omitempty,min=1"`
+}
+
+// UserStatsResponse represents the response of GET /stats/users
+type UserStatsResponse struct {
+	Total          int                   `json:"total"`
+	Active         int                   `json:"active"`
+	ByStatus       map[string]int        `json:"by_status"`
+	Deleted        int                   `json:"deleted"`
+	SignupsPerDay  []SignupCountResponse `json:"signups_per_day"`
+	SignupsPerWeek []SignupCountResponse `json:"signups_per_week"`
+	RefreshedAt    *utc.Time             `json:"refreshed_at"`
+}
+
+// SignupCountResponse represents the signups of a day or of the week
+// starting on Start
+type SignupCountResponse struct {
+	Start   string `json:"start"`
+	Signups int    `json:"signups"`
+}
+
+// ErrorResponse represents an error response. Code is the stable,
+// machine-readable error code clients branch on.
 type ErrorResponse struct {
-	Error string `json:"error"`
+	XMLName xml.Name             `json:"-" xml:"error"`
+	Error   string               `json:"error" xml:"message"`
+	Code    string               `json:"code" xml:"code"`
+	Fields  []request.FieldError `json:"fields,omitempty" xml:"fields>field,omitempty"`
 }
 
 // ToUserResponse converts a domain user to a user response
 func ToUserResponse(user *domain.User) UserResponse {
-	return UserResponse{
+	createdAt, updatedAt := utc.New(user.CreatedAt), utc.New(user.UpdatedAt)
+	response := UserResponse{
 		ID:        user.ID,
 		Email:     user.Email,
 		Name:      user.Name,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		Username:  user.Username,
+		Status:    string(user.Status),
+		CreatedAt: &createdAt,
+		UpdatedAt: &updatedAt,
+	}
+
+	if user.AvatarKey != "" {
+		response.AvatarURL = "/users/" + user.ID + "/avatar"
+	}
+
+	if user.PendingEmailChange != nil {
+		response.PendingEmail = user.PendingEmailChange.Email
+	}
+
+	return response
+}
+
+// EventResponse represents an entry of a user's activity feed
+type EventResponse struct {
+	ID         string    `json:"id" xml:"id"`
+	Type       string    `json:"type" xml:"type"`
+	Data       EventData `json:"data,omitempty" xml:"data,omitempty"`
+	OccurredAt utc.Time  `json:"occurred_at" xml:"occurred_at"`
+}
+
+// ActivityResponse represents a page of a user's activity feed. NextCursor
+// is omitted on the last page.
+type ActivityResponse struct {
+	XMLName    xml.Name        `json:"-" xml:"activity"`
+	Events     []EventResponse `json:"events" xml:"event"`
+	NextCursor string          `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+}
+
+// ToActivityResponse converts an activity page to an activity response
+func ToActivityResponse(page *domain.ActivityPage) ActivityResponse {
+	events := make([]EventResponse, len(page.Events))
+	for i, event := range page.Events {
+		events[i] = toEventResponse(event)
+	}
+
+	return ActivityResponse{
+		Events:     events,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// toEventResponse converts a domain event to an activity feed entry
+func toEventResponse(event domain.Event) EventResponse {
+	return EventResponse{
+		ID:         event.ID,
+		Type:       string(event.Type),
+		Data:       event.Data,
+		OccurredAt: utc.New(event.OccurredAt),
+	}
+}
+
+// LoginEventResponse represents an entry of a user's login history.
+// Failure is omitted for successful logins.
+type LoginEventResponse struct {
+	ID         string   `json:"id" xml:"id"`
+	Succeeded  bool     `json:"succeeded" xml:"succeeded"`
+	Failure    string   `json:"failure,omitempty" xml:"failure,omitempty"`
+	IP         string   `json:"ip,omitempty" xml:"ip,omitempty"`
+	UserAgent  string   `json:"user_agent,omitempty" xml:"user_agent,omitempty"`
+	Country    string   `json:"country,omitempty" xml:"country,omitempty"`
+	OccurredAt utc.Time `json:"occurred_at" xml:"occurred_at"`
+}
+
+// LoginsResponse represents a page of a user's login history. NextCursor
+// is omitted on the last page.
+type LoginsResponse struct {
+	XMLName    xml.Name             `json:"-" xml:"logins"`
+	Logins     []LoginEventResponse `json:"logins" xml:"login"`
+	NextCursor string               `json:"next_cursor,omitempty" xml:"next_cursor,omitempty"`
+}
+
+// ToLoginsResponse converts a login page to a logins response
+func ToLoginsResponse(page *domain.LoginPage) LoginsResponse {
+	logins := make([]LoginEventResponse, len(page.Logins))
+	for i, login := range page.Logins {
+		logins[i] = LoginEventResponse{
+			ID:         login.ID,
+			Succeeded:  login.Succeeded,
+			Failure:    string(login.Failure),
+			IP:         login.IP,
+			UserAgent:  login.UserAgent,
+			Country:    login.Country,
+			OccurredAt: utc.New(login.OccurredAt),
+		}
+	}
+
+	return LoginsResponse{
+		Logins:     logins,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// LoginResponse represents a successful login. StepUp tells the client to
+// verify a second factor before trusting the login.
+type LoginResponse struct {
+	XMLName xml.Name     `json:"-" xml:"login"`
+	User    UserResponse `json:"user" xml:"user"`
+	StepUp  bool         `json:"step_up" xml:"step_up"`
+}
+
+// ToLoginResponse converts an authentication to a login response
+func ToLoginResponse(authenticated *domain.Authentication) LoginResponse {
+	return LoginResponse{
+		User:   ToUserResponse(authenticated.User),
+		StepUp: authenticated.Risk.StepUp,
+	}
+}
+
+// AuditEventResponse represents an entry of the audit log, or an event of
+// GET /users/events
+type AuditEventResponse struct {
+	UserID string `json:"user_id"`
+	EventResponse
+}
+
+// ToAuditEventResponse converts a domain event to an audit log entry
+func ToAuditEventResponse(event domain.Event) AuditEventResponse {
+	return AuditEventResponse{
+		UserID:        event.UserID,
+		EventResponse: toEventResponse(event),
 	}
 }
 
-// ToUsersResponse converts a slice of domain users to user responses
-func ToUsersResponse(users []*domain.User) []UserResponse {
+// AuditLogResponse represents a page of the audit log. NextCursor is
+// omitted on the last page.
+type AuditLogResponse struct {
+	Events     []AuditEventResponse `json:"events"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+// ToAuditLogResponse converts an activity page to an audit log response
+func ToAuditLogResponse(page *domain.ActivityPage) AuditLogResponse {
+	events := make([]AuditEventResponse, len(page.Events))
+	for i, event := range page.Events {
+		events[i] = ToAuditEventResponse(event)
+	}
+
+	return AuditLogResponse{
+		Events:     events,
+		NextCursor: page.NextCursor,
+	}
+}
+
+// ToPreferencesResponse converts domain preferences to a preferences response
+func ToPreferencesResponse(prefs *domain.Preferences) PreferencesResponse {
+	response := PreferencesResponse{
+		Locale:   prefs.Locale,
+		Timezone: prefs.Timezone,
+		Notifications: NotificationPreferencesResponse{
+			Email:  prefs.Notifications.Email,
+			Digest: string(prefs.Notifications.Digest),
+		},
+	}
+
+	if !prefs.UpdatedAt.IsZero() {
+		updatedAt := utc.New(prefs.UpdatedAt)
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
+
+// ToPreferencesUpdate converts a preferences request to a domain preferences update
+func (r UpdatePreferencesRequest) ToPreferencesUpdate() domain.PreferencesUpdate {
+	update := domain.PreferencesUpdate{
+		Locale:   r.Locale,
+		Timezone: r.Timezone,
+	}
+
+	if r.Notifications != nil {
+		update.EmailNotifications = r.Notifications.Email
+		update.Digest = r.Notifications.Digest
+	}
+
+	return update
+}
+
+// ToUsersResponse converts a slice of domain users to user responses with
+// the selected fields
+func ToUsersResponse(users []*domain.User, fields UserFields) []UserResponse {
 	responses := make([]UserResponse, 0, len(users))
 	for _, user := range users {
-		responses = append(responses, ToUserResponse(user))
+		responses = append(responses, fields.Select(ToUserResponse(user)))
+	}
+	return responses
+}
+
+// ToAdminUserResponse converts a domain user to an admin user response
+func ToAdminUserResponse(user *domain.User) AdminUserResponse {
+	return AdminUserResponse{
+		UserResponse: ToUserResponse(user),
+		LastLoginAt:  utc.NewPtr(user.LastLoginAt),
+		DeletedAt:    utc.NewPtr(user.DeletedAt),
+	}
+}
+
+// ToAdminUsersResponse converts a slice of domain users to admin user
+// responses with the selected fields. The deletion and last login times are
+// always included.
+func ToAdminUsersResponse(users []*domain.User, fields UserFields) []AdminUserResponse {
+	responses := make([]AdminUserResponse, 0, len(users))
+	for _, user := range users {
+		response := ToAdminUserResponse(user)
+		response.UserResponse = fields.Select(response.UserResponse)
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// userFieldNames lists the user response fields clients can select
+var userFieldNames = []string{
+	"id", "email", "name", "username", "avatar_url", "status", "pending_email", "created_at", "updated_at",
+}
+
+// UserFields is a set of user response fields, named as in JSON. The nil
+// set selects every field.
+type UserFields map[string]struct{}
+
+// ParseUserFields parses the fields query parameter. An empty parameter
+// selects every field. The id is always selected, since links and
+// follow-up requests need it.
+func ParseUserFields(raw string) (UserFields, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	fields := UserFields{"id": {}}
+	var unknown []string
+	for name := range strings.SplitSeq(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(userFieldNames, name) {
+			unknown = append(unknown, name)
+			continue
+		}
+		fields[name] = struct{}{}
+	}
+
+	if len(unknown) > 0 {
+		return nil, &request.Error{
+			Status:  http.StatusBadRequest,
+			Message: "invalid query parameters",
+			Fields: []request.FieldError{{
+				Field: "fields",
+				Message: fmt.Sprintf("has unknown fields %s; must be among: %s",
+					strings.Join(unknown, ", "), strings.Join(userFieldNames, ", ")),
+			}},
+		}
+	}
+	return fields, nil
+}
+
+// Select returns the response with only the selected fields set. The
+// others are zeroed, which omits them from the encoded response.
+func (f UserFields) Select(response UserResponse) UserResponse {
+	if f == nil {
+		return response
+	}
+
+	selected := UserResponse{ID: response.ID}
+	for name := range f {
+		switch name {
+		case "email":
+			selected.Email = response.Email
+		case "name":
+			selected.Name = response.Name
+		case "username":
+			selected.Username = response.Username
+		case "avatar_url":
+			selected.AvatarURL = response.AvatarURL
+		case "status":
+			selected.Status = response.Status
+		case "pending_email":
+			selected.PendingEmail = response.PendingEmail
+		case "created_at":
+			selected.CreatedAt = response.CreatedAt
+		case "updated_at":
+			selected.UpdatedAt = response.UpdatedAt
+		}
+	}
+	return selected
+}
+
+// ToErasureResponse converts a domain erasure record to a response
+func ToErasureResponse(record *domain.ErasureRecord) ErasureResponse {
+	return ErasureResponse{
+		ID:       record.ID,
+		UserID:   record.UserID,
+		ErasedAt: utc.New(record.ErasedAt),
+	}
+}
+
+// ToImportReportResponse converts a domain import report to a response
+func ToImportReportResponse(report *domain.ImportReport) ImportReportResponse {
+	lines := make([]ImportLineResponse, 0, len(report.Lines))
+	for _, line := range report.Lines {
+		lines = append(lines, ImportLineResponse{
+			Line:   line.Line,
+			Email:  line.Email,
+			Status: string(line.Status),
+			Error:  line.Error,
+		})
+	}
+
+	return ImportReportResponse{
+		Created: report.Created,
+		Skipped: report.Skipped,
+		Failed:  report.Failed,
+		Lines:   lines,
+	}
+}
+
+// ToJobResponse converts a domain job to a response, with the signed URL
+// of its file, if any
+func ToJobResponse(job *domain.Job, downloadURL string) JobResponse {
+	response := JobResponse{
+		ID:     job.ID,
+		Kind:   string(job.Kind),
+		Status: string(job.Status),
+		Progress: JobProgressResponse{
+			Processed: job.Progress.Processed,
+			Total:     job.Progress.Total,
+		},
+		Format:      job.Format,
+		DownloadURL: downloadURL,
+		Error:       job.Error,
+		CreatedAt:   utc.New(job.CreatedAt),
+		UpdatedAt:   utc.New(job.UpdatedAt),
+		CompletedAt: utc.NewPtr(job.CompletedAt),
+	}
+
+	if job.Report != nil {
+		report := ToImportReportResponse(job.Report)
+		response.Report = &report
+	}
+
+	return response
+}
+
+// ToUserStatsResponse converts domain user statistics to a response. Every
+// status is listed, with zero users when none is in it.
+func ToUserStatsResponse(stats *domain.UserStats) UserStatsResponse {
+	response := UserStatsResponse{
+		Total:   stats.Total,
+		Active:  stats.Active,
+		Deleted: stats.Deleted,
+		ByStatus: map[string]int{
+			string(domain.StatusActive):      0,
+			string(domain.StatusSuspended):   0,
+			string(domain.StatusDeactivated): 0,
+		},
+		SignupsPerDay:  toSignupCountResponses(stats.SignupsPerDay),
+		SignupsPerWeek: toSignupCountResponses(stats.SignupsPerWeek),
+	}
+
+	for status, count := range stats.ByStatus {
+		response.ByStatus[string(status)] = count
+	}
+	if !stats.RefreshedAt.IsZero() {
+		refreshedAt := utc.New(stats.RefreshedAt)
+		response.RefreshedAt = &refreshedAt
+	}
+
+	return response
+}
+
+// toSignupCountResponses converts signup counts to responses, dated by the
+// first day of their period
+func toSignupCountResponses(counts []domain.SignupCount) []SignupCountResponse {
+	responses := make([]SignupCountResponse, len(counts))
+	for i, count := range counts {
+		responses[i] = SignupCountResponse{
+			Start:   count.Start.Format(time.DateOnly),
+			Signups: count.Signups,
+		}
 	}
 	return responses
 }
@@ -0,0 +1,252 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const (
+	// DefaultHeartbeat is how often idle event streams get a comment when
+	// no interval is configured
+	DefaultHeartbeat = 15 * time.Second
+
+	// DefaultMaxReplay is how many missed events are replayed when no
+	// limit is configured
+	DefaultMaxReplay = 1000
+
+	// replayPageSize is how many events are read at once while replaying
+	replayPageSize = 100
+
+	// resetEvent tells a client that it missed more events than are
+	// replayed, so it must reload the state it derived from them
+	resetEvent = "reset"
+)
+
+// EventStreamOptions configures GET /users/events
+type EventStreamOptions struct {
+	// Events are the user changes pushed to the streams
+	Events ports.UserEvents
+
+	// Heartbeat is how often idle streams get a comment; zero uses
+	// DefaultHeartbeat
+	Heartbeat time.Duration
+
+	// MaxReplay bounds the events replayed after Last-Event-ID; zero uses
+	// DefaultMaxReplay
+	MaxReplay int
+
+	// MaxConnections bounds the streams open at once. Zero disables the
+	// limit.
+	MaxConnections int
+}
+
+// EventStreamHandler streams user changes as server-sent events
+type EventStreamHandler struct {
+	events    ports.UserEvents
+	activity  ports.UserActivity
+	heartbeat time.Duration
+	maxReplay int
+	slots     chan struct{}
+}
+
+// NewEventStreamHandler creates a new EventStreamHandler
+func NewEventStreamHandler(activity ports.UserActivity, opts EventStreamOptions) *EventStreamHandler {
+	h := &EventStreamHandler{
+		events:    opts.Events,
+		activity:  activity,
+		heartbeat: opts.Heartbeat,
+		maxReplay: opts.MaxReplay,
+	}
+	if h.heartbeat <= 0 {
+		h.heartbeat = DefaultHeartbeat
+	}
+	if h.maxReplay <= 0 {
+		h.maxReplay = DefaultMaxReplay
+	}
+	if opts.MaxConnections > 0 {
+		h.slots = make(chan struct{}, opts.MaxConnections)
+	}
+	return h
+}
+
+// StreamEvents handles GET /users/events
+func (h *EventStreamHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	var query EventStreamQuery
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	var last *domain.EventCursor
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		cursor, err := domain.ParseEventCursor(id)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{
+				Error: "invalid Last-Event-ID",
+				Code:  problem.CodeFor(http.StatusBadRequest),
+			})
+			return
+		}
+		last = &cursor
+	}
+
+	if h.slots != nil {
+		select {
+		case h.slots <- struct{}{}:
+			defer func() { <-h.slots }()
+		default:
+			writeJSON(w, http.StatusServiceUnavailable, ErrorResponse{
+				Error: "too many event streams, retry later",
+				Code:  problem.CodeFor(http.StatusServiceUnavailable),
+			})
+			return
+		}
+	}
+
+	ctx := r.Context()
+	filter := query.ToEventFilter()
+
+	// Subscribe before replaying so no event committed in between is lost;
+	// events both replayed and received are only sent once
+	live, err := h.events.Subscribe(ctx, filter)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	var missed []domain.Event
+	complete := true
+	if last != nil {
+		missed, complete, err = h.missed(ctx, filter, *last)
+		if err != nil {
+			statusCode, response := domainErrorResponse(err)
+			writeJSON(w, statusCode, response)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Keep reverse proxies such as nginx from buffering the stream
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	flusher := http.NewResponseController(w)
+	sent := make(map[string]struct{}, len(missed))
+	if !complete {
+		// Resume after the newest event once the client has reset
+		newest := missed[0]
+		if err := writeSSE(w, domain.CursorAfter(newest).String(), resetEvent, struct{}{}); err != nil {
+			return
+		}
+		sent[newest.ID] = struct{}{}
+		missed = nil
+	}
+	for _, event := range missed {
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+		sent[event.ID] = struct{}{}
+	}
+	_ = flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-live:
+			if !ok {
+				// The client fell behind and was dropped; it reconnects
+				// with Last-Event-ID and catches up from the activity feed
+				return
+			}
+			if _, ok := sent[event.ID]; ok {
+				continue
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		_ = flusher.Flush()
+	}
+}
+
+// missed returns the events matching the filter that follow last, oldest
+// first. It reports false, with only the newest event, when more than
+// maxReplay events follow.
+func (h *EventStreamHandler) missed(ctx context.Context, filter domain.EventFilter, last domain.EventCursor) ([]domain.Event, bool, error) {
+	// Replicas may not have the latest events yet
+	ctx = database.ReadFromPrimary(ctx)
+
+	var events []domain.Event
+	cursor := ""
+	for {
+		page, err := h.activity.ListAuditLog(ctx, filter, cursor, replayPageSize)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// The feed is newest first, so the missed events end at last
+		for _, event := range page.Events {
+			if !after(event, last) {
+				slices.Reverse(events)
+				return events, true, nil
+			}
+			if len(events) == h.maxReplay {
+				return events[:1], false, nil
+			}
+			events = append(events, event)
+		}
+
+		if page.NextCursor == "" {
+			slices.Reverse(events)
+			return events, true, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// after reports whether the event follows the cursor in the activity feed
+func after(event domain.Event, cursor domain.EventCursor) bool {
+	if event.OccurredAt.Equal(cursor.OccurredAt) {
+		return event.ID > cursor.ID
+	}
+	return event.OccurredAt.After(cursor.OccurredAt)
+}
+
+// writeEvent writes a user event. Its ID is the cursor the stream resumes
+// from, and its name is the event type.
+func writeEvent(w io.Writer, event domain.Event) error {
+	return writeSSE(w, domain.CursorAfter(event).String(), string(event.Type), ToAuditEventResponse(event))
+}
+
+// writeSSE writes one server-sent event with data encoded as JSON
+func writeSSE(w io.Writer, id, name string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", id, name, payload)
+	return err
+}
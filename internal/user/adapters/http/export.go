@@ -0,0 +1,106 @@
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// ExportFormats returns the file formats users are exported in, by name.
+// GET /users/export streams them, and export jobs store them.
+func ExportFormats() map[string]ports.UserExportFormat {
+	return map[string]ports.UserExportFormat{
+		ExportFormatCSV:    csvExportFormat{},
+		ExportFormatNDJSON: ndjsonExportFormat{},
+	}
+}
+
+// exportCSVHeader is the header row of CSV exports
+var exportCSVHeader = []string{"id", "email", "name", "status", "created_at", "updated_at"}
+
+// csvExportFormat writes a header row, then a row per user
+type csvExportFormat struct{}
+
+// ContentType implements ports.UserExportFormat
+func (csvExportFormat) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+// NewWriter implements ports.UserExportFormat
+func (csvExportFormat) NewWriter(w io.Writer) ports.UserExportWriter {
+	return &csvExportWriter{w: csv.NewWriter(w)}
+}
+
+// csvExportWriter writes the header row before the first user
+type csvExportWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+// Write implements ports.UserExportWriter
+func (e *csvExportWriter) Write(user *domain.User) error {
+	if !e.header {
+		e.header = true
+		if err := e.w.Write(exportCSVHeader); err != nil {
+			return err
+		}
+	}
+	return e.w.Write(toCSVRecord(user))
+}
+
+// Flush implements ports.UserExportWriter. An export without users still
+// gets its header row.
+func (e *csvExportWriter) Flush() error {
+	if !e.header {
+		e.header = true
+		if err := e.w.Write(exportCSVHeader); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// toCSVRecord converts a domain user to a CSV export row
+func toCSVRecord(user *domain.User) []string {
+	return []string{
+		user.ID,
+		user.Email,
+		user.Name,
+		string(user.Status),
+		user.CreatedAt.UTC().Format(utc.Layout),
+		user.UpdatedAt.UTC().Format(utc.Layout),
+	}
+}
+
+// ndjsonExportFormat writes a line per user, shaped like GET /users/:id
+type ndjsonExportFormat struct{}
+
+// ContentType implements ports.UserExportFormat
+func (ndjsonExportFormat) ContentType() string {
+	return "application/x-ndjson"
+}
+
+// NewWriter implements ports.UserExportFormat
+func (ndjsonExportFormat) NewWriter(w io.Writer) ports.UserExportWriter {
+	return ndjsonExportWriter{enc: json.NewEncoder(w)}
+}
+
+// ndjsonExportWriter encodes each user as it is written
+type ndjsonExportWriter struct {
+	enc *json.Encoder
+}
+
+// Write implements ports.UserExportWriter
+func (e ndjsonExportWriter) Write(user *domain.User) error {
+	return e.enc.Encode(ToUserResponse(user))
+}
+
+// Flush implements ports.UserExportWriter; nothing is buffered
+func (ndjsonExportWriter) Flush() error {
+	return nil
+}
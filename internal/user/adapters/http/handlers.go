@@ -2,10 +2,14 @@ package http
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
-	"strconv"
+	"net/url"
+	"time"
 
-	"github.com/gin-gonic/gin"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
 	"github.com/yourusername/go-scaffolding/internal/user/ports"
 )
@@ -13,157 +17,905 @@ import (
 // UserHandler handles HTTP requests for user operations
 type UserHandler struct {
 	userService ports.UserService
+	importer    ports.UserImporter
+	jobs        ports.UserJobs
+	avatars     ports.UserAvatars
+	preferences ports.UserPreferences
+	activity    ports.UserActivity
+	passwords   ports.UserPasswords
+	renderer    renderer
+
+	// country resolves the country of clients logging in; nil leaves it
+	// unknown
+	country func(r *http.Request) string
 }
 
-// NewUserHandler creates a new UserHandler
-func NewUserHandler(userService ports.UserService) *UserHandler {
+// NewUserHandler creates a new UserHandler rendering responses in formats,
+// negotiated by the Accept header
+func NewUserHandler(userService ports.UserService, importer ports.UserImporter, jobs ports.UserJobs, avatars ports.UserAvatars, preferences ports.UserPreferences, activity ports.UserActivity, passwords ports.UserPasswords, formats []Format) *UserHandler {
 	return &UserHandler{
 		userService: userService,
+		importer:    importer,
+		jobs:        jobs,
+		avatars:     avatars,
+		preferences: preferences,
+		activity:    activity,
+		passwords:   passwords,
+		renderer:    newRenderer(formats),
 	}
 }
 
+// render writes obj with the status code in the format the client accepts
+func (h *UserHandler) render(w http.ResponseWriter, r *http.Request, status int, obj any) {
+	h.renderer.render(w, r, status, obj)
+}
+
 // CreateUser handles POST /users
-func (h *UserHandler) CreateUser(c *gin.Context) {
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 	var req CreateUserRequest
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: err.Error(),
-		})
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
 		return
 	}
 
-	user, err := h.userService.CreateUser(c.Request.Context(), req.Email, req.Name)
+	// Users registering with a password have it checked against the
+	// password policy; others can set one later
+	var user *domain.User
+	var err error
+	if req.Password != "" {
+		user, err = h.passwords.Register(r.Context(), req.Email, req.Name, req.Password)
+	} else {
+		user, err = h.userService.CreateUser(r.Context(), req.Email, req.Name)
+	}
 	if err != nil {
-		statusCode, errorMsg := mapDomainErrorToHTTP(err)
-		c.JSON(statusCode, ErrorResponse{
-			Error: errorMsg,
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusCreated, ToUserResponse(user))
+}
+
+// BulkCreateUsers handles POST /users/bulk
+func (h *UserHandler) BulkCreateUsers(w http.ResponseWriter, r *http.Request) {
+	var req BulkCreateUsersRequest
+
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	if len(req.Users) > MaxBulkSize {
+		h.render(w, r, http.StatusBadRequest, ErrorResponse{
+			Error: "bulk request cannot exceed 1000 users",
+			Code:  problem.CodeFor(http.StatusBadRequest),
 		})
 		return
 	}
 
-	c.JSON(http.StatusCreated, ToUserResponse(user))
+	mode := domain.BulkModeAtomic
+	if req.Mode != "" {
+		mode = domain.BulkMode(req.Mode)
+	}
+
+	inputs := make([]domain.NewUserInput, len(req.Users))
+	for i, item := range req.Users {
+		inputs[i] = domain.NewUserInput{Email: item.Email, Name: item.Name}
+	}
+
+	results, err := h.userService.BulkCreateUsers(r.Context(), inputs, mode)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	response := BulkCreateUsersResponse{
+		Results: make([]BulkCreateItemResponse, len(results)),
+	}
+	for i, result := range results {
+		item := BulkCreateItemResponse{Index: result.Index}
+		if result.Err != nil {
+			_, item.Error = mapDomainErrorToHTTP(result.Err)
+			item.Code = string(domain.CodeOf(result.Err))
+			item.Status = "failed"
+			response.Failed++
+		} else {
+			user := ToUserResponse(result.User)
+			item.User = &user
+			item.Status = "created"
+			response.Created++
+		}
+		response.Results[i] = item
+	}
+
+	// Multi-Status signals that items must be inspected individually
+	statusCode := http.StatusCreated
+	if response.Failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+
+	h.render(w, r, statusCode, response)
 }
 
-// GetUser handles GET /users/:id
-func (h *UserHandler) GetUser(c *gin.Context) {
-	id := c.Param("id")
+// ImportUsers handles POST /users/import
+func (h *UserHandler) ImportUsers(w http.ResponseWriter, r *http.Request) {
+	var query ImportUsersQuery
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, MaxImportSize)
 
-	user, err := h.userService.GetUser(c.Request.Context(), id)
+	file, _, err := r.FormFile("file")
 	if err != nil {
-		statusCode, errorMsg := mapDomainErrorToHTTP(err)
-		c.JSON(statusCode, ErrorResponse{
-			Error: errorMsg,
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.render(w, r, http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error: "import file cannot exceed 10MB",
+				Code:  problem.CodeFor(http.StatusRequestEntityTooLarge),
+			})
+			return
+		}
+		h.render(w, r, http.StatusBadRequest, ErrorResponse{
+			Error: "multipart form field 'file' is required",
+			Code:  problem.CodeFor(http.StatusBadRequest),
 		})
 		return
 	}
+	defer file.Close()
 
-	c.JSON(http.StatusOK, ToUserResponse(user))
+	if query.Async {
+		job, err := h.jobs.StartImport(r.Context(), file)
+		if err != nil {
+			statusCode, response := domainErrorResponse(err)
+			h.render(w, r, statusCode, response)
+			return
+		}
+
+		w.Header().Set("Location", "/jobs/"+job.ID)
+		h.render(w, r, http.StatusAccepted, ToJobResponse(job, ""))
+		return
+	}
+
+	report, err := h.importer.Import(r.Context(), file)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToImportReportResponse(report))
 }
 
-// GetUserByEmail handles GET /users/email/:email
-func (h *UserHandler) GetUserByEmail(c *gin.Context) {
-	email := c.Param("email")
+// GetJob handles GET /jobs/{id} and the deprecated GET /users/imports/{id}
+func (h *UserHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
 
-	user, err := h.userService.GetUserByEmail(c.Request.Context(), email)
+	job, err := h.jobs.GetJob(r.Context(), id)
 	if err != nil {
-		statusCode, errorMsg := mapDomainErrorToHTTP(err)
-		c.JSON(statusCode, ErrorResponse{
-			Error: errorMsg,
-		})
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	// Completed exports are downloaded from object storage directly
+	var downloadURL string
+	if job.Kind == domain.JobKindExport && job.Status == domain.JobCompleted {
+		downloadURL, err = h.jobs.DownloadURL(r.Context(), job)
+		if err != nil {
+			statusCode, response := domainErrorResponse(err)
+			h.render(w, r, statusCode, response)
+			return
+		}
+	}
+
+	h.render(w, r, http.StatusOK, ToJobResponse(job, downloadURL))
+}
+
+// GetUser handles GET /users/{id}
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var query UserFieldsQuery
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	fields, err := ParseUserFields(query.Fields)
+	if err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	user, err := h.userService.GetUser(r.Context(), id)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, fields.Select(ToUserResponse(user)))
+}
+
+// HeadUser handles HEAD /users/{id}, answering 200 when the user exists
+// and 404 when it does not, without loading it
+func (h *UserHandler) HeadUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, ToUserResponse(user))
+	h.exists(w, r, domain.UserKey{ID: id})
 }
 
-// UpdateUser handles PUT /users/:id
-func (h *UserHandler) UpdateUser(c *gin.Context) {
-	id := c.Param("id")
+// HeadUsers handles HEAD /users?email=, answering like HeadUser for the
+// user holding the email, such as to check it is available at signup
+func (h *UserHandler) HeadUsers(w http.ResponseWriter, r *http.Request) {
+	var query LookupUserQuery
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.exists(w, r, domain.UserKey{Email: query.Email})
+}
+
+// exists answers an existence check of the user of key
+func (h *UserHandler) exists(w http.ResponseWriter, r *http.Request, key domain.UserKey) {
+	exists, err := h.userService.UserExists(r.Context(), key)
+	if err == nil && !exists {
+		err = domain.ErrUserNotFound
+	}
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// LookupUser handles GET /users/lookup?email=
+func (h *UserHandler) LookupUser(w http.ResponseWriter, r *http.Request) {
+	var query LookupUserQuery
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	user, err := h.userService.GetUserByEmail(r.Context(), query.Email)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToUserResponse(user))
+}
+
+// GetUserByEmail handles GET /users/email/{email}.
+// Deprecated: emails needing URL encoding break the path; use LookupUser.
+func (h *UserHandler) GetUserByEmail(w http.ResponseWriter, r *http.Request) {
+	email := r.PathValue("email")
+
+	// Point clients at the replacement route
+	successor := "/users/lookup?" + url.Values{"email": {email}}.Encode()
+	w.Header().Set("Deprecation", "true")
+	w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="successor-version"`, successor))
+
+	user, err := h.userService.GetUserByEmail(r.Context(), email)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToUserResponse(user))
+}
+
+// GetUserByUsername handles GET /users/username/{username}
+func (h *UserHandler) GetUserByUsername(w http.ResponseWriter, r *http.Request) {
+	username := r.PathValue("username")
+
+	user, err := h.userService.GetUserByUsername(r.Context(), username)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToUserResponse(user))
+}
+
+// UpdateUser handles PUT /users/{id}
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
 
 	var req UpdateUserRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: err.Error(),
-		})
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	user, err := h.userService.UpdateUser(r.Context(), id, req.Name)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToUserResponse(user))
+}
+
+// ChangeEmail handles PUT /users/{id}/email
+func (h *UserHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ChangeEmailRequest
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	user, err := h.userService.ChangeEmail(r.Context(), id, req.Email)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	// A pending change is applied only once the mailed token is confirmed
+	statusCode := http.StatusOK
+	if user.PendingEmailChange != nil {
+		statusCode = http.StatusAccepted
+	}
+
+	h.render(w, r, statusCode, ToUserResponse(user))
+}
+
+// ChangeUsername handles PUT /users/{id}/username
+func (h *UserHandler) ChangeUsername(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ChangeUsernameRequest
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	user, err := h.userService.ChangeUsername(r.Context(), id, req.Username)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToUserResponse(user))
+}
+
+// ChangePassword handles PUT /users/{id}/password
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	if err := h.passwords.ChangePassword(r.Context(), id, req.CurrentPassword, req.Password); err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
 		return
 	}
 
-	user, err := h.userService.UpdateUser(c.Request.Context(), id, req.Name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Login handles POST /users/login
+func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	// The address resolved behind the trusted proxies, so the login history
+	// and the risk rules see the client rather than the load balancer
+	client := domain.LoginClient{UserAgent: r.UserAgent()}
+	if addr := clientip.FromRequest(r); addr.IsValid() {
+		client.IP = addr.String()
+	}
+	if h.country != nil {
+		client.Country = h.country(r)
+	}
+
+	authenticated, err := h.passwords.Authenticate(r.Context(), req.Email, req.Password, client)
 	if err != nil {
-		statusCode, errorMsg := mapDomainErrorToHTTP(err)
-		c.JSON(statusCode, ErrorResponse{
-			Error: errorMsg,
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToLoginResponse(authenticated))
+}
+
+// UploadAvatar handles PUT /users/{id}/avatar
+func (h *UserHandler) UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, MaxAvatarSize)
+
+	file, _, err := r.FormFile("avatar")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.render(w, r, http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error: "avatar cannot exceed 5MB",
+				Code:  problem.CodeFor(http.StatusRequestEntityTooLarge),
+			})
+			return
+		}
+		h.render(w, r, http.StatusBadRequest, ErrorResponse{
+			Error: "multipart form field 'avatar' is required",
+			Code:  problem.CodeFor(http.StatusBadRequest),
 		})
 		return
 	}
+	defer file.Close()
 
-	c.JSON(http.StatusOK, ToUserResponse(user))
+	user, err := h.avatars.UploadAvatar(r.Context(), id, file)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToUserResponse(user))
+}
+
+// GetAvatar handles GET /users/{id}/avatar
+func (h *UserHandler) GetAvatar(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	url, err := h.avatars.AvatarURL(r.Context(), id)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	// Signed URLs expire, so the redirect itself must not be cached
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+// DeleteAvatar handles DELETE /users/{id}/avatar
+func (h *UserHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.avatars.DeleteAvatar(r.Context(), id); err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ConfirmEmailChange handles POST /users/{id}/email/confirm
+func (h *UserHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req ConfirmEmailChangeRequest
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	user, err := h.userService.ConfirmEmailChange(r.Context(), id, req.Token)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToUserResponse(user))
+}
+
+// GetPreferences handles GET /users/{id}/preferences
+func (h *UserHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.preferences.GetPreferences(r.Context(), id)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToPreferencesResponse(prefs))
+}
+
+// UpdatePreferences handles PUT /users/{id}/preferences
+func (h *UserHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req UpdatePreferencesRequest
+	if err := request.DecodeJSON(w, r, &req); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	prefs, err := h.preferences.UpdatePreferences(r.Context(), id, req.ToPreferencesUpdate())
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToPreferencesResponse(prefs))
+}
+
+// ListActivity handles GET /users/{id}/activity
+func (h *UserHandler) ListActivity(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	query := ActivityQuery{Limit: DefaultActivityLimit}
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	page, err := h.activity.ListActivity(r.Context(), id, query.Cursor, query.Limit)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToActivityResponse(page))
 }
 
-// DeleteUser handles DELETE /users/:id
-func (h *UserHandler) DeleteUser(c *gin.Context) {
-	id := c.Param("id")
+// ActivateUser handles POST /users/{id}/activate
+func (h *UserHandler) ActivateUser(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, domain.StatusActive)
+}
+
+// SuspendUser handles POST /users/{id}/suspend
+func (h *UserHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, domain.StatusSuspended)
+}
 
-	err := h.userService.DeleteUser(c.Request.Context(), id)
+// DeactivateUser handles POST /users/{id}/deactivate
+func (h *UserHandler) DeactivateUser(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, domain.StatusDeactivated)
+}
+
+// changeStatus moves the user in the path to the given lifecycle state
+func (h *UserHandler) changeStatus(w http.ResponseWriter, r *http.Request, status domain.Status) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.ChangeUserStatus(r.Context(), id, status)
 	if err != nil {
-		statusCode, errorMsg := mapDomainErrorToHTTP(err)
-		c.JSON(statusCode, ErrorResponse{
-			Error: errorMsg,
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	h.render(w, r, http.StatusOK, ToUserResponse(user))
+}
+
+// DeleteUser handles DELETE /users/{id}
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r)
+	if !ok {
+		return
+	}
+
+	// Erasure moved to the admin routes. Clients still asking for it here
+	// are refused, so they are not told a soft delete erased the user.
+	var message string
+	switch r.URL.Query().Get("mode") {
+	case "", DeleteModeSoft:
+	case DeleteModeErase:
+		message = "erasure is an admin operation: use DELETE /admin/users/{id}"
+	default:
+		message = "mode must be soft; erase users with DELETE /admin/users/{id}"
+	}
+	if message != "" {
+		h.render(w, r, http.StatusBadRequest, ErrorResponse{
+			Error:  message,
+			Code:   problem.CodeValidationFailed,
+			Fields: []request.FieldError{{Field: "mode", Message: message}},
 		})
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	err := h.userService.DeleteUser(r.Context(), id)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 const (
-	// MaxLimit defines the maximum number of users that can be fetched in a single request
-	MaxLimit = 100
+	// DeleteModeSoft is the only mode of DELETE /users/{id}. DeleteModeErase
+	// is refused: users are erased with DELETE /admin/users/{id}.
+	DeleteModeSoft  = "soft"
+	DeleteModeErase = "erase"
+
+	// DefaultActivityLimit is the number of activity events returned when no limit is given
+	DefaultActivityLimit = 20
+
+	// DefaultLoginsLimit is the number of login attempts returned when no limit is given
+	DefaultLoginsLimit = 20
+
+	// MaxAvatarSize defines the maximum size in bytes of an avatar upload
+	MaxAvatarSize = 5 << 20
+
+	// MaxBulkSize defines the maximum number of users that can be created in a single request
+	MaxBulkSize = 1000
+
+	// MaxImportSize defines the maximum size in bytes of an import request
+	MaxImportSize = 10 << 20
+
+	// ExportFormatCSV and ExportFormatNDJSON are the supported export formats
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+
+	// exportFlushEvery is the number of exported rows between flushes
+	exportFlushEvery = 100
 )
 
 // ListUsers handles GET /users
-func (h *UserHandler) ListUsers(c *gin.Context) {
-	// Parse pagination parameters with defaults
-	limit := 10
-	offset := 0
-
-	if limitStr := c.Query("limit"); limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := ListUsersQuery{Pagination: request.NewPagination()}
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
 	}
 
-	if offsetStr := c.Query("offset"); offsetStr != "" {
-		if parsedOffset, err := strconv.Atoi(offsetStr); err == nil && parsedOffset >= 0 {
-			offset = parsedOffset
-		}
+	fields, err := ParseUserFields(query.Fields)
+	if err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
 	}
 
-	// Enforce maximum limit to prevent database overload
-	if limit > MaxLimit {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "limit cannot exceed 100",
-		})
+	filter := query.ToUserFilter()
+	limit, offset := query.Limit, query.Offset
+
+	users, err := h.userService.ListUsers(r.Context(), filter, limit, offset)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
 		return
 	}
 
-	users, err := h.userService.ListUsers(c.Request.Context(), limit, offset)
-	if err != nil {
-		statusCode, errorMsg := mapDomainErrorToHTTP(err)
-		c.JSON(statusCode, ErrorResponse{
-			Error: errorMsg,
+	// Deleted users are only listed on request, with their deletion time
+	if filter.IncludeDeleted {
+		h.render(w, r, http.StatusOK, AdminListUsersResponse{
+			Users:  ToAdminUsersResponse(users, fields),
+			Limit:  limit,
+			Offset: offset,
 		})
 		return
 	}
 
 	response := ListUsersResponse{
-		Users:  ToUsersResponse(users),
+		Users:  ToUsersResponse(users, fields),
 		Limit:  limit,
 		Offset: offset,
 	}
 
-	c.JSON(http.StatusOK, response)
+	h.render(w, r, http.StatusOK, response)
+}
+
+// ExportUsers handles GET /users/export
+func (h *UserHandler) ExportUsers(w http.ResponseWriter, r *http.Request) {
+	query := ExportUsersQuery{Format: ExportFormatCSV}
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	format := query.Format
+	filter := query.ToUserFilter()
+
+	filename := fmt.Sprintf("users-%s.%s", time.Now().UTC().Format("20060102-150405"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	exportFormat := ExportFormats()[format]
+	w.Header().Set("Content-Type", exportFormat.ContentType())
+	out := &responseTracker{ResponseWriter: w}
+	rows := exportFormat.NewWriter(out)
+
+	count := 0
+	err := h.userService.ExportUsers(r.Context(), filter, func(user *domain.User) error {
+		if err := rows.Write(user); err != nil {
+			return err
+		}
+
+		// Push rows to the client regularly instead of buffering the export
+		count++
+		if count%exportFlushEvery == 0 {
+			if err := rows.Flush(); err != nil {
+				return err
+			}
+			_ = http.NewResponseController(w).Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = rows.Flush()
+	}
+
+	if err != nil {
+		// Nothing reached the client yet, so a proper error can still be sent
+		if !out.written {
+			w.Header().Del("Content-Disposition")
+			statusCode, response := domainErrorResponse(err)
+			h.render(w, r, statusCode, response)
+			return
+		}
+
+		// The response is already streaming; drop the connection so the
+		// client sees a truncated download rather than a silently incomplete one
+		if conn, _, hijackErr := http.NewResponseController(w).Hijack(); hijackErr == nil {
+			_ = conn.Close()
+		}
+	}
+}
+
+// responseTracker records whether a response was started
+type responseTracker struct {
+	http.ResponseWriter
+	written bool
+}
+
+// WriteHeader implements http.ResponseWriter
+func (t *responseTracker) WriteHeader(status int) {
+	t.written = true
+	t.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements http.ResponseWriter
+func (t *responseTracker) Write(b []byte) (int, error) {
+	t.written = true
+	return t.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the wrapped writer, for http.ResponseController
+func (t *responseTracker) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+// StartExport handles POST /users/export, which exports in the background
+// what GET /users/export streams
+func (h *UserHandler) StartExport(w http.ResponseWriter, r *http.Request) {
+	query := ExportUsersQuery{Format: ExportFormatCSV}
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	job, err := h.jobs.StartExport(r.Context(), query.ToUserFilter(), query.Format)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return
+	}
+
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	h.render(w, r, http.StatusAccepted, ToJobResponse(job, ""))
+}
+
+// bindErrorResponse converts a request binding error to a status code and
+// an error response listing the offending fields
+func bindErrorResponse(err error) (int, ErrorResponse) {
+	var bindErr *request.Error
+	if errors.As(err, &bindErr) {
+		return bindErr.Status, ErrorResponse{
+			Error:  bindErr.Message,
+			Code:   problem.CodeOf(bindErr),
+			Fields: bindErr.Fields,
+		}
+	}
+
+	return http.StatusBadRequest, ErrorResponse{
+		Error: err.Error(),
+		Code:  problem.CodeValidationFailed,
+	}
+}
+
+// pathID returns the {id} path parameter, answering the request with 400
+// when it is not a UUID
+func (h *UserHandler) pathID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id, err := request.PathID(r)
+	if err != nil {
+		statusCode, response := bindErrorResponse(err)
+		h.render(w, r, statusCode, response)
+		return "", false
+	}
+	return id, true
+}
+
+// domainErrorResponse converts a domain error to a status code and an error
+// response carrying its code and, for validation errors, the invalid fields
+func domainErrorResponse(err error) (int, ErrorResponse) {
+	statusCode, message := mapDomainErrorToHTTP(err)
+	return statusCode, ErrorResponse{
+		Error:  message,
+		Code:   string(domain.CodeOf(err)),
+		Fields: request.FieldErrors(err),
+	}
 }
 
 // mapDomainErrorToHTTP maps domain errors to HTTP status codes and messages
@@ -177,6 +929,62 @@ func mapDomainErrorToHTTP(err error) (int, string) {
 		return http.StatusBadRequest, err.Error()
 	case errors.Is(err, domain.ErrDuplicateEmail):
 		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrUndeliverableEmail):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, domain.ErrInvalidUsername):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrReservedUsername):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrDuplicateUsername):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrInvalidImage):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrNoAvatar):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrInvalidLocale):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidTimezone):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidDigestFrequency):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrUserNotDeleted):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrInvalidStatus):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidStatusTransition):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrUserSuspended):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, domain.ErrUserDeactivated):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, domain.ErrNoPendingEmailChange):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrInvalidEmailChangeToken):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrBulkAborted):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrInvalidImportFile),
+		errors.Is(err, domain.ErrInvalidExportFormat):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrJobNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrImportInProgress):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrPasswordTooShort),
+		errors.Is(err, domain.ErrPasswordTooLong),
+		errors.Is(err, domain.ErrPasswordMissingUpper),
+		errors.Is(err, domain.ErrPasswordMissingLower),
+		errors.Is(err, domain.ErrPasswordMissingDigit),
+		errors.Is(err, domain.ErrPasswordMissingSymbol),
+		errors.Is(err, domain.ErrPasswordContainsPersonalInfo),
+		errors.Is(err, domain.ErrPasswordBreached):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrIncorrectPassword):
+		return http.StatusForbidden, err.Error()
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return http.StatusUnauthorized, err.Error()
 	default:
 		return http.StatusInternalServerError, "internal server error"
 	}
@@ -0,0 +1,228 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// MIMEJSONAPI is the media type of JSON:API documents
+const MIMEJSONAPI = "application/vnd.api+json"
+
+// JSON:API resource types
+const (
+	jsonapiUsers       = "users"
+	jsonapiEvents      = "events"
+	jsonapiPreferences = "preferences"
+	jsonapiJobs        = "jobs"
+)
+
+// JSONAPIDocument represents a JSON:API top-level document
+type JSONAPIDocument struct {
+	Data    any               `json:"data,omitempty"`
+	Errors  []JSONAPIError    `json:"errors,omitempty"`
+	Meta    any               `json:"meta,omitempty"`
+	Links   map[string]string `json:"links,omitempty"`
+	JSONAPI JSONAPIObject     `json:"jsonapi"`
+}
+
+// JSONAPIObject describes the JSON:API version a document follows
+type JSONAPIObject struct {
+	Version string `json:"version"`
+}
+
+// JSONAPIResource represents a JSON:API resource object
+type JSONAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    map[string]any                 `json:"attributes,omitempty"`
+	Relationships map[string]JSONAPIRelationship `json:"relationships,omitempty"`
+	Links         map[string]string              `json:"links,omitempty"`
+}
+
+// JSONAPIRelationship represents a relationship of a resource, reached by
+// its related link
+type JSONAPIRelationship struct {
+	Links map[string]string `json:"links"`
+}
+
+// JSONAPIError represents a JSON:API error object
+type JSONAPIError struct {
+	Status string              `json:"status"`
+	Code   string              `json:"code,omitempty"`
+	Title  string              `json:"title"`
+	Detail string              `json:"detail,omitempty"`
+	Source *JSONAPIErrorSource `json:"source,omitempty"`
+}
+
+// JSONAPIErrorSource points at the request field an error is about
+type JSONAPIErrorSource struct {
+	Pointer   string `json:"pointer,omitempty"`
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// ToJSONAPIDocument converts a response to a JSON:API document. Users,
+// user lists, preferences, activity feeds, import jobs and errors become
+// resources and error objects; other responses are returned as meta.
+func ToJSONAPIDocument(r *http.Request, status int, obj any) JSONAPIDocument {
+	doc := JSONAPIDocument{JSONAPI: JSONAPIObject{Version: "1.1"}}
+
+	switch v := obj.(type) {
+	case UserResponse:
+		doc.Data = toUserResource(v.ID, v)
+		doc.Links = map[string]string{"self": "/users/" + v.ID}
+	case ListUsersResponse:
+		users := make([]JSONAPIResource, len(v.Users))
+		for i, user := range v.Users {
+			users[i] = toUserResource(user.ID, user)
+		}
+		doc.Data = users
+		doc.Meta = map[string]int{"limit": v.Limit, "offset": v.Offset}
+		doc.Links = jsonapiPageLinks(r, v.Limit, v.Offset, len(v.Users))
+	case AdminListUsersResponse:
+		users := make([]JSONAPIResource, len(v.Users))
+		for i, user := range v.Users {
+			users[i] = toUserResource(user.ID, user)
+		}
+		doc.Data = users
+		doc.Meta = map[string]int{"limit": v.Limit, "offset": v.Offset}
+		doc.Links = jsonapiPageLinks(r, v.Limit, v.Offset, len(v.Users))
+	case PreferencesResponse:
+		// Preferences belong to one user and share its ID
+		id := r.PathValue("id")
+		doc.Data = JSONAPIResource{
+			Type:          jsonapiPreferences,
+			ID:            id,
+			Attributes:    jsonapiAttributes(v),
+			Relationships: map[string]JSONAPIRelationship{"user": jsonapiRelated("/users/" + id)},
+		}
+		doc.Links = map[string]string{"self": r.URL.Path}
+	case ActivityResponse:
+		id := r.PathValue("id")
+		events := make([]JSONAPIResource, len(v.Events))
+		for i, event := range v.Events {
+			events[i] = JSONAPIResource{
+				Type:          jsonapiEvents,
+				ID:            event.ID,
+				Attributes:    jsonapiAttributes(event, "id"),
+				Relationships: map[string]JSONAPIRelationship{"user": jsonapiRelated("/users/" + id)},
+			}
+		}
+		doc.Data = events
+		doc.Links = map[string]string{"self": r.URL.RequestURI()}
+		if v.NextCursor != "" {
+			doc.Links["next"] = jsonapiLink(r, map[string]string{"cursor": v.NextCursor})
+		}
+	case JobResponse:
+		links := map[string]string{"self": "/jobs/" + v.ID}
+		if v.DownloadURL != "" {
+			links["download"] = v.DownloadURL
+		}
+		doc.Data = JSONAPIResource{
+			Type:       jsonapiJobs,
+			ID:         v.ID,
+			Attributes: jsonapiAttributes(v, "id", "download_url"),
+			Links:      links,
+		}
+	case ErrorResponse:
+		doc.Errors = toJSONAPIErrors(r, status, v)
+	default:
+		doc.Meta = obj
+	}
+
+	return doc
+}
+
+// toUserResource converts a user response to a users resource, with links
+// to the user's preferences and activity
+func toUserResource(id string, user any) JSONAPIResource {
+	self := "/users/" + id
+	return JSONAPIResource{
+		Type:       jsonapiUsers,
+		ID:         id,
+		Attributes: jsonapiAttributes(user, "id"),
+		Relationships: map[string]JSONAPIRelationship{
+			"preferences": jsonapiRelated(self + "/preferences"),
+			"activity":    jsonapiRelated(self + "/activity"),
+		},
+		Links: map[string]string{"self": self},
+	}
+}
+
+// toJSONAPIErrors converts an error response to error objects, one per
+// offending field when they are known
+func toJSONAPIErrors(r *http.Request, status int, response ErrorResponse) []JSONAPIError {
+	code, title := strconv.Itoa(status), http.StatusText(status)
+	if len(response.Fields) == 0 {
+		return []JSONAPIError{{Status: code, Code: response.Code, Title: title, Detail: response.Error}}
+	}
+
+	errs := make([]JSONAPIError, len(response.Fields))
+	for i, field := range response.Fields {
+		// Fields of GET requests are query parameters, the others body fields
+		source := &JSONAPIErrorSource{Pointer: "/" + field.Field}
+		if r.Method == http.MethodGet {
+			source = &JSONAPIErrorSource{Parameter: field.Field}
+		}
+		errs[i] = JSONAPIError{Status: code, Code: response.Code, Title: response.Error, Detail: field.Message, Source: source}
+	}
+	return errs
+}
+
+// jsonapiAttributes returns the JSON fields of a response, without the
+// omitted ones
+func jsonapiAttributes(response any, omit ...string) map[string]any {
+	raw, err := json.Marshal(response)
+	if err != nil {
+		return nil
+	}
+
+	var attributes map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&attributes); err != nil {
+		return nil
+	}
+
+	for _, name := range omit {
+		delete(attributes, name)
+	}
+	return attributes
+}
+
+// jsonapiRelated returns a relationship reached by its related link
+func jsonapiRelated(path string) JSONAPIRelationship {
+	return JSONAPIRelationship{Links: map[string]string{"related": path}}
+}
+
+// jsonapiPageLinks returns the pagination links of a limit and offset
+// page holding count items. next is omitted when the page is not full.
+func jsonapiPageLinks(r *http.Request, limit, offset, count int) map[string]string {
+	page := func(offset int) string {
+		return jsonapiLink(r, map[string]string{
+			"limit":  strconv.Itoa(limit),
+			"offset": strconv.Itoa(offset),
+		})
+	}
+
+	links := map[string]string{"self": page(offset), "first": page(0)}
+	if offset > 0 {
+		links["prev"] = page(max(offset-limit, 0))
+	}
+	if count == limit {
+		links["next"] = page(offset + limit)
+	}
+	return links
+}
+
+// jsonapiLink returns the request's path and query with params replaced
+func jsonapiLink(r *http.Request, params map[string]string) string {
+	u := *r.URL
+	query := u.Query()
+	for name, value := range params {
+		query.Set(name, value)
+	}
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
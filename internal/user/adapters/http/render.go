@@ -0,0 +1,228 @@
+package http
+
+import (
+	"encoding/xml"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+	"github.com/ugorji/go/codec"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/locale"
+)
+
+// Format is a response encoding the user routes offer through the Accept
+// header
+type Format string
+
+// Response formats
+const (
+	FormatJSON    Format = "json"
+	FormatXML     Format = "xml"
+	FormatMsgPack Format = "msgpack"
+	FormatJSONAPI Format = "jsonapi"
+)
+
+// mediaTypes lists the media types clients ask for each format with
+var mediaTypes = map[Format][]string{
+	FormatJSON:    {binding.MIMEJSON},
+	FormatXML:     {binding.MIMEXML, binding.MIMEXML2},
+	FormatMsgPack: {binding.MIMEMSGPACK2, binding.MIMEMSGPACK},
+	FormatJSONAPI: {MIMEJSONAPI},
+}
+
+// ParseFormats validates the response formats named in configuration. The
+// default format, JSON when empty, answers clients without a preference.
+// It and JSON are always offered.
+func ParseFormats(defaultFormat string, names []string) ([]Format, error) {
+	if defaultFormat == "" {
+		defaultFormat = string(FormatJSON)
+	}
+
+	var formats []Format
+	for _, name := range append([]string{defaultFormat, string(FormatJSON)}, names...) {
+		format := Format(name)
+		if _, ok := mediaTypes[format]; !ok {
+			return nil, fmt.Errorf("unknown response format: %q", name)
+		}
+		if !slices.Contains(formats, format) {
+			formats = append(formats, format)
+		}
+	}
+	return formats, nil
+}
+
+// renderer writes responses in the format negotiated with the client
+type renderer struct {
+	offered []string
+}
+
+// newRenderer returns a renderer offering formats, the first being the
+// default; JSON when there are none
+func newRenderer(formats []Format) renderer {
+	if len(formats) == 0 {
+		formats = []Format{FormatJSON}
+	}
+
+	var r renderer
+	for _, format := range formats {
+		r.offered = append(r.offered, mediaTypes[format]...)
+	}
+	return r
+}
+
+// render writes obj with the status code in the offered format the Accept
+// header prefers. Clients accepting none of them get the default format
+// rather than 406, as before formats were negotiated. Times are written in
+// the time zone of the request's locale.
+func (rd renderer) render(w http.ResponseWriter, r *http.Request, status int, obj any) {
+	if len(rd.offered) > 1 {
+		// The body depends on the Accept header, which caches must key on
+		w.Header().Set("Vary", "Accept")
+	}
+	if l, ok := locale.FromContext(r.Context()); ok {
+		w.Header().Add("Vary", locale.TimezoneHeader)
+		obj = l.Localize(obj)
+	}
+
+	accept := strings.Join(r.Header.Values("Accept"), ",")
+	mediaType := negotiate(accept, rd.offered)
+	if mediaType == "" {
+		mediaType = rd.offered[0]
+	}
+
+	switch mediaType {
+	case MIMEJSONAPI:
+		w.Header().Set("Content-Type", MIMEJSONAPI)
+		writeRender(w, status, render.JSON{Data: ToJSONAPIDocument(r, status, obj)})
+	case binding.MIMEXML, binding.MIMEXML2:
+		writeRender(w, status, render.XML{Data: obj})
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		writeRender(w, status, msgpackRender{data: obj})
+	default:
+		writeJSON(w, status, obj)
+	}
+}
+
+// writeJSON writes obj as JSON with the status code
+func writeJSON(w http.ResponseWriter, status int, obj any) {
+	writeRender(w, status, render.JSON{Data: obj})
+}
+
+// writeRender writes the status code and the body rendered by body. The
+// response is already committed when rendering fails, so the error is
+// dropped, as gin does.
+func writeRender(w http.ResponseWriter, status int, body render.Render) {
+	body.WriteContentType(w)
+	w.WriteHeader(status)
+	_ = body.Render(w)
+}
+
+// negotiate returns the offered media type the Accept header gives the
+// highest quality, the first offered one when the header is empty, or ""
+// when none is acceptable. Unlike gin's NegotiateFormat it honors quality
+// values; ties go to the media range listed first.
+func negotiate(accept string, offered []string) string {
+	if strings.TrimSpace(accept) == "" {
+		return offered[0]
+	}
+
+	best, bestQuality := "", 0.0
+	for mediaRange := range strings.SplitSeq(accept, ",") {
+		mediaType, params, _ := strings.Cut(mediaRange, ";")
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+		q := quality(params)
+		if q <= bestQuality {
+			continue
+		}
+		for _, offer := range offered {
+			if matchesMediaRange(mediaType, offer) {
+				best, bestQuality = offer, q
+				break
+			}
+		}
+	}
+	return best
+}
+
+// quality returns the q parameter of a media range, 1 when it is absent
+// and 0 when it is invalid
+func quality(params string) float64 {
+	for param := range strings.SplitSeq(params, ";") {
+		name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if !strings.EqualFold(name, "q") {
+			continue
+		}
+		q, err := strconv.ParseFloat(value, 64)
+		if err != nil || q < 0 || q > 1 {
+			return 0
+		}
+		return q
+	}
+	return 1
+}
+
+// matchesMediaRange reports whether the media type falls in the range,
+// such as "*/*" or "application/*"
+func matchesMediaRange(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(mediaRange, "*")
+	return ok && strings.HasSuffix(prefix, "/") && strings.HasPrefix(mediaType, prefix)
+}
+
+// msgpackHandle encodes with the current MessagePack spec, so strings use
+// the str types and times the timestamp extension that other ecosystems
+// decode. Field names come from the json tags.
+var msgpackHandle = func() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{}
+	h.WriteExt = true
+	return h
+}()
+
+// msgpackRender renders MessagePack. gin's own renderer writes times in
+// an encoding only ugorji/go decodes.
+type msgpackRender struct {
+	data any
+}
+
+// Render encodes the data
+func (r msgpackRender) Render(w http.ResponseWriter) error {
+	r.WriteContentType(w)
+	return codec.NewEncoder(w, msgpackHandle).Encode(r.data)
+}
+
+// WriteContentType sets the MessagePack content type
+func (msgpackRender) WriteContentType(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", binding.MIMEMSGPACK2)
+}
+
+// EventData holds the changed fields of an event. XML has no maps, so it
+// is encoded as field elements named by attribute, sorted by name.
+type EventData map[string]string
+
+// MarshalXML encodes the data as <field name="...">value</field> elements
+func (d EventData) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(d)) {
+		field := xml.StartElement{
+			Name: xml.Name{Local: "field"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: name}},
+		}
+		if err := e.EncodeElement(d[name], field); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
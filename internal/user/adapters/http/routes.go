@@ -1,22 +1,96 @@
 package http
 
 import (
-	"github.com/gin-gonic/gin"
+	"net/http"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/transport"
 	"github.com/yourusername/go-scaffolding/internal/user/ports"
 )
 
+// RouteOptions configures optional user route behaviour
+type RouteOptions struct {
+	// LegacyEmailRoute keeps the deprecated GET /users/email/{email} route
+	LegacyEmailRoute bool
+
+	// Formats are the response formats offered, parsed by ParseFormats;
+	// JSON only when empty
+	Formats []Format
+
+	// EventStream serves GET /users/events; nil disables the route
+	EventStream *EventStreamOptions
+
+	// Stats serves GET /stats/users; nil disables the route
+	Stats *StatsOptions
+
+	// Availability serves GET /users/availability; nil disables the route
+	Availability *AvailabilityOptions
+
+	// Country resolves the country of clients logging in, as an ISO 3166-1
+	// alpha-2 code, for the login risk rules; nil leaves it unknown
+	Country func(r *http.Request) string
+}
+
 // RegisterUserRoutes registers all user routes
-func RegisterUserRoutes(router *gin.Engine, userService ports.UserService) {
-	handler := NewUserHandler(userService)
+func RegisterUserRoutes(router transport.Router, userService ports.UserService, importer ports.UserImporter, jobs ports.UserJobs, avatars ports.UserAvatars, preferences ports.UserPreferences, activity ports.UserActivity, passwords ports.UserPasswords, opts RouteOptions) {
+	handler := NewUserHandler(userService, importer, jobs, avatars, preferences, activity, passwords, opts.Formats)
+	handler.country = opts.Country
 
 	// User routes
-	users := router.Group("/users")
-	{
-		users.POST("", handler.CreateUser)
-		users.GET("", handler.ListUsers)
-		users.GET("/email/:email", handler.GetUserByEmail) // Must be before /:id to avoid route conflict
-		users.GET("/:id", handler.GetUser)
-		users.PUT("/:id", handler.UpdateUser)
-		users.DELETE("/:id", handler.DeleteUser)
+	router.Handle(http.MethodPost, "/users", handler.CreateUser)
+	router.Handle(http.MethodPost, "/users/bulk", handler.BulkCreateUsers)
+	router.Handle(http.MethodPost, "/users/import", handler.ImportUsers)
+	router.Handle(http.MethodPost, "/users/login", handler.Login)
+	router.Handle(http.MethodGet, "/users/imports/{id}", handler.GetJob) // Deprecated: use GET /jobs/{id}
+	router.Handle(http.MethodGet, "/users", handler.ListUsers)
+	router.Handle(http.MethodHead, "/users", handler.HeadUsers)
+	router.Handle(http.MethodGet, "/users/export", handler.ExportUsers)
+	router.Handle(http.MethodPost, "/users/export", handler.StartExport)
+	router.Handle(http.MethodGet, "/users/lookup", handler.LookupUser)
+	router.Handle(http.MethodGet, "/users/username/{username}", handler.GetUserByUsername)
+	router.Handle(http.MethodGet, "/users/{id}", handler.GetUser)
+	router.Handle(http.MethodHead, "/users/{id}", handler.HeadUser)
+	router.Handle(http.MethodPut, "/users/{id}", handler.UpdateUser)
+	router.Handle(http.MethodPut, "/users/{id}/email", handler.ChangeEmail)
+	router.Handle(http.MethodPut, "/users/{id}/username", handler.ChangeUsername)
+	router.Handle(http.MethodPut, "/users/{id}/password", handler.ChangePassword)
+	router.Handle(http.MethodPut, "/users/{id}/avatar", handler.UploadAvatar)
+	router.Handle(http.MethodGet, "/users/{id}/avatar", handler.GetAvatar)
+	router.Handle(http.MethodDelete, "/users/{id}/avatar", handler.DeleteAvatar)
+	router.Handle(http.MethodGet, "/users/{id}/preferences", handler.GetPreferences)
+	router.Handle(http.MethodPut, "/users/{id}/preferences", handler.UpdatePreferences)
+	router.Handle(http.MethodGet, "/users/{id}/activity", handler.ListActivity)
+	router.Handle(http.MethodPost, "/users/{id}/email/confirm", handler.ConfirmEmailChange)
+	router.Handle(http.MethodPost, "/users/{id}/activate", handler.ActivateUser)
+	router.Handle(http.MethodPost, "/users/{id}/suspend", handler.SuspendUser)
+	router.Handle(http.MethodPost, "/users/{id}/deactivate", handler.DeactivateUser)
+	router.Handle(http.MethodDelete, "/users/{id}", handler.DeleteUser)
+
+	if opts.LegacyEmailRoute {
+		router.Handle(http.MethodGet, "/users/email/{email}", handler.GetUserByEmail)
 	}
+
+	if opts.EventStream != nil {
+		router.Handle(http.MethodGet, "/users/events", NewEventStreamHandler(activity, *opts.EventStream).StreamEvents)
+	}
+
+	if opts.Availability != nil {
+		router.Handle(http.MethodGet, "/users/availability", NewAvailabilityHandler(*opts.Availability).CheckAvailability)
+	}
+
+	router.Handle(http.MethodGet, "/jobs/{id}", handler.GetJob)
+
+	if opts.Stats != nil {
+		router.Handle(http.MethodGet, "/stats/users", NewStatsHandler(*opts.Stats).GetUserStats)
+	}
+}
+
+// RegisterAdminRoutes registers the operational user routes on the router
+// of the admin route group, which must authorize the requests
+func RegisterAdminRoutes(admin transport.Router, userService ports.UserService, avatars ports.UserAvatars, activity ports.UserActivity) {
+	handler := NewAdminHandler(userService, avatars, activity)
+
+	admin.Handle(http.MethodPost, "/users/{id}/restore", handler.RestoreUser)
+	admin.Handle(http.MethodDelete, "/users/{id}", handler.EraseUser)
+	admin.Handle(http.MethodGet, "/users/{id}/logins", handler.ListLogins)
+	admin.Handle(http.MethodGet, "/audit", handler.ListAuditLog)
 }
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const (
+	// DefaultStatsDays is how many days of signups are returned when the
+	// request does not say
+	DefaultStatsDays = 30
+
+	// DefaultStatsMaxDays bounds the days of signups a request may ask for
+	// when no limit is configured
+	DefaultStatsMaxDays = 90
+)
+
+// StatsOptions configures GET /stats/users
+type StatsOptions struct {
+	// Stats computes the statistics
+	Stats ports.UserStatistics
+
+	// MaxDays bounds the days parameter; zero uses DefaultStatsMaxDays
+	MaxDays int
+}
+
+// StatsHandler serves user statistics. The statistics only change when
+// they are refreshed, so responses may be cached until the next refresh
+// and carry validators for conditional requests.
+type StatsHandler struct {
+	stats   ports.UserStatistics
+	maxDays int
+}
+
+// NewStatsHandler creates a new StatsHandler
+func NewStatsHandler(opts StatsOptions) *StatsHandler {
+	h := &StatsHandler{
+		stats:   opts.Stats,
+		maxDays: opts.MaxDays,
+	}
+	if h.maxDays <= 0 {
+		h.maxDays = DefaultStatsMaxDays
+	}
+	return h
+}
+
+// GetUserStats handles GET /stats/users
+func (h *StatsHandler) GetUserStats(w http.ResponseWriter, r *http.Request) {
+	var query UserStatsQuery
+	if err := request.DecodeQuery(r, &query); err != nil {
+		statusCode, response := bindErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	days := query.Days
+	if days == 0 {
+		days = min(DefaultStatsDays, h.maxDays)
+	}
+	if days > h.maxDays {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{
+			Error: fmt.Sprintf("days must be at most %d", h.maxDays),
+			Code:  problem.CodeFor(http.StatusBadRequest),
+		})
+		return
+	}
+
+	stats, err := h.stats.GetUserStats(r.Context(), days)
+	if err != nil {
+		statusCode, response := domainErrorResponse(err)
+		writeJSON(w, statusCode, response)
+		return
+	}
+
+	body, err := json.Marshal(ToUserStatsResponse(stats))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{
+			Error: "failed to encode user statistics",
+			Code:  problem.CodeFor(http.StatusInternalServerError),
+		})
+		return
+	}
+
+	// The body differs per tenant and range, so it is its own validator
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	maxAge := max(time.Until(stats.ExpiresAt), 0)
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds())))
+	if !stats.RefreshedAt.IsZero() {
+		w.Header().Set("Last-Modified", stats.RefreshedAt.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, stats.RefreshedAt) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// notModified reports whether the client's copy, identified by the
+// conditional headers of the request, is current. If-None-Match takes
+// precedence over If-Modified-Since, as RFC 9110 requires.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		for _, tag := range strings.Split(header, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || modified.IsZero() {
+		return false
+	}
+	// HTTP dates have a resolution of one second
+	return !modified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"github.com/go-playground/validator/v10"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// Validations are the binding tags of user fields, for the request
+// structs of any adapter:
+//
+//   - username: a username in the format ChangeUsername accepts
+//   - password_policy: a password following policy, besides its checks
+//     against the email address, name and breaches, which need the user
+func Validations(policy domain.PasswordPolicy) []request.Validation {
+	return []request.Validation{
+		{
+			Tag: "username",
+			Func: func(fl validator.FieldLevel) bool {
+				return domain.CheckUsername(fl.Field().String()) == nil
+			},
+			Message: func(fe validator.FieldError) string {
+				return violationMessage(domain.CheckUsername(fieldString(fe)))
+			},
+		},
+		{
+			Tag: "password_policy",
+			Func: func(fl validator.FieldLevel) bool {
+				return policy.Check(domain.PasswordCheck{Password: fl.Field().String()}) == nil
+			},
+			Message: func(fe validator.FieldError) string {
+				return violationMessage(policy.Check(domain.PasswordCheck{Password: fieldString(fe)}))
+			},
+		},
+	}
+}
+
+// fieldString returns the string value of a rejected field
+func fieldString(fe validator.FieldError) string {
+	s, _ := fe.Value().(string)
+	return s
+}
+
+// violationMessage returns the message of the violations err describes,
+// joined by semicolons when there are several
+func violationMessage(err error) string {
+	if err == nil {
+		return "is invalid"
+	}
+	return err.Error()
+}
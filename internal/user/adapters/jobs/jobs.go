@@ -0,0 +1,267 @@
+// Package jobs stores the background import and export jobs of users in
+// the user_jobs table, so a job outlives the instance that started it. A
+// running job saves its progress every second; one that stops being saved
+// was abandoned, and is resumed by the job service.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// unfinished lists the statuses of the jobs that are still to run
+var unfinished = []string{string(domain.JobPending), string(domain.JobRunning)}
+
+// finished lists the statuses of the jobs that ended
+var finished = []string{string(domain.JobCompleted), string(domain.JobFailed)}
+
+// Model is the database model of a job. Its times are set by the job
+// service, not by GORM, so that stale jobs are told apart by the same clock
+// that saves them.
+type Model struct {
+	ID          string       `gorm:"type:uuid;primaryKey"`
+	TenantID    string       `gorm:"type:varchar(56);not null;default:''"`
+	Kind        string       `gorm:"type:varchar(20);not null"`
+	Status      string       `gorm:"type:varchar(20);not null;index:idx_user_jobs_status,priority:1"`
+	Processed   int          `gorm:"not null;default:0"`
+	Total       int          `gorm:"not null;default:0"`
+	Format      string       `gorm:"type:varchar(20);not null;default:''"`
+	Filter      filterModel  `gorm:"type:jsonb;serializer:json;not null"`
+	InputKey    string       `gorm:"type:varchar(255);not null;default:''"`
+	ResultKey   string       `gorm:"type:varchar(255);not null;default:''"`
+	Report      *reportModel `gorm:"type:jsonb;serializer:json"`
+	Error       string       `gorm:"type:text;not null;default:''"`
+	CreatedAt   time.Time    `gorm:"not null;autoCreateTime:false"`
+	UpdatedAt   time.Time    `gorm:"not null;autoUpdateTime:false;index:idx_user_jobs_status,priority:2"`
+	CompletedAt *time.Time   `gorm:"index"`
+}
+
+// TableName specifies the table name for Model
+func (Model) TableName() string {
+	return "user_jobs"
+}
+
+// filterModel is the stored form of the user filter of an export
+type filterModel struct {
+	EmailContains  string     `json:"email_contains,omitempty"`
+	NameContains   string     `json:"name_contains,omitempty"`
+	Status         string     `json:"status,omitempty"`
+	CreatedAfter   *time.Time `json:"created_after,omitempty"`
+	CreatedBefore  *time.Time `json:"created_before,omitempty"`
+	IncludeDeleted bool       `json:"include_deleted,omitempty"`
+}
+
+// reportModel is the stored form of the report of an import
+type reportModel struct {
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Lines   []reportLineModel `json:"lines"`
+}
+
+// reportLineModel is the stored form of a skipped or failed import line
+type reportLineModel struct {
+	Line   int    `json:"line"`
+	Email  string `json:"email,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Store implements the JobRepository port on the user_jobs table
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore creates a job repository stored in db
+func NewStore(db *gorm.DB) ports.JobRepository {
+	return &Store{db: db}
+}
+
+// Create inserts the job. The tenant of ctx is stamped on the row, and
+// copied to the job.
+func (s *Store) Create(ctx context.Context, job *domain.Job) error {
+	model := toModel(job)
+	if err := database.Conn(ctx, s.db).Create(model).Error; err != nil {
+		return err
+	}
+	job.TenantID = model.TenantID
+	return nil
+}
+
+// Update saves the progress and outcome of the job
+func (s *Store) Update(ctx context.Context, job *domain.Job) error {
+	model := toModel(job)
+	return database.Conn(ctx, s.db).
+		Model(&Model{ID: job.ID}).
+		Select("status", "processed", "total", "report", "error", "updated_at", "completed_at").
+		Updates(model).Error
+}
+
+// Get retrieves a job by ID
+func (s *Store) Get(ctx context.Context, id string) (*domain.Job, error) {
+	// Anything but a UUID cannot name a job
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, domain.ErrJobNotFound
+	}
+
+	var model Model
+	err := database.Conn(ctx, s.db).Where("id = ?", id).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toDomain(&model), nil
+}
+
+// ListStale retrieves the unfinished jobs last saved before the time,
+// oldest first
+func (s *Store) ListStale(ctx context.Context, before time.Time) ([]*domain.Job, error) {
+	var models []*Model
+	err := database.Conn(ctx, s.db).
+		Where("status IN ? AND updated_at < ?", unfinished, before).
+		Order("created_at").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]*domain.Job, len(models))
+	for i, model := range models {
+		jobs[i] = toDomain(model)
+	}
+	return jobs, nil
+}
+
+// Claim marks a stale job running again. The condition is checked by the
+// update itself, so only one of the instances racing on a job claims it.
+func (s *Store) Claim(ctx context.Context, id string, staleBefore, now time.Time) (bool, error) {
+	result := database.Conn(ctx, s.db).
+		Model(&Model{}).
+		Where("id = ? AND status IN ? AND updated_at < ?", id, unfinished, staleBefore).
+		Updates(map[string]any{
+			"status":     string(domain.JobRunning),
+			"processed":  0,
+			"updated_at": now,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// DeleteFinished deletes the jobs finished before the time and returns
+// them
+func (s *Store) DeleteFinished(ctx context.Context, before time.Time) ([]*domain.Job, error) {
+	var models []*Model
+	err := database.Conn(ctx, s.db).
+		Where("status IN ? AND completed_at < ?", finished, before).
+		Find(&models).Error
+	if err != nil || len(models) == 0 {
+		return nil, err
+	}
+
+	jobs := make([]*domain.Job, len(models))
+	ids := make([]string, len(models))
+	for i, model := range models {
+		jobs[i] = toDomain(model)
+		ids[i] = model.ID
+	}
+
+	if err := database.Conn(ctx, s.db).Where("id IN ?", ids).Delete(&Model{}).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// toModel converts a domain job to a database model
+func toModel(job *domain.Job) *Model {
+	model := &Model{
+		ID:        job.ID,
+		TenantID:  job.TenantID,
+		Kind:      string(job.Kind),
+		Status:    string(job.Status),
+		Processed: job.Progress.Processed,
+		Total:     job.Progress.Total,
+		Format:    job.Format,
+		Filter: filterModel{
+			EmailContains:  job.Filter.EmailContains,
+			NameContains:   job.Filter.NameContains,
+			Status:         string(job.Filter.Status),
+			CreatedAfter:   job.Filter.CreatedAfter,
+			CreatedBefore:  job.Filter.CreatedBefore,
+			IncludeDeleted: job.Filter.IncludeDeleted,
+		},
+		InputKey:    job.InputKey,
+		ResultKey:   job.ResultKey,
+		Error:       job.Error,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		CompletedAt: job.CompletedAt,
+	}
+
+	if job.Report != nil {
+		report := &reportModel{
+			Created: job.Report.Created,
+			Skipped: job.Report.Skipped,
+			Failed:  job.Report.Failed,
+			Lines:   make([]reportLineModel, len(job.Report.Lines)),
+		}
+		for i, line := range job.Report.Lines {
+			report.Lines[i] = reportLineModel{Line: line.Line, Email: line.Email, Status: string(line.Status), Error: line.Error}
+		}
+		model.Report = report
+	}
+
+	return model
+}
+
+// toDomain converts a database model to a domain job
+func toDomain(model *Model) *domain.Job {
+	job := &domain.Job{
+		ID:       model.ID,
+		TenantID: model.TenantID,
+		Kind:     domain.JobKind(model.Kind),
+		Status:   domain.JobStatus(model.Status),
+		Progress: domain.JobProgress{Processed: model.Processed, Total: model.Total},
+		Format:   model.Format,
+		Filter: domain.UserFilter{
+			EmailContains:  model.Filter.EmailContains,
+			NameContains:   model.Filter.NameContains,
+			Status:         domain.Status(model.Filter.Status),
+			CreatedAfter:   model.Filter.CreatedAfter,
+			CreatedBefore:  model.Filter.CreatedBefore,
+			IncludeDeleted: model.Filter.IncludeDeleted,
+		},
+		InputKey:    model.InputKey,
+		ResultKey:   model.ResultKey,
+		Error:       model.Error,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+		CompletedAt: model.CompletedAt,
+	}
+
+	if model.Report != nil {
+		report := &domain.ImportReport{
+			Created: model.Report.Created,
+			Skipped: model.Report.Skipped,
+			Failed:  model.Report.Failed,
+			Lines:   make([]domain.ImportLineResult, len(model.Report.Lines)),
+		}
+		for i, line := range model.Report.Lines {
+			report.Lines[i] = domain.ImportLineResult{Line: line.Line, Email: line.Email, Status: domain.ImportLineStatus(line.Status), Error: line.Error}
+		}
+		job.Report = report
+	}
+
+	return job
+}
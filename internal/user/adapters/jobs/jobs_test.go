@@ -0,0 +1,197 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// stores returns the job repositories the tests run against
+func stores(t *testing.T) map[string]ports.JobRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Model{}))
+
+	return map[string]ports.JobRepository{
+		"gorm":   NewStore(db),
+		"memory": NewMemoryStore(),
+	}
+}
+
+// newJob returns a pending job last saved at updatedAt
+func newJob(kind domain.JobKind, updatedAt time.Time) *domain.Job {
+	return &domain.Job{
+		ID:        uuid.New().String(),
+		Kind:      kind,
+		Status:    domain.JobPending,
+		CreatedAt: updatedAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+func TestStore_CreateUpdateGet(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			after := now.Add(-24 * time.Hour)
+
+			job := newJob(domain.JobKindExport, now)
+			job.Format = "csv"
+			job.Filter = domain.UserFilter{EmailContains: "example.com", Status: domain.StatusActive, CreatedAfter: &after}
+			job.ResultKey = "exports/" + job.ID + ".csv"
+			require.NoError(t, store.Create(ctx, job))
+
+			job.Status = domain.JobRunning
+			job.Progress = domain.JobProgress{Processed: 500}
+			job.UpdatedAt = now.Add(time.Second)
+			require.NoError(t, store.Update(ctx, job))
+
+			got, err := store.Get(ctx, job.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.JobRunning, got.Status)
+			assert.Equal(t, 500, got.Progress.Processed)
+			assert.Equal(t, "csv", got.Format)
+			assert.Equal(t, "example.com", got.Filter.EmailContains)
+			assert.Equal(t, domain.StatusActive, got.Filter.Status)
+			require.NotNil(t, got.Filter.CreatedAfter)
+			assert.True(t, after.Equal(*got.Filter.CreatedAfter))
+			assert.Equal(t, job.ResultKey, got.ResultKey)
+			assert.True(t, now.Add(time.Second).Equal(got.UpdatedAt))
+			assert.Nil(t, got.Report)
+
+			_, err = store.Get(ctx, uuid.New().String())
+			assert.ErrorIs(t, err, domain.ErrJobNotFound)
+			_, err = store.Get(ctx, "not-a-uuid")
+			assert.ErrorIs(t, err, domain.ErrJobNotFound)
+		})
+	}
+}
+
+func TestStore_UpdateReport(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			job := newJob(domain.JobKindImport, now)
+			job.InputKey = "imports/" + job.ID + ".csv"
+			require.NoError(t, store.Create(ctx, job))
+
+			job.Report = &domain.ImportReport{Created: 1, Failed: 1, Lines: []domain.ImportLineResult{
+				{Line: 3, Email: "not-an-email", Status: domain.ImportLineFailed, Error: "invalid email format"},
+			}}
+			job.Complete(now)
+			require.NoError(t, store.Update(ctx, job))
+
+			got, err := store.Get(ctx, job.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.JobCompleted, got.Status)
+			assert.Equal(t, job.Report, got.Report)
+			require.NotNil(t, got.CompletedAt)
+		})
+	}
+}
+
+func TestStore_ListStaleAndClaim(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			staleBefore := now.Add(-time.Minute)
+
+			stale := newJob(domain.JobKindExport, now.Add(-time.Hour))
+			stale.Status = domain.JobRunning
+			stale.Progress.Processed = 1000
+			fresh := newJob(domain.JobKindExport, now)
+			done := newJob(domain.JobKindImport, now.Add(-time.Hour))
+			done.Complete(now.Add(-time.Hour))
+			for _, job := range []*domain.Job{stale, fresh, done} {
+				require.NoError(t, store.Create(ctx, job))
+			}
+
+			jobs, err := store.ListStale(ctx, staleBefore)
+			require.NoError(t, err)
+			require.Len(t, jobs, 1)
+			assert.Equal(t, stale.ID, jobs[0].ID)
+
+			claimed, err := store.Claim(ctx, stale.ID, staleBefore, now)
+			require.NoError(t, err)
+			assert.True(t, claimed)
+
+			// The job is no longer stale, so it cannot be claimed twice
+			claimed, err = store.Claim(ctx, stale.ID, staleBefore, now)
+			require.NoError(t, err)
+			assert.False(t, claimed)
+
+			claimed, err = store.Claim(ctx, done.ID, staleBefore, now)
+			require.NoError(t, err)
+			assert.False(t, claimed, "finished jobs are not resumed")
+
+			got, err := store.Get(ctx, stale.ID)
+			require.NoError(t, err)
+			assert.Equal(t, domain.JobRunning, got.Status)
+			assert.Zero(t, got.Progress.Processed)
+			assert.True(t, now.Equal(got.UpdatedAt))
+		})
+	}
+}
+
+func TestStore_DeleteFinished(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			old := newJob(domain.JobKindExport, now.Add(-48*time.Hour))
+			old.ResultKey = "exports/" + old.ID + ".csv"
+			old.Complete(now.Add(-48 * time.Hour))
+			recent := newJob(domain.JobKindExport, now)
+			recent.Fail(assert.AnError, now)
+			running := newJob(domain.JobKindImport, now.Add(-48*time.Hour))
+			for _, job := range []*domain.Job{old, recent, running} {
+				require.NoError(t, store.Create(ctx, job))
+			}
+
+			deleted, err := store.DeleteFinished(ctx, now.Add(-24*time.Hour))
+			require.NoError(t, err)
+			require.Len(t, deleted, 1)
+			assert.Equal(t, old.ResultKey, deleted[0].ResultKey)
+
+			_, err = store.Get(ctx, old.ID)
+			assert.ErrorIs(t, err, domain.ErrJobNotFound)
+			for _, job := range []*domain.Job{recent, running} {
+				_, err = store.Get(ctx, job.ID)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStore_Tenancy(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Model{}))
+	require.NoError(t, tenancy.Register(db, tenancy.SharedSchema))
+	store := NewStore(db)
+	acme := tenancy.WithTenant(context.Background(), "acme")
+
+	job := newJob(domain.JobKindExport, now)
+	require.NoError(t, store.Create(acme, job))
+	assert.Equal(t, "acme", job.TenantID, "the tenant is recorded to resume the job for it")
+
+	_, err = store.Get(tenancy.WithTenant(context.Background(), "globex"), job.ID)
+	assert.ErrorIs(t, err, domain.ErrJobNotFound, "jobs are only seen by their tenant")
+
+	jobs, err := store.ListStale(tenancy.AllTenants(context.Background()), now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "acme", jobs[0].TenantID)
+}
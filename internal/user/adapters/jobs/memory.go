@@ -0,0 +1,116 @@
+package jobs
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// MemoryStore implements the JobRepository port in process memory. Jobs are
+// lost on restart and not shared between instances, so it is meant for
+// running without a database.
+type MemoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]domain.Job
+}
+
+// NewMemoryStore creates a new in-memory job repository
+func NewMemoryStore() ports.JobRepository {
+	return &MemoryStore{
+		jobs: make(map[string]domain.Job),
+	}
+}
+
+// Create stores the job
+func (s *MemoryStore) Create(ctx context.Context, job *domain.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID] = *job
+	return nil
+}
+
+// Update saves the progress and outcome of the job
+func (s *MemoryStore) Update(ctx context.Context, job *domain.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.jobs[job.ID]
+	if !ok {
+		return nil
+	}
+	stored.Status = job.Status
+	stored.Progress = job.Progress
+	stored.Report = job.Report
+	stored.Error = job.Error
+	stored.UpdatedAt = job.UpdatedAt
+	stored.CompletedAt = job.CompletedAt
+	s.jobs[job.ID] = stored
+	return nil
+}
+
+// Get retrieves a job by ID
+func (s *MemoryStore) Get(ctx context.Context, id string) (*domain.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, domain.ErrJobNotFound
+	}
+	return &job, nil
+}
+
+// ListStale retrieves the unfinished jobs last saved before the time,
+// oldest first
+func (s *MemoryStore) ListStale(ctx context.Context, before time.Time) ([]*domain.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var jobs []*domain.Job
+	for _, job := range s.jobs {
+		if !job.Finished() && job.UpdatedAt.Before(before) {
+			jobs = append(jobs, &job)
+		}
+	}
+	slices.SortFunc(jobs, func(a, b *domain.Job) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	return jobs, nil
+}
+
+// Claim marks a stale job running again
+func (s *MemoryStore) Claim(ctx context.Context, id string, staleBefore, now time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok || job.Finished() || !job.UpdatedAt.Before(staleBefore) {
+		return false, nil
+	}
+	job.Status = domain.JobRunning
+	job.Progress.Processed = 0
+	job.UpdatedAt = now
+	s.jobs[id] = job
+	return true, nil
+}
+
+// DeleteFinished deletes the jobs finished before the time and returns
+// them
+func (s *MemoryStore) DeleteFinished(ctx context.Context, before time.Time) ([]*domain.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted []*domain.Job
+	for id, job := range s.jobs {
+		if job.Finished() && job.CompletedAt != nil && job.CompletedAt.Before(before) {
+			deleted = append(deleted, &job)
+			delete(s.jobs, id)
+		}
+	}
+	return deleted, nil
+}
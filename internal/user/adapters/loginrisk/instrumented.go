@@ -0,0 +1,83 @@
+package loginrisk
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// instrumented counts the assessments of a LoginRiskEvaluator
+type instrumented struct {
+	evaluator   ports.LoginRiskEvaluator
+	evaluations *prometheus.CounterVec
+	signals     *prometheus.CounterVec
+}
+
+// Instrument returns evaluator counting its assessments in the
+// user_login_risk_evaluations_total counter of reg, by result: allow,
+// notify, step_up or error, and the signals they raised in
+// user_login_risk_signals_total. Registering twice with the same registry
+// reuses the counters registered first.
+func Instrument(evaluator ports.LoginRiskEvaluator, reg prometheus.Registerer) (ports.LoginRiskEvaluator, error) {
+	evaluations, err := register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_login_risk_evaluations_total",
+		Help: "Login risk evaluations by result: allow, notify, step_up or error.",
+	}, []string{"result"}))
+	if err != nil {
+		return nil, err
+	}
+
+	signals, err := register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_login_risk_signals_total",
+		Help: "Signals raised by login risk evaluations.",
+	}, []string{"signal"}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &instrumented{evaluator: evaluator, evaluations: evaluations, signals: signals}, nil
+}
+
+// Evaluate assesses the attempt and records the result
+func (e *instrumented) Evaluate(ctx context.Context, attempt domain.LoginAttempt) (domain.RiskAssessment, error) {
+	risk, err := e.evaluator.Evaluate(ctx, attempt)
+	if err != nil {
+		e.evaluations.WithLabelValues("error").Inc()
+		return risk, err
+	}
+
+	e.evaluations.WithLabelValues(result(risk)).Inc()
+	for _, signal := range risk.Signals {
+		e.signals.WithLabelValues(string(signal)).Inc()
+	}
+	return risk, nil
+}
+
+// result is the result label of an assessment, the strongest action first
+func result(risk domain.RiskAssessment) string {
+	switch {
+	case risk.StepUp:
+		return "step_up"
+	case risk.Notify:
+		return "notify"
+	default:
+		return "allow"
+	}
+}
+
+// register registers c with reg, or returns the collector already
+// registered in its place
+func register[C prometheus.Collector](reg prometheus.Registerer, c C) (C, error) {
+	err := reg.Register(c)
+	var existing prometheus.AlreadyRegisteredError
+	if errors.As(err, &existing) {
+		if same, ok := existing.ExistingCollector.(C); ok {
+			return same, nil
+		}
+	}
+	return c, err
+}
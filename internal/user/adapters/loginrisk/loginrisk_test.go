@@ -0,0 +1,165 @@
+package loginrisk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+const (
+	firefox = "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0"
+	iphone  = "Mozilla/5.0 (iPhone; CPU iPhone OS 17_5 like Mac OS X) AppleWebKit/605.1.15 Mobile/15E148"
+)
+
+var now = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// login returns a login of the history, ago before now
+func login(succeeded bool, country, userAgent string, ago time.Duration) domain.LoginEvent {
+	return domain.LoginEvent{Succeeded: succeeded, Country: country, UserAgent: userAgent, OccurredAt: now.Add(-ago)}
+}
+
+func TestRules(t *testing.T) {
+	evaluator, err := NewRules(Options{
+		NewCountry: ActionStepUp,
+		NewDevice:  ActionNotify,
+		Velocity:   VelocityOptions{Window: 15 * time.Minute, MaxFailures: 3, Action: ActionStepUp},
+	})
+	require.NoError(t, err)
+
+	history := []domain.LoginEvent{
+		login(false, "FR", firefox, time.Minute),
+		login(true, "FR", firefox, time.Hour),
+		login(true, "", iphone, 48*time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		client  domain.LoginClient
+		history []domain.LoginEvent
+		want    domain.RiskAssessment
+	}{
+		{
+			name:    "known country and device",
+			client:  domain.LoginClient{Country: "fr", UserAgent: firefox},
+			history: history,
+		},
+		{
+			name:    "device updated to a new version",
+			client:  domain.LoginClient{Country: "FR", UserAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:131.0) Gecko/20100101 Firefox/131.0"},
+			history: history,
+		},
+		{
+			name:    "new country",
+			client:  domain.LoginClient{Country: "BR", UserAgent: iphone},
+			history: history,
+			want:    domain.RiskAssessment{Signals: []domain.LoginSignal{domain.SignalNewCountry}, StepUp: true},
+		},
+		{
+			name:    "new device",
+			client:  domain.LoginClient{Country: "FR", UserAgent: "curl/8.5.0"},
+			history: history,
+			want:    domain.RiskAssessment{Signals: []domain.LoginSignal{domain.SignalNewDevice}, Notify: true},
+		},
+		{
+			name:    "unknown country and device",
+			client:  domain.LoginClient{},
+			history: history,
+		},
+		{
+			name:   "first login",
+			client: domain.LoginClient{Country: "BR", UserAgent: "curl/8.5.0"},
+			history: []domain.LoginEvent{
+				login(false, "FR", firefox, time.Minute),
+			},
+		},
+		{
+			name:   "many recent failures",
+			client: domain.LoginClient{Country: "FR", UserAgent: firefox},
+			history: []domain.LoginEvent{
+				login(false, "FR", firefox, time.Minute),
+				login(false, "FR", firefox, 2*time.Minute),
+				login(false, "FR", firefox, 3*time.Minute),
+				login(true, "FR", firefox, time.Hour),
+			},
+			want: domain.RiskAssessment{Signals: []domain.LoginSignal{domain.SignalVelocity}, StepUp: true},
+		},
+		{
+			name:   "failures outside the window",
+			client: domain.LoginClient{Country: "FR", UserAgent: firefox},
+			history: []domain.LoginEvent{
+				login(false, "FR", firefox, time.Minute),
+				login(false, "FR", firefox, 2*time.Minute),
+				login(false, "FR", firefox, 20*time.Minute),
+				login(true, "FR", firefox, time.Hour),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risk, err := evaluator.Evaluate(context.Background(), domain.LoginAttempt{Client: tt.client, At: now, History: tt.history})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, risk)
+		})
+	}
+}
+
+func TestRules_DisabledRules(t *testing.T) {
+	evaluator, err := NewRules(Options{NewCountry: ActionNone, Velocity: VelocityOptions{Window: time.Hour, Action: ActionStepUp}})
+	require.NoError(t, err)
+
+	risk, err := evaluator.Evaluate(context.Background(), domain.LoginAttempt{
+		Client:  domain.LoginClient{Country: "BR", UserAgent: "curl/8.5.0"},
+		At:      now,
+		History: []domain.LoginEvent{login(false, "FR", firefox, time.Minute), login(true, "FR", firefox, time.Hour)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.RiskAssessment{}, risk)
+}
+
+func TestNewRules_InvalidOptions(t *testing.T) {
+	_, err := NewRules(Options{NewDevice: "block"})
+	assert.ErrorContains(t, err, `unknown login risk action: "block"`)
+
+	_, err = NewRules(Options{Velocity: VelocityOptions{MaxFailures: -1}})
+	assert.Error(t, err)
+}
+
+func TestInstrument(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+
+	inner := mocks.NewMockLoginRiskEvaluator(t)
+	inner.On("Evaluate", mock.Anything, mock.Anything).Return(domain.RiskAssessment{}, nil).Once()
+	inner.On("Evaluate", mock.Anything, mock.Anything).Return(domain.RiskAssessment{
+		Signals: []domain.LoginSignal{domain.SignalNewCountry, domain.SignalNewDevice},
+		Notify:  true,
+		StepUp:  true,
+	}, nil).Once()
+	inner.On("Evaluate", mock.Anything, mock.Anything).Return(domain.RiskAssessment{}, assert.AnError).Once()
+
+	evaluator, err := Instrument(inner, reg)
+	require.NoError(t, err)
+	for range 3 {
+		_, _ = evaluator.Evaluate(ctx, domain.LoginAttempt{})
+	}
+
+	_, err = Instrument(inner, reg)
+	require.NoError(t, err, "registering twice reuses the counters")
+
+	e := evaluator.(*instrumented)
+	assert.Equal(t, 1.0, testutil.ToFloat64(e.evaluations.WithLabelValues("allow")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(e.evaluations.WithLabelValues("step_up")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(e.evaluations.WithLabelValues("notify")), "a login is counted under its strongest action")
+	assert.Equal(t, 1.0, testutil.ToFloat64(e.evaluations.WithLabelValues("error")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(e.signals.WithLabelValues("new_country")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(e.signals.WithLabelValues("new_device")))
+}
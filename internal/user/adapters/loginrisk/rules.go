@@ -0,0 +1,188 @@
+// Package loginrisk implements ports.LoginRiskEvaluator with rules on the
+// user's login history, and counts the assessments of any evaluator in
+// Prometheus.
+//
+// Each rule raises a signal and takes an action when it matches:
+//
+//   - new_country: the login comes from a country none of the user's
+//     successful logins in the history came from
+//   - new_device: the login comes from a user agent none of the user's
+//     successful logins in the history came from, ignoring versions so
+//     browser and system updates are not new devices
+//   - velocity: at least MaxFailures failed attempts precede the login
+//     within Window
+//
+// Users without successful logins in their history have nothing to compare
+// with, so their first login raises no country or device signal.
+package loginrisk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// Action is what a matching rule asks for
+type Action string
+
+// Actions of the rules
+const (
+	ActionNone   Action = "none"
+	ActionNotify Action = "notify"
+	ActionStepUp Action = "step_up"
+)
+
+// VelocityOptions configures the velocity rule
+type VelocityOptions struct {
+	// Window is how far back failed attempts are counted
+	Window time.Duration
+
+	// MaxFailures is the number of failed attempts within Window that
+	// makes a login suspicious. Zero disables the rule.
+	MaxFailures int
+
+	// Action is taken when the rule matches
+	Action Action
+}
+
+// Options configures the rules. The zero Action of a rule disables it.
+type Options struct {
+	NewCountry Action
+	NewDevice  Action
+	Velocity   VelocityOptions
+}
+
+// rules implements ports.LoginRiskEvaluator with Options
+type rules struct {
+	opts Options
+}
+
+// NewRules returns an evaluator applying the rules of opts. Unknown
+// actions are rejected so a misconfiguration fails at startup.
+func NewRules(opts Options) (ports.LoginRiskEvaluator, error) {
+	for _, action := range []Action{opts.NewCountry, opts.NewDevice, opts.Velocity.Action} {
+		switch action {
+		case "", ActionNone, ActionNotify, ActionStepUp:
+		default:
+			return nil, fmt.Errorf("unknown login risk action: %q", action)
+		}
+	}
+	if opts.Velocity.MaxFailures < 0 || opts.Velocity.Window < 0 {
+		return nil, fmt.Errorf("login risk velocity window and max failures must not be negative")
+	}
+
+	return &rules{opts: opts}, nil
+}
+
+// Evaluate applies the rules to the attempt
+func (r *rules) Evaluate(_ context.Context, attempt domain.LoginAttempt) (domain.RiskAssessment, error) {
+	var risk domain.RiskAssessment
+
+	if r.newCountry(attempt) {
+		raise(&risk, domain.SignalNewCountry, r.opts.NewCountry)
+	}
+	if r.newDevice(attempt) {
+		raise(&risk, domain.SignalNewDevice, r.opts.NewDevice)
+	}
+	if r.tooManyFailures(attempt) {
+		raise(&risk, domain.SignalVelocity, r.opts.Velocity.Action)
+	}
+
+	return risk, nil
+}
+
+// newCountry reports whether the attempt comes from a country unseen in
+// the successful logins of the history
+func (r *rules) newCountry(attempt domain.LoginAttempt) bool {
+	country := domain.NormalizeCountry(attempt.Client.Country)
+	if !enabled(r.opts.NewCountry) || country == "" {
+		return false
+	}
+
+	return unseen(attempt.History, country, func(login domain.LoginEvent) string {
+		return login.Country
+	})
+}
+
+// newDevice reports whether the attempt comes from a device unseen in
+// the successful logins of the history
+func (r *rules) newDevice(attempt domain.LoginAttempt) bool {
+	device := deviceOf(attempt.Client.UserAgent)
+	if !enabled(r.opts.NewDevice) || device == "" {
+		return false
+	}
+
+	return unseen(attempt.History, device, func(login domain.LoginEvent) string {
+		return deviceOf(login.UserAgent)
+	})
+}
+
+// tooManyFailures reports whether the history holds at least MaxFailures
+// failed attempts within Window of the attempt
+func (r *rules) tooManyFailures(attempt domain.LoginAttempt) bool {
+	velocity := r.opts.Velocity
+	if !enabled(velocity.Action) || velocity.MaxFailures == 0 {
+		return false
+	}
+
+	failures := 0
+	for _, login := range attempt.History {
+		if attempt.At.Sub(login.OccurredAt) > velocity.Window {
+			break // the history is newest first
+		}
+		if !login.Succeeded {
+			failures++
+		}
+	}
+	return failures >= velocity.MaxFailures
+}
+
+// unseen reports whether the successful logins of history have known
+// values, none of them value
+func unseen(history []domain.LoginEvent, value string, of func(domain.LoginEvent) string) bool {
+	known := false
+	for _, login := range history {
+		if !login.Succeeded {
+			continue
+		}
+		switch of(login) {
+		case "":
+		case value:
+			return false
+		default:
+			known = true
+		}
+	}
+	return known
+}
+
+// deviceOf returns the user agent without version numbers, so updates of
+// a browser or system keep the device the same
+func deviceOf(userAgent string) string {
+	return strings.Map(func(r rune) rune {
+		if '0' <= r && r <= '9' || r == '.' || r == '_' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(userAgent))
+}
+
+// enabled reports whether a rule with action is applied
+func enabled(action Action) bool {
+	return action != "" && action != ActionNone
+}
+
+// raise adds signal to risk with the action it asks for
+func raise(risk *domain.RiskAssessment, signal domain.LoginSignal, action Action) {
+	risk.Signals = append(risk.Signals, signal)
+	switch action {
+	case ActionNotify:
+		risk.Notify = true
+	case ActionStepUp:
+		risk.StepUp = true
+	}
+}
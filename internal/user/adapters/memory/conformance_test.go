@@ -0,0 +1,15 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/repotest"
+)
+
+func TestRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) ports.UserRepository {
+		return NewUserRepository(idgen.NewSequential())
+	})
+}
@@ -0,0 +1,550 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// userRepository implements ports.UserRepository in process memory. It
+// keeps the semantics of the PostgreSQL repository, including unique
+// emails and usernames across soft-deleted users, so the API can run
+// without a database. Data is lost when the process exits.
+type userRepository struct {
+	mu          sync.RWMutex
+	users       map[string]*domain.User
+	preferences map[string]domain.Preferences
+	passwords   map[string]domain.Password
+	events      map[string][]domain.Event
+	logins      map[string][]domain.LoginEvent
+	erasures    []domain.ErasureRecord
+
+	// ids names the events the repository records itself
+	ids ports.IDGenerator
+}
+
+// NewUserRepository creates a new in-memory user repository
+func NewUserRepository(ids ports.IDGenerator) ports.UserRepository {
+	return &userRepository{
+		ids:         ids,
+		users:       make(map[string]*domain.User),
+		preferences: make(map[string]domain.Preferences),
+		passwords:   make(map[string]domain.Password),
+		events:      make(map[string][]domain.Event),
+		logins:      make(map[string][]domain.LoginEvent),
+	}
+}
+
+// Create creates a new user
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.ID]; ok {
+		return fmt.Errorf("user %s already exists", user.ID)
+	}
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+
+	r.users[user.ID] = clone(user)
+	r.addEvents(user.Events())
+	user.ClearEvents()
+	return nil
+}
+
+// CreateBatch creates multiple users; either all of them are created or none
+func (r *userRepository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Like PostgreSQL, a user conflicts with stored users and with the
+	// earlier users of the batch that do not conflict themselves
+	var conflicts []domain.BatchConflict
+	emails := make(map[string]bool, len(users))
+	usernames := make(map[string]bool, len(users))
+	for i, user := range users {
+		if _, ok := r.users[user.ID]; ok {
+			return fmt.Errorf("user %s already exists", user.ID)
+		}
+
+		email := strings.ToLower(user.Email)
+		err := r.checkUnique(user)
+		switch {
+		case err != nil:
+		case emails[email]:
+			err = domain.ErrDuplicateEmail
+		case user.Username != "" && usernames[user.Username]:
+			err = domain.ErrDuplicateUsername
+		}
+		if err != nil {
+			conflicts = append(conflicts, domain.BatchConflict{Index: i, Err: err})
+			continue
+		}
+
+		emails[email] = true
+		if user.Username != "" {
+			usernames[user.Username] = true
+		}
+	}
+	if len(conflicts) > 0 {
+		return &domain.BatchConflictError{Conflicts: conflicts}
+	}
+
+	for _, user := range users {
+		r.users[user.ID] = clone(user)
+		r.addEvents(user.Events())
+		user.ClearEvents()
+	}
+	return nil
+}
+
+// FindExistingEmails returns which of the given emails are already taken
+func (r *userRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		wanted[strings.ToLower(email)] = true
+	}
+
+	// Soft-deleted users still hold their email
+	existing := []string{}
+	for _, user := range r.users {
+		if wanted[strings.ToLower(user.Email)] {
+			existing = append(existing, user.Email)
+		}
+	}
+	return existing, nil
+}
+
+// GetByID retrieves a user by ID
+func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.ID == id && u.DeletedAt == nil })
+}
+
+// GetByIDIncludingDeleted retrieves a user by ID, including soft-deleted users
+func (r *userRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.ID == id })
+}
+
+// GetByUsername retrieves a user by username
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.Username == username && u.DeletedAt == nil })
+}
+
+// GetByEmail retrieves a user by email
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return strings.EqualFold(u.Email, email) && u.DeletedAt == nil })
+}
+
+// Exists reports whether the user of key exists
+func (r *userRepository) Exists(ctx context.Context, key domain.UserKey) (bool, error) {
+	_, err := r.find(func(u *domain.User) bool {
+		switch {
+		case u.DeletedAt != nil && !key.IncludeDeleted:
+			return false
+		case key.ID != "":
+			return u.ID == key.ID
+		case key.Username != "":
+			return u.Username == key.Username
+		default:
+			return strings.EqualFold(u.Email, key.Email)
+		}
+	})
+	return err == nil, nil
+}
+
+// Update updates an existing user
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.users[user.ID]
+	if !ok || stored.DeletedAt != nil {
+		return domain.ErrUserNotFound
+	}
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+
+	updated := clone(user)
+	updated.CreatedAt = stored.CreatedAt
+	updated.DeletedAt = nil
+	// Logins set the last login time on their own, so a user loaded
+	// before one does not reset it
+	updated.LastLoginAt = stored.LastLoginAt
+	r.users[user.ID] = updated
+	r.addEvents(user.Events())
+	user.ClearEvents()
+	return nil
+}
+
+// Delete soft-deletes a user by ID
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt != nil {
+		return domain.ErrUserNotFound
+	}
+
+	now := utc.Now()
+	user.DeletedAt = &now
+	r.addEvents([]domain.Event{domain.NewEvent(r.ids.NewID(), id, domain.EventUserDeleted, nil, now)})
+	return nil
+}
+
+// Erase permanently deletes a user with their preferences and activity,
+// and stores the erasure record
+func (r *userRepository) Erase(ctx context.Context, record *domain.ErasureRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[record.UserID]; !ok {
+		return domain.ErrUserNotFound
+	}
+
+	r.remove(record.UserID)
+	r.erasures = append(r.erasures, *record)
+	return nil
+}
+
+// PurgeDeleted permanently deletes up to limit users soft-deleted before
+// the cutoff, oldest deletions first
+func (r *userRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	expired := r.deletedBefore(before)
+	slices.SortFunc(expired, func(a, b *domain.User) int {
+		return a.DeletedAt.Compare(*b.DeletedAt)
+	})
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+
+	for _, user := range expired {
+		r.remove(user.ID)
+	}
+	return len(expired), nil
+}
+
+// CountDeleted counts users soft-deleted before the cutoff
+func (r *userRepository) CountDeleted(ctx context.Context, before time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.deletedBefore(before)), nil
+}
+
+// Restore undoes the soft delete of a user by ID
+func (r *userRepository) Restore(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt == nil {
+		return domain.ErrUserNotFound
+	}
+
+	user.DeletedAt = nil
+	user.UpdatedAt = utc.Now()
+	r.addEvents([]domain.Event{domain.NewEvent(r.ids.NewID(), id, domain.EventUserRestored, nil, user.UpdatedAt)})
+	return nil
+}
+
+// GetPreferences retrieves the preferences saved by a user
+func (r *userRepository) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prefs, ok := r.preferences[userID]
+	if !ok {
+		return nil, domain.ErrPreferencesNotFound
+	}
+	return &prefs, nil
+}
+
+// SavePreferences creates or replaces a user's preferences
+func (r *userRepository) SavePreferences(ctx context.Context, userID string, prefs *domain.Preferences) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.preferences[userID] = *prefs
+	return nil
+}
+
+// GetPassword retrieves a user's stored password
+func (r *userRepository) GetPassword(ctx context.Context, userID string) (*domain.Password, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	password, ok := r.passwords[userID]
+	if !ok {
+		return nil, domain.ErrPasswordNotSet
+	}
+	return &password, nil
+}
+
+// SavePassword creates or replaces a user's stored password
+func (r *userRepository) SavePassword(ctx context.Context, userID string, password *domain.Password) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.passwords[userID] = *password
+	return nil
+}
+
+// ListEvents retrieves up to limit events matching the filter, newest
+// first, starting after the cursor when one is given
+func (r *userRepository) ListEvents(ctx context.Context, filter domain.EventFilter, cursor *domain.EventCursor, limit int) ([]domain.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var events []domain.Event
+	for userID, userEvents := range r.events {
+		if filter.UserID != "" && userID != filter.UserID {
+			continue
+		}
+		for _, event := range userEvents {
+			if filter.Type == "" || event.Type == filter.Type {
+				events = append(events, event)
+			}
+		}
+	}
+	slices.SortFunc(events, func(a, b domain.Event) int {
+		return cmp.Or(b.OccurredAt.Compare(a.OccurredAt), strings.Compare(b.ID, a.ID))
+	})
+
+	page := []domain.Event{}
+	for _, event := range events {
+		if len(page) == limit {
+			break
+		}
+		if cursor != nil && !olderThan(event.OccurredAt, event.ID, *cursor) {
+			continue
+		}
+		page = append(page, event)
+	}
+	return page, nil
+}
+
+// RecordLogin stores a login attempt and sets the user's last login time
+// when it succeeded
+func (r *userRepository) RecordLogin(ctx context.Context, login domain.LoginEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[login.UserID]
+	if !ok {
+		return domain.ErrUserNotFound
+	}
+
+	if login.Succeeded {
+		occurredAt := login.OccurredAt
+		user.LastLoginAt = &occurredAt
+	}
+	r.logins[login.UserID] = append(r.logins[login.UserID], login)
+	return nil
+}
+
+// ListLogins retrieves up to limit login attempts of the user, newest
+// first, starting after the cursor when one is given
+func (r *userRepository) ListLogins(ctx context.Context, userID string, cursor *domain.EventCursor, limit int) ([]domain.LoginEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logins := slices.Clone(r.logins[userID])
+	slices.SortFunc(logins, func(a, b domain.LoginEvent) int {
+		return cmp.Or(b.OccurredAt.Compare(a.OccurredAt), strings.Compare(b.ID, a.ID))
+	})
+
+	page := []domain.LoginEvent{}
+	for _, login := range logins {
+		if len(page) == limit {
+			break
+		}
+		if cursor != nil && !olderThan(login.OccurredAt, login.ID, *cursor) {
+			continue
+		}
+		page = append(page, login)
+	}
+	return page, nil
+}
+
+// List retrieves users matching the filter with pagination, newest first
+func (r *userRepository) List(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	users := r.filter(filter)
+	if offset >= len(users) {
+		return []*domain.User{}, nil
+	}
+	users = users[offset:]
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// ListStream calls fn for every user matching the filter, newest first
+func (r *userRepository) ListStream(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	// Take a snapshot so fn can call back into the repository
+	r.mu.RLock()
+	users := r.filter(filter)
+	r.mu.RUnlock()
+
+	for _, user := range users {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUnique rejects a user whose email or username is held by another
+// user, including soft-deleted ones. Callers must hold the lock.
+func (r *userRepository) checkUnique(user *domain.User) error {
+	for _, other := range r.users {
+		if other.ID == user.ID {
+			continue
+		}
+		if strings.EqualFold(other.Email, user.Email) {
+			return domain.ErrDuplicateEmail
+		}
+		if user.Username != "" && other.Username == user.Username {
+			return domain.ErrDuplicateUsername
+		}
+	}
+	return nil
+}
+
+// find returns a copy of the first user matching the predicate
+func (r *userRepository) find(match func(*domain.User) bool) (*domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if match(user) {
+			return clone(user), nil
+		}
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+// filter returns copies of the users matching the filter, newest first.
+// Callers must hold the lock.
+func (r *userRepository) filter(filter domain.UserFilter) []*domain.User {
+	users := []*domain.User{}
+	for _, user := range r.users {
+		if matches(user, filter) {
+			users = append(users, clone(user))
+		}
+	}
+
+	slices.SortFunc(users, func(a, b *domain.User) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), strings.Compare(b.ID, a.ID))
+	})
+	return users
+}
+
+// deletedBefore returns the users soft-deleted before the cutoff. Callers
+// must hold the lock.
+func (r *userRepository) deletedBefore(before time.Time) []*domain.User {
+	var users []*domain.User
+	for _, user := range r.users {
+		if user.DeletedAt != nil && user.DeletedAt.Before(before) {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// remove deletes a user and the data that references them, like the
+// ON DELETE CASCADE foreign keys in PostgreSQL. Callers must hold the lock.
+func (r *userRepository) remove(id string) {
+	delete(r.users, id)
+	delete(r.preferences, id)
+	delete(r.passwords, id)
+	delete(r.events, id)
+	delete(r.logins, id)
+}
+
+// addEvents stores events in the activity feed. Callers must hold the lock.
+func (r *userRepository) addEvents(events []domain.Event) {
+	for _, event := range events {
+		r.events[event.UserID] = append(r.events[event.UserID], event)
+	}
+}
+
+// olderThan reports whether the event or login occurring at occurredAt
+// with id comes after the cursor in the newest-first feed order
+func olderThan(occurredAt time.Time, id string, cursor domain.EventCursor) bool {
+	if !occurredAt.Equal(cursor.OccurredAt) {
+		return occurredAt.Before(cursor.OccurredAt)
+	}
+	return id < cursor.ID
+}
+
+// matches reports whether a user satisfies the filter
+func matches(user *domain.User, filter domain.UserFilter) bool {
+	if user.DeletedAt != nil && !filter.IncludeDeleted {
+		return false
+	}
+	if filter.Status != "" && user.Status != filter.Status {
+		return false
+	}
+	if filter.EmailContains != "" && !containsFold(user.Email, filter.EmailContains) {
+		return false
+	}
+	if filter.NameContains != "" && !containsFold(user.Name, filter.NameContains) {
+		return false
+	}
+	if filter.CreatedAfter != nil && user.CreatedAt.Before(*filter.CreatedAfter) {
+		return false
+	}
+	if filter.CreatedBefore != nil && !user.CreatedAt.Before(*filter.CreatedBefore) {
+		return false
+	}
+	return true
+}
+
+// containsFold reports whether value contains substr, ignoring case
+func containsFold(value, substr string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(substr))
+}
+
+// clone copies a user so callers cannot change stored state through it
+func clone(user *domain.User) *domain.User {
+	c := *user
+	c.ClearEvents()
+
+	if user.PendingEmailChange != nil {
+		pending := *user.PendingEmailChange
+		c.PendingEmailChange = &pending
+	}
+	if user.DeletedAt != nil {
+		deletedAt := *user.DeletedAt
+		c.DeletedAt = &deletedAt
+	}
+	if user.LastLoginAt != nil {
+		lastLoginAt := *user.LastLoginAt
+		c.LastLoginAt = &lastLoginAt
+	}
+	return &c
+}
@@ -0,0 +1,269 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// newUser creates a valid user created at the given offset from now
+func newUser(t *testing.T, email string, age time.Duration) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser(uuid.NewString(), email, "Test User", time.Now())
+	require.NoError(t, err)
+	user.CreatedAt = user.CreatedAt.Add(-age)
+	return user
+}
+
+func TestRepository_Create(t *testing.T) {
+	repo := NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	user := newUser(t, "test@example.com", 0)
+	require.NoError(t, repo.Create(ctx, user))
+	assert.Empty(t, user.Events(), "events are cleared once stored")
+
+	t.Run("returns ErrDuplicateEmail ignoring case", func(t *testing.T) {
+		err := repo.Create(ctx, newUser(t, "TEST@example.com", 0))
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+	})
+
+	t.Run("returns ErrDuplicateUsername", func(t *testing.T) {
+		first := newUser(t, "first@example.com", 0)
+		require.NoError(t, first.ChangeUsername("taken", time.Now()))
+		require.NoError(t, repo.Create(ctx, first))
+
+		second := newUser(t, "second@example.com", 0)
+		require.NoError(t, second.ChangeUsername("taken", time.Now()))
+		assert.ErrorIs(t, repo.Create(ctx, second), domain.ErrDuplicateUsername)
+	})
+
+	t.Run("stored user is not changed through the caller's copy", func(t *testing.T) {
+		user.Name = "Changed"
+
+		stored, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Test User", stored.Name)
+	})
+}
+
+func TestRepository_CreateBatch(t *testing.T) {
+	repo := NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	t.Run("rolls back the whole batch on duplicate email", func(t *testing.T) {
+		users := []*domain.User{newUser(t, "a@example.com", 0), newUser(t, "A@example.com", 0)}
+
+		assert.ErrorIs(t, repo.CreateBatch(ctx, users), domain.ErrDuplicateEmail)
+
+		_, err := repo.GetByEmail(ctx, "a@example.com")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("creates all users", func(t *testing.T) {
+		users := []*domain.User{newUser(t, "a@example.com", 0), newUser(t, "b@example.com", 0)}
+		require.NoError(t, repo.CreateBatch(ctx, users))
+
+		existing, err := repo.FindExistingEmails(ctx, []string{"A@example.com", "c@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@example.com"}, existing)
+	})
+}
+
+func TestRepository_Get(t *testing.T) {
+	repo := NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	user := newUser(t, "test@example.com", 0)
+	require.NoError(t, user.ChangeUsername("tester", time.Now()))
+	require.NoError(t, repo.Create(ctx, user))
+
+	byEmail, err := repo.GetByEmail(ctx, "Test@Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, byEmail.ID)
+
+	byUsername, err := repo.GetByUsername(ctx, "tester")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, byUsername.ID)
+
+	_, err = repo.GetByID(ctx, "missing")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestRepository_Update(t *testing.T) {
+	repo := NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	user := newUser(t, "test@example.com", 0)
+	other := newUser(t, "other@example.com", 0)
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.User{user, other}))
+
+	require.NoError(t, user.UpdateName("Updated", time.Now()))
+	require.NoError(t, repo.Update(ctx, user))
+
+	stored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", stored.Name)
+
+	user.Email = "OTHER@example.com"
+	assert.ErrorIs(t, repo.Update(ctx, user), domain.ErrDuplicateEmail)
+
+	missing := newUser(t, "missing@example.com", 0)
+	assert.ErrorIs(t, repo.Update(ctx, missing), domain.ErrUserNotFound)
+}
+
+func TestRepository_SoftDelete(t *testing.T) {
+	repo := NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	user := newUser(t, "test@example.com", 0)
+	require.NoError(t, repo.Create(ctx, user))
+	require.NoError(t, repo.Delete(ctx, user.ID))
+
+	_, err := repo.GetByID(ctx, user.ID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, user.ID), domain.ErrUserNotFound)
+	assert.ErrorIs(t, repo.Update(ctx, user), domain.ErrUserNotFound)
+
+	deleted, err := repo.GetByIDIncludingDeleted(ctx, user.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, deleted.DeletedAt)
+
+	// Deleted users keep their email
+	assert.ErrorIs(t, repo.Create(ctx, newUser(t, "test@example.com", 0)), domain.ErrDuplicateEmail)
+
+	count, err := repo.CountDeleted(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, repo.Restore(ctx, user.ID))
+	assert.ErrorIs(t, repo.Restore(ctx, user.ID), domain.ErrUserNotFound)
+
+	restored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+}
+
+func TestRepository_PurgeAndErase(t *testing.T) {
+	repo := NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	users := []*domain.User{
+		newUser(t, "a@example.com", 0),
+		newUser(t, "b@example.com", 0),
+		newUser(t, "c@example.com", 0),
+	}
+	require.NoError(t, repo.CreateBatch(ctx, users))
+	require.NoError(t, repo.Delete(ctx, users[0].ID))
+	require.NoError(t, repo.Delete(ctx, users[1].ID))
+
+	purged, err := repo.PurgeDeleted(ctx, time.Now().Add(time.Minute), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+
+	// The oldest deletion goes first
+	_, err = repo.GetByIDIncludingDeleted(ctx, users[0].ID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	require.NoError(t, repo.SavePreferences(ctx, users[2].ID, &domain.Preferences{Locale: "en"}))
+	require.NoError(t, repo.Erase(ctx, domain.NewErasureRecord(uuid.NewString(), users[2].ID, time.Now())))
+
+	_, err = repo.GetPreferences(ctx, users[2].ID)
+	assert.ErrorIs(t, err, domain.ErrPreferencesNotFound)
+	events, err := repo.ListEvents(ctx, domain.EventFilter{UserID: users[2].ID}, nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	assert.ErrorIs(t, repo.Erase(ctx, domain.NewErasureRecord(uuid.NewString(), users[2].ID, time.Now())), domain.ErrUserNotFound)
+}
+
+func TestRepository_List(t *testing.T) {
+	repo := NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	oldest := newUser(t, "oldest@example.com", 3*time.Hour)
+	middle := newUser(t, "middle@example.com", 2*time.Hour)
+	newest := newUser(t, "newest@example.com", time.Hour)
+	require.NoError(t, repo.CreateBatch(ctx, []*domain.User{middle, oldest, newest}))
+	require.NoError(t, repo.Delete(ctx, middle.ID))
+
+	ids := func(users []*domain.User) []string {
+		var ids []string
+		for _, u := range users {
+			ids = append(ids, u.ID)
+		}
+		return ids
+	}
+
+	t.Run("returns active users newest first", func(t *testing.T) {
+		users, err := repo.List(ctx, domain.UserFilter{}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{newest.ID, oldest.ID}, ids(users))
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		users, err := repo.List(ctx, domain.UserFilter{IncludeDeleted: true}, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{middle.ID}, ids(users))
+
+		users, err = repo.List(ctx, domain.UserFilter{}, 10, 5)
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		after := time.Now().Add(-150 * time.Minute)
+		users, err := repo.List(ctx, domain.UserFilter{EmailContains: "EST", CreatedAfter: &after}, 10, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []string{newest.ID}, ids(users))
+	})
+
+	t.Run("streams until the callback fails", func(t *testing.T) {
+		stop := errors.New("stop")
+		var streamed []string
+
+		err := repo.ListStream(ctx, domain.UserFilter{}, func(u *domain.User) error {
+			streamed = append(streamed, u.ID)
+			return stop
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, []string{newest.ID}, streamed)
+	})
+}
+
+func TestRepository_Events(t *testing.T) {
+	repo := NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	user := newUser(t, "test@example.com", 0)
+	require.NoError(t, repo.Create(ctx, user))
+	require.NoError(t, user.UpdateName("Renamed", time.Now()))
+	require.NoError(t, repo.Update(ctx, user))
+	require.NoError(t, repo.Delete(ctx, user.ID))
+
+	events, err := repo.ListEvents(ctx, domain.EventFilter{UserID: user.ID}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	assert.ElementsMatch(t, []domain.EventType{
+		domain.EventUserCreated,
+		domain.EventNameChanged,
+		domain.EventUserDeleted,
+	}, []domain.EventType{events[0].Type, events[1].Type, events[2].Type})
+
+	// Pages continue after the cursor without gaps or repeats
+	first, err := repo.ListEvents(ctx, domain.EventFilter{UserID: user.ID}, nil, 2)
+	require.NoError(t, err)
+	cursor := domain.CursorAfter(first[1])
+	second, err := repo.ListEvents(ctx, domain.EventFilter{UserID: user.ID}, &cursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, events, append(first, second...))
+}
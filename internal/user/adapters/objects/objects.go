@@ -0,0 +1,48 @@
+// Package objects adapts the object storage of the infrastructure to the
+// user module's ObjectStorage port
+package objects
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/storage"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// Storage implements ports.ObjectStorage with a storage.ObjectStorage,
+// reporting missing objects as domain.ErrObjectNotFound
+type Storage struct {
+	objects storage.ObjectStorage
+}
+
+// NewStorage creates a new Storage storing in objects
+func NewStorage(objects storage.ObjectStorage) *Storage {
+	return &Storage{objects: objects}
+}
+
+// Put stores the object
+func (s *Storage) Put(ctx context.Context, key string, r io.Reader, contentType string) error {
+	return s.objects.Put(ctx, key, r, contentType)
+}
+
+// Get opens the object
+func (s *Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.objects.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, domain.ErrObjectNotFound
+	}
+	return object, err
+}
+
+// SignURL returns a signed URL of the object
+func (s *Storage) SignURL(ctx context.Context, key, method string, ttl time.Duration) (string, error) {
+	return s.objects.SignURL(ctx, key, method, ttl)
+}
+
+// Delete removes the object
+func (s *Storage) Delete(ctx context.Context, key string) error {
+	return s.objects.Delete(ctx, key)
+}
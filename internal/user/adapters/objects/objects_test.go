@@ -0,0 +1,37 @@
+package objects
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/storage"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// Storage must satisfy the port
+var _ ports.ObjectStorage = (*Storage)(nil)
+
+func TestStorage_Get(t *testing.T) {
+	local, err := storage.NewLocalObjectStorage(config.LocalObjectStorageConfig{Dir: t.TempDir(), BaseURL: "/objects"})
+	require.NoError(t, err)
+	s := NewStorage(local)
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "exports/users.csv", strings.NewReader("id,email"), "text/csv"))
+	object, err := s.Get(ctx, "exports/users.csv")
+	require.NoError(t, err)
+	defer object.Close()
+	data, err := io.ReadAll(object)
+	require.NoError(t, err)
+	assert.Equal(t, "id,email", string(data))
+
+	_, err = s.Get(ctx, "exports/missing.csv")
+	assert.ErrorIs(t, err, domain.ErrObjectNotFound)
+}
@@ -0,0 +1,36 @@
+//go:build integration
+
+package postgres
+
+import (
+	"os"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/repotest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// usersTemplate holds the schema of the user repository
+var usersTemplate = pgtest.Template{
+	Name: "users",
+	Migrate: func(db *gorm.DB) error {
+		return db.AutoMigrate(&UserModel{}, &ErasureModel{}, &PreferencesModel{}, &PasswordModel{}, &EventModel{}, &LoginModel{})
+	},
+}
+
+// TestRepository_ConformancePostgres runs the repository contract against
+// a real PostgreSQL, where unique indexes and case handling can differ
+// from SQLite. Every test case gets an empty database of its own.
+func TestRepository_ConformancePostgres(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) ports.UserRepository {
+		return NewUserRepository(pgtest.DB(t, usersTemplate), idgen.UUIDv7{})
+	})
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/repotest"
+)
+
+// TestRepository_Conformance runs the repository contract against SQLite.
+// The integration build runs it against PostgreSQL as well.
+func TestRepository_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) ports.UserRepository {
+		db := setupTestDB(t)
+
+		// Each connection to :memory: is a separate database
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.SetMaxOpenConns(1)
+
+		return NewUserRepository(db, idgen.UUIDv7{})
+	})
+}
+
+// TestRepository_ConformanceEncrypted runs the repository contract with
+// field encryption enabled
+func TestRepository_ConformanceEncrypted(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) ports.UserRepository {
+		db := setupTestDB(t)
+
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.SetMaxOpenConns(1)
+
+		return NewEncryptedUserRepository(db, testKeyring(t, "k1"), idgen.UUIDv7{})
+	})
+}
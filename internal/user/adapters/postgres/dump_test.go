@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+func TestDumpUsers(t *testing.T) {
+	db := setupTestDB(t)
+	keys := testKeyring(t, "k1")
+	repo := NewEncryptedUserRepository(db, keys, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	jane := createTestUser(t, repo, "jane@example.com", "Jane Doe")
+	john := createTestUser(t, repo, "john@example.com", "John Doe")
+	require.NoError(t, repo.Delete(ctx, john.ID))
+
+	var records []UserRecord
+	// A batch size of 1 exercises paging
+	dumped, err := DumpUsers(ctx, db, keys, 1, func(record UserRecord) error {
+		records = append(records, record)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, dumped)
+	require.Len(t, records, 2)
+
+	byID := map[string]UserRecord{records[0].ID: records[0], records[1].ID: records[1]}
+	assert.Equal(t, "jane@example.com", byID[jane.ID].Email)
+	assert.Equal(t, "Jane Doe", byID[jane.ID].Name)
+	assert.Nil(t, byID[jane.ID].DeletedAt)
+	assert.NotNil(t, byID[john.ID].DeletedAt, "deleted users are dumped")
+
+	t.Run("fails without the keys of encrypted values", func(t *testing.T) {
+		_, err := DumpUsers(ctx, db, nil, 10, func(UserRecord) error { return nil })
+		assert.Error(t, err)
+	})
+}
+
+func TestRestoreUsers(t *testing.T) {
+	db := setupTestDB(t)
+	keys := testKeyring(t, "k1")
+	ctx := context.Background()
+
+	username := "jane_doe"
+	deletedAt := time.Now().Add(-time.Hour).UTC()
+	records := []UserRecord{
+		{ID: "9b2f6c1e-5d0a-4c3b-8e7f-1a2b3c4d5e6f", Email: "Jane@Example.com", Name: "Jane Doe", Status: "active", Username: &username, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: "1c4e7a90-2b3d-4f5a-9c8b-7d6e5f4a3b2c", Email: "john@example.com", Name: "John Doe", Status: "active", CreatedAt: time.Now(), UpdatedAt: time.Now(), DeletedAt: &deletedAt},
+	}
+
+	restored, err := RestoreUsers(ctx, db, keys, records)
+	require.NoError(t, err)
+	assert.Equal(t, 2, restored)
+
+	row := storedRow(t, db, records[0].ID)
+	assert.True(t, strings.HasPrefix(row.Email, "enc:v1:k1:"))
+	require.NotNil(t, row.EmailIndex)
+	assert.Equal(t, keys.BlindIndex("jane@example.com"), *row.EmailIndex)
+
+	repo := NewEncryptedUserRepository(db, keys, idgen.UUIDv7{})
+	found, err := repo.GetByEmail(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", found.Name)
+	assert.Equal(t, "jane_doe", found.Username)
+
+	_, err = repo.GetByID(ctx, records[1].ID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound, "deleted users stay deleted")
+
+	t.Run("running again replaces the users", func(t *testing.T) {
+		records[0].Name = "Jane Smith"
+		_, err := RestoreUsers(ctx, db, keys, records)
+		require.NoError(t, err)
+
+		found, err := repo.GetByID(ctx, records[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Smith", found.Name)
+
+		var count int64
+		require.NoError(t, db.Model(&UserModel{}).Unscoped().Count(&count).Error)
+		assert.Equal(t, int64(2), count)
+	})
+}
@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+)
+
+// storedUser holds the encrypted columns of a user as stored. It has no
+// serializer tags, so they are read and written without decryption.
+type storedUser struct {
+	ID           string
+	Email        string
+	Name         string
+	PendingEmail *string
+	EmailIndex   *string
+}
+
+// storedEvent holds the data of an event as stored
+type storedEvent struct {
+	ID   string
+	Data *string
+}
+
+// RotateKeys re-encrypts the emails, names and event data stored with an
+// older key, or in plaintext before encryption was enabled, with the
+// primary key of keys, and fills in missing blind indexes. It runs in
+// batches of batchSize rows and can be run again after an interruption.
+// Rows changed concurrently are skipped, as their new values are written
+// with the primary key already. It returns the number of rows rewritten.
+func RotateKeys(ctx context.Context, db *gorm.DB, keys *fieldcrypt.Keyring, batchSize int) (int, error) {
+	users, err := rotateUsers(ctx, db, keys, batchSize)
+	if err != nil {
+		return users, fmt.Errorf("failed to rotate users: %w", err)
+	}
+
+	events, err := rotateEvents(ctx, db, keys, batchSize)
+	if err != nil {
+		return users + events, fmt.Errorf("failed to rotate user events: %w", err)
+	}
+
+	return users + events, nil
+}
+
+// rotateUsers rewrites the users whose columns are not current
+func rotateUsers(ctx context.Context, db *gorm.DB, keys *fieldcrypt.Keyring, batchSize int) (int, error) {
+	// The statements run without a keyring, so the values below are
+	// written exactly as given
+	conn := database.Conn(ctx, db)
+
+	rotated := 0
+	lastID := ""
+	for {
+		var batch []storedUser
+		query := conn.Model(&UserModel{}).Unscoped().
+			Select("id", "email", "name", "pending_email", "email_index").
+			Order("id").
+			Limit(batchSize)
+		if lastID != "" {
+			query = query.Where("id > ?", lastID)
+		}
+		if err := query.Find(&batch).Error; err != nil {
+			return rotated, err
+		}
+
+		for _, user := range batch {
+			updates, err := rotateUser(keys, user)
+			if err != nil {
+				return rotated, fmt.Errorf("user %s: %w", user.ID, err)
+			}
+			if updates == nil {
+				continue
+			}
+
+			query := conn.Model(&UserModel{}).Unscoped().
+				Where("id = ? AND email = ? AND name = ?", user.ID, user.Email, user.Name)
+			if user.PendingEmail == nil {
+				query = query.Where("pending_email IS NULL")
+			} else {
+				query = query.Where("pending_email = ?", *user.PendingEmail)
+			}
+
+			result := query.UpdateColumns(updates)
+			if result.Error != nil {
+				return rotated, fmt.Errorf("user %s: %w", user.ID, result.Error)
+			}
+			rotated += int(result.RowsAffected)
+		}
+
+		if len(batch) < batchSize {
+			return rotated, nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+// rotateUser returns the columns of user to rewrite, or nil when they are
+// all current
+func rotateUser(keys *fieldcrypt.Keyring, user storedUser) (map[string]interface{}, error) {
+	email, err := keys.Decrypt("email", user.Email)
+	if err != nil {
+		return nil, err
+	}
+	index := keys.BlindIndex(strings.ToLower(email))
+
+	current := keys.IsCurrent(user.Email) && keys.IsCurrent(user.Name) &&
+		(user.PendingEmail == nil || keys.IsCurrent(*user.PendingEmail)) &&
+		user.EmailIndex != nil && *user.EmailIndex == index
+	if current {
+		return nil, nil
+	}
+
+	updates := map[string]interface{}{"email_index": index}
+	columns := map[string]*string{"email": &user.Email, "name": &user.Name, "pending_email": user.PendingEmail}
+	for column, value := range columns {
+		if value == nil {
+			continue
+		}
+		reencrypted, err := reencrypt(keys, column, *value)
+		if err != nil {
+			return nil, err
+		}
+		updates[column] = reencrypted
+	}
+	return updates, nil
+}
+
+// rotateEvents rewrites the event data that is not current
+func rotateEvents(ctx context.Context, db *gorm.DB, keys *fieldcrypt.Keyring, batchSize int) (int, error) {
+	conn := database.Conn(ctx, db)
+
+	rotated := 0
+	lastID := ""
+	for {
+		var batch []storedEvent
+		query := conn.Model(&EventModel{}).
+			Select("id", "data").
+			Order("id").
+			Limit(batchSize)
+		if lastID != "" {
+			query = query.Where("id > ?", lastID)
+		}
+		if err := query.Find(&batch).Error; err != nil {
+			return rotated, err
+		}
+
+		for _, event := range batch {
+			data, err := rotateEventData(keys, event.Data)
+			if err != nil {
+				return rotated, fmt.Errorf("event %s: %w", event.ID, err)
+			}
+			if data == "" {
+				continue
+			}
+
+			result := conn.Model(&EventModel{}).Where("id = ?", event.ID).UpdateColumn("data", data)
+			if result.Error != nil {
+				return rotated, fmt.Errorf("event %s: %w", event.ID, result.Error)
+			}
+			rotated += int(result.RowsAffected)
+		}
+
+		if len(batch) < batchSize {
+			return rotated, nil
+		}
+		lastID = batch[len(batch)-1].ID
+	}
+}
+
+// rotateEventData returns the data to store for an event, or "" when the
+// stored data is current or absent. Encrypted data is a JSON string
+// holding the ciphertext of the JSON object.
+func rotateEventData(keys *fieldcrypt.Keyring, stored *string) (string, error) {
+	if stored == nil || *stored == "null" {
+		return "", nil
+	}
+
+	plaintext := *stored
+	var encrypted string
+	if json.Unmarshal([]byte(plaintext), &encrypted) == nil && fieldcrypt.IsEncrypted(encrypted) {
+		if keys.IsCurrent(encrypted) {
+			return "", nil
+		}
+		decrypted, err := keys.Decrypt("data", encrypted)
+		if err != nil {
+			return "", err
+		}
+		plaintext = decrypted
+	}
+
+	ciphertext, err := keys.Encrypt("data", plaintext)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(ciphertext)
+	return string(data), err
+}
+
+// reencrypt decrypts a value of the column and encrypts it with the
+// primary key
+func reencrypt(keys *fieldcrypt.Keyring, column, value string) (string, error) {
+	plaintext, err := keys.Decrypt(column, value)
+	if err != nil {
+		return "", err
+	}
+	return keys.Encrypt(column, plaintext)
+}
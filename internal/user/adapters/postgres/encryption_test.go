@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// testKeyring returns a keyring of keys derived from ids, the first of
+// which is primary
+func testKeyring(t *testing.T, ids ...string) *fieldcrypt.Keyring {
+	t.Helper()
+
+	entries := make([]string, len(ids))
+	for i, id := range ids {
+		key := sha256.Sum256([]byte(id))
+		entries[i] = id + ":" + base64.StdEncoding.EncodeToString(key[:])
+	}
+	index := sha256.Sum256([]byte("index"))
+
+	keys, err := fieldcrypt.NewKeyring(strings.Join(entries, ","), base64.StdEncoding.EncodeToString(index[:]))
+	require.NoError(t, err)
+	return keys
+}
+
+// storedRow reads the columns of a user as stored
+func storedRow(t *testing.T, db *gorm.DB, id string) storedUser {
+	t.Helper()
+
+	var row storedUser
+	require.NoError(t, db.Model(&UserModel{}).Unscoped().Where("id = ?", id).Take(&row).Error)
+	return row
+}
+
+// createTestUser creates a user through domain.NewUser so it records events
+func createTestUser(t *testing.T, repo interface {
+	Create(context.Context, *domain.User) error
+}, email, name string) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser(uuid.New().String(), email, name, time.Now())
+	require.NoError(t, err)
+	user.AssignEventIDs(uuid.NewString)
+	require.NoError(t, repo.Create(context.Background(), user))
+	return user
+}
+
+func TestEncryptedRepository(t *testing.T) {
+	db := setupTestDB(t)
+	keys := testKeyring(t, "k1")
+	repo := NewEncryptedUserRepository(db, keys, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	user := createTestUser(t, repo, "Jane@Example.com", "Jane Doe")
+
+	t.Run("stores personal data encrypted", func(t *testing.T) {
+		row := storedRow(t, db, user.ID)
+		assert.True(t, strings.HasPrefix(row.Email, "enc:v1:k1:"))
+		assert.True(t, strings.HasPrefix(row.Name, "enc:v1:k1:"))
+		require.NotNil(t, row.EmailIndex)
+		assert.Equal(t, keys.BlindIndex("jane@example.com"), *row.EmailIndex)
+
+		var events []storedEvent
+		require.NoError(t, db.Model(&EventModel{}).Where("user_id = ?", user.ID).Find(&events).Error)
+		require.NotEmpty(t, events)
+		for _, event := range events {
+			require.NotNil(t, event.Data)
+			assert.NotContains(t, *event.Data, "Jane")
+		}
+	})
+
+	t.Run("reads personal data decrypted", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "jane@example.com", found.Email)
+		assert.Equal(t, "Jane Doe", found.Name)
+
+		events, err := repo.ListEvents(ctx, domain.EventFilter{UserID: user.ID}, nil, 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		assert.Equal(t, "Jane Doe", events[len(events)-1].Data["name"])
+	})
+
+	t.Run("looks up emails through the blind index", func(t *testing.T) {
+		found, err := repo.GetByEmail(ctx, "jane@EXAMPLE.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	})
+
+	t.Run("reads rows stored before encryption", func(t *testing.T) {
+		legacy := createTestUser(t, NewUserRepository(db, idgen.UUIDv7{}), "legacy@example.com", "Legacy User")
+
+		found, err := repo.GetByEmail(ctx, "Legacy@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, legacy.ID, found.ID)
+		assert.Equal(t, "Legacy User", found.Name)
+	})
+
+	t.Run("rejects duplicate emails", func(t *testing.T) {
+		duplicate, err := domain.NewUser(uuid.New().String(), "jane@example.com", "Jane Again", time.Now())
+		require.NoError(t, err)
+		duplicate.AssignEventIDs(uuid.NewString)
+		assert.ErrorIs(t, repo.Create(ctx, duplicate), domain.ErrDuplicateEmail)
+	})
+}
+
+func TestRotateKeys(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	plain := createTestUser(t, NewUserRepository(db, idgen.UUIDv7{}), "plain@example.com", "Plain User")
+	old := createTestUser(t, NewEncryptedUserRepository(db, testKeyring(t, "k1"), idgen.UUIDv7{}), "old@example.com", "Old User")
+
+	keys := testKeyring(t, "k2", "k1")
+
+	t.Run("re-encrypts with the primary key", func(t *testing.T) {
+		// A batch size of 1 exercises paging
+		rotated, err := RotateKeys(ctx, db, keys, 1)
+		require.NoError(t, err)
+		// Both users and their created events
+		assert.Equal(t, 4, rotated)
+
+		for _, user := range []*domain.User{plain, old} {
+			row := storedRow(t, db, user.ID)
+			assert.True(t, strings.HasPrefix(row.Email, "enc:v1:k2:"))
+			assert.True(t, strings.HasPrefix(row.Name, "enc:v1:k2:"))
+			require.NotNil(t, row.EmailIndex)
+			assert.Equal(t, keys.BlindIndex(user.Email), *row.EmailIndex)
+		}
+	})
+
+	t.Run("values stay readable with the new key alone", func(t *testing.T) {
+		repo := NewEncryptedUserRepository(db, testKeyring(t, "k2"), idgen.UUIDv7{})
+
+		found, err := repo.GetByEmail(ctx, "plain@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "Plain User", found.Name)
+
+		events, err := repo.ListEvents(ctx, domain.EventFilter{UserID: old.ID}, nil, 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		assert.Equal(t, "old@example.com", events[0].Data["email"])
+	})
+
+	t.Run("running again rewrites nothing", func(t *testing.T) {
+		rotated, err := RotateKeys(ctx, db, keys, 10)
+		require.NoError(t, err)
+		assert.Zero(t, rotated)
+	})
+
+	t.Run("fails on values of unknown keys", func(t *testing.T) {
+		_, err := RotateKeys(ctx, db, testKeyring(t, "k3"), 10)
+		assert.ErrorIs(t, err, fieldcrypt.ErrUnknownKey)
+	})
+}
@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"gorm.io/gorm"
+
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
 )
 
@@ -10,13 +12,40 @@ func ToUserModel(user *domain.User) *UserModel {
 		return nil
 	}
 
-	return &UserModel{
+	model := &UserModel{
 		ID:        user.ID,
+		TenantID:  user.TenantID,
 		Email:     user.Email,
 		Name:      user.Name,
+		Status:    string(user.Status),
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
+
+	if user.Username != "" {
+		model.Username = &user.Username
+	}
+
+	if user.AvatarKey != "" {
+		model.AvatarKey = &user.AvatarKey
+	}
+
+	if user.DeletedAt != nil {
+		model.DeletedAt = gorm.DeletedAt{Time: *user.DeletedAt, Valid: true}
+	}
+
+	if user.LastLoginAt != nil {
+		lastLoginAt := *user.LastLoginAt
+		model.LastLoginAt = &lastLoginAt
+	}
+
+	if pending := user.PendingEmailChange; pending != nil {
+		model.PendingEmail = &pending.Email
+		model.EmailChangeTokenHash = &pending.TokenHash
+		model.EmailChangeExpiresAt = &pending.ExpiresAt
+	}
+
+	return model
 }
 
 // ToDomainUser converts a UserModel to a domain.User
@@ -25,13 +54,179 @@ func ToDomainUser(model *UserModel) *domain.User {
 		return nil
 	}
 
-	return &domain.User{
+	user := &domain.User{
 		ID:        model.ID,
+		TenantID:  model.TenantID,
 		Email:     model.Email,
 		Name:      model.Name,
+		Status:    domain.Status(model.Status),
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
 	}
+
+	if model.Username != nil {
+		user.Username = *model.Username
+	}
+
+	if model.AvatarKey != nil {
+		user.AvatarKey = *model.AvatarKey
+	}
+
+	if model.DeletedAt.Valid {
+		deletedAt := model.DeletedAt.Time
+		user.DeletedAt = &deletedAt
+	}
+
+	if model.LastLoginAt != nil {
+		lastLoginAt := *model.LastLoginAt
+		user.LastLoginAt = &lastLoginAt
+	}
+
+	if model.PendingEmail != nil && model.EmailChangeTokenHash != nil && model.EmailChangeExpiresAt != nil {
+		user.PendingEmailChange = &domain.EmailChange{
+			Email:     *model.PendingEmail,
+			TokenHash: *model.EmailChangeTokenHash,
+			ExpiresAt: *model.EmailChangeExpiresAt,
+		}
+	}
+
+	return user
+}
+
+// ToErasureModel converts a domain.ErasureRecord to an ErasureModel
+func ToErasureModel(record *domain.ErasureRecord) *ErasureModel {
+	if record == nil {
+		return nil
+	}
+
+	return &ErasureModel{
+		ID:       record.ID,
+		UserID:   record.UserID,
+		ErasedAt: record.ErasedAt,
+	}
+}
+
+// ToPreferencesModel converts a user's domain.Preferences to a PreferencesModel
+func ToPreferencesModel(userID string, prefs *domain.Preferences) *PreferencesModel {
+	if prefs == nil {
+		return nil
+	}
+
+	return &PreferencesModel{
+		UserID:             userID,
+		Locale:             prefs.Locale,
+		Timezone:           prefs.Timezone,
+		EmailNotifications: prefs.Notifications.Email,
+		Digest:             string(prefs.Notifications.Digest),
+		UpdatedAt:          prefs.UpdatedAt,
+	}
+}
+
+// ToDomainPreferences converts a PreferencesModel to a domain.Preferences
+func ToDomainPreferences(model *PreferencesModel) *domain.Preferences {
+	if model == nil {
+		return nil
+	}
+
+	return &domain.Preferences{
+		Locale:   model.Locale,
+		Timezone: model.Timezone,
+		Notifications: domain.NotificationPreferences{
+			Email:  model.EmailNotifications,
+			Digest: domain.DigestFrequency(model.Digest),
+		},
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+// ToPasswordModel converts a user's domain.Password to a PasswordModel
+func ToPasswordModel(userID string, password *domain.Password) *PasswordModel {
+	if password == nil {
+		return nil
+	}
+
+	return &PasswordModel{
+		UserID:    userID,
+		Hash:      password.Hash,
+		UpdatedAt: password.UpdatedAt,
+	}
+}
+
+// ToDomainPassword converts a PasswordModel to a domain.Password
+func ToDomainPassword(model *PasswordModel) *domain.Password {
+	if model == nil {
+		return nil
+	}
+
+	return &domain.Password{
+		Hash:      model.Hash,
+		UpdatedAt: model.UpdatedAt,
+	}
+}
+
+// ToEventModels converts domain events to EventModels
+func ToEventModels(events []domain.Event) []*EventModel {
+	models := make([]*EventModel, len(events))
+	for i, event := range events {
+		models[i] = &EventModel{
+			ID:         event.ID,
+			UserID:     event.UserID,
+			Type:       string(event.Type),
+			Data:       event.Data,
+			OccurredAt: event.OccurredAt,
+		}
+	}
+
+	return models
+}
+
+// ToDomainEvents converts EventModels to domain events
+func ToDomainEvents(models []*EventModel) []domain.Event {
+	events := make([]domain.Event, len(models))
+	for i, model := range models {
+		events[i] = domain.Event{
+			ID:         model.ID,
+			UserID:     model.UserID,
+			Type:       domain.EventType(model.Type),
+			Data:       model.Data,
+			OccurredAt: model.OccurredAt,
+		}
+	}
+
+	return events
+}
+
+// ToLoginModel converts a domain.LoginEvent to a LoginModel
+func ToLoginModel(login domain.LoginEvent) *LoginModel {
+	return &LoginModel{
+		ID:            login.ID,
+		UserID:        login.UserID,
+		Succeeded:     login.Succeeded,
+		FailureReason: string(login.Failure),
+		IPAddress:     login.IP,
+		UserAgent:     login.UserAgent,
+		Country:       login.Country,
+		OccurredAt:    login.OccurredAt,
+	}
+}
+
+// ToDomainLogins converts LoginModels to domain login events
+func ToDomainLogins(models []*LoginModel) []domain.LoginEvent {
+	logins := make([]domain.LoginEvent, len(models))
+	for i, model := range models {
+		logins[i] = domain.LoginEvent{
+			ID:         model.ID,
+			UserID:     model.UserID,
+			Succeeded:  model.Succeeded,
+			Failure:    domain.LoginFailure(model.FailureReason),
+			IP:         model.IPAddress,
+			UserAgent:  model.UserAgent,
+			Country:    model.Country,
+			OccurredAt: model.OccurredAt,
+		}
+	}
+
+	return logins
 }
 
 // ToDomainUsers converts a slice of UserModel to a slice of domain.User
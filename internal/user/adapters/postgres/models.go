@@ -9,14 +9,105 @@ import (
 // UserModel represents the database model for users
 type UserModel struct {
 	ID        string         `gorm:"type:uuid;primaryKey"`
-	Email     string         `gorm:"type:varchar(254);uniqueIndex;not null"`
-	Name      string         `gorm:"type:varchar(255);not null"`
+	TenantID  string         `gorm:"type:varchar(56);not null;default:'';uniqueIndex:idx_users_email_lower,priority:1;uniqueIndex:idx_users_username,priority:1;uniqueIndex:idx_users_email_index,priority:1"`
+	Email     string         `gorm:"type:text;serializer:encrypted;uniqueIndex:idx_users_email_lower,priority:2,expression:LOWER(email);not null"`
+	Name      string         `gorm:"type:text;serializer:encrypted;not null"`
+	Status    string         `gorm:"type:varchar(20);not null;default:active;index"`
+	Username  *string        `gorm:"type:varchar(30);uniqueIndex:idx_users_username,priority:2"`
+	AvatarKey *string        `gorm:"type:varchar(255)"`
 	CreatedAt time.Time      `gorm:"index;not null"`
 	UpdatedAt time.Time      `gorm:"not null"`
 	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// EmailIndex is the blind index of the lowercased email when field
+	// encryption is enabled, looked up instead of the encrypted email
+	EmailIndex *string `gorm:"type:varchar(64);uniqueIndex:idx_users_email_index,priority:2"`
+
+	// Pending email change awaiting confirmation
+	PendingEmail         *string `gorm:"type:text;serializer:encrypted"`
+	EmailChangeTokenHash *string `gorm:"type:varchar(64)"`
+	EmailChangeExpiresAt *time.Time
+
+	// LastLoginAt is only written by RecordLogin
+	LastLoginAt *time.Time
 }
 
 // TableName specifies the table name for UserModel
 func (UserModel) TableName() string {
 	return "users"
 }
+
+// ErasureModel represents the database model for user erasure records
+type ErasureModel struct {
+	ID       string    `gorm:"type:uuid;primaryKey"`
+	TenantID string    `gorm:"type:varchar(56);not null;default:''"`
+	UserID   string    `gorm:"type:uuid;index;not null"`
+	ErasedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for ErasureModel
+func (ErasureModel) TableName() string {
+	return "user_erasures"
+}
+
+// PreferencesModel represents the database model for user preferences
+type PreferencesModel struct {
+	UserID             string    `gorm:"type:uuid;primaryKey"`
+	TenantID           string    `gorm:"type:varchar(56);not null;default:''"`
+	Locale             string    `gorm:"type:varchar(35);not null"`
+	Timezone           string    `gorm:"type:varchar(64);not null"`
+	EmailNotifications bool      `gorm:"not null"`
+	Digest             string    `gorm:"type:varchar(10);not null"`
+	UpdatedAt          time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for PreferencesModel
+func (PreferencesModel) TableName() string {
+	return "user_preferences"
+}
+
+// PasswordModel represents the database model for user passwords
+type PasswordModel struct {
+	UserID    string    `gorm:"type:uuid;primaryKey"`
+	TenantID  string    `gorm:"type:varchar(56);not null;default:''"`
+	Hash      string    `gorm:"type:varchar(255);not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for PasswordModel
+func (PasswordModel) TableName() string {
+	return "user_passwords"
+}
+
+// EventModel represents the database model for user activity events
+type EventModel struct {
+	ID         string            `gorm:"type:uuid;primaryKey"`
+	TenantID   string            `gorm:"type:varchar(56);not null;default:''"`
+	UserID     string            `gorm:"type:uuid;not null;index:idx_user_events_feed,priority:1"`
+	Type       string            `gorm:"type:varchar(50);not null"`
+	Data       map[string]string `gorm:"serializer:encrypted"` // holds emails and names
+	OccurredAt time.Time         `gorm:"not null;index:idx_user_events_feed,priority:2,sort:desc"`
+}
+
+// TableName specifies the table name for EventModel
+func (EventModel) TableName() string {
+	return "user_events"
+}
+
+// LoginModel represents the database model for user login attempts
+type LoginModel struct {
+	ID            string    `gorm:"type:uuid;primaryKey"`
+	TenantID      string    `gorm:"type:varchar(56);not null;default:''"`
+	UserID        string    `gorm:"type:uuid;not null;index:idx_login_events_user,priority:1"`
+	Succeeded     bool      `gorm:"not null"`
+	FailureReason string    `gorm:"type:varchar(30);not null;default:''"`
+	IPAddress     string    `gorm:"type:varchar(45);not null;default:''"`
+	UserAgent     string    `gorm:"type:varchar(512);not null;default:''"`
+	Country       string    `gorm:"type:varchar(2);not null;default:''"`
+	OccurredAt    time.Time `gorm:"not null;index:idx_login_events_user,priority:2,sort:desc"`
+}
+
+// TableName specifies the table name for LoginModel
+func (LoginModel) TableName() string {
+	return "login_events"
+}
@@ -3,47 +3,218 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
 	"github.com/yourusername/go-scaffolding/internal/user/ports"
 )
 
+// createBatchSize is the number of rows inserted per statement in CreateBatch
+const createBatchSize = 100
+
 // userRepository implements ports.UserRepository using GORM
 type userRepository struct {
 	db *gorm.DB
+
+	// keys encrypts emails, names and event data when set
+	keys *fieldcrypt.Keyring
+
+	// ids names the events the repository records itself
+	ids ports.IDGenerator
 }
 
 // NewUserRepository creates a new PostgreSQL user repository
-func NewUserRepository(db *gorm.DB) ports.UserRepository {
+func NewUserRepository(db *gorm.DB, ids ports.IDGenerator) ports.UserRepository {
+	return &userRepository{
+		db:  db,
+		ids: ids,
+	}
+}
+
+// NewEncryptedUserRepository creates a PostgreSQL user repository that
+// encrypts emails, names and event data with keys and looks emails up by
+// their blind index. Rows stored before encryption was enabled are still
+// read; the rotation command encrypts them.
+func NewEncryptedUserRepository(db *gorm.DB, keys *fieldcrypt.Keyring, ids ports.IDGenerator) ports.UserRepository {
 	return &userRepository{
-		db: db,
+		db:   db,
+		keys: keys,
+		ids:  ids,
+	}
+}
+
+// conn returns the transaction carried by ctx, if any, or the repository's
+// database, with the keyring its statements encrypt with
+func (r *userRepository) conn(ctx context.Context) *gorm.DB {
+	return database.Conn(fieldcrypt.WithKeyring(ctx, r.keys), r.db)
+}
+
+// toUserModel converts user to a model, with the blind index of its email
+// when encryption is enabled
+func (r *userRepository) toUserModel(user *domain.User) *UserModel {
+	model := ToUserModel(user)
+	if r.keys != nil {
+		index := r.emailIndex(user.Email)
+		model.EmailIndex = &index
+	}
+	return model
+}
+
+// emailIndex returns the blind index of email, which ignores case like the
+// LOWER(email) index
+func (r *userRepository) emailIndex(email string) string {
+	return r.keys.BlindIndex(strings.ToLower(email))
+}
+
+// whereEmailIn restricts query to users with one of the lowercased emails.
+// With encryption enabled the blind index is compared, and rows stored
+// before it was enabled by their plaintext email.
+func (r *userRepository) whereEmailIn(query *gorm.DB, lowered []string) *gorm.DB {
+	if r.keys == nil {
+		return query.Where("LOWER(email) IN ?", lowered)
+	}
+
+	indexes := make([]string, len(lowered))
+	for i, email := range lowered {
+		indexes[i] = r.emailIndex(email)
 	}
+	return query.Where("email_index IN ? OR (email_index IS NULL AND LOWER(email) IN ?)", indexes, lowered)
 }
 
 // Create creates a new user in the database
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
-	model := ToUserModel(user)
+	model := r.toUserModel(user)
 
-	result := r.db.WithContext(ctx).Create(model)
-	if result.Error != nil {
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		return createEvents(tx, user.Events())
+	})
+	if err != nil {
 		// Check for unique constraint violation
-		if isDuplicateEmailError(result.Error) {
+		if isDuplicateEmailError(err) {
 			return domain.ErrDuplicateEmail
 		}
-		return result.Error
+		if isDuplicateUsernameError(err) {
+			return domain.ErrDuplicateUsername
+		}
+		return err
 	}
 
+	// The tenant is stamped from the context on insert
+	user.TenantID = model.TenantID
+	user.ClearEvents()
 	return nil
 }
 
+// CreateBatch creates multiple users in a single transaction
+func (r *userRepository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	models := make([]*UserModel, len(users))
+	var events []domain.Event
+	for i, user := range users {
+		models[i] = r.toUserModel(user)
+		events = append(events, user.Events()...)
+	}
+
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		// Skip conflicting rows instead of failing at the first one, so all
+		// conflicts can be reported; the transaction is rolled back if any
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(models, createBatchSize)
+		if result.Error != nil {
+			return result.Error
+		}
+		if int(result.RowsAffected) < len(models) {
+			return r.batchConflicts(tx, users)
+		}
+		return createEvents(tx, events)
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, user := range users {
+		user.TenantID = models[i].TenantID
+		user.ClearEvents()
+	}
+	return nil
+}
+
+// FindExistingEmails returns which of the given emails are already taken
+func (r *userRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	existing := []string{}
+	if len(emails) == 0 {
+		return existing, nil
+	}
+
+	lowered := make([]string, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(email)
+	}
+
+	// Soft-deleted rows still hold their email in the unique index. The
+	// emails are read through the model so they are decrypted.
+	var models []*UserModel
+	result := r.whereEmailIn(r.conn(ctx).Unscoped().Select("id", "email"), lowered).
+		Find(&models)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for _, model := range models {
+		existing = append(existing, model.Email)
+	}
+	return existing, nil
+}
+
 // GetByID retrieves a user by ID
 func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	var model UserModel
 
-	result := r.db.WithContext(ctx).Where("id = ?", id).First(&model)
+	result := r.conn(ctx).Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, result.Error
+	}
+
+	return ToDomainUser(&model), nil
+}
+
+// GetByIDIncludingDeleted retrieves a user by ID, including soft-deleted users
+func (r *userRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.User, error) {
+	var model UserModel
+
+	result := r.conn(ctx).Unscoped().Where("id = ?", id).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, result.Error
+	}
+
+	return ToDomainUser(&model), nil
+}
+
+// GetByUsername retrieves a user by username
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	var model UserModel
+
+	result := r.conn(ctx).Where("username = ?", username).First(&model)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
@@ -58,7 +229,7 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var model UserModel
 
-	result := r.db.WithContext(ctx).Where("email = ?", email).First(&model)
+	result := r.whereEmailIn(r.conn(ctx), []string{strings.ToLower(email)}).First(&model)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrUserNotFound
@@ -69,44 +240,289 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	return ToDomainUser(&model), nil
 }
 
+// Exists reports whether the user of key exists with a SELECT 1, loading
+// and decrypting no row
+func (r *userRepository) Exists(ctx context.Context, key domain.UserKey) (bool, error) {
+	query := r.conn(ctx)
+	if key.IncludeDeleted {
+		query = query.Unscoped()
+	}
+	query = query.Model(&UserModel{}).Select("1")
+
+	switch {
+	case key.ID != "":
+		query = query.Where("id = ?", key.ID)
+	case key.Username != "":
+		query = query.Where("username = ?", key.Username)
+	default:
+		query = r.whereEmailIn(query, []string{strings.ToLower(key.Email)})
+	}
+
+	var exists bool
+	if err := r.conn(ctx).Raw("SELECT EXISTS (?)", query).Scan(&exists).Error; err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
 // Update updates an existing user
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
-	model := ToUserModel(user)
+	model := r.toUserModel(user)
 
-	result := r.db.WithContext(ctx).Model(&UserModel{ID: user.ID}).
-		Updates(model)
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		// Select all columns so cleared fields (e.g. a confirmed pending email
+		// change) are written as NULL instead of being skipped as zero values
+		result := tx.Model(&UserModel{ID: user.ID}).
+			Select("*").
+			Omit("id", "created_at", "deleted_at", "last_login_at").
+			Updates(model)
+		if result.Error != nil {
+			return result.Error
+		}
 
-	if result.Error != nil {
-		return result.Error
-	}
+		if result.RowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
 
-	if result.RowsAffected == 0 {
-		return domain.ErrUserNotFound
+		return createEvents(tx, user.Events())
+	})
+	if err != nil {
+		if isDuplicateEmailError(err) {
+			return domain.ErrDuplicateEmail
+		}
+		if isDuplicateUsernameError(err) {
+			return domain.ErrDuplicateUsername
+		}
+		return err
 	}
 
+	user.ClearEvents()
 	return nil
 }
 
 // Delete deletes a user by ID
 func (r *userRepository) Delete(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Where("id = ?", id).Delete(&UserModel{})
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ?", id).Delete(&UserModel{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return createEvents(tx, []domain.Event{domain.NewEvent(r.ids.NewID(), id, domain.EventUserDeleted, nil, utc.Now())})
+	})
+}
+
+// Erase permanently deletes a user and stores the erasure record in the
+// same transaction. Tables holding per-user data must reference users with
+// ON DELETE CASCADE so they are erased along with the user.
+func (r *userRepository) Erase(ctx context.Context, record *domain.ErasureRecord) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id = ?", record.UserID).Delete(&UserModel{})
+		if result.Error != nil {
+			return result.Error
+		}
 
+		if result.RowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return tx.Create(ToErasureModel(record)).Error
+	})
+}
+
+// PurgeDeleted permanently deletes up to limit users soft-deleted before
+// the cutoff. Deleting in bounded batches keeps row locks short.
+func (r *userRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	ids := r.conn(ctx).Unscoped().
+		Model(&UserModel{}).
+		Select("id").
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("deleted_at").
+		Limit(limit)
+
+	result := r.conn(ctx).Unscoped().Where("id IN (?)", ids).Delete(&UserModel{})
 	if result.Error != nil {
-		return result.Error
+		return 0, result.Error
 	}
 
-	if result.RowsAffected == 0 {
-		return domain.ErrUserNotFound
+	return int(result.RowsAffected), nil
+}
+
+// CountDeleted counts users soft-deleted before the cutoff
+func (r *userRepository) CountDeleted(ctx context.Context, before time.Time) (int, error) {
+	var count int64
+
+	result := r.conn(ctx).Unscoped().
+		Model(&UserModel{}).
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Count(&count)
+	if result.Error != nil {
+		return 0, result.Error
 	}
 
-	return nil
+	return int(count), nil
+}
+
+// Restore undoes the soft delete of a user by ID
+func (r *userRepository) Restore(ctx context.Context, id string) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().
+			Model(&UserModel{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Updates(map[string]interface{}{
+				"deleted_at": nil,
+				"updated_at": utc.Now(),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return createEvents(tx, []domain.Event{domain.NewEvent(r.ids.NewID(), id, domain.EventUserRestored, nil, utc.Now())})
+	})
 }
 
-// List retrieves users with pagination
-func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*domain.User, error) {
+// GetPreferences retrieves the preferences saved by a user
+func (r *userRepository) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	var model PreferencesModel
+
+	result := r.conn(ctx).Where("user_id = ?", userID).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPreferencesNotFound
+		}
+		return nil, result.Error
+	}
+
+	return ToDomainPreferences(&model), nil
+}
+
+// SavePreferences creates or replaces a user's preferences
+func (r *userRepository) SavePreferences(ctx context.Context, userID string, prefs *domain.Preferences) error {
+	return r.conn(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			UpdateAll: true,
+		}).
+		Create(ToPreferencesModel(userID, prefs)).Error
+}
+
+// GetPassword retrieves a user's stored password
+func (r *userRepository) GetPassword(ctx context.Context, userID string) (*domain.Password, error) {
+	var model PasswordModel
+
+	result := r.conn(ctx).Where("user_id = ?", userID).First(&model)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrPasswordNotSet
+		}
+		return nil, result.Error
+	}
+
+	return ToDomainPassword(&model), nil
+}
+
+// SavePassword creates or replaces a user's stored password
+func (r *userRepository) SavePassword(ctx context.Context, userID string, password *domain.Password) error {
+	return r.conn(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			UpdateAll: true,
+		}).
+		Create(ToPasswordModel(userID, password)).Error
+}
+
+// ListEvents retrieves up to limit events matching the filter, newest
+// first, starting after the cursor when one is given
+func (r *userRepository) ListEvents(ctx context.Context, filter domain.EventFilter, cursor *domain.EventCursor, limit int) ([]domain.Event, error) {
+	var models []*EventModel
+
+	query := r.conn(ctx)
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", string(filter.Type))
+	}
+	if cursor != nil {
+		// Keyset pagination: the ID breaks ties between events of the same instant
+		query = query.Where("occurred_at < ? OR (occurred_at = ? AND id < ?)",
+			cursor.OccurredAt, cursor.OccurredAt, cursor.ID)
+	}
+
+	result := query.
+		Order("occurred_at DESC, id DESC").
+		Limit(limit).
+		Find(&models)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ToDomainEvents(models), nil
+}
+
+// RecordLogin stores a login attempt and sets the user's last login time
+// when it succeeded. The users trigger leaves updated_at alone when only
+// last_login_at changes.
+func (r *userRepository) RecordLogin(ctx context.Context, login domain.LoginEvent) error {
+	return r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if login.Succeeded {
+			result := tx.Unscoped().
+				Model(&UserModel{}).
+				Where("id = ?", login.UserID).
+				UpdateColumn("last_login_at", login.OccurredAt)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return domain.ErrUserNotFound
+			}
+		}
+
+		return tx.Create(ToLoginModel(login)).Error
+	})
+}
+
+// ListLogins retrieves up to limit login attempts of the user, newest
+// first, starting after the cursor when one is given
+func (r *userRepository) ListLogins(ctx context.Context, userID string, cursor *domain.EventCursor, limit int) ([]domain.LoginEvent, error) {
+	var models []*LoginModel
+
+	query := r.conn(ctx).Where("user_id = ?", userID)
+	if cursor != nil {
+		query = query.Where("occurred_at < ? OR (occurred_at = ? AND id < ?)",
+			cursor.OccurredAt, cursor.OccurredAt, cursor.ID)
+	}
+
+	result := query.
+		Order("occurred_at DESC, id DESC").
+		Limit(limit).
+		Find(&models)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return ToDomainLogins(models), nil
+}
+
+// List retrieves users matching the filter with pagination
+func (r *userRepository) List(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error) {
+	if r.matchesDecrypted(filter) {
+		return r.listDecrypted(ctx, filter, limit, offset)
+	}
+
 	var models []*UserModel
 
-	result := r.db.WithContext(ctx).
+	result := applyUserFilter(r.conn(ctx), filter).
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -119,6 +535,183 @@ func (r *userRepository) List(ctx context.Context, limit, offset int) ([]*domain
 	return ToDomainUsers(models), nil
 }
 
+// ListStream calls fn for every user matching the filter, reading rows
+// through a cursor instead of loading them all into memory
+func (r *userRepository) ListStream(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	// Encrypted emails and names are matched once decrypted
+	sqlFilter := filter
+	decrypted := r.matchesDecrypted(filter)
+	if decrypted {
+		sqlFilter.EmailContains, sqlFilter.NameContains = "", ""
+	}
+
+	conn := r.conn(ctx)
+	query := applyUserFilter(conn.Model(&UserModel{}), sqlFilter).
+		Order("created_at DESC")
+
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var model UserModel
+		if err := conn.ScanRows(rows, &model); err != nil {
+			return err
+		}
+
+		user := ToDomainUser(&model)
+		if decrypted && !matchesText(user, filter) {
+			continue
+		}
+		if err := fn(user); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// matchesDecrypted reports whether the email and name conditions of filter
+// must be evaluated after decryption, as SQL only sees ciphertext
+func (r *userRepository) matchesDecrypted(filter domain.UserFilter) bool {
+	return r.keys != nil && (filter.EmailContains != "" || filter.NameContains != "")
+}
+
+// errPageFull stops listDecrypted's stream once the page is complete
+var errPageFull = errors.New("page full")
+
+// listDecrypted pages through the users matching filter by decrypting
+// every user matching its other conditions. It reads rows up to the end
+// of the page, so deep pages cost more.
+func (r *userRepository) listDecrypted(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error) {
+	users := []*domain.User{}
+	err := r.ListStream(ctx, filter, func(user *domain.User) error {
+		if offset > 0 {
+			offset--
+			return nil
+		}
+		users = append(users, user)
+		if len(users) >= limit {
+			return errPageFull
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errPageFull) {
+		return nil, err
+	}
+	return users, nil
+}
+
+// matchesText reports whether user's email and name contain those of
+// filter, ignoring case
+func matchesText(user *domain.User, filter domain.UserFilter) bool {
+	return strings.Contains(strings.ToLower(user.Email), strings.ToLower(filter.EmailContains)) &&
+		strings.Contains(strings.ToLower(user.Name), strings.ToLower(filter.NameContains))
+}
+
+// createEvents stores events in the activity feed
+func createEvents(tx *gorm.DB, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(ToEventModels(events), createBatchSize).Error
+}
+
+// batchConflicts reports the users of a batch that were skipped because
+// their email or username is taken. The rows the batch inserted are visible
+// to tx, so a user conflicting with an earlier one is reported too.
+func (r *userRepository) batchConflicts(tx *gorm.DB, users []*domain.User) error {
+	ids := make([]string, len(users))
+	emails := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+		emails[i] = strings.ToLower(user.Email)
+	}
+
+	var created []string
+	if err := tx.Model(&UserModel{}).Unscoped().Where("id IN ?", ids).Pluck("id", &created).Error; err != nil {
+		return err
+	}
+	var models []*UserModel
+	if err := r.whereEmailIn(tx.Unscoped().Select("id", "email"), emails).Find(&models).Error; err != nil {
+		return err
+	}
+	taken := make([]string, len(models))
+	for i, model := range models {
+		taken[i] = strings.ToLower(model.Email)
+	}
+
+	return newBatchConflictError(users, created, taken)
+}
+
+// newBatchConflictError reports the users of a batch missing from created:
+// a duplicate email if their email is among the taken ones, otherwise a
+// duplicate username
+func newBatchConflictError(users []*domain.User, created, taken []string) error {
+	isCreated := make(map[string]bool, len(created))
+	for _, id := range created {
+		isCreated[id] = true
+	}
+	isTaken := make(map[string]bool, len(taken))
+	for _, email := range taken {
+		isTaken[email] = true
+	}
+
+	var conflicts []domain.BatchConflict
+	for i, user := range users {
+		switch {
+		case isCreated[user.ID]:
+		case isTaken[strings.ToLower(user.Email)]:
+			conflicts = append(conflicts, domain.BatchConflict{Index: i, Err: domain.ErrDuplicateEmail})
+		case user.Username != "":
+			conflicts = append(conflicts, domain.BatchConflict{Index: i, Err: domain.ErrDuplicateUsername})
+		default:
+			// Only the ID can conflict
+			return fmt.Errorf("user %s already exists", user.ID)
+		}
+	}
+
+	// A stored user with the ID of a batch user looks created
+	if len(conflicts) == 0 {
+		return errors.New("some users of the batch were not created")
+	}
+	return &domain.BatchConflictError{Conflicts: conflicts}
+}
+
+// applyUserFilter adds the filter conditions to a query
+func applyUserFilter(query *gorm.DB, filter domain.UserFilter) *gorm.DB {
+	if filter.IncludeDeleted {
+		query = query.Unscoped()
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.EmailContains != "" {
+		query = query.Where("LOWER(email) LIKE ? ESCAPE '\\'", containsPattern(filter.EmailContains))
+	}
+	if filter.NameContains != "" {
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '\\'", containsPattern(filter.NameContains))
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	return query
+}
+
+// containsPattern builds a case-insensitive LIKE pattern matching value
+// anywhere, escaping LIKE wildcards in the value itself
+func containsPattern(value string) string {
+	escaped := likeEscaper.Replace(strings.ToLower(value))
+	return "%" + escaped + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 // isDuplicateEmailError checks if the error is a unique constraint violation on email
 func isDuplicateEmailError(err error) bool {
 	if err == nil {
@@ -136,3 +729,18 @@ func isDuplicateEmailError(err error) bool {
 
 	return false
 }
+
+// isDuplicateUsernameError checks if the error is a unique constraint violation on username
+func isDuplicateUsernameError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errMsg := err.Error()
+	if strings.Contains(errMsg, "duplicate key value violates unique constraint") ||
+		strings.Contains(errMsg, "UNIQUE constraint failed") {
+		return strings.Contains(errMsg, "username")
+	}
+
+	return false
+}
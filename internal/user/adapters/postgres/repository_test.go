@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
 )
 
@@ -22,7 +25,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 	require.NoError(t, err)
 
 	// Auto-migrate the schema
-	err = db.AutoMigrate(&UserModel{})
+	err = db.AutoMigrate(&UserModel{}, &ErasureModel{}, &PreferencesModel{}, &PasswordModel{}, &EventModel{}, &LoginModel{})
 	require.NoError(t, err)
 
 	return db
@@ -30,7 +33,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 
 func TestRepository_Create(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewUserRepository(db)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
 	ctx := context.Background()
 
 	t.Run("successfully creates user", func(t *testing.T) {
@@ -76,11 +79,103 @@ func TestRepository_Create(t *testing.T) {
 		err = repo.Create(ctx, user2)
 		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
 	})
+
+	t.Run("returns error on duplicate email differing only in case", func(t *testing.T) {
+		user1 := &domain.User{
+			ID:        uuid.New().String(),
+			Email:     "casing@example.com",
+			Name:      "User One",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
+		err := repo.Create(ctx, user1)
+		require.NoError(t, err)
+
+		user2 := &domain.User{
+			ID:        uuid.New().String(),
+			Email:     "Casing@Example.com",
+			Name:      "User Two",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
+		err = repo.Create(ctx, user2)
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+	})
+}
+
+func TestRepository_CreateBatch(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	t.Run("successfully creates all users", func(t *testing.T) {
+		users := []*domain.User{
+			{ID: uuid.New().String(), Email: "batch1@example.com", Name: "Batch One", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			{ID: uuid.New().String(), Email: "batch2@example.com", Name: "Batch Two", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		}
+
+		err := repo.CreateBatch(ctx, users)
+		assert.NoError(t, err)
+
+		var count int64
+		db.Model(&UserModel{}).Where("email IN ?", []string{"batch1@example.com", "batch2@example.com"}).Count(&count)
+		assert.Equal(t, int64(2), count)
+	})
+
+	t.Run("rolls back the whole batch on duplicate email", func(t *testing.T) {
+		users := []*domain.User{
+			{ID: uuid.New().String(), Email: "batch3@example.com", Name: "Batch Three", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			{ID: uuid.New().String(), Email: "batch1@example.com", Name: "Duplicate", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		}
+
+		err := repo.CreateBatch(ctx, users)
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+
+		_, err = repo.GetByEmail(ctx, "batch3@example.com")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestRepository_FindExistingEmails(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	user := &domain.User{
+		ID:        uuid.New().String(),
+		Email:     "exists@example.com",
+		Name:      "Existing User",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	t.Run("returns only taken emails", func(t *testing.T) {
+		existing, err := repo.FindExistingEmails(ctx, []string{"Exists@Example.com", "free@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"exists@example.com"}, existing)
+	})
+
+	t.Run("includes soft-deleted users", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, user.ID))
+
+		existing, err := repo.FindExistingEmails(ctx, []string{"exists@example.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"exists@example.com"}, existing)
+	})
+
+	t.Run("returns empty slice for no emails", func(t *testing.T) {
+		existing, err := repo.FindExistingEmails(ctx, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, existing)
+	})
 }
 
 func TestRepository_GetByID(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewUserRepository(db)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
 	ctx := context.Background()
 
 	t.Run("successfully retrieves user by ID", func(t *testing.T) {
@@ -112,9 +207,47 @@ func TestRepository_GetByID(t *testing.T) {
 	})
 }
 
+func TestRepository_GetByUsername(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	first := &domain.User{ID: uuid.New().String(), Email: "first@example.com", Name: "First", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	second := &domain.User{ID: uuid.New().String(), Email: "second@example.com", Name: "Second", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	require.NoError(t, repo.Create(ctx, first))
+	require.NoError(t, repo.Create(ctx, second))
+
+	t.Run("users without a username do not conflict", func(t *testing.T) {
+		retrieved, err := repo.GetByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Empty(t, retrieved.Username)
+	})
+
+	t.Run("retrieves user by username", func(t *testing.T) {
+		first.Username = "first"
+		require.NoError(t, repo.Update(ctx, first))
+
+		retrieved, err := repo.GetByUsername(ctx, "first")
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, retrieved.ID)
+		assert.Equal(t, "first", retrieved.Username)
+	})
+
+	t.Run("returns ErrDuplicateUsername when username is taken", func(t *testing.T) {
+		second.Username = "first"
+		err := repo.Update(ctx, second)
+		assert.ErrorIs(t, err, domain.ErrDuplicateUsername)
+	})
+
+	t.Run("returns ErrUserNotFound for unknown username", func(t *testing.T) {
+		_, err := repo.GetByUsername(ctx, "nobody")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
 func TestRepository_GetByEmail(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewUserRepository(db)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
 	ctx := context.Background()
 
 	t.Run("successfully retrieves user by email", func(t *testing.T) {
@@ -137,6 +270,24 @@ func TestRepository_GetByEmail(t *testing.T) {
 		assert.Equal(t, user.Name, retrieved.Name)
 	})
 
+	t.Run("matches email case-insensitively", func(t *testing.T) {
+		user := &domain.User{
+			ID:        uuid.New().String(),
+			Email:     "MixedCase@Example.com",
+			Name:      "Mixed Case User",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
+		err := repo.Create(ctx, user)
+		require.NoError(t, err)
+
+		retrieved, err := repo.GetByEmail(ctx, "mixedcase@example.com")
+		assert.NoError(t, err)
+		assert.NotNil(t, retrieved)
+		assert.Equal(t, user.ID, retrieved.ID)
+	})
+
 	t.Run("returns ErrUserNotFound for non-existent email", func(t *testing.T) {
 		retrieved, err := repo.GetByEmail(ctx, "nonexistent@example.com")
 		assert.ErrorIs(t, err, domain.ErrUserNotFound)
@@ -146,7 +297,7 @@ func TestRepository_GetByEmail(t *testing.T) {
 
 func TestRepository_Update(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewUserRepository(db)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
 	ctx := context.Background()
 
 	t.Run("successfully updates user", func(t *testing.T) {
@@ -174,6 +325,62 @@ func TestRepository_Update(t *testing.T) {
 		assert.Equal(t, "Updated Name", retrieved.Name)
 	})
 
+	t.Run("persists and clears pending email change", func(t *testing.T) {
+		user := &domain.User{
+			ID:        uuid.New().String(),
+			Email:     "pending@example.com",
+			Name:      "Pending User",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
+		err := repo.Create(ctx, user)
+		require.NoError(t, err)
+
+		token, err := user.RequestEmailChange("pending-new@example.com", time.Hour, time.Now())
+		require.NoError(t, err)
+		user.AssignEventIDs(uuid.NewString)
+		require.NoError(t, repo.Update(ctx, user))
+
+		retrieved, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, retrieved.PendingEmailChange)
+		assert.Equal(t, "pending-new@example.com", retrieved.PendingEmailChange.Email)
+
+		require.NoError(t, retrieved.ConfirmEmailChange(token, time.Now()))
+		retrieved.AssignEventIDs(uuid.NewString)
+		require.NoError(t, repo.Update(ctx, retrieved))
+
+		retrieved, err = repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "pending-new@example.com", retrieved.Email)
+		assert.Nil(t, retrieved.PendingEmailChange)
+	})
+
+	t.Run("returns ErrDuplicateEmail when email is taken", func(t *testing.T) {
+		taken := &domain.User{
+			ID:        uuid.New().String(),
+			Email:     "taken@example.com",
+			Name:      "Taken User",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		user := &domain.User{
+			ID:        uuid.New().String(),
+			Email:     "free@example.com",
+			Name:      "Free User",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
+		require.NoError(t, repo.Create(ctx, taken))
+		require.NoError(t, repo.Create(ctx, user))
+
+		user.Email = taken.Email
+		err := repo.Update(ctx, user)
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+	})
+
 	t.Run("returns ErrUserNotFound for non-existent user", func(t *testing.T) {
 		user := &domain.User{
 			ID:        uuid.New().String(),
@@ -190,7 +397,7 @@ func TestRepository_Update(t *testing.T) {
 
 func TestRepository_Delete(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewUserRepository(db)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
 	ctx := context.Background()
 
 	t.Run("successfully deletes user", func(t *testing.T) {
@@ -222,9 +429,157 @@ func TestRepository_Delete(t *testing.T) {
 	})
 }
 
+func TestRepository_Erase(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	t.Run("permanently deletes a soft-deleted user and stores the record", func(t *testing.T) {
+		user := &domain.User{
+			ID:        uuid.New().String(),
+			Email:     "erase@example.com",
+			Name:      "Erase User",
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+		require.NoError(t, repo.Create(ctx, user))
+		require.NoError(t, repo.Delete(ctx, user.ID))
+
+		record := domain.NewErasureRecord(uuid.NewString(), user.ID, time.Now())
+		err := repo.Erase(ctx, record)
+		require.NoError(t, err)
+
+		_, err = repo.GetByIDIncludingDeleted(ctx, user.ID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		var stored ErasureModel
+		require.NoError(t, db.First(&stored, "id = ?", record.ID).Error)
+		assert.Equal(t, user.ID, stored.UserID)
+
+		// The email is free again once the personal data is gone
+		existing, err := repo.FindExistingEmails(ctx, []string{"erase@example.com"})
+		require.NoError(t, err)
+		assert.Empty(t, existing)
+	})
+
+	t.Run("returns ErrUserNotFound and stores no record for non-existent user", func(t *testing.T) {
+		record := domain.NewErasureRecord(uuid.NewString(), uuid.New().String(), time.Now())
+		err := repo.Erase(ctx, record)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		var count int64
+		require.NoError(t, db.Model(&ErasureModel{}).Where("id = ?", record.ID).Count(&count).Error)
+		assert.Zero(t, count)
+	})
+}
+
+func TestRepository_PurgeDeleted(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	now := time.Now()
+	active := &domain.User{ID: uuid.New().String(), Email: "active@example.com", Name: "Active", CreatedAt: now, UpdatedAt: now}
+	recent := &domain.User{ID: uuid.New().String(), Email: "recent@example.com", Name: "Recent", CreatedAt: now, UpdatedAt: now}
+	require.NoError(t, repo.Create(ctx, active))
+	require.NoError(t, repo.Create(ctx, recent))
+	require.NoError(t, repo.Delete(ctx, recent.ID))
+
+	expired := make([]string, 3)
+	for i := range expired {
+		user := &domain.User{ID: uuid.New().String(), Email: uuid.New().String() + "@example.com", Name: "Expired", CreatedAt: now, UpdatedAt: now}
+		require.NoError(t, repo.Create(ctx, user))
+		require.NoError(t, db.Unscoped().Model(&UserModel{}).Where("id = ?", user.ID).Update("deleted_at", now.AddDate(0, 0, -60)).Error)
+		expired[i] = user.ID
+	}
+
+	cutoff := now.AddDate(0, 0, -30)
+
+	t.Run("counts users deleted before the cutoff", func(t *testing.T) {
+		count, err := repo.CountDeleted(ctx, cutoff)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	t.Run("purges at most limit users per call", func(t *testing.T) {
+		purged, err := repo.PurgeDeleted(ctx, cutoff, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, purged)
+
+		purged, err = repo.PurgeDeleted(ctx, cutoff, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		for _, id := range expired {
+			_, err := repo.GetByIDIncludingDeleted(ctx, id)
+			assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		}
+	})
+
+	t.Run("keeps active and recently deleted users", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, active.ID)
+		assert.NoError(t, err)
+
+		_, err = repo.GetByIDIncludingDeleted(ctx, recent.ID)
+		assert.NoError(t, err)
+
+		count, err := repo.CountDeleted(ctx, cutoff)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+}
+
+func TestRepository_Restore(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	user := &domain.User{
+		ID:        uuid.New().String(),
+		Email:     "restore@example.com",
+		Name:      "Restore User",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	require.NoError(t, repo.Create(ctx, user))
+
+	t.Run("returns ErrUserNotFound for a user that is not deleted", func(t *testing.T) {
+		err := repo.Restore(ctx, user.ID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("retrieves deleted users only when including deleted", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, user.ID))
+
+		_, err := repo.GetByID(ctx, user.ID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		retrieved, err := repo.GetByIDIncludingDeleted(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, retrieved.IsDeleted())
+	})
+
+	t.Run("successfully restores a deleted user", func(t *testing.T) {
+		err := repo.Restore(ctx, user.ID)
+		require.NoError(t, err)
+
+		retrieved, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.False(t, retrieved.IsDeleted())
+	})
+
+	t.Run("returns ErrUserNotFound for non-existent user", func(t *testing.T) {
+		_, err := repo.GetByIDIncludingDeleted(ctx, uuid.New().String())
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		err = repo.Restore(ctx, uuid.New().String())
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
 func TestRepository_List(t *testing.T) {
 	db := setupTestDB(t)
-	repo := NewUserRepository(db)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
 	ctx := context.Background()
 
 	// Create test users
@@ -258,32 +613,32 @@ func TestRepository_List(t *testing.T) {
 	}
 
 	t.Run("retrieves all users with no pagination", func(t *testing.T) {
-		retrieved, err := repo.List(ctx, 10, 0)
+		retrieved, err := repo.List(ctx, domain.UserFilter{}, 10, 0)
 		assert.NoError(t, err)
 		assert.Len(t, retrieved, 3)
 	})
 
 	t.Run("retrieves users with limit", func(t *testing.T) {
-		retrieved, err := repo.List(ctx, 2, 0)
+		retrieved, err := repo.List(ctx, domain.UserFilter{}, 2, 0)
 		assert.NoError(t, err)
 		assert.Len(t, retrieved, 2)
 	})
 
 	t.Run("retrieves users with offset", func(t *testing.T) {
-		retrieved, err := repo.List(ctx, 10, 2)
+		retrieved, err := repo.List(ctx, domain.UserFilter{}, 10, 2)
 		assert.NoError(t, err)
 		assert.Len(t, retrieved, 1)
 	})
 
 	t.Run("returns empty slice when no users found", func(t *testing.T) {
-		retrieved, err := repo.List(ctx, 10, 100)
+		retrieved, err := repo.List(ctx, domain.UserFilter{}, 10, 100)
 		assert.NoError(t, err)
 		assert.NotNil(t, retrieved)
 		assert.Len(t, retrieved, 0)
 	})
 
 	t.Run("returns users in DESC order (newest first)", func(t *testing.T) {
-		retrieved, err := repo.List(ctx, 10, 0)
+		retrieved, err := repo.List(ctx, domain.UserFilter{}, 10, 0)
 		assert.NoError(t, err)
 		assert.Len(t, retrieved, 3)
 
@@ -292,4 +647,225 @@ func TestRepository_List(t *testing.T) {
 		assert.Equal(t, users[1].ID, retrieved[1].ID, "Second user should be User Two")
 		assert.Equal(t, users[0].ID, retrieved[2].ID, "Third user should be the oldest (User One)")
 	})
+
+	t.Run("includes deleted users only when requested", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, users[0].ID))
+
+		retrieved, err := repo.List(ctx, domain.UserFilter{}, 10, 0)
+		assert.NoError(t, err)
+		assert.Len(t, retrieved, 2)
+
+		retrieved, err = repo.List(ctx, domain.UserFilter{IncludeDeleted: true}, 10, 0)
+		assert.NoError(t, err)
+		require.Len(t, retrieved, 3)
+		assert.Equal(t, users[0].ID, retrieved[2].ID)
+		assert.NotNil(t, retrieved[2].DeletedAt)
+	})
+}
+
+func TestRepository_ListStream(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	base := time.Now().Add(-time.Hour)
+	users := []*domain.User{
+		{ID: uuid.New().String(), Email: "alice@example.com", Name: "Alice", CreatedAt: base, UpdatedAt: base},
+		{ID: uuid.New().String(), Email: "bob@example.com", Name: "Bob", CreatedAt: base.Add(time.Minute), UpdatedAt: base},
+		{ID: uuid.New().String(), Email: "a_lice@test.com", Name: "Alice Two", CreatedAt: base.Add(2 * time.Minute), UpdatedAt: base},
+	}
+	for _, user := range users {
+		require.NoError(t, repo.Create(ctx, user))
+	}
+
+	collect := func(filter domain.UserFilter) []string {
+		var emails []string
+		err := repo.ListStream(ctx, filter, func(user *domain.User) error {
+			emails = append(emails, user.Email)
+			return nil
+		})
+		require.NoError(t, err)
+		return emails
+	}
+
+	t.Run("streams all users newest first", func(t *testing.T) {
+		assert.Equal(t, []string{"a_lice@test.com", "bob@example.com", "alice@example.com"}, collect(domain.UserFilter{}))
+	})
+
+	t.Run("filters by email and name ignoring case", func(t *testing.T) {
+		assert.Equal(t, []string{"bob@example.com", "alice@example.com"}, collect(domain.UserFilter{EmailContains: "EXAMPLE"}))
+		assert.Equal(t, []string{"a_lice@test.com", "alice@example.com"}, collect(domain.UserFilter{NameContains: "alice"}))
+	})
+
+	t.Run("treats LIKE wildcards literally", func(t *testing.T) {
+		assert.Equal(t, []string{"a_lice@test.com"}, collect(domain.UserFilter{EmailContains: "a_l"}))
+	})
+
+	t.Run("filters by creation time", func(t *testing.T) {
+		after := base.Add(30 * time.Second)
+		before := base.Add(90 * time.Second)
+		assert.Equal(t, []string{"bob@example.com"}, collect(domain.UserFilter{CreatedAfter: &after, CreatedBefore: &before}))
+	})
+
+	t.Run("stops at the first callback error", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := repo.ListStream(ctx, domain.UserFilter{}, func(*domain.User) error {
+			calls++
+			return stop
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("defaults to active and filters by status", func(t *testing.T) {
+		bob, err := repo.GetByID(ctx, users[1].ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusActive, bob.Status)
+
+		require.NoError(t, bob.Suspend(time.Now()))
+		bob.AssignEventIDs(uuid.NewString)
+		require.NoError(t, repo.Update(ctx, bob))
+
+		assert.Equal(t, []string{"bob@example.com"}, collect(domain.UserFilter{Status: domain.StatusSuspended}))
+		assert.Equal(t, []string{"a_lice@test.com", "alice@example.com"}, collect(domain.UserFilter{Status: domain.StatusActive}))
+	})
+}
+
+func TestRepository_Preferences(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	_, err := repo.GetPreferences(ctx, userID)
+	assert.ErrorIs(t, err, domain.ErrPreferencesNotFound)
+
+	prefs := &domain.Preferences{
+		Locale:        "en-US",
+		Timezone:      "America/New_York",
+		Notifications: domain.NotificationPreferences{Email: true, Digest: domain.DigestDaily},
+		UpdatedAt:     time.Now(),
+	}
+	require.NoError(t, repo.SavePreferences(ctx, userID, prefs))
+
+	found, err := repo.GetPreferences(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "America/New_York", found.Timezone)
+	assert.True(t, found.Notifications.Email)
+
+	// Saving again replaces the stored preferences
+	prefs.Notifications = domain.NotificationPreferences{Email: false, Digest: domain.DigestOff}
+	require.NoError(t, repo.SavePreferences(ctx, userID, prefs))
+
+	found, err = repo.GetPreferences(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, found.Notifications.Email)
+	assert.Equal(t, domain.DigestOff, found.Notifications.Digest)
+}
+
+func TestRepository_Events(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	user, err := domain.NewUser(uuid.NewString(), "events@example.com", "Events User", time.Now())
+	require.NoError(t, err)
+	user.AssignEventIDs(uuid.NewString)
+	require.NoError(t, repo.Create(ctx, user))
+	assert.Empty(t, user.Events(), "persisted events should be cleared")
+
+	require.NoError(t, user.UpdateName("Renamed User", time.Now()))
+	user.AssignEventIDs(uuid.NewString)
+	require.NoError(t, repo.Update(ctx, user))
+	require.NoError(t, repo.Delete(ctx, user.ID))
+	require.NoError(t, repo.Restore(ctx, user.ID))
+
+	// Events of other users are not listed
+	other, err := domain.NewUser(uuid.NewString(), "other@example.com", "Other User", time.Now())
+	require.NoError(t, err)
+	other.AssignEventIDs(uuid.NewString)
+	require.NoError(t, repo.Create(ctx, other))
+
+	events, err := repo.ListEvents(ctx, domain.EventFilter{UserID: user.ID}, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, []domain.EventType{
+		domain.EventUserRestored,
+		domain.EventUserDeleted,
+		domain.EventNameChanged,
+		domain.EventUserCreated,
+	}, []domain.EventType{events[0].Type, events[1].Type, events[2].Type, events[3].Type})
+	assert.Equal(t, map[string]string{"name": "Renamed User"}, events[2].Data)
+
+	// Pages continue after the cursor without gaps or repeats
+	first, err := repo.ListEvents(ctx, domain.EventFilter{UserID: user.ID}, nil, 2)
+	require.NoError(t, err)
+	cursor := domain.CursorAfter(first[1])
+	second, err := repo.ListEvents(ctx, domain.EventFilter{UserID: user.ID}, &cursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, events[2:], second)
+}
+
+func TestRepository_EventsRolledBackWithFailedWrite(t *testing.T) {
+	db := setupTestDB(t)
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+	ctx := context.Background()
+
+	user, err := domain.NewUser(uuid.NewString(), "ghost@example.com", "Ghost", time.Now())
+	require.NoError(t, err)
+	user.AssignEventIDs(uuid.NewString)
+
+	// Updating a user that was never created fails and stores no events
+	err = repo.Update(ctx, user)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	assert.NotEmpty(t, user.Events())
+
+	events, err := repo.ListEvents(ctx, domain.EventFilter{UserID: user.ID}, nil, 10)
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestRepository_Tenancy(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, tenancy.Register(db, tenancy.SharedSchema))
+	repo := NewUserRepository(db, idgen.UUIDv7{})
+
+	acme := tenancy.WithTenant(context.Background(), "acme")
+	globex := tenancy.WithTenant(context.Background(), "globex")
+
+	acmeUser, err := domain.NewUser(uuid.NewString(), "shared@example.com", "Acme User", time.Now())
+	require.NoError(t, err)
+	acmeUser.AssignEventIDs(uuid.NewString)
+	require.NoError(t, repo.Create(acme, acmeUser))
+	assert.Equal(t, "acme", acmeUser.TenantID)
+
+	t.Run("tenants may share an email", func(t *testing.T) {
+		globexUser, err := domain.NewUser(uuid.NewString(), "shared@example.com", "Globex User", time.Now())
+		require.NoError(t, err)
+		globexUser.AssignEventIDs(uuid.NewString)
+		require.NoError(t, repo.Create(globex, globexUser))
+
+		found, err := repo.GetByEmail(globex, "shared@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, globexUser.ID, found.ID)
+		assert.Equal(t, "globex", found.TenantID)
+	})
+
+	t.Run("users of another tenant are not found", func(t *testing.T) {
+		_, err := repo.GetByID(globex, acmeUser.ID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		assert.ErrorIs(t, repo.Delete(globex, acmeUser.ID), domain.ErrUserNotFound)
+
+		users, err := repo.List(globex, domain.UserFilter{}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.NotEqual(t, acmeUser.ID, users[0].ID)
+	})
+
+	t.Run("refuses queries without a tenant", func(t *testing.T) {
+		_, err := repo.GetByID(context.Background(), acmeUser.ID)
+		assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+	})
 }
@@ -0,0 +1,218 @@
+// Package readmodel keeps a denormalized copy of users in the
+// user_read_models table, which listings and searches read instead of the
+// users table. The projection service fills it from user events, so heavy
+// read traffic does not contend with writes, and the table can carry
+// indexes tuned for searching without slowing them down.
+//
+// The copy is eventually consistent: a change shows up in listings once its
+// event is projected, usually within milliseconds. Users changed while no
+// projection was following the events are caught up by the periodic
+// rebuild.
+package readmodel
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// saveBatchSize is the number of rows upserted per statement in Save
+const saveBatchSize = 100
+
+// Model is the database model of a user in the read model. It holds the
+// fields listings render; pending email changes stay in the write model.
+type Model struct {
+	ID        string         `gorm:"type:uuid;primaryKey"`
+	TenantID  string         `gorm:"type:varchar(56);not null;default:'';index:idx_user_read_models_listing,priority:1"`
+	Email     string         `gorm:"type:text;not null"`
+	Name      string         `gorm:"type:text;not null"`
+	Username  *string        `gorm:"type:varchar(30)"`
+	AvatarKey *string        `gorm:"type:varchar(255)"`
+	Status    string         `gorm:"type:varchar(20);not null"`
+	CreatedAt time.Time      `gorm:"not null;index:idx_user_read_models_listing,priority:2,sort:desc"`
+	UpdatedAt time.Time      `gorm:"not null"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	// ChangedAt is the later of UpdatedAt and DeletedAt. It orders the
+	// versions of a user, as a soft delete leaves UpdatedAt unchanged.
+	ChangedAt time.Time `gorm:"not null"`
+
+	// ProjectedAt is when the row was last saved
+	ProjectedAt time.Time `gorm:"not null;index"`
+}
+
+// TableName specifies the table name for Model
+func (Model) TableName() string {
+	return "user_read_models"
+}
+
+// Store implements the UserReadModel port on the user_read_models table.
+// It takes part in transactions started by database.Transactor, and lists
+// from a replica when the context allows it.
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore creates a read model stored in db
+func NewStore(db *gorm.DB) ports.UserReadModel {
+	return &Store{db: db}
+}
+
+// Save upserts the users. A row is only replaced by a version of the user
+// changed at the same time or later, so a rebuild reading a user before an
+// event is projected cannot undo the event.
+func (s *Store) Save(ctx context.Context, users []*domain.User, projectedAt time.Time) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	models := make([]*Model, len(users))
+	for i, user := range users {
+		models[i] = toModel(user, projectedAt)
+	}
+
+	return database.Conn(ctx, s.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"tenant_id", "email", "name", "username", "avatar_key", "status",
+				"created_at", "updated_at", "deleted_at", "changed_at", "projected_at",
+			}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "user_read_models.changed_at <= excluded.changed_at"},
+			}},
+		}).
+		CreateInBatches(models, saveBatchSize).Error
+}
+
+// Remove deletes the rows of the users
+func (s *Store) Remove(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return database.Conn(ctx, s.db).Unscoped().Where("id IN ?", ids).Delete(&Model{}).Error
+}
+
+// RemoveStale deletes the rows last saved before the time
+func (s *Store) RemoveStale(ctx context.Context, before time.Time) (int, error) {
+	result := database.Conn(ctx, s.db).Unscoped().Where("projected_at < ?", before).Delete(&Model{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return int(result.RowsAffected), nil
+}
+
+// List retrieves users matching the filter with pagination, newest first
+func (s *Store) List(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error) {
+	var models []*Model
+
+	result := applyFilter(database.Conn(ctx, s.db), filter).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&models)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	users := make([]*domain.User, len(models))
+	for i, model := range models {
+		users[i] = toDomainUser(model)
+	}
+	return users, nil
+}
+
+// applyFilter adds the filter conditions to a query. The email and name
+// conditions are served by trigram indexes on PostgreSQL.
+func applyFilter(query *gorm.DB, filter domain.UserFilter) *gorm.DB {
+	if filter.IncludeDeleted {
+		query = query.Unscoped()
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if filter.EmailContains != "" {
+		query = query.Where("LOWER(email) LIKE ? ESCAPE '\\'", containsPattern(filter.EmailContains))
+	}
+	if filter.NameContains != "" {
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '\\'", containsPattern(filter.NameContains))
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	return query
+}
+
+// containsPattern builds a case-insensitive LIKE pattern matching value
+// anywhere, escaping LIKE wildcards in the value itself
+func containsPattern(value string) string {
+	return "%" + likeEscaper.Replace(strings.ToLower(value)) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// toModel converts a user to its row, saved at projectedAt
+func toModel(user *domain.User, projectedAt time.Time) *Model {
+	model := &Model{
+		ID:          user.ID,
+		TenantID:    user.TenantID,
+		Email:       user.Email,
+		Name:        user.Name,
+		Status:      string(user.Status),
+		CreatedAt:   user.CreatedAt,
+		UpdatedAt:   user.UpdatedAt,
+		ChangedAt:   user.UpdatedAt,
+		ProjectedAt: projectedAt,
+	}
+
+	if user.Username != "" {
+		model.Username = &user.Username
+	}
+	if user.AvatarKey != "" {
+		model.AvatarKey = &user.AvatarKey
+	}
+	if user.DeletedAt != nil {
+		model.DeletedAt = gorm.DeletedAt{Time: *user.DeletedAt, Valid: true}
+		if user.DeletedAt.After(model.ChangedAt) {
+			model.ChangedAt = *user.DeletedAt
+		}
+	}
+
+	return model
+}
+
+// toDomainUser converts a row to the user it was saved from
+func toDomainUser(model *Model) *domain.User {
+	user := &domain.User{
+		ID:        model.ID,
+		TenantID:  model.TenantID,
+		Email:     model.Email,
+		Name:      model.Name,
+		Status:    domain.Status(model.Status),
+		CreatedAt: model.CreatedAt,
+		UpdatedAt: model.UpdatedAt,
+	}
+
+	if model.Username != nil {
+		user.Username = *model.Username
+	}
+	if model.AvatarKey != nil {
+		user.AvatarKey = *model.AvatarKey
+	}
+	if model.DeletedAt.Valid {
+		deletedAt := model.DeletedAt.Time
+		user.DeletedAt = &deletedAt
+	}
+
+	return user
+}
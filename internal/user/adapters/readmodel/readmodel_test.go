@@ -0,0 +1,162 @@
+package readmodel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+var now = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Model{}))
+	return db
+}
+
+func newUser(id, email, name string, createdAt time.Time) *domain.User {
+	return &domain.User{
+		ID:        id,
+		Email:     email,
+		Name:      name,
+		Status:    domain.StatusActive,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func ids(users []*domain.User) []string {
+	ids := make([]string, len(users))
+	for i, user := range users {
+		ids[i] = user.ID
+	}
+	return ids
+}
+
+func TestStore_SaveAndList(t *testing.T) {
+	store := NewStore(setupTestDB(t))
+	ctx := context.Background()
+
+	ada := newUser("1", "ada@example.com", "Ada Lovelace", now.Add(-2*time.Hour))
+	ada.Username = "ada"
+	ada.AvatarKey = "avatars/1.png"
+	grace := newUser("2", "grace@example.com", "Grace_Hopper", now.Add(-time.Hour))
+	deleted := newUser("3", "alan@example.com", "Alan Turing", now)
+	deletedAt := now.Add(time.Minute)
+	deleted.DeletedAt = &deletedAt
+	require.NoError(t, store.Save(ctx, []*domain.User{ada, grace, deleted}, now))
+
+	users, err := store.List(ctx, domain.UserFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "1"}, ids(users), "newest first, without deleted users")
+	assert.Equal(t, "ada", users[1].Username)
+	assert.Equal(t, "avatars/1.png", users[1].AvatarKey)
+	assert.Equal(t, domain.StatusActive, users[1].Status)
+
+	tests := map[string]struct {
+		filter domain.UserFilter
+		want   []string
+	}{
+		"email":           {domain.UserFilter{EmailContains: "ADA@"}, []string{"1"}},
+		"name":            {domain.UserFilter{NameContains: "lace"}, []string{"1"}},
+		"like wildcards":  {domain.UserFilter{NameContains: "e_h"}, []string{"2"}},
+		"created after":   {domain.UserFilter{CreatedAfter: &grace.CreatedAt}, []string{"2"}},
+		"include deleted": {domain.UserFilter{IncludeDeleted: true}, []string{"3", "2", "1"}},
+		"status":          {domain.UserFilter{Status: domain.StatusSuspended}, []string{}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			users, err := store.List(ctx, tt.filter, 10, 0)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, ids(users))
+		})
+	}
+
+	page, err := store.List(ctx, domain.UserFilter{IncludeDeleted: true}, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, ids(page))
+}
+
+func TestStore_SaveKeepsNewerVersions(t *testing.T) {
+	store := NewStore(setupTestDB(t))
+	ctx := context.Background()
+
+	user := newUser("1", "ada@example.com", "Ada", now)
+	require.NoError(t, store.Save(ctx, []*domain.User{user}, now))
+
+	renamed := *user
+	renamed.Name = "Ada Lovelace"
+	renamed.UpdatedAt = now.Add(time.Minute)
+	require.NoError(t, store.Save(ctx, []*domain.User{&renamed}, now.Add(time.Minute)))
+
+	// A rebuild that read the user before the rename does not undo it
+	require.NoError(t, store.Save(ctx, []*domain.User{user}, now.Add(2*time.Minute)))
+	users, err := store.List(ctx, domain.UserFilter{}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "Ada Lovelace", users[0].Name)
+
+	// A soft delete leaves updated_at unchanged but is newer
+	deleted := renamed
+	deletedAt := now.Add(3 * time.Minute)
+	deleted.DeletedAt = &deletedAt
+	require.NoError(t, store.Save(ctx, []*domain.User{&deleted}, now.Add(3*time.Minute)))
+	require.NoError(t, store.Save(ctx, []*domain.User{&renamed}, now.Add(4*time.Minute)))
+
+	users, err = store.List(ctx, domain.UserFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestStore_Remove(t *testing.T) {
+	store := NewStore(setupTestDB(t))
+	ctx := context.Background()
+
+	require.NoError(t, store.Save(ctx, []*domain.User{
+		newUser("1", "ada@example.com", "Ada", now),
+		newUser("2", "grace@example.com", "Grace", now.Add(time.Second)),
+	}, now))
+	require.NoError(t, store.Save(ctx, []*domain.User{newUser("3", "alan@example.com", "Alan", now.Add(2*time.Second))}, now.Add(time.Hour)))
+
+	removed, err := store.RemoveStale(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed)
+
+	require.NoError(t, store.Remove(ctx, []string{"3"}))
+	require.NoError(t, store.Remove(ctx, nil))
+
+	users, err := store.List(ctx, domain.UserFilter{IncludeDeleted: true}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestStore_ScopesListsToTenants(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, tenancy.Register(db, tenancy.SharedSchema))
+	store := NewStore(db)
+
+	acme := newUser("1", "ada@acme.example", "Ada", now)
+	acme.TenantID = "acme"
+	globex := newUser("2", "ada@globex.example", "Ada", now)
+	globex.TenantID = "globex"
+
+	// The projection saves the users of every tenant
+	require.NoError(t, store.Save(tenancy.AllTenants(context.Background()), []*domain.User{acme, globex}, now))
+
+	users, err := store.List(tenancy.WithTenant(context.Background(), "globex"), domain.UserFilter{}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, "2", users[0].ID)
+	assert.Equal(t, "globex", users[0].TenantID)
+}
@@ -0,0 +1,38 @@
+package readmodel
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// repository lists users from the read model instead of the wrapped
+// repository
+type repository struct {
+	ports.UserRepository
+	readModel ports.UserReadModel
+}
+
+// NewRepository returns repo listing users from readModel. Lookups by ID,
+// email or username and exports still read repo, so they see a change as
+// soon as it is committed.
+func NewRepository(repo ports.UserRepository, readModel ports.UserReadModel) ports.UserRepository {
+	return &repository{UserRepository: repo, readModel: readModel}
+}
+
+// List retrieves users matching the filter from the read model
+func (r *repository) List(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error) {
+	return r.readModel.List(ctx, filter, limit, offset)
+}
+
+// Erase erases the user and removes it from the read model, in the same
+// transaction when ctx carries one. Erasures record no event, so the
+// projection would otherwise keep the user's email and name until the next
+// rebuild.
+func (r *repository) Erase(ctx context.Context, record *domain.ErasureRecord) error {
+	if err := r.UserRepository.Erase(ctx, record); err != nil {
+		return err
+	}
+	return r.readModel.Remove(ctx, []string{record.UserID})
+}
@@ -0,0 +1,56 @@
+package readmodel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/memory"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+func TestRepository_ListsFromReadModel(t *testing.T) {
+	store := NewStore(setupTestDB(t))
+	inner := memory.NewUserRepository(idgen.NewSequential())
+	repo := NewRepository(inner, store)
+	ctx := context.Background()
+
+	user := newUser("1", "ada@example.com", "Ada", now)
+	require.NoError(t, repo.Create(ctx, user))
+
+	users, err := repo.List(ctx, domain.UserFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, users, "the user is listed once projected")
+
+	require.NoError(t, store.Save(ctx, []*domain.User{user}, now))
+	users, err = repo.List(ctx, domain.UserFilter{}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, ids(users))
+
+	found, err := repo.GetByID(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", found.Email, "lookups read the write model")
+}
+
+func TestRepository_EraseRemovesFromReadModel(t *testing.T) {
+	store := NewStore(setupTestDB(t))
+	repo := NewRepository(memory.NewUserRepository(idgen.NewSequential()), store)
+	ctx := context.Background()
+
+	user := newUser("1", "ada@example.com", "Ada", now)
+	require.NoError(t, repo.Create(ctx, user))
+	require.NoError(t, store.Save(ctx, []*domain.User{user}, now))
+
+	err := repo.Erase(ctx, &domain.ErasureRecord{ID: "e1", UserID: "1", ErasedAt: now})
+	require.NoError(t, err)
+
+	users, err := store.List(ctx, domain.UserFilter{IncludeDeleted: true}, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	// A failed erasure leaves the read model alone
+	assert.ErrorIs(t, repo.Erase(ctx, &domain.ErasureRecord{ID: "e2", UserID: "1", ErasedAt: now}), domain.ErrUserNotFound)
+}
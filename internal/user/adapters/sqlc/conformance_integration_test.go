@@ -0,0 +1,57 @@
+//go:build integration
+
+package sqlc
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database/pgtest"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/repotest"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(pgtest.Run(m))
+}
+
+// migrationsTemplate holds the schema built by the migrations, which is
+// the schema sqlc checks the queries against
+var migrationsTemplate = pgtest.Template{
+	Name: "migrations",
+	Migrate: func(db *gorm.DB) error {
+		files, err := filepath.Glob(filepath.Join("..", "..", "..", "..", "migrations", "*.up.sql"))
+		if err != nil {
+			return err
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			migration, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			if err := db.Exec(string(migration)).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// TestRepository_ConformancePostgres runs the repository contract against
+// a real PostgreSQL. Every test case gets an empty database of its own.
+func TestRepository_ConformancePostgres(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) ports.UserRepository {
+		sqlDB, err := pgtest.DB(t, migrationsTemplate).DB()
+		require.NoError(t, err)
+
+		return NewUserRepository(sqlDB, idgen.UUIDv7{})
+	})
+}
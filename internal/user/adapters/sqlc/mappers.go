@@ -0,0 +1,312 @@
+package sqlc
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/sqlc/queries"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+// toCreateUserParams converts a domain.User to the parameters of CreateUser
+func toCreateUserParams(user *domain.User) queries.CreateUserParams {
+	params := queries.CreateUserParams{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Status:    string(user.Status),
+		Username:  nullString(user.Username),
+		AvatarKey: nullString(user.AvatarKey),
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		DeletedAt: nullTime(user.DeletedAt),
+	}
+
+	if pending := user.PendingEmailChange; pending != nil {
+		params.PendingEmail = nullString(pending.Email)
+		params.EmailChangeTokenHash = nullString(pending.TokenHash)
+		params.EmailChangeExpiresAt = nullTime(&pending.ExpiresAt)
+	}
+
+	return params
+}
+
+// userRow is a user in the JSON array taken by CreateUsers. Nil fields are
+// stored as NULL.
+type userRow struct {
+	ID                   string     `json:"id"`
+	Email                string     `json:"email"`
+	Name                 string     `json:"name"`
+	Status               string     `json:"status"`
+	Username             *string    `json:"username"`
+	AvatarKey            *string    `json:"avatar_key"`
+	PendingEmail         *string    `json:"pending_email"`
+	EmailChangeTokenHash *string    `json:"email_change_token_hash"`
+	EmailChangeExpiresAt *time.Time `json:"email_change_expires_at"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+	DeletedAt            *time.Time `json:"deleted_at"`
+}
+
+// toUserRows converts domain.Users to the argument of CreateUsers
+func toUserRows(users []*domain.User) (json.RawMessage, error) {
+	rows := make([]userRow, len(users))
+	for i, user := range users {
+		row := userRow{
+			ID:        user.ID,
+			Email:     user.Email,
+			Name:      user.Name,
+			Status:    string(user.Status),
+			Username:  optionalString(user.Username),
+			AvatarKey: optionalString(user.AvatarKey),
+			CreatedAt: jsonTime(user.CreatedAt),
+			UpdatedAt: jsonTime(user.UpdatedAt),
+		}
+		if user.DeletedAt != nil {
+			deletedAt := jsonTime(*user.DeletedAt)
+			row.DeletedAt = &deletedAt
+		}
+		if pending := user.PendingEmailChange; pending != nil {
+			expiresAt := jsonTime(pending.ExpiresAt)
+			row.PendingEmail = optionalString(pending.Email)
+			row.EmailChangeTokenHash = optionalString(pending.TokenHash)
+			row.EmailChangeExpiresAt = &expiresAt
+		}
+		rows[i] = row
+	}
+
+	return json.Marshal(rows)
+}
+
+// toUpdateUserParams converts a domain.User to the parameters of UpdateUser
+func toUpdateUserParams(user *domain.User) queries.UpdateUserParams {
+	params := queries.UpdateUserParams{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Status:    string(user.Status),
+		Username:  nullString(user.Username),
+		AvatarKey: nullString(user.AvatarKey),
+		UpdatedAt: user.UpdatedAt,
+	}
+
+	if pending := user.PendingEmailChange; pending != nil {
+		params.PendingEmail = nullString(pending.Email)
+		params.EmailChangeTokenHash = nullString(pending.TokenHash)
+		params.EmailChangeExpiresAt = nullTime(&pending.ExpiresAt)
+	}
+
+	return params
+}
+
+// toDomainUser converts a users row to a domain.User
+func toDomainUser(row queries.User) *domain.User {
+	user := &domain.User{
+		ID:        row.ID,
+		Email:     row.Email,
+		Name:      row.Name,
+		Status:    domain.Status(row.Status),
+		Username:  row.Username.String,
+		AvatarKey: row.AvatarKey.String,
+		TenantID:  row.TenantID,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+
+	if row.DeletedAt.Valid {
+		deletedAt := row.DeletedAt.Time
+		user.DeletedAt = &deletedAt
+	}
+
+	if row.LastLoginAt.Valid {
+		lastLoginAt := row.LastLoginAt.Time
+		user.LastLoginAt = &lastLoginAt
+	}
+
+	if row.PendingEmail.Valid && row.EmailChangeTokenHash.Valid && row.EmailChangeExpiresAt.Valid {
+		user.PendingEmailChange = &domain.EmailChange{
+			Email:     row.PendingEmail.String,
+			TokenHash: row.EmailChangeTokenHash.String,
+			ExpiresAt: row.EmailChangeExpiresAt.Time,
+		}
+	}
+
+	return user
+}
+
+// toDomainUsers converts users rows to domain.Users
+func toDomainUsers(rows []queries.User) []*domain.User {
+	users := make([]*domain.User, len(rows))
+	for i, row := range rows {
+		users[i] = toDomainUser(row)
+	}
+
+	return users
+}
+
+// toListUsersParams converts a filter to the parameters of ListUsers,
+// leaving pagination to the caller
+func toListUsersParams(filter domain.UserFilter) queries.ListUsersParams {
+	params := queries.ListUsersParams{
+		IncludeDeleted: filter.IncludeDeleted,
+		Status:         nullString(string(filter.Status)),
+		CreatedAfter:   nullTime(filter.CreatedAfter),
+		CreatedBefore:  nullTime(filter.CreatedBefore),
+	}
+
+	if filter.EmailContains != "" {
+		params.EmailPattern = nullString(containsPattern(filter.EmailContains))
+	}
+	if filter.NameContains != "" {
+		params.NamePattern = nullString(containsPattern(filter.NameContains))
+	}
+
+	return params
+}
+
+// toSavePreferencesParams converts a user's domain.Preferences to the
+// parameters of SavePreferences
+func toSavePreferencesParams(userID string, prefs *domain.Preferences) queries.SavePreferencesParams {
+	return queries.SavePreferencesParams{
+		UserID:             userID,
+		Locale:             prefs.Locale,
+		Timezone:           prefs.Timezone,
+		EmailNotifications: prefs.Notifications.Email,
+		Digest:             string(prefs.Notifications.Digest),
+		UpdatedAt:          prefs.UpdatedAt,
+	}
+}
+
+// toDomainPreferences converts a user_preferences row to a domain.Preferences
+func toDomainPreferences(row queries.UserPreference) *domain.Preferences {
+	return &domain.Preferences{
+		Locale:   row.Locale,
+		Timezone: row.Timezone,
+		Notifications: domain.NotificationPreferences{
+			Email:  row.EmailNotifications,
+			Digest: domain.DigestFrequency(row.Digest),
+		},
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+// toSavePasswordParams converts a user's domain.Password to the parameters
+// of SavePassword
+func toSavePasswordParams(userID string, password *domain.Password) queries.SavePasswordParams {
+	return queries.SavePasswordParams{
+		UserID:    userID,
+		Hash:      password.Hash,
+		UpdatedAt: password.UpdatedAt,
+	}
+}
+
+// toDomainPassword converts a user_passwords row to a domain.Password
+func toDomainPassword(row queries.UserPassword) *domain.Password {
+	return &domain.Password{
+		Hash:      row.Hash,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+// eventRow is an event in the JSON array taken by CreateEvents
+type eventRow struct {
+	ID         string            `json:"id"`
+	UserID     string            `json:"user_id"`
+	Type       string            `json:"type"`
+	Data       map[string]string `json:"data"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// toEventRows converts domain events to the argument of CreateEvents
+func toEventRows(events []domain.Event) (json.RawMessage, error) {
+	rows := make([]eventRow, len(events))
+	for i, event := range events {
+		rows[i] = eventRow{
+			ID:         event.ID,
+			UserID:     event.UserID,
+			Type:       string(event.Type),
+			Data:       event.Data,
+			OccurredAt: jsonTime(event.OccurredAt),
+		}
+	}
+
+	return json.Marshal(rows)
+}
+
+// toDomainEvents converts user_events rows to domain events
+func toDomainEvents(rows []queries.UserEvent) []domain.Event {
+	events := make([]domain.Event, len(rows))
+	for i, row := range rows {
+		events[i] = domain.Event{
+			ID:         row.ID,
+			UserID:     row.UserID,
+			Type:       domain.EventType(row.Type),
+			Data:       row.Data,
+			OccurredAt: row.OccurredAt,
+		}
+	}
+
+	return events
+}
+
+// toCreateLoginEventParams converts a login attempt to its insert parameters
+func toCreateLoginEventParams(login domain.LoginEvent) queries.CreateLoginEventParams {
+	return queries.CreateLoginEventParams{
+		ID:            login.ID,
+		UserID:        login.UserID,
+		Succeeded:     login.Succeeded,
+		FailureReason: string(login.Failure),
+		IpAddress:     login.IP,
+		UserAgent:     login.UserAgent,
+		Country:       login.Country,
+		OccurredAt:    login.OccurredAt,
+	}
+}
+
+// toDomainLogins converts login_events rows to domain login events
+func toDomainLogins(rows []queries.LoginEvent) []domain.LoginEvent {
+	logins := make([]domain.LoginEvent, len(rows))
+	for i, row := range rows {
+		logins[i] = domain.LoginEvent{
+			ID:         row.ID,
+			UserID:     row.UserID,
+			Succeeded:  row.Succeeded,
+			Failure:    domain.LoginFailure(row.FailureReason),
+			IP:         row.IpAddress,
+			UserAgent:  row.UserAgent,
+			Country:    row.Country,
+			OccurredAt: row.OccurredAt,
+		}
+	}
+
+	return logins
+}
+
+// nullString maps an empty string to NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// optionalString maps an empty string to nil
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// jsonTime truncates a time to the microseconds PostgreSQL stores, as the
+// pgx driver does for query parameters; PostgreSQL would round the
+// nanoseconds of a JSON time instead
+func jsonTime(value time.Time) time.Time {
+	return value.Truncate(time.Microsecond)
+}
+
+// nullTime maps a nil time to NULL
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
@@ -0,0 +1,39 @@
+package queries
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// EventData is the payload of a user event, stored as JSON. sqlc.yaml maps
+// the user_events.data column to it.
+type EventData map[string]string
+
+// Scan implements sql.Scanner
+func (d *EventData) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*d = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	default:
+		return fmt.Errorf("cannot scan %T into EventData", src)
+	}
+}
+
+// Value implements driver.Valuer
+func (d EventData) Value() (driver.Value, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,566 @@
+// Package sqlc implements ports.UserRepository with queries generated by
+// sqlc from internal/user/adapters/sqlc/users.sql. The SQL is checked
+// against the migrations at generation time and rows are scanned without
+// reflection. Queries run through database/sql over the pgx driver on the
+// connection pool GORM opened, so the repository joins transactions started
+// by database.Transactor like the GORM repositories do.
+package sqlc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/utc"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/sqlc/queries"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const (
+	// streamBatchSize is the number of users ListStream reads per query
+	streamBatchSize = 500
+
+	// uniqueViolation is the PostgreSQL error code for unique constraint violations
+	uniqueViolation = "23505"
+)
+
+// userRepository implements ports.UserRepository using sqlc-generated queries
+type userRepository struct {
+	db *sql.DB
+	q  *queries.Queries
+
+	// ids names the events the repository records itself
+	ids ports.IDGenerator
+}
+
+// NewUserRepository creates a new PostgreSQL user repository
+func NewUserRepository(db *sql.DB, ids ports.IDGenerator) ports.UserRepository {
+	return &userRepository{
+		db:  db,
+		q:   queries.New(db),
+		ids: ids,
+	}
+}
+
+// queries returns the queries bound to the transaction carried by ctx, if
+// any, or to the repository's database
+func (r *userRepository) queries(ctx context.Context) *queries.Queries {
+	if tx, ok := database.SQLTx(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+// transaction runs fn in a transaction. Inside a transaction carried by ctx
+// it runs in a savepoint instead, so a failed statement does not abort the
+// caller's transaction.
+func (r *userRepository) transaction(ctx context.Context, fn func(q *queries.Queries) error) error {
+	if tx, ok := database.SQLTx(ctx); ok {
+		return savepoint(ctx, tx, func() error {
+			return fn(r.q.WithTx(tx))
+		})
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(r.q.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepoint runs fn inside a savepoint of tx, rolling back to it on error
+func savepoint(ctx context.Context, tx *sql.Tx, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT user_repository"); err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT user_repository"); rbErr != nil {
+			return fmt.Errorf("%w (rollback to savepoint: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT user_repository")
+	return err
+}
+
+// Create creates a new user in the database
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	err := r.transaction(ctx, func(q *queries.Queries) error {
+		if err := q.CreateUser(ctx, toCreateUserParams(user)); err != nil {
+			return err
+		}
+		return createEvents(ctx, q, user.Events())
+	})
+	if err != nil {
+		return mapError(err)
+	}
+
+	user.ClearEvents()
+	return nil
+}
+
+// CreateBatch creates multiple users in a single transaction
+func (r *userRepository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	rows, err := toUserRows(users)
+	if err != nil {
+		return err
+	}
+	var events []domain.Event
+	for _, user := range users {
+		events = append(events, user.Events()...)
+	}
+
+	err = r.transaction(ctx, func(q *queries.Queries) error {
+		// Conflicting users are skipped so all conflicts can be reported;
+		// the transaction is rolled back if any
+		created, err := q.CreateUsers(ctx, rows)
+		if err != nil {
+			return err
+		}
+		if len(created) < len(users) {
+			return batchConflicts(ctx, q, users, created)
+		}
+		return createEvents(ctx, q, events)
+	})
+	if err != nil {
+		return mapError(err)
+	}
+
+	for _, user := range users {
+		user.ClearEvents()
+	}
+	return nil
+}
+
+// FindExistingEmails returns which of the given emails are already taken
+func (r *userRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	existing := []string{}
+	if len(emails) == 0 {
+		return existing, nil
+	}
+
+	lowered := make([]string, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(email)
+	}
+
+	found, err := r.queries(ctx).FindExistingEmails(ctx, lowered)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(existing, found...), nil
+}
+
+// GetByID retrieves a user by ID
+func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	return toDomainUserOrNotFound(r.queries(ctx).GetUserByID(ctx, id))
+}
+
+// GetByIDIncludingDeleted retrieves a user by ID, including soft-deleted users
+func (r *userRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.User, error) {
+	return toDomainUserOrNotFound(r.queries(ctx).GetUserByIDIncludingDeleted(ctx, id))
+}
+
+// GetByUsername retrieves a user by username
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return toDomainUserOrNotFound(r.queries(ctx).GetUserByUsername(ctx, username))
+}
+
+// GetByEmail retrieves a user by email
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return toDomainUserOrNotFound(r.queries(ctx).GetUserByEmail(ctx, email))
+}
+
+// Exists reports whether the user of key exists with a SELECT 1
+func (r *userRepository) Exists(ctx context.Context, key domain.UserKey) (bool, error) {
+	q := r.queries(ctx)
+	switch {
+	case key.ID != "":
+		return q.UserIDExists(ctx, queries.UserIDExistsParams{ID: key.ID, IncludeDeleted: key.IncludeDeleted})
+	case key.Username != "":
+		return q.UsernameExists(ctx, queries.UsernameExistsParams{Username: key.Username, IncludeDeleted: key.IncludeDeleted})
+	default:
+		return q.UserEmailExists(ctx, queries.UserEmailExistsParams{Email: key.Email, IncludeDeleted: key.IncludeDeleted})
+	}
+}
+
+// Update updates an existing user
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	err := r.transaction(ctx, func(q *queries.Queries) error {
+		rows, err := q.UpdateUser(ctx, toUpdateUserParams(user))
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return createEvents(ctx, q, user.Events())
+	})
+	if err != nil {
+		return mapError(err)
+	}
+
+	user.ClearEvents()
+	return nil
+}
+
+// Delete soft-deletes a user by ID
+func (r *userRepository) Delete(ctx context.Context, id string) error {
+	return r.transaction(ctx, func(q *queries.Queries) error {
+		now := utc.Now()
+
+		rows, err := q.SoftDeleteUser(ctx, queries.SoftDeleteUserParams{DeletedAt: now, ID: id})
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return createEvents(ctx, q, []domain.Event{domain.NewEvent(r.ids.NewID(), id, domain.EventUserDeleted, nil, now)})
+	})
+}
+
+// Erase permanently deletes a user and stores the erasure record in the
+// same transaction. Tables holding per-user data reference users with
+// ON DELETE CASCADE, so they are erased along with the user.
+func (r *userRepository) Erase(ctx context.Context, record *domain.ErasureRecord) error {
+	return r.transaction(ctx, func(q *queries.Queries) error {
+		rows, err := q.EraseUser(ctx, record.UserID)
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return q.CreateErasure(ctx, queries.CreateErasureParams{
+			ID:       record.ID,
+			UserID:   record.UserID,
+			ErasedAt: record.ErasedAt,
+		})
+	})
+}
+
+// PurgeDeleted permanently deletes up to limit users soft-deleted before
+// the cutoff
+func (r *userRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	rows, err := r.queries(ctx).PurgeDeletedUsers(ctx, queries.PurgeDeletedUsersParams{
+		Before:   before,
+		RowLimit: int32(limit),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rows), nil
+}
+
+// CountDeleted counts users soft-deleted before the cutoff
+func (r *userRepository) CountDeleted(ctx context.Context, before time.Time) (int, error) {
+	count, err := r.queries(ctx).CountDeletedUsers(ctx, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
+
+// Restore undoes the soft delete of a user by ID
+func (r *userRepository) Restore(ctx context.Context, id string) error {
+	return r.transaction(ctx, func(q *queries.Queries) error {
+		now := utc.Now()
+
+		rows, err := q.RestoreUser(ctx, queries.RestoreUserParams{UpdatedAt: now, ID: id})
+		if err != nil {
+			return err
+		}
+
+		if rows == 0 {
+			return domain.ErrUserNotFound
+		}
+
+		return createEvents(ctx, q, []domain.Event{domain.NewEvent(r.ids.NewID(), id, domain.EventUserRestored, nil, now)})
+	})
+}
+
+// GetPreferences retrieves the preferences saved by a user
+func (r *userRepository) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	row, err := r.queries(ctx).GetPreferences(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrPreferencesNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainPreferences(row), nil
+}
+
+// SavePreferences creates or replaces a user's preferences
+func (r *userRepository) SavePreferences(ctx context.Context, userID string, prefs *domain.Preferences) error {
+	return r.queries(ctx).SavePreferences(ctx, toSavePreferencesParams(userID, prefs))
+}
+
+// GetPassword retrieves a user's stored password
+func (r *userRepository) GetPassword(ctx context.Context, userID string) (*domain.Password, error) {
+	row, err := r.queries(ctx).GetPassword(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrPasswordNotSet
+		}
+		return nil, err
+	}
+
+	return toDomainPassword(row), nil
+}
+
+// SavePassword creates or replaces a user's stored password
+func (r *userRepository) SavePassword(ctx context.Context, userID string, password *domain.Password) error {
+	return r.queries(ctx).SavePassword(ctx, toSavePasswordParams(userID, password))
+}
+
+// ListEvents retrieves up to limit events matching the filter, newest
+// first, starting after the cursor when one is given
+func (r *userRepository) ListEvents(ctx context.Context, filter domain.EventFilter, cursor *domain.EventCursor, limit int) ([]domain.Event, error) {
+	var (
+		rows []queries.UserEvent
+		err  error
+	)
+
+	userID := nullString(filter.UserID)
+	eventType := nullString(string(filter.Type))
+	if cursor == nil {
+		rows, err = r.queries(ctx).ListEvents(ctx, queries.ListEventsParams{
+			UserID:   userID,
+			Type:     eventType,
+			RowLimit: int32(limit),
+		})
+	} else {
+		rows, err = r.queries(ctx).ListEventsAfter(ctx, queries.ListEventsAfterParams{
+			UserID:     userID,
+			Type:       eventType,
+			OccurredAt: cursor.OccurredAt,
+			ID:         cursor.ID,
+			RowLimit:   int32(limit),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainEvents(rows), nil
+}
+
+// RecordLogin stores a login attempt and sets the user's last login time
+// when it succeeded
+func (r *userRepository) RecordLogin(ctx context.Context, login domain.LoginEvent) error {
+	return r.transaction(ctx, func(q *queries.Queries) error {
+		if login.Succeeded {
+			rows, err := q.SetLastLoginAt(ctx, queries.SetLastLoginAtParams{LastLoginAt: login.OccurredAt, ID: login.UserID})
+			if err != nil {
+				return err
+			}
+
+			if rows == 0 {
+				return domain.ErrUserNotFound
+			}
+		}
+
+		return q.CreateLoginEvent(ctx, toCreateLoginEventParams(login))
+	})
+}
+
+// ListLogins retrieves up to limit login attempts of the user, newest
+// first, starting after the cursor when one is given
+func (r *userRepository) ListLogins(ctx context.Context, userID string, cursor *domain.EventCursor, limit int) ([]domain.LoginEvent, error) {
+	var (
+		rows []queries.LoginEvent
+		err  error
+	)
+
+	if cursor == nil {
+		rows, err = r.queries(ctx).ListLoginEvents(ctx, queries.ListLoginEventsParams{
+			UserID:   userID,
+			RowLimit: int32(limit),
+		})
+	} else {
+		rows, err = r.queries(ctx).ListLoginEventsAfter(ctx, queries.ListLoginEventsAfterParams{
+			UserID:     userID,
+			OccurredAt: cursor.OccurredAt,
+			ID:         cursor.ID,
+			RowLimit:   int32(limit),
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainLogins(rows), nil
+}
+
+// List retrieves users matching the filter with pagination
+func (r *userRepository) List(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error) {
+	params := toListUsersParams(filter)
+	params.RowLimit = int32(limit)
+	params.RowOffset = int32(offset)
+
+	rows, err := r.queries(ctx).ListUsers(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainUsers(rows), nil
+}
+
+// ListStream calls fn for every user matching the filter. Users are read
+// in pages of streamBatchSize, each continuing after the last user of the
+// previous one, so memory use does not grow with the number of users.
+func (r *userRepository) ListStream(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	list := toListUsersParams(filter)
+	params := queries.ListUsersPageParams{
+		IncludeDeleted: list.IncludeDeleted,
+		Status:         list.Status,
+		EmailPattern:   list.EmailPattern,
+		NamePattern:    list.NamePattern,
+		CreatedAfter:   list.CreatedAfter,
+		CreatedBefore:  list.CreatedBefore,
+		RowLimit:       streamBatchSize,
+	}
+
+	for {
+		rows, err := r.queries(ctx).ListUsersPage(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if err := fn(toDomainUser(row)); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < streamBatchSize {
+			return nil
+		}
+
+		last := rows[len(rows)-1]
+		params.AfterCreatedAt = sql.NullTime{Time: last.CreatedAt, Valid: true}
+		params.AfterID = sql.NullString{String: last.ID, Valid: true}
+	}
+}
+
+// createEvents stores events in the activity feed
+func createEvents(ctx context.Context, q *queries.Queries, events []domain.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	rows, err := toEventRows(events)
+	if err != nil {
+		return err
+	}
+	return q.CreateEvents(ctx, rows)
+}
+
+// batchConflicts reports the users of a batch that CreateUsers skipped
+// because their email or username is taken. The rows the batch inserted
+// are visible to q, so a user conflicting with an earlier one is reported
+// too.
+func batchConflicts(ctx context.Context, q *queries.Queries, users []*domain.User, created []string) error {
+	emails := make([]string, len(users))
+	for i, user := range users {
+		emails[i] = strings.ToLower(user.Email)
+	}
+	taken, err := q.FindExistingEmails(ctx, emails)
+	if err != nil {
+		return err
+	}
+
+	isCreated := make(map[string]bool, len(created))
+	for _, id := range created {
+		isCreated[id] = true
+	}
+	isTaken := make(map[string]bool, len(taken))
+	for _, email := range taken {
+		isTaken[strings.ToLower(email)] = true
+	}
+
+	var conflicts []domain.BatchConflict
+	for i, user := range users {
+		switch {
+		case isCreated[user.ID]:
+		case isTaken[strings.ToLower(user.Email)]:
+			conflicts = append(conflicts, domain.BatchConflict{Index: i, Err: domain.ErrDuplicateEmail})
+		case user.Username != "":
+			conflicts = append(conflicts, domain.BatchConflict{Index: i, Err: domain.ErrDuplicateUsername})
+		default:
+			// Only the ID can conflict
+			return fmt.Errorf("user %s already exists", user.ID)
+		}
+	}
+
+	return &domain.BatchConflictError{Conflicts: conflicts}
+}
+
+// toDomainUserOrNotFound converts the result of a single-user query,
+// reporting a missing row as domain.ErrUserNotFound
+func toDomainUserOrNotFound(row queries.User, err error) (*domain.User, error) {
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return toDomainUser(row), nil
+}
+
+// containsPattern builds a case-insensitive LIKE pattern matching value
+// anywhere, escaping LIKE wildcards in the value itself
+func containsPattern(value string) string {
+	escaped := likeEscaper.Replace(strings.ToLower(value))
+	return "%" + escaped + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// mapError translates unique constraint violations into domain errors
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != uniqueViolation {
+		return err
+	}
+
+	switch pgErr.ConstraintName {
+	case "idx_users_email_lower":
+		return domain.ErrDuplicateEmail
+	case "idx_users_username":
+		return domain.ErrDuplicateUsername
+	default:
+		return err
+	}
+}
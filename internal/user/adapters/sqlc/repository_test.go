@@ -0,0 +1,169 @@
+package sqlc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/sqlc/queries"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+func TestMapError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "duplicate email",
+			err:  &pgconn.PgError{Code: uniqueViolation, ConstraintName: "idx_users_email_lower"},
+			want: domain.ErrDuplicateEmail,
+		},
+		{
+			name: "duplicate username",
+			err:  fmt.Errorf("insert: %w", &pgconn.PgError{Code: uniqueViolation, ConstraintName: "idx_users_username"}),
+			want: domain.ErrDuplicateUsername,
+		},
+		{
+			name: "other constraint",
+			err:  &pgconn.PgError{Code: uniqueViolation, ConstraintName: "users_pkey"},
+		},
+		{
+			name: "not a constraint violation",
+			err:  errors.New("connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mapError(tt.err)
+			if tt.want == nil {
+				assert.Same(t, tt.err, got)
+				return
+			}
+			assert.ErrorIs(t, got, tt.want)
+		})
+	}
+}
+
+func TestUserMapping_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC)
+	deletedAt := createdAt.Add(time.Hour)
+	user := &domain.User{
+		ID:        "0190a6e0-0000-7000-8000-000000000001",
+		Email:     "john@example.com",
+		Name:      "John Doe",
+		Status:    domain.StatusActive,
+		Username:  "john",
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		DeletedAt: &deletedAt,
+		PendingEmailChange: &domain.EmailChange{
+			Email:     "new@example.com",
+			TokenHash: "hash",
+			ExpiresAt: createdAt.Add(24 * time.Hour),
+		},
+	}
+
+	params := toCreateUserParams(user)
+	assert.False(t, params.AvatarKey.Valid, "empty avatar key is stored as NULL")
+
+	row := queries.User{
+		ID:                   params.ID,
+		Email:                params.Email,
+		Name:                 params.Name,
+		CreatedAt:            params.CreatedAt,
+		UpdatedAt:            params.UpdatedAt,
+		DeletedAt:            params.DeletedAt,
+		PendingEmail:         params.PendingEmail,
+		EmailChangeTokenHash: params.EmailChangeTokenHash,
+		EmailChangeExpiresAt: params.EmailChangeExpiresAt,
+		Status:               params.Status,
+		Username:             params.Username,
+		AvatarKey:            params.AvatarKey,
+	}
+	assert.Equal(t, user, toDomainUser(row))
+}
+
+func TestToUpdateUserParams_ClearsPendingEmailChange(t *testing.T) {
+	params := toUpdateUserParams(&domain.User{ID: "id", Email: "john@example.com"})
+
+	assert.False(t, params.PendingEmail.Valid)
+	assert.False(t, params.EmailChangeTokenHash.Valid)
+	assert.False(t, params.EmailChangeExpiresAt.Valid)
+}
+
+func TestToUserRows(t *testing.T) {
+	createdAt := time.Date(2025, time.January, 15, 9, 30, 0, 123456789, time.UTC)
+	users := []*domain.User{
+		{ID: "id-1", Email: "john@example.com", Name: "John", Status: domain.StatusActive, Username: "john", CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: "id-2", Email: "jane@example.com", Name: "Jane", Status: domain.StatusActive, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	data, err := toUserRows(users)
+	require.NoError(t, err)
+
+	var rows []map[string]any
+	require.NoError(t, json.Unmarshal(data, &rows))
+	require.Len(t, rows, 2)
+	assert.Equal(t, "john", rows[0]["username"])
+	assert.Nil(t, rows[1]["username"], "empty username is stored as NULL")
+	assert.Nil(t, rows[1]["deleted_at"])
+	assert.Equal(t, "2025-01-15T09:30:00.123456Z", rows[0]["created_at"], "times are truncated to microseconds")
+}
+
+func TestToEventRows(t *testing.T) {
+	occurredAt := time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC)
+	events := []domain.Event{
+		{ID: "e1", UserID: "u1", Type: domain.EventUserCreated, OccurredAt: occurredAt},
+		{ID: "e2", UserID: "u1", Type: domain.EventEmailChanged, Data: map[string]string{"from": "old@example.com"}, OccurredAt: occurredAt},
+	}
+
+	data, err := toEventRows(events)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[
+		{"id":"e1","user_id":"u1","type":"user.created","data":null,"occurred_at":"2025-01-15T09:30:00Z"},
+		{"id":"e2","user_id":"u1","type":"user.email_changed","data":{"from":"old@example.com"},"occurred_at":"2025-01-15T09:30:00Z"}
+	]`, string(data))
+}
+
+func TestToListUsersParams(t *testing.T) {
+	after := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	params := toListUsersParams(domain.UserFilter{
+		EmailContains: "100%_Sure",
+		Status:        domain.StatusSuspended,
+		CreatedAfter:  &after,
+	})
+
+	assert.Equal(t, `%100\%\_sure%`, params.EmailPattern.String)
+	assert.False(t, params.NamePattern.Valid, "unset filters are NULL")
+	assert.Equal(t, "suspended", params.Status.String)
+	assert.Equal(t, after, params.CreatedAfter.Time)
+	assert.False(t, params.CreatedBefore.Valid)
+}
+
+func TestEventData(t *testing.T) {
+	value, err := queries.EventData{"from": "old@example.com"}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, `{"from":"old@example.com"}`, value)
+
+	var data queries.EventData
+	require.NoError(t, data.Scan([]byte(value.(string))))
+	assert.Equal(t, queries.EventData{"from": "old@example.com"}, data)
+
+	value, err = queries.EventData(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value, "missing data is stored as NULL")
+
+	require.NoError(t, data.Scan(nil))
+	assert.Nil(t, data)
+}
@@ -0,0 +1,223 @@
+-- name: CreateUser :exec
+INSERT INTO users (
+    id, email, name, status, username, avatar_key,
+    pending_email, email_change_token_hash, email_change_expires_at,
+    created_at, updated_at, deleted_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6,
+    $7, $8, $9,
+    $10, $11, $12
+);
+
+-- name: CreateUsers :many
+-- Inserts a JSON array of users in one statement. Users whose ID, email or
+-- username is taken, including by an earlier user of the array, are skipped;
+-- the IDs of the inserted users are returned.
+INSERT INTO users (
+    id, email, name, status, username, avatar_key,
+    pending_email, email_change_token_hash, email_change_expires_at,
+    created_at, updated_at, deleted_at
+)
+SELECT
+    u.id, u.email, u.name, u.status, u.username, u.avatar_key,
+    u.pending_email, u.email_change_token_hash, u.email_change_expires_at,
+    u.created_at, u.updated_at, u.deleted_at
+FROM jsonb_to_recordset(@users::jsonb) AS u (
+    id UUID, email VARCHAR, name VARCHAR, status VARCHAR, username VARCHAR, avatar_key VARCHAR,
+    pending_email VARCHAR, email_change_token_hash VARCHAR, email_change_expires_at TIMESTAMP,
+    created_at TIMESTAMP, updated_at TIMESTAMP, deleted_at TIMESTAMP
+)
+ON CONFLICT DO NOTHING
+RETURNING id;
+
+-- name: FindExistingEmails :many
+-- Soft-deleted rows still hold their email in the unique index
+SELECT email FROM users
+WHERE LOWER(email) = ANY(@emails::text[]);
+
+-- name: GetUserByID :one
+SELECT * FROM users
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: GetUserByIDIncludingDeleted :one
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: GetUserByUsername :one
+SELECT * FROM users
+WHERE username = @username::text AND deleted_at IS NULL;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users
+WHERE LOWER(email) = LOWER(@email::text) AND deleted_at IS NULL;
+
+-- name: UserIDExists :one
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE id = @id AND (deleted_at IS NULL OR @include_deleted::bool)
+);
+
+-- name: UsernameExists :one
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE username = @username::text AND (deleted_at IS NULL OR @include_deleted::bool)
+);
+
+-- name: UserEmailExists :one
+SELECT EXISTS (
+    SELECT 1 FROM users
+    WHERE LOWER(email) = LOWER(@email::text) AND (deleted_at IS NULL OR @include_deleted::bool)
+);
+
+-- name: UpdateUser :execrows
+-- Every column is written so cleared fields, e.g. a confirmed pending
+-- email change, become NULL
+UPDATE users SET
+    email = $2,
+    name = $3,
+    status = $4,
+    username = $5,
+    avatar_key = $6,
+    pending_email = $7,
+    email_change_token_hash = $8,
+    email_change_expires_at = $9,
+    updated_at = $10
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: SoftDeleteUser :execrows
+UPDATE users SET deleted_at = @deleted_at::timestamp
+WHERE id = @id AND deleted_at IS NULL;
+
+-- name: RestoreUser :execrows
+UPDATE users SET deleted_at = NULL, updated_at = @updated_at
+WHERE id = @id AND deleted_at IS NOT NULL;
+
+-- name: EraseUser :execrows
+DELETE FROM users
+WHERE id = $1;
+
+-- name: CreateErasure :exec
+INSERT INTO user_erasures (id, user_id, erased_at)
+VALUES ($1, $2, $3);
+
+-- name: PurgeDeletedUsers :execrows
+-- Deleting in bounded batches keeps row locks short
+DELETE FROM users
+WHERE id IN (
+    SELECT id FROM users
+    WHERE deleted_at IS NOT NULL AND deleted_at < @before::timestamp
+    ORDER BY deleted_at
+    LIMIT @row_limit
+);
+
+-- name: CountDeletedUsers :one
+SELECT COUNT(*) FROM users
+WHERE deleted_at IS NOT NULL AND deleted_at < @before::timestamp;
+
+-- name: GetPreferences :one
+SELECT * FROM user_preferences
+WHERE user_id = $1;
+
+-- name: SavePreferences :exec
+INSERT INTO user_preferences (
+    user_id, locale, timezone, email_notifications, digest, updated_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    locale = EXCLUDED.locale,
+    timezone = EXCLUDED.timezone,
+    email_notifications = EXCLUDED.email_notifications,
+    digest = EXCLUDED.digest,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: GetPassword :one
+SELECT * FROM user_passwords
+WHERE user_id = $1;
+
+-- name: SavePassword :exec
+INSERT INTO user_passwords (
+    user_id, hash, updated_at
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (user_id) DO UPDATE SET
+    hash = EXCLUDED.hash,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: CreateEvents :exec
+-- Inserts a JSON array of events in one statement
+INSERT INTO user_events (id, user_id, type, data, occurred_at)
+SELECT e.id, e.user_id, e.type, e.data, e.occurred_at
+FROM jsonb_to_recordset(@events::jsonb) AS e (
+    id UUID, user_id UUID, type VARCHAR, data JSONB, occurred_at TIMESTAMP
+);
+
+-- name: ListEvents :many
+SELECT * FROM user_events
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'))
+  AND (sqlc.narg('type')::text IS NULL OR type = sqlc.narg('type'))
+ORDER BY occurred_at DESC, id DESC
+LIMIT @row_limit;
+
+-- name: ListEventsAfter :many
+-- Keyset pagination: the ID breaks ties between events of the same instant
+SELECT * FROM user_events
+WHERE (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'))
+  AND (sqlc.narg('type')::text IS NULL OR type = sqlc.narg('type'))
+  AND (occurred_at < @occurred_at OR (occurred_at = @occurred_at AND id < @id))
+ORDER BY occurred_at DESC, id DESC
+LIMIT @row_limit;
+
+-- name: CreateLoginEvent :exec
+INSERT INTO login_events (
+    id, user_id, succeeded, failure_reason, ip_address, user_agent, country, occurred_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+);
+
+-- name: SetLastLoginAt :execrows
+-- The users trigger leaves updated_at alone when only last_login_at changes
+UPDATE users SET last_login_at = @last_login_at::timestamp
+WHERE id = @id;
+
+-- name: ListLoginEvents :many
+SELECT * FROM login_events
+WHERE user_id = @user_id
+ORDER BY occurred_at DESC, id DESC
+LIMIT @row_limit;
+
+-- name: ListLoginEventsAfter :many
+-- Keyset pagination, as for ListEventsAfter
+SELECT * FROM login_events
+WHERE user_id = @user_id
+  AND (occurred_at < @occurred_at OR (occurred_at = @occurred_at AND id < @id))
+ORDER BY occurred_at DESC, id DESC
+LIMIT @row_limit;
+
+-- name: ListUsers :many
+SELECT * FROM users
+WHERE (@include_deleted::boolean OR deleted_at IS NULL)
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('email_pattern')::text IS NULL OR LOWER(email) LIKE sqlc.narg('email_pattern') ESCAPE '\')
+  AND (sqlc.narg('name_pattern')::text IS NULL OR LOWER(name) LIKE sqlc.narg('name_pattern') ESCAPE '\')
+  AND (sqlc.narg('created_after')::timestamp IS NULL OR created_at >= sqlc.narg('created_after'))
+  AND (sqlc.narg('created_before')::timestamp IS NULL OR created_at < sqlc.narg('created_before'))
+ORDER BY created_at DESC
+LIMIT @row_limit OFFSET @row_offset;
+
+-- name: ListUsersPage :many
+-- Pages through users by keyset for ListStream. The ID breaks ties
+-- between users created at the same instant.
+SELECT * FROM users
+WHERE (@include_deleted::boolean OR deleted_at IS NULL)
+  AND (sqlc.narg('status')::text IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('email_pattern')::text IS NULL OR LOWER(email) LIKE sqlc.narg('email_pattern') ESCAPE '\')
+  AND (sqlc.narg('name_pattern')::text IS NULL OR LOWER(name) LIKE sqlc.narg('name_pattern') ESCAPE '\')
+  AND (sqlc.narg('created_after')::timestamp IS NULL OR created_at >= sqlc.narg('created_after'))
+  AND (sqlc.narg('created_before')::timestamp IS NULL OR created_at < sqlc.narg('created_before'))
+  AND (sqlc.narg('after_created_at')::timestamp IS NULL
+       OR created_at < sqlc.narg('after_created_at')
+       OR (created_at = sqlc.narg('after_created_at') AND id < sqlc.narg('after_id')::uuid))
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit;
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// liveStore computes the statistics from the repository on every request.
+// It serves the memory storage driver, which has no views and holds few
+// enough users to count them each time.
+type liveStore struct {
+	repo  ports.UserRepository
+	clock ports.Clock
+}
+
+// NewLiveStore creates a statistics store counting the users of repo
+func NewLiveStore(repo ports.UserRepository, clock ports.Clock) ports.UserStatsStore {
+	return &liveStore{repo: repo, clock: clock}
+}
+
+// Refresh does nothing; the statistics are computed when requested
+func (s *liveStore) Refresh(ctx context.Context) error {
+	return nil
+}
+
+// Stats counts the users of the repository
+func (s *liveStore) Stats(ctx context.Context, since time.Time) (*domain.UserStats, error) {
+	stats := &domain.UserStats{
+		ByStatus:    make(map[domain.Status]int),
+		RefreshedAt: s.clock.Now(),
+	}
+
+	signups := make(map[time.Time]int)
+	err := s.repo.ListStream(ctx, domain.UserFilter{IncludeDeleted: true}, func(user *domain.User) error {
+		if user.DeletedAt != nil {
+			stats.Deleted++
+		} else {
+			stats.ByStatus[user.Status]++
+		}
+		if day := domain.StartOfDay(user.CreatedAt); !day.Before(since) {
+			signups[day]++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for day, count := range signups {
+		stats.SignupsPerDay = append(stats.SignupsPerDay, domain.SignupCount{Start: day, Signups: count})
+	}
+	slices.SortFunc(stats.SignupsPerDay, func(a, b domain.SignupCount) int {
+		return a.Start.Compare(b.Start)
+	})
+	return stats, nil
+}
@@ -0,0 +1,102 @@
+// Package stats serves user statistics from the user_signups_daily and
+// user_status_counts materialized views. Counting the users table on every
+// request would slow down as it grows, so the views are refreshed by a
+// scheduled job and requests only read the few rows they hold per tenant.
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// views are the materialized views refreshed by Refresh
+var views = []string{"user_signups_daily", "user_status_counts"}
+
+// DailySignupsModel is a row of the user_signups_daily view: the users of
+// a tenant created on a day, deleted or not
+type DailySignupsModel struct {
+	TenantID string    `gorm:"type:varchar(56);primaryKey"`
+	Day      time.Time `gorm:"type:date;primaryKey"`
+	Signups  int       `gorm:"not null"`
+}
+
+// TableName specifies the table name for DailySignupsModel
+func (DailySignupsModel) TableName() string {
+	return "user_signups_daily"
+}
+
+// StatusCountsModel is a row of the user_status_counts view: the users of
+// a tenant in a status, either deleted or not
+type StatusCountsModel struct {
+	TenantID    string    `gorm:"type:varchar(56);primaryKey"`
+	Status      string    `gorm:"type:varchar(20);primaryKey"`
+	Deleted     bool      `gorm:"primaryKey"`
+	Users       int       `gorm:"not null"`
+	RefreshedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for StatusCountsModel
+func (StatusCountsModel) TableName() string {
+	return "user_status_counts"
+}
+
+// Store implements the UserStatsStore port on the materialized views. It
+// reads from a replica when the context allows it.
+type Store struct {
+	db *gorm.DB
+}
+
+// NewStore creates a statistics store reading the views of db
+func NewStore(db *gorm.DB) ports.UserStatsStore {
+	return &Store{db: db}
+}
+
+// Refresh recomputes the views. They are refreshed concurrently, so
+// requests keep reading the previous numbers meanwhile.
+func (s *Store) Refresh(ctx context.Context) error {
+	for _, view := range views {
+		if err := s.db.WithContext(ctx).Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + view).Error; err != nil {
+			return fmt.Errorf("failed to refresh %s: %w", view, err)
+		}
+	}
+	return nil
+}
+
+// Stats returns the statistics of the tenant of ctx as of the last refresh
+func (s *Store) Stats(ctx context.Context, since time.Time) (*domain.UserStats, error) {
+	var counts []StatusCountsModel
+	if err := database.Conn(ctx, s.db).Find(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	var days []DailySignupsModel
+	err := database.Conn(ctx, s.db).
+		Where("day >= ?", since).
+		Order("day").
+		Find(&days).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &domain.UserStats{ByStatus: make(map[domain.Status]int)}
+	for _, count := range counts {
+		if count.Deleted {
+			stats.Deleted += count.Users
+		} else {
+			stats.ByStatus[domain.Status(count.Status)] += count.Users
+		}
+		// Every row of a refresh has the same time
+		stats.RefreshedAt = count.RefreshedAt
+	}
+	for _, day := range days {
+		stats.SignupsPerDay = append(stats.SignupsPerDay, domain.SignupCount{Start: day.Day, Signups: day.Signups})
+	}
+	return stats, nil
+}
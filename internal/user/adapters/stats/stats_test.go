@@ -0,0 +1,121 @@
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/memory"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+var now = time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+// date returns midnight UTC of the day
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// setupTestDB creates the views as plain tables, as SQLite has no
+// materialized views
+func setupTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&DailySignupsModel{}, &StatusCountsModel{}))
+	return db
+}
+
+func TestStore_Stats(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, db.Create([]*StatusCountsModel{
+		{Status: "active", Users: 7, RefreshedAt: now},
+		{Status: "suspended", Users: 2, RefreshedAt: now},
+		{Status: "active", Deleted: true, Users: 3, RefreshedAt: now},
+	}).Error)
+	require.NoError(t, db.Create([]*DailySignupsModel{
+		{Day: date(2026, 2, 1), Signups: 4},
+		{Day: date(2026, 3, 3), Signups: 5},
+		{Day: date(2026, 3, 1), Signups: 1},
+	}).Error)
+
+	stats, err := NewStore(db).Stats(context.Background(), date(2026, 3, 1))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[domain.Status]int{domain.StatusActive: 7, domain.StatusSuspended: 2}, stats.ByStatus)
+	assert.Equal(t, 3, stats.Deleted)
+	assert.Equal(t, []domain.SignupCount{
+		{Start: date(2026, 3, 1), Signups: 1},
+		{Start: date(2026, 3, 3), Signups: 5},
+	}, stats.SignupsPerDay)
+	assert.True(t, now.Equal(stats.RefreshedAt))
+}
+
+func TestStore_StatsWithoutRefresh(t *testing.T) {
+	stats, err := NewStore(setupTestDB(t)).Stats(context.Background(), date(2026, 3, 1))
+	require.NoError(t, err)
+
+	assert.Empty(t, stats.ByStatus)
+	assert.Empty(t, stats.SignupsPerDay)
+	assert.True(t, stats.RefreshedAt.IsZero())
+}
+
+func TestStore_ScopesStatsToTenants(t *testing.T) {
+	db := setupTestDB(t)
+	require.NoError(t, tenancy.Register(db, tenancy.SharedSchema))
+
+	ctx := tenancy.AllTenants(context.Background())
+	require.NoError(t, db.WithContext(ctx).Create([]*StatusCountsModel{
+		{TenantID: "acme", Status: "active", Users: 7, RefreshedAt: now},
+		{TenantID: "globex", Status: "active", Users: 2, RefreshedAt: now},
+	}).Error)
+	require.NoError(t, db.WithContext(ctx).Create([]*DailySignupsModel{
+		{TenantID: "acme", Day: date(2026, 3, 3), Signups: 7},
+		{TenantID: "globex", Day: date(2026, 3, 3), Signups: 2},
+	}).Error)
+
+	stats, err := NewStore(db).Stats(tenancy.WithTenant(context.Background(), "globex"), date(2026, 3, 1))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[domain.Status]int{domain.StatusActive: 2}, stats.ByStatus)
+	assert.Equal(t, []domain.SignupCount{{Start: date(2026, 3, 3), Signups: 2}}, stats.SignupsPerDay)
+}
+
+func TestLiveStore_Stats(t *testing.T) {
+	repo := memory.NewUserRepository(idgen.NewSequential())
+	ctx := context.Background()
+
+	create := func(id, email string, createdAt time.Time) *domain.User {
+		user, err := domain.NewUser(id, email, "User "+id, createdAt)
+		require.NoError(t, err)
+		require.NoError(t, repo.Create(ctx, user))
+		return user
+	}
+	create("00000000-0000-0000-0000-000000000001", "ada@example.com", date(2026, 2, 1))
+	create("00000000-0000-0000-0000-000000000002", "grace@example.com", date(2026, 3, 3).Add(time.Hour))
+	suspended := create("00000000-0000-0000-0000-000000000003", "alan@example.com", date(2026, 3, 3).Add(2*time.Hour))
+	require.NoError(t, suspended.Suspend(now))
+	require.NoError(t, repo.Update(ctx, suspended))
+	create("00000000-0000-0000-0000-000000000004", "edsger@example.com", date(2026, 3, 4))
+	require.NoError(t, repo.Delete(ctx, "00000000-0000-0000-0000-000000000004"))
+
+	stats, err := NewLiveStore(repo, clock.NewFake(now)).Stats(ctx, date(2026, 3, 1))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[domain.Status]int{domain.StatusActive: 2, domain.StatusSuspended: 1}, stats.ByStatus)
+	assert.Equal(t, 1, stats.Deleted)
+	assert.Equal(t, []domain.SignupCount{
+		{Start: date(2026, 3, 3), Signups: 2},
+		{Start: date(2026, 3, 4), Signups: 1},
+	}, stats.SignupsPerDay)
+	assert.Equal(t, now, stats.RefreshedAt)
+}
@@ -0,0 +1,54 @@
+package domain
+
+// AvailabilityStatus tells whether a new user could claim an identifier,
+// such as an email or a username
+type AvailabilityStatus string
+
+const (
+	// Available identifiers are free to claim
+	Available AvailabilityStatus = "available"
+
+	// Taken identifiers belong to a user, including a soft-deleted one
+	Taken AvailabilityStatus = "taken"
+
+	// Invalid identifiers are malformed or reserved, so no user can claim them
+	Invalid AvailabilityStatus = "invalid"
+)
+
+// AvailabilityCheck holds the identifiers a signup form asks about. Empty
+// identifiers are not checked.
+type AvailabilityCheck struct {
+	Email    string
+	Username string
+}
+
+// Availability is the availability of one identifier. Value is the
+// identifier normalized as it would be stored, and Err tells why an
+// Invalid one cannot be claimed.
+type Availability struct {
+	Value  string
+	Status AvailabilityStatus
+	Err    error
+}
+
+// AvailabilityResult holds the availability of each identifier of a
+// check; those not asked about are nil
+type AvailabilityResult struct {
+	Email    *Availability
+	Username *Availability
+}
+
+// CheckEmail reports whether email, once normalized, has the format of an
+// email address
+func CheckEmail(email string) error {
+	if !isValidEmail(NormalizeEmail(email)) {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+// CheckNewUsername reports whether username, once normalized, can be
+// claimed: it has the format of a username and is not reserved
+func CheckNewUsername(username string) error {
+	return isValidUsername(NormalizeUsername(username))
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEmail(t *testing.T) {
+	assert.NoError(t, CheckEmail(" Jane@Example.com "), "normalized first")
+	assert.ErrorIs(t, CheckEmail("jane@"), ErrInvalidEmail)
+	assert.ErrorIs(t, CheckEmail("jane"), ErrInvalidEmail)
+}
+
+func TestCheckNewUsername(t *testing.T) {
+	assert.NoError(t, CheckNewUsername(" Jane.Doe "), "normalized first")
+	assert.ErrorIs(t, CheckNewUsername("Admin"), ErrReservedUsername)
+	assert.ErrorIs(t, CheckNewUsername("ja"), ErrInvalidUsername)
+}
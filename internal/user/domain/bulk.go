@@ -0,0 +1,26 @@
+package domain
+
+// BulkMode controls how a bulk creation reacts to failing items
+type BulkMode string
+
+const (
+	// BulkModeAtomic creates either all items or none of them
+	BulkModeAtomic BulkMode = "atomic"
+
+	// BulkModeBestEffort creates every valid item and reports the rest
+	BulkModeBestEffort BulkMode = "best_effort"
+)
+
+// NewUserInput holds the data for one user of a bulk creation
+type NewUserInput struct {
+	Email string
+	Name  string
+}
+
+// BulkCreateResult reports the outcome of one item of a bulk creation.
+// Exactly one of User and Err is set.
+type BulkCreateResult struct {
+	Index int
+	User  *User
+	Err   error
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// EmailChange represents an email change awaiting confirmation
+type EmailChange struct {
+	Email     string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// ChangeEmail validates and applies a new email immediately
+func (u *User) ChangeEmail(email string, now time.Time) error {
+	email = NormalizeEmail(email)
+	if !isValidEmail(email) {
+		return validation.Field("email", ErrInvalidEmail)
+	}
+
+	if email != u.Email {
+		u.record(EventEmailChanged, map[string]string{"email": email}, now)
+	}
+	u.Email = email
+	u.PendingEmailChange = nil
+	u.UpdatedAt = now
+	return nil
+}
+
+// RequestEmailChange records a pending email change and returns the
+// confirmation token that must be delivered to the new address.
+// Only a hash of the token is kept on the user. The token expires ttl
+// after now.
+func (u *User) RequestEmailChange(email string, ttl time.Duration, now time.Time) (string, error) {
+	email = NormalizeEmail(email)
+	if !isValidEmail(email) {
+		return "", validation.Field("email", ErrInvalidEmail)
+	}
+
+	token := rand.Text()
+
+	u.PendingEmailChange = &EmailChange{
+		Email:     email,
+		TokenHash: hashToken(token),
+		ExpiresAt: now.Add(ttl),
+	}
+	u.UpdatedAt = now
+	u.record(EventEmailChangeRequested, map[string]string{"email": email}, now)
+	return token, nil
+}
+
+// ConfirmEmailChange applies the pending email change if the token matches
+// and has not expired by now
+func (u *User) ConfirmEmailChange(token string, now time.Time) error {
+	pending := u.PendingEmailChange
+	if pending == nil {
+		return ErrNoPendingEmailChange
+	}
+
+	if now.After(pending.ExpiresAt) {
+		return ErrInvalidEmailChangeToken
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashToken(token)), []byte(pending.TokenHash)) != 1 {
+		return ErrInvalidEmailChangeToken
+	}
+
+	return u.ChangeEmail(pending.Email, now)
+}
+
+// hashToken returns the hex-encoded SHA-256 digest of a token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_ChangeEmail(t *testing.T) {
+	user, err := NewUser(testID, "old@example.com", "Test User", testNow)
+	require.NoError(t, err)
+
+	later := testNow.Add(time.Minute)
+	err = user.ChangeEmail("new@example.com", later)
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", user.Email)
+	assert.Nil(t, user.PendingEmailChange)
+	assert.Equal(t, later, user.UpdatedAt)
+}
+
+func TestUser_ChangeEmail_InvalidEmail(t *testing.T) {
+	user, err := NewUser(testID, "old@example.com", "Test User", testNow)
+	require.NoError(t, err)
+
+	err = user.ChangeEmail("invalid-email", testNow)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+	assert.Equal(t, "old@example.com", user.Email)
+}
+
+func TestUser_RequestEmailChange(t *testing.T) {
+	user, err := NewUser(testID, "old@example.com", "Test User", testNow)
+	require.NoError(t, err)
+
+	token, err := user.RequestEmailChange("new@example.com", time.Hour, testNow)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+
+	// Email is not changed until confirmed
+	assert.Equal(t, "old@example.com", user.Email)
+	require.NotNil(t, user.PendingEmailChange)
+	assert.Equal(t, "new@example.com", user.PendingEmailChange.Email)
+	assert.NotEqual(t, token, user.PendingEmailChange.TokenHash)
+	assert.Equal(t, testNow.Add(time.Hour), user.PendingEmailChange.ExpiresAt)
+}
+
+func TestUser_RequestEmailChange_InvalidEmail(t *testing.T) {
+	user, err := NewUser(testID, "old@example.com", "Test User", testNow)
+	require.NoError(t, err)
+
+	_, err = user.RequestEmailChange("invalid-email", time.Hour, testNow)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+	assert.Nil(t, user.PendingEmailChange)
+}
+
+func TestUser_ConfirmEmailChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		confirmIn   time.Duration
+		useToken    func(token string) string
+		expectedErr error
+	}{
+		{name: "valid token", confirmIn: 59 * time.Minute, useToken: func(token string) string { return token }},
+		{name: "wrong token", confirmIn: time.Minute, useToken: func(string) string { return "wrong" }, expectedErr: ErrInvalidEmailChangeToken},
+		{name: "expired token", confirmIn: 61 * time.Minute, useToken: func(token string) string { return token }, expectedErr: ErrInvalidEmailChangeToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser(testID, "old@example.com", "Test User", testNow)
+			require.NoError(t, err)
+
+			token, err := user.RequestEmailChange("new@example.com", time.Hour, testNow)
+			require.NoError(t, err)
+
+			err = user.ConfirmEmailChange(tt.useToken(token), testNow.Add(tt.confirmIn))
+			if tt.expectedErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Equal(t, "old@example.com", user.Email)
+				assert.NotNil(t, user.PendingEmailChange)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "new@example.com", user.Email)
+				assert.Nil(t, user.PendingEmailChange)
+			}
+		})
+	}
+}
+
+func TestUser_ConfirmEmailChange_NoPendingChange(t *testing.T) {
+	user, err := NewUser(testID, "old@example.com", "Test User", testNow)
+	require.NoError(t, err)
+
+	err = user.ConfirmEmailChange("token", testNow)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNoPendingEmailChange)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// ErasureRecord is the compliance record kept after a user's personal data
+// has been erased. It intentionally holds no personal data itself.
+type ErasureRecord struct {
+	ID       string
+	UserID   string
+	ErasedAt time.Time
+}
+
+// NewErasureRecord creates the compliance record with the given ID for a
+// user erased at now
+func NewErasureRecord(id, userID string, now time.Time) *ErasureRecord {
+	return &ErasureRecord{
+		ID:       id,
+		UserID:   userID,
+		ErasedAt: now,
+	}
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewErasureRecord(t *testing.T) {
+	record := NewErasureRecord(testID, "user-123", testNow)
+
+	assert.Equal(t, testID, record.ID)
+	assert.Equal(t, "user-123", record.UserID)
+	assert.Equal(t, testNow, record.ErasedAt)
+}
@@ -1,17 +1,247 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
+
+// Code is a stable, machine-readable error code. Messages may be reworded,
+// so clients branch on codes instead.
+type Code string
+
+// Error codes
+const (
+	// CodeInternal is the code of errors that are not domain errors
+	CodeInternal Code = "INTERNAL"
+
+	// CodeValidationFailed is the code of invalid input
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+
+	CodeUserNotFound            Code = "USER_NOT_FOUND"
+	CodeEmailTaken              Code = "EMAIL_TAKEN"
+	CodeEmailUndeliverable      Code = "EMAIL_UNDELIVERABLE"
+	CodeUsernameTaken           Code = "USERNAME_TAKEN"
+	CodeUsernameReserved        Code = "USERNAME_RESERVED"
+	CodeAvatarNotFound          Code = "AVATAR_NOT_FOUND"
+	CodePreferencesNotFound     Code = "PREFERENCES_NOT_FOUND"
+	CodeUserNotDeleted          Code = "USER_NOT_DELETED"
+	CodeInvalidStatusTransition Code = "INVALID_STATUS_TRANSITION"
+	CodeUserSuspended           Code = "USER_SUSPENDED"
+	CodeUserDeactivated         Code = "USER_DEACTIVATED"
+	CodeNoPendingEmailChange    Code = "NO_PENDING_EMAIL_CHANGE"
+	CodeInvalidEmailChangeToken Code = "INVALID_EMAIL_CHANGE_TOKEN"
+	CodeBulkAborted             Code = "BULK_ABORTED"
+	CodeJobNotFound             Code = "JOB_NOT_FOUND"
+	CodeImportInProgress        Code = "IMPORT_IN_PROGRESS"
+	CodePasswordBreached        Code = "PASSWORD_BREACHED"
+	CodePasswordNotSet          Code = "PASSWORD_NOT_SET"
+	CodeIncorrectPassword       Code = "INCORRECT_PASSWORD"
+	CodeInvalidCredentials      Code = "INVALID_CREDENTIALS"
+	CodeObjectNotFound          Code = "OBJECT_NOT_FOUND"
+)
+
+// Error is a domain error carrying a code. The errors below are its
+// sentinels; Wrap attaches the underlying cause to one of them.
+type Error struct {
+	// Code identifies the error for clients
+	Code Code
+
+	// Message describes the error
+	Message string
+
+	// Err is the underlying cause, if any
+	Err error
+}
+
+// NewError creates a domain error
+func NewError(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+	return e.Message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the same domain error, whatever the causes
+// of either, so errors.Is matches a wrapped error to its sentinel
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == e.Message
+}
+
+// Wrap returns a copy of the error caused by err
+func (e *Error) Wrap(err error) *Error {
+	return &Error{Code: e.Code, Message: e.Message, Err: err}
+}
+
+// CodeOf returns the code of the first domain error in err's tree, or
+// CodeInternal when there is none
+func CodeOf(err error) Code {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return CodeInternal
+}
 
 var (
 	// ErrUserNotFound indicates user was not found
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound = NewError(CodeUserNotFound, "user not found")
 
 	// ErrInvalidEmail indicates email format is invalid
-	ErrInvalidEmail = errors.New("invalid email format")
+	ErrInvalidEmail = NewError(CodeValidationFailed, "invalid email format")
 
 	// ErrInvalidName indicates name is invalid
-	ErrInvalidName = errors.New("name must be non-empty and not exceed 255 characters")
+	ErrInvalidName = NewError(CodeValidationFailed, "name must be non-empty, not exceed 255 characters and contain no control characters")
 
 	// ErrDuplicateEmail indicates email already exists
-	ErrDuplicateEmail = errors.New("email already exists")
+	ErrDuplicateEmail = NewError(CodeEmailTaken, "email already exists")
+
+	// ErrUndeliverableEmail indicates the email validation service found the address cannot receive mail
+	ErrUndeliverableEmail = NewError(CodeEmailUndeliverable, "email address cannot receive mail")
+
+	// ErrInvalidUsername indicates username is invalid
+	ErrInvalidUsername = NewError(CodeValidationFailed, "username must be 3-30 lowercase letters, digits or single . _ - separators")
+
+	// ErrReservedUsername indicates the username is reserved
+	ErrReservedUsername = NewError(CodeUsernameReserved, "username is reserved")
+
+	// ErrDuplicateUsername indicates username already exists
+	ErrDuplicateUsername = NewError(CodeUsernameTaken, "username already exists")
+
+	// ErrInvalidImage indicates an uploaded image could not be decoded or is too large
+	ErrInvalidImage = NewError(CodeValidationFailed, "image must be a JPEG, PNG, GIF or WebP of at most 8192x8192 pixels")
+
+	// ErrNoAvatar indicates the user has no avatar
+	ErrNoAvatar = NewError(CodeAvatarNotFound, "user has no avatar")
+
+	// ErrInvalidLocale indicates the locale is not a valid BCP 47 language tag
+	ErrInvalidLocale = NewError(CodeValidationFailed, "locale must be a BCP 47 language tag such as en or en-US")
+
+	// ErrInvalidTimezone indicates the timezone is not a known IANA time zone
+	ErrInvalidTimezone = NewError(CodeValidationFailed, "timezone must be an IANA time zone such as UTC or Europe/Berlin")
+
+	// ErrInvalidDigestFrequency indicates the digest frequency is unknown
+	ErrInvalidDigestFrequency = NewError(CodeValidationFailed, "digest must be one of off, daily, weekly")
+
+	// ErrPreferencesNotFound indicates the user has never saved preferences
+	ErrPreferencesNotFound = NewError(CodePreferencesNotFound, "preferences not found")
+
+	// ErrInvalidCursor indicates the activity feed cursor is malformed
+	ErrInvalidCursor = NewError(CodeValidationFailed, "invalid cursor")
+
+	// ErrUserNotDeleted indicates a restore was attempted on a user that is not deleted
+	ErrUserNotDeleted = NewError(CodeUserNotDeleted, "user is not deleted")
+
+	// ErrInvalidStatus indicates the status is not a known lifecycle state
+	ErrInvalidStatus = NewError(CodeValidationFailed, "status must be one of active, suspended, deactivated")
+
+	// ErrInvalidStatusTransition indicates the state machine does not allow the status change
+	ErrInvalidStatusTransition = NewError(CodeInvalidStatusTransition, "status transition not allowed")
+
+	// ErrUserSuspended indicates a suspended user attempted to log in
+	ErrUserSuspended = NewError(CodeUserSuspended, "user is suspended")
+
+	// ErrUserDeactivated indicates a deactivated user attempted to log in
+	ErrUserDeactivated = NewError(CodeUserDeactivated, "user is deactivated")
+
+	// ErrNoPendingEmailChange indicates there is no email change to confirm
+	ErrNoPendingEmailChange = NewError(CodeNoPendingEmailChange, "no pending email change")
+
+	// ErrInvalidEmailChangeToken indicates the confirmation token is wrong or expired
+	ErrInvalidEmailChangeToken = NewError(CodeInvalidEmailChangeToken, "invalid or expired email change token")
+
+	// ErrBulkAborted indicates an item was not created because another item of an atomic bulk failed
+	ErrBulkAborted = NewError(CodeBulkAborted, "not created because another item in the batch failed")
+
+	// ErrInvalidImportFile indicates the import file is not a valid users CSV
+	ErrInvalidImportFile = NewError(CodeValidationFailed, "import file must be a CSV with email and name columns")
+
+	// ErrInvalidExportFormat indicates the export file format is not supported
+	ErrInvalidExportFormat = NewError(CodeValidationFailed, "unsupported export format")
+
+	// ErrJobNotFound indicates the background job was not found
+	ErrJobNotFound = NewError(CodeJobNotFound, "job not found")
+
+	// ErrImportInProgress indicates another import is running, on this or another instance
+	ErrImportInProgress = NewError(CodeImportInProgress, "another import is in progress")
+
+	// ErrObjectNotFound indicates the stored object, such as an export, does not exist
+	ErrObjectNotFound = NewError(CodeObjectNotFound, "object not found")
+
+	// ErrPasswordTooShort indicates the password has fewer characters than the policy requires
+	ErrPasswordTooShort = NewError(CodeValidationFailed, "password is too short")
+
+	// ErrPasswordTooLong indicates the password has more characters than the policy allows
+	ErrPasswordTooLong = NewError(CodeValidationFailed, "password is too long")
+
+	// ErrPasswordMissingUpper indicates the policy requires an uppercase letter
+	ErrPasswordMissingUpper = NewError(CodeValidationFailed, "password must contain an uppercase letter")
+
+	// ErrPasswordMissingLower indicates the policy requires a lowercase letter
+	ErrPasswordMissingLower = NewError(CodeValidationFailed, "password must contain a lowercase letter")
+
+	// ErrPasswordMissingDigit indicates the policy requires a digit
+	ErrPasswordMissingDigit = NewError(CodeValidationFailed, "password must contain a digit")
+
+	// ErrPasswordMissingSymbol indicates the policy requires a symbol
+	ErrPasswordMissingSymbol = NewError(CodeValidationFailed, "password must contain a symbol")
+
+	// ErrPasswordContainsPersonalInfo indicates the password contains the user's email or name
+	ErrPasswordContainsPersonalInfo = NewError(CodeValidationFailed, "password must not contain the email address or name")
+
+	// ErrPasswordBreached indicates the password appears in known data breaches
+	ErrPasswordBreached = NewError(CodePasswordBreached, "password has appeared in a data breach")
+
+	// ErrPasswordNotSet indicates the user has no password
+	ErrPasswordNotSet = NewError(CodePasswordNotSet, "password not set")
+
+	// ErrIncorrectPassword indicates the current password given does not match
+	ErrIncorrectPassword = NewError(CodeIncorrectPassword, "current password is incorrect")
+
+	// ErrInvalidCredentials indicates a login with an unknown email or a wrong password
+	ErrInvalidCredentials = NewError(CodeInvalidCredentials, "invalid email or password")
 )
+
+// BatchConflict is a user of a batch that was not created because its
+// email or username is taken
+type BatchConflict struct {
+	// Index is the position of the user in the batch
+	Index int
+
+	// Err is ErrDuplicateEmail or ErrDuplicateUsername
+	Err error
+}
+
+// BatchConflictError reports the users of a batch whose email or username is
+// taken, by a stored user or by an earlier user of the same batch. None of
+// the batch was created. errors.Is matches the errors of the conflicts.
+type BatchConflictError struct {
+	Conflicts []BatchConflict
+}
+
+// Error implements the error interface
+func (e *BatchConflictError) Error() string {
+	if len(e.Conflicts) == 1 {
+		return fmt.Sprintf("batch user %d: %v", e.Conflicts[0].Index, e.Conflicts[0].Err)
+	}
+	return fmt.Sprintf("%d batch users conflict, first %d: %v", len(e.Conflicts), e.Conflicts[0].Index, e.Conflicts[0].Err)
+}
+
+// Unwrap returns the errors of the conflicts
+func (e *BatchConflictError) Unwrap() []error {
+	errs := make([]error, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		errs[i] = conflict.Err
+	}
+	return errs
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchConflictError(t *testing.T) {
+	err := error(&BatchConflictError{Conflicts: []BatchConflict{
+		{Index: 1, Err: ErrDuplicateEmail},
+		{Index: 3, Err: ErrDuplicateUsername},
+	}})
+
+	assert.ErrorIs(t, err, ErrDuplicateEmail)
+	assert.ErrorIs(t, err, ErrDuplicateUsername)
+	assert.NotErrorIs(t, err, ErrUserNotFound)
+	assert.Equal(t, "2 batch users conflict, first 1: email already exists", err.Error())
+
+	var conflicts *BatchConflictError
+	assert.True(t, errors.As(err, &conflicts))
+	assert.Len(t, conflicts.Conflicts, 2)
+}
+
+func TestBatchConflictError_Single(t *testing.T) {
+	err := &BatchConflictError{Conflicts: []BatchConflict{{Index: 0, Err: ErrDuplicateEmail}}}
+
+	assert.Equal(t, "batch user 0: email already exists", err.Error())
+}
+
+func TestError_Wrap(t *testing.T) {
+	cause := errors.New("mailbox does not exist")
+	err := error(ErrUndeliverableEmail.Wrap(cause))
+
+	assert.ErrorIs(t, err, ErrUndeliverableEmail)
+	assert.ErrorIs(t, err, cause)
+	assert.NotErrorIs(t, err, ErrInvalidEmail)
+	assert.Equal(t, "email address cannot receive mail: mailbox does not exist", err.Error())
+	assert.Equal(t, CodeEmailUndeliverable, CodeOf(err))
+}
+
+func TestCodeOf(t *testing.T) {
+	assert.Equal(t, CodeUserNotFound, CodeOf(ErrUserNotFound))
+	assert.Equal(t, CodeValidationFailed, CodeOf(fmt.Errorf("create user: %w", ErrInvalidName)))
+	assert.Equal(t, CodeEmailTaken, CodeOf(&BatchConflictError{Conflicts: []BatchConflict{{Index: 0, Err: ErrDuplicateEmail}}}))
+	assert.Equal(t, CodeInternal, CodeOf(errors.New("connection refused")))
+	assert.Equal(t, CodeInternal, CodeOf(nil))
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+// EventType identifies what happened to a user
+type EventType string
+
+// User event types recorded in the activity feed
+const (
+	EventUserCreated          EventType = "user.created"
+	EventNameChanged          EventType = "user.name_changed"
+	EventUsernameChanged      EventType = "user.username_changed"
+	EventEmailChangeRequested EventType = "user.email_change_requested"
+	EventEmailChanged         EventType = "user.email_changed"
+	EventStatusChanged        EventType = "user.status_changed"
+	EventAvatarUpdated        EventType = "user.avatar_updated"
+	EventAvatarRemoved        EventType = "user.avatar_removed"
+	EventUserDeleted          EventType = "user.deleted"
+	EventUserRestored         EventType = "user.restored"
+)
+
+// Event records a change to a user. Data holds the new values of the
+// changed fields, keyed by field name. TraceContext holds the W3C trace
+// context headers, such as traceparent, of the request that published the
+// event; it is not stored.
+type Event struct {
+	ID           string
+	UserID       string
+	Type         EventType
+	Data         map[string]string
+	OccurredAt   time.Time
+	TraceContext map[string]string
+}
+
+// NewEvent creates an event with the given ID for the user, occurring at now
+func NewEvent(id, userID string, eventType EventType, data map[string]string, now time.Time) Event {
+	return Event{
+		ID:     id,
+		UserID: userID,
+		Type:   eventType,
+		Data:   data,
+		// Stored timestamps have microsecond precision; truncating keeps
+		// cursors built from in-memory events comparable with stored ones
+		OccurredAt: now.Truncate(time.Microsecond),
+	}
+}
+
+// Events returns the events recorded on the user since it was loaded or
+// last saved
+func (u *User) Events() []Event {
+	return u.events
+}
+
+// ClearEvents discards the recorded events once they have been persisted
+func (u *User) ClearEvents() {
+	u.events = nil
+}
+
+// AssignEventIDs gives the recorded events that have no ID yet one from
+// newID. Services call it before saving the user.
+func (u *User) AssignEventIDs(newID func() string) {
+	for i := range u.events {
+		if u.events[i].ID == "" {
+			u.events[i].ID = newID()
+		}
+	}
+}
+
+// record appends an event for a change made to the user at now. The event
+// gets its ID from AssignEventIDs.
+func (u *User) record(eventType EventType, data map[string]string, now time.Time) {
+	u.events = append(u.events, NewEvent("", u.ID, eventType, data, now))
+}
+
+// EventCursor marks a position in a user's activity feed, which is ordered
+// from newest to oldest
+type EventCursor struct {
+	OccurredAt time.Time
+	ID         string
+}
+
+// CursorAfter returns the cursor continuing the feed after the event
+func CursorAfter(event Event) EventCursor {
+	return EventCursor{OccurredAt: event.OccurredAt, ID: event.ID}
+}
+
+// String encodes the cursor as an opaque URL-safe token
+func (c EventCursor) String() string {
+	raw := c.OccurredAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseEventCursor decodes a cursor token produced by EventCursor.String
+func ParseEventCursor(token string) (EventCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return EventCursor{}, ErrInvalidCursor
+	}
+
+	occurredAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return EventCursor{}, ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, occurredAt)
+	if err != nil {
+		return EventCursor{}, ErrInvalidCursor
+	}
+	if id == "" {
+		return EventCursor{}, ErrInvalidCursor
+	}
+
+	return EventCursor{OccurredAt: t, ID: id}, nil
+}
+
+// ActivityPage is one page of a user's activity feed. NextCursor is empty
+// on the last page.
+type ActivityPage struct {
+	Events     []Event
+	NextCursor string
+}
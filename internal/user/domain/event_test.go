@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eventTypes(events []Event) []EventType {
+	types := make([]EventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func TestUser_RecordsEvents(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
+	require.NoError(t, err)
+
+	require.Len(t, user.Events(), 1)
+	created := user.Events()[0]
+	assert.Equal(t, EventUserCreated, created.Type)
+	assert.Equal(t, user.ID, created.UserID)
+	assert.Equal(t, map[string]string{"email": "test@example.com", "name": "Test User"}, created.Data)
+
+	user.ClearEvents()
+	assert.Empty(t, user.Events())
+
+	require.NoError(t, user.UpdateName("New Name", testNow))
+	require.NoError(t, user.ChangeUsername("newname", testNow))
+	require.NoError(t, user.ChangeEmail("new@example.com", testNow))
+	require.NoError(t, user.Suspend(testNow))
+	user.SetAvatar("avatars/key.png", testNow)
+	user.RemoveAvatar(testNow)
+
+	assert.Equal(t, []EventType{
+		EventNameChanged,
+		EventUsernameChanged,
+		EventEmailChanged,
+		EventStatusChanged,
+		EventAvatarUpdated,
+		EventAvatarRemoved,
+	}, eventTypes(user.Events()))
+	assert.Equal(t, map[string]string{"from": "active", "to": "suspended"}, user.Events()[3].Data)
+}
+
+func TestUser_UnchangedValuesRecordNoEvents(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
+	require.NoError(t, err)
+	user.ClearEvents()
+
+	require.NoError(t, user.UpdateName("Test User", testNow))
+	require.NoError(t, user.ChangeEmail("TEST@example.com", testNow))
+	user.RemoveAvatar(testNow)
+
+	assert.Empty(t, user.Events())
+}
+
+func TestUser_EmailChangeFlowEvents(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
+	require.NoError(t, err)
+	user.ClearEvents()
+
+	token, err := user.RequestEmailChange("new@example.com", time.Hour, testNow)
+	require.NoError(t, err)
+	require.NoError(t, user.ConfirmEmailChange(token, testNow))
+
+	assert.Equal(t, []EventType{EventEmailChangeRequested, EventEmailChanged}, eventTypes(user.Events()))
+}
+
+func TestUser_AssignEventIDs(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
+	require.NoError(t, err)
+	assert.Empty(t, user.Events()[0].ID)
+
+	next := 0
+	newID := func() string {
+		next++
+		return fmt.Sprintf("event-%d", next)
+	}
+
+	user.AssignEventIDs(newID)
+	require.NoError(t, user.UpdateName("New Name", testNow))
+	user.AssignEventIDs(newID)
+
+	// Events keep the ID they were given first
+	assert.Equal(t, "event-1", user.Events()[0].ID)
+	assert.Equal(t, "event-2", user.Events()[1].ID)
+}
+
+func TestEventCursor_RoundTrip(t *testing.T) {
+	event := NewEvent("550e8400-e29b-41d4-a716-446655440000", testID, EventUserDeleted, nil, testNow)
+
+	cursor := CursorAfter(event)
+	parsed, err := ParseEventCursor(cursor.String())
+	require.NoError(t, err)
+
+	assert.True(t, parsed.OccurredAt.Equal(event.OccurredAt))
+	assert.Equal(t, event.ID, parsed.ID)
+}
+
+func TestParseEventCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "!!!"},
+		{name: "missing separator", token: "bm9zZXBhcmF0b3I"},
+		{name: "bad time", token: base64.RawURLEncoding.EncodeToString([]byte("yesterday|550e8400-e29b-41d4-a716-446655440000"))},
+		{name: "missing id", token: EventCursor{OccurredAt: time.Now()}.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseEventCursor(tt.token)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// UserFilter narrows which users are returned by listing operations.
+// Zero values mean "no restriction".
+type UserFilter struct {
+	// EmailContains matches users whose email contains the value, ignoring case
+	EmailContains string
+
+	// NameContains matches users whose name contains the value, ignoring case
+	NameContains string
+
+	// CreatedAfter matches users created at or after the time
+	CreatedAfter *time.Time
+
+	// CreatedBefore matches users created before the time
+	CreatedBefore *time.Time
+
+	// Status matches users in the given lifecycle state; empty matches any
+	Status Status
+
+	// IncludeDeleted also returns soft-deleted users
+	IncludeDeleted bool
+}
+
+// UserKey identifies the user an existence check looks for: by ID when
+// set, then by username, and otherwise by email, ignoring case.
+// Soft-deleted users only exist with IncludeDeleted; they keep their email
+// and username until purged.
+type UserKey struct {
+	ID             string
+	Username       string
+	Email          string
+	IncludeDeleted bool
+}
+
+// EventFilter narrows which events are returned by ListEvents or
+// delivered to subscribers. Zero values mean "no restriction".
+type EventFilter struct {
+	// UserID matches the events of one user
+	UserID string
+
+	// Type matches events of the given type
+	Type EventType
+}
+
+// Matches reports whether the event passes the filter
+func (f EventFilter) Matches(event Event) bool {
+	return (f.UserID == "" || event.UserID == f.UserID) &&
+		(f.Type == "" || event.Type == f.Type)
+}
@@ -0,0 +1,37 @@
+package domain
+
+// ImportLineStatus describes what happened to one line of an import
+type ImportLineStatus string
+
+const (
+	ImportLineSkipped ImportLineStatus = "skipped"
+	ImportLineFailed  ImportLineStatus = "failed"
+)
+
+// ImportLineResult reports the outcome of one imported line
+type ImportLineResult struct {
+	Line   int
+	Email  string
+	Status ImportLineStatus
+	Error  string
+}
+
+// ImportReport summarizes an import. Lines only lists skipped and failed
+// lines so large successful imports stay small.
+type ImportReport struct {
+	Created int
+	Skipped int
+	Failed  int
+	Lines   []ImportLineResult
+}
+
+// AddLine records a skipped or failed line and updates the counters
+func (r *ImportReport) AddLine(line ImportLineResult) {
+	switch line.Status {
+	case ImportLineSkipped:
+		r.Skipped++
+	case ImportLineFailed:
+		r.Failed++
+	}
+	r.Lines = append(r.Lines, line)
+}
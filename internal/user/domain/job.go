@@ -0,0 +1,78 @@
+package domain
+
+import "time"
+
+// JobKind identifies what a background job does
+type JobKind string
+
+const (
+	JobKindImport JobKind = "import"
+	JobKindExport JobKind = "export"
+)
+
+// JobStatus represents the state of a background job
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobCompleted JobStatus = "completed"
+	JobFailed    JobStatus = "failed"
+)
+
+// JobProgress counts the rows a job processed. Total is zero while it is
+// not known.
+type JobProgress struct {
+	Processed int
+	Total     int
+}
+
+// Job represents an import or export running in the background. Jobs are
+// stored, so they are resumed when the instance running them stops.
+type Job struct {
+	ID       string
+	TenantID string // tenant the job runs for; empty without multi-tenancy
+	Kind     JobKind
+	Status   JobStatus
+	Progress JobProgress
+
+	// Format is the file format of an export
+	Format string
+
+	// Filter selects the users of an export
+	Filter UserFilter
+
+	// InputKey is the object holding the file of an import
+	InputKey string
+
+	// ResultKey is the object holding the file of a completed export
+	ResultKey string
+
+	// Report is the report of a completed import
+	Report *ImportReport
+
+	Error       string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// Finished reports whether the job completed or failed
+func (j *Job) Finished() bool {
+	return j.Status == JobCompleted || j.Status == JobFailed
+}
+
+// Complete marks the job completed at now
+func (j *Job) Complete(now time.Time) {
+	j.Status = JobCompleted
+	j.UpdatedAt = now
+	j.CompletedAt = &now
+}
+
+// Fail marks the job failed at now with the error
+func (j *Job) Fail(err error, now time.Time) {
+	j.Status = JobFailed
+	j.Error = err.Error()
+	j.UpdatedAt = now
+	j.CompletedAt = &now
+}
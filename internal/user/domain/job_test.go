@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob_Complete(t *testing.T) {
+	job := &Job{Kind: JobKindExport, Status: JobRunning}
+	assert.False(t, job.Finished())
+
+	job.Complete(testNow)
+	assert.True(t, job.Finished())
+	assert.Equal(t, JobCompleted, job.Status)
+	assert.Equal(t, testNow, job.UpdatedAt)
+	require.NotNil(t, job.CompletedAt)
+	assert.Equal(t, testNow, *job.CompletedAt)
+}
+
+func TestJob_Fail(t *testing.T) {
+	job := &Job{Kind: JobKindImport, Status: JobRunning}
+
+	job.Fail(errors.New("connection refused"), testNow)
+	assert.True(t, job.Finished())
+	assert.Equal(t, JobFailed, job.Status)
+	assert.Equal(t, "connection refused", job.Error)
+	require.NotNil(t, job.CompletedAt)
+}
@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxUserAgentLength bounds the user agents stored with logins; clients
+// choose them, so they can be arbitrarily long
+const maxUserAgentLength = 512
+
+// LoginFailure tells why a login attempt failed
+type LoginFailure string
+
+// Login failures recorded in the login history
+const (
+	LoginInvalidPassword LoginFailure = "invalid_password"
+	LoginPasswordNotSet  LoginFailure = "password_not_set"
+	LoginUserSuspended   LoginFailure = "user_suspended"
+	LoginUserDeactivated LoginFailure = "user_deactivated"
+	LoginOtherFailure    LoginFailure = "other"
+)
+
+// LoginClient describes where a login attempt came from. Country is the
+// ISO 3166-1 alpha-2 code of the client's location, such as resolved from
+// its IP address, or empty when unknown.
+type LoginClient struct {
+	IP        string
+	UserAgent string
+	Country   string
+}
+
+// LoginEvent records a login attempt on a user's account. Failure is
+// empty when the attempt succeeded.
+type LoginEvent struct {
+	ID         string
+	UserID     string
+	Succeeded  bool
+	Failure    LoginFailure
+	IP         string
+	UserAgent  string
+	Country    string
+	OccurredAt time.Time
+}
+
+// NewLoginEvent records the login attempt of client on the user's account,
+// at now, under id. A nil err records a successful login; otherwise err
+// tells why the attempt failed.
+func NewLoginEvent(id, userID string, client LoginClient, err error, now time.Time) LoginEvent {
+	return LoginEvent{
+		ID:        id,
+		UserID:    userID,
+		Succeeded: err == nil,
+		Failure:   loginFailure(err),
+		IP:        client.IP,
+		UserAgent: truncateUTF8(client.UserAgent, maxUserAgentLength),
+		Country:   NormalizeCountry(client.Country),
+		// Truncated like events, so cursors match stored logins
+		OccurredAt: now.Truncate(time.Microsecond),
+	}
+}
+
+// loginFailure returns the failure a login error stands for, or "" for nil
+func loginFailure(err error) LoginFailure {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrInvalidCredentials):
+		return LoginInvalidPassword
+	case errors.Is(err, ErrPasswordNotSet):
+		return LoginPasswordNotSet
+	case errors.Is(err, ErrUserSuspended):
+		return LoginUserSuspended
+	case errors.Is(err, ErrUserDeactivated):
+		return LoginUserDeactivated
+	default:
+		return LoginOtherFailure
+	}
+}
+
+// NormalizeCountry returns the upper-case ISO 3166-1 alpha-2 code of
+// country, or "" when it is not one
+func NormalizeCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 || !isUpperLetter(country[0]) || !isUpperLetter(country[1]) {
+		return ""
+	}
+	return country
+}
+
+func isUpperLetter(c byte) bool {
+	return 'A' <= c && c <= 'Z'
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a character
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// CursorAfterLogin returns the cursor continuing a login history after
+// the login. Histories are ordered like activity feeds, newest first.
+func CursorAfterLogin(login LoginEvent) EventCursor {
+	return EventCursor{OccurredAt: login.OccurredAt, ID: login.ID}
+}
+
+// LoginPage is one page of a user's login history. NextCursor is empty on
+// the last page.
+type LoginPage struct {
+	Logins     []LoginEvent
+	NextCursor string
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLoginEvent(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 123456789, time.UTC)
+	client := LoginClient{IP: "203.0.113.7", UserAgent: "curl/8.5.0", Country: "fr"}
+
+	login := NewLoginEvent("login-1", "user-1", client, nil, now)
+	assert.Equal(t, "login-1", login.ID)
+	assert.Equal(t, "user-1", login.UserID)
+	assert.True(t, login.Succeeded)
+	assert.Empty(t, login.Failure)
+	assert.Equal(t, "203.0.113.7", login.IP)
+	assert.Equal(t, "curl/8.5.0", login.UserAgent)
+	assert.Equal(t, "FR", login.Country)
+	assert.Equal(t, now.Truncate(time.Microsecond), login.OccurredAt)
+
+	tests := []struct {
+		err  error
+		want LoginFailure
+	}{
+		{err: ErrInvalidCredentials, want: LoginInvalidPassword},
+		{err: ErrPasswordNotSet, want: LoginPasswordNotSet},
+		{err: ErrUserSuspended, want: LoginUserSuspended},
+		{err: ErrUserDeactivated, want: LoginUserDeactivated},
+		{err: assert.AnError, want: LoginOtherFailure},
+	}
+	for _, tt := range tests {
+		login := NewLoginEvent("login-1", "user-1", client, tt.err, now)
+		assert.False(t, login.Succeeded)
+		assert.Equal(t, tt.want, login.Failure, tt.err.Error())
+	}
+}
+
+func TestNewLoginEvent_TruncatesUserAgent(t *testing.T) {
+	userAgent := strings.Repeat("a", maxUserAgentLength-1) + "é"
+
+	login := NewLoginEvent("login-1", "user-1", LoginClient{UserAgent: userAgent}, nil, time.Now())
+	assert.Equal(t, strings.Repeat("a", maxUserAgentLength-1), login.UserAgent, "characters are not split")
+}
+
+func TestNormalizeCountry(t *testing.T) {
+	assert.Equal(t, "DE", NormalizeCountry(" de "))
+	assert.Equal(t, "", NormalizeCountry("DEU"), "only alpha-2 codes are kept")
+	assert.Equal(t, "", NormalizeCountry("X1"))
+	assert.Equal(t, "", NormalizeCountry(""))
+}
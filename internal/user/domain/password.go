@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// minPersonalInfoLength is the length below which parts of the email and
+// name are too common to be rejected in passwords
+const minPersonalInfoLength = 3
+
+// Password is the stored credential of a user. Only the hash is kept.
+type Password struct {
+	Hash      string
+	UpdatedAt time.Time
+}
+
+// PasswordPolicy holds the rules new passwords must follow. Lengths are
+// counted in characters after NormalizePassword.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+
+	// RejectPersonalInfo rejects passwords containing the user's email
+	// address, its local part, or a word of their name
+	RejectPersonalInfo bool
+}
+
+// Validate reports whether the policy can be satisfied, so a
+// misconfiguration fails at startup
+func (p PasswordPolicy) Validate() error {
+	if p.MinLength < 1 {
+		return errors.New("password policy min_length must be at least 1")
+	}
+	if p.MaxLength < p.MinLength {
+		return fmt.Errorf("password policy max_length %d is below min_length %d", p.MaxLength, p.MinLength)
+	}
+	return nil
+}
+
+// NormalizePassword returns password in Unicode NFC, so the same password
+// typed on different systems hashes the same
+func NormalizePassword(password string) string {
+	return norm.NFC.String(password)
+}
+
+// PasswordCheck is a password to check against a policy, with what it is
+// compared to
+type PasswordCheck struct {
+	Password string
+
+	// Email and Name are the user's, for RejectPersonalInfo
+	Email string
+	Name  string
+
+	// Breached reports that the password appears in known data breaches
+	Breached bool
+}
+
+// Check reports every rule check breaks as a violation of the password
+// field in a *validation.Error, or nil when it follows them all
+func (p PasswordPolicy) Check(check PasswordCheck) error {
+	var v validation.Validator
+
+	password := NormalizePassword(check.Password)
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		v.Check("password", ErrPasswordTooShort.Wrap(fmt.Errorf("must be at least %d characters", p.MinLength)))
+	}
+	if length > p.MaxLength {
+		v.Check("password", ErrPasswordTooLong.Wrap(fmt.Errorf("must be at most %d characters", p.MaxLength)))
+	}
+
+	if p.RequireUpper && !strings.ContainsFunc(password, unicode.IsUpper) {
+		v.Check("password", ErrPasswordMissingUpper)
+	}
+	if p.RequireLower && !strings.ContainsFunc(password, unicode.IsLower) {
+		v.Check("password", ErrPasswordMissingLower)
+	}
+	if p.RequireDigit && !strings.ContainsFunc(password, unicode.IsDigit) {
+		v.Check("password", ErrPasswordMissingDigit)
+	}
+	if p.RequireSymbol && !strings.ContainsFunc(password, isSymbol) {
+		v.Check("password", ErrPasswordMissingSymbol)
+	}
+
+	if p.RejectPersonalInfo && containsPersonalInfo(password, check.Email, check.Name) {
+		v.Check("password", ErrPasswordContainsPersonalInfo)
+	}
+	if check.Breached {
+		v.Check("password", ErrPasswordBreached)
+	}
+
+	return v.Err()
+}
+
+// isSymbol reports whether r is punctuation or a symbol
+func isSymbol(r rune) bool {
+	return unicode.IsPunct(r) || unicode.IsSymbol(r)
+}
+
+// containsPersonalInfo reports whether password contains, ignoring case,
+// the email address, its local part or any part of it, or a word of the
+// name, leaving out parts shorter than minPersonalInfoLength
+func containsPersonalInfo(password, email, name string) bool {
+	password = strings.ToLower(password)
+
+	email = NormalizeEmail(email)
+	local, _, _ := strings.Cut(email, "@")
+	parts := []string{email, local}
+	parts = append(parts, strings.FieldsFunc(local, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})...)
+	parts = append(parts, strings.Fields(strings.ToLower(name))...)
+
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+var strictPolicy = PasswordPolicy{
+	MinLength:          12,
+	MaxLength:          64,
+	RequireUpper:       true,
+	RequireLower:       true,
+	RequireDigit:       true,
+	RequireSymbol:      true,
+	RejectPersonalInfo: true,
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	assert.NoError(t, strictPolicy.Validate())
+	assert.Error(t, PasswordPolicy{MinLength: 0, MaxLength: 64}.Validate())
+	assert.Error(t, PasswordPolicy{MinLength: 12, MaxLength: 8}.Validate())
+}
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name  string
+		check PasswordCheck
+		want  []error
+	}{
+		{name: "valid", check: PasswordCheck{Password: "Tulip-Harbor-42"}},
+		{name: "too short", check: PasswordCheck{Password: "Tu-42"}, want: []error{ErrPasswordTooShort}},
+		{name: "too long", check: PasswordCheck{Password: "Tu-42" + strings.Repeat("x", 60)}, want: []error{ErrPasswordTooLong}},
+		{name: "counts characters not bytes", check: PasswordCheck{Password: "Ünïcødé-Pässwörd-1"}},
+		{name: "missing upper", check: PasswordCheck{Password: "tulip-harbor-42"}, want: []error{ErrPasswordMissingUpper}},
+		{name: "missing lower", check: PasswordCheck{Password: "TULIP-HARBOR-42"}, want: []error{ErrPasswordMissingLower}},
+		{name: "missing digit", check: PasswordCheck{Password: "Tulip-Harbor-xx"}, want: []error{ErrPasswordMissingDigit}},
+		{name: "missing symbol", check: PasswordCheck{Password: "TulipHarbor42x"}, want: []error{ErrPasswordMissingSymbol}},
+		{
+			name:  "every violation",
+			check: PasswordCheck{Password: "jane", Email: "jane@example.com", Breached: true},
+			want: []error{
+				ErrPasswordTooShort, ErrPasswordMissingUpper, ErrPasswordMissingDigit,
+				ErrPasswordMissingSymbol, ErrPasswordContainsPersonalInfo, ErrPasswordBreached,
+			},
+		},
+		{name: "contains email", check: PasswordCheck{Password: "Jane@Example.com-1", Email: "jane@example.com"}, want: []error{ErrPasswordContainsPersonalInfo}},
+		{name: "contains part of local part", check: PasswordCheck{Password: "Smith-Family-99", Email: "jane.smith@example.com"}, want: []error{ErrPasswordContainsPersonalInfo}},
+		{name: "contains name word", check: PasswordCheck{Password: "Tulip-DOE-4242", Name: "Jane Doe"}, want: []error{ErrPasswordContainsPersonalInfo}},
+		{name: "ignores short name words", check: PasswordCheck{Password: "Tulip-Harbor-42", Name: "Al Tu"}},
+		{name: "breached", check: PasswordCheck{Password: "Tulip-Harbor-42", Breached: true}, want: []error{ErrPasswordBreached}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := strictPolicy.Check(tt.check)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			violations := validation.Violations(err)
+			require.Len(t, violations, len(tt.want), err.Error())
+			for i, v := range violations {
+				assert.Equal(t, "password", v.Field)
+				assert.ErrorIs(t, v.Err, tt.want[i])
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_CheckLengthMessage(t *testing.T) {
+	err := strictPolicy.Check(PasswordCheck{Password: "Tu-42"})
+	assert.Equal(t, "password is too short: must be at least 12 characters", err.Error())
+}
+
+func TestNormalizePassword(t *testing.T) {
+	// "é" as a single code point and as "e" plus a combining accent
+	assert.Equal(t, NormalizePassword("caf\u00e9"), NormalizePassword("cafe\u0301"))
+}
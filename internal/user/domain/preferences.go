@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	_ "time/tzdata" // embed the timezone database so validation works on minimal images
+
+	"golang.org/x/text/language"
+)
+
+const maxTimezoneLength = 64
+
+// DigestFrequency is how often a user receives a summary email
+type DigestFrequency string
+
+const (
+	// DigestOff disables summary emails
+	DigestOff DigestFrequency = "off"
+
+	// DigestDaily sends a summary email every day
+	DigestDaily DigestFrequency = "daily"
+
+	// DigestWeekly sends a summary email every week
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+// IsValid reports whether the frequency is a known digest frequency
+func (f DigestFrequency) IsValid() bool {
+	switch f {
+	case DigestOff, DigestDaily, DigestWeekly:
+		return true
+	default:
+		return false
+	}
+}
+
+// NotificationPreferences holds a user's notification settings
+type NotificationPreferences struct {
+	// Email enables transactional notification emails
+	Email bool
+
+	// Digest is how often a summary email is sent
+	Digest DigestFrequency
+}
+
+// Preferences holds a user's settings. Users who never saved preferences
+// get the configured defaults, with a zero UpdatedAt.
+type Preferences struct {
+	Locale        string // BCP 47 language tag, e.g. en-US
+	Timezone      string // IANA time zone name, e.g. Europe/Berlin
+	Notifications NotificationPreferences
+	UpdatedAt     time.Time
+}
+
+// PreferencesUpdate holds the fields of a preferences update.
+// Nil fields are reset to their defaults.
+type PreferencesUpdate struct {
+	Locale             *string
+	Timezone           *string
+	EmailNotifications *bool
+	Digest             *string
+}
+
+// ApplyTo returns the defaults overridden by the fields set in the update,
+// validated and normalized
+func (u PreferencesUpdate) ApplyTo(defaults Preferences) (Preferences, error) {
+	prefs := defaults
+	if u.Locale != nil {
+		prefs.Locale = *u.Locale
+	}
+	if u.Timezone != nil {
+		prefs.Timezone = *u.Timezone
+	}
+	if u.EmailNotifications != nil {
+		prefs.Notifications.Email = *u.EmailNotifications
+	}
+	if u.Digest != nil {
+		prefs.Notifications.Digest = DigestFrequency(*u.Digest)
+	}
+
+	return NormalizePreferences(prefs)
+}
+
+// NormalizePreferences validates preferences and returns them in canonical form
+func NormalizePreferences(prefs Preferences) (Preferences, error) {
+	tag, err := language.Parse(strings.TrimSpace(prefs.Locale))
+	if err != nil {
+		return Preferences{}, ErrInvalidLocale
+	}
+	prefs.Locale = tag.String()
+
+	prefs.Timezone = strings.TrimSpace(prefs.Timezone)
+	if !isValidTimezone(prefs.Timezone) {
+		return Preferences{}, ErrInvalidTimezone
+	}
+
+	prefs.Notifications.Digest = DigestFrequency(strings.ToLower(strings.TrimSpace(string(prefs.Notifications.Digest))))
+	if !prefs.Notifications.Digest.IsValid() {
+		return Preferences{}, ErrInvalidDigestFrequency
+	}
+
+	return prefs, nil
+}
+
+// isValidTimezone reports whether tz is a known IANA time zone name.
+// "Local" is rejected because it depends on the server.
+func isValidTimezone(tz string) bool {
+	if tz == "" || tz == "Local" || len(tz) > maxTimezoneLength {
+		return false
+	}
+
+	_, err := time.LoadLocation(tz)
+	return err == nil
+}
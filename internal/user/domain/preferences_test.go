@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDefaults = Preferences{
+	Locale:        "en",
+	Timezone:      "UTC",
+	Notifications: NotificationPreferences{Email: true, Digest: DigestWeekly},
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestNormalizePreferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefs   Preferences
+		want    Preferences
+		wantErr error
+	}{
+		{
+			name:  "canonicalizes values",
+			prefs: Preferences{Locale: " en-us ", Timezone: " Europe/Berlin ", Notifications: NotificationPreferences{Digest: "Daily"}},
+			want:  Preferences{Locale: "en-US", Timezone: "Europe/Berlin", Notifications: NotificationPreferences{Digest: DigestDaily}},
+		},
+		{name: "invalid locale", prefs: Preferences{Locale: "not a locale", Timezone: "UTC", Notifications: NotificationPreferences{Digest: DigestOff}}, wantErr: ErrInvalidLocale},
+		{name: "empty locale", prefs: Preferences{Timezone: "UTC", Notifications: NotificationPreferences{Digest: DigestOff}}, wantErr: ErrInvalidLocale},
+		{name: "unknown timezone", prefs: Preferences{Locale: "en", Timezone: "Mars/Olympus", Notifications: NotificationPreferences{Digest: DigestOff}}, wantErr: ErrInvalidTimezone},
+		{name: "server local timezone", prefs: Preferences{Locale: "en", Timezone: "Local", Notifications: NotificationPreferences{Digest: DigestOff}}, wantErr: ErrInvalidTimezone},
+		{name: "unknown digest", prefs: Preferences{Locale: "en", Timezone: "UTC", Notifications: NotificationPreferences{Digest: "hourly"}}, wantErr: ErrInvalidDigestFrequency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePreferences(tt.prefs)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPreferencesUpdate_ApplyTo(t *testing.T) {
+	emailOff := false
+
+	t.Run("empty update yields defaults", func(t *testing.T) {
+		prefs, err := PreferencesUpdate{}.ApplyTo(testDefaults)
+		require.NoError(t, err)
+		assert.Equal(t, testDefaults, prefs)
+	})
+
+	t.Run("set fields override defaults", func(t *testing.T) {
+		prefs, err := PreferencesUpdate{
+			Timezone:           strPtr("Asia/Tokyo"),
+			EmailNotifications: &emailOff,
+			Digest:             strPtr("off"),
+		}.ApplyTo(testDefaults)
+		require.NoError(t, err)
+		assert.Equal(t, "en", prefs.Locale)
+		assert.Equal(t, "Asia/Tokyo", prefs.Timezone)
+		assert.False(t, prefs.Notifications.Email)
+		assert.Equal(t, DigestOff, prefs.Notifications.Digest)
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		_, err := PreferencesUpdate{Locale: strPtr("??")}.ApplyTo(testDefaults)
+		assert.ErrorIs(t, err, ErrInvalidLocale)
+	})
+}
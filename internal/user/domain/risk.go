@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// LoginSignal names something suspicious about a login attempt
+type LoginSignal string
+
+// Signals raised by login risk evaluation
+const (
+	// SignalNewCountry is raised by a login from a country the user never
+	// logged in from
+	SignalNewCountry LoginSignal = "new_country"
+
+	// SignalNewDevice is raised by a login from a device the user never
+	// logged in from
+	SignalNewDevice LoginSignal = "new_device"
+
+	// SignalVelocity is raised by a login following many failed attempts
+	SignalVelocity LoginSignal = "velocity"
+)
+
+// LoginAttempt is a login whose password matched, to be evaluated before
+// it is trusted. History holds the user's latest earlier login attempts,
+// newest first.
+type LoginAttempt struct {
+	User    *User
+	Client  LoginClient
+	At      time.Time
+	History []LoginEvent
+}
+
+// RiskAssessment is the verdict on a login attempt. StepUp asks for a
+// second factor before the login is trusted; Notify tells the user about
+// the login by email. The zero value lets the login through silently.
+type RiskAssessment struct {
+	Signals []LoginSignal
+	StepUp  bool
+	Notify  bool
+}
+
+// Authentication is the outcome of a successful password check. Callers
+// must verify a second factor before trusting the login when
+// Risk.StepUp is set.
+type Authentication struct {
+	User *User
+	Risk RiskAssessment
+}
@@ -0,0 +1,89 @@
+package domain
+
+import "time"
+
+// day is the length of the periods signups are counted in
+const day = 24 * time.Hour
+
+// SignupCount is the number of users who signed up in a period
+type SignupCount struct {
+	// Start is the first day of the period, at midnight UTC
+	Start time.Time
+
+	// Signups counts the users created in the period, deleted or not
+	Signups int
+}
+
+// UserStats summarizes the users of a tenant as of RefreshedAt
+type UserStats struct {
+	// Total counts the users not deleted
+	Total int
+
+	// Active counts the users not deleted in StatusActive
+	Active int
+
+	// ByStatus counts the users not deleted in each lifecycle state
+	ByStatus map[Status]int
+
+	// Deleted counts the soft-deleted users awaiting purge
+	Deleted int
+
+	// SignupsPerDay holds the signups of each day, oldest first
+	SignupsPerDay []SignupCount
+
+	// SignupsPerWeek holds the signups of each week starting on Monday,
+	// oldest first. The first week only counts the days of SignupsPerDay.
+	SignupsPerWeek []SignupCount
+
+	// RefreshedAt is when the numbers were computed; zero when they were
+	// never computed for the tenant, such as before its first user
+	RefreshedAt time.Time
+
+	// ExpiresAt is when the numbers are due to be computed again
+	ExpiresAt time.Time
+}
+
+// StartOfDay returns midnight UTC of the day of t
+func StartOfDay(t time.Time) time.Time {
+	return t.UTC().Truncate(day)
+}
+
+// StartOfWeek returns midnight UTC of the Monday of the week of t
+func StartOfWeek(t time.Time) time.Time {
+	start := StartOfDay(t)
+	// Weekday counts from Sunday
+	offset := (int(start.Weekday()) + 6) % 7
+	return start.AddDate(0, 0, -offset)
+}
+
+// DailySignups returns the signups of each of the days days ending on the
+// day of now, oldest first. Days missing from counts had no signups.
+func DailySignups(counts []SignupCount, days int, now time.Time) []SignupCount {
+	byDay := make(map[time.Time]int, len(counts))
+	for _, count := range counts {
+		byDay[StartOfDay(count.Start)] += count.Signups
+	}
+
+	first := StartOfDay(now).AddDate(0, 0, 1-days)
+	daily := make([]SignupCount, days)
+	for i := range daily {
+		start := first.AddDate(0, 0, i)
+		daily[i] = SignupCount{Start: start, Signups: byDay[start]}
+	}
+	return daily
+}
+
+// WeeklySignups sums the signups of the days per week starting on Monday,
+// oldest first
+func WeeklySignups(daily []SignupCount) []SignupCount {
+	var weekly []SignupCount
+	for _, count := range daily {
+		start := StartOfWeek(count.Start)
+		if n := len(weekly); n > 0 && weekly[n-1].Start.Equal(start) {
+			weekly[n-1].Signups += count.Signups
+			continue
+		}
+		weekly = append(weekly, SignupCount{Start: start, Signups: count.Signups})
+	}
+	return weekly
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// date returns midnight UTC of the day
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestStartOfWeek(t *testing.T) {
+	// 2026-03-04 is a Wednesday
+	assert.Equal(t, date(2026, 3, 2), StartOfWeek(time.Date(2026, 3, 4, 18, 30, 0, 0, time.UTC)))
+	assert.Equal(t, date(2026, 3, 2), StartOfWeek(date(2026, 3, 2)))
+	assert.Equal(t, date(2026, 3, 2), StartOfWeek(date(2026, 3, 8)), "Sunday ends the week")
+}
+
+func TestDailySignups(t *testing.T) {
+	now := time.Date(2026, 3, 4, 18, 30, 0, 0, time.UTC)
+
+	daily := DailySignups([]SignupCount{
+		{Start: date(2026, 2, 20), Signups: 9}, // before the range
+		{Start: date(2026, 3, 2), Signups: 2},
+		{Start: date(2026, 3, 4), Signups: 5},
+	}, 4, now)
+
+	assert.Equal(t, []SignupCount{
+		{Start: date(2026, 3, 1), Signups: 0},
+		{Start: date(2026, 3, 2), Signups: 2},
+		{Start: date(2026, 3, 3), Signups: 0},
+		{Start: date(2026, 3, 4), Signups: 5},
+	}, daily)
+}
+
+func TestWeeklySignups(t *testing.T) {
+	weekly := WeeklySignups([]SignupCount{
+		{Start: date(2026, 2, 28), Signups: 1}, // Saturday
+		{Start: date(2026, 3, 1), Signups: 2},
+		{Start: date(2026, 3, 2), Signups: 3}, // Monday
+		{Start: date(2026, 3, 3), Signups: 4},
+	})
+
+	assert.Equal(t, []SignupCount{
+		{Start: date(2026, 2, 23), Signups: 3},
+		{Start: date(2026, 3, 2), Signups: 7},
+	}, weekly)
+	assert.Empty(t, WeeklySignups(nil))
+}
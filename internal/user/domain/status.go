@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// Status is the lifecycle state of a user
+type Status string
+
+const (
+	StatusActive      Status = "active"
+	StatusSuspended   Status = "suspended"
+	StatusDeactivated Status = "deactivated"
+)
+
+// statusTransitions lists the states each state may move to
+var statusTransitions = map[Status][]Status{
+	StatusActive:      {StatusSuspended, StatusDeactivated},
+	StatusSuspended:   {StatusActive, StatusDeactivated},
+	StatusDeactivated: {StatusActive},
+}
+
+// ParseStatus converts a string to a Status
+func ParseStatus(value string) (Status, error) {
+	status := Status(value)
+	if !status.IsValid() {
+		return "", ErrInvalidStatus
+	}
+	return status, nil
+}
+
+// IsValid reports whether the status is a known lifecycle state
+func (s Status) IsValid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether the state machine allows moving to next
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangeStatus moves the user to the next lifecycle state
+func (u *User) ChangeStatus(next Status, now time.Time) error {
+	if !next.IsValid() {
+		return ErrInvalidStatus
+	}
+
+	if !u.Status.CanTransitionTo(next) {
+		return ErrInvalidStatusTransition
+	}
+
+	if next != u.Status {
+		u.record(EventStatusChanged, map[string]string{"from": string(u.Status), "to": string(next)}, now)
+	}
+	u.Status = next
+	u.UpdatedAt = now
+	return nil
+}
+
+// Activate reactivates a suspended or deactivated user
+func (u *User) Activate(now time.Time) error {
+	return u.ChangeStatus(StatusActive, now)
+}
+
+// Suspend blocks an active user, typically for moderation
+func (u *User) Suspend(now time.Time) error {
+	return u.ChangeStatus(StatusSuspended, now)
+}
+
+// Deactivate closes the account at the user's or an admin's request
+func (u *User) Deactivate(now time.Time) error {
+	return u.ChangeStatus(StatusDeactivated, now)
+}
+
+// CanLogIn returns an error if the user's status does not allow logging in.
+// Authentication must call it before issuing credentials.
+func (u *User) CanLogIn() error {
+	switch u.Status {
+	case StatusActive:
+		return nil
+	case StatusSuspended:
+		return ErrUserSuspended
+	default:
+		return ErrUserDeactivated
+	}
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUser_IsActive(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, user.Status)
+	assert.NoError(t, user.CanLogIn())
+}
+
+func TestParseStatus(t *testing.T) {
+	status, err := ParseStatus("suspended")
+	require.NoError(t, err)
+	assert.Equal(t, StatusSuspended, status)
+
+	_, err = ParseStatus("banned")
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+}
+
+func TestUser_ChangeStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    Status
+		change  func(u *User, now time.Time) error
+		want    Status
+		wantErr error
+	}{
+		{name: "suspend active user", from: StatusActive, change: (*User).Suspend, want: StatusSuspended},
+		{name: "deactivate active user", from: StatusActive, change: (*User).Deactivate, want: StatusDeactivated},
+		{name: "activate suspended user", from: StatusSuspended, change: (*User).Activate, want: StatusActive},
+		{name: "deactivate suspended user", from: StatusSuspended, change: (*User).Deactivate, want: StatusDeactivated},
+		{name: "activate deactivated user", from: StatusDeactivated, change: (*User).Activate, want: StatusActive},
+		{name: "suspend deactivated user", from: StatusDeactivated, change: (*User).Suspend, wantErr: ErrInvalidStatusTransition},
+		{name: "activate active user", from: StatusActive, change: (*User).Activate, wantErr: ErrInvalidStatusTransition},
+		{name: "suspend suspended user", from: StatusSuspended, change: (*User).Suspend, wantErr: ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Status: tt.from}
+
+			err := tt.change(user, testNow)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.from, user.Status)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, user.Status)
+			assert.Equal(t, testNow, user.UpdatedAt)
+		})
+	}
+}
+
+func TestUser_ChangeStatus_InvalidStatus(t *testing.T) {
+	user := &User{Status: StatusActive}
+
+	err := user.ChangeStatus("banned", testNow)
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+}
+
+func TestUser_CanLogIn(t *testing.T) {
+	assert.NoError(t, (&User{Status: StatusActive}).CanLogIn())
+	assert.ErrorIs(t, (&User{Status: StatusSuspended}).CanLogIn(), ErrUserSuspended)
+	assert.ErrorIs(t, (&User{Status: StatusDeactivated}).CanLogIn(), ErrUserDeactivated)
+}
@@ -1,114 +1,223 @@
 package domain
 
 import (
-	"regexp"
+	"net/mail"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
-	"github.com/google/uuid"
-)
+	"golang.org/x/text/unicode/norm"
 
-// Email validation regex that prevents:
-// - consecutive dots (..)
-// - leading/trailing dots in local part
-// - leading/trailing dots in domain
-var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
 
 const (
 	maxEmailLength = 254
 	maxNameLength  = 255
+
+	// maxDomainLabelLength is the longest label of a DNS name
+	maxDomainLabelLength = 63
 )
 
 // User represents a user entity
 type User struct {
 	ID        string
+	TenantID  string // tenant the user belongs to; empty without multi-tenancy
 	Email     string
 	Name      string
+	Username  string // optional unique handle; empty when not set
+	AvatarKey string // storage key of the avatar image; empty when not set
+	Status    Status
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// PendingEmailChange holds an unconfirmed email change, if any
+	PendingEmailChange *EmailChange
+
+	// DeletedAt is set when the user has been soft-deleted
+	DeletedAt *time.Time
+
+	// LastLoginAt is when the user last logged in successfully; nil if
+	// they never did. Logins set it without changing UpdatedAt.
+	LastLoginAt *time.Time
+
+	// events holds changes not yet persisted to the activity feed
+	events []Event
 }
 
-// NewUser creates a new user with validation
-func NewUser(email, name string) (*User, error) {
+// NewUser creates a new user with the given ID and validation, created at
+// now. Invalid fields are reported together in a *validation.Error.
+func NewUser(id, email, name string, now time.Time) (*User, error) {
+	var v validation.Validator
+
+	email = NormalizeEmail(email)
 	if !isValidEmail(email) {
-		return nil, ErrInvalidEmail
+		v.Check("email", ErrInvalidEmail)
 	}
 
-	// Trim whitespace and validate name
-	name = strings.TrimSpace(name)
-	if err := isValidName(name); err != nil {
+	name, err := NormalizeName(name)
+	v.Check("name", err)
+
+	if err := v.Err(); err != nil {
 		return nil, err
 	}
 
-	now := time.Now()
-	return &User{
-		ID:        uuid.New().String(),
+	user := &User{
+		ID:        id,
 		Email:     email,
 		Name:      name,
+		Status:    StatusActive,
 		CreatedAt: now,
 		UpdatedAt: now,
-	}, nil
+	}
+	user.record(EventUserCreated, map[string]string{"email": email, "name": name}, now)
+	return user, nil
 }
 
 // UpdateName updates the user's name
-func (u *User) UpdateName(name string) error {
-	// Trim whitespace and validate name
-	name = strings.TrimSpace(name)
-	if err := isValidName(name); err != nil {
-		return err
+func (u *User) UpdateName(name string, now time.Time) error {
+	name, err := NormalizeName(name)
+	if err != nil {
+		return validation.Field("name", err)
 	}
 
+	if name != u.Name {
+		u.record(EventNameChanged, map[string]string{"name": name}, now)
+	}
 	u.Name = name
-	u.UpdatedAt = time.Now()
+	u.UpdatedAt = now
 	return nil
 }
 
-func isValidName(name string) error {
-	if name == "" {
-		return ErrInvalidName
+// NormalizeEmail returns the canonical form of an email address.
+// Emails are compared case-insensitively, so they are stored lowercased.
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// SetAvatar records the storage key of a new avatar image
+func (u *User) SetAvatar(key string, now time.Time) {
+	u.AvatarKey = key
+	u.UpdatedAt = now
+	u.record(EventAvatarUpdated, nil, now)
+}
+
+// RemoveAvatar clears the user's avatar
+func (u *User) RemoveAvatar(now time.Time) {
+	if u.AvatarKey != "" {
+		u.record(EventAvatarRemoved, nil, now)
 	}
+	u.AvatarKey = ""
+	u.UpdatedAt = now
+}
+
+// IsDeleted reports whether the user has been soft-deleted
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
 
-	if len(name) > maxNameLength {
-		return ErrInvalidName
+// Restore undoes a soft delete
+func (u *User) Restore(now time.Time) error {
+	if !u.IsDeleted() {
+		return ErrUserNotDeleted
 	}
 
+	u.DeletedAt = nil
+	u.UpdatedAt = now
 	return nil
 }
 
-func isValidEmail(email string) bool {
-	// Check length
-	if len(email) > maxEmailLength {
-		return false
-	}
+// invisibleChars are zero-width characters with no place in a name, which
+// would let two names that look the same differ. The zero-width joiner and
+// non-joiner are kept: emoji sequences and scripts such as Persian need them.
+var invisibleChars = strings.NewReplacer(
+	"\u200B", "", // zero-width space
+	"\u2060", "", // word joiner
+	"\uFEFF", "", // zero-width no-break space (byte order mark)
+)
 
-	// Check basic format
-	if !emailRegex.MatchString(email) {
-		return false
+// NormalizeName returns the canonical form of a name: trimmed, in Unicode
+// NFC so composed and decomposed accents compare equal, and without
+// zero-width characters. Names that are empty, exceed 255 characters,
+// counted as runes like the database column does, or contain control
+// characters, including the bidirectional overrides that reorder how text
+// is displayed, are rejected.
+func NormalizeName(name string) (string, error) {
+	if !utf8.ValidString(name) {
+		return "", ErrInvalidName
 	}
 
-	// Split into local and domain parts
-	parts := strings.Split(email, "@")
-	if len(parts) != 2 {
-		return false
+	name = strings.TrimSpace(invisibleChars.Replace(norm.NFC.String(name)))
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", ErrInvalidName
 	}
+	if strings.ContainsFunc(name, isControl) {
+		return "", ErrInvalidName
+	}
+
+	return name, nil
+}
 
-	local := parts[0]
-	domain := parts[1]
+// isControl reports whether r is a control or bidirectional formatting
+// character
+func isControl(r rune) bool {
+	return unicode.IsControl(r) || unicode.Is(unicode.Bidi_Control, r)
+}
 
-	// Check for consecutive dots
-	if strings.Contains(email, "..") {
+// isValidEmail reports whether email is a bare address with a dot-atom
+// local part, as net/mail parses it, whose domain is a hostname. Stricter
+// rules, such as the length limits of RFC 5321, are left to the configured
+// ports.EmailValidator.
+func isValidEmail(email string) bool {
+	if email == "" || len(email) > maxEmailLength {
 		return false
 	}
 
-	// Check for leading/trailing dots in local part
-	if strings.HasPrefix(local, ".") || strings.HasSuffix(local, ".") {
+	// Display names, comments and angle brackets are not part of an
+	// address, and net/mail unquotes quoted local parts
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
 		return false
 	}
 
-	// Check for leading/trailing dots in domain
-	if strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+	at := strings.LastIndexByte(email, '@')
+	return isValidEmailDomain(email[at+1:])
+}
+
+// isValidEmailDomain reports whether domain is a hostname of at least two
+// labels made of ASCII letters, digits and inner hyphens, with a top-level
+// label that is not all digits. Address literals such as [192.0.2.1] are
+// rejected; internationalized domains must be given in their xn-- form.
+func isValidEmailDomain(domain string) bool {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
 		return false
 	}
 
-	return true
+	for _, label := range labels {
+		if label == "" || len(label) > maxDomainLabelLength {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range []byte(label) {
+			if !isLetter(c) && !isDigit(c) && c != '-' {
+				return false
+			}
+		}
+	}
+
+	return strings.ContainsFunc(labels[len(labels)-1], func(r rune) bool {
+		return r < utf8.RuneSelf && isLetter(byte(r))
+	})
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
 }
@@ -1,35 +1,78 @@
 package domain
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
 )
 
+// testNow is the time passed to domain methods in tests
+var testNow = time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC)
+
+// testID is the ID given to users created in tests
+const testID = "0190a6e0-0000-7000-8000-000000000001"
+
 func TestNewUser(t *testing.T) {
-	user, err := NewUser("test@example.com", "Test User")
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
 	require.NoError(t, err)
-	assert.NotEmpty(t, user.ID)
+	assert.Equal(t, testID, user.ID)
 	assert.Equal(t, "test@example.com", user.Email)
 	assert.Equal(t, "Test User", user.Name)
-	assert.False(t, user.CreatedAt.IsZero())
-	assert.False(t, user.UpdatedAt.IsZero())
+	assert.Equal(t, testNow, user.CreatedAt)
+	assert.Equal(t, testNow, user.UpdatedAt)
+}
+
+func TestNewUser_NormalizesEmail(t *testing.T) {
+	user, err := NewUser(testID, "  Test.User@Example.COM ", "Test User", testNow)
+	require.NoError(t, err)
+	assert.Equal(t, "test.user@example.com", user.Email)
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "test@example.com", expected: "test@example.com"},
+		{input: "Test@Example.com", expected: "test@example.com"},
+		{input: "  TEST@EXAMPLE.COM  ", expected: "test@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeEmail(tt.input))
+		})
+	}
 }
 
 func TestNewUser_InvalidEmail(t *testing.T) {
-	_, err := NewUser("invalid-email", "Test User")
+	_, err := NewUser(testID, "invalid-email", "Test User", testNow)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrInvalidEmail)
 }
 
 func TestNewUser_EmptyName(t *testing.T) {
-	_, err := NewUser("test@example.com", "")
+	_, err := NewUser(testID, "test@example.com", "", testNow)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrInvalidName)
 }
 
+func TestNewUser_ReportsEveryInvalidField(t *testing.T) {
+	_, err := NewUser(testID, "invalid-email", "", testNow)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidEmail)
+	assert.ErrorIs(t, err, ErrInvalidName)
+	assert.Equal(t, []validation.Violation{
+		{Field: "email", Err: ErrInvalidEmail},
+		{Field: "name", Err: ErrInvalidName},
+	}, validation.Violations(err))
+}
+
 func TestNewUser_EmailEdgeCases(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -43,6 +86,8 @@ func TestNewUser_EmailEdgeCases(t *testing.T) {
 		{name: "valid with underscore", email: "test_user@example.com", shouldErr: false},
 		{name: "valid with dot", email: "test.user@example.com", shouldErr: false},
 		{name: "valid subdomain", email: "test@mail.example.com", shouldErr: false},
+		{name: "valid hyphen in domain", email: "test@my-mail.example.com", shouldErr: false},
+		{name: "valid punycode domain", email: "test@xn--bcher-kva.example", shouldErr: false},
 
 		// Invalid emails - consecutive dots
 		{name: "consecutive dots in local", email: "test..user@example.com", shouldErr: true},
@@ -60,6 +105,17 @@ func TestNewUser_EmailEdgeCases(t *testing.T) {
 		{name: "no domain", email: "test@", shouldErr: true},
 		{name: "no local part", email: "@example.com", shouldErr: true},
 		{name: "no TLD", email: "test@example", shouldErr: true},
+		{name: "quoted local part", email: `"test user"@example.com`, shouldErr: true},
+		{name: "display name", email: "Test <test@example.com>", shouldErr: true},
+		{name: "angle brackets", email: "<test@example.com>", shouldErr: true},
+		{name: "comment", email: "test@example.com (Test)", shouldErr: true},
+		{name: "address literal", email: "test@[192.0.2.1]", shouldErr: true},
+		{name: "numeric TLD", email: "test@192.0.2.1", shouldErr: true},
+		{name: "leading hyphen in label", email: "test@-example.com", shouldErr: true},
+		{name: "trailing hyphen in label", email: "test@example-.com", shouldErr: true},
+		{name: "label too long", email: "test@" + strings.Repeat("a", 64) + ".com", shouldErr: true},
+		{name: "underscore in domain", email: "test@ex_ample.com", shouldErr: true},
+		{name: "unicode domain", email: "test@bücher.example", shouldErr: true},
 
 		// Invalid emails - length
 		{name: "too long", email: "a" + string(make([]byte, 250)) + "@example.com", shouldErr: true},
@@ -67,7 +123,7 @@ func TestNewUser_EmailEdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			user, err := NewUser(tt.email, "Test User")
+			user, err := NewUser(testID, tt.email, "Test User", testNow)
 			if tt.shouldErr {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, ErrInvalidEmail)
@@ -82,42 +138,59 @@ func TestNewUser_EmailEdgeCases(t *testing.T) {
 }
 
 func TestUser_UpdateName(t *testing.T) {
-	user, err := NewUser("test@example.com", "Old Name")
+	user, err := NewUser(testID, "test@example.com", "Old Name", testNow)
 	require.NoError(t, err)
 
-	err = user.UpdateName("New Name")
+	err = user.UpdateName("New Name", testNow)
 	require.NoError(t, err)
 	assert.Equal(t, "New Name", user.Name)
 }
 
 func TestUser_UpdateName_Empty(t *testing.T) {
-	user, err := NewUser("test@example.com", "Test User")
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
 	require.NoError(t, err)
 
-	err = user.UpdateName("")
+	err = user.UpdateName("", testNow)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrInvalidName)
 }
 
-func TestUser_UpdateName_UpdatesTimestamp(t *testing.T) {
-	user, err := NewUser("test@example.com", "Old Name")
+func TestUser_Restore(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
 	require.NoError(t, err)
 
-	originalCreatedAt := user.CreatedAt
-	originalUpdatedAt := user.UpdatedAt
+	deletedAt := testNow
+	user.DeletedAt = &deletedAt
+	assert.True(t, user.IsDeleted())
 
-	// Sleep briefly to ensure timestamp difference
-	time.Sleep(10 * time.Millisecond)
+	err = user.Restore(testNow)
+	require.NoError(t, err)
+	assert.False(t, user.IsDeleted())
+	assert.Nil(t, user.DeletedAt)
+}
 
-	err = user.UpdateName("New Name")
+func TestUser_Restore_NotDeleted(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
+	require.NoError(t, err)
+
+	err = user.Restore(testNow)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrUserNotDeleted)
+}
+
+func TestUser_UpdateName_UpdatesTimestamp(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Old Name", testNow)
+	require.NoError(t, err)
+
+	later := testNow.Add(time.Minute)
+	err = user.UpdateName("New Name", later)
 	require.NoError(t, err)
 
 	// CreatedAt should not change
-	assert.Equal(t, originalCreatedAt, user.CreatedAt)
+	assert.Equal(t, testNow, user.CreatedAt)
 
 	// UpdatedAt should change
-	assert.NotEqual(t, originalUpdatedAt, user.UpdatedAt)
-	assert.True(t, user.UpdatedAt.After(originalUpdatedAt))
+	assert.Equal(t, later, user.UpdatedAt)
 }
 
 func TestNewUser_NameWhitespace(t *testing.T) {
@@ -139,7 +212,7 @@ func TestNewUser_NameWhitespace(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			user, err := NewUser("test@example.com", tt.inputName)
+			user, err := NewUser(testID, "test@example.com", tt.inputName, testNow)
 			if tt.shouldErr {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, ErrInvalidName)
@@ -179,7 +252,7 @@ func TestNewUser_NameLength(t *testing.T) {
 				}
 			}
 
-			user, err := NewUser("test@example.com", name)
+			user, err := NewUser(testID, "test@example.com", name, testNow)
 			if tt.shouldErr {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, ErrInvalidName)
@@ -191,8 +264,54 @@ func TestNewUser_NameLength(t *testing.T) {
 	}
 }
 
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputName string
+		expected  string
+		shouldErr bool
+	}{
+		{name: "multibyte within limit", inputName: strings.Repeat("é", 255), expected: strings.Repeat("é", 255)},
+		{name: "multibyte over limit", inputName: strings.Repeat("é", 256), shouldErr: true},
+		{name: "emoji within limit", inputName: strings.Repeat("😀", 255), expected: strings.Repeat("😀", 255)},
+		{name: "emoji over limit", inputName: strings.Repeat("😀", 256), shouldErr: true},
+		{name: "decomposed accent", inputName: "Jose\u0301", expected: "José"},
+		{name: "zero-width space", inputName: "Jo\u200Bhn", expected: "John"},
+		{name: "only zero-width characters", inputName: "\u200B\uFEFF", shouldErr: true},
+		{name: "emoji sequence keeps joiner", inputName: "👩\u200D💻 Ada", expected: "👩\u200D💻 Ada"},
+		{name: "null byte", inputName: "John\x00Doe", shouldErr: true},
+		{name: "inner newline", inputName: "John\nDoe", shouldErr: true},
+		{name: "escape sequence", inputName: "\x1b[31mJohn", shouldErr: true},
+		{name: "bidi override", inputName: "John\u202Eeod", shouldErr: true},
+		{name: "invalid UTF-8", inputName: "John\xff", shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := NormalizeName(tt.inputName)
+			if tt.shouldErr {
+				assert.ErrorIs(t, err, ErrInvalidName)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, name)
+		})
+	}
+}
+
+func TestUser_UpdateName_Normalizes(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Old Name", testNow)
+	require.NoError(t, err)
+
+	require.NoError(t, user.UpdateName("  Zoe\u0308\u200B ", testNow))
+	assert.Equal(t, "Zoë", user.Name)
+
+	assert.ErrorIs(t, user.UpdateName("Zoë\tSmith", testNow), ErrInvalidName)
+	assert.Equal(t, "Zoë", user.Name, "rejected names leave the name unchanged")
+}
+
 func TestUser_UpdateName_Whitespace(t *testing.T) {
-	user, err := NewUser("test@example.com", "Old Name")
+	user, err := NewUser(testID, "test@example.com", "Old Name", testNow)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -208,7 +327,7 @@ func TestUser_UpdateName_Whitespace(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := user.UpdateName(tt.inputName)
+			err := user.UpdateName(tt.inputName, testNow)
 			if tt.shouldErr {
 				require.Error(t, err)
 				assert.ErrorIs(t, err, ErrInvalidName)
@@ -221,7 +340,7 @@ func TestUser_UpdateName_Whitespace(t *testing.T) {
 }
 
 func TestUser_UpdateName_Length(t *testing.T) {
-	user, err := NewUser("test@example.com", "Old Name")
+	user, err := NewUser(testID, "test@example.com", "Old Name", testNow)
 	require.NoError(t, err)
 
 	// Test too long name
@@ -230,7 +349,7 @@ func TestUser_UpdateName_Length(t *testing.T) {
 		tooLongName += "a"
 	}
 
-	err = user.UpdateName(tooLongName)
+	err = user.UpdateName(tooLongName, testNow)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, ErrInvalidName)
 
@@ -240,7 +359,7 @@ func TestUser_UpdateName_Length(t *testing.T) {
 		maxLengthName += "a"
 	}
 
-	err = user.UpdateName(maxLengthName)
+	err = user.UpdateName(maxLengthName, testNow)
 	require.NoError(t, err)
 	assert.Equal(t, maxLengthName, user.Name)
 }
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// usernameRegex allows lowercase letters and digits, separated by single
+// dots, underscores or hyphens
+var usernameRegex = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*$`)
+
+const (
+	minUsernameLength = 3
+	maxUsernameLength = 30
+)
+
+// reservedUsernames cannot be claimed because they collide with routes or
+// could be mistaken for official accounts
+var reservedUsernames = map[string]bool{
+	"admin":         true,
+	"administrator": true,
+	"api":           true,
+	"help":          true,
+	"me":            true,
+	"moderator":     true,
+	"null":          true,
+	"root":          true,
+	"security":      true,
+	"support":       true,
+	"system":        true,
+	"undefined":     true,
+	"user":          true,
+	"users":         true,
+}
+
+// NormalizeUsername returns the canonical form of a username.
+// Usernames are compared case-insensitively, so they are stored lowercased.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// ChangeUsername validates and sets the user's username
+func (u *User) ChangeUsername(username string, now time.Time) error {
+	username = NormalizeUsername(username)
+	if err := isValidUsername(username); err != nil {
+		return validation.Field("username", err)
+	}
+
+	if username != u.Username {
+		u.record(EventUsernameChanged, map[string]string{"username": username}, now)
+	}
+	u.Username = username
+	u.UpdatedAt = now
+	return nil
+}
+
+// CheckUsername reports whether username, once normalized, has the
+// format of a username. Whether it is reserved is left to ChangeUsername.
+func CheckUsername(username string) error {
+	return checkUsernameFormat(NormalizeUsername(username))
+}
+
+func isValidUsername(username string) error {
+	if err := checkUsernameFormat(username); err != nil {
+		return err
+	}
+
+	if reservedUsernames[username] {
+		return ErrReservedUsername
+	}
+
+	return nil
+}
+
+func checkUsernameFormat(username string) error {
+	if len(username) < minUsernameLength || len(username) > maxUsernameLength {
+		return ErrInvalidUsername
+	}
+
+	if !usernameRegex.MatchString(username) {
+		return ErrInvalidUsername
+	}
+
+	return nil
+}
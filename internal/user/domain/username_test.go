@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_ChangeUsername(t *testing.T) {
+	user, err := NewUser(testID, "test@example.com", "Test User", testNow)
+	require.NoError(t, err)
+	assert.Empty(t, user.Username)
+
+	err = user.ChangeUsername("  John.Doe_42 ", testNow)
+	require.NoError(t, err)
+	assert.Equal(t, "john.doe_42", user.Username)
+}
+
+func TestUser_ChangeUsername_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		wantErr  error
+	}{
+		{name: "too short", username: "ab", wantErr: ErrInvalidUsername},
+		{name: "too long", username: strings.Repeat("a", 31), wantErr: ErrInvalidUsername},
+		{name: "invalid character", username: "john doe", wantErr: ErrInvalidUsername},
+		{name: "non-ascii", username: "jöhn", wantErr: ErrInvalidUsername},
+		{name: "leading separator", username: "_john", wantErr: ErrInvalidUsername},
+		{name: "trailing separator", username: "john-", wantErr: ErrInvalidUsername},
+		{name: "consecutive separators", username: "john..doe", wantErr: ErrInvalidUsername},
+		{name: "reserved", username: "admin", wantErr: ErrReservedUsername},
+		{name: "reserved in other case", username: "Support", wantErr: ErrReservedUsername},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{Username: "original"}
+
+			err := user.ChangeUsername(tt.username, testNow)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, "original", user.Username)
+		})
+	}
+}
+
+func TestUser_ChangeUsername_LengthBounds(t *testing.T) {
+	user := &User{}
+
+	assert.NoError(t, user.ChangeUsername(strings.Repeat("a", minUsernameLength), testNow))
+	assert.NoError(t, user.ChangeUsername(strings.Repeat("a", maxUsernameLength), testNow))
+}
+
+func TestCheckUsername(t *testing.T) {
+	assert.NoError(t, CheckUsername("  John.Doe_42 "), "normalized first")
+	assert.NoError(t, CheckUsername("admin"), "reserved names have the format")
+	assert.ErrorIs(t, CheckUsername("john..doe"), ErrInvalidUsername)
+	assert.ErrorIs(t, CheckUsername("ab"), ErrInvalidUsername)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=UserActivity --output=mocks --outpkg=mocks
+
+// UserActivity defines the interface for reading a user's change and
+// login history
+type UserActivity interface {
+	// ListActivity returns up to limit events of the user, newest first,
+	// continuing after cursor when it is not empty
+	ListActivity(ctx context.Context, id, cursor string, limit int) (*domain.ActivityPage, error)
+
+	// ListAuditLog returns up to limit events of all users matching the
+	// filter, newest first, continuing after cursor when it is not empty
+	ListAuditLog(ctx context.Context, filter domain.EventFilter, cursor string, limit int) (*domain.ActivityPage, error)
+
+	// ListLogins returns up to limit login attempts of the user, newest
+	// first, continuing after cursor when it is not empty
+	ListLogins(ctx context.Context, id, cursor string, limit int) (*domain.LoginPage, error)
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=UserAvatars --output=mocks --outpkg=mocks
+
+// UserAvatars defines the interface for managing user avatars
+type UserAvatars interface {
+	// UploadAvatar validates, resizes and stores a new avatar image
+	UploadAvatar(ctx context.Context, id string, r io.Reader) (*domain.User, error)
+
+	// AvatarURL returns a URL the user's avatar can be read from
+	AvatarURL(ctx context.Context, id string) (string, error)
+
+	// DeleteAvatar removes the user's avatar, including for soft-deleted users
+	DeleteAvatar(ctx context.Context, id string) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "context"
+
+//go:generate mockery --name=BreachedPasswords --output=mocks --outpkg=mocks
+
+// BreachedPasswords checks passwords against known data breaches
+type BreachedPasswords interface {
+	// IsBreached reports whether password appears in a known breach
+	IsBreached(ctx context.Context, password string) (bool, error)
+}
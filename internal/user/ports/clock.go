@@ -0,0 +1,10 @@
+package ports
+
+import "time"
+
+// Clock tells services the current time. Domain methods take the time as
+// an argument, so tests control it by injecting a fake clock.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+}
@@ -0,0 +1,14 @@
+package ports
+
+import "context"
+
+//go:generate mockery --name=EmailValidator --output=mocks --outpkg=mocks
+
+// EmailValidator checks addresses the domain's syntax rules accept, such
+// as against stricter syntax, DNS or an external service
+type EmailValidator interface {
+	// Validate returns domain.ErrInvalidEmail when the address is
+	// malformed and domain.ErrUndeliverableEmail when it cannot receive
+	// mail
+	Validate(ctx context.Context, email string) error
+}
@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=UserEvents --output=mocks --outpkg=mocks
+
+// UserEvents defines the interface for following user changes as they
+// are stored
+type UserEvents interface {
+	// Subscribe delivers the events matching the filter that are stored in
+	// the tenant of ctx after the call. The channel is closed once ctx is
+	// done, or early when the subscriber falls too far behind.
+	Subscribe(ctx context.Context, filter domain.EventFilter) (<-chan domain.Event, error)
+}
@@ -0,0 +1,8 @@
+package ports
+
+// IDGenerator creates identifiers for new users. The default generates
+// UUIDv7; any scheme can be plugged in as long as the storage accepts it.
+type IDGenerator interface {
+	// NewID returns a new unique identifier
+	NewID() string
+}
@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+	"io"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=UserImporter --output=mocks --outpkg=mocks
+
+// UserImporter defines the interface for importing users from CSV. Imports
+// in the background run as jobs; see UserJobs.
+type UserImporter interface {
+	// Import reads CSV rows and creates users, returning a per-line report
+	Import(ctx context.Context, r io.Reader) (*domain.ImportReport, error)
+}
@@ -0,0 +1,85 @@
+package ports
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=JobRepository --output=mocks --outpkg=mocks
+
+// JobRepository defines the interface for storing background jobs, so they
+// survive the instance running them
+type JobRepository interface {
+	// Create stores a new job, recording the tenant of ctx as its tenant
+	Create(ctx context.Context, job *domain.Job) error
+
+	// Update saves the progress and outcome of a job
+	Update(ctx context.Context, job *domain.Job) error
+
+	// Get retrieves a job by ID, failing with domain.ErrJobNotFound when it
+	// does not exist
+	Get(ctx context.Context, id string) (*domain.Job, error)
+
+	// ListStale retrieves the unfinished jobs last updated before the time,
+	// which no instance is running anymore
+	ListStale(ctx context.Context, before time.Time) ([]*domain.Job, error)
+
+	// Claim marks the job running at now if it is unfinished and was last
+	// updated before staleBefore, and reports whether it did. Instances
+	// resuming the same stale job race on it, and one of them wins.
+	Claim(ctx context.Context, id string, staleBefore, now time.Time) (bool, error)
+
+	// DeleteFinished deletes the jobs finished before the time and returns
+	// them, so their objects can be deleted as well
+	DeleteFinished(ctx context.Context, before time.Time) ([]*domain.Job, error)
+}
+
+//go:generate mockery --name=UserJobs --output=mocks --outpkg=mocks
+
+// UserJobs defines the interface for importing and exporting users in the
+// background. Jobs report their progress while they run, and exports land
+// in object storage.
+type UserJobs interface {
+	// StartImport stores the CSV file and imports it in the background. It
+	// fails with ErrImportInProgress while another import runs.
+	StartImport(ctx context.Context, r io.Reader) (*domain.Job, error)
+
+	// StartExport exports the users matching the filter in the format in
+	// the background
+	StartExport(ctx context.Context, filter domain.UserFilter, format string) (*domain.Job, error)
+
+	// GetJob retrieves a job by ID
+	GetJob(ctx context.Context, id string) (*domain.Job, error)
+
+	// DownloadURL returns a signed URL of the file of a completed export
+	DownloadURL(ctx context.Context, job *domain.Job) (string, error)
+
+	// ResumeStaleJobs runs the jobs again whose instance stopped before
+	// they finished, and returns how many it resumed
+	ResumeStaleJobs(ctx context.Context) (int, error)
+
+	// PruneJobs deletes the jobs finished longer ago than the retention,
+	// and their files, and returns how many it deleted
+	PruneJobs(ctx context.Context) (int, error)
+}
+
+// UserExportFormat encodes exported users in a file format
+type UserExportFormat interface {
+	// ContentType returns the media type of the files
+	ContentType() string
+
+	// NewWriter returns a writer encoding users to w
+	NewWriter(w io.Writer) UserExportWriter
+}
+
+// UserExportWriter encodes users to a file
+type UserExportWriter interface {
+	// Write encodes a user, possibly buffering it
+	Write(user *domain.User) error
+
+	// Flush writes any buffered users
+	Flush() error
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockery --name=Locker --output=mocks --outpkg=mocks
+
+// Locker defines the interface for locks held across every instance of the
+// application. A lock expires after its TTL, so a crashed holder cannot
+// keep it.
+type Locker interface {
+	// Acquire takes the lock named key for ttl, unless another holder has
+	// it, and returns its fencing token with acquired set to true. Tokens
+	// increase with every acquisition of a key.
+	Acquire(ctx context.Context, key string, ttl time.Duration) (token int64, acquired bool, err error)
+
+	// Release gives up the lock named key if it is still held with the
+	// token
+	Release(ctx context.Context, key string, token int64) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=LoginRiskEvaluator --output=mocks --outpkg=mocks
+
+// LoginRiskEvaluator tells how suspicious a login attempt is, such as from
+// rules on the user's login history or through a fraud detection service
+type LoginRiskEvaluator interface {
+	// Evaluate assesses a login attempt whose password matched
+	Evaluate(ctx context.Context, attempt domain.LoginAttempt) (domain.RiskAssessment, error)
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
@@ -38,6 +39,72 @@ func (_m *MockUserRepository) EXPECT() *MockUserRepository_Expecter {
 	return &MockUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// CountDeleted provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) CountDeleted(ctx context.Context, before time.Time) (int, error) {
+	ret := _mock.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountDeleted")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int); ok {
+		r0 = returnFunc(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_CountDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountDeleted'
+type MockUserRepository_CountDeleted_Call struct {
+	*mock.Call
+}
+
+// CountDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockUserRepository_Expecter) CountDeleted(ctx interface{}, before interface{}) *MockUserRepository_CountDeleted_Call {
+	return &MockUserRepository_CountDeleted_Call{Call: _e.mock.On("CountDeleted", ctx, before)}
+}
+
+func (_c *MockUserRepository_CountDeleted_Call) Run(run func(ctx context.Context, before time.Time)) *MockUserRepository_CountDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_CountDeleted_Call) Return(n int, err error) *MockUserRepository_CountDeleted_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserRepository_CountDeleted_Call) RunAndReturn(run func(ctx context.Context, before time.Time) (int, error)) *MockUserRepository_CountDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) Create(ctx context.Context, user *domain.User) error {
 	ret := _mock.Called(ctx, user)
@@ -95,6 +162,63 @@ func (_c *MockUserRepository_Create_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// CreateBatch provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) CreateBatch(ctx context.Context, users []*domain.User) error {
+	ret := _mock.Called(ctx, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []*domain.User) error); ok {
+		r0 = returnFunc(ctx, users)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockUserRepository_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users []*domain.User
+func (_e *MockUserRepository_Expecter) CreateBatch(ctx interface{}, users interface{}) *MockUserRepository_CreateBatch_Call {
+	return &MockUserRepository_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, users)}
+}
+
+func (_c *MockUserRepository_CreateBatch_Call) Run(run func(ctx context.Context, users []*domain.User)) *MockUserRepository_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []*domain.User
+		if args[1] != nil {
+			arg1 = args[1].([]*domain.User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_CreateBatch_Call) Return(err error) *MockUserRepository_CreateBatch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_CreateBatch_Call) RunAndReturn(run func(ctx context.Context, users []*domain.User) error) *MockUserRepository_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) Delete(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
@@ -152,6 +276,197 @@ func (_c *MockUserRepository_Delete_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// Erase provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Erase(ctx context.Context, record *domain.ErasureRecord) error {
+	ret := _mock.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Erase")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.ErasureRecord) error); ok {
+		r0 = returnFunc(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Erase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Erase'
+type MockUserRepository_Erase_Call struct {
+	*mock.Call
+}
+
+// Erase is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record *domain.ErasureRecord
+func (_e *MockUserRepository_Expecter) Erase(ctx interface{}, record interface{}) *MockUserRepository_Erase_Call {
+	return &MockUserRepository_Erase_Call{Call: _e.mock.On("Erase", ctx, record)}
+}
+
+func (_c *MockUserRepository_Erase_Call) Run(run func(ctx context.Context, record *domain.ErasureRecord)) *MockUserRepository_Erase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *domain.ErasureRecord
+		if args[1] != nil {
+			arg1 = args[1].(*domain.ErasureRecord)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Erase_Call) Return(err error) *MockUserRepository_Erase_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Erase_Call) RunAndReturn(run func(ctx context.Context, record *domain.ErasureRecord) error) *MockUserRepository_Erase_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Exists(ctx context.Context, key domain.UserKey) (bool, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserKey) (bool, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserKey) bool); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserKey) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type MockUserRepository_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key domain.UserKey
+func (_e *MockUserRepository_Expecter) Exists(ctx interface{}, key interface{}) *MockUserRepository_Exists_Call {
+	return &MockUserRepository_Exists_Call{Call: _e.mock.On("Exists", ctx, key)}
+}
+
+func (_c *MockUserRepository_Exists_Call) Run(run func(ctx context.Context, key domain.UserKey)) *MockUserRepository_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.UserKey
+		if args[1] != nil {
+			arg1 = args[1].(domain.UserKey)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Exists_Call) Return(b bool, err error) *MockUserRepository_Exists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserRepository_Exists_Call) RunAndReturn(run func(ctx context.Context, key domain.UserKey) (bool, error)) *MockUserRepository_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindExistingEmails provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) FindExistingEmails(ctx context.Context, emails []string) ([]string, error) {
+	ret := _mock.Called(ctx, emails)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExistingEmails")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return returnFunc(ctx, emails)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = returnFunc(ctx, emails)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, emails)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_FindExistingEmails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindExistingEmails'
+type MockUserRepository_FindExistingEmails_Call struct {
+	*mock.Call
+}
+
+// FindExistingEmails is a helper method to define mock.On call
+//   - ctx context.Context
+//   - emails []string
+func (_e *MockUserRepository_Expecter) FindExistingEmails(ctx interface{}, emails interface{}) *MockUserRepository_FindExistingEmails_Call {
+	return &MockUserRepository_FindExistingEmails_Call{Call: _e.mock.On("FindExistingEmails", ctx, emails)}
+}
+
+func (_c *MockUserRepository_FindExistingEmails_Call) Run(run func(ctx context.Context, emails []string)) *MockUserRepository_FindExistingEmails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_FindExistingEmails_Call) Return(ss []string, err error) *MockUserRepository_FindExistingEmails_Call {
+	_c.Call.Return(ss, err)
+	return _c
+}
+
+func (_c *MockUserRepository_FindExistingEmails_Call) RunAndReturn(run func(ctx context.Context, emails []string) ([]string, error)) *MockUserRepository_FindExistingEmails_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByEmail provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	ret := _mock.Called(ctx, email)
@@ -288,76 +603,889 @@ func (_c *MockUserRepository_GetByID_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
-// List provides a mock function for the type MockUserRepository
-func (_mock *MockUserRepository) List(ctx context.Context, limit int, offset int) ([]*domain.User, error) {
-	ret := _mock.Called(ctx, limit, offset)
+// GetByIDIncludingDeleted provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for List")
+		panic("no return value specified for GetByIDIncludingDeleted")
 	}
 
-	var r0 []*domain.User
+	var r0 *domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]*domain.User, error)); ok {
-		return returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*domain.User); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.User)
+			r0 = ret.Get(0).(*domain.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockUserRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
-type MockUserRepository_List_Call struct {
+// MockUserRepository_GetByIDIncludingDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByIDIncludingDeleted'
+type MockUserRepository_GetByIDIncludingDeleted_Call struct {
 	*mock.Call
 }
 
-// List is a helper method to define mock.On call
+// GetByIDIncludingDeleted is a helper method to define mock.On call
 //   - ctx context.Context
-//   - limit int
-//   - offset int
-func (_e *MockUserRepository_Expecter) List(ctx interface{}, limit interface{}, offset interface{}) *MockUserRepository_List_Call {
-	return &MockUserRepository_List_Call{Call: _e.mock.On("List", ctx, limit, offset)}
+//   - id string
+func (_e *MockUserRepository_Expecter) GetByIDIncludingDeleted(ctx interface{}, id interface{}) *MockUserRepository_GetByIDIncludingDeleted_Call {
+	return &MockUserRepository_GetByIDIncludingDeleted_Call{Call: _e.mock.On("GetByIDIncludingDeleted", ctx, id)}
 }
 
-func (_c *MockUserRepository_List_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockUserRepository_List_Call {
+func (_c *MockUserRepository_GetByIDIncludingDeleted_Call) Run(run func(ctx context.Context, id string)) *MockUserRepository_GetByIDIncludingDeleted_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockUserRepository_List_Call) Return(users []*domain.User, err error) *MockUserRepository_List_Call {
-	_c.Call.Return(users, err)
+func (_c *MockUserRepository_GetByIDIncludingDeleted_Call) Return(user *domain.User, err error) *MockUserRepository_GetByIDIncludingDeleted_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetByIDIncludingDeleted_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.User, error)) *MockUserRepository_GetByIDIncludingDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByUsername provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUsername")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByUsername'
+type MockUserRepository_GetByUsername_Call struct {
+	*mock.Call
+}
+
+// GetByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockUserRepository_Expecter) GetByUsername(ctx interface{}, username interface{}) *MockUserRepository_GetByUsername_Call {
+	return &MockUserRepository_GetByUsername_Call{Call: _e.mock.On("GetByUsername", ctx, username)}
+}
+
+func (_c *MockUserRepository_GetByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUserRepository_GetByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetByUsername_Call) Return(user *domain.User, err error) *MockUserRepository_GetByUsername_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (*domain.User, error)) *MockUserRepository_GetByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPassword provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetPassword(ctx context.Context, userID string) (*domain.Password, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPassword")
+	}
+
+	var r0 *domain.Password
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Password, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Password); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Password)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetPassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPassword'
+type MockUserRepository_GetPassword_Call struct {
+	*mock.Call
+}
+
+// GetPassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserRepository_Expecter) GetPassword(ctx interface{}, userID interface{}) *MockUserRepository_GetPassword_Call {
+	return &MockUserRepository_GetPassword_Call{Call: _e.mock.On("GetPassword", ctx, userID)}
+}
+
+func (_c *MockUserRepository_GetPassword_Call) Run(run func(ctx context.Context, userID string)) *MockUserRepository_GetPassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetPassword_Call) Return(password *domain.Password, err error) *MockUserRepository_GetPassword_Call {
+	_c.Call.Return(password, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetPassword_Call) RunAndReturn(run func(ctx context.Context, userID string) (*domain.Password, error)) *MockUserRepository_GetPassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPreferences provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPreferences")
+	}
+
+	var r0 *domain.Preferences
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Preferences, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Preferences); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Preferences)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetPreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPreferences'
+type MockUserRepository_GetPreferences_Call struct {
+	*mock.Call
+}
+
+// GetPreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *MockUserRepository_Expecter) GetPreferences(ctx interface{}, userID interface{}) *MockUserRepository_GetPreferences_Call {
+	return &MockUserRepository_GetPreferences_Call{Call: _e.mock.On("GetPreferences", ctx, userID)}
+}
+
+func (_c *MockUserRepository_GetPreferences_Call) Run(run func(ctx context.Context, userID string)) *MockUserRepository_GetPreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetPreferences_Call) Return(preferences *domain.Preferences, err error) *MockUserRepository_GetPreferences_Call {
+	_c.Call.Return(preferences, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetPreferences_Call) RunAndReturn(run func(ctx context.Context, userID string) (*domain.Preferences, error)) *MockUserRepository_GetPreferences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) List(ctx context.Context, filter domain.UserFilter, limit int, offset int) ([]*domain.User, error) {
+	ret := _mock.Called(ctx, filter, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserFilter, int, int) ([]*domain.User, error)); ok {
+		return returnFunc(ctx, filter, limit, offset)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserFilter, int, int) []*domain.User); ok {
+		r0 = returnFunc(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserFilter, int, int) error); ok {
+		r1 = returnFunc(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockUserRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.UserFilter
+//   - limit int
+//   - offset int
+func (_e *MockUserRepository_Expecter) List(ctx interface{}, filter interface{}, limit interface{}, offset interface{}) *MockUserRepository_List_Call {
+	return &MockUserRepository_List_Call{Call: _e.mock.On("List", ctx, filter, limit, offset)}
+}
+
+func (_c *MockUserRepository_List_Call) Run(run func(ctx context.Context, filter domain.UserFilter, limit int, offset int)) *MockUserRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.UserFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.UserFilter)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_List_Call) Return(users []*domain.User, err error) *MockUserRepository_List_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserRepository_List_Call) RunAndReturn(run func(ctx context.Context, filter domain.UserFilter, limit int, offset int) ([]*domain.User, error)) *MockUserRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListEvents provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ListEvents(ctx context.Context, filter domain.EventFilter, cursor *domain.EventCursor, limit int) ([]domain.Event, error) {
+	ret := _mock.Called(ctx, filter, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEvents")
+	}
+
+	var r0 []domain.Event
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.EventFilter, *domain.EventCursor, int) ([]domain.Event, error)); ok {
+		return returnFunc(ctx, filter, cursor, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.EventFilter, *domain.EventCursor, int) []domain.Event); ok {
+		r0 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Event)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.EventFilter, *domain.EventCursor, int) error); ok {
+		r1 = returnFunc(ctx, filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_ListEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEvents'
+type MockUserRepository_ListEvents_Call struct {
+	*mock.Call
+}
+
+// ListEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.EventFilter
+//   - cursor *domain.EventCursor
+//   - limit int
+func (_e *MockUserRepository_Expecter) ListEvents(ctx interface{}, filter interface{}, cursor interface{}, limit interface{}) *MockUserRepository_ListEvents_Call {
+	return &MockUserRepository_ListEvents_Call{Call: _e.mock.On("ListEvents", ctx, filter, cursor, limit)}
+}
+
+func (_c *MockUserRepository_ListEvents_Call) Run(run func(ctx context.Context, filter domain.EventFilter, cursor *domain.EventCursor, limit int)) *MockUserRepository_ListEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.EventFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.EventFilter)
+		}
+		var arg2 *domain.EventCursor
+		if args[2] != nil {
+			arg2 = args[2].(*domain.EventCursor)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ListEvents_Call) Return(events []domain.Event, err error) *MockUserRepository_ListEvents_Call {
+	_c.Call.Return(events, err)
+	return _c
+}
+
+func (_c *MockUserRepository_ListEvents_Call) RunAndReturn(run func(ctx context.Context, filter domain.EventFilter, cursor *domain.EventCursor, limit int) ([]domain.Event, error)) *MockUserRepository_ListEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLogins provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ListLogins(ctx context.Context, userID string, cursor *domain.EventCursor, limit int) ([]domain.LoginEvent, error) {
+	ret := _mock.Called(ctx, userID, cursor, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLogins")
+	}
+
+	var r0 []domain.LoginEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.EventCursor, int) ([]domain.LoginEvent, error)); ok {
+		return returnFunc(ctx, userID, cursor, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.EventCursor, int) []domain.LoginEvent); ok {
+		r0 = returnFunc(ctx, userID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LoginEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.EventCursor, int) error); ok {
+		r1 = returnFunc(ctx, userID, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_ListLogins_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLogins'
+type MockUserRepository_ListLogins_Call struct {
+	*mock.Call
+}
+
+// ListLogins is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - cursor *domain.EventCursor
+//   - limit int
+func (_e *MockUserRepository_Expecter) ListLogins(ctx interface{}, userID interface{}, cursor interface{}, limit interface{}) *MockUserRepository_ListLogins_Call {
+	return &MockUserRepository_ListLogins_Call{Call: _e.mock.On("ListLogins", ctx, userID, cursor, limit)}
+}
+
+func (_c *MockUserRepository_ListLogins_Call) Run(run func(ctx context.Context, userID string, cursor *domain.EventCursor, limit int)) *MockUserRepository_ListLogins_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.EventCursor
+		if args[2] != nil {
+			arg2 = args[2].(*domain.EventCursor)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ListLogins_Call) Return(loginEvents []domain.LoginEvent, err error) *MockUserRepository_ListLogins_Call {
+	_c.Call.Return(loginEvents, err)
+	return _c
+}
+
+func (_c *MockUserRepository_ListLogins_Call) RunAndReturn(run func(ctx context.Context, userID string, cursor *domain.EventCursor, limit int) ([]domain.LoginEvent, error)) *MockUserRepository_ListLogins_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStream provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ListStream(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	ret := _mock.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStream")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserFilter, func(*domain.User) error) error); ok {
+		r0 = returnFunc(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_ListStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStream'
+type MockUserRepository_ListStream_Call struct {
+	*mock.Call
+}
+
+// ListStream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.UserFilter
+//   - fn func(*domain.User) error
+func (_e *MockUserRepository_Expecter) ListStream(ctx interface{}, filter interface{}, fn interface{}) *MockUserRepository_ListStream_Call {
+	return &MockUserRepository_ListStream_Call{Call: _e.mock.On("ListStream", ctx, filter, fn)}
+}
+
+func (_c *MockUserRepository_ListStream_Call) Run(run func(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error)) *MockUserRepository_ListStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.UserFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.UserFilter)
+		}
+		var arg2 func(*domain.User) error
+		if args[2] != nil {
+			arg2 = args[2].(func(*domain.User) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ListStream_Call) Return(err error) *MockUserRepository_ListStream_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_ListStream_Call) RunAndReturn(run func(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error) *MockUserRepository_ListStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeDeleted provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error) {
+	ret := _mock.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeleted")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) (int, error)); ok {
+		return returnFunc(ctx, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) int); ok {
+		r0 = returnFunc(ctx, before, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_PurgeDeleted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeDeleted'
+type MockUserRepository_PurgeDeleted_Call struct {
+	*mock.Call
+}
+
+// PurgeDeleted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockUserRepository_Expecter) PurgeDeleted(ctx interface{}, before interface{}, limit interface{}) *MockUserRepository_PurgeDeleted_Call {
+	return &MockUserRepository_PurgeDeleted_Call{Call: _e.mock.On("PurgeDeleted", ctx, before, limit)}
+}
+
+func (_c *MockUserRepository_PurgeDeleted_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockUserRepository_PurgeDeleted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_PurgeDeleted_Call) Return(n int, err error) *MockUserRepository_PurgeDeleted_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserRepository_PurgeDeleted_Call) RunAndReturn(run func(ctx context.Context, before time.Time, limit int) (int, error)) *MockUserRepository_PurgeDeleted_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordLogin provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) RecordLogin(ctx context.Context, login domain.LoginEvent) error {
+	ret := _mock.Called(ctx, login)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordLogin")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.LoginEvent) error); ok {
+		r0 = returnFunc(ctx, login)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_RecordLogin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordLogin'
+type MockUserRepository_RecordLogin_Call struct {
+	*mock.Call
+}
+
+// RecordLogin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - login domain.LoginEvent
+func (_e *MockUserRepository_Expecter) RecordLogin(ctx interface{}, login interface{}) *MockUserRepository_RecordLogin_Call {
+	return &MockUserRepository_RecordLogin_Call{Call: _e.mock.On("RecordLogin", ctx, login)}
+}
+
+func (_c *MockUserRepository_RecordLogin_Call) Run(run func(ctx context.Context, login domain.LoginEvent)) *MockUserRepository_RecordLogin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.LoginEvent
+		if args[1] != nil {
+			arg1 = args[1].(domain.LoginEvent)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_RecordLogin_Call) Return(err error) *MockUserRepository_RecordLogin_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_RecordLogin_Call) RunAndReturn(run func(ctx context.Context, login domain.LoginEvent) error) *MockUserRepository_RecordLogin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Restore provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) Restore(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_Restore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Restore'
+type MockUserRepository_Restore_Call struct {
+	*mock.Call
+}
+
+// Restore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockUserRepository_Expecter) Restore(ctx interface{}, id interface{}) *MockUserRepository_Restore_Call {
+	return &MockUserRepository_Restore_Call{Call: _e.mock.On("Restore", ctx, id)}
+}
+
+func (_c *MockUserRepository_Restore_Call) Run(run func(ctx context.Context, id string)) *MockUserRepository_Restore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_Restore_Call) Return(err error) *MockUserRepository_Restore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_Restore_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockUserRepository_Restore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePassword provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SavePassword(ctx context.Context, userID string, password *domain.Password) error {
+	ret := _mock.Called(ctx, userID, password)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePassword")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Password) error); ok {
+		r0 = returnFunc(ctx, userID, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_SavePassword_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePassword'
+type MockUserRepository_SavePassword_Call struct {
+	*mock.Call
+}
+
+// SavePassword is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - password *domain.Password
+func (_e *MockUserRepository_Expecter) SavePassword(ctx interface{}, userID interface{}, password interface{}) *MockUserRepository_SavePassword_Call {
+	return &MockUserRepository_SavePassword_Call{Call: _e.mock.On("SavePassword", ctx, userID, password)}
+}
+
+func (_c *MockUserRepository_SavePassword_Call) Run(run func(ctx context.Context, userID string, password *domain.Password)) *MockUserRepository_SavePassword_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.Password
+		if args[2] != nil {
+			arg2 = args[2].(*domain.Password)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SavePassword_Call) Return(err error) *MockUserRepository_SavePassword_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_SavePassword_Call) RunAndReturn(run func(ctx context.Context, userID string, password *domain.Password) error) *MockUserRepository_SavePassword_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePreferences provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) SavePreferences(ctx context.Context, userID string, prefs *domain.Preferences) error {
+	ret := _mock.Called(ctx, userID, prefs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePreferences")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.Preferences) error); ok {
+		r0 = returnFunc(ctx, userID, prefs)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_SavePreferences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePreferences'
+type MockUserRepository_SavePreferences_Call struct {
+	*mock.Call
+}
+
+// SavePreferences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - prefs *domain.Preferences
+func (_e *MockUserRepository_Expecter) SavePreferences(ctx interface{}, userID interface{}, prefs interface{}) *MockUserRepository_SavePreferences_Call {
+	return &MockUserRepository_SavePreferences_Call{Call: _e.mock.On("SavePreferences", ctx, userID, prefs)}
+}
+
+func (_c *MockUserRepository_SavePreferences_Call) Run(run func(ctx context.Context, userID string, prefs *domain.Preferences)) *MockUserRepository_SavePreferences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.Preferences
+		if args[2] != nil {
+			arg2 = args[2].(*domain.Preferences)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_SavePreferences_Call) Return(err error) *MockUserRepository_SavePreferences_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepository_List_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]*domain.User, error)) *MockUserRepository_List_Call {
+func (_c *MockUserRepository_SavePreferences_Call) RunAndReturn(run func(ctx context.Context, userID string, prefs *domain.Preferences) error) *MockUserRepository_SavePreferences_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -38,6 +38,444 @@ func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
 	return &MockUserService_Expecter{mock: &_m.Mock}
 }
 
+// BulkCreateUsers provides a mock function for the type MockUserService
+func (_mock *MockUserService) BulkCreateUsers(ctx context.Context, inputs []domain.NewUserInput, mode domain.BulkMode) ([]domain.BulkCreateResult, error) {
+	ret := _mock.Called(ctx, inputs, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BulkCreateUsers")
+	}
+
+	var r0 []domain.BulkCreateResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.NewUserInput, domain.BulkMode) ([]domain.BulkCreateResult, error)); ok {
+		return returnFunc(ctx, inputs, mode)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.NewUserInput, domain.BulkMode) []domain.BulkCreateResult); ok {
+		r0 = returnFunc(ctx, inputs, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.BulkCreateResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []domain.NewUserInput, domain.BulkMode) error); ok {
+		r1 = returnFunc(ctx, inputs, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_BulkCreateUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BulkCreateUsers'
+type MockUserService_BulkCreateUsers_Call struct {
+	*mock.Call
+}
+
+// BulkCreateUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - inputs []domain.NewUserInput
+//   - mode domain.BulkMode
+func (_e *MockUserService_Expecter) BulkCreateUsers(ctx interface{}, inputs interface{}, mode interface{}) *MockUserService_BulkCreateUsers_Call {
+	return &MockUserService_BulkCreateUsers_Call{Call: _e.mock.On("BulkCreateUsers", ctx, inputs, mode)}
+}
+
+func (_c *MockUserService_BulkCreateUsers_Call) Run(run func(ctx context.Context, inputs []domain.NewUserInput, mode domain.BulkMode)) *MockUserService_BulkCreateUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []domain.NewUserInput
+		if args[1] != nil {
+			arg1 = args[1].([]domain.NewUserInput)
+		}
+		var arg2 domain.BulkMode
+		if args[2] != nil {
+			arg2 = args[2].(domain.BulkMode)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_BulkCreateUsers_Call) Return(bulkCreateResults []domain.BulkCreateResult, err error) *MockUserService_BulkCreateUsers_Call {
+	_c.Call.Return(bulkCreateResults, err)
+	return _c
+}
+
+func (_c *MockUserService_BulkCreateUsers_Call) RunAndReturn(run func(ctx context.Context, inputs []domain.NewUserInput, mode domain.BulkMode) ([]domain.BulkCreateResult, error)) *MockUserService_BulkCreateUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeEmail provides a mock function for the type MockUserService
+func (_mock *MockUserService) ChangeEmail(ctx context.Context, id string, email string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeEmail")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id, email)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_ChangeEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeEmail'
+type MockUserService_ChangeEmail_Call struct {
+	*mock.Call
+}
+
+// ChangeEmail is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - email string
+func (_e *MockUserService_Expecter) ChangeEmail(ctx interface{}, id interface{}, email interface{}) *MockUserService_ChangeEmail_Call {
+	return &MockUserService_ChangeEmail_Call{Call: _e.mock.On("ChangeEmail", ctx, id, email)}
+}
+
+func (_c *MockUserService_ChangeEmail_Call) Run(run func(ctx context.Context, id string, email string)) *MockUserService_ChangeEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ChangeEmail_Call) Return(user *domain.User, err error) *MockUserService_ChangeEmail_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_ChangeEmail_Call) RunAndReturn(run func(ctx context.Context, id string, email string) (*domain.User, error)) *MockUserService_ChangeEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeUserStatus provides a mock function for the type MockUserService
+func (_mock *MockUserService) ChangeUserStatus(ctx context.Context, id string, status domain.Status) (*domain.User, error) {
+	ret := _mock.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeUserStatus")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Status) (*domain.User, error)); ok {
+		return returnFunc(ctx, id, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.Status) *domain.User); ok {
+		r0 = returnFunc(ctx, id, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.Status) error); ok {
+		r1 = returnFunc(ctx, id, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_ChangeUserStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeUserStatus'
+type MockUserService_ChangeUserStatus_Call struct {
+	*mock.Call
+}
+
+// ChangeUserStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status domain.Status
+func (_e *MockUserService_Expecter) ChangeUserStatus(ctx interface{}, id interface{}, status interface{}) *MockUserService_ChangeUserStatus_Call {
+	return &MockUserService_ChangeUserStatus_Call{Call: _e.mock.On("ChangeUserStatus", ctx, id, status)}
+}
+
+func (_c *MockUserService_ChangeUserStatus_Call) Run(run func(ctx context.Context, id string, status domain.Status)) *MockUserService_ChangeUserStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.Status
+		if args[2] != nil {
+			arg2 = args[2].(domain.Status)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ChangeUserStatus_Call) Return(user *domain.User, err error) *MockUserService_ChangeUserStatus_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_ChangeUserStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status domain.Status) (*domain.User, error)) *MockUserService_ChangeUserStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ChangeUsername provides a mock function for the type MockUserService
+func (_mock *MockUserService) ChangeUsername(ctx context.Context, id string, username string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChangeUsername")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_ChangeUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeUsername'
+type MockUserService_ChangeUsername_Call struct {
+	*mock.Call
+}
+
+// ChangeUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - username string
+func (_e *MockUserService_Expecter) ChangeUsername(ctx interface{}, id interface{}, username interface{}) *MockUserService_ChangeUsername_Call {
+	return &MockUserService_ChangeUsername_Call{Call: _e.mock.On("ChangeUsername", ctx, id, username)}
+}
+
+func (_c *MockUserService_ChangeUsername_Call) Run(run func(ctx context.Context, id string, username string)) *MockUserService_ChangeUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ChangeUsername_Call) Return(user *domain.User, err error) *MockUserService_ChangeUsername_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_ChangeUsername_Call) RunAndReturn(run func(ctx context.Context, id string, username string) (*domain.User, error)) *MockUserService_ChangeUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CheckAvailability provides a mock function for the type MockUserService
+func (_mock *MockUserService) CheckAvailability(ctx context.Context, check domain.AvailabilityCheck) (*domain.AvailabilityResult, error) {
+	ret := _mock.Called(ctx, check)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckAvailability")
+	}
+
+	var r0 *domain.AvailabilityResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AvailabilityCheck) (*domain.AvailabilityResult, error)); ok {
+		return returnFunc(ctx, check)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.AvailabilityCheck) *domain.AvailabilityResult); ok {
+		r0 = returnFunc(ctx, check)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AvailabilityResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.AvailabilityCheck) error); ok {
+		r1 = returnFunc(ctx, check)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_CheckAvailability_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckAvailability'
+type MockUserService_CheckAvailability_Call struct {
+	*mock.Call
+}
+
+// CheckAvailability is a helper method to define mock.On call
+//   - ctx context.Context
+//   - check domain.AvailabilityCheck
+func (_e *MockUserService_Expecter) CheckAvailability(ctx interface{}, check interface{}) *MockUserService_CheckAvailability_Call {
+	return &MockUserService_CheckAvailability_Call{Call: _e.mock.On("CheckAvailability", ctx, check)}
+}
+
+func (_c *MockUserService_CheckAvailability_Call) Run(run func(ctx context.Context, check domain.AvailabilityCheck)) *MockUserService_CheckAvailability_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.AvailabilityCheck
+		if args[1] != nil {
+			arg1 = args[1].(domain.AvailabilityCheck)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_CheckAvailability_Call) Return(availabilityResult *domain.AvailabilityResult, err error) *MockUserService_CheckAvailability_Call {
+	_c.Call.Return(availabilityResult, err)
+	return _c
+}
+
+func (_c *MockUserService_CheckAvailability_Call) RunAndReturn(run func(ctx context.Context, check domain.AvailabilityCheck) (*domain.AvailabilityResult, error)) *MockUserService_CheckAvailability_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmEmailChange provides a mock function for the type MockUserService
+func (_mock *MockUserService) ConfirmEmailChange(ctx context.Context, id string, token string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmEmailChange")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, id, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_ConfirmEmailChange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmEmailChange'
+type MockUserService_ConfirmEmailChange_Call struct {
+	*mock.Call
+}
+
+// ConfirmEmailChange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - token string
+func (_e *MockUserService_Expecter) ConfirmEmailChange(ctx interface{}, id interface{}, token interface{}) *MockUserService_ConfirmEmailChange_Call {
+	return &MockUserService_ConfirmEmailChange_Call{Call: _e.mock.On("ConfirmEmailChange", ctx, id, token)}
+}
+
+func (_c *MockUserService_ConfirmEmailChange_Call) Run(run func(ctx context.Context, id string, token string)) *MockUserService_ConfirmEmailChange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ConfirmEmailChange_Call) Return(user *domain.User, err error) *MockUserService_ConfirmEmailChange_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_ConfirmEmailChange_Call) RunAndReturn(run func(ctx context.Context, id string, token string) (*domain.User, error)) *MockUserService_ConfirmEmailChange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function for the type MockUserService
 func (_mock *MockUserService) CreateUser(ctx context.Context, email string, name string) (*domain.User, error) {
 	ret := _mock.Called(ctx, email, name)
@@ -169,6 +607,137 @@ func (_c *MockUserService_DeleteUser_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// EraseUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) EraseUser(ctx context.Context, id string) (*domain.ErasureRecord, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EraseUser")
+	}
+
+	var r0 *domain.ErasureRecord
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.ErasureRecord, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.ErasureRecord); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ErasureRecord)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_EraseUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EraseUser'
+type MockUserService_EraseUser_Call struct {
+	*mock.Call
+}
+
+// EraseUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockUserService_Expecter) EraseUser(ctx interface{}, id interface{}) *MockUserService_EraseUser_Call {
+	return &MockUserService_EraseUser_Call{Call: _e.mock.On("EraseUser", ctx, id)}
+}
+
+func (_c *MockUserService_EraseUser_Call) Run(run func(ctx context.Context, id string)) *MockUserService_EraseUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_EraseUser_Call) Return(erasureRecord *domain.ErasureRecord, err error) *MockUserService_EraseUser_Call {
+	_c.Call.Return(erasureRecord, err)
+	return _c
+}
+
+func (_c *MockUserService_EraseUser_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.ErasureRecord, error)) *MockUserService_EraseUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportUsers provides a mock function for the type MockUserService
+func (_mock *MockUserService) ExportUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	ret := _mock.Called(ctx, filter, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserFilter, func(*domain.User) error) error); ok {
+		r0 = returnFunc(ctx, filter, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_ExportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUsers'
+type MockUserService_ExportUsers_Call struct {
+	*mock.Call
+}
+
+// ExportUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter domain.UserFilter
+//   - fn func(*domain.User) error
+func (_e *MockUserService_Expecter) ExportUsers(ctx interface{}, filter interface{}, fn interface{}) *MockUserService_ExportUsers_Call {
+	return &MockUserService_ExportUsers_Call{Call: _e.mock.On("ExportUsers", ctx, filter, fn)}
+}
+
+func (_c *MockUserService_ExportUsers_Call) Run(run func(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error)) *MockUserService_ExportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.UserFilter
+		if args[1] != nil {
+			arg1 = args[1].(domain.UserFilter)
+		}
+		var arg2 func(*domain.User) error
+		if args[2] != nil {
+			arg2 = args[2].(func(*domain.User) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ExportUsers_Call) Return(err error) *MockUserService_ExportUsers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_ExportUsers_Call) RunAndReturn(run func(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error) *MockUserService_ExportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUser provides a mock function for the type MockUserService
 func (_mock *MockUserService) GetUser(ctx context.Context, id string) (*domain.User, error) {
 	ret := _mock.Called(ctx, id)
@@ -305,9 +874,77 @@ func (_c *MockUserService_GetUserByEmail_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// GetUserByUsername provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	ret := _mock.Called(ctx, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByUsername")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetUserByUsername_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByUsername'
+type MockUserService_GetUserByUsername_Call struct {
+	*mock.Call
+}
+
+// GetUserByUsername is a helper method to define mock.On call
+//   - ctx context.Context
+//   - username string
+func (_e *MockUserService_Expecter) GetUserByUsername(ctx interface{}, username interface{}) *MockUserService_GetUserByUsername_Call {
+	return &MockUserService_GetUserByUsername_Call{Call: _e.mock.On("GetUserByUsername", ctx, username)}
+}
+
+func (_c *MockUserService_GetUserByUsername_Call) Run(run func(ctx context.Context, username string)) *MockUserService_GetUserByUsername_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetUserByUsername_Call) Return(user *domain.User, err error) *MockUserService_GetUserByUsername_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_GetUserByUsername_Call) RunAndReturn(run func(ctx context.Context, username string) (*domain.User, error)) *MockUserService_GetUserByUsername_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListUsers provides a mock function for the type MockUserService
-func (_mock *MockUserService) ListUsers(ctx context.Context, limit int, offset int) ([]*domain.User, error) {
-	ret := _mock.Called(ctx, limit, offset)
+func (_mock *MockUserService) ListUsers(ctx context.Context, filter domain.UserFilter, limit int, offset int) ([]*domain.User, error) {
+	ret := _mock.Called(ctx, filter, limit, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListUsers")
@@ -315,18 +952,18 @@ func (_mock *MockUserService) ListUsers(ctx context.Context, limit int, offset i
 
 	var r0 []*domain.User
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) ([]*domain.User, error)); ok {
-		return returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserFilter, int, int) ([]*domain.User, error)); ok {
+		return returnFunc(ctx, filter, limit, offset)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) []*domain.User); ok {
-		r0 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserFilter, int, int) []*domain.User); ok {
+		r0 = returnFunc(ctx, filter, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*domain.User)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
-		r1 = returnFunc(ctx, limit, offset)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserFilter, int, int) error); ok {
+		r1 = returnFunc(ctx, filter, limit, offset)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -340,30 +977,36 @@ type MockUserService_ListUsers_Call struct {
 
 // ListUsers is a helper method to define mock.On call
 //   - ctx context.Context
+//   - filter domain.UserFilter
 //   - limit int
 //   - offset int
-func (_e *MockUserService_Expecter) ListUsers(ctx interface{}, limit interface{}, offset interface{}) *MockUserService_ListUsers_Call {
-	return &MockUserService_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, limit, offset)}
+func (_e *MockUserService_Expecter) ListUsers(ctx interface{}, filter interface{}, limit interface{}, offset interface{}) *MockUserService_ListUsers_Call {
+	return &MockUserService_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx, filter, limit, offset)}
 }
 
-func (_c *MockUserService_ListUsers_Call) Run(run func(ctx context.Context, limit int, offset int)) *MockUserService_ListUsers_Call {
+func (_c *MockUserService_ListUsers_Call) Run(run func(ctx context.Context, filter domain.UserFilter, limit int, offset int)) *MockUserService_ListUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 domain.UserFilter
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(domain.UserFilter)
 		}
 		var arg2 int
 		if args[2] != nil {
 			arg2 = args[2].(int)
 		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -374,7 +1017,75 @@ func (_c *MockUserService_ListUsers_Call) Return(users []*domain.User, err error
 	return _c
 }
 
-func (_c *MockUserService_ListUsers_Call) RunAndReturn(run func(ctx context.Context, limit int, offset int) ([]*domain.User, error)) *MockUserService_ListUsers_Call {
+func (_c *MockUserService_ListUsers_Call) RunAndReturn(run func(ctx context.Context, filter domain.UserFilter, limit int, offset int) ([]*domain.User, error)) *MockUserService_ListUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreUser provides a mock function for the type MockUserService
+func (_mock *MockUserService) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreUser")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_RestoreUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreUser'
+type MockUserService_RestoreUser_Call struct {
+	*mock.Call
+}
+
+// RestoreUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockUserService_Expecter) RestoreUser(ctx interface{}, id interface{}) *MockUserService_RestoreUser_Call {
+	return &MockUserService_RestoreUser_Call{Call: _e.mock.On("RestoreUser", ctx, id)}
+}
+
+func (_c *MockUserService_RestoreUser_Call) Run(run func(ctx context.Context, id string)) *MockUserService_RestoreUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_RestoreUser_Call) Return(user *domain.User, err error) *MockUserService_RestoreUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockUserService_RestoreUser_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.User, error)) *MockUserService_RestoreUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -452,3 +1163,69 @@ func (_c *MockUserService_UpdateUser_Call) RunAndReturn(run func(ctx context.Con
 	_c.Call.Return(run)
 	return _c
 }
+
+// UserExists provides a mock function for the type MockUserService
+func (_mock *MockUserService) UserExists(ctx context.Context, key domain.UserKey) (bool, error) {
+	ret := _mock.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserKey) (bool, error)); ok {
+		return returnFunc(ctx, key)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.UserKey) bool); ok {
+		r0 = returnFunc(ctx, key)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.UserKey) error); ok {
+		r1 = returnFunc(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_UserExists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserExists'
+type MockUserService_UserExists_Call struct {
+	*mock.Call
+}
+
+// UserExists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key domain.UserKey
+func (_e *MockUserService_Expecter) UserExists(ctx interface{}, key interface{}) *MockUserService_UserExists_Call {
+	return &MockUserService_UserExists_Call{Call: _e.mock.On("UserExists", ctx, key)}
+}
+
+func (_c *MockUserService_UserExists_Call) Run(run func(ctx context.Context, key domain.UserKey)) *MockUserService_UserExists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.UserKey
+		if args[1] != nil {
+			arg1 = args[1].(domain.UserKey)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_UserExists_Call) Return(b bool, err error) *MockUserService_UserExists_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockUserService_UserExists_Call) RunAndReturn(run func(ctx context.Context, key domain.UserKey) (bool, error)) *MockUserService_UserExists_Call {
+	_c.Call.Return(run)
+	return _c
+}
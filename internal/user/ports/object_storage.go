@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+//go:generate mockery --name=ObjectStorage --output=mocks --outpkg=mocks
+
+// ObjectStorage defines the interface for storing private objects such as
+// exports, imports and attachments
+type ObjectStorage interface {
+	// Put stores the object under key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+
+	// Get opens the object, failing with domain.ErrObjectNotFound when it
+	// is missing. The caller closes the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// SignURL returns a URL letting a client without credentials send a
+	// GET or PUT request for the object until ttl elapsed; a zero ttl uses
+	// the configured default
+	SignURL(ctx context.Context, key, method string, ttl time.Duration) (string, error)
+
+	// Delete removes the object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,12 @@
+package ports
+
+//go:generate mockery --name=PasswordHasher --output=mocks --outpkg=mocks
+
+// PasswordHasher turns passwords into hashes that are safe to store
+type PasswordHasher interface {
+	// Hash returns a salted hash of password
+	Hash(password string) (string, error)
+
+	// Verify reports whether password matches hash
+	Verify(hash, password string) (bool, error)
+}
@@ -0,0 +1,25 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=UserPasswords --output=mocks --outpkg=mocks
+
+// UserPasswords defines the interface for managing user passwords
+type UserPasswords interface {
+	// Register creates a user with a password that follows the policy
+	Register(ctx context.Context, email, name, password string) (*domain.User, error)
+
+	// ChangePassword replaces the user's password. current must match the
+	// existing password, if the user has one.
+	ChangePassword(ctx context.Context, id, current, password string) error
+
+	// Authenticate returns the user of email when password is theirs and
+	// they may log in, with the risk assessment of the login, and records
+	// the attempt of client in their login history. Unknown emails and
+	// wrong passwords fail alike with ErrInvalidCredentials.
+	Authenticate(ctx context.Context, email, password string, client domain.LoginClient) (*domain.Authentication, error)
+}
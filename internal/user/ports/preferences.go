@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=UserPreferences --output=mocks --outpkg=mocks
+
+// UserPreferences defines the interface for managing user preferences
+type UserPreferences interface {
+	// GetPreferences returns the user's preferences, or the defaults if they never saved any
+	GetPreferences(ctx context.Context, id string) (*domain.Preferences, error)
+
+	// UpdatePreferences replaces the user's preferences; fields missing from
+	// the update are reset to their defaults
+	UpdatePreferences(ctx context.Context, id string, update domain.PreferencesUpdate) (*domain.Preferences, error)
+}
@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=UserReadModel --output=mocks --outpkg=mocks
+
+// UserReadModel defines the interface for the denormalized copy of users
+// that listings and searches read instead of the write model. It is kept
+// up to date from user events, so it lags behind the repository slightly.
+type UserReadModel interface {
+	// Save creates or replaces the users, each in its tenant, unless a
+	// newer version of the user is already saved
+	Save(ctx context.Context, users []*domain.User, projectedAt time.Time) error
+
+	// Remove removes the users with the given IDs
+	Remove(ctx context.Context, ids []string) error
+
+	// RemoveStale removes the users last saved before the time and returns
+	// how many were removed
+	RemoveStale(ctx context.Context, before time.Time) (int, error)
+
+	// List retrieves users matching the filter with pagination, newest
+	// first, like UserRepository.List
+	List(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error)
+}
+
+//go:generate mockery --name=UserProjection --output=mocks --outpkg=mocks
+
+// UserProjection defines the interface for maintaining the user read model
+type UserProjection interface {
+	// Run applies the events of users to the read model as they are
+	// stored, until ctx is done
+	Run(ctx context.Context)
+
+	// Rebuild saves every stored user to the read model, removes those no
+	// longer stored, and returns how many were saved
+	Rebuild(ctx context.Context) (int, error)
+}
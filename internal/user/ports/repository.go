@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
 )
@@ -10,21 +11,82 @@ import (
 
 // UserRepository defines the interface for user data access
 type UserRepository interface {
-	// Create creates a new user
+	// Create creates a new user. Create, CreateBatch and Update also store
+	// the events recorded on the users in the same transaction.
 	Create(ctx context.Context, user *domain.User) error
 
+	// CreateBatch creates multiple users in a single transaction. When
+	// emails or usernames are taken it creates none of them and returns a
+	// *domain.BatchConflictError naming every conflicting user.
+	CreateBatch(ctx context.Context, users []*domain.User) error
+
+	// FindExistingEmails returns which of the given emails are already taken
+	FindExistingEmails(ctx context.Context, emails []string) ([]string, error)
+
 	// GetByID retrieves a user by ID
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 
+	// GetByIDIncludingDeleted retrieves a user by ID, including soft-deleted users
+	GetByIDIncludingDeleted(ctx context.Context, id string) (*domain.User, error)
+
+	// GetByUsername retrieves a user by username
+	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+
 	// GetByEmail retrieves a user by email
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
 
+	// Exists reports whether the user of key exists, without loading it
+	Exists(ctx context.Context, key domain.UserKey) (bool, error)
+
 	// Update updates an existing user
 	Update(ctx context.Context, user *domain.User) error
 
 	// Delete deletes a user by ID
 	Delete(ctx context.Context, id string) error
 
-	// List retrieves users with pagination
-	List(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	// Erase permanently deletes a user and stores the erasure record in the
+	// same transaction
+	Erase(ctx context.Context, record *domain.ErasureRecord) error
+
+	// PurgeDeleted permanently deletes up to limit users soft-deleted before
+	// the cutoff and returns how many were deleted
+	PurgeDeleted(ctx context.Context, before time.Time, limit int) (int, error)
+
+	// CountDeleted counts users soft-deleted before the cutoff
+	CountDeleted(ctx context.Context, before time.Time) (int, error)
+
+	// Restore undoes the soft delete of a user by ID
+	Restore(ctx context.Context, id string) error
+
+	// GetPreferences retrieves the preferences saved by a user
+	GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error)
+
+	// SavePreferences creates or replaces a user's preferences
+	SavePreferences(ctx context.Context, userID string, prefs *domain.Preferences) error
+
+	// GetPassword retrieves a user's stored password
+	GetPassword(ctx context.Context, userID string) (*domain.Password, error)
+
+	// SavePassword creates or replaces a user's stored password
+	SavePassword(ctx context.Context, userID string, password *domain.Password) error
+
+	// ListEvents retrieves up to limit events matching the filter, newest
+	// first, starting after the cursor when one is given
+	ListEvents(ctx context.Context, filter domain.EventFilter, cursor *domain.EventCursor, limit int) ([]domain.Event, error)
+
+	// RecordLogin stores a login attempt. A successful one also sets the
+	// user's LastLoginAt, in the same transaction and without changing
+	// UpdatedAt.
+	RecordLogin(ctx context.Context, login domain.LoginEvent) error
+
+	// ListLogins retrieves up to limit login attempts of the user, newest
+	// first, starting after the cursor when one is given
+	ListLogins(ctx context.Context, userID string, cursor *domain.EventCursor, limit int) ([]domain.LoginEvent, error)
+
+	// List retrieves users matching the filter with pagination
+	List(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error)
+
+	// ListStream calls fn for every user matching the filter without loading
+	// them all into memory. Iteration stops at the first error returned by fn.
+	ListStream(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error
 }
@@ -0,0 +1,865 @@
+// Package repotest provides a conformance suite for ports.UserRepository.
+// Every adapter runs it so they keep identical semantics, and services can
+// rely on the contract whichever adapter is configured.
+package repotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// Factory returns an empty repository. It is called once per test case.
+type Factory func(t *testing.T) ports.UserRepository
+
+// Run exercises the full ports.UserRepository contract against the
+// repositories returned by newRepo
+func Run(t *testing.T, newRepo Factory) {
+	t.Run("Create", func(t *testing.T) { testCreate(t, newRepo) })
+	t.Run("CreateBatch", func(t *testing.T) { testCreateBatch(t, newRepo) })
+	t.Run("FindExistingEmails", func(t *testing.T) { testFindExistingEmails(t, newRepo) })
+	t.Run("Get", func(t *testing.T) { testGet(t, newRepo) })
+	t.Run("Exists", func(t *testing.T) { testExists(t, newRepo) })
+	t.Run("Update", func(t *testing.T) { testUpdate(t, newRepo) })
+	t.Run("SoftDelete", func(t *testing.T) { testSoftDelete(t, newRepo) })
+	t.Run("Erase", func(t *testing.T) { testErase(t, newRepo) })
+	t.Run("PurgeDeleted", func(t *testing.T) { testPurgeDeleted(t, newRepo) })
+	t.Run("Preferences", func(t *testing.T) { testPreferences(t, newRepo) })
+	t.Run("Passwords", func(t *testing.T) { testPasswords(t, newRepo) })
+	t.Run("Events", func(t *testing.T) { testEvents(t, newRepo) })
+	t.Run("Logins", func(t *testing.T) { testLogins(t, newRepo) })
+	t.Run("List", func(t *testing.T) { testList(t, newRepo) })
+	t.Run("ListStream", func(t *testing.T) { testListStream(t, newRepo) })
+}
+
+// newUser returns a valid user with the given email
+func newUser(t *testing.T, email string) *domain.User {
+	t.Helper()
+
+	user, err := domain.NewUser(uuid.NewString(), email, "Test User", time.Now())
+	require.NoError(t, err)
+	user.AssignEventIDs(uuid.NewString)
+	return user
+}
+
+// withUsername sets the user's username
+func withUsername(t *testing.T, user *domain.User, username string) *domain.User {
+	t.Helper()
+
+	require.NoError(t, user.ChangeUsername(username, time.Now()))
+	user.AssignEventIDs(uuid.NewString)
+	return user
+}
+
+// create stores users and fails the test on error
+func create(t *testing.T, repo ports.UserRepository, users ...*domain.User) {
+	t.Helper()
+
+	for _, user := range users {
+		user.AssignEventIDs(uuid.NewString)
+		require.NoError(t, repo.Create(context.Background(), user))
+	}
+}
+
+// ids returns the IDs of users in order
+func ids(users []*domain.User) []string {
+	ids := []string{}
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
+
+func testCreate(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("stores the user and clears its events", func(t *testing.T) {
+		repo := newRepo(t)
+		user := withUsername(t, newUser(t, "create@example.com"), "creator")
+
+		require.NoError(t, repo.Create(ctx, user))
+		assert.Empty(t, user.Events())
+
+		stored, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.Email, stored.Email)
+		assert.Equal(t, user.Name, stored.Name)
+		assert.Equal(t, user.Username, stored.Username)
+		assert.Equal(t, user.Status, stored.Status)
+		assert.WithinDuration(t, user.CreatedAt, stored.CreatedAt, time.Millisecond)
+		assert.Nil(t, stored.DeletedAt)
+	})
+
+	t.Run("rejects a taken email ignoring case", func(t *testing.T) {
+		repo := newRepo(t)
+		create(t, repo, newUser(t, "taken@example.com"))
+
+		err := repo.Create(ctx, newUser(t, "TAKEN@example.com"))
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+	})
+
+	t.Run("rejects a taken username", func(t *testing.T) {
+		repo := newRepo(t)
+		create(t, repo, withUsername(t, newUser(t, "first@example.com"), "taken"))
+
+		err := repo.Create(ctx, withUsername(t, newUser(t, "second@example.com"), "taken"))
+		assert.ErrorIs(t, err, domain.ErrDuplicateUsername)
+	})
+
+	t.Run("users without a username do not conflict", func(t *testing.T) {
+		repo := newRepo(t)
+		create(t, repo, newUser(t, "first@example.com"), newUser(t, "second@example.com"))
+	})
+
+	t.Run("soft-deleted users keep their email and username", func(t *testing.T) {
+		repo := newRepo(t)
+		deleted := withUsername(t, newUser(t, "deleted@example.com"), "deleted")
+		create(t, repo, deleted)
+		require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+		err := repo.Create(ctx, newUser(t, "deleted@example.com"))
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+
+		err = repo.Create(ctx, withUsername(t, newUser(t, "other@example.com"), "deleted"))
+		assert.ErrorIs(t, err, domain.ErrDuplicateUsername)
+	})
+}
+
+func testCreateBatch(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("empty batch is a no-op", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.NoError(t, repo.CreateBatch(ctx, nil))
+	})
+
+	t.Run("creates all users", func(t *testing.T) {
+		repo := newRepo(t)
+		users := []*domain.User{newUser(t, "a@example.com"), newUser(t, "b@example.com")}
+
+		require.NoError(t, repo.CreateBatch(ctx, users))
+
+		for _, user := range users {
+			assert.Empty(t, user.Events())
+			_, err := repo.GetByID(ctx, user.ID)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("creates nothing when an email is taken within the batch", func(t *testing.T) {
+		repo := newRepo(t)
+		first := newUser(t, "dup@example.com")
+
+		err := repo.CreateBatch(ctx, []*domain.User{first, newUser(t, "DUP@example.com")})
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+
+		_, err = repo.GetByID(ctx, first.ID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("creates nothing when an email is already stored", func(t *testing.T) {
+		repo := newRepo(t)
+		create(t, repo, newUser(t, "stored@example.com"))
+		fresh := newUser(t, "fresh@example.com")
+
+		err := repo.CreateBatch(ctx, []*domain.User{fresh, newUser(t, "stored@example.com")})
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+
+		_, err = repo.GetByID(ctx, fresh.ID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("rejects a taken username", func(t *testing.T) {
+		repo := newRepo(t)
+		users := []*domain.User{
+			withUsername(t, newUser(t, "a@example.com"), "same"),
+			withUsername(t, newUser(t, "b@example.com"), "same"),
+		}
+
+		assert.ErrorIs(t, repo.CreateBatch(ctx, users), domain.ErrDuplicateUsername)
+	})
+
+	t.Run("reports every conflicting user", func(t *testing.T) {
+		repo := newRepo(t)
+		create(t, repo, newUser(t, "stored@example.com"))
+		users := []*domain.User{
+			newUser(t, "fresh@example.com"),
+			newUser(t, "STORED@example.com"),
+			withUsername(t, newUser(t, "a@example.com"), "same"),
+			withUsername(t, newUser(t, "b@example.com"), "same"),
+			newUser(t, "Fresh@example.com"),
+		}
+
+		err := repo.CreateBatch(ctx, users)
+
+		var conflicts *domain.BatchConflictError
+		require.ErrorAs(t, err, &conflicts)
+		assert.Equal(t, []domain.BatchConflict{
+			{Index: 1, Err: domain.ErrDuplicateEmail},
+			{Index: 3, Err: domain.ErrDuplicateUsername},
+			{Index: 4, Err: domain.ErrDuplicateEmail},
+		}, conflicts.Conflicts)
+
+		for _, user := range users {
+			_, err := repo.GetByID(ctx, user.ID)
+			assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		}
+	})
+
+	t.Run("a conflicting user does not block later ones", func(t *testing.T) {
+		repo := newRepo(t)
+		create(t, repo, newUser(t, "stored@example.com"))
+		users := []*domain.User{
+			withUsername(t, newUser(t, "stored@example.com"), "taken"),
+			withUsername(t, newUser(t, "other@example.com"), "taken"),
+		}
+
+		err := repo.CreateBatch(ctx, users)
+
+		// The first user was skipped, so its username stayed free
+		var conflicts *domain.BatchConflictError
+		require.ErrorAs(t, err, &conflicts)
+		assert.Equal(t, []domain.BatchConflict{{Index: 0, Err: domain.ErrDuplicateEmail}}, conflicts.Conflicts)
+	})
+}
+
+func testFindExistingEmails(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	deleted := newUser(t, "deleted@example.com")
+	create(t, repo, newUser(t, "active@example.com"), deleted)
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	t.Run("returns taken emails ignoring case, including soft-deleted users", func(t *testing.T) {
+		existing, err := repo.FindExistingEmails(ctx, []string{"ACTIVE@example.com", "deleted@example.com", "free@example.com"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"active@example.com", "deleted@example.com"}, existing)
+	})
+
+	t.Run("returns an empty slice for no emails", func(t *testing.T) {
+		existing, err := repo.FindExistingEmails(ctx, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, existing)
+		assert.Empty(t, existing)
+	})
+}
+
+func testGet(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	user := withUsername(t, newUser(t, "get@example.com"), "getter")
+	create(t, repo, user)
+
+	t.Run("by ID", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	})
+
+	t.Run("by username", func(t *testing.T) {
+		found, err := repo.GetByUsername(ctx, "getter")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	})
+
+	t.Run("by email ignoring case", func(t *testing.T) {
+		found, err := repo.GetByEmail(ctx, "Get@Example.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+	})
+
+	t.Run("returns ErrUserNotFound for unknown users", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, "00000000-0000-0000-0000-000000000000")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		_, err = repo.GetByIDIncludingDeleted(ctx, "00000000-0000-0000-0000-000000000000")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		_, err = repo.GetByUsername(ctx, "nobody")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		_, err = repo.GetByEmail(ctx, "nobody@example.com")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("returned users do not share state with the repository", func(t *testing.T) {
+		found, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		found.Name = "Changed Locally"
+
+		again, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Test User", again.Name)
+	})
+}
+
+func testExists(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	user := withUsername(t, newUser(t, "exists@example.com"), "exists")
+	deleted := withUsername(t, newUser(t, "deleted@example.com"), "deleted")
+	create(t, repo, user, deleted)
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	cases := []struct {
+		name string
+		key  domain.UserKey
+		want bool
+	}{
+		{"by ID", domain.UserKey{ID: user.ID}, true},
+		{"by username", domain.UserKey{Username: "exists"}, true},
+		{"by email ignoring case", domain.UserKey{Email: "Exists@Example.com"}, true},
+		{"unknown ID", domain.UserKey{ID: "00000000-0000-0000-0000-000000000000"}, false},
+		{"unknown username", domain.UserKey{Username: "nobody"}, false},
+		{"unknown email", domain.UserKey{Email: "nobody@example.com"}, false},
+		{"soft-deleted by ID", domain.UserKey{ID: deleted.ID}, false},
+		{"soft-deleted by username", domain.UserKey{Username: "deleted"}, false},
+		{"soft-deleted by email", domain.UserKey{Email: "deleted@example.com"}, false},
+		{"soft-deleted by ID including deleted", domain.UserKey{ID: deleted.ID, IncludeDeleted: true}, true},
+		{"soft-deleted by username including deleted", domain.UserKey{Username: "deleted", IncludeDeleted: true}, true},
+		{"soft-deleted by email including deleted", domain.UserKey{Email: "Deleted@example.com", IncludeDeleted: true}, true},
+		{"unknown email including deleted", domain.UserKey{Email: "nobody@example.com", IncludeDeleted: true}, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			exists, err := repo.Exists(ctx, tc.key)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, exists)
+		})
+	}
+}
+
+func testUpdate(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("persists changes", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser(t, "update@example.com")
+		create(t, repo, user)
+
+		require.NoError(t, user.UpdateName("Updated Name", time.Now()))
+		require.NoError(t, user.ChangeUsername("updated", time.Now()))
+		user.AssignEventIDs(uuid.NewString)
+		require.NoError(t, repo.Update(ctx, user))
+		assert.Empty(t, user.Events())
+
+		stored, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Updated Name", stored.Name)
+		assert.Equal(t, "updated", stored.Username)
+	})
+
+	t.Run("persists and clears a pending email change", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser(t, "pending@example.com")
+		create(t, repo, user)
+
+		expiresAt := time.Now().Add(time.Hour)
+		user.PendingEmailChange = &domain.EmailChange{Email: "new@example.com", TokenHash: "hash", ExpiresAt: expiresAt}
+		require.NoError(t, repo.Update(ctx, user))
+
+		stored, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, stored.PendingEmailChange)
+		assert.Equal(t, "new@example.com", stored.PendingEmailChange.Email)
+		assert.Equal(t, "hash", stored.PendingEmailChange.TokenHash)
+		assert.WithinDuration(t, expiresAt, stored.PendingEmailChange.ExpiresAt, time.Millisecond)
+
+		user.PendingEmailChange = nil
+		require.NoError(t, repo.Update(ctx, user))
+
+		stored, err = repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, stored.PendingEmailChange)
+	})
+
+	t.Run("rejects an email or username taken by another user", func(t *testing.T) {
+		repo := newRepo(t)
+		user := newUser(t, "mine@example.com")
+		create(t, repo, user, withUsername(t, newUser(t, "theirs@example.com"), "theirs"))
+
+		user.Email = "THEIRS@example.com"
+		assert.ErrorIs(t, repo.Update(ctx, user), domain.ErrDuplicateEmail)
+
+		user.Email = "mine@example.com"
+		user.Username = "theirs"
+		assert.ErrorIs(t, repo.Update(ctx, user), domain.ErrDuplicateUsername)
+	})
+
+	t.Run("returns ErrUserNotFound for unknown or deleted users", func(t *testing.T) {
+		repo := newRepo(t)
+		assert.ErrorIs(t, repo.Update(ctx, newUser(t, "unknown@example.com")), domain.ErrUserNotFound)
+
+		deleted := newUser(t, "deleted@example.com")
+		create(t, repo, deleted)
+		require.NoError(t, repo.Delete(ctx, deleted.ID))
+		assert.ErrorIs(t, repo.Update(ctx, deleted), domain.ErrUserNotFound)
+	})
+}
+
+func testSoftDelete(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	user := withUsername(t, newUser(t, "soft@example.com"), "soft")
+	create(t, repo, user)
+	require.NoError(t, repo.Delete(ctx, user.ID))
+
+	t.Run("hides the user from lookups", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, user.ID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		_, err = repo.GetByUsername(ctx, "soft")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+		_, err = repo.GetByEmail(ctx, "soft@example.com")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+
+	t.Run("keeps the user retrievable including deleted", func(t *testing.T) {
+		deleted, err := repo.GetByIDIncludingDeleted(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, deleted.DeletedAt)
+		assert.WithinDuration(t, time.Now(), *deleted.DeletedAt, time.Minute)
+	})
+
+	t.Run("deleting twice returns ErrUserNotFound", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Delete(ctx, user.ID), domain.ErrUserNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, "00000000-0000-0000-0000-000000000000"), domain.ErrUserNotFound)
+	})
+
+	t.Run("restore brings the user back", func(t *testing.T) {
+		require.NoError(t, repo.Restore(ctx, user.ID))
+
+		restored, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+	})
+
+	t.Run("restoring a user that is not deleted returns ErrUserNotFound", func(t *testing.T) {
+		assert.ErrorIs(t, repo.Restore(ctx, user.ID), domain.ErrUserNotFound)
+		assert.ErrorIs(t, repo.Restore(ctx, "00000000-0000-0000-0000-000000000000"), domain.ErrUserNotFound)
+	})
+}
+
+func testErase(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	active := newUser(t, "active@example.com")
+	deleted := newUser(t, "deleted@example.com")
+	create(t, repo, active, deleted)
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	for _, user := range []*domain.User{active, deleted} {
+		require.NoError(t, repo.Erase(ctx, domain.NewErasureRecord(uuid.NewString(), user.ID, time.Now())))
+
+		_, err := repo.GetByIDIncludingDeleted(ctx, user.ID)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	}
+
+	// The email is free again once the user is erased
+	create(t, repo, newUser(t, "deleted@example.com"))
+
+	err := repo.Erase(ctx, domain.NewErasureRecord(uuid.NewString(), active.ID, time.Now()))
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func testPurgeDeleted(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	active := newUser(t, "active@example.com")
+	deleted := []*domain.User{newUser(t, "a@example.com"), newUser(t, "b@example.com"), newUser(t, "c@example.com")}
+	create(t, repo, active)
+	create(t, repo, deleted...)
+	for _, user := range deleted {
+		require.NoError(t, repo.Delete(ctx, user.ID))
+	}
+
+	t.Run("ignores users deleted after the cutoff", func(t *testing.T) {
+		cutoff := time.Now().Add(-time.Hour)
+
+		count, err := repo.CountDeleted(ctx, cutoff)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+
+		purged, err := repo.PurgeDeleted(ctx, cutoff, 10)
+		require.NoError(t, err)
+		assert.Zero(t, purged)
+	})
+
+	t.Run("purges at most limit users per call", func(t *testing.T) {
+		cutoff := time.Now().Add(time.Minute)
+
+		count, err := repo.CountDeleted(ctx, cutoff)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+
+		purged, err := repo.PurgeDeleted(ctx, cutoff, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, purged)
+
+		purged, err = repo.PurgeDeleted(ctx, cutoff, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		count, err = repo.CountDeleted(ctx, cutoff)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("keeps active users", func(t *testing.T) {
+		_, err := repo.GetByID(ctx, active.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func testPreferences(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	user := newUser(t, "prefs@example.com")
+	create(t, repo, user)
+
+	_, err := repo.GetPreferences(ctx, user.ID)
+	assert.ErrorIs(t, err, domain.ErrPreferencesNotFound)
+
+	for _, prefs := range []domain.Preferences{
+		{Locale: "en-US", Timezone: "UTC", Notifications: domain.NotificationPreferences{Email: true, Digest: domain.DigestWeekly}},
+		{Locale: "de-DE", Timezone: "Europe/Berlin", Notifications: domain.NotificationPreferences{Email: false, Digest: domain.DigestOff}},
+	} {
+		prefs.UpdatedAt = time.Now()
+		require.NoError(t, repo.SavePreferences(ctx, user.ID, &prefs))
+
+		stored, err := repo.GetPreferences(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, prefs.Locale, stored.Locale)
+		assert.Equal(t, prefs.Timezone, stored.Timezone)
+		assert.Equal(t, prefs.Notifications, stored.Notifications)
+		assert.WithinDuration(t, prefs.UpdatedAt, stored.UpdatedAt, time.Millisecond)
+	}
+}
+
+func testPasswords(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	user := newUser(t, "password@example.com")
+	create(t, repo, user)
+
+	_, err := repo.GetPassword(ctx, user.ID)
+	assert.ErrorIs(t, err, domain.ErrPasswordNotSet)
+
+	for _, hash := range []string{"$argon2id$first", "$argon2id$second"} {
+		password := &domain.Password{Hash: hash, UpdatedAt: time.Now()}
+		require.NoError(t, repo.SavePassword(ctx, user.ID, password))
+
+		stored, err := repo.GetPassword(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, hash, stored.Hash)
+		assert.WithinDuration(t, password.UpdatedAt, stored.UpdatedAt, time.Millisecond)
+	}
+}
+
+func testEvents(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	user := newUser(t, "events@example.com")
+	create(t, repo, user)
+	require.NoError(t, user.UpdateName("Renamed User", time.Now()))
+	user.AssignEventIDs(uuid.NewString)
+	require.NoError(t, repo.Update(ctx, user))
+	require.NoError(t, repo.Delete(ctx, user.ID))
+	require.NoError(t, repo.Restore(ctx, user.ID))
+	other := newUser(t, "other@example.com")
+	create(t, repo, other)
+
+	byUser := domain.EventFilter{UserID: user.ID}
+	events, err := repo.ListEvents(ctx, byUser, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	t.Run("lists only the user's events, newest first", func(t *testing.T) {
+		types := []domain.EventType{}
+		for i, event := range events {
+			assert.Equal(t, user.ID, event.UserID)
+			types = append(types, event.Type)
+			if i > 0 {
+				assert.False(t, event.OccurredAt.After(events[i-1].OccurredAt))
+			}
+		}
+		assert.ElementsMatch(t, []domain.EventType{
+			domain.EventUserCreated,
+			domain.EventNameChanged,
+			domain.EventUserDeleted,
+			domain.EventUserRestored,
+		}, types)
+	})
+
+	t.Run("pages continue after the cursor without gaps or repeats", func(t *testing.T) {
+		first, err := repo.ListEvents(ctx, byUser, nil, 3)
+		require.NoError(t, err)
+		require.Len(t, first, 3)
+
+		cursor := domain.CursorAfter(first[2])
+		second, err := repo.ListEvents(ctx, byUser, &cursor, 3)
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+
+		assert.Equal(t, eventIDs(events), eventIDs(append(first, second...)))
+
+		cursor = domain.CursorAfter(second[0])
+		rest, err := repo.ListEvents(ctx, byUser, &cursor, 3)
+		require.NoError(t, err)
+		assert.Empty(t, rest)
+	})
+
+	t.Run("unknown users have no events", func(t *testing.T) {
+		none, err := repo.ListEvents(ctx, domain.EventFilter{UserID: "00000000-0000-0000-0000-000000000000"}, nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+
+	t.Run("lists the events of all users without a user filter", func(t *testing.T) {
+		all, err := repo.ListEvents(ctx, domain.EventFilter{}, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, all, 5)
+
+		created, err := repo.ListEvents(ctx, domain.EventFilter{Type: domain.EventUserCreated}, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, created, 2)
+		assert.ElementsMatch(t, []string{user.ID, other.ID}, []string{created[0].UserID, created[1].UserID})
+
+		renamed, err := repo.ListEvents(ctx, domain.EventFilter{UserID: other.ID, Type: domain.EventNameChanged}, nil, 10)
+		require.NoError(t, err)
+		assert.Empty(t, renamed)
+	})
+}
+
+// eventIDs returns the IDs of events in order
+func eventIDs(events []domain.Event) []string {
+	ids := []string{}
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+	return ids
+}
+
+func testLogins(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+
+	user := newUser(t, "logins@example.com")
+	never := newUser(t, "never@example.com")
+	create(t, repo, user, never)
+
+	stored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	updatedAt := stored.UpdatedAt
+
+	client := domain.LoginClient{IP: "203.0.113.7", UserAgent: "Mozilla/5.0", Country: "FR"}
+	start := time.Now().UTC()
+	logins := []domain.LoginEvent{
+		domain.NewLoginEvent(uuid.NewString(), user.ID, client, domain.ErrInvalidCredentials, start),
+		domain.NewLoginEvent(uuid.NewString(), user.ID, client, nil, start.Add(time.Second)),
+		domain.NewLoginEvent(uuid.NewString(), user.ID, client, nil, start.Add(2*time.Second)),
+		domain.NewLoginEvent(uuid.NewString(), never.ID, client, domain.ErrInvalidCredentials, start),
+	}
+	for _, login := range logins {
+		require.NoError(t, repo.RecordLogin(ctx, login))
+	}
+
+	t.Run("successful logins set the last login time, not the update time", func(t *testing.T) {
+		got, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.LastLoginAt)
+		assert.True(t, logins[2].OccurredAt.Equal(*got.LastLoginAt), "got %s", got.LastLoginAt)
+		assert.True(t, updatedAt.Equal(got.UpdatedAt), "got %s", got.UpdatedAt)
+	})
+
+	t.Run("failed logins leave the last login time alone", func(t *testing.T) {
+		got, err := repo.GetByID(ctx, never.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.LastLoginAt)
+	})
+
+	t.Run("lists only the user's logins, newest first", func(t *testing.T) {
+		got, err := repo.ListLogins(ctx, user.ID, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+
+		assert.Equal(t, []string{logins[2].ID, logins[1].ID, logins[0].ID}, loginIDs(got))
+		assert.False(t, got[2].Succeeded)
+		assert.Equal(t, domain.LoginInvalidPassword, got[2].Failure)
+		assert.Equal(t, user.ID, got[2].UserID)
+		assert.Equal(t, client.IP, got[2].IP)
+		assert.Equal(t, client.UserAgent, got[2].UserAgent)
+		assert.Equal(t, client.Country, got[2].Country)
+		assert.True(t, got[0].Succeeded)
+		assert.Empty(t, got[0].Failure)
+	})
+
+	t.Run("pages continue after the cursor", func(t *testing.T) {
+		first, err := repo.ListLogins(ctx, user.ID, nil, 2)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		cursor := domain.CursorAfterLogin(first[1])
+		second, err := repo.ListLogins(ctx, user.ID, &cursor, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{logins[0].ID}, loginIDs(second))
+	})
+
+	t.Run("updating a user loaded before a login keeps its last login time", func(t *testing.T) {
+		require.NoError(t, user.UpdateName("Renamed User", time.Now()))
+		user.AssignEventIDs(uuid.NewString)
+		require.NoError(t, repo.Update(ctx, user))
+
+		got, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, got.LastLoginAt)
+		assert.True(t, logins[2].OccurredAt.Equal(*got.LastLoginAt), "got %s", got.LastLoginAt)
+	})
+}
+
+func loginIDs(logins []domain.LoginEvent) []string {
+	ids := []string{}
+	for _, login := range logins {
+		ids = append(ids, login.ID)
+	}
+	return ids
+}
+
+// listFixture stores users created an hour apart, newest first in the
+// returned slice, with the middle one suspended and the oldest deleted
+func listFixture(t *testing.T, repo ports.UserRepository) []*domain.User {
+	t.Helper()
+
+	base := time.Now().Add(-24 * time.Hour).Truncate(time.Microsecond)
+	specs := []struct{ email, name string }{
+		{"carol@example.com", "Carol 100%"},
+		{"bob@sample.org", "Bob_Builder"},
+		{"alice@example.com", "Alice"},
+		{"dave@example.com", "Dave"},
+	}
+
+	users := make([]*domain.User, len(specs))
+	for i, spec := range specs {
+		user, err := domain.NewUser(uuid.NewString(), spec.email, spec.name, base)
+		require.NoError(t, err)
+		user.CreatedAt = base.Add(time.Duration(len(specs)-i) * time.Hour)
+		users[i] = user
+	}
+	require.NoError(t, users[1].Suspend(base))
+	create(t, repo, users...)
+	require.NoError(t, repo.Delete(context.Background(), users[3].ID))
+
+	return users
+}
+
+func testList(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	users := listFixture(t, repo)
+	carol, bob, alice, dave := users[0], users[1], users[2], users[3]
+
+	list := func(t *testing.T, filter domain.UserFilter, limit, offset int) []string {
+		t.Helper()
+
+		found, err := repo.List(ctx, filter, limit, offset)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		return ids(found)
+	}
+
+	t.Run("returns users newest first without soft-deleted ones", func(t *testing.T) {
+		assert.Equal(t, []string{carol.ID, bob.ID, alice.ID}, list(t, domain.UserFilter{}, 10, 0))
+	})
+
+	t.Run("applies limit and offset", func(t *testing.T) {
+		assert.Equal(t, []string{carol.ID}, list(t, domain.UserFilter{}, 1, 0))
+		assert.Equal(t, []string{bob.ID, alice.ID}, list(t, domain.UserFilter{}, 2, 1))
+		assert.Equal(t, []string{alice.ID}, list(t, domain.UserFilter{}, 10, 2))
+	})
+
+	t.Run("returns an empty slice past the last page", func(t *testing.T) {
+		assert.Empty(t, list(t, domain.UserFilter{}, 10, 3))
+		assert.Empty(t, list(t, domain.UserFilter{}, 10, 100))
+	})
+
+	t.Run("includes soft-deleted users when asked", func(t *testing.T) {
+		assert.Equal(t, []string{carol.ID, bob.ID, alice.ID, dave.ID}, list(t, domain.UserFilter{IncludeDeleted: true}, 10, 0))
+	})
+
+	t.Run("filters by status", func(t *testing.T) {
+		assert.Equal(t, []string{bob.ID}, list(t, domain.UserFilter{Status: domain.StatusSuspended}, 10, 0))
+	})
+
+	t.Run("filters by email and name ignoring case", func(t *testing.T) {
+		assert.Equal(t, []string{carol.ID, alice.ID}, list(t, domain.UserFilter{EmailContains: "EXAMPLE"}, 10, 0))
+		assert.Equal(t, []string{alice.ID}, list(t, domain.UserFilter{NameContains: "aLiCe"}, 10, 0))
+	})
+
+	t.Run("treats LIKE wildcards literally", func(t *testing.T) {
+		assert.Equal(t, []string{carol.ID}, list(t, domain.UserFilter{NameContains: "100%"}, 10, 0))
+		assert.Equal(t, []string{bob.ID}, list(t, domain.UserFilter{NameContains: "_"}, 10, 0))
+		assert.Equal(t, []string{carol.ID}, list(t, domain.UserFilter{NameContains: "%"}, 10, 0))
+	})
+
+	t.Run("filters by creation time, after inclusive and before exclusive", func(t *testing.T) {
+		after, before := alice.CreatedAt, carol.CreatedAt
+		assert.Equal(t, []string{bob.ID, alice.ID}, list(t, domain.UserFilter{CreatedAfter: &after, CreatedBefore: &before}, 10, 0))
+	})
+}
+
+func testListStream(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+	repo := newRepo(t)
+	users := listFixture(t, repo)
+	carol, bob, alice := users[0], users[1], users[2]
+
+	t.Run("streams matching users newest first", func(t *testing.T) {
+		var streamed []*domain.User
+		err := repo.ListStream(ctx, domain.UserFilter{}, func(user *domain.User) error {
+			streamed = append(streamed, user)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{carol.ID, bob.ID, alice.ID}, ids(streamed))
+	})
+
+	t.Run("applies the filter", func(t *testing.T) {
+		var streamed []*domain.User
+		err := repo.ListStream(ctx, domain.UserFilter{Status: domain.StatusSuspended}, func(user *domain.User) error {
+			streamed = append(streamed, user)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{bob.ID}, ids(streamed))
+	})
+
+	t.Run("stops at the first callback error", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+
+		err := repo.ListStream(ctx, domain.UserFilter{}, func(*domain.User) error {
+			calls++
+			return stop
+		})
+		assert.ErrorIs(t, err, stop)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,12 @@
+package ports
+
+import "context"
+
+//go:generate mockery --name=UserRetention --output=mocks --outpkg=mocks
+
+// UserRetention defines the interface for purging expired soft-deleted users
+type UserRetention interface {
+	// PurgeDeletedUsers permanently deletes users soft-deleted longer than the
+	// retention period and returns how many were (or, in dry-run mode, would be) purged
+	PurgeDeletedUsers(ctx context.Context) (int, error)
+}
@@ -13,18 +13,53 @@ type UserService interface {
 	// CreateUser creates a new user
 	CreateUser(ctx context.Context, email, name string) (*domain.User, error)
 
+	// BulkCreateUsers creates many users at once and reports a result per item
+	BulkCreateUsers(ctx context.Context, inputs []domain.NewUserInput, mode domain.BulkMode) ([]domain.BulkCreateResult, error)
+
 	// GetUser retrieves a user by ID
 	GetUser(ctx context.Context, id string) (*domain.User, error)
 
 	// GetUserByEmail retrieves a user by email
 	GetUserByEmail(ctx context.Context, email string) (*domain.User, error)
 
+	// GetUserByUsername retrieves a user by username
+	GetUserByUsername(ctx context.Context, username string) (*domain.User, error)
+
+	// UserExists reports whether the user of key exists, without loading it
+	UserExists(ctx context.Context, key domain.UserKey) (bool, error)
+
+	// CheckAvailability reports whether a new user could claim the email
+	// and username of check
+	CheckAvailability(ctx context.Context, check domain.AvailabilityCheck) (*domain.AvailabilityResult, error)
+
+	// ChangeUsername sets a user's username
+	ChangeUsername(ctx context.Context, id, username string) (*domain.User, error)
+
 	// UpdateUser updates a user's information
 	UpdateUser(ctx context.Context, id, name string) (*domain.User, error)
 
+	// ChangeEmail changes a user's email, or starts a confirmation flow when
+	// email verification is required
+	ChangeEmail(ctx context.Context, id, email string) (*domain.User, error)
+
+	// ConfirmEmailChange applies a pending email change using the mailed token
+	ConfirmEmailChange(ctx context.Context, id, token string) (*domain.User, error)
+
+	// ChangeUserStatus moves a user to the given lifecycle state
+	ChangeUserStatus(ctx context.Context, id string, status domain.Status) (*domain.User, error)
+
 	// DeleteUser deletes a user
 	DeleteUser(ctx context.Context, id string) error
 
-	// ListUsers retrieves users with pagination
-	ListUsers(ctx context.Context, limit, offset int) ([]*domain.User, error)
+	// EraseUser permanently erases a user's personal data
+	EraseUser(ctx context.Context, id string) (*domain.ErasureRecord, error)
+
+	// RestoreUser restores a soft-deleted user
+	RestoreUser(ctx context.Context, id string) (*domain.User, error)
+
+	// ListUsers retrieves users matching the filter with pagination
+	ListUsers(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error)
+
+	// ExportUsers streams every user matching the filter to fn
+	ExportUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error
 }
@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+)
+
+//go:generate mockery --name=UserStatsStore --output=mocks --outpkg=mocks
+
+// UserStatsStore defines the interface for user statistics precomputed
+// from the stored users. Counting every user on each request would not
+// scale, so the statistics are only as current as the last refresh.
+type UserStatsStore interface {
+	// Refresh recomputes the statistics of every tenant
+	Refresh(ctx context.Context) error
+
+	// Stats returns the statistics of the tenant of ctx as of the last
+	// refresh: the counts by status, the deleted users, the days since the
+	// time that had signups, oldest first, and when they were computed
+	Stats(ctx context.Context, since time.Time) (*domain.UserStats, error)
+}
+
+//go:generate mockery --name=UserStatistics --output=mocks --outpkg=mocks
+
+// UserStatistics defines the interface for reading user statistics
+type UserStatistics interface {
+	// GetUserStats returns the statistics of the tenant of ctx with the
+	// signups of the last days days, today included
+	GetUserStats(ctx context.Context, days int) (*domain.UserStats, error)
+
+	// RefreshUserStats recomputes the statistics of every tenant
+	RefreshUserStats(ctx context.Context) error
+}
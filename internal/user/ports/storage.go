@@ -0,0 +1,20 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+//go:generate mockery --name=FileStorage --output=mocks --outpkg=mocks
+
+// FileStorage defines the interface for storing files such as avatars
+type FileStorage interface {
+	// Put stores the file under key, replacing any existing file
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+
+	// Delete removes the file; deleting a missing file is not an error
+	Delete(ctx context.Context, key string) error
+
+	// URL returns a URL clients can read the file from
+	URL(ctx context.Context, key string) (string, error)
+}
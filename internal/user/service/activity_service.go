@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// ActivityService implements the UserActivity port
+type ActivityService struct {
+	repo ports.UserRepository
+}
+
+// NewActivityService creates a new activity service
+func NewActivityService(repo ports.UserRepository) ports.UserActivity {
+	return &ActivityService{
+		repo: repo,
+	}
+}
+
+// ListActivity returns up to limit events of the user, newest first,
+// continuing after cursor when it is not empty
+func (s *ActivityService) ListActivity(ctx context.Context, id, cursor string, limit int) (*domain.ActivityPage, error) {
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.page(ctx, domain.EventFilter{UserID: id}, after, limit)
+}
+
+// ListAuditLog returns up to limit events of all users matching the
+// filter, newest first, continuing after cursor when it is not empty.
+// Unlike ListActivity it includes the events of deleted and erased users.
+func (s *ActivityService) ListAuditLog(ctx context.Context, filter domain.EventFilter, cursor string, limit int) (*domain.ActivityPage, error) {
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.page(ctx, filter, after, limit)
+}
+
+// ListLogins returns up to limit login attempts of the user, newest first,
+// continuing after cursor when it is not empty
+func (s *ActivityService) ListLogins(ctx context.Context, id, cursor string, limit int) (*domain.LoginPage, error) {
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	// Fetch one extra login to learn whether another page follows
+	logins, err := s.repo.ListLogins(ctx, id, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.LoginPage{Logins: logins}
+	if len(logins) > limit {
+		page.Logins = logins[:limit]
+		page.NextCursor = domain.CursorAfterLogin(page.Logins[limit-1]).String()
+	}
+
+	return page, nil
+}
+
+// page fetches one page of events
+func (s *ActivityService) page(ctx context.Context, filter domain.EventFilter, after *domain.EventCursor, limit int) (*domain.ActivityPage, error) {
+	// Fetch one extra event to learn whether another page follows
+	events, err := s.repo.ListEvents(ctx, filter, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.ActivityPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		page.NextCursor = domain.CursorAfter(page.Events[limit-1]).String()
+	}
+
+	return page, nil
+}
+
+// parseCursor decodes cursor, returning nil when it is empty
+func parseCursor(cursor string) (*domain.EventCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	parsed, err := domain.ParseEventCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+func TestActivityService_ListActivity(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: "123"}
+	events := []domain.Event{
+		domain.NewEvent(idgen.SequentialID(1), "123", domain.EventStatusChanged, nil, testNow),
+		domain.NewEvent(idgen.SequentialID(2), "123", domain.EventNameChanged, nil, testNow),
+		domain.NewEvent(idgen.SequentialID(3), "123", domain.EventUserCreated, nil, testNow),
+	}
+
+	t.Run("last page has no cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+		mockRepo.On("ListEvents", ctx, domain.EventFilter{UserID: "123"}, (*domain.EventCursor)(nil), 11).Return(events, nil)
+
+		page, err := service.ListActivity(ctx, "123", "", 10)
+		require.NoError(t, err)
+		assert.Len(t, page.Events, 3)
+		assert.Empty(t, page.NextCursor)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("full page returns cursor after last event", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+		mockRepo.On("ListEvents", ctx, domain.EventFilter{UserID: "123"}, (*domain.EventCursor)(nil), 3).Return(events, nil)
+
+		page, err := service.ListActivity(ctx, "123", "", 2)
+		require.NoError(t, err)
+		assert.Len(t, page.Events, 2)
+		assert.Equal(t, domain.CursorAfter(events[1]).String(), page.NextCursor)
+	})
+
+	t.Run("continues after cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		cursor := domain.CursorAfter(events[1])
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+		mockRepo.On("ListEvents", ctx, domain.EventFilter{UserID: "123"}, mock.MatchedBy(func(c *domain.EventCursor) bool {
+			return c != nil && c.ID == cursor.ID && c.OccurredAt.Equal(cursor.OccurredAt)
+		}), 3).Return(events[2:], nil)
+
+		page, err := service.ListActivity(ctx, "123", cursor.String(), 2)
+		require.NoError(t, err)
+		assert.Len(t, page.Events, 1)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		_, err := service.ListActivity(ctx, "123", "garbage!", 10)
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+		mockRepo.AssertNotCalled(t, "ListEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		mockRepo.On("GetByID", ctx, "missing").Return(nil, domain.ErrUserNotFound)
+
+		_, err := service.ListActivity(ctx, "missing", "", 10)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockRepo.AssertNotCalled(t, "ListEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestActivityService_ListAuditLog(t *testing.T) {
+	ctx := context.Background()
+	events := []domain.Event{
+		domain.NewEvent(idgen.SequentialID(4), "123", domain.EventUserDeleted, nil, testNow),
+		domain.NewEvent(idgen.SequentialID(5), "456", domain.EventUserDeleted, nil, testNow),
+	}
+	filter := domain.EventFilter{Type: domain.EventUserDeleted}
+
+	t.Run("lists events of all users without looking them up", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		mockRepo.On("ListEvents", ctx, filter, (*domain.EventCursor)(nil), 2).Return(events, nil)
+
+		page, err := service.ListAuditLog(ctx, filter, "", 1)
+		require.NoError(t, err)
+		assert.Len(t, page.Events, 1)
+		assert.Equal(t, domain.CursorAfter(events[0]).String(), page.NextCursor)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		_, err := service.ListAuditLog(ctx, filter, "garbage!", 10)
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+		mockRepo.AssertNotCalled(t, "ListEvents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestActivityService_ListLogins(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: "123"}
+	logins := []domain.LoginEvent{
+		domain.NewLoginEvent(idgen.SequentialID(1), "123", domain.LoginClient{}, nil, testNow),
+		domain.NewLoginEvent(idgen.SequentialID(2), "123", domain.LoginClient{}, domain.ErrInvalidCredentials, testNow),
+		domain.NewLoginEvent(idgen.SequentialID(3), "123", domain.LoginClient{}, nil, testNow),
+	}
+
+	t.Run("full page returns cursor after last login", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+		mockRepo.On("ListLogins", ctx, "123", (*domain.EventCursor)(nil), 3).Return(logins, nil)
+
+		page, err := service.ListLogins(ctx, "123", "", 2)
+		require.NoError(t, err)
+		assert.Equal(t, logins[:2], page.Logins)
+		assert.Equal(t, domain.CursorAfterLogin(logins[1]).String(), page.NextCursor)
+	})
+
+	t.Run("continues after cursor", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+		cursor := domain.CursorAfterLogin(logins[1])
+
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+		mockRepo.On("ListLogins", ctx, "123", &cursor, 3).Return(logins[2:], nil)
+
+		page, err := service.ListLogins(ctx, "123", cursor.String(), 2)
+		require.NoError(t, err)
+		assert.Len(t, page.Logins, 1)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service := NewActivityService(mockRepo)
+
+		mockRepo.On("GetByID", ctx, "123").Return(nil, domain.ErrUserNotFound)
+
+		_, err := service.ListLogins(ctx, "123", "", 10)
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockRepo.AssertNotCalled(t, "ListLogins", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
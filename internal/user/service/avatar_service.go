@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder
+	_ "image/jpeg" // register JPEG decoder
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // register WebP decoder
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const (
+	// avatarSize is the maximum width and height of stored avatars
+	avatarSize = 512
+
+	// maxAvatarSourceSize bounds the dimensions of uploaded images so a small
+	// file cannot decode into a huge bitmap
+	maxAvatarSourceSize = 8192
+
+	// avatarContentType is the format avatars are stored in
+	avatarContentType = "image/png"
+)
+
+// AvatarService implements the UserAvatars port
+type AvatarService struct {
+	repo    ports.UserRepository
+	storage ports.FileStorage
+	clock   ports.Clock
+	ids     ports.IDGenerator
+}
+
+// NewAvatarService creates a new avatar service
+func NewAvatarService(repo ports.UserRepository, storage ports.FileStorage, clock ports.Clock, ids ports.IDGenerator) ports.UserAvatars {
+	return &AvatarService{
+		repo:    repo,
+		storage: storage,
+		clock:   clock,
+		ids:     ids,
+	}
+}
+
+// UploadAvatar validates, resizes and stores a new avatar image. Each upload
+// gets a new key so cached copies of the previous avatar are never served.
+func (s *AvatarService) UploadAvatar(ctx context.Context, id string, r io.Reader) (*domain.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := processAvatar(r)
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf("avatars/%s/%s.png", user.ID, s.ids.NewID())
+	if err := s.storage.Put(ctx, key, bytes.NewReader(data), avatarContentType); err != nil {
+		return nil, fmt.Errorf("failed to store avatar: %w", err)
+	}
+
+	previous := user.AvatarKey
+	user.SetAvatar(key, s.clock.Now())
+
+	assignEventIDs(s.ids, user)
+	if err := s.repo.Update(ctx, user); err != nil {
+		_ = s.storage.Delete(ctx, key)
+		return nil, err
+	}
+
+	// The new avatar is in place, so failing to remove the old file only
+	// leaves an orphan behind
+	if previous != "" {
+		_ = s.storage.Delete(ctx, previous)
+	}
+
+	return user, nil
+}
+
+// AvatarURL returns a URL the user's avatar can be read from
+func (s *AvatarService) AvatarURL(ctx context.Context, id string) (string, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	if user.AvatarKey == "" {
+		return "", domain.ErrNoAvatar
+	}
+
+	return s.storage.URL(ctx, user.AvatarKey)
+}
+
+// DeleteAvatar removes the user's avatar, including for soft-deleted users
+// so their images can be erased too
+func (s *AvatarService) DeleteAvatar(ctx context.Context, id string) error {
+	user, err := s.repo.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if user.AvatarKey == "" {
+		return nil
+	}
+
+	if err := s.storage.Delete(ctx, user.AvatarKey); err != nil {
+		return fmt.Errorf("failed to delete avatar: %w", err)
+	}
+
+	if user.IsDeleted() {
+		return nil
+	}
+
+	user.RemoveAvatar(s.clock.Now())
+	assignEventIDs(s.ids, user)
+	return s.repo.Update(ctx, user)
+}
+
+// processAvatar decodes an uploaded image, scales it down to fit the avatar
+// size and re-encodes it as PNG, which also strips any embedded metadata
+func processAvatar(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+	if cfg.Width > maxAvatarSourceSize || cfg.Height > maxAvatarSourceSize {
+		return nil, domain.ErrInvalidImage
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrInvalidImage
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, fitWithin(src, avatarSize)); err != nil {
+		return nil, fmt.Errorf("failed to encode avatar: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// fitWithin scales img down, keeping its aspect ratio, so neither side
+// exceeds size. Smaller images are returned unchanged.
+func fitWithin(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		height = max(1, height*size/width)
+		width = size
+	} else {
+		width = max(1, width*size/height)
+		height = size
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestAvatarService_UploadAvatar(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockStorage := new(mocks.MockFileStorage)
+	service := NewAvatarService(mockRepo, mockStorage, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	user := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User"}
+
+	var stored []byte
+	mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+	mockStorage.On("Put", ctx, "avatars/123/"+idgen.SequentialID(1)+".png", mock.Anything, "image/png").Run(func(args mock.Arguments) {
+		stored, _ = io.ReadAll(args.Get(2).(io.Reader))
+	}).Return(nil)
+	mockRepo.On("Update", ctx, user).Return(nil)
+
+	updated, err := service.UploadAvatar(ctx, "123", bytes.NewReader(testPNG(t, 1024, 256)))
+	require.NoError(t, err)
+	assert.Equal(t, "avatars/123/"+idgen.SequentialID(1)+".png", updated.AvatarKey)
+
+	// Large images are scaled down keeping their aspect ratio
+	cfg, err := png.DecodeConfig(bytes.NewReader(stored))
+	require.NoError(t, err)
+	assert.Equal(t, 512, cfg.Width)
+	assert.Equal(t, 128, cfg.Height)
+
+	mockRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+	mockStorage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAvatarService_UploadAvatar_ReplacesPrevious(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockStorage := new(mocks.MockFileStorage)
+	service := NewAvatarService(mockRepo, mockStorage, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	user := &domain.User{ID: "123", Email: "test@example.com", AvatarKey: "avatars/123/old.png"}
+
+	mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+	mockStorage.On("Put", ctx, mock.AnythingOfType("string"), mock.Anything, "image/png").Return(nil)
+	mockRepo.On("Update", ctx, user).Return(nil)
+	mockStorage.On("Delete", ctx, "avatars/123/old.png").Return(nil)
+
+	updated, err := service.UploadAvatar(ctx, "123", bytes.NewReader(testPNG(t, 64, 64)))
+	require.NoError(t, err)
+	assert.NotEqual(t, "avatars/123/old.png", updated.AvatarKey)
+
+	mockRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestAvatarService_UploadAvatar_InvalidImage(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockStorage := new(mocks.MockFileStorage)
+	service := NewAvatarService(mockRepo, mockStorage, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	user := &domain.User{ID: "123", Email: "test@example.com"}
+
+	mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+
+	_, err := service.UploadAvatar(ctx, "123", strings.NewReader("not an image"))
+	assert.ErrorIs(t, err, domain.ErrInvalidImage)
+
+	mockStorage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAvatarService_UploadAvatar_UpdateFails(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockStorage := new(mocks.MockFileStorage)
+	service := NewAvatarService(mockRepo, mockStorage, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	user := &domain.User{ID: "123", Email: "test@example.com"}
+	updateErr := errors.New("database unavailable")
+
+	var key string
+	mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+	mockStorage.On("Put", ctx, mock.AnythingOfType("string"), mock.Anything, "image/png").Run(func(args mock.Arguments) {
+		key = args.String(1)
+	}).Return(nil)
+	mockRepo.On("Update", ctx, user).Return(updateErr)
+	mockStorage.On("Delete", ctx, mock.AnythingOfType("string")).Return(nil)
+
+	_, err := service.UploadAvatar(ctx, "123", bytes.NewReader(testPNG(t, 64, 64)))
+	assert.ErrorIs(t, err, updateErr)
+
+	// The newly stored file is cleaned up
+	mockStorage.AssertCalled(t, "Delete", ctx, key)
+}
+
+func TestAvatarService_AvatarURL(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockStorage := new(mocks.MockFileStorage)
+	service := NewAvatarService(mockRepo, mockStorage, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	user := &domain.User{ID: "123", AvatarKey: "avatars/123/a.png"}
+
+	mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+	mockStorage.On("URL", ctx, "avatars/123/a.png").Return("/files/avatars/123/a.png", nil)
+
+	url, err := service.AvatarURL(ctx, "123")
+	require.NoError(t, err)
+	assert.Equal(t, "/files/avatars/123/a.png", url)
+
+	mockRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestAvatarService_AvatarURL_NoAvatar(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockStorage := new(mocks.MockFileStorage)
+	service := NewAvatarService(mockRepo, mockStorage, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	mockRepo.On("GetByID", ctx, "123").Return(&domain.User{ID: "123"}, nil)
+
+	_, err := service.AvatarURL(ctx, "123")
+	assert.ErrorIs(t, err, domain.ErrNoAvatar)
+
+	mockStorage.AssertNotCalled(t, "URL", mock.Anything, mock.Anything)
+}
+
+func TestAvatarService_DeleteAvatar(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockStorage := new(mocks.MockFileStorage)
+	service := NewAvatarService(mockRepo, mockStorage, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	user := &domain.User{ID: "123", AvatarKey: "avatars/123/a.png"}
+
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "123").Return(user, nil)
+	mockStorage.On("Delete", ctx, "avatars/123/a.png").Return(nil)
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(u *domain.User) bool {
+		return u.AvatarKey == ""
+	})).Return(nil)
+
+	err := service.DeleteAvatar(ctx, "123")
+	require.NoError(t, err)
+
+	mockRepo.AssertExpectations(t)
+	mockStorage.AssertExpectations(t)
+}
+
+func TestAvatarService_DeleteAvatar_NoAvatar(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockStorage := new(mocks.MockFileStorage)
+	service := NewAvatarService(mockRepo, mockStorage, clock.NewFake(testNow), idgen.NewSequential())
+
+	ctx := context.Background()
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "123").Return(&domain.User{ID: "123"}, nil)
+
+	err := service.DeleteAvatar(ctx, "123")
+	require.NoError(t, err)
+
+	mockStorage.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const (
+	// importBatchSize is the number of valid rows inserted together
+	importBatchSize = 100
+
+	// importLockKey names the lock that lets one import run at a time
+	// across instances, so imports of overlapping files cannot race
+	importLockKey = "user_import"
+
+	// importLockTTL bounds how long an import holds the lock; a longer
+	// import may overlap the next one
+	importLockTTL = time.Hour
+)
+
+// ImportService implements the UserImporter port
+type ImportService struct {
+	repo   ports.UserRepository
+	clock  ports.Clock
+	ids    ports.IDGenerator
+	locker ports.Locker
+}
+
+// NewImportService creates a new user import service running one import
+// at a time across the instances sharing locker
+func NewImportService(repo ports.UserRepository, clock ports.Clock, ids ports.IDGenerator, locker ports.Locker) ports.UserImporter {
+	return newImportService(repo, clock, ids, locker)
+}
+
+// newImportService creates the import service, which background import
+// jobs run as well
+func newImportService(repo ports.UserRepository, clock ports.Clock, ids ports.IDGenerator, locker ports.Locker) *ImportService {
+	return &ImportService{
+		repo:   repo,
+		clock:  clock,
+		ids:    ids,
+		locker: locker,
+	}
+}
+
+// importRow is a parsed CSV row waiting to be inserted
+type importRow struct {
+	line int
+	user *domain.User
+}
+
+// Import reads CSV rows and creates users, returning a per-line report.
+// It fails with ErrImportInProgress while another import runs.
+func (s *ImportService) Import(ctx context.Context, r io.Reader) (*domain.ImportReport, error) {
+	token, err := s.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.unlock(ctx, token)
+
+	return s.importRows(ctx, r, func(int) {})
+}
+
+// importRows creates the users of the CSV rows. Rows are validated as they
+// are read and inserted in batches, so the whole file is never held in
+// memory. Every batch of rows, and once done, progress is called with the
+// number of rows processed.
+func (s *ImportService) importRows(ctx context.Context, r io.Reader, progress func(processed int)) (*domain.ImportReport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, domain.ErrInvalidImportFile
+	}
+	emailCol, nameCol, err := importColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ImportReport{}
+	seen := make(map[string]bool)
+	batch := make([]importRow, 0, importBatchSize)
+	processed := 0
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		processed++
+		if processed%importBatchSize == 0 {
+			progress(processed)
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			report.AddLine(domain.ImportLineResult{Line: parseErr.StartLine, Status: domain.ImportLineFailed, Error: parseErr.Err.Error()})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		email, name := field(record, emailCol), field(record, nameCol)
+
+		user, err := domain.NewUser(s.ids.NewID(), email, name, s.clock.Now())
+		if err != nil {
+			report.AddLine(domain.ImportLineResult{Line: line, Email: email, Status: domain.ImportLineFailed, Error: err.Error()})
+			continue
+		}
+
+		// Repeated emails within the file are skipped like existing ones
+		if seen[user.Email] {
+			report.AddLine(domain.ImportLineResult{Line: line, Email: user.Email, Status: domain.ImportLineSkipped, Error: domain.ErrDuplicateEmail.Error()})
+			continue
+		}
+		seen[user.Email] = true
+
+		batch = append(batch, importRow{line: line, user: user})
+		if len(batch) == importBatchSize {
+			if err := s.flush(ctx, batch, report); err != nil {
+				return nil, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if err := s.flush(ctx, batch, report); err != nil {
+		return nil, err
+	}
+	progress(processed)
+
+	return report, nil
+}
+
+// lock takes the import lock and returns its token
+func (s *ImportService) lock(ctx context.Context) (int64, error) {
+	token, acquired, err := s.locker.Acquire(ctx, importLockKey, importLockTTL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock imports: %w", err)
+	}
+	if !acquired {
+		return 0, domain.ErrImportInProgress
+	}
+	return token, nil
+}
+
+// unlock gives up the import lock. The lock expires on its own if this
+// fails, so the error is dropped.
+func (s *ImportService) unlock(ctx context.Context, token int64) {
+	_ = s.locker.Release(context.WithoutCancel(ctx), importLockKey, token)
+}
+
+// flush inserts a batch of valid rows, skipping emails that already exist
+func (s *ImportService) flush(ctx context.Context, batch []importRow, report *domain.ImportReport) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	emails := make([]string, len(batch))
+	for i, row := range batch {
+		emails[i] = row.user.Email
+	}
+
+	existing, err := s.repo.FindExistingEmails(ctx, emails)
+	if err != nil {
+		return fmt.Errorf("failed to check existing emails: %w", err)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, email := range existing {
+		taken[domain.NormalizeEmail(email)] = true
+	}
+
+	var rows []importRow
+	var users []*domain.User
+	for _, row := range batch {
+		if taken[row.user.Email] {
+			report.AddLine(domain.ImportLineResult{Line: row.line, Email: row.user.Email, Status: domain.ImportLineSkipped, Error: domain.ErrDuplicateEmail.Error()})
+			continue
+		}
+		rows = append(rows, row)
+		users = append(users, row.user)
+	}
+
+	if len(users) == 0 {
+		return nil
+	}
+
+	// Emails taken concurrently since the check are skipped as well
+	assignEventIDs(s.ids, users...)
+	conflicts, err := createBatch(ctx, s.repo, users)
+	if err != nil {
+		return fmt.Errorf("failed to insert batch: %w", err)
+	}
+	for i, row := range rows {
+		if err, ok := conflicts[i]; ok {
+			report.AddLine(domain.ImportLineResult{Line: row.line, Email: row.user.Email, Status: domain.ImportLineSkipped, Error: err.Error()})
+			continue
+		}
+		report.Created++
+	}
+
+	return nil
+}
+
+// importColumns locates the email and name columns in the header row
+func importColumns(header []string) (int, int, error) {
+	emailCol, nameCol := -1, -1
+	for i, col := range header {
+		switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(col, "\ufeff"))) {
+		case "email":
+			emailCol = i
+		case "name":
+			nameCol = i
+		}
+	}
+
+	if emailCol < 0 || nameCol < 0 {
+		return 0, 0, domain.ErrInvalidImportFile
+	}
+
+	return emailCol, nameCol, nil
+}
+
+// field returns the value at index i, or an empty string if the row is short
+func field(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+	return record[i]
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lock"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+func TestImportService_Import(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	importer := NewImportService(mockRepo, clock.NewFake(testNow), idgen.NewSequential(), lock.NewMemoryLocker())
+
+	ctx := context.Background()
+	csv := "name,email\n" +
+		"User One,one@example.com\n" +
+		"Invalid,not-an-email\n" +
+		"Taken,taken@example.com\n" +
+		"Repeated,ONE@example.com\n"
+
+	mockRepo.On("FindExistingEmails", ctx, []string{"one@example.com", "taken@example.com"}).Return([]string{"taken@example.com"}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.MatchedBy(func(users []*domain.User) bool {
+		return len(users) == 1 && users[0].Email == "one@example.com"
+	})).Return(nil)
+
+	report, err := importer.Import(ctx, strings.NewReader(csv))
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 2, report.Skipped)
+	assert.Equal(t, 1, report.Failed)
+
+	require.Len(t, report.Lines, 3)
+	assert.Equal(t, domain.ImportLineResult{Line: 3, Email: "not-an-email", Status: domain.ImportLineFailed, Error: domain.ErrInvalidEmail.Error()}, report.Lines[0])
+	assert.Equal(t, 5, report.Lines[1].Line)
+	assert.Equal(t, domain.ImportLineSkipped, report.Lines[1].Status)
+	assert.Equal(t, 4, report.Lines[2].Line)
+	assert.Equal(t, domain.ImportLineSkipped, report.Lines[2].Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportService_Import_InsertsInBatches(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	importer := NewImportService(mockRepo, clock.NewFake(testNow), idgen.NewSequential(), lock.NewMemoryLocker())
+
+	ctx := context.Background()
+	var b strings.Builder
+	b.WriteString("email,name\n")
+	for i := 0; i < importBatchSize+1; i++ {
+		b.WriteString("user" + strings.Repeat("x", i) + "@example.com,User\n")
+	}
+
+	mockRepo.On("FindExistingEmails", ctx, mock.Anything).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.Anything).Return(nil)
+
+	report, err := importer.Import(ctx, strings.NewReader(b.String()))
+	require.NoError(t, err)
+	assert.Equal(t, importBatchSize+1, report.Created)
+
+	mockRepo.AssertNumberOfCalls(t, "CreateBatch", 2)
+}
+
+func TestImportService_Import_SkipsConcurrentConflicts(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	importer := NewImportService(mockRepo, clock.NewFake(testNow), idgen.NewSequential(), lock.NewMemoryLocker())
+
+	ctx := context.Background()
+	csv := "email,name\n" +
+		"one@example.com,User One\n" +
+		"raced@example.com,Raced\n"
+
+	mockRepo.On("FindExistingEmails", ctx, mock.Anything).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.MatchedBy(func(users []*domain.User) bool { return len(users) == 2 })).
+		Return(&domain.BatchConflictError{Conflicts: []domain.BatchConflict{{Index: 1, Err: domain.ErrDuplicateEmail}}}).Once()
+	mockRepo.On("CreateBatch", ctx, mock.MatchedBy(func(users []*domain.User) bool {
+		return len(users) == 1 && users[0].Email == "one@example.com"
+	})).Return(nil).Once()
+
+	report, err := importer.Import(ctx, strings.NewReader(csv))
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Skipped)
+	require.Len(t, report.Lines, 1)
+	assert.Equal(t, domain.ImportLineResult{Line: 3, Email: "raced@example.com", Status: domain.ImportLineSkipped, Error: domain.ErrDuplicateEmail.Error()}, report.Lines[0])
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestImportService_Import_InvalidHeader(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	importer := NewImportService(mockRepo, clock.NewFake(testNow), idgen.NewSequential(), lock.NewMemoryLocker())
+
+	tests := []struct {
+		name string
+		csv  string
+	}{
+		{name: "empty file", csv: ""},
+		{name: "missing name column", csv: "email\none@example.com\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := importer.Import(context.Background(), strings.NewReader(tt.csv))
+			require.Error(t, err)
+			assert.ErrorIs(t, err, domain.ErrInvalidImportFile)
+		})
+	}
+}
+
+func TestImportService_RunsOneImportAtATime(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockLocker := new(mocks.MockLocker)
+	importer := NewImportService(mockRepo, clock.NewFake(testNow), idgen.NewSequential(), mockLocker)
+
+	ctx := context.Background()
+	mockLocker.On("Acquire", ctx, "user_import", time.Hour).Return(int64(0), false, nil).Once()
+
+	_, err := importer.Import(ctx, strings.NewReader("email,name\none@example.com,User One\n"))
+	assert.ErrorIs(t, err, domain.ErrImportInProgress)
+
+	mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	mockLocker.AssertExpectations(t)
+}
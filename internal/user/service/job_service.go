@@ -0,0 +1,488 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+const (
+	// DefaultJobRetention is how long finished jobs stay queryable when no
+	// retention is configured
+	DefaultJobRetention = 24 * time.Hour
+
+	// DefaultJobStaleAfter is how long a job may go without being saved
+	// before it is resumed, when no period is configured
+	DefaultJobStaleAfter = time.Minute
+
+	// jobSaveInterval is how often a running job saves its progress, which
+	// also tells other instances it is still running
+	jobSaveInterval = time.Second
+
+	// exportProgressEvery is the number of exported users between progress
+	// updates
+	exportProgressEvery = 500
+)
+
+// jobMetrics publishes background job counters under /debug/vars
+var jobMetrics = expvar.NewMap("user_jobs")
+
+// JobOptions configures the background import and export jobs
+type JobOptions struct {
+	// Formats are the export file formats, by name
+	Formats map[string]ports.UserExportFormat
+
+	// Retention is how long finished jobs and their files are kept; zero
+	// uses DefaultJobRetention
+	Retention time.Duration
+
+	// StaleAfter is how long an unfinished job may go without being saved
+	// before it is considered abandoned and resumed; zero uses
+	// DefaultJobStaleAfter
+	StaleAfter time.Duration
+
+	// TenantContext returns a context for work on behalf of the tenant of
+	// a resumed job; nil without multi-tenancy
+	TenantContext func(ctx context.Context, tenantID string) context.Context
+
+	// OnError receives the errors jobs cannot return, such as a failure to
+	// save their progress; nil ignores them
+	OnError func(error)
+
+	// Detach returns the context a job runs in, carrying the values of the
+	// context that started it but outliving it, and a func releasing it
+	// once the job is done. A job whose context is cancelled, e.g. on
+	// shutdown, is left unfinished for ResumeStaleJobs. Nil runs jobs until
+	// they finish.
+	Detach func(ctx context.Context) (context.Context, context.CancelFunc)
+}
+
+// JobService implements the UserJobs port. Jobs run on the instance that
+// started them and save their progress every second. A job left unfinished
+// by an instance that stopped is resumed from the start by
+// ResumeStaleJobs: imports skip the users they already created, and
+// exports are written again.
+type JobService struct {
+	jobs     ports.JobRepository
+	users    ports.UserRepository
+	objects  ports.ObjectStorage
+	importer *ImportService
+	clock    ports.Clock
+	ids      ports.IDGenerator
+	opts     JobOptions
+}
+
+// NewJobService creates a new job service storing jobs in jobs and their
+// files in objects. Imports hold the import lock of locker like the
+// imports of ImportService.
+func NewJobService(jobs ports.JobRepository, users ports.UserRepository, objects ports.ObjectStorage, clock ports.Clock, ids ports.IDGenerator, locker ports.Locker, opts JobOptions) ports.UserJobs {
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultJobRetention
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = DefaultJobStaleAfter
+	}
+
+	return &JobService{
+		jobs:     jobs,
+		users:    users,
+		objects:  objects,
+		importer: newImportService(users, clock, ids, locker),
+		clock:    clock,
+		ids:      ids,
+		opts:     opts,
+	}
+}
+
+// StartImport stores the CSV file and imports it in the background. The
+// file is read whole, so callers bound its size.
+func (s *JobService) StartImport(ctx context.Context, r io.Reader) (*domain.Job, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	// Lock before accepting the job so the caller learns of a conflict
+	token, err := s.importer.lock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	release := func() { s.importer.unlock(ctx, token) }
+
+	now := s.clock.Now()
+	id := s.ids.NewID()
+	job := &domain.Job{
+		ID:        id,
+		Kind:      domain.JobKindImport,
+		Status:    domain.JobPending,
+		Progress:  domain.JobProgress{Total: countRows(data)},
+		InputKey:  "imports/" + id + ".csv",
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.objects.Put(ctx, job.InputKey, bytes.NewReader(data), "text/csv"); err != nil {
+		release()
+		return nil, fmt.Errorf("failed to store import file: %w", err)
+	}
+	if err := s.jobs.Create(ctx, job); err != nil {
+		release()
+		_ = s.objects.Delete(context.WithoutCancel(ctx), job.InputKey)
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	snapshot := *job
+	s.start(ctx, job, release)
+	return &snapshot, nil
+}
+
+// StartExport exports the users matching the filter in the background
+func (s *JobService) StartExport(ctx context.Context, filter domain.UserFilter, format string) (*domain.Job, error) {
+	if _, ok := s.opts.Formats[format]; !ok {
+		return nil, domain.ErrInvalidExportFormat
+	}
+
+	now := s.clock.Now()
+	id := s.ids.NewID()
+	job := &domain.Job{
+		ID:        id,
+		Kind:      domain.JobKindExport,
+		Status:    domain.JobPending,
+		Format:    format,
+		Filter:    filter,
+		ResultKey: "exports/" + id + "." + format,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.jobs.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	snapshot := *job
+	s.start(ctx, job, func() {})
+	return &snapshot, nil
+}
+
+// GetJob retrieves a job by ID
+func (s *JobService) GetJob(ctx context.Context, id string) (*domain.Job, error) {
+	return s.jobs.Get(ctx, id)
+}
+
+// DownloadURL returns a signed URL of the file of a completed export,
+// valid for the default TTL of the object storage
+func (s *JobService) DownloadURL(ctx context.Context, job *domain.Job) (string, error) {
+	if job.Kind != domain.JobKindExport || job.Status != domain.JobCompleted {
+		return "", domain.ErrObjectNotFound
+	}
+	return s.objects.SignURL(ctx, job.ResultKey, http.MethodGet, 0)
+}
+
+// ResumeStaleJobs runs the unfinished jobs again that were not saved for
+// longer than StaleAfter. An import waits for a later call while another
+// import holds the lock.
+func (s *JobService) ResumeStaleJobs(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	staleBefore := now.Add(-s.opts.StaleAfter)
+
+	jobs, err := s.jobs.ListStale(ctx, staleBefore)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list stale jobs: %w", err)
+	}
+
+	resumed := 0
+	for _, job := range jobs {
+		jobCtx := ctx
+		if s.opts.TenantContext != nil && job.TenantID != "" {
+			jobCtx = s.opts.TenantContext(ctx, job.TenantID)
+		}
+
+		release := func() {}
+		if job.Kind == domain.JobKindImport {
+			token, err := s.importer.lock(jobCtx)
+			if errors.Is(err, domain.ErrImportInProgress) {
+				continue
+			}
+			if err != nil {
+				return resumed, err
+			}
+			release = func() { s.importer.unlock(jobCtx, token) }
+		}
+
+		claimed, err := s.jobs.Claim(jobCtx, job.ID, staleBefore, now)
+		if err != nil {
+			release()
+			return resumed, fmt.Errorf("failed to claim job %s: %w", job.ID, err)
+		}
+		if !claimed {
+			// Another instance resumed it first
+			release()
+			continue
+		}
+
+		// The job starts over
+		job.Status = domain.JobRunning
+		job.Progress.Processed = 0
+		job.Report = nil
+		job.UpdatedAt = now
+		s.start(jobCtx, job, release)
+
+		jobMetrics.Add("resumed", 1)
+		resumed++
+	}
+	return resumed, nil
+}
+
+// PruneJobs deletes the jobs finished before the retention and their files
+func (s *JobService) PruneJobs(ctx context.Context) (int, error) {
+	jobs, err := s.jobs.DeleteFinished(ctx, s.clock.Now().Add(-s.opts.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete finished jobs: %w", err)
+	}
+
+	for _, job := range jobs {
+		for _, key := range []string{job.InputKey, job.ResultKey} {
+			if key == "" {
+				continue
+			}
+			if err := s.objects.Delete(ctx, key); err != nil {
+				return len(jobs), fmt.Errorf("failed to delete the files of job %s: %w", job.ID, err)
+			}
+		}
+	}
+	return len(jobs), nil
+}
+
+// start runs the job in the background, detached from ctx so it outlives
+// the request, and calls release once it finished
+func (s *JobService) start(ctx context.Context, job *domain.Job, release func()) {
+	jobMetrics.Add(string(job.Kind)+".started", 1)
+
+	detach := s.opts.Detach
+	if detach == nil {
+		detach = func(ctx context.Context) (context.Context, context.CancelFunc) {
+			return context.WithoutCancel(ctx), func() {}
+		}
+	}
+	jobCtx, cancel := detach(ctx)
+	go func() {
+		defer cancel()
+		s.run(jobCtx, &jobRun{job: job}, release)
+	}()
+}
+
+// run executes a job, saving its progress while it runs and its outcome
+func (s *JobService) run(ctx context.Context, run *jobRun, release func()) {
+	defer release()
+
+	run.update(func(job *domain.Job) {
+		job.Status = domain.JobRunning
+		job.UpdatedAt = s.clock.Now()
+	})
+	s.save(ctx, run)
+
+	stop := s.saveEvery(ctx, run, s.saveInterval())
+	var err error
+	switch run.job.Kind {
+	case domain.JobKindImport:
+		err = s.runImport(ctx, run)
+	case domain.JobKindExport:
+		err = s.runExport(ctx, run)
+	default:
+		err = fmt.Errorf("unknown job kind: %q", run.job.Kind)
+	}
+	stop()
+
+	// Cancelled, e.g. on shutdown: the job is not failed but left running,
+	// so it goes stale and another instance resumes it
+	if ctx.Err() != nil {
+		jobMetrics.Add(string(run.job.Kind)+".interrupted", 1)
+		return
+	}
+
+	run.update(func(job *domain.Job) {
+		if err != nil {
+			job.Fail(err, s.clock.Now())
+			return
+		}
+		job.Complete(s.clock.Now())
+	})
+	s.save(ctx, run)
+
+	outcome := "completed"
+	if err != nil {
+		outcome = "failed"
+	}
+	jobMetrics.Add(string(run.job.Kind)+"."+outcome, 1)
+}
+
+// runImport imports the stored file of an import job
+func (s *JobService) runImport(ctx context.Context, run *jobRun) error {
+	file, err := s.objects.Get(ctx, run.job.InputKey)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	report, err := s.importer.importRows(ctx, file, run.progress)
+	if err != nil {
+		return err
+	}
+
+	run.update(func(job *domain.Job) {
+		job.Report = report
+	})
+	return nil
+}
+
+// runExport writes the users of an export job to a temporary file, which
+// keeps memory use constant and gives the object storage a known size,
+// then stores it
+func (s *JobService) runExport(ctx context.Context, run *jobRun) error {
+	format, ok := s.opts.Formats[run.job.Format]
+	if !ok {
+		return domain.ErrInvalidExportFormat
+	}
+
+	file, err := os.CreateTemp("", "user-export-*")
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	w := format.NewWriter(file)
+	exported := 0
+	err = s.users.ListStream(ctx, run.job.Filter, func(user *domain.User) error {
+		if err := w.Write(user); err != nil {
+			return err
+		}
+		exported++
+		if exported%exportProgressEvery == 0 {
+			run.progress(exported)
+		}
+		return nil
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to export users: %w", err)
+	}
+	run.progress(exported)
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to read export file: %w", err)
+	}
+	if err := s.objects.Put(ctx, run.job.ResultKey, file, format.ContentType()); err != nil {
+		return fmt.Errorf("failed to store export file: %w", err)
+	}
+	return nil
+}
+
+// saveInterval returns how often running jobs are saved, often enough that
+// they are never considered stale
+func (s *JobService) saveInterval() time.Duration {
+	return min(jobSaveInterval, s.opts.StaleAfter/3)
+}
+
+// saveEvery saves the job every interval until the returned function is
+// called
+func (s *JobService) saveEvery(ctx context.Context, run *jobRun, interval time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				run.update(func(job *domain.Job) {
+					job.UpdatedAt = s.clock.Now()
+				})
+				s.save(ctx, run)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// save stores the current state of the job. A failure is only reported:
+// the job goes on, and is saved again shortly.
+func (s *JobService) save(ctx context.Context, run *jobRun) {
+	job := run.snapshot()
+	if err := s.jobs.Update(ctx, &job); err != nil {
+		jobMetrics.Add("errors", 1)
+		if s.opts.OnError != nil {
+			s.opts.OnError(fmt.Errorf("failed to save job %s: %w", job.ID, err))
+		}
+	}
+}
+
+// jobRun guards a running job, which the job and its periodic saves share
+type jobRun struct {
+	mu  sync.Mutex
+	job *domain.Job
+}
+
+// update applies fn to the job while holding the lock
+func (r *jobRun) update(fn func(job *domain.Job)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fn(r.job)
+}
+
+// progress records the number of rows processed
+func (r *jobRun) progress(processed int) {
+	r.update(func(job *domain.Job) {
+		job.Progress.Processed = processed
+	})
+}
+
+// snapshot returns a copy of the job
+func (r *jobRun) snapshot() domain.Job {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return *r.job
+}
+
+// countRows returns the number of rows after the header of a CSV file,
+// counted as importRows counts them
+func countRows(data []byte) int {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	rows := -1
+	for {
+		_, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			break
+		}
+		rows++
+	}
+	return max(rows, 0)
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lifecycle"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lock"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+// emailFormat exports a line with the email of each user
+type emailFormat struct{}
+
+func (emailFormat) ContentType() string { return "text/plain" }
+
+func (emailFormat) NewWriter(w io.Writer) ports.UserExportWriter { return emailWriter{w: w} }
+
+type emailWriter struct{ w io.Writer }
+
+func (e emailWriter) Write(user *domain.User) error {
+	_, err := fmt.Fprintln(e.w, user.Email)
+	return err
+}
+
+func (emailWriter) Flush() error { return nil }
+
+// jobServiceMocks are the dependencies of a job service under test
+type jobServiceMocks struct {
+	jobs    *mocks.MockJobRepository
+	users   *mocks.MockUserRepository
+	objects *mocks.MockObjectStorage
+
+	// saved receives the job each time it is saved
+	saved chan domain.Job
+}
+
+func newTestJobService(t *testing.T, locker ports.Locker) (ports.UserJobs, *jobServiceMocks) {
+	return newTestJobServiceWithOptions(t, locker, JobOptions{})
+}
+
+// newTestJobServiceWithOptions is newTestJobService with the options opts,
+// exporting the txt format
+func newTestJobServiceWithOptions(t *testing.T, locker ports.Locker, opts JobOptions) (ports.UserJobs, *jobServiceMocks) {
+	m := &jobServiceMocks{
+		jobs:    mocks.NewMockJobRepository(t),
+		users:   mocks.NewMockUserRepository(t),
+		objects: mocks.NewMockObjectStorage(t),
+		saved:   make(chan domain.Job, 100),
+	}
+	m.jobs.On("Update", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { m.saved <- *args.Get(1).(*domain.Job) }).
+		Return(nil).Maybe()
+
+	opts.Formats = map[string]ports.UserExportFormat{"txt": emailFormat{}}
+	jobs := NewJobService(m.jobs, m.users, m.objects, clock.NewFake(testNow), idgen.NewSequential(), locker, opts)
+	return jobs, m
+}
+
+// finished waits for the job to be saved finished
+func (m *jobServiceMocks) finished(t *testing.T) domain.Job {
+	t.Helper()
+	for {
+		select {
+		case job := <-m.saved:
+			if job.Finished() {
+				return job
+			}
+		case <-time.After(time.Second):
+			t.Fatal("job did not finish")
+			return domain.Job{}
+		}
+	}
+}
+
+func TestJobService_StartExport(t *testing.T) {
+	jobs, m := newTestJobService(t, lock.NewMemoryLocker())
+	ctx := context.Background()
+	filter := domain.UserFilter{Status: domain.StatusActive}
+
+	m.jobs.On("Create", ctx, mock.Anything).Return(nil).Once()
+	m.users.On("ListStream", mock.Anything, filter, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*domain.User) error)
+			_ = fn(&domain.User{Email: "one@example.com"})
+			_ = fn(&domain.User{Email: "two@example.com"})
+		}).
+		Return(nil).Once()
+	var stored bytes.Buffer
+	m.objects.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool { return strings.HasPrefix(key, "exports/") }), mock.Anything, "text/plain").
+		Run(func(args mock.Arguments) { _, _ = io.Copy(&stored, args.Get(2).(io.Reader)) }).
+		Return(nil).Once()
+
+	job, err := jobs.StartExport(ctx, filter, "txt")
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobKindExport, job.Kind)
+	assert.Equal(t, domain.JobPending, job.Status)
+	assert.Equal(t, idgen.SequentialID(1), job.ID, "IDs come from the generator")
+	assert.Equal(t, "exports/"+job.ID+".txt", job.ResultKey)
+
+	done := m.finished(t)
+	assert.Equal(t, domain.JobCompleted, done.Status, done.Error)
+	assert.Equal(t, 2, done.Progress.Processed)
+	assert.Equal(t, "one@example.com\ntwo@example.com\n", stored.String())
+
+	m.objects.On("SignURL", ctx, done.ResultKey, http.MethodGet, time.Duration(0)).Return("https://objects.example.com/signed", nil).Once()
+	url, err := jobs.DownloadURL(ctx, &done)
+	require.NoError(t, err)
+	assert.Equal(t, "https://objects.example.com/signed", url)
+}
+
+func TestJobService_StartExport_Fails(t *testing.T) {
+	jobs, m := newTestJobService(t, lock.NewMemoryLocker())
+	ctx := context.Background()
+
+	_, err := jobs.StartExport(ctx, domain.UserFilter{}, "xlsx")
+	assert.ErrorIs(t, err, domain.ErrInvalidExportFormat)
+
+	m.jobs.On("Create", ctx, mock.Anything).Return(nil).Once()
+	m.users.On("ListStream", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+	_, err = jobs.StartExport(ctx, domain.UserFilter{}, "txt")
+	require.NoError(t, err)
+
+	done := m.finished(t)
+	assert.Equal(t, domain.JobFailed, done.Status)
+	assert.Equal(t, "failed to export users: connection refused", done.Error)
+	require.NotNil(t, done.CompletedAt)
+
+	_, err = jobs.DownloadURL(ctx, &done)
+	assert.ErrorIs(t, err, domain.ErrObjectNotFound)
+}
+
+func TestJobService_StartExport_CancelledOnShutdown(t *testing.T) {
+	root := lifecycle.New()
+	jobs, m := newTestJobServiceWithOptions(t, lock.NewMemoryLocker(), JobOptions{Detach: root.Detach})
+	ctx, cancelRequest := context.WithCancel(context.Background())
+
+	exporting := make(chan struct{})
+	exported := make(chan error, 1)
+	m.jobs.On("Create", ctx, mock.Anything).Return(nil).Once()
+	m.users.On("ListStream", mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, _ domain.UserFilter, _ func(*domain.User) error) error {
+			close(exporting)
+			<-ctx.Done()
+			exported <- ctx.Err()
+			return ctx.Err()
+		}).Once()
+
+	_, err := jobs.StartExport(ctx, domain.UserFilter{}, "txt")
+	require.NoError(t, err)
+	<-exporting
+
+	cancelRequest()
+	select {
+	case <-exported:
+		t.Fatal("the export was cancelled with the request")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	root.Cancel()
+	assert.ErrorIs(t, <-exported, context.Canceled)
+	assert.Never(t, func() bool {
+		select {
+		case job := <-m.saved:
+			return job.Finished()
+		default:
+			return false
+		}
+	}, 100*time.Millisecond, 10*time.Millisecond, "the job is left running for ResumeStaleJobs")
+	m.objects.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestJobService_StartImport(t *testing.T) {
+	locker := lock.NewMemoryLocker()
+	jobs, m := newTestJobService(t, locker)
+	ctx := context.Background()
+	csv := "email,name\none@example.com,User One\nnot-an-email,Invalid\n"
+
+	m.objects.On("Put", ctx, mock.Anything, mock.Anything, "text/csv").Return(nil).Once()
+	m.jobs.On("Create", ctx, mock.Anything).Return(nil).Once()
+	m.objects.On("Get", mock.Anything, mock.Anything).Return(io.NopCloser(strings.NewReader(csv)), nil).Once()
+	m.users.On("FindExistingEmails", mock.Anything, []string{"one@example.com"}).Return([]string{}, nil).Once()
+	m.users.On("CreateBatch", mock.Anything, mock.Anything).Return(nil).Once()
+
+	job, err := jobs.StartImport(ctx, strings.NewReader(csv))
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobKindImport, job.Kind)
+	assert.Equal(t, idgen.SequentialID(1), job.ID, "IDs come from the generator")
+	assert.Equal(t, "imports/"+job.ID+".csv", job.InputKey)
+	assert.Equal(t, 2, job.Progress.Total)
+
+	done := m.finished(t)
+	assert.Equal(t, domain.JobCompleted, done.Status, done.Error)
+	assert.Equal(t, 2, done.Progress.Processed)
+	require.NotNil(t, done.Report)
+	assert.Equal(t, 1, done.Report.Created)
+	assert.Equal(t, 1, done.Report.Failed)
+
+	// The import lock was released
+	require.Eventually(t, func() bool {
+		_, acquired, err := locker.Acquire(ctx, importLockKey, time.Minute)
+		return err == nil && acquired
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestJobService_StartImport_OneAtATime(t *testing.T) {
+	locker := lock.NewMemoryLocker()
+	jobs, _ := newTestJobService(t, locker)
+	ctx := context.Background()
+
+	_, acquired, err := locker.Acquire(ctx, importLockKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	_, err = jobs.StartImport(ctx, strings.NewReader("email,name\none@example.com,User One\n"))
+	assert.ErrorIs(t, err, domain.ErrImportInProgress)
+}
+
+func TestJobService_ResumeStaleJobs(t *testing.T) {
+	jobs, m := newTestJobService(t, lock.NewMemoryLocker())
+	ctx := context.Background()
+	staleBefore := testNow.Add(-DefaultJobStaleAfter)
+
+	abandoned := &domain.Job{ID: "job-1", Kind: domain.JobKindExport, Status: domain.JobRunning, Format: "txt", ResultKey: "exports/job-1.txt", Progress: domain.JobProgress{Processed: 1000}}
+	taken := &domain.Job{ID: "job-2", Kind: domain.JobKindExport, Status: domain.JobRunning, Format: "txt", ResultKey: "exports/job-2.txt"}
+	m.jobs.On("ListStale", ctx, staleBefore).Return([]*domain.Job{abandoned, taken}, nil).Once()
+	m.jobs.On("Claim", ctx, "job-1", staleBefore, testNow).Return(true, nil).Once()
+	m.jobs.On("Claim", ctx, "job-2", staleBefore, testNow).Return(false, nil).Once()
+	m.users.On("ListStream", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.objects.On("Put", mock.Anything, "exports/job-1.txt", mock.Anything, "text/plain").Return(nil).Once()
+
+	resumed, err := jobs.ResumeStaleJobs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+
+	done := m.finished(t)
+	assert.Equal(t, "job-1", done.ID)
+	assert.Equal(t, domain.JobCompleted, done.Status)
+	assert.Zero(t, done.Progress.Processed, "the export started over")
+}
+
+func TestJobService_ResumeStaleJobs_WaitsForTheImportLock(t *testing.T) {
+	locker := lock.NewMemoryLocker()
+	jobs, m := newTestJobService(t, locker)
+	ctx := context.Background()
+
+	_, acquired, err := locker.Acquire(ctx, importLockKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	m.jobs.On("ListStale", ctx, mock.Anything).Return([]*domain.Job{{ID: "job-1", Kind: domain.JobKindImport, Status: domain.JobPending}}, nil).Once()
+
+	resumed, err := jobs.ResumeStaleJobs(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, resumed)
+	m.jobs.AssertNotCalled(t, "Claim", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestJobService_PruneJobs(t *testing.T) {
+	jobs, m := newTestJobService(t, lock.NewMemoryLocker())
+	ctx := context.Background()
+
+	m.jobs.On("DeleteFinished", ctx, testNow.Add(-DefaultJobRetention)).Return([]*domain.Job{
+		{ID: "job-1", Kind: domain.JobKindImport, InputKey: "imports/job-1.csv"},
+		{ID: "job-2", Kind: domain.JobKindExport, ResultKey: "exports/job-2.csv"},
+	}, nil).Once()
+	m.objects.On("Delete", ctx, "imports/job-1.csv").Return(nil).Once()
+	m.objects.On("Delete", ctx, "exports/job-2.csv").Return(nil).Once()
+
+	deleted, err := jobs.PruneJobs(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+}
+
+func TestCountRows(t *testing.T) {
+	assert.Equal(t, 0, countRows(nil))
+	assert.Equal(t, 0, countRows([]byte("email,name\n")))
+	assert.Equal(t, 3, countRows([]byte("email,name\none@example.com,\"Multi\nline\"\ntwo@example.com,Two,Extra\n\"bad\"quote,x\n")))
+}
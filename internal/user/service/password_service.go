@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+// DefaultLoginHistory is the number of earlier logins the risk of a login
+// is evaluated against when LoginOptions.History is not set
+const DefaultLoginHistory = 20
+
+// dummyPassword is hashed when the service is created, for logins without
+// a stored hash to have one to verify against
+const dummyPassword = "no password is stored for this login"
+
+// LoginOptions configures the evaluation of logins
+type LoginOptions struct {
+	// Risk evaluates logins whose password matched. Nil lets them all
+	// through.
+	Risk ports.LoginRiskEvaluator
+
+	// Mailer notifies users of the logins Risk asks to notify them of
+	Mailer ports.Mailer
+
+	// History is the number of earlier logins given to Risk
+	History int
+
+	// OnError receives the errors of risk evaluations and notifications,
+	// which do not fail logins. Nil drops them.
+	OnError func(error)
+}
+
+// PasswordService implements the UserPasswords port
+type PasswordService struct {
+	users    ports.UserService
+	repo     ports.UserRepository
+	hasher   ports.PasswordHasher
+	breaches ports.BreachedPasswords
+	clock    ports.Clock
+	ids      ports.IDGenerator
+	policy   domain.PasswordPolicy
+	logins   LoginOptions
+
+	// dummyHash is verified on logins without a stored hash
+	dummyHash string
+}
+
+// NewPasswordService creates a new password service. breaches may be nil
+// to skip the breached password check. The policy is validated so a
+// misconfiguration fails at startup, as is the hasher, which hashes the
+// dummy password verified on logins of unknown emails.
+func NewPasswordService(
+	users ports.UserService,
+	repo ports.UserRepository,
+	hasher ports.PasswordHasher,
+	breaches ports.BreachedPasswords,
+	clock ports.Clock,
+	ids ports.IDGenerator,
+	policy domain.PasswordPolicy,
+	logins LoginOptions,
+) (ports.UserPasswords, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	if logins.History <= 0 {
+		logins.History = DefaultLoginHistory
+	}
+	if logins.OnError == nil {
+		logins.OnError = func(error) {}
+	}
+
+	dummyHash, err := hasher.Hash(dummyPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the dummy password: %w", err)
+	}
+
+	return &PasswordService{
+		users:     users,
+		repo:      repo,
+		hasher:    hasher,
+		breaches:  breaches,
+		clock:     clock,
+		ids:       ids,
+		policy:    policy,
+		logins:    logins,
+		dummyHash: dummyHash,
+	}, nil
+}
+
+// Register creates a user through the user service and stores their
+// password. The email, name and password are checked together so every
+// invalid field is reported at once.
+func (s *PasswordService) Register(ctx context.Context, email, name, password string) (*domain.User, error) {
+	var v validation.Validator
+	_, err := domain.NewUser("", email, name, s.clock.Now())
+	v.Merge(err)
+	v.Merge(s.policy.Check(domain.PasswordCheck{Password: password, Email: email, Name: name}))
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkBreached(ctx, password); err != nil {
+		return nil, err
+	}
+
+	hash, err := s.hasher.Hash(domain.NormalizePassword(password))
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.CreateUser(ctx, email, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// A failure here leaves the user without a password; they can still
+	// set one with ChangePassword, which needs no current password then
+	if err := s.repo.SavePassword(ctx, user.ID, &domain.Password{Hash: hash, UpdatedAt: s.clock.Now()}); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ChangePassword replaces the user's password after verifying the current
+// one. Users without a password set their first one without it.
+func (s *PasswordService) ChangePassword(ctx context.Context, id, current, password string) error {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.repo.GetPassword(ctx, id)
+	if err != nil && !errors.Is(err, domain.ErrPasswordNotSet) {
+		return err
+	}
+	if stored != nil {
+		ok, err := s.hasher.Verify(stored.Hash, domain.NormalizePassword(current))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return domain.ErrIncorrectPassword
+		}
+	}
+
+	if err := s.policy.Check(domain.PasswordCheck{Password: password, Email: user.Email, Name: user.Name}); err != nil {
+		return err
+	}
+	if err := s.checkBreached(ctx, password); err != nil {
+		return err
+	}
+
+	hash, err := s.hasher.Hash(domain.NormalizePassword(password))
+	if err != nil {
+		return err
+	}
+
+	return s.repo.SavePassword(ctx, id, &domain.Password{Hash: hash, UpdatedAt: s.clock.Now()})
+}
+
+// Authenticate returns the user of email when password is theirs and they
+// may log in, and records the attempt of client in their login history.
+// Unknown emails and wrong passwords fail alike with ErrInvalidCredentials,
+// as do users without a password, so callers cannot tell them apart; a
+// dummy hash is verified for them so response times do not either.
+// Attempts on unknown emails have no history to be recorded in. Suspended
+// and deactivated users are only told so once their password matched.
+// Successful logins are evaluated for risk against the history before
+// they are recorded.
+func (s *PasswordService) Authenticate(ctx context.Context, email, password string, client domain.LoginClient) (*domain.Authentication, error) {
+	user, err := s.repo.GetByEmail(ctx, domain.NormalizeEmail(email))
+	if errors.Is(err, domain.ErrUserNotFound) {
+		s.verifyDummy(password)
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	refused, err := s.checkLogin(ctx, user, password)
+	if err != nil {
+		return nil, err
+	}
+
+	login := domain.NewLoginEvent(s.ids.NewID(), user.ID, client, refused, s.clock.Now())
+
+	var risk domain.RiskAssessment
+	if refused == nil {
+		risk = s.evaluateRisk(ctx, user, client, login.OccurredAt)
+	}
+
+	if err := s.repo.RecordLogin(ctx, login); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case errors.Is(refused, domain.ErrPasswordNotSet):
+		return nil, domain.ErrInvalidCredentials
+	case refused != nil:
+		return nil, refused
+	}
+
+	user.LastLoginAt = &login.OccurredAt
+	if risk.Notify {
+		s.notifyLogin(ctx, user, login)
+	}
+	return &domain.Authentication{User: user, Risk: risk}, nil
+}
+
+// evaluateRisk assesses the login of user from client against their login
+// history. Evaluation errors let the login through, so an unavailable
+// evaluator does not lock users out.
+func (s *PasswordService) evaluateRisk(ctx context.Context, user *domain.User, client domain.LoginClient, at time.Time) domain.RiskAssessment {
+	if s.logins.Risk == nil {
+		return domain.RiskAssessment{}
+	}
+
+	history, err := s.repo.ListLogins(ctx, user.ID, nil, s.logins.History)
+	if err != nil {
+		s.logins.OnError(fmt.Errorf("failed to list logins for risk evaluation: %w", err))
+		return domain.RiskAssessment{}
+	}
+
+	risk, err := s.logins.Risk.Evaluate(ctx, domain.LoginAttempt{User: user, Client: client, At: at, History: history})
+	if err != nil {
+		s.logins.OnError(fmt.Errorf("failed to evaluate login risk: %w", err))
+		return domain.RiskAssessment{}
+	}
+	return risk
+}
+
+// notifyLogin emails the user about the login, so they can secure their
+// account if it was not theirs
+func (s *PasswordService) notifyLogin(ctx context.Context, user *domain.User, login domain.LoginEvent) {
+	if s.logins.Mailer == nil {
+		return
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Your account was signed in to on %s.\n\n", login.OccurredAt.UTC().Format(time.RFC1123))
+	if login.IP != "" {
+		fmt.Fprintf(&b, "IP address: %s\n", login.IP)
+	}
+	if login.Country != "" {
+		fmt.Fprintf(&b, "Country: %s\n", login.Country)
+	}
+	if login.UserAgent != "" {
+		fmt.Fprintf(&b, "Device: %s\n", login.UserAgent)
+	}
+	b.WriteString("\nIf this was not you, change your password now.\n")
+
+	if err := s.logins.Mailer.Send(ctx, user.Email, "New sign-in to your account", b.String()); err != nil {
+		s.logins.OnError(fmt.Errorf("failed to send login notification: %w", err))
+	}
+}
+
+// checkLogin returns why the user may not log in with password, or nil
+// when they may. The error is only set when the check itself failed.
+func (s *PasswordService) checkLogin(ctx context.Context, user *domain.User, password string) (refused, err error) {
+	stored, err := s.repo.GetPassword(ctx, user.ID)
+	if errors.Is(err, domain.ErrPasswordNotSet) {
+		s.verifyDummy(password)
+		return domain.ErrPasswordNotSet, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ok, err := s.hasher.Verify(stored.Hash, domain.NormalizePassword(password))
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return domain.ErrInvalidCredentials, nil
+	}
+
+	return user.CanLogIn(), nil
+}
+
+// verifyDummy verifies password against the dummy hash, taking as long as
+// verifying a stored one. The outcome is ignored.
+func (s *PasswordService) verifyDummy(password string) {
+	_, _ = s.hasher.Verify(s.dummyHash, domain.NormalizePassword(password))
+}
+
+// checkBreached returns a violation of the password field when password
+// appears in known data breaches. It runs after the local rules pass, so
+// passwords they reject are never sent anywhere.
+func (s *PasswordService) checkBreached(ctx context.Context, password string) error {
+	if s.breaches == nil {
+		return nil
+	}
+
+	breached, err := s.breaches.IsBreached(ctx, domain.NormalizePassword(password))
+	if err != nil {
+		return err
+	}
+	return s.policy.Check(domain.PasswordCheck{Password: password, Breached: breached})
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+	"github.com/yourusername/go-scaffolding/internal/validation"
+)
+
+var testPasswordPolicy = domain.PasswordPolicy{
+	MinLength:          12,
+	MaxLength:          128,
+	RequireDigit:       true,
+	RejectPersonalInfo: true,
+}
+
+type passwordServiceMocks struct {
+	users    *mocks.MockUserService
+	repo     *mocks.MockUserRepository
+	hasher   *mocks.MockPasswordHasher
+	breaches *mocks.MockBreachedPasswords
+}
+
+func newTestPasswordService(t *testing.T) (ports.UserPasswords, passwordServiceMocks) {
+	t.Helper()
+
+	m := passwordServiceMocks{
+		users:    new(mocks.MockUserService),
+		repo:     new(mocks.MockUserRepository),
+		hasher:   new(mocks.MockPasswordHasher),
+		breaches: new(mocks.MockBreachedPasswords),
+	}
+	m.hasher.On("Hash", dummyPassword).Return("dummy-hash", nil).Once()
+	service, err := NewPasswordService(m.users, m.repo, m.hasher, m.breaches, clock.NewFake(testNow), idgen.NewSequential(), testPasswordPolicy, LoginOptions{})
+	require.NoError(t, err)
+	return service, m
+}
+
+func TestNewPasswordService_InvalidPolicy(t *testing.T) {
+	_, err := NewPasswordService(nil, nil, nil, nil, clock.NewFake(testNow), idgen.NewSequential(), domain.PasswordPolicy{MinLength: 12, MaxLength: 8}, LoginOptions{})
+	assert.Error(t, err)
+}
+
+func TestNewPasswordService_HasherFails(t *testing.T) {
+	hasher := mocks.NewMockPasswordHasher(t)
+	hasher.On("Hash", dummyPassword).Return("", assert.AnError)
+
+	_, err := NewPasswordService(nil, nil, hasher, nil, clock.NewFake(testNow), idgen.NewSequential(), testPasswordPolicy, LoginOptions{})
+	assert.ErrorIs(t, err, assert.AnError)
+}
+
+func TestPasswordService_Register(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the user and stores the hash", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+		user := &domain.User{ID: "123", Email: "jane@example.com", Name: "Jane Doe"}
+
+		m.breaches.On("IsBreached", ctx, "tulip-harbor-42").Return(false, nil)
+		m.hasher.On("Hash", "tulip-harbor-42").Return("hashed", nil)
+		m.users.On("CreateUser", ctx, "jane@example.com", "Jane Doe").Return(user, nil)
+		m.repo.On("SavePassword", ctx, "123", &domain.Password{Hash: "hashed", UpdatedAt: testNow}).Return(nil)
+
+		created, err := service.Register(ctx, "jane@example.com", "Jane Doe", "tulip-harbor-42")
+		require.NoError(t, err)
+		assert.Equal(t, user, created)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		_, err := service.Register(ctx, "not-an-email", "Jane Doe", "janedoe")
+		require.Error(t, err)
+
+		var fields []string
+		for _, v := range validation.Violations(err) {
+			fields = append(fields, v.Field)
+		}
+		assert.Equal(t, []string{"email", "password", "password", "password"}, fields)
+		assert.ErrorIs(t, err, domain.ErrInvalidEmail)
+		assert.ErrorIs(t, err, domain.ErrPasswordTooShort)
+		assert.ErrorIs(t, err, domain.ErrPasswordMissingDigit)
+		assert.ErrorIs(t, err, domain.ErrPasswordContainsPersonalInfo)
+
+		m.breaches.AssertNotCalled(t, "IsBreached", mock.Anything, mock.Anything)
+		m.users.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects breached passwords", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		m.breaches.On("IsBreached", ctx, "password1234").Return(true, nil)
+
+		_, err := service.Register(ctx, "jane@example.com", "Jane Doe", "password1234")
+		assert.ErrorIs(t, err, domain.ErrPasswordBreached)
+		assert.Equal(t, domain.CodePasswordBreached, domain.CodeOf(err))
+		m.users.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("duplicate email", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		m.breaches.On("IsBreached", ctx, "tulip-harbor-42").Return(false, nil)
+		m.hasher.On("Hash", "tulip-harbor-42").Return("hashed", nil)
+		m.users.On("CreateUser", ctx, "jane@example.com", "Jane Doe").Return(nil, domain.ErrDuplicateEmail)
+
+		_, err := service.Register(ctx, "jane@example.com", "Jane Doe", "tulip-harbor-42")
+		assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+		m.repo.AssertNotCalled(t, "SavePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestPasswordService_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: "123", Email: "jane@example.com", Name: "Jane Doe"}
+
+	t.Run("verifies the current password", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		m.repo.On("GetByID", ctx, "123").Return(user, nil)
+		m.repo.On("GetPassword", ctx, "123").Return(&domain.Password{Hash: "old-hash"}, nil)
+		m.hasher.On("Verify", "old-hash", "tulip-harbor-42").Return(true, nil)
+		m.breaches.On("IsBreached", ctx, "violet-canyon-77").Return(false, nil)
+		m.hasher.On("Hash", "violet-canyon-77").Return("new-hash", nil)
+		m.repo.On("SavePassword", ctx, "123", &domain.Password{Hash: "new-hash", UpdatedAt: testNow}).Return(nil)
+
+		require.NoError(t, service.ChangePassword(ctx, "123", "tulip-harbor-42", "violet-canyon-77"))
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("incorrect current password", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		m.repo.On("GetByID", ctx, "123").Return(user, nil)
+		m.repo.On("GetPassword", ctx, "123").Return(&domain.Password{Hash: "old-hash"}, nil)
+		m.hasher.On("Verify", "old-hash", "wrong").Return(false, nil)
+
+		err := service.ChangePassword(ctx, "123", "wrong", "violet-canyon-77")
+		assert.ErrorIs(t, err, domain.ErrIncorrectPassword)
+		m.repo.AssertNotCalled(t, "SavePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("first password needs no current password", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		m.repo.On("GetByID", ctx, "123").Return(user, nil)
+		m.repo.On("GetPassword", ctx, "123").Return(nil, domain.ErrPasswordNotSet)
+		m.breaches.On("IsBreached", ctx, "violet-canyon-77").Return(false, nil)
+		m.hasher.On("Hash", "violet-canyon-77").Return("new-hash", nil)
+		m.repo.On("SavePassword", ctx, "123", &domain.Password{Hash: "new-hash", UpdatedAt: testNow}).Return(nil)
+
+		require.NoError(t, service.ChangePassword(ctx, "123", "", "violet-canyon-77"))
+		m.hasher.AssertNotCalled(t, "Verify", mock.Anything, mock.Anything)
+	})
+
+	t.Run("checks the policy against the user", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		m.repo.On("GetByID", ctx, "123").Return(user, nil)
+		m.repo.On("GetPassword", ctx, "123").Return(nil, domain.ErrPasswordNotSet)
+
+		err := service.ChangePassword(ctx, "123", "", "doe-family-2024")
+		assert.ErrorIs(t, err, domain.ErrPasswordContainsPersonalInfo)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		m.repo.On("GetByID", ctx, "missing").Return(nil, domain.ErrUserNotFound)
+
+		err := service.ChangePassword(ctx, "missing", "", "violet-canyon-77")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	})
+}
+
+func TestPasswordService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	client := domain.LoginClient{IP: "203.0.113.7", UserAgent: "curl/8.5.0"}
+	recorded := func(succeeded bool, failure domain.LoginFailure) any {
+		return mock.MatchedBy(func(login domain.LoginEvent) bool {
+			return login.ID == idgen.SequentialID(1) && login.UserID == "123" && login.Succeeded == succeeded && login.Failure == failure &&
+				login.IP == client.IP && login.UserAgent == client.UserAgent && login.OccurredAt.Equal(testNow)
+		})
+	}
+
+	t.Run("records a successful login", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+		user := &domain.User{ID: "123", Email: "jane@example.com", Status: domain.StatusActive}
+
+		m.repo.On("GetByEmail", ctx, "jane@example.com").Return(user, nil)
+		m.repo.On("GetPassword", ctx, "123").Return(&domain.Password{Hash: "hash"}, nil)
+		m.hasher.On("Verify", "hash", "tulip-harbor-42").Return(true, nil)
+		m.repo.On("RecordLogin", ctx, recorded(true, "")).Return(nil)
+
+		authenticated, err := service.Authenticate(ctx, "Jane@Example.com", "tulip-harbor-42", client)
+		require.NoError(t, err)
+		assert.Same(t, user, authenticated.User)
+		require.NotNil(t, user.LastLoginAt)
+		assert.Equal(t, testNow, *user.LastLoginAt)
+		assert.Equal(t, domain.RiskAssessment{}, authenticated.Risk, "logins are not evaluated without an evaluator")
+		m.repo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name     string
+		status   domain.Status
+		password *domain.Password
+		matches  bool
+		failure  domain.LoginFailure
+		wantErr  error
+	}{
+		{name: "wrong password", status: domain.StatusActive, password: &domain.Password{Hash: "hash"}, failure: domain.LoginInvalidPassword, wantErr: domain.ErrInvalidCredentials},
+		{name: "no password", status: domain.StatusActive, failure: domain.LoginPasswordNotSet, wantErr: domain.ErrInvalidCredentials},
+		{name: "suspended", status: domain.StatusSuspended, password: &domain.Password{Hash: "hash"}, matches: true, failure: domain.LoginUserSuspended, wantErr: domain.ErrUserSuspended},
+	}
+	for _, tt := range tests {
+		t.Run("records a failed login: "+tt.name, func(t *testing.T) {
+			service, m := newTestPasswordService(t)
+
+			m.repo.On("GetByEmail", ctx, "jane@example.com").Return(&domain.User{ID: "123", Status: tt.status}, nil)
+			if tt.password != nil {
+				m.repo.On("GetPassword", ctx, "123").Return(tt.password, nil)
+				m.hasher.On("Verify", "hash", "tulip-harbor-42").Return(tt.matches, nil)
+			} else {
+				m.repo.On("GetPassword", ctx, "123").Return(nil, domain.ErrPasswordNotSet)
+				m.hasher.On("Verify", "dummy-hash", "tulip-harbor-42").Return(false, nil)
+			}
+			m.repo.On("RecordLogin", ctx, recorded(false, tt.failure)).Return(nil)
+
+			_, err := service.Authenticate(ctx, "jane@example.com", "tulip-harbor-42", client)
+			assert.ErrorIs(t, err, tt.wantErr)
+			m.repo.AssertExpectations(t)
+			m.hasher.AssertExpectations(t)
+		})
+	}
+
+	t.Run("unknown email", func(t *testing.T) {
+		service, m := newTestPasswordService(t)
+
+		m.repo.On("GetByEmail", ctx, "nobody@example.com").Return(nil, domain.ErrUserNotFound)
+		m.hasher.On("Verify", "dummy-hash", "tulip-harbor-42").Return(false, nil)
+
+		_, err := service.Authenticate(ctx, "nobody@example.com", "tulip-harbor-42", client)
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		m.repo.AssertNotCalled(t, "RecordLogin", mock.Anything, mock.Anything)
+		m.hasher.AssertExpectations(t)
+	})
+}
+
+func TestPasswordService_AuthenticateRisk(t *testing.T) {
+	ctx := context.Background()
+	client := domain.LoginClient{IP: "203.0.113.7", UserAgent: "curl/8.5.0", Country: "BR"}
+	history := []domain.LoginEvent{{ID: "earlier", UserID: "123", Succeeded: true, Country: "FR"}}
+
+	// newService returns a password service evaluating logins with risk,
+	// for a user whose password matches unless wrongPassword is set
+	newService := func(t *testing.T, wrongPassword bool) (ports.UserPasswords, passwordServiceMocks, *mocks.MockLoginRiskEvaluator, *mocks.MockMailer, *[]error) {
+		t.Helper()
+
+		m := passwordServiceMocks{
+			users:  mocks.NewMockUserService(t),
+			repo:   mocks.NewMockUserRepository(t),
+			hasher: mocks.NewMockPasswordHasher(t),
+		}
+		risk := mocks.NewMockLoginRiskEvaluator(t)
+		mailer := mocks.NewMockMailer(t)
+		var errs []error
+		m.hasher.On("Hash", dummyPassword).Return("dummy-hash", nil)
+		service, err := NewPasswordService(m.users, m.repo, m.hasher, nil, clock.NewFake(testNow), idgen.NewSequential(), testPasswordPolicy, LoginOptions{
+			Risk:    risk,
+			Mailer:  mailer,
+			History: 5,
+			OnError: func(err error) { errs = append(errs, err) },
+		})
+		require.NoError(t, err)
+
+		m.repo.On("GetByEmail", ctx, "jane@example.com").Return(&domain.User{ID: "123", Email: "jane@example.com", Status: domain.StatusActive}, nil)
+		m.repo.On("GetPassword", ctx, "123").Return(&domain.Password{Hash: "hash"}, nil)
+		m.hasher.On("Verify", "hash", "tulip-harbor-42").Return(!wrongPassword, nil)
+		m.repo.On("RecordLogin", ctx, mock.Anything).Return(nil)
+		return service, m, risk, mailer, &errs
+	}
+	attempt := mock.MatchedBy(func(attempt domain.LoginAttempt) bool {
+		return attempt.User.ID == "123" && attempt.Client == client && attempt.At.Equal(testNow) && len(attempt.History) == 1
+	})
+
+	t.Run("asks for a second factor", func(t *testing.T) {
+		service, m, risk, _, _ := newService(t, false)
+		assessment := domain.RiskAssessment{Signals: []domain.LoginSignal{domain.SignalNewCountry}, StepUp: true}
+
+		m.repo.On("ListLogins", ctx, "123", (*domain.EventCursor)(nil), 5).Return(history, nil)
+		risk.On("Evaluate", ctx, attempt).Return(assessment, nil)
+
+		authenticated, err := service.Authenticate(ctx, "jane@example.com", "tulip-harbor-42", client)
+		require.NoError(t, err)
+		assert.Equal(t, assessment, authenticated.Risk)
+	})
+
+	t.Run("notifies the user", func(t *testing.T) {
+		service, m, risk, mailer, errs := newService(t, false)
+
+		m.repo.On("ListLogins", ctx, "123", (*domain.EventCursor)(nil), 5).Return(history, nil)
+		risk.On("Evaluate", ctx, attempt).Return(domain.RiskAssessment{Signals: []domain.LoginSignal{domain.SignalNewDevice}, Notify: true}, nil)
+		mailer.On("Send", ctx, "jane@example.com", "New sign-in to your account", mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "IP address: 203.0.113.7") && strings.Contains(body, "Country: BR") && strings.Contains(body, "Device: curl/8.5.0")
+		})).Return(assert.AnError)
+
+		authenticated, err := service.Authenticate(ctx, "jane@example.com", "tulip-harbor-42", client)
+		require.NoError(t, err, "a failed notification does not fail the login")
+		assert.True(t, authenticated.Risk.Notify)
+		require.Len(t, *errs, 1)
+		assert.ErrorIs(t, (*errs)[0], assert.AnError)
+	})
+
+	t.Run("lets logins through when evaluation fails", func(t *testing.T) {
+		service, m, risk, _, errs := newService(t, false)
+
+		m.repo.On("ListLogins", ctx, "123", (*domain.EventCursor)(nil), 5).Return(history, nil)
+		risk.On("Evaluate", ctx, attempt).Return(domain.RiskAssessment{}, assert.AnError)
+
+		authenticated, err := service.Authenticate(ctx, "jane@example.com", "tulip-harbor-42", client)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RiskAssessment{}, authenticated.Risk)
+		require.Len(t, *errs, 1)
+		assert.ErrorIs(t, (*errs)[0], assert.AnError)
+	})
+
+	t.Run("does not evaluate failed logins", func(t *testing.T) {
+		service, _, _, _, _ := newService(t, true)
+
+		_, err := service.Authenticate(ctx, "jane@example.com", "tulip-harbor-42", client)
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	})
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// PreferencesService implements the UserPreferences port
+type PreferencesService struct {
+	repo     ports.UserRepository
+	clock    ports.Clock
+	defaults domain.Preferences
+}
+
+// NewPreferencesService creates a new preferences service. The defaults are
+// validated so a misconfiguration fails at startup.
+func NewPreferencesService(repo ports.UserRepository, clock ports.Clock, defaults domain.Preferences) (ports.UserPreferences, error) {
+	defaults, err := domain.NormalizePreferences(defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PreferencesService{
+		repo:     repo,
+		clock:    clock,
+		defaults: defaults,
+	}, nil
+}
+
+// GetPreferences returns the user's preferences, or the defaults if they never saved any
+func (s *PreferencesService) GetPreferences(ctx context.Context, id string) (*domain.Preferences, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	prefs, err := s.repo.GetPreferences(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPreferencesNotFound) {
+			defaults := s.defaults
+			return &defaults, nil
+		}
+		return nil, err
+	}
+
+	return prefs, nil
+}
+
+// UpdatePreferences replaces the user's preferences; fields missing from the
+// update are reset to their defaults
+func (s *PreferencesService) UpdatePreferences(ctx context.Context, id string, update domain.PreferencesUpdate) (*domain.Preferences, error) {
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	prefs, err := update.ApplyTo(s.defaults)
+	if err != nil {
+		return nil, err
+	}
+	prefs.UpdatedAt = s.clock.Now()
+
+	if err := s.repo.SavePreferences(ctx, id, &prefs); err != nil {
+		return nil, err
+	}
+
+	return &prefs, nil
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+var defaultPreferences = domain.Preferences{
+	Locale:        "en",
+	Timezone:      "UTC",
+	Notifications: domain.NotificationPreferences{Email: true, Digest: domain.DigestWeekly},
+}
+
+func TestNewPreferencesService_InvalidDefaults(t *testing.T) {
+	defaults := defaultPreferences
+	defaults.Timezone = "Nowhere/Special"
+
+	_, err := NewPreferencesService(new(mocks.MockUserRepository), clock.NewFake(testNow), defaults)
+	assert.ErrorIs(t, err, domain.ErrInvalidTimezone)
+}
+
+func TestPreferencesService_GetPreferences(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: "123"}
+
+	t.Run("returns defaults when never saved", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service, err := NewPreferencesService(mockRepo, clock.NewFake(testNow), defaultPreferences)
+		require.NoError(t, err)
+
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+		mockRepo.On("GetPreferences", ctx, "123").Return(nil, domain.ErrPreferencesNotFound)
+
+		prefs, err := service.GetPreferences(ctx, "123")
+		require.NoError(t, err)
+		assert.Equal(t, defaultPreferences, *prefs)
+		assert.True(t, prefs.UpdatedAt.IsZero())
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns saved preferences", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service, err := NewPreferencesService(mockRepo, clock.NewFake(testNow), defaultPreferences)
+		require.NoError(t, err)
+
+		saved := &domain.Preferences{Locale: "de", Timezone: "Europe/Berlin", Notifications: domain.NotificationPreferences{Digest: domain.DigestOff}}
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+		mockRepo.On("GetPreferences", ctx, "123").Return(saved, nil)
+
+		prefs, err := service.GetPreferences(ctx, "123")
+		require.NoError(t, err)
+		assert.Equal(t, saved, prefs)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service, err := NewPreferencesService(mockRepo, clock.NewFake(testNow), defaultPreferences)
+		require.NoError(t, err)
+
+		mockRepo.On("GetByID", ctx, "missing").Return(nil, domain.ErrUserNotFound)
+
+		_, err = service.GetPreferences(ctx, "missing")
+		assert.ErrorIs(t, err, domain.ErrUserNotFound)
+		mockRepo.AssertNotCalled(t, "GetPreferences", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPreferencesService_UpdatePreferences(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: "123"}
+	locale := "fr-fr"
+
+	t.Run("saves merged preferences", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service, err := NewPreferencesService(mockRepo, clock.NewFake(testNow), defaultPreferences)
+		require.NoError(t, err)
+
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+		mockRepo.On("SavePreferences", ctx, "123", mock.MatchedBy(func(p *domain.Preferences) bool {
+			return p.Locale == "fr-FR" && p.Timezone == "UTC" && p.UpdatedAt.Equal(testNow)
+		})).Return(nil)
+
+		prefs, err := service.UpdatePreferences(ctx, "123", domain.PreferencesUpdate{Locale: &locale})
+		require.NoError(t, err)
+		assert.Equal(t, "fr-FR", prefs.Locale)
+		assert.Equal(t, domain.DigestWeekly, prefs.Notifications.Digest)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid preferences", func(t *testing.T) {
+		mockRepo := new(mocks.MockUserRepository)
+		service, err := NewPreferencesService(mockRepo, clock.NewFake(testNow), defaultPreferences)
+		require.NoError(t, err)
+
+		digest := "hourly"
+		mockRepo.On("GetByID", ctx, "123").Return(user, nil)
+
+		_, err = service.UpdatePreferences(ctx, "123", domain.PreferencesUpdate{Digest: &digest})
+		assert.ErrorIs(t, err, domain.ErrInvalidDigestFrequency)
+		mockRepo.AssertNotCalled(t, "SavePreferences", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
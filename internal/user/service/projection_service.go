@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// defaultProjectionBatchSize is used when no batch size is configured
+const defaultProjectionBatchSize = 500
+
+// projectionRetryDelay is how long Run waits to subscribe again after a
+// subscription failed
+const projectionRetryDelay = time.Second
+
+// projectionMetrics publishes read model counters under /debug/vars
+var projectionMetrics = expvar.NewMap("user_projection")
+
+// ProjectionOptions configures the user read model projection
+type ProjectionOptions struct {
+	// BatchSize is the maximum number of users saved per call by Rebuild,
+	// and of events Run applies together
+	BatchSize int
+
+	// OnError receives the errors Run cannot return, such as a failure to
+	// apply events; nil ignores them. They are counted either way.
+	OnError func(error)
+}
+
+// ProjectionService implements the UserProjection port. It applies an
+// event by reading the user's current state rather than replaying the
+// event, so events applied twice or out of order leave the read model
+// right.
+type ProjectionService struct {
+	repo      ports.UserRepository
+	readModel ports.UserReadModel
+	events    ports.UserEvents
+	clock     ports.Clock
+	opts      ProjectionOptions
+}
+
+// NewProjectionService creates a new projection service following events
+// to keep readModel in step with repo
+func NewProjectionService(repo ports.UserRepository, readModel ports.UserReadModel, events ports.UserEvents, clock ports.Clock, opts ProjectionOptions) ports.UserProjection {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultProjectionBatchSize
+	}
+
+	return &ProjectionService{
+		repo:      repo,
+		readModel: readModel,
+		events:    events,
+		clock:     clock,
+		opts:      opts,
+	}
+}
+
+// Run applies the events delivered to the tenants of ctx until ctx is
+// done. The events stored while it was not subscribed, such as after
+// falling behind, are lost, so it rebuilds the read model each time it
+// subscribes again.
+func (s *ProjectionService) Run(ctx context.Context) {
+	missed := false
+	for ctx.Err() == nil {
+		events, err := s.events.Subscribe(ctx, domain.EventFilter{})
+		if err != nil {
+			s.fail(fmt.Errorf("failed to subscribe to user events: %w", err))
+			missed = true
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(projectionRetryDelay):
+			}
+			continue
+		}
+
+		if missed {
+			projectionMetrics.Add("resubscriptions_total", 1)
+			if _, err := s.Rebuild(ctx); err != nil {
+				// Counted by Rebuild
+				s.report(err)
+			}
+		}
+
+		s.follow(ctx, events)
+		missed = true
+	}
+}
+
+// follow applies the events of a subscription until it is closed
+func (s *ProjectionService) follow(ctx context.Context, events <-chan domain.Event) {
+	for event := range events {
+		batch := []domain.Event{event}
+
+		// Apply the events already waiting together
+	drain:
+		for len(batch) < s.opts.BatchSize {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, event)
+			default:
+				break drain
+			}
+		}
+
+		if err := s.project(ctx, batch); err != nil {
+			s.fail(err)
+		}
+	}
+}
+
+// project saves the current state of the users the events are about, and
+// removes those no longer stored
+func (s *ProjectionService) project(ctx context.Context, events []domain.Event) error {
+	seen := make(map[string]bool, len(events))
+	var (
+		users   []*domain.User
+		removed []string
+	)
+	for _, event := range events {
+		if seen[event.UserID] {
+			continue
+		}
+		seen[event.UserID] = true
+
+		user, err := s.repo.GetByIDIncludingDeleted(ctx, event.UserID)
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			// Erased or purged since
+			removed = append(removed, event.UserID)
+		case err != nil:
+			return fmt.Errorf("failed to read user %s: %w", event.UserID, err)
+		default:
+			users = append(users, user)
+		}
+	}
+
+	if err := s.readModel.Save(ctx, users, s.clock.Now()); err != nil {
+		return fmt.Errorf("failed to save users to the read model: %w", err)
+	}
+	if err := s.readModel.Remove(ctx, removed); err != nil {
+		return fmt.Errorf("failed to remove users from the read model: %w", err)
+	}
+
+	projectionMetrics.Add("events_total", int64(len(events)))
+	projectionMetrics.Add("saved_total", int64(len(users)))
+	projectionMetrics.Add("removed_total", int64(len(removed)))
+	return nil
+}
+
+// Rebuild saves every user of the tenants of ctx, including soft-deleted
+// ones, then removes the users it did not save, which are no longer
+// stored. Events applied meanwhile are kept, as saving only replaces older
+// versions of a user.
+func (s *ProjectionService) Rebuild(ctx context.Context) (int, error) {
+	start := s.clock.Now()
+
+	var (
+		total int
+		batch []*domain.User
+	)
+	save := func() error {
+		if err := s.readModel.Save(ctx, batch, s.clock.Now()); err != nil {
+			return err
+		}
+		total += len(batch)
+		batch = nil
+		return nil
+	}
+
+	err := s.repo.ListStream(ctx, domain.UserFilter{IncludeDeleted: true}, func(user *domain.User) error {
+		batch = append(batch, user)
+		if len(batch) < s.opts.BatchSize {
+			return nil
+		}
+		return save()
+	})
+	if err == nil && len(batch) > 0 {
+		err = save()
+	}
+	if err != nil {
+		projectionMetrics.Add("errors_total", 1)
+		return total, fmt.Errorf("failed to rebuild the read model: %w", err)
+	}
+
+	removed, err := s.readModel.RemoveStale(ctx, start)
+	if err != nil {
+		projectionMetrics.Add("errors_total", 1)
+		return total, fmt.Errorf("failed to remove stale users from the read model: %w", err)
+	}
+
+	projectionMetrics.Add("rebuilds_total", 1)
+	projectionMetrics.Add("removed_total", int64(removed))
+	return total, nil
+}
+
+// fail counts and reports an error of Run
+func (s *ProjectionService) fail(err error) {
+	projectionMetrics.Add("errors_total", 1)
+	s.report(err)
+}
+
+// report hands an error of Run to OnError
+func (s *ProjectionService) report(err error) {
+	if s.opts.OnError != nil {
+		s.opts.OnError(err)
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+// closedSubscription returns a subscription holding the events, closed
+// like one dropped for falling behind
+func closedSubscription(events ...domain.Event) <-chan domain.Event {
+	ch := make(chan domain.Event, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+	return ch
+}
+
+// streamUsers makes ListStream call its callback with the users
+func streamUsers(users ...*domain.User) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		fn := args.Get(2).(func(*domain.User) error)
+		for _, user := range users {
+			if err := fn(user); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func TestProjectionService_RunAppliesEvents(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockReadModel := new(mocks.MockUserReadModel)
+	mockEvents := new(mocks.MockUserEvents)
+	projection := NewProjectionService(mockRepo, mockReadModel, mockEvents, clock.NewFake(testNow), ProjectionOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ada := &domain.User{ID: "1", Email: "ada@example.com", Name: "Ada"}
+	mockEvents.On("Subscribe", ctx, domain.EventFilter{}).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(closedSubscription(
+			domain.NewEvent(idgen.SequentialID(1), "1", domain.EventUserCreated, nil, testNow),
+			domain.NewEvent(idgen.SequentialID(2), "2", domain.EventUserDeleted, nil, testNow),
+			domain.NewEvent(idgen.SequentialID(3), "1", domain.EventNameChanged, nil, testNow),
+		), nil).Once()
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "1").Return(ada, nil).Once()
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "2").Return(nil, domain.ErrUserNotFound).Once()
+	mockReadModel.On("Save", ctx, []*domain.User{ada}, testNow).Return(nil).Once()
+	mockReadModel.On("Remove", ctx, []string{"2"}).Return(nil).Once()
+
+	projection.Run(ctx)
+
+	mockEvents.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockReadModel.AssertExpectations(t)
+}
+
+func TestProjectionService_RunRebuildsAfterFallingBehind(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockReadModel := new(mocks.MockUserReadModel)
+	mockEvents := new(mocks.MockUserEvents)
+	projection := NewProjectionService(mockRepo, mockReadModel, mockEvents, clock.NewFake(testNow), ProjectionOptions{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ada := &domain.User{ID: "1"}
+	mockEvents.On("Subscribe", ctx, domain.EventFilter{}).Return(closedSubscription(), nil).Once()
+	mockEvents.On("Subscribe", ctx, domain.EventFilter{}).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(closedSubscription(), nil).Once()
+	mockRepo.On("ListStream", ctx, domain.UserFilter{IncludeDeleted: true}, mock.Anything).
+		Run(streamUsers(ada)).
+		Return(nil).Once()
+	mockReadModel.On("Save", ctx, []*domain.User{ada}, testNow).Return(nil).Once()
+	mockReadModel.On("RemoveStale", ctx, testNow).Return(0, nil).Once()
+
+	projection.Run(ctx)
+
+	mockEvents.AssertExpectations(t)
+	mockRepo.AssertExpectations(t)
+	mockReadModel.AssertExpectations(t)
+}
+
+func TestProjectionService_RunReportsErrors(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockReadModel := new(mocks.MockUserReadModel)
+	mockEvents := new(mocks.MockUserEvents)
+
+	var reported []error
+	projection := NewProjectionService(mockRepo, mockReadModel, mockEvents, clock.NewFake(testNow), ProjectionOptions{
+		OnError: func(err error) { reported = append(reported, err) },
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockEvents.On("Subscribe", ctx, domain.EventFilter{}).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(closedSubscription(domain.NewEvent(idgen.SequentialID(4), "1", domain.EventUserCreated, nil, testNow)), nil).Once()
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "1").Return(nil, errors.New("connection refused")).Once()
+
+	projection.Run(ctx)
+
+	require.Len(t, reported, 1)
+	assert.EqualError(t, reported[0], "failed to read user 1: connection refused")
+	mockReadModel.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProjectionService_Rebuild(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockReadModel := new(mocks.MockUserReadModel)
+	projection := NewProjectionService(mockRepo, mockReadModel, new(mocks.MockUserEvents), clock.NewFake(testNow), ProjectionOptions{BatchSize: 2})
+
+	ctx := context.Background()
+	users := []*domain.User{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+
+	mockRepo.On("ListStream", ctx, domain.UserFilter{IncludeDeleted: true}, mock.Anything).
+		Run(streamUsers(users...)).
+		Return(nil).Once()
+	mockReadModel.On("Save", ctx, users[:2], testNow).Return(nil).Once()
+	mockReadModel.On("Save", ctx, users[2:], testNow).Return(nil).Once()
+	mockReadModel.On("RemoveStale", ctx, testNow).Return(1, nil).Once()
+
+	saved, err := projection.Rebuild(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, saved)
+
+	mockRepo.AssertExpectations(t)
+	mockReadModel.AssertExpectations(t)
+}
+
+func TestProjectionService_RebuildKeepsUsersWhenSavingFails(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockReadModel := new(mocks.MockUserReadModel)
+	projection := NewProjectionService(mockRepo, mockReadModel, new(mocks.MockUserEvents), clock.NewFake(testNow), ProjectionOptions{})
+
+	ctx := context.Background()
+	mockRepo.On("ListStream", ctx, domain.UserFilter{IncludeDeleted: true}, mock.Anything).
+		Run(streamUsers(&domain.User{ID: "1"})).
+		Return(nil).Once()
+	mockReadModel.On("Save", ctx, mock.Anything, testNow).Return(errors.New("disk full")).Once()
+
+	saved, err := projection.Rebuild(ctx)
+	assert.EqualError(t, err, "failed to rebuild the read model: disk full")
+	assert.Zero(t, saved)
+
+	// Users not saved by an incomplete rebuild must not be removed
+	mockReadModel.AssertNotCalled(t, "RemoveStale", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// defaultRetentionBatchSize is used when no batch size is configured
+const defaultRetentionBatchSize = 500
+
+// retentionMetrics publishes purge counters under /debug/vars
+var retentionMetrics = expvar.NewMap("user_retention")
+
+// RetentionOptions configures the purge of soft-deleted users
+type RetentionOptions struct {
+	// Period is how long soft-deleted users are kept before being purged
+	Period time.Duration
+
+	// BatchSize is the maximum number of users deleted per statement
+	BatchSize int
+
+	// DryRun only counts the users that would be purged
+	DryRun bool
+}
+
+// RetentionService implements the UserRetention port
+type RetentionService struct {
+	repo  ports.UserRepository
+	clock ports.Clock
+	opts  RetentionOptions
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(repo ports.UserRepository, clock ports.Clock, opts RetentionOptions) ports.UserRetention {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultRetentionBatchSize
+	}
+
+	return &RetentionService{
+		repo:  repo,
+		clock: clock,
+		opts:  opts,
+	}
+}
+
+// PurgeDeletedUsers permanently deletes users soft-deleted longer than the
+// retention period, one batch at a time
+func (s *RetentionService) PurgeDeletedUsers(ctx context.Context) (int, error) {
+	cutoff := s.clock.Now().Add(-s.opts.Period)
+
+	if s.opts.DryRun {
+		count, err := s.repo.CountDeleted(ctx, cutoff)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count deleted users: %w", err)
+		}
+		retentionMetrics.Add("dry_run_eligible", int64(count))
+		return count, nil
+	}
+
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		purged, err := s.repo.PurgeDeleted(ctx, cutoff, s.opts.BatchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to purge deleted users: %w", err)
+		}
+
+		total += purged
+		retentionMetrics.Add("batches_total", 1)
+		retentionMetrics.Add("purged_total", int64(purged))
+
+		if purged < s.opts.BatchSize {
+			return total, nil
+		}
+	}
+}
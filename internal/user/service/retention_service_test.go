@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+func TestRetentionService_PurgeDeletedUsers(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	retention := NewRetentionService(mockRepo, clock.NewFake(testNow), RetentionOptions{Period: 30 * 24 * time.Hour, BatchSize: 2})
+
+	ctx := context.Background()
+	cutoff := testNow.Add(-30 * 24 * time.Hour)
+
+	mockRepo.On("PurgeDeleted", ctx, cutoff, 2).Return(2, nil).Twice()
+	mockRepo.On("PurgeDeleted", ctx, cutoff, 2).Return(1, nil).Once()
+
+	purged, err := retention.PurgeDeletedUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, purged)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNumberOfCalls(t, "PurgeDeleted", 3)
+}
+
+func TestRetentionService_PurgeDeletedUsers_DryRun(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	retention := NewRetentionService(mockRepo, clock.NewFake(testNow), RetentionOptions{Period: 24 * time.Hour, DryRun: true})
+
+	ctx := context.Background()
+
+	mockRepo.On("CountDeleted", ctx, testNow.Add(-24*time.Hour)).Return(7, nil)
+
+	purged, err := retention.PurgeDeletedUsers(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 7, purged)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "PurgeDeleted", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRetentionService_PurgeDeletedUsers_Error(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	retention := NewRetentionService(mockRepo, clock.NewFake(testNow), RetentionOptions{Period: 24 * time.Hour, BatchSize: 2})
+
+	ctx := context.Background()
+	dbErr := errors.New("database unavailable")
+
+	mockRepo.On("PurgeDeleted", ctx, mock.AnythingOfType("time.Time"), 2).Return(2, nil).Once()
+	mockRepo.On("PurgeDeleted", ctx, mock.AnythingOfType("time.Time"), 2).Return(0, dbErr).Once()
+
+	purged, err := retention.PurgeDeletedUsers(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, dbErr)
+	assert.Equal(t, 2, purged)
+
+	mockRepo.AssertExpectations(t)
+}
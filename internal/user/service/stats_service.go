@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"time"
+
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// statsMetrics publishes user statistics counters under /debug/vars
+var statsMetrics = expvar.NewMap("user_stats")
+
+// StatsOptions configures the user statistics
+type StatsOptions struct {
+	// RefreshInterval is how often the statistics are refreshed, which
+	// bounds how long they may be reused
+	RefreshInterval time.Duration
+}
+
+// StatsService implements the UserStatistics port
+type StatsService struct {
+	store ports.UserStatsStore
+	clock ports.Clock
+	opts  StatsOptions
+}
+
+// NewStatsService creates a new statistics service reading store
+func NewStatsService(store ports.UserStatsStore, clock ports.Clock, opts StatsOptions) ports.UserStatistics {
+	return &StatsService{
+		store: store,
+		clock: clock,
+		opts:  opts,
+	}
+}
+
+// GetUserStats returns the statistics of the tenant of ctx with the
+// signups of the last days days, today included
+func (s *StatsService) GetUserStats(ctx context.Context, days int) (*domain.UserStats, error) {
+	now := s.clock.Now()
+	since := domain.StartOfDay(now).AddDate(0, 0, 1-days)
+
+	stats, err := s.store.Stats(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, count := range stats.ByStatus {
+		stats.Total += count
+	}
+	stats.Active = stats.ByStatus[domain.StatusActive]
+	stats.SignupsPerDay = domain.DailySignups(stats.SignupsPerDay, days, now)
+	stats.SignupsPerWeek = domain.WeeklySignups(stats.SignupsPerDay)
+
+	// A refresh that is late or never ran leaves nothing to reuse
+	stats.ExpiresAt = stats.RefreshedAt.Add(s.opts.RefreshInterval)
+	if stats.ExpiresAt.Before(now) {
+		stats.ExpiresAt = now
+	}
+
+	statsMetrics.Add("reads_total", 1)
+	return stats, nil
+}
+
+// RefreshUserStats recomputes the statistics of every tenant
+func (s *StatsService) RefreshUserStats(ctx context.Context) error {
+	if err := s.store.Refresh(ctx); err != nil {
+		statsMetrics.Add("refresh_errors_total", 1)
+		return fmt.Errorf("failed to refresh user statistics: %w", err)
+	}
+
+	statsMetrics.Add("refreshes_total", 1)
+	return nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
+)
+
+func TestStatsService_GetUserStats(t *testing.T) {
+	mockStore := new(mocks.MockUserStatsStore)
+	service := NewStatsService(mockStore, clock.NewFake(testNow), StatsOptions{RefreshInterval: 15 * time.Minute})
+
+	ctx := context.Background()
+	// testNow is on Wednesday 2025-01-15
+	refreshedAt := testNow.Add(-5 * time.Minute)
+	mockStore.On("Stats", ctx, time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)).Return(&domain.UserStats{
+		ByStatus: map[domain.Status]int{domain.StatusActive: 7, domain.StatusSuspended: 2},
+		Deleted:  3,
+		SignupsPerDay: []domain.SignupCount{
+			{Start: time.Date(2025, time.January, 12, 0, 0, 0, 0, time.UTC), Signups: 2},
+			{Start: time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC), Signups: 4},
+		},
+		RefreshedAt: refreshedAt,
+	}, nil).Once()
+
+	stats, err := service.GetUserStats(ctx, 6)
+	require.NoError(t, err)
+
+	assert.Equal(t, 9, stats.Total)
+	assert.Equal(t, 7, stats.Active)
+	assert.Equal(t, 3, stats.Deleted)
+	assert.Equal(t, []int{0, 0, 2, 0, 0, 4}, signups(stats.SignupsPerDay))
+	assert.Equal(t, []domain.SignupCount{
+		{Start: time.Date(2025, time.January, 6, 0, 0, 0, 0, time.UTC), Signups: 2},
+		{Start: time.Date(2025, time.January, 13, 0, 0, 0, 0, time.UTC), Signups: 4},
+	}, stats.SignupsPerWeek)
+	assert.Equal(t, refreshedAt.Add(15*time.Minute), stats.ExpiresAt)
+
+	mockStore.AssertExpectations(t)
+}
+
+func TestStatsService_GetUserStatsAfterMissedRefresh(t *testing.T) {
+	mockStore := new(mocks.MockUserStatsStore)
+	service := NewStatsService(mockStore, clock.NewFake(testNow), StatsOptions{RefreshInterval: 15 * time.Minute})
+
+	mockStore.On("Stats", mock.Anything, mock.Anything).Return(&domain.UserStats{
+		RefreshedAt: testNow.Add(-time.Hour),
+	}, nil).Once()
+
+	stats, err := service.GetUserStats(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, testNow, stats.ExpiresAt, "late numbers must not be reused")
+}
+
+func TestStatsService_RefreshUserStats(t *testing.T) {
+	mockStore := new(mocks.MockUserStatsStore)
+	service := NewStatsService(mockStore, clock.NewFake(testNow), StatsOptions{})
+
+	ctx := context.Background()
+	mockStore.On("Refresh", ctx).Return(errors.New("connection refused")).Once()
+
+	err := service.RefreshUserStats(ctx)
+	assert.EqualError(t, err, "failed to refresh user statistics: connection refused")
+}
+
+// signups returns the counts of the periods
+func signups(counts []domain.SignupCount) []int {
+	values := make([]int, len(counts))
+	for i, count := range counts {
+		values[i] = count.Signups
+	}
+	return values
+}
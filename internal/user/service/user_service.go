@@ -3,25 +3,52 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
 	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/validation"
 )
 
+// Options configures optional user service behaviour
+type Options struct {
+	// RequireEmailVerification keeps email changes pending until they are
+	// confirmed with a token mailed to the new address
+	RequireEmailVerification bool
+
+	// EmailChangeTokenTTL is how long an email change token stays valid
+	EmailChangeTokenTTL time.Duration
+
+	// EmailValidator checks new and changed addresses with an external
+	// service; nil skips the check
+	EmailValidator ports.EmailValidator
+}
+
 // UserService implements the UserService port
 type UserService struct {
-	repo ports.UserRepository
+	repo   ports.UserRepository
+	mailer ports.Mailer
+	clock  ports.Clock
+	ids    ports.IDGenerator
+	opts   Options
 }
 
 // NewUserService creates a new user service
-func NewUserService(repo ports.UserRepository) ports.UserService {
+func NewUserService(repo ports.UserRepository, mailer ports.Mailer, clock ports.Clock, ids ports.IDGenerator, opts Options) ports.UserService {
 	return &UserService{
-		repo: repo,
+		repo:   repo,
+		mailer: mailer,
+		clock:  clock,
+		ids:    ids,
+		opts:   opts,
 	}
 }
 
 // CreateUser creates a new user
 func (s *UserService) CreateUser(ctx context.Context, email, name string) (*domain.User, error) {
+	email = domain.NormalizeEmail(email)
+
 	// Check if email already exists
 	_, err := s.repo.GetByEmail(ctx, email)
 	if err == nil {
@@ -32,12 +59,17 @@ func (s *UserService) CreateUser(ctx context.Context, email, name string) (*doma
 	}
 
 	// Create new user
-	user, err := domain.NewUser(email, name)
+	user, err := domain.NewUser(s.ids.NewID(), email, name, s.clock.Now())
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.validateEmail(ctx, email); err != nil {
+		return nil, err
+	}
+
 	// Save to repository
+	assignEventIDs(s.ids, user)
 	if err := s.repo.Create(ctx, user); err != nil {
 		return nil, err
 	}
@@ -45,6 +77,100 @@ func (s *UserService) CreateUser(ctx context.Context, email, name string) (*doma
 	return user, nil
 }
 
+// BulkCreateUsers creates many users at once and reports a result per item.
+// In atomic mode nothing is created unless every item is valid and free.
+func (s *UserService) BulkCreateUsers(ctx context.Context, inputs []domain.NewUserInput, mode domain.BulkMode) ([]domain.BulkCreateResult, error) {
+	results := make([]domain.BulkCreateResult, len(inputs))
+
+	// Validate every item and reject emails repeated within the batch
+	seen := make(map[string]bool, len(inputs))
+	emails := make([]string, 0, len(inputs))
+	for i, input := range inputs {
+		results[i].Index = i
+
+		user, err := domain.NewUser(s.ids.NewID(), input.Email, input.Name, s.clock.Now())
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		if seen[user.Email] {
+			results[i].Err = domain.ErrDuplicateEmail
+			continue
+		}
+
+		seen[user.Email] = true
+		emails = append(emails, user.Email)
+		results[i].User = user
+	}
+
+	// Reject emails that already belong to stored users
+	existing, err := s.repo.FindExistingEmails(ctx, emails)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, email := range existing {
+		taken[domain.NormalizeEmail(email)] = true
+	}
+
+	var users []*domain.User
+	var positions []int
+	failed := false
+	for i := range results {
+		if results[i].User != nil && taken[results[i].User.Email] {
+			results[i].User = nil
+			results[i].Err = domain.ErrDuplicateEmail
+		}
+		if results[i].Err != nil {
+			failed = true
+			continue
+		}
+		users = append(users, results[i].User)
+		positions = append(positions, i)
+	}
+
+	if mode == domain.BulkModeAtomic {
+		if failed {
+			abortBulk(results)
+			return results, nil
+		}
+
+		// A concurrent insert may have taken an email since the check
+		assignEventIDs(s.ids, users...)
+		err := s.repo.CreateBatch(ctx, users)
+		var conflicts *domain.BatchConflictError
+		if errors.As(err, &conflicts) {
+			for _, conflict := range conflicts.Conflicts {
+				results[positions[conflict.Index]].User = nil
+				results[positions[conflict.Index]].Err = conflict.Err
+			}
+			abortBulk(results)
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return results, nil
+	}
+
+	if len(users) == 0 {
+		return results, nil
+	}
+
+	// Best effort: users taken concurrently are reported and the rest created
+	assignEventIDs(s.ids, users...)
+	conflicts, err := createBatch(ctx, s.repo, users)
+	if err != nil {
+		return nil, err
+	}
+	for i, err := range conflicts {
+		results[positions[i]].User = nil
+		results[positions[i]].Err = err
+	}
+
+	return results, nil
+}
+
 // GetUser retrieves a user by ID
 func (s *UserService) GetUser(ctx context.Context, id string) (*domain.User, error) {
 	return s.repo.GetByID(ctx, id)
@@ -52,7 +178,98 @@ func (s *UserService) GetUser(ctx context.Context, id string) (*domain.User, err
 
 // GetUserByEmail retrieves a user by email
 func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	return s.repo.GetByEmail(ctx, email)
+	return s.repo.GetByEmail(ctx, domain.NormalizeEmail(email))
+}
+
+// GetUserByUsername retrieves a user by username
+func (s *UserService) GetUserByUsername(ctx context.Context, username string) (*domain.User, error) {
+	return s.repo.GetByUsername(ctx, domain.NormalizeUsername(username))
+}
+
+// UserExists reports whether the user of key exists, without loading it
+func (s *UserService) UserExists(ctx context.Context, key domain.UserKey) (bool, error) {
+	if key.ID == "" {
+		key.Email = domain.NormalizeEmail(key.Email)
+	}
+	return s.repo.Exists(ctx, key)
+}
+
+// CheckAvailability reports whether a new user could claim the email and
+// username of check. Malformed or reserved identifiers are reported as
+// invalid rather than failing, so forms can check them as they are typed;
+// only their format is checked, not the configured email validation.
+// Identifiers of soft-deleted users are taken until they are purged.
+func (s *UserService) CheckAvailability(ctx context.Context, check domain.AvailabilityCheck) (*domain.AvailabilityResult, error) {
+	result := &domain.AvailabilityResult{}
+
+	if check.Email != "" {
+		email := domain.NormalizeEmail(check.Email)
+		availability, err := s.availability(ctx, email, domain.CheckEmail(email), domain.UserKey{Email: email, IncludeDeleted: true})
+		if err != nil {
+			return nil, err
+		}
+		result.Email = availability
+	}
+
+	if check.Username != "" {
+		username := domain.NormalizeUsername(check.Username)
+		availability, err := s.availability(ctx, username, domain.CheckNewUsername(username), domain.UserKey{Username: username, IncludeDeleted: true})
+		if err != nil {
+			return nil, err
+		}
+		result.Username = availability
+	}
+
+	return result, nil
+}
+
+// availability returns the availability of value: invalid when invalid is
+// set, and otherwise taken when the user of key exists
+func (s *UserService) availability(ctx context.Context, value string, invalid error, key domain.UserKey) (*domain.Availability, error) {
+	if invalid != nil {
+		return &domain.Availability{Value: value, Status: domain.Invalid, Err: invalid}, nil
+	}
+
+	exists, err := s.repo.Exists(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return &domain.Availability{Value: value, Status: domain.Taken}, nil
+	}
+	return &domain.Availability{Value: value, Status: domain.Available}, nil
+}
+
+// ChangeUsername sets a user's username after checking it is not taken
+func (s *UserService) ChangeUsername(ctx context.Context, id, username string) (*domain.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := user.Username
+	if err := user.ChangeUsername(username, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if user.Username == previous {
+		return user, nil
+	}
+
+	existing, err := s.repo.GetByUsername(ctx, user.Username)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.ID != user.ID {
+		return nil, domain.ErrDuplicateUsername
+	}
+
+	assignEventIDs(s.ids, user)
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
 }
 
 // UpdateUser updates a user's information
@@ -64,11 +281,120 @@ func (s *UserService) UpdateUser(ctx context.Context, id, name string) (*domain.
 	}
 
 	// Update name
-	if err := user.UpdateName(name); err != nil {
+	if err := user.UpdateName(name, s.clock.Now()); err != nil {
 		return nil, err
 	}
 
 	// Save to repository
+	assignEventIDs(s.ids, user)
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ChangeEmail changes a user's email, or starts a confirmation flow when
+// email verification is required
+func (s *UserService) ChangeEmail(ctx context.Context, id, email string) (*domain.User, error) {
+	email = domain.NormalizeEmail(email)
+
+	// Get existing user
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing to do if the email is unchanged
+	if user.Email == email {
+		return user, nil
+	}
+
+	if !s.opts.RequireEmailVerification {
+		if err := user.ChangeEmail(email, s.clock.Now()); err != nil {
+			return nil, err
+		}
+
+		if err := s.ensureEmailAvailable(ctx, user.ID, email); err != nil {
+			return nil, err
+		}
+
+		if err := s.validateEmail(ctx, email); err != nil {
+			return nil, err
+		}
+
+		assignEventIDs(s.ids, user)
+		if err := s.repo.Update(ctx, user); err != nil {
+			return nil, err
+		}
+
+		return user, nil
+	}
+
+	token, err := user.RequestEmailChange(email, s.opts.EmailChangeTokenTTL, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.ensureEmailAvailable(ctx, user.ID, email); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateEmail(ctx, email); err != nil {
+		return nil, err
+	}
+
+	assignEventIDs(s.ids, user)
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// Deliver the token to the new address to prove ownership
+	body := fmt.Sprintf("Use the following token to confirm your new email address:\n\n%s\n", token)
+	if err := s.mailer.Send(ctx, email, "Confirm your new email address", body); err != nil {
+		return nil, fmt.Errorf("failed to send email change confirmation: %w", err)
+	}
+
+	return user, nil
+}
+
+// ConfirmEmailChange applies a pending email change using the mailed token
+func (s *UserService) ConfirmEmailChange(ctx context.Context, id, token string) (*domain.User, error) {
+	// Get existing user
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.ConfirmEmailChange(token, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	// The address may have been taken while the change was pending
+	if err := s.ensureEmailAvailable(ctx, user.ID, user.Email); err != nil {
+		return nil, err
+	}
+
+	assignEventIDs(s.ids, user)
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ChangeUserStatus moves a user to the given lifecycle state
+func (s *UserService) ChangeUserStatus(ctx context.Context, id string, status domain.Status) (*domain.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.ChangeStatus(status, s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	assignEventIDs(s.ids, user)
 	if err := s.repo.Update(ctx, user); err != nil {
 		return nil, err
 	}
@@ -81,7 +407,134 @@ func (s *UserService) DeleteUser(ctx context.Context, id string) error {
 	return s.repo.Delete(ctx, id)
 }
 
-// ListUsers retrieves users with pagination
-func (s *UserService) ListUsers(ctx context.Context, limit, offset int) ([]*domain.User, error) {
-	return s.repo.List(ctx, limit, offset)
+// EraseUser permanently erases a user's personal data, including users
+// that have already been soft-deleted, and returns the compliance record
+func (s *UserService) EraseUser(ctx context.Context, id string) (*domain.ErasureRecord, error) {
+	if _, err := s.repo.GetByIDIncludingDeleted(ctx, id); err != nil {
+		return nil, err
+	}
+
+	record := domain.NewErasureRecord(s.ids.NewID(), id, s.clock.Now())
+	if err := s.repo.Erase(ctx, record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// RestoreUser restores a soft-deleted user
+func (s *UserService) RestoreUser(ctx context.Context, id string) (*domain.User, error) {
+	user, err := s.repo.GetByIDIncludingDeleted(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.Restore(s.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ListUsers retrieves users matching the filter with pagination
+func (s *UserService) ListUsers(ctx context.Context, filter domain.UserFilter, limit, offset int) ([]*domain.User, error) {
+	return s.repo.List(ctx, filter, limit, offset)
+}
+
+// ExportUsers streams every user matching the filter to fn
+func (s *UserService) ExportUsers(ctx context.Context, filter domain.UserFilter, fn func(*domain.User) error) error {
+	return s.repo.ListStream(ctx, filter, fn)
+}
+
+// ensureEmailAvailable returns ErrDuplicateEmail if another user owns the email
+func (s *UserService) ensureEmailAvailable(ctx context.Context, userID, email string) error {
+	existing, err := s.repo.GetByEmail(ctx, email)
+	if err == nil {
+		if existing.ID != userID {
+			return domain.ErrDuplicateEmail
+		}
+		return nil
+	}
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+	return nil
+}
+
+// validateEmail checks email with the configured validator, if any.
+// Rejected addresses are reported as a violation of the email field.
+func (s *UserService) validateEmail(ctx context.Context, email string) error {
+	if s.opts.EmailValidator == nil {
+		return nil
+	}
+
+	err := s.opts.EmailValidator.Validate(ctx, email)
+	if errors.Is(err, domain.ErrInvalidEmail) || errors.Is(err, domain.ErrUndeliverableEmail) {
+		return validation.Field("email", err)
+	}
+	return err
+}
+
+// assignEventIDs gives the events recorded on the users their IDs before
+// the users are saved
+func assignEventIDs(ids ports.IDGenerator, users ...*domain.User) {
+	for _, user := range users {
+		user.AssignEventIDs(ids.NewID)
+	}
+}
+
+// createBatch creates users in batch, leaving out those whose email or
+// username is taken. As CreateBatch creates nothing when some users
+// conflict, it retries without them. It returns the error of every user
+// left out by index.
+func createBatch(ctx context.Context, repo ports.UserRepository, users []*domain.User) (map[int]error, error) {
+	conflicts := make(map[int]error)
+
+	pending := make([]int, len(users))
+	for i := range pending {
+		pending[i] = i
+	}
+
+	for len(pending) > 0 {
+		batch := make([]*domain.User, len(pending))
+		for i, index := range pending {
+			batch[i] = users[index]
+		}
+
+		err := repo.CreateBatch(ctx, batch)
+		var conflictErr *domain.BatchConflictError
+		if !errors.As(err, &conflictErr) || len(conflictErr.Conflicts) == 0 {
+			return conflicts, err
+		}
+
+		left := make(map[int]bool, len(conflictErr.Conflicts))
+		for _, conflict := range conflictErr.Conflicts {
+			conflicts[pending[conflict.Index]] = conflict.Err
+			left[conflict.Index] = true
+		}
+
+		remaining := make([]int, 0, len(pending)-len(left))
+		for i, index := range pending {
+			if !left[i] {
+				remaining = append(remaining, index)
+			}
+		}
+		pending = remaining
+	}
+
+	return conflicts, nil
+}
+
+// abortBulk marks every successful item of an atomic bulk as not created
+func abortBulk(results []domain.BulkCreateResult) {
+	for i := range results {
+		if results[i].Err == nil {
+			results[i].User = nil
+			results[i].Err = domain.ErrBulkAborted
+		}
+	}
 }
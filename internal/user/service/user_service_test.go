@@ -2,19 +2,28 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
 	"github.com/yourusername/go-scaffolding/internal/user/domain"
 	"github.com/yourusername/go-scaffolding/internal/user/ports/mocks"
 )
 
+// testNow is the time reported by the fake clock in service tests
+var testNow = time.Date(2025, time.January, 15, 9, 30, 0, 0, time.UTC)
+
 func TestUserService_CreateUser(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -25,15 +34,37 @@ func TestUserService_CreateUser(t *testing.T) {
 
 	user, err := service.CreateUser(ctx, email, name)
 	require.NoError(t, err)
+	assert.Equal(t, idgen.SequentialID(1), user.ID)
 	assert.Equal(t, email, user.Email)
 	assert.Equal(t, name, user.Name)
+	assert.Equal(t, testNow, user.CreatedAt)
+
+	// The mock keeps the events a repository would clear once stored
+	require.Len(t, user.Events(), 1)
+	assert.Equal(t, idgen.SequentialID(2), user.Events()[0].ID)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_CreateUser_NormalizesEmail(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+
+	mockRepo.On("GetByEmail", ctx, "test@example.com").Return(nil, domain.ErrUserNotFound)
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	user, err := service.CreateUser(ctx, "Test@Example.com", "Test User")
+	require.NoError(t, err)
+	assert.Equal(t, "test@example.com", user.Email)
 
 	mockRepo.AssertExpectations(t)
 }
 
 func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
 
 	ctx := context.Background()
 	existingUser := &domain.User{Email: "test@example.com"}
@@ -49,7 +80,7 @@ func TestUserService_CreateUser_DuplicateEmail(t *testing.T) {
 
 func TestUserService_GetUser(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
 
 	ctx := context.Background()
 	expectedUser := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User"}
@@ -63,12 +94,237 @@ func TestUserService_GetUser(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUserService_RestoreUser(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	deletedAt := time.Now()
+	deletedUser := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User", DeletedAt: &deletedAt}
+
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "123").Return(deletedUser, nil)
+	mockRepo.On("Restore", ctx, "123").Return(nil)
+
+	user, err := service.RestoreUser(ctx, "123")
+	require.NoError(t, err)
+	assert.Nil(t, user.DeletedAt)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_RestoreUser_NotDeleted(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	activeUser := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User"}
+
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "123").Return(activeUser, nil)
+
+	user, err := service.RestoreUser(ctx, "123")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrUserNotDeleted)
+	assert.Nil(t, user)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+}
+
+func TestUserService_ChangeUserStatus(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User", Status: domain.StatusActive}
+
+	mockRepo.On("GetByID", ctx, "123").Return(existingUser, nil)
+	mockRepo.On("Update", ctx, mock.MatchedBy(func(user *domain.User) bool {
+		return user.Status == domain.StatusSuspended
+	})).Return(nil)
+
+	user, err := service.ChangeUserStatus(ctx, "123", domain.StatusSuspended)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusSuspended, user.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_ChangeUserStatus_InvalidTransition(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User", Status: domain.StatusDeactivated}
+
+	mockRepo.On("GetByID", ctx, "123").Return(existingUser, nil)
+
+	user, err := service.ChangeUserStatus(ctx, "123", domain.StatusSuspended)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	assert.Nil(t, user)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUserService_EraseUser(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	user := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User"}
+
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "123").Return(user, nil)
+	mockRepo.On("Erase", ctx, mock.MatchedBy(func(record *domain.ErasureRecord) bool {
+		return record.UserID == "123"
+	})).Return(nil)
+
+	record, err := service.EraseUser(ctx, "123")
+	require.NoError(t, err)
+	assert.Equal(t, "123", record.UserID)
+	assert.Equal(t, idgen.SequentialID(1), record.ID)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_EraseUser_NotFound(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+
+	mockRepo.On("GetByIDIncludingDeleted", ctx, "missing").Return(nil, domain.ErrUserNotFound)
+
+	record, err := service.EraseUser(ctx, "missing")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	assert.Nil(t, record)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Erase", mock.Anything, mock.Anything)
+}
+
+func TestUserService_GetUserByUsername(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	expectedUser := &domain.User{ID: "123", Username: "johndoe"}
+
+	mockRepo.On("GetByUsername", ctx, "johndoe").Return(expectedUser, nil)
+
+	user, err := service.GetUserByUsername(ctx, " JohnDoe ")
+	require.NoError(t, err)
+	assert.Equal(t, expectedUser, user)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_CheckAvailability(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+
+	mockRepo.On("Exists", ctx, domain.UserKey{Email: "jane@example.com", IncludeDeleted: true}).Return(true, nil)
+	mockRepo.On("Exists", ctx, domain.UserKey{Username: "jane", IncludeDeleted: true}).Return(false, nil)
+
+	result, err := service.CheckAvailability(ctx, domain.AvailabilityCheck{Email: " Jane@Example.com ", Username: "Jane"})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Availability{Value: "jane@example.com", Status: domain.Taken}, result.Email)
+	assert.Equal(t, &domain.Availability{Value: "jane", Status: domain.Available}, result.Username)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_CheckAvailability_Invalid(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	result, err := service.CheckAvailability(context.Background(), domain.AvailabilityCheck{Email: "jane@", Username: "admin"})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Availability{Value: "jane@", Status: domain.Invalid, Err: domain.ErrInvalidEmail}, result.Email)
+	assert.Equal(t, &domain.Availability{Value: "admin", Status: domain.Invalid, Err: domain.ErrReservedUsername}, result.Username)
+
+	mockRepo.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+}
+
+func TestUserService_CheckAvailability_OnlyAsked(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	mockRepo.On("Exists", ctx, domain.UserKey{Username: "jane", IncludeDeleted: true}).Return(false, nil)
+
+	result, err := service.CheckAvailability(ctx, domain.AvailabilityCheck{Username: "jane"})
+	require.NoError(t, err)
+	assert.Nil(t, result.Email)
+	assert.Equal(t, domain.Available, result.Username.Status)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_ChangeUsername(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User"}
+
+	mockRepo.On("GetByID", ctx, "123").Return(existingUser, nil)
+	mockRepo.On("GetByUsername", ctx, "johndoe").Return(nil, domain.ErrUserNotFound)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*domain.User")).Return(nil)
+
+	user, err := service.ChangeUsername(ctx, "123", "JohnDoe")
+	require.NoError(t, err)
+	assert.Equal(t, "johndoe", user.Username)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_ChangeUsername_DuplicateUsername(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User"}
+	otherUser := &domain.User{ID: "456", Username: "johndoe"}
+
+	mockRepo.On("GetByID", ctx, "123").Return(existingUser, nil)
+	mockRepo.On("GetByUsername", ctx, "johndoe").Return(otherUser, nil)
+
+	user, err := service.ChangeUsername(ctx, "123", "johndoe")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrDuplicateUsername)
+	assert.Nil(t, user)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUserService_ChangeUsername_Reserved(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser := &domain.User{ID: "123", Email: "test@example.com", Name: "Test User"}
+
+	mockRepo.On("GetByID", ctx, "123").Return(existingUser, nil)
+
+	user, err := service.ChangeUsername(ctx, "123", "admin")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrReservedUsername)
+	assert.Nil(t, user)
+
+	mockRepo.AssertExpectations(t)
+}
+
 func TestUserService_UpdateUser(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
-	service := NewUserService(mockRepo)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
 
 	ctx := context.Background()
-	existingUser, _ := domain.NewUser("test@example.com", "Old Name")
+	existingUser, _ := domain.NewUser(uuid.NewString(), "test@example.com", "Old Name", testNow)
 
 	mockRepo.On("GetByID", ctx, existingUser.ID).Return(existingUser, nil)
 	mockRepo.On("Update", ctx, existingUser).Return(nil)
@@ -79,3 +335,351 @@ func TestUserService_UpdateUser(t *testing.T) {
 
 	mockRepo.AssertExpectations(t)
 }
+
+func TestUserService_ChangeEmail(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockMailer := new(mocks.MockMailer)
+	service := NewUserService(mockRepo, mockMailer, clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser, _ := domain.NewUser(uuid.NewString(), "old@example.com", "Test User", testNow)
+
+	mockRepo.On("GetByID", ctx, existingUser.ID).Return(existingUser, nil)
+	mockRepo.On("GetByEmail", ctx, "new@example.com").Return(nil, domain.ErrUserNotFound)
+	mockRepo.On("Update", ctx, existingUser).Return(nil)
+
+	user, err := service.ChangeEmail(ctx, existingUser.ID, "new@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", user.Email)
+	assert.Nil(t, user.PendingEmailChange)
+
+	mockRepo.AssertExpectations(t)
+	mockMailer.AssertNotCalled(t, "Send")
+}
+
+func TestUserService_CreateUser_ValidatesEmail(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	validator := new(mocks.MockEmailValidator)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{EmailValidator: validator})
+
+	ctx := context.Background()
+
+	mockRepo.On("GetByEmail", ctx, "nobody@example.com").Return(nil, domain.ErrUserNotFound)
+	validator.On("Validate", ctx, "nobody@example.com").Return(domain.ErrUndeliverableEmail)
+
+	user, err := service.CreateUser(ctx, "Nobody@Example.com", "Nobody")
+	assert.ErrorIs(t, err, domain.ErrUndeliverableEmail)
+	assert.Nil(t, user)
+
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	validator.AssertExpectations(t)
+}
+
+func TestUserService_ChangeEmail_ValidatesEmail(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	validator := new(mocks.MockEmailValidator)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{EmailValidator: validator})
+
+	ctx := context.Background()
+	existingUser, _ := domain.NewUser(uuid.NewString(), "old@example.com", "Test User", testNow)
+
+	mockRepo.On("GetByID", ctx, existingUser.ID).Return(existingUser, nil)
+	mockRepo.On("GetByEmail", ctx, "nobody@example.com").Return(nil, domain.ErrUserNotFound)
+	validator.On("Validate", ctx, "nobody@example.com").Return(domain.ErrUndeliverableEmail)
+
+	_, err := service.ChangeEmail(ctx, existingUser.ID, "nobody@example.com")
+	assert.ErrorIs(t, err, domain.ErrUndeliverableEmail)
+
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	validator.AssertExpectations(t)
+}
+
+func TestUserService_ChangeEmail_DuplicateEmail(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser, _ := domain.NewUser(uuid.NewString(), "old@example.com", "Test User", testNow)
+	otherUser, _ := domain.NewUser(uuid.NewString(), "taken@example.com", "Other User", testNow)
+
+	mockRepo.On("GetByID", ctx, existingUser.ID).Return(existingUser, nil)
+	mockRepo.On("GetByEmail", ctx, "taken@example.com").Return(otherUser, nil)
+
+	_, err := service.ChangeEmail(ctx, existingUser.ID, "taken@example.com")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrDuplicateEmail)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUserService_ChangeEmail_InvalidEmail(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser, _ := domain.NewUser(uuid.NewString(), "old@example.com", "Test User", testNow)
+
+	mockRepo.On("GetByID", ctx, existingUser.ID).Return(existingUser, nil)
+
+	_, err := service.ChangeEmail(ctx, existingUser.ID, "invalid-email")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrInvalidEmail)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_ChangeEmail_RequiresVerification(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	mockMailer := new(mocks.MockMailer)
+	service := NewUserService(mockRepo, mockMailer, clock.NewFake(testNow), idgen.NewSequential(), Options{
+		RequireEmailVerification: true,
+		EmailChangeTokenTTL:      time.Hour,
+	})
+
+	ctx := context.Background()
+	existingUser, _ := domain.NewUser(uuid.NewString(), "old@example.com", "Test User", testNow)
+
+	var mailedBody string
+	mockRepo.On("GetByID", ctx, existingUser.ID).Return(existingUser, nil)
+	mockRepo.On("GetByEmail", ctx, "new@example.com").Return(nil, domain.ErrUserNotFound)
+	mockRepo.On("Update", ctx, existingUser).Return(nil)
+	mockMailer.On("Send", ctx, "new@example.com", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { mailedBody = args.String(3) }).
+		Return(nil)
+
+	user, err := service.ChangeEmail(ctx, existingUser.ID, "new@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "old@example.com", user.Email)
+	require.NotNil(t, user.PendingEmailChange)
+	assert.Equal(t, "new@example.com", user.PendingEmailChange.Email)
+
+	// The mailed token confirms the change
+	lines := strings.Split(strings.TrimSpace(mailedBody), "\n")
+	token := lines[len(lines)-1]
+	user, err = service.ConfirmEmailChange(ctx, existingUser.ID, token)
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", user.Email)
+	assert.Nil(t, user.PendingEmailChange)
+
+	mockRepo.AssertExpectations(t)
+	mockMailer.AssertExpectations(t)
+}
+
+func TestUserService_ConfirmEmailChange_InvalidToken(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser, _ := domain.NewUser(uuid.NewString(), "old@example.com", "Test User", testNow)
+	_, err := existingUser.RequestEmailChange("new@example.com", time.Hour, testNow)
+	require.NoError(t, err)
+
+	mockRepo.On("GetByID", ctx, existingUser.ID).Return(existingUser, nil)
+
+	_, err = service.ConfirmEmailChange(ctx, existingUser.ID, "wrong-token")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrInvalidEmailChangeToken)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUserService_ConfirmEmailChange_ExpiredToken(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	fakeClock := clock.NewFake(testNow)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), fakeClock, idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	existingUser, _ := domain.NewUser(uuid.NewString(), "old@example.com", "Test User", testNow)
+	token, err := existingUser.RequestEmailChange("new@example.com", time.Hour, testNow)
+	require.NoError(t, err)
+
+	mockRepo.On("GetByID", ctx, existingUser.ID).Return(existingUser, nil)
+
+	fakeClock.Advance(time.Hour + time.Second)
+	_, err = service.ConfirmEmailChange(ctx, existingUser.ID, token)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrInvalidEmailChangeToken)
+	assert.Equal(t, "old@example.com", existingUser.Email)
+
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestUserService_BulkCreateUsers_Atomic(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	inputs := []domain.NewUserInput{
+		{Email: "one@example.com", Name: "User One"},
+		{Email: "two@example.com", Name: "User Two"},
+	}
+
+	mockRepo.On("FindExistingEmails", ctx, []string{"one@example.com", "two@example.com"}).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.AnythingOfType("[]*domain.User")).Return(nil)
+
+	results, err := service.BulkCreateUsers(ctx, inputs, domain.BulkModeAtomic)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+		assert.NoError(t, result.Err)
+		assert.Equal(t, inputs[i].Email, result.User.Email)
+		assert.Equal(t, idgen.SequentialID(i+1), result.User.ID)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_BulkCreateUsers_AtomicAbortsOnFailure(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	inputs := []domain.NewUserInput{
+		{Email: "one@example.com", Name: "User One"},
+		{Email: "invalid-email", Name: "Invalid"},
+		{Email: "taken@example.com", Name: "Taken"},
+	}
+
+	mockRepo.On("FindExistingEmails", ctx, []string{"one@example.com", "taken@example.com"}).Return([]string{"taken@example.com"}, nil)
+
+	results, err := service.BulkCreateUsers(ctx, inputs, domain.BulkModeAtomic)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.ErrorIs(t, results[0].Err, domain.ErrBulkAborted)
+	assert.ErrorIs(t, results[1].Err, domain.ErrInvalidEmail)
+	assert.ErrorIs(t, results[2].Err, domain.ErrDuplicateEmail)
+	for _, result := range results {
+		assert.Nil(t, result.User)
+	}
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+}
+
+func TestUserService_BulkCreateUsers_BestEffort(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	inputs := []domain.NewUserInput{
+		{Email: "one@example.com", Name: "User One"},
+		{Email: "ONE@example.com", Name: "Repeated"},
+		{Email: "two@example.com", Name: ""},
+	}
+
+	mockRepo.On("FindExistingEmails", ctx, []string{"one@example.com"}).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.MatchedBy(func(users []*domain.User) bool {
+		return len(users) == 1 && users[0].Email == "one@example.com"
+	})).Return(nil)
+
+	results, err := service.BulkCreateUsers(ctx, inputs, domain.BulkModeBestEffort)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.NoError(t, results[0].Err)
+	assert.NotNil(t, results[0].User)
+	assert.ErrorIs(t, results[1].Err, domain.ErrDuplicateEmail)
+	assert.ErrorIs(t, results[2].Err, domain.ErrInvalidName)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_BulkCreateUsers_BestEffortRetriesWithoutConflicts(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	inputs := []domain.NewUserInput{
+		{Email: "one@example.com", Name: "User One"},
+		{Email: "raced@example.com", Name: "Raced"},
+		{Email: "three@example.com", Name: "User Three"},
+	}
+
+	mockRepo.On("FindExistingEmails", ctx, mock.Anything).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.MatchedBy(func(users []*domain.User) bool { return len(users) == 3 })).
+		Return(&domain.BatchConflictError{Conflicts: []domain.BatchConflict{{Index: 1, Err: domain.ErrDuplicateEmail}}}).Once()
+	mockRepo.On("CreateBatch", ctx, mock.MatchedBy(func(users []*domain.User) bool {
+		return len(users) == 2 && users[0].Email == "one@example.com" && users[1].Email == "three@example.com"
+	})).Return(nil).Once()
+
+	results, err := service.BulkCreateUsers(ctx, inputs, domain.BulkModeBestEffort)
+	require.NoError(t, err)
+	assert.NoError(t, results[0].Err)
+	assert.ErrorIs(t, results[1].Err, domain.ErrDuplicateEmail)
+	assert.Nil(t, results[1].User)
+	assert.NoError(t, results[2].Err)
+
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUserService_BulkCreateUsers_AtomicReportsConflicts(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	inputs := []domain.NewUserInput{
+		{Email: "one@example.com", Name: "User One"},
+		{Email: "raced@example.com", Name: "Raced"},
+	}
+
+	mockRepo.On("FindExistingEmails", ctx, mock.Anything).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.Anything).
+		Return(&domain.BatchConflictError{Conflicts: []domain.BatchConflict{{Index: 1, Err: domain.ErrDuplicateEmail}}})
+
+	results, err := service.BulkCreateUsers(ctx, inputs, domain.BulkModeAtomic)
+	require.NoError(t, err)
+	assert.ErrorIs(t, results[0].Err, domain.ErrBulkAborted)
+	assert.ErrorIs(t, results[1].Err, domain.ErrDuplicateEmail)
+	for _, result := range results {
+		assert.Nil(t, result.User)
+	}
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUserService_BulkCreateUsers_RepositoryFailure(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	failure := errors.New("connection reset")
+
+	mockRepo.On("FindExistingEmails", ctx, mock.Anything).Return([]string{}, nil)
+	mockRepo.On("CreateBatch", ctx, mock.Anything).Return(failure)
+
+	_, err := service.BulkCreateUsers(ctx, []domain.NewUserInput{{Email: "one@example.com", Name: "User One"}}, domain.BulkModeBestEffort)
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestUserService_ExportUsers(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	service := NewUserService(mockRepo, new(mocks.MockMailer), clock.NewFake(testNow), idgen.NewSequential(), Options{})
+
+	ctx := context.Background()
+	filter := domain.UserFilter{EmailContains: "example"}
+	users := []*domain.User{{ID: "1", Email: "one@example.com"}, {ID: "2", Email: "two@example.com"}}
+
+	mockRepo.On("ListStream", ctx, filter, mock.Anything).
+		Run(func(args mock.Arguments) {
+			fn := args.Get(2).(func(*domain.User) error)
+			for _, user := range users {
+				if err := fn(user); err != nil {
+					return
+				}
+			}
+		}).
+		Return(nil)
+
+	var exported []*domain.User
+	err := service.ExportUsers(ctx, filter, func(user *domain.User) error {
+		exported = append(exported, user)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, users, exported)
+
+	mockRepo.AssertExpectations(t)
+}
@@ -0,0 +1,106 @@
+// Package validation collects the violations of a validation, so domain
+// constructors report every invalid field at once instead of the first.
+// It depends on nothing but the standard library, so domain packages can
+// use it.
+//
+//	var v validation.Validator
+//	v.Check("email", checkEmail(email))
+//	v.Check("name", checkName(name))
+//	if err := v.Err(); err != nil {
+//		return nil, err
+//	}
+//
+// The returned *Error unwraps to the error of each violation, so
+// errors.Is still matches the domain's sentinel errors.
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+// Violation describes why a single field is invalid
+type Violation struct {
+	// Field is the name of the field, as clients know it
+	Field string
+
+	// Err is the reason, typically a sentinel error of the domain
+	Err error
+}
+
+// Error is returned when one or more fields are invalid. Violations are
+// kept in the order they were found.
+type Error struct {
+	Violations []Violation
+}
+
+// Error joins the messages of the violations. A single violation reads
+// as its own error.
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.Err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Unwrap returns the error of each violation
+func (e *Error) Unwrap() []error {
+	errs := make([]error, len(e.Violations))
+	for i, v := range e.Violations {
+		errs[i] = v.Err
+	}
+	return errs
+}
+
+// Field returns an Error with a single violation, or nil when err is nil
+func Field(field string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Violations: []Violation{{Field: field, Err: err}}}
+}
+
+// Violations returns the violations in err's tree, or nil when it holds no
+// *Error
+func Violations(err error) []Violation {
+	var validationErr *Error
+	if errors.As(err, &validationErr) {
+		return validationErr.Violations
+	}
+	return nil
+}
+
+// Validator collects violations. The zero value is ready to use.
+type Validator struct {
+	violations []Violation
+}
+
+// Check records a violation of field when err is not nil
+func (v *Validator) Check(field string, err error) {
+	if err != nil {
+		v.violations = append(v.violations, Violation{Field: field, Err: err})
+	}
+}
+
+// Err returns an *Error holding the violations recorded so far, or nil
+// when there are none
+func (v *Validator) Err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &Error{Violations: v.violations}
+}
+
+// Merge records the violations of err, an *Error returned by another
+// validation. Any other error is recorded with an empty field.
+func (v *Validator) Merge(err error) {
+	if err == nil {
+		return
+	}
+	if violations := Violations(err); violations != nil {
+		v.violations = append(v.violations, violations...)
+		return
+	}
+	v.violations = append(v.violations, Violation{Err: err})
+}
@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errInvalidEmail = errors.New("invalid email format")
+	errInvalidName  = errors.New("name must not be empty")
+)
+
+func TestValidator(t *testing.T) {
+	t.Run("no violations", func(t *testing.T) {
+		var v Validator
+		v.Check("email", nil)
+		v.Check("name", nil)
+
+		assert.NoError(t, v.Err())
+	})
+
+	t.Run("collects every violation in order", func(t *testing.T) {
+		var v Validator
+		v.Check("email", errInvalidEmail)
+		v.Check("username", nil)
+		v.Check("name", errInvalidName)
+
+		err := v.Err()
+		require.Error(t, err)
+		assert.Equal(t, "invalid email format; name must not be empty", err.Error())
+		assert.ErrorIs(t, err, errInvalidEmail)
+		assert.ErrorIs(t, err, errInvalidName)
+		assert.Equal(t, []Violation{
+			{Field: "email", Err: errInvalidEmail},
+			{Field: "name", Err: errInvalidName},
+		}, Violations(err))
+	})
+}
+
+func TestField(t *testing.T) {
+	assert.NoError(t, Field("name", nil))
+
+	err := Field("name", errInvalidName)
+	assert.Equal(t, "name must not be empty", err.Error(), "a single violation reads as its own error")
+	assert.ErrorIs(t, err, errInvalidName)
+	assert.Equal(t, []Violation{{Field: "name", Err: errInvalidName}}, Violations(err))
+}
+
+func TestViolations(t *testing.T) {
+	assert.Nil(t, Violations(errInvalidName))
+	assert.Nil(t, Violations(nil))
+
+	wrapped := fmt.Errorf("create user: %w", Field("email", errInvalidEmail))
+	assert.Equal(t, []Violation{{Field: "email", Err: errInvalidEmail}}, Violations(wrapped))
+}
+
+func TestValidator_Merge(t *testing.T) {
+	var v Validator
+	v.Merge(nil)
+	require.NoError(t, v.Err())
+
+	v.Merge(Field("email", errInvalidEmail))
+	v.Check("name", errInvalidName)
+	v.Merge(errInvalidName)
+
+	assert.Equal(t, []Violation{
+		{Field: "email", Err: errInvalidEmail},
+		{Field: "name", Err: errInvalidName},
+		{Field: "", Err: errInvalidName},
+	}, Violations(v.Err()))
+}
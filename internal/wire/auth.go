@@ -0,0 +1,114 @@
+package wire
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/google/wire"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/httpclient"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/ipfilter"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/mtls"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/passwordhash"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/hibp"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// AuthSet provides what authenticates callers and protects credentials:
+// TLS with client certificates, the IP filter, password hashing and the
+// breached password check.
+var AuthSet = wire.NewSet(
+	ProvideTLSConfig,
+	ProvideClientIdentities,
+	ProvideIPFilter,
+	ProvidePasswordHasher,
+	ProvideBreachedPasswords,
+)
+
+// ProvideTLSConfig provides the TLS configuration of the HTTP and gRPC
+// listeners. It returns nil when tls.enabled is false.
+func ProvideTLSConfig(cfg *config.Config) (*tls.Config, error) {
+	if !cfg.TLS.Enabled {
+		return nil, nil
+	}
+
+	return mtls.ServerConfig(mtls.Options{
+		CertFile:     cfg.TLS.CertFile,
+		KeyFile:      cfg.TLS.KeyFile,
+		ClientCAFile: cfg.TLS.ClientCAFile,
+		ClientAuth:   cfg.TLS.ClientAuth,
+	})
+}
+
+// ProvideClientIdentities provides the mapping of client certificate
+// identities to principals. It returns nil unless client certificates are
+// verified.
+func ProvideClientIdentities(cfg *config.Config) *mtls.Identities {
+	if !cfg.TLS.Enabled || cfg.TLS.ClientCAFile == "" {
+		return nil
+	}
+	return mtls.NewIdentities(cfg.TLS.Principals)
+}
+
+// ProvideIPFilter provides the filter restricting route groups to client
+// networks. It returns nil when http.ip_filter.enabled is false.
+func ProvideIPFilter(cfg *config.Config, log *logger.Logger) (*ipfilter.Filter, error) {
+	if !cfg.HTTP.IPFilter.Enabled {
+		return nil, nil
+	}
+
+	opts, err := IPFilterOptions(cfg.HTTP.IPFilter)
+	if err != nil {
+		return nil, err
+	}
+	return ipfilter.New(opts, log), nil
+}
+
+// IPFilterOptions converts the IP filter configuration. A disabled filter
+// has no rules, so reloading it with enabled false lets every request
+// through.
+func IPFilterOptions(cfg config.IPFilterConfig) (ipfilter.Options, error) {
+	if !cfg.Enabled {
+		return ipfilter.Options{}, nil
+	}
+
+	routes := make(map[string]ipfilter.Rules, len(cfg.Routes))
+	for prefix, rules := range cfg.Routes {
+		allow, err := clientip.ParseNetworks(rules.Allow)
+		if err != nil {
+			return ipfilter.Options{}, fmt.Errorf("invalid allow list of %s: %w", prefix, err)
+		}
+		deny, err := clientip.ParseNetworks(rules.Deny)
+		if err != nil {
+			return ipfilter.Options{}, fmt.Errorf("invalid deny list of %s: %w", prefix, err)
+		}
+		routes[prefix] = ipfilter.Rules{Allow: allow, Deny: deny}
+	}
+
+	return ipfilter.Options{Routes: routes}, nil
+}
+
+// ProvidePasswordHasher provides the Argon2id password hasher
+func ProvidePasswordHasher() ports.PasswordHasher {
+	return passwordhash.NewArgon2id(passwordhash.DefaultParams())
+}
+
+// ProvideBreachedPasswords provides the breached password check configured
+// under users.passwords.breach_check, or nil when it is disabled
+func ProvideBreachedPasswords(cfg *config.Config, log *logger.Logger) (ports.BreachedPasswords, error) {
+	opts := cfg.Users.Passwords.BreachCheck
+	if !opts.Enabled {
+		return nil, nil
+	}
+
+	client := httpclient.New(httpclient.Options{
+		Name:    "breached_passwords",
+		Timeout: opts.Timeout,
+	})
+	return hibp.New(hibp.Options{
+		BaseURL:  opts.BaseURL,
+		FailOpen: opts.FailOpen,
+	}, client, log)
+}
@@ -0,0 +1,30 @@
+package wire
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// DomainSet provides the domain modules besides users, and their routes.
+// It is the extension point for new domains, such as those generated by
+// cmd/scaffold:
+//
+//  1. Add the provider set of the domain below.
+//  2. Take its routes as a parameter of ProvideDomainRoutes and add them
+//     to the routes it returns.
+var DomainSet = wire.NewSet(
+	OrganizationSet,
+	ProvideDomainRoutes,
+)
+
+// DomainRoutes registers the routes of the domain modules on the router,
+// after the user routes. Nil elements are skipped, for domains whose routes
+// are disabled.
+type DomainRoutes []func(router *gin.Engine)
+
+// ProvideDomainRoutes provides the routes of the domain modules
+func ProvideDomainRoutes(orgRoutes OrganizationRoutes) DomainRoutes {
+	return DomainRoutes{
+		orgRoutes,
+	}
+}
@@ -0,0 +1,677 @@
+package wire
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/admin"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/cache"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/capture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fixture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/health"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/httpclient"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idempotency"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idgen"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/leader"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lifecycle"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/quota"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/slowalert"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/startup"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/storage"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tracing"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// InfraSet provides the infrastructure shared by the modules: configuration,
+// logging, tracing, the root context, the clock and ID generator, health
+// checks and warm-up, PostgreSQL
+// and its replicas, storage, locks, the cache, and the HTTP request infrastructure of
+// idempotency, quotas, body capture, client addresses and tenants.
+var InfraSet = wire.NewSet(
+	ProvideConfig,
+	ProvideLogger,
+	ProvideTracerProvider,
+	ProvideLifecycle,
+	ProvideClock,
+	ProvideIDGenerator,
+	ProvideHealthChecker,
+	ProvideWarmer,
+	ProvideSlowAlerts,
+	ProvideDatabaseCredentials,
+	ProvidePostgresDB,
+	ProvideReplicas,
+	ProvideFileStorage,
+	ProvideObjectStorage,
+	ProvideRedisClient,
+	ProvideLocker,
+	ProvideCacheInvalidator,
+	ProvideCache,
+	ProvideIdempotencyStore,
+	ProvideQuotaTracker,
+	ProvideCapturer,
+	ProvideFixtureRecorder,
+	ProvideClientIPResolver,
+	ProvideTenantResolver,
+	ProvideFieldKeys,
+)
+
+// ProvideConfig provides the application configuration, accepting the API
+// keys of the seed fixtures when seeding is enabled
+func ProvideConfig(configPath string) (*config.Config, error) {
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := addSeedAPIKeys(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ProvideLogger provides the logger instance, redacting events with the
+// policy of the environment
+func ProvideLogger(cfg *config.Config) *logger.Logger {
+	return logger.NewRedacted(cfg.App.LogLevel, os.Stdout, newRedactor(cfg))
+}
+
+// newRedactor returns the redactor of log events and access logs configured
+// under observability.redaction, or nil when redaction is off
+func newRedactor(cfg *config.Config) *logger.Redactor {
+	redaction := cfg.Observability.Redaction
+	return logger.NewRedactor(redaction.PolicyFor(cfg.App.Environment), redaction.Fields)
+}
+
+// ProvideTracerProvider provides the tracer provider exporting spans as
+// observability.tracing says and installs it as the global provider, used by
+// the HTTP and gRPC client and server instrumentation. Without an exporter it
+// returns the global provider, which records nothing.
+func ProvideTracerProvider(cfg *config.Config, log *logger.Logger) (trace.TracerProvider, func(), error) {
+	tracingCfg := cfg.Observability.Tracing
+
+	endpoint := tracingCfg.Endpoint
+	if endpoint == "" && tracingCfg.Exporter == tracing.ExporterJaeger {
+		endpoint = cfg.Observability.JaegerEndpoint
+	}
+	serviceName := tracingCfg.ServiceName
+	if serviceName == "" {
+		serviceName = cfg.App.Name
+	}
+
+	provider, err := tracing.NewProvider(context.Background(), tracing.Options{
+		Exporter:    tracingCfg.Exporter,
+		Endpoint:    endpoint,
+		Headers:     tracingCfg.Headers,
+		ServiceName: serviceName,
+		Environment: cfg.App.Environment,
+		Sampling: tracing.Sampling{
+			Ratio:  tracingCfg.Sampling.Ratio,
+			Routes: tracingCfg.Sampling.Routes,
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if provider == nil {
+		return otel.GetTracerProvider(), func() {}, nil
+	}
+	otel.SetTracerProvider(provider)
+
+	cleanup := func() {
+		// Flush the spans still buffered
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("Failed to flush traces")
+		}
+	}
+
+	return provider, cleanup, nil
+}
+
+// ProvideHealthChecker provides the health checker instance with warm-up,
+// database, read replica, Redis and leader election checks
+func ProvideHealthChecker(db *gorm.DB, replicas *database.Replicas, redisClient redis.UniversalClient, userCDC *UserCDC, elector *leader.Elector, warmer *startup.Warmer) *health.Checker {
+	checker := health.NewChecker()
+
+	// Not ready until warmed up, so traffic waits for the pools and caches
+	if warmer != nil {
+		checker.AddCheck("warmup", warmer.Ready)
+	}
+
+	// Redis only backs background work, such as the locks of the jobs
+	if redisClient != nil {
+		checker.AddNonCriticalCheck("redis", func(ctx context.Context) error {
+			return redisClient.Ping(ctx).Err()
+		})
+	}
+
+	// Without a leader the background jobs stop, but requests are served
+	if elector != nil {
+		checker.AddNonCriticalCheck("leader_election", elector.Check)
+	}
+
+	// Nothing to check when running without a database
+	if db == nil {
+		return checker
+	}
+
+	// Register database health check
+	checker.AddCheck("database", func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+
+	// A replica that is down only degrades the service: reads fall back to
+	// the other nodes
+	for _, replica := range replicas.Nodes() {
+		checker.AddNonCriticalCheck("database_replica_"+replica.Name, replica.Check)
+	}
+
+	// Stalled change data capture delays events, but serves requests
+	if userCDC != nil {
+		check := userCDC.listener.Check
+		if elector != nil {
+			// Only the leader captures changes
+			check = func(ctx context.Context) error {
+				if !elector.IsLeader() {
+					return nil
+				}
+				return userCDC.listener.Check(ctx)
+			}
+		}
+		checker.AddNonCriticalCheck("cdc", check)
+	}
+
+	return checker
+}
+
+// ProvideWarmer provides the warm-up run by main once the servers listen,
+// with tasks opening the idle connections of the database pools and
+// running the user lookups of hot paths once, so GORM has parsed the user
+// schema and PostgreSQL has planned the statements before the first
+// request. Components add their own tasks with Register. It returns nil
+// when startup.warmup is disabled.
+func ProvideWarmer(cfg *config.Config, log *logger.Logger, db *gorm.DB, replicas *database.Replicas, users ports.UserRepository) (*startup.Warmer, error) {
+	if !cfg.Startup.Warmup.Enabled {
+		return nil, nil
+	}
+
+	warmer := startup.NewWarmer(cfg.Startup.Warmup.TaskTimeout, log)
+
+	// Nothing to warm when running without a database
+	if db == nil {
+		return warmer, nil
+	}
+
+	pools, err := database.Pools(db, replicas)
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range pools {
+		warmer.Register("postgres_pool_"+pool.Name, 0, func(ctx context.Context) error {
+			return pool.Warm(ctx, cfg.Postgres.MaxIdleConns)
+		})
+	}
+
+	warmer.Register("user_lookups", 0, func(ctx context.Context) error {
+		// Absent keys: only the queries matter, not their results
+		ctx = tenancy.AllTenants(ctx)
+		lookups := []func() (*domain.User, error){
+			func() (*domain.User, error) { return users.GetByID(ctx, "00000000-0000-0000-0000-000000000000") },
+			func() (*domain.User, error) { return users.GetByEmail(ctx, "warmup@example.invalid") },
+			func() (*domain.User, error) { return users.GetByUsername(ctx, "warmup") },
+		}
+		for _, lookup := range lookups {
+			if _, err := lookup(); err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+				return err
+			}
+		}
+		return nil
+	})
+	return warmer, nil
+}
+
+// ProvideDatabaseCredentials provides the PostgreSQL credentials selected by
+// postgres.auth and, when the password comes from a file, reloads it in the
+// background. It returns nil when the storage driver needs no database.
+func ProvideDatabaseCredentials(cfg *config.Config, log *logger.Logger) (*database.Credentials, func(), error) {
+	if cfg.Storage.InMemory() {
+		return nil, func() {}, nil
+	}
+
+	creds, err := database.Authenticate(context.Background(), cfg.Postgres, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if cfg.Postgres.PasswordFile != "" && cfg.Postgres.PasswordReloadInterval > 0 {
+		go creds.Watch(ctx, cfg.Postgres.PasswordReloadInterval)
+	}
+
+	cleanup := func() {
+		cancel()
+		if err := creds.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close database credentials")
+		}
+	}
+
+	return creds, cleanup, nil
+}
+
+// ProvideSlowAlerts provides the monitor alerting on routes and queries that
+// keep exceeding their latency threshold, or nil unless
+// observability.alerts is enabled
+func ProvideSlowAlerts(cfg *config.Config, log *logger.Logger, clock ports.Clock) (*slowalert.Monitor, func(), error) {
+	opts := cfg.Observability.Alerts
+	if !opts.Enabled {
+		return nil, func() {}, nil
+	}
+
+	var hook slowalert.Hook
+	switch opts.Hook {
+	case "log":
+		hook = slowalert.NewLogHook(log)
+	case "webhook":
+		hook = slowalert.NewWebhookHook(opts.WebhookURL, httpclient.New(httpclient.Options{Name: "alerts"}))
+	case "pagerduty":
+		hook = slowalert.NewPagerDutyHook(slowalert.PagerDutyOptions{
+			RoutingKey: opts.PagerDutyRoutingKey,
+			Source:     cfg.App.Name,
+		}, httpclient.New(httpclient.Options{Name: "alerts"}))
+	default:
+		return nil, nil, fmt.Errorf("unknown alert hook: %q", opts.Hook)
+	}
+
+	monitor := slowalert.New(slowalert.Options{
+		Window:      opts.Window,
+		Occurrences: opts.Occurrences,
+		Cooldown:    opts.Cooldown,
+	}, hook, log, clock)
+
+	// Deliver the alerts still being sent
+	return monitor, monitor.Close, nil
+}
+
+// ProvidePostgresDB provides the PostgreSQL database connection, its pool
+// stats exported to Prometheus. Slow statements are reported to alerts
+// unless it is nil.
+func ProvidePostgresDB(cfg *config.Config, creds *database.Credentials, alerts *slowalert.Monitor, log *logger.Logger) (*gorm.DB, func(), error) {
+	// The memory storage driver runs without a database
+	if cfg.Storage.InMemory() {
+		log.Warn().Msg("Storage driver is memory: data is not persisted and organization routes are disabled")
+		return nil, func() {}, nil
+	}
+
+	db, err := database.NewPostgresDB(cfg, creds, log)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Scope queries on tenant data to the tenant of the request
+	if cfg.Tenancy.Enabled {
+		if err := tenancy.Register(db, cfg.Tenancy.Isolation); err != nil {
+			_ = database.ClosePostgresDB(db)
+			return nil, nil, err
+		}
+	}
+
+	// Let PostgreSQL give up on statements whose request already timed out
+	if cfg.Postgres.DeadlineTimeouts {
+		if err := database.UseDeadlineTimeouts(db); err != nil {
+			_ = database.ClosePostgresDB(db)
+			return nil, nil, err
+		}
+	}
+
+	if alerts != nil {
+		if err := alerts.RegisterQueries(db, cfg.Observability.Alerts.SlowQuery); err != nil {
+			_ = database.ClosePostgresDB(db)
+			return nil, nil, err
+		}
+	}
+
+	pools, err := database.Pools(db, nil)
+	if err != nil {
+		_ = database.ClosePostgresDB(db)
+		return nil, nil, err
+	}
+	unregister, err := database.RegisterPoolMetrics(prometheus.DefaultRegisterer, pools)
+	if err != nil {
+		_ = database.ClosePostgresDB(db)
+		return nil, nil, err
+	}
+
+	cleanup := func() {
+		unregister()
+		if err := database.ClosePostgresDB(db); err != nil {
+			log.Error().Err(err).Msg("Failed to close database connection")
+		}
+	}
+
+	return db, cleanup, nil
+}
+
+// ProvideReplicas registers the configured read replicas on db, exports
+// their pool stats to Prometheus and checks them in the background. It returns nil when none are configured.
+func ProvideReplicas(cfg *config.Config, db *gorm.DB, creds *database.Credentials, log *logger.Logger) (*database.Replicas, func(), error) {
+	if db == nil || len(cfg.Postgres.Replicas) == 0 {
+		return nil, func() {}, nil
+	}
+	if cfg.Postgres.ReplicaCheckInterval <= 0 {
+		return nil, nil, fmt.Errorf("postgres.replica_check_interval must be positive, got %s", cfg.Postgres.ReplicaCheckInterval)
+	}
+
+	replicas, err := database.UseReplicas(db, cfg.Postgres, creds, log)
+	if err != nil {
+		return nil, nil, err
+	}
+	unregister, err := database.RegisterPoolMetrics(prometheus.DefaultRegisterer, replicas.Pools())
+	if err != nil {
+		_ = replicas.Close()
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go replicas.Monitor(ctx, cfg.Postgres.ReplicaCheckInterval)
+
+	cleanup := func() {
+		cancel()
+		unregister()
+		if err := replicas.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close read replica connections")
+		}
+	}
+
+	return replicas, cleanup, nil
+}
+
+// ProvideLifecycle provides the root context of the application, which
+// main cancels on shutdown once requests have drained
+func ProvideLifecycle() (*lifecycle.Root, func()) {
+	root := lifecycle.New()
+	return root, root.Cancel
+}
+
+// ProvideClock provides the system clock
+func ProvideClock() ports.Clock {
+	return clock.System{}
+}
+
+// ProvideIDGenerator provides the user ID generator selected by configuration
+func ProvideIDGenerator(cfg *config.Config) (ports.IDGenerator, error) {
+	return idgen.New(cfg.Users.IDGenerator)
+}
+
+// ProvideFileStorage provides the file storage selected by configuration
+func ProvideFileStorage(cfg *config.Config) (ports.FileStorage, error) {
+	return storage.New(context.Background(), cfg.Storage)
+}
+
+// ProvideObjectStorage provides the storage of private objects selected by
+// storage.objects
+func ProvideObjectStorage(cfg *config.Config) (storage.ObjectStorage, error) {
+	return storage.NewObjectStorage(context.Background(), cfg.Storage.Objects)
+}
+
+// ProvideRedisClient provides the Redis client shared by the components
+// using Redis, or nil when none does or redis.host is not set, so a
+// deployment without Redis neither needs nor connects to it. The client
+// connects on first use.
+func ProvideRedisClient(cfg *config.Config, log *logger.Logger) (redis.UniversalClient, func()) {
+	if !cfg.Redis.Enabled() || len(redisUsers(cfg)) == 0 {
+		return nil, func() {}
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Address(),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	cleanup := func() {
+		if err := client.Close(); err != nil {
+			log.Error().Err(err).Msg("Failed to close Redis client")
+		}
+	}
+	return client, cleanup
+}
+
+// redisUsers returns the components configured to use Redis
+func redisUsers(cfg *config.Config) []string {
+	var users []string
+	if cfg.Locks.Driver == "redis" {
+		users = append(users, "locks")
+	}
+	if cfg.Cache.Driver == "redis" {
+		users = append(users, "cache")
+	}
+	if cfg.Cache.Invalidation.Driver == "redis" {
+		users = append(users, "cache invalidation")
+	}
+	return users
+}
+
+// ProvideLocker provides the locks shared by the instances, taken with
+// the locks.driver backend. Conflicts are logged and every outcome is
+// counted in /debug/vars.
+func ProvideLocker(cfg *config.Config, db *gorm.DB, redisClient redis.UniversalClient, log *logger.Logger) lock.Locker {
+	var locker lock.Locker
+	switch cfg.Locks.Driver {
+	case "postgres":
+		locker = lock.NewPostgresLocker(db)
+	case "redis":
+		locker = lock.NewRedisLocker(redisClient, cfg.Locks.Prefix)
+	default:
+		locker = lock.NewMemoryLocker()
+	}
+
+	return lock.Instrument(locker, cfg.Locks.Driver, log)
+}
+
+// ProvideCacheInvalidator provides the fan-out of the memory cache
+// invalidations over the cache.invalidation.driver bus. It returns nil
+// when cache.invalidation.driver is none.
+func ProvideCacheInvalidator(cfg *config.Config, redisClient redis.UniversalClient, db *gorm.DB, creds *database.Credentials, log *logger.Logger) (*cache.Invalidator, error) {
+	var bus cache.Bus
+	switch cfg.Cache.Invalidation.Driver {
+	case "redis":
+		bus = cache.NewRedisBus(redisClient, cfg.Cache.Invalidation.Channel)
+	case "postgres":
+		connect := func(ctx context.Context) (*pgx.Conn, error) {
+			return creds.Connect(ctx, cfg.Postgres)
+		}
+		bus = cache.NewPostgresBus(db, connect, cfg.Cache.Invalidation.Channel)
+	default:
+		return nil, nil
+	}
+
+	return cache.NewInvalidator(bus, prometheus.DefaultRegisterer, log)
+}
+
+// ProvideCache provides the cache selected by cache.driver, counting its
+// hits and misses in Prometheus. The memory cache publishes its deletes
+// through invalidator when it is not nil. It returns nil when cache.driver
+// is none.
+func ProvideCache(cfg *config.Config, redisClient redis.UniversalClient, invalidator *cache.Invalidator) (cache.Cache, func(), error) {
+	var c cache.Cache
+	cleanup := func() {}
+	switch cfg.Cache.Driver {
+	case "memory":
+		memory, err := cache.NewMemoryCache(cfg.Cache.Memory.MaxBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := prometheus.DefaultRegisterer.Register(memory); err != nil {
+			memory.Close()
+			return nil, nil, fmt.Errorf("failed to register cache metrics: %w", err)
+		}
+		c = memory
+		if invalidator != nil {
+			c = invalidator.Wrap(memory)
+		}
+		cleanup = func() {
+			prometheus.DefaultRegisterer.Unregister(memory)
+			memory.Close()
+		}
+	case "redis":
+		c = cache.NewRedisCache(redisClient, cfg.Cache.Prefix)
+	default:
+		return nil, func() {}, nil
+	}
+
+	instrumented, err := cache.Instrument(c, cfg.Cache.Driver, prometheus.DefaultRegisterer)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return instrumented, cleanup, nil
+}
+
+// ProvideIdempotencyStore provides the store for Idempotency-Key records
+func ProvideIdempotencyStore(cfg *config.Config, db *gorm.DB) idempotency.Store {
+	if cfg.Storage.InMemory() {
+		return idempotency.NewMemoryStore()
+	}
+	return idempotency.NewGormStore(db)
+}
+
+// ProvideQuotaTracker provides the tracker of the request quotas of API
+// clients and tenants. It returns nil when http.quota.enabled is false.
+func ProvideQuotaTracker(cfg *config.Config, db *gorm.DB, clock ports.Clock) *quota.Tracker {
+	opts := cfg.HTTP.Quota
+	if !opts.Enabled {
+		return nil
+	}
+
+	var store quota.Store
+	if cfg.Storage.InMemory() {
+		store = quota.NewMemoryStore()
+	} else {
+		store = quota.NewGormStore(db)
+	}
+
+	overrides := make(map[string]quota.Limits, len(opts.Overrides))
+	for subject, limits := range opts.Overrides {
+		overrides[subject] = quota.Limits{Daily: limits.Daily, Monthly: limits.Monthly}
+	}
+	return quota.NewTracker(store, clock, quota.Limits{Daily: opts.Daily, Monthly: opts.Monthly}, overrides)
+}
+
+// ProvideCapturer provides the capture of request and response bodies, or
+// nil unless observability.capture is enabled. Bodies are redacted with the
+// strict policy whatever the environment, as they are full of personal
+// data.
+func ProvideCapturer(cfg *config.Config, log *logger.Logger, clock ports.Clock) *capture.Capturer {
+	opts := cfg.Observability.Capture
+	if !opts.Enabled {
+		return nil
+	}
+
+	return capture.New(capture.Options{
+		MaxBodyBytes: opts.MaxBodyBytes,
+		BufferSize:   opts.BufferSize,
+		Log:          opts.Log,
+		MaxDuration:  opts.MaxDuration,
+		ExemptPaths:  []string{admin.Prefix},
+	}, logger.NewRedactor(logger.PolicyStrict, cfg.Observability.Redaction.Fields), log, clock)
+}
+
+// ProvideFixtureRecorder provides the recording of fixtures for cmd/replay,
+// or nil unless http.fixtures.record is set. Like captures, they are
+// redacted with the strict policy.
+func ProvideFixtureRecorder(cfg *config.Config, log *logger.Logger, clock ports.Clock) (*fixture.Recorder, error) {
+	opts := cfg.HTTP.Fixtures
+	if !opts.Record {
+		return nil, nil
+	}
+
+	tapper := capture.New(capture.Options{
+		MaxBodyBytes: opts.MaxBodyBytes,
+	}, logger.NewRedactor(logger.PolicyStrict, cfg.Observability.Redaction.Fields), log, clock)
+	return fixture.NewRecorder(opts.Dir, tapper, []string{admin.Prefix, "/health/", "/metrics", "/debug/"}, log)
+}
+
+// ProvideClientIPResolver provides the resolver of client addresses,
+// believing X-Forwarded-For from http.trusted_proxies only
+func ProvideClientIPResolver(cfg *config.Config) (*clientip.Resolver, error) {
+	trusted, err := clientip.ParseNetworks(cfg.HTTP.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	return clientip.NewResolver(trusted), nil
+}
+
+// ProvideTenantResolver provides the resolver selected by tenancy.resolver.
+// It returns nil when multi-tenancy is disabled.
+func ProvideTenantResolver(cfg *config.Config) (tenancy.Resolver, error) {
+	if !cfg.Tenancy.Enabled {
+		return nil, nil
+	}
+
+	// Only the GORM repositories scope their queries to the tenant
+	if cfg.Storage.InMemory() {
+		return nil, fmt.Errorf("tenancy requires the postgres storage driver")
+	}
+	if cfg.Users.Repository == "sqlc" {
+		return nil, fmt.Errorf("tenancy requires users.repository gorm, got sqlc")
+	}
+
+	return tenancy.NewResolver(cfg.Tenancy)
+}
+
+// ProvideFieldKeys provides the keys user emails and names are encrypted
+// with, read from users.encryption or the files it names. It returns nil
+// when encryption is disabled or data is kept in memory.
+func ProvideFieldKeys(cfg *config.Config) (*fieldcrypt.Keyring, error) {
+	opts := cfg.Users.Encryption
+	if !opts.Enabled || cfg.Storage.InMemory() {
+		return nil, nil
+	}
+
+	keys, err := secretValue(opts.Keys, opts.KeysFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encryption keys: %w", err)
+	}
+	indexKey, err := secretValue(opts.IndexKey, opts.IndexKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blind index key: %w", err)
+	}
+
+	return fieldcrypt.NewKeyring(keys, indexKey)
+}
+
+// secretValue returns the contents of file when it is set, otherwise value
+func secretValue(value, file string) (string, error) {
+	if file == "" {
+		return value, nil
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
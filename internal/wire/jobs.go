@@ -0,0 +1,206 @@
+package wire
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/wire"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idempotency"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/leader"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/quota"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/scheduler"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/storage"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"gorm.io/gorm"
+)
+
+// JobsSet provides the background jobs and the election of the replica
+// running them
+var JobsSet = wire.NewSet(
+	ProvideScheduler,
+	ProvideLeaderElector,
+)
+
+// ProvideScheduler provides the background job scheduler with all periodic jobs registered
+func ProvideScheduler(cfg *config.Config, log *logger.Logger, db *gorm.DB, retention ports.UserRetention, projection ports.UserProjection, statistics ports.UserStatistics, userJobs ports.UserJobs, locker lock.Locker, objectStorage storage.ObjectStorage, idempotencyStore idempotency.Store, quotas *quota.Tracker) *scheduler.Scheduler {
+	sched := scheduler.New(log)
+	sched.UseLocker(locker)
+
+	if cfg.Users.Retention.Enabled {
+		sched.Every("user_retention", cfg.Users.Retention.Interval, func(ctx context.Context) error {
+			purged, err := purgeDeletedUsers(ctx, cfg, db, retention)
+			if err != nil {
+				return err
+			}
+
+			log.Info().
+				Int("purged", purged).
+				Bool("dry_run", cfg.Users.Retention.DryRun).
+				Msg("Purged soft-deleted users")
+			return nil
+		})
+	}
+
+	if projection != nil {
+		sched.Every("user_read_model_rebuild", cfg.Users.ReadModel.RebuildInterval, func(ctx context.Context) error {
+			saved, err := projection.Rebuild(tenancy.AllTenants(ctx))
+			if err != nil {
+				return err
+			}
+
+			log.Info().
+				Int("saved", saved).
+				Msg("Rebuilt the user read model")
+			return nil
+		})
+	}
+
+	// Statistics counted on each request need no refresh
+	if statistics != nil && !cfg.Storage.InMemory() {
+		sched.Every("user_stats_refresh", cfg.Users.Stats.RefreshInterval, func(ctx context.Context) error {
+			if err := statistics.RefreshUserStats(ctx); err != nil {
+				return err
+			}
+
+			log.Info().Msg("Refreshed user statistics")
+			return nil
+		})
+	}
+
+	sched.Every("user_jobs_resume", cfg.Users.Jobs.CheckInterval, func(ctx context.Context) error {
+		resumed, err := forEachTenant(ctx, cfg, db, userJobs.ResumeStaleJobs)
+		if err != nil {
+			return err
+		}
+
+		if resumed > 0 {
+			log.Info().
+				Int("resumed", resumed).
+				Msg("Resumed abandoned user jobs")
+		}
+		return nil
+	})
+
+	sched.Every("user_jobs_cleanup", cfg.Users.Jobs.CheckInterval, func(ctx context.Context) error {
+		deleted, err := forEachTenant(ctx, cfg, db, userJobs.PruneJobs)
+		if err != nil {
+			return err
+		}
+
+		log.Debug().
+			Int("deleted", deleted).
+			Msg("Deleted finished user jobs")
+		return nil
+	})
+
+	if rules := cfg.Storage.Objects.Lifecycle; len(rules) > 0 {
+		lifecycle := make([]storage.LifecycleRule, len(rules))
+		for i, rule := range rules {
+			lifecycle[i] = storage.LifecycleRule{Prefix: rule.Prefix, ExpireAfter: rule.ExpireAfter}
+		}
+
+		sched.Every("object_storage_cleanup", cfg.Storage.Objects.CleanupInterval, func(ctx context.Context) error {
+			deleted, err := storage.DeleteExpired(ctx, objectStorage, lifecycle, time.Now())
+			if err != nil {
+				return err
+			}
+
+			log.Info().
+				Int("deleted", deleted).
+				Msg("Deleted expired objects")
+			return nil
+		})
+	}
+
+	if cfg.HTTP.Idempotency.Enabled {
+		sched.Every("idempotency_cleanup", cfg.HTTP.Idempotency.CleanupInterval, func(ctx context.Context) error {
+			deleted, err := idempotencyStore.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				return err
+			}
+
+			log.Debug().
+				Int("deleted", deleted).
+				Msg("Deleted expired idempotency keys")
+			return nil
+		})
+	}
+
+	if quotas != nil {
+		sched.Every("quota_cleanup", cfg.HTTP.Quota.CleanupInterval, func(ctx context.Context) error {
+			deleted, err := quotas.DeleteExpired(ctx)
+			if err != nil {
+				return err
+			}
+
+			log.Debug().
+				Int("deleted", deleted).
+				Msg("Deleted expired request quota counts")
+			return nil
+		})
+	}
+
+	return sched
+}
+
+// ProvideLeaderElector provides the election of the replica running the
+// scheduler and change data capture, or nil unless leader.enabled
+func ProvideLeaderElector(cfg *config.Config, db *gorm.DB, sched *scheduler.Scheduler, userCDC *UserCDC, log *logger.Logger) (*leader.Elector, error) {
+	leaderCfg := cfg.Leader
+	if !leaderCfg.Enabled {
+		return nil, nil
+	}
+
+	var lease leader.Lease
+	switch leaderCfg.Driver {
+	case "postgres":
+		lease = leader.NewPostgresLease(db)
+	default:
+		opts, err := leader.InCluster(leaderCfg.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		lease = leader.NewKubernetesLease(opts)
+	}
+
+	name := leaderCfg.Name
+	if name == "" {
+		name = cfg.App.Name
+	}
+
+	return leader.New(lease, leader.Options{
+		Name:          name,
+		Identity:      leaderCfg.Identity,
+		LeaseDuration: leaderCfg.LeaseDuration,
+		RenewDeadline: leaderCfg.RenewDeadline,
+		RetryPeriod:   leaderCfg.RetryPeriod,
+		OnStartedLeading: func(ctx context.Context) {
+			runSingletons(ctx, sched, userCDC)
+		},
+	}, log)
+}
+
+// purgeDeletedUsers purges the soft-deleted users of every tenant
+func purgeDeletedUsers(ctx context.Context, cfg *config.Config, db *gorm.DB, retention ports.UserRetention) (int, error) {
+	return forEachTenant(ctx, cfg, db, retention.PurgeDeletedUsers)
+}
+
+// forEachTenant runs fn for every tenant when tenancy is enabled, or once
+// otherwise, and sums the counts it returns
+func forEachTenant(ctx context.Context, cfg *config.Config, db *gorm.DB, fn func(ctx context.Context) (int, error)) (int, error) {
+	if !cfg.Tenancy.Enabled {
+		return fn(ctx)
+	}
+
+	total := 0
+	err := tenancy.ForEachTenant(ctx, db, cfg.Tenancy.Isolation, func(ctx context.Context) error {
+		n, err := fn(ctx)
+		total += n
+		return err
+	})
+	return total, err
+}
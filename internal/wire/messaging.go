@@ -0,0 +1,87 @@
+package wire
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/cdc"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/mailer"
+	usercdc "github.com/yourusername/go-scaffolding/internal/user/adapters/cdc"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/eventbus"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// MessagingSet provides the messages sent out of the service: mail, the
+// user event bus and the change data capture publishing to it.
+var MessagingSet = wire.NewSet(
+	ProvideMailer,
+	ProvideEventBus,
+	ProvideUserCDC,
+)
+
+// ProvideMailer provides the mailer selected by configuration
+func ProvideMailer(cfg *config.Config, log *logger.Logger) (ports.Mailer, error) {
+	return mailer.New(cfg.Mailer, log)
+}
+
+// ProvideEventBus provides the bus pushing user changes to GraphQL
+// subscriptions and the event stream, or nil when both are disabled
+func ProvideEventBus(cfg *config.Config) *eventbus.Bus {
+	subscriptions := cfg.HTTP.GraphQL.Enabled && cfg.HTTP.GraphQL.Subscriptions.Enabled
+	if !subscriptions && !cfg.Users.Events.Stream.Enabled && !cfg.Users.ReadModel.Enabled {
+		return nil
+	}
+	return eventbus.New(eventbus.Options{Buffer: cfg.Users.Events.Buffer})
+}
+
+// UserCDC publishes the changes to users captured from the replication
+// stream to the event bus
+type UserCDC struct {
+	listener  *cdc.Listener
+	publisher *usercdc.Publisher
+}
+
+// Run streams the changes until ctx is done
+func (c *UserCDC) Run(ctx context.Context) {
+	c.listener.Run(ctx, c.publisher.Handle)
+}
+
+// ProvideUserCDC provides the change data capture of users configured under
+// users.events.cdc, or nil when it is disabled or nothing subscribes to
+// user events
+func ProvideUserCDC(cfg *config.Config, creds *database.Credentials, bus *eventbus.Bus, keys *fieldcrypt.Keyring, log *logger.Logger) (*UserCDC, error) {
+	cdcCfg := cfg.Users.Events.CDC
+	if !cdcCfg.Enabled {
+		return nil, nil
+	}
+	if bus == nil {
+		log.Warn().Msg("users.events.cdc is enabled, but nothing subscribes to user events; not capturing changes")
+		return nil, nil
+	}
+
+	listener, err := cdc.NewListener(cdc.Options{
+		Plugin:         cdcCfg.Plugin,
+		Slot:           cdcCfg.Slot,
+		Publication:    cdcCfg.Publication,
+		Tables:         usercdc.Tables,
+		CreateSlot:     cdcCfg.CreateSlot,
+		TemporarySlot:  cdcCfg.TemporarySlot,
+		StatusInterval: cdcCfg.StatusInterval,
+		MaxLagBytes:    uint64(max(cdcCfg.MaxLagBytes, 0)),
+	}, func(ctx context.Context) (*pgconn.PgConn, error) {
+		return creds.ConnectReplication(ctx, cfg.Postgres)
+	}, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserCDC{
+		listener:  listener,
+		publisher: usercdc.NewPublisher(bus, keys),
+	}, nil
+}
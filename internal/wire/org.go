@@ -0,0 +1,103 @@
+package wire
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	orghttp "github.com/yourusername/go-scaffolding/internal/org/adapters/http"
+	orgpostgres "github.com/yourusername/go-scaffolding/internal/org/adapters/postgres"
+	orgusers "github.com/yourusername/go-scaffolding/internal/org/adapters/users"
+	orgports "github.com/yourusername/go-scaffolding/internal/org/ports"
+	orgservice "github.com/yourusername/go-scaffolding/internal/org/service"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"gorm.io/gorm"
+)
+
+// OrganizationSet provides the organization domain and its routes
+var OrganizationSet = wire.NewSet(
+	ProvideOrganizationRepository,
+	ProvideOrganizationUserDirectory,
+	ProvideOrganizationService,
+	ProvideInvitationRepository,
+	ProvideOrganizationMailer,
+	ProvideOrganizationClock,
+	ProvideOrganizationIDGenerator,
+	ProvideTransactor,
+	ProvideInvitationService,
+	ProvideOrganizationRoutes,
+)
+
+// ProvideOrganizationRepository provides the organization repository implementation
+func ProvideOrganizationRepository(db *gorm.DB) orgports.OrganizationRepository {
+	return orgpostgres.NewOrganizationRepository(db)
+}
+
+// ProvideOrganizationUserDirectory provides the organization feature's view of users,
+// backed by the user service
+func ProvideOrganizationUserDirectory(userService ports.UserService) orgports.UserDirectory {
+	return orgusers.NewUserDirectory(userService)
+}
+
+// ProvideOrganizationService provides the organization service implementation
+func ProvideOrganizationService(repo orgports.OrganizationRepository, users orgports.UserDirectory, clock orgports.Clock, ids orgports.IDGenerator) orgports.OrganizationService {
+	return orgservice.NewOrganizationService(repo, users, clock, ids)
+}
+
+// ProvideInvitationRepository provides the invitation repository implementation
+func ProvideInvitationRepository(db *gorm.DB) orgports.InvitationRepository {
+	return orgpostgres.NewInvitationRepository(db)
+}
+
+// ProvideOrganizationMailer provides the mailer used for invitations
+func ProvideOrganizationMailer(m ports.Mailer) orgports.Mailer {
+	return m
+}
+
+// ProvideOrganizationClock provides the clock used for organizations and invitations
+func ProvideOrganizationClock(c ports.Clock) orgports.Clock {
+	return c
+}
+
+// ProvideOrganizationIDGenerator provides the ID generator used for organizations and invitations
+func ProvideOrganizationIDGenerator(ids ports.IDGenerator) orgports.IDGenerator {
+	return ids
+}
+
+// ProvideTransactor provides transactions spanning repositories of any feature
+func ProvideTransactor(db *gorm.DB) orgports.Transactor {
+	return database.NewTransactor(db)
+}
+
+// ProvideInvitationService provides the organization invitation service
+func ProvideInvitationService(
+	cfg *config.Config,
+	invitations orgports.InvitationRepository,
+	orgs orgports.OrganizationRepository,
+	users orgports.UserDirectory,
+	mailer orgports.Mailer,
+	tx orgports.Transactor,
+	clock orgports.Clock,
+	ids orgports.IDGenerator,
+) orgports.InvitationService {
+	return orgservice.NewInvitationService(invitations, orgs, users, mailer, tx, clock, ids, orgservice.InvitationOptions{
+		TTL:       cfg.Organizations.Invitations.TTL,
+		AcceptURL: cfg.Organizations.Invitations.AcceptURL,
+	})
+}
+
+// OrganizationRoutes registers the organization and invitation routes on
+// the router. It is nil when the storage driver is memory, as they need a
+// database.
+type OrganizationRoutes func(router *gin.Engine)
+
+// ProvideOrganizationRoutes provides the organization and invitation routes
+func ProvideOrganizationRoutes(cfg *config.Config, orgService orgports.OrganizationService, invitations orgports.InvitationService, clock orgports.Clock) OrganizationRoutes {
+	if cfg.Storage.InMemory() {
+		return nil
+	}
+
+	return func(router *gin.Engine) {
+		orghttp.RegisterOrganizationRoutes(router, orgService, invitations, clock)
+	}
+}
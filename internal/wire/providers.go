@@ -2,121 +2,157 @@ package wire
 
 import (
 	"context"
-	"os"
+	"crypto/tls"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
-	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/cache"
 	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
-	"github.com/yourusername/go-scaffolding/internal/infrastructure/health"
-	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
-	"github.com/yourusername/go-scaffolding/internal/user/adapters/http"
-	"github.com/yourusername/go-scaffolding/internal/user/adapters/postgres"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/grpcserver"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/ipfilter"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/leader"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lifecycle"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/scheduler"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/startup"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/seed"
 	"github.com/yourusername/go-scaffolding/internal/user/ports"
-	"github.com/yourusername/go-scaffolding/internal/user/service"
-	"gorm.io/gorm"
 )
 
-// ProviderSet is the Wire provider set that includes all dependencies
+// ProviderSet is the Wire provider set that includes all dependencies. It
+// composes one set per bounded context, each declared next to its
+// providers:
+//
+//   - InfraSet in infra.go
+//   - AuthSet in auth.go
+//   - MessagingSet in messaging.go
+//   - UserSet in user.go
+//   - DomainSet in domains.go, with OrganizationSet in org.go
+//   - JobsSet in jobs.go
+//   - SeedSet in seed.go
+//   - ServerSet in server.go
+//
+// New domains are added to DomainSet, not here. An optional subsystem can
+// be replaced by a set providing the same types, such as a MessagingSet
+// sending mail through another provider.
 var ProviderSet = wire.NewSet(
-	// Infrastructure
-	ProvideConfig,
-	ProvideLogger,
-	ProvideHealthChecker,
-	ProvidePostgresDB,
-
-	// User domain
-	ProvideUserRepository,
-	ProvideUserService,
-
-	// HTTP server
-	ProvideGinEngine,
+	InfraSet,
+	AuthSet,
+	MessagingSet,
+	UserSet,
+	DomainSet,
+	JobsSet,
+	SeedSet,
+	ServerSet,
+	ProvideApp,
 )
 
-// ProvideConfig provides the application configuration
-func ProvideConfig(configPath string) (*config.Config, error) {
-	return config.Load(configPath)
-}
+// App holds the top-level components started by main
+type App struct {
+	Engine    *gin.Engine
+	Scheduler *scheduler.Scheduler
 
-// ProvideLogger provides the logger instance
-func ProvideLogger(cfg *config.Config) *logger.Logger {
-	return logger.New(cfg.App.LogLevel, os.Stdout)
-}
+	// Lifecycle is the root context of requests, workers and jobs, which
+	// main cancels on shutdown once requests have drained
+	Lifecycle *lifecycle.Root
+
+	// Credentials is nil when the application runs without a database
+	Credentials *database.Credentials
+
+	// GRPC is nil when the gRPC server is disabled
+	GRPC *grpcserver.Server
+
+	// IPFilter is nil when the IP filter is disabled
+	IPFilter *ipfilter.Filter
+
+	// CDC is nil when change data capture is disabled
+	CDC *UserCDC
+
+	// Leader is nil when leader election is disabled
+	Leader *leader.Elector
+
+	// Projection is nil when the user read model is disabled
+	Projection ports.UserProjection
+
+	// TLS is nil when the HTTP server is served in plaintext
+	TLS *tls.Config
 
-// ProvideHealthChecker provides the health checker instance with database check
-func ProvideHealthChecker(db *gorm.DB) *health.Checker {
-	checker := health.NewChecker()
+	// Seeder is nil when seeding is disabled
+	Seeder *seed.Seeder
 
-	// Register database health check
-	checker.AddCheck("database", func(ctx context.Context) error {
-		sqlDB, err := db.DB()
-		if err != nil {
-			return err
-		}
-		return sqlDB.PingContext(ctx)
-	})
+	// Warmer is nil when startup.warmup is disabled
+	Warmer *startup.Warmer
 
-	return checker
+	// CacheInvalidator is nil when cache.invalidation is disabled
+	CacheInvalidator *cache.Invalidator
 }
 
-// ProvidePostgresDB provides the PostgreSQL database connection
-func ProvidePostgresDB(cfg *config.Config, log *logger.Logger) (*gorm.DB, func(), error) {
-	db, err := database.NewPostgresDB(cfg, log)
-	if err != nil {
-		return nil, nil, err
+// ProvideApp provides the application components
+func ProvideApp(engine *gin.Engine, sched *scheduler.Scheduler, root *lifecycle.Root, creds *database.Credentials, grpcServer *grpcserver.Server, ipFilter *ipfilter.Filter, userCDC *UserCDC, elector *leader.Elector, projection ports.UserProjection, tlsConfig *tls.Config, seeder *seed.Seeder, warmer *startup.Warmer, invalidator *cache.Invalidator) *App {
+	return &App{
+		Engine:      engine,
+		Scheduler:   sched,
+		Lifecycle:   root,
+		Credentials: creds,
+		GRPC:        grpcServer,
+		IPFilter:    ipFilter,
+		CDC:         userCDC,
+		Leader:      elector,
+		Projection:  projection,
+		TLS:         tlsConfig,
+		Seeder:      seeder,
+		Warmer:      warmer,
+
+		CacheInvalidator: invalidator,
 	}
+}
 
-	cleanup := func() {
-		if err := database.ClosePostgresDB(db); err != nil {
-			log.Error().Err(err).Msg("Failed to close database connection")
-		}
+// Seed loads the demo data when seeding is enabled
+func (a *App) Seed(ctx context.Context) (seed.Report, error) {
+	if a.Seeder == nil {
+		return seed.Report{}, nil
 	}
-
-	return db, cleanup, nil
+	return a.Seeder.Seed(ctx)
 }
 
-// ProvideUserRepository provides the user repository implementation
-func ProvideUserRepository(db *gorm.DB) ports.UserRepository {
-	return postgres.NewUserRepository(db)
+// WarmUp runs the warm-up tasks, after which readiness reports healthy.
+// Failed tasks are logged; they do not keep the application unready.
+func (a *App) WarmUp(ctx context.Context) {
+	if a.Warmer == nil {
+		return
+	}
+	_ = a.Warmer.Run(ctx)
 }
 
-// ProvideUserService provides the user service implementation
-func ProvideUserService(repo ports.UserRepository) ports.UserService {
-	return service.NewUserService(repo)
+// RunWorkers runs the background jobs, change data capture, the read
+// model projection and the cache invalidations until ctx is done. With
+// leader election the jobs and change data capture only run while this
+// replica leads.
+func (a *App) RunWorkers(ctx context.Context) {
+	var workers sync.WaitGroup
+	if a.Leader != nil {
+		workers.Go(func() { a.Leader.Run(ctx) })
+	} else {
+		workers.Go(func() { runSingletons(ctx, a.Scheduler, a.CDC) })
+	}
+	if a.Projection != nil {
+		workers.Go(func() { a.Projection.Run(tenancy.AllTenants(ctx)) })
+	}
+	if a.CacheInvalidator != nil {
+		workers.Go(func() { a.CacheInvalidator.Run(ctx) })
+	}
+	workers.Wait()
 }
 
-// ProvideGinEngine provides the configured Gin engine with all routes
-func ProvideGinEngine(cfg *config.Config, userService ports.UserService, healthChecker *health.Checker) *gin.Engine {
-	// Set Gin mode based on environment
-	if cfg.App.Environment == "production" {
-		gin.SetMode(gin.ReleaseMode)
-	}
+// runSingletons runs the components that must not run on several replicas
+// at once, the scheduler and change data capture, until ctx is done
+func runSingletons(ctx context.Context, sched *scheduler.Scheduler, userCDC *UserCDC) {
+	sched.Start(ctx)
+	defer sched.Stop()
 
-	router := gin.New()
-	router.Use(gin.Recovery())
-	router.Use(gin.Logger())
-
-	// Health check routes
-	router.GET("/health/live", func(c *gin.Context) {
-		result := healthChecker.Liveness()
-		status := 200
-		if result.Status != "healthy" {
-			status = 503
-		}
-		c.JSON(status, result)
-	})
-
-	router.GET("/health/ready", func(c *gin.Context) {
-		result := healthChecker.Readiness(c.Request.Context())
-		status := 200
-		if result.Status != "healthy" {
-			status = 503
-		}
-		c.JSON(status, result)
-	})
-
-	// Register user routes
-	http.RegisterUserRoutes(router, userService)
-
-	return router
+	if userCDC != nil {
+		userCDC.Run(ctx)
+	}
+	<-ctx.Done()
 }
@@ -0,0 +1,70 @@
+package wire
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/google/wire"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	orgports "github.com/yourusername/go-scaffolding/internal/org/ports"
+	"github.com/yourusername/go-scaffolding/internal/seed"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+)
+
+// SeedSet provides the seeder of demo data, run when the API server starts
+// with --seed
+var SeedSet = wire.NewSet(
+	ProvideSeeder,
+)
+
+// ProvideSeeder provides the seeder of the fixtures configured under seed,
+// or nil when seeding is disabled. With the memory storage driver only users
+// are seeded.
+func ProvideSeeder(cfg *config.Config, userService ports.UserService, passwords ports.UserPasswords, orgService orgports.OrganizationService, log *logger.Logger) (*seed.Seeder, error) {
+	if !cfg.Seed.Enabled {
+		return nil, nil
+	}
+	if err := seed.CheckEnvironment(cfg.App.Environment); err != nil {
+		return nil, err
+	}
+
+	fixtures, err := seed.Load(cfg.Seed.Fixtures)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Storage.InMemory() {
+		log.Warn().Msg("Storage driver is memory: only users are seeded")
+		orgService = nil
+	}
+	return seed.NewSeeder(fixtures, userService, passwords, orgService), nil
+}
+
+// addSeedAPIKeys adds the API keys of the seed fixtures to those accepted
+// by the HTTP quotas and gRPC authentication when seeding is enabled.
+// Configured keys keep their names.
+func addSeedAPIKeys(cfg *config.Config) error {
+	if !cfg.Seed.Enabled {
+		return nil
+	}
+
+	fixtures, err := seed.Load(cfg.Seed.Fixtures)
+	if err != nil {
+		return fmt.Errorf("failed to load seed fixtures: %w", err)
+	}
+	cfg.HTTP.Quota.APIKeys = withAPIKeys(cfg.HTTP.Quota.APIKeys, fixtures.APIKeys)
+	cfg.GRPC.Auth.APIKeys = withAPIKeys(cfg.GRPC.Auth.APIKeys, fixtures.APIKeys)
+	return nil
+}
+
+// withAPIKeys returns keys with the added keys whose names it lacks
+func withAPIKeys(keys, added map[string]string) map[string]string {
+	if len(added) == 0 {
+		return keys
+	}
+
+	merged := make(map[string]string, len(keys)+len(added))
+	maps.Copy(merged, added)
+	maps.Copy(merged, keys)
+	return merged
+}
@@ -0,0 +1,525 @@
+package wire
+
+import (
+	"context"
+	"crypto/tls"
+	"expvar"
+	"fmt"
+	nethttp "net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	userv1 "github.com/yourusername/go-scaffolding/api/proto/user/v1"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/admin"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/cachecontrol"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/capture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/clientip"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/database"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fixture"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/grpcserver"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/health"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/idempotency"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/ipfilter"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/loadshed"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/locale"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/mtls"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/openapi"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/problem"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/quota"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/ratelimit"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/recovery"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/slowalert"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/storage"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/timeout"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tracecontext"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/transport"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/eventbus"
+	usergraphql "github.com/yourusername/go-scaffolding/internal/user/adapters/graphql"
+	usergrpc "github.com/yourusername/go-scaffolding/internal/user/adapters/grpc"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/http"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/text/language"
+	"gorm.io/gorm"
+)
+
+// ServerSet provides the HTTP and gRPC servers and the route groups they
+// serve besides the domain routes
+var ServerSet = wire.NewSet(
+	ProvideAdminRoutes,
+	ProvideGatewayRoutes,
+	ProvideGraphQLRoutes,
+	ProvideGinEngine,
+	ProvideGRPCServer,
+)
+
+// loadShedOptions converts the load shedding configuration
+func loadShedOptions(cfg config.LoadShedConfig) loadshed.Options {
+	limit := func(c config.ConcurrencyLimitConfig) loadshed.Limit {
+		return loadshed.Limit{MaxConcurrent: c.MaxConcurrent, MaxQueue: c.MaxQueue, MaxWait: c.MaxWait}
+	}
+
+	routes := make(map[string]loadshed.Limit, len(cfg.Routes))
+	for prefix, c := range cfg.Routes {
+		routes[prefix] = limit(c)
+	}
+
+	return loadshed.Options{
+		Default:    limit(cfg.Limit),
+		Routes:     routes,
+		RetryAfter: cfg.RetryAfter,
+	}
+}
+
+// cacheControlOptions parses the Cache-Control policies
+func cacheControlOptions(cfg config.CacheControlConfig) (cachecontrol.Options, error) {
+	parse := func(name, value string) (cachecontrol.Policy, error) {
+		policy, err := cachecontrol.ParsePolicy(value)
+		if err != nil {
+			return cachecontrol.Policy{}, fmt.Errorf("invalid http.cache_control.%s: %w", name, err)
+		}
+		return policy, nil
+	}
+
+	var opts cachecontrol.Options
+	var err error
+	if opts.Default, err = parse("default", cfg.Default); err != nil {
+		return opts, err
+	}
+	if opts.Mutations, err = parse("mutations", cfg.Mutations); err != nil {
+		return opts, err
+	}
+	if opts.Authenticated, err = parse("authenticated", cfg.Authenticated); err != nil {
+		return opts, err
+	}
+
+	opts.Routes = make(map[string]cachecontrol.Policy, len(cfg.Routes))
+	for prefix, value := range cfg.Routes {
+		if opts.Routes[prefix], err = parse("routes["+prefix+"]", value); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// AdminRoutes registers the operational routes under /admin on the router.
+// It is nil when admin.token is not set.
+type AdminRoutes func(router *gin.Engine)
+
+// ProvideAdminRoutes provides the admin routes: user restore and erasure,
+// the audit log, the log level, quota usage, body capture and, with a
+// database, its connection pools and credential reloads
+func ProvideAdminRoutes(cfg *config.Config, log *logger.Logger, userService ports.UserService, userAvatars ports.UserAvatars, userActivity ports.UserActivity, db *gorm.DB, replicas *database.Replicas, creds *database.Credentials, quotas *quota.Tracker, capturer *capture.Capturer) (AdminRoutes, error) {
+	if cfg.Admin.Token == "" {
+		return nil, nil
+	}
+
+	networks, err := admin.ParseNetworks(cfg.Admin.AllowedNetworks)
+	if err != nil {
+		return nil, err
+	}
+
+	var pools []database.Pool
+	if db != nil {
+		if pools, err = database.Pools(db, replicas); err != nil {
+			return nil, err
+		}
+	}
+
+	mount, err := transport.NewMounter(cfg.HTTP.Router)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(router *gin.Engine) {
+		group := router.Group(admin.Prefix, admin.Middleware(admin.Options{
+			Token:           cfg.Admin.Token,
+			AllowedNetworks: networks,
+		}, log))
+
+		admin.RegisterLogLevelRoutes(group)
+		userRoutes, mountUserRoutes := mount(group)
+		http.RegisterAdminRoutes(userRoutes, userService, userAvatars, userActivity)
+		mountUserRoutes()
+		if quotas != nil {
+			quota.RegisterAdminRoutes(group, quotas)
+		}
+		if capturer != nil {
+			capture.RegisterAdminRoutes(group, capturer)
+		}
+		if pools != nil {
+			database.RegisterAdminRoutes(group, pools, cfg.Postgres)
+		}
+
+		// Let operators apply rotated database credentials right away
+		// instead of waiting for the next reload
+		if creds != nil {
+			group.POST("/database/credentials/reload", func(c *gin.Context) {
+				rotated, err := creds.Reload(c.Request.Context())
+				if err != nil {
+					c.JSON(500, gin.H{"error": err.Error()})
+					return
+				}
+				c.JSON(200, gin.H{"rotated": rotated})
+			})
+		}
+	}, nil
+}
+
+// GatewayRoutes registers the REST API generated by grpc-gateway under /v1
+// on the router. It is nil when http.gateway.enabled is false.
+type GatewayRoutes func(router *gin.Engine)
+
+// ProvideGatewayRoutes provides the grpc-gateway routes of the user service
+// and its OpenAPI spec
+func ProvideGatewayRoutes(cfg *config.Config, userService ports.UserService) (GatewayRoutes, error) {
+	if !cfg.HTTP.Gateway.Enabled {
+		return nil, nil
+	}
+
+	gateway, err := usergrpc.NewGateway(context.Background(), userService, usergrpc.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create REST gateway: %w", err)
+	}
+
+	handlers := []gin.HandlerFunc{gin.WrapH(gateway)}
+	if cfg.HTTP.Gateway.ValidateRequests {
+		// Hold requests to the spec the gateway serves
+		validator, err := openapi.NewValidator(userv1.OpenAPI, cfg.HTTP.JSON.MaxBodyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the gateway OpenAPI spec: %w", err)
+		}
+		handlers = append([]gin.HandlerFunc{openapi.Middleware(validator)}, handlers...)
+	}
+
+	return func(router *gin.Engine) {
+		for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+			router.Handle(method, "/v1/*path", handlers...)
+		}
+	}, nil
+}
+
+// GraphQLRoutes registers the GraphQL API of the users on the router. It is
+// nil when http.graphql.enabled is false.
+type GraphQLRoutes func(router *gin.Engine)
+
+// ProvideGraphQLRoutes provides the GraphQL endpoint of the user service,
+// with introspection and the playground outside production, and
+// subscriptions fed by bus when it is not nil
+func ProvideGraphQLRoutes(cfg *config.Config, userService ports.UserService, bus *eventbus.Bus) GraphQLRoutes {
+	if !cfg.HTTP.GraphQL.Enabled {
+		return nil
+	}
+
+	development := cfg.App.Environment != "production"
+	opts := usergraphql.Options{Introspection: development}
+	if bus != nil && cfg.HTTP.GraphQL.Subscriptions.Enabled {
+		subscriptions := cfg.HTTP.GraphQL.Subscriptions
+		opts.Subscriptions = &usergraphql.SubscriptionOptions{
+			Events:         bus,
+			JWTSecret:      subscriptions.JWTSecret,
+			MaxConnections: subscriptions.MaxConnections,
+			KeepAlive:      subscriptions.KeepAlive,
+		}
+	}
+	handler := gin.WrapH(usergraphql.NewHandler(userService, opts))
+
+	return func(router *gin.Engine) {
+		router.GET(usergraphql.Path, handler)
+		router.POST(usergraphql.Path, handler)
+		if development {
+			router.GET(usergraphql.PlaygroundPath, gin.WrapH(usergraphql.NewPlaygroundHandler()))
+		}
+	}
+}
+
+// ProvideGinEngine provides the configured Gin engine with all routes
+func ProvideGinEngine(cfg *config.Config, userService ports.UserService, userImporter ports.UserImporter, userJobs ports.UserJobs, userAvatars ports.UserAvatars, userPreferences ports.UserPreferences, userActivity ports.UserActivity, userPasswords ports.UserPasswords, userStats ports.UserStatistics, domainRoutes DomainRoutes, fileStorage ports.FileStorage, objectStorage storage.ObjectStorage, idempotencyStore idempotency.Store, quotas *quota.Tracker, capturer *capture.Capturer, recorder *fixture.Recorder, clientIPs *clientip.Resolver, identities *mtls.Identities, ipFilter *ipfilter.Filter, healthChecker *health.Checker, adminRoutes AdminRoutes, gatewayRoutes GatewayRoutes, graphqlRoutes GraphQLRoutes, tenants tenancy.Resolver, bus *eventbus.Bus, tracer trace.TracerProvider, alerts *slowalert.Monitor, log *logger.Logger) (*gin.Engine, error) {
+	formats, err := http.ParseFormats(cfg.Users.DefaultResponseFormat, cfg.Users.ResponseFormats)
+	if err != nil {
+		return nil, fmt.Errorf("invalid users response formats: %w", err)
+	}
+
+	// Make the custom binding tags of every domain available before any
+	// route binds a request
+	if err := request.RegisterValidations(bindingValidations(cfg)...); err != nil {
+		return nil, err
+	}
+
+	// Set Gin mode based on environment
+	if cfg.App.Environment == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	router := gin.New()
+
+	// Gin trusts X-Forwarded-For from any client by default; only believe
+	// the configured proxies, so access logs show the real client address
+	if err := router.SetTrustedProxies(cfg.HTTP.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	router.RemoteIPHeaders = []string{clientip.ForwardedForHeader}
+
+	router.Use(gin.LoggerWithWriter(newRedactor(cfg).Writer(gin.DefaultWriter)))
+
+	// Answer panics with problem details and log them with the request;
+	// after the access log, so it records the 500
+	router.Use(recovery.Middleware(log, recovery.Options{}))
+
+	// Continue the W3C trace context of callers, for outbound calls,
+	// published events and log lines, and record the request span
+	router.Use(tracecontext.Middleware(tracer))
+
+	// Alert on routes that keep answering slowly, queueing included
+	if alerts != nil {
+		router.Use(alerts.Middleware(slowalert.Thresholds{
+			Default: cfg.Observability.Alerts.SlowRequest,
+			Routes:  cfg.Observability.Alerts.SlowRequestRoutes,
+		}))
+	}
+
+	// Answer unknown routes, unsupported methods and OPTIONS with problem details
+	problem.Register(router)
+
+	// Resolve the client address behind load balancers for the middleware
+	// and handlers below
+	router.Use(clientIPs.Middleware())
+
+	// Name the callers presenting client certificates
+	if identities != nil {
+		router.Use(mtls.Middleware(identities))
+	}
+
+	// Turn away clients outside the allowed networks before doing any work
+	if ipFilter != nil {
+		router.Use(ipFilter.Middleware())
+	}
+
+	// Shed requests beyond capacity before they reach the database
+	if cfg.HTTP.LoadShed.Enabled {
+		router.Use(loadshed.Middleware(loadShedOptions(cfg.HTTP.LoadShed)))
+	}
+
+	// Decode JSON request bodies with the configured limits
+	router.Use(request.Middleware(request.Options{
+		Strict:       cfg.HTTP.JSON.Strict,
+		MaxBodyBytes: cfg.HTTP.JSON.MaxBodyBytes,
+	}))
+
+	// Resolve the language and time zone responses are written in
+	if cfg.HTTP.Locale.Enabled {
+		languages := make([]language.Tag, len(cfg.HTTP.Locale.Languages))
+		for i, tag := range cfg.HTTP.Locale.Languages {
+			languages[i] = language.Make(tag)
+		}
+		router.Use(locale.Middleware(locale.Options{Languages: languages}))
+	}
+
+	// Capture the bodies operators turned capturing on for, including
+	// the rejections of the middleware below
+	if capturer != nil {
+		router.Use(capturer.Middleware())
+	}
+
+	// Record fixtures for cmd/replay, with the same rejections
+	if recorder != nil {
+		router.Use(recorder.Middleware())
+	}
+
+	// Resolve the tenant of each request before anything keyed by it
+	if tenants != nil {
+		router.Use(tenancy.Middleware(tenants, tenancy.Options{
+			ExemptPaths: cfg.Tenancy.ExemptPaths,
+		}))
+	}
+
+	// Count requests of API clients and tenants against their quotas
+	if quotas != nil {
+		router.Use(quota.Middleware(quotas, quota.Options{
+			APIKeys:     cfg.HTTP.Quota.APIKeys,
+			ExemptPaths: cfg.HTTP.Quota.ExemptPaths,
+		}, log))
+		quota.RegisterRoutes(router, quotas)
+	}
+
+	// Make POST requests safe to retry with an Idempotency-Key header
+	if cfg.HTTP.Idempotency.Enabled {
+		router.Use(idempotency.Middleware(idempotencyStore, idempotency.Options{
+			TTL:          cfg.HTTP.Idempotency.TTL,
+			MaxBodyBytes: cfg.HTTP.Idempotency.MaxBodyBytes,
+		}))
+	}
+
+	// Let GET and HEAD requests read from replicas; writes and the reads of
+	// other requests stay on the primary
+	router.Use(database.ReplicaReads())
+
+	// Tell clients and proxies how long responses may be reused
+	if cfg.HTTP.CacheControl.Enabled {
+		opts, err := cacheControlOptions(cfg.HTTP.CacheControl)
+		if err != nil {
+			return nil, err
+		}
+		router.Use(cachecontrol.Middleware(opts))
+	}
+
+	// Bound handler work, including database queries, with a deadline
+	router.Use(timeout.Middleware(timeout.Options{
+		Default: cfg.HTTP.Timeout.Default,
+		Routes:  cfg.HTTP.Timeout.Routes,
+	}))
+
+	// Health check routes
+	router.GET("/health/live", func(c *gin.Context) {
+		result := healthChecker.Liveness()
+		status := 200
+		if result.Status != "healthy" {
+			status = 503
+		}
+		c.JSON(status, result)
+	})
+
+	// A degraded service still takes traffic
+	router.GET("/health/ready", func(c *gin.Context) {
+		result := healthChecker.Readiness(c.Request.Context())
+		status := 200
+		if result.Status == health.StatusUnhealthy {
+			status = 503
+		}
+		c.JSON(status, result)
+	})
+
+	// Expose expvar metrics, including scheduled job counters
+	router.GET("/debug/vars", gin.WrapH(expvar.Handler()))
+
+	// Expose Prometheus metrics, including gRPC call metrics
+	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// Operational routes, behind their own authorization
+	if adminRoutes != nil {
+		adminRoutes(router)
+	}
+
+	// Serve locally stored files; other drivers hand out their own URLs
+	if local, ok := fileStorage.(*storage.LocalStorage); ok {
+		router.Static(local.BaseURL(), local.Dir())
+	}
+
+	// Serve the signed URLs of locally stored objects; S3 and MinIO serve
+	// their own
+	if local, ok := objectStorage.(*storage.LocalObjectStorage); ok {
+		objects := gin.WrapH(local.Handler())
+		router.GET(local.BaseURL()+"/*key", objects)
+		router.HEAD(local.BaseURL()+"/*key", objects)
+		router.PUT(local.BaseURL()+"/*key", objects)
+	}
+
+	// Register user routes
+	routeOptions := http.RouteOptions{
+		LegacyEmailRoute: cfg.Users.LegacyEmailRoute,
+		Formats:          formats,
+	}
+	if bus != nil && cfg.Users.Events.Stream.Enabled {
+		stream := cfg.Users.Events.Stream
+		routeOptions.EventStream = &http.EventStreamOptions{
+			Events:         bus,
+			Heartbeat:      stream.Heartbeat,
+			MaxReplay:      stream.MaxReplay,
+			MaxConnections: stream.MaxConnections,
+		}
+	}
+	if userStats != nil {
+		routeOptions.Stats = &http.StatsOptions{
+			Stats:   userStats,
+			MaxDays: cfg.Users.Stats.MaxDays,
+		}
+	}
+	if availability := cfg.Users.Availability; availability.Enabled {
+		routeOptions.Availability = &http.AvailabilityOptions{
+			Users:   userService,
+			Limiter: ratelimit.New(availability.Requests, availability.Interval, availability.Burst),
+			MaxAge:  availability.MaxAge,
+		}
+	}
+	if header := cfg.Users.LoginRisk.CountryHeader; header != "" {
+		routeOptions.Country = func(r *nethttp.Request) string {
+			return clientIPs.TrustedHeader(r, header)
+		}
+	}
+	mount, err := transport.NewMounter(cfg.HTTP.Router)
+	if err != nil {
+		return nil, err
+	}
+	userRoutes, mountUserRoutes := mount(&router.RouterGroup)
+	http.RegisterUserRoutes(userRoutes, userService, userImporter, userJobs, userAvatars, userPreferences, userActivity, userPasswords, routeOptions)
+	mountUserRoutes()
+
+	// Register the routes of the other domains
+	for _, register := range domainRoutes {
+		if register != nil {
+			register(router)
+		}
+	}
+
+	// Serve the REST API generated from the protos next to the routes above
+	if gatewayRoutes != nil {
+		gatewayRoutes(router)
+	}
+
+	// Serve the GraphQL API over the same user service
+	if graphqlRoutes != nil {
+		graphqlRoutes(router)
+	}
+
+	return router, nil
+}
+
+// ProvideGRPCServer provides the gRPC server with the standard interceptor
+// chain and the gRPC services, or nil when gRPC is disabled. It serves the
+// health service from the health checker and, outside production, server
+// reflection.
+func ProvideGRPCServer(cfg *config.Config, log *logger.Logger, healthChecker *health.Checker, userService ports.UserService, tlsConfig *tls.Config, identities *mtls.Identities) (*grpcserver.Server, error) {
+	if !cfg.GRPC.Enabled {
+		return nil, nil
+	}
+
+	var auth *grpcserver.AuthOptions
+	if cfg.GRPC.Auth.Enabled {
+		if len(cfg.GRPC.Auth.APIKeys) == 0 && cfg.GRPC.Auth.JWTSecret == "" && identities == nil {
+			return nil, fmt.Errorf("grpc auth requires grpc.auth.api_keys, grpc.auth.jwt_secret or tls.client_ca_file; set grpc.auth.enabled to false to serve without credentials")
+		}
+		auth = &grpcserver.AuthOptions{
+			ClientCertificates: identities,
+			APIKeys:            cfg.GRPC.Auth.APIKeys,
+			JWTSecret:          cfg.GRPC.Auth.JWTSecret,
+			ExemptMethods:      cfg.GRPC.Auth.ExemptMethods,
+		}
+	}
+
+	metrics, err := grpcserver.NewMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register gRPC metrics: %w", err)
+	}
+
+	server := grpcserver.New(grpcserver.Options{
+		Addr:       fmt.Sprintf(":%d", cfg.App.GRPCPort),
+		TLS:        tlsConfig,
+		Logger:     log,
+		Auth:       auth,
+		Metrics:    metrics,
+		Health:     healthChecker,
+		Reflection: cfg.App.Environment != "production",
+	})
+
+	usergrpc.RegisterUserService(server, userService, usergrpc.Options{
+		SendTimeout: cfg.GRPC.StreamSendTimeout,
+	})
+
+	return server, nil
+}
@@ -0,0 +1,336 @@
+package wire
+
+import (
+	"fmt"
+
+	"github.com/google/wire"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/cache"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/fieldcrypt"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/httpclient"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lifecycle"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/lock"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/logger"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/storage"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/tenancy"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/cached"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/emailvalidation"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/eventbus"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/http"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/jobs"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/loginrisk"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/memory"
+	userobjects "github.com/yourusername/go-scaffolding/internal/user/adapters/objects"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/postgres"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/readmodel"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/sqlc"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/stats"
+	"github.com/yourusername/go-scaffolding/internal/user/domain"
+	"github.com/yourusername/go-scaffolding/internal/user/ports"
+	"github.com/yourusername/go-scaffolding/internal/user/service"
+	"gorm.io/gorm"
+)
+
+// UserSet provides the user domain: its repository, read model and
+// services, and the adapters of shared infrastructure to its ports.
+var UserSet = wire.NewSet(
+	ProvideUserReadModel,
+	ProvideUserRepository,
+	ProvideEmailValidator,
+	ProvideUserService,
+	ProvideUserImporter,
+	ProvideJobRepository,
+	ProvideUserJobs,
+	ProvideUserAvatars,
+	ProvideUserPreferences,
+	ProvideLoginRiskEvaluator,
+	ProvideUserPasswords,
+	ProvideUserActivity,
+	ProvideUserRetention,
+	ProvideUserProjection,
+	ProvideUserStatistics,
+	ProvideUserLocker,
+	ProvideUserObjectStorage,
+)
+
+// ProvideUserReadModel provides the user read model, or nil when
+// users.read_model is disabled
+func ProvideUserReadModel(cfg *config.Config, db *gorm.DB) ports.UserReadModel {
+	if !cfg.Users.ReadModel.Enabled {
+		return nil
+	}
+	return readmodel.NewStore(db)
+}
+
+// ProvideUserRepository provides the user repository selected by the storage
+// driver and, for PostgreSQL, by users.repository. Emails and names are
+// encrypted with keys when it is not nil. Users are read through userCache
+// when it is not nil. Users are listed from readModel when it is not nil.
+// Changes are published to bus once committed when it is not nil, unless
+// change data capture publishes them.
+func ProvideUserRepository(cfg *config.Config, db *gorm.DB, keys *fieldcrypt.Keyring, userCache cache.Cache, bus *eventbus.Bus, readModel ports.UserReadModel, ids ports.IDGenerator, log *logger.Logger) (ports.UserRepository, error) {
+	repo, err := newUserRepository(cfg, db, keys, ids)
+	if err != nil {
+		return nil, err
+	}
+	if userCache != nil {
+		repo = cached.NewRepository(repo, userCache, cfg.Cache.TTL, log)
+	}
+	if readModel != nil {
+		repo = readmodel.NewRepository(repo, readModel)
+	}
+	if bus == nil || cfg.Users.Events.CDC.Enabled {
+		return repo, nil
+	}
+	return eventbus.NewRepository(repo, bus, ids), nil
+}
+
+// newUserRepository returns the user repository selected by configuration
+func newUserRepository(cfg *config.Config, db *gorm.DB, keys *fieldcrypt.Keyring, ids ports.IDGenerator) (ports.UserRepository, error) {
+	if cfg.Storage.InMemory() {
+		return memory.NewUserRepository(ids), nil
+	}
+
+	switch cfg.Users.Repository {
+	case "gorm", "":
+		if keys != nil {
+			return postgres.NewEncryptedUserRepository(db, keys, ids), nil
+		}
+		return postgres.NewUserRepository(db, ids), nil
+	case "sqlc":
+		// The sqlc queries read and compare the columns as plaintext
+		if keys != nil {
+			return nil, fmt.Errorf("users.encryption requires users.repository gorm, got sqlc")
+		}
+		// Share GORM's connection pool so both take part in the same transactions
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
+		}
+		return sqlc.NewUserRepository(sqlDB, ids), nil
+	default:
+		return nil, fmt.Errorf("unknown users repository: %q", cfg.Users.Repository)
+	}
+}
+
+// ProvideEmailValidator provides the email validation strategies
+// configured under users.email_validation, chained cheapest first, or nil
+// when only the domain's syntax rules apply
+func ProvideEmailValidator(cfg *config.Config, log *logger.Logger) (ports.EmailValidator, error) {
+	opts := cfg.Users.EmailValidation
+
+	var strict ports.EmailValidator
+	switch opts.Mode {
+	case "", "default":
+	case "strict":
+		strict = emailvalidation.NewStrict()
+	default:
+		return nil, fmt.Errorf("unknown email validation mode: %q", opts.Mode)
+	}
+
+	var mx ports.EmailValidator
+	if opts.MX.Enabled {
+		mx = emailvalidation.NewMX(emailvalidation.MXOptions{
+			Timeout:  opts.MX.Timeout,
+			FailOpen: opts.FailOpen,
+		}, nil, log)
+	}
+
+	var remote ports.EmailValidator
+	if opts.Enabled {
+		client := httpclient.New(httpclient.Options{
+			Name:    "email_validation",
+			Timeout: opts.Timeout,
+		})
+		var err error
+		remote, err = emailvalidation.New(emailvalidation.Options{
+			BaseURL:  opts.BaseURL,
+			APIKey:   opts.APIKey,
+			FailOpen: opts.FailOpen,
+		}, client, log)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return emailvalidation.Chain(strict, mx, remote), nil
+}
+
+// ProvideUserService provides the user service implementation
+func ProvideUserService(cfg *config.Config, repo ports.UserRepository, mailer ports.Mailer, clock ports.Clock, ids ports.IDGenerator, validator ports.EmailValidator) ports.UserService {
+	return service.NewUserService(repo, mailer, clock, ids, service.Options{
+		RequireEmailVerification: cfg.Users.RequireEmailVerification,
+		EmailChangeTokenTTL:      cfg.Users.EmailChangeTokenTTL,
+		EmailValidator:           validator,
+	})
+}
+
+// ProvideUserLocker adapts the locker to the user module's Locker port
+func ProvideUserLocker(locker lock.Locker) ports.Locker {
+	return locker
+}
+
+// ProvideUserObjectStorage adapts the object storage to the user module's
+// ObjectStorage port
+func ProvideUserObjectStorage(objects storage.ObjectStorage) ports.ObjectStorage {
+	return userobjects.NewStorage(objects)
+}
+
+// ProvideUserImporter provides the user CSV import service
+func ProvideUserImporter(repo ports.UserRepository, clock ports.Clock, ids ports.IDGenerator, locker ports.Locker) ports.UserImporter {
+	return service.NewImportService(repo, clock, ids, locker)
+}
+
+// ProvideJobRepository provides the store of user import and export jobs,
+// kept in memory when the storage driver is memory
+func ProvideJobRepository(cfg *config.Config, db *gorm.DB) ports.JobRepository {
+	if cfg.Storage.InMemory() {
+		return jobs.NewMemoryStore()
+	}
+	return jobs.NewStore(db)
+}
+
+// ProvideUserJobs provides the background user import and export jobs,
+// with their files in object storage. Jobs are cancelled on shutdown and
+// resumed by another instance, for the tenant that started them.
+func ProvideUserJobs(cfg *config.Config, jobRepo ports.JobRepository, repo ports.UserRepository, objects ports.ObjectStorage, clock ports.Clock, ids ports.IDGenerator, locker ports.Locker, root *lifecycle.Root, log *logger.Logger) ports.UserJobs {
+	opts := service.JobOptions{
+		Formats:    http.ExportFormats(),
+		Retention:  cfg.Users.Jobs.Retention,
+		StaleAfter: cfg.Users.Jobs.StaleAfter,
+		OnError: func(err error) {
+			log.Error().Err(err).Msg("User job failed")
+		},
+		Detach: root.Detach,
+	}
+	if cfg.Tenancy.Enabled {
+		opts.TenantContext = tenancy.WithTenant
+	}
+	return service.NewJobService(jobRepo, repo, objects, clock, ids, locker, opts)
+}
+
+// ProvideUserAvatars provides the user avatar service
+func ProvideUserAvatars(repo ports.UserRepository, fileStorage ports.FileStorage, clock ports.Clock, ids ports.IDGenerator) ports.UserAvatars {
+	return service.NewAvatarService(repo, fileStorage, clock, ids)
+}
+
+// ProvideUserPreferences provides the user preferences service with defaults from configuration
+func ProvideUserPreferences(cfg *config.Config, repo ports.UserRepository, clock ports.Clock) (ports.UserPreferences, error) {
+	defaults := cfg.Users.Preferences
+	return service.NewPreferencesService(repo, clock, domain.Preferences{
+		Locale:   defaults.Locale,
+		Timezone: defaults.Timezone,
+		Notifications: domain.NotificationPreferences{
+			Email:  defaults.Notifications.Email,
+			Digest: domain.DigestFrequency(defaults.Notifications.Digest),
+		},
+	})
+}
+
+// ProvideLoginRiskEvaluator provides the login risk rules configured under
+// users.login_risk, counted in Prometheus, or nil when they are disabled
+func ProvideLoginRiskEvaluator(cfg *config.Config) (ports.LoginRiskEvaluator, error) {
+	opts := cfg.Users.LoginRisk
+	if !opts.Enabled {
+		return nil, nil
+	}
+
+	rules, err := loginrisk.NewRules(loginrisk.Options{
+		NewCountry: loginrisk.Action(opts.NewCountry),
+		NewDevice:  loginrisk.Action(opts.NewDevice),
+		Velocity: loginrisk.VelocityOptions{
+			Window:      opts.Velocity.Window,
+			MaxFailures: opts.Velocity.MaxFailures,
+			Action:      loginrisk.Action(opts.Velocity.Action),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return loginrisk.Instrument(rules, prometheus.DefaultRegisterer)
+}
+
+// ProvideUserPasswords provides the user password service with the policy
+// from configuration. Logins are evaluated by risk when it is not nil, and
+// users are notified of suspicious ones through mailer.
+func ProvideUserPasswords(
+	cfg *config.Config,
+	userService ports.UserService,
+	repo ports.UserRepository,
+	hasher ports.PasswordHasher,
+	breaches ports.BreachedPasswords,
+	risk ports.LoginRiskEvaluator,
+	mailer ports.Mailer,
+	clock ports.Clock,
+	ids ports.IDGenerator,
+	log *logger.Logger,
+) (ports.UserPasswords, error) {
+	return service.NewPasswordService(userService, repo, hasher, breaches, clock, ids, passwordPolicy(cfg), service.LoginOptions{
+		Risk:    risk,
+		Mailer:  mailer,
+		History: cfg.Users.LoginRisk.History,
+		OnError: func(err error) {
+			log.Warn().Err(err).Msg("Login risk check failed")
+		},
+	})
+}
+
+// passwordPolicy returns the password policy of cfg
+func passwordPolicy(cfg *config.Config) domain.PasswordPolicy {
+	policy := cfg.Users.Passwords
+	return domain.PasswordPolicy{
+		MinLength:          policy.MinLength,
+		MaxLength:          policy.MaxLength,
+		RequireUpper:       policy.RequireUpper,
+		RequireLower:       policy.RequireLower,
+		RequireDigit:       policy.RequireDigit,
+		RequireSymbol:      policy.RequireSymbol,
+		RejectPersonalInfo: policy.RejectPersonalInfo,
+	}
+}
+
+// ProvideUserActivity provides the user activity feed service
+func ProvideUserActivity(repo ports.UserRepository) ports.UserActivity {
+	return service.NewActivityService(repo)
+}
+
+// ProvideUserRetention provides the soft-deleted user purge service
+func ProvideUserRetention(cfg *config.Config, repo ports.UserRepository, clock ports.Clock) ports.UserRetention {
+	return service.NewRetentionService(repo, clock, service.RetentionOptions{
+		Period:    cfg.Users.Retention.Period(),
+		BatchSize: cfg.Users.Retention.BatchSize,
+		DryRun:    cfg.Users.Retention.DryRun,
+	})
+}
+
+// ProvideUserProjection provides the projection of user events into the
+// read model, or nil when users.read_model is disabled
+func ProvideUserProjection(cfg *config.Config, repo ports.UserRepository, readModel ports.UserReadModel, bus *eventbus.Bus, clock ports.Clock, log *logger.Logger) ports.UserProjection {
+	if readModel == nil {
+		return nil
+	}
+	return service.NewProjectionService(repo, readModel, bus, clock, service.ProjectionOptions{
+		BatchSize: cfg.Users.ReadModel.BatchSize,
+		OnError: func(err error) {
+			log.Error().Err(err).Msg("Failed to project user events")
+		},
+	})
+}
+
+// ProvideUserStatistics provides the user statistics, or nil when
+// users.stats is disabled. Without a database they are counted on each
+// request instead of read from the views.
+func ProvideUserStatistics(cfg *config.Config, db *gorm.DB, repo ports.UserRepository, clock ports.Clock) ports.UserStatistics {
+	if !cfg.Users.Stats.Enabled {
+		return nil
+	}
+
+	store := stats.NewStore(db)
+	if cfg.Storage.InMemory() {
+		store = stats.NewLiveStore(repo, clock)
+	}
+	return service.NewStatsService(store, clock, service.StatsOptions{
+		RefreshInterval: cfg.Users.Stats.RefreshInterval,
+	})
+}
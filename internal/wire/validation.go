@@ -0,0 +1,14 @@
+package wire
+
+import (
+	"github.com/yourusername/go-scaffolding/internal/config"
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/request"
+	"github.com/yourusername/go-scaffolding/internal/user/adapters/http"
+)
+
+// bindingValidations are the custom binding tags request structs of any
+// domain can use, such as binding:"required,username". Domains with tags
+// of their own add them here.
+func bindingValidations(cfg *config.Config) []request.Validation {
+	return http.Validations(passwordPolicy(cfg))
+}
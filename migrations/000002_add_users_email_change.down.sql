@@ -0,0 +1,4 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_change_expires_at,
+    DROP COLUMN IF EXISTS email_change_token_hash,
+    DROP COLUMN IF EXISTS pending_email;
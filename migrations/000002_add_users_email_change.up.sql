@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN pending_email VARCHAR(254),
+    ADD COLUMN email_change_token_hash VARCHAR(64),
+    ADD COLUMN email_change_expires_at TIMESTAMP;
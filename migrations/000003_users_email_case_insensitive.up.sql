@@ -0,0 +1,18 @@
+-- Existing rows that only differ by case cannot be merged automatically.
+-- Abort so they can be resolved by hand before enforcing uniqueness.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM users GROUP BY LOWER(TRIM(email)) HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'users.email has case-insensitive duplicates; resolve them before running this migration';
+    END IF;
+END $$;
+
+-- Normalize existing data to the canonical lowercase form
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+UPDATE users SET pending_email = LOWER(TRIM(pending_email)) WHERE pending_email <> LOWER(TRIM(pending_email));
+
+-- Replace the case-sensitive constraint with a functional unique index
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX idx_users_email_lower ON users (LOWER(email));
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS user_erasures (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    erased_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_user_erasures_user_id ON user_erasures(user_id);
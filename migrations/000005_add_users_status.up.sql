@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('active', 'suspended', 'deactivated'));
+
+CREATE INDEX idx_users_status ON users(status);
@@ -0,0 +1,6 @@
+-- Usernames are optional, so existing users are valid with NULL and can
+-- claim a username later through PUT /users/:id/username
+ALTER TABLE users ADD COLUMN username VARCHAR(30);
+
+-- Usernames are stored normalized (lowercase); NULLs do not conflict
+CREATE UNIQUE INDEX idx_users_username ON users(username);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS organization_invitations (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    email VARCHAR(254) NOT NULL,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+    token_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organization_invitations_token_hash ON organization_invitations(token_hash);
+CREATE INDEX IF NOT EXISTS idx_organization_invitations_org_email ON organization_invitations(organization_id, email);
+
+CREATE TRIGGER update_organization_invitations_updated_at BEFORE UPDATE ON organization_invitations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS user_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    locale VARCHAR(35) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    email_notifications BOOLEAN NOT NULL,
+    digest VARCHAR(10) NOT NULL CHECK (digest IN ('off', 'daily', 'weekly')),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
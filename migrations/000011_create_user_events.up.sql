@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_events (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    data JSONB,
+    occurred_at TIMESTAMP NOT NULL
+);
+
+-- Serves the activity feed: a user's events, newest first
+CREATE INDEX IF NOT EXISTS idx_user_events_feed ON user_events(user_id, occurred_at DESC, id DESC);
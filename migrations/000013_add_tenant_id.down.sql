@@ -0,0 +1,20 @@
+-- Fails if keys longer than 255 characters are stored; they expire with
+-- their TTL
+ALTER TABLE idempotency_keys ALTER COLUMN key TYPE VARCHAR(255);
+
+DROP INDEX IF EXISTS idx_organizations_slug;
+CREATE UNIQUE INDEX idx_organizations_slug ON organizations (slug);
+
+DROP INDEX IF EXISTS idx_users_username;
+CREATE UNIQUE INDEX idx_users_username ON users (username);
+
+DROP INDEX IF EXISTS idx_users_email_lower;
+CREATE UNIQUE INDEX idx_users_email_lower ON users (LOWER(email));
+
+ALTER TABLE organization_invitations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE organization_members DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE organizations DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE user_events DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE user_erasures DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,23 @@
+-- Tenant of each row for multi-tenancy with a shared schema. Rows written
+-- without multi-tenancy keep the empty tenant.
+ALTER TABLE users ADD COLUMN tenant_id VARCHAR(56) NOT NULL DEFAULT '';
+ALTER TABLE user_erasures ADD COLUMN tenant_id VARCHAR(56) NOT NULL DEFAULT '';
+ALTER TABLE user_preferences ADD COLUMN tenant_id VARCHAR(56) NOT NULL DEFAULT '';
+ALTER TABLE user_events ADD COLUMN tenant_id VARCHAR(56) NOT NULL DEFAULT '';
+ALTER TABLE organizations ADD COLUMN tenant_id VARCHAR(56) NOT NULL DEFAULT '';
+ALTER TABLE organization_members ADD COLUMN tenant_id VARCHAR(56) NOT NULL DEFAULT '';
+ALTER TABLE organization_invitations ADD COLUMN tenant_id VARCHAR(56) NOT NULL DEFAULT '';
+
+-- Emails, usernames and slugs are unique per tenant; the indexes lead with
+-- tenant_id so they also serve the tenant filter
+DROP INDEX IF EXISTS idx_users_email_lower;
+CREATE UNIQUE INDEX idx_users_email_lower ON users (tenant_id, LOWER(email));
+
+DROP INDEX IF EXISTS idx_users_username;
+CREATE UNIQUE INDEX idx_users_username ON users (tenant_id, username);
+
+DROP INDEX IF EXISTS idx_organizations_slug;
+CREATE UNIQUE INDEX idx_organizations_slug ON organizations (tenant_id, slug);
+
+-- Idempotency keys are namespaced by tenant
+ALTER TABLE idempotency_keys ALTER COLUMN key TYPE VARCHAR(320);
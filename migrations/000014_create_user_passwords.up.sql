@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS user_passwords (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    hash VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tenant_id VARCHAR(56) NOT NULL DEFAULT ''
+);
@@ -0,0 +1,7 @@
+-- Fails if encrypted values are stored; decrypt them before rolling back
+DROP INDEX IF EXISTS idx_users_email_index;
+ALTER TABLE users DROP COLUMN IF EXISTS email_index;
+
+ALTER TABLE users ALTER COLUMN pending_email TYPE VARCHAR(254);
+ALTER TABLE users ALTER COLUMN name TYPE VARCHAR(255);
+ALTER TABLE users ALTER COLUMN email TYPE VARCHAR(254);
@@ -0,0 +1,10 @@
+-- Encrypted emails and names are longer than the plaintext they replace
+ALTER TABLE users ALTER COLUMN email TYPE TEXT;
+ALTER TABLE users ALTER COLUMN name TYPE TEXT;
+ALTER TABLE users ALTER COLUMN pending_email TYPE TEXT;
+
+-- Blind index of the lowercased email: an HMAC that looks up and keeps
+-- unique the emails once they are encrypted. It is NULL for rows written
+-- without encryption, which idx_users_email_lower still covers.
+ALTER TABLE users ADD COLUMN email_index VARCHAR(64);
+CREATE UNIQUE INDEX idx_users_email_index ON users (tenant_id, email_index);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS request_quotas (
+    subject VARCHAR(255) NOT NULL,
+    window_key VARCHAR(16) NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (subject, window_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_quotas_expires_at ON request_quotas(expires_at);
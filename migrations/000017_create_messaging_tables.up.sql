@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS processed_messages (
+    consumer VARCHAR(255) NOT NULL,
+    message_id VARCHAR(255) NOT NULL,
+    processed BOOLEAN NOT NULL DEFAULT FALSE,
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (consumer, message_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_processed_messages_expires_at ON processed_messages(expires_at);
+
+CREATE TABLE IF NOT EXISTS dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    consumer VARCHAR(255) NOT NULL,
+    message_id VARCHAR(255) NOT NULL,
+    topic VARCHAR(255) NOT NULL DEFAULT '',
+    payload BYTEA,
+    headers TEXT NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    published_at TIMESTAMP,
+    failed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_dead_letters_consumer ON dead_letters(consumer);
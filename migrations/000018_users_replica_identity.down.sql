@@ -0,0 +1 @@
+ALTER TABLE users REPLICA IDENTITY DEFAULT;
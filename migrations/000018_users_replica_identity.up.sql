@@ -0,0 +1,3 @@
+-- Change data capture derives the events of changes made outside the API
+-- from the old and new rows, so updates and deletes log every column
+ALTER TABLE users REPLICA IDENTITY FULL;
@@ -0,0 +1,26 @@
+-- Denormalized copy of users that listings and searches read, projected
+-- from user events
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE TABLE IF NOT EXISTS user_read_models (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(56) NOT NULL DEFAULT '',
+    email TEXT NOT NULL,
+    name TEXT NOT NULL,
+    username VARCHAR(30),
+    avatar_key VARCHAR(255),
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    deleted_at TIMESTAMP,
+    changed_at TIMESTAMP NOT NULL,
+    projected_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_read_models_listing ON user_read_models(tenant_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_read_models_deleted_at ON user_read_models(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_user_read_models_projected_at ON user_read_models(projected_at);
+
+-- Serve the case-insensitive substring searches on email and name
+CREATE INDEX IF NOT EXISTS idx_user_read_models_email_trgm ON user_read_models USING GIN (LOWER(email) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_user_read_models_name_trgm ON user_read_models USING GIN (LOWER(name) gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP MATERIALIZED VIEW IF EXISTS user_status_counts;
+DROP MATERIALIZED VIEW IF EXISTS user_signups_daily;
@@ -0,0 +1,15 @@
+-- User statistics served by GET /stats/users, refreshed by the
+-- user_stats_refresh job instead of counting users on every request
+CREATE MATERIALIZED VIEW IF NOT EXISTS user_signups_daily AS
+SELECT tenant_id, created_at::date AS day, COUNT(*)::integer AS signups
+FROM users
+GROUP BY tenant_id, created_at::date;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS user_status_counts AS
+SELECT tenant_id, status, deleted_at IS NOT NULL AS deleted, COUNT(*)::integer AS users, NOW() AS refreshed_at
+FROM users
+GROUP BY tenant_id, status, deleted_at IS NOT NULL;
+
+-- REFRESH MATERIALIZED VIEW CONCURRENTLY needs a unique index on each view
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_signups_daily ON user_signups_daily(tenant_id, day);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_status_counts ON user_status_counts(tenant_id, status, deleted);
@@ -0,0 +1,6 @@
+-- Fencing tokens of the locks taken with locks.driver postgres; the locks
+-- themselves are advisory locks
+CREATE TABLE IF NOT EXISTS lock_fences (
+    key VARCHAR(255) PRIMARY KEY,
+    token BIGINT NOT NULL
+);
@@ -0,0 +1,7 @@
+-- Leases of the leader elections held with leader.driver postgres
+CREATE TABLE IF NOT EXISTS leader_leases (
+    name VARCHAR(255) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    transitions INTEGER NOT NULL DEFAULT 0
+);
@@ -0,0 +1,23 @@
+-- Background import and export jobs of users, persisted so they survive restarts
+CREATE TABLE IF NOT EXISTS user_jobs (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(56) NOT NULL DEFAULT '',
+    kind VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    processed INTEGER NOT NULL DEFAULT 0,
+    total INTEGER NOT NULL DEFAULT 0,
+    format VARCHAR(20) NOT NULL DEFAULT '',
+    filter JSONB NOT NULL,
+    input_key VARCHAR(255) NOT NULL DEFAULT '',
+    result_key VARCHAR(255) NOT NULL DEFAULT '',
+    report JSONB,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ
+);
+
+-- Finds the stale jobs to resume
+CREATE INDEX IF NOT EXISTS idx_user_jobs_status ON user_jobs(status, updated_at);
+-- Finds the finished jobs to delete
+CREATE INDEX IF NOT EXISTS idx_user_jobs_completed_at ON user_jobs(completed_at);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS update_users_updated_at ON users;
+CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_at;
+
+DROP TABLE IF EXISTS login_events;
@@ -0,0 +1,26 @@
+-- Login attempts of users, successful or not, listed by GET /admin/users/:id/logins
+CREATE TABLE IF NOT EXISTS login_events (
+    id UUID PRIMARY KEY,
+    tenant_id VARCHAR(56) NOT NULL DEFAULT '',
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    succeeded BOOLEAN NOT NULL,
+    failure_reason VARCHAR(30) NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    -- Country of the client, as an ISO 3166-1 alpha-2 code, so the login
+    -- risk rules can tell logins from new countries
+    country VARCHAR(2) NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP NOT NULL
+);
+
+-- Serves the login history: a user's attempts, newest first
+CREATE INDEX IF NOT EXISTS idx_login_events_user ON login_events(user_id, occurred_at DESC, id DESC);
+
+ALTER TABLE users ADD COLUMN last_login_at TIMESTAMP;
+
+-- A login only sets last_login_at, which is not a change to the user, so
+-- it leaves updated_at alone
+DROP TRIGGER IF EXISTS update_users_updated_at ON users;
+CREATE TRIGGER update_users_updated_at BEFORE UPDATE ON users
+    FOR EACH ROW WHEN (OLD.last_login_at IS NOT DISTINCT FROM NEW.last_login_at)
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,222 @@
+// Package client is the Go client of the user API. It sends requests with
+// the instrumented HTTP client of the application, so calls are traced,
+// carry the request ID of their context and are retried on transient
+// failures:
+//
+//	users, err := client.New(client.Options{
+//		BaseURL: "http://users.internal:8080",
+//		Auth:    client.BearerToken(token),
+//	})
+//	user, err := users.CreateUser(ctx, client.CreateUserRequest{Email: "jane@example.com", Name: "Jane"})
+//
+// POST requests carry an Idempotency-Key, so they are retried safely too.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/yourusername/go-scaffolding/internal/infrastructure/httpclient"
+)
+
+// IdempotencyKeyHeader carries the key that lets the API replay the
+// response of a POST request instead of running it twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// AuthFunc adds credentials to every request
+type AuthFunc func(req *http.Request) error
+
+// BearerToken authenticates requests with a bearer token
+func BearerToken(token string) AuthFunc {
+	return func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+// Header authenticates requests with a header, such as an API key or the
+// tenant header
+func Header(name, value string) AuthFunc {
+	return func(req *http.Request) error {
+		req.Header.Set(name, value)
+		return nil
+	}
+}
+
+// Options configures a client
+type Options struct {
+	// BaseURL is the URL the API is served at, such as
+	// "http://users.internal:8080"
+	BaseURL string
+
+	// Auth adds credentials to every request; nil sends none
+	Auth AuthFunc
+
+	// Timeout bounds a whole call, retries included. The default of the
+	// application's HTTP client when zero.
+	Timeout time.Duration
+
+	// MaxAttempts is how many times a request is sent at most. The default
+	// of the application's HTTP client when zero; 1 disables retries.
+	MaxAttempts int
+
+	// Transport sends single attempts; http.DefaultTransport when nil
+	Transport http.RoundTripper
+}
+
+// Client calls the user API. It is safe for concurrent use.
+type Client struct {
+	baseURL *url.URL
+	auth    AuthFunc
+	http    *http.Client
+}
+
+// New creates a client for the API at opts.BaseURL
+func New(opts Options) (*Client, error) {
+	base, err := url.Parse(strings.TrimSuffix(opts.BaseURL, "/"))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("client: invalid base URL %q", opts.BaseURL)
+	}
+
+	return &Client{
+		baseURL: base,
+		auth:    opts.Auth,
+		http: httpclient.New(httpclient.Options{
+			Name:      "user_api",
+			Timeout:   opts.Timeout,
+			Retry:     httpclient.RetryPolicy{MaxAttempts: opts.MaxAttempts},
+			Transport: opts.Transport,
+		}),
+	}, nil
+}
+
+// Error is returned for responses with an error status
+type Error struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+
+	// Message describes the error
+	Message string
+
+	// Code is the machine-readable error code, such as USER_NOT_FOUND or
+	// EMAIL_TAKEN; branch on it rather than on the message
+	Code string
+
+	// Fields lists the invalid request fields of validation errors
+	Fields []FieldError
+}
+
+// FieldError describes a problem with a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	return fmt.Sprintf("user API: %d %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is a 404 response
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether err is a 409 response, such as for a taken
+// email
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
+
+// do sends a request with body encoded as JSON and decodes the response
+// into out, when both are set
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("client: encode request: %w", err)
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), payload)
+	if err != nil {
+		return fmt.Errorf("client: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if method == http.MethodPost {
+		req.Header.Set(IdempotencyKeyHeader, uuid.NewString())
+	}
+	if c.auth != nil {
+		if err := c.auth(req); err != nil {
+			return fmt.Errorf("client: authenticate request: %w", err)
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("client: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return decodeError(resp)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("client: decode response: %w", err)
+	}
+	return nil
+}
+
+// decodeError reads the error body of the handlers, {"error": ...}, or the
+// problem details of the middleware
+func decodeError(resp *http.Response) error {
+	var body struct {
+		Error  string       `json:"error"`
+		Code   string       `json:"code"`
+		Fields []FieldError `json:"fields"`
+		Title  string       `json:"title"`
+		Detail string       `json:"detail"`
+	}
+	_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body)
+
+	message := body.Error
+	if message == "" {
+		message = body.Detail
+	}
+	if message == "" {
+		message = body.Title
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+
+	return &Error{StatusCode: resp.StatusCode, Message: message, Code: body.Code, Fields: body.Fields}
+}
@@ -0,0 +1,192 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/yourusername/go-scaffolding/internal/apptest"
+	"github.com/yourusername/go-scaffolding/pkg/client"
+)
+
+// newClient returns a client for a test application
+func newClient(t *testing.T) *client.Client {
+	t.Helper()
+
+	app := apptest.StartTestApp(t, apptest.Options{})
+	c, err := client.New(client.Options{BaseURL: app.URL})
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient_Users(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	created, err := c.CreateUser(ctx, client.CreateUserRequest{Email: "jane@example.com", Name: "Jane"})
+	require.NoError(t, err)
+	assert.Equal(t, "active", created.Status)
+
+	user, err := c.GetUser(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, *created, *user)
+
+	user, err = c.LookupUser(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, user.ID)
+
+	exists, err := c.UserExists(ctx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = c.EmailExists(ctx, "Jane@Example.com")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	exists, err = c.EmailExists(ctx, "john@example.com")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	user, err = c.UpdateUser(ctx, created.ID, client.UpdateUserRequest{Name: "Jane Doe"})
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", user.Name)
+
+	user, err = c.ChangeUsername(ctx, created.ID, "jane")
+	require.NoError(t, err)
+	assert.Equal(t, "jane", user.Username)
+
+	user, err = c.SuspendUser(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "suspended", user.Status)
+
+	user, err = c.ActivateUser(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "active", user.Status)
+
+	require.NoError(t, c.DeleteUser(ctx, created.ID))
+	_, err = c.GetUser(ctx, created.ID)
+	assert.True(t, client.IsNotFound(err))
+	exists, err = c.UserExists(ctx, created.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestClient_Errors(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	_, err := c.CreateUser(ctx, client.CreateUserRequest{Email: "jane@example.com", Name: "Jane"})
+	require.NoError(t, err)
+
+	_, err = c.CreateUser(ctx, client.CreateUserRequest{Email: "jane@example.com", Name: "Jane"})
+	assert.True(t, client.IsConflict(err))
+
+	_, err = c.CreateUser(ctx, client.CreateUserRequest{Email: "not-an-email", Name: "Jane"})
+	var apiErr *client.Error
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.NotEmpty(t, apiErr.Message)
+	assert.False(t, client.IsNotFound(err))
+}
+
+func TestClient_UsersIterator(t *testing.T) {
+	c := newClient(t)
+	ctx := context.Background()
+
+	emails := []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"}
+	for _, email := range emails {
+		_, err := c.CreateUser(ctx, client.CreateUserRequest{Email: email, Name: "User"})
+		require.NoError(t, err)
+	}
+
+	var listed []string
+	for user, err := range c.Users(ctx, client.ListUsersOptions{Limit: 2}) {
+		require.NoError(t, err)
+		listed = append(listed, user.Email)
+	}
+	assert.ElementsMatch(t, emails, listed, "every page is fetched")
+
+	var first []string
+	for user, err := range c.Users(ctx, client.ListUsersOptions{Limit: 2}) {
+		require.NoError(t, err)
+		first = append(first, user.Email)
+		if len(first) == 3 {
+			break
+		}
+	}
+	assert.Len(t, first, 3, "iteration stops when the caller breaks")
+
+	page, err := c.ListUsers(ctx, client.ListUsersOptions{Email: "c@", Status: "active"})
+	require.NoError(t, err)
+	require.Len(t, page.Users, 1)
+	assert.Equal(t, "c@example.com", page.Users[0].Email)
+}
+
+func TestClient_RetriesWithAuth(t *testing.T) {
+	var mu sync.Mutex
+	var keys, tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		keys = append(keys, r.Header.Get(client.IdempotencyKeyHeader))
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		if len(keys) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(client.User{ID: "1", Email: "jane@example.com"})
+	}))
+	defer server.Close()
+
+	c, err := client.New(client.Options{BaseURL: server.URL, Auth: client.BearerToken("secret")})
+	require.NoError(t, err)
+
+	user, err := c.CreateUser(context.Background(), client.CreateUserRequest{Email: "jane@example.com", Name: "Jane"})
+	require.NoError(t, err)
+	assert.Equal(t, "1", user.ID)
+
+	require.Len(t, keys, 2, "the failed attempt is retried")
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1], "retries reuse the idempotency key")
+	assert.Equal(t, []string{"Bearer secret", "Bearer secret"}, tokens)
+}
+
+func TestClient_UsersIteratorStopsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"error":  "invalid query parameters",
+			"code":   "VALIDATION_FAILED",
+			"fields": []client.FieldError{{Field: "status", Message: "must be one of active suspended deactivated"}},
+		})
+	}))
+	defer server.Close()
+
+	c, err := client.New(client.Options{BaseURL: server.URL})
+	require.NoError(t, err)
+
+	var errs []error
+	for user, err := range c.Users(context.Background(), client.ListUsersOptions{Status: "banned"}) {
+		assert.Nil(t, user)
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+
+	var apiErr *client.Error
+	require.True(t, errors.As(errs[0], &apiErr))
+	assert.Equal(t, "invalid query parameters", apiErr.Message)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.Equal(t, "status", apiErr.Fields[0].Field)
+}
+
+func TestNew_RejectsInvalidBaseURL(t *testing.T) {
+	_, err := client.New(client.Options{BaseURL: "users.internal"})
+	assert.Error(t, err)
+}
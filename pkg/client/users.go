@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxPageSize is the largest page the API returns
+const MaxPageSize = 100
+
+// User is a user account
+type User struct {
+	ID           string     `json:"id"`
+	Email        string     `json:"email"`
+	Name         string     `json:"name"`
+	Username     string     `json:"username,omitempty"`
+	AvatarURL    string     `json:"avatar_url,omitempty"`
+	Status       string     `json:"status"`
+	PendingEmail string     `json:"pending_email,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at,omitempty"` // only listed with IncludeDeleted
+}
+
+// CreateUserRequest creates a user
+type CreateUserRequest struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// UpdateUserRequest changes a user's information
+type UpdateUserRequest struct {
+	Name string `json:"name"`
+}
+
+// ListUsersOptions filters and pages users. Zero values mean "no
+// restriction".
+type ListUsersOptions struct {
+	Email          string // email contains the value, ignoring case
+	Name           string // name contains the value, ignoring case
+	Status         string // active, suspended or deactivated
+	CreatedAfter   time.Time
+	CreatedBefore  time.Time
+	IncludeDeleted bool
+
+	// Fields lists the user fields to return, such as "email"; the others
+	// are left zero. The ID is always returned.
+	Fields []string
+
+	// Limit is the page size; the API default when zero, at most MaxPageSize
+	Limit  int
+	Offset int
+}
+
+// UserPage is one page of users
+type UserPage struct {
+	Users  []User `json:"users"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// CreateUser creates a new user
+func (c *Client) CreateUser(ctx context.Context, req CreateUserRequest) (*User, error) {
+	return c.user(ctx, http.MethodPost, "/users", nil, req)
+}
+
+// GetUser retrieves a user by ID
+func (c *Client) GetUser(ctx context.Context, id string) (*User, error) {
+	return c.user(ctx, http.MethodGet, "/users/"+url.PathEscape(id), nil, nil)
+}
+
+// LookupUser retrieves a user by email
+func (c *Client) LookupUser(ctx context.Context, email string) (*User, error) {
+	return c.user(ctx, http.MethodGet, "/users/lookup", url.Values{"email": {email}}, nil)
+}
+
+// UserExists reports whether a user has the ID, with a HEAD request
+// loading nothing
+func (c *Client) UserExists(ctx context.Context, id string) (bool, error) {
+	return c.exists(ctx, "/users/"+url.PathEscape(id), nil)
+}
+
+// EmailExists reports whether a user has the email, such as to check it is
+// available at signup
+func (c *Client) EmailExists(ctx context.Context, email string) (bool, error) {
+	return c.exists(ctx, "/users", url.Values{"email": {email}})
+}
+
+// UpdateUser updates a user's information
+func (c *Client) UpdateUser(ctx context.Context, id string, req UpdateUserRequest) (*User, error) {
+	return c.user(ctx, http.MethodPut, "/users/"+url.PathEscape(id), nil, req)
+}
+
+// ChangeEmail changes a user's email. When the API requires verification,
+// the user keeps the old email and PendingEmail is set until the change is
+// confirmed.
+func (c *Client) ChangeEmail(ctx context.Context, id, email string) (*User, error) {
+	return c.user(ctx, http.MethodPut, "/users/"+url.PathEscape(id)+"/email", nil, map[string]string{"email": email})
+}
+
+// ChangeUsername sets a user's username
+func (c *Client) ChangeUsername(ctx context.Context, id, username string) (*User, error) {
+	return c.user(ctx, http.MethodPut, "/users/"+url.PathEscape(id)+"/username", nil, map[string]string{"username": username})
+}
+
+// ActivateUser reactivates a suspended or deactivated user
+func (c *Client) ActivateUser(ctx context.Context, id string) (*User, error) {
+	return c.user(ctx, http.MethodPost, "/users/"+url.PathEscape(id)+"/activate", nil, nil)
+}
+
+// SuspendUser blocks an active user
+func (c *Client) SuspendUser(ctx context.Context, id string) (*User, error) {
+	return c.user(ctx, http.MethodPost, "/users/"+url.PathEscape(id)+"/suspend", nil, nil)
+}
+
+// DeactivateUser closes a user's account
+func (c *Client) DeactivateUser(ctx context.Context, id string) (*User, error) {
+	return c.user(ctx, http.MethodPost, "/users/"+url.PathEscape(id)+"/deactivate", nil, nil)
+}
+
+// DeleteUser soft-deletes a user
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/users/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ListUsers retrieves one page of users matching the options
+func (c *Client) ListUsers(ctx context.Context, opts ListUsersOptions) (*UserPage, error) {
+	var page UserPage
+	if err := c.do(ctx, http.MethodGet, "/users", opts.query(), nil, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// Users iterates over every user matching the options, from opts.Offset on,
+// fetching a page at a time. Iteration stops at the first error.
+func (c *Client) Users(ctx context.Context, opts ListUsersOptions) iter.Seq2[*User, error] {
+	return func(yield func(*User, error) bool) {
+		if opts.Limit <= 0 {
+			opts.Limit = MaxPageSize
+		}
+
+		for {
+			page, err := c.ListUsers(ctx, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for i := range page.Users {
+				if !yield(&page.Users[i], nil) {
+					return
+				}
+			}
+
+			if len(page.Users) < opts.Limit {
+				return
+			}
+			opts.Offset += len(page.Users)
+		}
+	}
+}
+
+// user sends a request whose response is a user
+func (c *Client) user(ctx context.Context, method, path string, query url.Values, body any) (*User, error) {
+	var user User
+	if err := c.do(ctx, method, path, query, body, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// exists sends a HEAD request, which finds the resource unless it is 404
+func (c *Client) exists(ctx context.Context, path string, query url.Values) (bool, error) {
+	err := c.do(ctx, http.MethodHead, path, query, nil, nil)
+	if IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// query encodes the options as query parameters of GET /users
+func (o ListUsersOptions) query() url.Values {
+	query := url.Values{}
+	if o.Email != "" {
+		query.Set("email", o.Email)
+	}
+	if o.Name != "" {
+		query.Set("name", o.Name)
+	}
+	if o.Status != "" {
+		query.Set("status", o.Status)
+	}
+	if !o.CreatedAfter.IsZero() {
+		query.Set("created_after", o.CreatedAfter.Format(time.RFC3339Nano))
+	}
+	if !o.CreatedBefore.IsZero() {
+		query.Set("created_before", o.CreatedBefore.Format(time.RFC3339Nano))
+	}
+	if o.IncludeDeleted {
+		query.Set("include_deleted", "true")
+	}
+	if len(o.Fields) > 0 {
+		query.Set("fields", strings.Join(o.Fields, ","))
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		query.Set("offset", strconv.Itoa(o.Offset))
+	}
+	return query
+}